@@ -135,14 +135,34 @@ func (elp *Envelope) Proto() *iotextypes.ActionCore {
 		actCore.Action = &iotextypes.ActionCore_CreateDeposit{CreateDeposit: act.Proto()}
 	case *SettleDeposit:
 		actCore.Action = &iotextypes.ActionCore_SettleDeposit{SettleDeposit: act.Proto()}
+	case *CreateWithdraw:
+		actCore.Action = &iotextypes.ActionCore_CreateWithdraw{CreateWithdraw: act.Proto()}
+	case *SettleWithdraw:
+		actCore.Action = &iotextypes.ActionCore_SettleWithdraw{SettleWithdraw: act.Proto()}
 	case *GrantReward:
 		actCore.Action = &iotextypes.ActionCore_GrantReward{GrantReward: act.Proto()}
 	case *SetReward:
 		actCore.Action = &iotextypes.ActionCore_SetReward{SetReward: act.Proto()}
+	case *SetRewardingAdmin:
+		actCore.Action = &iotextypes.ActionCore_SetRewardingAdmin{SetRewardingAdmin: act.Proto()}
 	case *ClaimFromRewardingFund:
 		actCore.Action = &iotextypes.ActionCore_ClaimFromRewardingFund{ClaimFromRewardingFund: act.Proto()}
 	case *DepositToRewardingFund:
 		actCore.Action = &iotextypes.ActionCore_DepositToRewardingFund{DepositToRewardingFund: act.Proto()}
+	case *UpdateAllowlist:
+		actCore.Action = &iotextypes.ActionCore_UpdateAllowlist{UpdateAllowlist: act.Proto()}
+	case *SetConsensusParams:
+		actCore.Action = &iotextypes.ActionCore_SetConsensusParams{SetConsensusParams: act.Proto()}
+	case *DoubleSignEvidence:
+		actCore.Action = &iotextypes.ActionCore_DoubleSignEvidence{DoubleSignEvidence: act.Proto()}
+	case *SessionEnvelope:
+		actCore.Action = &iotextypes.ActionCore_SessionEnvelope{SessionEnvelope: act.Proto()}
+	case *PauseActions:
+		actCore.Action = &iotextypes.ActionCore_PauseActions{PauseActions: act.Proto()}
+	case *DKGMessage:
+		actCore.Action = &iotextypes.ActionCore_DkgMessage{DkgMessage: act.Proto()}
+	case *RegisterBLSKey:
+		actCore.Action = &iotextypes.ActionCore_RegisterBLSKey{RegisterBLSKey: act.Proto()}
 	default:
 		log.S().Panicf("Cannot convert type of action %T.\r\n", act)
 	}
@@ -213,6 +233,18 @@ func (elp *Envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 			return err
 		}
 		elp.payload = act
+	case pbAct.GetCreateWithdraw() != nil:
+		act := &CreateWithdraw{}
+		if err := act.LoadProto(pbAct.GetCreateWithdraw()); err != nil {
+			return err
+		}
+		elp.payload = act
+	case pbAct.GetSettleWithdraw() != nil:
+		act := &SettleWithdraw{}
+		if err := act.LoadProto(pbAct.GetSettleWithdraw()); err != nil {
+			return err
+		}
+		elp.payload = act
 	case pbAct.GetGrantReward() != nil:
 		act := &GrantReward{}
 		if err := act.LoadProto(pbAct.GetGrantReward()); err != nil {
@@ -225,6 +257,12 @@ func (elp *Envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 			return err
 		}
 		elp.payload = act
+	case pbAct.GetSetRewardingAdmin() != nil:
+		act := &SetRewardingAdmin{}
+		if err := act.LoadProto(pbAct.GetSetRewardingAdmin()); err != nil {
+			return err
+		}
+		elp.payload = act
 	case pbAct.GetClaimFromRewardingFund() != nil:
 		act := &ClaimFromRewardingFund{}
 		if err := act.LoadProto(pbAct.GetClaimFromRewardingFund()); err != nil {
@@ -236,6 +274,48 @@ func (elp *Envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 		if err := act.LoadProto(pbAct.GetDepositToRewardingFund()); err != nil {
 			return err
 		}
+	case pbAct.GetUpdateAllowlist() != nil:
+		act := &UpdateAllowlist{}
+		if err := act.LoadProto(pbAct.GetUpdateAllowlist()); err != nil {
+			return err
+		}
+		elp.payload = act
+	case pbAct.GetSetConsensusParams() != nil:
+		act := &SetConsensusParams{}
+		if err := act.LoadProto(pbAct.GetSetConsensusParams()); err != nil {
+			return err
+		}
+		elp.payload = act
+	case pbAct.GetDoubleSignEvidence() != nil:
+		act := &DoubleSignEvidence{}
+		if err := act.LoadProto(pbAct.GetDoubleSignEvidence()); err != nil {
+			return err
+		}
+		elp.payload = act
+	case pbAct.GetSessionEnvelope() != nil:
+		act := &SessionEnvelope{}
+		if err := act.LoadProto(pbAct.GetSessionEnvelope()); err != nil {
+			return err
+		}
+		elp.payload = act
+	case pbAct.GetPauseActions() != nil:
+		act := &PauseActions{}
+		if err := act.LoadProto(pbAct.GetPauseActions()); err != nil {
+			return err
+		}
+		elp.payload = act
+	case pbAct.GetDkgMessage() != nil:
+		act := &DKGMessage{}
+		if err := act.LoadProto(pbAct.GetDkgMessage()); err != nil {
+			return err
+		}
+		elp.payload = act
+	case pbAct.GetRegisterBLSKey() != nil:
+		act := &RegisterBLSKey{}
+		if err := act.LoadProto(pbAct.GetRegisterBLSKey()); err != nil {
+			return err
+		}
+		elp.payload = act
 	default:
 		return errors.New("no applicable action to handle in action proto")
 	}
@@ -317,6 +397,20 @@ func Sign(act Envelope, sk keypair.PrivateKey) (SealedEnvelope, error) {
 	return sealed, nil
 }
 
+// SignWith signs the action hash with the sign function, e.g., of a remote signer holding the private key of the
+// public key
+func SignWith(act Envelope, pk keypair.PublicKey, sign func([]byte) ([]byte, error)) (SealedEnvelope, error) {
+	sealed := SealedEnvelope{Envelope: act, srcPubkey: pk}
+	hash := act.Hash()
+	sig, err := sign(hash[:])
+	if err != nil {
+		return sealed, errors.Wrapf(ErrAction, "failed to sign action hash = %x: %v", hash, err)
+	}
+	sealed.signature = sig
+	sealed.payload.SetEnvelopeContext(sealed)
+	return sealed, nil
+}
+
 // FakeSeal creates a SealedActionEnvelope without signature.
 // This method should be only used in tests.
 func FakeSeal(act Envelope, pubk keypair.PublicKey) SealedEnvelope {
@@ -26,8 +26,9 @@ var (
 type ClaimFromRewardingFund struct {
 	AbstractAction
 
-	amount *big.Int
-	data   []byte
+	amount    *big.Int
+	data      []byte
+	recipient string
 }
 
 // Amount returns the amount to claim
@@ -36,6 +37,9 @@ func (c *ClaimFromRewardingFund) Amount() *big.Int { return c.amount }
 // Data returns the additional data
 func (c *ClaimFromRewardingFund) Data() []byte { return c.data }
 
+// Recipient returns the address to receive the claimed token. Empty recipient means the claimer itself
+func (c *ClaimFromRewardingFund) Recipient() string { return c.recipient }
+
 // ByteStream returns a raw byte stream of a claim action
 func (c *ClaimFromRewardingFund) ByteStream() []byte {
 	return byteutil.Must(proto.Marshal(c.Proto()))
@@ -44,8 +48,9 @@ func (c *ClaimFromRewardingFund) ByteStream() []byte {
 // Proto converts a claim action struct to a claim action protobuf
 func (c *ClaimFromRewardingFund) Proto() *iotextypes.ClaimFromRewardingFund {
 	return &iotextypes.ClaimFromRewardingFund{
-		Amount: c.amount.Bytes(),
-		Data:   c.data,
+		Amount:    c.amount.Bytes(),
+		Data:      c.data,
+		Recipient: c.recipient,
 	}
 }
 
@@ -54,6 +59,7 @@ func (c *ClaimFromRewardingFund) LoadProto(claim *iotextypes.ClaimFromRewardingF
 	*c = ClaimFromRewardingFund{}
 	c.amount = big.NewInt(0).SetBytes(claim.Amount)
 	c.data = claim.Data
+	c.recipient = claim.Recipient
 	return nil
 }
 
@@ -93,6 +99,12 @@ func (b *ClaimFromRewardingFundBuilder) SetData(data []byte) *ClaimFromRewarding
 	return b
 }
 
+// SetRecipient sets the address to receive the claimed token
+func (b *ClaimFromRewardingFundBuilder) SetRecipient(recipient string) *ClaimFromRewardingFundBuilder {
+	b.claim.recipient = recipient
+	return b
+}
+
 // Build builds a new claim from rewarding fund action
 func (b *ClaimFromRewardingFundBuilder) Build() ClaimFromRewardingFund {
 	b.claim.AbstractAction = b.Builder.Build()
@@ -29,4 +29,10 @@ var (
 	ErrVotee = errors.New("votee is not a candidate")
 	// ErrHash indicates the error of action's hash
 	ErrHash = errors.New("invalid hash")
+	// ErrNotAllowed indicates the sender isn't allowed to send actions
+	ErrNotAllowed = errors.New("sender is not allowed")
+	// ErrFreeGas indicates the action at zero gas price isn't granted free gas
+	ErrFreeGas = errors.New("free gas isn't granted")
+	// ErrPaused indicates the actions of the type are paused
+	ErrPaused = errors.New("action type is paused")
 )
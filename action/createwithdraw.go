@@ -0,0 +1,108 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// CreateWithdrawIntrinsicGas represents the intrinsic gas for the withdraw action
+	CreateWithdrawIntrinsicGas = uint64(10000)
+)
+
+var _ hasDestination = (*CreateWithdraw)(nil)
+
+// CreateWithdraw represents the action to burn the token on sub-chain in order to withdraw it to main-chain. The
+// recipient address must be a main-chain address, but it doesn't need to be owned by the sender.
+type CreateWithdraw struct {
+	AbstractAction
+
+	amount    *big.Int
+	recipient string
+}
+
+// NewCreateWithdraw instantiates a withdraw creation to main-chain action struct
+func NewCreateWithdraw(
+	nonce uint64,
+	amount *big.Int,
+	recipient string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *CreateWithdraw {
+	return &CreateWithdraw{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		amount:    amount,
+		recipient: recipient,
+	}
+}
+
+// Amount returns the amount
+func (w *CreateWithdraw) Amount() *big.Int { return w.amount }
+
+// Recipient returns the recipient address. The recipient should be an address on the main-chain
+func (w *CreateWithdraw) Recipient() string { return w.recipient }
+
+// Destination returns the recipient address. The recipient should be an address on the main-chain
+func (w *CreateWithdraw) Destination() string { return w.Recipient() }
+
+// ByteStream returns a raw byte stream of the withdraw action
+func (w *CreateWithdraw) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(w.Proto()))
+}
+
+// Proto converts CreateWithdraw to protobuf's Action
+func (w *CreateWithdraw) Proto() *iotextypes.CreateWithdraw {
+	act := &iotextypes.CreateWithdraw{
+		Recipient: w.recipient,
+	}
+	if w.amount != nil && len(w.amount.Bytes()) > 0 {
+		act.Amount = w.amount.Bytes()
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's Action to CreateWithdraw
+func (w *CreateWithdraw) LoadProto(pbWithdraw *iotextypes.CreateWithdraw) error {
+	if pbWithdraw == nil {
+		return errors.New("empty action proto to load")
+	}
+	if w == nil {
+		return errors.New("nil action to load proto")
+	}
+	*w = CreateWithdraw{}
+
+	w.amount = big.NewInt(0)
+	w.amount.SetBytes(pbWithdraw.GetAmount())
+	w.recipient = pbWithdraw.GetRecipient()
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a create withdraw
+func (w *CreateWithdraw) IntrinsicGas() (uint64, error) { return CreateWithdrawIntrinsicGas, nil }
+
+// Cost returns the total cost of a create withdraw
+func (w *CreateWithdraw) Cost() (*big.Int, error) {
+	intrinsicGas, err := w.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the create withdraw")
+	}
+	withdrawFee := big.NewInt(0).Mul(w.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return big.NewInt(0).Add(w.Amount(), withdrawFee), nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestCreateWithdraw(t *testing.T) {
+	require := require.New(t)
+
+	recipient := testaddress.Addrinfo["alfa"].String()
+	withdraw1 := NewCreateWithdraw(1, big.NewInt(1000), recipient, 10, big.NewInt(100))
+	require.Equal(big.NewInt(1000), withdraw1.Amount())
+	require.Equal(recipient, withdraw1.Recipient())
+	require.Equal(recipient, withdraw1.Destination())
+	cost, err := withdraw1.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(1000+100*int64(CreateWithdrawIntrinsicGas)), cost)
+
+	var withdraw2 CreateWithdraw
+	require.NoError(withdraw2.LoadProto(withdraw1.Proto()))
+	require.Equal(big.NewInt(1000), withdraw2.Amount())
+	require.Equal(recipient, withdraw2.Recipient())
+	require.Error(withdraw2.LoadProto(nil))
+}
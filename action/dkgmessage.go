@@ -0,0 +1,212 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// DKGMessageKind is the kind of a DKG message, which is also the phase of the epoch the message is sent in
+type DKGMessageKind uint32
+
+const (
+	// DKGKey announces the key the shares to the delegate are encrypted with
+	DKGKey DKGMessageKind = iota
+	// DKGDeal commits to the secret polynomial of the dealer, and carries the encrypted shares to the delegates
+	DKGDeal
+	// DKGComplaint accuses the dealers whose shares to the delegate are missing or don't match their commitments
+	DKGComplaint
+	// DKGJustification reveals the shares of the dealer to the delegates complaining about it
+	DKGJustification
+	// DKGBeaconShare carries the signature of the delegate's group key share on the beacon message
+	DKGBeaconShare
+)
+
+// NumDKGPhases is the number of the phases of the distributed key generation in an epoch
+const NumDKGPhases = int(DKGBeaconShare) + 1
+
+type (
+	// DKGMessage is a message of a delegate in the distributed key generation of the beacon of the next epoch. It's
+	// signed by the delegate, and like the double sign evidence action, it's put into the block by the producer
+	DKGMessage struct {
+		AbstractAction
+
+		epoch          uint64
+		kind           DKGMessageKind
+		encryptionKey  []byte
+		commitments    [][]byte
+		shares         []DKGShare
+		accused        []string
+		signatureShare []byte
+	}
+
+	// DKGShare is the secret share of a dealer for a recipient, which is encrypted in a deal, and in plaintext in a
+	// justification
+	DKGShare struct {
+		Recipient string
+		Share     []byte
+	}
+)
+
+// Epoch returns the epoch whose delegates run the key generation
+func (m *DKGMessage) Epoch() uint64 { return m.epoch }
+
+// Kind returns the kind of the message
+func (m *DKGMessage) Kind() DKGMessageKind { return m.kind }
+
+// EncryptionKey returns the public key the shares to the delegate are encrypted with
+func (m *DKGMessage) EncryptionKey() []byte { return m.encryptionKey }
+
+// Commitments returns the commitments to the coefficients of the dealer's polynomial
+func (m *DKGMessage) Commitments() [][]byte { return m.commitments }
+
+// Shares returns the encrypted shares of a deal, or the plaintext shares of a justification
+func (m *DKGMessage) Shares() []DKGShare { return m.shares }
+
+// Accused returns the dealers accused by a complaint
+func (m *DKGMessage) Accused() []string { return m.accused }
+
+// SignatureShare returns the signature share of the beacon message
+func (m *DKGMessage) SignatureShare() []byte { return m.signatureShare }
+
+// Verify checks that only the fields of the message kind are set
+func (m *DKGMessage) Verify() error {
+	hasKey, hasCommitments, hasShares := len(m.encryptionKey) > 0, len(m.commitments) > 0, len(m.shares) > 0
+	hasAccused, hasSignature := len(m.accused) > 0, len(m.signatureShare) > 0
+	var valid bool
+	switch m.kind {
+	case DKGKey:
+		valid = hasKey && !hasCommitments && !hasShares && !hasAccused && !hasSignature
+	case DKGDeal:
+		valid = !hasKey && hasCommitments && hasShares && !hasAccused && !hasSignature
+	case DKGComplaint:
+		valid = !hasKey && !hasCommitments && !hasShares && hasAccused && !hasSignature
+	case DKGJustification:
+		valid = !hasKey && !hasCommitments && hasShares && !hasAccused && !hasSignature
+	case DKGBeaconShare:
+		valid = !hasKey && !hasCommitments && !hasShares && !hasAccused && hasSignature
+	default:
+		return errors.Errorf("unknown DKG message kind %d", m.kind)
+	}
+	if !valid {
+		return errors.Errorf("DKG message of kind %d has wrong fields set", m.kind)
+	}
+	return nil
+}
+
+// ByteStream returns a raw byte stream of a DKG message action
+func (m *DKGMessage) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(m.Proto()))
+}
+
+// Proto converts a DKG message action struct to a DKG message action protobuf
+func (m *DKGMessage) Proto() *iotextypes.DKGMessage {
+	mProto := iotextypes.DKGMessage{
+		Epoch:          m.epoch,
+		Kind:           uint32(m.kind),
+		EncryptionKey:  m.encryptionKey,
+		Commitments:    m.commitments,
+		Accused:        m.accused,
+		SignatureShare: m.signatureShare,
+	}
+	for _, share := range m.shares {
+		mProto.Shares = append(mProto.Shares, &iotextypes.DKGShare{
+			Recipient: share.Recipient,
+			Share:     share.Share,
+		})
+	}
+	return &mProto
+}
+
+// LoadProto converts a DKG message action protobuf to a DKG message action struct
+func (m *DKGMessage) LoadProto(mProto *iotextypes.DKGMessage) error {
+	*m = DKGMessage{
+		epoch:          mProto.Epoch,
+		kind:           DKGMessageKind(mProto.Kind),
+		encryptionKey:  mProto.EncryptionKey,
+		commitments:    mProto.Commitments,
+		accused:        mProto.Accused,
+		signatureShare: mProto.SignatureShare,
+	}
+	for _, share := range mProto.Shares {
+		if share == nil {
+			return errors.New("DKG message contains an empty share")
+		}
+		m.shares = append(m.shares, DKGShare{Recipient: share.Recipient, Share: share.Share})
+	}
+	return m.Verify()
+}
+
+// IntrinsicGas returns the intrinsic gas of a DKG message action, which is 0
+func (*DKGMessage) IntrinsicGas() (uint64, error) {
+	return 0, nil
+}
+
+// Cost returns the total cost of a DKG message action
+func (*DKGMessage) Cost() (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// DKGMessageBuilder is the struct to build DKGMessage
+type DKGMessageBuilder struct {
+	Builder
+	message DKGMessage
+}
+
+// SetEpoch sets the epoch whose delegates run the key generation
+func (b *DKGMessageBuilder) SetEpoch(epoch uint64) *DKGMessageBuilder {
+	b.message.epoch = epoch
+	return b
+}
+
+// SetEncryptionKey makes a key message announcing the encryption key
+func (b *DKGMessageBuilder) SetEncryptionKey(key []byte) *DKGMessageBuilder {
+	b.message.kind = DKGKey
+	b.message.encryptionKey = key
+	return b
+}
+
+// SetDeal makes a deal of the commitments and the encrypted shares
+func (b *DKGMessageBuilder) SetDeal(commitments [][]byte, shares []DKGShare) *DKGMessageBuilder {
+	b.message.kind = DKGDeal
+	b.message.commitments = commitments
+	b.message.shares = shares
+	return b
+}
+
+// SetComplaint makes a complaint accusing the dealers
+func (b *DKGMessageBuilder) SetComplaint(accused []string) *DKGMessageBuilder {
+	b.message.kind = DKGComplaint
+	b.message.accused = accused
+	return b
+}
+
+// SetJustification makes a justification revealing the shares
+func (b *DKGMessageBuilder) SetJustification(shares []DKGShare) *DKGMessageBuilder {
+	b.message.kind = DKGJustification
+	b.message.shares = shares
+	return b
+}
+
+// SetBeaconShare makes a beacon share of the signature share
+func (b *DKGMessageBuilder) SetBeaconShare(sig []byte) *DKGMessageBuilder {
+	b.message.kind = DKGBeaconShare
+	b.message.signatureShare = sig
+	return b
+}
+
+// Build builds a new DKG message action
+func (b *DKGMessageBuilder) Build() DKGMessage {
+	b.message.AbstractAction = b.Builder.Build()
+	return b.message
+}
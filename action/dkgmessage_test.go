@@ -0,0 +1,58 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDKGMessage(t *testing.T) {
+	require := require.New(t)
+
+	shares := []DKGShare{{Recipient: "io1", Share: []byte("share")}}
+	builders := []func(b *DKGMessageBuilder) *DKGMessageBuilder{
+		func(b *DKGMessageBuilder) *DKGMessageBuilder { return b.SetEncryptionKey([]byte("key")) },
+		func(b *DKGMessageBuilder) *DKGMessageBuilder { return b.SetDeal([][]byte{[]byte("c0")}, shares) },
+		func(b *DKGMessageBuilder) *DKGMessageBuilder { return b.SetComplaint([]string{"io2"}) },
+		func(b *DKGMessageBuilder) *DKGMessageBuilder { return b.SetJustification(shares) },
+		func(b *DKGMessageBuilder) *DKGMessageBuilder { return b.SetBeaconShare([]byte("sig")) },
+	}
+	require.Equal(NumDKGPhases, len(builders))
+	for i, set := range builders {
+		b := &DKGMessageBuilder{}
+		m1 := set(b.SetEpoch(3)).Build()
+		require.Equal(DKGMessageKind(i), m1.Kind())
+		require.NoError(m1.Verify())
+		cost, err := m1.Cost()
+		require.NoError(err)
+		require.Equal(big.NewInt(0), cost)
+
+		// The action survives the envelope round trip
+		eb := EnvelopeBuilder{}
+		elp := eb.SetAction(&m1).Build()
+		elp2 := Envelope{}
+		require.NoError(elp2.LoadProto(elp.Proto()))
+		m2, ok := elp2.Action().(*DKGMessage)
+		require.True(ok)
+		require.Equal(uint64(3), m2.Epoch())
+		require.Equal(m1.Proto(), m2.Proto())
+	}
+
+	// A message of one kind cannot carry the fields of another
+	b := &DKGMessageBuilder{}
+	m := b.SetDeal([][]byte{[]byte("c0")}, shares).SetComplaint([]string{"io2"}).Build()
+	require.Error(m.Verify())
+	b = &DKGMessageBuilder{}
+	m = b.SetComplaint(nil).Build()
+	require.Error(m.Verify())
+	mProto := m.Proto()
+	mProto.Kind = uint32(NumDKGPhases)
+	require.Error(m.LoadProto(mProto))
+}
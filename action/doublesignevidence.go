@@ -0,0 +1,141 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// DoubleSignEvidence is the action to report a delegate endorsing two different blocks at the same height, round and
+// topic. Like the grant reward action, it's put into the block by the producer
+type DoubleSignEvidence struct {
+	AbstractAction
+
+	first  *endorsement.Endorsement
+	second *endorsement.Endorsement
+}
+
+// First returns the first of the conflicting endorsements
+func (d *DoubleSignEvidence) First() *endorsement.Endorsement { return d.first }
+
+// Second returns the second of the conflicting endorsements
+func (d *DoubleSignEvidence) Second() *endorsement.Endorsement { return d.second }
+
+// Offender returns the address of the delegate who signed both endorsements
+func (d *DoubleSignEvidence) Offender() string { return d.first.Endorser() }
+
+// Verify checks that the endorsements are signed by the same delegate on the same height, round and topic, but
+// for different blocks. An endorsement without a block, which is made when no block is received in time, doesn't
+// count
+func (d *DoubleSignEvidence) Verify() error {
+	if d.first == nil || d.second == nil {
+		return errors.New("evidence misses an endorsement")
+	}
+	for _, en := range []*endorsement.Endorsement{d.first, d.second} {
+		if len(en.ConsensusVote().BlkHash) == 0 {
+			return errors.New("evidence contains an endorsement without block")
+		}
+		if !en.VerifySignature() {
+			return errors.New("evidence contains an endorsement with invalid signature")
+		}
+		pkHash := keypair.HashPubKey(en.EndorserPublicKey())
+		addr, err := address.FromBytes(pkHash[:])
+		if err != nil {
+			return errors.Wrap(err, "error when converting the endorser public key to address")
+		}
+		if addr.String() != en.Endorser() {
+			return errors.Errorf("endorser %s doesn't match the public key", en.Endorser())
+		}
+	}
+	if d.first.Endorser() != d.second.Endorser() {
+		return errors.Errorf("endorsements are signed by %s and %s", d.first.Endorser(), d.second.Endorser())
+	}
+	v1, v2 := d.first.ConsensusVote(), d.second.ConsensusVote()
+	if v1.Height != v2.Height || v1.Round != v2.Round || v1.Topic != v2.Topic {
+		return errors.New("endorsements are not on the same height, round and topic")
+	}
+	if bytes.Equal(v1.BlkHash, v2.BlkHash) {
+		return errors.New("endorsements are on the same block")
+	}
+	return nil
+}
+
+// ByteStream returns a raw byte stream of a double sign evidence action
+func (d *DoubleSignEvidence) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(d.Proto()))
+}
+
+// Proto converts a double sign evidence action struct to a double sign evidence action protobuf
+func (d *DoubleSignEvidence) Proto() *iotextypes.DoubleSignEvidence {
+	dProto := iotextypes.DoubleSignEvidence{}
+	if d.first != nil {
+		dProto.First = d.first.ToProtoMsg()
+	}
+	if d.second != nil {
+		dProto.Second = d.second.ToProtoMsg()
+	}
+	return &dProto
+}
+
+// LoadProto converts a double sign evidence action protobuf to a double sign evidence action struct
+func (d *DoubleSignEvidence) LoadProto(dProto *iotextypes.DoubleSignEvidence) error {
+	*d = DoubleSignEvidence{}
+	if dProto.First == nil || dProto.Second == nil {
+		return errors.New("evidence misses an endorsement")
+	}
+	d.first = &endorsement.Endorsement{}
+	if err := d.first.FromProtoMsg(dProto.First); err != nil {
+		return errors.Wrap(err, "error when loading the first endorsement")
+	}
+	d.second = &endorsement.Endorsement{}
+	if err := d.second.FromProtoMsg(dProto.Second); err != nil {
+		return errors.Wrap(err, "error when loading the second endorsement")
+	}
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a double sign evidence action, which is 0
+func (*DoubleSignEvidence) IntrinsicGas() (uint64, error) {
+	return 0, nil
+}
+
+// Cost returns the total cost of a double sign evidence action
+func (*DoubleSignEvidence) Cost() (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// DoubleSignEvidenceBuilder is the struct to build DoubleSignEvidence
+type DoubleSignEvidenceBuilder struct {
+	Builder
+	evidence DoubleSignEvidence
+}
+
+// SetEndorsements sets the conflicting endorsements
+func (b *DoubleSignEvidenceBuilder) SetEndorsements(
+	first *endorsement.Endorsement,
+	second *endorsement.Endorsement,
+) *DoubleSignEvidenceBuilder {
+	b.evidence.first = first
+	b.evidence.second = second
+	return b
+}
+
+// Build builds a new double sign evidence action
+func (b *DoubleSignEvidenceBuilder) Build() DoubleSignEvidence {
+	b.evidence.AbstractAction = b.Builder.Build()
+	return b.evidence
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestDoubleSignEvidence(t *testing.T) {
+	require := require.New(t)
+
+	endorse := func(name string, blkHash []byte, height uint64, round uint32) *endorsement.Endorsement {
+		vote := endorsement.NewConsensusVote(blkHash, height, round, endorsement.PROPOSAL)
+		key := testaddress.Keyinfo[name]
+		return endorsement.NewEndorsement(vote, key.PubKey, key.PriKey, testaddress.Addrinfo[name].String())
+	}
+	first := endorse("alfa", []byte("block1"), 10, 1)
+
+	b := DoubleSignEvidenceBuilder{}
+	d1 := b.SetEndorsements(first, endorse("alfa", []byte("block2"), 10, 1)).Build()
+	require.NoError(d1.Verify())
+	require.Equal(testaddress.Addrinfo["alfa"].String(), d1.Offender())
+	cost, err := d1.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(0), cost)
+
+	// The action survives the envelope round trip
+	eb := EnvelopeBuilder{}
+	elp := eb.SetAction(&d1).Build()
+	elp2 := Envelope{}
+	require.NoError(elp2.LoadProto(elp.Proto()))
+	d2, ok := elp2.Action().(*DoubleSignEvidence)
+	require.True(ok)
+	require.NoError(d2.Verify())
+	require.Equal(d1.Proto(), d2.Proto())
+
+	// Endorsements which don't conflict aren't evidence
+	for _, second := range []*endorsement.Endorsement{
+		endorse("alfa", []byte("block1"), 10, 1),
+		endorse("alfa", []byte("block2"), 11, 1),
+		endorse("alfa", []byte("block2"), 10, 2),
+		endorse("bravo", []byte("block2"), 10, 1),
+		endorse("alfa", nil, 10, 1),
+	} {
+		d := DoubleSignEvidenceBuilder{}
+		evidence := d.SetEndorsements(first, second).Build()
+		require.Error(evidence.Verify())
+	}
+
+	// The endorser has to match the public key
+	key := testaddress.Keyinfo["alfa"]
+	vote := endorsement.NewConsensusVote([]byte("block2"), 10, 1, endorsement.PROPOSAL)
+	forged := endorsement.NewEndorsement(vote, key.PubKey, key.PriKey, testaddress.Addrinfo["bravo"].String())
+	d3 := b.SetEndorsements(endorse("bravo", []byte("block1"), 10, 1), forged).Build()
+	require.Error(d3.Verify())
+}
@@ -20,6 +20,12 @@ const (
 	BlockReward = iota
 	// EpochReward indicates that the action is to grant epoch reward
 	EpochReward
+	// EpochRewardCap indicates that the action is to set the max epoch reward a delegate could get. It's only valid
+	// for SetReward
+	EpochRewardCap
+	// MinClaimAmount indicates that the action is to set the min amount to claim from the rewarding fund. It's only
+	// valid for SetReward
+	MinClaimAmount
 )
 
 // GrantReward is the action to grant either block or epoch reward
@@ -0,0 +1,113 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+var (
+	pauseActionsBaseGas    = uint64(10000)
+	pauseActionsGasPerType = uint64(1000)
+)
+
+// PauseActions is the governance admin action to pause the actions of certain types network-wide in an emergency.
+// The pause expires automatically after the duration, and a zero duration lifts it
+type PauseActions struct {
+	AbstractAction
+
+	actionTypes []string
+	startHeight uint64
+	duration    uint64
+}
+
+// ActionTypes returns the type names of the actions to pause, such as Execution
+func (p *PauseActions) ActionTypes() []string { return p.actionTypes }
+
+// StartHeight returns the height the pause starts from
+func (p *PauseActions) StartHeight() uint64 { return p.startHeight }
+
+// Duration returns the number of blocks the pause lasts
+func (p *PauseActions) Duration() uint64 { return p.duration }
+
+// ByteStream returns a raw byte stream of a pause actions action
+func (p *PauseActions) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(p.Proto()))
+}
+
+// Proto converts a pause actions action struct to a pause actions action protobuf
+func (p *PauseActions) Proto() *iotextypes.PauseActions {
+	return &iotextypes.PauseActions{
+		ActionTypes: p.actionTypes,
+		StartHeight: p.startHeight,
+		Duration:    p.duration,
+	}
+}
+
+// LoadProto converts a pause actions action protobuf to a pause actions action struct
+func (p *PauseActions) LoadProto(pProto *iotextypes.PauseActions) error {
+	*p = PauseActions{}
+	p.actionTypes = pProto.ActionTypes
+	p.startHeight = pProto.StartHeight
+	p.duration = pProto.Duration
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a pause actions action
+func (p *PauseActions) IntrinsicGas() (uint64, error) {
+	numTypes := uint64(len(p.actionTypes))
+	if (math.MaxUint64-pauseActionsBaseGas)/pauseActionsGasPerType < numTypes {
+		return 0, ErrOutOfGas
+	}
+	return pauseActionsBaseGas + pauseActionsGasPerType*numTypes, nil
+}
+
+// Cost returns the total cost of a pause actions action
+func (p *PauseActions) Cost() (*big.Int, error) {
+	intrinsicGas, err := p.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting intrinsic gas for the pause actions action")
+	}
+	return big.NewInt(0).Mul(p.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// PauseActionsBuilder is the struct to build PauseActions
+type PauseActionsBuilder struct {
+	Builder
+	pauseActions PauseActions
+}
+
+// SetActionTypes sets the type names of the actions to pause
+func (b *PauseActionsBuilder) SetActionTypes(types []string) *PauseActionsBuilder {
+	b.pauseActions.actionTypes = types
+	return b
+}
+
+// SetStartHeight sets the height the pause starts from
+func (b *PauseActionsBuilder) SetStartHeight(height uint64) *PauseActionsBuilder {
+	b.pauseActions.startHeight = height
+	return b
+}
+
+// SetDuration sets the number of blocks the pause lasts
+func (b *PauseActionsBuilder) SetDuration(duration uint64) *PauseActionsBuilder {
+	b.pauseActions.duration = duration
+	return b
+}
+
+// Build builds a new pause actions action
+func (b *PauseActionsBuilder) Build() PauseActions {
+	b.pauseActions.AbstractAction = b.Builder.Build()
+	return b.pauseActions
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseActions(t *testing.T) {
+	require := require.New(t)
+
+	b := PauseActionsBuilder{}
+	b.SetGasPrice(big.NewInt(2))
+	p1 := b.SetActionTypes([]string{"Execution", "Transfer"}).
+		SetStartHeight(10).
+		SetDuration(100).
+		Build()
+	p2 := PauseActions{}
+	require.NoError(p2.LoadProto(p1.Proto()))
+	require.Equal(p1.ActionTypes(), p2.ActionTypes())
+	require.Equal(uint64(10), p2.StartHeight())
+	require.Equal(uint64(100), p2.Duration())
+
+	gas, err := p1.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(pauseActionsBaseGas+2*pauseActionsGasPerType, gas)
+	cost, err := p1.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(0).SetUint64(2*gas), cost)
+
+	// The action survives the envelope round trip
+	eb := EnvelopeBuilder{}
+	elp := eb.SetNonce(1).SetAction(&p1).Build()
+	elp2 := Envelope{}
+	require.NoError(elp2.LoadProto(elp.Proto()))
+	p3, ok := elp2.Action().(*PauseActions)
+	require.True(ok)
+	require.Equal(p1.ActionTypes(), p3.ActionTypes())
+	require.Equal(p1.Duration(), p3.Duration())
+}
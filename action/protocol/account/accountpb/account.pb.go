@@ -105,24 +105,176 @@ func (m *Account) GetVotee() string {
 	return ""
 }
 
+type DustEpoch struct {
+	// epoch number
+	Epoch                uint64   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DustEpoch) Reset()         { *m = DustEpoch{} }
+func (m *DustEpoch) String() string { return proto.CompactTextString(m) }
+func (*DustEpoch) ProtoMessage()    {}
+func (*DustEpoch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_account_566f9b0b4c43d0c4, []int{1}
+}
+func (m *DustEpoch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DustEpoch.Unmarshal(m, b)
+}
+func (m *DustEpoch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DustEpoch.Marshal(b, m, deterministic)
+}
+func (dst *DustEpoch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DustEpoch.Merge(dst, src)
+}
+func (m *DustEpoch) XXX_Size() int {
+	return xxx_messageInfo_DustEpoch.Size(m)
+}
+func (m *DustEpoch) XXX_DiscardUnknown() {
+	xxx_messageInfo_DustEpoch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DustEpoch proto.InternalMessageInfo
+
+func (m *DustEpoch) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+type DustBucket struct {
+	// addresses of the dust accounts last receiving a transfer in the epoch of the bucket
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DustBucket) Reset()         { *m = DustBucket{} }
+func (m *DustBucket) String() string { return proto.CompactTextString(m) }
+func (*DustBucket) ProtoMessage()    {}
+func (*DustBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_account_566f9b0b4c43d0c4, []int{2}
+}
+func (m *DustBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DustBucket.Unmarshal(m, b)
+}
+func (m *DustBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DustBucket.Marshal(b, m, deterministic)
+}
+func (dst *DustBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DustBucket.Merge(dst, src)
+}
+func (m *DustBucket) XXX_Size() int {
+	return xxx_messageInfo_DustBucket.Size(m)
+}
+func (m *DustBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_DustBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DustBucket proto.InternalMessageInfo
+
+func (m *DustBucket) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type DustSweep struct {
+	// the last epoch whose bucket is fully swept
+	Epoch uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// the last height sweeping the buckets
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// number of the dust accounts checked at the height
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// total balance of the dust accounts deleted
+	Burnt                []byte   `protobuf:"bytes,4,opt,name=burnt,proto3" json:"burnt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DustSweep) Reset()         { *m = DustSweep{} }
+func (m *DustSweep) String() string { return proto.CompactTextString(m) }
+func (*DustSweep) ProtoMessage()    {}
+func (*DustSweep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_account_566f9b0b4c43d0c4, []int{3}
+}
+func (m *DustSweep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DustSweep.Unmarshal(m, b)
+}
+func (m *DustSweep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DustSweep.Marshal(b, m, deterministic)
+}
+func (dst *DustSweep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DustSweep.Merge(dst, src)
+}
+func (m *DustSweep) XXX_Size() int {
+	return xxx_messageInfo_DustSweep.Size(m)
+}
+func (m *DustSweep) XXX_DiscardUnknown() {
+	xxx_messageInfo_DustSweep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DustSweep proto.InternalMessageInfo
+
+func (m *DustSweep) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *DustSweep) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *DustSweep) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *DustSweep) GetBurnt() []byte {
+	if m != nil {
+		return m.Burnt
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Account)(nil), "accountpb.Account")
+	proto.RegisterType((*DustEpoch)(nil), "accountpb.DustEpoch")
+	proto.RegisterType((*DustBucket)(nil), "accountpb.DustBucket")
+	proto.RegisterType((*DustSweep)(nil), "accountpb.DustSweep")
 }
 
 func init() { proto.RegisterFile("account.proto", fileDescriptor_account_566f9b0b4c43d0c4) }
 
 var fileDescriptor_account_566f9b0b4c43d0c4 = []byte{
-	// 182 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0xcf, 0x3d, 0xae, 0xc2, 0x30,
-	0x0c, 0xc0, 0x71, 0xe5, 0xbd, 0x7e, 0x9a, 0xb2, 0x58, 0x0c, 0x11, 0x53, 0xd4, 0x29, 0x13, 0x0b,
-	0x27, 0x40, 0x2c, 0xcc, 0x59, 0x98, 0xd3, 0x34, 0x6a, 0x23, 0xa1, 0xb8, 0x6a, 0x43, 0x6f, 0xc8,
-	0xbd, 0x50, 0x53, 0x40, 0xb0, 0xf9, 0xf7, 0x1f, 0x2c, 0x1b, 0xb6, 0xda, 0x18, 0xba, 0xfb, 0x70,
-	0x18, 0x46, 0x0a, 0x84, 0xe5, 0x8b, 0x43, 0x53, 0x3f, 0x18, 0xe4, 0xa7, 0x55, 0xb8, 0x83, 0xd4,
-	0x93, 0x37, 0x96, 0x33, 0xc1, 0x64, 0xa2, 0x56, 0x20, 0x87, 0xbc, 0xd1, 0x37, 0xbd, 0xf4, 0x3f,
-	0xc1, 0x64, 0xa5, 0xde, 0x44, 0x84, 0x64, 0x24, 0x0a, 0xfc, 0x3f, 0xe6, 0x38, 0xe3, 0x1e, 0x0a,
-	0x43, 0xad, 0xbd, 0xe8, 0xa9, 0xe7, 0x49, 0xec, 0x1f, 0xa3, 0x80, 0x8d, 0x9b, 0xce, 0xda, 0xb7,
-	0xae, 0xd5, 0xc1, 0xf2, 0x54, 0x30, 0x59, 0xa8, 0xef, 0x84, 0x35, 0x54, 0x33, 0x05, 0xe7, 0xbb,
-	0xab, 0x75, 0x5d, 0x1f, 0x78, 0x16, 0x37, 0xfc, 0xb4, 0xe5, 0xca, 0x99, 0x82, 0xb5, 0x3c, 0x17,
-	0x4c, 0x96, 0x6a, 0x45, 0x93, 0xc5, 0xcf, 0x8e, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7b, 0x25,
-	0x5b, 0x42, 0xea, 0x00, 0x00, 0x00,
+	// 263 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4d, 0x4b, 0xc4, 0x30,
+	0x10, 0x86, 0xc9, 0x6e, 0x3f, 0xb6, 0xe3, 0x7a, 0x09, 0x22, 0x41, 0x3c, 0xc4, 0x9e, 0x8a, 0x07,
+	0x2f, 0xfe, 0x02, 0xbf, 0xc0, 0x73, 0x3c, 0x78, 0x4e, 0xd3, 0x61, 0x5b, 0x94, 0xa4, 0x34, 0xd3,
+	0xf5, 0x17, 0xfa, 0xbf, 0x24, 0x49, 0x77, 0x55, 0xd8, 0xdb, 0x3c, 0x0f, 0xc3, 0xcb, 0xe4, 0x0d,
+	0x9c, 0x6b, 0x63, 0xdc, 0x6c, 0xe9, 0x6e, 0x9c, 0x1c, 0x39, 0x5e, 0x2d, 0x38, 0xb6, 0xf5, 0x37,
+	0x83, 0xf2, 0x21, 0x11, 0xbf, 0x80, 0xdc, 0x3a, 0x6b, 0x50, 0x30, 0xc9, 0x9a, 0x4c, 0x25, 0xe0,
+	0x02, 0xca, 0x56, 0x7f, 0xea, 0xe0, 0x57, 0x92, 0x35, 0x5b, 0x75, 0x40, 0xce, 0x21, 0x9b, 0x9c,
+	0x23, 0xb1, 0x8e, 0x3a, 0xce, 0xfc, 0x0a, 0x36, 0xc6, 0x75, 0xf8, 0xaa, 0x7d, 0x2f, 0xb2, 0xe8,
+	0x8f, 0xcc, 0x25, 0x9c, 0x0d, 0xfe, 0x49, 0xdb, 0x6e, 0xe8, 0x34, 0xa1, 0xc8, 0x25, 0x6b, 0x36,
+	0xea, 0xaf, 0xe2, 0x35, 0x6c, 0xf7, 0x8e, 0x06, 0xbb, 0x7b, 0xc7, 0x61, 0xd7, 0x93, 0x28, 0x62,
+	0xc2, 0x3f, 0x17, 0xae, 0xdc, 0x3b, 0x42, 0x14, 0xa5, 0x64, 0x4d, 0xa5, 0x12, 0xd4, 0x37, 0x50,
+	0x3d, 0xcf, 0x9e, 0x5e, 0x46, 0x67, 0xfa, 0xb0, 0x82, 0x61, 0x38, 0x3c, 0x24, 0x42, 0x7d, 0x0b,
+	0x10, 0x56, 0x1e, 0x67, 0xf3, 0x81, 0xc4, 0xaf, 0xa1, 0xd2, 0x5d, 0x37, 0xa1, 0xf7, 0xe8, 0x05,
+	0x93, 0xeb, 0xa6, 0x52, 0xbf, 0xa2, 0xc6, 0x14, 0xf7, 0xf6, 0x85, 0x38, 0x9e, 0x8e, 0xe3, 0x97,
+	0x50, 0xf4, 0xe9, 0xca, 0x55, 0xd4, 0x0b, 0x85, 0xed, 0x58, 0x67, 0xac, 0x25, 0x53, 0xf9, 0xb1,
+	0xdb, 0x76, 0x9e, 0x2c, 0x2d, 0xa5, 0x24, 0x68, 0x8b, 0xf8, 0x1f, 0xf7, 0x3f, 0x03, 0x00, 0x21,
+	0xd9, 0x9f, 0xd4, 0xa0, 0x01, 0x00, 0x00,
 }
@@ -0,0 +1,298 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/accountpb"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var (
+	dustEpochKeyPrefix  = []byte("dustEpoch")
+	dustBucketKeyPrefix = []byte("dustBucket")
+	dustSweepKey        = []byte("dustSweep")
+)
+
+type (
+	// dustEpoch stores the epoch in which a dust account last received a transfer, or the last epoch swept
+	dustEpoch struct {
+		epoch uint64
+	}
+
+	// dustBucket stores the addresses of the dust accounts last receiving a transfer in an epoch
+	dustBucket struct {
+		addrs []string
+	}
+
+	// dustSweep stores the progress of sweeping the buckets, and the total balance burnt with the dust accounts
+	dustSweep struct {
+		epoch  uint64
+		height uint64
+		count  uint64
+		burnt  *big.Int
+	}
+)
+
+// Serialize serializes dust epoch state into bytes
+func (e dustEpoch) Serialize() ([]byte, error) {
+	return proto.Marshal(&accountpb.DustEpoch{Epoch: e.epoch})
+}
+
+// Deserialize deserializes bytes into dust epoch state
+func (e *dustEpoch) Deserialize(data []byte) error {
+	gen := accountpb.DustEpoch{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	e.epoch = gen.Epoch
+	return nil
+}
+
+// Serialize serializes dust bucket state into bytes
+func (b dustBucket) Serialize() ([]byte, error) {
+	return proto.Marshal(&accountpb.DustBucket{Addresses: b.addrs})
+}
+
+// Deserialize deserializes bytes into dust bucket state
+func (b *dustBucket) Deserialize(data []byte) error {
+	gen := accountpb.DustBucket{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	b.addrs = gen.Addresses
+	return nil
+}
+
+// Serialize serializes dust sweep state into bytes
+func (s dustSweep) Serialize() ([]byte, error) {
+	return proto.Marshal(&accountpb.DustSweep{
+		Epoch:  s.epoch,
+		Height: s.height,
+		Count:  s.count,
+		Burnt:  s.burnt.Bytes(),
+	})
+}
+
+// Deserialize deserializes bytes into dust sweep state
+func (s *dustSweep) Deserialize(data []byte) error {
+	gen := accountpb.DustSweep{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	s.epoch = gen.Epoch
+	s.height = gen.Height
+	s.count = gen.Count
+	s.burnt = new(big.Int).SetBytes(gen.Burnt)
+	return nil
+}
+
+// DustReclaimOption reclaims the dust accounts from the fork height on. An account is dust if it has never sent any
+// action, its balance is at or below the threshold, and it isn't a contract, a candidate or a voter. The dust account
+// is deleted, and its balance is burnt and added up to the total burnt, once it hasn't received any transfer for the
+// inactive epochs. It's recreated from scratch if it receives a transfer later. At most maxSweepsPerBlock accounts are
+// checked in a block, and the rest are left to the following blocks
+func DustReclaimOption(height uint64, threshold *big.Int, inactiveEpochs uint64, maxSweepsPerBlock uint64) Option {
+	return func(p *Protocol) {
+		p.dustHeight = height
+		p.dustThreshold = new(big.Int).Set(threshold)
+		p.dustInactiveEpochs = inactiveEpochs
+		p.dustMaxSweepsPerBlock = maxSweepsPerBlock
+	}
+}
+
+// isDust checks whether the account could be reclaimed
+func (p *Protocol) isDust(acct *state.Account) bool {
+	return acct.Nonce == 0 &&
+		acct.Balance.Cmp(p.dustThreshold) <= 0 &&
+		len(acct.CodeHash) == 0 &&
+		acct.Root == hash.ZeroHash256 &&
+		!acct.IsCandidate &&
+		acct.Votee == "" &&
+		acct.VotingWeight.Sign() == 0
+}
+
+func (p *Protocol) dustReclaimEnabled(height uint64) bool {
+	return p.dustHeight != 0 && height >= p.dustHeight
+}
+
+// trackDust puts the recipient of a transfer into the bucket of the current epoch, if it's dust after the transfer
+func (p *Protocol) trackDust(ctx context.Context, sm protocol.StateManager, recipient string, acct *state.Account) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	if !p.dustReclaimEnabled(raCtx.BlockHeight) || !p.isDust(acct) {
+		return nil
+	}
+	addr, err := address.FromString(recipient)
+	if err != nil {
+		return errors.Wrapf(err, "error when decoding address %s", recipient)
+	}
+	last := dustEpoch{}
+	err = p.state(sm, dustEpochKey(addr), &last)
+	switch {
+	case err == nil && last.epoch == raCtx.EpochNumber:
+		// Already in the bucket of the current epoch
+		return nil
+	case err != nil && errors.Cause(err) != state.ErrStateNotExist:
+		return err
+	}
+	if err := p.putState(sm, dustEpochKey(addr), &dustEpoch{epoch: raCtx.EpochNumber}); err != nil {
+		return err
+	}
+	bucket := dustBucket{}
+	if err := p.state(sm, dustBucketKey(raCtx.EpochNumber), &bucket); err != nil &&
+		errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	bucket.addrs = append(bucket.addrs, recipient)
+	return p.putState(sm, dustBucketKey(raCtx.EpochNumber), &bucket)
+}
+
+// DustBurnt returns the total balance burnt with the dust accounts deleted
+func (p *Protocol) DustBurnt(sm protocol.StateReader) (*big.Int, error) {
+	sweep := dustSweep{}
+	if err := p.state(sm, dustSweepKey, &sweep); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+	return sweep.burnt, nil
+}
+
+// reclaimDust sweeps the buckets of the epochs which are at least the inactive epochs ago. It runs before each action
+// is handled, and checks at most the max sweeps of accounts in a block, so that a large bucket is swept over a number
+// of blocks
+func (p *Protocol) reclaimDust(ctx context.Context, sm protocol.StateManager) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	if !p.dustReclaimEnabled(raCtx.BlockHeight) || raCtx.EpochNumber <= p.dustInactiveEpochs {
+		return nil
+	}
+	target := raCtx.EpochNumber - p.dustInactiveEpochs
+	sweep := dustSweep{}
+	if err := p.state(sm, dustSweepKey, &sweep); err != nil {
+		if errors.Cause(err) != state.ErrStateNotExist {
+			return err
+		}
+		// No account is tracked before the fork, so the buckets below the target are empty
+		sweep = dustSweep{epoch: target - 1, burnt: big.NewInt(0)}
+	}
+	if sweep.epoch >= target {
+		return nil
+	}
+	if sweep.height != raCtx.BlockHeight {
+		sweep.height = raCtx.BlockHeight
+		sweep.count = 0
+	}
+	if sweep.count >= p.dustMaxSweepsPerBlock {
+		return nil
+	}
+	for sweep.epoch < target && sweep.count < p.dustMaxSweepsPerBlock {
+		done, err := p.sweepBucket(sm, sweep.epoch+1, &sweep)
+		if err != nil {
+			return errors.Wrapf(err, "error when sweeping the dust accounts of epoch %d", sweep.epoch+1)
+		}
+		if !done {
+			break
+		}
+		sweep.epoch++
+	}
+	return p.putState(sm, dustSweepKey, &sweep)
+}
+
+// sweepBucket deletes the accounts in the bucket of the epoch, unless they have received a transfer in a later epoch,
+// or aren't dust any more. It checks the accounts up to the max sweeps in the block, keeps the rest in the bucket, and
+// returns whether the bucket is fully swept
+func (p *Protocol) sweepBucket(sm protocol.StateManager, epoch uint64, sweep *dustSweep) (bool, error) {
+	bucket := dustBucket{}
+	if err := p.state(sm, dustBucketKey(epoch), &bucket); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return true, nil
+		}
+		return false, err
+	}
+	for len(bucket.addrs) > 0 && sweep.count < p.dustMaxSweepsPerBlock {
+		addrStr := bucket.addrs[0]
+		bucket.addrs = bucket.addrs[1:]
+		sweep.count++
+		balance, err := p.sweepAccount(sm, addrStr, epoch)
+		if err != nil {
+			return false, err
+		}
+		sweep.burnt.Add(sweep.burnt, balance)
+	}
+	if len(bucket.addrs) > 0 {
+		return false, p.putState(sm, dustBucketKey(epoch), &bucket)
+	}
+	return true, p.delState(sm, dustBucketKey(epoch))
+}
+
+// sweepAccount deletes the account if it's still dust and hasn't received a transfer since the epoch, and returns the
+// balance burnt
+func (p *Protocol) sweepAccount(sm protocol.StateManager, addrStr string, epoch uint64) (*big.Int, error) {
+	addr, err := address.FromString(addrStr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when decoding address %s", addrStr)
+	}
+	last := dustEpoch{}
+	if err := p.state(sm, dustEpochKey(addr), &last); err != nil {
+		return nil, err
+	}
+	if last.epoch != epoch {
+		return big.NewInt(0), nil
+	}
+	if err := p.delState(sm, dustEpochKey(addr)); err != nil {
+		return nil, err
+	}
+	addrHash := byteutil.BytesTo20B(addr.Bytes())
+	var acct state.Account
+	if err := sm.State(addrHash, &acct); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+	if !p.isDust(&acct) {
+		return big.NewInt(0), nil
+	}
+	if err := sm.DelState(addrHash); err != nil {
+		return nil, errors.Wrapf(err, "error when deleting account %s", addrStr)
+	}
+	return acct.Balance, nil
+}
+
+func (p *Protocol) state(sm protocol.StateReader, key []byte, value interface{}) error {
+	return sm.State(hash.Hash160b(append(p.keyPrefix, key...)), value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	return sm.PutState(hash.Hash160b(append(p.keyPrefix, key...)), value)
+}
+
+func (p *Protocol) delState(sm protocol.StateManager, key []byte) error {
+	return sm.DelState(hash.Hash160b(append(p.keyPrefix, key...)))
+}
+
+func dustEpochKey(addr address.Address) []byte {
+	key := make([]byte, 0, len(dustEpochKeyPrefix)+len(addr.Bytes()))
+	key = append(key, dustEpochKeyPrefix...)
+	return append(key, addr.Bytes()...)
+}
+
+func dustBucketKey(epoch uint64) []byte {
+	key := make([]byte, 0, len(dustBucketKeyPrefix)+8)
+	key = append(key, dustBucketKeyPrefix...)
+	return append(key, byteutil.Uint64ToBytes(epoch)...)
+}
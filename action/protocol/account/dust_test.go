@@ -0,0 +1,129 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestProtocol_ReclaimDust(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	ctx := context.Background()
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+
+	// Reclaim the accounts with balance up to 5 from height 10, after 2 epochs without transfer, checking at most 2
+	// accounts in a block
+	p := NewProtocol(DustReclaimOption(10, big.NewInt(5), 2, 2))
+
+	sender := testaddress.Addrinfo["alfa"]
+	require.NoError(ws.PutState(byteutil.BytesTo20B(sender.Bytes()), &state.Account{
+		Balance:      big.NewInt(1000),
+		VotingWeight: big.NewInt(0),
+	}))
+	candidate := testaddress.Addrinfo["delta"]
+	require.NoError(ws.PutState(byteutil.BytesTo20B(candidate.Bytes()), &state.Account{
+		Balance:      big.NewInt(0),
+		VotingWeight: big.NewInt(0),
+		IsCandidate:  true,
+	}))
+
+	var nonce uint64
+	transfer := func(epoch uint64, height uint64, recipient string, amount int64) {
+		nonce++
+		tsf, err := action.NewTransfer(nonce, big.NewInt(amount), recipient, nil, uint64(10000), big.NewInt(0))
+		require.NoError(err)
+		gasLimit := testutil.TestGasLimit
+		ctx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			EpochNumber: epoch,
+			BlockHeight: height,
+			Producer:    testaddress.Addrinfo["producer"],
+			Caller:      sender,
+			GasLimit:    &gasLimit,
+		})
+		_, err = p.Handle(ctx, tsf, ws)
+		require.NoError(err)
+	}
+	recorded := func(name string) bool {
+		ok, err := util.Recorded(ws, testaddress.Addrinfo[name])
+		require.NoError(err)
+		return ok
+	}
+	burnt := func() string {
+		data, err := p.ReadState(ctx, ws, []byte("DustBurnt"))
+		require.NoError(err)
+		return string(data)
+	}
+	require.Equal("0", burnt())
+
+	// The accounts receiving transfers before the fork aren't tracked
+	transfer(1, 5, testaddress.Addrinfo["galilei"].String(), 1)
+	transfer(2, 10, testaddress.Addrinfo["bravo"].String(), 1)
+	transfer(2, 10, testaddress.Addrinfo["charlie"].String(), 6)
+	transfer(2, 11, candidate.String(), 1)
+	transfer(2, 11, testaddress.Addrinfo["foxtrot"].String(), 0)
+	transfer(3, 12, testaddress.Addrinfo["echo"].String(), 5)
+	transfer(3, 12, testaddress.Addrinfo["foxtrot"].String(), 1)
+	for _, name := range []string{"bravo", "charlie", "delta", "echo", "foxtrot", "galilei"} {
+		require.True(recorded(name))
+	}
+
+	// The dust accounts without transfer since epoch 2 are deleted in epoch 4
+	transfer(4, 13, testaddress.Addrinfo["charlie"].String(), 1)
+	require.False(recorded("bravo"))
+	require.True(recorded("charlie"))
+	require.True(recorded("delta"))
+	require.True(recorded("echo"))
+	require.True(recorded("foxtrot"))
+	require.True(recorded("galilei"))
+	require.True(recorded("alfa"))
+	require.Equal("1", burnt())
+
+	// Sweeping again in the same epoch is a no-op, and a deleted account is recreated once it receives a transfer
+	transfer(4, 13, testaddress.Addrinfo["bravo"].String(), 2)
+	bravo, err := util.LoadAccount(ws, byteutil.BytesTo20B(testaddress.Addrinfo["bravo"].Bytes()))
+	require.NoError(err)
+	require.Equal(big.NewInt(2), bravo.Balance)
+
+	// The buckets of the epochs skipped are swept together, while at most 2 accounts are checked in a block, so bravo in
+	// the bucket of epoch 4 is left to the next block
+	transfer(7, 16, testaddress.Addrinfo["charlie"].String(), 1)
+	require.False(recorded("echo"))
+	require.False(recorded("foxtrot"))
+	require.True(recorded("bravo"))
+	transfer(7, 16, testaddress.Addrinfo["charlie"].String(), 1)
+	require.True(recorded("bravo"))
+	require.Equal("7", burnt())
+	transfer(7, 17, testaddress.Addrinfo["charlie"].String(), 1)
+	require.False(recorded("bravo"))
+	require.True(recorded("charlie"))
+	require.True(recorded("delta"))
+	require.True(recorded("galilei"))
+	require.Equal("9", burnt())
+}
@@ -8,25 +8,52 @@ package account
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 )
 
 // ProtocolID is the protocol ID
 // TODO: it works only for one instance per protocol definition now
 const ProtocolID = "account"
 
-// Protocol defines the protocol of handling account
-type Protocol struct{}
+type (
+	// Protocol defines the protocol of handling account
+	Protocol struct {
+		keyPrefix []byte
+		// dustHeight is the fork height from which the dust accounts are reclaimed, 0 means never
+		dustHeight            uint64
+		dustThreshold         *big.Int
+		dustInactiveEpochs    uint64
+		dustMaxSweepsPerBlock uint64
+	}
+
+	// Option is the option to create the protocol of account
+	Option func(*Protocol)
+)
 
 // NewProtocol instantiates the protocol of account
-func NewProtocol() *Protocol { return &Protocol{} }
+func NewProtocol(opts ...Option) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	p := &Protocol{
+		keyPrefix:     h[:],
+		dustThreshold: big.NewInt(0),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
 
 // Handle handles an account
 func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
+	if err := p.reclaimDust(ctx, sm); err != nil {
+		return nil, errors.Wrap(err, "error when reclaiming dust accounts")
+	}
 	switch act := act.(type) {
 	case *action.Transfer:
 		if err := p.handleTransfer(ctx, act, sm); err != nil {
@@ -46,3 +73,17 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
 	}
 	return nil
 }
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(_ context.Context, sm protocol.StateManager, method []byte, _ ...[]byte) ([]byte, error) {
+	switch string(method) {
+	case "DustBurnt":
+		burnt, err := p.DustBurnt(sm)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(burnt.String()), nil
+	default:
+		return nil, protocol.ErrUnimplemented
+	}
+}
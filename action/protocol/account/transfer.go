@@ -112,6 +112,9 @@ func (p *Protocol) handleTransfer(ctx context.Context, act action.Action, sm pro
 	if err := util.StoreAccount(sm, tsf.Recipient(), recipient); err != nil {
 		return errors.Wrap(err, "failed to update pending account changes to trie")
 	}
+	if err := p.trackDust(ctx, sm, tsf.Recipient(), recipient); err != nil {
+		return errors.Wrapf(err, "failed to track the dust account %s", tsf.Recipient())
+	}
 	// Update recipient votes
 	if len(recipient.Votee) > 0 {
 		// recipient already voted to a different person
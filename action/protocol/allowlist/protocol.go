@@ -0,0 +1,369 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package allowlist
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/allowlist/allowlistpb"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	ProtocolID = "allowlist"
+)
+
+var (
+	adminKey       = []byte("admin")
+	entryKeyPrefix = []byte("entry")
+)
+
+type (
+	// Protocol defines the protocol of the allowlist, which only accepts the actions sent by the admin and the
+	// addresses in the allowlist. The admin manages the allowlist with the update allowlist actions. The protocol
+	// handler should run before the others, so that the actions from the other addresses are rejected before mutating
+	// any state
+	Protocol struct {
+		keyPrefix []byte
+		addr      address.Address
+		sr        protocol.StateReader
+	}
+
+	// admin stores the admin of the allowlist
+	admin struct {
+		admin address.Address
+	}
+
+	// entry stores an address in the allowlist
+	entry struct {
+		addr address.Address
+	}
+)
+
+// Serialize serializes admin state into bytes
+func (a admin) Serialize() ([]byte, error) {
+	return proto.Marshal(&allowlistpb.Admin{Admin: a.admin.Bytes()})
+}
+
+// Deserialize deserializes bytes into admin state
+func (a *admin) Deserialize(data []byte) error {
+	gen := allowlistpb.Admin{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	var err error
+	a.admin, err = address.FromBytes(gen.Admin)
+	return err
+}
+
+// Serialize serializes entry state into bytes
+func (e entry) Serialize() ([]byte, error) {
+	return proto.Marshal(&allowlistpb.Entry{Address: e.addr.Bytes()})
+}
+
+// Deserialize deserializes bytes into entry state
+func (e *entry) Deserialize(data []byte) error {
+	gen := allowlistpb.Entry{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	var err error
+	e.addr, err = address.FromBytes(gen.Address)
+	return err
+}
+
+// NewProtocol instantiates an allowlist protocol instance. The state reader is used to reject the actions from the
+// addresses not in the allowlist before they get into the actpool
+func NewProtocol(sr protocol.StateReader) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of allowlist protocol", zap.Error(err))
+	}
+	return &Protocol{
+		keyPrefix: h[:],
+		addr:      addr,
+		sr:        sr,
+	}
+}
+
+// Priority makes the protocol handle the actions before the other protocols, whatever order they're registered in
+func (p *Protocol) Priority() int { return protocol.AllowlistPriority }
+
+// Initialize initializes the allowlist protocol by setting the admin and the addresses initially in the allowlist.
+// It should only be called when creating the genesis states
+func (p *Protocol) Initialize(
+	_ context.Context,
+	sm protocol.StateManager,
+	adminAddr address.Address,
+	addrs []address.Address,
+) error {
+	if err := p.putState(sm, adminKey, &admin{admin: adminAddr}); err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := p.putState(sm, entryKey(addr), &entry{addr: addr}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Admin returns the address of the admin
+func (p *Protocol) Admin(_ context.Context, sm protocol.StateManager) (address.Address, error) {
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return nil, err
+	}
+	return a.admin, nil
+}
+
+// IsAllowed returns true if the address is allowed to send actions, which is either the admin or in the allowlist
+func (p *Protocol) IsAllowed(_ context.Context, sm protocol.StateManager, addr address.Address) (bool, error) {
+	return p.isAllowed(func(key []byte, s interface{}) error { return p.state(sm, key, s) }, addr)
+}
+
+// Allow adds the addresses to the allowlist. Only the admin could make this change
+func (p *Protocol) Allow(ctx context.Context, sm protocol.StateManager, addrs []address.Address) error {
+	if err := p.assertAdmin(ctx, sm); err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := p.putState(sm, entryKey(addr), &entry{addr: addr}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Disallow removes the addresses from the allowlist. Only the admin could make this change
+func (p *Protocol) Disallow(ctx context.Context, sm protocol.StateManager, addrs []address.Address) error {
+	if err := p.assertAdmin(ctx, sm); err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := p.deleteState(sm, entryKey(addr)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handle rejects the actions from the addresses not in the allowlist, and handles the update allowlist actions
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	// The block producer grants itself the block reward
+	if _, ok := act.(*action.GrantReward); ok {
+		return nil, nil
+	}
+	allowed, err := p.IsAllowed(ctx, sm, raCtx.Caller)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.Wrapf(action.ErrNotAllowed, "%s is not in the allowlist", raCtx.Caller.String())
+	}
+	u, ok := act.(*action.UpdateAllowlist)
+	if !ok {
+		return nil, nil
+	}
+	addrs, err := toAddresses(u.Addresses())
+	if err != nil {
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	if u.Remove() {
+		err = p.Disallow(ctx, sm, addrs)
+	} else {
+		err = p.Allow(ctx, sm, addrs)
+	}
+	if err != nil {
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	return p.settleAction(ctx, sm, 0), nil
+}
+
+// Validate validates the update allowlist actions, and rejects the actions from the addresses not in the allowlist
+// when they are added into the actpool, where the block height is unset in the context. When validating a block, the
+// allowlist is checked against the pending states while handling the actions
+func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
+	vaCtx, ok := protocol.GetValidateActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss validate action context")
+	}
+	if u, ok := act.(*action.UpdateAllowlist); ok {
+		if _, err := toAddresses(u.Addresses()); err != nil {
+			return err
+		}
+	}
+	if p.sr == nil || vaCtx.BlockHeight != 0 {
+		return nil
+	}
+	allowed, err := p.isAllowed(
+		func(key []byte, s interface{}) error {
+			return p.sr.State(hash.Hash160b(append(p.keyPrefix, key...)), s)
+		},
+		vaCtx.Caller,
+	)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.Wrapf(action.ErrNotAllowed, "%s is not in the allowlist", vaCtx.Caller.String())
+	}
+	return nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "Admin":
+		addr, err := p.Admin(ctx, sm)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(addr.String()), nil
+	case "IsAllowed":
+		if len(args) != 1 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		addr, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when decoding address %s", string(args[0]))
+		}
+		allowed, err := p.IsAllowed(ctx, sm, addr)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.FormatBool(allowed)), nil
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+// isAllowed checks the address against the allowlist read by the state function. All the addresses are allowed before
+// the allowlist is initialized in the genesis states
+func (p *Protocol) isAllowed(stateFn func([]byte, interface{}) error, addr address.Address) (bool, error) {
+	a := admin{}
+	if err := stateFn(adminKey, &a); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return true, nil
+		}
+		return false, err
+	}
+	if bytes.Equal(a.admin.Bytes(), addr.Bytes()) {
+		return true, nil
+	}
+	e := entry{}
+	if err := stateFn(entryKey(addr), &e); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *Protocol) assertAdmin(ctx context.Context, sm protocol.StateManager) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	adminAddr, err := p.Admin(ctx, sm)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(adminAddr.Bytes(), raCtx.Caller.Bytes()) {
+		return errors.Errorf("%s is not the allowlist admin", raCtx.Caller.String())
+	}
+	return nil
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func (p *Protocol) deleteState(sm protocol.StateManager, key []byte) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.DelState(keyHash)
+}
+
+func (p *Protocol) settleAction(ctx context.Context, sm protocol.StateManager, status uint64) *action.Receipt {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas)
+}
+
+func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
+	acc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if nonce > acc.Nonce {
+		acc.Nonce = nonce
+	}
+	return util.StoreAccount(sm, addr.String(), acc)
+}
+
+func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsumed uint64) *action.Receipt {
+	return &action.Receipt{
+		Status:          status,
+		ActHash:         actHash,
+		GasConsumed:     gasConsumed,
+		ContractAddress: p.addr.String(),
+	}
+}
+
+func entryKey(addr address.Address) []byte {
+	key := make([]byte, 0, len(entryKeyPrefix)+len(addr.Bytes()))
+	key = append(key, entryKeyPrefix...)
+	return append(key, addr.Bytes()...)
+}
+
+func toAddresses(addrStrs []string) ([]address.Address, error) {
+	addrs := make([]address.Address, 0, len(addrStrs))
+	for _, addrStr := range addrStrs {
+		addr, err := address.FromString(addrStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when validating address %s", addrStr)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package allowlist
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	p := NewProtocol(sf)
+
+	admin := ta.Addrinfo["producer"]
+	alfa := ta.Addrinfo["alfa"]
+	bravo := ta.Addrinfo["bravo"]
+	runCtx := func(caller address.Address) context.Context {
+		return protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight:  1,
+			Caller:       caller,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: 10000,
+			Nonce:        1,
+		})
+	}
+	validateCtx := func(caller address.Address, height uint64) context.Context {
+		return protocol.WithValidateActionsCtx(context.Background(), protocol.ValidateActionsCtx{
+			BlockHeight: height,
+			Caller:      caller,
+		})
+	}
+	tsf, err := action.NewTransfer(1, big.NewInt(1), admin.String(), nil, 0, big.NewInt(0))
+	require.NoError(err)
+
+	// All the addresses are allowed before the allowlist is initialized
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = p.Handle(runCtx(alfa), tsf, ws)
+	require.NoError(err)
+	require.NoError(p.Validate(validateCtx(alfa, 0), tsf))
+
+	require.NoError(p.Initialize(context.Background(), ws, admin, []address.Address{alfa}))
+	require.NoError(sf.Commit(ws))
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	for _, addr := range []address.Address{admin, alfa} {
+		_, err = p.Handle(runCtx(addr), tsf, ws)
+		require.NoError(err)
+		require.NoError(p.Validate(validateCtx(addr, 0), tsf))
+	}
+	_, err = p.Handle(runCtx(bravo), tsf, ws)
+	require.Equal(action.ErrNotAllowed, errors.Cause(err))
+	require.Equal(action.ErrNotAllowed, errors.Cause(p.Validate(validateCtx(bravo, 0), tsf)))
+	// The allowlist isn't checked when validating a block
+	require.NoError(p.Validate(validateCtx(bravo, 2), tsf))
+	// The block producer is able to grant the block reward
+	gb := action.GrantRewardBuilder{}
+	grant := gb.SetRewardType(action.BlockReward).Build()
+	_, err = p.Handle(runCtx(bravo), &grant, ws)
+	require.NoError(err)
+
+	// Only the admin is able to update the allowlist
+	ub := action.UpdateAllowlistBuilder{}
+	update := ub.SetAddresses([]string{bravo.String()}).Build()
+	receipt, err := p.Handle(runCtx(alfa), &update, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	receipt, err = p.Handle(runCtx(admin), &update, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	allowed, err := p.IsAllowed(context.Background(), ws, bravo)
+	require.NoError(err)
+	require.True(allowed)
+
+	update = ub.SetAddresses([]string{alfa.String()}).SetRemove(true).Build()
+	receipt, err = p.Handle(runCtx(admin), &update, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	require.NoError(sf.Commit(ws))
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	data, err := p.ReadState(context.Background(), ws, []byte("IsAllowed"), []byte(alfa.String()))
+	require.NoError(err)
+	require.Equal("false", string(data))
+	data, err = p.ReadState(context.Background(), ws, []byte("IsAllowed"), []byte(bravo.String()))
+	require.NoError(err)
+	require.Equal("true", string(data))
+	data, err = p.ReadState(context.Background(), ws, []byte("Admin"))
+	require.NoError(err)
+	require.Equal(admin.String(), string(data))
+	require.Error(p.Validate(validateCtx(alfa, 0), tsf))
+
+	// The addresses to update should be valid
+	update = ub.SetAddresses([]string{"invalid"}).Build()
+	require.Error(p.Validate(validateCtx(admin, 0), &update))
+	// The update with an invalid address fails without changing the allowlist
+	update = ub.SetAddresses([]string{"invalid", alfa.String()}).SetRemove(false).Build()
+	receipt, err = p.Handle(runCtx(admin), &update, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	allowed, err = p.IsAllowed(context.Background(), ws, alfa)
+	require.NoError(err)
+	require.False(allowed)
+
+	// The admin is always allowed, even if its address is removed from the allowlist
+	update = ub.SetAddresses([]string{admin.String()}).SetRemove(true).Build()
+	receipt, err = p.Handle(runCtx(admin), &update, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	allowed, err = p.IsAllowed(context.Background(), ws, admin)
+	require.NoError(err)
+	require.True(allowed)
+
+	_, err = p.ReadState(context.Background(), ws, []byte("IsAllowed"))
+	require.Error(err)
+	_, err = p.ReadState(context.Background(), ws, []byte("Unknown"))
+	require.Error(err)
+}
@@ -0,0 +1,123 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: blskey.proto
+
+package blskeypb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Key struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	PublicKey            []byte   `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Key) Reset()         { *m = Key{} }
+func (m *Key) String() string { return proto.CompactTextString(m) }
+func (*Key) ProtoMessage()    {}
+func (*Key) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blskey_8d1f6a2c9e4b7035, []int{0}
+}
+func (m *Key) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Key.Unmarshal(m, b)
+}
+func (m *Key) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Key.Marshal(b, m, deterministic)
+}
+func (dst *Key) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Key.Merge(dst, src)
+}
+func (m *Key) XXX_Size() int {
+	return xxx_messageInfo_Key.Size(m)
+}
+func (m *Key) XXX_DiscardUnknown() {
+	xxx_messageInfo_Key.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Key proto.InternalMessageInfo
+
+func (m *Key) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Key) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type KeyHistory struct {
+	Keys                 []*Key   `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyHistory) Reset()         { *m = KeyHistory{} }
+func (m *KeyHistory) String() string { return proto.CompactTextString(m) }
+func (*KeyHistory) ProtoMessage()    {}
+func (*KeyHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blskey_8d1f6a2c9e4b7035, []int{1}
+}
+func (m *KeyHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyHistory.Unmarshal(m, b)
+}
+func (m *KeyHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyHistory.Marshal(b, m, deterministic)
+}
+func (dst *KeyHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyHistory.Merge(dst, src)
+}
+func (m *KeyHistory) XXX_Size() int {
+	return xxx_messageInfo_KeyHistory.Size(m)
+}
+func (m *KeyHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyHistory proto.InternalMessageInfo
+
+func (m *KeyHistory) GetKeys() []*Key {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Key)(nil), "blskeypb.Key")
+	proto.RegisterType((*KeyHistory)(nil), "blskeypb.KeyHistory")
+}
+
+func init() { proto.RegisterFile("blskey.proto", fileDescriptor_blskey_8d1f6a2c9e4b7035) }
+
+var fileDescriptor_blskey_8d1f6a2c9e4b7035 = []byte{
+	// 133 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0xca, 0x29, 0xce,
+	0x4e, 0xad, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x80, 0xf0, 0x0a, 0x92, 0x94, 0xac,
+	0xb9, 0x98, 0xbd, 0x53, 0x2b, 0x85, 0xc4, 0xb8, 0xd8, 0x32, 0x52, 0x33, 0xd3, 0x33, 0x4a, 0x24,
+	0x18, 0x15, 0x18, 0x35, 0x58, 0x82, 0xa0, 0x3c, 0x21, 0x19, 0x2e, 0xce, 0x82, 0xd2, 0xa4, 0x9c,
+	0xcc, 0x64, 0xef, 0xd4, 0x4a, 0x09, 0x26, 0x05, 0x46, 0x0d, 0x9e, 0x20, 0x84, 0x80, 0x92, 0x3e,
+	0x17, 0x97, 0x77, 0x6a, 0xa5, 0x47, 0x66, 0x71, 0x49, 0x7e, 0x51, 0xa5, 0x90, 0x22, 0x17, 0x4b,
+	0x76, 0x6a, 0x65, 0xb1, 0x04, 0xa3, 0x02, 0xb3, 0x06, 0xb7, 0x11, 0xaf, 0x1e, 0xcc, 0x0e, 0x3d,
+	0xef, 0xd4, 0xca, 0x20, 0xb0, 0x54, 0x12, 0x1b, 0xd8, 0x7a, 0x63, 0xc0, 0x00, 0x51, 0x96, 0x64,
+	0x57, 0x8e, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blskey
+
+import (
+	"context"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/blskey/blskeypb"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// ProtocolID is the protocol ID
+const ProtocolID = "blskey"
+
+var keyHistoryKeyPrefix = []byte("keys")
+
+type (
+	// Protocol defines the protocol of the BLS keys of the delegates, with which their endorsements are aggregated and
+	// their VRF proofs are verified. The keys in the genesis block take effect from the start, and the ones registered
+	// with the register BLS key actions in an epoch take effect from the first height of the next epoch, so that all
+	// the delegates switch to them at the same height. Only the key in effect and the one to take effect are kept for
+	// each address
+	Protocol struct {
+		keyPrefix []byte
+		addr      address.Address
+		epochSize uint64
+	}
+
+	// key is a BLS public key taking effect from a height
+	key struct {
+		height    uint64
+		publicKey []byte
+	}
+
+	// keyHistory stores the keys of an address, sorted by the heights they take effect
+	keyHistory []key
+)
+
+// Serialize serializes key history state into bytes
+func (h keyHistory) Serialize() ([]byte, error) {
+	gen := blskeypb.KeyHistory{}
+	for _, k := range h {
+		gen.Keys = append(gen.Keys, &blskeypb.Key{Height: k.height, PublicKey: k.publicKey})
+	}
+	return proto.Marshal(&gen)
+}
+
+// Deserialize deserializes bytes into key history state
+func (h *keyHistory) Deserialize(data []byte) error {
+	gen := blskeypb.KeyHistory{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	*h = make(keyHistory, len(gen.Keys))
+	for i, keyPb := range gen.Keys {
+		(*h)[i] = key{height: keyPb.Height, publicKey: keyPb.PublicKey}
+	}
+	return nil
+}
+
+// NewProtocol instantiates a BLS key protocol instance, where an epoch consists of the blocks of the number of
+// delegates times the number of sub-epochs
+func NewProtocol(numDelegates uint64, numSubEpochs uint64) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of BLS key protocol", zap.Error(err))
+	}
+	if numDelegates == 0 || numSubEpochs == 0 || numDelegates > math.MaxUint64/numSubEpochs {
+		log.L().Panic(
+			"Invalid epoch size of BLS key protocol",
+			zap.Uint64("numDelegates", numDelegates),
+			zap.Uint64("numSubEpochs", numSubEpochs),
+		)
+	}
+	return &Protocol{
+		keyPrefix: h[:],
+		addr:      addr,
+		epochSize: numDelegates * numSubEpochs,
+	}
+}
+
+// ReadKeys reads the BLS public keys of the addresses in effect at a height from the committed states, keyed by the
+// addresses. The addresses without a key in effect are left out. It's meant for the consensus to read the keys out of
+// the handling of a block. The keys in effect at a height are registered before the height, so they could be read as
+// long as the height is above the tip of the committed states
+func ReadKeys(sr protocol.StateReader, height uint64, addrs []string) (map[string][]byte, error) {
+	keyPrefix := hash.Hash160b([]byte(ProtocolID))
+	stateFn := func(key []byte, s interface{}) error {
+		return sr.State(hash.Hash160b(append(keyPrefix[:], key...)), s)
+	}
+	keys := make(map[string][]byte, len(addrs))
+	for _, addr := range addrs {
+		pk, err := keyAt(stateFn, addr, height)
+		if err != nil {
+			return nil, err
+		}
+		if pk != nil {
+			keys[addr] = pk
+		}
+	}
+	return keys, nil
+}
+
+// Initialize initializes the BLS key protocol with the keys of the addresses, which take effect from the start. It
+// should only be called when creating the genesis states
+func (p *Protocol) Initialize(
+	_ context.Context,
+	sm protocol.StateManager,
+	addrs []address.Address,
+	publicKeys [][]byte,
+	proofs [][]byte,
+) error {
+	if len(addrs) != len(publicKeys) || len(addrs) != len(proofs) {
+		return errors.Errorf("%d addresses, %d public keys and %d proofs", len(addrs), len(publicKeys), len(proofs))
+	}
+	for i, addr := range addrs {
+		if err := verifyPossession(addr.String(), publicKeys[i], proofs[i]); err != nil {
+			return errors.Wrapf(err, "error when verifying the BLS key of %s", addr.String())
+		}
+		history := keyHistory{{height: 0, publicKey: publicKeys[i]}}
+		if err := p.putState(sm, keyHistoryKey(addr.String()), &history); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Key returns the BLS public key of the address in effect at a height, which is nil if there is none
+func (p *Protocol) Key(_ context.Context, sm protocol.StateManager, addr string, height uint64) ([]byte, error) {
+	return keyAt(func(key []byte, s interface{}) error { return p.state(sm, key, s) }, addr, height)
+}
+
+// Register registers the BLS public key of the caller taking effect from the first height of the next epoch, which
+// replaces the one registered earlier in the same epoch
+func (p *Protocol) Register(ctx context.Context, sm protocol.StateManager, publicKey []byte, proof []byte) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	caller := raCtx.Caller.String()
+	if err := verifyPossession(caller, publicKey, proof); err != nil {
+		return err
+	}
+	if raCtx.BlockHeight == 0 {
+		return errors.New("BLS keys could not be registered in the genesis block")
+	}
+	epochStart := (raCtx.BlockHeight-1)/p.epochSize*p.epochSize + 1
+	if epochStart > math.MaxUint64-p.epochSize {
+		return errors.Errorf("next epoch of height %d overflows", raCtx.BlockHeight)
+	}
+	height := epochStart + p.epochSize
+	history := keyHistory{}
+	if err := p.state(sm, keyHistoryKey(caller), &history); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	history = history.prune(raCtx.BlockHeight)
+	if n := len(history); n > 0 && history[n-1].height == height {
+		history[n-1].publicKey = publicKey
+	} else {
+		history = append(history, key{height: height, publicKey: publicKey})
+	}
+	return p.putState(sm, keyHistoryKey(caller), &history)
+}
+
+// Handle handles the register BLS key actions
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	r, ok := act.(*action.RegisterBLSKey)
+	if !ok {
+		return nil, nil
+	}
+	if err := p.Register(ctx, sm, r.PublicKey(), r.Proof()); err != nil {
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	return p.settleAction(ctx, sm, 0), nil
+}
+
+// Validate validates the register BLS key actions
+func (p *Protocol) Validate(_ context.Context, act action.Action) error {
+	r, ok := act.(*action.RegisterBLSKey)
+	if !ok {
+		return nil
+	}
+	return validateKey(r.PublicKey(), r.Proof())
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "Key":
+		if len(args) != 2 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		height, err := strconv.ParseUint(string(args[1]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when decoding height %s", string(args[1]))
+		}
+		pk, err := p.Key(ctx, sm, string(args[0]), height)
+		if err != nil {
+			return nil, err
+		}
+		if pk == nil {
+			return nil, errors.Wrapf(state.ErrStateNotExist, "no BLS key of %s at height %d", string(args[0]), height)
+		}
+		return []byte(hex.EncodeToString(pk)), nil
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+// prune drops the keys superseded by the one in effect at the height
+func (h keyHistory) prune(height uint64) keyHistory {
+	for i := len(h) - 1; i > 0; i-- {
+		if h[i].height <= height {
+			return h[i:]
+		}
+	}
+	return h
+}
+
+// keyAt reads the key of the address in effect at a height from the states read by the state function
+func keyAt(stateFn func([]byte, interface{}) error, addr string, height uint64) ([]byte, error) {
+	history := keyHistory{}
+	if err := stateFn(keyHistoryKey(addr), &history); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].height <= height {
+			return history[i].publicKey, nil
+		}
+	}
+	return nil, nil
+}
+
+// validateKey checks the public key is on the curve, and the proof is of the right size
+func validateKey(publicKey []byte, proof []byte) error {
+	if len(publicKey) != crypto.BLSPublicKeyLength {
+		return errors.Errorf("BLS public key of %d bytes", len(publicKey))
+	}
+	if len(proof) != crypto.BLSSignatureLength {
+		return errors.Errorf("proof of possession of %d bytes", len(proof))
+	}
+	return errors.Wrap(crypto.BLS.PkValidation(publicKey), "invalid BLS public key")
+}
+
+// verifyPossession verifies the proof of possession of the private key of the sender's public key
+func verifyPossession(sender string, publicKey []byte, proof []byte) error {
+	if err := validateKey(publicKey, proof); err != nil {
+		return err
+	}
+	if err := crypto.BLS.Verify(publicKey, action.BLSPossessionMessage(sender, publicKey), proof); err != nil {
+		return errors.Wrap(err, "invalid proof of possession")
+	}
+	return nil
+}
+
+func keyHistoryKey(addr string) []byte {
+	return append(append([]byte{}, keyHistoryKeyPrefix...), addr...)
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func (p *Protocol) settleAction(ctx context.Context, sm protocol.StateManager, status uint64) *action.Receipt {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas)
+}
+
+func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
+	acc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if nonce > acc.Nonce {
+		acc.Nonce = nonce
+	}
+	return util.StoreAccount(sm, addr.String(), acc)
+}
+
+func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsumed uint64) *action.Receipt {
+	return &action.Receipt{
+		Status:          status,
+		ActHash:         actHash,
+		GasConsumed:     gasConsumed,
+		ContractAddress: p.addr.String(),
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blskey
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	// An epoch consists of 4 blocks
+	p := NewProtocol(4, 1)
+
+	alfa := ta.Addrinfo["alfa"].String()
+	bravo := ta.Addrinfo["bravo"].String()
+	runCtx := func(caller string, height uint64) context.Context {
+		addr, err := address.FromString(caller)
+		require.NoError(err)
+		return protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight:  height,
+			Caller:       addr,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: 10000,
+			Nonce:        1,
+		})
+	}
+	newKey := func(sender string) ([]byte, []byte) {
+		sk := crypto.DKG.SkGeneration()
+		pk, err := crypto.BLS.NewPubKey(sk)
+		require.NoError(err)
+		_, proof, err := crypto.BLS.Sign(sk, action.BLSPossessionMessage(sender, pk))
+		require.NoError(err)
+		return pk, proof
+	}
+	register := func(pk []byte, proof []byte) *action.RegisterBLSKey {
+		rb := action.RegisterBLSKeyBuilder{}
+		r := rb.SetPublicKey(pk, proof).Build()
+		return &r
+	}
+
+	// The genesis keys take effect from the start
+	alfaPK, alfaProof := newKey(alfa)
+	alfaAddr, err := address.FromString(alfa)
+	require.NoError(err)
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	require.Error(p.Initialize(context.Background(), ws, []address.Address{alfaAddr}, [][]byte{alfaPK}, [][]byte{}))
+	bravoPK, bravoProof := newKey(bravo)
+	require.Error(p.Initialize(
+		context.Background(),
+		ws,
+		[]address.Address{alfaAddr},
+		[][]byte{bravoPK},
+		[][]byte{bravoProof},
+	))
+	require.NoError(p.Initialize(
+		context.Background(),
+		ws,
+		[]address.Address{alfaAddr},
+		[][]byte{alfaPK},
+		[][]byte{alfaProof},
+	))
+	require.NoError(sf.Commit(ws))
+	keys, err := ReadKeys(sf, 1, []string{alfa, bravo})
+	require.NoError(err)
+	require.Equal(map[string][]byte{alfa: alfaPK}, keys)
+
+	// The proof of possession has to be signed by the key over the sender
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	receipt, err := p.Handle(runCtx(alfa, 2), register(bravoPK, bravoProof), ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	receipt, err = p.Handle(runCtx(bravo, 2), register(bravoPK, bravoProof), ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	// The later key in the same epoch replaces the earlier one
+	newAlfaPK, newAlfaProof := newKey(alfa)
+	receipt, err = p.Handle(runCtx(alfa, 3), register(alfaPK, alfaProof), ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	receipt, err = p.Handle(runCtx(alfa, 4), register(newAlfaPK, newAlfaProof), ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	require.NoError(sf.Commit(ws))
+
+	// The keys take effect from the first height of the next epoch
+	keys, err = ReadKeys(sf, 4, []string{alfa, bravo})
+	require.NoError(err)
+	require.Equal(map[string][]byte{alfa: alfaPK}, keys)
+	keys, err = ReadKeys(sf, 5, []string{alfa, bravo})
+	require.NoError(err)
+	require.Equal(map[string][]byte{alfa: newAlfaPK, bravo: bravoPK}, keys)
+
+	// Only the key in effect and the one to take effect are kept
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	receipt, err = p.Handle(runCtx(alfa, 6), register(alfaPK, alfaProof), ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	require.NoError(sf.Commit(ws))
+	for height, pk := range map[uint64][]byte{
+		1:  nil,
+		5:  newAlfaPK,
+		8:  newAlfaPK,
+		9:  alfaPK,
+		20: alfaPK,
+	} {
+		keys, err = ReadKeys(sf, height, []string{alfa})
+		require.NoError(err)
+		require.Equal(pk, keys[alfa])
+	}
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	data, err := p.ReadState(context.Background(), ws, []byte("Key"), []byte(bravo), []byte("5"))
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(bravoPK), string(data))
+	_, err = p.ReadState(context.Background(), ws, []byte("Key"), []byte(bravo), []byte("4"))
+	require.Error(err)
+
+	// The malformed keys and proofs are rejected
+	for _, r := range []*action.RegisterBLSKey{
+		register(bravoPK[1:], bravoProof),
+		register(bravoPK, bravoProof[1:]),
+		register(make([]byte, crypto.BLSPublicKeyLength), bravoProof),
+	} {
+		require.Error(p.Validate(context.Background(), r))
+		receipt, err = p.Handle(runCtx(bravo, 10), r, ws)
+		require.NoError(err)
+		require.Equal(uint64(1), receipt.Status)
+	}
+	require.NoError(p.Validate(context.Background(), register(bravoPK, bravoProof)))
+}
@@ -0,0 +1,84 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: circuitbreaker.proto
+
+package circuitbreakerpb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Pause struct {
+	StartHeight          uint64   `protobuf:"varint,1,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	EndHeight            uint64   `protobuf:"varint,2,opt,name=endHeight,proto3" json:"endHeight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pause) Reset()         { *m = Pause{} }
+func (m *Pause) String() string { return proto.CompactTextString(m) }
+func (*Pause) ProtoMessage()    {}
+func (*Pause) Descriptor() ([]byte, []int) {
+	return fileDescriptor_circuitbreaker_5c0d7ad2f1a6e38b, []int{0}
+}
+func (m *Pause) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pause.Unmarshal(m, b)
+}
+func (m *Pause) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pause.Marshal(b, m, deterministic)
+}
+func (dst *Pause) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pause.Merge(dst, src)
+}
+func (m *Pause) XXX_Size() int {
+	return xxx_messageInfo_Pause.Size(m)
+}
+func (m *Pause) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pause.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pause proto.InternalMessageInfo
+
+func (m *Pause) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *Pause) GetEndHeight() uint64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Pause)(nil), "circuitbreakerpb.Pause")
+}
+
+func init() {
+	proto.RegisterFile("circuitbreaker.proto", fileDescriptor_circuitbreaker_5c0d7ad2f1a6e38b)
+}
+
+var fileDescriptor_circuitbreaker_5c0d7ad2f1a6e38b = []byte{
+	// 101 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x49, 0xce, 0x2c, 0x4a,
+	0x2e, 0xcd, 0x2c, 0x49, 0x2a, 0x4a, 0x4d, 0xcc, 0x4e, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9,
+	0x17, 0x12, 0x40, 0x15, 0x2d, 0x48, 0x52, 0x72, 0xe7, 0x62, 0x0d, 0x48, 0x2c, 0x2d, 0x4e, 0x15,
+	0x52, 0xe0, 0xe2, 0x2e, 0x2e, 0x49, 0x2c, 0x2a, 0xf1, 0x48, 0xcd, 0x4c, 0xcf, 0x28, 0x91, 0x60,
+	0x54, 0x60, 0xd4, 0x60, 0x09, 0x42, 0x16, 0x12, 0x92, 0xe1, 0xe2, 0x4c, 0xcd, 0x4b, 0x81, 0xca,
+	0x33, 0x81, 0xe5, 0x11, 0x02, 0x49, 0x6c, 0x60, 0x1b, 0x8c, 0x01, 0x03, 0x00, 0x41, 0xfe, 0xf6,
+	0x21, 0x79, 0x00, 0x00, 0x00,
+}
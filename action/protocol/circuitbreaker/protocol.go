@@ -0,0 +1,349 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package circuitbreaker
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/circuitbreaker/circuitbreakerpb"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	ProtocolID = "circuitbreaker"
+)
+
+var pauseKeyPrefix = []byte("pause")
+
+// unpausableTypes are the type names of the actions which are never paused, so that the block producers are still
+// rewarded and the admin is always able to lift a pause
+var unpausableTypes = map[string]struct{}{
+	"GrantReward":  {},
+	"PauseActions": {},
+}
+
+type (
+	// StateReader reads the committed states and the height they're committed at
+	StateReader interface {
+		protocol.StateReader
+		Height() (uint64, error)
+	}
+
+	// Protocol defines the protocol of the circuit breaker, which lets the governance admin pause the actions of
+	// certain types, such as the executions, network-wide from a height as an emergency response. Every pause expires
+	// after at most the max duration, unless the admin renews it. The protocol handler runs before the others by
+	// priority, so that the paused actions are rejected before mutating any state
+	Protocol struct {
+		keyPrefix        []byte
+		addr             address.Address
+		sr               StateReader
+		maxPauseDuration uint64
+	}
+
+	// pause stores the heights the actions of a type are paused in, which is [startHeight, endHeight)
+	pause struct {
+		startHeight uint64
+		endHeight   uint64
+	}
+)
+
+// Serialize serializes pause state into bytes
+func (p pause) Serialize() ([]byte, error) {
+	return proto.Marshal(&circuitbreakerpb.Pause{StartHeight: p.startHeight, EndHeight: p.endHeight})
+}
+
+// Deserialize deserializes bytes into pause state
+func (p *pause) Deserialize(data []byte) error {
+	gen := circuitbreakerpb.Pause{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	p.startHeight = gen.StartHeight
+	p.endHeight = gen.EndHeight
+	return nil
+}
+
+// NewProtocol instantiates a circuit breaker protocol instance, where a pause lasts for at most the max duration in
+// number of blocks. The state reader is used to reject the paused actions before they get into the actpool
+func NewProtocol(sr StateReader, maxPauseDuration uint64) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of circuit breaker protocol", zap.Error(err))
+	}
+	return &Protocol{
+		keyPrefix:        h[:],
+		addr:             addr,
+		sr:               sr,
+		maxPauseDuration: maxPauseDuration,
+	}
+}
+
+// Priority makes the protocol handle the actions before the other protocols, whatever order they're registered in
+func (p *Protocol) Priority() int { return protocol.CircuitBreakerPriority }
+
+// IsPaused returns true if the actions of the type are paused at the height, where the type name is case insensitive
+// and has to be the one of a known action type
+func (p *Protocol) IsPaused(
+	_ context.Context,
+	sm protocol.StateManager,
+	actionType string,
+	height uint64,
+) (bool, error) {
+	return p.isPaused(func(key []byte, s interface{}) error { return p.state(sm, key, s) }, actionType, height)
+}
+
+// Pause pauses the actions of the types from the start height for the duration, which replaces the earlier pauses of
+// the types. The pause starts from the next block at the earliest, and a zero duration lifts the pause. Only the
+// governance admin could make this change
+func (p *Protocol) Pause(
+	ctx context.Context,
+	sm protocol.StateManager,
+	actionTypes []string,
+	startHeight uint64,
+	duration uint64,
+) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	if err := p.assertAdmin(ctx, sm); err != nil {
+		return err
+	}
+	if err := p.validatePause(actionTypes, duration); err != nil {
+		return err
+	}
+	if startHeight <= raCtx.BlockHeight {
+		startHeight = raCtx.BlockHeight + 1
+	}
+	// A lifted pause is kept as an empty range of heights, which never takes effect
+	ps := pause{startHeight: startHeight, endHeight: startHeight + duration}
+	for _, typ := range actionTypes {
+		if err := p.putState(sm, pauseKey(typ), &ps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handle rejects the paused actions, and handles the pause actions actions
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	typ := action.TypeName(act)
+	if typ == "" {
+		return nil, nil
+	}
+	paused, err := p.IsPaused(ctx, sm, typ, raCtx.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	if paused {
+		return nil, errors.Wrapf(action.ErrPaused, "%s is paused at height %d", typ, raCtx.BlockHeight)
+	}
+	pa, ok := act.(*action.PauseActions)
+	if !ok {
+		return nil, nil
+	}
+	if err := p.Pause(ctx, sm, pa.ActionTypes(), pa.StartHeight(), pa.Duration()); err != nil {
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	return p.settleAction(ctx, sm, 0), nil
+}
+
+// Validate validates the pause actions actions, and rejects the paused actions when they are added into the actpool,
+// where the block height is unset in the context. When validating a block, the pauses are checked against the pending
+// states while handling the actions
+func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
+	vaCtx, ok := protocol.GetValidateActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss validate action context")
+	}
+	if pa, ok := act.(*action.PauseActions); ok {
+		if err := p.validatePause(pa.ActionTypes(), pa.Duration()); err != nil {
+			return err
+		}
+	}
+	if p.sr == nil || vaCtx.BlockHeight != 0 {
+		return nil
+	}
+	height, err := p.sr.Height()
+	if err != nil {
+		return err
+	}
+	typ := action.TypeName(act)
+	if typ == "" {
+		return nil
+	}
+	paused, err := p.isPaused(
+		func(key []byte, s interface{}) error {
+			return p.sr.State(hash.Hash160b(append(p.keyPrefix, key...)), s)
+		},
+		typ,
+		height+1,
+	)
+	if err != nil {
+		return err
+	}
+	if paused {
+		return errors.Wrapf(action.ErrPaused, "%s is paused at height %d", typ, height+1)
+	}
+	return nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "IsPaused":
+		if len(args) != 2 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		height, err := strconv.ParseUint(string(args[1]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when parsing height %s", string(args[1]))
+		}
+		paused, err := p.IsPaused(ctx, sm, string(args[0]), height)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.FormatBool(paused)), nil
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+func (p *Protocol) validatePause(actionTypes []string, duration uint64) error {
+	if len(actionTypes) == 0 {
+		return errors.New("no action type to pause")
+	}
+	for _, typ := range actionTypes {
+		name, ok := action.CanonicalTypeName(typ)
+		if !ok {
+			return errors.Errorf("unknown action type %s", typ)
+		}
+		if _, ok := unpausableTypes[name]; ok {
+			return errors.Errorf("action type %s can't be paused", typ)
+		}
+	}
+	if duration > p.maxPauseDuration {
+		return errors.Errorf("pause duration %d is longer than %d", duration, p.maxPauseDuration)
+	}
+	return nil
+}
+
+// isPaused checks the action type against the pause read by the state function. An expired pause is left in the
+// states until it's replaced, as it doesn't take effect anyway
+func (p *Protocol) isPaused(stateFn func([]byte, interface{}) error, actionType string, height uint64) (bool, error) {
+	name, ok := action.CanonicalTypeName(actionType)
+	if !ok {
+		return false, errors.Errorf("unknown action type %s", actionType)
+	}
+	if _, ok := unpausableTypes[name]; ok {
+		return false, nil
+	}
+	ps := pause{}
+	if err := stateFn(pauseKey(name), &ps); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return false, nil
+		}
+		return false, err
+	}
+	return height >= ps.startHeight && height < ps.endHeight, nil
+}
+
+// assertAdmin checks the caller against the admin of the governance protocol, which has to be registered
+func (p *Protocol) assertAdmin(ctx context.Context, sm protocol.StateManager) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	if raCtx.Registry == nil {
+		return errors.New("registry is missing in the context")
+	}
+	gp, ok := raCtx.Registry.Find(governance.ProtocolID)
+	if !ok {
+		return errors.Errorf("protocol %s isn't found", governance.ProtocolID)
+	}
+	govProtocol, ok := gp.(*governance.Protocol)
+	if !ok {
+		return errors.Errorf("error when casting protocol")
+	}
+	adminAddr, err := govProtocol.Admin(ctx, sm)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(adminAddr.Bytes(), raCtx.Caller.Bytes()) {
+		return errors.Errorf("%s is not the governance admin", raCtx.Caller.String())
+	}
+	return nil
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func (p *Protocol) settleAction(ctx context.Context, sm protocol.StateManager, status uint64) *action.Receipt {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas)
+}
+
+func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
+	acc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if nonce > acc.Nonce {
+		acc.Nonce = nonce
+	}
+	return util.StoreAccount(sm, addr.String(), acc)
+}
+
+func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsumed uint64) *action.Receipt {
+	return &action.Receipt{
+		Status:          status,
+		ActHash:         actHash,
+		GasConsumed:     gasConsumed,
+		ContractAddress: p.addr.String(),
+	}
+}
+
+// pauseKey returns the key of the pause of an action type, whose name is case insensitive
+func pauseKey(actionType string) []byte {
+	return append(append([]byte{}, pauseKeyPrefix...), strings.ToLower(actionType)...)
+}
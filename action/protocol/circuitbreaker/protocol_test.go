@@ -0,0 +1,131 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package circuitbreaker
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	p := NewProtocol(sf, 100)
+	gp := governance.NewProtocol(4, 1)
+	registry := protocol.Registry{}
+	require.NoError(registry.Register(governance.ProtocolID, gp))
+
+	admin := ta.Addrinfo["producer"]
+	alfa := ta.Addrinfo["alfa"]
+	runCtx := func(caller address.Address, height uint64) context.Context {
+		return protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight:  height,
+			Caller:       caller,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: 10000,
+			Nonce:        1,
+			Registry:     &registry,
+		})
+	}
+	validateCtx := func(caller address.Address, height uint64) context.Context {
+		return protocol.WithValidateActionsCtx(context.Background(), protocol.ValidateActionsCtx{
+			BlockHeight: height,
+			Caller:      caller,
+		})
+	}
+	pauseActions := func(types []string, startHeight uint64, duration uint64) *action.PauseActions {
+		pb := action.PauseActionsBuilder{}
+		pa := pb.SetActionTypes(types).SetStartHeight(startHeight).SetDuration(duration).Build()
+		return &pa
+	}
+	ex, err := action.NewExecution(admin.String(), 1, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	tsf, err := action.NewTransfer(1, big.NewInt(1), admin.String(), nil, 0, big.NewInt(0))
+	require.NoError(err)
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	require.NoError(gp.Initialize(context.Background(), ws, admin))
+
+	// Only the governance admin is able to pause the actions
+	receipt, err := p.Handle(runCtx(alfa, 1), pauseActions([]string{"Execution"}, 0, 10), ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	receipt, err = p.Handle(runCtx(admin, 0), pauseActions([]string{"Execution"}, 0, 10), ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	_, _, err = ws.RunActions(runCtx(admin, 0), 0, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+
+	// The executions are paused from the next block until the pause expires
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	for height, paused := range map[uint64]bool{0: false, 1: true, 10: true, 11: false} {
+		_, err = p.Handle(runCtx(alfa, height), ex, ws)
+		if paused {
+			require.Equal(action.ErrPaused, errors.Cause(err))
+		} else {
+			require.NoError(err)
+		}
+	}
+	_, err = p.Handle(runCtx(alfa, 1), tsf, ws)
+	require.NoError(err)
+	require.Equal(action.ErrPaused, errors.Cause(p.Validate(validateCtx(alfa, 0), ex)))
+	require.NoError(p.Validate(validateCtx(alfa, 0), tsf))
+	// The pauses aren't checked when validating a block
+	require.NoError(p.Validate(validateCtx(alfa, 1), ex))
+	data, err := p.ReadState(context.Background(), ws, []byte("IsPaused"), []byte("execution"), []byte("5"))
+	require.NoError(err)
+	require.Equal("true", string(data))
+
+	// The admin is able to lift the pause while the actions are paused
+	receipt, err = p.Handle(runCtx(admin, 1), pauseActions([]string{"Execution"}, 0, 0), ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	_, err = p.Handle(runCtx(alfa, 2), ex, ws)
+	require.NoError(err)
+
+	// A pause should not last longer than the max duration, and the pause actions can't be paused
+	require.Error(p.Validate(validateCtx(admin, 0), pauseActions([]string{"Transfer"}, 0, 101)))
+	require.Error(p.Validate(validateCtx(admin, 0), pauseActions([]string{"PauseActions"}, 0, 10)))
+	require.Error(p.Validate(validateCtx(admin, 0), pauseActions(nil, 0, 10)))
+	receipt, err = p.Handle(runCtx(admin, 1), pauseActions([]string{"Transfer"}, 0, 101), ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+
+	// The unknown action types are rejected rather than paused in vain
+	require.Error(p.Validate(validateCtx(admin, 0), pauseActions([]string{"Executions"}, 0, 10)))
+	receipt, err = p.Handle(runCtx(admin, 1), pauseActions([]string{"Executions"}, 0, 10), ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	_, err = p.ReadState(context.Background(), ws, []byte("IsPaused"), []byte("Executions"), []byte("5"))
+	require.Error(err)
+
+	// The protocol handles the actions ahead of the allowlist and the others, even if it's registered later
+	require.True(protocol.PriorityOf(p) < protocol.AllowlistPriority)
+	require.NoError(registry.Register(ProtocolID, p))
+	require.Equal(p, registry.All()[0])
+}
@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dkg.proto
+
+package dkgpb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Delegates struct {
+	Delegates            []string `protobuf:"bytes,1,rep,name=delegates,proto3" json:"delegates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Delegates) Reset()         { *m = Delegates{} }
+func (m *Delegates) String() string { return proto.CompactTextString(m) }
+func (*Delegates) ProtoMessage()    {}
+func (*Delegates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dkg_5e2a9c1f4b7d3a68, []int{0}
+}
+func (m *Delegates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Delegates.Unmarshal(m, b)
+}
+func (m *Delegates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Delegates.Marshal(b, m, deterministic)
+}
+func (dst *Delegates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Delegates.Merge(dst, src)
+}
+func (m *Delegates) XXX_Size() int {
+	return xxx_messageInfo_Delegates.Size(m)
+}
+func (m *Delegates) XXX_DiscardUnknown() {
+	xxx_messageInfo_Delegates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Delegates proto.InternalMessageInfo
+
+func (m *Delegates) GetDelegates() []string {
+	if m != nil {
+		return m.Delegates
+	}
+	return nil
+}
+
+type Group struct {
+	// dealers qualified for the group key
+	Qualified []string `protobuf:"bytes,1,rep,name=qualified,proto3" json:"qualified,omitempty"`
+	// commitments of the group polynomial
+	Commitments [][]byte `protobuf:"bytes,2,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	// delegates whose signature shares of the beacon are recorded
+	Signers              []string `protobuf:"bytes,3,rep,name=signers,proto3" json:"signers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Group) Reset()         { *m = Group{} }
+func (m *Group) String() string { return proto.CompactTextString(m) }
+func (*Group) ProtoMessage()    {}
+func (*Group) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dkg_5e2a9c1f4b7d3a68, []int{1}
+}
+func (m *Group) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Group.Unmarshal(m, b)
+}
+func (m *Group) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Group.Marshal(b, m, deterministic)
+}
+func (dst *Group) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Group.Merge(dst, src)
+}
+func (m *Group) XXX_Size() int {
+	return xxx_messageInfo_Group.Size(m)
+}
+func (m *Group) XXX_DiscardUnknown() {
+	xxx_messageInfo_Group.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Group proto.InternalMessageInfo
+
+func (m *Group) GetQualified() []string {
+	if m != nil {
+		return m.Qualified
+	}
+	return nil
+}
+
+func (m *Group) GetCommitments() [][]byte {
+	if m != nil {
+		return m.Commitments
+	}
+	return nil
+}
+
+func (m *Group) GetSigners() []string {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
+type Beacon struct {
+	Seed                 []byte   `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Beacon) Reset()         { *m = Beacon{} }
+func (m *Beacon) String() string { return proto.CompactTextString(m) }
+func (*Beacon) ProtoMessage()    {}
+func (*Beacon) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dkg_5e2a9c1f4b7d3a68, []int{2}
+}
+func (m *Beacon) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Beacon.Unmarshal(m, b)
+}
+func (m *Beacon) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Beacon.Marshal(b, m, deterministic)
+}
+func (dst *Beacon) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Beacon.Merge(dst, src)
+}
+func (m *Beacon) XXX_Size() int {
+	return xxx_messageInfo_Beacon.Size(m)
+}
+func (m *Beacon) XXX_DiscardUnknown() {
+	xxx_messageInfo_Beacon.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Beacon proto.InternalMessageInfo
+
+func (m *Beacon) GetSeed() []byte {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Delegates)(nil), "dkgpb.Delegates")
+	proto.RegisterType((*Group)(nil), "dkgpb.Group")
+	proto.RegisterType((*Beacon)(nil), "dkgpb.Beacon")
+}
+
+func init() { proto.RegisterFile("dkg.proto", fileDescriptor_dkg_5e2a9c1f4b7d3a68) }
+
+var fileDescriptor_dkg_5e2a9c1f4b7d3a68 = []byte{
+	// 161 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x8e, 0xb1, 0xce, 0x82, 0x30,
+	0x10, 0x80, 0xc3, 0xcf, 0x0f, 0xa6, 0x27, 0xd3, 0x4d, 0x1d, 0x18, 0x08, 0x13, 0x2e, 0x2e, 0xbe,
+	0x81, 0x31, 0x71, 0xe7, 0x0d, 0x0a, 0x3d, 0x9b, 0x06, 0x68, 0x6b, 0x5b, 0xde, 0xdf, 0xd8, 0xa4,
+	0xea, 0x76, 0xdf, 0x97, 0xef, 0x2e, 0x07, 0x4c, 0x2e, 0xea, 0xec, 0xbc, 0x8d, 0x16, 0x2b, 0xb9,
+	0x28, 0x37, 0xf5, 0x27, 0x60, 0x37, 0x5a, 0x49, 0x89, 0x48, 0x01, 0x5b, 0x60, 0x32, 0x03, 0x2f,
+	0xba, 0x72, 0x60, 0xe3, 0x57, 0xf4, 0x02, 0xaa, 0xbb, 0xb7, 0xbb, 0x7b, 0x67, 0xcf, 0x5d, 0xac,
+	0xfa, 0xa1, 0x49, 0xe6, 0xec, 0x23, 0xb0, 0x83, 0xe3, 0x6c, 0xb7, 0x4d, 0xc7, 0x8d, 0x4c, 0x0c,
+	0xfc, 0xaf, 0x2b, 0x87, 0x66, 0xfc, 0x55, 0xc8, 0xe1, 0x10, 0xb4, 0x32, 0xe4, 0x03, 0x2f, 0xd3,
+	0x76, 0xc6, 0xbe, 0x85, 0xfa, 0x4a, 0x62, 0xb6, 0x06, 0x11, 0xfe, 0x03, 0xa5, 0xf3, 0xc5, 0xd0,
+	0x8c, 0x69, 0x9e, 0xea, 0xf4, 0xf9, 0xe5, 0x35, 0x00, 0x23, 0xda, 0x76, 0xbd, 0xc6, 0x00, 0x00,
+	0x00,
+}
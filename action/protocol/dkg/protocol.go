@@ -0,0 +1,766 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package dkg
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg/dkgpb"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/bls"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	ProtocolID = "dkg"
+	// MaxEncryptedShareLength is the max length of an encrypted share in a deal
+	MaxEncryptedShareLength = 256
+)
+
+var (
+	delegatesKeyPrefix = []byte("delegates")
+	messageKeyPrefix   = []byte("message")
+	groupKeyPrefix     = []byte("group")
+	beaconKeyPrefix    = []byte("beacon")
+	beaconDomain       = []byte("DKG_BEACON")
+)
+
+type (
+	// Protocol defines the protocol of the distributed key generation of the epoch beacons. In each epoch, the
+	// delegates run a joint Feldman key generation with the chain as the broadcast channel: they announce their
+	// encryption keys, deal the encrypted shares of their secret polynomials, complain about the bad shares, and
+	// justify the complained shares in plaintext, each in its phase of the epoch. The dealers with all the
+	// complaints justified are qualified, and their polynomials sum up to the group key. At the end of the epoch, the
+	// delegates sign the beacon message with their group key shares, and any degree+1 valid signature shares recover
+	// the unique group signature, whose hash is the beacon seeding the delegate order of the next epoch. Neither a
+	// dealer nor a coalition of up to degree delegates could predict or bias the beacon
+	Protocol struct {
+		keyPrefix     []byte
+		addr          address.Address
+		schedule      Schedule
+		numCandidates uint64
+		numDelegates  uint64
+	}
+
+	// Schedule is the schedule of the key generation phases in the epochs. Each epoch is divided evenly into the
+	// phases, with the beacon phase taking the remaining blocks
+	Schedule struct {
+		// ForkHeight is the height from which the epochs run the key generation, 0 means never
+		ForkHeight uint64
+		// EpochSize is the number of blocks in an epoch
+		EpochSize uint64
+	}
+
+	// Transcript is the DKG messages of the delegates of an epoch recorded on chain
+	Transcript struct {
+		Epoch uint64
+		// Delegates are the delegates of the epoch, which is empty if no message of the epoch has been recorded
+		Delegates []string
+		// Messages are the messages of each kind by the delegates sending them
+		Messages [action.NumDKGPhases]map[string]*action.DKGMessage
+	}
+
+	delegateList []string
+
+	message struct {
+		m action.DKGMessage
+	}
+
+	group struct {
+		qualified   []string
+		commitments [][]byte
+		signers     []string
+	}
+
+	beacon struct {
+		seed []byte
+	}
+)
+
+// NewProtocol instantiates a DKG protocol instance
+func NewProtocol(schedule Schedule, numCandidates uint64, numDelegates uint64) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of DKG protocol", zap.Error(err))
+	}
+	if schedule.EpochSize == 0 || numDelegates == 0 || numCandidates < numDelegates {
+		log.L().Panic(
+			"Invalid DKG protocol parameters",
+			zap.Uint64("epochSize", schedule.EpochSize),
+			zap.Uint64("numCandidates", numCandidates),
+			zap.Uint64("numDelegates", numDelegates),
+		)
+	}
+	return &Protocol{
+		keyPrefix:     h[:],
+		addr:          addr,
+		schedule:      schedule,
+		numCandidates: numCandidates,
+		numDelegates:  numDelegates,
+	}
+}
+
+// Degree returns the degree of the polynomials of the delegates. The beacon takes degree+1 signature shares, which is
+// more than the faulty delegates, i.e., less than one third of the delegates, could provide
+func Degree(numDelegates int) int {
+	if numDelegates == 0 {
+		return 0
+	}
+	return (numDelegates - 1) / 3
+}
+
+// BeaconMessage returns the message signed for the beacon of the epoch, which chains the seed of the previous epoch
+func BeaconMessage(epoch uint64, prevSeed []byte) []byte {
+	msg := append([]byte{}, beaconDomain...)
+	msg = append(msg, byteutil.Uint64ToBytes(epoch)...)
+	return append(msg, prevSeed...)
+}
+
+// EpochHeight returns the first height of the epoch
+func (s Schedule) EpochHeight(epoch uint64) uint64 {
+	return (epoch-1)*s.EpochSize + 1
+}
+
+// Phase returns the epoch of the height and its key generation phase, which is false if the epoch doesn't run the
+// key generation, or is too short to have all the phases
+func (s Schedule) Phase(height uint64) (uint64, action.DKGMessageKind, bool) {
+	if s.ForkHeight == 0 || s.EpochSize < uint64(action.NumDKGPhases) || height == 0 {
+		return 0, 0, false
+	}
+	epoch := (height-1)/s.EpochSize + 1
+	epochHeight := s.EpochHeight(epoch)
+	if epochHeight < s.ForkHeight {
+		return 0, 0, false
+	}
+	phase := (height - epochHeight) / (s.EpochSize / uint64(action.NumDKGPhases))
+	if phase >= uint64(action.NumDKGPhases) {
+		phase = uint64(action.NumDKGPhases) - 1
+	}
+	return epoch, action.DKGMessageKind(phase), true
+}
+
+// ReadSeed reads the seed of the delegate order of the epoch, which is the beacon generated in the previous epoch, or
+// the fixed seed if there is none. The beacon of an epoch is final once the previous epoch ends
+func ReadSeed(sr protocol.StateReader, epoch uint64) ([]byte, error) {
+	return seed(readStateFunc(sr), epoch)
+}
+
+// ReadTranscript reads the DKG messages of the epoch recorded so far. It's meant for the delegates to take the part
+// in the key generation out of the handling of a block. The transcript is deleted once the beacon is generated
+func ReadTranscript(sr protocol.StateReader, epoch uint64) (*Transcript, error) {
+	stateFn := readStateFunc(sr)
+	delegates := delegateList{}
+	if err := stateFn(delegatesKey(epoch), &delegates); err != nil {
+		if errors.Cause(err) != state.ErrStateNotExist {
+			return nil, err
+		}
+		return newTranscript(epoch, nil), nil
+	}
+	return loadTranscript(stateFn, epoch, delegates)
+}
+
+// Index returns the index of the delegate, starting from 1, which is 0 if it isn't a delegate of the epoch
+func (t *Transcript) Index(delegate string) uint64 {
+	for i, d := range t.Delegates {
+		if d == delegate {
+			return uint64(i + 1)
+		}
+	}
+	return 0
+}
+
+// Degree returns the degree of the polynomials of the delegates
+func (t *Transcript) Degree() int {
+	return Degree(len(t.Delegates))
+}
+
+// Qualified returns the dealers qualified for the group key, i.e., the ones whose deals are recorded, and who have
+// revealed the shares to all the delegates complaining about them
+func (t *Transcript) Qualified() []string {
+	qualified := make([]string, 0, len(t.Messages[action.DKGDeal]))
+	for _, dealer := range t.Delegates {
+		if _, ok := t.Messages[action.DKGDeal][dealer]; !ok {
+			continue
+		}
+		justified := make(map[string]bool)
+		if justification, ok := t.Messages[action.DKGJustification][dealer]; ok {
+			for _, share := range justification.Shares() {
+				justified[share.Recipient] = true
+			}
+		}
+		disqualified := false
+		for complainer, complaint := range t.Messages[action.DKGComplaint] {
+			if accuses(complaint, dealer) && !justified[complainer] {
+				disqualified = true
+				break
+			}
+		}
+		if !disqualified {
+			qualified = append(qualified, dealer)
+		}
+	}
+	return qualified
+}
+
+// Handle handles the DKG message actions
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	m, ok := act.(*action.DKGMessage)
+	if !ok {
+		return nil, nil
+	}
+	if err := p.RecordMessage(ctx, sm, m); err != nil {
+		log.L().Debug("Error when recording DKG message.", zap.Error(err))
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	return p.settleAction(ctx, sm, 0), nil
+}
+
+// Validate validates the DKG message actions
+func (p *Protocol) Validate(_ context.Context, act action.Action) error {
+	m, ok := act.(*action.DKGMessage)
+	if !ok {
+		return nil
+	}
+	return m.Verify()
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "Seed":
+		if len(args) != 1 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		return seed(p.stateFunc(sm), byteutil.BytesToUint64(args[0]))
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+// RecordMessage records the DKG message of the sender. The message has to be of the epoch and the phase of the
+// current block, and each delegate could only send one message of each kind
+func (p *Protocol) RecordMessage(ctx context.Context, sm protocol.StateManager, m *action.DKGMessage) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	epoch, phase, ok := p.schedule.Phase(raCtx.BlockHeight)
+	if !ok {
+		return errors.Errorf("no key generation at height %d", raCtx.BlockHeight)
+	}
+	if m.Epoch() != epoch || m.Kind() != phase {
+		return errors.Errorf(
+			"message of kind %d in epoch %d is not in phase %d of epoch %d",
+			m.Kind(),
+			m.Epoch(),
+			phase,
+			epoch,
+		)
+	}
+	if err := m.Verify(); err != nil {
+		return err
+	}
+	stateFn := p.stateFunc(sm)
+	if _, err := readBeacon(stateFn, epoch+1); err == nil {
+		return errors.Errorf("beacon of epoch %d has been generated", epoch+1)
+	} else if errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	t, err := p.transcript(sm, epoch)
+	if err != nil {
+		return err
+	}
+	sender := raCtx.Caller.String()
+	if t.Index(sender) == 0 {
+		return errors.Errorf("%s is not a delegate of epoch %d", sender, epoch)
+	}
+	if _, ok := t.Messages[m.Kind()][sender]; ok {
+		return errors.Errorf("message of kind %d from %s has been recorded", m.Kind(), sender)
+	}
+	switch m.Kind() {
+	case action.DKGKey:
+		if _, err := keypair.BytesToPublicKey(m.EncryptionKey()); err != nil {
+			return errors.Wrap(err, "invalid encryption key")
+		}
+	case action.DKGDeal:
+		err = verifyDeal(t, m)
+	case action.DKGComplaint:
+		err = verifyComplaint(t, sender, m)
+	case action.DKGJustification:
+		err = verifyJustification(t, sender, m)
+	case action.DKGBeaconShare:
+		return p.recordBeaconShare(sm, t, raCtx.Caller, m)
+	}
+	if err != nil {
+		return err
+	}
+	return p.putState(sm, messageKey(epoch, m.Kind(), raCtx.Caller), &message{m: *m})
+}
+
+func verifyDeal(t *Transcript, m *action.DKGMessage) error {
+	if len(m.Commitments()) != t.Degree()+1 {
+		return errors.Errorf("%d commitments, expecting %d", len(m.Commitments()), t.Degree()+1)
+	}
+	// Evaluating the commitments at 0 checks that all of them are valid
+	if _, err := bls.PublicKeyShare(m.Commitments(), 0); err != nil {
+		return err
+	}
+	recipients := make(map[string]bool)
+	for _, share := range m.Shares() {
+		if recipients[share.Recipient] {
+			return errors.Errorf("duplicate share to %s", share.Recipient)
+		}
+		recipients[share.Recipient] = true
+		if _, ok := t.Messages[action.DKGKey][share.Recipient]; !ok {
+			return errors.Errorf("recipient %s has no encryption key", share.Recipient)
+		}
+		if len(share.Share) == 0 || len(share.Share) > MaxEncryptedShareLength {
+			return errors.Errorf("invalid length of share %d", len(share.Share))
+		}
+	}
+	return nil
+}
+
+func verifyComplaint(t *Transcript, complainer string, m *action.DKGMessage) error {
+	accused := make(map[string]bool)
+	for _, dealer := range m.Accused() {
+		if accused[dealer] {
+			return errors.Errorf("duplicate accused dealer %s", dealer)
+		}
+		accused[dealer] = true
+		if dealer == complainer {
+			return errors.New("cannot accuse oneself")
+		}
+		if _, ok := t.Messages[action.DKGDeal][dealer]; !ok {
+			return errors.Errorf("accused %s has no deal", dealer)
+		}
+	}
+	return nil
+}
+
+func verifyJustification(t *Transcript, dealer string, m *action.DKGMessage) error {
+	deal, ok := t.Messages[action.DKGDeal][dealer]
+	if !ok {
+		return errors.Errorf("%s has no deal", dealer)
+	}
+	revealed := make(map[string]bool)
+	for _, share := range m.Shares() {
+		if revealed[share.Recipient] {
+			return errors.Errorf("duplicate share to %s", share.Recipient)
+		}
+		revealed[share.Recipient] = true
+		complaint, ok := t.Messages[action.DKGComplaint][share.Recipient]
+		if !ok || !accuses(complaint, dealer) {
+			return errors.Errorf("%s doesn't complain about %s", share.Recipient, dealer)
+		}
+		if err := bls.VerifyShare(deal.Commitments(), t.Index(share.Recipient), share.Share); err != nil {
+			return errors.Wrapf(err, "invalid share to %s", share.Recipient)
+		}
+	}
+	return nil
+}
+
+// recordBeaconShare records the valid signature share of the beacon message, and generates the beacon once there are
+// degree+1 of them. The group of the qualified dealers is fixed at the first signature share, as no deal, complaint or
+// justification could be recorded in the beacon phase
+func (p *Protocol) recordBeaconShare(
+	sm protocol.StateManager,
+	t *Transcript,
+	signer address.Address,
+	m *action.DKGMessage,
+) error {
+	stateFn := p.stateFunc(sm)
+	g := group{}
+	if err := p.state(sm, groupKey(t.Epoch), &g); err != nil {
+		if errors.Cause(err) != state.ErrStateNotExist {
+			return err
+		}
+		if g, err = newGroup(t); err != nil {
+			return err
+		}
+	}
+	prevSeed, err := seed(stateFn, t.Epoch)
+	if err != nil {
+		return err
+	}
+	msg := BeaconMessage(t.Epoch+1, prevSeed)
+	pk, err := bls.PublicKeyShare(g.commitments, t.Index(signer.String()))
+	if err != nil {
+		return err
+	}
+	if err := pk.Verify(msg, m.SignatureShare()); err != nil {
+		return errors.Wrap(err, "invalid signature share")
+	}
+	if err := p.putState(sm, messageKey(t.Epoch, m.Kind(), signer), &message{m: *m}); err != nil {
+		return err
+	}
+	g.signers = append(g.signers, signer.String())
+	if len(g.signers) <= t.Degree() {
+		return p.putState(sm, groupKey(t.Epoch), &g)
+	}
+	t.Messages[action.DKGBeaconShare][signer.String()] = m
+	indices := make([]uint64, 0, len(g.signers))
+	sigs := make([][]byte, 0, len(g.signers))
+	for _, s := range g.signers {
+		indices = append(indices, t.Index(s))
+		sigs = append(sigs, t.Messages[action.DKGBeaconShare][s].SignatureShare())
+	}
+	sig, err := bls.RecoverSignature(indices, sigs)
+	if err != nil {
+		return err
+	}
+	groupPK, err := bls.PublicKeyShare(g.commitments, 0)
+	if err != nil {
+		return err
+	}
+	if err := groupPK.Verify(msg, sig); err != nil {
+		return errors.Wrap(err, "invalid recovered beacon signature")
+	}
+	h := hash.Hash256b(sig)
+	if err := p.putState(sm, beaconKey(t.Epoch+1), &beacon{seed: h[:]}); err != nil {
+		return err
+	}
+	return p.deleteTranscript(sm, t)
+}
+
+func newGroup(t *Transcript) (group, error) {
+	qualified := t.Qualified()
+	if len(qualified) == 0 {
+		return group{}, errors.Errorf("no qualified dealer in epoch %d", t.Epoch)
+	}
+	commitments := make([][][]byte, 0, len(qualified))
+	for _, dealer := range qualified {
+		commitments = append(commitments, t.Messages[action.DKGDeal][dealer].Commitments())
+	}
+	aggregated, err := bls.AggregateCommitments(commitments)
+	if err != nil {
+		return group{}, err
+	}
+	return group{qualified: qualified, commitments: aggregated}, nil
+}
+
+// transcript loads the transcript of the epoch. The delegates of the epoch are determined and stored along with the
+// first message, and the transcript of the previous epoch left by a failed key generation is deleted then
+func (p *Protocol) transcript(sm protocol.StateManager, epoch uint64) (*Transcript, error) {
+	stateFn := p.stateFunc(sm)
+	delegates := delegateList{}
+	err := stateFn(delegatesKey(epoch), &delegates)
+	if err == nil {
+		return loadTranscript(stateFn, epoch, delegates)
+	}
+	if errors.Cause(err) != state.ErrStateNotExist {
+		return nil, err
+	}
+	if epoch > 1 {
+		prev := delegateList{}
+		if err := stateFn(delegatesKey(epoch-1), &prev); err == nil {
+			t, err := loadTranscript(stateFn, epoch-1, prev)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.deleteTranscript(sm, t); err != nil {
+				return nil, err
+			}
+		} else if errors.Cause(err) != state.ErrStateNotExist {
+			return nil, err
+		}
+	}
+	if delegates, err = p.delegates(sm, epoch); err != nil {
+		return nil, err
+	}
+	if err := p.putState(sm, delegatesKey(epoch), &delegates); err != nil {
+		return nil, err
+	}
+	return newTranscript(epoch, delegates), nil
+}
+
+func (p *Protocol) delegates(sm protocol.StateManager, epoch uint64) (delegateList, error) {
+	return Delegates(sm, p.schedule.EpochHeight(epoch), epoch, p.numCandidates, p.numDelegates)
+}
+
+// Delegates determines the delegates of the epoch starting at the height in the same way as the roll-DPoS consensus
+// does, i.e., out of the candidates at the end of the previous epoch, ordered with the seed of the epoch, which is the
+// beacon if it has been generated
+func Delegates(
+	sm protocol.StateManager,
+	epochHeight uint64,
+	epoch uint64,
+	numCandidates uint64,
+	numDelegates uint64,
+) ([]string, error) {
+	candidates, err := candidatesutil.CandidatesByHeight(sm, epochHeight-1)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(candidates)) > numCandidates {
+		candidates = candidates[:numCandidates]
+	}
+	if uint64(len(candidates)) < numDelegates {
+		return nil, errors.Errorf("%d candidates, less than %d delegates", len(candidates), numDelegates)
+	}
+	addrs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		addrs = append(addrs, candidate.Address)
+	}
+	seed, err := ReadSeed(sm, epoch)
+	if err != nil {
+		return nil, err
+	}
+	crypto.SortCandidates(addrs, epoch, seed)
+	return addrs[:numDelegates], nil
+}
+
+func (p *Protocol) deleteTranscript(sm protocol.StateManager, t *Transcript) error {
+	for kind, messages := range t.Messages {
+		for sender := range messages {
+			addr, err := address.FromString(sender)
+			if err != nil {
+				return err
+			}
+			if err := p.deleteState(sm, messageKey(t.Epoch, action.DKGMessageKind(kind), addr)); err != nil {
+				return err
+			}
+		}
+	}
+	g := group{}
+	if err := p.state(sm, groupKey(t.Epoch), &g); err == nil {
+		if err := p.deleteState(sm, groupKey(t.Epoch)); err != nil {
+			return err
+		}
+	} else if errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	return p.deleteState(sm, delegatesKey(t.Epoch))
+}
+
+func newTranscript(epoch uint64, delegates []string) *Transcript {
+	t := &Transcript{Epoch: epoch, Delegates: delegates}
+	for i := range t.Messages {
+		t.Messages[i] = make(map[string]*action.DKGMessage)
+	}
+	return t
+}
+
+func loadTranscript(
+	stateFn func([]byte, interface{}) error,
+	epoch uint64,
+	delegates []string,
+) (*Transcript, error) {
+	t := newTranscript(epoch, delegates)
+	for _, delegate := range delegates {
+		addr, err := address.FromString(delegate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when decoding delegate address %s", delegate)
+		}
+		for kind := range t.Messages {
+			msg := message{}
+			if err := stateFn(messageKey(epoch, action.DKGMessageKind(kind), addr), &msg); err != nil {
+				if errors.Cause(err) == state.ErrStateNotExist {
+					continue
+				}
+				return nil, err
+			}
+			t.Messages[kind][delegate] = &msg.m
+		}
+	}
+	return t, nil
+}
+
+func accuses(complaint *action.DKGMessage, dealer string) bool {
+	for _, accused := range complaint.Accused() {
+		if accused == dealer {
+			return true
+		}
+	}
+	return false
+}
+
+func seed(stateFn func([]byte, interface{}) error, epoch uint64) ([]byte, error) {
+	b, err := readBeacon(stateFn, epoch)
+	if err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return crypto.CryptoSeed, nil
+		}
+		return nil, err
+	}
+	return b.seed, nil
+}
+
+func readBeacon(stateFn func([]byte, interface{}) error, epoch uint64) (*beacon, error) {
+	b := beacon{}
+	if err := stateFn(beaconKey(epoch), &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func readStateFunc(sr protocol.StateReader) func([]byte, interface{}) error {
+	keyPrefix := hash.Hash160b([]byte(ProtocolID))
+	return func(key []byte, s interface{}) error {
+		return sr.State(hash.Hash160b(append(keyPrefix[:], key...)), s)
+	}
+}
+
+func delegatesKey(epoch uint64) []byte {
+	return append(append([]byte{}, delegatesKeyPrefix...), byteutil.Uint64ToBytes(epoch)...)
+}
+
+func messageKey(epoch uint64, kind action.DKGMessageKind, sender address.Address) []byte {
+	key := append(append([]byte{}, messageKeyPrefix...), byteutil.Uint64ToBytes(epoch)...)
+	key = append(key, byteutil.Uint32ToBytes(uint32(kind))...)
+	return append(key, sender.Bytes()...)
+}
+
+func groupKey(epoch uint64) []byte {
+	return append(append([]byte{}, groupKeyPrefix...), byteutil.Uint64ToBytes(epoch)...)
+}
+
+func beaconKey(epoch uint64) []byte {
+	return append(append([]byte{}, beaconKeyPrefix...), byteutil.Uint64ToBytes(epoch)...)
+}
+
+// Serialize serializes the delegates into bytes
+func (d delegateList) Serialize() ([]byte, error) {
+	return proto.Marshal(&dkgpb.Delegates{Delegates: d})
+}
+
+// Deserialize deserializes bytes into the delegates
+func (d *delegateList) Deserialize(data []byte) error {
+	gen := dkgpb.Delegates{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	*d = gen.Delegates
+	return nil
+}
+
+// Serialize serializes the message into bytes
+func (m *message) Serialize() ([]byte, error) {
+	return proto.Marshal(m.m.Proto())
+}
+
+// Deserialize deserializes bytes into the message
+func (m *message) Deserialize(data []byte) error {
+	gen := iotextypes.DKGMessage{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	return m.m.LoadProto(&gen)
+}
+
+// Serialize serializes the group into bytes
+func (g *group) Serialize() ([]byte, error) {
+	return proto.Marshal(&dkgpb.Group{
+		Qualified:   g.qualified,
+		Commitments: g.commitments,
+		Signers:     g.signers,
+	})
+}
+
+// Deserialize deserializes bytes into the group
+func (g *group) Deserialize(data []byte) error {
+	gen := dkgpb.Group{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	g.qualified = gen.Qualified
+	g.commitments = gen.Commitments
+	g.signers = gen.Signers
+	return nil
+}
+
+// Serialize serializes the beacon into bytes
+func (b *beacon) Serialize() ([]byte, error) {
+	return proto.Marshal(&dkgpb.Beacon{Seed: b.seed})
+}
+
+// Deserialize deserializes bytes into the beacon
+func (b *beacon) Deserialize(data []byte) error {
+	gen := dkgpb.Beacon{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	b.seed = gen.Seed
+	return nil
+}
+
+func (p *Protocol) stateFunc(sm protocol.StateManager) func([]byte, interface{}) error {
+	return func(key []byte, value interface{}) error {
+		return p.state(sm, key, value)
+	}
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func (p *Protocol) deleteState(sm protocol.StateManager, key []byte) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.DelState(keyHash)
+}
+
+func (p *Protocol) settleAction(ctx context.Context, sm protocol.StateManager, status uint64) *action.Receipt {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas)
+}
+
+func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
+	acc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if nonce > acc.Nonce {
+		acc.Nonce = nonce
+	}
+	return util.StoreAccount(sm, addr.String(), acc)
+}
+
+func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsumed uint64) *action.Receipt {
+	return &action.Receipt{
+		Status:          status,
+		ActHash:         actHash,
+		GasConsumed:     gasConsumed,
+		ContractAddress: p.addr.String(),
+	}
+}
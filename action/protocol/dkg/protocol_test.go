@@ -0,0 +1,206 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package dkg
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/bls"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestSchedule(t *testing.T) {
+	require := require.New(t)
+
+	s := Schedule{ForkHeight: 11, EpochSize: 12}
+	for _, c := range []struct {
+		height uint64
+		epoch  uint64
+		kind   action.DKGMessageKind
+		ok     bool
+	}{
+		{0, 0, 0, false},
+		{1, 0, 0, false},
+		{12, 0, 0, false},
+		{13, 2, action.DKGKey, true},
+		{15, 2, action.DKGDeal, true},
+		{20, 2, action.DKGJustification, true},
+		{21, 2, action.DKGBeaconShare, true},
+		{24, 2, action.DKGBeaconShare, true},
+		{25, 3, action.DKGKey, true},
+	} {
+		epoch, kind, ok := s.Phase(c.height)
+		require.Equal(c.ok, ok, "height %d", c.height)
+		require.Equal(c.epoch, epoch, "height %d", c.height)
+		require.Equal(c.kind, kind, "height %d", c.height)
+	}
+	require.Equal(uint64(25), s.EpochHeight(3))
+	_, _, ok := Schedule{EpochSize: 12}.Phase(13)
+	require.False(ok)
+	_, _, ok = Schedule{ForkHeight: 1, EpochSize: 4}.Phase(1)
+	require.False(ok)
+}
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	// Each phase of the epoch takes 2 blocks
+	p := NewProtocol(Schedule{ForkHeight: 1, EpochSize: 10}, 5, 4)
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	var candidates state.CandidateList
+	for _, name := range []string{"alfa", "bravo", "charlie", "delta", "echo"} {
+		candidates = append(candidates, &state.Candidate{
+			Address:   ta.Addrinfo[name].String(),
+			PublicKey: ta.Keyinfo[name].PubKey,
+			Votes:     big.NewInt(1),
+		})
+	}
+	require.NoError(ws.PutState(candidatesutil.ConstructKey(0), &candidates))
+	require.NoError(sf.Commit(ws))
+
+	seed, err := ReadSeed(sf, 2)
+	require.NoError(err)
+	require.Equal(crypto.CryptoSeed, seed)
+	tr, err := ReadTranscript(sf, 1)
+	require.NoError(err)
+	require.Empty(tr.Delegates)
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	handle := func(height uint64, sender string, m action.DKGMessage) uint64 {
+		ctx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight: height,
+			Caller:      ta.Addrinfo[sender],
+			GasPrice:    big.NewInt(0),
+		})
+		require.NoError(p.Validate(ctx, &m))
+		receipt, err := p.Handle(ctx, &m, ws)
+		require.NoError(err)
+		return receipt.Status
+	}
+	newMessage := func() *action.DKGMessageBuilder {
+		b := &action.DKGMessageBuilder{}
+		return b.SetEpoch(1)
+	}
+
+	// The delegates announce their encryption keys, and the candidate out of the delegates cannot
+	var rejected uint64
+	for _, name := range []string{"alfa", "bravo", "charlie", "delta", "echo"} {
+		key := keypair.PublicKeyToBytes(ta.Keyinfo[name].PubKey)
+		rejected += handle(1, name, newMessage().SetEncryptionKey(key).Build())
+	}
+	require.Equal(uint64(1), rejected)
+	tr, err = ReadTranscript(ws, 1)
+	require.NoError(err)
+	require.Len(tr.Delegates, 4)
+	require.Equal(1, tr.Degree())
+	delegates := tr.Delegates
+	require.Len(tr.Messages[action.DKGKey], 4)
+	var outsider string
+	for _, name := range []string{"alfa", "bravo", "charlie", "delta", "echo"} {
+		if tr.Index(ta.Addrinfo[name].String()) == 0 {
+			outsider = ta.Addrinfo[name].String()
+		}
+	}
+	require.NotEmpty(outsider)
+	names := make(map[string]string)
+	for name, addr := range ta.Addrinfo {
+		names[addr.String()] = name
+	}
+	// The duplicate key and the message out of its phase are rejected
+	require.Equal(uint64(1), handle(2, names[delegates[0]], newMessage().SetEncryptionKey([]byte("key")).Build()))
+	require.Equal(uint64(1), handle(3, names[delegates[0]], newMessage().SetEncryptionKey([]byte("key")).Build()))
+
+	// The delegates deal the shares, with the encryption left out of the test
+	polys := make(map[string]*bls.Polynomial)
+	for _, dealer := range delegates {
+		poly, err := bls.NewPolynomial(1)
+		require.NoError(err)
+		polys[dealer] = poly
+		var shares []action.DKGShare
+		for _, recipient := range delegates {
+			shares = append(shares, action.DKGShare{Recipient: recipient, Share: poly.Share(tr.Index(recipient))})
+		}
+		require.Equal(uint64(0), handle(3, names[dealer], newMessage().SetDeal(poly.Commitments(), shares).Build()))
+	}
+	poly, err := bls.NewPolynomial(2)
+	require.NoError(err)
+	shares := []action.DKGShare{{Recipient: delegates[0], Share: poly.Share(1)}}
+	require.Equal(uint64(1), handle(4, names[outsider], newMessage().SetDeal(poly.Commitments(), shares).Build()))
+
+	// The last delegate complains about the first two dealers, and only the first one justifies its share
+	accused := []string{delegates[0], delegates[1]}
+	require.Equal(uint64(0), handle(5, names[delegates[3]], newMessage().SetComplaint(accused).Build()))
+	self := []string{delegates[2]}
+	require.Equal(uint64(1), handle(5, names[delegates[2]], newMessage().SetComplaint(self).Build()))
+	wrong := []action.DKGShare{{Recipient: delegates[3], Share: polys[delegates[1]].Share(1)}}
+	require.Equal(uint64(1), handle(7, names[delegates[1]], newMessage().SetJustification(wrong).Build()))
+	unaccused := []action.DKGShare{{Recipient: delegates[2], Share: polys[delegates[2]].Share(3)}}
+	require.Equal(uint64(1), handle(7, names[delegates[2]], newMessage().SetJustification(unaccused).Build()))
+	justified := []action.DKGShare{{Recipient: delegates[3], Share: polys[delegates[0]].Share(4)}}
+	require.Equal(uint64(0), handle(7, names[delegates[0]], newMessage().SetJustification(justified).Build()))
+
+	tr, err = ReadTranscript(ws, 1)
+	require.NoError(err)
+	qualified := []string{delegates[0], delegates[2], delegates[3]}
+	require.Equal(qualified, tr.Qualified())
+
+	// Any two signature shares of the group key shares generate the beacon
+	msg := BeaconMessage(2, crypto.CryptoSeed)
+	sign := func(recipient string) []byte {
+		var received [][]byte
+		for _, dealer := range qualified {
+			received = append(received, polys[dealer].Share(tr.Index(recipient)))
+		}
+		sk, err := bls.AggregateShares(received)
+		require.NoError(err)
+		return sk.Sign(msg)
+	}
+	require.Equal(uint64(1), handle(9, names[delegates[0]], newMessage().SetBeaconShare(sign(delegates[1])).Build()))
+	require.Equal(uint64(0), handle(9, names[delegates[0]], newMessage().SetBeaconShare(sign(delegates[0])).Build()))
+	seed, err = ReadSeed(ws, 2)
+	require.NoError(err)
+	require.Equal(crypto.CryptoSeed, seed)
+	require.Equal(uint64(0), handle(10, names[delegates[1]], newMessage().SetBeaconShare(sign(delegates[1])).Build()))
+	seed, err = ReadSeed(ws, 2)
+	require.NoError(err)
+	require.NotEqual(crypto.CryptoSeed, seed)
+	data, err := p.ReadState(context.Background(), ws, []byte("Seed"), byteutil.Uint64ToBytes(2))
+	require.NoError(err)
+	require.Equal(seed, data)
+
+	// The transcript is deleted along with the beacon generated, and no more messages are recorded
+	tr, err = ReadTranscript(ws, 1)
+	require.NoError(err)
+	require.Empty(tr.Delegates)
+	require.Equal(uint64(1), handle(10, names[delegates[2]], newMessage().SetBeaconShare(sign(delegates[2])).Build()))
+	require.NoError(sf.Commit(ws))
+	seed2, err := ReadSeed(sf, 2)
+	require.NoError(err)
+	require.Equal(seed, seed2)
+}
@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: evidence.proto
+
+package evidencepb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Offense struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  uint32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Topic  uint32 `protobuf:"varint,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	// height of the block including the evidence
+	ReportedAt           uint64   `protobuf:"varint,4,opt,name=reportedAt,proto3" json:"reportedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Offense) Reset()         { *m = Offense{} }
+func (m *Offense) String() string { return proto.CompactTextString(m) }
+func (*Offense) ProtoMessage()    {}
+func (*Offense) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evidence_71024067f6820f7f, []int{0}
+}
+func (m *Offense) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Offense.Unmarshal(m, b)
+}
+func (m *Offense) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Offense.Marshal(b, m, deterministic)
+}
+func (dst *Offense) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Offense.Merge(dst, src)
+}
+func (m *Offense) XXX_Size() int {
+	return xxx_messageInfo_Offense.Size(m)
+}
+func (m *Offense) XXX_DiscardUnknown() {
+	xxx_messageInfo_Offense.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Offense proto.InternalMessageInfo
+
+func (m *Offense) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Offense) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *Offense) GetTopic() uint32 {
+	if m != nil {
+		return m.Topic
+	}
+	return 0
+}
+
+func (m *Offense) GetReportedAt() uint64 {
+	if m != nil {
+		return m.ReportedAt
+	}
+	return 0
+}
+
+type Offenses struct {
+	Offenses             []*Offense `protobuf:"bytes,1,rep,name=offenses,proto3" json:"offenses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Offenses) Reset()         { *m = Offenses{} }
+func (m *Offenses) String() string { return proto.CompactTextString(m) }
+func (*Offenses) ProtoMessage()    {}
+func (*Offenses) Descriptor() ([]byte, []int) {
+	return fileDescriptor_evidence_71024067f6820f7f, []int{1}
+}
+func (m *Offenses) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Offenses.Unmarshal(m, b)
+}
+func (m *Offenses) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Offenses.Marshal(b, m, deterministic)
+}
+func (dst *Offenses) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Offenses.Merge(dst, src)
+}
+func (m *Offenses) XXX_Size() int {
+	return xxx_messageInfo_Offenses.Size(m)
+}
+func (m *Offenses) XXX_DiscardUnknown() {
+	xxx_messageInfo_Offenses.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Offenses proto.InternalMessageInfo
+
+func (m *Offenses) GetOffenses() []*Offense {
+	if m != nil {
+		return m.Offenses
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Offense)(nil), "evidencepb.Offense")
+	proto.RegisterType((*Offenses)(nil), "evidencepb.Offenses")
+}
+
+func init() { proto.RegisterFile("evidence.proto", fileDescriptor_evidence_71024067f6820f7f) }
+
+var fileDescriptor_evidence_71024067f6820f7f = []byte{
+	// 159 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4b, 0x2d, 0xcb, 0x4c,
+	0x49, 0xcd, 0x4b, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x82, 0xf1, 0x0b, 0x92,
+	0x94, 0x72, 0xb9, 0xd8, 0xfd, 0xd3, 0xd2, 0x52, 0xf3, 0x8a, 0x53, 0x85, 0xc4, 0xb8, 0xd8, 0x32,
+	0x52, 0x33, 0xd3, 0x33, 0x4a, 0x24, 0x18, 0x15, 0x18, 0x35, 0x58, 0x82, 0xa0, 0x3c, 0x21, 0x11,
+	0x2e, 0xd6, 0xa2, 0xfc, 0xd2, 0xbc, 0x14, 0x09, 0x26, 0x05, 0x46, 0x0d, 0xde, 0x20, 0x08, 0x07,
+	0x24, 0x5a, 0x92, 0x5f, 0x90, 0x99, 0x2c, 0xc1, 0x0c, 0x11, 0x05, 0x73, 0x84, 0xe4, 0xb8, 0xb8,
+	0x8a, 0x52, 0x0b, 0xf2, 0x8b, 0x4a, 0x52, 0x53, 0x1c, 0x4b, 0x24, 0x58, 0xc0, 0xe6, 0x20, 0x89,
+	0x28, 0x59, 0x73, 0x71, 0x40, 0xad, 0x2b, 0x16, 0xd2, 0xe7, 0xe2, 0xc8, 0x87, 0xb2, 0x25, 0x18,
+	0x15, 0x98, 0x35, 0xb8, 0x8d, 0x84, 0xf5, 0x10, 0x2e, 0xd3, 0x83, 0xaa, 0x0b, 0x82, 0x2b, 0x4a,
+	0x62, 0x03, 0x3b, 0xdf, 0x18, 0x30, 0x00, 0x4b, 0x05, 0x1a, 0xf4, 0xd0, 0x00, 0x00, 0x00,
+}
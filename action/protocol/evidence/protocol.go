@@ -0,0 +1,303 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
+	"github.com/iotexproject/iotex-core/action/protocol/evidence/evidencepb"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	ProtocolID = "evidence"
+)
+
+var offensesKeyPrefix = []byte("offenses")
+
+type (
+	// Protocol defines the protocol recording the double sign evidences of the delegates. Each offense is recorded
+	// once under the offender, so that a slashing protocol could read the offenses and penalize the offender
+	Protocol struct {
+		keyPrefix     []byte
+		addr          address.Address
+		numCandidates uint64
+		numDelegates  uint64
+		epochSize     uint64
+		maxAge        uint64
+	}
+
+	// Offense is a double sign of a delegate proven by an evidence
+	Offense struct {
+		Height uint64
+		Round  uint32
+		Topic  endorsement.ConsensusVoteTopic
+		// ReportedAt is the height of the block including the evidence
+		ReportedAt uint64
+	}
+
+	// Offenses are the offenses of a delegate in the order of being reported
+	Offenses []Offense
+)
+
+// Serialize serializes offenses state into bytes
+func (o Offenses) Serialize() ([]byte, error) {
+	return proto.Marshal(o.toProto())
+}
+
+// Deserialize deserializes bytes into offenses state
+func (o *Offenses) Deserialize(data []byte) error {
+	gen := evidencepb.Offenses{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	*o = make(Offenses, 0, len(gen.Offenses))
+	for _, offense := range gen.Offenses {
+		*o = append(*o, Offense{
+			Height:     offense.Height,
+			Round:      offense.Round,
+			Topic:      endorsement.ConsensusVoteTopic(offense.Topic),
+			ReportedAt: offense.ReportedAt,
+		})
+	}
+	return nil
+}
+
+func (o Offenses) toProto() *evidencepb.Offenses {
+	gen := evidencepb.Offenses{}
+	for _, offense := range o {
+		gen.Offenses = append(gen.Offenses, &evidencepb.Offense{
+			Height:     offense.Height,
+			Round:      offense.Round,
+			Topic:      uint32(offense.Topic),
+			ReportedAt: offense.ReportedAt,
+		})
+	}
+	return &gen
+}
+
+// NewProtocol instantiates an evidence protocol instance. The offender has to be a delegate of the epoch of the double
+// sign, where the delegates are determined in the same way as the roll-DPoS consensus does, and the evidence has to be
+// reported within max age blocks
+func NewProtocol(numCandidates uint64, numDelegates uint64, numSubEpochs uint64, maxAge uint64) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of evidence protocol", zap.Error(err))
+	}
+	if numDelegates == 0 || numSubEpochs == 0 || numCandidates < numDelegates || maxAge == 0 {
+		log.L().Panic(
+			"Invalid evidence protocol parameters.",
+			zap.Uint64("numCandidates", numCandidates),
+			zap.Uint64("numDelegates", numDelegates),
+			zap.Uint64("numSubEpochs", numSubEpochs),
+			zap.Uint64("maxAge", maxAge),
+		)
+	}
+	return &Protocol{
+		keyPrefix:     h[:],
+		addr:          addr,
+		numCandidates: numCandidates,
+		numDelegates:  numDelegates,
+		epochSize:     numDelegates * numSubEpochs,
+		maxAge:        maxAge,
+	}
+}
+
+// Offenses returns the offenses recorded for the delegate
+func (p *Protocol) Offenses(_ context.Context, sm protocol.StateManager, delegate address.Address) (Offenses, error) {
+	offenses := Offenses{}
+	if err := p.state(sm, offensesKey(delegate), &offenses); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return Offenses{}, nil
+		}
+		return nil, err
+	}
+	return offenses, nil
+}
+
+// RecordDoubleSign records the offense proven by the evidence. The same offense cannot be recorded twice, the evidence
+// cannot be about a height after the current block or older than the max age, and the offender has to be a delegate
+// at the height
+func (p *Protocol) RecordDoubleSign(
+	ctx context.Context,
+	sm protocol.StateManager,
+	evidence *action.DoubleSignEvidence,
+) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	if err := evidence.Verify(); err != nil {
+		return err
+	}
+	vote := evidence.First().ConsensusVote()
+	if vote.Height == 0 {
+		return errors.New("evidence height is 0")
+	}
+	if vote.Height > raCtx.BlockHeight {
+		return errors.Errorf("evidence height %d is after the current height %d", vote.Height, raCtx.BlockHeight)
+	}
+	if vote.Height+p.maxAge < raCtx.BlockHeight {
+		return errors.Errorf(
+			"evidence height %d is more than %d blocks below the current height %d",
+			vote.Height,
+			p.maxAge,
+			raCtx.BlockHeight,
+		)
+	}
+	offender, err := address.FromString(evidence.Offender())
+	if err != nil {
+		return errors.Wrapf(err, "error when decoding offender address %s", evidence.Offender())
+	}
+	epoch := (vote.Height-1)/p.epochSize + 1
+	delegates, err := dkg.Delegates(sm, (epoch-1)*p.epochSize+1, epoch, p.numCandidates, p.numDelegates)
+	if err != nil {
+		return errors.Wrapf(err, "error when getting the delegates at height %d", vote.Height)
+	}
+	isDelegate := false
+	for _, delegate := range delegates {
+		if delegate == evidence.Offender() {
+			isDelegate = true
+			break
+		}
+	}
+	if !isDelegate {
+		return errors.Errorf("offender %s isn't a delegate at height %d", evidence.Offender(), vote.Height)
+	}
+	offenses, err := p.Offenses(ctx, sm, offender)
+	if err != nil {
+		return err
+	}
+	for _, offense := range offenses {
+		if offense.Height == vote.Height && offense.Round == vote.Round && offense.Topic == vote.Topic {
+			return errors.Errorf(
+				"offense of %s at height %d round %d has been recorded",
+				evidence.Offender(),
+				vote.Height,
+				vote.Round,
+			)
+		}
+	}
+	offenses = append(offenses, Offense{
+		Height:     vote.Height,
+		Round:      vote.Round,
+		Topic:      vote.Topic,
+		ReportedAt: raCtx.BlockHeight,
+	})
+	return p.putState(sm, offensesKey(offender), offenses)
+}
+
+// Handle handles the double sign evidence actions
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	evidence, ok := act.(*action.DoubleSignEvidence)
+	if !ok {
+		return nil, nil
+	}
+	if err := p.RecordDoubleSign(ctx, sm, evidence); err != nil {
+		log.L().Debug("Error when recording double sign evidence.", zap.Error(err))
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	return p.settleAction(ctx, sm, 0), nil
+}
+
+// Validate validates the double sign evidence actions
+func (p *Protocol) Validate(_ context.Context, act action.Action) error {
+	evidence, ok := act.(*action.DoubleSignEvidence)
+	if !ok {
+		return nil
+	}
+	return evidence.Verify()
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "Offenses":
+		if len(args) != 1 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		delegate, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when decoding delegate address %s", string(args[0]))
+		}
+		offenses, err := p.Offenses(ctx, sm, delegate)
+		if err != nil {
+			return nil, err
+		}
+		return offenses.Serialize()
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+func offensesKey(delegate address.Address) []byte {
+	return append(offensesKeyPrefix, delegate.Bytes()...)
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func (p *Protocol) settleAction(ctx context.Context, sm protocol.StateManager, status uint64) *action.Receipt {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas)
+}
+
+func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
+	acc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if nonce > acc.Nonce {
+		acc.Nonce = nonce
+	}
+	return util.StoreAccount(sm, addr.String(), acc)
+}
+
+func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsumed uint64) *action.Receipt {
+	return &action.Receipt{
+		Status:          status,
+		ActHash:         actHash,
+		GasConsumed:     gasConsumed,
+		ContractAddress: p.addr.String(),
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	// 4 out of the 5 candidates are the delegates in each epoch of 8 blocks, and the evidences are 5 blocks old at most
+	p := NewProtocol(5, 4, 2, 5)
+	names := []string{"alfa", "bravo", "charlie", "delta", "echo"}
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	var candidates state.CandidateList
+	for _, name := range names {
+		candidates = append(candidates, &state.Candidate{
+			Address:   ta.Addrinfo[name].String(),
+			PublicKey: ta.Keyinfo[name].PubKey,
+			Votes:     big.NewInt(1),
+		})
+	}
+	require.NoError(ws.PutState(candidatesutil.ConstructKey(0), &candidates))
+	require.NoError(sf.Commit(ws))
+	delegates, err := dkg.Delegates(ws, 9, 2, 5, 4)
+	require.NoError(err)
+	var offender, outsider string
+	for _, name := range names {
+		isDelegate := false
+		for _, delegate := range delegates {
+			isDelegate = isDelegate || delegate == ta.Addrinfo[name].String()
+		}
+		if isDelegate && offender == "" {
+			offender = name
+		}
+		if !isDelegate {
+			outsider = name
+		}
+	}
+	require.NotEmpty(offender)
+	require.NotEmpty(outsider)
+
+	runCtx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+		BlockHeight: 11,
+		Caller:      ta.Addrinfo["producer"],
+		GasPrice:    big.NewInt(0),
+	})
+	evidenceOf := func(name string, height uint64, round uint32, blkHashes ...string) action.DoubleSignEvidence {
+		key := ta.Keyinfo[name]
+		var ens []*endorsement.Endorsement
+		for _, blkHash := range blkHashes {
+			vote := endorsement.NewConsensusVote([]byte(blkHash), height, round, endorsement.LOCK)
+			ens = append(ens, endorsement.NewEndorsement(vote, key.PubKey, key.PriKey, ta.Addrinfo[name].String()))
+		}
+		b := action.DoubleSignEvidenceBuilder{}
+		return b.SetEndorsements(ens[0], ens[1]).Build()
+	}
+	evidence := func(height uint64, round uint32, blkHashes ...string) action.DoubleSignEvidence {
+		return evidenceOf(offender, height, round, blkHashes...)
+	}
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	offenses, err := p.Offenses(context.Background(), ws, ta.Addrinfo[offender])
+	require.NoError(err)
+	require.Empty(offenses)
+
+	e := evidence(10, 1, "block1", "block2")
+	require.NoError(p.Validate(context.Background(), &e))
+	receipt, err := p.Handle(runCtx, &e, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	// The same offense isn't recorded twice, even with another conflicting block
+	e = evidence(10, 1, "block1", "block3")
+	receipt, err = p.Handle(runCtx, &e, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	// The offense in another round is recorded
+	e = evidence(10, 2, "block1", "block2")
+	receipt, err = p.Handle(runCtx, &e, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	// The evidence after the current height is rejected
+	e = evidence(12, 1, "block1", "block2")
+	receipt, err = p.Handle(runCtx, &e, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	// The evidence older than the max age is rejected
+	e = evidence(5, 1, "block1", "block2")
+	receipt, err = p.Handle(runCtx, &e, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	// The evidence against a candidate out of the delegates is rejected
+	e = evidenceOf(outsider, 10, 1, "block1", "block2")
+	receipt, err = p.Handle(runCtx, &e, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	// The endorsements on the same block are not evidence
+	e = evidence(10, 3, "block1", "block1")
+	require.Error(p.Validate(context.Background(), &e))
+	receipt, err = p.Handle(runCtx, &e, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	require.NoError(sf.Commit(ws))
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	offenses, err = p.Offenses(context.Background(), ws, ta.Addrinfo[offender])
+	require.NoError(err)
+	require.Equal(Offenses{
+		{Height: 10, Round: 1, Topic: endorsement.LOCK, ReportedAt: 11},
+		{Height: 10, Round: 2, Topic: endorsement.LOCK, ReportedAt: 11},
+	}, offenses)
+	data, err := p.ReadState(
+		context.Background(),
+		ws,
+		[]byte("Offenses"),
+		[]byte(ta.Addrinfo[offender].String()),
+	)
+	require.NoError(err)
+	read := Offenses{}
+	require.NoError(read.Deserialize(data))
+	require.Equal(offenses, read)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// StateOverride substitutes the states of an address for a contract read, so that the read could be simulated on
+// the states which haven't been put on chain. The balance and the code are left as they are if nil, and only the
+// storage slots in Storage are substituted
+type StateOverride struct {
+	Address string
+	Balance *big.Int
+	Code    []byte
+	Storage map[hash.Hash256]hash.Hash256
+}
+
+// OverrideStates applies the overrides to the states in sm. It is meant for a working set which is discarded after
+// the read, since the overridden states are not consistent with the chain
+func OverrideStates(cm protocol.ChainManager, sm protocol.StateManager, overrides []*StateOverride) error {
+	stateDB := NewStateDBAdapter(cm, sm, 0, hash.ZeroHash256, hash.ZeroHash256)
+	overridden := make(map[hash.Hash160]struct{}, len(overrides))
+	for _, o := range overrides {
+		addr, err := address.FromString(o.Address)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode override address %s", o.Address)
+		}
+		addrHash := byteutil.BytesTo20B(addr.Bytes())
+		if _, ok := overridden[addrHash]; ok {
+			return errors.Errorf("states of %s are overridden more than once", o.Address)
+		}
+		overridden[addrHash] = struct{}{}
+		if o.Balance != nil {
+			if o.Balance.Sign() < 0 {
+				return errors.Errorf("negative balance %s to override for %s", o.Balance, o.Address)
+			}
+			account, err := util.LoadOrCreateAccount(sm, o.Address, big.NewInt(0))
+			if err != nil {
+				return err
+			}
+			account.Balance = o.Balance
+			if err := util.StoreAccount(sm, o.Address, account); err != nil {
+				return errors.Wrapf(err, "failed to override balance of %s", o.Address)
+			}
+		}
+		if o.Code == nil && len(o.Storage) == 0 {
+			continue
+		}
+		// The contract is loaded after the balance is overridden, so that the account stored along with the contract
+		// has the overridden balance
+		contract, err := stateDB.Contract(addrHash)
+		if err != nil {
+			return err
+		}
+		if o.Code != nil {
+			contract.SetCode(hash.Hash256b(o.Code), o.Code)
+		}
+		for k, v := range o.Storage {
+			if err := contract.SetState(k, v[:]); err != nil {
+				return errors.Wrapf(err, "failed to override storage of %s", o.Address)
+			}
+		}
+	}
+	if err := stateDB.commitContracts(); err != nil {
+		return errors.Wrap(err, "failed to commit the overridden contracts")
+	}
+	return nil
+}
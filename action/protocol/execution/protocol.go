@@ -70,3 +70,8 @@ func (p *Protocol) Validate(_ context.Context, act action.Action) error {
 	}
 	return nil
 }
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(context.Context, protocol.StateManager, []byte, ...[]byte) ([]byte, error) {
+	return nil, protocol.ErrUnimplemented
+}
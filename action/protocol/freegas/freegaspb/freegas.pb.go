@@ -0,0 +1,83 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: freegas.proto
+
+package freegaspb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Usage struct {
+	// day since unix epoch of the last free gas action
+	Day uint64 `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	// free gas used in the day
+	Gas                  uint64   `protobuf:"varint,2,opt,name=gas,proto3" json:"gas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Usage) Reset()         { *m = Usage{} }
+func (m *Usage) String() string { return proto.CompactTextString(m) }
+func (*Usage) ProtoMessage()    {}
+func (*Usage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_freegas_5d48a06315b65737, []int{0}
+}
+func (m *Usage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Usage.Unmarshal(m, b)
+}
+func (m *Usage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Usage.Marshal(b, m, deterministic)
+}
+func (dst *Usage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Usage.Merge(dst, src)
+}
+func (m *Usage) XXX_Size() int {
+	return xxx_messageInfo_Usage.Size(m)
+}
+func (m *Usage) XXX_DiscardUnknown() {
+	xxx_messageInfo_Usage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Usage proto.InternalMessageInfo
+
+func (m *Usage) GetDay() uint64 {
+	if m != nil {
+		return m.Day
+	}
+	return 0
+}
+
+func (m *Usage) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Usage)(nil), "freegaspb.Usage")
+}
+
+func init() { proto.RegisterFile("freegas.proto", fileDescriptor_freegas_5d48a06315b65737) }
+
+var fileDescriptor_freegas_5d48a06315b65737 = []byte{
+	// 83 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4d, 0x2b, 0x4a, 0x4d,
+	0x4d, 0x4f, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x84, 0x72, 0x0b, 0x92, 0x94,
+	0xb4, 0xb9, 0x58, 0x43, 0x8b, 0x13, 0xd3, 0x53, 0x85, 0x04, 0xb8, 0x98, 0x53, 0x12, 0x2b, 0x25,
+	0x18, 0x15, 0x18, 0x35, 0x58, 0x82, 0x40, 0x4c, 0x90, 0x48, 0x7a, 0x62, 0xb1, 0x04, 0x13, 0x44,
+	0x24, 0x3d, 0xb1, 0x38, 0x89, 0x0d, 0xac, 0xdd, 0x18, 0x30, 0x00, 0x16, 0x76, 0xe1, 0x8e, 0x4f,
+	0x00, 0x00, 0x00,
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package freegas
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/freegas/freegaspb"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	ProtocolID = "freegas"
+	// secondsPerDay is the length of the period the quota is granted for
+	secondsPerDay = int64(24 * time.Hour / time.Second)
+)
+
+var (
+	usageKeyPrefix = []byte("usage")
+	// totalKey is the key of the free gas used by all the accounts in a day
+	totalKey = []byte("total")
+)
+
+type (
+	// Protocol defines the protocol of the free gas quota, which lets an account send the actions of certain types at
+	// zero gas price, until their intrinsic gas used in a day reaches the quota, and the free gas used by all the
+	// accounts in the day reaches the cap. It supports the devices without any balance. The other actions at zero gas
+	// price are rejected, except the ones consuming no gas. The protocol handler runs before the others by its
+	// priority, so that the actions exceeding the quota are rejected before mutating any state
+	Protocol struct {
+		keyPrefix  []byte
+		addr       address.Address
+		sr         protocol.StateReader
+		dailyQuota uint64
+		dailyCap   uint64
+		// maxSizes are the max sizes in bytes of the free gas actions keyed by their type names, where 0 means no limit
+		maxSizes map[string]uint64
+	}
+
+	// usage stores the free gas used by an account in a day
+	usage struct {
+		day uint64
+		gas uint64
+	}
+
+	// payload is the part of the action checked against the quota
+	payload interface {
+		ByteStream() []byte
+		GasPrice() *big.Int
+		IntrinsicGas() (uint64, error)
+	}
+)
+
+// Serialize serializes usage state into bytes
+func (u usage) Serialize() ([]byte, error) {
+	return proto.Marshal(&freegaspb.Usage{Day: u.day, Gas: u.gas})
+}
+
+// Deserialize deserializes bytes into usage state
+func (u *usage) Deserialize(data []byte) error {
+	gen := freegaspb.Usage{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	u.day = gen.Day
+	u.gas = gen.Gas
+	return nil
+}
+
+// NewProtocol instantiates a free gas protocol instance, which grants the daily quota to the actions of the given
+// types, such as Transfer, whose sizes are up to the given max sizes, while the free gas used by all the accounts in a
+// day is capped by the daily cap, where 0 means no cap. The state reader is used to reject the actions exceeding the
+// quota before they get into the actpool
+func NewProtocol(sr protocol.StateReader, dailyQuota uint64, dailyCap uint64, maxSizes map[string]uint64) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of free gas protocol", zap.Error(err))
+	}
+	p := &Protocol{
+		keyPrefix:  h[:],
+		addr:       addr,
+		sr:         sr,
+		dailyQuota: dailyQuota,
+		dailyCap:   dailyCap,
+		maxSizes:   make(map[string]uint64, len(maxSizes)),
+	}
+	for typ, size := range maxSizes {
+		name, ok := action.CanonicalTypeName(typ)
+		if !ok {
+			log.L().Panic("Unknown action type granted free gas", zap.String("type", typ))
+		}
+		p.maxSizes[name] = size
+	}
+	return p
+}
+
+// Priority makes the protocol handle the actions after the allowlist and before the other protocols, whatever order
+// they're registered in
+func (p *Protocol) Priority() int { return protocol.FreeGasPriority }
+
+// Used returns the free gas used by the account in the day of the timestamp
+func (p *Protocol) Used(
+	_ context.Context,
+	sm protocol.StateManager,
+	addr address.Address,
+	timestamp int64,
+) (uint64, error) {
+	return p.used(
+		func(key []byte, s interface{}) error {
+			return p.state(sm, key, s)
+		},
+		usageKey(addr),
+		timestamp,
+	)
+}
+
+// TotalUsed returns the free gas used by all the accounts in the day of the timestamp
+func (p *Protocol) TotalUsed(_ context.Context, sm protocol.StateManager, timestamp int64) (uint64, error) {
+	return p.used(
+		func(key []byte, s interface{}) error {
+			return p.state(sm, key, s)
+		},
+		totalKey,
+		timestamp,
+	)
+}
+
+// Handle rejects the actions at zero gas price which aren't granted free gas, and charges the quota of the others
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	if (raCtx.GasPrice != nil && raCtx.GasPrice.Sign() != 0) || raCtx.IntrinsicGas == 0 {
+		return nil, nil
+	}
+	if err := p.checkAction(act); err != nil {
+		return nil, err
+	}
+	used, err := p.Used(ctx, sm, raCtx.Caller, raCtx.BlockTimeStamp)
+	if err != nil {
+		return nil, err
+	}
+	total, err := p.TotalUsed(ctx, sm, raCtx.BlockTimeStamp)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkQuota(raCtx.Caller, used, total, raCtx.IntrinsicGas); err != nil {
+		return nil, err
+	}
+	d := day(raCtx.BlockTimeStamp)
+	if err := p.putState(sm, usageKey(raCtx.Caller), &usage{day: d, gas: used + raCtx.IntrinsicGas}); err != nil {
+		return nil, err
+	}
+	return nil, p.putState(sm, totalKey, &usage{day: d, gas: total + raCtx.IntrinsicGas})
+}
+
+// Validate rejects the actions at zero gas price which aren't granted free gas. When the actions are added into the
+// actpool, where the block height is unset in the context, the quota is checked against the committed states. When
+// validating a block, the quota is checked against the pending states while handling the actions
+func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
+	vaCtx, ok := protocol.GetValidateActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss validate action context")
+	}
+	pl, ok := act.(payload)
+	if !ok || pl.GasPrice().Sign() != 0 {
+		return nil
+	}
+	gas, err := pl.IntrinsicGas()
+	if err != nil || gas == 0 {
+		return err
+	}
+	if err := p.checkAction(act); err != nil {
+		return err
+	}
+	if p.sr == nil || vaCtx.BlockHeight != 0 {
+		return nil
+	}
+	stateFunc := func(key []byte, s interface{}) error {
+		return p.sr.State(hash.Hash160b(append(p.keyPrefix, key...)), s)
+	}
+	now := time.Now().Unix()
+	used, err := p.used(stateFunc, usageKey(vaCtx.Caller), now)
+	if err != nil {
+		return err
+	}
+	total, err := p.used(stateFunc, totalKey, now)
+	if err != nil {
+		return err
+	}
+	return p.checkQuota(vaCtx.Caller, used, total, gas)
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "Used":
+		if len(args) != 2 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		addr, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when decoding address %s", string(args[0]))
+		}
+		timestamp, err := strconv.ParseInt(string(args[1]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when parsing timestamp %s", string(args[1]))
+		}
+		used, err := p.Used(ctx, sm, addr, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.FormatUint(used, 10)), nil
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+// checkAction checks whether the type and the size of the action are granted free gas
+func (p *Protocol) checkAction(act action.Action) error {
+	typ := action.TypeName(act)
+	maxSize, ok := p.maxSizes[typ]
+	if !ok {
+		return errors.Wrapf(action.ErrFreeGas, "action type %s isn't granted free gas", typ)
+	}
+	pl, ok := act.(payload)
+	if !ok {
+		return errors.Wrapf(action.ErrFreeGas, "action type %s isn't granted free gas", typ)
+	}
+	if size := uint64(len(pl.ByteStream())); maxSize > 0 && size > maxSize {
+		return errors.Wrapf(action.ErrFreeGas, "action size %d is larger than %d", size, maxSize)
+	}
+	return nil
+}
+
+func (p *Protocol) checkQuota(addr address.Address, used uint64, total uint64, gas uint64) error {
+	if used+gas < used || used+gas > p.dailyQuota {
+		return errors.Wrapf(
+			action.ErrFreeGas,
+			"%s has used %d free gas of the daily quota %d, %d more is required",
+			addr.String(),
+			used,
+			p.dailyQuota,
+			gas,
+		)
+	}
+	if p.dailyCap != 0 && (total+gas < total || total+gas > p.dailyCap) {
+		return errors.Wrapf(
+			action.ErrFreeGas,
+			"%d free gas of the daily cap %d has been used, %d more is required",
+			total,
+			p.dailyCap,
+			gas,
+		)
+	}
+	return nil
+}
+
+func (p *Protocol) used(stateFunc func([]byte, interface{}) error, key []byte, timestamp int64) (uint64, error) {
+	u := usage{}
+	if err := stateFunc(key, &u); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return 0, nil
+		}
+		return 0, err
+	}
+	// The quota is renewed every day
+	if u.day != day(timestamp) {
+		return 0, nil
+	}
+	return u.gas, nil
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func usageKey(addr address.Address) []byte {
+	return append(usageKeyPrefix, addr.Bytes()...)
+}
+
+func day(timestamp int64) uint64 {
+	if timestamp < 0 {
+		return 0
+	}
+	return uint64(timestamp / secondsPerDay)
+}
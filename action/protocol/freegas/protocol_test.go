@@ -0,0 +1,122 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package freegas
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	p := NewProtocol(sf, 25000, 40000, map[string]uint64{"transfer": 64})
+	require.Equal(protocol.FreeGasPriority, protocol.PriorityOf(p))
+	require.Panics(func() { NewProtocol(sf, 25000, 0, map[string]uint64{"Transfers": 64}) })
+
+	alfa := ta.Addrinfo["alfa"]
+	now := time.Now().Unix()
+	runCtx := func(caller address.Address, gasPrice int64, timestamp int64) context.Context {
+		return protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight:    1,
+			BlockTimeStamp: timestamp,
+			Caller:         caller,
+			GasPrice:       big.NewInt(gasPrice),
+			IntrinsicGas:   10000,
+			Nonce:          1,
+		})
+	}
+	validateCtx := func(caller address.Address, height uint64) context.Context {
+		return protocol.WithValidateActionsCtx(context.Background(), protocol.ValidateActionsCtx{
+			BlockHeight: height,
+			Caller:      caller,
+		})
+	}
+	tsf, err := action.NewTransfer(1, big.NewInt(0), alfa.String(), []byte("t=21"), 0, big.NewInt(0))
+	require.NoError(err)
+
+	// Only the small transfers are granted free gas, while the paid actions are left to the other protocols
+	require.NoError(p.Validate(validateCtx(alfa, 0), tsf))
+	large, err := action.NewTransfer(1, big.NewInt(0), alfa.String(), make([]byte, 64), 0, big.NewInt(0))
+	require.NoError(err)
+	require.Equal(action.ErrFreeGas, errors.Cause(p.Validate(validateCtx(alfa, 0), large)))
+	exec, err := action.NewExecution(alfa.String(), 1, big.NewInt(0), 0, big.NewInt(0), nil)
+	require.NoError(err)
+	require.Equal(action.ErrFreeGas, errors.Cause(p.Validate(validateCtx(alfa, 0), exec)))
+	exec, err = action.NewExecution(alfa.String(), 1, big.NewInt(0), 0, big.NewInt(1), nil)
+	require.NoError(err)
+	require.NoError(p.Validate(validateCtx(alfa, 0), exec))
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	receipt, err := p.Handle(runCtx(alfa, 0, now), tsf, ws)
+	require.NoError(err)
+	require.Nil(receipt)
+	_, err = p.Handle(runCtx(alfa, 1, now), exec, ws)
+	require.NoError(err)
+	_, err = p.Handle(runCtx(alfa, 0, now), tsf, ws)
+	require.NoError(err)
+	used, err := p.Used(context.Background(), ws, alfa, now)
+	require.NoError(err)
+	require.Equal(uint64(20000), used)
+
+	// The action exceeding the quota is rejected until the next day
+	_, err = p.Handle(runCtx(alfa, 0, now), tsf, ws)
+	require.Equal(action.ErrFreeGas, errors.Cause(err))
+	require.NoError(sf.Commit(ws))
+	require.Equal(action.ErrFreeGas, errors.Cause(p.Validate(validateCtx(alfa, 0), tsf)))
+	require.NoError(p.Validate(validateCtx(alfa, 1), tsf))
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	tomorrow := now + secondsPerDay
+	_, err = p.Handle(runCtx(alfa, 0, tomorrow), tsf, ws)
+	require.NoError(err)
+	data, err := p.ReadState(
+		context.Background(),
+		ws,
+		[]byte("Used"),
+		[]byte(alfa.String()),
+		[]byte(strconv.FormatInt(tomorrow, 10)),
+	)
+	require.NoError(err)
+	require.Equal("10000", string(data))
+
+	// The free gas of all the accounts in a day is capped
+	bravo := ta.Addrinfo["bravo"]
+	charlie := ta.Addrinfo["charlie"]
+	_, err = p.Handle(runCtx(alfa, 0, tomorrow), tsf, ws)
+	require.NoError(err)
+	_, err = p.Handle(runCtx(bravo, 0, tomorrow), tsf, ws)
+	require.NoError(err)
+	_, err = p.Handle(runCtx(charlie, 0, tomorrow), tsf, ws)
+	require.NoError(err)
+	total, err := p.TotalUsed(context.Background(), ws, tomorrow)
+	require.NoError(err)
+	require.Equal(uint64(40000), total)
+	_, err = p.Handle(runCtx(charlie, 0, tomorrow), tsf, ws)
+	require.Equal(action.ErrFreeGas, errors.Cause(err))
+}
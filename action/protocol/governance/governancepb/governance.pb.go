@@ -0,0 +1,192 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: governance.proto
+
+package governancepb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Admin struct {
+	Admin                []byte   `protobuf:"bytes,1,opt,name=admin,proto3" json:"admin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Admin) Reset()         { *m = Admin{} }
+func (m *Admin) String() string { return proto.CompactTextString(m) }
+func (*Admin) ProtoMessage()    {}
+func (*Admin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_governance_2c2c800eed26ffd5, []int{0}
+}
+func (m *Admin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Admin.Unmarshal(m, b)
+}
+func (m *Admin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Admin.Marshal(b, m, deterministic)
+}
+func (dst *Admin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Admin.Merge(dst, src)
+}
+func (m *Admin) XXX_Size() int {
+	return xxx_messageInfo_Admin.Size(m)
+}
+func (m *Admin) XXX_DiscardUnknown() {
+	xxx_messageInfo_Admin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Admin proto.InternalMessageInfo
+
+func (m *Admin) GetAdmin() []byte {
+	if m != nil {
+		return m.Admin
+	}
+	return nil
+}
+
+type ConsensusParams struct {
+	// first height the params take effect
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// durations in milliseconds
+	BlockInterval                uint64   `protobuf:"varint,2,opt,name=blockInterval,proto3" json:"blockInterval,omitempty"`
+	AcceptBlockTTL               uint64   `protobuf:"varint,3,opt,name=acceptBlockTTL,proto3" json:"acceptBlockTTL,omitempty"`
+	AcceptProposalEndorsementTTL uint64   `protobuf:"varint,4,opt,name=acceptProposalEndorsementTTL,proto3" json:"acceptProposalEndorsementTTL,omitempty"`
+	AcceptLockEndorsementTTL     uint64   `protobuf:"varint,5,opt,name=acceptLockEndorsementTTL,proto3" json:"acceptLockEndorsementTTL,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
+func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
+func (*ConsensusParams) ProtoMessage()    {}
+func (*ConsensusParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_governance_2c2c800eed26ffd5, []int{1}
+}
+func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusParams.Unmarshal(m, b)
+}
+func (m *ConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusParams.Marshal(b, m, deterministic)
+}
+func (dst *ConsensusParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusParams.Merge(dst, src)
+}
+func (m *ConsensusParams) XXX_Size() int {
+	return xxx_messageInfo_ConsensusParams.Size(m)
+}
+func (m *ConsensusParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusParams proto.InternalMessageInfo
+
+func (m *ConsensusParams) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ConsensusParams) GetBlockInterval() uint64 {
+	if m != nil {
+		return m.BlockInterval
+	}
+	return 0
+}
+
+func (m *ConsensusParams) GetAcceptBlockTTL() uint64 {
+	if m != nil {
+		return m.AcceptBlockTTL
+	}
+	return 0
+}
+
+func (m *ConsensusParams) GetAcceptProposalEndorsementTTL() uint64 {
+	if m != nil {
+		return m.AcceptProposalEndorsementTTL
+	}
+	return 0
+}
+
+func (m *ConsensusParams) GetAcceptLockEndorsementTTL() uint64 {
+	if m != nil {
+		return m.AcceptLockEndorsementTTL
+	}
+	return 0
+}
+
+type ConsensusParamsHistory struct {
+	Params               []*ConsensusParams `protobuf:"bytes,1,rep,name=params,proto3" json:"params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ConsensusParamsHistory) Reset()         { *m = ConsensusParamsHistory{} }
+func (m *ConsensusParamsHistory) String() string { return proto.CompactTextString(m) }
+func (*ConsensusParamsHistory) ProtoMessage()    {}
+func (*ConsensusParamsHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_governance_2c2c800eed26ffd5, []int{2}
+}
+func (m *ConsensusParamsHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusParamsHistory.Unmarshal(m, b)
+}
+func (m *ConsensusParamsHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusParamsHistory.Marshal(b, m, deterministic)
+}
+func (dst *ConsensusParamsHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusParamsHistory.Merge(dst, src)
+}
+func (m *ConsensusParamsHistory) XXX_Size() int {
+	return xxx_messageInfo_ConsensusParamsHistory.Size(m)
+}
+func (m *ConsensusParamsHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusParamsHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusParamsHistory proto.InternalMessageInfo
+
+func (m *ConsensusParamsHistory) GetParams() []*ConsensusParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Admin)(nil), "governancepb.Admin")
+	proto.RegisterType((*ConsensusParams)(nil), "governancepb.ConsensusParams")
+	proto.RegisterType((*ConsensusParamsHistory)(nil), "governancepb.ConsensusParamsHistory")
+}
+
+func init() { proto.RegisterFile("governance.proto", fileDescriptor_governance_2c2c800eed26ffd5) }
+
+var fileDescriptor_governance_2c2c800eed26ffd5 = []byte{
+	// 202 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x48, 0xcf, 0x2f, 0x4b,
+	0x2d, 0xca, 0x4b, 0xcc, 0x4b, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x41, 0x88,
+	0x14, 0x24, 0x29, 0xc9, 0x72, 0xb1, 0x3a, 0xa6, 0xe4, 0x66, 0xe6, 0x09, 0x89, 0x70, 0xb1, 0x26,
+	0x82, 0x18, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x10, 0x8e, 0xd2, 0x57, 0x46, 0x2e, 0x7e,
+	0xe7, 0xfc, 0xbc, 0xe2, 0xd4, 0xbc, 0xe2, 0xd2, 0xe2, 0x80, 0xc4, 0xa2, 0xc4, 0xdc, 0x62, 0x90,
+	0xca, 0xd4, 0x82, 0xfc, 0xe4, 0x0c, 0xb0, 0x4a, 0x96, 0x20, 0x08, 0x47, 0x48, 0x85, 0x8b, 0x37,
+	0x29, 0x27, 0x3f, 0x39, 0xdb, 0x33, 0xaf, 0x24, 0xb5, 0xa8, 0x2c, 0x31, 0x47, 0x82, 0x09, 0x2c,
+	0x8b, 0x2a, 0x28, 0xa4, 0xc6, 0xc5, 0x97, 0x98, 0x9c, 0x9c, 0x5a, 0x50, 0xe2, 0x04, 0x12, 0x0e,
+	0x09, 0xf1, 0x91, 0x60, 0x06, 0x2b, 0x43, 0x13, 0x15, 0x72, 0xe2, 0x92, 0x81, 0x88, 0x04, 0x14,
+	0xe5, 0x17, 0xe4, 0x17, 0x27, 0xe6, 0xb8, 0xe6, 0xa5, 0xe4, 0x17, 0x15, 0xa7, 0xe6, 0xa6, 0xe6,
+	0x95, 0x80, 0x74, 0xb1, 0x80, 0x75, 0xe1, 0x55, 0x23, 0x64, 0xc5, 0x25, 0x01, 0x91, 0xf7, 0xc9,
+	0x4f, 0xce, 0x46, 0xd3, 0xcf, 0x0a, 0xd6, 0x8f, 0x53, 0x3e, 0x89, 0x0d, 0x1c, 0x56, 0xc6, 0x80,
+	0x01, 0x00, 0x72, 0xdc, 0xc6, 0xfa, 0x3f, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,414 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/governance/governancepb"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	ProtocolID = "governance"
+	// MinBlockInterval is the min block interval could be set
+	MinBlockInterval = time.Second
+	// MaxBlockInterval is the max block interval could be set
+	MaxBlockInterval = 10 * time.Minute
+	// MinRoundTTL is the min ttl of each step of a round could be set
+	MinRoundTTL = 100 * time.Millisecond
+)
+
+var (
+	adminKey         = []byte("admin")
+	paramsHistoryKey = []byte("paramsHistory")
+)
+
+type (
+	// Protocol defines the protocol of the on-chain governance, which lets the admin tune the roll-DPoS timing
+	// parameters with the set consensus params actions, without a coordinated upgrade of the nodes. The parameters set
+	// in an epoch take effect from the first height of the next epoch, so that all the delegates switch to them at the
+	// same height. All the parameters set are kept by the heights they take effect, so that the ones in effect at any
+	// height could be read
+	Protocol struct {
+		keyPrefix []byte
+		addr      address.Address
+		epochSize uint64
+	}
+
+	// ConsensusParams are the roll-DPoS timing parameters taking effect from a height
+	ConsensusParams struct {
+		// Height is the first height the parameters take effect
+		Height                       uint64
+		BlockInterval                time.Duration
+		AcceptBlockTTL               time.Duration
+		AcceptProposalEndorsementTTL time.Duration
+		AcceptLockEndorsementTTL     time.Duration
+	}
+
+	// admin stores the admin of the governance
+	admin struct {
+		admin address.Address
+	}
+
+	// paramsHistory stores all the consensus params set, sorted by the heights they take effect
+	paramsHistory []ConsensusParams
+)
+
+// Serialize serializes admin state into bytes
+func (a admin) Serialize() ([]byte, error) {
+	return proto.Marshal(&governancepb.Admin{Admin: a.admin.Bytes()})
+}
+
+// Deserialize deserializes bytes into admin state
+func (a *admin) Deserialize(data []byte) error {
+	gen := governancepb.Admin{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	var err error
+	a.admin, err = address.FromBytes(gen.Admin)
+	return err
+}
+
+// Serialize serializes consensus params state into bytes
+func (c ConsensusParams) Serialize() ([]byte, error) {
+	return proto.Marshal(c.toProto())
+}
+
+func (c ConsensusParams) toProto() *governancepb.ConsensusParams {
+	return &governancepb.ConsensusParams{
+		Height:                       c.Height,
+		BlockInterval:                uint64(c.BlockInterval / time.Millisecond),
+		AcceptBlockTTL:               uint64(c.AcceptBlockTTL / time.Millisecond),
+		AcceptProposalEndorsementTTL: uint64(c.AcceptProposalEndorsementTTL / time.Millisecond),
+		AcceptLockEndorsementTTL:     uint64(c.AcceptLockEndorsementTTL / time.Millisecond),
+	}
+}
+
+// Deserialize deserializes bytes into consensus params state
+func (c *ConsensusParams) Deserialize(data []byte) error {
+	gen := governancepb.ConsensusParams{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	return c.loadProto(&gen)
+}
+
+func (c *ConsensusParams) loadProto(gen *governancepb.ConsensusParams) error {
+	durations := make([]time.Duration, 4)
+	for i, ms := range []uint64{
+		gen.BlockInterval,
+		gen.AcceptBlockTTL,
+		gen.AcceptProposalEndorsementTTL,
+		gen.AcceptLockEndorsementTTL,
+	} {
+		if ms > uint64(math.MaxInt64/int64(time.Millisecond)) {
+			return errors.Errorf("duration %d ms overflows", ms)
+		}
+		durations[i] = time.Duration(ms) * time.Millisecond
+	}
+	*c = ConsensusParams{
+		Height:                       gen.Height,
+		BlockInterval:                durations[0],
+		AcceptBlockTTL:               durations[1],
+		AcceptProposalEndorsementTTL: durations[2],
+		AcceptLockEndorsementTTL:     durations[3],
+	}
+	return nil
+}
+
+// Serialize serializes consensus params history state into bytes
+func (h paramsHistory) Serialize() ([]byte, error) {
+	gen := governancepb.ConsensusParamsHistory{}
+	for _, params := range h {
+		gen.Params = append(gen.Params, params.toProto())
+	}
+	return proto.Marshal(&gen)
+}
+
+// Deserialize deserializes bytes into consensus params history state
+func (h *paramsHistory) Deserialize(data []byte) error {
+	gen := governancepb.ConsensusParamsHistory{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	*h = make(paramsHistory, len(gen.Params))
+	for i, paramsPb := range gen.Params {
+		if err := (*h)[i].loadProto(paramsPb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewProtocol instantiates a governance protocol instance, where an epoch consists of the blocks of the number of
+// delegates times the number of sub-epochs
+func NewProtocol(numDelegates uint64, numSubEpochs uint64) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of governance protocol", zap.Error(err))
+	}
+	if numDelegates == 0 || numSubEpochs == 0 || numDelegates > math.MaxUint64/numSubEpochs {
+		log.L().Panic(
+			"Invalid epoch size of governance protocol",
+			zap.Uint64("numDelegates", numDelegates),
+			zap.Uint64("numSubEpochs", numSubEpochs),
+		)
+	}
+	return &Protocol{
+		keyPrefix: h[:],
+		addr:      addr,
+		epochSize: numDelegates * numSubEpochs,
+	}
+}
+
+// ReadConsensusParams reads the consensus params in effect at a height from the committed states, which is nil if no
+// params have been set for the height. It's meant for the consensus to read the params out of the handling of a block.
+// The params in effect at a height are set before the height, so they could be read as long as the height is above
+// the tip of the committed states
+func ReadConsensusParams(sr protocol.StateReader, height uint64) (*ConsensusParams, error) {
+	keyPrefix := hash.Hash160b([]byte(ProtocolID))
+	return consensusParams(
+		func(key []byte, s interface{}) error {
+			return sr.State(hash.Hash160b(append(keyPrefix[:], key...)), s)
+		},
+		height,
+	)
+}
+
+// Initialize initializes the governance protocol by setting the admin. It should only be called when creating the
+// genesis states
+func (p *Protocol) Initialize(_ context.Context, sm protocol.StateManager, adminAddr address.Address) error {
+	return p.putState(sm, adminKey, &admin{admin: adminAddr})
+}
+
+// Admin returns the address of the admin
+func (p *Protocol) Admin(_ context.Context, sm protocol.StateManager) (address.Address, error) {
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return nil, err
+	}
+	return a.admin, nil
+}
+
+// ConsensusParams returns the consensus params in effect at a height, which is nil if no params have been set for the
+// height
+func (p *Protocol) ConsensusParams(
+	_ context.Context,
+	sm protocol.StateManager,
+	height uint64,
+) (*ConsensusParams, error) {
+	return consensusParams(func(key []byte, s interface{}) error { return p.state(sm, key, s) }, height)
+}
+
+// SetConsensusParams sets the consensus params taking effect from the first height of the next epoch, which replace
+// the ones set earlier in the same epoch. Only the admin could make this change
+func (p *Protocol) SetConsensusParams(ctx context.Context, sm protocol.StateManager, params ConsensusParams) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	if err := p.assertAdmin(ctx, sm); err != nil {
+		return err
+	}
+	if err := params.validate(); err != nil {
+		return err
+	}
+	if raCtx.BlockHeight == 0 {
+		return errors.New("consensus params could not be set in the genesis block")
+	}
+	epochStart := (raCtx.BlockHeight-1)/p.epochSize*p.epochSize + 1
+	if epochStart > math.MaxUint64-p.epochSize {
+		return errors.Errorf("next epoch of height %d overflows", raCtx.BlockHeight)
+	}
+	params.Height = epochStart + p.epochSize
+	history := paramsHistory{}
+	if err := p.state(sm, paramsHistoryKey, &history); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	if n := len(history); n > 0 && history[n-1].Height == params.Height {
+		history[n-1] = params
+	} else {
+		history = append(history, params)
+	}
+	return p.putState(sm, paramsHistoryKey, &history)
+}
+
+// Handle handles the set consensus params actions
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	s, ok := act.(*action.SetConsensusParams)
+	if !ok {
+		return nil, nil
+	}
+	if err := p.SetConsensusParams(ctx, sm, fromAction(s)); err != nil {
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	return p.settleAction(ctx, sm, 0), nil
+}
+
+// Validate validates the set consensus params actions
+func (p *Protocol) Validate(_ context.Context, act action.Action) error {
+	s, ok := act.(*action.SetConsensusParams)
+	if !ok {
+		return nil
+	}
+	params := fromAction(s)
+	return params.validate()
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "Admin":
+		addr, err := p.Admin(ctx, sm)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(addr.String()), nil
+	case "ConsensusParams":
+		if len(args) != 1 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		height, err := strconv.ParseUint(string(args[0]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when decoding height %s", string(args[0]))
+		}
+		params, err := p.ConsensusParams(ctx, sm, height)
+		if err != nil {
+			return nil, err
+		}
+		if params == nil {
+			return nil, errors.Wrapf(state.ErrStateNotExist, "no consensus params for height %d", height)
+		}
+		return params.Serialize()
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+// validate checks the params are within the bounds, and the rounds fit in the block interval in the same way as the
+// roll-DPoS config
+func (c *ConsensusParams) validate() error {
+	if c.BlockInterval < MinBlockInterval || c.BlockInterval > MaxBlockInterval {
+		return errors.Errorf("block interval should be within [%s, %s]", MinBlockInterval, MaxBlockInterval)
+	}
+	for _, ttl := range []time.Duration{c.AcceptBlockTTL, c.AcceptProposalEndorsementTTL, c.AcceptLockEndorsementTTL} {
+		if ttl < MinRoundTTL || ttl >= c.BlockInterval {
+			return errors.Errorf("accept ttls should be within [%s, %s)", MinRoundTTL, c.BlockInterval)
+		}
+	}
+	if c.AcceptBlockTTL+c.AcceptProposalEndorsementTTL+c.AcceptLockEndorsementTTL >= c.BlockInterval {
+		return errors.New("ttl sum should be less than block interval")
+	}
+	return nil
+}
+
+// consensusParams reads the params in effect at a height from the states read by the state function
+func consensusParams(stateFn func([]byte, interface{}) error, height uint64) (*ConsensusParams, error) {
+	history := paramsHistory{}
+	if err := stateFn(paramsHistoryKey, &history); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Height <= height {
+			params := history[i]
+			return &params, nil
+		}
+	}
+	return nil, nil
+}
+
+func fromAction(s *action.SetConsensusParams) ConsensusParams {
+	return ConsensusParams{
+		BlockInterval:                s.BlockInterval(),
+		AcceptBlockTTL:               s.AcceptBlockTTL(),
+		AcceptProposalEndorsementTTL: s.AcceptProposalEndorsementTTL(),
+		AcceptLockEndorsementTTL:     s.AcceptLockEndorsementTTL(),
+	}
+}
+
+func (p *Protocol) assertAdmin(ctx context.Context, sm protocol.StateManager) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	adminAddr, err := p.Admin(ctx, sm)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(adminAddr.Bytes(), raCtx.Caller.Bytes()) {
+		return errors.Errorf("%s is not the governance admin", raCtx.Caller.String())
+	}
+	return nil
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func (p *Protocol) settleAction(ctx context.Context, sm protocol.StateManager, status uint64) *action.Receipt {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas)
+}
+
+func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
+	acc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if nonce > acc.Nonce {
+		acc.Nonce = nonce
+	}
+	return util.StoreAccount(sm, addr.String(), acc)
+}
+
+func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsumed uint64) *action.Receipt {
+	return &action.Receipt{
+		Status:          status,
+		ActHash:         actHash,
+		GasConsumed:     gasConsumed,
+		ContractAddress: p.addr.String(),
+	}
+}
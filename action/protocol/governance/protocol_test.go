@@ -0,0 +1,141 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package governance
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	// An epoch consists of 4 blocks
+	p := NewProtocol(4, 1)
+
+	admin := ta.Addrinfo["producer"]
+	alfa := ta.Addrinfo["alfa"]
+	runCtx := func(caller address.Address, height uint64) context.Context {
+		return protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight:  height,
+			Caller:       caller,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: 10000,
+			Nonce:        1,
+		})
+	}
+	setParams := func(interval time.Duration) action.SetConsensusParams {
+		sb := action.SetConsensusParamsBuilder{}
+		return sb.SetBlockInterval(interval).SetAcceptTTLs(time.Second, time.Second, time.Second).Build()
+	}
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	require.NoError(p.Initialize(context.Background(), ws, admin))
+	require.NoError(sf.Commit(ws))
+
+	// No params are set in the genesis
+	params, err := ReadConsensusParams(sf, 1)
+	require.NoError(err)
+	require.Nil(params)
+
+	// Only the admin is able to set the params
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	set := setParams(5 * time.Second)
+	receipt, err := p.Handle(runCtx(alfa, 2), &set, ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	receipt, err = p.Handle(runCtx(admin, 2), &set, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	// The later params in the same epoch replace the earlier ones
+	set = setParams(6 * time.Second)
+	receipt, err = p.Handle(runCtx(admin, 4), &set, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	require.NoError(sf.Commit(ws))
+
+	// The params take effect from the first height of the next epoch
+	params, err = ReadConsensusParams(sf, 4)
+	require.NoError(err)
+	require.Nil(params)
+	params, err = ReadConsensusParams(sf, 5)
+	require.NoError(err)
+	require.Equal(&ConsensusParams{
+		Height:                       5,
+		BlockInterval:                6 * time.Second,
+		AcceptBlockTTL:               time.Second,
+		AcceptProposalEndorsementTTL: time.Second,
+		AcceptLockEndorsementTTL:     time.Second,
+	}, params)
+
+	// The earlier params stay in effect until the newer ones take effect, and are still read at the earlier heights
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	set = setParams(8 * time.Second)
+	receipt, err = p.Handle(runCtx(admin, 10), &set, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	require.NoError(sf.Commit(ws))
+	for height, interval := range map[uint64]time.Duration{
+		5:  6 * time.Second,
+		12: 6 * time.Second,
+		13: 8 * time.Second,
+		20: 8 * time.Second,
+	} {
+		params, err = ReadConsensusParams(sf, height)
+		require.NoError(err)
+		require.Equal(interval, params.BlockInterval)
+	}
+	params, err = ReadConsensusParams(sf, 4)
+	require.NoError(err)
+	require.Nil(params)
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	data, err := p.ReadState(context.Background(), ws, []byte("ConsensusParams"), []byte("14"))
+	require.NoError(err)
+	params = &ConsensusParams{}
+	require.NoError(params.Deserialize(data))
+	require.Equal(uint64(13), params.Height)
+	_, err = p.ReadState(context.Background(), ws, []byte("ConsensusParams"), []byte("1"))
+	require.Error(err)
+	data, err = p.ReadState(context.Background(), ws, []byte("Admin"))
+	require.NoError(err)
+	require.Equal(admin.String(), string(data))
+
+	// The rounds should fit in the block interval, and the params should be within the bounds
+	for _, set := range []action.SetConsensusParams{
+		setParams(3 * time.Second),
+		setParams(MaxBlockInterval + time.Second),
+		(&action.SetConsensusParamsBuilder{}).SetBlockInterval(5*time.Second).
+			SetAcceptTTLs(time.Millisecond, time.Second, time.Second).Build(),
+	} {
+		require.Error(p.Validate(context.Background(), &set))
+		receipt, err = p.Handle(runCtx(admin, 14), &set, ws)
+		require.NoError(err)
+		require.Equal(uint64(1), receipt.Status)
+	}
+}
@@ -110,6 +110,7 @@ func (p *Protocol) mutateDeposit(
 	}
 	depositIndex := subChain.DepositCount
 	subChain.DepositCount++
+	subChain.LockedAmount = big.NewInt(0).Add(subChain.LockedAmount, deposit.Amount())
 	if err := sm.PutState(byteutil.BytesTo20B(addr.Bytes()), subChain); err != nil {
 		return nil, err
 	}
@@ -29,6 +29,8 @@ type SubChain struct {
 	OwnerPublicKey     keypair.PublicKey
 	CurrentHeight      uint64
 	DepositCount       uint64
+	// LockedAmount is the amount of the token deposited to sub-chain and not withdrawn yet
+	LockedAmount *big.Int
 }
 
 // Serialize serializes sub-chain state into bytes
@@ -48,6 +50,9 @@ func (bs SubChain) Serialize() ([]byte, error) {
 	if bs.OperationDeposit != nil {
 		gen.OperationDeposit = bs.OperationDeposit.Bytes()
 	}
+	if bs.LockedAmount != nil {
+		gen.LockedAmount = bs.LockedAmount.Bytes()
+	}
 	return proto.Marshal(gen)
 }
 
@@ -66,6 +71,7 @@ func (bs *SubChain) Deserialize(data []byte) error {
 		ChainID:            gen.ChainID,
 		SecurityDeposit:    &big.Int{},
 		OperationDeposit:   &big.Int{},
+		LockedAmount:       &big.Int{},
 		StartHeight:        gen.StartHeight,
 		StopHeight:         gen.StopHeight,
 		ParentHeightOffset: gen.ParentHeightOffset,
@@ -75,6 +81,7 @@ func (bs *SubChain) Deserialize(data []byte) error {
 	}
 	bs.SecurityDeposit.SetBytes(gen.SecurityDeposit)
 	bs.OperationDeposit.SetBytes(gen.OperationDeposit)
+	bs.LockedAmount.SetBytes(gen.LockedAmount)
 	return nil
 }
 
@@ -257,3 +264,34 @@ func (bs *Deposit) Deserialize(data []byte) error {
 	bs.Amount.SetBytes(gen.Amount)
 	return nil
 }
+
+// Withdraw represents the state of a withdraw settled on main-chain
+type Withdraw struct {
+	Amount *big.Int
+	Addr   []byte
+}
+
+// Serialize serializes withdraw state into bytes
+func (bs Withdraw) Serialize() ([]byte, error) {
+	gen := &mainchainpb.Withdraw{
+		Address: bs.Addr,
+	}
+	if bs.Amount != nil {
+		gen.Amount = bs.Amount.Bytes()
+	}
+	return proto.Marshal(gen)
+}
+
+// Deserialize deserializes bytes into withdraw state
+func (bs *Withdraw) Deserialize(data []byte) error {
+	gen := &mainchainpb.Withdraw{}
+	if err := proto.Unmarshal(data, gen); err != nil {
+		return err
+	}
+	*bs = Withdraw{
+		Amount: &big.Int{},
+		Addr:   gen.Address,
+	}
+	bs.Amount.SetBytes(gen.Amount)
+	return nil
+}
@@ -30,6 +30,7 @@ func TestSubChainState(t *testing.T) {
 		OwnerPublicKey:     testaddress.Keyinfo["producer"].PubKey,
 		CurrentHeight:      200,
 		DepositCount:       300,
+		LockedAmount:       big.NewInt(400),
 	}
 	data, err := sc1.Serialize()
 	require.NoError(t, err)
@@ -103,3 +104,18 @@ func TestSubChainsInOperation(t *testing.T) {
 		assert.True(t, ok)
 	}
 }
+
+func TestWithdrawState(t *testing.T) {
+	t.Parallel()
+
+	w1 := Withdraw{
+		Amount: big.NewInt(100),
+		Addr:   testaddress.Addrinfo["producer"].Bytes(),
+	}
+	data, err := w1.Serialize()
+	require.NoError(t, err)
+
+	var w2 Withdraw
+	require.NoError(t, w2.Deserialize(data))
+	require.Equal(t, w1, w2)
+}
@@ -28,6 +28,7 @@ type SubChain struct {
 	OwnerPublicKey       []byte   `protobuf:"bytes,7,opt,name=ownerPublicKey,proto3" json:"ownerPublicKey,omitempty"`
 	CurrentHeight        uint64   `protobuf:"varint,8,opt,name=currentHeight,proto3" json:"currentHeight,omitempty"`
 	DepositCount         uint64   `protobuf:"varint,9,opt,name=depositCount,proto3" json:"depositCount,omitempty"`
+	LockedAmount         []byte   `protobuf:"bytes,10,opt,name=lockedAmount,proto3" json:"lockedAmount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -37,7 +38,7 @@ func (m *SubChain) Reset()         { *m = SubChain{} }
 func (m *SubChain) String() string { return proto.CompactTextString(m) }
 func (*SubChain) ProtoMessage()    {}
 func (*SubChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mainchain_9b64ad107a8bc8dd, []int{0}
+	return fileDescriptor_mainchain_b5798e4392d5de35, []int{0}
 }
 func (m *SubChain) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubChain.Unmarshal(m, b)
@@ -120,6 +121,13 @@ func (m *SubChain) GetDepositCount() uint64 {
 	return 0
 }
 
+func (m *SubChain) GetLockedAmount() []byte {
+	if m != nil {
+		return m.LockedAmount
+	}
+	return nil
+}
+
 type MerkleRoot struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -132,7 +140,7 @@ func (m *MerkleRoot) Reset()         { *m = MerkleRoot{} }
 func (m *MerkleRoot) String() string { return proto.CompactTextString(m) }
 func (*MerkleRoot) ProtoMessage()    {}
 func (*MerkleRoot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mainchain_9b64ad107a8bc8dd, []int{1}
+	return fileDescriptor_mainchain_b5798e4392d5de35, []int{1}
 }
 func (m *MerkleRoot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MerkleRoot.Unmarshal(m, b)
@@ -181,7 +189,7 @@ func (m *BlockProof) Reset()         { *m = BlockProof{} }
 func (m *BlockProof) String() string { return proto.CompactTextString(m) }
 func (*BlockProof) ProtoMessage()    {}
 func (*BlockProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mainchain_9b64ad107a8bc8dd, []int{2}
+	return fileDescriptor_mainchain_b5798e4392d5de35, []int{2}
 }
 func (m *BlockProof) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockProof.Unmarshal(m, b)
@@ -248,7 +256,7 @@ func (m *InOperation) Reset()         { *m = InOperation{} }
 func (m *InOperation) String() string { return proto.CompactTextString(m) }
 func (*InOperation) ProtoMessage()    {}
 func (*InOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mainchain_9b64ad107a8bc8dd, []int{3}
+	return fileDescriptor_mainchain_b5798e4392d5de35, []int{3}
 }
 func (m *InOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InOperation.Unmarshal(m, b)
@@ -293,7 +301,7 @@ func (m *SubChainsInOperation) Reset()         { *m = SubChainsInOperation{} }
 func (m *SubChainsInOperation) String() string { return proto.CompactTextString(m) }
 func (*SubChainsInOperation) ProtoMessage()    {}
 func (*SubChainsInOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mainchain_9b64ad107a8bc8dd, []int{4}
+	return fileDescriptor_mainchain_b5798e4392d5de35, []int{4}
 }
 func (m *SubChainsInOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubChainsInOperation.Unmarshal(m, b)
@@ -333,7 +341,7 @@ func (m *Deposit) Reset()         { *m = Deposit{} }
 func (m *Deposit) String() string { return proto.CompactTextString(m) }
 func (*Deposit) ProtoMessage()    {}
 func (*Deposit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mainchain_9b64ad107a8bc8dd, []int{5}
+	return fileDescriptor_mainchain_b5798e4392d5de35, []int{5}
 }
 func (m *Deposit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Deposit.Unmarshal(m, b)
@@ -374,6 +382,52 @@ func (m *Deposit) GetConfirmed() bool {
 	return false
 }
 
+type Withdraw struct {
+	Amount               []byte   `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Address              []byte   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Withdraw) Reset()         { *m = Withdraw{} }
+func (m *Withdraw) String() string { return proto.CompactTextString(m) }
+func (*Withdraw) ProtoMessage()    {}
+func (*Withdraw) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mainchain_b5798e4392d5de35, []int{6}
+}
+func (m *Withdraw) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Withdraw.Unmarshal(m, b)
+}
+func (m *Withdraw) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Withdraw.Marshal(b, m, deterministic)
+}
+func (dst *Withdraw) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Withdraw.Merge(dst, src)
+}
+func (m *Withdraw) XXX_Size() int {
+	return xxx_messageInfo_Withdraw.Size(m)
+}
+func (m *Withdraw) XXX_DiscardUnknown() {
+	xxx_messageInfo_Withdraw.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Withdraw proto.InternalMessageInfo
+
+func (m *Withdraw) GetAmount() []byte {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *Withdraw) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SubChain)(nil), "mainchainpb.SubChain")
 	proto.RegisterType((*MerkleRoot)(nil), "mainchainpb.MerkleRoot")
@@ -381,38 +435,41 @@ func init() {
 	proto.RegisterType((*InOperation)(nil), "mainchainpb.InOperation")
 	proto.RegisterType((*SubChainsInOperation)(nil), "mainchainpb.SubChainsInOperation")
 	proto.RegisterType((*Deposit)(nil), "mainchainpb.Deposit")
-}
-
-func init() { proto.RegisterFile("mainchain.proto", fileDescriptor_mainchain_9b64ad107a8bc8dd) }
-
-var fileDescriptor_mainchain_9b64ad107a8bc8dd = []byte{
-	// 446 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x4f, 0x8f, 0xd3, 0x30,
-	0x10, 0xc5, 0x95, 0x34, 0xfd, 0x37, 0xdd, 0x3f, 0x30, 0x5a, 0x2d, 0x3e, 0x20, 0x14, 0x45, 0x08,
-	0x45, 0x68, 0xe9, 0x01, 0x24, 0x38, 0xc3, 0xf6, 0xc0, 0x0a, 0xa1, 0xae, 0xc2, 0x89, 0xa3, 0x9b,
-	0xb8, 0xd4, 0xda, 0xd6, 0x8e, 0x6c, 0x07, 0xb4, 0x1f, 0x94, 0x2b, 0x9f, 0x05, 0x75, 0x62, 0xd3,
-	0x34, 0xe5, 0xd6, 0xf7, 0xf3, 0xeb, 0xf4, 0x79, 0xfc, 0x0a, 0x97, 0x3b, 0x2e, 0x55, 0xb9, 0xe1,
-	0x52, 0xcd, 0x6b, 0xa3, 0x9d, 0xc6, 0xd9, 0x3f, 0x50, 0xaf, 0xb2, 0x3f, 0x31, 0x4c, 0xbe, 0x35,
-	0xab, 0xdb, 0xbd, 0x44, 0x06, 0x63, 0xe2, 0x77, 0x0b, 0x16, 0xa5, 0x51, 0x7e, 0x5e, 0x04, 0x89,
-	0x39, 0x5c, 0x5a, 0x51, 0x36, 0x46, 0xba, 0xc7, 0x85, 0xa8, 0xb5, 0x95, 0x8e, 0xc5, 0x69, 0x94,
-	0x9f, 0x15, 0x7d, 0x8c, 0xaf, 0xe1, 0x89, 0xae, 0x85, 0xe1, 0x4e, 0x6a, 0x15, 0xac, 0x03, 0xb2,
-	0x9e, 0x70, 0x4c, 0x61, 0x66, 0x1d, 0x37, 0xee, 0xb3, 0x90, 0x3f, 0x36, 0x8e, 0x25, 0x69, 0x94,
-	0x27, 0x45, 0x17, 0xe1, 0x0b, 0x00, 0xeb, 0x74, 0xed, 0x0d, 0x43, 0x32, 0x74, 0x08, 0xce, 0x01,
-	0x6b, 0x6e, 0x84, 0xf2, 0xfe, 0xe5, 0x7a, 0x6d, 0x85, 0x63, 0x23, 0xf2, 0xfd, 0xe7, 0x04, 0x5f,
-	0xc1, 0x85, 0xfe, 0xa5, 0x84, 0xb9, 0x6f, 0x56, 0x5b, 0x59, 0x7e, 0x11, 0x8f, 0x6c, 0x4c, 0xd9,
-	0x7a, 0x14, 0x5f, 0xc2, 0x79, 0xd9, 0x98, 0xc3, 0xd7, 0xd9, 0x84, 0x46, 0x1e, 0x43, 0xcc, 0xe0,
-	0xac, 0x6a, 0xaf, 0x72, 0xab, 0x1b, 0xe5, 0xd8, 0x94, 0x4c, 0x47, 0x2c, 0x7b, 0x0f, 0xf0, 0x55,
-	0x98, 0x87, 0xad, 0x28, 0xb4, 0x76, 0x88, 0x90, 0x28, 0xbe, 0x13, 0xb4, 0xde, 0x69, 0x41, 0x9f,
-	0xf1, 0x0a, 0x86, 0x3f, 0xf9, 0xb6, 0x11, 0x7e, 0xa3, 0xad, 0xc8, 0x7e, 0x47, 0x00, 0x9f, 0xb6,
-	0xba, 0x7c, 0xb8, 0x37, 0x5a, 0xaf, 0xe9, 0x01, 0xfc, 0x33, 0x7d, 0xac, 0x2a, 0x23, 0xac, 0xf5,
-	0x33, 0xfa, 0x18, 0xaf, 0x61, 0xb4, 0x69, 0x33, 0xc7, 0x14, 0xc7, 0x2b, 0x7c, 0x03, 0x43, 0xa3,
-	0xb5, 0xb3, 0x6c, 0x90, 0x0e, 0xf2, 0xd9, 0xdb, 0x67, 0xf3, 0x4e, 0x0d, 0xe6, 0x87, 0x88, 0x45,
-	0xeb, 0xc2, 0x1b, 0x78, 0x5a, 0x1b, 0x5d, 0x35, 0x65, 0x77, 0x59, 0x09, 0x25, 0x3c, 0x3d, 0xd8,
-	0xc7, 0x0b, 0x30, 0xc4, 0x1b, 0xb6, 0xf1, 0x7a, 0x38, 0xfb, 0x00, 0xb3, 0x3b, 0xb5, 0x0c, 0x4d,
-	0xc0, 0x0b, 0x88, 0x65, 0xe5, 0xdb, 0x16, 0xcb, 0x6a, 0x5f, 0xc1, 0x30, 0xa0, 0x5d, 0x47, 0x90,
-	0xd9, 0x02, 0xae, 0x42, 0x51, 0x6d, 0x77, 0xc2, 0x0d, 0x24, 0x52, 0x2d, 0x6b, 0x16, 0xd1, 0xb5,
-	0xd8, 0xd1, 0xb5, 0x3a, 0xbe, 0x82, 0x5c, 0xd9, 0x77, 0x18, 0x87, 0xf6, 0x5d, 0xc3, 0x88, 0xef,
-	0xe8, 0xdd, 0x22, 0xfa, 0x25, 0xaf, 0xf6, 0x11, 0xf8, 0x71, 0x04, 0x2f, 0xf1, 0x39, 0x4c, 0x4b,
-	0xad, 0xd6, 0xd2, 0xec, 0x44, 0x45, 0xa5, 0x9e, 0x14, 0x07, 0xb0, 0x1a, 0xd1, 0xdf, 0xeb, 0xdd,
-	0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x6d, 0xd2, 0x65, 0xea, 0x71, 0x03, 0x00, 0x00,
+	proto.RegisterType((*Withdraw)(nil), "mainchainpb.Withdraw")
+}
+
+func init() { proto.RegisterFile("mainchain.proto", fileDescriptor_mainchain_b5798e4392d5de35) }
+
+var fileDescriptor_mainchain_b5798e4392d5de35 = []byte{
+	// 470 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x4f, 0x8f, 0xd3, 0x30,
+	0x10, 0xc5, 0x95, 0xfe, 0xef, 0x74, 0xff, 0xc0, 0x68, 0xb5, 0xf8, 0x80, 0x50, 0x14, 0x21, 0x14,
+	0xa1, 0xa5, 0x07, 0x90, 0xe0, 0xc2, 0x65, 0xd9, 0x1e, 0x58, 0x21, 0xd4, 0x55, 0x38, 0x20, 0x8e,
+	0x6e, 0xe2, 0x52, 0x6b, 0x5b, 0x3b, 0xb2, 0x1d, 0x56, 0x7b, 0xe5, 0x3b, 0xf2, 0x7d, 0x50, 0x26,
+	0x36, 0x4d, 0xba, 0x5c, 0xf6, 0xd6, 0xf9, 0xf9, 0x75, 0xfc, 0xc6, 0xf3, 0x02, 0xa7, 0x3b, 0x2e,
+	0x55, 0xbe, 0xe1, 0x52, 0xcd, 0x4b, 0xa3, 0x9d, 0xc6, 0xd9, 0x3f, 0x50, 0xae, 0x92, 0xdf, 0x7d,
+	0x98, 0x7c, 0xab, 0x56, 0x57, 0x75, 0x89, 0x0c, 0xc6, 0xc4, 0xaf, 0x17, 0x2c, 0x8a, 0xa3, 0xf4,
+	0x38, 0x0b, 0x25, 0xa6, 0x70, 0x6a, 0x45, 0x5e, 0x19, 0xe9, 0xee, 0x17, 0xa2, 0xd4, 0x56, 0x3a,
+	0xd6, 0x8b, 0xa3, 0xf4, 0x28, 0x3b, 0xc4, 0xf8, 0x1a, 0x9e, 0xe8, 0x52, 0x18, 0xee, 0xa4, 0x56,
+	0x41, 0xda, 0x27, 0xe9, 0x03, 0x8e, 0x31, 0xcc, 0xac, 0xe3, 0xc6, 0x7d, 0x16, 0xf2, 0xe7, 0xc6,
+	0xb1, 0x41, 0x1c, 0xa5, 0x83, 0xac, 0x8d, 0xf0, 0x05, 0x80, 0x75, 0xba, 0xf4, 0x82, 0x21, 0x09,
+	0x5a, 0x04, 0xe7, 0x80, 0x25, 0x37, 0x42, 0x79, 0xfd, 0x72, 0xbd, 0xb6, 0xc2, 0xb1, 0x11, 0xe9,
+	0xfe, 0x73, 0x82, 0xaf, 0xe0, 0x44, 0xdf, 0x29, 0x61, 0x6e, 0xaa, 0xd5, 0x56, 0xe6, 0x5f, 0xc4,
+	0x3d, 0x1b, 0x93, 0xb7, 0x03, 0x8a, 0x2f, 0xe1, 0x38, 0xaf, 0xcc, 0xfe, 0xef, 0x6c, 0x42, 0x2d,
+	0xbb, 0x10, 0x13, 0x38, 0x2a, 0x9a, 0x51, 0xae, 0x74, 0xa5, 0x1c, 0x9b, 0x92, 0xa8, 0xc3, 0x6a,
+	0xcd, 0x56, 0xe7, 0xb7, 0xa2, 0xb8, 0xdc, 0x91, 0x06, 0xe8, 0xbe, 0x0e, 0x4b, 0xde, 0x03, 0x7c,
+	0x15, 0xe6, 0x76, 0x2b, 0x32, 0xad, 0x1d, 0x22, 0x0c, 0x14, 0xdf, 0x09, 0x5a, 0xc1, 0x34, 0xa3,
+	0xdf, 0x78, 0x06, 0xc3, 0x5f, 0x7c, 0x5b, 0x09, 0xff, 0xea, 0x4d, 0x91, 0xfc, 0x89, 0x00, 0x3e,
+	0xd5, 0x9d, 0x6e, 0x8c, 0xd6, 0x6b, 0x5a, 0x92, 0x5f, 0xe5, 0x65, 0x51, 0x18, 0x61, 0xad, 0xef,
+	0x71, 0x88, 0xf1, 0x1c, 0x46, 0x9b, 0x66, 0xae, 0x1e, 0x59, 0xf6, 0x15, 0xbe, 0x81, 0xa1, 0xd1,
+	0xda, 0x59, 0xd6, 0x8f, 0xfb, 0xe9, 0xec, 0xed, 0xb3, 0x79, 0x2b, 0x2a, 0xf3, 0xbd, 0xc5, 0xac,
+	0x51, 0xe1, 0x05, 0x3c, 0x2d, 0x8d, 0x2e, 0xaa, 0xbc, 0xfd, 0xa0, 0x03, 0x72, 0xf8, 0xf0, 0xa0,
+	0xb6, 0x17, 0x60, 0xb0, 0x37, 0x6c, 0xec, 0x1d, 0xe0, 0xe4, 0x03, 0xcc, 0xae, 0xd5, 0x32, 0xa4,
+	0x05, 0x4f, 0xa0, 0x27, 0x0b, 0x9f, 0xc8, 0x9e, 0x2c, 0xea, 0x98, 0x86, 0x06, 0xcd, 0x73, 0x84,
+	0x32, 0x59, 0xc0, 0x59, 0x08, 0xb3, 0x6d, 0x77, 0xb8, 0x80, 0x81, 0x54, 0xcb, 0x92, 0x45, 0x34,
+	0x16, 0xeb, 0x8c, 0xd5, 0xd2, 0x65, 0xa4, 0x4a, 0x7e, 0xc0, 0x38, 0x24, 0xf4, 0x1c, 0x46, 0xbc,
+	0xd9, 0x5b, 0x44, 0x37, 0xf9, 0xaa, 0xb6, 0xc0, 0xbb, 0x16, 0x7c, 0x89, 0xcf, 0x61, 0x9a, 0x6b,
+	0xb5, 0x96, 0x66, 0x27, 0x0a, 0x0a, 0xfe, 0x24, 0xdb, 0x83, 0xe4, 0x23, 0x4c, 0xbe, 0x4b, 0xb7,
+	0x29, 0x0c, 0xbf, 0x7b, 0x7c, 0xef, 0xd5, 0x88, 0x3e, 0xe0, 0x77, 0x7f, 0x07, 0x00, 0x3d, 0x6a,
+	0xb8, 0x10, 0xd3, 0x03, 0x00, 0x00,
 }
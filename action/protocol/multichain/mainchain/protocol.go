@@ -9,6 +9,7 @@ package mainchain
 import (
 	"context"
 	"math/big"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -72,6 +73,10 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 		if err := p.handleStopSubChain(ctx, act, sm); err != nil {
 			return nil, errors.Wrapf(err, "error when handling stop sub-chain action")
 		}
+	case *action.SettleWithdraw:
+		if err := p.handleSettleWithdraw(ctx, act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling withdraw settlement action")
+		}
 	}
 	// The action is not handled by this handler or no error
 	return nil, nil
@@ -89,7 +94,7 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
 			return errors.Wrapf(err, "error when validating start sub-chain action")
 		}
 	case *action.PutBlock:
-		if err := p.validatePutBlock(act, nil); err != nil {
+		if err := p.validatePutBlock(nil, act, nil); err != nil {
 			return errors.Wrapf(err, "error when validating put sub-chain block action")
 		}
 	case *action.CreateDeposit:
@@ -100,11 +105,36 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
 		if _, _, err := p.validateDeposit(vaCtx.Caller, act, nil); err != nil {
 			return errors.Wrapf(err, "error when validating deposit creation action")
 		}
+	case *action.SettleWithdraw:
+		if _, _, _, err := p.validateSettleWithdraw(act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating withdraw settlement action")
+		}
 	}
 	// The action is not validated by this handler or no error
 	return nil
 }
 
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(_ context.Context, _ protocol.StateManager, method []byte, args ...[]byte) ([]byte, error) {
+	switch string(method) {
+	case "BlockProof":
+		if len(args) != 2 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		height, err := strconv.ParseUint(string(args[1]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when parsing height %s", string(args[1]))
+		}
+		bp, err := p.BlockProof(string(args[0]), height)
+		if err != nil {
+			return nil, err
+		}
+		return bp.Serialize()
+	default:
+		return nil, protocol.ErrUnimplemented
+	}
+}
+
 func (p *Protocol) account(sender string, sm protocol.StateManager) (*state.Account, error) {
 	if sm == nil {
 		return p.sf.AccountState(sender)
@@ -175,3 +205,39 @@ func (p *Protocol) SubChainsInOperation() (SubChainsInOperation, error) {
 	}
 	return subChainsInOp, nil
 }
+
+// BlockProof returns the block proof put by sub-chain at the given height
+func (p *Protocol) BlockProof(subChainAddr string, height uint64) (*BlockProof, error) {
+	return p.blockProof(nil, subChainAddr, height)
+}
+
+// VerifyBlockRoot verifies the merkle root of the given name against the block proof put by sub-chain at the given
+// height, e.g., the state root against which a withdrawal is proved
+func (p *Protocol) VerifyBlockRoot(subChainAddr string, height uint64, name string, root hash.Hash256) error {
+	bp, err := p.BlockProof(subChainAddr, height)
+	if err != nil {
+		return err
+	}
+	return bp.verifyRoot(name, root)
+}
+
+func (p *Protocol) blockProof(sm protocol.StateManager, subChainAddr string, height uint64) (*BlockProof, error) {
+	var bp BlockProof
+	if err := p.state(sm, blockProofKey(subChainAddr, height), &bp); err != nil {
+		return nil, errors.Wrapf(err, "error when loading block proof of %s at height %d", subChainAddr, height)
+	}
+	return &bp, nil
+}
+
+func (bp *BlockProof) verifyRoot(name string, root hash.Hash256) error {
+	for _, r := range bp.Roots {
+		if r.Name != name {
+			continue
+		}
+		if r.Value != root {
+			return errors.Errorf("%s root %x mismatches the one %x put at height %d", name, root, r.Value, bp.Height)
+		}
+		return nil
+	}
+	return errors.Errorf("%s root is not put at height %d", name, bp.Height)
+}
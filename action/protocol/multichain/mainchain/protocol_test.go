@@ -104,5 +104,5 @@ func TestAddSubChainActions(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, ap.Add(sscselp))
 
-	assert.Equal(t, 3, len(ap.PickActs()))
+	assert.Equal(t, 3, len(ap.PickActs(actpool.PickBudget{})))
 }
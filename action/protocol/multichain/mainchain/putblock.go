@@ -7,6 +7,7 @@
 package mainchain
 
 import (
+	"bytes"
 	"context"
 	"sort"
 
@@ -18,8 +19,10 @@ import (
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 func (p *Protocol) handlePutBlock(ctx context.Context, pb *action.PutBlock, sm protocol.StateManager) error {
@@ -28,7 +31,7 @@ func (p *Protocol) handlePutBlock(ctx context.Context, pb *action.PutBlock, sm p
 		log.S().Panic("Miss run action context")
 	}
 
-	if err := p.validatePutBlock(pb, sm); err != nil {
+	if err := p.validatePutBlock(raCtx.Caller, pb, sm); err != nil {
 		return err
 	}
 	proof := putBlockToBlockProof(raCtx.Caller, pb)
@@ -45,13 +48,34 @@ func (p *Protocol) handlePutBlock(ctx context.Context, pb *action.PutBlock, sm p
 	return util.StoreAccount(sm, raCtx.Caller.String(), acct)
 }
 
-func (p *Protocol) validatePutBlock(pb *action.PutBlock, sm protocol.StateManager) error {
-	// use owner address TODO
+// validatePutBlock validates the put block action. The owner of the sub-chain is only checked when the action runs,
+// i.e., with the state manager, because the sub-chain could be started by an action still pending in the actpool
+func (p *Protocol) validatePutBlock(caller address.Address, pb *action.PutBlock, sm protocol.StateManager) error {
+	if sm != nil {
+		subChainAddr, err := address.FromString(pb.SubChainAddress())
+		if err != nil {
+			return errors.Wrapf(err, "invalid sub-chain address %s", pb.SubChainAddress())
+		}
+		var subChain SubChain
+		if err := p.state(sm, byteutil.BytesTo20B(subChainAddr.Bytes()), &subChain); err != nil {
+			return errors.Wrapf(err, "error when loading the state of sub-chain %s", pb.SubChainAddress())
+		}
+		// only the owner of the sub-chain could put its blocks
+		ownerPKHash := keypair.HashPubKey(subChain.OwnerPublicKey)
+		if !bytes.Equal(ownerPKHash[:], caller.Bytes()) {
+			return errors.Errorf("sender %s is not the owner of sub-chain %s", caller.String(), pb.SubChainAddress())
+		}
+	}
 	// can only emit on one height
-	if _, exist := p.getBlockProof(pb.SubChainAddress(), pb.Height()); exist {
+	var bp BlockProof
+	switch err := p.state(sm, blockProofKey(pb.SubChainAddress(), pb.Height()), &bp); errors.Cause(err) {
+	case nil:
 		return errors.Errorf("block %d already exists", pb.Height())
+	case state.ErrStateNotExist:
+		return nil
+	default:
+		return err
 	}
-	return nil
 }
 
 func (p *Protocol) getBlockProof(addr string, height uint64) (BlockProof, bool) {
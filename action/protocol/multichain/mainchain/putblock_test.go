@@ -42,6 +42,7 @@ func TestHandlePutBlock(t *testing.T) {
 
 	addr := testaddress.Addrinfo["producer"]
 	key2 := testaddress.Keyinfo["echo"]
+	subChainAddr := testaddress.Addrinfo["alfa"]
 
 	ws, err := sf.NewWorkingSet()
 	require.NoError(t, err)
@@ -51,6 +52,10 @@ func TestHandlePutBlock(t *testing.T) {
 		big.NewInt(0).Mul(big.NewInt(2000000000), big.NewInt(unit.Iotx)),
 	)
 	require.NoError(t, err)
+	require.NoError(t, ws.PutState(byteutil.BytesTo20B(subChainAddr.Bytes()), &SubChain{
+		ChainID:        2,
+		OwnerPublicKey: testaddress.Keyinfo["producer"].PubKey,
+	}))
 	gasLimit := testutil.TestGasLimit
 	ctx = protocol.WithRunActionsCtx(ctx,
 		protocol.RunActionsCtx{
@@ -76,7 +81,7 @@ func TestHandlePutBlock(t *testing.T) {
 	roots["10002"] = byteutil.BytesTo32B([]byte("10002"))
 	pb := action.NewPutBlock(
 		1,
-		addr.String(),
+		subChainAddr.String(),
 		10001,
 		roots,
 		10003,
@@ -100,6 +105,17 @@ func TestHandlePutBlock(t *testing.T) {
 	_, err = p.Handle(ctx, selp.Action(), ws)
 	require.Error(t, err)
 
+	// only the owner of the sub-chain could put its blocks
+	notOwnerCtx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			Caller:   testaddress.Addrinfo["echo"],
+			GasLimit: &gasLimit,
+		})
+	_, err = p.Handle(notOwnerCtx, pb, ws)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not the owner of sub-chain")
+
 	// get exist
 	bp, exist := p.getBlockProof(pb.SubChainAddress(), pb.Height())
 	require.True(t, exist)
@@ -112,7 +128,7 @@ func TestHandlePutBlock(t *testing.T) {
 	roots["10002"] = byteutil.BytesTo32B([]byte("10003"))
 	pb2 := action.NewPutBlock(
 		1,
-		addr.String(),
+		subChainAddr.String(),
 		10002,
 		roots,
 		10003,
@@ -137,4 +153,19 @@ func TestHandlePutBlock(t *testing.T) {
 	assert.Equal(t, bp2.SubChainAddress, pb2.SubChainAddress())
 	assert.Equal(t, bp2.Roots[0].Name, "10002")
 	assert.Equal(t, bp2.Roots[0].Value, roots["10002"])
+
+	// verify the roots put
+	require.NoError(t, p.VerifyBlockRoot(pb2.SubChainAddress(), pb2.Height(), "10002", roots["10002"]))
+	require.Error(t, p.VerifyBlockRoot(pb2.SubChainAddress(), pb2.Height(), "10002", hash.ZeroHash256))
+	require.Error(t, p.VerifyBlockRoot(pb2.SubChainAddress(), pb2.Height(), "state", roots["10002"]))
+	require.Error(t, p.VerifyBlockRoot(pb2.SubChainAddress(), 10003, "10002", roots["10002"]))
+
+	// read the block proof via protocol
+	data, err := p.ReadState(ctx, ws, []byte("BlockProof"), []byte(pb2.SubChainAddress()), []byte("10002"))
+	require.NoError(t, err)
+	var bp3 BlockProof
+	require.NoError(t, bp3.Deserialize(data))
+	assert.Equal(t, bp2, bp3)
+	_, err = p.ReadState(ctx, ws, []byte("BlockProof"), []byte(pb2.SubChainAddress()))
+	require.Error(t, err)
 }
@@ -0,0 +1,179 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package mainchain
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/multichain/subchain"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// WithdrawAddress returns the address (20-byte) of the withdraw settled on main-chain
+func WithdrawAddress(subChainAddr []byte, withdrawHash hash.Hash256) hash.Hash160 {
+	var stream []byte
+	stream = append(stream, subChainAddr...)
+	stream = append(stream, []byte(".withdraw.")...)
+	stream = append(stream, withdrawHash[:]...)
+	return hash.Hash160b(stream)
+}
+
+// Withdraw returns the withdraw record
+func (p *Protocol) Withdraw(subChainAddr address.Address, withdrawHash hash.Hash256) (*Withdraw, error) {
+	key := WithdrawAddress(subChainAddr.Bytes(), withdrawHash)
+	var withdraw Withdraw
+	if err := p.sf.State(key, &withdraw); err != nil {
+		return nil, errors.Wrapf(err, "error when loading state of %x", key)
+	}
+	return &withdraw, nil
+}
+
+func (p *Protocol) handleSettleWithdraw(
+	ctx context.Context,
+	settle *action.SettleWithdraw,
+	sm protocol.StateManager,
+) error {
+	raCtx, ok := protocol.GetRunActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss run action context")
+	}
+	subChainAddr, subChain, withdraw, err := p.validateSettleWithdraw(settle, sm)
+	if err != nil {
+		return err
+	}
+	withdrawSelp := settle.Withdraw()
+
+	// Record the withdraw, so that it couldn't be settled again
+	recipient, err := address.FromString(withdraw.Recipient())
+	if err != nil {
+		return err
+	}
+	if err := sm.PutState(
+		WithdrawAddress(subChainAddr.Bytes(), withdrawSelp.Hash()),
+		&Withdraw{
+			Amount: withdraw.Amount(),
+			Addr:   recipient.Bytes(),
+		},
+	); err != nil {
+		return err
+	}
+
+	// Unlock the token from sub-chain
+	subChain.LockedAmount = big.NewInt(0).Sub(subChain.LockedAmount, withdraw.Amount())
+	if err := sm.PutState(byteutil.BytesTo20B(subChainAddr.Bytes()), subChain); err != nil {
+		return err
+	}
+	recipientAcct, err := util.LoadOrCreateAccount(sm, withdraw.Recipient(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if err := recipientAcct.AddBalance(withdraw.Amount()); err != nil {
+		return err
+	}
+	if err := util.StoreAccount(sm, withdraw.Recipient(), recipientAcct); err != nil {
+		return err
+	}
+
+	// Update the settler's nonce
+	acct, err := util.LoadAccount(sm, byteutil.BytesTo20B(raCtx.Caller.Bytes()))
+	if err != nil {
+		return err
+	}
+	util.SetNonce(settle, acct)
+	return util.StoreAccount(sm, raCtx.Caller.String(), acct)
+}
+
+// validateSettleWithdraw verifies the withdraw action is included in the sub-chain block put on main-chain by the
+// operator of the sub-chain, by calculating the tx root from the merkle proof, and the withdraw isn't settled yet. The
+// sub-chain has to be in operation
+func (p *Protocol) validateSettleWithdraw(
+	settle *action.SettleWithdraw,
+	sm protocol.StateManager,
+) (address.Address, *SubChain, *action.CreateWithdraw, error) {
+	withdrawSelp := settle.Withdraw()
+	withdraw, ok := withdrawSelp.Action().(*action.CreateWithdraw)
+	if !ok {
+		return nil, nil, nil, errors.New("action to settle is not a withdraw")
+	}
+	subChainAddr, err := address.FromString(settle.SubChainAddress())
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "invalid sub-chain address %s", settle.SubChainAddress())
+	}
+	var subChain SubChain
+	if err := p.state(sm, byteutil.BytesTo20B(subChainAddr.Bytes()), &subChain); err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "error when loading the state of sub-chain %s", settle.SubChainAddress())
+	}
+	subChainsInOp, err := p.subChainsInOperation(sm)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if inOp, ok := subChainsInOp.Get(subChain.ChainID); !ok || !bytes.Equal(inOp.Addr, subChainAddr.Bytes()) {
+		return nil, nil, nil, errors.Errorf("address %s is not on a sub-chain in operation", settle.SubChainAddress())
+	}
+
+	withdrawHash := withdrawSelp.Hash()
+	var settled Withdraw
+	switch err := p.state(sm, WithdrawAddress(subChainAddr.Bytes(), withdrawHash), &settled); errors.Cause(err) {
+	case nil:
+		return nil, nil, nil, errors.Errorf("withdraw %x is already settled", withdrawHash)
+	case state.ErrStateNotExist:
+	default:
+		return nil, nil, nil, err
+	}
+
+	bp, err := p.blockProof(sm, settle.SubChainAddress(), settle.Height())
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "failed to prove the inclusion of withdraw %x", withdrawHash)
+	}
+	// the roots are only trusted if the block proof is signed by the operator of the sub-chain
+	ownerPKHash := keypair.HashPubKey(subChain.OwnerPublicKey)
+	ownerAddr, err := address.FromBytes(ownerPKHash[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if bp.ProducerAddress != ownerAddr.String() {
+		return nil, nil, nil, errors.Errorf(
+			"block %d is put by %s rather than the operator %s of sub-chain",
+			settle.Height(),
+			bp.ProducerAddress,
+			ownerAddr.String(),
+		)
+	}
+	txRoot := crypto.MerkleRootFromProof(withdrawHash, settle.Index(), settle.Proof())
+	if err := bp.verifyRoot(subchain.TxRootName, txRoot); err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "failed to prove the inclusion of withdraw %x", withdrawHash)
+	}
+	if subChain.LockedAmount.Cmp(withdraw.Amount()) < 0 {
+		return nil, nil, nil, errors.Errorf(
+			"withdraw amount %d exceeds the amount %d locked for sub-chain %s",
+			withdraw.Amount(),
+			subChain.LockedAmount,
+			settle.SubChainAddress(),
+		)
+	}
+	return subChainAddr, &subChain, withdraw, nil
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key hash.Hash160, s interface{}) error {
+	if sm == nil {
+		return p.sf.State(key, s)
+	}
+	return sm.State(key, s)
+}
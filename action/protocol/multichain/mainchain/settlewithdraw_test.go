@@ -0,0 +1,211 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package mainchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/multichain/subchain"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestHandleSettleWithdraw(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	sf, err := factory.NewFactory(config.Default, factory.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().GetFactory().Return(sf).AnyTimes()
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+		ctrl.Finish()
+	}()
+
+	settler := testaddress.Addrinfo["producer"]
+	recipient := testaddress.Addrinfo["alfa"]
+	subChainAddr, err := createSubChainAddress(settler.String(), 0)
+	require.NoError(err)
+	addrSubChain, err := address.FromBytes(subChainAddr[:])
+	require.NoError(err)
+
+	// Withdraws created on sub-chain, and the tx root of the sub-chain block including them
+	var withdraws []action.SealedEnvelope
+	var leaves []hash.Hash256
+	for i, amount := range []int64{100, 200, 2000} {
+		act := action.NewCreateWithdraw(uint64(i+1), big.NewInt(amount), recipient.String(), testutil.TestGasLimit, big.NewInt(0))
+		bd := &action.EnvelopeBuilder{}
+		elp := bd.SetNonce(uint64(i + 1)).SetGasLimit(testutil.TestGasLimit).SetAction(act).Build()
+		selp, err := action.Sign(elp, testaddress.Keyinfo["bravo"].PriKey)
+		require.NoError(err)
+		withdraws = append(withdraws, selp)
+		leaves = append(leaves, selp.Hash())
+	}
+	mk := crypto.NewMerkleTree(leaves)
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = util.LoadOrCreateAccount(ws, settler.String(), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ws.PutState(
+		subChainAddr,
+		&SubChain{
+			ChainID:            2,
+			SecurityDeposit:    big.NewInt(1),
+			OperationDeposit:   big.NewInt(2),
+			StartHeight:        100,
+			ParentHeightOffset: 10,
+			OwnerPublicKey:     testaddress.Keyinfo["producer"].PubKey,
+			CurrentHeight:      200,
+			DepositCount:       300,
+			LockedAmount:       big.NewInt(1000),
+		},
+	))
+	require.NoError(ws.PutState(
+		blockProofKey(addrSubChain.String(), 10),
+		&BlockProof{
+			SubChainAddress:   addrSubChain.String(),
+			Height:            10,
+			Roots:             []MerkleRoot{{Name: subchain.TxRootName, Value: mk.HashTree()}},
+			ProducerPublicKey: testaddress.Keyinfo["producer"].PubKey,
+			ProducerAddress:   settler.String(),
+		},
+	))
+	require.NoError(ws.PutState(
+		blockProofKey(addrSubChain.String(), 11),
+		&BlockProof{
+			SubChainAddress:   addrSubChain.String(),
+			Height:            11,
+			Roots:             []MerkleRoot{{Name: subchain.TxRootName, Value: mk.HashTree()}},
+			ProducerPublicKey: testaddress.Keyinfo["alfa"].PubKey,
+			ProducerAddress:   recipient.String(),
+		},
+	))
+	require.NoError(sf.Commit(ws))
+
+	p := NewProtocol(chain)
+	gasLimit := testutil.TestGasLimit
+	ctx = protocol.WithRunActionsCtx(ctx, protocol.RunActionsCtx{
+		Producer: settler,
+		Caller:   settler,
+		GasLimit: &gasLimit,
+	})
+	settle := func(nonce uint64, index int, height uint64) *action.SettleWithdraw {
+		proof, err := mk.Proof(index)
+		require.NoError(err)
+		return action.NewSettleWithdraw(
+			nonce,
+			addrSubChain.String(),
+			height,
+			withdraws[index],
+			uint32(index),
+			proof,
+			testutil.TestGasLimit,
+			big.NewInt(0),
+		)
+	}
+
+	// The sub-chain isn't in operation
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = p.Handle(ctx, settle(1, 0, 10), ws)
+	require.Error(err)
+	require.Contains(err.Error(), "is not on a sub-chain in operation")
+	require.NoError(ws.PutState(
+		SubChainsInOperationKey,
+		SubChainsInOperation{}.Append(InOperation{ID: 2, Addr: addrSubChain.Bytes()}),
+	))
+	require.NoError(sf.Commit(ws))
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	// The block isn't put by the operator of the sub-chain
+	_, err = p.Handle(ctx, settle(1, 0, 11), ws)
+	require.Error(err)
+	require.Contains(err.Error(), "rather than the operator")
+	_, err = p.Handle(ctx, settle(1, 0, 10), ws)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+
+	account, err := sf.AccountState(recipient.String())
+	require.NoError(err)
+	require.Equal(big.NewInt(100), account.Balance)
+	account, err = sf.AccountState(settler.String())
+	require.NoError(err)
+	require.Equal(uint64(1), account.Nonce)
+	subChain, err := p.SubChain(addrSubChain)
+	require.NoError(err)
+	require.Equal(big.NewInt(900), subChain.LockedAmount)
+	withdraw, err := p.Withdraw(addrSubChain, leaves[0])
+	require.NoError(err)
+	require.Equal(big.NewInt(100), withdraw.Amount)
+	require.Equal(recipient.Bytes(), withdraw.Addr)
+
+	// The withdraw couldn't be settled twice
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = p.Handle(ctx, settle(2, 0, 10), ws)
+	require.Error(err)
+	// The proof doesn't match the tx root of the block
+	wrongProof := settle(2, 1, 10)
+	_, err = p.Handle(ctx, action.NewSettleWithdraw(
+		2,
+		addrSubChain.String(),
+		10,
+		withdraws[1],
+		0,
+		wrongProof.Proof(),
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	), ws)
+	require.Error(err)
+	// The block isn't put on main-chain
+	_, err = p.Handle(ctx, settle(2, 1, 12), ws)
+	require.Error(err)
+	// The amount exceeds the locked amount
+	_, err = p.Handle(ctx, settle(2, 2, 10), ws)
+	require.Error(err)
+	// The sub-chain address is invalid
+	_, err = p.Handle(ctx, action.NewSettleWithdraw(
+		2,
+		"invalid",
+		10,
+		withdraws[1],
+		1,
+		settle(2, 1, 10).Proof(),
+		testutil.TestGasLimit,
+		big.NewInt(0),
+	), ws)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid sub-chain address")
+
+	_, err = p.Handle(ctx, settle(2, 1, 10), ws)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	account, err = sf.AccountState(recipient.String())
+	require.NoError(err)
+	require.Equal(big.NewInt(300), account.Balance)
+	subChain, err = p.SubChain(addrSubChain)
+	require.NoError(err)
+	require.Equal(big.NewInt(700), subChain.LockedAmount)
+}
@@ -87,6 +87,7 @@ func (p *Protocol) mutateSubChainState(
 		OwnerPublicKey:     start.OwnerPublicKey(),
 		CurrentHeight:      0,
 		DepositCount:       0,
+		LockedAmount:       big.NewInt(0),
 	}
 	if err := sm.PutState(addr, &sc); err != nil {
 		return errors.Wrap(err, "error when putting sub-chain state")
@@ -0,0 +1,68 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package subchain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+func (p *Protocol) validateWithdraw(
+	caller address.Address,
+	withdraw *action.CreateWithdraw,
+	sm protocol.StateManager,
+) (*state.Account, error) {
+	if withdraw.Amount().Sign() <= 0 {
+		return nil, errors.New("withdraw amount should be positive")
+	}
+	if _, err := address.FromString(withdraw.Recipient()); err != nil {
+		return nil, errors.Wrapf(err, "invalid recipient address %s", withdraw.Recipient())
+	}
+	var acct *state.Account
+	var err error
+	if sm == nil {
+		acct, err = p.sf.AccountState(caller.String())
+	} else {
+		acct, err = util.LoadAccount(sm, byteutil.BytesTo20B(caller.Bytes()))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error when getting the account of address %s", caller.String())
+	}
+	cost, err := withdraw.Cost()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting withdraw's cost")
+	}
+	if acct.Balance.Cmp(cost) < 0 {
+		return nil, errors.Errorf("%s doesn't have at least required balance %d", caller.String(), cost)
+	}
+	return acct, nil
+}
+
+// mutateWithdraw burns the withdrawn token on sub-chain, which is to be unlocked on main-chain
+func (p *Protocol) mutateWithdraw(ctx context.Context, withdraw *action.CreateWithdraw, sm protocol.StateManager) error {
+	raCtx, ok := protocol.GetRunActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss run action context")
+	}
+	acct, err := p.validateWithdraw(raCtx.Caller, withdraw, sm)
+	if err != nil {
+		return err
+	}
+	acct.Balance = big.NewInt(0).Sub(acct.Balance, withdraw.Amount())
+	util.SetNonce(withdraw, acct)
+	return util.StoreAccount(sm, raCtx.Caller.String(), acct)
+}
@@ -56,21 +56,38 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 		if err := p.mutateDeposit(ctx, act, sm); err != nil {
 			return nil, errors.Wrapf(err, "error when handling deposit settlement action")
 		}
+	case *action.CreateWithdraw:
+		if err := p.mutateWithdraw(ctx, act, sm); err != nil {
+			return nil, errors.Wrapf(err, "error when handling withdraw creation action")
+		}
 	}
 	return nil, nil
 }
 
 // Validate validates the multi-chain action on sub-chain
-func (p *Protocol) Validate(_ context.Context, act action.Action) error {
+func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
 	switch act := act.(type) {
 	case *action.SettleDeposit:
 		if err := p.validateDeposit(act, nil); err != nil {
 			return errors.Wrapf(err, "error when validating deposit settlement action")
 		}
+	case *action.CreateWithdraw:
+		vaCtx, ok := protocol.GetValidateActionsCtx(ctx)
+		if !ok {
+			log.S().Panic("Miss validate action context")
+		}
+		if _, err := p.validateWithdraw(vaCtx.Caller, act, nil); err != nil {
+			return errors.Wrapf(err, "error when validating withdraw creation action")
+		}
 	}
 	return nil
 }
 
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(context.Context, protocol.StateManager, []byte, ...[]byte) ([]byte, error) {
+	return nil, protocol.ErrUnimplemented
+}
+
 func (p *Protocol) validateDeposit(deposit *action.SettleDeposit, sm protocol.StateManager) error {
 	// Validate main-chain state
 	// TODO: this may not be the type safe casting if index is greater than 2^63
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
@@ -145,3 +146,56 @@ func TestMutateDeposit(t *testing.T) {
 	var zero DepositIndex
 	assert.Equal(t, zero, di)
 }
+
+func TestMutateWithdraw(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesis.Default),
+	)
+	require.NoError(bc.Start(ctx))
+	exp := mock_explorer.NewMockExplorer(ctrl)
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+		ctrl.Finish()
+	}()
+
+	p := NewProtocol(bc, exp)
+	caller := testaddress.Addrinfo["producer"]
+	ws, err := bc.GetFactory().NewWorkingSet()
+	require.NoError(err)
+	_, err = util.LoadOrCreateAccount(ws, caller.String(), big.NewInt(1000))
+	require.NoError(err)
+	require.NoError(bc.GetFactory().Commit(ws))
+
+	// Invalid withdraws
+	vaCtx := protocol.WithValidateActionsCtx(ctx, protocol.ValidateActionsCtx{Caller: caller})
+	withdraw := action.NewCreateWithdraw(1, big.NewInt(0), testaddress.Addrinfo["alfa"].String(), 0, big.NewInt(0))
+	require.Error(p.Validate(vaCtx, withdraw))
+	withdraw = action.NewCreateWithdraw(1, big.NewInt(100), "invalid", 0, big.NewInt(0))
+	require.Error(p.Validate(vaCtx, withdraw))
+	withdraw = action.NewCreateWithdraw(1, big.NewInt(1001), testaddress.Addrinfo["alfa"].String(), 0, big.NewInt(0))
+	require.Error(p.Validate(vaCtx, withdraw))
+
+	// The withdrawn amount is burnt
+	withdraw = action.NewCreateWithdraw(1, big.NewInt(400), testaddress.Addrinfo["alfa"].String(), 0, big.NewInt(0))
+	require.NoError(p.Validate(vaCtx, withdraw))
+	raCtx := protocol.WithRunActionsCtx(ctx, protocol.RunActionsCtx{Caller: caller})
+	ws, err = bc.GetFactory().NewWorkingSet()
+	require.NoError(err)
+	_, err = p.Handle(raCtx, withdraw, ws)
+	require.NoError(err)
+	require.NoError(bc.GetFactory().Commit(ws))
+
+	account, err := bc.GetFactory().AccountState(caller.String())
+	require.NoError(err)
+	require.Equal(uint64(1), account.Nonce)
+	require.Equal(big.NewInt(600), account.Balance)
+	account, err = bc.GetFactory().AccountState(testaddress.Addrinfo["alfa"].String())
+	require.NoError(err)
+	require.Zero(account.Balance.Sign())
+}
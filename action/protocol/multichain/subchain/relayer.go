@@ -0,0 +1,194 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package subchain
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// BlockRootName is the name of the sub-chain block hash put into main-chain
+	BlockRootName = "block"
+	// StateRootName is the name of the sub-chain state root put into main-chain
+	StateRootName = "state"
+	// TxRootName is the name of the sub-chain tx root put into main-chain
+	TxRootName = "tx"
+	// RelayerSubscriberName is the name of the durable subscription of the relayer to sub-chain, so that no block to
+	// put is missed while main-chain is unreachable or the node is down
+	RelayerSubscriberName = "subChainRelayer"
+
+	putBlockGasLimit = uint64(1000000)
+)
+
+var putBlockGasPrice = big.NewInt(10)
+
+// Relayer commits the block hash and merkle roots of every interval sub-chain blocks into main-chain, so that the
+// history of sub-chain could be verified on main-chain
+type Relayer struct {
+	mutex        sync.Mutex
+	subChainAddr string
+	mainChainAPI explorer.Explorer
+	interval     uint64
+	pubKey       keypair.PublicKey
+	priKey       keypair.PrivateKey
+}
+
+// NewRelayer constructs a relayer, which signs the put block actions with the given key
+func NewRelayer(
+	subChainAddr string,
+	mainChainAPI explorer.Explorer,
+	interval uint64,
+	pubKey keypair.PublicKey,
+	priKey keypair.PrivateKey,
+) *Relayer {
+	return &Relayer{
+		subChainAddr: subChainAddr,
+		mainChainAPI: mainChainAPI,
+		interval:     interval,
+		pubKey:       pubKey,
+		priKey:       priKey,
+	}
+}
+
+// HandleBlock puts the block into main-chain if its height is a multiple of the interval
+func (r *Relayer) HandleBlock(blk *block.Block) error {
+	if r.interval == 0 || blk.Height()%r.interval != 0 {
+		return nil
+	}
+	// Put block actions are sent one by one, as the nonce is read from main-chain
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err := PutBlockToParentChain(r.mainChainAPI, r.subChainAddr, r.pubKey, r.priKey, blk); err != nil {
+		return err
+	}
+	log.L().Info("Succeeded to put block to main-chain.",
+		zap.String("subChainAddress", r.subChainAddr),
+		zap.Uint64("height", blk.Height()))
+	return nil
+}
+
+// PutBlockToParentChain signs a put block action of the block and sends it to main-chain
+func PutBlockToParentChain(
+	rootChainAPI explorer.Explorer,
+	subChainAddr string,
+	senderPubKey keypair.PublicKey,
+	senderPriKey keypair.PrivateKey,
+	b *block.Block,
+) error {
+	return PutBlockToParentChainSignedWith(rootChainAPI, subChainAddr, senderPubKey, func(h []byte) ([]byte, error) {
+		return crypto.Sign(h, senderPriKey)
+	}, b)
+}
+
+// PutBlockToParentChainSignedWith is PutBlockToParentChain with the put block action signed by the sign function,
+// e.g., by a remote signer holding the key
+func PutBlockToParentChainSignedWith(
+	rootChainAPI explorer.Explorer,
+	subChainAddr string,
+	senderPubKey keypair.PublicKey,
+	sign func([]byte) ([]byte, error),
+	b *block.Block,
+) error {
+	req, err := constructPutSubChainBlockRequest(rootChainAPI, subChainAddr, senderPubKey, sign, b)
+	if err != nil {
+		return errors.Wrap(err, "fail to construct PutSubChainBlockRequest")
+	}
+
+	if _, err := rootChainAPI.PutSubChainBlock(req); err != nil {
+		return errors.Wrap(err, "fail to call explorerapi to put block")
+	}
+	return nil
+}
+
+func constructPutSubChainBlockRequest(
+	rootChainAPI explorer.Explorer,
+	subChainAddr string,
+	senderPubKey keypair.PublicKey,
+	sign func([]byte) ([]byte, error),
+	b *block.Block,
+) (explorer.PutSubChainBlockRequest, error) {
+	senderPKHash := keypair.HashPubKey(senderPubKey)
+	senderPCAddr, err := address.FromBytes(senderPKHash[:])
+	if err != nil {
+		return explorer.PutSubChainBlockRequest{}, err
+	}
+	encodedSenderPCAddr := senderPCAddr.String()
+
+	// get sender current pending nonce on parent chain
+	senderPCAddrDetails, err := rootChainAPI.GetAddressDetails(encodedSenderPCAddr)
+	if err != nil {
+		return explorer.PutSubChainBlockRequest{}, errors.Wrap(err, "fail to get address details")
+	}
+
+	rootm := make(map[string]hash.Hash256)
+	rootm[BlockRootName] = b.HashBlock()
+	rootm[StateRootName] = b.StateRoot()
+	rootm[TxRootName] = b.TxRoot()
+	pb := action.NewPutBlock(
+		uint64(senderPCAddrDetails.PendingNonce),
+		subChainAddr,
+		b.Height(),
+		rootm,
+		putBlockGasLimit,
+		putBlockGasPrice,
+	)
+
+	bd := &action.EnvelopeBuilder{}
+	elp := bd.SetNonce(uint64(senderPCAddrDetails.PendingNonce)).
+		SetDestinationAddress(subChainAddr).
+		SetGasPrice(putBlockGasPrice).
+		SetGasLimit(putBlockGasLimit).
+		SetAction(pb).Build()
+
+	// sign action
+	selp, err := action.SignWith(elp, senderPubKey, sign)
+	if err != nil {
+		return explorer.PutSubChainBlockRequest{}, errors.Wrap(err, "fail to sign put block action")
+	}
+
+	req := explorer.PutSubChainBlockRequest{
+		Version:         int64(selp.Version()),
+		Nonce:           int64(selp.Nonce()),
+		SenderPubKey:    keypair.EncodePublicKey(senderPubKey),
+		GasLimit:        int64(selp.GasLimit()),
+		GasPrice:        selp.GasPrice().String(),
+		SubChainAddress: pb.SubChainAddress(),
+		Height:          int64(pb.Height()),
+		Roots:           make([]explorer.PutSubChainBlockMerkelRoot, 0, len(rootm)),
+		Signature:       hex.EncodeToString(selp.Signature()),
+	}
+
+	// put merkel roots
+	keys := make([]string, 0, len(rootm))
+	for k := range rootm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := rootm[k]
+		req.Roots = append(req.Roots, explorer.PutSubChainBlockMerkelRoot{
+			Name:  k,
+			Value: hex.EncodeToString(v[:]),
+		})
+	}
+	return req, nil
+}
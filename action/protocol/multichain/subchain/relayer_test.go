@@ -0,0 +1,69 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package subchain
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/test/mock/mock_explorer"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestRelayer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := testaddress.Keyinfo["producer"]
+	subChainAddr := testaddress.Addrinfo["echo"].String()
+	mainChainAPI := mock_explorer.NewMockExplorer(ctrl)
+	relayer := NewRelayer(subChainAddr, mainChainAPI, 2, key.PubKey, key.PriKey)
+
+	newBlock := func(height uint64) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(key.PubKey, key.PriKey)
+		require.NoError(err)
+		return &blk
+	}
+
+	// Block not at the interval isn't put
+	require.NoError(relayer.HandleBlock(newBlock(1)))
+
+	blk := newBlock(2)
+	blkHash := blk.HashBlock()
+	stateRoot := blk.StateRoot()
+	txRoot := blk.TxRoot()
+	mainChainAPI.EXPECT().
+		GetAddressDetails(testaddress.Addrinfo["producer"].String()).
+		Return(explorer.AddressDetails{PendingNonce: 5}, nil).
+		Times(1)
+	mainChainAPI.EXPECT().PutSubChainBlock(gomock.Any()).Times(1).
+		DoAndReturn(func(req explorer.PutSubChainBlockRequest) (explorer.PutSubChainBlockResponse, error) {
+			require.Equal(int64(5), req.Nonce)
+			require.Equal(int64(2), req.Height)
+			require.Equal(subChainAddr, req.SubChainAddress)
+			require.Equal([]explorer.PutSubChainBlockMerkelRoot{
+				{Name: BlockRootName, Value: hex.EncodeToString(blkHash[:])},
+				{Name: StateRootName, Value: hex.EncodeToString(stateRoot[:])},
+				{Name: TxRootName, Value: hex.EncodeToString(txRoot[:])},
+			}, req.Roots)
+			return explorer.PutSubChainBlockResponse{}, nil
+		})
+	require.NoError(relayer.HandleBlock(blk))
+
+	// Relayer is disabled with zero interval
+	require.NoError(NewRelayer(subChainAddr, mainChainAPI, 0, key.PubKey, key.PriKey).HandleBlock(blk))
+}
@@ -8,6 +8,9 @@ package protocol
 
 import (
 	"context"
+	"sort"
+
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/db"
@@ -15,10 +18,16 @@ import (
 	"github.com/iotexproject/iotex-core/state"
 )
 
+var (
+	// ErrUnimplemented indicates a method is not implemented yet
+	ErrUnimplemented = errors.New("method is unimplemented")
+)
+
 // Protocol defines the protocol interfaces atop IoTeX blockchain
 type Protocol interface {
 	ActionValidator
 	ActionHandler
+	ReadState(context.Context, StateManager, []byte, ...[]byte) ([]byte, error)
 }
 
 // ActionValidator is the interface of validating an action
@@ -38,6 +47,40 @@ type ActionHandler interface {
 	Handle(context.Context, action.Action, StateManager) (*action.Receipt, error)
 }
 
+// Prioritized is implemented by the action handlers which have to handle the actions ahead of the others regardless of
+// the order they're registered in, e.g., to reject the actions not allowed. The handlers are ordered by priority in
+// ascending order, and the ones not implementing it have priority 0
+type Prioritized interface {
+	Priority() int
+}
+
+// CircuitBreakerPriority is the priority of the circuit breaker protocol, which rejects the paused actions before any
+// other protocol, including the allowlist, mutates the states for them
+const CircuitBreakerPriority = -40
+
+// AllowlistPriority is the priority of the allowlist protocol, which rejects the actions from the addresses not in the
+// allowlist before any other protocol handles them
+const AllowlistPriority = -30
+
+// FreeGasPriority is the priority of the free gas protocol, which rejects the actions exceeding the free gas quota
+// after the allowlist and before the other protocols handle them
+const FreeGasPriority = -20
+
+// PriorityOf returns the priority of the action handler
+func PriorityOf(h interface{}) int {
+	if p, ok := h.(Prioritized); ok {
+		return p.Priority()
+	}
+	return 0
+}
+
+// SortActionHandlers sorts the action handlers by priority, keeping the order of the ones with the same priority
+func SortActionHandlers(handlers []ActionHandler) {
+	sort.SliceStable(handlers, func(i, j int) bool {
+		return PriorityOf(handlers[i]) < PriorityOf(handlers[j])
+	})
+}
+
 // ChainManager defines the blockchain interface
 type ChainManager interface {
 	// GetChainID returns the chain ID
@@ -50,6 +93,11 @@ type ChainManager interface {
 	Nonce(addr string) (uint64, error)
 }
 
+// StateReader reads the committed states, such as the state factory
+type StateReader interface {
+	State(hash.Hash160, interface{}) error
+}
+
 // StateManager defines the state DB interface atop IoTeX blockchain
 type StateManager interface {
 	// Accounts
@@ -7,6 +7,7 @@
 package protocol
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -16,15 +17,28 @@ import (
 
 // Registry is the hub of all protocols deployed on the chain
 type Registry struct {
+	mutex     sync.RWMutex
 	protocols sync.Map
+	// ids keeps the order of the protocols by priority, and then by registration, in which they handle the actions
+	ids []string
 }
 
 // Register registers the protocol with a unique ID
 func (r *Registry) Register(id string, p Protocol) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 	_, loaded := r.protocols.LoadOrStore(id, p)
 	if loaded {
 		return errors.Errorf("Protocol with ID %s is already registered", id)
 	}
+	// The protocol goes after the registered ones of the same or higher priority
+	i := sort.Search(len(r.ids), func(i int) bool {
+		registered, _ := r.Find(r.ids[i])
+		return PriorityOf(registered) > PriorityOf(p)
+	})
+	r.ids = append(r.ids, "")
+	copy(r.ids[i+1:], r.ids[i:])
+	r.ids[i] = id
 	return nil
 }
 
@@ -41,16 +55,18 @@ func (r *Registry) Find(id string) (Protocol, bool) {
 	return p, true
 }
 
-// All returns all protocols
+// All returns all protocols by priority and then in the order they're registered, so that the actions are handled
+// deterministically
 func (r *Registry) All() []Protocol {
-	all := make([]Protocol, 0)
-	r.protocols.Range(func(_, value interface{}) bool {
-		p, ok := value.(Protocol)
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	all := make([]Protocol, 0, len(r.ids))
+	for _, id := range r.ids {
+		p, ok := r.Find(id)
 		if !ok {
-			log.S().Panic("Registry stores the item which is not a protocol")
+			log.S().Panic("Registry misses a registered protocol")
 		}
 		all = append(all, p)
-		return true
-	})
+	}
 	return all
 }
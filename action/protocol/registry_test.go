@@ -0,0 +1,67 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+type dummyProtocol struct{ id string }
+
+func (p *dummyProtocol) Handle(context.Context, action.Action, StateManager) (*action.Receipt, error) {
+	return nil, nil
+}
+
+func (p *dummyProtocol) Validate(context.Context, action.Action) error { return nil }
+
+func (p *dummyProtocol) ReadState(context.Context, StateManager, []byte, ...[]byte) ([]byte, error) {
+	return nil, nil
+}
+
+func TestRegistry_All(t *testing.T) {
+	require := require.New(t)
+	r := Registry{}
+	var expected []Protocol
+	for i := 0; i < 10; i++ {
+		p := &dummyProtocol{id: fmt.Sprintf("protocol-%d", 9-i)}
+		require.NoError(r.Register(p.id, p))
+		expected = append(expected, p)
+	}
+	require.Error(r.Register("protocol-0", &dummyProtocol{}))
+	// The protocols are returned in the order they're registered every time
+	for i := 0; i < 10; i++ {
+		require.Equal(expected, r.All())
+	}
+	p, ok := r.Find("protocol-3")
+	require.True(ok)
+	require.Equal("protocol-3", p.(*dummyProtocol).id)
+
+	// The prioritized protocols come first whenever they're registered
+	high := &prioritizedProtocol{dummyProtocol{id: "high"}, -2}
+	low := &prioritizedProtocol{dummyProtocol{id: "low"}, -1}
+	require.NoError(r.Register(low.id, low))
+	require.NoError(r.Register(high.id, high))
+	expected = append([]Protocol{high, low}, expected...)
+	require.Equal(expected, r.All())
+
+	handlers := []ActionHandler{expected[2], low, expected[3], high}
+	SortActionHandlers(handlers)
+	require.Equal([]ActionHandler{high, low, expected[2], expected[3]}, handlers)
+}
+
+type prioritizedProtocol struct {
+	dummyProtocol
+	priority int
+}
+
+func (p *prioritizedProtocol) Priority() int { return p.priority }
@@ -14,10 +14,14 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
 	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 // admin stores the admin data of the rewarding protocol
@@ -25,14 +29,39 @@ type admin struct {
 	admin       address.Address
 	BlockReward *big.Int
 	EpochReward *big.Int
+	// GasFeeBurnPercentage is the percentage of the gas fee to burn
+	GasFeeBurnPercentage uint64
+	// GasFeeProducerPercentage is the percentage of the gas fee to grant to the block producer immediately. The rest
+	// of the gas fee goes to the rewarding fund
+	GasFeeProducerPercentage uint64
+	// EpochRewardCap is the max epoch reward a single delegate could get in an epoch. 0 means no cap
+	EpochRewardCap *big.Int
+	// MinClaimAmount is the min amount to claim from the rewarding fund. 0 means no min amount
+	MinClaimAmount *big.Int
+	// multisigAdmins is the set of the addresses that co-sign the admin changes. If it's empty, the single admin
+	// address is in charge
+	multisigAdmins []address.Address
+	// MultisigThreshold is the number of approvals from the multisig admins to make a change
+	MultisigThreshold uint64
+	// ProposalTTL is the number of blocks a pending admin change lasts before it expires. 0 means it never expires
+	ProposalTTL uint64
 }
 
 // Serialize serializes admin state into bytes
 func (a admin) Serialize() ([]byte, error) {
 	gen := rewardingpb.Admin{
-		Admin:       a.admin.Bytes(),
-		BlockReward: a.BlockReward.Bytes(),
-		EpochReward: a.EpochReward.Bytes(),
+		Admin:                    a.admin.Bytes(),
+		BlockReward:              a.BlockReward.Bytes(),
+		EpochReward:              a.EpochReward.Bytes(),
+		GasFeeBurnPercentage:     a.GasFeeBurnPercentage,
+		GasFeeProducerPercentage: a.GasFeeProducerPercentage,
+		EpochRewardCap:           a.EpochRewardCap.Bytes(),
+		MinClaimAmount:           a.MinClaimAmount.Bytes(),
+		MultisigThreshold:        a.MultisigThreshold,
+		ProposalTTL:              a.ProposalTTL,
+	}
+	for _, addr := range a.multisigAdmins {
+		gen.MultisigAdmins = append(gen.MultisigAdmins, addr.Bytes())
 	}
 	return proto.Marshal(&gen)
 }
@@ -49,10 +78,97 @@ func (a *admin) Deserialize(data []byte) error {
 	}
 	a.BlockReward = big.NewInt(0).SetBytes(gen.BlockReward)
 	a.EpochReward = big.NewInt(0).SetBytes(gen.EpochReward)
+	a.GasFeeBurnPercentage = gen.GasFeeBurnPercentage
+	a.GasFeeProducerPercentage = gen.GasFeeProducerPercentage
+	a.EpochRewardCap = big.NewInt(0).SetBytes(gen.EpochRewardCap)
+	a.MinClaimAmount = big.NewInt(0).SetBytes(gen.MinClaimAmount)
+	a.multisigAdmins = nil
+	for _, addrBytes := range gen.MultisigAdmins {
+		addr, err := address.FromBytes(addrBytes)
+		if err != nil {
+			return err
+		}
+		a.multisigAdmins = append(a.multisigAdmins, addr)
+	}
+	a.MultisigThreshold = gen.MultisigThreshold
+	a.ProposalTTL = gen.ProposalTTL
+	return nil
+}
+
+// isMultisigAdmin returns true if the address is one of the multisig admins
+func (a *admin) isMultisigAdmin(addr address.Address) bool {
+	for _, admin := range a.multisigAdmins {
+		if bytes.Equal(admin.Bytes(), addr.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+// proposal stores the approvals of a pending admin change from the multisig admins, and the height it's proposed at
+type proposal struct {
+	approvers []address.Address
+	height    uint64
+}
+
+// Serialize serializes proposal state into bytes
+func (pp proposal) Serialize() ([]byte, error) {
+	gen := rewardingpb.Proposal{Height: pp.height}
+	for _, addr := range pp.approvers {
+		gen.Approvers = append(gen.Approvers, addr.Bytes())
+	}
+	return proto.Marshal(&gen)
+}
+
+// Deserialize deserializes bytes into proposal state
+func (pp *proposal) Deserialize(data []byte) error {
+	gen := rewardingpb.Proposal{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	pp.approvers = nil
+	for _, addrBytes := range gen.Approvers {
+		addr, err := address.FromBytes(addrBytes)
+		if err != nil {
+			return err
+		}
+		pp.approvers = append(pp.approvers, addr)
+	}
+	pp.height = gen.Height
 	return nil
 }
 
-// Initialize initializes the rewarding protocol by setting the original admin, block and epoch reward
+// proposals stores the hashes of the pending admin changes
+type proposals struct {
+	hashes []hash.Hash256
+}
+
+// Serialize serializes proposals state into bytes
+func (ps proposals) Serialize() ([]byte, error) {
+	gen := rewardingpb.Proposals{}
+	for _, h := range ps.hashes {
+		gen.Hashes = append(gen.Hashes, h[:])
+	}
+	return proto.Marshal(&gen)
+}
+
+// Deserialize deserializes bytes into proposals state
+func (ps *proposals) Deserialize(data []byte) error {
+	gen := rewardingpb.Proposals{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	ps.hashes = nil
+	for _, b := range gen.Hashes {
+		var h hash.Hash256
+		h.SetBytes(b)
+		ps.hashes = append(ps.hashes, h)
+	}
+	return nil
+}
+
+// Initialize initializes the rewarding protocol by setting the original admin, block and epoch reward, and how the gas
+// fee is split between burning, the block producer and the rewarding fund
 func (p *Protocol) Initialize(
 	ctx context.Context,
 	sm protocol.StateManager,
@@ -60,6 +176,8 @@ func (p *Protocol) Initialize(
 	initBalance *big.Int,
 	blockReward *big.Int,
 	epochReward *big.Int,
+	gasFeeBurnPercentage uint64,
+	gasFeeProducerPercentage uint64,
 ) error {
 	if err := p.assertAmount(blockReward); err != nil {
 		return err
@@ -67,13 +185,26 @@ func (p *Protocol) Initialize(
 	if err := p.assertAmount(epochReward); err != nil {
 		return err
 	}
+	if gasFeeBurnPercentage > 100 ||
+		gasFeeProducerPercentage > 100 ||
+		gasFeeBurnPercentage+gasFeeProducerPercentage > 100 {
+		return errors.Errorf(
+			"gas fee burn percentage %d plus producer percentage %d shouldn't exceed 100",
+			gasFeeBurnPercentage,
+			gasFeeProducerPercentage,
+		)
+	}
 	if err := p.putState(
 		sm,
 		adminKey,
 		&admin{
-			admin:       adminAddr,
-			BlockReward: blockReward,
-			EpochReward: epochReward,
+			admin:                    adminAddr,
+			BlockReward:              blockReward,
+			EpochReward:              epochReward,
+			GasFeeBurnPercentage:     gasFeeBurnPercentage,
+			GasFeeProducerPercentage: gasFeeProducerPercentage,
+			EpochRewardCap:           big.NewInt(0),
+			MinClaimAmount:           big.NewInt(0),
 		},
 	); err != nil {
 		return err
@@ -91,6 +222,28 @@ func (p *Protocol) Initialize(
 	return nil
 }
 
+// InitializeMultisigAdmins sets the original multisig admins, the number of approvals required to make an admin change
+// and the number of blocks a pending change lasts. It should only be called when creating the genesis states
+func (p *Protocol) InitializeMultisigAdmins(
+	_ context.Context,
+	sm protocol.StateManager,
+	addrs []address.Address,
+	threshold uint64,
+	proposalTTL uint64,
+) error {
+	if err := assertMultisig(addrs, threshold); err != nil {
+		return err
+	}
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return err
+	}
+	a.multisigAdmins = addrs
+	a.MultisigThreshold = threshold
+	a.ProposalTTL = proposalTTL
+	return p.putState(sm, adminKey, &a)
+}
+
 // Admin returns the address of current admin
 func (p *Protocol) Admin(
 	_ context.Context,
@@ -113,7 +266,8 @@ func (p *Protocol) SetAdmin(
 	if !ok {
 		log.S().Panic("Miss run action context")
 	}
-	if err := p.assertAdminPermission(raCtx, sm); err != nil {
+	approved, err := p.approve(raCtx, sm, append([]byte("admin"), addr.Bytes()...))
+	if err != nil || !approved {
 		return err
 	}
 	a := admin{}
@@ -127,6 +281,53 @@ func (p *Protocol) SetAdmin(
 	return nil
 }
 
+// MultisigAdmins returns the multisig admins and the number of approvals required to make an admin change
+func (p *Protocol) MultisigAdmins(
+	_ context.Context,
+	sm protocol.StateManager,
+) ([]address.Address, uint64, error) {
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return nil, 0, err
+	}
+	return a.multisigAdmins, a.MultisigThreshold, nil
+}
+
+// SetMultisigAdmins replaces the multisig admins and the number of approvals required to make an admin change. Setting
+// no multisig admin hands the control back to the single admin. If the multisig admins are in charge, the change itself
+// needs to be approved by enough multisig admins
+func (p *Protocol) SetMultisigAdmins(
+	ctx context.Context,
+	sm protocol.StateManager,
+	addrs []address.Address,
+	threshold uint64,
+) error {
+	raCtx, ok := protocol.GetRunActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss run action context")
+	}
+	if err := assertMultisig(addrs, threshold); err != nil {
+		return err
+	}
+	var thresholdBytes [8]byte
+	enc.MachineEndian.PutUint64(thresholdBytes[:], threshold)
+	change := append([]byte("multisigAdmins"), thresholdBytes[:]...)
+	for _, addr := range addrs {
+		change = append(change, addr.Bytes()...)
+	}
+	approved, err := p.approve(raCtx, sm, change)
+	if err != nil || !approved {
+		return err
+	}
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return err
+	}
+	a.multisigAdmins = addrs
+	a.MultisigThreshold = threshold
+	return p.putState(sm, adminKey, &a)
+}
+
 // BlockReward returns the block reward amount
 func (p *Protocol) BlockReward(
 	_ context.Context,
@@ -145,7 +346,7 @@ func (p *Protocol) SetBlockReward(
 	sm protocol.StateManager,
 	amount *big.Int,
 ) error {
-	return p.setReward(ctx, sm, amount, true)
+	return p.setReward(ctx, sm, amount, action.BlockReward)
 }
 
 // EpochReward returns the epoch reward amount
@@ -167,7 +368,64 @@ func (p *Protocol) SetEpochReward(
 	sm protocol.StateManager,
 	amount *big.Int,
 ) error {
-	return p.setReward(ctx, sm, amount, false)
+	return p.setReward(ctx, sm, amount, action.EpochReward)
+}
+
+// EpochRewardCap returns the max epoch reward a single delegate could get in an epoch
+func (p *Protocol) EpochRewardCap(
+	_ context.Context,
+	sm protocol.StateManager,
+) (*big.Int, error) {
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return nil, err
+	}
+	return a.EpochRewardCap, nil
+}
+
+// SetEpochRewardCap sets the max epoch reward a single delegate could get in an epoch. 0 means no cap. Only the
+// current admin could make this change
+func (p *Protocol) SetEpochRewardCap(
+	ctx context.Context,
+	sm protocol.StateManager,
+	amount *big.Int,
+) error {
+	return p.setReward(ctx, sm, amount, action.EpochRewardCap)
+}
+
+// MinClaimAmount returns the min amount to claim from the rewarding fund
+func (p *Protocol) MinClaimAmount(
+	_ context.Context,
+	sm protocol.StateManager,
+) (*big.Int, error) {
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return nil, err
+	}
+	return a.MinClaimAmount, nil
+}
+
+// SetMinClaimAmount sets the min amount to claim from the rewarding fund. 0 means no min amount. Only the current
+// admin could make this change
+func (p *Protocol) SetMinClaimAmount(
+	ctx context.Context,
+	sm protocol.StateManager,
+	amount *big.Int,
+) error {
+	return p.setReward(ctx, sm, amount, action.MinClaimAmount)
+}
+
+// GasFeeSplit returns the percentages of the gas fee to burn and to grant to the block producer. They're set by the
+// genesis and never change afterwards, so that they could be read from the committed states
+func (p *Protocol) GasFeeSplit(
+	_ context.Context,
+	sm protocol.StateReader,
+) (uint64, uint64, error) {
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return 0, 0, err
+	}
+	return a.GasFeeBurnPercentage, a.GasFeeProducerPercentage, nil
 }
 
 func (p *Protocol) assertAmount(amount *big.Int) error {
@@ -177,41 +435,237 @@ func (p *Protocol) assertAmount(amount *big.Int) error {
 	return errors.Errorf("reward amount %s shouldn't be negative", amount.String())
 }
 
-func (p *Protocol) assertAdminPermission(raCtx protocol.RunActionsCtx, sm protocol.StateManager) error {
+// approve records the caller's approval of an admin change. It returns true if the change gets enough approvals and
+// should be applied now. If there's no multisig admin, the change is approved as long as the caller is the admin. The
+// states are only written once all the checks pass, so that a rejected approval changes nothing
+func (p *Protocol) approve(raCtx protocol.RunActionsCtx, sm protocol.StateManager, change []byte) (bool, error) {
 	a := admin{}
 	if err := p.state(sm, adminKey, &a); err != nil {
+		return false, err
+	}
+	expired, err := p.expiredProposals(sm, raCtx.BlockHeight, a.ProposalTTL)
+	if err != nil {
+		return false, err
+	}
+	if len(a.multisigAdmins) == 0 {
+		if !bytes.Equal(a.admin.Bytes(), raCtx.Caller.Bytes()) {
+			return false, errors.Errorf("%s is not the rewarding protocol admin", raCtx.Caller.String())
+		}
+		return true, p.removeProposals(sm, expired)
+	}
+	if !a.isMultisigAdmin(raCtx.Caller) {
+		return false, errors.Errorf("%s is not a rewarding protocol multisig admin", raCtx.Caller.String())
+	}
+	h := hash.Hash256b(change)
+	key := proposalKey(h)
+	pp := proposal{}
+	proposed := true
+	switch err := p.state(sm, key, &pp); errors.Cause(err) {
+	case nil:
+		// The expired proposal of the same change is proposed again from scratch
+		if expired[h] {
+			pp = proposal{}
+			proposed = false
+		}
+	case state.ErrStateNotExist:
+		proposed = false
+	default:
+		return false, err
+	}
+	if !proposed {
+		pp.height = raCtx.BlockHeight
+	}
+	// Only count the approvals from the current multisig admins, in case the admins have been changed since proposed
+	approvers := make([]address.Address, 0, len(pp.approvers)+1)
+	for _, approver := range pp.approvers {
+		if bytes.Equal(approver.Bytes(), raCtx.Caller.Bytes()) {
+			return false, errors.Errorf("%s has already approved the change", raCtx.Caller.String())
+		}
+		if a.isMultisigAdmin(approver) {
+			approvers = append(approvers, approver)
+		}
+	}
+	approvers = append(approvers, raCtx.Caller)
+
+	if uint64(len(approvers)) >= a.MultisigThreshold {
+		if proposed {
+			expired[h] = true
+		}
+		return true, p.removeProposals(sm, expired)
+	}
+	if err := p.removeProposals(sm, expired); err != nil {
+		return false, err
+	}
+	if !proposed {
+		if err := p.addProposal(sm, h); err != nil {
+			return false, err
+		}
+	}
+	pp.approvers = approvers
+	return false, p.putState(sm, key, &pp)
+}
+
+// expiredProposals returns the pending admin changes which have lasted for the TTL without getting enough approvals
+func (p *Protocol) expiredProposals(sm protocol.StateManager, height uint64, ttl uint64) (map[hash.Hash256]bool, error) {
+	expired := make(map[hash.Hash256]bool)
+	if ttl == 0 {
+		return expired, nil
+	}
+	ps := proposals{}
+	if err := p.state(sm, proposalsKey, &ps); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return expired, nil
+		}
+		return nil, err
+	}
+	for _, h := range ps.hashes {
+		pp := proposal{}
+		if err := p.state(sm, proposalKey(h), &pp); err != nil {
+			return nil, err
+		}
+		if pp.height+ttl <= height {
+			expired[h] = true
+		}
+	}
+	return expired, nil
+}
+
+// addProposal adds a new pending admin change into the index
+func (p *Protocol) addProposal(sm protocol.StateManager, h hash.Hash256) error {
+	ps := proposals{}
+	if err := p.state(sm, proposalsKey, &ps); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	ps.hashes = append(ps.hashes, h)
+	return p.putState(sm, proposalsKey, &ps)
+}
+
+// removeProposals deletes the pending admin changes and removes them from the index
+func (p *Protocol) removeProposals(sm protocol.StateManager, removed map[hash.Hash256]bool) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	ps := proposals{}
+	if err := p.state(sm, proposalsKey, &ps); err != nil {
+		return err
+	}
+	hashes := make([]hash.Hash256, 0, len(ps.hashes))
+	for _, h := range ps.hashes {
+		if !removed[h] {
+			hashes = append(hashes, h)
+			continue
+		}
+		if err := p.deleteState(sm, proposalKey(h)); err != nil {
+			return err
+		}
+	}
+	if len(hashes) == 0 {
+		return p.deleteState(sm, proposalsKey)
+	}
+	ps.hashes = hashes
+	return p.putState(sm, proposalsKey, &ps)
+}
+
+func proposalKey(h hash.Hash256) []byte {
+	key := make([]byte, 0, len(proposalKeyPrefix)+len(h))
+	key = append(key, proposalKeyPrefix...)
+	return append(key, h[:]...)
+}
+
+// setRewardingAdmin hands the control to the single admin or the multisig admins set by the action
+func (p *Protocol) setRewardingAdmin(
+	ctx context.Context,
+	sm protocol.StateManager,
+	act *action.SetRewardingAdmin,
+) error {
+	adminAddr, multisigAdmins, err := parseRewardingAdmin(act)
+	if err != nil {
 		return err
 	}
-	if bytes.Equal(a.admin.Bytes(), raCtx.Caller.Bytes()) {
+	if adminAddr != nil {
+		return p.SetAdmin(ctx, sm, adminAddr)
+	}
+	return p.SetMultisigAdmins(ctx, sm, multisigAdmins, act.MultisigThreshold())
+}
+
+// parseRewardingAdmin parses either the single admin or the multisig admins set by the action
+func parseRewardingAdmin(act *action.SetRewardingAdmin) (address.Address, []address.Address, error) {
+	if act.Admin() != "" {
+		if len(act.MultisigAdmins()) != 0 || act.MultisigThreshold() != 0 {
+			return nil, nil, errors.New("admin and multisig admins shouldn't be set at the same time")
+		}
+		addr, err := address.FromString(act.Admin())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error when validating admin's address %s", act.Admin())
+		}
+		return addr, nil, nil
+	}
+	addrs := make([]address.Address, 0, len(act.MultisigAdmins()))
+	for _, addrStr := range act.MultisigAdmins() {
+		addr, err := address.FromString(addrStr)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error when validating multisig admin's address %s", addrStr)
+		}
+		addrs = append(addrs, addr)
+	}
+	if err := assertMultisig(addrs, act.MultisigThreshold()); err != nil {
+		return nil, nil, err
+	}
+	return nil, addrs, nil
+}
+
+func assertMultisig(addrs []address.Address, threshold uint64) error {
+	if len(addrs) == 0 {
+		if threshold != 0 {
+			return errors.Errorf("threshold %d should be 0 when there's no multisig admin", threshold)
+		}
 		return nil
 	}
-	return errors.Errorf("%s is not the rewarding protocol admin", raCtx.Caller.String())
+	if threshold == 0 || threshold > uint64(len(addrs)) {
+		return errors.Errorf("threshold %d should be between 1 and the number of multisig admins %d", threshold, len(addrs))
+	}
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		if seen[addr.String()] {
+			return errors.Errorf("duplicate multisig admin %s", addr.String())
+		}
+		seen[addr.String()] = true
+	}
+	return nil
 }
 
 func (p *Protocol) setReward(
 	ctx context.Context,
 	sm protocol.StateManager,
 	amount *big.Int,
-	blockLevel bool,
+	t int,
 ) error {
 	raCtx, ok := protocol.GetRunActionsCtx(ctx)
 	if !ok {
 		log.S().Panic("Miss run action context")
 	}
-	if err := p.assertAdminPermission(raCtx, sm); err != nil {
+	if err := p.assertAmount(amount); err != nil {
 		return err
 	}
-	if err := p.assertAmount(amount); err != nil {
+	approved, err := p.approve(raCtx, sm, append([]byte{byte(t)}, amount.Bytes()...))
+	if err != nil || !approved {
 		return err
 	}
 	a := admin{}
 	if err := p.state(sm, adminKey, &a); err != nil {
 		return err
 	}
-	if blockLevel {
+	switch t {
+	case action.BlockReward:
 		a.BlockReward = amount
-	} else {
+	case action.EpochReward:
 		a.EpochReward = amount
+	case action.EpochRewardCap:
+		a.EpochRewardCap = amount
+	case action.MinClaimAmount:
+		a.MinClaimAmount = amount
+	default:
+		return errors.Errorf("unsupported reward type %d", t)
 	}
 	if err := p.putState(sm, adminKey, &a); err != nil {
 		return err
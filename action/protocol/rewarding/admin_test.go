@@ -8,6 +8,7 @@ package rewarding
 
 import (
 	"context"
+	"math"
 	"math/big"
 	"testing"
 
@@ -15,10 +16,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/testaddress"
 )
 
 func TestProtocol_Admin(t *testing.T) {
@@ -80,6 +83,42 @@ func TestProtocol_Admin(t *testing.T) {
 			big.NewInt(300),
 		))
 
+		// Update epoch reward cap and min claim amount
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.SetEpochRewardCap(ctx, ws, big.NewInt(50)))
+		require.NoError(t, p.SetMinClaimAmount(ctx, ws, big.NewInt(5)))
+		stateDB.Commit(ws)
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		epochRewardCap, err := p.EpochRewardCap(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(50), epochRewardCap)
+		minClaimAmount, err := p.MinClaimAmount(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(5), minClaimAmount)
+		data, err := p.ReadState(ctx, ws, []byte("EpochRewardCap"))
+		require.NoError(t, err)
+		assert.Equal(t, "50", string(data))
+		data, err = p.ReadState(ctx, ws, []byte("MinClaimAmount"))
+		require.NoError(t, err)
+		assert.Equal(t, "5", string(data))
+		_, err = p.ReadState(ctx, ws, []byte("Unknown"))
+		require.Error(t, err)
+
+		// Set epoch reward cap and min claim amount again will fail because caller is not admin
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		noAuthCtx := protocol.WithRunActionsCtx(
+			context.Background(),
+			protocol.RunActionsCtx{
+				Caller: addrNoAuth,
+			},
+		)
+		require.Error(t, p.SetEpochRewardCap(noAuthCtx, ws, big.NewInt(60)))
+		require.Error(t, p.SetMinClaimAmount(noAuthCtx, ws, big.NewInt(6)))
+
 		// Update admin
 		ws, err = stateDB.NewWorkingSet()
 		require.NoError(t, err)
@@ -106,6 +145,212 @@ func TestProtocol_Admin(t *testing.T) {
 		addrNew, err = address.FromBytes(pkHashNew[:])
 		require.NoError(t, err)
 		require.Error(t, p.SetAdmin(ctx, ws, addrNew))
+
+		// Initialize will fail if the gas fee percentages exceed 100, even if their sum wraps around
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.Error(t, p.Initialize(ctx, ws, addrNew, big.NewInt(0), big.NewInt(10), big.NewInt(100), 60, 41))
+		require.Error(t, p.Initialize(ctx, ws, addrNew, big.NewInt(0), big.NewInt(10), big.NewInt(100), math.MaxUint64, 2))
 	})
 
 }
+
+func TestProtocol_MultisigAdmin(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		raCtx, ok := protocol.GetRunActionsCtx(ctx)
+		require.True(t, ok)
+		admins := []address.Address{
+			testaddress.Addrinfo["alfa"],
+			testaddress.Addrinfo["bravo"],
+			testaddress.Addrinfo["charlie"],
+		}
+		adminCtxs := make([]context.Context, 0, len(admins))
+		for _, admin := range admins {
+			adminRaCtx := raCtx
+			adminRaCtx.Caller = admin
+			adminCtxs = append(adminCtxs, protocol.WithRunActionsCtx(context.Background(), adminRaCtx))
+		}
+
+		// Invalid threshold
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.Error(t, p.SetMultisigAdmins(ctx, ws, admins, 0))
+		require.Error(t, p.SetMultisigAdmins(ctx, ws, admins, 4))
+		require.Error(t, p.SetMultisigAdmins(ctx, ws, []address.Address{admins[0], admins[0]}, 1))
+
+		// The single admin hands over the control to the 2-of-3 multisig admins
+		require.NoError(t, p.SetMultisigAdmins(ctx, ws, admins, 2))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		multisigAdmins, threshold, err := p.MultisigAdmins(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, admins, multisigAdmins)
+		assert.Equal(t, uint64(2), threshold)
+
+		// The single admin could no longer make the change
+		require.Error(t, p.SetBlockReward(ctx, ws, big.NewInt(30)))
+
+		// The first approval doesn't apply the change
+		require.NoError(t, p.SetBlockReward(adminCtxs[0], ws, big.NewInt(30)))
+		require.NoError(t, stateDB.Commit(ws))
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		blockReward, err := p.BlockReward(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(10), blockReward)
+
+		// The same admin couldn't approve twice
+		require.Error(t, p.SetBlockReward(adminCtxs[0], ws, big.NewInt(30)))
+
+		// Approving a different amount is a different change
+		require.NoError(t, p.SetBlockReward(adminCtxs[1], ws, big.NewInt(40)))
+		blockReward, err = p.BlockReward(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(10), blockReward)
+
+		// The second approval applies the change
+		require.NoError(t, p.SetBlockReward(adminCtxs[2], ws, big.NewInt(30)))
+		require.NoError(t, stateDB.Commit(ws))
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		blockReward, err = p.BlockReward(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(30), blockReward)
+
+		// The multisig admins hand the control back to the single admin
+		require.NoError(t, p.SetMultisigAdmins(adminCtxs[0], ws, nil, 0))
+		require.NoError(t, p.SetMultisigAdmins(adminCtxs[1], ws, nil, 0))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.SetBlockReward(ctx, ws, big.NewInt(50)))
+		blockReward, err = p.BlockReward(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(50), blockReward)
+	})
+}
+
+func TestProtocol_SetRewardingAdmin(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		raCtx, ok := protocol.GetRunActionsCtx(ctx)
+		require.True(t, ok)
+		raCtx.GasPrice = big.NewInt(0)
+		ctx = protocol.WithRunActionsCtx(context.Background(), raCtx)
+		admins := []string{
+			testaddress.Addrinfo["alfa"].String(),
+			testaddress.Addrinfo["bravo"].String(),
+		}
+
+		// Either the single admin or the multisig admins could be set
+		b := action.SetRewardingAdminBuilder{}
+		act := b.SetAdmin(admins[0]).SetMultisigAdmins(admins, 1).Build()
+		require.Error(t, p.Validate(ctx, &act))
+		b = action.SetRewardingAdminBuilder{}
+		act = b.SetAdmin("invalid").Build()
+		require.Error(t, p.Validate(ctx, &act))
+		b = action.SetRewardingAdminBuilder{}
+		act = b.SetMultisigAdmins(admins, 3).Build()
+		require.Error(t, p.Validate(ctx, &act))
+		b = action.SetRewardingAdminBuilder{}
+		act = b.SetMultisigAdmins([]string{"", "invalid"}, 1).Build()
+		require.Error(t, p.Validate(ctx, &act))
+
+		b = action.SetRewardingAdminBuilder{}
+		act = b.SetMultisigAdmins(admins, 2).Build()
+		require.NoError(t, p.Validate(ctx, &act))
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		_, err = p.Handle(ctx, &act, ws)
+		require.NoError(t, err)
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		multisigAdmins, threshold, err := p.MultisigAdmins(ctx, ws)
+		require.NoError(t, err)
+		require.Len(t, multisigAdmins, 2)
+		assert.Equal(t, admins[1], multisigAdmins[1].String())
+		assert.Equal(t, uint64(2), threshold)
+
+		// Both multisig admins approve handing the control to a new single admin
+		b = action.SetRewardingAdminBuilder{}
+		act = b.SetMultisigAdmins(nil, 0).Build()
+		for _, admin := range []string{"alfa", "bravo"} {
+			adminRaCtx := raCtx
+			adminRaCtx.Caller = testaddress.Addrinfo[admin]
+			_, err = p.Handle(protocol.WithRunActionsCtx(context.Background(), adminRaCtx), &act, ws)
+			require.NoError(t, err)
+		}
+		b = action.SetRewardingAdminBuilder{}
+		act = b.SetAdmin(admins[1]).Build()
+		_, err = p.Handle(ctx, &act, ws)
+		require.NoError(t, err)
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		multisigAdmins, _, err = p.MultisigAdmins(ctx, ws)
+		require.NoError(t, err)
+		assert.Empty(t, multisigAdmins)
+		adminAddr, err := p.Admin(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, admins[1], adminAddr.String())
+	})
+}
+
+func TestProtocol_ProposalExpiry(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		raCtx, ok := protocol.GetRunActionsCtx(ctx)
+		require.True(t, ok)
+		admins := []address.Address{
+			testaddress.Addrinfo["alfa"],
+			testaddress.Addrinfo["bravo"],
+		}
+		adminCtx := func(admin int, height uint64) context.Context {
+			adminRaCtx := raCtx
+			adminRaCtx.Caller = admins[admin]
+			adminRaCtx.BlockHeight = height
+			return protocol.WithRunActionsCtx(context.Background(), adminRaCtx)
+		}
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.InitializeMultisigAdmins(ctx, ws, admins, 2, 10))
+		pendingProposals := func() int {
+			ps := proposals{}
+			if err := p.state(ws, proposalsKey, &ps); err != nil {
+				return 0
+			}
+			return len(ps.hashes)
+		}
+
+		// The change proposed at height 1 expires at height 11, and the approval then proposes it again
+		require.NoError(t, p.SetBlockReward(adminCtx(0, 1), ws, big.NewInt(30)))
+		require.NoError(t, p.SetBlockReward(adminCtx(0, 5), ws, big.NewInt(40)))
+		assert.Equal(t, 2, pendingProposals())
+
+		// The rejected approvals write nothing, including the removal of the expired change
+		require.Error(t, p.SetBlockReward(adminCtx(0, 6), ws, big.NewInt(40)))
+		outsiderRaCtx := raCtx
+		outsiderRaCtx.Caller = testaddress.Addrinfo["charlie"]
+		outsiderRaCtx.BlockHeight = 11
+		outsiderCtx := protocol.WithRunActionsCtx(context.Background(), outsiderRaCtx)
+		require.Error(t, p.SetBlockReward(outsiderCtx, ws, big.NewInt(30)))
+		assert.Equal(t, 2, pendingProposals())
+
+		require.NoError(t, p.SetBlockReward(adminCtx(1, 11), ws, big.NewInt(30)))
+		blockReward, err := p.BlockReward(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(10), blockReward)
+		assert.Equal(t, 2, pendingProposals())
+
+		// The change approved in time is applied, and the other expired one is removed
+		require.NoError(t, p.SetBlockReward(adminCtx(0, 15), ws, big.NewInt(30)))
+		blockReward, err = p.BlockReward(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(30), blockReward)
+		assert.Equal(t, 0, pendingProposals())
+	})
+}
@@ -138,5 +138,71 @@ func DepositGas(ctx context.Context, sm protocol.StateManager, amount *big.Int,
 	if !ok {
 		log.S().Panicf("Protocol %d is not a rewarding protocol", ProtocolID)
 	}
-	return rp.Deposit(ctx, sm, amount)
+	return rp.depositGas(ctx, sm, amount)
+}
+
+// depositGas charges the gas fee from the caller, and splits it into the burned part, the part granted to the block
+// producer and the part deposited into the rewarding fund according to the genesis parameters
+func (p *Protocol) depositGas(
+	ctx context.Context,
+	sm protocol.StateManager,
+	amount *big.Int,
+) error {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return err
+	}
+	if a.GasFeeBurnPercentage == 0 && a.GasFeeProducerPercentage == 0 {
+		return p.Deposit(ctx, sm, amount)
+	}
+	if err := p.assertEnoughBalance(raCtx, sm, amount); err != nil {
+		return err
+	}
+	// Subtract the whole gas fee from caller
+	acc, err := util.LoadOrCreateAccount(sm, raCtx.Caller.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	acc.Balance = big.NewInt(0).Sub(acc.Balance, amount)
+	if err := util.StoreAccount(sm, raCtx.Caller.String(), acc); err != nil {
+		return err
+	}
+	_, producerShare, fundShare := SplitGasFee(amount, a.GasFeeBurnPercentage, a.GasFeeProducerPercentage)
+	// Grant the producer share to the producer's primary account, or deposit it into the fund if the producer is unknown
+	if raCtx.Producer == nil {
+		fundShare.Add(fundShare, producerShare)
+	} else if producerShare.Sign() > 0 {
+		producerAcc, err := util.LoadOrCreateAccount(sm, raCtx.Producer.String(), big.NewInt(0))
+		if err != nil {
+			return err
+		}
+		producerAcc.Balance = big.NewInt(0).Add(producerAcc.Balance, producerShare)
+		if err := util.StoreAccount(sm, raCtx.Producer.String(), producerAcc); err != nil {
+			return err
+		}
+	}
+	// Add the rest to fund. The burned part is simply not credited to anyone
+	f := fund{}
+	if err := p.state(sm, fundKey, &f); err != nil {
+		return err
+	}
+	f.totalBalance = big.NewInt(0).Add(f.totalBalance, fundShare)
+	f.unclaimedBalance = big.NewInt(0).Add(f.unclaimedBalance, fundShare)
+	return p.putState(sm, fundKey, &f)
+}
+
+// SplitGasFee splits the gas fee into the part to burn, the part to grant to the block producer and the part to deposit
+// into the rewarding fund by the given percentages
+func SplitGasFee(amount *big.Int, burnPercentage, producerPercentage uint64) (*big.Int, *big.Int, *big.Int) {
+	burned := percentageOf(amount, burnPercentage)
+	producerShare := percentageOf(amount, producerPercentage)
+	fundShare := big.NewInt(0).Sub(amount, burned)
+	fundShare.Sub(fundShare, producerShare)
+	return burned, producerShare, fundShare
+}
+
+func percentageOf(amount *big.Int, percentage uint64) *big.Int {
+	share := big.NewInt(0).Mul(amount, big.NewInt(0).SetUint64(percentage))
+	return share.Div(share, big.NewInt(100))
 }
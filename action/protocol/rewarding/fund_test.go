@@ -49,3 +49,55 @@ func TestProtocol_Fund(t *testing.T) {
 	})
 
 }
+
+func TestProtocol_DepositGas(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		raCtx, ok := protocol.GetRunActionsCtx(ctx)
+		require.True(t, ok)
+		registry := protocol.Registry{}
+		require.NoError(t, registry.Register(ProtocolID, p))
+
+		// Burn 20% and grant 30% to the producer
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		a := admin{}
+		require.NoError(t, p.state(ws, adminKey, &a))
+		a.GasFeeBurnPercentage = 20
+		a.GasFeeProducerPercentage = 30
+		require.NoError(t, p.putState(ws, adminKey, &a))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, DepositGas(ctx, ws, big.NewInt(100), &registry))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		burn, producer, err := p.GasFeeSplit(ctx, stateDB)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(20), burn)
+		assert.Equal(t, uint64(30), producer)
+		totalBalance, err := p.TotalBalance(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(50), totalBalance)
+		acc, err := util.LoadAccount(ws, byteutil.BytesTo20B(raCtx.Caller.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(900), acc.Balance)
+		producerAcc, err := util.LoadAccount(ws, byteutil.BytesTo20B(raCtx.Producer.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(30), producerAcc.Balance)
+
+		// The producer share is deposited into the fund if the producer is unknown
+		noProducerCtx := protocol.WithRunActionsCtx(ctx, protocol.RunActionsCtx{
+			BlockHeight: raCtx.BlockHeight,
+			Caller:      raCtx.Caller,
+		})
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, DepositGas(noProducerCtx, ws, big.NewInt(100), &registry))
+		totalBalance, err = p.TotalBalance(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(130), totalBalance)
+	})
+}
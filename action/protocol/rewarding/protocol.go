@@ -9,7 +9,10 @@ package rewarding
 import (
 	"context"
 	"math/big"
+	"strconv"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -32,29 +35,48 @@ var (
 	blockRewardHistoryKeyPrefix = []byte("blockRewardHistory")
 	epochRewardHistoryKeyPrefix = []byte("epochRewardHistory")
 	accountKeyPrefix            = []byte("account")
+	proposalKeyPrefix           = []byte("proposal")
+	proposalsKey                = []byte("proposals")
+	beneficiariesKey            = []byte("beneficiaries")
+	epochSnapshotKeyPrefix      = []byte("epochSnapshot")
+	// claimTopic is the topic of the log emitted when claiming from the rewarding fund
+	claimTopic = hash.Hash256b([]byte("ClaimFromRewardingFund"))
 )
 
 // Protocol defines the protocol of the rewarding fund and the rewarding process. It allows the admin to config the
 // reward amount, users to donate tokens to the fund, block producers to grant them block and epoch reward and,
 // beneficiaries to claim the balance into their personal account.
 type Protocol struct {
-	keyPrefix []byte
-	addr      address.Address
+	keyPrefix         []byte
+	addr              address.Address
+	snapshotHeight    uint64
+	snapshotRetention uint64
+	claimLogHeight    uint64
 }
 
+// Option is the option to create the protocol of rewarding
+type Option func(*Protocol)
+
 // NewProtocol instantiates a rewarding protocol instance.
-func NewProtocol() *Protocol {
+func NewProtocol(opts ...Option) *Protocol {
 	h := hash.Hash160b([]byte(ProtocolID))
 	addr, err := address.FromBytes(h[:])
 	if err != nil {
 		log.L().Panic("Error when constructing the address of rewarding protocol", zap.Error(err))
 	}
-	return &Protocol{
+	p := &Protocol{
 		keyPrefix: h[:],
 		addr:      addr,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
+// Address returns the address of the rewarding fund, which is the beneficiary of the gas fee
+func (p *Protocol) Address() address.Address { return p.addr }
+
 // Handle handles the actions on the rewarding protocol
 func (p *Protocol) Handle(
 	ctx context.Context,
@@ -75,17 +97,44 @@ func (p *Protocol) Handle(
 				return p.settleAction(ctx, sm, 1), nil
 			}
 			return p.settleAction(ctx, sm, 0), nil
+		case action.EpochRewardCap:
+			if err := p.SetEpochRewardCap(ctx, sm, act.Amount()); err != nil {
+				return p.settleAction(ctx, sm, 1), nil
+			}
+			return p.settleAction(ctx, sm, 0), nil
+		case action.MinClaimAmount:
+			if err := p.SetMinClaimAmount(ctx, sm, act.Amount()); err != nil {
+				return p.settleAction(ctx, sm, 1), nil
+			}
+			return p.settleAction(ctx, sm, 0), nil
+		}
+	case *action.SetRewardingAdmin:
+		if err := p.setRewardingAdmin(ctx, sm, act); err != nil {
+			return p.settleAction(ctx, sm, 1), nil
 		}
+		return p.settleAction(ctx, sm, 0), nil
 	case *action.DepositToRewardingFund:
 		if err := p.Deposit(ctx, sm, act.Amount()); err != nil {
 			return p.settleAction(ctx, sm, 1), nil
 		}
 		return p.settleAction(ctx, sm, 0), nil
 	case *action.ClaimFromRewardingFund:
-		if err := p.Claim(ctx, sm, act.Amount()); err != nil {
+		raCtx := protocol.MustGetRunActionsCtx(ctx)
+		recipient := raCtx.Caller
+		if act.Recipient() != "" {
+			addr, err := address.FromString(act.Recipient())
+			if err != nil {
+				return p.settleAction(ctx, sm, 1), nil
+			}
+			recipient = addr
+		}
+		if err := p.ClaimTo(ctx, sm, act.Amount(), recipient); err != nil {
 			return p.settleAction(ctx, sm, 1), nil
 		}
-		return p.settleAction(ctx, sm, 0), nil
+		if p.claimLogHeight == 0 || raCtx.BlockHeight < p.claimLogHeight {
+			return p.settleAction(ctx, sm, 0), nil
+		}
+		return p.settleAction(ctx, sm, 0, p.claimLog(raCtx, recipient, act.Amount())), nil
 	case *action.GrantReward:
 		switch act.RewardType() {
 		case action.BlockReward:
@@ -109,10 +158,61 @@ func (p *Protocol) Validate(
 	act action.Action,
 ) error {
 	// TODO: validate interface shouldn't be required for protocol code
+	switch act := act.(type) {
+	case *action.SetRewardingAdmin:
+		if _, _, err := parseRewardingAdmin(act); err != nil {
+			return err
+		}
+	case *action.ClaimFromRewardingFund:
+		if act.Recipient() == "" {
+			return nil
+		}
+		if _, err := address.FromString(act.Recipient()); err != nil {
+			return errors.Wrapf(err, "error when validating recipient's address %s", act.Recipient())
+		}
+	}
 	return nil
 }
 
-func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "EpochRewardCap":
+		epochRewardCap, err := p.EpochRewardCap(ctx, sm)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(epochRewardCap.String()), nil
+	case "MinClaimAmount":
+		minClaimAmount, err := p.MinClaimAmount(ctx, sm)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(minClaimAmount.String()), nil
+	case "EpochSnapshot":
+		if len(args) != 1 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		epoch, err := strconv.ParseUint(string(args[0]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when parsing epoch number %s", string(args[0]))
+		}
+		s := epochSnapshot{}
+		if err := p.state(sm, epochSnapshotKey(epoch), &s); err != nil {
+			return nil, err
+		}
+		return proto.Marshal(s.toProto())
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+func (p *Protocol) state(sm protocol.StateReader, key []byte, value interface{}) error {
 	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
 	return sm.State(keyHash, value)
 }
@@ -131,6 +231,7 @@ func (p *Protocol) settleAction(
 	ctx context.Context,
 	sm protocol.StateManager,
 	status uint64,
+	logs ...*action.Log,
 ) *action.Receipt {
 	raCtx := protocol.MustGetRunActionsCtx(ctx)
 	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
@@ -140,7 +241,7 @@ func (p *Protocol) settleAction(
 	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
 		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
 	}
-	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas)
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas, logs...)
 }
 
 func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
@@ -158,7 +259,12 @@ func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address,
 	return nil
 }
 
-func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsumed uint64) *action.Receipt {
+func (p *Protocol) createReceipt(
+	status uint64,
+	actHash hash.Hash256,
+	gasConsumed uint64,
+	logs ...*action.Log,
+) *action.Receipt {
 	// TODO: need to review the fields
 	return &action.Receipt{
 		ReturnValue:     nil,
@@ -166,6 +272,33 @@ func (p *Protocol) createReceipt(status uint64, actHash hash.Hash256, gasConsume
 		ActHash:         actHash,
 		GasConsumed:     gasConsumed,
 		ContractAddress: p.addr.String(),
-		Logs:            nil,
+		Logs:            logs,
+	}
+}
+
+// ClaimLogOption makes the claims from the rewarding fund emit the claim logs from the fork height, so that the receipts
+// of the claims before it are unchanged
+func ClaimLogOption(height uint64) Option {
+	return func(p *Protocol) {
+		p.claimLogHeight = height
+	}
+}
+
+// claimLog records who claimed how much token to which recipient. The topics are the claim topic, the claimer and the
+// recipient, and the data is the claimed amount
+func (p *Protocol) claimLog(
+	raCtx protocol.RunActionsCtx,
+	recipient address.Address,
+	amount *big.Int,
+) *action.Log {
+	var claimer, to hash.Hash256
+	claimer.SetBytes(raCtx.Caller.Bytes())
+	to.SetBytes(recipient.Bytes())
+	return &action.Log{
+		Address:     p.addr.String(),
+		Topics:      []hash.Hash256{claimTopic, claimer, to},
+		Data:        amount.Bytes(),
+		BlockNumber: raCtx.BlockHeight,
+		TxnHash:     raCtx.ActionHash,
 	}
 }
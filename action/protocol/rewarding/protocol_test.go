@@ -55,7 +55,7 @@ func testProtocol(t *testing.T, test func(*testing.T, context.Context, factory.F
 	)
 	ws, err := stateDB.NewWorkingSet()
 	require.NoError(t, err)
-	require.NoError(t, p.Initialize(ctx, ws, addr, big.NewInt(0), big.NewInt(10), big.NewInt(100)))
+	require.NoError(t, p.Initialize(ctx, ws, addr, big.NewInt(0), big.NewInt(10), big.NewInt(100), 0, 0))
 	require.NoError(t, stateDB.Commit(ws))
 
 	ws, err = stateDB.NewWorkingSet()
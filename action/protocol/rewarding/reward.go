@@ -82,7 +82,7 @@ func (p *Protocol) GrantBlockReward(
 	if err := p.updateAvailableBalance(sm, a.BlockReward); err != nil {
 		return err
 	}
-	if err := p.grantToAccount(sm, raCtx.Producer, a.BlockReward); err != nil {
+	if err := p.grantToAccount(sm, raCtx.BlockHeight, raCtx.Producer, a.BlockReward); err != nil {
 		return err
 	}
 	if err := p.updateRewardHistory(sm, blockRewardHistoryKeyPrefix, raCtx.BlockHeight); err != nil {
@@ -115,15 +115,24 @@ func (p *Protocol) GrantEpochReward(
 	if err != nil {
 		return err
 	}
+	// The amount exceeding the per delegate cap goes back to the available balance of the rewarding fund
+	if excess := capEpochReward(amounts, a.EpochRewardCap); excess.Sign() > 0 {
+		if err := p.updateAvailableBalance(sm, big.NewInt(0).Neg(excess)); err != nil {
+			return err
+		}
+	}
 	for i := range addrs {
-		if err := p.grantToAccount(sm, addrs[i], amounts[i]); err != nil {
+		if err := p.grantToAccount(sm, raCtx.BlockHeight, addrs[i], amounts[i]); err != nil {
 			return err
 		}
 	}
 	if err := p.updateRewardHistory(sm, epochRewardHistoryKeyPrefix, raCtx.EpochNumber); err != nil {
 		return err
 	}
-	return nil
+	if !p.snapshotEnabled(raCtx.BlockHeight) {
+		return nil
+	}
+	return p.takeEpochSnapshot(sm, raCtx.EpochNumber)
 }
 
 // Claim claims the token from the rewarding fund
@@ -136,10 +145,27 @@ func (p *Protocol) Claim(
 	if !ok {
 		log.S().Panic("Miss run action context")
 	}
+	return p.ClaimTo(ctx, sm, amount, raCtx.Caller)
+}
+
+// ClaimTo claims the token from the rewarding fund, and deposits it into the recipient's primary account
+func (p *Protocol) ClaimTo(
+	ctx context.Context,
+	sm protocol.StateManager,
+	amount *big.Int,
+	recipient address.Address,
+) error {
+	raCtx, ok := protocol.GetRunActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss run action context")
+	}
+	if err := p.assertClaimAmount(ctx, sm, raCtx.Caller, amount); err != nil {
+		return err
+	}
 	if err := p.updateTotalBalance(sm, amount); err != nil {
 		return err
 	}
-	if err := p.claimFromAccount(sm, raCtx.Caller, amount); err != nil {
+	if err := p.claimFromAccount(sm, raCtx.BlockHeight, raCtx.Caller, recipient, amount); err != nil {
 		return err
 	}
 	return nil
@@ -163,6 +189,34 @@ func (p *Protocol) UnclaimedBalance(
 	return nil, err
 }
 
+func (p *Protocol) assertClaimAmount(
+	ctx context.Context,
+	sm protocol.StateManager,
+	addr address.Address,
+	amount *big.Int,
+) error {
+	a := admin{}
+	if err := p.state(sm, adminKey, &a); err != nil {
+		return err
+	}
+	if amount.Cmp(a.MinClaimAmount) >= 0 {
+		return nil
+	}
+	// Claiming all the remaining balance is always allowed, so that the dust won't be locked in the account
+	balance, err := p.UnclaimedBalance(ctx, sm, addr)
+	if err != nil {
+		return err
+	}
+	if amount.Cmp(balance) == 0 {
+		return nil
+	}
+	return errors.Errorf(
+		"claim amount %s is less than the min claim amount %s",
+		amount.String(),
+		a.MinClaimAmount.String(),
+	)
+}
+
 func (p *Protocol) updateTotalBalance(sm protocol.StateManager, amount *big.Int) error {
 	f := fund{}
 	if err := p.state(sm, fundKey, &f); err != nil {
@@ -195,7 +249,12 @@ func (p *Protocol) updateAvailableBalance(sm protocol.StateManager, amount *big.
 	return nil
 }
 
-func (p *Protocol) grantToAccount(sm protocol.StateManager, addr address.Address, amount *big.Int) error {
+func (p *Protocol) grantToAccount(
+	sm protocol.StateManager,
+	height uint64,
+	addr address.Address,
+	amount *big.Int,
+) error {
 	acc := rewardAccount{}
 	accKey := append(adminKey, addr.Bytes()...)
 	if err := p.state(sm, accKey, &acc); err != nil {
@@ -206,6 +265,12 @@ func (p *Protocol) grantToAccount(sm protocol.StateManager, addr address.Address
 			balance: big.NewInt(0),
 		}
 	}
+	// The account created before the snapshots are enabled is tracked once it's granted again
+	if p.snapshotEnabled(height) {
+		if err := p.addBeneficiary(sm, addr); err != nil {
+			return err
+		}
+	}
 	acc.balance = big.NewInt(0).Add(acc.balance, amount)
 	if err := p.putState(sm, accKey, &acc); err != nil {
 		return err
@@ -213,7 +278,13 @@ func (p *Protocol) grantToAccount(sm protocol.StateManager, addr address.Address
 	return nil
 }
 
-func (p *Protocol) claimFromAccount(sm protocol.StateManager, addr address.Address, amount *big.Int) error {
+func (p *Protocol) claimFromAccount(
+	sm protocol.StateManager,
+	height uint64,
+	addr address.Address,
+	recipient address.Address,
+	amount *big.Int,
+) error {
 	// Update reward account
 	acc := rewardAccount{}
 	accKey := append(adminKey, addr.Bytes()...)
@@ -228,21 +299,31 @@ func (p *Protocol) claimFromAccount(sm protocol.StateManager, addr address.Addre
 		if err := p.deleteState(sm, accKey); err != nil {
 			return err
 		}
+		if p.snapshotEnabled(height) {
+			if err := p.removeBeneficiary(sm, addr); err != nil {
+				return err
+			}
+		}
 	} else {
 		acc.balance = balance
 		if err := p.putState(sm, accKey, &acc); err != nil {
 			return err
 		}
+		if p.snapshotEnabled(height) {
+			if err := p.addBeneficiary(sm, addr); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Update primary account
-	primAcc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	primAcc, err := util.LoadOrCreateAccount(sm, recipient.String(), big.NewInt(0))
 	if err != nil {
 		return err
 	}
 	primAcc.Balance = big.NewInt(0).Add(primAcc.Balance, amount)
-	if err := util.StoreAccount(sm, addr.String(), primAcc); err != nil {
-
+	if err := util.StoreAccount(sm, recipient.String(), primAcc); err != nil {
+		return err
 	}
 	return nil
 }
@@ -261,6 +342,23 @@ func (p *Protocol) splitEpochReward(totalAmount *big.Int) ([]address.Address, []
 	return nil, nil, nil
 }
 
+// capEpochReward caps each epoch reward share to the given amount in place, and returns the total amount exceeding the
+// cap. 0 cap means no cap
+func capEpochReward(amounts []*big.Int, capAmount *big.Int) *big.Int {
+	excess := big.NewInt(0)
+	if capAmount.Sign() == 0 {
+		return excess
+	}
+	for i, amount := range amounts {
+		if amount.Cmp(capAmount) <= 0 {
+			continue
+		}
+		excess.Add(excess, big.NewInt(0).Sub(amount, capAmount))
+		amounts[i] = big.NewInt(0).Set(capAmount)
+	}
+	return excess
+}
+
 func (p *Protocol) assertNoRewardYet(sm protocol.StateManager, prefix []byte, index uint64) error {
 	history := rewardHistory{}
 	var indexBytes [8]byte
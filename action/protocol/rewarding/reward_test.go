@@ -11,11 +11,15 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/iotexproject/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state/factory"
 )
@@ -139,3 +143,119 @@ func TestProtocol_ClaimReward(t *testing.T) {
 		require.Error(t, p.Claim(claimCtx, ws, big.NewInt(5)))
 	})
 }
+
+func TestProtocol_ClaimRewardWithMinAmount(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.Deposit(ctx, ws, big.NewInt(20)))
+		require.NoError(t, p.SetMinClaimAmount(ctx, ws, big.NewInt(4)))
+		require.NoError(t, p.GrantBlockReward(ctx, ws))
+		require.NoError(t, stateDB.Commit(ws))
+
+		raCtx, ok := protocol.GetRunActionsCtx(ctx)
+		require.True(t, ok)
+		claimRaCtx := raCtx
+		claimRaCtx.Caller = raCtx.Producer
+		claimCtx := protocol.WithRunActionsCtx(context.Background(), claimRaCtx)
+
+		// Claim 3 token will fail because it's less than the min claim amount
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.Error(t, p.Claim(claimCtx, ws, big.NewInt(3)))
+
+		// Claim 7 token
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.Claim(claimCtx, ws, big.NewInt(7)))
+		require.NoError(t, stateDB.Commit(ws))
+
+		// Claim the remaining 3 token is allowed even if it's less than the min claim amount
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.Claim(claimCtx, ws, big.NewInt(3)))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		unclaimedBalance, err := p.UnclaimedBalance(ctx, ws, raCtx.Producer)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(0), unclaimedBalance)
+	})
+}
+
+func TestProtocol_ClaimRewardToRecipient(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.Deposit(ctx, ws, big.NewInt(20)))
+		require.NoError(t, p.GrantBlockReward(ctx, ws))
+		require.NoError(t, stateDB.Commit(ws))
+
+		raCtx, ok := protocol.GetRunActionsCtx(ctx)
+		require.True(t, ok)
+		claimRaCtx := raCtx
+		claimRaCtx.Caller = raCtx.Producer
+		claimRaCtx.GasPrice = big.NewInt(0)
+		claimCtx := protocol.WithRunActionsCtx(context.Background(), claimRaCtx)
+
+		sk, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		pkHash := keypair.HashPubKey(&sk.PublicKey)
+		recipient, err := address.FromBytes(pkHash[:])
+		require.NoError(t, err)
+
+		// Claim with an invalid recipient will fail the validation
+		b := action.ClaimFromRewardingFundBuilder{}
+		claim := b.SetAmount(big.NewInt(4)).SetRecipient("invalid").Build()
+		require.Error(t, p.Validate(claimCtx, &claim))
+
+		// Claim 1 token into the recipient's account before the claim logs are enabled
+		claim = b.SetAmount(big.NewInt(1)).SetRecipient(recipient.String()).Build()
+		require.NoError(t, p.Validate(claimCtx, &claim))
+		ClaimLogOption(raCtx.BlockHeight + 1)(p)
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		receipt, err := p.Handle(claimCtx, &claim, ws)
+		require.NoError(t, err)
+		require.NoError(t, stateDB.Commit(ws))
+		require.Equal(t, 0, len(receipt.Logs))
+
+		// Claim 3 more token into the recipient's account, which emits the claim log
+		claim = b.SetAmount(big.NewInt(3)).SetRecipient(recipient.String()).Build()
+		ClaimLogOption(raCtx.BlockHeight)(p)
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		receipt, err = p.Handle(claimCtx, &claim, ws)
+		require.NoError(t, err)
+		require.NoError(t, stateDB.Commit(ws))
+		require.Equal(t, 1, len(receipt.Logs))
+		assert.Equal(t, claimTopic, receipt.Logs[0].Topics[0])
+		assert.Equal(t, raCtx.Producer.Bytes(), receipt.Logs[0].Topics[1][12:])
+		assert.Equal(t, recipient.Bytes(), receipt.Logs[0].Topics[2][12:])
+		assert.Equal(t, big.NewInt(3).Bytes(), receipt.Logs[0].Data)
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		unclaimedBalance, err := p.UnclaimedBalance(ctx, ws, raCtx.Producer)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(6), unclaimedBalance)
+		recipientAcc, err := util.LoadAccount(ws, byteutil.BytesTo20B(recipient.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(4), recipientAcc.Balance)
+		producerAcc, err := util.LoadAccount(ws, byteutil.BytesTo20B(raCtx.Producer.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(0), producerAcc.Balance)
+	})
+}
+
+func TestCapEpochReward(t *testing.T) {
+	amounts := []*big.Int{big.NewInt(10), big.NewInt(50), big.NewInt(80)}
+	excess := capEpochReward(amounts, big.NewInt(0))
+	assert.Equal(t, big.NewInt(0), excess)
+	assert.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(50), big.NewInt(80)}, amounts)
+
+	excess = capEpochReward(amounts, big.NewInt(30))
+	assert.Equal(t, big.NewInt(70), excess)
+	assert.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(30), big.NewInt(30)}, amounts)
+}
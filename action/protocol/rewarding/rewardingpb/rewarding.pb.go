@@ -19,19 +19,26 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type Admin struct {
-	Admin                []byte   `protobuf:"bytes,1,opt,name=admin,proto3" json:"admin,omitempty"`
-	BlockReward          []byte   `protobuf:"bytes,2,opt,name=blockReward,proto3" json:"blockReward,omitempty"`
-	EpochReward          []byte   `protobuf:"bytes,3,opt,name=epochReward,proto3" json:"epochReward,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Admin                    []byte   `protobuf:"bytes,1,opt,name=admin,proto3" json:"admin,omitempty"`
+	BlockReward              []byte   `protobuf:"bytes,2,opt,name=blockReward,proto3" json:"blockReward,omitempty"`
+	EpochReward              []byte   `protobuf:"bytes,3,opt,name=epochReward,proto3" json:"epochReward,omitempty"`
+	GasFeeBurnPercentage     uint64   `protobuf:"varint,4,opt,name=gasFeeBurnPercentage,proto3" json:"gasFeeBurnPercentage,omitempty"`
+	GasFeeProducerPercentage uint64   `protobuf:"varint,5,opt,name=gasFeeProducerPercentage,proto3" json:"gasFeeProducerPercentage,omitempty"`
+	EpochRewardCap           []byte   `protobuf:"bytes,6,opt,name=epochRewardCap,proto3" json:"epochRewardCap,omitempty"`
+	MinClaimAmount           []byte   `protobuf:"bytes,7,opt,name=minClaimAmount,proto3" json:"minClaimAmount,omitempty"`
+	MultisigAdmins           [][]byte `protobuf:"bytes,8,rep,name=multisigAdmins,proto3" json:"multisigAdmins,omitempty"`
+	MultisigThreshold        uint64   `protobuf:"varint,9,opt,name=multisigThreshold,proto3" json:"multisigThreshold,omitempty"`
+	ProposalTTL              uint64   `protobuf:"varint,10,opt,name=proposalTTL,proto3" json:"proposalTTL,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
 }
 
 func (m *Admin) Reset()         { *m = Admin{} }
 func (m *Admin) String() string { return proto.CompactTextString(m) }
 func (*Admin) ProtoMessage()    {}
 func (*Admin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rewarding_59a21f7fb859b992, []int{0}
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{0}
 }
 func (m *Admin) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Admin.Unmarshal(m, b)
@@ -72,6 +79,55 @@ func (m *Admin) GetEpochReward() []byte {
 	return nil
 }
 
+func (m *Admin) GetGasFeeBurnPercentage() uint64 {
+	if m != nil {
+		return m.GasFeeBurnPercentage
+	}
+	return 0
+}
+
+func (m *Admin) GetGasFeeProducerPercentage() uint64 {
+	if m != nil {
+		return m.GasFeeProducerPercentage
+	}
+	return 0
+}
+
+func (m *Admin) GetEpochRewardCap() []byte {
+	if m != nil {
+		return m.EpochRewardCap
+	}
+	return nil
+}
+
+func (m *Admin) GetMinClaimAmount() []byte {
+	if m != nil {
+		return m.MinClaimAmount
+	}
+	return nil
+}
+
+func (m *Admin) GetMultisigAdmins() [][]byte {
+	if m != nil {
+		return m.MultisigAdmins
+	}
+	return nil
+}
+
+func (m *Admin) GetMultisigThreshold() uint64 {
+	if m != nil {
+		return m.MultisigThreshold
+	}
+	return 0
+}
+
+func (m *Admin) GetProposalTTL() uint64 {
+	if m != nil {
+		return m.ProposalTTL
+	}
+	return 0
+}
+
 type Fund struct {
 	TotalBalance         []byte   `protobuf:"bytes,1,opt,name=totalBalance,proto3" json:"totalBalance,omitempty"`
 	UnclaimedBalance     []byte   `protobuf:"bytes,2,opt,name=unclaimedBalance,proto3" json:"unclaimedBalance,omitempty"`
@@ -84,7 +140,7 @@ func (m *Fund) Reset()         { *m = Fund{} }
 func (m *Fund) String() string { return proto.CompactTextString(m) }
 func (*Fund) ProtoMessage()    {}
 func (*Fund) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rewarding_59a21f7fb859b992, []int{1}
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{1}
 }
 func (m *Fund) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Fund.Unmarshal(m, b)
@@ -128,7 +184,7 @@ func (m *RewardHistory) Reset()         { *m = RewardHistory{} }
 func (m *RewardHistory) String() string { return proto.CompactTextString(m) }
 func (*RewardHistory) ProtoMessage()    {}
 func (*RewardHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rewarding_59a21f7fb859b992, []int{2}
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{2}
 }
 func (m *RewardHistory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RewardHistory.Unmarshal(m, b)
@@ -159,7 +215,7 @@ func (m *Account) Reset()         { *m = Account{} }
 func (m *Account) String() string { return proto.CompactTextString(m) }
 func (*Account) ProtoMessage()    {}
 func (*Account) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rewarding_59a21f7fb859b992, []int{3}
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{3}
 }
 func (m *Account) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Account.Unmarshal(m, b)
@@ -186,27 +242,219 @@ func (m *Account) GetBalance() []byte {
 	return nil
 }
 
+type Proposal struct {
+	Approvers            [][]byte `protobuf:"bytes,1,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Proposal) Reset()         { *m = Proposal{} }
+func (m *Proposal) String() string { return proto.CompactTextString(m) }
+func (*Proposal) ProtoMessage()    {}
+func (*Proposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{4}
+}
+func (m *Proposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Proposal.Unmarshal(m, b)
+}
+func (m *Proposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Proposal.Marshal(b, m, deterministic)
+}
+func (dst *Proposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Proposal.Merge(dst, src)
+}
+func (m *Proposal) XXX_Size() int {
+	return xxx_messageInfo_Proposal.Size(m)
+}
+func (m *Proposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Proposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Proposal proto.InternalMessageInfo
+
+func (m *Proposal) GetApprovers() [][]byte {
+	if m != nil {
+		return m.Approvers
+	}
+	return nil
+}
+
+func (m *Proposal) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type Beneficiaries struct {
+	Addresses            [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Beneficiaries) Reset()         { *m = Beneficiaries{} }
+func (m *Beneficiaries) String() string { return proto.CompactTextString(m) }
+func (*Beneficiaries) ProtoMessage()    {}
+func (*Beneficiaries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{5}
+}
+func (m *Beneficiaries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Beneficiaries.Unmarshal(m, b)
+}
+func (m *Beneficiaries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Beneficiaries.Marshal(b, m, deterministic)
+}
+func (dst *Beneficiaries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Beneficiaries.Merge(dst, src)
+}
+func (m *Beneficiaries) XXX_Size() int {
+	return xxx_messageInfo_Beneficiaries.Size(m)
+}
+func (m *Beneficiaries) XXX_DiscardUnknown() {
+	xxx_messageInfo_Beneficiaries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Beneficiaries proto.InternalMessageInfo
+
+func (m *Beneficiaries) GetAddresses() [][]byte {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type Snapshot struct {
+	Addresses            [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Balances             [][]byte `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"`
+	Root                 []byte   `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Snapshot) Reset()         { *m = Snapshot{} }
+func (m *Snapshot) String() string { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()    {}
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{6}
+}
+func (m *Snapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Snapshot.Unmarshal(m, b)
+}
+func (m *Snapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Snapshot.Marshal(b, m, deterministic)
+}
+func (dst *Snapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Snapshot.Merge(dst, src)
+}
+func (m *Snapshot) XXX_Size() int {
+	return xxx_messageInfo_Snapshot.Size(m)
+}
+func (m *Snapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Snapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Snapshot proto.InternalMessageInfo
+
+func (m *Snapshot) GetAddresses() [][]byte {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *Snapshot) GetBalances() [][]byte {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+func (m *Snapshot) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+type Proposals struct {
+	Hashes               [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Proposals) Reset()         { *m = Proposals{} }
+func (m *Proposals) String() string { return proto.CompactTextString(m) }
+func (*Proposals) ProtoMessage()    {}
+func (*Proposals) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rewarding_c29b9264170ad2c3, []int{7}
+}
+func (m *Proposals) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Proposals.Unmarshal(m, b)
+}
+func (m *Proposals) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Proposals.Marshal(b, m, deterministic)
+}
+func (dst *Proposals) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Proposals.Merge(dst, src)
+}
+func (m *Proposals) XXX_Size() int {
+	return xxx_messageInfo_Proposals.Size(m)
+}
+func (m *Proposals) XXX_DiscardUnknown() {
+	xxx_messageInfo_Proposals.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Proposals proto.InternalMessageInfo
+
+func (m *Proposals) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Admin)(nil), "rewardingpb.Admin")
 	proto.RegisterType((*Fund)(nil), "rewardingpb.Fund")
 	proto.RegisterType((*RewardHistory)(nil), "rewardingpb.RewardHistory")
 	proto.RegisterType((*Account)(nil), "rewardingpb.Account")
+	proto.RegisterType((*Proposal)(nil), "rewardingpb.Proposal")
+	proto.RegisterType((*Beneficiaries)(nil), "rewardingpb.Beneficiaries")
+	proto.RegisterType((*Snapshot)(nil), "rewardingpb.Snapshot")
+	proto.RegisterType((*Proposals)(nil), "rewardingpb.Proposals")
 }
 
-func init() { proto.RegisterFile("rewarding.proto", fileDescriptor_rewarding_59a21f7fb859b992) }
-
-var fileDescriptor_rewarding_59a21f7fb859b992 = []byte{
-	// 189 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2f, 0x4a, 0x2d, 0x4f,
-	0x2c, 0x4a, 0xc9, 0xcc, 0x4b, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x86, 0x0b, 0x14,
-	0x24, 0x29, 0x25, 0x72, 0xb1, 0x3a, 0xa6, 0xe4, 0x66, 0xe6, 0x09, 0x89, 0x70, 0xb1, 0x26, 0x82,
-	0x18, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x10, 0x8e, 0x90, 0x02, 0x17, 0x77, 0x52, 0x4e,
-	0x7e, 0x72, 0x76, 0x10, 0x58, 0x8b, 0x04, 0x13, 0x58, 0x0e, 0x59, 0x08, 0xa4, 0x22, 0xb5, 0x20,
-	0x3f, 0x39, 0x03, 0xaa, 0x82, 0x19, 0xa2, 0x02, 0x49, 0x48, 0x29, 0x8c, 0x8b, 0xc5, 0xad, 0x34,
-	0x2f, 0x45, 0x48, 0x89, 0x8b, 0xa7, 0x24, 0xbf, 0x24, 0x31, 0xc7, 0x29, 0x31, 0x27, 0x31, 0x2f,
-	0x39, 0x15, 0x6a, 0x11, 0x8a, 0x98, 0x90, 0x16, 0x97, 0x40, 0x69, 0x5e, 0x72, 0x4e, 0x62, 0x66,
-	0x6e, 0x6a, 0x0a, 0x4c, 0x1d, 0xc4, 0x52, 0x0c, 0x71, 0x25, 0x7e, 0x2e, 0x5e, 0x88, 0x0d, 0x1e,
-	0x99, 0xc5, 0x25, 0xf9, 0x45, 0x95, 0x4a, 0xca, 0x5c, 0xec, 0x8e, 0xc9, 0xc9, 0xf9, 0xa5, 0x79,
-	0x25, 0x42, 0x12, 0x5c, 0xec, 0x49, 0x28, 0xda, 0x61, 0xdc, 0x24, 0x36, 0x70, 0x20, 0x18, 0x03,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xae, 0xbc, 0x16, 0x18, 0x17, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("rewarding.proto", fileDescriptor_rewarding_c29b9264170ad2c3) }
+
+var fileDescriptor_rewarding_c29b9264170ad2c3 = []byte{
+	// 376 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x41, 0x8f, 0xd3, 0x30,
+	0x10, 0x46, 0x95, 0x6e, 0xba, 0xcd, 0xce, 0xee, 0xb2, 0x60, 0xed, 0xc1, 0x42, 0x1c, 0x22, 0x23,
+	0xa1, 0x0a, 0x01, 0x07, 0xb8, 0x71, 0x6b, 0x57, 0xaa, 0x38, 0x56, 0x05, 0x21, 0xae, 0x8e, 0x3d,
+	0x24, 0x16, 0x89, 0x6d, 0xd9, 0x0e, 0x88, 0x5f, 0xce, 0x15, 0xc5, 0x49, 0xda, 0x84, 0x02, 0x37,
+	0xcf, 0xfb, 0xde, 0x28, 0x33, 0x8e, 0xe1, 0xce, 0xe1, 0x0f, 0xee, 0xa4, 0xd2, 0xe5, 0x1b, 0xeb,
+	0x4c, 0x30, 0xe4, 0xfa, 0x08, 0x6c, 0xc1, 0x7e, 0x2d, 0x60, 0xb9, 0x91, 0x8d, 0xd2, 0xe4, 0x1e,
+	0x96, 0xbc, 0x3b, 0xd0, 0x24, 0x4f, 0xd6, 0x37, 0x87, 0xbe, 0x20, 0x39, 0x5c, 0x17, 0xb5, 0x11,
+	0xdf, 0x0e, 0xb1, 0x87, 0x2e, 0x62, 0x36, 0x45, 0x9d, 0x81, 0xd6, 0x88, 0x6a, 0x30, 0x2e, 0x7a,
+	0x63, 0x82, 0xc8, 0x5b, 0xb8, 0x2f, 0xb9, 0xdf, 0x21, 0x6e, 0x5b, 0xa7, 0xf7, 0xe8, 0x04, 0xea,
+	0xc0, 0x4b, 0xa4, 0x69, 0x9e, 0xac, 0xd3, 0xc3, 0x5f, 0x33, 0xf2, 0x1e, 0x68, 0xcf, 0xf7, 0xce,
+	0xc8, 0x56, 0xa0, 0x9b, 0xf4, 0x2d, 0x63, 0xdf, 0x3f, 0x73, 0xf2, 0x02, 0x1e, 0x4d, 0x3e, 0xff,
+	0xc0, 0x2d, 0xbd, 0x8c, 0x43, 0xfd, 0x41, 0x3b, 0xaf, 0x51, 0xfa, 0xa1, 0xe6, 0xaa, 0xd9, 0x34,
+	0xa6, 0xd5, 0x81, 0xae, 0x7a, 0x6f, 0x4e, 0xa3, 0xd7, 0xd6, 0x41, 0x79, 0x55, 0xc6, 0xab, 0xf2,
+	0x34, 0xcb, 0x2f, 0xa2, 0x37, 0xa3, 0xe4, 0x15, 0x3c, 0x19, 0xc9, 0xa7, 0xca, 0xa1, 0xaf, 0x4c,
+	0x2d, 0xe9, 0x55, 0x1c, 0xf6, 0x3c, 0x60, 0x9f, 0x21, 0xdd, 0xb5, 0x5a, 0x12, 0x06, 0x37, 0xc1,
+	0x04, 0x5e, 0x6f, 0x79, 0xcd, 0xb5, 0xc0, 0xe1, 0xfa, 0x67, 0x8c, 0xbc, 0x84, 0xc7, 0xad, 0x16,
+	0xdd, 0x48, 0x28, 0x47, 0xaf, 0xff, 0x15, 0x67, 0x9c, 0xdd, 0xc1, 0x6d, 0xbf, 0xe2, 0x07, 0xe5,
+	0x83, 0x71, 0x3f, 0xd9, 0x73, 0x58, 0x6d, 0x84, 0x88, 0x9b, 0x50, 0x58, 0x15, 0xb3, 0xf6, 0xb1,
+	0x64, 0x6b, 0xc8, 0xf6, 0xce, 0x58, 0xe3, 0x79, 0x4d, 0x9e, 0xc1, 0x15, 0xb7, 0xd6, 0x99, 0xef,
+	0xe8, 0x3c, 0x4d, 0xe2, 0xaa, 0x27, 0xc0, 0x5e, 0xc3, 0xed, 0x16, 0x35, 0x7e, 0x55, 0x42, 0x71,
+	0xa7, 0xd0, 0x47, 0x5d, 0x4a, 0x87, 0xde, 0xe3, 0x49, 0x1f, 0x01, 0xfb, 0x02, 0xd9, 0x47, 0xcd,
+	0xad, 0xaf, 0x4c, 0xf8, 0xbf, 0x49, 0x9e, 0x42, 0x36, 0x4c, 0xe3, 0xe9, 0x22, 0x86, 0xc7, 0x9a,
+	0x10, 0x48, 0x9d, 0x31, 0x61, 0x78, 0x5d, 0xf1, 0x5c, 0x5c, 0xc6, 0xe7, 0xfc, 0xee, 0xf7, 0x00,
+	0x94, 0x55, 0x29, 0x55, 0xe1, 0x02, 0x00, 0x00,
 }
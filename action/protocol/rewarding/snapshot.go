@@ -0,0 +1,212 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// EpochSnapshotOption enables the epoch snapshots from the fork height, and keeps the snapshots of the latest epochs
+// of the retention only. Only the rewarding accounts granted or claimed since the fork height are tracked in the
+// snapshots. 0 retention keeps all the snapshots
+func EpochSnapshotOption(height uint64, retention uint64) Option {
+	return func(p *Protocol) {
+		p.snapshotHeight = height
+		p.snapshotRetention = retention
+	}
+}
+
+// snapshotEnabled checks whether the epoch snapshots are taken at the height
+func (p *Protocol) snapshotEnabled(height uint64) bool {
+	return p.snapshotHeight != 0 && height >= p.snapshotHeight
+}
+
+// beneficiaries stores the addresses which own a rewarding account, in the order of the account creation
+type beneficiaries struct {
+	addrs []address.Address
+}
+
+// Serialize serializes beneficiaries state into bytes
+func (b beneficiaries) Serialize() ([]byte, error) {
+	gen := rewardingpb.Beneficiaries{}
+	for _, addr := range b.addrs {
+		gen.Addresses = append(gen.Addresses, addr.Bytes())
+	}
+	return proto.Marshal(&gen)
+}
+
+// Deserialize deserializes bytes into beneficiaries state
+func (b *beneficiaries) Deserialize(data []byte) error {
+	gen := rewardingpb.Beneficiaries{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	b.addrs = nil
+	for _, addrBytes := range gen.Addresses {
+		addr, err := address.FromBytes(addrBytes)
+		if err != nil {
+			return err
+		}
+		b.addrs = append(b.addrs, addr)
+	}
+	return nil
+}
+
+// epochSnapshot stores the balances of all the rewarding accounts at the end of an epoch, and the Merkle root of them
+type epochSnapshot struct {
+	addrs    []address.Address
+	balances []*big.Int
+	root     hash.Hash256
+}
+
+// Serialize serializes epoch snapshot state into bytes
+func (s epochSnapshot) Serialize() ([]byte, error) {
+	return proto.Marshal(s.toProto())
+}
+
+// Deserialize deserializes bytes into epoch snapshot state
+func (s *epochSnapshot) Deserialize(data []byte) error {
+	gen := rewardingpb.Snapshot{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	if len(gen.Addresses) != len(gen.Balances) {
+		return errors.Errorf(
+			"number of addresses %d doesn't match number of balances %d",
+			len(gen.Addresses),
+			len(gen.Balances),
+		)
+	}
+	*s = epochSnapshot{}
+	for i := range gen.Addresses {
+		addr, err := address.FromBytes(gen.Addresses[i])
+		if err != nil {
+			return err
+		}
+		s.addrs = append(s.addrs, addr)
+		s.balances = append(s.balances, big.NewInt(0).SetBytes(gen.Balances[i]))
+	}
+	copy(s.root[:], gen.Root)
+	return nil
+}
+
+func (s *epochSnapshot) toProto() *rewardingpb.Snapshot {
+	gen := rewardingpb.Snapshot{
+		Root: s.root[:],
+	}
+	for i := range s.addrs {
+		gen.Addresses = append(gen.Addresses, s.addrs[i].Bytes())
+		gen.Balances = append(gen.Balances, s.balances[i].Bytes())
+	}
+	return &gen
+}
+
+// EpochSnapshot returns the balances of all the rewarding accounts at the end of the given epoch, and the Merkle root
+// of them. Each leaf of the Merkle tree is the hash of the address bytes followed by the balance bytes
+func (p *Protocol) EpochSnapshot(
+	_ context.Context,
+	sm protocol.StateManager,
+	epoch uint64,
+) ([]address.Address, []*big.Int, hash.Hash256, error) {
+	s := epochSnapshot{}
+	if err := p.state(sm, epochSnapshotKey(epoch), &s); err != nil {
+		return nil, nil, hash.ZeroHash256, err
+	}
+	return s.addrs, s.balances, s.root, nil
+}
+
+// takeEpochSnapshot takes the snapshot of the epoch, and deletes the one falling out of the retention
+func (p *Protocol) takeEpochSnapshot(sm protocol.StateManager, epoch uint64) error {
+	b := beneficiaries{}
+	if err := p.state(sm, beneficiariesKey, &b); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	s := epochSnapshot{}
+	leaves := make([]hash.Hash256, 0, len(b.addrs))
+	for _, addr := range b.addrs {
+		acc := rewardAccount{}
+		if err := p.state(sm, append(adminKey, addr.Bytes()...), &acc); err != nil {
+			return err
+		}
+		s.addrs = append(s.addrs, addr)
+		s.balances = append(s.balances, acc.balance)
+		leaf := make([]byte, 0, len(addr.Bytes())+len(acc.balance.Bytes()))
+		leaf = append(leaf, addr.Bytes()...)
+		leaves = append(leaves, hash.Hash256b(append(leaf, acc.balance.Bytes()...)))
+	}
+	if len(leaves) > 0 {
+		s.root = crypto.NewMerkleTree(leaves).HashTree()
+	}
+	if err := p.putState(sm, epochSnapshotKey(epoch), &s); err != nil {
+		return err
+	}
+	if p.snapshotRetention == 0 || epoch <= p.snapshotRetention {
+		return nil
+	}
+	expiredKey := epochSnapshotKey(epoch - p.snapshotRetention)
+	switch err := p.state(sm, expiredKey, &epochSnapshot{}); errors.Cause(err) {
+	case nil:
+		return p.deleteState(sm, expiredKey)
+	case state.ErrStateNotExist:
+		return nil
+	default:
+		return err
+	}
+}
+
+// addBeneficiary adds the address into the beneficiaries if it isn't there yet
+func (p *Protocol) addBeneficiary(sm protocol.StateManager, addr address.Address) error {
+	b := beneficiaries{}
+	if err := p.state(sm, beneficiariesKey, &b); err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return err
+	}
+	for _, beneficiary := range b.addrs {
+		if bytes.Equal(beneficiary.Bytes(), addr.Bytes()) {
+			return nil
+		}
+	}
+	b.addrs = append(b.addrs, addr)
+	return p.putState(sm, beneficiariesKey, &b)
+}
+
+func (p *Protocol) removeBeneficiary(sm protocol.StateManager, addr address.Address) error {
+	b := beneficiaries{}
+	if err := p.state(sm, beneficiariesKey, &b); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil
+		}
+		return err
+	}
+	for i := range b.addrs {
+		if bytes.Equal(b.addrs[i].Bytes(), addr.Bytes()) {
+			b.addrs = append(b.addrs[:i], b.addrs[i+1:]...)
+			return p.putState(sm, beneficiariesKey, &b)
+		}
+	}
+	return nil
+}
+
+func epochSnapshotKey(epoch uint64) []byte {
+	var epochBytes [8]byte
+	enc.MachineEndian.PutUint64(epochBytes[:], epoch)
+	key := make([]byte, 0, len(epochSnapshotKeyPrefix)+len(epochBytes))
+	key = append(key, epochSnapshotKeyPrefix...)
+	return append(key, epochBytes[:]...)
+}
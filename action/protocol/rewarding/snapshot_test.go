@@ -0,0 +1,96 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+func TestProtocol_EpochSnapshot(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		raCtx, ok := protocol.GetRunActionsCtx(ctx)
+		require.True(t, ok)
+		EpochSnapshotOption(2, 1)(p)
+		ctxAt := func(caller address.Address, height uint64) context.Context {
+			raCtxAt := raCtx
+			raCtxAt.Caller = caller
+			raCtxAt.BlockHeight = height
+			raCtxAt.EpochNumber = height
+			return protocol.WithRunActionsCtx(context.Background(), raCtxAt)
+		}
+
+		// No snapshot is taken before the fork height
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.Deposit(ctx, ws, big.NewInt(400)))
+		require.NoError(t, p.GrantBlockReward(ctx, ws))
+		require.NoError(t, p.GrantEpochReward(ctx, ws))
+		require.NoError(t, stateDB.Commit(ws))
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		_, _, _, err = p.EpochSnapshot(ctx, ws, 1)
+		require.Error(t, err)
+
+		// The account created before the fork height is tracked once it's granted again
+		require.NoError(t, p.GrantBlockReward(ctxAt(raCtx.Caller, 2), ws))
+		require.NoError(t, p.GrantEpochReward(ctxAt(raCtx.Caller, 2), ws))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		addrs, balances, root, err := p.EpochSnapshot(ctx, ws, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []address.Address{raCtx.Producer}, addrs)
+		assert.Equal(t, []*big.Int{big.NewInt(20)}, balances)
+		leaf := hash.Hash256b(append(raCtx.Producer.Bytes(), big.NewInt(20).Bytes()...))
+		assert.Equal(t, crypto.NewMerkleTree([]hash.Hash256{leaf}).HashTree(), root)
+
+		data, err := p.ReadState(ctx, ws, []byte("EpochSnapshot"), []byte("2"))
+		require.NoError(t, err)
+		snapshot := rewardingpb.Snapshot{}
+		require.NoError(t, proto.Unmarshal(data, &snapshot))
+		assert.Equal(t, [][]byte{raCtx.Producer.Bytes()}, snapshot.Addresses)
+		assert.Equal(t, [][]byte{big.NewInt(20).Bytes()}, snapshot.Balances)
+		assert.Equal(t, root[:], snapshot.Root)
+
+		// No snapshot for epoch 3 yet
+		_, _, _, err = p.EpochSnapshot(ctx, ws, 3)
+		require.Error(t, err)
+		_, err = p.ReadState(ctx, ws, []byte("EpochSnapshot"), []byte("a"))
+		require.Error(t, err)
+
+		// The producer claims all the balance, and is removed from the snapshot of epoch 3
+		require.NoError(t, p.Claim(ctxAt(raCtx.Producer, 3), ws, big.NewInt(20)))
+		require.NoError(t, p.GrantEpochReward(ctxAt(raCtx.Caller, 3), ws))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		addrs, balances, root, err = p.EpochSnapshot(ctx, ws, 3)
+		require.NoError(t, err)
+		assert.Empty(t, addrs)
+		assert.Empty(t, balances)
+		assert.Equal(t, hash.ZeroHash256, root)
+
+		// The snapshot falling out of the retention is deleted
+		_, _, _, err = p.EpochSnapshot(ctx, ws, 2)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/session/sessionpb"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	ProtocolID = "session"
+	// MaxMicroActions is the max number of the micro actions in a session envelope
+	MaxMicroActions = 1000
+)
+
+var (
+	deviceKeyPrefix = []byte("device")
+	// anchorTopic is the topic of the log emitted for each micro action, whose data is the payload
+	anchorTopic = hash.Hash256b([]byte("MicroAction"))
+)
+
+type (
+	// Protocol defines the protocol of the session envelopes, with which a gateway sends the micro actions signed by
+	// many devices in one action and pays a single fee. Each micro action is anchored on chain as a log of the receipt,
+	// and its nonce is counted per device, so that the gateway cannot replay or reorder the micro actions of a device
+	Protocol struct {
+		keyPrefix []byte
+		addr      address.Address
+		chainID   uint32
+	}
+
+	// device stores the nonce of the last micro action of a device
+	device struct {
+		nonce uint64
+	}
+)
+
+// Serialize serializes device state into bytes
+func (d device) Serialize() ([]byte, error) {
+	return proto.Marshal(&sessionpb.Device{Nonce: d.nonce})
+}
+
+// Deserialize deserializes bytes into device state
+func (d *device) Deserialize(data []byte) error {
+	gen := sessionpb.Device{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	d.nonce = gen.Nonce
+	return nil
+}
+
+// NewProtocol instantiates a session protocol instance of the chain
+func NewProtocol(chainID uint32) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of session protocol", zap.Error(err))
+	}
+	return &Protocol{
+		keyPrefix: h[:],
+		addr:      addr,
+		chainID:   chainID,
+	}
+}
+
+// Nonce returns the nonce of the last micro action of the device, which is 0 if it hasn't sent any
+func (p *Protocol) Nonce(_ context.Context, sm protocol.StateManager, addr address.Address) (uint64, error) {
+	d := device{}
+	if err := p.state(sm, deviceKey(addr), &d); err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return d.nonce, nil
+}
+
+// Anchor anchors the micro actions sent by the caller and returns their logs. The micro actions have to be bound to
+// the chain and the caller, and the nonces of the micro actions of each device have to continue from the last one, in
+// the order in the envelope. Nothing is anchored if any micro action doesn't match
+func (p *Protocol) Anchor(
+	ctx context.Context,
+	sm protocol.StateManager,
+	microActions []*action.MicroAction,
+) ([]*action.Log, error) {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	nonces := make(map[string]uint64)
+	senders := make([]address.Address, 0, len(microActions))
+	for _, m := range microActions {
+		if err := p.checkBinding(m, raCtx.Caller); err != nil {
+			return nil, err
+		}
+		sender, err := m.Sender()
+		if err != nil {
+			return nil, err
+		}
+		nonce, ok := nonces[sender.String()]
+		if !ok {
+			if nonce, err = p.Nonce(ctx, sm, sender); err != nil {
+				return nil, err
+			}
+		}
+		if m.Nonce() != nonce+1 {
+			return nil, errors.Errorf(
+				"invalid micro action nonce %d of device %s, %d expected",
+				m.Nonce(),
+				sender.String(),
+				nonce+1,
+			)
+		}
+		nonces[sender.String()] = m.Nonce()
+		senders = append(senders, sender)
+	}
+	logs := make([]*action.Log, 0, len(microActions))
+	for i, m := range microActions {
+		sender := senders[i]
+		if nonces[sender.String()] == m.Nonce() {
+			if err := p.putState(sm, deviceKey(sender), &device{nonce: m.Nonce()}); err != nil {
+				return nil, err
+			}
+		}
+		var senderTopic hash.Hash256
+		senderTopic.SetBytes(sender.Bytes())
+		logs = append(logs, &action.Log{
+			Address:     p.addr.String(),
+			Topics:      []hash.Hash256{anchorTopic, senderTopic, m.Hash()},
+			Data:        m.Payload(),
+			BlockNumber: raCtx.BlockHeight,
+			TxnHash:     raCtx.ActionHash,
+		})
+	}
+	return logs, nil
+}
+
+// Handle handles the session envelope actions
+func (p *Protocol) Handle(
+	ctx context.Context,
+	act action.Action,
+	sm protocol.StateManager,
+) (*action.Receipt, error) {
+	s, ok := act.(*action.SessionEnvelope)
+	if !ok {
+		return nil, nil
+	}
+	logs, err := p.Anchor(ctx, sm, s.MicroActions())
+	if err != nil {
+		log.L().Debug("Error when anchoring micro actions.", zap.Error(err))
+		return p.settleAction(ctx, sm, 1), nil
+	}
+	return p.settleAction(ctx, sm, 0, logs...), nil
+}
+
+// Validate validates the session envelope actions, where every micro action has to be signed by its device for the
+// chain and the caller, and the same micro action cannot appear twice
+func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
+	vaCtx, ok := protocol.GetValidateActionsCtx(ctx)
+	if !ok {
+		log.S().Panic("Miss validate action context")
+	}
+	s, ok := act.(*action.SessionEnvelope)
+	if !ok {
+		return nil
+	}
+	microActions := s.MicroActions()
+	if len(microActions) == 0 {
+		return errors.New("session envelope has no micro action")
+	}
+	if len(microActions) > MaxMicroActions {
+		return errors.Errorf("session envelope has %d micro actions, more than %d", len(microActions), MaxMicroActions)
+	}
+	seen := make(map[hash.Hash256]bool)
+	for _, m := range microActions {
+		if err := p.checkBinding(m, vaCtx.Caller); err != nil {
+			return err
+		}
+		if err := m.Verify(); err != nil {
+			return err
+		}
+		h := m.Hash()
+		if seen[h] {
+			return errors.Errorf("duplicate micro action %x", h)
+		}
+		seen[h] = true
+	}
+	return nil
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "Nonce":
+		if len(args) != 1 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		addr, err := address.FromString(string(args[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when decoding device address %s", string(args[0]))
+		}
+		nonce, err := p.Nonce(ctx, sm, addr)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.FormatUint(nonce, 10)), nil
+	default:
+		return nil, errors.Errorf("unknown method %s", string(method))
+	}
+}
+
+// checkBinding checks the micro action is bound to the chain and the gateway sending it, so that the micro action
+// signed by a device for one gateway can't be replayed by another gateway or on another chain
+func (p *Protocol) checkBinding(m *action.MicroAction, gateway address.Address) error {
+	if m.ChainID() != p.chainID {
+		return errors.Errorf("micro action of chain %d is sent on chain %d", m.ChainID(), p.chainID)
+	}
+	if gateway == nil || m.Gateway() != gateway.String() {
+		return errors.Errorf("micro action of gateway %s is sent by another gateway", m.Gateway())
+	}
+	return nil
+}
+
+func deviceKey(addr address.Address) []byte {
+	return append(deviceKeyPrefix, addr.Bytes()...)
+}
+
+func (p *Protocol) state(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.State(keyHash, value)
+}
+
+func (p *Protocol) putState(sm protocol.StateManager, key []byte, value interface{}) error {
+	keyHash := hash.Hash160b(append(p.keyPrefix, key...))
+	return sm.PutState(keyHash, value)
+}
+
+func (p *Protocol) settleAction(
+	ctx context.Context,
+	sm protocol.StateManager,
+	status uint64,
+	logs ...*action.Log,
+) *action.Receipt {
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	if err := p.increaseNonce(sm, raCtx.Caller, raCtx.Nonce); err != nil {
+		return p.createReceipt(1, raCtx.ActionHash, raCtx.IntrinsicGas)
+	}
+	return p.createReceipt(status, raCtx.ActionHash, raCtx.IntrinsicGas, logs...)
+}
+
+func (p *Protocol) increaseNonce(sm protocol.StateManager, addr address.Address, nonce uint64) error {
+	acc, err := util.LoadOrCreateAccount(sm, addr.String(), big.NewInt(0))
+	if err != nil {
+		return err
+	}
+	if nonce > acc.Nonce {
+		acc.Nonce = nonce
+	}
+	return util.StoreAccount(sm, addr.String(), acc)
+}
+
+func (p *Protocol) createReceipt(
+	status uint64,
+	actHash hash.Hash256,
+	gasConsumed uint64,
+	logs ...*action.Log,
+) *action.Receipt {
+	return &action.Receipt{
+		Status:          status,
+		ActHash:         actHash,
+		GasConsumed:     gasConsumed,
+		ContractAddress: p.addr.String(),
+		Logs:            logs,
+	}
+}
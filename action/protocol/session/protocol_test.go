@@ -0,0 +1,140 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package session
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestProtocol(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	p := NewProtocol(1)
+	gateway := ta.Addrinfo["producer"]
+	valCtx := protocol.WithValidateActionsCtx(context.Background(), protocol.ValidateActionsCtx{Caller: gateway})
+
+	runCtx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+		BlockHeight:  1,
+		Caller:       gateway,
+		GasPrice:     big.NewInt(0),
+		IntrinsicGas: 10000,
+		Nonce:        1,
+	})
+	micro := func(device string, nonce uint64, payload string) *action.MicroAction {
+		m, err := action.NewMicroAction(1, gateway.String(), nonce, []byte(payload), ta.Keyinfo[device].PriKey)
+		require.NoError(err)
+		return m
+	}
+	envelope := func(microActions ...*action.MicroAction) *action.SessionEnvelope {
+		b := action.SessionEnvelopeBuilder{}
+		s := b.AddMicroActions(microActions...).Build()
+		return &s
+	}
+
+	// The envelope without micro actions, with duplicate ones or with forged ones is invalid
+	require.Error(p.Validate(valCtx, envelope()))
+	m := micro("alfa", 1, "t=21")
+	require.Error(p.Validate(valCtx, envelope(m, m)))
+	forged, err := action.NewMicroAction(1, gateway.String(), 1, []byte("t=21"), ta.Keyinfo["alfa"].PriKey)
+	require.NoError(err)
+	forgedProto := forged.Proto()
+	forgedProto.Payload = []byte("t=99")
+	require.NoError(forged.LoadProto(forgedProto))
+	require.Error(p.Validate(valCtx, envelope(forged)))
+
+	// The micro action bound to another chain or another gateway can't be replayed
+	otherChain, err := action.NewMicroAction(2, gateway.String(), 1, []byte("t=21"), ta.Keyinfo["alfa"].PriKey)
+	require.NoError(err)
+	require.Error(p.Validate(valCtx, envelope(otherChain)))
+	otherCtx := protocol.WithValidateActionsCtx(
+		context.Background(),
+		protocol.ValidateActionsCtx{Caller: ta.Addrinfo["delta"]},
+	)
+	require.Error(p.Validate(otherCtx, envelope(m)))
+	_, err = p.Anchor(protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+		Caller: ta.Addrinfo["delta"],
+	}), nil, []*action.MicroAction{m})
+	require.Error(err)
+
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	s := envelope(m, micro("bravo", 1, "h=40"), micro("alfa", 2, "t=22"))
+	require.NoError(p.Validate(valCtx, s))
+	receipt, err := p.Handle(runCtx, s, ws)
+	require.NoError(err)
+	require.Equal(uint64(0), receipt.Status)
+	// Each micro action is anchored as a log
+	require.Equal(3, len(receipt.Logs))
+	require.Equal([]byte("t=21"), receipt.Logs[0].Data)
+	require.Equal(m.Hash(), receipt.Logs[0].Topics[2])
+	require.Equal([]byte("t=22"), receipt.Logs[2].Data)
+	nonce, err := p.Nonce(context.Background(), ws, ta.Addrinfo["alfa"])
+	require.NoError(err)
+	require.Equal(uint64(2), nonce)
+	nonce, err = p.Nonce(context.Background(), ws, ta.Addrinfo["bravo"])
+	require.NoError(err)
+	require.Equal(uint64(1), nonce)
+
+	// The replayed or skipped micro action fails the whole envelope, and nothing of it is anchored
+	for _, s := range []*action.SessionEnvelope{
+		envelope(micro("charlie", 1, "p=1"), micro("alfa", 2, "t=22")),
+		envelope(micro("charlie", 1, "p=1"), micro("bravo", 3, "h=41")),
+	} {
+		receipt, err = p.Handle(runCtx, s, ws)
+		require.NoError(err)
+		require.Equal(uint64(1), receipt.Status)
+		require.Empty(receipt.Logs)
+	}
+	nonce, err = p.Nonce(context.Background(), ws, ta.Addrinfo["charlie"])
+	require.NoError(err)
+	require.Equal(uint64(0), nonce)
+
+	// The micro actions bound to another chain or sent by another gateway aren't anchored either
+	otherChain, err = action.NewMicroAction(2, gateway.String(), 1, []byte("p=1"), ta.Keyinfo["charlie"].PriKey)
+	require.NoError(err)
+	receipt, err = p.Handle(runCtx, envelope(otherChain), ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	require.Empty(receipt.Logs)
+	otherRunCtx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+		BlockHeight:  1,
+		Caller:       ta.Addrinfo["delta"],
+		GasPrice:     big.NewInt(0),
+		IntrinsicGas: 10000,
+		Nonce:        1,
+	})
+	receipt, err = p.Handle(otherRunCtx, envelope(micro("charlie", 1, "p=1")), ws)
+	require.NoError(err)
+	require.Equal(uint64(1), receipt.Status)
+	require.Empty(receipt.Logs)
+	nonce, err = p.Nonce(context.Background(), ws, ta.Addrinfo["charlie"])
+	require.NoError(err)
+	require.Equal(uint64(0), nonce)
+	require.NoError(sf.Commit(ws))
+
+	ws, err = sf.NewWorkingSet()
+	require.NoError(err)
+	data, err := p.ReadState(context.Background(), ws, []byte("Nonce"), []byte(ta.Addrinfo["alfa"].String()))
+	require.NoError(err)
+	require.Equal("2", string(data))
+}
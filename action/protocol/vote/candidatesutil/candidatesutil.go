@@ -85,6 +85,23 @@ func GetMostRecentCandidateMap(sm protocol.StateManager) (map[hash.Hash160]*stat
 	return nil, errors.Wrap(state.ErrStateNotExist, "failed to get most recent state of candidateList")
 }
 
+// CandidatesByHeight gets the candidates in the candidate pool of a height from trie, like the state factory does
+// for the committed states
+func CandidatesByHeight(sm protocol.StateManager, height uint64) (state.CandidateList, error) {
+	var sc state.CandidateList
+	for h := int(height); h >= 0; h-- {
+		if err := sm.State(ConstructKey(uint64(h)), &sc); err == nil {
+			break
+		} else if errors.Cause(err) != state.ErrStateNotExist {
+			return nil, errors.Wrap(err, "failed to get most recent state of candidateList")
+		}
+	}
+	if len(sc) == 0 {
+		return nil, errors.Wrap(state.ErrStateNotExist, "failed to get most recent state of candidateList")
+	}
+	return sc, nil
+}
+
 // ConstructKey constructs a key for candidates storage
 func ConstructKey(height uint64) hash.Hash160 {
 	heightInBytes := byteutil.Uint64ToBytes(height)
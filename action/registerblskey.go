@@ -0,0 +1,103 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+var (
+	registerBLSKeyBaseGas = uint64(10000)
+	// blsPossessionDomain separates the proofs of possession from the other messages signed with the BLS keys
+	blsPossessionDomain = []byte("BLS_POP")
+)
+
+// RegisterBLSKey registers the BLS public key of the sender, with which its endorsements are aggregated and its VRF
+// proofs are verified from the next epoch on. It comes with the proof of possession of the private key against the
+// rogue key attacks
+type RegisterBLSKey struct {
+	AbstractAction
+
+	publicKey []byte
+	proof     []byte
+}
+
+// PublicKey returns the BLS public key
+func (r *RegisterBLSKey) PublicKey() []byte { return r.publicKey }
+
+// Proof returns the proof of possession of the BLS private key
+func (r *RegisterBLSKey) Proof() []byte { return r.proof }
+
+// ByteStream returns a raw byte stream of a register BLS key action
+func (r *RegisterBLSKey) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(r.Proto()))
+}
+
+// Proto converts a register BLS key action struct to a register BLS key action protobuf
+func (r *RegisterBLSKey) Proto() *iotextypes.RegisterBLSKey {
+	return &iotextypes.RegisterBLSKey{
+		PublicKey: r.publicKey,
+		Proof:     r.proof,
+	}
+}
+
+// LoadProto converts a register BLS key action protobuf to a register BLS key action struct
+func (r *RegisterBLSKey) LoadProto(rProto *iotextypes.RegisterBLSKey) error {
+	*r = RegisterBLSKey{
+		publicKey: rProto.PublicKey,
+		proof:     rProto.Proof,
+	}
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a register BLS key action
+func (r *RegisterBLSKey) IntrinsicGas() (uint64, error) {
+	return registerBLSKeyBaseGas, nil
+}
+
+// Cost returns the total cost of a register BLS key action
+func (r *RegisterBLSKey) Cost() (*big.Int, error) {
+	intrinsicGas, err := r.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting intrinsic gas for the register BLS key action")
+	}
+	return big.NewInt(0).Mul(r.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// BLSPossessionMessage returns the message signed by the BLS private key as the proof of its possession. It binds the
+// public key to the sender, so that a registration couldn't be replayed by others
+func BLSPossessionMessage(sender string, publicKey []byte) []byte {
+	msg := make([]byte, 0, len(blsPossessionDomain)+len(sender)+len(publicKey))
+	msg = append(msg, blsPossessionDomain...)
+	msg = append(msg, sender...)
+	return append(msg, publicKey...)
+}
+
+// RegisterBLSKeyBuilder is the struct to build RegisterBLSKey
+type RegisterBLSKeyBuilder struct {
+	Builder
+	registerBLSKey RegisterBLSKey
+}
+
+// SetPublicKey sets the BLS public key and the proof of possession of its private key
+func (b *RegisterBLSKeyBuilder) SetPublicKey(publicKey []byte, proof []byte) *RegisterBLSKeyBuilder {
+	b.registerBLSKey.publicKey = publicKey
+	b.registerBLSKey.proof = proof
+	return b
+}
+
+// Build builds a new register BLS key action
+func (b *RegisterBLSKeyBuilder) Build() RegisterBLSKey {
+	b.registerBLSKey.AbstractAction = b.Builder.Build()
+	return b.registerBLSKey
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterBLSKey(t *testing.T) {
+	require := require.New(t)
+
+	b := RegisterBLSKeyBuilder{}
+	b.SetGasPrice(big.NewInt(2))
+	r1 := b.SetPublicKey([]byte("public key"), []byte("proof")).Build()
+	r2 := RegisterBLSKey{}
+	require.NoError(r2.LoadProto(r1.Proto()))
+	require.Equal([]byte("public key"), r2.PublicKey())
+	require.Equal([]byte("proof"), r2.Proof())
+
+	gas, err := r1.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(registerBLSKeyBaseGas, gas)
+	cost, err := r1.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(0).SetUint64(2*gas), cost)
+
+	// The action survives the envelope round trip
+	eb := EnvelopeBuilder{}
+	elp := eb.SetNonce(1).SetAction(&r1).Build()
+	elp2 := Envelope{}
+	require.NoError(elp2.LoadProto(elp.Proto()))
+	r3, ok := elp2.Action().(*RegisterBLSKey)
+	require.True(ok)
+	require.Equal(r1.PublicKey(), r3.PublicKey())
+	require.Equal(r1.Proof(), r3.Proof())
+	require.Equal("RegisterBLSKey", TypeName(r3))
+}
+
+func TestBLSPossessionMessage(t *testing.T) {
+	require := require.New(t)
+
+	msg := BLSPossessionMessage("io1sender", []byte("public key"))
+	require.Equal(msg, BLSPossessionMessage("io1sender", []byte("public key")))
+	require.NotEqual(msg, BLSPossessionMessage("io1other", []byte("public key")))
+	require.NotEqual(msg, BLSPossessionMessage("io1sender", []byte("other key")))
+}
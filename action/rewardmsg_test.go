@@ -30,12 +30,14 @@ func TestClaimFromRewardingFund(t *testing.T) {
 	b := ClaimFromRewardingFundBuilder{}
 	s1 := b.SetAmount(big.NewInt(1)).
 		SetData([]byte{2}).
+		SetRecipient("io1recipient").
 		Build()
 	proto := s1.Proto()
 	s2 := ClaimFromRewardingFund{}
 	require.NoError(t, s2.LoadProto(proto))
 	assert.Equal(t, s1.Amount(), s2.Amount())
 	assert.Equal(t, s2.Data(), s2.Data())
+	assert.Equal(t, s1.Recipient(), s2.Recipient())
 }
 
 func TestSetBlockReward(t *testing.T) {
@@ -48,6 +50,14 @@ func TestSetBlockReward(t *testing.T) {
 	require.NoError(t, s2.LoadProto(proto))
 	assert.Equal(t, s1.Amount(), s2.Amount())
 	assert.Equal(t, s2.Data(), s2.Data())
+
+	for _, rewardType := range []int{EpochRewardCap, MinClaimAmount} {
+		s1 = b.SetRewardType(rewardType).Build()
+		proto = s1.Proto()
+		s2 = SetReward{}
+		require.NoError(t, s2.LoadProto(proto))
+		assert.Equal(t, rewardType, s2.RewardType())
+	}
 }
 
 func TestGrantBlockReward(t *testing.T) {
@@ -58,3 +68,22 @@ func TestGrantBlockReward(t *testing.T) {
 	require.NoError(t, s2.LoadProto(proto))
 	assert.Equal(t, s1.RewardType(), s2.RewardType())
 }
+
+func TestSetRewardingAdmin(t *testing.T) {
+	b := SetRewardingAdminBuilder{}
+	s1 := b.SetMultisigAdmins([]string{"a", "b", "c"}, 2).Build()
+	gas, err := s1.IntrinsicGas()
+	require.NoError(t, err)
+	assert.Equal(t, setRewardingAdminBaseGas+3*setRewardingAdminGasPerAdmin, gas)
+
+	// The action survives the envelope round trip
+	eb := EnvelopeBuilder{}
+	elp := eb.SetNonce(1).SetAction(&s1).Build()
+	elp2 := Envelope{}
+	require.NoError(t, elp2.LoadProto(elp.Proto()))
+	s2, ok := elp2.Action().(*SetRewardingAdmin)
+	require.True(t, ok)
+	assert.Equal(t, "", s2.Admin())
+	assert.Equal(t, s1.MultisigAdmins(), s2.MultisigAdmins())
+	assert.Equal(t, uint64(2), s2.MultisigThreshold())
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+var (
+	// SessionEnvelopeBaseIntrinsicGas represents the base intrinsic gas for session envelope
+	SessionEnvelopeBaseIntrinsicGas = uint64(10000)
+	// MicroActionGas represents the intrinsic gas per micro action in a session envelope
+	MicroActionGas = uint64(1000)
+	// MicroActionPayloadGas represents the micro action payload gas per uint
+	MicroActionPayloadGas = uint64(100)
+)
+
+// MicroAction is a payload signed by a device, which is batched into a session envelope by a gateway. Its nonce is
+// counted per device by the session protocol, apart from the account nonce. The device signs the chain ID and the
+// address of the gateway too, so that the micro action can only be sent by the gateway on the chain
+type MicroAction struct {
+	chainID      uint32
+	gateway      string
+	nonce        uint64
+	payload      []byte
+	senderPubKey keypair.PublicKey
+	signature    []byte
+}
+
+// NewMicroAction creates a micro action on the chain, to be sent by the gateway, signed by the device key
+func NewMicroAction(
+	chainID uint32,
+	gateway string,
+	nonce uint64,
+	payload []byte,
+	sk keypair.PrivateKey,
+) (*MicroAction, error) {
+	m := &MicroAction{
+		chainID:      chainID,
+		gateway:      gateway,
+		nonce:        nonce,
+		payload:      payload,
+		senderPubKey: &sk.PublicKey,
+	}
+	h := m.Hash()
+	sig, err := crypto.Sign(h[:], sk)
+	if err != nil {
+		return nil, errors.Wrapf(ErrAction, "failed to sign micro action hash = %x", h)
+	}
+	m.signature = sig
+	return m, nil
+}
+
+// ChainID returns the ID of the chain the micro action is on
+func (m *MicroAction) ChainID() uint32 { return m.chainID }
+
+// Gateway returns the address of the gateway allowed to send the micro action
+func (m *MicroAction) Gateway() string { return m.gateway }
+
+// Nonce returns the nonce of the micro action
+func (m *MicroAction) Nonce() uint64 { return m.nonce }
+
+// Payload returns the payload of the micro action
+func (m *MicroAction) Payload() []byte { return m.payload }
+
+// SenderPublicKey returns the public key of the device signing the micro action
+func (m *MicroAction) SenderPublicKey() keypair.PublicKey { return m.senderPubKey }
+
+// Signature returns the signature of the micro action
+func (m *MicroAction) Signature() []byte { return m.signature }
+
+// Sender returns the address of the device signing the micro action
+func (m *MicroAction) Sender() (address.Address, error) {
+	pkHash := keypair.HashPubKey(m.senderPubKey)
+	return address.FromBytes(pkHash[:])
+}
+
+// Hash returns the hash of the signed fields of the micro action
+func (m *MicroAction) Hash() hash.Hash256 {
+	return hash.Hash256b(byteutil.Must(proto.Marshal(&iotextypes.MicroAction{
+		ChainID:      m.chainID,
+		Gateway:      m.gateway,
+		Nonce:        m.nonce,
+		Payload:      m.payload,
+		SenderPubKey: keypair.PublicKeyToBytes(m.senderPubKey),
+	})))
+}
+
+// Verify verifies the signature of the micro action
+func (m *MicroAction) Verify() error {
+	if len(m.signature) != SignatureLength {
+		return errors.New("incorrect length of signature")
+	}
+	h := m.Hash()
+	if !crypto.VerifySignature(keypair.PublicKeyToBytes(m.senderPubKey), h[:], m.signature[:SignatureLength-1]) {
+		return errors.Wrapf(ErrAction, "failed to verify micro action hash = %x", h)
+	}
+	return nil
+}
+
+// Proto converts a micro action struct to a micro action protobuf
+func (m *MicroAction) Proto() *iotextypes.MicroAction {
+	return &iotextypes.MicroAction{
+		ChainID:      m.chainID,
+		Gateway:      m.gateway,
+		Nonce:        m.nonce,
+		Payload:      m.payload,
+		SenderPubKey: keypair.PublicKeyToBytes(m.senderPubKey),
+		Signature:    m.signature,
+	}
+}
+
+// LoadProto converts a micro action protobuf to a micro action struct
+func (m *MicroAction) LoadProto(mProto *iotextypes.MicroAction) error {
+	pubKey, err := keypair.BytesToPublicKey(mProto.SenderPubKey)
+	if err != nil {
+		return errors.Wrap(err, "error when loading the micro action sender public key")
+	}
+	*m = MicroAction{
+		chainID:      mProto.ChainID,
+		gateway:      mProto.Gateway,
+		nonce:        mProto.Nonce,
+		payload:      mProto.Payload,
+		senderPubKey: pubKey,
+		signature:    mProto.Signature,
+	}
+	return nil
+}
+
+// SessionEnvelope is the action for a gateway to send the micro actions of many devices at once, paying a single fee
+type SessionEnvelope struct {
+	AbstractAction
+
+	microActions []*MicroAction
+}
+
+// MicroActions returns the micro actions in the session envelope
+func (s *SessionEnvelope) MicroActions() []*MicroAction { return s.microActions }
+
+// ByteStream returns a raw byte stream of a session envelope action
+func (s *SessionEnvelope) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(s.Proto()))
+}
+
+// Proto converts a session envelope action struct to a session envelope action protobuf
+func (s *SessionEnvelope) Proto() *iotextypes.SessionEnvelope {
+	sProto := iotextypes.SessionEnvelope{}
+	for _, m := range s.microActions {
+		sProto.MicroActions = append(sProto.MicroActions, m.Proto())
+	}
+	return &sProto
+}
+
+// LoadProto converts a session envelope action protobuf to a session envelope action struct
+func (s *SessionEnvelope) LoadProto(sProto *iotextypes.SessionEnvelope) error {
+	*s = SessionEnvelope{}
+	for _, mProto := range sProto.MicroActions {
+		m := &MicroAction{}
+		if err := m.LoadProto(mProto); err != nil {
+			return err
+		}
+		s.microActions = append(s.microActions, m)
+	}
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a session envelope action, which grows with the number of the micro
+// actions and the size of their payloads
+func (s *SessionEnvelope) IntrinsicGas() (uint64, error) {
+	gas := SessionEnvelopeBaseIntrinsicGas
+	for _, m := range s.microActions {
+		payloadSize := uint64(len(m.payload))
+		if (math.MaxUint64-gas-MicroActionGas)/MicroActionPayloadGas < payloadSize {
+			return 0, ErrOutOfGas
+		}
+		gas += MicroActionGas + payloadSize*MicroActionPayloadGas
+	}
+	return gas, nil
+}
+
+// Cost returns the total cost of a session envelope action
+func (s *SessionEnvelope) Cost() (*big.Int, error) {
+	intrinsicGas, err := s.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting intrinsic gas for the session envelope action")
+	}
+	return big.NewInt(0).Mul(s.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// SessionEnvelopeBuilder is the struct to build SessionEnvelope
+type SessionEnvelopeBuilder struct {
+	Builder
+	sessionEnvelope SessionEnvelope
+}
+
+// AddMicroActions adds the micro actions into the session envelope
+func (b *SessionEnvelopeBuilder) AddMicroActions(microActions ...*MicroAction) *SessionEnvelopeBuilder {
+	b.sessionEnvelope.microActions = append(b.sessionEnvelope.microActions, microActions...)
+	return b
+}
+
+// Build builds a new session envelope action
+func (b *SessionEnvelopeBuilder) Build() SessionEnvelope {
+	b.sessionEnvelope.AbstractAction = b.Builder.Build()
+	return b.sessionEnvelope
+}
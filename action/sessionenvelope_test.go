@@ -0,0 +1,68 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestSessionEnvelope(t *testing.T) {
+	require := require.New(t)
+
+	gateway := testaddress.Addrinfo["producer"].String()
+	m1, err := NewMicroAction(1, gateway, 1, []byte("temperature=21"), testaddress.Keyinfo["alfa"].PriKey)
+	require.NoError(err)
+	require.NoError(m1.Verify())
+	require.Equal(uint32(1), m1.ChainID())
+	require.Equal(gateway, m1.Gateway())
+	sender, err := m1.Sender()
+	require.NoError(err)
+	require.Equal(testaddress.Addrinfo["alfa"].String(), sender.String())
+	m2, err := NewMicroAction(1, gateway, 1, []byte("humidity=40"), testaddress.Keyinfo["bravo"].PriKey)
+	require.NoError(err)
+
+	b := SessionEnvelopeBuilder{}
+	b.SetGasPrice(big.NewInt(2))
+	s1 := b.AddMicroActions(m1, m2).Build()
+	gas, err := s1.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(SessionEnvelopeBaseIntrinsicGas+2*MicroActionGas+25*MicroActionPayloadGas, gas)
+	cost, err := s1.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(0).SetUint64(2*gas), cost)
+
+	// The action survives the envelope round trip, and so do the signatures of the micro actions
+	eb := EnvelopeBuilder{}
+	elp := eb.SetNonce(1).SetAction(&s1).Build()
+	elp2 := Envelope{}
+	require.NoError(elp2.LoadProto(elp.Proto()))
+	s2, ok := elp2.Action().(*SessionEnvelope)
+	require.True(ok)
+	require.Equal(s1.Proto(), s2.Proto())
+	for _, m := range s2.MicroActions() {
+		require.NoError(m.Verify())
+	}
+
+	// The micro action altered by the gateway fails the verification
+	altered := *m1
+	altered.nonce = 2
+	require.Error(altered.Verify())
+	altered = *m1
+	altered.payload = []byte("temperature=99")
+	require.Error(altered.Verify())
+	altered = *m1
+	altered.chainID = 2
+	require.Error(altered.Verify())
+	altered = *m1
+	altered.gateway = testaddress.Addrinfo["alfa"].String()
+	require.Error(altered.Verify())
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+var setConsensusParamsBaseGas = uint64(10000)
+
+// SetConsensusParams is the governance admin action to set the roll-DPoS timing parameters, which take effect from
+// the next epoch
+type SetConsensusParams struct {
+	AbstractAction
+
+	blockInterval                time.Duration
+	acceptBlockTTL               time.Duration
+	acceptProposalEndorsementTTL time.Duration
+	acceptLockEndorsementTTL     time.Duration
+}
+
+// BlockInterval returns the interval of block production
+func (s *SetConsensusParams) BlockInterval() time.Duration { return s.blockInterval }
+
+// AcceptBlockTTL returns the time to wait for the block proposal in a round
+func (s *SetConsensusParams) AcceptBlockTTL() time.Duration { return s.acceptBlockTTL }
+
+// AcceptProposalEndorsementTTL returns the time to wait for the proposal endorsements in a round
+func (s *SetConsensusParams) AcceptProposalEndorsementTTL() time.Duration {
+	return s.acceptProposalEndorsementTTL
+}
+
+// AcceptLockEndorsementTTL returns the time to wait for the lock endorsements in a round
+func (s *SetConsensusParams) AcceptLockEndorsementTTL() time.Duration {
+	return s.acceptLockEndorsementTTL
+}
+
+// ByteStream returns a raw byte stream of a set consensus params action
+func (s *SetConsensusParams) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(s.Proto()))
+}
+
+// Proto converts a set consensus params action struct to a set consensus params action protobuf
+func (s *SetConsensusParams) Proto() *iotextypes.SetConsensusParams {
+	return &iotextypes.SetConsensusParams{
+		BlockInterval:                durationToMillis(s.blockInterval),
+		AcceptBlockTTL:               durationToMillis(s.acceptBlockTTL),
+		AcceptProposalEndorsementTTL: durationToMillis(s.acceptProposalEndorsementTTL),
+		AcceptLockEndorsementTTL:     durationToMillis(s.acceptLockEndorsementTTL),
+	}
+}
+
+// LoadProto converts a set consensus params action protobuf to a set consensus params action struct
+func (s *SetConsensusParams) LoadProto(sProto *iotextypes.SetConsensusParams) error {
+	*s = SetConsensusParams{}
+	for _, d := range []struct {
+		ms  uint64
+		dst *time.Duration
+	}{
+		{sProto.BlockInterval, &s.blockInterval},
+		{sProto.AcceptBlockTTL, &s.acceptBlockTTL},
+		{sProto.AcceptProposalEndorsementTTL, &s.acceptProposalEndorsementTTL},
+		{sProto.AcceptLockEndorsementTTL, &s.acceptLockEndorsementTTL},
+	} {
+		duration, err := millisToDuration(d.ms)
+		if err != nil {
+			return err
+		}
+		*d.dst = duration
+	}
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a set consensus params action
+func (s *SetConsensusParams) IntrinsicGas() (uint64, error) {
+	return setConsensusParamsBaseGas, nil
+}
+
+// Cost returns the total cost of a set consensus params action
+func (s *SetConsensusParams) Cost() (*big.Int, error) {
+	intrinsicGas, err := s.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting intrinsic gas for the set consensus params action")
+	}
+	return big.NewInt(0).Mul(s.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// SetConsensusParamsBuilder is the struct to build SetConsensusParams
+type SetConsensusParamsBuilder struct {
+	Builder
+	setConsensusParams SetConsensusParams
+}
+
+// SetBlockInterval sets the interval of block production
+func (b *SetConsensusParamsBuilder) SetBlockInterval(interval time.Duration) *SetConsensusParamsBuilder {
+	b.setConsensusParams.blockInterval = interval
+	return b
+}
+
+// SetAcceptTTLs sets the time to wait for the block proposal, the proposal endorsements and the lock endorsements in
+// a round
+func (b *SetConsensusParamsBuilder) SetAcceptTTLs(
+	blockTTL time.Duration,
+	proposalEndorsementTTL time.Duration,
+	lockEndorsementTTL time.Duration,
+) *SetConsensusParamsBuilder {
+	b.setConsensusParams.acceptBlockTTL = blockTTL
+	b.setConsensusParams.acceptProposalEndorsementTTL = proposalEndorsementTTL
+	b.setConsensusParams.acceptLockEndorsementTTL = lockEndorsementTTL
+	return b
+}
+
+// Build builds a new set consensus params action
+func (b *SetConsensusParamsBuilder) Build() SetConsensusParams {
+	b.setConsensusParams.AbstractAction = b.Builder.Build()
+	return b.setConsensusParams
+}
+
+func durationToMillis(d time.Duration) uint64 {
+	if d <= 0 {
+		return 0
+	}
+	return uint64(d / time.Millisecond)
+}
+
+func millisToDuration(ms uint64) (time.Duration, error) {
+	if ms > uint64(math.MaxInt64/int64(time.Millisecond)) {
+		return 0, errors.Errorf("duration %d ms overflows", ms)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetConsensusParams(t *testing.T) {
+	require := require.New(t)
+
+	b := SetConsensusParamsBuilder{}
+	b.SetGasPrice(big.NewInt(2))
+	s1 := b.SetBlockInterval(5*time.Second).
+		SetAcceptTTLs(2*time.Second, 1500*time.Millisecond, time.Second).
+		Build()
+	s2 := SetConsensusParams{}
+	require.NoError(s2.LoadProto(s1.Proto()))
+	require.Equal(5*time.Second, s2.BlockInterval())
+	require.Equal(2*time.Second, s2.AcceptBlockTTL())
+	require.Equal(1500*time.Millisecond, s2.AcceptProposalEndorsementTTL())
+	require.Equal(time.Second, s2.AcceptLockEndorsementTTL())
+	// The durations overflowing are rejected
+	sPb := s1.Proto()
+	sPb.BlockInterval = math.MaxUint64 / 1000
+	require.Error(s2.LoadProto(sPb))
+
+	cost, err := s1.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(0).SetUint64(2*setConsensusParamsBaseGas), cost)
+
+	// The action survives the envelope round trip
+	eb := EnvelopeBuilder{}
+	elp := eb.SetNonce(1).SetAction(&s1).Build()
+	elp2 := Envelope{}
+	require.NoError(elp2.LoadProto(elp.Proto()))
+	s3, ok := elp2.Action().(*SetConsensusParams)
+	require.True(ok)
+	require.Equal(s1.Proto(), s3.Proto())
+}
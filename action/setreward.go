@@ -56,6 +56,10 @@ func (s *SetReward) Proto() *iotextypes.SetReward {
 		sProto.Type = iotextypes.RewardType_BlockReward
 	case EpochReward:
 		sProto.Type = iotextypes.RewardType_EpochReward
+	case EpochRewardCap:
+		sProto.Type = iotextypes.RewardType_EpochRewardCap
+	case MinClaimAmount:
+		sProto.Type = iotextypes.RewardType_MinClaimAmount
 	}
 	return &sProto
 }
@@ -70,6 +74,10 @@ func (s *SetReward) LoadProto(sProto *iotextypes.SetReward) error {
 		s.t = BlockReward
 	case iotextypes.RewardType_EpochReward:
 		s.t = EpochReward
+	case iotextypes.RewardType_EpochRewardCap:
+		s.t = EpochRewardCap
+	case iotextypes.RewardType_MinClaimAmount:
+		s.t = MinClaimAmount
 	}
 	return nil
 }
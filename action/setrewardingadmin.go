@@ -0,0 +1,108 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+var (
+	setRewardingAdminBaseGas     = uint64(10000)
+	setRewardingAdminGasPerAdmin = uint64(1000)
+)
+
+// SetRewardingAdmin is the action to replace the admin of the rewarding protocol. If the admin is set, it hands the
+// control to the single admin, otherwise to the multisig admins, which need the number of approvals to make a change
+type SetRewardingAdmin struct {
+	AbstractAction
+
+	admin             string
+	multisigAdmins    []string
+	multisigThreshold uint64
+}
+
+// Admin returns the address of the single admin
+func (s *SetRewardingAdmin) Admin() string { return s.admin }
+
+// MultisigAdmins returns the addresses of the multisig admins
+func (s *SetRewardingAdmin) MultisigAdmins() []string { return s.multisigAdmins }
+
+// MultisigThreshold returns the number of approvals from the multisig admins to make a change
+func (s *SetRewardingAdmin) MultisigThreshold() uint64 { return s.multisigThreshold }
+
+// ByteStream returns a raw byte stream of a set rewarding admin action
+func (s *SetRewardingAdmin) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(s.Proto()))
+}
+
+// Proto converts a set rewarding admin action struct to a set rewarding admin action protobuf
+func (s *SetRewardingAdmin) Proto() *iotextypes.SetRewardingAdmin {
+	return &iotextypes.SetRewardingAdmin{
+		Admin:             s.admin,
+		MultisigAdmins:    s.multisigAdmins,
+		MultisigThreshold: s.multisigThreshold,
+	}
+}
+
+// LoadProto converts a set rewarding admin action protobuf to a set rewarding admin action struct
+func (s *SetRewardingAdmin) LoadProto(sProto *iotextypes.SetRewardingAdmin) error {
+	*s = SetRewardingAdmin{}
+	s.admin = sProto.Admin
+	s.multisigAdmins = sProto.MultisigAdmins
+	s.multisigThreshold = sProto.MultisigThreshold
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a set rewarding admin action
+func (s *SetRewardingAdmin) IntrinsicGas() (uint64, error) {
+	numAdmins := uint64(len(s.multisigAdmins))
+	if (math.MaxUint64-setRewardingAdminBaseGas)/setRewardingAdminGasPerAdmin < numAdmins {
+		return 0, ErrOutOfGas
+	}
+	return setRewardingAdminBaseGas + setRewardingAdminGasPerAdmin*numAdmins, nil
+}
+
+// Cost returns the total cost of a set rewarding admin action
+func (s *SetRewardingAdmin) Cost() (*big.Int, error) {
+	intrinsicGas, err := s.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting intrinsic gas for the set rewarding admin action")
+	}
+	return big.NewInt(0).Mul(s.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// SetRewardingAdminBuilder is the struct to build SetRewardingAdmin
+type SetRewardingAdminBuilder struct {
+	Builder
+	setRewardingAdmin SetRewardingAdmin
+}
+
+// SetAdmin sets the address of the single admin
+func (b *SetRewardingAdminBuilder) SetAdmin(admin string) *SetRewardingAdminBuilder {
+	b.setRewardingAdmin.admin = admin
+	return b
+}
+
+// SetMultisigAdmins sets the addresses of the multisig admins and the number of approvals to make a change
+func (b *SetRewardingAdminBuilder) SetMultisigAdmins(admins []string, threshold uint64) *SetRewardingAdminBuilder {
+	b.setRewardingAdmin.multisigAdmins = admins
+	b.setRewardingAdmin.multisigThreshold = threshold
+	return b
+}
+
+// Build builds a new set rewarding admin action
+func (b *SetRewardingAdminBuilder) Build() SetRewardingAdmin {
+	b.setRewardingAdmin.AbstractAction = b.Builder.Build()
+	return b.setRewardingAdmin
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// SettleWithdrawIntrinsicGas represents the intrinsic gas for the withdraw settlement action
+	SettleWithdrawIntrinsicGas = uint64(10000)
+)
+
+// SettleWithdraw represents the action to unlock the token withdrawn from sub-chain on main-chain. It carries the
+// withdraw action on sub-chain, and the merkle proof of its inclusion in the sub-chain block put on main-chain
+type SettleWithdraw struct {
+	AbstractAction
+
+	subChainAddress string
+	height          uint64
+	withdraw        SealedEnvelope
+	index           uint32
+	proof           []hash.Hash256
+}
+
+// NewSettleWithdraw instantiates a withdraw settlement on main-chain action struct
+func NewSettleWithdraw(
+	nonce uint64,
+	subChainAddress string,
+	height uint64,
+	withdraw SealedEnvelope,
+	index uint32,
+	proof []hash.Hash256,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) *SettleWithdraw {
+	return &SettleWithdraw{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		subChainAddress: subChainAddress,
+		height:          height,
+		withdraw:        withdraw,
+		index:           index,
+		proof:           proof,
+	}
+}
+
+// SubChainAddress returns the address of the sub-chain where the withdraw action is
+func (sw *SettleWithdraw) SubChainAddress() string { return sw.subChainAddress }
+
+// Height returns the height of the sub-chain block including the withdraw action
+func (sw *SettleWithdraw) Height() uint64 { return sw.height }
+
+// Withdraw returns the withdraw action on sub-chain
+func (sw *SettleWithdraw) Withdraw() SealedEnvelope { return sw.withdraw }
+
+// Index returns the index of the withdraw action in the sub-chain block
+func (sw *SettleWithdraw) Index() uint32 { return sw.index }
+
+// Proof returns the merkle proof of the withdraw action's inclusion in the tx root of the sub-chain block
+func (sw *SettleWithdraw) Proof() []hash.Hash256 { return sw.proof }
+
+// ByteStream returns a raw byte stream of the settle withdraw action
+func (sw *SettleWithdraw) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(sw.Proto()))
+}
+
+// Proto converts SettleWithdraw to protobuf's Action
+func (sw *SettleWithdraw) Proto() *iotextypes.SettleWithdraw {
+	act := &iotextypes.SettleWithdraw{
+		SubChainAddress: sw.subChainAddress,
+		Height:          sw.height,
+		Withdraw:        sw.withdraw.Proto(),
+		Index:           sw.index,
+		Proof:           make([][]byte, 0, len(sw.proof)),
+	}
+	for i := range sw.proof {
+		act.Proof = append(act.Proof, sw.proof[i][:])
+	}
+	return act
+}
+
+// LoadProto converts a protobuf's Action to SettleWithdraw
+func (sw *SettleWithdraw) LoadProto(pbSettle *iotextypes.SettleWithdraw) error {
+	if pbSettle == nil {
+		return errors.New("empty action proto to load")
+	}
+	if sw == nil {
+		return errors.New("nil action to load proto")
+	}
+	*sw = SettleWithdraw{}
+
+	sw.subChainAddress = pbSettle.GetSubChainAddress()
+	sw.height = pbSettle.GetHeight()
+	if err := sw.withdraw.LoadProto(pbSettle.GetWithdraw()); err != nil {
+		return errors.Wrap(err, "failed to load withdraw action")
+	}
+	sw.index = pbSettle.GetIndex()
+	for _, h := range pbSettle.GetProof() {
+		sw.proof = append(sw.proof, byteutil.BytesTo32B(h))
+	}
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a settle withdraw
+func (sw *SettleWithdraw) IntrinsicGas() (uint64, error) { return SettleWithdrawIntrinsicGas, nil }
+
+// Cost returns the total cost of a settle withdraw
+func (sw *SettleWithdraw) Cost() (*big.Int, error) {
+	intrinsicGas, err := sw.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the settle withdraw")
+	}
+	return big.NewInt(0).Mul(sw.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestSettleWithdraw(t *testing.T) {
+	require := require.New(t)
+
+	subChainAddr := testaddress.Addrinfo["producer"].String()
+	bd := &EnvelopeBuilder{}
+	elp := bd.SetNonce(1).
+		SetGasLimit(10).
+		SetAction(NewCreateWithdraw(1, big.NewInt(1000), testaddress.Addrinfo["alfa"].String(), 10, big.NewInt(0))).
+		Build()
+	withdraw, err := Sign(elp, testaddress.Keyinfo["bravo"].PriKey)
+	require.NoError(err)
+	proof := []hash.Hash256{hash.Hash256b([]byte("1")), hash.Hash256b([]byte("2"))}
+
+	settle1 := NewSettleWithdraw(2, subChainAddr, 100, withdraw, 3, proof, 10, big.NewInt(100))
+	require.Equal(subChainAddr, settle1.SubChainAddress())
+	require.Equal(uint64(100), settle1.Height())
+	require.Equal(uint32(3), settle1.Index())
+	require.Equal(proof, settle1.Proof())
+	cost, err := settle1.Cost()
+	require.NoError(err)
+	require.Equal(big.NewInt(100*int64(SettleWithdrawIntrinsicGas)), cost)
+
+	var settle2 SettleWithdraw
+	require.NoError(settle2.LoadProto(settle1.Proto()))
+	require.Equal(subChainAddr, settle2.SubChainAddress())
+	require.Equal(uint64(100), settle2.Height())
+	require.Equal(uint32(3), settle2.Index())
+	require.Equal(proof, settle2.Proof())
+	withdraw2 := settle2.Withdraw()
+	require.Equal(withdraw.Hash(), withdraw2.Hash())
+	_, ok := withdraw2.Action().(*CreateWithdraw)
+	require.True(ok)
+	require.Error(settle2.LoadProto(nil))
+}
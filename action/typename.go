@@ -0,0 +1,99 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import "strings"
+
+// typeNames are the names of the action types, which are used to designate the actions by type in the configs, the
+// metrics and the queries
+var typeNames = []string{
+	"Transfer",
+	"Vote",
+	"Execution",
+	"PutBlock",
+	"StartSubChain",
+	"StopSubChain",
+	"CreateDeposit",
+	"SettleDeposit",
+	"CreateWithdraw",
+	"SettleWithdraw",
+	"GrantReward",
+	"SetReward",
+	"SetRewardingAdmin",
+	"ClaimFromRewardingFund",
+	"DepositToRewardingFund",
+	"UpdateAllowlist",
+	"SetConsensusParams",
+	"DoubleSignEvidence",
+	"SessionEnvelope",
+	"PauseActions",
+	"DKGMessage",
+	"RegisterBLSKey",
+}
+
+// TypeName returns the name of the type of the action, such as Transfer, or an empty string if the type is unknown
+func TypeName(act Action) string {
+	switch act.(type) {
+	case *Transfer:
+		return "Transfer"
+	case *Vote:
+		return "Vote"
+	case *Execution:
+		return "Execution"
+	case *PutBlock:
+		return "PutBlock"
+	case *StartSubChain:
+		return "StartSubChain"
+	case *StopSubChain:
+		return "StopSubChain"
+	case *CreateDeposit:
+		return "CreateDeposit"
+	case *SettleDeposit:
+		return "SettleDeposit"
+	case *CreateWithdraw:
+		return "CreateWithdraw"
+	case *SettleWithdraw:
+		return "SettleWithdraw"
+	case *GrantReward:
+		return "GrantReward"
+	case *SetReward:
+		return "SetReward"
+	case *SetRewardingAdmin:
+		return "SetRewardingAdmin"
+	case *ClaimFromRewardingFund:
+		return "ClaimFromRewardingFund"
+	case *DepositToRewardingFund:
+		return "DepositToRewardingFund"
+	case *UpdateAllowlist:
+		return "UpdateAllowlist"
+	case *SetConsensusParams:
+		return "SetConsensusParams"
+	case *DoubleSignEvidence:
+		return "DoubleSignEvidence"
+	case *SessionEnvelope:
+		return "SessionEnvelope"
+	case *PauseActions:
+		return "PauseActions"
+	case *DKGMessage:
+		return "DKGMessage"
+	case *RegisterBLSKey:
+		return "RegisterBLSKey"
+	default:
+		return ""
+	}
+}
+
+// CanonicalTypeName returns the name of the action type matching the name case-insensitively, and false if there
+// isn't such an action type
+func CanonicalTypeName(name string) (string, bool) {
+	for _, n := range typeNames {
+		if strings.EqualFold(n, name) {
+			return n, true
+		}
+	}
+	return "", false
+}
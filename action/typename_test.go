@@ -0,0 +1,48 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeName(t *testing.T) {
+	require := require.New(t)
+
+	acts := []Action{
+		&Transfer{}, &Vote{}, &Execution{}, &PutBlock{}, &StartSubChain{}, &StopSubChain{}, &CreateDeposit{},
+		&SettleDeposit{}, &CreateWithdraw{}, &SettleWithdraw{}, &GrantReward{}, &SetReward{}, &SetRewardingAdmin{},
+		&ClaimFromRewardingFund{}, &DepositToRewardingFund{}, &UpdateAllowlist{}, &SetConsensusParams{},
+		&DoubleSignEvidence{}, &SessionEnvelope{}, &PauseActions{}, &DKGMessage{}, &RegisterBLSKey{},
+	}
+	// Every action type is named, in the order of the names
+	require.Equal(len(typeNames), len(acts))
+	for i, act := range acts {
+		require.Equal(typeNames[i], TypeName(act))
+	}
+	require.Equal("", TypeName(nil))
+
+	tests := []struct {
+		name      string
+		canonical string
+		ok        bool
+	}{
+		{"Execution", "Execution", true},
+		{"execution", "Execution", true},
+		{"DKGMESSAGE", "DKGMessage", true},
+		{"Execute", "", false},
+		{" Execution", "", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		name, ok := CanonicalTypeName(test.name)
+		require.Equal(test.ok, ok)
+		require.Equal(test.canonical, name)
+	}
+}
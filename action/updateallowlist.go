@@ -0,0 +1,100 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+var (
+	updateAllowlistBaseGas       = uint64(10000)
+	updateAllowlistGasPerAddress = uint64(1000)
+)
+
+// UpdateAllowlist is the admin action to add the addresses to or remove them from the allowlist
+type UpdateAllowlist struct {
+	AbstractAction
+
+	addresses []string
+	remove    bool
+}
+
+// Addresses returns the addresses to update
+func (u *UpdateAllowlist) Addresses() []string { return u.addresses }
+
+// Remove returns true if the addresses are removed from the allowlist
+func (u *UpdateAllowlist) Remove() bool { return u.remove }
+
+// ByteStream returns a raw byte stream of an update allowlist action
+func (u *UpdateAllowlist) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(u.Proto()))
+}
+
+// Proto converts an update allowlist action struct to an update allowlist action protobuf
+func (u *UpdateAllowlist) Proto() *iotextypes.UpdateAllowlist {
+	return &iotextypes.UpdateAllowlist{
+		Addresses: u.addresses,
+		Remove:    u.remove,
+	}
+}
+
+// LoadProto converts an update allowlist action protobuf to an update allowlist action struct
+func (u *UpdateAllowlist) LoadProto(uProto *iotextypes.UpdateAllowlist) error {
+	*u = UpdateAllowlist{}
+	u.addresses = uProto.Addresses
+	u.remove = uProto.Remove
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of an update allowlist action
+func (u *UpdateAllowlist) IntrinsicGas() (uint64, error) {
+	numAddrs := uint64(len(u.addresses))
+	if (math.MaxUint64-updateAllowlistBaseGas)/updateAllowlistGasPerAddress < numAddrs {
+		return 0, ErrOutOfGas
+	}
+	return updateAllowlistBaseGas + updateAllowlistGasPerAddress*numAddrs, nil
+}
+
+// Cost returns the total cost of an update allowlist action
+func (u *UpdateAllowlist) Cost() (*big.Int, error) {
+	intrinsicGas, err := u.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "error when getting intrinsic gas for the update allowlist action")
+	}
+	return big.NewInt(0).Mul(u.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// UpdateAllowlistBuilder is the struct to build UpdateAllowlist
+type UpdateAllowlistBuilder struct {
+	Builder
+	updateAllowlist UpdateAllowlist
+}
+
+// SetAddresses sets the addresses to update
+func (b *UpdateAllowlistBuilder) SetAddresses(addrs []string) *UpdateAllowlistBuilder {
+	b.updateAllowlist.addresses = addrs
+	return b
+}
+
+// SetRemove sets whether the addresses are removed from the allowlist
+func (b *UpdateAllowlistBuilder) SetRemove(remove bool) *UpdateAllowlistBuilder {
+	b.updateAllowlist.remove = remove
+	return b
+}
+
+// Build builds a new update allowlist action
+func (b *UpdateAllowlistBuilder) Build() UpdateAllowlist {
+	b.updateAllowlist.AbstractAction = b.Builder.Build()
+	return b.updateAllowlist
+}
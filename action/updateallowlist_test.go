@@ -0,0 +1,32 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateAllowlist(t *testing.T) {
+	require := require.New(t)
+
+	b := UpdateAllowlistBuilder{}
+	u := b.SetAddresses([]string{"io1a", "io1b"}).SetRemove(true).Build()
+	gas, err := u.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(updateAllowlistBaseGas+2*updateAllowlistGasPerAddress, gas)
+
+	eb := EnvelopeBuilder{}
+	elp := eb.SetNonce(1).SetAction(&u).Build()
+	elp2 := Envelope{}
+	require.NoError(elp2.LoadProto(elp.Proto()))
+	u2, ok := elp2.Action().(*UpdateAllowlist)
+	require.True(ok)
+	require.Equal(u.Addresses(), u2.Addresses())
+	require.True(u2.Remove())
+}
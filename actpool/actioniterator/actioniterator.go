@@ -7,26 +7,39 @@
 package actioniterator
 
 import (
+	"bytes"
 	"container/heap"
 
 	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 
 	"github.com/iotexproject/iotex-core/action"
 )
 
+// head is the next action of an account, along with the key ordering it among the actions of the same gas price
+type head struct {
+	selp action.SealedEnvelope
+	key  hash.Hash256
+}
+
 // ActionByPrice implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
 // It's essentially a big root heap of actions
-type actionByPrice []action.SealedEnvelope
+type actionByPrice []head
 
-func (s actionByPrice) Len() int           { return len(s) }
-func (s actionByPrice) Less(i, j int) bool { return s[i].GasPrice().Cmp(s[j].GasPrice()) > 0 }
-func (s actionByPrice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s actionByPrice) Len() int { return len(s) }
+func (s actionByPrice) Less(i, j int) bool {
+	if cmp := s[i].selp.GasPrice().Cmp(s[j].selp.GasPrice()); cmp != 0 {
+		return cmp > 0
+	}
+	return bytes.Compare(s[i].key[:], s[j].key[:]) < 0
+}
+func (s actionByPrice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 // Push define the push function of heap
 func (s *actionByPrice) Push(x interface{}) {
-	*s = append(*s, x.(action.SealedEnvelope))
+	*s = append(*s, x.(head))
 }
 
 // Pop define the pop function of heap
@@ -47,38 +60,59 @@ type ActionIterator interface {
 type actionIterator struct {
 	accountActs map[string][]action.SealedEnvelope
 	heads       actionByPrice
+	seed        *hash.Hash256
 }
 
 // NewActionIterator return a new action iterator
 func NewActionIterator(accountActs map[string][]action.SealedEnvelope) ActionIterator {
-	heads := make(actionByPrice, 0, len(accountActs))
+	return newActionIterator(accountActs, nil)
+}
+
+// NewHashOrderedActionIterator returns a new action iterator, which orders the actions of the same gas price by the
+// hash of the action hash and the seed, rather than by their arrival. Given the previous block hash as the seed, the
+// order is determined before the producer sees the actions
+func NewHashOrderedActionIterator(accountActs map[string][]action.SealedEnvelope, seed hash.Hash256) ActionIterator {
+	return newActionIterator(accountActs, &seed)
+}
+
+func newActionIterator(accountActs map[string][]action.SealedEnvelope, seed *hash.Hash256) ActionIterator {
+	ai := &actionIterator{
+		accountActs: accountActs,
+		heads:       make(actionByPrice, 0, len(accountActs)),
+		seed:        seed,
+	}
 	for sender, accActs := range accountActs {
 		if len(accActs) == 0 {
 			continue
 		}
 
-		heads = append(heads, accActs[0])
+		ai.heads = append(ai.heads, ai.newHead(accActs[0]))
 		if len(accActs) > 1 {
 			accountActs[sender] = accActs[1:]
 		} else {
 			accountActs[sender] = []action.SealedEnvelope{}
 		}
 	}
-	heap.Init(&heads)
-	return &actionIterator{
-		accountActs: accountActs,
-		heads:       heads,
+	heap.Init(&ai.heads)
+	return ai
+}
+
+func (ai *actionIterator) newHead(selp action.SealedEnvelope) head {
+	if ai.seed == nil {
+		return head{selp: selp}
 	}
+	h := selp.Hash()
+	return head{selp: selp, key: hash.Hash256b(append(h[:], ai.seed[:]...))}
 }
 
 // LoadNext load next action of account of top action
 func (ai *actionIterator) loadNextActionForTopAccount() {
-	sender := ai.heads[0].SrcPubkey()
+	sender := ai.heads[0].selp.SrcPubkey()
 	callerPKHash := keypair.HashPubKey(sender)
 	callerAddr, _ := address.FromBytes(callerPKHash[:])
 	callerAddrStr := callerAddr.String()
 	if actions, ok := ai.accountActs[callerAddrStr]; ok && len(actions) > 0 {
-		ai.heads[0], ai.accountActs[callerAddrStr] = actions[0], actions[1:]
+		ai.heads[0], ai.accountActs[callerAddrStr] = ai.newHead(actions[0]), actions[1:]
 		heap.Fix(&ai.heads, 0)
 	} else {
 		heap.Pop(&ai.heads)
@@ -91,7 +125,7 @@ func (ai *actionIterator) Next() (action.SealedEnvelope, bool) {
 		return action.SealedEnvelope{}, false
 	}
 
-	headAction := ai.heads[0]
+	headAction := ai.heads[0].selp
 	ai.loadNextActionForTopAccount()
 	return headAction, true
 }
@@ -7,12 +7,14 @@
 package actioniterator
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 )
 
@@ -103,3 +105,56 @@ func TestActionIterator(t *testing.T) {
 	}
 	require.Equal(appliedActionList, []action.SealedEnvelope{selp3, selp1, selp2, selp4, selp5, selp6})
 }
+
+func TestHashOrderedActionIterator(t *testing.T) {
+	require := require.New(t)
+
+	accMap := make(map[string][]action.SealedEnvelope)
+	var selps []action.SealedEnvelope
+	for i, name := range []string{"alfa", "bravo", "charlie", "delta"} {
+		gasPrice := big.NewInt(10)
+		if i == 0 {
+			gasPrice = big.NewInt(5)
+		}
+		tsf, err := action.NewTransfer(uint64(1), big.NewInt(100), "1", nil, uint64(0), gasPrice)
+		require.NoError(err)
+		bd := &action.EnvelopeBuilder{}
+		elp := bd.SetNonce(1).
+			SetGasPrice(gasPrice).
+			SetAction(tsf).
+			SetDestinationAddress(testaddress.Addrinfo["echo"].String()).Build()
+		selp, err := action.Sign(elp, testaddress.Keyinfo[name].PriKey)
+		require.NoError(err)
+		accMap[testaddress.Addrinfo[name].String()] = []action.SealedEnvelope{selp}
+		selps = append(selps, selp)
+	}
+	order := func(seed hash.Hash256) []action.SealedEnvelope {
+		accActs := make(map[string][]action.SealedEnvelope, len(accMap))
+		for addr, acts := range accMap {
+			accActs[addr] = acts
+		}
+		ai := NewHashOrderedActionIterator(accActs, seed)
+		appliedActionList := make([]action.SealedEnvelope, 0)
+		for {
+			bestAction, ok := ai.Next()
+			if !ok {
+				break
+			}
+			appliedActionList = append(appliedActionList, bestAction)
+		}
+		return appliedActionList
+	}
+
+	seed := hash.Hash256b([]byte("seed"))
+	ordered := order(seed)
+	require.Equal(order(seed), ordered)
+	require.Len(ordered, 4)
+	// The action of the lower gas price is still the last, and the others are ordered by the hash with the seed
+	require.Equal(selps[0], ordered[3])
+	for i := 1; i < 3; i++ {
+		prev, next := ordered[i-1].Hash(), ordered[i].Hash()
+		prevKey := hash.Hash256b(append(prev[:], seed[:]...))
+		nextKey := hash.Hash256b(append(next[:], seed[:]...))
+		require.True(bytes.Compare(prevKey[:], nextKey[:]) < 0)
+	}
+}
@@ -8,36 +8,57 @@ package actpool
 
 import (
 	"context"
+	"math/big"
+	"runtime"
 	"sync"
+	"time"
 
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/routine"
 )
 
 // ActPool is the interface of actpool
 type ActPool interface {
+	lifecycle.StartStopper
+
 	// Reset resets actpool state
 	Reset()
-	// PickActs returns all currently accepted actions in actpool
-	PickActs() []action.SealedEnvelope
+	// PickActs returns the executable actions in actpool packed within the budget
+	PickActs(budget PickBudget) []action.SealedEnvelope
 	// PendingActionMap returns an action map with all accepted actions
 	PendingActionMap() map[string][]action.SealedEnvelope
 	// Add adds an action into the pool after passing validation
 	Add(act action.SealedEnvelope) error
+	// AddLocal adds an action received via the local API into the pool after passing validation. If it's sent by a
+	// local sender of the node operator, it isn't evicted for the actions received from the network
+	AddLocal(act action.SealedEnvelope) error
+	// AddBatch adds the actions into the pool, and returns the error of each action, which is nil if it's accepted
+	AddBatch(acts []action.SealedEnvelope) []error
 	// GetPendingNonce returns pending nonce in pool given an account address
 	GetPendingNonce(addr string) (uint64, error)
+	// PendingState returns the state of an account as if all its pending actions in pool were applied
+	PendingState(addr string) (AccountState, error)
 	// GetUnconfirmedActs returns unconfirmed actions in pool given an account address
 	GetUnconfirmedActs(addr string) []action.SealedEnvelope
+	// GetQueuedActs returns the actions in pool given an account address, which wait for the preceding nonces
+	GetQueuedActs(addr string) []action.SealedEnvelope
+	// Stats returns the numbers of the executable and the queued actions in pool
+	Stats() Stats
 	// GetActionByHash returns the pending action in pool given action's hash
 	GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, error)
 	// GetSize returns the act pool size
@@ -48,6 +69,46 @@ type ActPool interface {
 	AddActionValidators(...protocol.ActionValidator)
 
 	AddActionEnvelopeValidators(...protocol.ActionEnvelopeValidator)
+	// AddSubscriber makes the subscriber get notified of the changes of the actions in pool
+	AddSubscriber(Subscriber) error
+	// RemoveSubscriber stops notifying the subscriber
+	RemoveSubscriber(Subscriber) error
+	// Validated returns whether the action in pool has been validated against the current state of its sender
+	Validated(act action.SealedEnvelope) bool
+	// RegisterAdmissionHook adds a hook which decides whether an action is admitted into the pool
+	RegisterAdmissionHook(AdmissionHook) error
+	// HandleBlockRevert puts the actions of the block reverted from the chain back into the pool
+	HandleBlockRevert(blk *block.Block) error
+}
+
+// PickBudget is the budget of the actions picked to mint a block. A zero limit means no limit
+type PickBudget struct {
+	// GasLimit is the max total gas limit of the picked actions
+	GasLimit uint64
+	// ByteLimit is the max total size in bytes of the picked actions
+	ByteLimit uint64
+	// MaxCount is the max number of the picked actions, on top of the limit of the actpool config
+	MaxCount uint64
+}
+
+// Stats is the statistics of actpool. The actions of an account are executable if their nonces are contiguous from the
+// pending nonce of the account, which could be picked into the next block, and are queued if they wait for the
+// preceding nonces
+type Stats struct {
+	NumExecutable uint64
+	NumQueued     uint64
+	Capacity      uint64
+	Bytes         uint64
+	// GasPriceFloor is the min gas price of the actions accepted by the pool
+	GasPriceFloor *big.Int
+}
+
+// AccountState is the state of an account as if all its pending actions in pool were applied
+type AccountState struct {
+	// PendingNonce is the nonce of the next action of the account
+	PendingNonce uint64
+	// Balance is the balance of the account after paying the max costs of the pending actions
+	Balance *big.Int
 }
 
 // actPool implements ActPool interface
@@ -59,6 +120,23 @@ type actPool struct {
 	allActions               map[hash.Hash256]action.SealedEnvelope
 	actionEnvelopeValidators []protocol.ActionEnvelopeValidator
 	validators               []protocol.ActionValidator
+	journal                  *journal
+	journalTask              *routine.RecurringTask
+	// poolBytes is the total size in bytes of the actions in pool
+	poolBytes      uint64
+	subscribers    []Subscriber
+	admissionHooks []AdmissionHook
+	// validationCache is the cache of the actions which have passed the validation, nil if it's disabled
+	validationCache *validationCache
+	// gasPriceFloor is the min gas price of the actions accepted by the pool, nil if there is no such limit
+	gasPriceFloor *gasPriceFloor
+	// localActs is the set of the actions of the local senders received via the local API
+	localActs map[hash.Hash256]struct{}
+	// localSenders are the addresses of the node operator, whose actions could be local
+	localSenders map[string]bool
+	// reverted is the actions of the reverted blocks which failed to be put back into the pool, e.g., while the state
+	// is being rebuilt. They are kept in journal and retried at the next reset
+	reverted []action.SealedEnvelope
 }
 
 // NewActPool constructs a new actpool
@@ -71,6 +149,27 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool) (ActPool, error) {
 		bc:          bc,
 		accountActs: make(map[string]ActQueue),
 		allActions:  make(map[hash.Hash256]action.SealedEnvelope),
+		localActs:   make(map[hash.Hash256]struct{}),
+	}
+	if len(cfg.LocalSenders) > 0 {
+		ap.localSenders = make(map[string]bool, len(cfg.LocalSenders))
+		for _, sender := range cfg.LocalSenders {
+			ap.localSenders[sender] = true
+		}
+	}
+	if cfg.EnableValidationCache {
+		ap.validationCache = newValidationCache()
+	}
+	minGasPrice := big.NewInt(0)
+	if cfg.MinGasPriceStr != "" {
+		var ok bool
+		if minGasPrice, ok = big.NewInt(0).SetString(cfg.MinGasPriceStr, 10); !ok {
+			return nil, errors.Errorf("error when casting min gas price string %s into big int", cfg.MinGasPriceStr)
+		}
+	}
+	if minGasPrice.Sign() > 0 || cfg.DynamicGasPriceFloor.Enabled {
+		ap.gasPriceFloor = newGasPriceFloor(minGasPrice, cfg.DynamicGasPriceFloor)
+		ap.admissionHooks = append(ap.admissionHooks, ap.gasPriceFloor)
 	}
 	return ap, nil
 }
@@ -84,6 +183,95 @@ func (ap *actPool) AddActionEnvelopeValidators(fs ...protocol.ActionEnvelopeVali
 	ap.actionEnvelopeValidators = append(ap.actionEnvelopeValidators, fs...)
 }
 
+// AddSubscriber makes the subscriber get notified of the changes of the actions in pool
+func (ap *actPool) AddSubscriber(s Subscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	ap.subscribers = append(ap.subscribers, s)
+	return nil
+}
+
+// RemoveSubscriber stops notifying the subscriber
+func (ap *actPool) RemoveSubscriber(s Subscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	for i, sub := range ap.subscribers {
+		if sub == s {
+			ap.subscribers = append(ap.subscribers[:i], ap.subscribers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("cannot find subscription")
+}
+
+// RegisterAdmissionHook adds a hook which decides whether an action is admitted into the pool
+func (ap *actPool) RegisterAdmissionHook(hook AdmissionHook) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	if hook == nil {
+		return errors.New("admission hook could not be nil")
+	}
+	ap.admissionHooks = append(ap.admissionHooks, hook)
+	return nil
+}
+
+// Validated returns whether the action in pool has been validated against the current state of its sender
+func (ap *actPool) Validated(act action.SealedEnvelope) bool {
+	if ap.validationCache == nil {
+		return false
+	}
+	return ap.validationCache.contains(act.Hash())
+}
+
+// Start reloads the actions in journal if it is enabled. The actions are validated again, and the invalid ones, e.g.,
+// those having been committed, are dropped
+func (ap *actPool) Start(ctx context.Context) error {
+	if ap.cfg.JournalPath == "" {
+		return nil
+	}
+	j := newJournal(ap.cfg.JournalPath)
+	total, dropped, err := j.load(ap.Add)
+	if err != nil {
+		log.L().Warn("Error when loading actpool journal.", zap.Error(err))
+	}
+	log.L().Info("Loaded actions from actpool journal.", zap.Int("total", total), zap.Int("dropped", dropped))
+
+	ap.mutex.Lock()
+	ap.journal = j
+	err = ap.rotateJournal()
+	ap.mutex.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "failed to rotate actpool journal")
+	}
+	ap.journalTask = routine.NewRecurringTask(func() {
+		ap.mutex.Lock()
+		defer ap.mutex.Unlock()
+		if err := ap.rotateJournal(); err != nil {
+			log.L().Error("Error when rotating actpool journal.", zap.Error(err))
+		}
+	}, ap.cfg.JournalRotation)
+	return ap.journalTask.Start(ctx)
+}
+
+// Stop regenerates the journal with the actions in actpool if it is enabled
+func (ap *actPool) Stop(ctx context.Context) error {
+	if ap.journal == nil {
+		return nil
+	}
+	if err := ap.journalTask.Stop(ctx); err != nil {
+		return err
+	}
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	if err := ap.rotateJournal(); err != nil {
+		return errors.Wrap(err, "failed to rotate actpool journal")
+	}
+	return ap.journal.close()
+}
+
 // Reset resets actpool state
 // Step I: remove all the actions in actpool that have already been committed to block
 // Step II: update pending balance of each account if it still exists in pool
@@ -96,8 +284,17 @@ func (ap *actPool) Reset() {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
 
+	ap.reset()
+	ap.retryReverted()
+}
+
+// reset resets actpool state as Reset does, which should be called with actpool locked
+func (ap *actPool) reset() {
 	// Remove confirmed actions in actpool
 	ap.removeConfirmedActs()
+	if ap.gasPriceFloor != nil {
+		ap.gasPriceFloor.adjust(ap.utilizationPct())
+	}
 	for from, queue := range ap.accountActs {
 		// Reset pending balance for each account
 		balance, err := ap.bc.Balance(from)
@@ -113,32 +310,79 @@ func (ap *actPool) Reset() {
 			log.L().Error("Error when resetting actpool state.", zap.Error(err))
 			return
 		}
+		if ap.validationCache != nil {
+			ap.validationCache.setAccountState(from, confirmedNonce, balance)
+		}
 		pendingNonce := confirmedNonce + 1
+		// The actions below the previous pending nonce have been promoted already
+		promotedNonce := queue.PendingNonce()
+		if promotedNonce < pendingNonce {
+			promotedNonce = pendingNonce
+		}
 		queue.SetStartNonce(pendingNonce)
 		queue.SetPendingNonce(pendingNonce)
-		ap.updateAccount(from)
+		ap.updateAccount(from, promotedNonce)
 	}
 }
 
-// PickActs returns all currently accepted transfers and votes for all accounts
-func (ap *actPool) PickActs() []action.SealedEnvelope {
+// PickActs returns the executable actions of all accounts packed within the budget. The actions with higher gas price
+// are picked first, unless the actions are picked account by account in FIFO mode. If an action doesn't fit into the
+// remaining budget, the rest actions of its account are skipped to keep the nonces contiguous, while the actions of
+// the other accounts are still picked
+func (ap *actPool) PickActs(budget PickBudget) []action.SealedEnvelope {
+	defer observeLatency("pick", time.Now())
 	ap.mutex.RLock()
 	defer ap.mutex.RUnlock()
 
-	numActs := uint64(0)
-	actions := make([]action.SealedEnvelope, 0)
-	for _, queue := range ap.accountActs {
-		for _, act := range queue.PendingActs() {
-			actions = append(actions, act)
-			numActs++
-			if ap.cfg.MaxNumActsToPick > 0 && numActs >= ap.cfg.MaxNumActsToPick {
-				log.L().Debug("Reach the max number of actions to pick.",
-					zap.Uint64("limit", ap.cfg.MaxNumActsToPick))
-				return actions
+	p := &packer{budget: budget, actions: make([]action.SealedEnvelope, 0)}
+	if ap.cfg.PickInFIFO {
+		for _, queue := range ap.accountActs {
+			for _, act := range queue.PendingActs() {
+				if !p.fit(act) {
+					break
+				}
+				p.pack(act)
+				if ap.reachPickLimit(len(p.actions)) || p.full() {
+					return p.actions
+				}
 			}
 		}
+		return p.actions
 	}
-	return actions
+
+	actionMap := make(map[string][]action.SealedEnvelope, len(ap.accountActs))
+	for from, queue := range ap.accountActs {
+		actionMap[from] = queue.PendingActs()
+	}
+	it := actioniterator.NewActionIterator(actionMap)
+	for {
+		act, ok := it.Next()
+		if !ok {
+			return p.actions
+		}
+		if !p.fit(act) {
+			it.PopAccount()
+			continue
+		}
+		p.pack(act)
+		if ap.reachPickLimit(len(p.actions)) || p.full() {
+			return p.actions
+		}
+	}
+}
+
+// GroupBySender groups the actions by the address of the sender, keeping the order of the actions of each sender
+func GroupBySender(acts []action.SealedEnvelope) (map[string][]action.SealedEnvelope, error) {
+	actionMap := make(map[string][]action.SealedEnvelope)
+	for _, act := range acts {
+		callerPKHash := keypair.HashPubKey(act.SrcPubkey())
+		caller, err := address.FromBytes(callerPKHash[:])
+		if err != nil {
+			return nil, err
+		}
+		actionMap[caller.String()] = append(actionMap[caller.String()], act)
+	}
+	return actionMap, nil
 }
 
 // PendingActionIterator returns an action interator with all accepted actions
@@ -154,48 +398,85 @@ func (ap *actPool) PendingActionMap() map[string][]action.SealedEnvelope {
 }
 
 func (ap *actPool) Add(act action.SealedEnvelope) error {
+	return ap.add(act, false)
+}
+
+// AddLocal adds an action received via the local API. If it's sent by a local sender, it could evict the actions
+// received from the network when pool is full regardless of their gas prices, while it's never evicted for them.
+// Otherwise it's added as a remote action, so that the public API can't be used to take the unevictable room
+func (ap *actPool) AddLocal(act action.SealedEnvelope) error {
+	return ap.add(act, true)
+}
+
+func (ap *actPool) add(act action.SealedEnvelope, local bool) (err error) {
+	defer observeLatency("add", time.Now())
+	defer func() { observeRejection(err) }()
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
-	// Reject action if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
-		return errors.Wrap(action.ErrActPool, "insufficient space for action")
-	}
 	hash := act.Hash()
 	// Reject action if it already exists in pool
 	if _, exist := ap.allActions[hash]; exist {
-		return errors.Errorf("reject existed action: %x", hash)
+		return errors.Wrapf(errExistedAction, "reject action %x", hash)
 	}
-
-	callerPKHash := keypair.HashPubKey(act.SrcPubkey())
-	caller, err := address.FromBytes(callerPKHash[:])
+	adm, err := ap.validateEnvelope(act, hash, ap.admissionHooks)
 	if err != nil {
 		return err
 	}
-	// envelope validation
-	for _, validator := range ap.actionEnvelopeValidators {
-		ctx := protocol.WithValidateActionsCtx(
-			context.Background(),
-			protocol.ValidateActionsCtx{
-				Caller: caller,
-			},
-		)
-		if err := validator.Validate(ctx, act); err != nil {
-			return errors.Wrapf(err, "reject invalid action: %x", hash)
-		}
+	adm.local = local && ap.localSenders[adm.caller.String()]
+	return ap.admit(act, hash, adm)
+}
+
+// AddBatch adds the actions into the pool, and returns the error of each action, which is nil if it's accepted. The
+// actions are validated by the envelope validators in parallel, which verify the signatures, and then added with the
+// pool locked once
+func (ap *actPool) AddBatch(acts []action.SealedEnvelope) []error {
+	defer observeLatency("addBatch", time.Now())
+	errs := make([]error, len(acts))
+	hashes := make([]hash.Hash256, len(acts))
+	adms := make([]*admission, len(acts))
+	ap.mutex.RLock()
+	hooks := ap.admissionHooks
+	ap.mutex.RUnlock()
+
+	numWorkers := runtime.NumCPU()
+	if numWorkers > len(acts) {
+		numWorkers = len(acts)
 	}
-	// Reject action if it's invalid
-	for _, validator := range ap.validators {
-		ctx := protocol.WithValidateActionsCtx(
-			context.Background(),
-			protocol.ValidateActionsCtx{
-				Caller: caller,
-			},
-		)
-		if err := validator.Validate(ctx, act.Action()); err != nil {
-			return errors.Wrapf(err, "reject invalid action: %x", hash)
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				hashes[i] = acts[i].Hash()
+				adms[i], errs[i] = ap.validateEnvelope(acts[i], hashes[i], hooks)
+			}
+		}()
+	}
+	for i := range acts {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	for i, act := range acts {
+		if errs[i] != nil {
+			continue
+		}
+		// Reject action if it already exists in pool, including the same action earlier in the batch
+		if _, exist := ap.allActions[hashes[i]]; exist {
+			errs[i] = errors.Wrapf(errExistedAction, "reject action %x", hashes[i])
+			continue
 		}
+		errs[i] = ap.admit(act, hashes[i], adms[i])
 	}
-	return ap.enqueueAction(caller.String(), act, hash, act.Nonce())
+	for _, err := range errs {
+		observeRejection(err)
+	}
+	return errs
 }
 
 // GetPendingNonce returns pending nonce in pool or confirmed nonce given an account address
@@ -211,6 +492,149 @@ func (ap *actPool) GetPendingNonce(addr string) (uint64, error) {
 	return pendingNonce, err
 }
 
+// PendingState returns the pending nonce and balance of the account in pool, or the confirmed ones if the account has
+// no action in pool
+func (ap *actPool) PendingState(addr string) (AccountState, error) {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	if queue, ok := ap.accountActs[addr]; ok {
+		return AccountState{
+			PendingNonce: queue.PendingNonce(),
+			Balance:      new(big.Int).Set(queue.PendingBalance()),
+		}, nil
+	}
+	confirmedNonce, err := ap.bc.Nonce(addr)
+	if err != nil {
+		return AccountState{}, err
+	}
+	balance, err := ap.bc.Balance(addr)
+	if err != nil {
+		return AccountState{}, err
+	}
+	return AccountState{PendingNonce: confirmedNonce + 1, Balance: balance}, nil
+}
+
+// HandleBlockRevert puts the actions of the block reverted from the chain back into the pool, as they are not
+// confirmed any more. They are added the same way as the actions received from the network, i.e., checked by the
+// admission hooks and the validators, and rejected if there isn't room for them. The pending actions of the senders
+// from the nonces of the reverted actions on are taken out before and added again after, so that a reverted action
+// invalidates the pending action of the same nonce unless the latter could replace it by fee. The pool is reset
+// against the reverted state before and after, which removes the actions not payable any more. The reverted actions
+// failing to be put back are kept in journal, and retried at the next reset or reloaded after the node restarts
+func (ap *actPool) HandleBlockRevert(blk *block.Block) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	ap.reset()
+	acts := make([]action.SealedEnvelope, 0, len(blk.Actions))
+	fromNonces := make(map[string]uint64)
+	for _, act := range blk.Actions {
+		// The reward is granted and the evidences are reported by the producer of the block only
+		switch act.Action().(type) {
+		case *action.GrantReward, *action.DoubleSignEvidence:
+			continue
+		}
+		callerPKHash := keypair.HashPubKey(act.SrcPubkey())
+		caller, err := address.FromBytes(callerPKHash[:])
+		if err != nil {
+			continue
+		}
+		if nonce, ok := fromNonces[caller.String()]; !ok || act.Nonce() < nonce {
+			fromNonces[caller.String()] = act.Nonce()
+		}
+		acts = append(acts, act)
+	}
+	var displaced []action.SealedEnvelope
+	displacedLocal := make(map[hash.Hash256]bool)
+	for sender, nonce := range fromNonces {
+		for _, act := range ap.displace(sender, nonce) {
+			displaced = append(displaced, act)
+			hash := act.Hash()
+			displacedLocal[hash] = ap.isLocal(act)
+			ap.removeFromPool(hash, act)
+		}
+	}
+	for _, act := range acts {
+		if err := ap.reinject(act, false); err != nil {
+			hash := act.Hash()
+			log.L().Debug("Failed to put reverted action back into actpool.",
+				log.Hex("hash", hash[:]),
+				zap.Uint64("height", blk.Height()),
+				zap.Error(err))
+			ap.reverted = append(ap.reverted, act)
+			if ap.journal != nil {
+				if err := ap.journal.insert(act); err != nil {
+					log.L().Error("Error when writing action into journal.", log.Hex("hash", hash[:]), zap.Error(err))
+				}
+			}
+		}
+	}
+	for _, act := range displaced {
+		if err := ap.reinject(act, displacedLocal[act.Hash()]); err != nil {
+			hash := act.Hash()
+			log.L().Debug("Invalidated action conflicting with reverted action.",
+				log.Hex("hash", hash[:]),
+				zap.Error(err))
+			ap.emit(ActionRemoved, act)
+		}
+	}
+	ap.reset()
+	return nil
+}
+
+// retryReverted puts the reverted actions failing to be put back before into the pool again. The ones failing again
+// are dropped
+func (ap *actPool) retryReverted() {
+	if len(ap.reverted) == 0 {
+		return
+	}
+	reverted := ap.reverted
+	ap.reverted = nil
+	for _, act := range reverted {
+		if err := ap.reinject(act, false); err != nil {
+			hash := act.Hash()
+			log.L().Warn("Dropped reverted action.", log.Hex("hash", hash[:]), zap.Error(err))
+		}
+	}
+	ap.reset()
+}
+
+// displace takes the actions of the sender from the nonce on out of the queue, in the order of nonce
+func (ap *actPool) displace(sender string, nonce uint64) []action.SealedEnvelope {
+	queue, ok := ap.accountActs[sender]
+	if !ok {
+		return nil
+	}
+	var displaced []action.SealedEnvelope
+	for {
+		last, ok := queue.LastAct()
+		if !ok || last.Nonce() < nonce {
+			break
+		}
+		queue.PopLastAct()
+		displaced = append([]action.SealedEnvelope{last}, displaced...)
+	}
+	if queue.Empty() {
+		delete(ap.accountActs, sender)
+	}
+	return displaced
+}
+
+// reinject puts the action taken out of the chain or the pool back into the pool through the same path as Add
+func (ap *actPool) reinject(act action.SealedEnvelope, local bool) error {
+	hash := act.Hash()
+	if _, exist := ap.allActions[hash]; exist {
+		return nil
+	}
+	adm, err := ap.validateEnvelope(act, hash, ap.admissionHooks)
+	if err != nil {
+		return err
+	}
+	adm.local = local
+	return ap.admit(act, hash, adm)
+}
+
 // GetUnconfirmedActs returns unconfirmed actions in pool given an account address
 func (ap *actPool) GetUnconfirmedActs(addr string) []action.SealedEnvelope {
 	ap.mutex.RLock()
@@ -222,6 +646,38 @@ func (ap *actPool) GetUnconfirmedActs(addr string) []action.SealedEnvelope {
 	return make([]action.SealedEnvelope, 0)
 }
 
+// GetQueuedActs returns the actions in pool given an account address, which wait for the preceding nonces
+func (ap *actPool) GetQueuedActs(addr string) []action.SealedEnvelope {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	if queue, ok := ap.accountActs[addr]; ok {
+		return queue.QueuedActs()
+	}
+	return make([]action.SealedEnvelope, 0)
+}
+
+// Stats returns the numbers of the executable and the queued actions in pool
+func (ap *actPool) Stats() Stats {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	stats := Stats{
+		Capacity:      ap.cfg.MaxNumActsPerPool,
+		Bytes:         ap.poolBytes,
+		GasPriceFloor: big.NewInt(0),
+	}
+	if ap.gasPriceFloor != nil {
+		stats.GasPriceFloor = ap.gasPriceFloor.Floor()
+	}
+	for _, queue := range ap.accountActs {
+		numExecutable := uint64(len(queue.PendingActs()))
+		stats.NumExecutable += numExecutable
+		stats.NumQueued += uint64(queue.Len()) - numExecutable
+	}
+	return stats
+}
+
 // GetActionByHash returns the pending action in pool given action's hash
 func (ap *actPool) GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, error) {
 	ap.mutex.RLock()
@@ -253,29 +709,104 @@ func (ap *actPool) GetCapacity() uint64 {
 //======================================
 // private functions
 //======================================
+// admission is an action which has passed the admission hooks and the envelope validation, together with its sender
+// and the account state of the sender read before the validation
+type admission struct {
+	caller    address.Address
+	cacheable bool
+	nonce     uint64
+	balance   *big.Int
+	local     bool
+}
+
+// validateEnvelope checks the action against the admission hooks and the envelope validators, which doesn't require
+// the pool to be locked
+func (ap *actPool) validateEnvelope(
+	act action.SealedEnvelope,
+	hash hash.Hash256,
+	hooks []AdmissionHook,
+) (*admission, error) {
+	callerPKHash := keypair.HashPubKey(act.SrcPubkey())
+	caller, err := address.FromBytes(callerPKHash[:])
+	if err != nil {
+		return nil, err
+	}
+	// Reject action if it's not admitted by the policies of the operator
+	for _, hook := range hooks {
+		if err := hook.Admit(caller.String(), act); err != nil {
+			return nil, errors.Wrapf(err, "reject action: %x", hash)
+		}
+	}
+	adm := &admission{caller: caller}
+	// Read the account state of the sender before the validation, against which the action is cached as validated
+	if ap.validationCache != nil {
+		adm.nonce, adm.balance, adm.cacheable = ap.accountState(caller.String())
+	}
+	// envelope validation
+	for _, validator := range ap.actionEnvelopeValidators {
+		ctx := protocol.WithValidateActionsCtx(
+			context.Background(),
+			protocol.ValidateActionsCtx{
+				Caller: caller,
+			},
+		)
+		if err := validator.Validate(ctx, act); err != nil {
+			return nil, errors.Wrapf(err, "reject invalid action: %x", hash)
+		}
+	}
+	return adm, nil
+}
+
+// admit validates the action by the action validators and puts it into the pool, which should be locked
+func (ap *actPool) admit(act action.SealedEnvelope, hash hash.Hash256, adm *admission) error {
+	caller := adm.caller
+	// Reject action if it's invalid
+	for _, validator := range ap.validators {
+		ctx := protocol.WithValidateActionsCtx(
+			context.Background(),
+			protocol.ValidateActionsCtx{
+				Caller: caller,
+			},
+		)
+		if err := validator.Validate(ctx, act.Action()); err != nil {
+			return errors.Wrapf(err, "reject invalid action: %x", hash)
+		}
+	}
+	// Reject action if pool space is full, unless room is made by evicting an action of lower gas price. A replacement
+	// of the action of the same nonce doesn't take more room
+	if queue, ok := ap.accountActs[caller.String()]; !ok || !queue.Overlaps(act) {
+		if err := ap.makeRoom(caller.String(), act, adm.local); err != nil {
+			return err
+		}
+	}
+	if err := ap.enqueueAction(caller.String(), act, hash, act.Nonce()); err != nil {
+		return err
+	}
+	if adm.local {
+		ap.localActs[hash] = struct{}{}
+	}
+	if adm.cacheable {
+		ap.validationCache.add(caller.String(), adm.nonce, adm.balance, hash)
+	}
+	if ap.journal != nil {
+		if err := ap.journal.insert(act); err != nil {
+			log.L().Error("Error when writing action into journal.", log.Hex("hash", hash[:]), zap.Error(err))
+		}
+	}
+	return nil
+}
+
 func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash hash.Hash256, actNonce uint64) error {
 	queue := ap.accountActs[sender]
 	if queue == nil {
-		queue = NewActQueue(WithTimeOut(ap.cfg.ActionExpiry))
-		ap.accountActs[sender] = queue
-		confirmedNonce, err := ap.bc.Nonce(sender)
-		if err != nil {
-			return errors.Wrapf(err, "failed to get sender's nonce for action %x", hash)
-		}
-		// Initialize pending nonce for new account
-		pendingNonce := confirmedNonce + 1
-		queue.SetPendingNonce(pendingNonce)
-		queue.SetStartNonce(pendingNonce)
-		// Initialize balance for new account
-		balance, err := ap.bc.Balance(sender)
-		if err != nil {
-			return errors.Wrapf(err, "failed to get sender's balance for action %x", hash)
+		var err error
+		if queue, err = ap.createQueue(sender); err != nil {
+			return errors.Wrapf(err, "failed to create queue for action %x", hash)
 		}
-		queue.SetPendingBalance(balance)
 	}
 	if queue.Overlaps(act) {
 		// Nonce already exists
-		return errors.Wrapf(action.ErrNonce, "duplicate nonce for action %x", hash)
+		return ap.replaceAction(queue, act, hash, actNonce)
 	}
 
 	if actNonce-queue.StartNonce() >= ap.cfg.MaxNumActsPerAcct {
@@ -299,15 +830,250 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 	if err := queue.Put(act); err != nil {
 		return errors.Wrapf(err, "cannot put action %x into ActQueue", hash)
 	}
-	ap.allActions[hash] = act
+	ap.addToPool(hash, act)
+	ap.emit(ActionAdded, act)
 	// If the pending nonce equals this nonce, update queue
 	nonce := queue.PendingNonce()
 	if actNonce == nonce {
-		ap.updateAccount(sender)
+		ap.updateAccount(sender, actNonce)
+	}
+	return nil
+}
+
+// createQueue creates the queue of the sender, whose pending nonce and balance are initialized by the confirmed ones
+func (ap *actPool) createQueue(sender string) (ActQueue, error) {
+	confirmedNonce, err := ap.bc.Nonce(sender)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sender's nonce")
+	}
+	balance, err := ap.bc.Balance(sender)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sender's balance")
+	}
+	queue := NewActQueue(WithTimeOut(ap.cfg.ActionExpiry), WithGapTimeOut(ap.cfg.GapEvictionTTL))
+	pendingNonce := confirmedNonce + 1
+	queue.SetPendingNonce(pendingNonce)
+	queue.SetStartNonce(pendingNonce)
+	queue.SetPendingBalance(balance)
+	ap.accountActs[sender] = queue
+	return queue, nil
+}
+
+// replaceAction replaces the action of the same nonce in the queue, if the gas price of the given action is higher
+// by at least the configured percentage
+func (ap *actPool) replaceAction(queue ActQueue, act action.SealedEnvelope, hash hash.Hash256, actNonce uint64) error {
+	if ap.cfg.ReplaceByFeePct == 0 {
+		return errors.Wrapf(action.ErrNonce, "duplicate nonce for action %x", hash)
+	}
+	old, _ := queue.Get(actNonce)
+	oldHash := old.Hash()
+	minGasPrice := new(big.Int).Mul(old.GasPrice(), big.NewInt(int64(100+ap.cfg.ReplaceByFeePct)))
+	minGasPrice.Div(minGasPrice, big.NewInt(100))
+	if act.GasPrice().Cmp(minGasPrice) < 0 || act.GasPrice().Cmp(old.GasPrice()) <= 0 {
+		return errors.Wrapf(
+			action.ErrNonce,
+			"duplicate nonce for action %x, whose gas price should be at least %s to replace the action in pool",
+			hash,
+			minGasPrice,
+		)
+	}
+	oldCost, err := old.Cost()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get cost of action %x", oldHash)
+	}
+	cost, err := act.Cost()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get cost of action %x", hash)
+	}
+	// The cost of the replaced action has been deducted from the pending balance if it is pending
+	balance := new(big.Int).Set(queue.PendingBalance())
+	pending := actNonce < queue.PendingNonce()
+	if pending {
+		balance.Add(balance, oldCost)
+	}
+	if balance.Cmp(cost) < 0 {
+		return errors.Wrapf(action.ErrBalance, "insufficient balance for action %x", hash)
+	}
+	if _, err := queue.Replace(act); err != nil {
+		return errors.Wrapf(err, "cannot replace action %x in ActQueue", oldHash)
+	}
+	if pending {
+		queue.SetPendingBalance(balance.Sub(balance, cost))
+	}
+	ap.removeFromPool(oldHash, old)
+	ap.emit(ActionRemoved, old)
+	ap.addToPool(hash, act)
+	ap.emit(ActionAdded, act)
+	if pending {
+		ap.emit(ActionPromoted, act)
+	}
+	log.L().Debug("Replaced action by fee.",
+		log.Hex("old", oldHash[:]),
+		log.Hex("new", hash[:]),
+		zap.Uint64("nonce", actNonce))
+	return nil
+}
+
+// rotateJournal regenerates the journal with the actions in actpool, which are sorted by nonce for each account, and
+// the reverted actions to be put back
+func (ap *actPool) rotateJournal() error {
+	acts := make([]action.SealedEnvelope, 0, len(ap.allActions))
+	for _, queue := range ap.accountActs {
+		acts = append(acts, queue.AllActs()...)
+	}
+	acts = append(acts, ap.reverted...)
+	return ap.journal.rotate(acts)
+}
+
+// isFull returns true if there is no room for an action of the given size in pool
+func (ap *actPool) isFull(size uint64) bool {
+	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
+		return true
+	}
+	return ap.cfg.MaxPoolBytes != 0 && ap.poolBytes+size > ap.cfg.MaxPoolBytes
+}
+
+// utilizationPct returns the percentage of the pool capacity in use, by the number of actions or by the size in bytes
+// if it's limited, whichever is higher
+func (ap *actPool) utilizationPct() uint64 {
+	pct := uint64(len(ap.allActions)) * 100 / ap.cfg.MaxNumActsPerPool
+	if ap.cfg.MaxPoolBytes != 0 {
+		if bytesPct := ap.poolBytes * 100 / ap.cfg.MaxPoolBytes; bytesPct > pct {
+			pct = bytesPct
+		}
+	}
+	return pct
+}
+
+// makeRoom makes room for the action if pool is full. If eviction is enabled, the last actions of the other senders
+// are evicted in the order of gas price from low to high, as long as their gas prices are lower than the action's. The
+// local actions are never evicted, and a local action evicts the others regardless of their gas prices
+func (ap *actPool) makeRoom(sender string, act action.SealedEnvelope, local bool) error {
+	size := actSize(act)
+	for ap.isFull(size) {
+		if !ap.cfg.EvictLowestGasPrice {
+			return errors.Wrap(action.ErrActPool, "insufficient space for action")
+		}
+		var (
+			evictFrom     string
+			evictGasPrice *big.Int
+		)
+		for from, queue := range ap.accountActs {
+			if from == sender {
+				continue
+			}
+			last, ok := queue.LastAct()
+			if !ok || ap.isLocal(last) {
+				continue
+			}
+			if evictGasPrice == nil || last.GasPrice().Cmp(evictGasPrice) < 0 {
+				evictFrom, evictGasPrice = from, last.GasPrice()
+			}
+		}
+		if evictGasPrice == nil || (!local && evictGasPrice.Cmp(act.GasPrice()) >= 0) {
+			return errors.Wrap(action.ErrActPool, "insufficient space for action")
+		}
+		queue := ap.accountActs[evictFrom]
+		evicted, _ := queue.PopLastAct()
+		evictedHash := evicted.Hash()
+		log.L().Debug("Evicted action of lower gas price.",
+			log.Hex("hash", evictedHash[:]),
+			zap.String("gasPrice", evictGasPrice.String()))
+		evictionMtc.WithLabelValues("gasPrice").Inc()
+		ap.removeFromPool(evictedHash, evicted)
+		ap.emit(ActionRemoved, evicted)
+		if queue.Empty() {
+			delete(ap.accountActs, evictFrom)
+		}
 	}
 	return nil
 }
 
+// updateSizeMetrics updates the metrics of the pool size, which should be called once the pool size changes
+func (ap *actPool) updateSizeMetrics() {
+	poolSizeMtc.WithLabelValues("actions").Set(float64(len(ap.allActions)))
+	poolSizeMtc.WithLabelValues("bytes").Set(float64(ap.poolBytes))
+	poolUtilizationMtc.Set(float64(ap.utilizationPct()))
+}
+
+// isLocal returns true if the action in pool is received via the local API
+func (ap *actPool) isLocal(act action.SealedEnvelope) bool {
+	_, ok := ap.localActs[act.Hash()]
+	return ok
+}
+
+func (ap *actPool) addToPool(hash hash.Hash256, act action.SealedEnvelope) {
+	ap.allActions[hash] = act
+	ap.poolBytes += actSize(act)
+	ap.updateSizeMetrics()
+}
+
+func (ap *actPool) removeFromPool(hash hash.Hash256, act action.SealedEnvelope) {
+	if _, exist := ap.allActions[hash]; !exist {
+		return
+	}
+	delete(ap.allActions, hash)
+	delete(ap.localActs, hash)
+	ap.poolBytes -= actSize(act)
+	ap.updateSizeMetrics()
+	if ap.validationCache != nil {
+		ap.validationCache.remove(hash)
+	}
+}
+
+// accountState returns the confirmed nonce and the balance of the account, and whether they are read successfully
+func (ap *actPool) accountState(addr string) (uint64, *big.Int, bool) {
+	nonce, err := ap.bc.Nonce(addr)
+	if err != nil {
+		return 0, nil, false
+	}
+	balance, err := ap.bc.Balance(addr)
+	if err != nil {
+		return 0, nil, false
+	}
+	return nonce, balance, true
+}
+
+func actSize(act action.SealedEnvelope) uint64 {
+	return uint64(proto.Size(act.Proto()))
+}
+
+// packer keeps the actions picked within the budget
+type packer struct {
+	budget  PickBudget
+	actions []action.SealedEnvelope
+	gas     uint64
+	bytes   uint64
+}
+
+// fit returns true if the action fits into the remaining budget
+func (p *packer) fit(act action.SealedEnvelope) bool {
+	if p.budget.GasLimit != 0 && p.gas+act.GasLimit() > p.budget.GasLimit {
+		return false
+	}
+	return p.budget.ByteLimit == 0 || p.bytes+actSize(act) <= p.budget.ByteLimit
+}
+
+func (p *packer) pack(act action.SealedEnvelope) {
+	p.actions = append(p.actions, act)
+	p.gas += act.GasLimit()
+	p.bytes += actSize(act)
+}
+
+// full returns true if the number of the picked actions reaches the budget
+func (p *packer) full() bool {
+	return p.budget.MaxCount != 0 && uint64(len(p.actions)) >= p.budget.MaxCount
+}
+
+// reachPickLimit returns true if the number of the picked actions reaches the limit
+func (ap *actPool) reachPickLimit(numActs int) bool {
+	if ap.cfg.MaxNumActsToPick == 0 || uint64(numActs) < ap.cfg.MaxNumActsToPick {
+		return false
+	}
+	log.L().Debug("Reach the max number of actions to pick.", zap.Uint64("limit", ap.cfg.MaxNumActsToPick))
+	return true
+}
+
 // removeConfirmedActs removes processed (committed to block) actions from pool
 func (ap *actPool) removeConfirmedActs() {
 	for from, queue := range ap.accountActs {
@@ -318,8 +1084,10 @@ func (ap *actPool) removeConfirmedActs() {
 		}
 		pendingNonce := confirmedNonce + 1
 		// Remove all actions that are committed to new block
-		acts := queue.FilterNonce(pendingNonce)
-		ap.removeInvalidActs(acts)
+		for _, act := range queue.FilterNonce(pendingNonce) {
+			ap.removeFromPool(act.Hash(), act)
+			ap.emit(ActionConfirmed, act)
+		}
 
 		// Delete the queue entry if it becomes empty
 		if queue.Empty() {
@@ -332,19 +1100,34 @@ func (ap *actPool) removeInvalidActs(acts []action.SealedEnvelope) {
 	for _, act := range acts {
 		hash := act.Hash()
 		log.L().Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
-		delete(ap.allActions, hash)
+		ap.removeFromPool(hash, act)
+		ap.emit(ActionRemoved, act)
 	}
 }
 
-// updateAccount updates queue's status and remove invalidated actions from pool if necessary
-func (ap *actPool) updateAccount(sender string) {
+// updateAccount updates queue's status and remove invalidated actions from pool if necessary. The actions from the
+// given nonce up to the new pending nonce are promoted
+func (ap *actPool) updateAccount(sender string, promotedNonce uint64) {
 	queue := ap.accountActs[sender]
 	acts := queue.UpdateQueue(queue.PendingNonce())
 	if len(acts) > 0 {
 		ap.removeInvalidActs(acts)
 	}
+	for nonce := promotedNonce; nonce < queue.PendingNonce(); nonce++ {
+		if act, ok := queue.Get(nonce); ok {
+			ap.emit(ActionPromoted, act)
+		}
+	}
 	// Delete the queue entry if it becomes empty
 	if queue.Empty() {
 		delete(ap.accountActs, sender)
 	}
 }
+
+func (ap *actPool) emit(typ ActionEventType, act action.SealedEnvelope) {
+	for _, s := range ap.subscribers {
+		if err := s.HandleActionEvent(ActionEvent{Type: typ, Action: act}); err != nil {
+			log.L().Error("Failed to handle action event.", zap.Stringer("type", typ), zap.Error(err))
+		}
+	}
+}
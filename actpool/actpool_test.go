@@ -8,9 +8,12 @@ package actpool
 
 import (
 	"context"
+	"io/ioutil"
 	"math/big"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -22,8 +25,10 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -299,6 +304,397 @@ func TestActPool_AddActs(t *testing.T) {
 	require.Equal(action.ErrInsufficientBalanceForGas, errors.Cause(err))
 }
 
+func TestActPool_AddBatch(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(100))
+	require.NoError(err)
+	ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+	require.Empty(ap.AddBatch(nil))
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	// tsf4 is rejected by the action validator as the sender doesn't have enough balance
+	tsf4, err := testutil.SignedTransfer(addr1, priKey2, uint64(2), big.NewInt(100), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	// tsf5 is rejected by the envelope validator as its signature is invalid
+	unsignedTsf, err := action.NewTransfer(uint64(3), big.NewInt(1), addr2, []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	bd := &action.EnvelopeBuilder{}
+	elp := bd.SetNonce(3).
+		SetAction(unsignedTsf).
+		SetGasLimit(100000).
+		SetDestinationAddress(addr2).Build()
+	tsf5 := action.FakeSeal(elp, pubKey1)
+
+	errs := ap.AddBatch([]action.SealedEnvelope{tsf1, tsf2, tsf3, tsf1, tsf4, tsf5})
+	require.Equal(6, len(errs))
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.NoError(errs[2])
+	require.Error(errs[3])
+	require.Error(errs[4])
+	require.Error(errs[5])
+	require.Equal(uint64(3), ap.GetSize())
+	pendingNonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(3), pendingNonce)
+
+	// The actions in pool are rejected in batch as well
+	errs = ap.AddBatch([]action.SealedEnvelope{tsf2})
+	require.Error(errs[0])
+}
+
+func TestActPool_ReplaceByFee(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	apConfig.ReplaceByFeePct = 10
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.Equal(big.NewInt(899990), ap.accountActs[addr1].PendingBalance())
+
+	// Case I: Gas price is not higher by the configured percentage
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(20), []byte{}, uint64(10000), big.NewInt(10))
+	require.NoError(err)
+	err = ap.Add(tsf2)
+	require.Equal(action.ErrNonce, errors.Cause(err))
+	// Case II: Gas price is high enough to replace the action
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(11))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf3))
+	require.Equal(uint64(1), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Error(err)
+	act, err := ap.GetActionByHash(tsf3.Hash())
+	require.NoError(err)
+	require.Equal(tsf3, act)
+	require.Equal(big.NewInt(889990), ap.accountActs[addr1].PendingBalance())
+	require.Equal([]action.SealedEnvelope{tsf3}, ap.PendingActionMap()[addr1])
+	// Case III: Insufficient balance for the replacement
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(900000), []byte{}, uint64(10000), big.NewInt(20))
+	require.NoError(err)
+	err = ap.Add(tsf4)
+	require.Equal(action.ErrBalance, errors.Cause(err))
+
+	// Replacement is disabled
+	ap.cfg.ReplaceByFeePct = 0
+	tsf5, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(100))
+	require.NoError(err)
+	err = ap.Add(tsf5)
+	require.Equal(action.ErrNonce, errors.Cause(err))
+}
+
+func TestActPool_Journal(t *testing.T) {
+	require := require.New(t)
+	file, err := ioutil.TempFile("", "actpool-journal")
+	require.NoError(err)
+	path := file.Name()
+	require.NoError(file.Close())
+	require.NoError(os.Remove(path))
+	defer os.Remove(path)
+
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	_, err = bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.JournalPath = path
+	apConfig.JournalRotation = time.Hour
+	newActPool := func() *actPool {
+		Ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		ap, ok := Ap.(*actPool)
+		require.True(ok)
+		ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+		ap.AddActionValidators(account.NewProtocol())
+		return ap
+	}
+
+	ap := newActPool()
+	require.NoError(ap.Start(context.Background()))
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf3))
+
+	// The actions are reloaded after restart, even if the actpool isn't stopped gracefully
+	ap2 := newActPool()
+	require.NoError(ap2.Start(context.Background()))
+	require.Equal(uint64(3), ap2.GetSize())
+	for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+		_, err := ap2.GetActionByHash(tsf.Hash())
+		require.NoError(err)
+	}
+	require.NoError(ap2.Stop(context.Background()))
+	require.NoError(ap.Stop(context.Background()))
+
+	// The committed actions are dropped while being reloaded
+	gasLimit := uint64(1000000)
+	ctx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			GasLimit: &gasLimit,
+		})
+	sf := bc.GetFactory()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, _, err = ws.RunActions(ctx, 0, []action.SealedEnvelope{tsf1})
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	ap3 := newActPool()
+	require.NoError(ap3.Start(context.Background()))
+	require.Equal(uint64(2), ap3.GetSize())
+	_, err = ap3.GetActionByHash(tsf1.Hash())
+	require.Error(err)
+	require.Len(ap3.PendingActionMap()[addr1], 2)
+	require.NoError(ap3.Stop(context.Background()))
+}
+
+type testSubscriber struct {
+	events []ActionEvent
+}
+
+func (s *testSubscriber) HandleActionEvent(e ActionEvent) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestActPool_Subscriber(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.ReplaceByFeePct = 10
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+	sub := &testSubscriber{}
+	require.Error(ap.AddSubscriber(nil))
+	require.NoError(ap.AddSubscriber(sub))
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf5, err := testutil.SignedTransfer(addr2, priKey1, uint64(4), big.NewInt(40), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	requireEvents := func(expected ...ActionEvent) {
+		require.Equal(len(expected), len(sub.events))
+		for i, e := range expected {
+			require.Equal(e.Type, sub.events[i].Type)
+			require.Equal(e.Action.Hash(), sub.events[i].Action.Hash())
+		}
+		sub.events = nil
+	}
+
+	// The action following a nonce gap isn't promoted until the gap is filled
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf3))
+	requireEvents(ActionEvent{ActionAdded, tsf1}, ActionEvent{ActionPromoted, tsf1}, ActionEvent{ActionAdded, tsf3})
+	require.NoError(ap.Add(tsf2))
+	requireEvents(ActionEvent{ActionAdded, tsf2}, ActionEvent{ActionPromoted, tsf2}, ActionEvent{ActionPromoted, tsf3})
+	// The replaced action is removed
+	require.NoError(ap.Add(tsf4))
+	requireEvents(ActionEvent{ActionRemoved, tsf3}, ActionEvent{ActionAdded, tsf4}, ActionEvent{ActionPromoted, tsf4})
+
+	// The committed action is confirmed, while the pending ones aren't promoted again
+	gasLimit := uint64(1000000)
+	ctx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			GasLimit: &gasLimit,
+		})
+	sf := bc.GetFactory()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, _, err = ws.RunActions(ctx, 0, []action.SealedEnvelope{tsf1})
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	ap.Reset()
+	requireEvents(ActionEvent{ActionConfirmed, tsf1})
+
+	// No event is emitted after unsubscription
+	require.NoError(ap.RemoveSubscriber(sub))
+	require.Error(ap.RemoveSubscriber(sub))
+	require.NoError(ap.Add(tsf5))
+	requireEvents()
+}
+
+func TestActPool_Eviction(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(context.Background()))
+	for _, addr := range []string{addr1, addr2, addr3} {
+		_, err := bc.CreateState(addr, big.NewInt(1000000000))
+		require.NoError(err)
+	}
+	newActPool := func(cfg config.ActPool) *actPool {
+		Ap, err := NewActPool(bc, cfg)
+		require.NoError(err)
+		ap, ok := Ap.(*actPool)
+		require.True(ok)
+		ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+		ap.AddActionValidators(account.NewProtocol())
+		return ap
+	}
+	transfer := func(priKey keypair.PrivateKey, nonce uint64, gasPrice int64) action.SealedEnvelope {
+		tsf, err := testutil.SignedTransfer(addr4, priKey, nonce, big.NewInt(1), []byte{}, uint64(10000), big.NewInt(gasPrice))
+		require.NoError(err)
+		return tsf
+	}
+
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 3
+	apConfig.EvictLowestGasPrice = true
+	ap := newActPool(apConfig)
+	tsf1 := transfer(priKey1, 1, 2)
+	tsf2 := transfer(priKey1, 2, 1)
+	tsf3 := transfer(priKey2, 1, 3)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf3))
+	// Case I: No action of lower gas price to evict
+	err := ap.Add(transfer(priKey3, 1, 1))
+	require.Equal(action.ErrActPool, errors.Cause(err))
+	// Case II: The last action of the lowest gas price is evicted
+	tsf5 := transfer(priKey3, 1, 2)
+	require.NoError(ap.Add(tsf5))
+	require.Equal(uint64(3), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf2.Hash())
+	require.Error(err)
+	pendingNonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(2), pendingNonce)
+	// Case III: The actions of the sender itself are not evicted
+	tsf6 := transfer(priKey1, 2, 5)
+	require.NoError(ap.Add(tsf6))
+	_, err = ap.GetActionByHash(tsf5.Hash())
+	require.Error(err)
+	require.Equal([]action.SealedEnvelope{tsf1, tsf6}, ap.PendingActionMap()[addr1])
+	require.Equal(actSize(tsf1)+actSize(tsf3)+actSize(tsf6), ap.poolBytes)
+	// Case IV: Eviction is disabled
+	ap.cfg.EvictLowestGasPrice = false
+	err = ap.Add(transfer(priKey3, 1, 10))
+	require.Equal(action.ErrActPool, errors.Cause(err))
+
+	// Pool size in bytes is limited
+	apConfig = getActPoolCfg()
+	apConfig.MaxPoolBytes = actSize(tsf1) * 2
+	ap = newActPool(apConfig)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf3))
+	err = ap.Add(transfer(priKey3, 1, 1))
+	require.Equal(action.ErrActPool, errors.Cause(err))
+	require.Equal(actSize(tsf1)+actSize(tsf3), ap.poolBytes)
+
+	// The actions of the senders not configured as local are added as remote ones via the local API
+	apConfig = getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 2
+	apConfig.EvictLowestGasPrice = true
+	ap = newActPool(apConfig)
+	require.NoError(ap.AddLocal(transfer(priKey1, 1, 1)))
+	require.NoError(ap.Add(transfer(priKey2, 1, 2)))
+	require.NoError(ap.Add(transfer(priKey3, 1, 10)))
+	require.Empty(ap.PendingActionMap()[addr1])
+	require.Empty(ap.localActs)
+
+	// Local actions are not evicted for the remote ones
+	apConfig.LocalSenders = []string{addr1, addr3}
+	ap = newActPool(apConfig)
+	local1 := transfer(priKey1, 1, 1)
+	require.NoError(ap.AddLocal(local1))
+	require.NoError(ap.Add(transfer(priKey2, 1, 1)))
+	remote3 := transfer(priKey3, 1, 10)
+	require.NoError(ap.Add(remote3))
+	_, err = ap.GetActionByHash(local1.Hash())
+	require.NoError(err)
+	require.Empty(ap.PendingActionMap()[addr2])
+	err = ap.Add(transfer(priKey2, 1, 100))
+	require.NoError(err)
+	_, err = ap.GetActionByHash(remote3.Hash())
+	require.Error(err)
+	// A local action evicts the remote ones regardless of their gas prices
+	local3 := transfer(priKey3, 1, 1)
+	require.NoError(ap.AddLocal(local3))
+	require.Empty(ap.PendingActionMap()[addr2])
+	err = ap.Add(transfer(priKey2, 1, 1000))
+	require.Equal(action.ErrActPool, errors.Cause(err))
+	require.Equal([]action.SealedEnvelope{local1}, ap.PendingActionMap()[addr1])
+	require.Equal([]action.SealedEnvelope{local3}, ap.PendingActionMap()[addr3])
+	// The local tag is removed with the action
+	ap.removeFromPool(local3.Hash(), local3)
+	require.False(ap.isLocal(local3))
+}
+
 func TestActPool_PickActs(t *testing.T) {
 	createActPool := func(cfg config.ActPool) (*actPool, []action.SealedEnvelope, []action.SealedEnvelope, []action.SealedEnvelope) {
 		require := require.New(t)
@@ -369,23 +765,106 @@ func TestActPool_PickActs(t *testing.T) {
 	t.Run("no-limit", func(t *testing.T) {
 		apConfig := getActPoolCfg()
 		ap, transfers, votes, executions := createActPool(apConfig)
-		pickedActs := ap.PickActs()
+		pickedActs := ap.PickActs(PickBudget{})
 		require.Equal(t, len(transfers)+len(votes)+len(executions), len(pickedActs))
 	})
 	t.Run("enough-limit", func(t *testing.T) {
 		apConfig := getActPoolCfg()
 		apConfig.MaxNumActsToPick = 10
 		ap, transfers, votes, executions := createActPool(apConfig)
-		pickedActs := ap.PickActs()
+		pickedActs := ap.PickActs(PickBudget{})
 		require.Equal(t, len(transfers)+len(votes)+len(executions), len(pickedActs))
 	})
 	t.Run("low-limit", func(t *testing.T) {
 		apConfig := getActPoolCfg()
 		apConfig.MaxNumActsToPick = 3
 		ap, _, _, _ := createActPool(apConfig)
-		pickedActs := ap.PickActs()
+		pickedActs := ap.PickActs(PickBudget{})
 		require.Equal(t, 3, len(pickedActs))
 	})
+	t.Run("budget", func(t *testing.T) {
+		for _, fifo := range []bool{false, true} {
+			apConfig := getActPoolCfg()
+			apConfig.PickInFIFO = fifo
+			ap, transfers, votes, executions := createActPool(apConfig)
+			total := len(transfers) + len(votes) + len(executions)
+			require.Equal(t, 2, len(ap.PickActs(PickBudget{GasLimit: 250000})))
+			require.Equal(t, 2, len(ap.PickActs(PickBudget{MaxCount: 2})))
+			require.Equal(t, 0, len(ap.PickActs(PickBudget{ByteLimit: 1})))
+			require.Equal(t, total, len(ap.PickActs(PickBudget{GasLimit: 1000000, ByteLimit: 1 << 20, MaxCount: 10})))
+		}
+	})
+	t.Run("budget-skips-account", func(t *testing.T) {
+		require := require.New(t)
+		bc := blockchain.NewBlockchain(
+			config.Default,
+			blockchain.InMemStateFactoryOption(),
+			blockchain.InMemDaoOption(),
+			blockchain.GenesisOption(genesis.Default),
+		)
+		require.NoError(bc.Start(context.Background()))
+		_, err := bc.CreateState(addr1, big.NewInt(1000000))
+		require.NoError(err)
+		_, err = bc.CreateState(addr2, big.NewInt(1000000))
+		require.NoError(err)
+		tsf1, err := testutil.SignedTransfer(addr1, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(50000), big.NewInt(5))
+		require.NoError(err)
+		tsf2, err := testutil.SignedTransfer(addr1, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(5))
+		require.NoError(err)
+		tsf3, err := testutil.SignedTransfer(addr2, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(3))
+		require.NoError(err)
+
+		for _, fifo := range []bool{false, true} {
+			apConfig := getActPoolCfg()
+			apConfig.PickInFIFO = fifo
+			ap, err := NewActPool(bc, apConfig)
+			require.NoError(err)
+			for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+				require.NoError(ap.Add(tsf))
+			}
+			// The action with nonce 2 isn't picked as the action with nonce 1 exceeds the budget
+			require.Equal([]action.SealedEnvelope{tsf3}, ap.PickActs(PickBudget{GasLimit: 30000}))
+		}
+	})
+	t.Run("gas-price", func(t *testing.T) {
+		require := require.New(t)
+		bc := blockchain.NewBlockchain(
+			config.Default,
+			blockchain.InMemStateFactoryOption(),
+			blockchain.InMemDaoOption(),
+			blockchain.GenesisOption(genesis.Default),
+		)
+		require.NoError(bc.Start(context.Background()))
+		_, err := bc.CreateState(addr1, big.NewInt(1000000))
+		require.NoError(err)
+		_, err = bc.CreateState(addr2, big.NewInt(1000000))
+		require.NoError(err)
+		tsf1, err := testutil.SignedTransfer(addr1, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(1))
+		require.NoError(err)
+		tsf2, err := testutil.SignedTransfer(addr1, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(5))
+		require.NoError(err)
+		tsf3, err := testutil.SignedTransfer(addr2, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(3))
+		require.NoError(err)
+
+		for _, fifo := range []bool{false, true} {
+			apConfig := getActPoolCfg()
+			apConfig.PickInFIFO = fifo
+			ap, err := NewActPool(bc, apConfig)
+			require.NoError(err)
+			for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+				require.NoError(ap.Add(tsf))
+			}
+			pickedActs := ap.PickActs(PickBudget{})
+			require.Equal(3, len(pickedActs))
+			if fifo {
+				// The actions of the same account are picked together
+				require.NotEqual(tsf3, pickedActs[1])
+				continue
+			}
+			// The action with nonce 2 waits for the action with nonce 1, which has the lowest gas price
+			require.Equal([]action.SealedEnvelope{tsf3, tsf1, tsf2}, pickedActs)
+		}
+	})
 }
 
 func TestActPool_removeConfirmedActs(t *testing.T) {
@@ -584,7 +1063,7 @@ func TestActPool_Reset(t *testing.T) {
 	ap2PBalance3, _ := ap2.getPendingBalance(addr3)
 	require.Equal(big.NewInt(50).Uint64(), ap2PBalance3.Uint64())
 	// Let ap1 be BP's actpool
-	pickedActs := ap1.PickActs()
+	pickedActs := ap1.PickActs(PickBudget{})
 	// ap1 commits update of accounts to trie
 	sf := bc.GetFactory()
 	require.NotNil(sf)
@@ -697,7 +1176,7 @@ func TestActPool_Reset(t *testing.T) {
 	ap2PBalance3, _ = ap2.getPendingBalance(addr3)
 	require.Equal(big.NewInt(180).Uint64(), ap2PBalance3.Uint64())
 	// Let ap2 be BP's actpool
-	pickedActs = ap2.PickActs()
+	pickedActs = ap2.PickActs(PickBudget{})
 	// ap2 commits update of accounts to trie
 	ws, err = sf.NewWorkingSet()
 	require.NoError(err)
@@ -806,7 +1285,7 @@ func TestActPool_Reset(t *testing.T) {
 	ap1PBalance5, _ := ap1.getPendingBalance(addr5)
 	require.Equal(big.NewInt(10).Uint64(), ap1PBalance5.Uint64())
 	// Let ap1 be BP's actpool
-	pickedActs = ap1.PickActs()
+	pickedActs = ap1.PickActs(PickBudget{})
 	// ap1 commits update of accounts to trie
 	ws, err = sf.NewWorkingSet()
 	require.NoError(err)
@@ -930,6 +1409,131 @@ func TestActPool_GetPendingNonce(t *testing.T) {
 	require.Equal(uint64(2), nonce)
 }
 
+func TestActPool_HandleBlockRevert(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	// The pending action of the same nonce as the reverted one is invalidated
+	conflict2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(5), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	gb := action.GrantRewardBuilder{}
+	grant := gb.SetRewardType(action.BlockReward).Build()
+	eb := action.EnvelopeBuilder{}
+	elp := eb.SetNonce(0).SetGasPrice(big.NewInt(0)).SetGasLimit(grant.GasLimit()).SetAction(&grant).Build()
+	selp, err := action.Sign(elp, testaddress.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+
+	// The confirmed nonce is 0 in the state, as if the block has been reverted
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(conflict2))
+	require.NoError(ap.Add(tsf3))
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf1, tsf2, selp).
+		SignAndBuild(testaddress.Keyinfo["producer"].PubKey, testaddress.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.NoError(ap.HandleBlockRevert(&blk))
+
+	require.Equal(uint64(3), ap.GetSize())
+	require.Equal([]action.SealedEnvelope{tsf1, tsf2, tsf3}, ap.PendingActionMap()[addr1])
+	_, err = ap.GetActionByHash(conflict2.Hash())
+	require.Error(err)
+	_, err = ap.GetActionByHash(selp.Hash())
+	require.Error(err)
+	state, err := ap.PendingState(addr1)
+	require.NoError(err)
+	require.Equal(uint64(4), state.PendingNonce)
+	require.Equal(big.NewInt(40), state.Balance)
+
+	// The reverted actions are checked by the admission hooks as the actions received from the network
+	require.NoError(ap.RegisterAdmissionHook(MinGasPrice(big.NewInt(1))))
+	for _, act := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+		ap.removeFromPool(act.Hash(), act)
+	}
+	ap.accountActs = make(map[string]ActQueue)
+	require.NoError(ap.HandleBlockRevert(&blk))
+	require.Equal(uint64(0), ap.GetSize())
+	require.Equal([]action.SealedEnvelope{tsf1, tsf2}, ap.reverted)
+}
+
+func TestActPool_PendingState(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(100))
+	require.NoError(err)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, uint64(4), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf4))
+
+	// The queued action is not applied
+	state, err := ap.PendingState(addr1)
+	require.NoError(err)
+	require.Equal(uint64(3), state.PendingNonce)
+	require.Equal(big.NewInt(70), state.Balance)
+
+	// The account without any action in pool has the confirmed state
+	state, err = ap.PendingState(addr2)
+	require.NoError(err)
+	require.Equal(uint64(1), state.PendingNonce)
+	require.Equal(big.NewInt(100), state.Balance)
+
+	// The returned balance is a copy
+	state, err = ap.PendingState(addr1)
+	require.NoError(err)
+	state.Balance.SetInt64(0)
+	state, err = ap.PendingState(addr1)
+	require.NoError(err)
+	require.Equal(big.NewInt(70), state.Balance)
+}
+
 func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	require := require.New(t)
 	genesisCfg := genesis.Default
@@ -974,6 +1578,57 @@ func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	require.Equal([]action.SealedEnvelope{tsf1, tsf3, vote4}, acts)
 }
 
+func TestActPool_Stats(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(100))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+
+	tsf1, err := testutil.SignedTransfer(addr1, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr1, priKey2, uint64(2), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf3))
+	require.NoError(ap.Add(tsf4))
+
+	stats := ap.Stats()
+	require.Equal(uint64(1), stats.NumExecutable)
+	require.Equal(uint64(2), stats.NumQueued)
+	require.Equal(apConfig.MaxNumActsPerPool, stats.Capacity)
+	require.Equal([]action.SealedEnvelope{tsf3}, ap.GetQueuedActs(addr1))
+	require.Equal([]action.SealedEnvelope{tsf4}, ap.GetQueuedActs(addr2))
+	require.Empty(ap.GetQueuedActs(addr3))
+
+	// Only the executable actions are picked
+	require.Equal([]action.SealedEnvelope{tsf1}, ap.PickActs(PickBudget{}))
+
+	// The queued action becomes executable once the gap is filled
+	tsf2, err := testutil.SignedTransfer(addr1, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf2))
+	stats = ap.Stats()
+	require.Equal(uint64(3), stats.NumExecutable)
+	require.Equal(uint64(1), stats.NumQueued)
+	require.Empty(ap.GetQueuedActs(addr1))
+}
+
 func TestActPool_GetActionByHash(t *testing.T) {
 	require := require.New(t)
 	genesisCfg := genesis.Default
@@ -1106,3 +1761,19 @@ func getActPoolCfg() config.ActPool {
 		MaxNumActsPerAcct: maxNumActsPerAcct,
 	}
 }
+
+func TestGroupBySender(t *testing.T) {
+	require := require.New(t)
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(10000), big.NewInt(0))
+	require.NoError(err)
+	actionMap, err := GroupBySender([]action.SealedEnvelope{tsf1, tsf2, tsf3})
+	require.NoError(err)
+	require.Equal(map[string][]action.SealedEnvelope{
+		addr1: {tsf1, tsf3},
+		addr2: {tsf2},
+	}, actionMap)
+}
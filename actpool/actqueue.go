@@ -14,13 +14,28 @@ import (
 
 	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/iotexproject/iotex-core/action"
 )
 
+var evictionMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_actpool_evicted_actions",
+		Help: "Actions evicted from the actpool on time out",
+	},
+	[]string{"reason"},
+)
+
+func init() {
+	prometheus.MustRegister(evictionMtc)
+}
+
 type nonceWithTTL struct {
 	nonce    uint64
 	deadline time.Time
+	// gapDeadline is the time before which the gap in front of the nonce should be filled
+	gapDeadline time.Time
 }
 
 type noncePriorityQueue []nonceWithTTL
@@ -49,6 +64,8 @@ func (h *noncePriorityQueue) Pop() interface{} {
 type ActQueue interface {
 	Overlaps(action.SealedEnvelope) bool
 	Put(action.SealedEnvelope) error
+	Get(uint64) (action.SealedEnvelope, bool)
+	Replace(action.SealedEnvelope) (action.SealedEnvelope, error)
 	FilterNonce(uint64) []action.SealedEnvelope
 	SetStartNonce(uint64)
 	StartNonce() uint64
@@ -60,7 +77,10 @@ type ActQueue interface {
 	Len() int
 	Empty() bool
 	PendingActs() []action.SealedEnvelope
+	QueuedActs() []action.SealedEnvelope
 	AllActs() []action.SealedEnvelope
+	LastAct() (action.SealedEnvelope, bool)
+	PopLastAct() (action.SealedEnvelope, bool)
 }
 
 // actQueue is a queue of actions from an account
@@ -77,6 +97,8 @@ type actQueue struct {
 	pendingBalance *big.Int
 	clock          clock.Clock
 	ttl            time.Duration
+	// gapTTL is how long an action beyond the pending nonce waits for the preceding nonces. 0 means no limit
+	gapTTL time.Duration
 }
 
 // ActQueueOption is the option for actQueue.
@@ -94,6 +116,7 @@ func NewActQueue(ops ...ActQueueOption) ActQueue {
 		pendingBalance: big.NewInt(0),
 		clock:          clock.New(),
 		ttl:            0,
+		gapTTL:         0,
 	}
 	for _, op := range ops {
 		op.SetActQueueOption(aq)
@@ -113,11 +136,29 @@ func (q *actQueue) Put(act action.SealedEnvelope) error {
 	if _, exist := q.items[nonce]; exist {
 		return errors.Wrapf(action.ErrNonce, "duplicate nonce")
 	}
-	heap.Push(&q.index, nonceWithTTL{nonce: nonce, deadline: q.clock.Now().Add(q.ttl)})
+	now := q.clock.Now()
+	heap.Push(&q.index, nonceWithTTL{nonce: nonce, deadline: now.Add(q.ttl), gapDeadline: now.Add(q.gapTTL)})
 	q.items[nonce] = act
 	return nil
 }
 
+// Get returns the action of the given nonce
+func (q *actQueue) Get(nonce uint64) (action.SealedEnvelope, bool) {
+	act, exist := q.items[nonce]
+	return act, exist
+}
+
+// Replace replaces the action of the same nonce with the given one, and returns the replaced action
+func (q *actQueue) Replace(act action.SealedEnvelope) (action.SealedEnvelope, error) {
+	nonce := act.Nonce()
+	old, exist := q.items[nonce]
+	if !exist {
+		return action.SealedEnvelope{}, errors.Wrapf(action.ErrNonce, "nonce %d does not exist", nonce)
+	}
+	q.items[nonce] = act
+	return old, nil
+}
+
 // FilterNonce removes all actions from the map with a nonce lower than the given threshold
 func (q *actQueue) FilterNonce(threshold uint64) []action.SealedEnvelope {
 	var removed []action.SealedEnvelope
@@ -140,6 +181,27 @@ func (q *actQueue) cleanTimeout() []action.SealedEnvelope {
 			q.index = append(q.index[:i], q.index[i+1:]...)
 		}
 	}
+	evictionMtc.WithLabelValues("expiry").Add(float64(len(removedFromQueue)))
+	return removedFromQueue
+}
+
+// cleanGapTimeout removes the actions beyond the pending nonce, which have waited for the preceding nonces longer than
+// the gap TTL
+func (q *actQueue) cleanGapTimeout() []action.SealedEnvelope {
+	removedFromQueue := make([]action.SealedEnvelope, 0)
+	now := q.clock.Now()
+	index := q.index[:0]
+	for _, n := range q.index {
+		if n.nonce > q.pendingNonce && now.After(n.gapDeadline) {
+			removedFromQueue = append(removedFromQueue, q.items[n.nonce])
+			delete(q.items, n.nonce)
+			continue
+		}
+		index = append(index, n)
+	}
+	q.index = index
+	heap.Init(&q.index)
+	evictionMtc.WithLabelValues("gap").Add(float64(len(removedFromQueue)))
 	return removedFromQueue
 }
 
@@ -163,6 +225,10 @@ func (q *actQueue) UpdateQueue(nonce uint64) []action.SealedEnvelope {
 		}
 	}
 	q.pendingNonce = nonce
+	// Then remove the actions stuck behind a nonce gap for too long
+	if q.gapTTL != 0 {
+		removedFromQueue = append(removedFromQueue, q.cleanGapTimeout()...)
+	}
 
 	// Find the index of new pending nonce within the queue
 	sort.Sort(q.index)
@@ -250,6 +316,29 @@ func (q *actQueue) PendingActs() []action.SealedEnvelope {
 	return acts
 }
 
+// QueuedActs returns the nonce-sorted actions waiting for the preceding nonces, which can't be executed until the gap
+// is filled
+func (q *actQueue) QueuedActs() []action.SealedEnvelope {
+	acts := make([]action.SealedEnvelope, 0)
+	if q.Len() == 0 {
+		return acts
+	}
+	nonce := q.startNonce
+	for {
+		if _, exist := q.items[nonce]; !exist {
+			break
+		}
+		nonce++
+	}
+	sort.Sort(q.index)
+	for _, n := range q.index {
+		if n.nonce > nonce {
+			acts = append(acts, q.items[n.nonce])
+		}
+	}
+	return acts
+}
+
 // AllActs returns all the actions currently in queue
 func (q *actQueue) AllActs() []action.SealedEnvelope {
 	acts := make([]action.SealedEnvelope, 0, len(q.items))
@@ -263,6 +352,35 @@ func (q *actQueue) AllActs() []action.SealedEnvelope {
 	return acts
 }
 
+// LastAct returns the action of the highest nonce in queue
+func (q *actQueue) LastAct() (action.SealedEnvelope, bool) {
+	if q.Len() == 0 {
+		return action.SealedEnvelope{}, false
+	}
+	sort.Sort(q.index)
+	return q.items[q.index[q.index.Len()-1].nonce], true
+}
+
+// PopLastAct removes the action of the highest nonce from queue. If the action is pending, its cost is returned to the
+// pending balance
+func (q *actQueue) PopLastAct() (action.SealedEnvelope, bool) {
+	if q.Len() == 0 {
+		return action.SealedEnvelope{}, false
+	}
+	sort.Sort(q.index)
+	nonce := q.index[q.index.Len()-1].nonce
+	act := q.items[nonce]
+	// A sorted index is still a valid heap after removing its last element
+	q.index = q.index[:q.index.Len()-1]
+	delete(q.items, nonce)
+	if nonce < q.pendingNonce {
+		cost, _ := act.Cost()
+		q.pendingBalance.Add(q.pendingBalance, cost)
+		q.pendingNonce = nonce
+	}
+	return act, true
+}
+
 // removeActs removes all the actions starting at idx from queue
 func (q *actQueue) removeActs(idx int) []action.SealedEnvelope {
 	removedFromQueue := make([]action.SealedEnvelope, 0)
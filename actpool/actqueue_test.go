@@ -74,6 +74,63 @@ func TestActQueuePut(t *testing.T) {
 	require.NotNil(err)
 }
 
+func TestActQueueReplace(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue().(*actQueue)
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	_, err = q.Replace(tsf1)
+	require.Error(err)
+	require.NoError(q.Put(tsf1))
+	act, exist := q.Get(1)
+	require.True(exist)
+	require.Equal(tsf1, act)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	old, err := q.Replace(tsf2)
+	require.NoError(err)
+	require.Equal(tsf1, old)
+	act, exist = q.Get(1)
+	require.True(exist)
+	require.Equal(tsf2, act)
+	require.Equal(1, q.Len())
+	_, exist = q.Get(2)
+	require.False(exist)
+}
+
+func TestActQueuePopLastAct(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue().(*actQueue)
+	_, ok := q.LastAct()
+	require.False(ok)
+	_, ok = q.PopLastAct()
+	require.False(ok)
+
+	q.pendingBalance = big.NewInt(1000)
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 2, big.NewInt(200), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(q.Put(tsf2))
+	require.NoError(q.Put(tsf1))
+	q.UpdateQueue(q.pendingNonce)
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(700), q.pendingBalance)
+
+	last, ok := q.LastAct()
+	require.True(ok)
+	require.Equal(tsf2, last)
+	last, ok = q.PopLastAct()
+	require.True(ok)
+	require.Equal(tsf2, last)
+	require.Equal(1, q.Len())
+	require.Equal(uint64(2), q.pendingNonce)
+	require.Equal(big.NewInt(900), q.pendingBalance)
+	last, ok = q.LastAct()
+	require.True(ok)
+	require.Equal(tsf1, last)
+}
+
 func TestActQueueFilterNonce(t *testing.T) {
 	require := require.New(t)
 	q := NewActQueue().(*actQueue)
@@ -154,6 +211,31 @@ func TestActQueuePendingActs(t *testing.T) {
 	require.Equal([]action.SealedEnvelope{vote1, tsf2}, actions)
 }
 
+func TestActQueueQueuedActs(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue().(*actQueue)
+	require.Empty(q.QueuedActs())
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 3, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, 7, big.NewInt(1000), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, 5, big.NewInt(10000), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	for _, act := range []action.SealedEnvelope{tsf1, tsf2, tsf3, tsf4} {
+		require.NoError(q.Put(act))
+	}
+	q.startNonce = 2
+	require.Equal([]action.SealedEnvelope{tsf1, tsf2}, q.PendingActs())
+	require.Equal([]action.SealedEnvelope{tsf4, tsf3}, q.QueuedActs())
+
+	// All the actions are queued if the start nonce is missing
+	q.startNonce = 1
+	require.Empty(q.PendingActs())
+	require.Equal([]action.SealedEnvelope{tsf1, tsf2, tsf4, tsf3}, q.QueuedActs())
+}
+
 func TestActQueueAllActs(t *testing.T) {
 	require := require.New(t)
 	q := NewActQueue().(*actQueue)
@@ -225,3 +307,34 @@ func TestActQueueTimeOutAction(t *testing.T) {
 	q.(*actQueue).cleanTimeout()
 	assert.Equal(t, 1, q.Len())
 }
+
+func TestActQueueGapTimeOutAction(t *testing.T) {
+	require := require.New(t)
+	c := clock.NewMock()
+	q := NewActQueue(WithClock(c), WithGapTimeOut(3*time.Minute))
+	q.SetPendingBalance(big.NewInt(1000))
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, 3, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, 4, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+	require.NoError(err)
+
+	require.NoError(q.Put(tsf1))
+	require.NoError(q.Put(tsf3))
+	require.Empty(q.UpdateQueue(1))
+	require.Equal(uint64(2), q.PendingNonce())
+	c.Add(2 * time.Minute)
+	require.NoError(q.Put(tsf4))
+	require.Empty(q.UpdateQueue(q.PendingNonce()))
+	require.Equal(3, q.Len())
+
+	// The action with nonce 3 has waited for nonce 2 too long
+	c.Add(2 * time.Minute)
+	require.Equal([]action.SealedEnvelope{tsf3}, q.UpdateQueue(q.PendingNonce()))
+	require.Equal([]action.SealedEnvelope{tsf1, tsf4}, q.AllActs())
+	c.Add(2 * time.Minute)
+	require.Equal([]action.SealedEnvelope{tsf4}, q.UpdateQueue(q.PendingNonce()))
+	// The pending action is kept
+	require.Equal([]action.SealedEnvelope{tsf1}, q.AllActs())
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+// ErrRejectedByPolicy indicates that an action is rejected by an admission hook
+var ErrRejectedByPolicy = errors.New("action is rejected by admission policy")
+
+// AdmissionHook decides whether an action is admitted into actpool, which allows the operator to enforce custom
+// policies on top of the protocol validation. It's called before the validators, so it should be cheap
+type AdmissionHook interface {
+	// Admit returns an error if the action from the sender should be rejected
+	Admit(sender string, act action.SealedEnvelope) error
+}
+
+// AdmissionHookFunc is an adapter to use a function as an admission hook
+type AdmissionHookFunc func(sender string, act action.SealedEnvelope) error
+
+// Admit calls the function
+func (f AdmissionHookFunc) Admit(sender string, act action.SealedEnvelope) error {
+	return f(sender, act)
+}
+
+// SenderBlacklist returns an admission hook rejecting the actions from the given senders
+func SenderBlacklist(senders ...string) AdmissionHook {
+	blocked := make(map[string]struct{}, len(senders))
+	for _, sender := range senders {
+		blocked[sender] = struct{}{}
+	}
+	return AdmissionHookFunc(func(sender string, _ action.SealedEnvelope) error {
+		if _, ok := blocked[sender]; ok {
+			return errors.Wrapf(ErrRejectedByPolicy, "sender %s is blocked", sender)
+		}
+		return nil
+	})
+}
+
+// MinGasPrice returns an admission hook rejecting the actions of a gas price lower than the given one
+func MinGasPrice(price *big.Int) AdmissionHook {
+	minPrice := new(big.Int).Set(price)
+	return AdmissionHookFunc(func(_ string, act action.SealedEnvelope) error {
+		if act.GasPrice() == nil || act.GasPrice().Cmp(minPrice) < 0 {
+			return errors.Wrapf(ErrRejectedByPolicy, "gas price %s is lower than %s", act.GasPrice(), minPrice)
+		}
+		return nil
+	})
+}
+
+// ContractBlacklist returns an admission hook rejecting the executions of the given contracts
+func ContractBlacklist(contracts ...string) AdmissionHook {
+	blocked := make(map[string]struct{}, len(contracts))
+	for _, contract := range contracts {
+		blocked[contract] = struct{}{}
+	}
+	return AdmissionHookFunc(func(_ string, act action.SealedEnvelope) error {
+		exec, ok := act.Action().(*action.Execution)
+		if !ok {
+			return nil
+		}
+		if _, ok := blocked[exec.Contract()]; ok {
+			return errors.Wrapf(ErrRejectedByPolicy, "contract %s is blocked", exec.Contract())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestAdmissionHooks(t *testing.T) {
+	require := require.New(t)
+	tsf, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	exec, err := testutil.SignedExecution(addr3, priKey1, uint64(1), big.NewInt(0), uint64(100000), big.NewInt(10), []byte{})
+	require.NoError(err)
+
+	hook := SenderBlacklist(addr2)
+	require.NoError(hook.Admit(addr1, tsf))
+	require.Equal(ErrRejectedByPolicy, errors.Cause(hook.Admit(addr2, tsf)))
+
+	hook = MinGasPrice(big.NewInt(10))
+	require.NoError(hook.Admit(addr1, tsf))
+	hook = MinGasPrice(big.NewInt(11))
+	require.Equal(ErrRejectedByPolicy, errors.Cause(hook.Admit(addr1, tsf)))
+
+	// Only the executions of the contract are rejected, but not the transfers to it
+	hook = ContractBlacklist(addr3)
+	require.Equal(ErrRejectedByPolicy, errors.Cause(hook.Admit(addr1, exec)))
+	hook = ContractBlacklist(addr2)
+	require.NoError(hook.Admit(addr1, exec))
+	require.NoError(hook.Admit(addr1, tsf))
+}
+
+func TestActPool_AdmissionHook(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(100))
+	require.NoError(err)
+	ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+	require.Error(ap.RegisterAdmissionHook(nil))
+	require.NoError(ap.RegisterAdmissionHook(SenderBlacklist(addr2)))
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	err = ap.Add(tsf2)
+	require.Equal(ErrRejectedByPolicy, errors.Cause(err))
+	require.Equal(uint64(1), ap.GetSize())
+}
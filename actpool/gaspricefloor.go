@@ -0,0 +1,101 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+)
+
+var gasPriceFloorMtc = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "iotex_actpool_gas_price_floor",
+		Help: "Min gas price of the actions accepted by actpool",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(gasPriceFloorMtc)
+}
+
+// gasPriceFloor is the admission hook rejecting the actions of a gas price lower than the floor, which is adjusted
+// with the utilization of the pool if the dynamic mode is enabled. It has its own lock as the admission hooks may be
+// called without locking actpool
+type gasPriceFloor struct {
+	mutex   sync.RWMutex
+	cfg     config.DynamicGasPriceFloor
+	minimum *big.Int
+	floor   *big.Int
+}
+
+func newGasPriceFloor(minimum *big.Int, cfg config.DynamicGasPriceFloor) *gasPriceFloor {
+	f := &gasPriceFloor{
+		cfg:     cfg,
+		minimum: new(big.Int).Set(minimum),
+		floor:   new(big.Int).Set(minimum),
+	}
+	f.updateMetric()
+	return f
+}
+
+// Admit rejects the action if its gas price is lower than the floor
+func (f *gasPriceFloor) Admit(_ string, act action.SealedEnvelope) error {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	if act.GasPrice() == nil || act.GasPrice().Cmp(f.floor) < 0 {
+		return errors.Wrapf(ErrRejectedByPolicy, "gas price %s is lower than %s", act.GasPrice(), f.floor)
+	}
+	return nil
+}
+
+// Floor returns the current floor
+func (f *gasPriceFloor) Floor() *big.Int {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return new(big.Int).Set(f.floor)
+}
+
+// adjust raises the floor by a step if the utilization of the pool in percentage is high, and lowers it if it's low,
+// but never below the minimum
+func (f *gasPriceFloor) adjust(utilizationPct uint64) {
+	if !f.cfg.Enabled {
+		return
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	switch {
+	case utilizationPct >= f.cfg.HighUtilizationPct:
+		raised := new(big.Int).Mul(f.floor, big.NewInt(int64(100+f.cfg.StepPct)))
+		raised.Div(raised, big.NewInt(100))
+		// Make sure a small or zero floor is raised as well
+		if raised.Cmp(f.floor) <= 0 {
+			raised.Add(f.floor, big.NewInt(1))
+		}
+		f.floor = raised
+	case utilizationPct <= f.cfg.LowUtilizationPct:
+		lowered := new(big.Int).Mul(f.floor, big.NewInt(int64(100-f.cfg.StepPct)))
+		lowered.Div(lowered, big.NewInt(100))
+		if lowered.Cmp(f.minimum) < 0 {
+			lowered.Set(f.minimum)
+		}
+		f.floor = lowered
+	default:
+		return
+	}
+	f.updateMetric()
+}
+
+func (f *gasPriceFloor) updateMetric() {
+	floor, _ := new(big.Float).SetInt(f.floor).Float64()
+	gasPriceFloorMtc.Set(floor)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestGasPriceFloor(t *testing.T) {
+	require := require.New(t)
+	cfg := config.DynamicGasPriceFloor{
+		Enabled:            true,
+		HighUtilizationPct: 80,
+		LowUtilizationPct:  20,
+		StepPct:            50,
+	}
+	f := newGasPriceFloor(big.NewInt(0), cfg)
+	require.Equal(big.NewInt(0), f.Floor())
+
+	// A zero floor is raised as well
+	f.adjust(80)
+	require.Equal(big.NewInt(1), f.Floor())
+	f.adjust(90)
+	f.adjust(100)
+	require.Equal(big.NewInt(3), f.Floor())
+	f.adjust(50)
+	require.Equal(big.NewInt(3), f.Floor())
+	f.adjust(20)
+	require.Equal(big.NewInt(1), f.Floor())
+	f.adjust(0)
+	f.adjust(0)
+	require.Equal(big.NewInt(0), f.Floor())
+
+	// The floor never goes below the minimum
+	f = newGasPriceFloor(big.NewInt(10), cfg)
+	f.adjust(100)
+	require.Equal(big.NewInt(15), f.Floor())
+	f.adjust(0)
+	f.adjust(0)
+	require.Equal(big.NewInt(10), f.Floor())
+
+	// The floor is fixed if the dynamic mode is disabled
+	cfg.Enabled = false
+	f = newGasPriceFloor(big.NewInt(10), cfg)
+	f.adjust(100)
+	require.Equal(big.NewInt(10), f.Floor())
+}
+
+func TestActPool_GasPriceFloor(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(10000000))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 2
+	apConfig.MinGasPriceStr = "1"
+	apConfig.DynamicGasPriceFloor = config.DynamicGasPriceFloor{
+		Enabled:            true,
+		HighUtilizationPct: 100,
+		LowUtilizationPct:  0,
+		StepPct:            10,
+	}
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(1), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.Equal(ErrRejectedByPolicy, errors.Cause(ap.Add(tsf1)))
+	tsf1, err = testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(1), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(1), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+
+	// The floor is raised once the pool is full, and lowered back once it drains
+	ap.Reset()
+	require.Equal(big.NewInt(2), ap.Stats().GasPriceFloor)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(1), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	require.Equal(ErrRejectedByPolicy, errors.Cause(ap.Add(tsf3)))
+	ws, err := bc.GetFactory().NewWorkingSet()
+	require.NoError(err)
+	acct, err := util.LoadOrCreateAccount(ws, addr1, big.NewInt(0))
+	require.NoError(err)
+	acct.Nonce = 2
+	require.NoError(util.StoreAccount(ws, addr1, acct))
+	require.NoError(bc.GetFactory().Commit(ws))
+	ap.Reset()
+	require.Equal(uint64(0), ap.GetSize())
+	require.Equal(big.NewInt(1), ap.Stats().GasPriceFloor)
+
+	// Invalid min gas price
+	apConfig.MinGasPriceStr = "x"
+	_, err = NewActPool(bc, apConfig)
+	require.Error(err)
+}
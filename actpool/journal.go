@@ -0,0 +1,147 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// journal keeps the actions accepted by actpool in a local file, so that they could be reloaded after the node
+// restarts. Each action is stored as its length followed by its serialized protobuf message
+type journal struct {
+	path   string
+	writer *os.File
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads the actions in the journal, and calls add on each of them. The actions failing to be added are skipped
+func (j *journal) load(add func(action.SealedEnvelope) error) (int, int, error) {
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to open journal %s", j.path)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var total, dropped int
+	for {
+		act, err := readJournalAction(reader)
+		if err == io.EOF {
+			return total, dropped, nil
+		}
+		if err != nil {
+			return total, dropped, err
+		}
+		total++
+		if err := add(act); err != nil {
+			dropped++
+		}
+	}
+}
+
+// insert appends the action to the journal
+func (j *journal) insert(act action.SealedEnvelope) error {
+	if j.writer == nil {
+		return errors.New("journal is not open")
+	}
+	return writeJournalAction(j.writer, act)
+}
+
+// rotate regenerates the journal with the given actions, and opens it for appending
+func (j *journal) rotate(acts []action.SealedEnvelope) error {
+	if err := j.close(); err != nil {
+		return err
+	}
+	tmpPath := j.path + ".new"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create journal %s", tmpPath)
+	}
+	writer := bufio.NewWriter(file)
+	for _, act := range acts {
+		if err := writeJournalAction(writer, act); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to flush journal")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "failed to close journal")
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return errors.Wrapf(err, "failed to replace journal %s", j.path)
+	}
+	j.writer, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open journal %s", j.path)
+	}
+	return nil
+}
+
+// close closes the journal
+func (j *journal) close() error {
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return err
+}
+
+func writeJournalAction(w io.Writer, act action.SealedEnvelope) error {
+	data, err := proto.Marshal(act.Proto())
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize action")
+	}
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+	if _, err := w.Write(append(size[:], data...)); err != nil {
+		return errors.Wrap(err, "failed to write action into journal")
+	}
+	return nil
+}
+
+func readJournalAction(r io.Reader) (action.SealedEnvelope, error) {
+	var act action.SealedEnvelope
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		if err == io.EOF {
+			return act, err
+		}
+		return act, errors.Wrap(err, "failed to read journal")
+	}
+	data := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return act, errors.Wrap(err, "failed to read journal")
+	}
+	actPb := &iotextypes.Action{}
+	if err := proto.Unmarshal(data, actPb); err != nil {
+		return act, errors.Wrap(err, "failed to deserialize action")
+	}
+	if err := act.LoadProto(actPb); err != nil {
+		return act, errors.Wrap(err, "failed to load action")
+	}
+	return act, nil
+}
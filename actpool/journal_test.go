@@ -0,0 +1,74 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestJournal(t *testing.T) {
+	require := require.New(t)
+	file, err := ioutil.TempFile("", "actpool-journal")
+	require.NoError(err)
+	path := file.Name()
+	require.NoError(file.Close())
+	require.NoError(os.Remove(path))
+	defer os.Remove(path)
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(10), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 2, big.NewInt(20), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, 3, big.NewInt(30), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+
+	// Nothing to load before the journal is created
+	j := newJournal(path)
+	total, dropped, err := j.load(func(action.SealedEnvelope) error { return nil })
+	require.NoError(err)
+	require.Zero(total)
+	require.Zero(dropped)
+	require.Error(j.insert(tsf1))
+
+	require.NoError(j.rotate([]action.SealedEnvelope{tsf1}))
+	require.NoError(j.insert(tsf2))
+	require.NoError(j.insert(tsf3))
+	require.NoError(j.close())
+
+	var loaded []action.SealedEnvelope
+	add := func(act action.SealedEnvelope) error {
+		if act.Nonce() == 2 {
+			return errors.New("invalid action")
+		}
+		loaded = append(loaded, act)
+		return nil
+	}
+	total, dropped, err = newJournal(path).load(add)
+	require.NoError(err)
+	require.Equal(3, total)
+	require.Equal(1, dropped)
+	require.Equal([]action.SealedEnvelope{tsf1, tsf3}, loaded)
+
+	// Rotation drops the actions not given
+	j = newJournal(path)
+	require.NoError(j.rotate([]action.SealedEnvelope{tsf3}))
+	require.NoError(j.close())
+	loaded = nil
+	total, _, err = newJournal(path).load(add)
+	require.NoError(err)
+	require.Equal(1, total)
+	require.Equal([]action.SealedEnvelope{tsf3}, loaded)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+var (
+	poolSizeMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_actpool_size",
+			Help: "Size of the actpool in number of actions or in bytes",
+		},
+		[]string{"type"},
+	)
+
+	poolUtilizationMtc = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iotex_actpool_utilization",
+			Help: "Percentage of the actpool capacity in use",
+		},
+	)
+
+	rejectionMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_actpool_rejected_actions",
+			Help: "Actions rejected by the actpool",
+		},
+		[]string{"reason"},
+	)
+
+	latencyMtc = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "iotex_actpool_latency",
+			Help:    "Latency of the actpool operations in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 2, 16),
+		},
+		[]string{"operation"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(poolSizeMtc)
+	prometheus.MustRegister(poolUtilizationMtc)
+	prometheus.MustRegister(rejectionMtc)
+	prometheus.MustRegister(latencyMtc)
+}
+
+// errExistedAction indicates that the action already exists in pool
+var errExistedAction = errors.New("existed action")
+
+// rejectionReason returns the reason label of the error of adding an action
+func rejectionReason(err error) string {
+	switch errors.Cause(err) {
+	case errExistedAction:
+		return "existed"
+	case ErrRejectedByPolicy:
+		return "policy"
+	case action.ErrNonce:
+		return "nonce"
+	case action.ErrBalance:
+		return "balance"
+	case action.ErrActPool:
+		return "full"
+	default:
+		return "invalid"
+	}
+}
+
+// observeRejection counts the action rejected with the error, if any
+func observeRejection(err error) {
+	if err != nil {
+		rejectionMtc.WithLabelValues(rejectionReason(err)).Inc()
+	}
+}
+
+// observeLatency records the latency of the operation started at the given time
+func observeLatency(operation string, start time.Time) {
+	latencyMtc.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
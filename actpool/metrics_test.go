@@ -0,0 +1,89 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestRejectionReason(t *testing.T) {
+	require := require.New(t)
+	require.Equal("existed", rejectionReason(errors.Wrap(errExistedAction, "")))
+	require.Equal("policy", rejectionReason(errors.Wrap(ErrRejectedByPolicy, "")))
+	require.Equal("nonce", rejectionReason(errors.Wrap(action.ErrNonce, "")))
+	require.Equal("balance", rejectionReason(errors.Wrap(action.ErrBalance, "")))
+	require.Equal("full", rejectionReason(errors.Wrap(action.ErrActPool, "")))
+	require.Equal("invalid", rejectionReason(errors.New("invalid signature")))
+}
+
+func TestActPool_Metrics(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 4
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	gauge := func(m interface{ Write(*dto.Metric) error }) float64 {
+		var metric dto.Metric
+		require.NoError(m.Write(&metric))
+		return metric.GetGauge().GetValue()
+	}
+	counter := func(reason string) float64 {
+		var metric dto.Metric
+		require.NoError(rejectionMtc.WithLabelValues(reason).Write(&metric))
+		return metric.GetCounter().GetValue()
+	}
+	existed, nonce := counter("existed"), counter("nonce")
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.Equal(errExistedAction, errors.Cause(ap.Add(tsf1)))
+	errs := ap.AddBatch([]action.SealedEnvelope{tsf2, tsf1})
+	require.NoError(errs[0])
+	require.Equal(errExistedAction, errors.Cause(errs[1]))
+	tsf0, err := testutil.SignedTransfer(addr2, priKey1, uint64(0), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.Equal(action.ErrNonce, errors.Cause(ap.Add(tsf0)))
+
+	require.Equal(existed+2, counter("existed"))
+	require.Equal(nonce+1, counter("nonce"))
+	require.Equal(float64(2), gauge(poolSizeMtc.WithLabelValues("actions")))
+	require.Equal(float64(actSize(tsf1)+actSize(tsf2)), gauge(poolSizeMtc.WithLabelValues("bytes")))
+	require.Equal(float64(50), gauge(poolUtilizationMtc))
+}
@@ -29,3 +29,12 @@ func WithTimeOut(ttl time.Duration) interface{ ActQueueOption } {
 }
 
 func (o *ttlOption) SetActQueueOption(aq *actQueue) { aq.ttl = o.ttl }
+
+type gapTTLOption struct{ ttl time.Duration }
+
+// WithGapTimeOut returns an option to overwrite the time out of the actions waiting for the preceding nonces.
+func WithGapTimeOut(ttl time.Duration) interface{ ActQueueOption } {
+	return &gapTTLOption{ttl}
+}
+
+func (o *gapTTLOption) SetActQueueOption(aq *actQueue) { aq.gapTTL = o.ttl }
@@ -0,0 +1,127 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// Rebroadcaster broadcasts the locally submitted actions again if they are still in pool after a number of blocks, so
+// that they won't get stuck when the initial broadcast is missed by the delegates. It should be added as a subscriber
+// of both actpool and blockchain
+type Rebroadcaster struct {
+	mutex       sync.Mutex
+	bc          blockchain.Blockchain
+	ap          ActPool
+	afterBlocks uint64
+	broadcast   func(proto.Message) error
+	// local maps the hash of a locally submitted action to the height at which it is broadcast last time
+	local map[hash.Hash256]*localAction
+}
+
+type localAction struct {
+	act    action.SealedEnvelope
+	height uint64
+}
+
+// NewRebroadcaster creates a new rebroadcaster
+func NewRebroadcaster(
+	bc blockchain.Blockchain,
+	ap ActPool,
+	afterBlocks uint64,
+	broadcast func(proto.Message) error,
+) *Rebroadcaster {
+	return &Rebroadcaster{
+		bc:          bc,
+		ap:          ap,
+		afterBlocks: afterBlocks,
+		broadcast:   broadcast,
+		local:       make(map[hash.Hash256]*localAction),
+	}
+}
+
+// Track starts tracking an action submitted to this node
+func (r *Rebroadcaster) Track(actPb *iotextypes.Action) error {
+	var act action.SealedEnvelope
+	if err := act.LoadProto(actPb); err != nil {
+		return errors.Wrap(err, "failed to load action")
+	}
+	height := r.bc.TipHeight()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.local[act.Hash()] = &localAction{act: act, height: height}
+	return nil
+}
+
+// HandleActionEvent stops tracking the actions which leave actpool
+func (r *Rebroadcaster) HandleActionEvent(evt ActionEvent) error {
+	if evt.Type != ActionRemoved && evt.Type != ActionConfirmed {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.local, evt.Action.Hash())
+	return nil
+}
+
+// HandleBlock broadcasts again the tracked actions which haven't been included after the given number of blocks
+func (r *Rebroadcaster) HandleBlock(blk *block.Block) error {
+	included := make(map[hash.Hash256]bool, len(blk.Actions))
+	for _, selp := range blk.Actions {
+		included[selp.Hash()] = true
+	}
+
+	r.mutex.Lock()
+	var stuck []action.SealedEnvelope
+	for h, la := range r.local {
+		if included[h] {
+			delete(r.local, h)
+			continue
+		}
+		if blk.Height() < la.height+r.afterBlocks {
+			continue
+		}
+		la.height = blk.Height()
+		stuck = append(stuck, la.act)
+	}
+	r.mutex.Unlock()
+
+	for _, act := range stuck {
+		actHash := act.Hash()
+		// Stop tracking the action never accepted by actpool, e.g., it was rejected as invalid
+		if _, err := r.ap.GetActionByHash(actHash); err != nil {
+			r.mutex.Lock()
+			delete(r.local, actHash)
+			r.mutex.Unlock()
+			continue
+		}
+		if err := r.broadcast(act.Proto()); err != nil {
+			log.L().Warn("Failed to re-broadcast action.", zap.Error(err))
+			continue
+		}
+		log.L().Debug("Re-broadcast stuck action.", log.Hex("actHash", actHash[:]), zap.Uint64("height", blk.Height()))
+	}
+	return nil
+}
+
+// Size returns the number of actions being tracked
+func (r *Rebroadcaster) Size() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.local)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestRebroadcaster(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	var broadcast []*iotextypes.Action
+	r := NewRebroadcaster(bc, ap, 2, func(msg proto.Message) error {
+		broadcast = append(broadcast, msg.(*iotextypes.Action))
+		return nil
+	})
+	require.NoError(ap.AddSubscriber(r))
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	// tsf3 is never accepted by actpool as the sender has no balance
+	tsf3, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	for _, selp := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+		require.NoError(r.Track(selp.Proto()))
+	}
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.Error(ap.Add(tsf3))
+	require.Equal(3, r.Size())
+
+	newBlock := func(height uint64, acts ...action.SealedEnvelope) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(acts...).
+			SignAndBuild(testaddress.Keyinfo["producer"].PubKey, testaddress.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		return &blk
+	}
+
+	// Nothing is broadcast before the given number of blocks
+	require.NoError(r.HandleBlock(newBlock(1)))
+	require.Empty(broadcast)
+
+	// tsf1 is included, tsf2 is broadcast again, and tsf3 is no longer tracked
+	require.NoError(r.HandleBlock(newBlock(2, tsf1)))
+	require.Equal(1, len(broadcast))
+	require.True(proto.Equal(tsf2.Proto(), broadcast[0]))
+	require.Equal(1, r.Size())
+
+	// tsf2 is broadcast again every the given number of blocks
+	require.NoError(r.HandleBlock(newBlock(3)))
+	require.Equal(1, len(broadcast))
+	require.NoError(r.HandleBlock(newBlock(4)))
+	require.Equal(2, len(broadcast))
+
+	// tsf2 leaves actpool
+	require.NoError(r.HandleActionEvent(ActionEvent{Type: ActionConfirmed, Action: tsf2}))
+	require.Zero(r.Size())
+	require.NoError(r.HandleBlock(newBlock(6)))
+	require.Equal(2, len(broadcast))
+}
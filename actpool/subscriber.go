@@ -0,0 +1,51 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import "github.com/iotexproject/iotex-core/action"
+
+// ActionEventType is the type of the change of an action in actpool
+type ActionEventType int
+
+const (
+	// ActionAdded means an action is accepted by actpool
+	ActionAdded ActionEventType = iota
+	// ActionPromoted means an action becomes pending, i.e., it could be picked into the next block
+	ActionPromoted
+	// ActionRemoved means an action is dropped without being committed, e.g., it is replaced, evicted or expired
+	ActionRemoved
+	// ActionConfirmed means an action is removed after it is committed into a block
+	ActionConfirmed
+)
+
+// String returns the name of the action event type
+func (t ActionEventType) String() string {
+	switch t {
+	case ActionAdded:
+		return "added"
+	case ActionPromoted:
+		return "promoted"
+	case ActionRemoved:
+		return "removed"
+	case ActionConfirmed:
+		return "confirmed"
+	default:
+		return "unknown"
+	}
+}
+
+// ActionEvent is a change of an action in actpool
+type ActionEvent struct {
+	Type   ActionEventType
+	Action action.SealedEnvelope
+}
+
+// Subscriber is an interface which will get notified when an action in actpool changes. The events are emitted in
+// order while actpool is locked, so HandleActionEvent should neither block nor call actpool
+type Subscriber interface {
+	HandleActionEvent(ActionEvent) error
+}
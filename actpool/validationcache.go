@@ -0,0 +1,107 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// validationCache keeps the hashes of the actions which have passed the validation, together with the account state
+// of their senders at that time. The actions of a sender are invalidated once its nonce or balance changes. It has its
+// own lock as it's read when minting a block, without locking actpool
+type validationCache struct {
+	mutex   sync.RWMutex
+	senders map[string]*senderValidation
+	acts    map[hash.Hash256]string
+}
+
+// senderValidation is the account state of a sender against which its actions are validated
+type senderValidation struct {
+	nonce   uint64
+	balance *big.Int
+	acts    map[hash.Hash256]struct{}
+}
+
+func newValidationCache() *validationCache {
+	return &validationCache{
+		senders: make(map[string]*senderValidation),
+		acts:    make(map[hash.Hash256]string),
+	}
+}
+
+// add records an action validated against the given account state of its sender
+func (c *validationCache) add(sender string, nonce uint64, balance *big.Int, actHash hash.Hash256) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.update(sender, nonce, balance)
+	c.senders[sender].acts[actHash] = struct{}{}
+	c.acts[actHash] = sender
+}
+
+// contains returns whether the action is validated against the current account state of its sender
+func (c *validationCache) contains(actHash hash.Hash256) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	_, ok := c.acts[actHash]
+	return ok
+}
+
+// remove drops the action from the cache
+func (c *validationCache) remove(actHash hash.Hash256) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	sender, ok := c.acts[actHash]
+	if !ok {
+		return
+	}
+	delete(c.acts, actHash)
+	sv := c.senders[sender]
+	delete(sv.acts, actHash)
+	if len(sv.acts) == 0 {
+		delete(c.senders, sender)
+	}
+}
+
+// setAccountState invalidates the actions of the sender if its account state has changed
+func (c *validationCache) setAccountState(sender string, nonce uint64, balance *big.Int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.senders[sender]; !ok {
+		return
+	}
+	c.update(sender, nonce, balance)
+	if len(c.senders[sender].acts) == 0 {
+		delete(c.senders, sender)
+	}
+}
+
+// size returns the number of the validated actions
+func (c *validationCache) size() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.acts)
+}
+
+func (c *validationCache) update(sender string, nonce uint64, balance *big.Int) {
+	sv, ok := c.senders[sender]
+	if ok && sv.nonce == nonce && sv.balance.Cmp(balance) == 0 {
+		return
+	}
+	if ok {
+		for actHash := range sv.acts {
+			delete(c.acts, actHash)
+		}
+	}
+	c.senders[sender] = &senderValidation{
+		nonce:   nonce,
+		balance: new(big.Int).Set(balance),
+		acts:    make(map[hash.Hash256]struct{}),
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestValidationCache(t *testing.T) {
+	require := require.New(t)
+	c := newValidationCache()
+	h1 := hash.Hash256b([]byte("1"))
+	h2 := hash.Hash256b([]byte("2"))
+	h3 := hash.Hash256b([]byte("3"))
+
+	c.add("a", 1, big.NewInt(10), h1)
+	c.add("a", 1, big.NewInt(10), h2)
+	c.add("b", 1, big.NewInt(10), h3)
+	require.True(c.contains(h1))
+	require.True(c.contains(h2))
+	require.Equal(3, c.size())
+
+	// The unchanged account state keeps the actions
+	c.setAccountState("a", 1, big.NewInt(10))
+	require.Equal(3, c.size())
+
+	// The changed account state invalidates the actions of the sender only
+	c.setAccountState("a", 1, big.NewInt(9))
+	require.False(c.contains(h1))
+	require.False(c.contains(h2))
+	require.True(c.contains(h3))
+	c.add("b", 2, big.NewInt(10), h1)
+	require.True(c.contains(h1))
+	require.False(c.contains(h3))
+
+	c.remove(h1)
+	require.Zero(c.size())
+	require.Empty(c.senders)
+}
+
+func TestActPool_ValidationCache(t *testing.T) {
+	require := require.New(t)
+	genesisCfg := genesis.Default
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(100))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.EnableValidationCache = true
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.True(ap.Validated(tsf1))
+	require.True(ap.Validated(tsf2))
+
+	// The cached action is invalidated once the balance of its sender changes
+	ws, err := bc.GetFactory().NewWorkingSet()
+	require.NoError(err)
+	acct, err := util.LoadOrCreateAccount(ws, addr1, big.NewInt(0))
+	require.NoError(err)
+	require.NoError(acct.AddBalance(big.NewInt(1)))
+	require.NoError(util.StoreAccount(ws, addr1, acct))
+	require.NoError(bc.GetFactory().Commit(ws))
+	ap.Reset()
+	require.False(ap.Validated(tsf1))
+	require.True(ap.Validated(tsf2))
+
+	// The action leaving actpool is dropped from the cache
+	ws, err = bc.GetFactory().NewWorkingSet()
+	require.NoError(err)
+	acct, err = util.LoadOrCreateAccount(ws, addr2, big.NewInt(0))
+	require.NoError(err)
+	acct.Nonce = 1
+	require.NoError(util.StoreAccount(ws, addr2, acct))
+	require.NoError(bc.GetFactory().Commit(ws))
+	ap.Reset()
+	_, err = ap.GetActionByHash(tsf2.Hash())
+	require.Error(err)
+	require.False(ap.Validated(tsf2))
+
+	// The cache is disabled by default
+	ap, err = NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.False(ap.Validated(tsf1))
+}
@@ -11,29 +11,53 @@ import (
 	"encoding/hex"
 	"math/big"
 	"net"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/freegas"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/gasstation"
 	"github.com/iotexproject/iotex-core/indexservice"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/protogen/iotexapi"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// maxFinalityScanBlocks is the max number of blocks scanned back from the tip for the latest finalized one, when
+	// the blocks above the one cached are scanned
+	maxFinalityScanBlocks = 1024
+	// syncStatusBufferSize is the number of sync statuses buffered for a stream which hasn't sent out the previous
+	// ones
+	syncStatusBufferSize = 16
 )
 
 var (
@@ -48,9 +72,33 @@ var (
 // BroadcastOutbound sends a broadcast message to the whole network
 type BroadcastOutbound func(ctx context.Context, chainID uint32, msg proto.Message) error
 
+// LocalActionTracker tracks an action submitted to this node
+type LocalActionTracker func(act *iotextypes.Action) error
+
+// Neighbors returns the neighbors' addresses
+type Neighbors func(context.Context) ([]peerstore.PeerInfo, error)
+
+// NetworkInfo returns the self network information
+type NetworkInfo func() peerstore.PeerInfo
+
+// ConsensusTimeline returns the timelines of the latest count consensus rounds, the latest first
+type ConsensusTimeline func(count int) []*iotexapi.RoundTimeline
+
 // Config represents the config to setup api
 type Config struct {
 	broadcastHandler BroadcastOutbound
+	actionTracker    LocalActionTracker
+	registry         *protocol.Registry
+	blockSync        blocksync.BlockSync
+	numDelegates     uint64
+	numSubEpochs     uint64
+	commitThreshold  scheme.CommitThreshold
+	consensus        consensus.Consensus
+	indexBuilder     *blockchain.IndexBuilder
+	neighbors        Neighbors
+	networkInfo      NetworkInfo
+	timeline         ConsensusTimeline
+	dbPaths          map[string]string
 }
 
 // Option is the option to override the api config
@@ -64,6 +112,104 @@ func WithBroadcastOutbound(broadcastHandler BroadcastOutbound) Option {
 	}
 }
 
+// WithLocalActionTracker is the option to track the actions sent via api, e.g., to broadcast them again if they get
+// stuck
+func WithLocalActionTracker(actionTracker LocalActionTracker) Option {
+	return func(cfg *Config) error {
+		cfg.actionTracker = actionTracker
+		return nil
+	}
+}
+
+// WithRegistry is the option to read the states of the registered protocols
+func WithRegistry(registry *protocol.Registry) Option {
+	return func(cfg *Config) error {
+		cfg.registry = registry
+		return nil
+	}
+}
+
+// WithBlockSync is the option to expose the statistics of the block sync
+func WithBlockSync(bs blocksync.BlockSync) Option {
+	return func(cfg *Config) error {
+		cfg.blockSync = bs
+		return nil
+	}
+}
+
+// WithNumDelegates is the option to set the number of delegates, more than 2/3 of whom endorse a finalized block
+func WithNumDelegates(numDelegates uint64) Option {
+	return func(cfg *Config) error {
+		cfg.numDelegates = numDelegates
+		return nil
+	}
+}
+
+// WithNumSubEpochs is the option to set the number of sub-epochs in an epoch
+func WithNumSubEpochs(numSubEpochs uint64) Option {
+	return func(cfg *Config) error {
+		cfg.numSubEpochs = numSubEpochs
+		return nil
+	}
+}
+
+// WithCommitThreshold is the option to set the fraction of the delegates, more than which endorse a finalized block.
+// Default is 2/3
+func WithCommitThreshold(threshold scheme.CommitThreshold) Option {
+	return func(cfg *Config) error {
+		cfg.commitThreshold = threshold
+		return nil
+	}
+}
+
+// WithConsensus is the option to expose the participation of the node in the consensus
+func WithConsensus(c consensus.Consensus) Option {
+	return func(cfg *Config) error {
+		cfg.consensus = c
+		return nil
+	}
+}
+
+// WithIndexBuilder is the option to expose the number of the committed blocks waiting to be indexed
+func WithIndexBuilder(ib *blockchain.IndexBuilder) Option {
+	return func(cfg *Config) error {
+		cfg.indexBuilder = ib
+		return nil
+	}
+}
+
+// WithNeighbors is the option to expose the number of the neighbors
+func WithNeighbors(neighbors Neighbors) Option {
+	return func(cfg *Config) error {
+		cfg.neighbors = neighbors
+		return nil
+	}
+}
+
+// WithNetworkInfo is the option to expose the self network information
+func WithNetworkInfo(networkInfo NetworkInfo) Option {
+	return func(cfg *Config) error {
+		cfg.networkInfo = networkInfo
+		return nil
+	}
+}
+
+// WithConsensusTimeline is the option to expose the timelines of the latest consensus rounds
+func WithConsensusTimeline(timeline ConsensusTimeline) Option {
+	return func(cfg *Config) error {
+		cfg.timeline = timeline
+		return nil
+	}
+}
+
+// WithDBPaths is the option to expose the sizes of the databases, keyed by name
+func WithDBPaths(dbPaths map[string]string) Option {
+	return func(cfg *Config) error {
+		cfg.dbPaths = dbPaths
+		return nil
+	}
+}
+
 // Server provides api for user to query blockchain data
 type Server struct {
 	bc               blockchain.Blockchain
@@ -71,9 +217,35 @@ type Server struct {
 	ap               actpool.ActPool
 	gs               *gasstation.GasStation
 	broadcastHandler BroadcastOutbound
+	actionTracker    LocalActionTracker
+	registry         *protocol.Registry
+	bs               blocksync.BlockSync
+	numDelegates     uint64
+	numSubEpochs     uint64
+	commitThreshold  scheme.CommitThreshold
+	consensus        consensus.Consensus
+	indexBuilder     *blockchain.IndexBuilder
+	neighbors        Neighbors
+	networkInfo      NetworkInfo
+	timeline         ConsensusTimeline
+	dbPaths          map[string]string
 	cfg              config.API
 	idx              *indexservice.Server
 	grpcserver       *grpc.Server
+	// finality caches the latest irreversible height
+	finality struct {
+		mutex           sync.Mutex
+		finalizedHeight uint64
+		scannedHeight   uint64
+		scannedHash     hash.Hash256
+	}
+	// readStateCache caches the protocol read results at the tip, which is nil if it's disabled
+	readStateCache *readStateCache
+	// keys are the API keys assigned to the users, which is nil if the API keys are disabled
+	keys *APIKeyStore
+	// chains are the servers of the other chains whose requests are routed to by chain ID
+	chains      map[uint32]*Server
+	chainsMutex sync.RWMutex
 }
 
 // NewServer creates a new server
@@ -92,6 +264,9 @@ func NewServer(
 		}
 	}
 
+	if apiCfg.commitThreshold.Denominator == 0 {
+		apiCfg.commitThreshold = scheme.DefaultCommitThreshold
+	}
 	if cfg == (config.API{}) {
 		log.L().Warn("API server is not configured.")
 		cfg = config.Default.API
@@ -102,14 +277,37 @@ func NewServer(
 		dp:               dispatcher,
 		ap:               actPool,
 		broadcastHandler: apiCfg.broadcastHandler,
+		actionTracker:    apiCfg.actionTracker,
+		registry:         apiCfg.registry,
+		bs:               apiCfg.blockSync,
+		numDelegates:     apiCfg.numDelegates,
+		numSubEpochs:     apiCfg.numSubEpochs,
+		commitThreshold:  apiCfg.commitThreshold,
+		consensus:        apiCfg.consensus,
+		indexBuilder:     apiCfg.indexBuilder,
+		neighbors:        apiCfg.neighbors,
+		networkInfo:      apiCfg.networkInfo,
+		timeline:         apiCfg.timeline,
+		dbPaths:          apiCfg.dbPaths,
 		cfg:              cfg,
 		idx:              idx,
 		gs:               gasstation.NewGasStation(chain, cfg),
+		chains:           make(map[uint32]*Server),
+	}
+	if cfg.ReadStateCacheSize > 0 {
+		svr.readStateCache = newReadStateCache(cfg.ReadStateCacheSize)
+	}
+	if cfg.KeyStorePath != "" {
+		keys, err := NewAPIKeyStore(cfg.KeyStorePath)
+		if err != nil {
+			return nil, err
+		}
+		svr.keys = keys
 	}
 
 	svr.grpcserver = grpc.NewServer(
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
+		grpc.StreamInterceptor(svr.streamInterceptor),
+		grpc.UnaryInterceptor(svr.unaryInterceptor),
 	)
 	iotexapi.RegisterAPIServiceServer(svr.grpcserver, svr)
 	grpc_prometheus.Register(svr.grpcserver)
@@ -124,15 +322,16 @@ func (api *Server) GetAccount(ctx context.Context, in *iotexapi.GetAccountReques
 	if err != nil {
 		return nil, err
 	}
-	pendingNonce, err := api.ap.GetPendingNonce(in.Address)
+	pendingState, err := api.ap.PendingState(in.Address)
 	if err != nil {
 		return nil, err
 	}
 	accountMeta := &iotextypes.AccountMeta{
-		Address:      in.Address,
-		Balance:      state.Balance.String(),
-		Nonce:        state.Nonce,
-		PendingNonce: pendingNonce,
+		Address:        in.Address,
+		Balance:        state.Balance.String(),
+		Nonce:          state.Nonce,
+		PendingNonce:   pendingState.PendingNonce,
+		PendingBalance: pendingState.Balance.String(),
 	}
 	return &iotexapi.GetAccountResponse{AccountMeta: accountMeta}, nil
 }
@@ -174,7 +373,31 @@ func (api *Server) GetBlockMetas(ctx context.Context, in *iotexapi.GetBlockMetas
 	}
 }
 
-// GetChainMeta returns blockchain metadata
+// GetRawBlocks returns the raw blocks, including the footers, starting from the given height
+func (api *Server) GetRawBlocks(ctx context.Context, in *iotexapi.GetRawBlocksRequest) (*iotexapi.GetRawBlocksResponse, error) {
+	if in.Count == 0 {
+		return nil, errors.New("block count should be greater than 0")
+	}
+	if in.Count > api.cfg.RangeQueryLimit {
+		return nil, errors.Errorf("block count %d exceeds the limit %d", in.Count, api.cfg.RangeQueryLimit)
+	}
+	tipHeight := api.bc.TipHeight()
+	if in.StartHeight > tipHeight {
+		return nil, errors.Errorf("start height %d is higher than tip height %d", in.StartHeight, tipHeight)
+	}
+	var res []*iotextypes.Block
+	for height := in.StartHeight; height <= tipHeight && uint64(len(res)) < in.Count; height++ {
+		blk, err := api.bc.GetBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, blk.ConvertToBlockPb())
+	}
+	return &iotexapi.GetRawBlocksResponse{Blocks: res}, nil
+}
+
+// GetChainMeta returns blockchain metadata, along with an overview of the sync, actpool, consensus and network of the
+// node. The overview sections are left unset if they fail to be read, rather than failing the chain metadata
 func (api *Server) GetChainMeta(ctx context.Context, in *iotexapi.GetChainMetaRequest) (*iotexapi.GetChainMetaResponse, error) {
 	tipHeight := api.bc.TipHeight()
 	totalActions, err := api.bc.GetTotalActions()
@@ -209,14 +432,40 @@ func (api *Server) GetChainMeta(ctx context.Context, in *iotexapi.GetChainMetaRe
 
 	tps := int64(totalActions) / timeDuration
 
+	irreversibleHeight, err := api.irreversibleHeight()
+	if err != nil {
+		return nil, err
+	}
+
 	chainMeta := &iotextypes.ChainMeta{
-		Height:     tipHeight,
-		Supply:     blockchain.Gen.TotalSupply.String(),
-		NumActions: int64(totalActions),
-		Tps:        tps,
+		Height:             tipHeight,
+		Supply:             blockchain.Gen.TotalSupply.String(),
+		NumActions:         int64(totalActions),
+		Tps:                tps,
+		IrreversibleHeight: irreversibleHeight,
 	}
 
-	return &iotexapi.GetChainMetaResponse{ChainMeta: chainMeta}, nil
+	res := &iotexapi.GetChainMetaResponse{
+		ChainMeta: chainMeta,
+		Sync:      api.syncMeta(),
+	}
+	if api.ap != nil {
+		stats := api.ap.Stats()
+		res.ActPool = &iotexapi.ActPoolStats{
+			NumExecutable: stats.NumExecutable,
+			NumQueued:     stats.NumQueued,
+			Capacity:      stats.Capacity,
+			Bytes:         stats.Bytes,
+			GasPriceFloor: stats.GasPriceFloor.String(),
+		}
+	}
+	if res.Consensus, err = api.consensusMeta(); err != nil {
+		log.L().Warn("Failed to get consensus meta.", zap.Error(err))
+	}
+	if res.Network, err = api.networkMeta(ctx, false); err != nil {
+		log.L().Warn("Failed to get network meta.", zap.Error(err))
+	}
+	return res, nil
 }
 
 // SendAction is the API to send an action to blockchain.
@@ -227,8 +476,24 @@ func (api *Server) SendAction(ctx context.Context, in *iotexapi.SendActionReques
 	if err = api.broadcastHandler(context.Background(), api.bc.ChainID(), in.Action); err != nil {
 		log.L().Warn("Failed to broadcast SendAction request.", zap.Error(err))
 	}
-	// send to actpool via dispatcher
-	api.dp.HandleBroadcast(context.Background(), api.bc.ChainID(), in.Action)
+	if api.ap != nil {
+		// add into actpool as a local action, which isn't evicted for the actions received from the network
+		var selp action.SealedEnvelope
+		if err := selp.LoadProto(in.Action); err != nil {
+			return nil, err
+		}
+		if err := api.ap.AddLocal(selp); err != nil {
+			log.L().Warn("Failed to add SendAction request into actpool.", zap.Error(err))
+		}
+	} else {
+		// send to actpool via dispatcher
+		api.dp.HandleBroadcast(context.Background(), api.bc.ChainID(), in.Action)
+	}
+	if api.actionTracker != nil {
+		if err := api.actionTracker(in.Action); err != nil {
+			log.L().Warn("Failed to track SendAction request.", zap.Error(err))
+		}
+	}
 
 	return &iotexapi.SendActionResponse{}, nil
 }
@@ -243,8 +508,16 @@ func (api *Server) GetReceiptByAction(ctx context.Context, in *iotexapi.GetRecei
 	if err != nil {
 		return nil, err
 	}
+	selp, err := api.bc.GetActionByActionHash(actHash)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := api.actionFee(selp, receipt)
+	if err != nil {
+		return nil, err
+	}
 
-	return &iotexapi.GetReceiptByActionResponse{Receipt: receipt.ConvertToReceiptPb()}, nil
+	return &iotexapi.GetReceiptByActionResponse{Receipt: receipt.ConvertToReceiptPb(), Fee: fee}, nil
 }
 
 // ReadContract reads the state in a contract address specified by the slot
@@ -266,7 +539,11 @@ func (api *Server) ReadContract(ctx context.Context, in *iotexapi.ReadContractRe
 		return nil, err
 	}
 
-	res, err := api.bc.ExecuteContractRead(callerAddr, sc)
+	overrides, err := convertStateOverrides(in.StateOverrides)
+	if err != nil {
+		return nil, err
+	}
+	res, err := api.bc.ExecuteContractReadWithOverrides(callerAddr, sc, overrides)
 	if err != nil {
 		return nil, err
 	}
@@ -279,6 +556,12 @@ func (api *Server) SuggestGasPrice(ctx context.Context, in *iotexapi.SuggestGasP
 	if err != nil {
 		return nil, err
 	}
+	// The suggested gas price shouldn't be rejected by actpool
+	if api.ap != nil {
+		if floor := api.ap.Stats().GasPriceFloor; floor.IsUint64() && floor.Uint64() > suggestPrice {
+			suggestPrice = floor.Uint64()
+		}
+	}
 	return &iotexapi.SuggestGasPriceResponse{GasPrice: suggestPrice}, nil
 }
 
@@ -291,10 +574,407 @@ func (api *Server) EstimateGasForAction(ctx context.Context, in *iotexapi.Estima
 	return &iotexapi.EstimateGasForActionResponse{Gas: estimateGas}, nil
 }
 
+// ReadState reads state on blockchain via the registered protocol. The results are cached until the tip moves
+func (api *Server) ReadState(ctx context.Context, in *iotexapi.ReadStateRequest) (*iotexapi.ReadStateResponse, error) {
+	if api.registry == nil {
+		return nil, errors.New("protocol registry is not set")
+	}
+	p, ok := api.registry.Find(string(in.ProtocolID))
+	if !ok {
+		return nil, errors.Errorf("protocol %s isn't registered", string(in.ProtocolID))
+	}
+	var (
+		height  = api.bc.TipHeight()
+		tipHash = api.bc.TipHash()
+		key     = readStateKey(in)
+	)
+	if api.readStateCache != nil {
+		if data, ok := api.readStateCache.get(height, tipHash, key); ok {
+			return &iotexapi.ReadStateResponse{Data: data}, nil
+		}
+	}
+	ws, err := api.bc.GetFactory().NewWorkingSet()
+	if err != nil {
+		return nil, err
+	}
+	data, err := p.ReadState(ctx, ws, in.MethodName, in.Arguments...)
+	if err != nil {
+		return nil, err
+	}
+	// The result isn't cached if the tip has moved during the read, as it may be read from either tip
+	if api.readStateCache != nil && api.bc.TipHash() == tipHash {
+		api.readStateCache.put(height, tipHash, key, data)
+	}
+	return &iotexapi.ReadStateResponse{Data: data}, nil
+}
+
+// GetDelegateParticipation returns the proposals, endorsements and missed slots of each delegate in the recent blocks
+func (api *Server) GetDelegateParticipation(
+	ctx context.Context,
+	in *iotexapi.GetDelegateParticipationRequest,
+) (*iotexapi.GetDelegateParticipationResponse, error) {
+	if in.Window == 0 {
+		return nil, errors.New("window must be greater than 0")
+	}
+	if in.Window > api.cfg.RangeQueryLimit {
+		return nil, errors.Errorf("window %d exceeds the limit %d", in.Window, api.cfg.RangeQueryLimit)
+	}
+	endHeight := api.bc.TipHeight()
+	if endHeight == 0 {
+		return nil, errors.New("no block has been produced yet")
+	}
+	// The genesis block isn't produced by any delegate
+	startHeight := uint64(1)
+	if endHeight >= in.Window {
+		startHeight = endHeight - in.Window + 1
+	}
+
+	delegates, err := api.delegatesByHeight(endHeight)
+	if err != nil {
+		return nil, err
+	}
+	var summaries []*indexservice.DelegateParticipation
+	if api.cfg.UseRDS {
+		summaries, err = api.idx.Indexer().GetDelegateParticipation(startHeight, endHeight, delegates)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var participations []*indexservice.BlockParticipation
+		for height := startHeight; height <= endHeight; height++ {
+			blk, err := api.bc.GetBlockByHeight(height)
+			if err != nil {
+				return nil, err
+			}
+			participations = append(participations, indexservice.BlockParticipations(blk)...)
+		}
+		summaries = indexservice.SummarizeParticipation(participations, endHeight-startHeight+1, delegates)
+	}
+
+	res := &iotexapi.GetDelegateParticipationResponse{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+	for _, summary := range summaries {
+		res.Delegates = append(res.Delegates, &iotexapi.DelegateParticipation{
+			Delegate:     summary.Delegate,
+			Proposals:    summary.Proposals,
+			Endorsements: summary.Endorsements,
+			MissedSlots:  summary.MissedSlots,
+		})
+	}
+	return res, nil
+}
+
+// delegatesByHeight returns the addresses of the delegates at the given height, so that the delegates who haven't
+// participated in any block are reported too. It returns none if no candidate has been elected yet
+func (api *Server) delegatesByHeight(height uint64) ([]string, error) {
+	candidates, err := api.bc.CandidatesByHeight(height)
+	if err != nil {
+		if errors.Cause(err) == state.ErrStateNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if api.numDelegates > 0 && uint64(len(candidates)) > api.numDelegates {
+		candidates = candidates[:api.numDelegates]
+	}
+	delegates := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		delegates = append(delegates, candidate.Address)
+	}
+	return delegates, nil
+}
+
+// GetBlockSyncBufferStats returns the statistics of the block sync buffer
+func (api *Server) GetBlockSyncBufferStats(
+	ctx context.Context,
+	in *iotexapi.GetBlockSyncBufferStatsRequest,
+) (*iotexapi.GetBlockSyncBufferStatsResponse, error) {
+	if api.bs == nil {
+		return nil, errors.New("block sync is not available")
+	}
+	return &iotexapi.GetBlockSyncBufferStatsResponse{Stats: api.bs.BufferStats()}, nil
+}
+
+// GetBlockSyncPeerScores returns the scores of the peers serving the block sync requests
+func (api *Server) GetBlockSyncPeerScores(
+	ctx context.Context,
+	in *iotexapi.GetBlockSyncPeerScoresRequest,
+) (*iotexapi.GetBlockSyncPeerScoresResponse, error) {
+	if api.bs == nil {
+		return nil, errors.New("block sync is not available")
+	}
+	return &iotexapi.GetBlockSyncPeerScoresResponse{Peers: api.bs.PeerScores()}, nil
+}
+
+// GetForkEvents returns the recent competing blocks observed at the same height, the latest first
+func (api *Server) GetForkEvents(
+	ctx context.Context,
+	in *iotexapi.GetForkEventsRequest,
+) (*iotexapi.GetForkEventsResponse, error) {
+	if api.bs == nil {
+		return nil, errors.New("block sync is not available")
+	}
+	return &iotexapi.GetForkEventsResponse{Events: api.bs.ForkEvents()}, nil
+}
+
+// GetBlockSyncStatus returns the progress of the block sync
+func (api *Server) GetBlockSyncStatus(
+	ctx context.Context,
+	in *iotexapi.GetBlockSyncStatusRequest,
+) (*iotexapi.GetBlockSyncStatusResponse, error) {
+	if api.bs == nil {
+		return nil, errors.New("block sync is not available")
+	}
+	return &iotexapi.GetBlockSyncStatusResponse{Status: api.bs.SyncStatus()}, nil
+}
+
+// StreamBlockSyncStatus streams the progress of the block sync, starting with the current status, until the client
+// cancels the stream
+func (api *Server) StreamBlockSyncStatus(
+	in *iotexapi.StreamBlockSyncStatusRequest,
+	stream iotexapi.APIService_StreamBlockSyncStatusServer,
+) error {
+	if api.bs == nil {
+		return errors.New("block sync is not available")
+	}
+	statuses := make(chan *iotexapi.BlockSyncStatus, syncStatusBufferSize)
+	api.bs.SubscribeSyncStatus(statuses)
+	defer api.bs.UnsubscribeSyncStatus(statuses)
+	if err := stream.Send(&iotexapi.StreamBlockSyncStatusResponse{Status: api.bs.SyncStatus()}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case status := <-statuses:
+			if err := stream.Send(&iotexapi.StreamBlockSyncStatusResponse{Status: status}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// WatchAddresses streams the balance deltas of the watched addresses in the blocks affecting them, until the client
+// cancels the stream
+func (api *Server) WatchAddresses(
+	in *iotexapi.WatchAddressesRequest,
+	stream iotexapi.APIService_WatchAddressesServer,
+) error {
+	w, err := newAddressWatcher(api.bc, in.Addresses)
+	if err != nil {
+		return err
+	}
+	if err := api.bc.AddSubscriber(w); err != nil {
+		return err
+	}
+	defer func() {
+		if err := api.bc.RemoveSubscriber(w); err != nil {
+			log.L().Warn("Failed to remove the address watcher.", zap.Error(err))
+		}
+	}()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case wb := <-w.blocks:
+			res, err := w.notification(wb)
+			if err != nil {
+				return err
+			}
+			if res == nil {
+				continue
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StreamPendingActions streams the changes of the actions in actpool until the client cancels the stream. The changes
+// are filtered by the senders, the event types and the action types in the request, each of which matches all if empty
+func (api *Server) StreamPendingActions(
+	in *iotexapi.StreamPendingActionsRequest,
+	stream iotexapi.APIService_StreamPendingActionsServer,
+) error {
+	w, err := newPendingActionWatcher(in.Addresses, in.EventTypes, in.ActionTypes)
+	if err != nil {
+		return err
+	}
+	if err := api.ap.AddSubscriber(w); err != nil {
+		return err
+	}
+	defer func() {
+		if err := api.ap.RemoveSubscriber(w); err != nil {
+			log.L().Warn("Failed to remove the pending action watcher.", zap.Error(err))
+		}
+	}()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-w.events:
+			res, err := w.notification(e)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetEpochStats returns the number of actions, average TPS, gas used, active senders and new accounts of an epoch
+func (api *Server) GetEpochStats(
+	ctx context.Context,
+	in *iotexapi.GetEpochStatsRequest,
+) (*iotexapi.GetEpochStatsResponse, error) {
+	if in.EpochNumber == 0 {
+		return nil, errors.New("epoch number must be greater than 0")
+	}
+	if !api.cfg.UseRDS || api.idx == nil {
+		return nil, errors.New("epoch stats are only available with the indexer")
+	}
+	if api.numDelegates == 0 {
+		return nil, errors.New("number of delegates isn't configured")
+	}
+	numSubEpochs := api.numSubEpochs
+	if numSubEpochs == 0 {
+		numSubEpochs = 1
+	}
+	epochSize := api.numDelegates * numSubEpochs
+	startHeight := (in.EpochNumber-1)*epochSize + 1
+	endHeight := startHeight + epochSize - 1
+	tipHeight := api.bc.TipHeight()
+	if startHeight > tipHeight {
+		return nil, errors.Errorf("epoch %d hasn't started yet", in.EpochNumber)
+	}
+	if endHeight > tipHeight {
+		endHeight = tipHeight
+	}
+
+	stats, err := api.idx.Indexer().GetEpochStats(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetEpochStatsResponse{
+		Stats: &iotexapi.EpochStats{
+			EpochNumber:   in.EpochNumber,
+			StartHeight:   stats.StartHeight,
+			EndHeight:     stats.EndHeight,
+			NumActions:    stats.NumActions,
+			AverageTps:    stats.AverageTPS,
+			GasUsed:       stats.GasUsed,
+			ActiveSenders: stats.ActiveSenders,
+			NewAccounts:   stats.NewAccounts,
+		},
+	}, nil
+}
+
+// GetActPoolStats returns the numbers of the executable and the queued actions in actpool, together with the hashes of
+// the actions of the given address in both sets
+func (api *Server) GetActPoolStats(
+	ctx context.Context,
+	in *iotexapi.GetActPoolStatsRequest,
+) (*iotexapi.GetActPoolStatsResponse, error) {
+	stats := api.ap.Stats()
+	res := &iotexapi.GetActPoolStatsResponse{
+		Stats: &iotexapi.ActPoolStats{
+			NumExecutable: stats.NumExecutable,
+			NumQueued:     stats.NumQueued,
+			Capacity:      stats.Capacity,
+			Bytes:         stats.Bytes,
+			GasPriceFloor: stats.GasPriceFloor.String(),
+		},
+	}
+	if in.Address == "" {
+		return res, nil
+	}
+	if _, err := address.FromString(in.Address); err != nil {
+		return nil, errors.Wrapf(err, "invalid address %s", in.Address)
+	}
+	for _, selp := range api.ap.PendingActionMap()[in.Address] {
+		h := selp.Hash()
+		res.ExecutableActHashes = append(res.ExecutableActHashes, hex.EncodeToString(h[:]))
+	}
+	for _, selp := range api.ap.GetQueuedActs(in.Address) {
+		h := selp.Hash()
+		res.QueuedActHashes = append(res.QueuedActHashes, hex.EncodeToString(h[:]))
+	}
+	return res, nil
+}
+
+// GetConsensusTimeline returns the timelines of the latest consensus rounds, i.e., when the proposal is received, when
+// the endorsements reach the quorums and when the block is committed, along with the endorsement latency of each
+// delegate, which tells the delegates slowing the rounds down
+func (api *Server) GetConsensusTimeline(
+	ctx context.Context,
+	in *iotexapi.GetConsensusTimelineRequest,
+) (*iotexapi.GetConsensusTimelineResponse, error) {
+	if api.timeline == nil {
+		return nil, errors.New("consensus timeline is not available")
+	}
+	return &iotexapi.GetConsensusTimelineResponse{Rounds: api.timeline(int(in.Count))}, nil
+}
+
+// GetStateDiff returns the changes of the states made by each action of the block at the height, which are dumped by
+// the node when the state root or the delta state digest of the block doesn't match its local execution, so that the
+// action diverging could be found out by comparing with other nodes
+func (api *Server) GetStateDiff(
+	ctx context.Context,
+	in *iotexapi.GetStateDiffRequest,
+) (*iotexapi.GetStateDiffResponse, error) {
+	diff, err := api.bc.StateDiff(in.Height)
+	if err != nil {
+		return nil, err
+	}
+	pbDiff := &iotexapi.StateDiff{
+		Height:                   diff.Height,
+		BlkHash:                  diff.BlockHash,
+		Error:                    diff.Error,
+		ExpectedStateRoot:        diff.ExpectedStateRoot,
+		StateRoot:                diff.StateRoot,
+		ExpectedDeltaStateDigest: diff.ExpectedDeltaStateDigest,
+		DeltaStateDigest:         diff.DeltaStateDigest,
+	}
+	for _, actionDiff := range diff.Actions {
+		pbActionDiff := &iotexapi.ActionStateDiff{ActHash: actionDiff.ActionHash}
+		for _, change := range actionDiff.Changes {
+			pbActionDiff.Changes = append(pbActionDiff.Changes, &iotexapi.StateChange{
+				Key:     change.Key,
+				Address: change.Address,
+				Before:  change.Before,
+				After:   change.After,
+			})
+		}
+		pbDiff.Actions = append(pbDiff.Actions, pbActionDiff)
+	}
+	return &iotexapi.GetStateDiffResponse{StateDiff: pbDiff}, nil
+}
+
+// GetStateGrowth returns the net number of trie nodes and state bytes added up to the current height
+func (api *Server) GetStateGrowth(
+	ctx context.Context,
+	in *iotexapi.GetStateGrowthRequest,
+) (*iotexapi.GetStateGrowthResponse, error) {
+	height, totals, err := api.bc.GetFactory().StateGrowth()
+	if err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetStateGrowthResponse{
+		Height:     height,
+		TrieNodes:  totals.TrieNodes,
+		StateBytes: totals.StateBytes,
+	}, nil
+}
+
 // Start starts the API server
 func (api *Server) Start() error {
-	portStr := ":" + strconv.Itoa(api.cfg.Port)
-	lis, err := net.Listen("tcp", portStr)
+	lis, err := net.Listen("tcp", net.JoinHostPort(api.cfg.Host, strconv.Itoa(api.cfg.Port)))
 	if err != nil {
 		log.L().Error("API server failed to listen.", zap.Error(err))
 		return errors.Wrap(err, "API server failed to listen")
@@ -319,8 +999,14 @@ func (api *Server) Stop() error {
 // GetActions returns actions within the range
 func (api *Server) getActions(start uint64, count uint64) (*iotexapi.GetActionsResponse, error) {
 	var res []*iotextypes.Action
+	var fees []*iotextypes.ActionFee
+	var finalized []bool
 	var actionCount uint64
 
+	irreversibleHeight, err := api.irreversibleHeight()
+	if err != nil {
+		return nil, err
+	}
 	tipHeight := api.bc.TipHeight()
 	for height := int64(tipHeight); height >= 0; height-- {
 		blk, err := api.bc.GetBlockByHeight(uint64(height))
@@ -336,13 +1022,19 @@ func (api *Server) getActions(start uint64, count uint64) (*iotexapi.GetActionsR
 			}
 
 			if uint64(len(res)) >= count {
-				return &iotexapi.GetActionsResponse{Actions: res}, nil
+				return &iotexapi.GetActionsResponse{Actions: res, Fees: fees, Finalized: finalized}, nil
+			}
+			fee, err := api.confirmedActionFee(selps[i])
+			if err != nil {
+				return nil, err
 			}
 			res = append(res, selps[i].Proto())
+			fees = append(fees, fee)
+			finalized = append(finalized, blk.Height() <= irreversibleHeight)
 		}
 	}
 
-	return &iotexapi.GetActionsResponse{Actions: res}, nil
+	return &iotexapi.GetActionsResponse{Actions: res, Fees: fees, Finalized: finalized}, nil
 }
 
 // getAction returns action by action hash
@@ -355,12 +1047,31 @@ func (api *Server) getAction(actionHash string, checkPending bool) (*iotexapi.Ge
 	if err != nil {
 		return nil, err
 	}
-	return &iotexapi.GetActionsResponse{Actions: []*iotextypes.Action{actPb}}, nil
+	res := &iotexapi.GetActionsResponse{
+		Actions:   []*iotextypes.Action{actPb},
+		Finalized: []bool{false},
+	}
+	// Only the confirmed action has the fee charged and could be finalized
+	selp, err := api.bc.GetActionByActionHash(actHash)
+	if err != nil {
+		return res, nil
+	}
+	if res.Finalized[0], err = api.isActionFinalized(actHash); err != nil {
+		return nil, err
+	}
+	fee, err := api.confirmedActionFee(selp)
+	if err != nil {
+		return nil, err
+	}
+	res.Fees = []*iotextypes.ActionFee{fee}
+	return res, nil
 }
 
 // getActionsByAddress returns all actions associated with an address
 func (api *Server) getActionsByAddress(address string, start uint64, count uint64) (*iotexapi.GetActionsResponse, error) {
 	var res []*iotextypes.Action
+	var fees []*iotextypes.ActionFee
+	var finalized []bool
 	var actions []hash.Hash256
 	if api.cfg.UseRDS {
 		actionHistory, err := api.idx.Indexer().GetIndexHistory(config.IndexAction, address)
@@ -395,15 +1106,24 @@ func (api *Server) getActionsByAddress(address string, start uint64, count uint6
 			break
 		}
 
-		actPb, err := getAction(api.bc, api.ap, actions[i], false)
+		selp, err := api.bc.GetActionByActionHash(actions[i])
 		if err != nil {
 			return nil, err
 		}
-
-		res = append(res, actPb)
+		fee, err := api.confirmedActionFee(selp)
+		if err != nil {
+			return nil, err
+		}
+		isFinalized, err := api.isActionFinalized(actions[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, selp.Proto())
+		fees = append(fees, fee)
+		finalized = append(finalized, isFinalized)
 	}
 
-	return &iotexapi.GetActionsResponse{Actions: res}, nil
+	return &iotexapi.GetActionsResponse{Actions: res, Fees: fees, Finalized: finalized}, nil
 }
 
 // getUnconfirmedActionsByAddress returns all unconfirmed actions in actpool associated with an address
@@ -426,12 +1146,13 @@ func (api *Server) getUnconfirmedActionsByAddress(address string, start uint64,
 		res = append(res, selps[i].Proto())
 	}
 
-	return &iotexapi.GetActionsResponse{Actions: res}, nil
+	return &iotexapi.GetActionsResponse{Actions: res, Finalized: make([]bool, len(res))}, nil
 }
 
 // getActionsByBlock returns all actions in a block
 func (api *Server) getActionsByBlock(blkHash string, start uint64, count uint64) (*iotexapi.GetActionsResponse, error) {
 	var res []*iotextypes.Action
+	var fees []*iotextypes.ActionFee
 	hash, err := toHash256(blkHash)
 	if err != nil {
 		return nil, err
@@ -455,9 +1176,22 @@ func (api *Server) getActionsByBlock(blkHash string, start uint64, count uint64)
 			break
 		}
 
+		fee, err := api.confirmedActionFee(selps[i])
+		if err != nil {
+			return nil, err
+		}
 		res = append(res, selps[i].Proto())
+		fees = append(fees, fee)
+	}
+	isFinalized, err := api.isFinalized(blk.Height())
+	if err != nil {
+		return nil, err
+	}
+	finalized := make([]bool, len(res))
+	for i := range finalized {
+		finalized[i] = isFinalized
 	}
-	return &iotexapi.GetActionsResponse{Actions: res}, nil
+	return &iotexapi.GetActionsResponse{Actions: res, Fees: fees, Finalized: finalized}, nil
 }
 
 // getBlockMetas gets block within the height range
@@ -466,6 +1200,10 @@ func (api *Server) getBlockMetas(start uint64, number uint64) (*iotexapi.GetBloc
 
 	startHeight := api.bc.TipHeight()
 	var blkCount uint64
+	irreversibleHeight, err := api.irreversibleHeight()
+	if err != nil {
+		return nil, err
+	}
 	for height := int(startHeight); height >= 0; height-- {
 		blkCount++
 
@@ -499,6 +1237,8 @@ func (api *Server) getBlockMetas(start uint64, number uint64) (*iotexapi.GetBloc
 			TxRoot:           hex.EncodeToString(txRoot[:]),
 			ReceiptRoot:      hex.EncodeToString(receiptRoot[:]),
 			DeltaStateDigest: hex.EncodeToString(deltaStateDigest[:]),
+			Footer:           blk.ConvertToBlockFooterPb(),
+			Finalized:        blk.Height() <= irreversibleHeight,
 		}
 
 		res = append(res, blockMeta)
@@ -519,6 +1259,10 @@ func (api *Server) getBlockMeta(blkHash string) (*iotexapi.GetBlockMetasResponse
 		return nil, err
 	}
 
+	finalized, err := api.isFinalized(blk.Height())
+	if err != nil {
+		return nil, err
+	}
 	blkHeaderPb := blk.ConvertToBlockHeaderPb()
 	txRoot := blk.TxRoot()
 	receiptRoot := blk.ReceiptRoot()
@@ -535,11 +1279,194 @@ func (api *Server) getBlockMeta(blkHash string) (*iotexapi.GetBlockMetasResponse
 		TxRoot:           hex.EncodeToString(txRoot[:]),
 		ReceiptRoot:      hex.EncodeToString(receiptRoot[:]),
 		DeltaStateDigest: hex.EncodeToString(deltaStateDigest[:]),
+		Footer:           blk.ConvertToBlockFooterPb(),
+		Finalized:        finalized,
 	}
 
 	return &iotexapi.GetBlockMetasResponse{BlkMetas: []*iotextypes.BlockMeta{blockMeta}}, nil
 }
 
+// endorsedByQuorum returns whether the block is committed with the endorsements of more than the commit threshold of
+// the delegates. A block merely observed, e.g., produced without consensus, isn't endorsed by the quorum
+func (api *Server) endorsedByQuorum(blk *block.Block) bool {
+	if api.numDelegates == 0 {
+		return false
+	}
+	return api.commitThreshold.Reached(len(blk.CommitEndorsers()), int(api.numDelegates))
+}
+
+// isFinalized returns whether the block at the height is finalized, i.e., at or below the latest irreversible height
+func (api *Server) isFinalized(height uint64) (bool, error) {
+	irreversibleHeight, err := api.irreversibleHeight()
+	if err != nil {
+		return false, err
+	}
+	return height <= irreversibleHeight, nil
+}
+
+// isActionFinalized returns whether the action is confirmed in a finalized block. A pending action isn't finalized
+func (api *Server) isActionFinalized(actHash hash.Hash256) (bool, error) {
+	blkHash, err := api.bc.GetBlockHashByActionHash(actHash)
+	if errors.Cause(err) == db.ErrNotExist {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	blk, err := api.bc.GetBlockByHash(blkHash)
+	if err != nil {
+		return false, err
+	}
+	return api.isFinalized(blk.Height())
+}
+
+// irreversibleHeight returns the height of the latest block endorsed by the quorum, below which the blocks are
+// irreversible as well. The height is cached along with the last block scanned, so that only the blocks committed
+// since then are scanned, up to maxFinalityScanBlocks blocks back from the tip. The blocks above the finalized one are
+// scanned again if they have been replaced
+func (api *Server) irreversibleHeight() (uint64, error) {
+	api.finality.mutex.Lock()
+	defer api.finality.mutex.Unlock()
+
+	f := &api.finality
+	if f.scannedHeight > f.finalizedHeight {
+		scannedHash, err := api.bc.GetHashByHeight(f.scannedHeight)
+		if err != nil || scannedHash != f.scannedHash {
+			f.scannedHeight = f.finalizedHeight
+		}
+	}
+	tipHeight := api.bc.TipHeight()
+	start := f.scannedHeight + 1
+	if tipHeight >= maxFinalityScanBlocks && start < tipHeight-maxFinalityScanBlocks+1 {
+		start = tipHeight - maxFinalityScanBlocks + 1
+	}
+	for height := start; height <= tipHeight; height++ {
+		blk, err := api.bc.GetBlockByHeight(height)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to get block %d", height)
+		}
+		if api.endorsedByQuorum(blk) {
+			f.finalizedHeight = height
+		}
+		f.scannedHeight = height
+		f.scannedHash = blk.HashBlock()
+	}
+	return f.finalizedHeight, nil
+}
+
+// StorageMeta returns the sizes of the databases, or nil if their paths aren't set. It walks the db directories and
+// exposes their paths, so it's only served by the admin endpoint
+func (api *Server) StorageMeta() *iotexapi.StorageMeta {
+	if len(api.dbPaths) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(api.dbPaths))
+	for name := range api.dbPaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	meta := &iotexapi.StorageMeta{}
+	for _, name := range names {
+		path := api.dbPaths[name]
+		size, err := dbSize(path)
+		if err != nil {
+			log.L().Debug("Error when getting the size of db.", zap.String("path", path), zap.Error(err))
+		}
+		meta.Dbs = append(meta.Dbs, &iotexapi.DBSize{Name: name, Path: path, Bytes: size})
+		meta.TotalBytes += size
+	}
+	return meta
+}
+
+// CandidatesByHeight returns the candidates at the height. It isn't served by the gRPC api, but by the explorer bridge
+// serving the legacy candidate metrics
+func (api *Server) CandidatesByHeight(height uint64) ([]*state.Candidate, error) {
+	return api.bc.CandidatesByHeight(height)
+}
+
+// syncMeta returns the progress of the block sync and the indexing, or nil if neither is running
+func (api *Server) syncMeta() *iotexapi.SyncMeta {
+	if api.bs == nil && api.indexBuilder == nil {
+		return nil
+	}
+	meta := &iotexapi.SyncMeta{}
+	if api.bs != nil {
+		meta.BlockSync = api.bs.SyncStatus()
+		meta.CatchingUp = api.bs.CatchingUp()
+	}
+	if api.indexBuilder != nil {
+		meta.IndexLag = api.indexBuilder.Lag()
+	}
+	return meta
+}
+
+// consensusMeta returns the latest consensus metrics, or nil if the consensus isn't set
+func (api *Server) consensusMeta() (*iotexapi.ConsensusMeta, error) {
+	if api.consensus == nil {
+		return nil, nil
+	}
+	metrics, err := api.consensus.Metrics()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get consensus metrics")
+	}
+	return &iotexapi.ConsensusMeta{
+		Epoch:               metrics.LatestEpoch,
+		Height:              metrics.LatestHeight,
+		Delegates:           metrics.LatestDelegates,
+		LatestBlockProducer: metrics.LatestBlockProducer,
+		NumCandidates:       uint64(len(metrics.Candidates)),
+		ProposalsReceived:   metrics.ProposalsReceived,
+		RoundTimeouts:       metrics.RoundTimeouts,
+		EpochTransitions:    metrics.EpochTransitions,
+	}, nil
+}
+
+// NetworkMeta returns the self network information along with the number of neighbors, or nil if the node isn't
+// connected to the p2p network. It exposes the peer ID and addresses of the node, so it's only served by the admin
+// endpoint
+func (api *Server) NetworkMeta(ctx context.Context) (*iotexapi.NetworkMeta, error) {
+	return api.networkMeta(ctx, true)
+}
+
+// networkMeta returns the number of neighbors, and the self network information if it's exposed, or nil if the node
+// isn't connected to the p2p network
+func (api *Server) networkMeta(ctx context.Context, exposeSelf bool) (*iotexapi.NetworkMeta, error) {
+	if api.networkInfo == nil && api.neighbors == nil {
+		return nil, nil
+	}
+	meta := &iotexapi.NetworkMeta{}
+	if exposeSelf && api.networkInfo != nil {
+		info := api.networkInfo()
+		meta.PeerID = info.ID.Pretty()
+		for _, addr := range info.Addrs {
+			meta.Addresses = append(meta.Addresses, addr.String())
+		}
+	}
+	if api.neighbors != nil {
+		neighbors, err := api.neighbors(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get neighbors")
+		}
+		meta.NumNeighbors = uint64(len(neighbors))
+	}
+	return meta, nil
+}
+
+// dbSize returns the size of a db file, or the total size of the files if the db is a directory
+func dbSize(path string) (uint64, error) {
+	var size uint64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size, err
+}
+
 func toHash256(hashString string) (hash.Hash256, error) {
 	bytes, err := hex.DecodeString(hashString)
 	if err != nil {
@@ -565,6 +1492,122 @@ func getAction(bc blockchain.Blockchain, ap actpool.ActPool, actHash hash.Hash25
 	return selp.Proto(), nil
 }
 
+// confirmedActionFee computes the fee breakdown of a confirmed action by its receipt. Transfer and vote don't produce
+// receipts, and their fee is determined by the intrinsic gas. The fee is empty if the receipt has been pruned
+func (api *Server) confirmedActionFee(selp action.SealedEnvelope) (*iotextypes.ActionFee, error) {
+	receipt, err := api.bc.GetReceiptByActionHash(selp.Hash())
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		receipt = nil
+	case blockchain.ErrReceiptPruned:
+		return &iotextypes.ActionFee{}, nil
+	default:
+		return nil, err
+	}
+	return api.actionFee(selp, receipt)
+}
+
+// actionFee computes the fee breakdown of a confirmed action, split into the burned part, the part granted to the
+// producer of its block and the part deposited into the rewarding fund the same way as the gas fee is deposited
+func (api *Server) actionFee(selp action.SealedEnvelope, receipt *action.Receipt) (*iotextypes.ActionFee, error) {
+	burnPercentage, producerPercentage, err := api.gasFeeSplit()
+	if err != nil {
+		return nil, err
+	}
+	var producer string
+	if producerPercentage > 0 {
+		blkHash, err := api.bc.GetBlockHashByActionHash(selp.Hash())
+		if err != nil {
+			return nil, err
+		}
+		blk, err := api.bc.GetBlockByHash(blkHash)
+		if err != nil {
+			return nil, err
+		}
+		producer = blk.ProducerAddress()
+	}
+	return getActionFee(selp, receipt, burnPercentage, producerPercentage, producer, api.freeGasEnabled())
+}
+
+// freeGasEnabled returns whether the free gas protocol is registered, with which the actions at zero gas price are
+// charged to the free gas quota of the senders
+func (api *Server) freeGasEnabled() bool {
+	if api.registry == nil {
+		return false
+	}
+	_, ok := api.registry.Find(freegas.ProtocolID)
+	return ok
+}
+
+// gasFeeSplit returns the percentages of the gas fee to burn and to grant to the block producer. All the gas fee is
+// deposited into the rewarding fund if the rewarding protocol isn't registered. The split is read from the current
+// states, which applies to the historical actions as well, because it's set by the genesis and never changes
+func (api *Server) gasFeeSplit() (uint64, uint64, error) {
+	if api.registry == nil {
+		return 0, 0, nil
+	}
+	p, ok := api.registry.Find(rewarding.ProtocolID)
+	if !ok {
+		return 0, 0, nil
+	}
+	rp, ok := p.(*rewarding.Protocol)
+	if !ok {
+		return 0, 0, errors.Errorf("protocol %s is not a rewarding protocol", rewarding.ProtocolID)
+	}
+	return rp.GasFeeSplit(context.Background(), api.bc.GetFactory())
+}
+
+// getActionFee computes the fee breakdown of a confirmed action. If the receipt is nil, the action is charged by its
+// intrinsic gas only. The producer share is deposited into the fund if the producer is unknown. If free gas is enabled,
+// the action at zero gas price is charged to the free gas quota by its intrinsic gas instead
+func getActionFee(
+	selp action.SealedEnvelope,
+	receipt *action.Receipt,
+	burnPercentage uint64,
+	producerPercentage uint64,
+	producer string,
+	freeGas bool,
+) (*iotextypes.ActionFee, error) {
+	intrinsicGas, err := selp.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	gasConsumed := intrinsicGas
+	if receipt != nil {
+		gasConsumed = receipt.GasConsumed
+	}
+	var executionGas uint64
+	if gasConsumed > intrinsicGas {
+		executionGas = gasConsumed - intrinsicGas
+	}
+	totalFee := big.NewInt(0).Mul(selp.GasPrice(), big.NewInt(0).SetUint64(gasConsumed))
+	burned, producerShare, fundShare := rewarding.SplitGasFee(totalFee, burnPercentage, producerPercentage)
+	if producer == "" {
+		fundShare.Add(fundShare, producerShare)
+		producerShare = big.NewInt(0)
+	}
+	fee := &iotextypes.ActionFee{
+		IntrinsicGas: intrinsicGas,
+		ExecutionGas: executionGas,
+		GasPrice:     selp.GasPrice().String(),
+		TotalFee:     totalFee.String(),
+		BurnedFee:    burned.String(),
+		ProducerFee:  producerShare.String(),
+		FundFee:      fundShare.String(),
+	}
+	if producerShare.Sign() > 0 {
+		fee.Producer = producer
+	}
+	if fundShare.Sign() > 0 {
+		fee.Beneficiary = rewarding.NewProtocol().Address().String()
+	}
+	if freeGas && selp.GasPrice().Sign() == 0 {
+		fee.FreeGas = intrinsicGas
+	}
+	return fee, nil
+}
+
 func getTranferAmountInBlock(blk *block.Block) *big.Int {
 	totalAmount := big.NewInt(0)
 	for _, selp := range blk.Actions {
@@ -576,3 +1619,31 @@ func getTranferAmountInBlock(blk *block.Block) *big.Int {
 	}
 	return totalAmount
 }
+
+func convertStateOverrides(pbOverrides []*iotexapi.StateOverride) ([]*evm.StateOverride, error) {
+	overrides := make([]*evm.StateOverride, 0, len(pbOverrides))
+	for _, pb := range pbOverrides {
+		o := &evm.StateOverride{Address: pb.Address}
+		if pb.Balance != "" {
+			balance, ok := big.NewInt(0).SetString(pb.Balance, 10)
+			if !ok {
+				return nil, errors.Errorf("invalid balance %s to override for %s", pb.Balance, pb.Address)
+			}
+			o.Balance = balance
+		}
+		if len(pb.Code) > 0 {
+			o.Code = pb.Code
+		}
+		if len(pb.Storage) > 0 {
+			o.Storage = make(map[hash.Hash256]hash.Hash256, len(pb.Storage))
+			for _, slot := range pb.Storage {
+				if len(slot.Key) != len(hash.ZeroHash256) || len(slot.Value) != len(hash.ZeroHash256) {
+					return nil, errors.Errorf("storage key and value to override for %s should be 32 bytes", pb.Address)
+				}
+				o.Storage[byteutil.BytesTo32B(slot.Key)] = byteutil.BytesTo32B(slot.Value)
+			}
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, nil
+}
@@ -7,41 +7,58 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
+	"io/ioutil"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/gasstation"
+	"github.com/iotexproject/iotex-core/indexservice"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/protogen/iotexapi"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
+	"github.com/iotexproject/iotex-core/test/mock/mock_factory"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
 
 const (
-	testTriePath = "trie.test"
-	testDBPath   = "db.test"
+	testTriePath  = "trie.test"
+	testDBPath    = "db.test"
+	testIndexPath = "index.test"
 )
 
 var (
@@ -76,17 +93,19 @@ var (
 
 var (
 	getAccountTests = []struct {
-		in           string
-		address      string
-		balance      string
-		nonce        uint64
-		pendingNonce uint64
+		in             string
+		address        string
+		balance        string
+		nonce          uint64
+		pendingNonce   uint64
+		pendingBalance string
 	}{
 		{ta.Addrinfo["charlie"].String(),
 			"io1hw79kmqxlp33h7t83wrf9gkduy58th4vmkkue4",
 			"3",
 			8,
 			9,
+			"3",
 		},
 		{
 			ta.Addrinfo["producer"].String(),
@@ -94,6 +113,7 @@ var (
 			"9999999999999999999999999991",
 			1,
 			6,
+			"9999999999999999999999799950",
 		},
 	}
 
@@ -119,24 +139,28 @@ var (
 		in           string
 		nonce        uint64
 		senderPubKey string
+		numFees      int
 	}{
 		{
 			false,
 			hex.EncodeToString(transferHash1[:]),
 			1,
 			keypair.EncodePublicKey(testTransfer1.SrcPubkey()),
+			1,
 		},
 		{
 			false,
 			hex.EncodeToString(voteHash1[:]),
 			5,
 			keypair.EncodePublicKey(testVote1.SrcPubkey()),
+			1,
 		},
 		{
 			true,
 			hex.EncodeToString(executionHash1[:]),
 			5,
 			keypair.EncodePublicKey(testExecution1.SrcPubkey()),
+			0,
 		},
 	}
 
@@ -150,7 +174,7 @@ var (
 			ta.Addrinfo["producer"].String(),
 			0,
 			3,
-			2,
+			3,
 		},
 		{
 			ta.Addrinfo["charlie"].String(),
@@ -211,6 +235,65 @@ var (
 		},
 	}
 
+	getRawBlocksTests = []struct {
+		startHeight uint64
+		count       uint64
+		numBlks     int
+	}{
+		{
+			1,
+			2,
+			2,
+		},
+		{
+			3,
+			5,
+			2,
+		},
+		{
+			1,
+			0,
+			0,
+		},
+		{
+			5,
+			1,
+			0,
+		},
+		{
+			1,
+			101,
+			0,
+		},
+	}
+
+	getDelegateParticipationTests = []struct {
+		window      uint64
+		startHeight uint64
+		proposals   uint64
+	}{
+		{
+			2,
+			3,
+			2,
+		},
+		{
+			10,
+			1,
+			4,
+		},
+		{
+			0,
+			0,
+			0,
+		},
+		{
+			101,
+			0,
+			0,
+		},
+	}
+
 	getBlockMetaTests = []struct {
 		blkHeight      uint64
 		numActions     int64
@@ -298,6 +381,38 @@ var (
 			10000,
 		},
 	}
+
+	readStateTests = []struct {
+		protocolID   string
+		methodName   string
+		expectedData string
+		expectedErr  bool
+	}{
+		{
+			rewarding.ProtocolID,
+			"EpochRewardCap",
+			"0",
+			false,
+		},
+		{
+			rewarding.ProtocolID,
+			"MinClaimAmount",
+			"0",
+			false,
+		},
+		{
+			rewarding.ProtocolID,
+			"Unknown",
+			"",
+			true,
+		},
+		{
+			"unknown",
+			"EpochRewardCap",
+			"",
+			true,
+		},
+	}
 )
 
 func TestServer_GetAccount(t *testing.T) {
@@ -322,6 +437,7 @@ func TestServer_GetAccount(t *testing.T) {
 		require.Equal(test.balance, accountMeta.Balance)
 		require.Equal(test.nonce, accountMeta.Nonce)
 		require.Equal(test.pendingNonce, accountMeta.PendingNonce)
+		require.Equal(test.pendingBalance, accountMeta.PendingBalance)
 	}
 	// failure
 	_, err = svr.GetAccount(context.Background(), &iotexapi.GetAccountRequest{})
@@ -352,6 +468,7 @@ func TestServer_GetActions(t *testing.T) {
 		res, err := svr.GetActions(context.Background(), request)
 		require.NoError(err)
 		require.Equal(test.numActions, len(res.Actions))
+		require.Equal(test.numActions, len(res.Fees))
 	}
 }
 
@@ -382,6 +499,14 @@ func TestServer_GetAction(t *testing.T) {
 		actPb := res.Actions[0]
 		require.Equal(test.nonce, actPb.GetCore().GetNonce())
 		require.Equal(test.senderPubKey, hex.EncodeToString(actPb.SenderPubKey))
+		require.Equal(test.numFees, len(res.Fees))
+		if test.numFees > 0 {
+			fee := res.Fees[0]
+			require.Equal(uint64(10000), fee.IntrinsicGas)
+			require.Equal(uint64(0), fee.ExecutionGas)
+			totalFee := big.NewInt(0).Mul(big.NewInt(testutil.TestGasPrice), big.NewInt(10000))
+			require.Equal(totalFee.String(), fee.TotalFee)
+		}
 	}
 }
 
@@ -410,6 +535,7 @@ func TestServer_GetActionsByAddress(t *testing.T) {
 		res, err := svr.GetActions(context.Background(), request)
 		require.NoError(err)
 		require.Equal(test.numActions, len(res.Actions))
+		require.Equal(test.numActions, len(res.Fees))
 	}
 }
 
@@ -438,6 +564,7 @@ func TestServer_GetUnconfirmedActionsByAddress(t *testing.T) {
 		res, err := svr.GetActions(context.Background(), request)
 		require.NoError(err)
 		require.Equal(test.numActions, len(res.Actions))
+		require.Empty(res.Fees)
 	}
 }
 
@@ -469,6 +596,7 @@ func TestServer_GetActionsByBlock(t *testing.T) {
 		res, err := svr.GetActions(context.Background(), request)
 		require.NoError(err)
 		require.Equal(test.numActions, len(res.Actions))
+		require.Equal(test.numActions, len(res.Fees))
 	}
 }
 
@@ -498,6 +626,7 @@ func TestServer_GetBlockMetas(t *testing.T) {
 		require.Equal(test.numBlks, len(res.BlkMetas))
 		var prevBlkPb *iotextypes.BlockMeta
 		for _, blkPb := range res.BlkMetas {
+			require.NotNil(blkPb.Footer)
 			if prevBlkPb != nil {
 				require.True(blkPb.Timestamp < prevBlkPb.Timestamp)
 				require.True(blkPb.Height < prevBlkPb.Height)
@@ -536,9 +665,385 @@ func TestServer_GetBlockMeta(t *testing.T) {
 		blkPb := res.BlkMetas[0]
 		require.Equal(test.numActions, blkPb.NumActions)
 		require.Equal(test.transferAmount, blkPb.TransferAmount)
+		require.Equal(blk.ConvertToBlockFooterPb(), blkPb.Footer)
 	}
 }
 
+func TestServer_GetRawBlocks(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+
+	for _, test := range getRawBlocksTests {
+		request := &iotexapi.GetRawBlocksRequest{
+			StartHeight: test.startHeight,
+			Count:       test.count,
+		}
+		res, err := svr.GetRawBlocks(context.Background(), request)
+		if test.numBlks == 0 {
+			require.Error(err)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(test.numBlks, len(res.Blocks))
+		for i, blkPb := range res.Blocks {
+			require.Equal(test.startHeight+uint64(i), blkPb.Header.GetHeight())
+			require.NotNil(blkPb.Footer)
+		}
+	}
+}
+
+func TestServer_GetDelegateParticipation(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+
+	for _, test := range getDelegateParticipationTests {
+		request := &iotexapi.GetDelegateParticipationRequest{Window: test.window}
+		res, err := svr.GetDelegateParticipation(context.Background(), request)
+		if test.proposals == 0 {
+			require.Error(err)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(test.startHeight, res.StartHeight)
+		require.Equal(uint64(4), res.EndHeight)
+		// All the testing blocks are produced by the same producer without endorsements, and the other delegates have
+		// missed all the slots
+		numBlocks := res.EndHeight - res.StartHeight + 1
+		require.True(len(res.Delegates) > 1)
+		for _, delegate := range res.Delegates {
+			require.Equal(uint64(0), delegate.Endorsements)
+			if delegate.Delegate == ta.Addrinfo["producer"].String() {
+				require.Equal(test.proposals, delegate.Proposals)
+				require.Equal(uint64(0), delegate.MissedSlots)
+				continue
+			}
+			require.Equal(uint64(0), delegate.Proposals)
+			require.Equal(numBlocks, delegate.MissedSlots)
+		}
+	}
+}
+
+func TestServer_GetActPoolStats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tsf1, err := testutil.SignedTransfer(ta.Addrinfo["bravo"].String(), ta.Keyinfo["alfa"].PriKey, uint64(1),
+		big.NewInt(1), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(ta.Addrinfo["bravo"].String(), ta.Keyinfo["alfa"].PriKey, uint64(3),
+		big.NewInt(1), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	sender := ta.Addrinfo["alfa"].String()
+	ap := mock_actpool.NewMockActPool(ctrl)
+	ap.EXPECT().Stats().Return(actpool.Stats{
+		NumExecutable: 1,
+		NumQueued:     1,
+		Capacity:      10,
+		Bytes:         100,
+		GasPriceFloor: big.NewInt(5),
+	}).Times(3)
+	ap.EXPECT().PendingActionMap().Return(map[string][]action.SealedEnvelope{sender: {tsf1}}).Times(1)
+	ap.EXPECT().GetQueuedActs(sender).Return([]action.SealedEnvelope{tsf3}).Times(1)
+	svr := Server{ap: ap}
+
+	res, err := svr.GetActPoolStats(context.Background(), &iotexapi.GetActPoolStatsRequest{})
+	require.NoError(err)
+	require.Equal(uint64(1), res.Stats.NumExecutable)
+	require.Equal(uint64(1), res.Stats.NumQueued)
+	require.Equal(uint64(10), res.Stats.Capacity)
+	require.Equal(uint64(100), res.Stats.Bytes)
+	require.Equal("5", res.Stats.GasPriceFloor)
+	require.Empty(res.ExecutableActHashes)
+	require.Empty(res.QueuedActHashes)
+
+	res, err = svr.GetActPoolStats(context.Background(), &iotexapi.GetActPoolStatsRequest{Address: sender})
+	require.NoError(err)
+	h1 := tsf1.Hash()
+	h3 := tsf3.Hash()
+	require.Equal([]string{hex.EncodeToString(h1[:])}, res.ExecutableActHashes)
+	require.Equal([]string{hex.EncodeToString(h3[:])}, res.QueuedActHashes)
+
+	_, err = svr.GetActPoolStats(context.Background(), &iotexapi.GetActPoolStatsRequest{Address: "invalid"})
+	require.Error(err)
+}
+
+func TestServer_GetEpochStats(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.Indexer.NodeAddr = "node"
+	cfg.Indexer.WhetherLocalStore = true
+	cfg.DB.SQLITE3.SQLite3File = testIndexPath
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+	testutil.CleanupPath(t, testIndexPath)
+	defer testutil.CleanupPath(t, testIndexPath)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	svr.numDelegates = 2
+
+	// The stats are only available with the indexer
+	_, err = svr.GetEpochStats(context.Background(), &iotexapi.GetEpochStatsRequest{EpochNumber: 1})
+	require.Error(err)
+
+	idx := indexservice.NewServer(cfg, svr.bc)
+	require.NotNil(idx)
+	require.NoError(idx.Start(context.Background()))
+	defer func() {
+		require.NoError(idx.Stop(context.Background()))
+	}()
+	var numActions []uint64
+	for height := uint64(1); height <= svr.bc.TipHeight(); height++ {
+		blk, err := svr.bc.GetBlockByHeight(height)
+		require.NoError(err)
+		require.NoError(idx.Indexer().BuildIndex(blk))
+		numActions = append(numActions, uint64(len(blk.Actions)))
+	}
+	svr.idx = idx
+	svr.cfg.UseRDS = true
+
+	_, err = svr.GetEpochStats(context.Background(), &iotexapi.GetEpochStatsRequest{EpochNumber: 0})
+	require.Error(err)
+	// The testing chain has 4 blocks, so the 3rd epoch hasn't started
+	_, err = svr.GetEpochStats(context.Background(), &iotexapi.GetEpochStatsRequest{EpochNumber: 3})
+	require.Error(err)
+	for epochNum := uint64(1); epochNum <= 2; epochNum++ {
+		res, err := svr.GetEpochStats(context.Background(), &iotexapi.GetEpochStatsRequest{EpochNumber: epochNum})
+		require.NoError(err)
+		require.Equal(epochNum, res.Stats.EpochNumber)
+		require.Equal(2*epochNum-1, res.Stats.StartHeight)
+		require.Equal(2*epochNum, res.Stats.EndHeight)
+		require.Equal(numActions[2*epochNum-2]+numActions[2*epochNum-1], res.Stats.NumActions)
+		require.True(res.Stats.ActiveSenders > 0)
+	}
+}
+
+func TestServer_GetBlockSyncBufferStats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svr := &Server{}
+	_, err := svr.GetBlockSyncBufferStats(context.Background(), &iotexapi.GetBlockSyncBufferStatsRequest{})
+	require.Error(err)
+
+	stats := &iotexapi.BlockSyncBufferStats{Size: 16, InMemory: 4, Spilled: 2, SpilledBytes: 1024, CommitHeight: 10}
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	bs.EXPECT().BufferStats().Return(stats).Times(1)
+	svr.bs = bs
+	res, err := svr.GetBlockSyncBufferStats(context.Background(), &iotexapi.GetBlockSyncBufferStatsRequest{})
+	require.NoError(err)
+	require.Equal(stats, res.Stats)
+}
+
+func TestServer_GetBlockSyncPeerScores(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svr := &Server{}
+	_, err := svr.GetBlockSyncPeerScores(context.Background(), &iotexapi.GetBlockSyncPeerScoresRequest{})
+	require.Error(err)
+
+	scores := []*iotexapi.PeerScore{
+		{PeerID: "good", Score: 100, Requests: 2, Responses: 4},
+		{PeerID: "bad", Score: -25, Requests: 1, Responses: 1, InvalidBlocks: 5},
+	}
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	bs.EXPECT().PeerScores().Return(scores).Times(1)
+	svr.bs = bs
+	res, err := svr.GetBlockSyncPeerScores(context.Background(), &iotexapi.GetBlockSyncPeerScoresRequest{})
+	require.NoError(err)
+	require.Equal(scores, res.Peers)
+}
+
+func TestServer_GetForkEvents(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svr := &Server{}
+	_, err := svr.GetForkEvents(context.Background(), &iotexapi.GetForkEventsRequest{})
+	require.Error(err)
+
+	events := []*iotexapi.ForkEvent{
+		{Height: 5, ChosenHash: "aa", CompetingHash: "bb", Source: "sync", Reason: "committed", Timestamp: 10},
+	}
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	bs.EXPECT().ForkEvents().Return(events).Times(1)
+	svr.bs = bs
+	res, err := svr.GetForkEvents(context.Background(), &iotexapi.GetForkEventsRequest{})
+	require.NoError(err)
+	require.Equal(events, res.Events)
+}
+
+func TestServer_GetConsensusTimeline(t *testing.T) {
+	require := require.New(t)
+
+	svr := &Server{}
+	_, err := svr.GetConsensusTimeline(context.Background(), &iotexapi.GetConsensusTimelineRequest{})
+	require.Error(err)
+
+	rounds := []*iotexapi.RoundTimeline{
+		{Height: 6, Round: 1, Proposer: "io1", ProposalReceived: 300, Committed: 2500},
+		{Height: 6, Round: 0, Proposer: "io2"},
+	}
+	svr.timeline = func(count int) []*iotexapi.RoundTimeline {
+		return rounds[:count]
+	}
+	res, err := svr.GetConsensusTimeline(context.Background(), &iotexapi.GetConsensusTimelineRequest{Count: 1})
+	require.NoError(err)
+	require.Equal(rounds[:1], res.Rounds)
+}
+
+func TestServer_GetStateDiff(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	svr := &Server{bc: chain}
+	chain.EXPECT().StateDiff(uint64(5)).Return(nil, errors.New("state diff isn't enabled")).Times(1)
+	_, err := svr.GetStateDiff(context.Background(), &iotexapi.GetStateDiffRequest{Height: 5})
+	require.Error(err)
+
+	diff := &blockchain.StateDiff{
+		Height:            6,
+		BlockHash:         "ab",
+		Error:             "state root hash does not match",
+		ExpectedStateRoot: "01",
+		StateRoot:         "02",
+		Actions: []*blockchain.ActionStateDiff{
+			{
+				ActionHash: "cd",
+				Changes: []*blockchain.StateChange{
+					{Key: "0a", Address: "io1", Before: "0b", After: "0c"},
+					{Key: "1a", After: "1c"},
+				},
+			},
+			{ActionHash: "ef"},
+		},
+	}
+	chain.EXPECT().StateDiff(uint64(6)).Return(diff, nil).Times(1)
+	res, err := svr.GetStateDiff(context.Background(), &iotexapi.GetStateDiffRequest{Height: 6})
+	require.NoError(err)
+	require.Equal(&iotexapi.StateDiff{
+		Height:            6,
+		BlkHash:           "ab",
+		Error:             "state root hash does not match",
+		ExpectedStateRoot: "01",
+		StateRoot:         "02",
+		Actions: []*iotexapi.ActionStateDiff{
+			{
+				ActHash: "cd",
+				Changes: []*iotexapi.StateChange{
+					{Key: "0a", Address: "io1", Before: "0b", After: "0c"},
+					{Key: "1a", After: "1c"},
+				},
+			},
+			{ActHash: "ef"},
+		},
+	}, res.StateDiff)
+}
+
+func TestServer_GetStateGrowth(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	sf := mock_factory.NewMockFactory(ctrl)
+	svr := &Server{bc: chain}
+	chain.EXPECT().GetFactory().Return(sf).Times(2)
+	sf.EXPECT().StateGrowth().Return(uint64(0), factory.StateGrowth{}, errors.New("failed to get state growth")).Times(1)
+	_, err := svr.GetStateGrowth(context.Background(), &iotexapi.GetStateGrowthRequest{})
+	require.Error(err)
+
+	sf.EXPECT().StateGrowth().Return(uint64(7), factory.StateGrowth{TrieNodes: 12, StateBytes: -3}, nil).Times(1)
+	res, err := svr.GetStateGrowth(context.Background(), &iotexapi.GetStateGrowthRequest{})
+	require.NoError(err)
+	require.Equal(uint64(7), res.Height)
+	require.Equal(int64(12), res.TrieNodes)
+	require.Equal(int64(-3), res.StateBytes)
+}
+
+type syncStatusStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *iotexapi.StreamBlockSyncStatusResponse
+}
+
+func (s *syncStatusStream) Context() context.Context { return s.ctx }
+
+func (s *syncStatusStream) Send(res *iotexapi.StreamBlockSyncStatusResponse) error {
+	s.sent <- res
+	return nil
+}
+
+func TestServer_BlockSyncStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svr := &Server{}
+	_, err := svr.GetBlockSyncStatus(context.Background(), &iotexapi.GetBlockSyncStatusRequest{})
+	require.Error(err)
+	require.Error(svr.StreamBlockSyncStatus(&iotexapi.StreamBlockSyncStatusRequest{}, &syncStatusStream{}))
+
+	status := &iotexapi.BlockSyncStatus{
+		StartingHeight:  10,
+		CurrentHeight:   15,
+		TargetHeight:    20,
+		Peers:           []string{"peer"},
+		BlocksPerSecond: 2.5,
+	}
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	bs.EXPECT().SyncStatus().Return(status).Times(2)
+	svr.bs = bs
+	res, err := svr.GetBlockSyncStatus(context.Background(), &iotexapi.GetBlockSyncStatusRequest{})
+	require.NoError(err)
+	require.Equal(status, res.Status)
+
+	// The stream starts with the current status, followed by the published ones
+	var subscribed chan<- *iotexapi.BlockSyncStatus
+	bs.EXPECT().SubscribeSyncStatus(gomock.Any()).Do(func(ch chan<- *iotexapi.BlockSyncStatus) {
+		subscribed = ch
+	}).Times(1)
+	bs.EXPECT().UnsubscribeSyncStatus(gomock.Any()).Times(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &syncStatusStream{ctx: ctx, sent: make(chan *iotexapi.StreamBlockSyncStatusResponse, 2)}
+	done := make(chan error)
+	go func() {
+		done <- svr.StreamBlockSyncStatus(&iotexapi.StreamBlockSyncStatusRequest{}, stream)
+	}()
+	require.Equal(status, (<-stream.sent).Status)
+	next := &iotexapi.BlockSyncStatus{StartingHeight: 10, CurrentHeight: 18, TargetHeight: 20}
+	subscribed <- next
+	require.Equal(next, (<-stream.sent).Status)
+	cancel()
+	require.NoError(<-done)
+}
+
 func TestServer_GetChainMeta(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
@@ -561,6 +1066,199 @@ func TestServer_GetChainMeta(t *testing.T) {
 	}
 }
 
+func TestServer_GetChainMetaSections(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cfg := newConfig()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	svr, err := createServer(cfg, true)
+	require.NoError(err)
+
+	// The sections of the modules which aren't set are left unset
+	res, err := svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Nil(res.Sync)
+	require.Nil(res.Consensus)
+	require.Nil(res.Network)
+	require.Equal(uint64(4), res.ActPool.NumExecutable)
+
+	status := &iotexapi.BlockSyncStatus{StartingHeight: 1, CurrentHeight: 3, TargetHeight: 5}
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	bs.EXPECT().SyncStatus().Return(status).Times(1)
+	bs.EXPECT().CatchingUp().Return(true).Times(1)
+	svr.bs = bs
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().Metrics().Return(scheme.ConsensusMetrics{
+		LatestEpoch:         2,
+		LatestHeight:        5,
+		LatestDelegates:     []string{"a", "b"},
+		LatestBlockProducer: "a",
+		Candidates:          []string{"a", "b", "c"},
+		ProposalsReceived:   4,
+		RoundTimeouts:       1,
+	}, nil).Times(1)
+	svr.consensus = cs
+	addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/4689")
+	require.NoError(err)
+	svr.networkInfo = func() peerstore.PeerInfo { return peerstore.PeerInfo{Addrs: []multiaddr.Multiaddr{addr}} }
+	svr.neighbors = func(_ context.Context) ([]peerstore.PeerInfo, error) {
+		return []peerstore.PeerInfo{{}, {}}, nil
+	}
+	dir, err := ioutil.TempDir("", "dbsize")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	chainPath := filepath.Join(dir, "chain.db")
+	require.NoError(ioutil.WriteFile(chainPath, make([]byte, 10), 0600))
+	// The size of a db in a directory is the total size of its files
+	triePath := filepath.Join(dir, "trie")
+	require.NoError(os.Mkdir(triePath, 0700))
+	require.NoError(ioutil.WriteFile(filepath.Join(triePath, "000001.vlog"), make([]byte, 3), 0600))
+	require.NoError(ioutil.WriteFile(filepath.Join(triePath, "000001.sst"), make([]byte, 4), 0600))
+	svr.dbPaths = map[string]string{"trie": triePath, "chain": chainPath}
+
+	res, err = svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Equal(status, res.Sync.BlockSync)
+	require.True(res.Sync.CatchingUp)
+	require.Equal(uint64(2), res.Consensus.Epoch)
+	require.Equal([]string{"a", "b"}, res.Consensus.Delegates)
+	require.Equal(uint64(3), res.Consensus.NumCandidates)
+	require.Equal(uint64(1), res.Consensus.RoundTimeouts)
+	// The self network information is only served by the admin endpoint
+	require.Empty(res.Network.Addresses)
+	require.Equal(uint64(2), res.Network.NumNeighbors)
+	network, err := svr.NetworkMeta(context.Background())
+	require.NoError(err)
+	require.Equal([]string{"/ip4/127.0.0.1/tcp/4689"}, network.Addresses)
+	require.Equal(uint64(2), network.NumNeighbors)
+	storage := svr.StorageMeta()
+	require.Equal([]*iotexapi.DBSize{
+		{Name: "chain", Path: chainPath, Bytes: 10},
+		{Name: "trie", Path: triePath, Bytes: 7},
+	}, storage.Dbs)
+	require.Equal(uint64(17), storage.TotalBytes)
+
+	// The neighbors failing to be fetched leave the network section unset, rather than failing the chain meta
+	svr.neighbors = func(_ context.Context) ([]peerstore.PeerInfo, error) {
+		return nil, errors.New("not connected")
+	}
+	svr.consensus = nil
+	bs.EXPECT().SyncStatus().Return(status).Times(1)
+	bs.EXPECT().CatchingUp().Return(true).Times(1)
+	res, err = svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.NotNil(res.ChainMeta)
+	require.Nil(res.Network)
+	_, err = svr.NetworkMeta(context.Background())
+	require.Error(err)
+}
+
+func TestServer_BlockFinality(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	svr.numDelegates = 3
+
+	// The testing blocks are committed without endorsements
+	res, err := svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Equal(uint64(0), res.ChainMeta.IrreversibleHeight)
+	blkMetas, err := svr.getBlockMetas(0, 1)
+	require.NoError(err)
+	require.False(blkMetas.BlkMetas[0].Finalized)
+
+	// Commit a block with the commit endorsements of 2 out of 3 delegates, which isn't enough
+	commitBlock := func(endorsers ...string) *block.Block {
+		nonce, err := svr.bc.Nonce(ta.Addrinfo["charlie"].String())
+		require.NoError(err)
+		tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["charlie"].PriKey, nonce+1,
+			big.NewInt(1), []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+		require.NoError(err)
+		blk, err := svr.bc.MintNewBlock(
+			map[string][]action.SealedEnvelope{ta.Addrinfo["charlie"].String(): {tsf}},
+			ta.Keyinfo["producer"].PubKey,
+			ta.Keyinfo["producer"].PriKey,
+			ta.Addrinfo["producer"].String(),
+			time.Now().Unix(),
+		)
+		require.NoError(err)
+		blkHash := blk.HashBlock()
+		vote := endorsement.NewConsensusVote(blkHash[:], blk.Height(), 0, endorsement.COMMIT)
+		set := &iotextypes.EndorsementSet{BlockHash: blkHash[:]}
+		for _, endorser := range endorsers {
+			set.Endorsements = append(set.Endorsements, endorsement.NewEndorsement(
+				vote, ta.Keyinfo[endorser].PubKey, ta.Keyinfo[endorser].PriKey, ta.Addrinfo[endorser].String(),
+			).ToProtoMsg())
+		}
+		blkPb := blk.ConvertToBlockPb()
+		blkPb.Footer = &iotextypes.BlockFooter{Endorsements: set}
+		endorsed := &block.Block{}
+		require.NoError(endorsed.ConvertFromBlockPb(blkPb))
+		endorsed.Receipts = blk.Receipts
+		endorsed.WorkingSet = blk.WorkingSet
+		require.NoError(svr.bc.CommitBlock(endorsed))
+		return endorsed
+	}
+	blk := commitBlock("alfa", "bravo")
+	require.False(svr.endorsedByQuorum(blk))
+	res, err = svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Equal(uint64(0), res.ChainMeta.IrreversibleHeight)
+
+	// Commit a block with the commit endorsements of all the delegates
+	blk = commitBlock("alfa", "bravo", "delta")
+	require.True(svr.endorsedByQuorum(blk))
+	// The configured commit threshold is applied
+	svr.numDelegates = 4
+	svr.commitThreshold = scheme.CommitThreshold{Numerator: 3, Denominator: 4}
+	require.False(svr.endorsedByQuorum(blk))
+	svr.commitThreshold = scheme.DefaultCommitThreshold
+	require.True(svr.endorsedByQuorum(blk))
+	svr.numDelegates = 3
+	blkHash := blk.HashBlock()
+	blkMetas, err = svr.getBlockMeta(hex.EncodeToString(blkHash[:]))
+	require.NoError(err)
+	require.True(blkMetas.BlkMetas[0].Finalized)
+	res, err = svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Equal(blk.Height(), res.ChainMeta.IrreversibleHeight)
+
+	actHash := blk.Actions[0].Hash()
+	actions, err := svr.getAction(hex.EncodeToString(actHash[:]), false)
+	require.NoError(err)
+	require.Equal([]bool{true}, actions.Finalized)
+	// The actions of the previous block are irreversible as well
+	actions, err = svr.getActions(0, uint64(len(blk.Actions)+1))
+	require.NoError(err)
+	for i := range blk.Actions {
+		require.True(actions.Finalized[i])
+	}
+	require.True(actions.Finalized[len(blk.Actions)])
+
+	// The finalized height is kept while the blocks above aren't endorsed by the quorum
+	next := commitBlock("alfa")
+	res, err = svr.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.Equal(blk.Height(), res.ChainMeta.IrreversibleHeight)
+	nextHash := next.HashBlock()
+	blkMetas, err = svr.getBlockMeta(hex.EncodeToString(nextHash[:]))
+	require.NoError(err)
+	require.False(blkMetas.BlkMetas[0].Finalized)
+}
+
 func TestServer_SendAction(t *testing.T) {
 	require := require.New(t)
 
@@ -570,12 +1268,16 @@ func TestServer_SendAction(t *testing.T) {
 	chain := mock_blockchain.NewMockBlockchain(ctrl)
 	mDp := mock_dispatcher.NewMockDispatcher(ctrl)
 	broadcastHandlerCount := 0
+	trackedCount := 0
 	svr := Server{bc: chain, dp: mDp, broadcastHandler: func(_ context.Context, _ uint32, _ proto.Message) error {
 		broadcastHandlerCount++
 		return nil
+	}, actionTracker: func(*iotextypes.Action) error {
+		trackedCount++
+		return nil
 	}}
 
-	chain.EXPECT().ChainID().Return(uint32(1)).Times(4)
+	chain.EXPECT().ChainID().Return(uint32(1)).Times(6)
 	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
 	for i, test := range sendActionTests {
@@ -583,6 +1285,17 @@ func TestServer_SendAction(t *testing.T) {
 		_, err := svr.SendAction(context.Background(), request)
 		require.NoError(err)
 		require.Equal(i+1, broadcastHandlerCount)
+		require.Equal(i+1, trackedCount)
+	}
+
+	// The action is added into actpool as a local action instead of via dispatcher
+	ap := mock_actpool.NewMockActPool(ctrl)
+	svr.ap = ap
+	ap.EXPECT().AddLocal(gomock.Any()).Return(nil).Times(len(sendActionTests))
+	for _, test := range sendActionTests {
+		request := &iotexapi.SendActionRequest{Action: test.actionPb}
+		_, err := svr.SendAction(context.Background(), request)
+		require.NoError(err)
 	}
 }
 
@@ -604,9 +1317,92 @@ func TestServer_GetReceiptByAction(t *testing.T) {
 		require.NoError(err)
 		receiptPb := res.Receipt
 		require.Equal(test.status, receiptPb.Status)
+		fee := res.Fee
+		require.Equal(receiptPb.GasConsumed, fee.IntrinsicGas+fee.ExecutionGas)
+		totalFee := big.NewInt(0).Mul(big.NewInt(testutil.TestGasPrice), big.NewInt(0).SetUint64(receiptPb.GasConsumed))
+		require.Equal(totalFee.String(), fee.TotalFee)
 	}
 }
 
+func TestGetActionFee(t *testing.T) {
+	require := require.New(t)
+
+	intrinsicGas, err := testExecution1.IntrinsicGas()
+	require.NoError(err)
+	receipt := &action.Receipt{GasConsumed: intrinsicGas + 100}
+	fee, err := getActionFee(testExecution1, receipt, 0, 0, "", false)
+	require.NoError(err)
+	require.Equal(intrinsicGas, fee.IntrinsicGas)
+	require.Equal(uint64(100), fee.ExecutionGas)
+	require.Equal("10", fee.GasPrice)
+	require.Equal(big.NewInt(0).SetUint64((intrinsicGas+100)*10).String(), fee.TotalFee)
+	require.Equal(rewarding.NewProtocol().Address().String(), fee.Beneficiary)
+
+	// Without receipt, the action is charged by the intrinsic gas only
+	fee, err = getActionFee(testExecution1, nil, 0, 0, "", false)
+	require.NoError(err)
+	require.Equal(uint64(0), fee.ExecutionGas)
+	require.Equal(big.NewInt(0).SetUint64(intrinsicGas*10).String(), fee.TotalFee)
+
+	// Free action has no beneficiary
+	fee, err = getActionFee(testTransfer1, nil, 0, 0, "", false)
+	require.NoError(err)
+	require.Equal("0", fee.TotalFee)
+	require.Equal("", fee.Beneficiary)
+	require.Equal(uint64(0), fee.FreeGas)
+
+	// With free gas, the action at zero gas price is charged to the free gas quota by its intrinsic gas
+	fee, err = getActionFee(testTransfer1, nil, 0, 0, "", true)
+	require.NoError(err)
+	transferGas, err := testTransfer1.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(transferGas, fee.FreeGas)
+	require.Equal("0", fee.TotalFee)
+	fee, err = getActionFee(testExecution1, nil, 0, 0, "", true)
+	require.NoError(err)
+	require.Equal(uint64(0), fee.FreeGas)
+
+	// The fee is split before the rest is deposited into the rewarding fund
+	fee, err = getActionFee(testExecution1, nil, 20, 30, ta.Addrinfo["producer"].String(), false)
+	require.NoError(err)
+	total := intrinsicGas * 10
+	require.Equal(big.NewInt(0).SetUint64(total*20/100).String(), fee.BurnedFee)
+	require.Equal(big.NewInt(0).SetUint64(total*30/100).String(), fee.ProducerFee)
+	require.Equal(ta.Addrinfo["producer"].String(), fee.Producer)
+	require.Equal(big.NewInt(0).SetUint64(total-total*20/100-total*30/100).String(), fee.FundFee)
+	require.Equal(rewarding.NewProtocol().Address().String(), fee.Beneficiary)
+
+	// Without the producer, its share is deposited into the fund
+	fee, err = getActionFee(testExecution1, nil, 40, 60, "", false)
+	require.NoError(err)
+	require.Equal(big.NewInt(0).SetUint64(total*40/100).String(), fee.BurnedFee)
+	require.Equal("0", fee.ProducerFee)
+	require.Equal("", fee.Producer)
+	require.Equal(big.NewInt(0).SetUint64(total-total*40/100).String(), fee.FundFee)
+	require.Equal(rewarding.NewProtocol().Address().String(), fee.Beneficiary)
+}
+
+func TestServer_ConfirmedActionFee(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	svr := Server{bc: chain}
+
+	// The fee of the action whose receipt is pruned is empty
+	chain.EXPECT().GetReceiptByActionHash(testTransfer1.Hash()).
+		Return(nil, errors.Wrap(blockchain.ErrReceiptPruned, "receipts are pruned")).Times(1)
+	fee, err := svr.confirmedActionFee(testTransfer1)
+	require.NoError(err)
+	require.Equal(&iotextypes.ActionFee{}, fee)
+
+	// The other errors are returned
+	chain.EXPECT().GetReceiptByActionHash(testTransfer1.Hash()).Return(nil, errors.New("db error")).Times(1)
+	_, err = svr.confirmedActionFee(testTransfer1)
+	require.Error(err)
+}
+
 func TestServer_ReadContract(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
@@ -630,6 +1426,33 @@ func TestServer_ReadContract(t *testing.T) {
 		require.NoError(err)
 		require.Equal(test.retValue, res.Data)
 	}
+
+	// The contract reads the slot 0 of its storage with the overrides
+	hash, err := toHash256(readContractTests[0].execHash)
+	require.NoError(err)
+	exec, err := svr.bc.GetActionByActionHash(hash)
+	require.NoError(err)
+	// PUSH1 0, SLOAD, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, RETURN
+	code, err := hex.DecodeString("60005460005260206000f3")
+	require.NoError(err)
+	value := bytes.Repeat([]byte{1}, 32)
+	override := &iotexapi.StateOverride{
+		Address: exec.Action().(*action.Execution).Contract(),
+		Code:    code,
+		Storage: []*iotexapi.StorageOverride{{Key: make([]byte, 32), Value: value}},
+	}
+	request := &iotexapi.ReadContractRequest{Action: exec.Proto(), StateOverrides: []*iotexapi.StateOverride{override}}
+	res, err := svr.ReadContract(context.Background(), request)
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(value), res.Data)
+
+	override.Balance = "not a number"
+	_, err = svr.ReadContract(context.Background(), request)
+	require.Error(err)
+	override.Balance = ""
+	override.Storage[0].Key = []byte{0}
+	_, err = svr.ReadContract(context.Background(), request)
+	require.Error(err)
 }
 
 func TestServer_SuggestGasPrice(t *testing.T) {
@@ -649,6 +1472,18 @@ func TestServer_SuggestGasPrice(t *testing.T) {
 		require.NoError(err)
 		require.Equal(test.suggestedGasPrice, res.GasPrice)
 	}
+
+	// The suggested gas price is raised to the gas price floor of actpool
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	ap.EXPECT().Stats().Return(actpool.Stats{GasPriceFloor: big.NewInt(100)}).Times(1)
+	svr.ap = ap
+	res, err := svr.SuggestGasPrice(context.Background(), &iotexapi.SuggestGasPriceRequest{})
+	require.NoError(err)
+	require.Equal(uint64(100), res.GasPrice)
 }
 
 func TestServer_EstimateGasForAction(t *testing.T) {
@@ -676,6 +1511,32 @@ func TestServer_EstimateGasForAction(t *testing.T) {
 	}
 }
 
+func TestServer_ReadState(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+
+	for _, test := range readStateTests {
+		res, err := svr.ReadState(context.Background(), &iotexapi.ReadStateRequest{
+			ProtocolID: []byte(test.protocolID),
+			MethodName: []byte(test.methodName),
+		})
+		if test.expectedErr {
+			require.Error(err)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(test.expectedData, string(res.Data))
+	}
+}
+
 func addProducerToFactory(sf factory.Factory) error {
 	ws, err := sf.NewWorkingSet()
 	if err != nil {
@@ -877,11 +1738,16 @@ func setupChain(cfg config.Config) (blockchain.Blockchain, error) {
 
 	// create chain
 	genesisConfig := genesis.Default
+	registry := protocol.Registry{}
+	if err := registry.Register(rewarding.ProtocolID, rewarding.NewProtocol()); err != nil {
+		return nil, err
+	}
 	bc := blockchain.NewBlockchain(
 		cfg,
 		blockchain.PrecreatedStateFactoryOption(sf),
 		blockchain.InMemDaoOption(),
 		blockchain.GenesisOption(genesisConfig),
+		blockchain.RegistryOption(&registry),
 	)
 	if bc == nil {
 		return nil, errors.New("failed to create blockchain")
@@ -949,13 +1815,24 @@ func createServer(cfg config.Config, needActPool bool) (*Server, error) {
 		}
 	}
 
-	apiCfg := config.API{TpsWindow: 10, MaxTransferPayloadBytes: 1024, GasStation: cfg.API.GasStation}
+	apiCfg := config.API{
+		TpsWindow:               10,
+		MaxTransferPayloadBytes: 1024,
+		GasStation:              cfg.API.GasStation,
+		RangeQueryLimit:         100,
+	}
 
 	svr := &Server{
 		bc:  bc,
 		ap:  ap,
 		cfg: apiCfg,
 		gs:  gasstation.NewGasStation(bc, apiCfg),
+
+		commitThreshold: scheme.DefaultCommitThreshold,
+	}
+	svr.registry = &protocol.Registry{}
+	if err := svr.registry.Register(rewarding.ProtocolID, rewarding.NewProtocol()); err != nil {
+		return nil, err
 	}
 
 	return svr, nil
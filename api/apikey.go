@@ -0,0 +1,254 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// APIKeyMetadataKey is the key of the gRPC metadata carrying the API key of a request
+const APIKeyMetadataKey = "apikey"
+
+// apiKeyRateWindow is the window of the rate limit of an API key
+const apiKeyRateWindow = time.Minute
+
+// ErrAPIKeyNotFound indicates that the API key doesn't exist
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+var apiKeyMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_api_key_requests",
+		Help: "IoTeX API requests by API key and result.",
+	},
+	[]string{"key", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(apiKeyMtc)
+}
+
+type (
+	// APIKey is an API key assigned to a downstream user of the API, which is only known by the hash of its secret
+	APIKey struct {
+		// ID identifies the key, e.g., to revoke it
+		ID string `json:"id"`
+		// Name is the user of the key
+		Name string `json:"name"`
+		// RateLimit is the max number of the requests per minute, 0 means no limit
+		RateLimit uint64 `json:"rateLimit"`
+		// Methods are the API methods allowed, e.g., GetAccount, empty means all
+		Methods   []string  `json:"methods,omitempty"`
+		CreatedAt time.Time `json:"createdAt"`
+		Hash      string    `json:"hash"`
+	}
+
+	// APIKeyStore keeps the API keys in a local file, and checks the requests against the quotas of their keys
+	APIKeyStore struct {
+		mutex   sync.Mutex
+		path    string
+		keys    map[string]*APIKey // hash of secret -> key
+		windows map[string]*keyWindow
+	}
+
+	keyWindow struct {
+		start time.Time
+		count uint64
+	}
+
+	apiKeyFile struct {
+		Keys []*APIKey `json:"keys"`
+	}
+)
+
+// NewAPIKeyStore loads the API keys from the file, which is created once a key is added if it doesn't exist
+func NewAPIKeyStore(path string) (*APIKeyStore, error) {
+	s := &APIKeyStore{
+		path:    path,
+		keys:    make(map[string]*APIKey),
+		windows: make(map[string]*keyWindow),
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read API key file %s", path)
+	}
+	var file apiKeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal API key file %s", path)
+	}
+	for _, key := range file.Keys {
+		s.keys[key.Hash] = key
+	}
+	return s, nil
+}
+
+// Create adds a new API key, and returns its secret, which isn't stored and can't be retrieved later
+func (s *APIKeyStore) Create(name string, rateLimit uint64, methods []string) (string, *APIKey, error) {
+	if name == "" {
+		return "", nil, errors.New("name of API key is empty")
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, errors.Wrap(err, "failed to generate API key")
+	}
+	secret := hex.EncodeToString(b)
+	keyHash := secretHash(secret)
+	key := &APIKey{
+		ID:        keyHash[:16],
+		Name:      name,
+		RateLimit: rateLimit,
+		Methods:   methods,
+		CreatedAt: time.Now().UTC(),
+		Hash:      keyHash,
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys[keyHash] = key
+	if err := s.save(); err != nil {
+		delete(s.keys, keyHash)
+		return "", nil, err
+	}
+	res := *key
+	return secret, &res, nil
+}
+
+// Revoke removes the API key of the ID
+func (s *APIKeyStore) Revoke(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for keyHash, key := range s.keys {
+		if key.ID != id {
+			continue
+		}
+		delete(s.keys, keyHash)
+		if err := s.save(); err != nil {
+			s.keys[keyHash] = key
+			return err
+		}
+		delete(s.windows, keyHash)
+		return nil
+	}
+	return errors.Wrapf(ErrAPIKeyNotFound, "id %s", id)
+}
+
+// List returns the API keys sorted by creation time
+func (s *APIKeyStore) List() []APIKey {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	keys := make([]APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, *key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys
+}
+
+// authorize checks the request of the method with the secret against the allowed methods and the rate limit of its key
+func (s *APIKeyStore) authorize(secret, method string, now time.Time) error {
+	keyHash := secretHash(secret)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	key, ok := s.keys[keyHash]
+	if !ok {
+		apiKeyMtc.WithLabelValues("unknown", "unauthenticated").Inc()
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	if len(key.Methods) > 0 {
+		allowed := false
+		for _, m := range key.Methods {
+			if m == method {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			apiKeyMtc.WithLabelValues(key.ID, "denied").Inc()
+			return status.Errorf(codes.PermissionDenied, "method %s is not allowed for API key %s", method, key.ID)
+		}
+	}
+	if key.RateLimit > 0 {
+		w, ok := s.windows[keyHash]
+		if !ok || now.Sub(w.start) >= apiKeyRateWindow {
+			w = &keyWindow{start: now}
+			s.windows[keyHash] = w
+		}
+		if w.count >= key.RateLimit {
+			apiKeyMtc.WithLabelValues(key.ID, "rate_limited").Inc()
+			return status.Errorf(codes.ResourceExhausted, "API key %s exceeds the rate limit", key.ID)
+		}
+		w.count++
+	}
+	apiKeyMtc.WithLabelValues(key.ID, "served").Inc()
+	return nil
+}
+
+// save writes the keys into a temporary file first, so that the key file is never left partially written
+func (s *APIKeyStore) save() error {
+	file := apiKeyFile{Keys: make([]*APIKey, 0, len(s.keys))}
+	for _, key := range s.keys {
+		file.Keys = append(file.Keys, key)
+	}
+	sort.Slice(file.Keys, func(i, j int) bool { return file.Keys[i].ID < file.Keys[j].ID })
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal API keys")
+	}
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write API key file %s", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, s.path), "failed to rename API key file %s", tmp)
+}
+
+// KeyStore returns the API keys of the server, which is nil if the API keys are disabled
+func (api *Server) KeyStore() *APIKeyStore {
+	return api.keys
+}
+
+// authorize checks the API key of the request, if the API keys are enabled
+func (api *Server) authorize(ctx context.Context, fullMethod string) error {
+	if api.keys == nil {
+		return nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(APIKeyMetadataKey)) == 0 || md.Get(APIKeyMetadataKey)[0] == "" {
+		if api.cfg.RequireAPIKey {
+			return status.Error(codes.Unauthenticated, "API key is required")
+		}
+		return nil
+	}
+	return api.keys.authorize(md.Get(APIKeyMetadataKey)[0], path.Base(fullMethod), time.Now())
+}
+
+func secretHash(secret string) string {
+	h := hash.Hash256b([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
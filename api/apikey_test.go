@@ -0,0 +1,105 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestAPIKeyStore(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(os.TempDir(), "api-keys.json")
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	s, err := NewAPIKeyStore(path)
+	require.NoError(err)
+	require.Empty(s.List())
+	_, _, err = s.Create("", 0, nil)
+	require.Error(err)
+	secret1, key1, err := s.Create("explorer", 2, nil)
+	require.NoError(err)
+	secret2, key2, err := s.Create("wallet", 0, []string{"GetAccount", "SendAction"})
+	require.NoError(err)
+	require.NotEqual(secret1, secret2)
+	require.Len(s.List(), 2)
+
+	now := time.Now()
+	code := func(err error) codes.Code { return status.Code(err) }
+	require.Equal(codes.Unauthenticated, code(s.authorize("unknown", "GetAccount", now)))
+	// The methods not allowed are denied
+	require.NoError(s.authorize(secret2, "GetAccount", now))
+	require.Equal(codes.PermissionDenied, code(s.authorize(secret2, "ReadState", now)))
+	// The requests beyond the rate limit are rejected until the next window
+	require.NoError(s.authorize(secret1, "ReadState", now))
+	require.NoError(s.authorize(secret1, "GetAccount", now))
+	require.Equal(codes.ResourceExhausted, code(s.authorize(secret1, "GetAccount", now)))
+	require.NoError(s.authorize(secret1, "GetAccount", now.Add(apiKeyRateWindow)))
+
+	// The keys are loaded from the file, and the secrets aren't stored
+	s, err = NewAPIKeyStore(path)
+	require.NoError(err)
+	keys := s.List()
+	require.Len(keys, 2)
+	for _, key := range keys {
+		require.Contains([]string{key1.ID, key2.ID}, key.ID)
+		require.NotEqual(secret1, key.Hash)
+		require.NotEqual(secret2, key.Hash)
+	}
+	require.NoError(s.authorize(secret2, "SendAction", now))
+
+	require.Equal(ErrAPIKeyNotFound, errors.Cause(s.Revoke("unknown")))
+	require.NoError(s.Revoke(key2.ID))
+	require.Equal(codes.Unauthenticated, code(s.authorize(secret2, "GetAccount", now)))
+	s, err = NewAPIKeyStore(path)
+	require.NoError(err)
+	require.Len(s.List(), 1)
+	require.Equal(key1.ID, s.List()[0].ID)
+}
+
+func TestServerAuthorize(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(os.TempDir(), "api-keys.json")
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+
+	method := "/iotexapi.APIService/GetAccount"
+	svr := &Server{cfg: config.Default.API}
+	require.Nil(svr.KeyStore())
+	require.NoError(svr.authorize(context.Background(), method))
+
+	keys, err := NewAPIKeyStore(path)
+	require.NoError(err)
+	secret, _, err := keys.Create("explorer", 0, []string{"GetAccount"})
+	require.NoError(err)
+	svr.keys = keys
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyMetadataKey, key))
+	}
+	require.NoError(svr.authorize(context.Background(), method))
+	require.NoError(svr.authorize(withKey(secret), method))
+	require.Equal(codes.Unauthenticated, status.Code(svr.authorize(withKey("unknown"), method)))
+	require.Equal(codes.PermissionDenied, status.Code(svr.authorize(withKey(secret), "/iotexapi.APIService/ReadState")))
+
+	// The requests without a key are rejected if the key is required
+	svr.cfg.RequireAPIKey = true
+	require.Equal(codes.Unauthenticated, status.Code(svr.authorize(context.Background(), method)))
+	require.NoError(svr.authorize(withKey(secret), method))
+}
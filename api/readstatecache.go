@@ -0,0 +1,94 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+var readStateCacheMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_api_read_state_cache",
+		Help: "IoTeX API read state cache counter.",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(readStateCacheMtc)
+}
+
+// readStateCache caches the results of the protocol reads at the tip, e.g., the delegate lists and the reward
+// settings, which are the same until the next block. The results are keyed by the protocol, the method and the
+// arguments, and are dropped once the tip moves, either to a new block or onto another branch in a reorg
+type readStateCache struct {
+	mutex   sync.Mutex
+	size    int
+	height  uint64
+	tipHash hash.Hash256
+	results map[hash.Hash256][]byte
+}
+
+func newReadStateCache(size int) *readStateCache {
+	return &readStateCache{
+		size:    size,
+		results: make(map[hash.Hash256][]byte),
+	}
+}
+
+// get returns the cached result of the read at the tip
+func (c *readStateCache) get(height uint64, tipHash hash.Hash256, key hash.Hash256) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.moveTo(height, tipHash)
+	data, ok := c.results[key]
+	if ok {
+		readStateCacheMtc.WithLabelValues("hit").Inc()
+	} else {
+		readStateCacheMtc.WithLabelValues("miss").Inc()
+	}
+	return data, ok
+}
+
+// put caches the result of the read at the tip. The result isn't cached if the cache is full, until the tip moves
+func (c *readStateCache) put(height uint64, tipHash hash.Hash256, key hash.Hash256, data []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.moveTo(height, tipHash)
+	if _, ok := c.results[key]; !ok && len(c.results) >= c.size {
+		return
+	}
+	c.results[key] = data
+}
+
+// moveTo drops the cached results if the tip has moved
+func (c *readStateCache) moveTo(height uint64, tipHash hash.Hash256) {
+	if c.height == height && c.tipHash == tipHash {
+		return
+	}
+	c.height, c.tipHash = height, tipHash
+	if len(c.results) > 0 {
+		c.results = make(map[hash.Hash256][]byte)
+	}
+}
+
+// readStateKey returns the key of the read, where each part is prefixed with its length, so that the parts can't be
+// shifted from one to another
+func readStateKey(in *iotexapi.ReadStateRequest) hash.Hash256 {
+	var buf []byte
+	for _, part := range append([][]byte{in.ProtocolID, in.MethodName}, in.Arguments...) {
+		buf = append(buf, byteutil.Uint32ToBytes(uint32(len(part)))...)
+		buf = append(buf, part...)
+	}
+	return hash.Hash256b(buf)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+func TestReadStateCache(t *testing.T) {
+	require := require.New(t)
+	c := newReadStateCache(2)
+	tip := hash.Hash256b([]byte("tip"))
+	keys := []hash.Hash256{
+		readStateKey(&iotexapi.ReadStateRequest{ProtocolID: []byte("poll"), MethodName: []byte("DelegatesByEpoch")}),
+		readStateKey(&iotexapi.ReadStateRequest{
+			ProtocolID: []byte("poll"),
+			MethodName: []byte("DelegatesByEpoch"),
+			Arguments:  [][]byte{[]byte("1")},
+		}),
+		// The parts can't be shifted from one to another
+		readStateKey(&iotexapi.ReadStateRequest{
+			ProtocolID: []byte("poll"),
+			MethodName: []byte("DelegatesByEpoch1"),
+		}),
+	}
+	require.NotEqual(keys[0], keys[1])
+	require.NotEqual(keys[1], keys[2])
+
+	_, ok := c.get(1, tip, keys[0])
+	require.False(ok)
+	c.put(1, tip, keys[0], []byte("a"))
+	c.put(1, tip, keys[1], []byte("b"))
+	// The result isn't cached once the cache is full
+	c.put(1, tip, keys[2], []byte("c"))
+	data, ok := c.get(1, tip, keys[0])
+	require.True(ok)
+	require.Equal([]byte("a"), data)
+	data, ok = c.get(1, tip, keys[1])
+	require.True(ok)
+	require.Equal([]byte("b"), data)
+	_, ok = c.get(1, tip, keys[2])
+	require.False(ok)
+
+	// The results are dropped once the tip moves, either to a new block or onto another branch at the same height
+	_, ok = c.get(1, hash.Hash256b([]byte("fork")), keys[0])
+	require.False(ok)
+	c.put(2, tip, keys[2], []byte("c"))
+	_, ok = c.get(2, tip, keys[0])
+	require.False(ok)
+	data, ok = c.get(2, tip, keys[2])
+	require.True(ok)
+	require.Equal([]byte("c"), data)
+}
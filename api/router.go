@@ -0,0 +1,117 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"strconv"
+
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ChainIDMetadataKey is the key of the gRPC metadata carrying the ID of the chain a request queries. The request
+// without it is served by the chain of the server
+const ChainIDMetadataKey = "chainid"
+
+// AddChain makes the server serve the requests for the chain of the given server, which is not started on its own
+func (api *Server) AddChain(svr *Server) error {
+	chainID := svr.bc.ChainID()
+	api.chainsMutex.Lock()
+	defer api.chainsMutex.Unlock()
+	if chainID == api.bc.ChainID() {
+		return errors.Errorf("chain %d is served by the server itself", chainID)
+	}
+	if _, ok := api.chains[chainID]; ok {
+		return errors.Errorf("chain %d is already added", chainID)
+	}
+	api.chains[chainID] = svr
+	return nil
+}
+
+// RemoveChain stops serving the requests for the given chain
+func (api *Server) RemoveChain(chainID uint32) {
+	api.chainsMutex.Lock()
+	defer api.chainsMutex.Unlock()
+	delete(api.chains, chainID)
+}
+
+// route returns the server of the chain the request queries
+func (api *Server) route(ctx context.Context) (*Server, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(ChainIDMetadataKey)) == 0 {
+		return api, nil
+	}
+	chainID, err := strconv.ParseUint(md.Get(ChainIDMetadataKey)[0], 10, 32)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain ID %s", md.Get(ChainIDMetadataKey)[0])
+	}
+	if uint32(chainID) == api.bc.ChainID() {
+		return api, nil
+	}
+	api.chainsMutex.RLock()
+	defer api.chainsMutex.RUnlock()
+	svr, ok := api.chains[uint32(chainID)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "chain %d is not served", chainID)
+	}
+	return svr, nil
+}
+
+func (api *Server) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return grpc_prometheus.UnaryServerInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if err := api.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		svr, err := api.route(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if svr == api {
+			return handler(ctx, req)
+		}
+		// The handler is bound to this server, so the method of the same name is called on the routed one instead
+		method := reflect.ValueOf(svr).MethodByName(path.Base(info.FullMethod))
+		if !method.IsValid() {
+			return nil, status.Errorf(codes.Unimplemented, "method %s is not implemented", info.FullMethod)
+		}
+		out := method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(req)})
+		if err, ok := out[1].Interface().(error); ok && err != nil {
+			return nil, err
+		}
+		return out[0].Interface(), nil
+	})
+}
+
+func (api *Server) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return grpc_prometheus.StreamServerInterceptor(srv, ss, info, func(srv interface{}, ss grpc.ServerStream) error {
+		if err := api.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		svr, err := api.route(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(svr, ss)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func TestServer_RouteByChainID(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rootChain := mock_blockchain.NewMockBlockchain(ctrl)
+	rootChain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	subChain := mock_blockchain.NewMockBlockchain(ctrl)
+	subChain.EXPECT().ChainID().Return(uint32(2)).AnyTimes()
+	bs := mock_blocksync.NewMockBlockSync(ctrl)
+	bs.EXPECT().SyncStatus().Return(&iotexapi.BlockSyncStatus{CurrentHeight: 10}).AnyTimes()
+
+	root := &Server{bc: rootChain, chains: make(map[uint32]*Server)}
+	sub := &Server{bc: subChain, bs: bs, chains: make(map[uint32]*Server)}
+	require.Error(root.AddChain(root))
+	require.NoError(root.AddChain(sub))
+	require.Error(root.AddChain(sub))
+
+	withChainID := func(chainID string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(ChainIDMetadataKey, chainID))
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetBlockSyncStatus"}
+	call := func(ctx context.Context) (interface{}, error) {
+		return root.unaryInterceptor(ctx, &iotexapi.GetBlockSyncStatusRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return root.GetBlockSyncStatus(ctx, req.(*iotexapi.GetBlockSyncStatusRequest))
+		})
+	}
+
+	// The request without chain ID or with the chain ID of the server is served by the server itself
+	_, err := call(context.Background())
+	require.Error(err)
+	_, err = call(withChainID("1"))
+	require.Error(err)
+
+	res, err := call(withChainID("2"))
+	require.NoError(err)
+	require.Equal(uint64(10), res.(*iotexapi.GetBlockSyncStatusResponse).Status.CurrentHeight)
+
+	_, err = call(withChainID("3"))
+	require.Equal(codes.NotFound, status.Code(err))
+	_, err = call(withChainID("x"))
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Streams are routed as well
+	var served interface{}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		served = srv
+		return nil
+	}
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/iotexapi.APIService/StreamBlockSyncStatus"}
+	require.NoError(root.streamInterceptor(root, &testServerStream{ctx: withChainID("2")}, streamInfo, handler))
+	require.Equal(sub, served)
+	require.NoError(root.streamInterceptor(root, &testServerStream{ctx: context.Background()}, streamInfo, handler))
+	require.Equal(root, served)
+
+	root.RemoveChain(2)
+	_, err = call(withChainID("2"))
+	require.Equal(codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+// watchBufferSize is the number of committed blocks buffered for a watcher which hasn't sent out the previous ones
+const watchBufferSize = 64
+
+type (
+	// addressWatcher subscribes the committed blocks along with their states for a stream, and computes the balance
+	// deltas of the watched addresses in the blocks affecting them
+	addressWatcher struct {
+		addrs    map[string]hash.Hash160
+		balances map[string]*big.Int // address -> balance when last notified
+		height   uint64
+		blocks   chan *watchedBlock
+	}
+
+	// watchedBlock is a committed block, along with the balances of the watched addresses right after it
+	watchedBlock struct {
+		blk      *block.Block
+		balances map[string]*big.Int
+	}
+)
+
+func newAddressWatcher(bc blockchain.Blockchain, addrs []string) (*addressWatcher, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("no address to watch")
+	}
+	w := &addressWatcher{
+		addrs:    make(map[string]hash.Hash160, len(addrs)),
+		balances: make(map[string]*big.Int, len(addrs)),
+		height:   bc.TipHeight(),
+		blocks:   make(chan *watchedBlock, watchBufferSize),
+	}
+	for _, addr := range addrs {
+		decoded, err := address.FromString(addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address %s", addr)
+		}
+		balance, err := bc.Balance(addr)
+		if err != nil {
+			return nil, err
+		}
+		w.addrs[addr] = byteutil.BytesTo20B(decoded.Bytes())
+		w.balances[addr] = balance
+	}
+	return w, nil
+}
+
+// HandleBlock does nothing, because the blocks are delivered along with their states by HandleBlockStates
+func (w *addressWatcher) HandleBlock(*block.Block) error {
+	return nil
+}
+
+// HandleBlockStates reads the balances of the watched addresses right after the block, and buffers the block along
+// with the balances. The blocks are handed over in order, so that the deltas are computed at the height of each block.
+// The block is dropped if the watcher lags too far behind, and its delta is then folded into the next block notified
+func (w *addressWatcher) HandleBlockStates(blk *block.Block, sm protocol.StateManager) error {
+	balances := make(map[string]*big.Int, len(w.addrs))
+	for addr, addrHash := range w.addrs {
+		account, err := accountutil.LoadAccount(sm, addrHash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load account %s", addr)
+		}
+		balances[addr] = account.Balance
+	}
+	select {
+	case w.blocks <- &watchedBlock{blk: blk, balances: balances}:
+		return nil
+	default:
+		return errors.Errorf("address watcher is lagging behind, drop block %d", blk.Height())
+	}
+}
+
+// notification returns the balance deltas of the watched addresses which are involved in the actions of the block, or
+// whose balances have changed since the last notification. Nil is returned if no watched address is affected
+func (w *addressWatcher) notification(wb *watchedBlock) (*iotexapi.WatchAddressesResponse, error) {
+	blk := wb.blk
+	// The block committed before watching, or reverted and committed again, isn't notified
+	if blk.Height() <= w.height {
+		return nil, nil
+	}
+	w.height = blk.Height()
+
+	involved := make(map[string][]string)
+	for _, selp := range blk.Actions {
+		actHash := selp.Hash()
+		callerPKHash := keypair.HashPubKey(selp.SrcPubkey())
+		callerAddr, err := address.FromBytes(callerPKHash[:])
+		if err != nil {
+			return nil, err
+		}
+		parties := []string{callerAddr.String()}
+		if dst, ok := selp.Destination(); ok && dst != callerAddr.String() {
+			parties = append(parties, dst)
+		}
+		for _, addr := range parties {
+			if _, ok := w.balances[addr]; ok {
+				involved[addr] = append(involved[addr], hex.EncodeToString(actHash[:]))
+			}
+		}
+	}
+
+	var deltas []*iotexapi.BalanceDelta
+	for addr, last := range w.balances {
+		balance := wb.balances[addr]
+		delta := new(big.Int).Sub(balance, last)
+		if delta.Sign() == 0 && len(involved[addr]) == 0 {
+			continue
+		}
+		w.balances[addr] = balance
+		deltas = append(deltas, &iotexapi.BalanceDelta{
+			Address:      addr,
+			Balance:      balance.String(),
+			Delta:        delta.String(),
+			ActionHashes: involved[addr],
+		})
+	}
+	if len(deltas) == 0 {
+		return nil, nil
+	}
+	sort.Slice(deltas, func(i, j int) bool { return deltas[i].Address < deltas[j].Address })
+	blkHash := blk.HashBlock()
+	return &iotexapi.WatchAddressesResponse{
+		Height:    blk.Height(),
+		BlockHash: hex.EncodeToString(blkHash[:]),
+		Deltas:    deltas,
+	}, nil
+}
+
+// pendingActionWatcher subscribes the action events of actpool for a stream, which are filtered by the senders, the
+// event types and the action types
+type pendingActionWatcher struct {
+	senders    map[string]bool
+	eventTypes map[actpool.ActionEventType]bool
+	actTypes   map[string]bool // lower-cased action type name -> true
+	events     chan actpool.ActionEvent
+}
+
+func newPendingActionWatcher(
+	addrs []string,
+	eventTypes []iotexapi.PendingActionEventType,
+	actTypes []string,
+) (*pendingActionWatcher, error) {
+	w := &pendingActionWatcher{
+		senders:    make(map[string]bool, len(addrs)),
+		eventTypes: make(map[actpool.ActionEventType]bool, len(eventTypes)),
+		actTypes:   make(map[string]bool, len(actTypes)),
+		events:     make(chan actpool.ActionEvent, watchBufferSize),
+	}
+	for _, addr := range addrs {
+		if _, err := address.FromString(addr); err != nil {
+			return nil, errors.Wrapf(err, "invalid address %s", addr)
+		}
+		w.senders[addr] = true
+	}
+	for _, typ := range eventTypes {
+		switch typ {
+		case iotexapi.PendingActionEventType_ADDED:
+			w.eventTypes[actpool.ActionAdded] = true
+		case iotexapi.PendingActionEventType_PROMOTED:
+			w.eventTypes[actpool.ActionPromoted] = true
+		case iotexapi.PendingActionEventType_REMOVED:
+			w.eventTypes[actpool.ActionRemoved] = true
+		case iotexapi.PendingActionEventType_CONFIRMED:
+			w.eventTypes[actpool.ActionConfirmed] = true
+		default:
+			return nil, errors.Errorf("unknown pending action event type %d", typ)
+		}
+	}
+	for _, typ := range actTypes {
+		name, ok := action.CanonicalTypeName(typ)
+		if !ok {
+			return nil, errors.Errorf("unknown action type %s", typ)
+		}
+		w.actTypes[name] = true
+	}
+	return w, nil
+}
+
+// HandleActionEvent buffers an event passing the filters, which is dropped if the watcher lags too far behind
+func (w *pendingActionWatcher) HandleActionEvent(e actpool.ActionEvent) error {
+	if len(w.eventTypes) > 0 && !w.eventTypes[e.Type] {
+		return nil
+	}
+	if len(w.actTypes) > 0 && !w.actTypes[action.TypeName(e.Action.Action())] {
+		return nil
+	}
+	if len(w.senders) > 0 {
+		callerPKHash := keypair.HashPubKey(e.Action.SrcPubkey())
+		callerAddr, err := address.FromBytes(callerPKHash[:])
+		if err != nil {
+			return err
+		}
+		if !w.senders[callerAddr.String()] {
+			return nil
+		}
+	}
+	select {
+	case w.events <- e:
+		return nil
+	default:
+		actHash := e.Action.Hash()
+		return errors.Errorf("pending action watcher is lagging behind, drop %s event of action %x", e.Type, actHash)
+	}
+}
+
+func (w *pendingActionWatcher) notification(e actpool.ActionEvent) (*iotexapi.StreamPendingActionsResponse, error) {
+	var typ iotexapi.PendingActionEventType
+	switch e.Type {
+	case actpool.ActionAdded:
+		typ = iotexapi.PendingActionEventType_ADDED
+	case actpool.ActionPromoted:
+		typ = iotexapi.PendingActionEventType_PROMOTED
+	case actpool.ActionRemoved:
+		typ = iotexapi.PendingActionEventType_REMOVED
+	case actpool.ActionConfirmed:
+		typ = iotexapi.PendingActionEventType_CONFIRMED
+	default:
+		return nil, errors.Errorf("unknown action event type %d", e.Type)
+	}
+	actHash := e.Action.Hash()
+	return &iotexapi.StreamPendingActionsResponse{
+		Type:    typ,
+		ActHash: hex.EncodeToString(actHash[:]),
+		Action:  e.Action.Proto(),
+	}, nil
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *iotexapi.WatchAddressesResponse
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(res *iotexapi.WatchAddressesResponse) error {
+	s.sent <- res
+	return nil
+}
+
+func mintTransfer(bc blockchain.Blockchain, sender string, recipient string, amount int64) (*block.Block, error) {
+	nonce, err := bc.Nonce(ta.Addrinfo[sender].String())
+	if err != nil {
+		return nil, err
+	}
+	tsf, err := testutil.SignedTransfer(
+		ta.Addrinfo[recipient].String(),
+		ta.Keyinfo[sender].PriKey,
+		nonce+1,
+		big.NewInt(amount),
+		[]byte{},
+		testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPrice),
+	)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := bc.MintNewBlock(
+		map[string][]action.SealedEnvelope{ta.Addrinfo[sender].String(): {tsf}},
+		ta.Keyinfo["producer"].PubKey,
+		ta.Keyinfo["producer"].PriKey,
+		ta.Addrinfo["producer"].String(),
+		time.Now().Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return blk, bc.CommitBlock(blk)
+}
+
+func TestServer_WatchAddresses(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchStream{ctx: ctx, sent: make(chan *iotexapi.WatchAddressesResponse, 10)}
+	require.Error(svr.WatchAddresses(&iotexapi.WatchAddressesRequest{}, stream))
+	require.Error(svr.WatchAddresses(&iotexapi.WatchAddressesRequest{Addresses: []string{"invalid"}}, stream))
+
+	bravo := ta.Addrinfo["bravo"].String()
+	delta := ta.Addrinfo["delta"].String()
+	balance, err := svr.bc.Balance(bravo)
+	require.NoError(err)
+	done := make(chan error)
+	go func() {
+		done <- svr.WatchAddresses(&iotexapi.WatchAddressesRequest{Addresses: []string{bravo, delta}}, stream)
+	}()
+	// Wait for the watcher to subscribe
+	time.Sleep(100 * time.Millisecond)
+
+	// The block not affecting the watched addresses isn't notified
+	_, err = mintTransfer(svr.bc, "charlie", "alfa", 1)
+	require.NoError(err)
+	blk, err := mintTransfer(svr.bc, "charlie", "bravo", 2)
+	require.NoError(err)
+	select {
+	case res := <-stream.sent:
+		blkHash := blk.HashBlock()
+		require.Equal(blk.Height(), res.Height)
+		require.Equal(hex.EncodeToString(blkHash[:]), res.BlockHash)
+		require.Equal(1, len(res.Deltas))
+		require.Equal(bravo, res.Deltas[0].Address)
+		require.Equal(balance.Add(balance, big.NewInt(2)).String(), res.Deltas[0].Balance)
+		require.Equal("2", res.Deltas[0].Delta)
+		actHash := blk.Actions[0].Hash()
+		require.Equal([]string{hex.EncodeToString(actHash[:])}, res.Deltas[0].ActionHashes)
+	case <-time.After(5 * time.Second):
+		require.Fail("no notification is received")
+	}
+	require.Empty(stream.sent)
+
+	cancel()
+	require.NoError(<-done)
+}
+
+func TestAddressWatcher_HandleBlockStates(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	producer := ta.Addrinfo["producer"].String()
+	w, err := newAddressWatcher(svr.bc, []string{producer})
+	require.NoError(err)
+	balance, err := svr.bc.Balance(producer)
+	require.NoError(err)
+
+	// The balances are read from the states of each block, rather than the tip
+	balances := []*big.Int{new(big.Int).Add(balance, big.NewInt(5)), new(big.Int).Add(balance, big.NewInt(8))}
+	sm := mock_chainmanager.NewMockStateManager(ctrl)
+	for _, b := range balances {
+		b := b
+		sm.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(_ hash.Hash160, s interface{}) error {
+			account := s.(*state.Account)
+			*account = state.EmptyAccount()
+			account.Balance = b
+			return nil
+		}).Times(1)
+	}
+	tip := svr.bc.TipHeight()
+	newBlock := func(height uint64) *block.Block {
+		return block.NewBlockDeprecated(
+			1,
+			height,
+			hash.ZeroHash256,
+			testutil.TimestampNow(),
+			ta.Keyinfo["producer"].PubKey,
+			nil,
+		)
+	}
+	require.NoError(w.HandleBlockStates(newBlock(tip+1), sm))
+	require.NoError(w.HandleBlockStates(newBlock(tip+2), sm))
+	for i, expected := range []string{"5", "3"} {
+		res, err := w.notification(<-w.blocks)
+		require.NoError(err)
+		require.Equal(tip+uint64(i)+1, res.Height)
+		require.Equal(1, len(res.Deltas))
+		require.Equal(balances[i].String(), res.Deltas[0].Balance)
+		require.Equal(expected, res.Deltas[0].Delta)
+	}
+
+	// The blocks committed before watching are skipped
+	res, err := w.notification(&watchedBlock{blk: newBlock(tip), balances: map[string]*big.Int{producer: balance}})
+	require.NoError(err)
+	require.Nil(res)
+
+	// The block is dropped if the watcher lags behind
+	sm.EXPECT().State(gomock.Any(), gomock.Any()).Return(state.ErrStateNotExist).AnyTimes()
+	for i := 0; i < watchBufferSize; i++ {
+		require.NoError(w.HandleBlockStates(newBlock(tip+3), sm))
+	}
+	require.Error(w.HandleBlockStates(newBlock(tip+3), sm))
+}
+
+func TestPendingActionWatcher(t *testing.T) {
+	require := require.New(t)
+
+	_, err := newPendingActionWatcher([]string{"invalid"}, nil, nil)
+	require.Error(err)
+	alfa, bravo := ta.Addrinfo["alfa"].String(), ta.Addrinfo["bravo"].String()
+	tsf1, err := testutil.SignedTransfer(bravo, ta.Keyinfo["alfa"].PriKey, 1, big.NewInt(1), nil, 10000, big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(alfa, ta.Keyinfo["bravo"].PriKey, 1, big.NewInt(1), nil, 10000, big.NewInt(0))
+	require.NoError(err)
+	w, err := newPendingActionWatcher([]string{alfa}, nil, nil)
+	require.NoError(err)
+
+	// The action of the other sender is filtered out
+	require.NoError(w.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionAdded, Action: tsf2}))
+	require.Empty(w.events)
+	require.NoError(w.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionPromoted, Action: tsf1}))
+	res, err := w.notification(<-w.events)
+	require.NoError(err)
+	actHash := tsf1.Hash()
+	require.Equal(iotexapi.PendingActionEventType_PROMOTED, res.Type)
+	require.Equal(hex.EncodeToString(actHash[:]), res.ActHash)
+	require.Equal(tsf1.Proto(), res.Action)
+
+	// The event is dropped if the watcher lags behind
+	for i := 0; i < watchBufferSize; i++ {
+		require.NoError(w.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionRemoved, Action: tsf1}))
+	}
+	require.Error(w.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionRemoved, Action: tsf1}))
+}
+
+func TestPendingActionWatcher_Filters(t *testing.T) {
+	require := require.New(t)
+
+	_, err := newPendingActionWatcher(nil, []iotexapi.PendingActionEventType{iotexapi.PendingActionEventType(99)}, nil)
+	require.Error(err)
+	_, err = newPendingActionWatcher(nil, nil, []string{""})
+	require.Error(err)
+	_, err = newPendingActionWatcher(nil, nil, []string{"Transfers"})
+	require.Error(err)
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["bravo"].String(), ta.Keyinfo["alfa"].PriKey, 1, big.NewInt(1), nil,
+		10000, big.NewInt(0))
+	require.NoError(err)
+	exec, err := testutil.SignedExecution(action.EmptyAddress, ta.Keyinfo["alfa"].PriKey, 2, big.NewInt(0), 10000,
+		big.NewInt(0), nil)
+	require.NoError(err)
+	w, err := newPendingActionWatcher(
+		nil,
+		[]iotexapi.PendingActionEventType{iotexapi.PendingActionEventType_ADDED},
+		[]string{"execution"},
+	)
+	require.NoError(err)
+
+	// Only the admitted executions are streamed
+	require.NoError(w.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionAdded, Action: tsf}))
+	require.NoError(w.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionRemoved, Action: exec}))
+	require.Empty(w.events)
+	require.NoError(w.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionAdded, Action: exec}))
+	res, err := w.notification(<-w.events)
+	require.NoError(err)
+	require.Equal(iotexapi.PendingActionEventType_ADDED, res.Type)
+	require.Equal(exec.Proto(), res.Action)
+}
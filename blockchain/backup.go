@@ -0,0 +1,73 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// Backup copies the chain DB, which holds the blocks and the indices, and the trie DB into the directory while the
+// chain keeps running. The files are named after the configured DB paths. The snapshots of both DBs are taken between
+// the block commits, so that the copies are consistent with each other, and the blocks keep being committed while
+// they are copied. It returns the tip height of the backup. The indices written asynchronously may lag behind the
+// blocks
+func (bc *blockchain) Backup(dir string) (uint64, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return 0, errors.Wrapf(err, "failed to create backup directory %s", dir)
+	}
+	chainSnapshot, trieSnapshot, height, err := bc.snapshot()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := chainSnapshot.Close(); err != nil {
+			log.L().Error("Failed to close chain DB snapshot.", zap.Error(err))
+		}
+		if err := trieSnapshot.Close(); err != nil {
+			log.L().Error("Failed to close trie DB snapshot.", zap.Error(err))
+		}
+	}()
+	chainPath := filepath.Join(dir, filepath.Base(bc.config.Chain.ChainDBPath))
+	if err := chainSnapshot.WriteTo(chainPath); err != nil {
+		return 0, errors.Wrap(err, "failed to back up chain DB")
+	}
+	triePath := filepath.Join(dir, filepath.Base(bc.config.Chain.TrieDBPath))
+	if err := trieSnapshot.WriteTo(triePath); err != nil {
+		return 0, errors.Wrap(err, "failed to back up trie DB")
+	}
+	log.L().Info("Backed up the chain.",
+		zap.Uint64("height", height),
+		zap.String("chainDB", chainPath),
+		zap.String("trieDB", triePath))
+	return height, nil
+}
+
+// snapshot takes the snapshots of the chain DB and the trie DB at the tip height. Block commits take the write lock,
+// so holding the read lock while taking them acts as the commit barrier
+func (bc *blockchain) snapshot() (db.Snapshot, db.Snapshot, uint64, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	chainSnapshot, err := db.NewSnapshot(bc.dao.kvstore)
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "failed to take chain DB snapshot")
+	}
+	trieSnapshot, err := bc.sf.Snapshot()
+	if err != nil {
+		if err := chainSnapshot.Close(); err != nil {
+			log.L().Error("Failed to close chain DB snapshot.", zap.Error(err))
+		}
+		return nil, nil, 0, errors.Wrap(err, "failed to take trie DB snapshot")
+	}
+	return chainSnapshot, trieSnapshot, bc.tipHeight, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestBlockchain_Backup(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(err)
+	defer func() { require.NoError(os.RemoveAll(dir)) }()
+
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	cfg.Chain.EnableIndex = true
+	genesisConfig := genesis.Default
+	newChain := func(cfg config.Config) Blockchain {
+		sf, err := factory.NewFactory(cfg, factory.DefaultTrieOption())
+		require.NoError(err)
+		bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), BoltDBDaoOption(), GenesisOption(genesisConfig))
+		bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.ActionGasLimit))
+		bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+		sf.AddActionHandlers(account.NewProtocol(), vote.NewProtocol(bc))
+		return bc
+	}
+
+	bc := newChain(cfg)
+	require.NoError(bc.Start(ctx))
+	defer func() { require.NoError(bc.Stop(ctx)) }()
+	require.NoError(addTestingTsfBlocks(bc))
+	tipHeight := bc.TipHeight()
+	tipHash := bc.TipHash()
+	balance, err := bc.Balance(Gen.CreatorAddr())
+	require.NoError(err)
+
+	// The snapshots are taken at the tip, and the block committed while they're copied is left out. The commit may
+	// wait for the snapshots to be closed if the DB has to grow
+	chainSnapshot, trieSnapshot, height, err := bc.(*blockchain).snapshot()
+	require.NoError(err)
+	require.Equal(tipHeight, height)
+	committed := make(chan error, 1)
+	go func() {
+		blk, err := bc.MintNewBlock(
+			nil,
+			ta.Keyinfo["producer"].PubKey,
+			ta.Keyinfo["producer"].PriKey,
+			ta.Addrinfo["producer"].String(),
+			0,
+		)
+		if err == nil {
+			err = bc.CommitBlock(blk)
+		}
+		committed <- err
+	}()
+	require.NoError(chainSnapshot.WriteTo(filepath.Join(dir, testDBPath)))
+	require.NoError(trieSnapshot.WriteTo(filepath.Join(dir, testTriePath)))
+	require.NoError(chainSnapshot.Close())
+	require.NoError(trieSnapshot.Close())
+	require.NoError(<-committed)
+	require.Equal(tipHeight+1, bc.TipHeight())
+	height, err = bc.Backup(filepath.Join(dir, "latest"))
+	require.NoError(err)
+	require.Equal(tipHeight+1, height)
+	// The existing backup files aren't overwritten
+	_, err = bc.Backup(filepath.Join(dir, "latest"))
+	require.Equal(db.ErrAlreadyExist, errors.Cause(err))
+
+	// The chain opened from the backup files is at the same height with the same states
+	backupCfg := cfg
+	backupCfg.Chain.TrieDBPath = filepath.Join(dir, testTriePath)
+	backupCfg.Chain.ChainDBPath = filepath.Join(dir, testDBPath)
+	backup := newChain(backupCfg)
+	require.NoError(backup.Start(ctx))
+	defer func() { require.NoError(backup.Stop(ctx)) }()
+	require.Equal(tipHeight, backup.TipHeight())
+	require.Equal(tipHash, backup.TipHash())
+	backupBalance, err := backup.Balance(Gen.CreatorAddr())
+	require.NoError(err)
+	require.Equal(balance, backupBalance)
+
+	// The in-memory chain cannot be backed up
+	inMem := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), GenesisOption(genesisConfig))
+	require.NoError(inMem.Start(ctx))
+	defer func() { require.NoError(inMem.Stop(ctx)) }()
+	_, err = inMem.Backup(filepath.Join(dir, "inmem"))
+	require.Equal(db.ErrBackupNotSupported, errors.Cause(err))
+}
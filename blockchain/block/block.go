@@ -69,6 +69,9 @@ func (b *Block) ConvertToBlockHeaderPb() *iotextypes.BlockHeader {
 	pbHeader.ReceiptRoot = b.Header.receiptRoot[:]
 	pbHeader.Signature = b.Header.blockSig
 	pbHeader.Pubkey = keypair.PublicKeyToBytes(b.Header.pubkey)
+	pbHeader.ActionOrder = b.Header.actionOrder
+	pbHeader.VrfProof = b.Header.vrfProof
+	pbHeader.XXX_unrecognized = b.Header.extension
 	return &pbHeader
 }
 
@@ -104,6 +107,11 @@ func (b *Block) ConvertFromBlockHeaderPb(pbBlock *iotextypes.Block) {
 	copy(b.Header.deltaStateDigest[:], pbBlock.GetHeader().GetDeltaStateDigest())
 	copy(b.Header.receiptRoot[:], pbBlock.GetHeader().GetReceiptRoot())
 	b.Header.blockSig = pbBlock.GetHeader().GetSignature()
+	b.Header.actionOrder = pbBlock.GetHeader().GetActionOrder()
+	b.Header.vrfProof = pbBlock.GetHeader().GetVrfProof()
+	if pb := pbBlock.GetHeader(); pb != nil && !IsKnownHeaderVersion(pb.Version) {
+		b.Header.extension = pb.XXX_unrecognized
+	}
 
 	pubKey, err := keypair.BytesToPublicKey(pbBlock.GetHeader().GetPubkey())
 	if err != nil {
@@ -114,6 +122,9 @@ func (b *Block) ConvertFromBlockHeaderPb(pbBlock *iotextypes.Block) {
 
 // ConvertFromBlockPb converts Block to Block
 func (b *Block) ConvertFromBlockPb(pbBlock *iotextypes.Block) error {
+	if err := verifyHeaderPb(pbBlock.GetHeader()); err != nil {
+		return err
+	}
 	b.ConvertFromBlockHeaderPb(pbBlock)
 
 	b.Actions = []action.SealedEnvelope{}
@@ -125,6 +136,12 @@ func (b *Block) ConvertFromBlockPb(pbBlock *iotextypes.Block) error {
 		}
 		b.Actions = append(b.Actions, act)
 	}
+	// The receipts are only there in the block sync responses requested with them
+	for _, receiptPb := range pbBlock.GetReceipts() {
+		receipt := &action.Receipt{}
+		receipt.ConvertFromReceiptPb(receiptPb)
+		b.Receipts = append(b.Receipts, receipt)
+	}
 
 	return b.ConvertFromBlockFooterPb(pbBlock.GetFooter())
 }
@@ -154,6 +171,11 @@ func (b *Block) CalculateTxRoot() hash.Hash256 {
 	return calculateTxRoot(b.Actions)
 }
 
+// TxProof returns the merkle proof of the inclusion of the action of the given index in the tx root
+func (b *Block) TxProof(index int) ([]hash.Hash256, error) {
+	return calculateTxProof(b.Actions, index)
+}
+
 // HashBlock return the hash of this block (actually hash of block header)
 func (b *Block) HashBlock() hash.Hash256 {
 	return blake2b.Sum256(b.Header.ByteStream())
@@ -225,7 +247,7 @@ func (b *Block) RunnableActions() RunnableActions {
 
 // Finalize creates a footer for the block
 func (b *Block) Finalize(set *endorsement.Set, ts time.Time) error {
-	if b.endorsements != nil {
+	if b.endorsements != nil || len(b.aggregates) != 0 {
 		return errors.New("the block has been finalized")
 	}
 	if set == nil {
@@ -241,8 +263,26 @@ func (b *Block) Finalize(set *endorsement.Set, ts time.Time) error {
 	return nil
 }
 
+// FinalizeAggregate creates a footer for the block with the aggregated BLS signatures of the endorsements, one for
+// each topic including COMMIT
+func (b *Block) FinalizeAggregate(aggregates []*endorsement.Aggregate, ts time.Time) error {
+	if b.endorsements != nil || len(b.aggregates) != 0 {
+		return errors.New("the block has been finalized")
+	}
+	if err := validateAggregates(aggregates); err != nil {
+		return err
+	}
+	b.aggregates = aggregates
+	b.commitTimestamp = ts.Unix()
+
+	return nil
+}
+
 // FooterLogger logs the endorsements in block footer
 func (b *Block) FooterLogger(l *zap.Logger) *zap.Logger {
+	if commit := b.commitAggregate(); commit != nil {
+		return commit.AggregateLogger(l)
+	}
 	if b.endorsements == nil {
 		h := b.HashBlock()
 		return l.With(
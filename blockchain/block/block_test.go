@@ -9,13 +9,18 @@ package block
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/version"
@@ -77,6 +82,15 @@ func TestMerkle(t *testing.T) {
 	hash := block.CalculateTxRoot()
 	require.Equal("27708bd46b8ea8026db2eb764d19ae5c4213d20b035290f1e799d2298717887d", hex.EncodeToString(hash[:]))
 
+	// verify the proofs of the actions' inclusion in the tx root
+	for i, selp := range block.Actions {
+		proof, err := block.TxProof(i)
+		require.NoError(err)
+		require.Equal(hash, crypto.MerkleRootFromProof(selp.Hash(), uint32(i), proof))
+	}
+	_, err = NewBlockDeprecated(0, 0, hash, testutil.TimestampNow(), producerPubKey, nil).TxProof(0)
+	require.Error(err)
+
 	t.Log("Merkle root match pass\n")
 }
 
@@ -158,3 +172,133 @@ func TestConvertFromBlockPb(t *testing.T) {
 	require.Equal(t, blk.Header.stateRoot, blk.StateRoot())
 	require.Equal(t, blk.Header.receiptRoot, blk.ReceiptRoot())
 }
+
+func TestActionOrder(t *testing.T) {
+	require := require.New(t)
+	blk, err := NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(0).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.Equal(ArrivalOrder, blk.ActionOrder())
+	arrivalHash := blk.HashBlock()
+
+	blk.Header.actionOrder = HashOrder
+	require.NotEqual(arrivalHash, blk.HashBlock())
+	raw, err := blk.Serialize()
+	require.NoError(err)
+	var newblk Block
+	require.NoError(newblk.Deserialize(raw))
+	require.Equal(HashOrder, newblk.ActionOrder())
+	require.Equal(blk.HashBlock(), newblk.HashBlock())
+}
+
+func TestHeaderVersionDecoding(t *testing.T) {
+	require := require.New(t)
+	blk, err := NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(0).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.Equal(HeaderVersionV1, blk.Version())
+	// field 100 of varint 1, which is unknown to the header
+	unknownField := []byte{0xa0, 0x06, 0x01}
+	deserialize := func(pb *iotextypes.Block) (*Block, []byte, error) {
+		raw, err := proto.Marshal(pb)
+		require.NoError(err)
+		var newblk Block
+		return &newblk, raw, newblk.Deserialize(raw)
+	}
+
+	_, _, err = deserialize(blk.ConvertToBlockPb())
+	require.NoError(err)
+	// The version has to be set
+	pb := blk.ConvertToBlockPb()
+	pb.Header.Version = 0
+	_, _, err = deserialize(pb)
+	require.Error(err)
+	// A header of a known version can't carry unknown fields
+	pb = blk.ConvertToBlockPb()
+	pb.Header.XXX_unrecognized = unknownField
+	_, _, err = deserialize(pb)
+	require.Error(err)
+	// A header of an unknown version is decoded, and encoded back as is
+	pb = blk.ConvertToBlockPb()
+	pb.Header.Version = HeaderVersionV2 + 1
+	pb.Header.XXX_unrecognized = unknownField
+	newblk, raw, err := deserialize(pb)
+	require.NoError(err)
+	require.Equal(HeaderVersionV2+1, newblk.Version())
+	require.Equal(blk.Height(), newblk.Height())
+	reraw, err := newblk.Serialize()
+	require.NoError(err)
+	require.Equal(raw, reraw)
+
+	// The VRF proof of a header of version 2 is decoded, and covered by the block hash
+	v2, err := NewTestingBuilder().
+		SetVersion(HeaderVersionV2).
+		SetHeight(1).
+		SetTimeStamp(0).
+		SetVRFProof([]byte("proof")).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	newblk, _, err = deserialize(v2.ConvertToBlockPb())
+	require.NoError(err)
+	require.Equal([]byte("proof"), newblk.VRFProof())
+	require.Equal(v2.HashBlock(), newblk.HashBlock())
+	newblk.Header.vrfProof = []byte("other proof")
+	require.NotEqual(v2.HashBlock(), newblk.HashBlock())
+}
+
+func TestFinalizeAggregate(t *testing.T) {
+	require := require.New(t)
+
+	blk, err := NewTestingBuilder().
+		SetHeight(123).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	set := endorsement.NewSet(blkHash[:])
+	pubKeys := map[string][]byte{}
+	endorsers := []string{}
+	for i := 0; i < crypto.Degree+1; i++ {
+		endorser := fmt.Sprintf("endorser%02d", i)
+		sk := crypto.DKG.SkGeneration()
+		pk, err := crypto.BLS.NewPubKey(sk)
+		require.NoError(err)
+		pubKeys[endorser] = pk
+		endorsers = append(endorsers, endorser)
+		for _, topic := range []endorsement.ConsensusVoteTopic{endorsement.LOCK, endorsement.COMMIT} {
+			en := endorsement.NewEndorsement(
+				endorsement.NewConsensusVote(blkHash[:], blk.Height(), 0, topic),
+				ta.Keyinfo["alfa"].PubKey,
+				ta.Keyinfo["alfa"].PriKey,
+				endorser,
+			)
+			require.NoError(en.SignBLS(sk))
+			require.NoError(set.AddEndorsement(en))
+		}
+	}
+	lock, err := endorsement.AggregateEndorsements(set, endorsement.LOCK, pubKeys)
+	require.NoError(err)
+	commit, err := endorsement.AggregateEndorsements(set, endorsement.COMMIT, pubKeys)
+	require.NoError(err)
+	// There has to be one aggregate of COMMIT, and no more than one of each topic
+	require.Error(blk.FinalizeAggregate([]*endorsement.Aggregate{lock}, time.Unix(blk.Timestamp(), 0)))
+	require.Error(blk.FinalizeAggregate([]*endorsement.Aggregate{commit, commit}, time.Unix(blk.Timestamp(), 0)))
+	require.NoError(blk.FinalizeAggregate([]*endorsement.Aggregate{lock, commit}, time.Unix(blk.Timestamp(), 0)))
+	require.Error(blk.Finalize(set, time.Unix(blk.Timestamp(), 0)))
+
+	raw, err := blk.Serialize()
+	require.NoError(err)
+	var newblk Block
+	require.NoError(newblk.Deserialize(raw))
+	require.Equal(2, len(newblk.AggregatedEndorsements()))
+	for _, aggregate := range newblk.AggregatedEndorsements() {
+		require.NoError(aggregate.Verify(pubKeys))
+	}
+	require.Equal(endorsers, newblk.CommitEndorsers())
+	require.Equal(1, newblk.NumOfDelegateEndorsements([]string{"endorser00"}))
+	require.Equal(0, newblk.NumOfDelegateEndorsements([]string{ta.Addrinfo["bravo"].String()}))
+}
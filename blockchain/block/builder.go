@@ -52,6 +52,18 @@ func (b *Builder) SetPrevBlockHash(h hash.Hash256) *Builder {
 	return b
 }
 
+// SetActionOrder sets how the actions of the same gas price are ordered in the block which is building.
+func (b *Builder) SetActionOrder(o uint32) *Builder {
+	b.blk.Header.actionOrder = o
+	return b
+}
+
+// SetVRFProof sets the VRF proof of the producer for block which is building.
+func (b *Builder) SetVRFProof(proof []byte) *Builder {
+	b.blk.Header.vrfProof = proof
+	return b
+}
+
 // SetStateRoot sets the new state root after running actions included in this building block.
 func (b *Builder) SetStateRoot(h hash.Hash256) *Builder {
 	b.blk.Header.stateRoot = h
@@ -78,11 +90,19 @@ func (b *Builder) SetReceiptRoot(h hash.Hash256) *Builder {
 
 // SignAndBuild signs and then builds a block.
 func (b *Builder) SignAndBuild(signerPubKey keypair.PublicKey, signerPriKey keypair.PrivateKey) (Block, error) {
+	return b.SignAndBuildWith(signerPubKey, func(hash []byte) ([]byte, error) {
+		return crypto.Sign(hash, signerPriKey)
+	})
+}
+
+// SignAndBuildWith signs the block hash with the sign function, e.g., of a remote signer holding the private key of
+// the signer, and then builds a block
+func (b *Builder) SignAndBuildWith(signerPubKey keypair.PublicKey, sign func([]byte) ([]byte, error)) (Block, error) {
 	b.blk.Header.pubkey = signerPubKey
 	blkHash := b.blk.HashBlock()
-	sig, err := crypto.Sign(blkHash[:], signerPriKey)
+	sig, err := sign(blkHash[:])
 	if err != nil {
-		return Block{}, errors.New("Failed to sign block")
+		return Block{}, errors.Wrap(err, "Failed to sign block")
 	}
 	b.blk.Header.blockSig = sig
 	return b.blk, nil
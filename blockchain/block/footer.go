@@ -7,6 +7,8 @@
 package block
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
@@ -14,7 +16,10 @@ import (
 // Footer defines a set of proof of this block
 type Footer struct {
 	// endorsements contain COMMIT endorsements from more than 2/3 delegates
-	endorsements    *endorsement.Set
+	endorsements *endorsement.Set
+	// aggregates contain the aggregated BLS signatures of the endorsements instead, one for each topic including COMMIT,
+	// which are empty if they aren't aggregated
+	aggregates      []*endorsement.Aggregate
 	commitTimestamp int64
 }
 
@@ -25,6 +30,9 @@ func (f *Footer) ConvertToBlockFooterPb() *iotextypes.BlockFooter {
 	if f.endorsements != nil {
 		pb.Endorsements = f.endorsements.ToProto()
 	}
+	for _, aggregate := range f.aggregates {
+		pb.AggregatedEndorsements = append(pb.AggregatedEndorsements, aggregate.ToProto())
+	}
 
 	return &pb
 }
@@ -32,6 +40,20 @@ func (f *Footer) ConvertToBlockFooterPb() *iotextypes.BlockFooter {
 // ConvertFromBlockFooterPb converts BlockFooter to BlockFooter
 func (f *Footer) ConvertFromBlockFooterPb(pb *iotextypes.BlockFooter) error {
 	f.commitTimestamp = pb.GetCommitTimestamp()
+	if pbAggregates := pb.GetAggregatedEndorsements(); len(pbAggregates) != 0 {
+		aggregates := make([]*endorsement.Aggregate, 0, len(pbAggregates))
+		for _, pbAggregate := range pbAggregates {
+			aggregate := &endorsement.Aggregate{}
+			if err := aggregate.FromProto(pbAggregate); err != nil {
+				return err
+			}
+			aggregates = append(aggregates, aggregate)
+		}
+		if err := validateAggregates(aggregates); err != nil {
+			return err
+		}
+		f.aggregates = aggregates
+	}
 	pbEndorsements := pb.GetEndorsements()
 	if pbEndorsements == nil {
 		return nil
@@ -48,6 +70,19 @@ func (f *Footer) CommitTime() int64 {
 
 // NumOfDelegateEndorsements returns the number of commit endorsements froms delegates
 func (f *Footer) NumOfDelegateEndorsements(delegates []string) int {
+	if commit := f.commitAggregate(); commit != nil {
+		delegateSet := make(map[string]bool, len(delegates))
+		for _, delegate := range delegates {
+			delegateSet[delegate] = true
+		}
+		cnt := 0
+		for _, endorser := range commit.Endorsers() {
+			if delegateSet[endorser] {
+				cnt++
+			}
+		}
+		return cnt
+	}
 	if f.endorsements == nil {
 		return 0
 	}
@@ -56,3 +91,53 @@ func (f *Footer) NumOfDelegateEndorsements(delegates []string) int {
 		delegates,
 	)
 }
+
+// CommitEndorsers returns the delegates who have endorsed the commit of the block
+func (f *Footer) CommitEndorsers() []string {
+	if commit := f.commitAggregate(); commit != nil {
+		return commit.Endorsers()
+	}
+	if f.endorsements == nil {
+		return []string{}
+	}
+	return f.endorsements.Endorsers(map[endorsement.ConsensusVoteTopic]bool{endorsement.COMMIT: true})
+}
+
+// CommitEndorsements returns the commit endorsements of the block, which are empty if they are aggregated
+func (f *Footer) CommitEndorsements() []*endorsement.Endorsement {
+	if f.endorsements == nil {
+		return []*endorsement.Endorsement{}
+	}
+	return f.endorsements.Endorsements(map[endorsement.ConsensusVoteTopic]bool{endorsement.COMMIT: true})
+}
+
+// AggregatedEndorsements returns the endorsements whose BLS signatures are aggregated, one for each topic, which are
+// empty if they aren't aggregated
+func (f *Footer) AggregatedEndorsements() []*endorsement.Aggregate {
+	return f.aggregates
+}
+
+func (f *Footer) commitAggregate() *endorsement.Aggregate {
+	for _, aggregate := range f.aggregates {
+		if aggregate.ConsensusVote().Topic == endorsement.COMMIT {
+			return aggregate
+		}
+	}
+	return nil
+}
+
+// validateAggregates checks that there is at most one aggregate of each topic, and one of them is of COMMIT
+func validateAggregates(aggregates []*endorsement.Aggregate) error {
+	topics := make(map[endorsement.ConsensusVoteTopic]bool, len(aggregates))
+	for _, aggregate := range aggregates {
+		topic := aggregate.ConsensusVote().Topic
+		if topics[topic] {
+			return errors.Errorf("duplicate aggregates of topic %d", topic)
+		}
+		topics[topic] = true
+	}
+	if !topics[endorsement.COMMIT] {
+		return errors.New("no aggregate of COMMIT endorsements")
+	}
+	return nil
+}
@@ -7,12 +7,22 @@
 package block
 
 import (
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// ArrivalOrder orders the actions of the same gas price in the block as the producer receives them
+	ArrivalOrder uint32 = iota
+	// HashOrder orders the actions of the same gas price in the block by the hash of the action hash and the previous
+	// block hash, so that the producer can't put its own actions ahead of the others at will
+	HashOrder
 )
 
 // Header defines the struct of block header
@@ -29,6 +39,9 @@ type Header struct {
 	receiptRoot      hash.Hash256      // root of receipt trie
 	blockSig         []byte            // block signature
 	pubkey           keypair.PublicKey // block producer's public key
+	actionOrder      uint32            // how the actions of the same gas price are ordered
+	vrfProof         []byte            // VRF proof of the producer over the epoch seed and the height
+	extension        []byte            // encoded header fields unknown to this node, kept as is for unknown versions
 }
 
 // Version returns the version of this block.
@@ -61,6 +74,12 @@ func (h Header) PublicKey() keypair.PublicKey { return h.pubkey }
 // ReceiptRoot returns the receipt root after apply this block
 func (h Header) ReceiptRoot() hash.Hash256 { return h.receiptRoot }
 
+// ActionOrder returns how the actions of the same gas price are ordered in this block.
+func (h Header) ActionOrder() uint32 { return h.actionOrder }
+
+// VRFProof returns the VRF proof of the producer of this block, which is empty before header version 2.
+func (h Header) VRFProof() []byte { return h.vrfProof }
+
 // ByteStream returns a byte stream of the header.
 func (h Header) ByteStream() []byte {
 	stream := make([]byte, 4)
@@ -78,6 +97,13 @@ func (h Header) ByteStream() []byte {
 	stream = append(stream, h.stateRoot[:]...)
 	stream = append(stream, h.deltaStateDigest[:]...)
 	stream = append(stream, h.receiptRoot[:]...)
+	// The action order is only hashed if it isn't the arrival order, so that the hashes of the existing blocks are kept
+	if h.actionOrder != ArrivalOrder {
+		tmp4B := make([]byte, 4)
+		enc.MachineEndian.PutUint32(tmp4B, h.actionOrder)
+		stream = append(stream, tmp4B...)
+	}
+	stream = append(stream, h.vrfProof...)
 	return stream
 }
 
@@ -94,3 +120,17 @@ func (h Header) HeaderLogger(l *zap.Logger) *zap.Logger {
 		log.Hex("deltaStateDigest", h.deltaStateDigest[:]),
 	)
 }
+
+// verifyHeaderPb applies the strict decoding rules to the block header. The version has to be set, and a header of a
+// known version can't carry the fields unknown to the version, as they aren't covered by the block hash. A header of an
+// unknown version is decoded with the fields known to this node, and rejected when the block is validated
+func verifyHeaderPb(pb *iotextypes.BlockHeader) error {
+	v := pb.GetVersion()
+	if v == 0 {
+		return errors.New("block header version is missing")
+	}
+	if IsKnownHeaderVersion(v) && len(pb.XXX_unrecognized) != 0 {
+		return errors.Errorf("block header of version %d has unknown fields", v)
+	}
+	return nil
+}
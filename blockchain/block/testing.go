@@ -60,6 +60,18 @@ func (b *TestingBuilder) SetPrevBlockHash(h hash.Hash256) *TestingBuilder {
 	return b
 }
 
+// SetActionOrder sets how the actions of the same gas price are ordered in the block which is building.
+func (b *TestingBuilder) SetActionOrder(o uint32) *TestingBuilder {
+	b.blk.Header.actionOrder = o
+	return b
+}
+
+// SetVRFProof sets the VRF proof of the producer for block which is building.
+func (b *TestingBuilder) SetVRFProof(proof []byte) *TestingBuilder {
+	b.blk.Header.vrfProof = proof
+	return b
+}
+
 // AddActions adds actions for block which is building.
 func (b *TestingBuilder) AddActions(acts ...action.SealedEnvelope) *TestingBuilder {
 	if b.blk.Actions == nil {
@@ -7,6 +7,8 @@
 package block
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/hash"
@@ -22,3 +24,14 @@ func calculateTxRoot(acts []action.SealedEnvelope) hash.Hash256 {
 	}
 	return crypto.NewMerkleTree(h).HashTree()
 }
+
+func calculateTxProof(acts []action.SealedEnvelope, index int) ([]hash.Hash256, error) {
+	var h []hash.Hash256
+	for _, act := range acts {
+		h = append(h, act.Hash())
+	}
+	if len(h) == 0 {
+		return nil, errors.New("no action to prove")
+	}
+	return crypto.NewMerkleTree(h).Proof(index)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// HeaderVersionV1 is the version of the block headers from the genesis block
+	HeaderVersionV1 uint32 = version.ProtocolVersion
+	// HeaderVersionV2 is the version of the block headers carrying the VRF proof of the producer, which seeds the
+	// proposer selection of the next block
+	HeaderVersionV2 = HeaderVersionV1 + 1
+)
+
+var (
+	// ErrUnknownHeaderVersion is the error returned when the version of a block header isn't known to this node, which
+	// has to be upgraded to validate the block
+	ErrUnknownHeaderVersion = errors.New("unknown block header version")
+
+	// knownHeaderVersions registers the header versions this node knows how to hash and validate, with what each
+	// version changes in the header. A new version, e.g., one adding the base fee or the witness root, is registered
+	// here along with the header fields it introduces, and is activated at a fork height in the genesis
+	knownHeaderVersions = map[uint32]string{
+		HeaderVersionV1: "the header since the genesis block",
+		HeaderVersionV2: "the header with the VRF proof of the producer",
+	}
+)
+
+// IsKnownHeaderVersion tells whether the header version is known to this node
+func IsKnownHeaderVersion(v uint32) bool {
+	_, ok := knownHeaderVersions[v]
+	return ok
+}
+
+// HeaderVersionAt returns the header version of the block at the height, which is the latest version activated at or
+// below the height. The fork heights map the header versions to their activation heights, and V1 is activated from the
+// genesis block
+func HeaderVersionAt(forkHeights map[uint32]uint64, height uint64) uint32 {
+	v := HeaderVersionV1
+	for fv, fh := range forkHeights {
+		if fh <= height && fv > v {
+			v = fv
+		}
+	}
+	return v
+}
+
+// ValidateHeaderVersionForks validates the fork heights of the header versions. The versions have to be known, the
+// genesis block is always of V1, and a later version can't be activated below an earlier one
+func ValidateHeaderVersionForks(forkHeights map[uint32]uint64) error {
+	versions := make([]uint32, 0, len(forkHeights))
+	for v, h := range forkHeights {
+		if !IsKnownHeaderVersion(v) {
+			return errors.Wrapf(ErrUnknownHeaderVersion, "version %d", v)
+		}
+		if v != HeaderVersionV1 && h == 0 {
+			return errors.Errorf("header version %d can't be activated at the genesis block", v)
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	for i := 1; i < len(versions); i++ {
+		if forkHeights[versions[i]] < forkHeights[versions[i-1]] {
+			return errors.Errorf(
+				"header version %d is activated at %d, below version %d at %d",
+				versions[i],
+				forkHeights[versions[i]],
+				versions[i-1],
+				forkHeights[versions[i-1]],
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderVersionAt(t *testing.T) {
+	require := require.New(t)
+	defer func(known map[uint32]string) { knownHeaderVersions = known }(knownHeaderVersions)
+	knownHeaderVersions = map[uint32]string{HeaderVersionV1: "v1", 2: "v2", 3: "v3"}
+
+	require.True(IsKnownHeaderVersion(HeaderVersionV1))
+	require.False(IsKnownHeaderVersion(0))
+	require.False(IsKnownHeaderVersion(4))
+
+	require.Equal(HeaderVersionV1, HeaderVersionAt(nil, 100))
+	forks := map[uint32]uint64{2: 10, 3: 20}
+	require.NoError(ValidateHeaderVersionForks(forks))
+	require.Equal(HeaderVersionV1, HeaderVersionAt(forks, 0))
+	require.Equal(HeaderVersionV1, HeaderVersionAt(forks, 9))
+	require.Equal(uint32(2), HeaderVersionAt(forks, 10))
+	require.Equal(uint32(2), HeaderVersionAt(forks, 19))
+	require.Equal(uint32(3), HeaderVersionAt(forks, 20))
+
+	require.NoError(ValidateHeaderVersionForks(nil))
+	require.NoError(ValidateHeaderVersionForks(map[uint32]uint64{HeaderVersionV1: 0, 2: 10, 3: 10}))
+	err := ValidateHeaderVersionForks(map[uint32]uint64{4: 10})
+	require.Equal(ErrUnknownHeaderVersion, errors.Cause(err))
+	require.Error(ValidateHeaderVersionForks(map[uint32]uint64{2: 0}))
+	require.Error(ValidateHeaderVersionForks(map[uint32]uint64{2: 20, 3: 10}))
+}
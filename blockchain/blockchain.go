@@ -21,7 +21,10 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/allowlist"
+	"github.com/iotexproject/iotex-core/action/protocol/blskey"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/address"
@@ -35,6 +38,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
@@ -95,6 +99,10 @@ type Blockchain interface {
 	GetBlockHashByExecutionHash(h hash.Hash256) (hash.Hash256, error)
 	// GetReceiptByActionHash returns the receipt by action hash
 	GetReceiptByActionHash(h hash.Hash256) (*action.Receipt, error)
+	// GetReceiptsByHeight returns the receipts of the block at the height
+	GetReceiptsByHeight(height uint64) ([]*action.Receipt, error)
+	// FilterLogs returns the logs of the address with the topics in the blocks from fromHeight to toHeight
+	FilterLogs(fromHeight uint64, toHeight uint64, topics []hash.Hash256, address string) ([]*action.Log, error)
 	// GetActionsFromAddress returns actions from address
 	GetActionsFromAddress(address string) ([]hash.Hash256, error)
 	// GetActionsToAddress returns actions to address
@@ -117,6 +125,28 @@ type Blockchain interface {
 	StateByAddr(address string) (*state.Account, error)
 	// RecoverChainAndState recovers the chain to target height and refresh state db if necessary
 	RecoverChainAndState(targetHeight uint64) error
+	// RollbackTo reverts the blocks above the height and their states while the chain is running, so that the chain
+	// could be reorganized onto another branch. The subscribers are notified of the reverted blocks
+	RollbackTo(height uint64) error
+	// Fork keeps a block which doesn't extend the tip as a side block, so that the chain could be rebased onto its
+	// branch later. The branch has to fork from the chain no more than MaxReorgDepth blocks below the tip
+	Fork(blk *block.Block) error
+	// Rebase rolls the chain back to where the branch of the side block forks from it, and commits the branch up to the
+	// side block. The reverted blocks are kept as side blocks, and the subscribers are notified of them. If
+	// validateFooter is not nil, the chain isn't rebased once a block to revert passes it, i.e., it's finalized
+	Rebase(h hash.Hash256, validateFooter func(*block.Block) error) error
+	// Replay re-executes the blocks from genesis to target height against a fresh state factory, and compares the
+	// states with the stored chain at each height. It returns the last height being verified
+	Replay(ctx context.Context, targetHeight uint64) (uint64, error)
+	// ExportState re-executes the blocks from genesis to target height, and returns the canonical snapshot of the
+	// states at target height
+	ExportState(ctx context.Context, targetHeight uint64) (*StateSnapshot, error)
+	// StateDiff returns the changes of the states made by each action of the block at the height, which are dumped
+	// when the state root or delta state digest of the block doesn't match the local execution
+	StateDiff(height uint64) (*StateDiff, error)
+	// Backup copies the chain DB and the trie DB into the directory while the chain is running, and returns the tip
+	// height of the backup
+	Backup(dir string) (uint64, error)
 
 	// For block operations
 	// MintNewBlock creates a new block with given actions
@@ -128,10 +158,33 @@ type Blockchain interface {
 		producerAddr string,
 		timestamp int64,
 	) (*block.Block, error)
+	// MintNewBlockSignedWith creates a new block like MintNewBlock, which is signed with the sign function rather than
+	// the private key of the producer, e.g., by a remote signer holding the key. The grant reward action is signed with
+	// the sign action function. The VRF proof of the producer is put into the header, which has to be empty below the
+	// height of header version 2
+	MintNewBlockSignedWith(
+		actionMap map[string][]action.SealedEnvelope,
+		producerPubKey keypair.PublicKey,
+		sign func([]byte) ([]byte, error),
+		signAction func([]byte) ([]byte, error),
+		producerAddr string,
+		timestamp int64,
+		vrfProof []byte,
+	) (*block.Block, error)
 	// CommitBlock validates and appends a block to the chain
 	CommitBlock(blk *block.Block) error
+	// CommitBlocks validates and appends the sequential blocks to the chain, and commits their states in a batch.
+	// validateFooter validates the consensus footer of a block once the previous one is appended. It returns the number
+	// of the blocks committed, which is less than the number of the given blocks if the next one fails to commit, or
+	// the batch ends at an epoch boundary
+	CommitBlocks(blks []*block.Block, validateFooter func(*block.Block) error) (int, error)
 	// ValidateBlock validates a new block before adding it to the blockchain
 	ValidateBlock(blk *block.Block) error
+	// ImportTrustedBlock appends a block, whose hash has been verified against the trusted checkpoint, to the chain
+	// without running its actions
+	ImportTrustedBlock(blk *block.Block) error
+	// LoadStateSnapshot loads the states in the snapshot taken at the tip height into the state factory
+	LoadStateSnapshot(snapshot *StateSnapshot) error
 
 	// For action operations
 	// Validator returns the current validator object
@@ -143,12 +196,27 @@ type Blockchain interface {
 	// ExecuteContractRead runs a read-only smart contract operation, this is done off the network since it does not
 	// cause any state change
 	ExecuteContractRead(caller address.Address, ex *action.Execution) (*action.Receipt, error)
+	// ExecuteContractReadWithOverrides runs a read-only smart contract operation as ExecuteContractRead does, on the
+	// states with the overrides applied
+	ExecuteContractReadWithOverrides(
+		caller address.Address,
+		ex *action.Execution,
+		overrides []*evm.StateOverride,
+	) (*action.Receipt, error)
 
 	// AddSubscriber make you listen to every single produced block
 	AddSubscriber(BlockCreationSubscriber) error
 
+	// AddDurableSubscriber makes the subscriber get every committed block in order, from the block above its cursor
+	// persisted under the name, so that the blocks committed while it's down are replayed once it's added again. A block
+	// failed to handle is retried instead of skipped
+	AddDurableSubscriber(string, BlockCreationSubscriber) error
+
 	// RemoveSubscriber make you listen to every single produced block
 	RemoveSubscriber(BlockCreationSubscriber) error
+
+	// AddRevertSubscriber makes the subscriber get notified of the blocks reverted from the tip of the chain
+	AddRevertSubscriber(BlockRevertSubscriber) error
 }
 
 // blockchain implements the Blockchain interface
@@ -163,7 +231,16 @@ type blockchain struct {
 	lifecycle     lifecycle.Lifecycle
 	clk           clock.Clock
 	blocklistener []BlockCreationSubscriber
-	timerFactory  *prometheustimer.TimerFactory
+	// durableListener is delivered the blocks from the cursors persisted, while the chain is running
+	durableListener []*durableSubscriber
+	running         bool
+	// emittedHeight is the height of the last block emitted to the subscribers, along with its receipts written
+	emittedHeight uint64
+	// revertListener is notified of the blocks reverted when the chain is recovered to a lower height
+	revertListener []BlockRevertSubscriber
+	timerFactory   *prometheustimer.TimerFactory
+	// minted keeps the execution results of the blocks minted by this node, which aren't executed again on commit
+	minted *mintedCache
 
 	// used by account-based model
 	sf factory.Factory
@@ -262,6 +339,12 @@ func RegistryOption(registry *protocol.Registry) Option {
 // GenesisOption sets the blockchain with the genesis configs
 func GenesisOption(genesisConfig genesis.Genesis) Option {
 	return func(bc *blockchain, conf config.Config) error {
+		if err := block.ValidateHeaderVersionForks(genesisConfig.HeaderVersionHeights); err != nil {
+			return errors.Wrap(err, "invalid header version heights in genesis")
+		}
+		if _, ok := genesisConfig.HeaderVersionHeights[block.HeaderVersionV2]; ok && !genesisConfig.EnableBLS {
+			return errors.New("header version 2 carries the BLS VRF proofs, which requires BLS enabled in genesis")
+		}
 		bc.genesisConfig = genesisConfig
 		return nil
 	}
@@ -274,6 +357,7 @@ func NewBlockchain(cfg config.Config, opts ...Option) Blockchain {
 		config:  cfg,
 		genesis: Gen,
 		clk:     clock.New(),
+		minted:  newMintedCache(),
 	}
 	for _, opt := range opts {
 		if err := opt(chain, cfg); err != nil {
@@ -294,10 +378,22 @@ func NewBlockchain(cfg config.Config, opts ...Option) Blockchain {
 	if err != nil {
 		log.L().Panic("Failed to get block producer address.", zap.Error(err))
 	}
-	chain.validator = &validator{sf: chain.sf, validatorAddr: producerAddress(cfg).String()}
+	val := &validator{
+		sf:              chain.sf,
+		validatorAddr:   producerAddress(cfg).String(),
+		hashOrderHeight: chain.genesisConfig.HashActionOrderHeight,
+	}
+	if err := val.disableRules(cfg.Chain.DisabledValidationRules...); err != nil {
+		log.L().Panic("Failed to disable validation rules.", zap.Error(err))
+	}
+	chain.validator = val
 
 	if chain.dao != nil {
 		chain.lifecycle.Add(chain.dao)
+		if cfg.Chain.PruneMode != "" && cfg.Chain.PruneMode != config.PruneModeArchive {
+			chain.dao.compactOnStart = true
+			chain.lifecycle.Add(newBlockPruner(chain.dao, cfg.Chain))
+		}
 	}
 	if chain.sf != nil {
 		chain.lifecycle.Add(chain.sf)
@@ -317,6 +413,11 @@ func (bc *blockchain) ChainAddress() string {
 func (bc *blockchain) Start(ctx context.Context) (err error) {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
+	defer func() {
+		if err == nil {
+			err = bc.startDurableSubscribers()
+		}
+	}()
 	if err = bc.lifecycle.OnStart(ctx); err != nil {
 		return err
 	}
@@ -342,6 +443,10 @@ func (bc *blockchain) Start(ctx context.Context) (err error) {
 
 // Stop stops the blockchain.
 func (bc *blockchain) Stop(ctx context.Context) error {
+	// The durable subscribers are stopped out of the lock, as they may read the chain while handling a block
+	for _, ds := range bc.stopDurableSubscribers() {
+		ds.stop()
+	}
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
@@ -583,6 +688,11 @@ func (bc *blockchain) GetReceiptByActionHash(h hash.Hash256) (*action.Receipt, e
 	return bc.dao.getReceiptByActionHash(h)
 }
 
+// GetReceiptsByHeight returns the receipts of the block at the height
+func (bc *blockchain) GetReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	return bc.dao.getReceipts(height)
+}
+
 // GetActionsFromAddress returns actions from address
 func (bc *blockchain) GetActionsFromAddress(address string) ([]hash.Hash256, error) {
 	if !bc.config.Chain.EnableIndex {
@@ -678,6 +788,53 @@ func (bc *blockchain) MintNewBlock(
 	producerPriKey keypair.PrivateKey,
 	producerAddr string,
 	timestamp int64,
+) (*block.Block, error) {
+	return bc.mintNewBlock(
+		actionMap,
+		producerPubKey,
+		producerAddr,
+		timestamp,
+		func(elp action.Envelope) (action.SealedEnvelope, error) {
+			return action.Sign(elp, producerPriKey)
+		},
+		func(b *block.Builder) (block.Block, error) {
+			return b.SignAndBuild(producerPubKey, producerPriKey)
+		},
+	)
+}
+
+func (bc *blockchain) MintNewBlockSignedWith(
+	actionMap map[string][]action.SealedEnvelope,
+	producerPubKey keypair.PublicKey,
+	sign func([]byte) ([]byte, error),
+	signAction func([]byte) ([]byte, error),
+	producerAddr string,
+	timestamp int64,
+	vrfProof []byte,
+) (*block.Block, error) {
+	return bc.mintNewBlock(
+		actionMap,
+		producerPubKey,
+		producerAddr,
+		timestamp,
+		func(elp action.Envelope) (action.SealedEnvelope, error) {
+			return action.SignWith(elp, producerPubKey, signAction)
+		},
+		func(b *block.Builder) (block.Block, error) {
+			return b.SetVRFProof(vrfProof).SignAndBuildWith(producerPubKey, sign)
+		},
+	)
+}
+
+// mintNewBlock runs the actions and builds a new block on top of the tip, which is signed by the build function. The
+// grant reward action of the producer is signed by the sign grant function
+func (bc *blockchain) mintNewBlock(
+	actionMap map[string][]action.SealedEnvelope,
+	producerPubKey keypair.PublicKey,
+	producerAddr string,
+	timestamp int64,
+	signGrant func(action.Envelope) (action.SealedEnvelope, error),
+	signAndBuild func(*block.Builder) (block.Block, error),
 ) (*block.Block, error) {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
@@ -712,7 +869,7 @@ func (bc *blockchain) MintNewBlock(
 			ActionGasLimit: bc.genesisConfig.ActionGasLimit,
 			Registry:       bc.registry,
 		})
-	root, rc, actions, err := bc.pickAndRunActions(ctx, actionMap, ws)
+	root, rc, actions, err := bc.pickAndRunActions(ctx, actionMap, ws, signGrant)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to update state changes in new block %d", newblockHeight)
 	}
@@ -735,23 +892,25 @@ func (bc *blockchain) MintNewBlock(
 	}
 	validateActionsOnlyTimer.End()
 
-	blk, err := block.NewBuilder(ra).
+	blk, err := signAndBuild(block.NewBuilder(ra).
+		SetVersion(block.HeaderVersionAt(bc.genesisConfig.HeaderVersionHeights, newblockHeight)).
 		SetChainID(bc.config.Chain.ID).
 		SetPrevBlockHash(bc.tipHash).
+		SetActionOrder(actionOrderAt(bc.genesisConfig.HashActionOrderHeight, newblockHeight)).
 		SetStateRoot(root).
 		SetDeltaStateDigest(ws.Digest()).
 		SetReceipts(rc).
-		SetReceiptRoot(calculateReceiptRoot(rc)).
-		SignAndBuild(producerPubKey, producerPriKey)
+		SetReceiptRoot(calculateReceiptRoot(rc)))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block")
 	}
 	blk.WorkingSet = ws
+	bc.minted.put(&blk, ws, rc)
 
 	return &blk, nil
 }
 
-//  CommitBlock validates and appends a block to the chain
+// CommitBlock validates and appends a block to the chain
 func (bc *blockchain) CommitBlock(blk *block.Block) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
@@ -761,6 +920,16 @@ func (bc *blockchain) CommitBlock(blk *block.Block) error {
 	return bc.commitBlock(blk)
 }
 
+// CommitBlocks validates and appends the sequential blocks to the chain, and commits their states in a batch
+func (bc *blockchain) CommitBlocks(blks []*block.Block, validateFooter func(*block.Block) error) (int, error) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	timer := bc.timerFactory.NewTimer("CommitBlocks")
+	defer timer.End()
+
+	return bc.commitBlocks(blks, validateFooter)
+}
+
 // StateByAddr returns the account of an address
 func (bc *blockchain) StateByAddr(address string) (*state.Account, error) {
 	if bc.sf != nil {
@@ -800,7 +969,34 @@ func (bc *blockchain) AddSubscriber(s BlockCreationSubscriber) error {
 	return nil
 }
 
+// AddDurableSubscriber makes the subscriber get every committed block in order from its cursor persisted under the name
+func (bc *blockchain) AddDurableSubscriber(name string, s BlockCreationSubscriber) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	for _, ds := range bc.durableListener {
+		if ds.name == name {
+			return errors.Errorf("durable subscriber %s already exists", name)
+		}
+	}
+	ds := newDurableSubscriber(bc, name, s)
+	if bc.running {
+		if err := ds.start(); err != nil {
+			return err
+		}
+	}
+	bc.durableListener = append(bc.durableListener, ds)
+	log.L().Info("Add a durable subscriber.", zap.String("name", name))
+	return nil
+}
+
 func (bc *blockchain) RemoveSubscriber(s BlockCreationSubscriber) error {
+	if ds := bc.removeDurableSubscriber(s); ds != nil {
+		ds.stop()
+		return nil
+	}
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 	for i, sub := range bc.blocklistener {
@@ -813,6 +1009,17 @@ func (bc *blockchain) RemoveSubscriber(s BlockCreationSubscriber) error {
 	return errors.New("cannot find subscription")
 }
 
+// AddRevertSubscriber makes the subscriber get notified of the blocks reverted from the tip of the chain
+func (bc *blockchain) AddRevertSubscriber(s BlockRevertSubscriber) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	bc.revertListener = append(bc.revertListener, s)
+	return nil
+}
+
 //======================================
 // internal functions
 //=====================================
@@ -820,6 +1027,17 @@ func (bc *blockchain) RemoveSubscriber(s BlockCreationSubscriber) error {
 // ExecuteContractRead runs a read-only smart contract operation, this is done off the network since it does not
 // cause any state change
 func (bc *blockchain) ExecuteContractRead(caller address.Address, ex *action.Execution) (*action.Receipt, error) {
+	return bc.ExecuteContractReadWithOverrides(caller, ex, nil)
+}
+
+// ExecuteContractReadWithOverrides runs a read-only smart contract operation as ExecuteContractRead does, on the
+// states with the overrides applied. The overrides are applied to the working set of the execution only, which is
+// discarded afterwards
+func (bc *blockchain) ExecuteContractReadWithOverrides(
+	caller address.Address,
+	ex *action.Execution,
+	overrides []*evm.StateOverride,
+) (*action.Receipt, error) {
 	// use latest block as carrier to run the offline execution
 	// the block itself is not used
 	h := bc.TipHeight()
@@ -831,6 +1049,9 @@ func (bc *blockchain) ExecuteContractRead(caller address.Address, ex *action.Exe
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain working set from state factory")
 	}
+	if err := evm.OverrideStates(bc, ws, overrides); err != nil {
+		return nil, errors.Wrap(err, "failed to override states")
+	}
 	producer, err := address.FromString(blk.ProducerAddress())
 	if err != nil {
 		return nil, err
@@ -901,15 +1122,19 @@ func (bc *blockchain) CreateState(addr string, init *big.Int) (*state.Account, e
 	return account, nil
 }
 
-// RecoverChainAndState recovers the chain to target height and refresh state db if necessary
+// RecoverChainAndState recovers the chain to target height and refresh state db if necessary. The subscribers are
+// notified of the reverted blocks once the state is recovered
 func (bc *blockchain) RecoverChainAndState(targetHeight uint64) error {
-	var buildStateFromScratch bool
+	var (
+		buildStateFromScratch bool
+		reverted              []*block.Block
+	)
 	stateHeight, err := bc.sf.Height()
 	if err != nil {
 		buildStateFromScratch = true
 	}
 	if targetHeight > 0 {
-		if err := bc.recoverToHeight(targetHeight); err != nil {
+		if reverted, err = bc.recoverToHeight(targetHeight); err != nil {
 			return errors.Wrapf(err, "failed to recover blockchain to target height %d", targetHeight)
 		}
 		if stateHeight > bc.tipHeight {
@@ -918,15 +1143,210 @@ func (bc *blockchain) RecoverChainAndState(targetHeight uint64) error {
 	}
 
 	if buildStateFromScratch {
-		return bc.refreshStateDB()
+		if err := bc.refreshStateDB(); err != nil {
+			return err
+		}
+	}
+	bc.emitRevertToSubscribers(reverted)
+	return nil
+}
+
+// RollbackTo reverts the blocks above the height and their states while the chain is running. The states are reverted
+// ahead of the blocks, so that the states left behind by a failure are replayed up to the tip on the next start. The
+// subscribers are notified of the reverted blocks once the chain is unlocked
+func (bc *blockchain) RollbackTo(height uint64) error {
+	bc.mu.Lock()
+	reverted, err := bc.rollbackTo(height)
+	bc.mu.Unlock()
+	if err != nil {
+		return err
 	}
+	bc.emitRevertToSubscribers(reverted)
 	return nil
 }
 
+// Fork keeps a block which doesn't extend the tip as a side block. Only the header version, the signature and the
+// merkle root are verified, while the actions are validated once the chain is rebased onto the branch
+func (bc *blockchain) Fork(blk *block.Block) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	blkHash := blk.HashBlock()
+	if blk.PrevHash() == bc.tipHash {
+		return errors.Wrapf(ErrInvalidBlock, "block %x extends the tip", blkHash)
+	}
+	if h, err := bc.dao.getBlockHash(blk.Height()); err == nil && h == blkHash {
+		return errors.Wrapf(ErrInvalidBlock, "block %x is on the chain", blkHash)
+	}
+	if _, _, err := bc.sideBranch(blk); err != nil {
+		return err
+	}
+	if err := verifyHeaderVersion(blk, bc.genesisConfig.HeaderVersionHeights); err != nil {
+		return err
+	}
+	if err := verifySigAndRoot(blk); err != nil {
+		return errors.Wrap(err, "failed to verify block's signature and merkle root")
+	}
+	return bc.dao.putSideBlock(blk)
+}
+
+// ErrFinalizedBlock indicates the chain is asked to revert a finalized block
+var ErrFinalizedBlock = errors.New("block is finalized")
+
+// Rebase reorganizes the chain onto the branch of the side block, unless a block to revert is finalized. If a block of
+// the branch fails to be committed, the chain is restored onto the reverted blocks. The subscribers are notified of the
+// reverted blocks once the chain is unlocked
+func (bc *blockchain) Rebase(h hash.Hash256, validateFooter func(*block.Block) error) error {
+	bc.mu.Lock()
+	reverted, err := bc.rebase(h, validateFooter)
+	bc.mu.Unlock()
+	bc.emitRevertToSubscribers(reverted)
+	return err
+}
+
 //======================================
 // private functions
 //=====================================
 
+// sideBranch returns the side blocks from where the branch of the block forks from the chain up to the parent of the
+// block, and the height of the fork point. The fork point has to be no more than MaxReorgDepth blocks below the tip,
+// as the states of the deeper blocks can't be reverted
+func (bc *blockchain) sideBranch(blk *block.Block) ([]*block.Block, uint64, error) {
+	var (
+		branch []*block.Block
+		prev   = blk.PrevHash()
+		height = blk.Height()
+	)
+	for height > 0 {
+		height--
+		if height < bc.tipHeight && bc.tipHeight-height > bc.config.BlockSync.MaxReorgDepth {
+			break
+		}
+		if h, err := bc.dao.getBlockHash(height); err == nil && h == prev {
+			return branch, height, nil
+		} else if err != nil && errors.Cause(err) != db.ErrNotExist {
+			return nil, 0, err
+		}
+		parent, err := bc.dao.getSideBlock(prev)
+		if err != nil {
+			if errors.Cause(err) == db.ErrNotExist {
+				return nil, 0, errors.Wrapf(ErrInvalidBlock, "parent %x of the branch is unknown", prev)
+			}
+			return nil, 0, err
+		}
+		if parent.Height() != height {
+			return nil, 0, errors.Wrapf(ErrInvalidBlock, "side block %x is at height %d", prev, parent.Height())
+		}
+		branch = append([]*block.Block{parent}, branch...)
+		prev = parent.PrevHash()
+	}
+	return nil, 0, errors.Wrapf(
+		ErrInvalidBlock,
+		"branch of block %d forks from the chain more than %d blocks below the tip",
+		blk.Height(),
+		bc.config.BlockSync.MaxReorgDepth,
+	)
+}
+
+// rebase reorganizes the chain onto the branch of the side block, and returns the reverted blocks
+func (bc *blockchain) rebase(h hash.Hash256, validateFooter func(*block.Block) error) ([]*block.Block, error) {
+	sideTip, err := bc.dao.getSideBlock(h)
+	if err != nil {
+		return nil, err
+	}
+	branch, forkHeight, err := bc.sideBranch(sideTip)
+	if err != nil {
+		return nil, err
+	}
+	if validateFooter != nil {
+		for height := forkHeight + 1; height <= bc.tipHeight; height++ {
+			blk, err := bc.getBlockByHeight(height)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateFooter(blk); err == nil {
+				return nil, errors.Wrapf(ErrFinalizedBlock, "failed to rebase onto block %x reverting block %d", h, height)
+			}
+		}
+	}
+	branch = append(branch, sideTip)
+	reverted, err := bc.switchBranch(forkHeight, branch)
+	if err == nil {
+		log.L().Warn(
+			"Rebased the chain onto the side branch.",
+			zap.Uint64("forkHeight", forkHeight),
+			zap.Int("reverted", len(reverted)),
+			zap.Int("committed", len(branch)),
+			log.Hex("tipHash", bc.tipHash[:]),
+		)
+		return reverted, nil
+	}
+	err = errors.Wrapf(err, "failed to rebase onto block %x", h)
+	// The blocks of the branch committed so far are reverted in turn, and the reverted blocks, which were on the chain,
+	// are committed again
+	restoreReverted, restoreErr := bc.switchBranch(forkHeight, reverted)
+	if restoreErr != nil {
+		log.L().Error("Failed to restore the chain.", zap.Uint64("forkHeight", forkHeight), zap.Error(restoreErr))
+	}
+	return restoreReverted, err
+}
+
+// switchBranch rolls the chain back to the fork height, keeps the reverted blocks as side blocks, and commits the
+// blocks of the branch. It returns the reverted blocks
+func (bc *blockchain) switchBranch(forkHeight uint64, branch []*block.Block) ([]*block.Block, error) {
+	var (
+		reverted []*block.Block
+		err      error
+	)
+	if forkHeight < bc.tipHeight {
+		if reverted, err = bc.rollbackTo(forkHeight); err != nil {
+			return nil, err
+		}
+		for _, blk := range reverted {
+			if err := bc.dao.putSideBlock(blk); err != nil {
+				return reverted, errors.Wrapf(err, "failed to keep reverted block %d", blk.Height())
+			}
+		}
+	}
+	for _, blk := range branch {
+		if err := bc.validateBlock(blk); err != nil {
+			return reverted, err
+		}
+		if err := bc.commitBlock(blk); err != nil {
+			return reverted, err
+		}
+		if err := bc.dao.deleteSideBlock(blk.HashBlock()); err != nil {
+			log.L().Error("Failed to delete side block.", zap.Uint64("height", blk.Height()), zap.Error(err))
+		}
+	}
+	return reverted, nil
+}
+
+func (bc *blockchain) rollbackTo(height uint64) ([]*block.Block, error) {
+	if height >= bc.tipHeight {
+		return nil, errors.Errorf("cannot roll back tip height %d to %d", bc.tipHeight, height)
+	}
+	if bc.sf != nil {
+		if err := bc.sf.RevertTo(height); err != nil {
+			return nil, errors.Wrapf(err, "failed to revert states to height %d", height)
+		}
+	}
+	reverted, err := bc.recoverToHeight(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to roll back blocks to height %d", height)
+	}
+	if bc.tipHash, err = bc.dao.getBlockHash(height); err != nil {
+		return nil, errors.Wrapf(err, "failed to get block hash at height %d", height)
+	}
+	bc.minted.clear()
+	log.L().Warn(
+		"Rolled back the chain.",
+		zap.Uint64("height", height),
+		zap.Int("reverted", len(reverted)),
+		log.Hex("tipHash", bc.tipHash[:]),
+	)
+	return reverted, nil
+}
+
 func (bc *blockchain) getBlockByHeight(height uint64) (*block.Block, error) {
 	hash, err := bc.dao.getBlockHash(height)
 	if err != nil {
@@ -972,7 +1392,7 @@ func (bc *blockchain) startEmptyBlockchain() error {
 
 		genesis, err = block.NewBuilder(racts).
 			SetChainID(bc.ChainID()).
-			SetPrevBlockHash(Gen.ParentHash).
+			SetPrevBlockHash(bc.genesisPrevHash()).
 			SetStateRoot(root).
 			SetDeltaStateDigest(ws.Digest()).
 			SetReceipts(receipts).
@@ -1014,6 +1434,14 @@ func (bc *blockchain) startExistingBlockchain() error {
 	if stateHeight > bc.tipHeight {
 		return errors.New("factory is higher than blockchain")
 	}
+	if bc.config.BlockSync.FastSync && stateHeight < bc.tipHeight && bc.tipHeight <= bc.genesisConfig.CheckpointHeight {
+		// The blocks up to the checkpoint are imported without running their actions in fast sync, and the states are
+		// loaded from the snapshot at the checkpoint instead
+		log.L().Info("Restarting blockchain in fast sync.",
+			zap.Uint64("chainHeight", bc.tipHeight),
+			zap.Uint64("factoryHeight", stateHeight))
+		return nil
+	}
 
 	for i := stateHeight + 1; i <= bc.tipHeight; i++ {
 		blk, err := bc.getBlockByHeight(i)
@@ -1044,16 +1472,28 @@ func (bc *blockchain) startExistingBlockchain() error {
 
 func (bc *blockchain) validateBlock(blk *block.Block) error {
 	validateTimer := bc.timerFactory.NewTimer("validate")
-	err := bc.validator.Validate(blk, bc.tipHeight, bc.tipHash)
+	err := verifyHeaderVersion(blk, bc.genesisConfig.HeaderVersionHeights)
+	if err == nil {
+		err = bc.validator.Validate(blk, bc.tipHeight, bc.tipHash)
+	}
 	validateTimer.End()
 	if err != nil {
 		return errors.Wrapf(err, "error when validating block %d", blk.Height())
 	}
+	if result, ok := bc.minted.take(blk); ok {
+		// The block was minted by this node on the tip, and the hash has covered the actions and the roots after
+		// running them, so the working set and receipts of the minting are attached instead of running the actions
+		// again
+		blk.WorkingSet = result.ws
+		blk.Receipts = result.receipts
+		return nil
+	}
 	// run actions and update state factory
 	ws, err := bc.sf.NewWorkingSet()
 	if err != nil {
 		return errors.Wrap(err, "Failed to obtain working set from state factory")
 	}
+	snapshot := bc.stateDiffSnapshot(ws)
 	runTimer := bc.timerFactory.NewTimer("runActions")
 	root, receipts, err := bc.runActions(blk.RunnableActions(), ws)
 	runTimer.End()
@@ -1061,11 +1501,7 @@ func (bc *blockchain) validateBlock(blk *block.Block) error {
 		log.L().Panic("Failed to update state.", zap.Uint64("tipHeight", bc.tipHeight), zap.Error(err))
 	}
 
-	if err = blk.VerifyStateRoot(root); err != nil {
-		return err
-	}
-
-	if err = blk.VerifyDeltaStateDigest(ws.Digest()); err != nil {
+	if err = bc.verifyStates(blk, root, ws, snapshot); err != nil {
 		return err
 	}
 
@@ -1101,8 +1537,10 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 	// update tip hash and height
 	atomic.StoreUint64(&bc.tipHeight, blk.Height())
 	bc.tipHash = blk.HashBlock()
+	bc.minted.clear()
 
 	if bc.sf != nil {
+		bc.emitStatesToSubscribers(blk, blk.WorkingSet)
 		sfTimer := bc.timerFactory.NewTimer("sf.Commit")
 		err := bc.sf.Commit(blk.WorkingSet)
 		sfTimer.End()
@@ -1119,7 +1557,9 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to put smart contract receipts into DB on height %d", blk.Height())
 		}
+		bc.pruneReceipts(blk.Height())
 	}
+	bc.pruneSideBlocks(blk.Height())
 	blk.HeaderLogger(log.L()).Info("Committed a block.", log.Hex("tipHash", bc.tipHash[:]))
 
 	// emit block to all block subscribers
@@ -1127,6 +1567,192 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 	return nil
 }
 
+// commitBlocks appends the blocks to the chain one by one, while their states are kept pending in the working sets
+// created one on top of another, and are committed in a single batch at the end. The batch ends at an epoch boundary,
+// because the delegates of an epoch are calculated from the committed states
+func (bc *blockchain) commitBlocks(blks []*block.Block, validateFooter func(*block.Block) error) (int, error) {
+	val, ok := bc.validator.(*validator)
+	if !ok {
+		// A customized validator isn't able to check the nonces against the pending states, so the blocks are
+		// committed one by one
+		for i, blk := range blks {
+			if validateFooter != nil {
+				if err := validateFooter(blk); err != nil {
+					return i, err
+				}
+			}
+			if err := bc.validateBlock(blk); err != nil {
+				return i, err
+			}
+			if err := bc.commitBlock(blk); err != nil {
+				return i, err
+			}
+		}
+		return len(blks), nil
+	}
+	if depth := bc.config.Chain.CommitPipelineDepth; depth > 0 {
+		return newCommitPipeline(bc, val, validateFooter, depth).run(blks)
+	}
+
+	var (
+		wss       []factory.WorkingSet
+		epochNum  uint64
+		tipHeight = bc.tipHeight
+		tipHash   = bc.tipHash
+		err       error
+	)
+	for _, blk := range blks {
+		num := getEpochNum(blk.Height(), bc.genesisConfig.NumDelegates, bc.genesisConfig.NumSubEpochs)
+		var ws factory.WorkingSet
+		if len(wss) == 0 {
+			epochNum = num
+			if ws, err = bc.sf.NewWorkingSet(); err != nil {
+				return 0, errors.Wrap(err, "failed to obtain working set from state factory")
+			}
+		} else {
+			if num != epochNum {
+				break
+			}
+			var wsErr error
+			if ws, wsErr = bc.sf.NewWorkingSetOn(wss[len(wss)-1]); wsErr != nil {
+				// The state factory doesn't support batching, so the batch ends here
+				log.L().Debug("Failed to obtain working set on top of the pending one.", zap.Error(wsErr))
+				break
+			}
+		}
+		if validateFooter != nil {
+			if err = validateFooter(blk); err != nil {
+				break
+			}
+		}
+		if err = bc.validateBlockOn(val, blk, tipHeight, tipHash, ws); err != nil {
+			break
+		}
+		putTimer := bc.timerFactory.NewTimer("putBlock")
+		err = bc.dao.putBlock(blk)
+		putTimer.End()
+		if err != nil {
+			break
+		}
+		bc.emitStatesToSubscribers(blk, ws)
+		wss = append(wss, ws)
+		tipHeight, tipHash = blk.Height(), blk.HashBlock()
+	}
+	n := len(wss)
+	if n == 0 {
+		return 0, err
+	}
+
+	sfTimer := bc.timerFactory.NewTimer("sf.CommitBatch")
+	sfErr := bc.sf.CommitBatch(wss)
+	sfTimer.End()
+	if sfErr != nil {
+		log.L().Panic("Error when committing states.", zap.Error(sfErr))
+	}
+	// update tip hash and height
+	atomic.StoreUint64(&bc.tipHeight, tipHeight)
+	bc.tipHash = tipHash
+	bc.minted.clear()
+	for _, blk := range blks[:n] {
+		// write smart contract receipt into DB
+		if rErr := bc.dao.putReceipts(blk.Height(), blk.Receipts); rErr != nil {
+			log.L().Error(
+				"Failed to put smart contract receipts into DB.",
+				zap.Error(rErr),
+				zap.Uint64("height", blk.Height()),
+			)
+		}
+		blk.HeaderLogger(log.L()).Info("Committed a block.", log.Hex("tipHash", tipHash[:]))
+		// emit block to all block subscribers
+		bc.emitToSubscribers(blk)
+	}
+	bc.pruneReceipts(tipHeight)
+	bc.pruneSideBlocks(tipHeight)
+	return n, err
+}
+
+// pruneReceipts prunes the receipts of the blocks before the latest epochs to retain. The failure is only logged,
+// because the receipts left behind are pruned along with the following blocks
+func (bc *blockchain) pruneReceipts(tipHeight uint64) {
+	retention := bc.config.Chain.ReceiptRetentionEpochs
+	epochSize := bc.genesisConfig.NumDelegates * bc.genesisConfig.NumSubEpochs
+	if retention == 0 || epochSize == 0 {
+		return
+	}
+	epochNum := getEpochNum(tipHeight, bc.genesisConfig.NumDelegates, bc.genesisConfig.NumSubEpochs)
+	if epochNum <= retention {
+		return
+	}
+	if err := bc.dao.pruneReceipts((epochNum - retention) * epochSize); err != nil {
+		log.L().Error("Failed to prune receipts.", zap.Error(err), zap.Uint64("height", tipHeight))
+	}
+}
+
+// pruneSideBlocks prunes the side blocks which can't be rebased onto any more, as their branches fork from the chain
+// more than MaxReorgDepth blocks below the tip. The failure is only logged, like pruning the receipts
+func (bc *blockchain) pruneSideBlocks(tipHeight uint64) {
+	depth := bc.config.BlockSync.MaxReorgDepth
+	if depth == 0 || tipHeight <= depth {
+		return
+	}
+	if err := bc.dao.pruneSideBlocks(tipHeight - depth); err != nil {
+		log.L().Error("Failed to prune side blocks.", zap.Error(err), zap.Uint64("height", tipHeight))
+	}
+}
+
+// validateBlockOn validates the block and runs its actions in the working set, which is created on top of the pending
+// states of the previous blocks
+func (bc *blockchain) validateBlockOn(
+	val *validator,
+	blk *block.Block,
+	tipHeight uint64,
+	tipHash hash.Hash256,
+	ws factory.WorkingSet,
+) error {
+	validateTimer := bc.timerFactory.NewTimer("validate")
+	err := val.validate(blk, tipHeight, tipHash, pendingNonceFn(ws))
+	validateTimer.End()
+	if err != nil {
+		return errors.Wrapf(err, "error when validating block %d", blk.Height())
+	}
+	return bc.runBlockOn(blk, ws)
+}
+
+// runBlockOn runs the actions of the block in the working set, verifies the roots against the header, and attaches
+// the receipts to be put into the DB
+func (bc *blockchain) runBlockOn(blk *block.Block, ws factory.WorkingSet) error {
+	snapshot := bc.stateDiffSnapshot(ws)
+	runTimer := bc.timerFactory.NewTimer("runActions")
+	root, receipts, err := bc.runActions(blk.RunnableActions(), ws)
+	runTimer.End()
+	if err != nil {
+		return errors.Wrapf(err, "failed to run the actions of block %d", blk.Height())
+	}
+	if err = bc.verifyStates(blk, root, ws, snapshot); err != nil {
+		return err
+	}
+	if err = blk.VerifyReceiptRoot(calculateReceiptRoot(receipts)); err != nil {
+		return errors.Wrap(err, "Failed to verify receipt root")
+	}
+	blk.Receipts = receipts
+	return nil
+}
+
+// pendingNonceFn returns the function reading the nonces of the accounts from the pending states of the working set
+func pendingNonceFn(ws factory.WorkingSet) func(string) (uint64, error) {
+	return func(addr string) (uint64, error) {
+		a, err := address.FromString(addr)
+		if err != nil {
+			return 0, err
+		}
+		account, err := util.LoadAccount(ws, byteutil.BytesTo20B(a.Bytes()))
+		if err != nil {
+			return 0, err
+		}
+		return account.Nonce, nil
+	}
+}
+
 func (bc *blockchain) runActions(
 	acts block.RunnableActions,
 	ws factory.WorkingSet,
@@ -1134,14 +1760,23 @@ func (bc *blockchain) runActions(
 	if bc.sf == nil {
 		return hash.ZeroHash256, nil, errors.New("statefactory cannot be nil")
 	}
+	ctx, err := bc.runActionsCtx(acts)
+	if err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	return ws.RunActions(ctx, acts.BlockHeight(), acts.Actions())
+}
+
+// runActionsCtx returns the context to run the actions of the block
+func (bc *blockchain) runActionsCtx(acts block.RunnableActions) (context.Context, error) {
 	gasLimit := bc.genesisConfig.BlockGasLimit
 	// update state factory
 	producer, err := address.FromString(acts.BlockProducerAddr())
 	if err != nil {
-		return hash.ZeroHash256, nil, err
+		return nil, err
 	}
 
-	ctx := protocol.WithRunActionsCtx(context.Background(),
+	return protocol.WithRunActionsCtx(context.Background(),
 		protocol.RunActionsCtx{
 			EpochNumber: getEpochNum(
 				acts.BlockHeight(),
@@ -1156,13 +1791,12 @@ func (bc *blockchain) runActions(
 			GasLimit:       &gasLimit,
 			ActionGasLimit: bc.genesisConfig.ActionGasLimit,
 			Registry:       bc.registry,
-		})
-
-	return ws.RunActions(ctx, acts.BlockHeight(), acts.Actions())
+		}), nil
 }
 
 func (bc *blockchain) pickAndRunActions(ctx context.Context, actionMap map[string][]action.SealedEnvelope,
-	ws factory.WorkingSet) (hash.Hash256, []*action.Receipt, []action.SealedEnvelope, error) {
+	ws factory.WorkingSet, signGrant func(action.Envelope) (action.SealedEnvelope, error),
+) (hash.Hash256, []*action.Receipt, []action.SealedEnvelope, error) {
 	if bc.sf == nil {
 		return hash.ZeroHash256, nil, nil, errors.New("statefactory cannot be nil")
 	}
@@ -1174,7 +1808,12 @@ func (bc *blockchain) pickAndRunActions(ctx context.Context, actionMap map[strin
 		return hash.ZeroHash256, nil, nil, errors.New("failed to get action context")
 	}
 	// initial action iterator
-	actionIterator := actioniterator.NewActionIterator(actionMap)
+	var actionIterator actioniterator.ActionIterator
+	if actionOrderAt(bc.genesisConfig.HashActionOrderHeight, raCtx.BlockHeight) == block.HashOrder {
+		actionIterator = actioniterator.NewHashOrderedActionIterator(actionMap, bc.tipHash)
+	} else {
+		actionIterator = actioniterator.NewActionIterator(actionMap)
+	}
 	for {
 		nextAction, ok := actionIterator.Next()
 		if !ok {
@@ -1190,6 +1829,24 @@ func (bc *blockchain) pickAndRunActions(ctx context.Context, actionMap map[strin
 				actionIterator.PopAccount()
 				continue
 			}
+			if errors.Cause(err) == action.ErrNotAllowed {
+				// the sender has been removed from the allowlist since the action was added into the actpool, so the
+				// rest of its actions are skipped as well
+				actionIterator.PopAccount()
+				continue
+			}
+			if errors.Cause(err) == action.ErrFreeGas {
+				// the sender has run out of the free gas quota since the action was added into the actpool, so the
+				// rest of its actions are skipped as well
+				actionIterator.PopAccount()
+				continue
+			}
+			if errors.Cause(err) == action.ErrPaused {
+				// the action type has been paused since the action was added into the actpool, so the rest of the
+				// sender's actions are skipped as well, as their nonces can't be filled
+				actionIterator.PopAccount()
+				continue
+			}
 			return hash.ZeroHash256, nil, nil, errors.Wrapf(err, "Failed to update state changes for selp %s", nextAction.Hash())
 		}
 		if receipt != nil {
@@ -1205,7 +1862,7 @@ func (bc *blockchain) pickAndRunActions(ctx context.Context, actionMap map[strin
 	}
 
 	// Process grant block reward action
-	grant, err := bc.createGrantBlockRewardAction()
+	grant, err := bc.createGrantBlockRewardAction(signGrant)
 	if err != nil {
 		return hash.ZeroHash256, nil, nil, err
 	}
@@ -1218,7 +1875,25 @@ func (bc *blockchain) pickAndRunActions(ctx context.Context, actionMap map[strin
 	return ws.UpdateBlockLevelInfo(raCtx.BlockHeight), receipts, executedActions, nil
 }
 
+// emitStatesToSubscribers hands the states right after the block over to the subscribers reading them, before the
+// states are committed, because the states of a block are no longer readable once another block is committed on top
+func (bc *blockchain) emitStatesToSubscribers(blk *block.Block, sm protocol.StateManager) {
+	for _, s := range bc.blocklistener {
+		ss, ok := s.(BlockStateSubscriber)
+		if !ok {
+			continue
+		}
+		if err := ss.HandleBlockStates(blk, sm); err != nil {
+			log.L().Error("Failed to handle states of new block.", zap.Error(err), zap.Uint64("height", blk.Height()))
+		}
+	}
+}
+
 func (bc *blockchain) emitToSubscribers(blk *block.Block) {
+	atomic.StoreUint64(&bc.emittedHeight, blk.Height())
+	for _, ds := range bc.durableListener {
+		ds.wake()
+	}
 	if bc.blocklistener == nil {
 		return
 	}
@@ -1231,6 +1906,52 @@ func (bc *blockchain) emitToSubscribers(blk *block.Block) {
 	}
 }
 
+// startDurableSubscribers starts delivering the blocks to the durable subscribers, once the chain is started
+func (bc *blockchain) startDurableSubscribers() error {
+	atomic.StoreUint64(&bc.emittedHeight, bc.tipHeight)
+	for _, ds := range bc.durableListener {
+		if err := ds.start(); err != nil {
+			return errors.Wrapf(err, "failed to start durable subscriber %s", ds.name)
+		}
+	}
+	bc.running = true
+	return nil
+}
+
+// stopDurableSubscribers marks the chain stopped, and returns the durable subscribers to stop
+func (bc *blockchain) stopDurableSubscribers() []*durableSubscriber {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.running = false
+	return append([]*durableSubscriber{}, bc.durableListener...)
+}
+
+// removeDurableSubscriber removes the durable subscriber, and returns nil if it isn't found
+func (bc *blockchain) removeDurableSubscriber(s BlockCreationSubscriber) *durableSubscriber {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	for i, ds := range bc.durableListener {
+		if ds.s == s {
+			bc.durableListener = append(bc.durableListener[:i], bc.durableListener[i+1:]...)
+			log.L().Info("Remove a durable subscriber.", zap.String("name", ds.name))
+			return ds
+		}
+	}
+	return nil
+}
+
+// emitRevertToSubscribers notifies the subscribers of the reverted blocks in order, so that the actions of the lower
+// blocks are handled first
+func (bc *blockchain) emitRevertToSubscribers(blks []*block.Block) {
+	for _, blk := range blks {
+		for _, s := range bc.revertListener {
+			if err := s.HandleBlockRevert(blk); err != nil {
+				log.L().Error("Failed to handle reverted block.", zap.Uint64("height", blk.Height()), zap.Error(err))
+			}
+		}
+	}
+}
+
 func (bc *blockchain) now() int64 { return bc.clk.Now().Unix() }
 
 func (bc *blockchain) genesisProducer() (keypair.PublicKey, keypair.PrivateKey, string, error) {
@@ -1251,14 +1972,21 @@ func (bc *blockchain) genesisProducer() (keypair.PublicKey, keypair.PrivateKey,
 }
 
 // RecoverToHeight recovers the blockchain to target height
-func (bc *blockchain) recoverToHeight(targetHeight uint64) error {
+// recoverToHeight deletes the blocks above the target height, and returns the deleted blocks from low to high
+func (bc *blockchain) recoverToHeight(targetHeight uint64) ([]*block.Block, error) {
+	var reverted []*block.Block
 	for bc.tipHeight > targetHeight {
+		blk, err := bc.getBlockByHeight(bc.tipHeight)
+		if err != nil {
+			return nil, err
+		}
 		if err := bc.dao.deleteTipBlock(); err != nil {
-			return err
+			return nil, err
 		}
-		bc.tipHeight--
+		atomic.StoreUint64(&bc.tipHeight, bc.tipHeight-1)
+		reverted = append([]*block.Block{blk}, reverted...)
 	}
-	return nil
+	return reverted, nil
 }
 
 // RefreshStateDB deletes the existing state DB and creates a new one with state changes from genesis block
@@ -1316,7 +2044,9 @@ func (bc *blockchain) buildStateInGenesis() error {
 	return nil
 }
 
-func (bc *blockchain) createGrantBlockRewardAction() (action.SealedEnvelope, error) {
+func (bc *blockchain) createGrantBlockRewardAction(
+	sign func(action.Envelope) (action.SealedEnvelope, error),
+) (action.SealedEnvelope, error) {
 	gb := action.GrantRewardBuilder{}
 	grant := gb.SetRewardType(action.BlockReward).Build()
 	eb := action.EnvelopeBuilder{}
@@ -1325,11 +2055,7 @@ func (bc *blockchain) createGrantBlockRewardAction() (action.SealedEnvelope, err
 		SetGasLimit(grant.GasLimit()).
 		SetAction(&grant).
 		Build()
-	_, sk, err := bc.config.KeyPair()
-	if err != nil {
-		log.L().Panic("Failed to get block producer private key.", zap.Error(err))
-	}
-	return action.Sign(envelope, sk)
+	return sign(envelope)
 }
 
 func (bc *blockchain) createGenesisStates(ws factory.WorkingSet) error {
@@ -1358,14 +2084,73 @@ func (bc *blockchain) createGenesisStates(ws factory.WorkingSet) error {
 	if !ok {
 		return errors.Errorf("error when casting protocol")
 	}
-	return rp.Initialize(
+	if err := rp.Initialize(
 		ctx,
 		ws,
 		bc.genesisConfig.Rewarding.InitAdminAddr(),
 		bc.genesisConfig.InitBalance(),
 		bc.genesisConfig.BlockReward(),
 		bc.genesisConfig.EpochReward(),
-	)
+		bc.genesisConfig.GasFeeBurnPercentage,
+		bc.genesisConfig.GasFeeProducerPercentage,
+	); err != nil {
+		return err
+	}
+	if err := rp.InitializeMultisigAdmins(
+		ctx,
+		ws,
+		bc.genesisConfig.Rewarding.InitMultisigAdminAddrs(),
+		bc.genesisConfig.MultisigThreshold,
+		bc.genesisConfig.MultisigProposalTTL,
+	); err != nil {
+		return err
+	}
+	if bc.genesisConfig.EnableAllowlist {
+		p, ok = bc.registry.Find(allowlist.ProtocolID)
+		if !ok {
+			return errors.Errorf("protocol %s isn't found", allowlist.ProtocolID)
+		}
+		ap, ok := p.(*allowlist.Protocol)
+		if !ok {
+			return errors.Errorf("error when casting protocol")
+		}
+		if err := ap.Initialize(
+			ctx,
+			ws,
+			bc.genesisConfig.Allowlist.InitAllowlistAdminAddr(),
+			bc.genesisConfig.Allowlist.InitAllowedAddrs(),
+		); err != nil {
+			return err
+		}
+	}
+	if bc.genesisConfig.EnableGovernance {
+		p, ok = bc.registry.Find(governance.ProtocolID)
+		if !ok {
+			return errors.Errorf("protocol %s isn't found", governance.ProtocolID)
+		}
+		gp, ok := p.(*governance.Protocol)
+		if !ok {
+			return errors.Errorf("error when casting protocol")
+		}
+		if err := gp.Initialize(ctx, ws, bc.genesisConfig.Governance.InitGovernanceAdminAddr()); err != nil {
+			return err
+		}
+	}
+	if bc.genesisConfig.EnableBLS {
+		p, ok = bc.registry.Find(blskey.ProtocolID)
+		if !ok {
+			return errors.Errorf("protocol %s isn't found", blskey.ProtocolID)
+		}
+		bp, ok := p.(*blskey.Protocol)
+		if !ok {
+			return errors.Errorf("error when casting protocol")
+		}
+		addrs, pubKeys, proofs := bc.genesisConfig.BLS.InitDelegateBLSKeys()
+		if err := bp.Initialize(ctx, ws, addrs, pubKeys, proofs); err != nil {
+			return err
+		}
+	}
+	return bc.createSnapshotStates(ws)
 }
 
 func calculateReceiptRoot(receipts []*action.Receipt) hash.Hash256 {
@@ -1381,12 +2166,7 @@ func calculateReceiptRoot(receipts []*action.Receipt) hash.Hash256 {
 }
 
 func producerAddress(cfg config.Config) address.Address {
-	pubKey, _, err := cfg.KeyPair()
-	if err != nil {
-		log.L().Panic("Failed to get block producer public key.", zap.Error(err))
-	}
-	pkHash := keypair.HashPubKey(pubKey)
-	address, err := address.FromBytes(pkHash[:])
+	address, err := cfg.BlockchainAddress()
 	if err != nil {
 		log.L().Panic("Failed to get block producer address.", zap.Error(err))
 	}
@@ -8,21 +8,25 @@ package blockchain
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/state/factory"
@@ -374,6 +378,56 @@ func TestBlockchain_MintNewBlock(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBlockchain_MintNewBlock_CachedExecution(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	genesisCfg := genesis.Default
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), GenesisOption(genesisCfg))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.ActionGasLimit))
+	bc.Validator().AddActionValidators(account.NewProtocol())
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(ctx))
+	defer func() { require.NoError(bc.Stop(ctx)) }()
+
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["producer"].String(), mustGenesisProducerKey(t), 1, big.NewInt(100), []byte{}, 100000, big.NewInt(10))
+	require.NoError(err)
+	blk, err := bc.MintNewBlock(
+		map[string][]action.SealedEnvelope{Gen.CreatorAddr(): {tsf}},
+		ta.Keyinfo["producer"].PubKey,
+		ta.Keyinfo["producer"].PriKey,
+		ta.Addrinfo["producer"].String(),
+		0,
+	)
+	require.NoError(err)
+
+	// The copy of the minted block takes the working set and receipts of the minting, rather than running the
+	// actions again
+	raw, err := blk.Serialize()
+	require.NoError(err)
+	var received block.Block
+	require.NoError(received.Deserialize(raw))
+	require.Nil(received.WorkingSet)
+	require.NoError(bc.ValidateBlock(&received))
+	require.True(blk.WorkingSet == received.WorkingSet)
+	require.Equal(blk.Receipts, received.Receipts)
+	require.NoError(bc.CommitBlock(&received))
+	require.Equal(blk.HashBlock(), bc.TipHash())
+	balance, err := bc.Balance(ta.Addrinfo["producer"].String())
+	require.NoError(err)
+	require.Equal(big.NewInt(100), balance)
+
+	// The cached results are dropped once the block is committed
+	_, ok := bc.(*blockchain).minted.take(blk)
+	require.False(ok)
+}
+
+func mustGenesisProducerKey(t *testing.T) keypair.PrivateKey {
+	sk, err := keypair.DecodePrivateKey(GenesisProducerPrivateKey)
+	require.NoError(t, err)
+	return sk
+}
+
 func TestBlockchain_MintNewBlock_PopAccount(t *testing.T) {
 	ctx := context.Background()
 	cfg := config.Default
@@ -1109,3 +1163,326 @@ func addCreatorToFactory(sf factory.Factory) error {
 	}
 	return sf.Commit(ws)
 }
+
+func TestBlockchain_CommitBlocks(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	genesisConfig := genesis.Default
+
+	newChain := func() (Blockchain, factory.Factory) {
+		sf, err := factory.NewStateDB(cfg, factory.InMemStateDBOption())
+		require.NoError(err)
+		sf.AddActionHandlers(account.NewProtocol())
+		bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+		bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit))
+		bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+		sf.AddActionHandlers(vote.NewProtocol(bc))
+		require.NoError(bc.Start(ctx))
+		require.NoError(addCreatorToFactory(sf))
+		return bc, sf
+	}
+
+	// Produce the blocks on a chain committing them one by one
+	src, _ := newChain()
+	defer func() {
+		require.NoError(src.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(src))
+	tip := src.TipHeight()
+	blks := make([]*block.Block, 0, tip)
+	for h := uint64(1); h <= tip; h++ {
+		blk, err := src.GetBlockByHeight(h)
+		require.NoError(err)
+		blks = append(blks, blk)
+	}
+
+	bc, sf := newChain()
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	// The batch stops at the block failing the footer validation
+	errFooter := errors.New("invalid footer")
+	n, err := bc.CommitBlocks(blks, func(blk *block.Block) error {
+		if blk.Height() == 3 {
+			return errFooter
+		}
+		return nil
+	})
+	require.Equal(errFooter, err)
+	require.Equal(2, n)
+	require.Equal(uint64(2), bc.TipHeight())
+
+	// The remaining blocks are committed in a batch
+	n, err = bc.CommitBlocks(blks[2:], nil)
+	require.NoError(err)
+	require.Equal(len(blks)-2, n)
+	require.Equal(tip, bc.TipHeight())
+	require.Equal(src.TipHash(), bc.TipHash())
+	height, err := sf.Height()
+	require.NoError(err)
+	require.Equal(tip, height)
+	for _, name := range []string{"producer", "alfa", "bravo", "charlie", "delta", "echo", "foxtrot"} {
+		addr := ta.Addrinfo[name].String()
+		expected, err := src.Balance(addr)
+		require.NoError(err)
+		balance, err := bc.Balance(addr)
+		require.NoError(err)
+		require.Equal(expected, balance)
+	}
+
+	// A block that is already committed is rejected
+	n, err = bc.CommitBlocks(blks[len(blks)-1:], nil)
+	require.Error(err)
+	require.Equal(0, n)
+}
+
+func TestBlockchain_RollbackTo(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.BlockSync.MaxReorgDepth = 3
+	genesisConfig := genesis.Default
+
+	sf, err := factory.NewStateDB(cfg, factory.InMemStateDBOption())
+	require.NoError(err)
+	sf.AddActionHandlers(account.NewProtocol())
+	bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit))
+	bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+	sf.AddActionHandlers(vote.NewProtocol(bc))
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addCreatorToFactory(sf))
+	require.NoError(addTestingTsfBlocks(bc))
+
+	tip := bc.TipHeight()
+	blks := make([]*block.Block, 0, tip)
+	for h := uint64(1); h <= tip; h++ {
+		blk, err := bc.GetBlockByHeight(h)
+		require.NoError(err)
+		blks = append(blks, blk)
+	}
+	names := []string{"producer", "alfa", "bravo", "charlie", "delta", "echo", "foxtrot"}
+	balances := make(map[string]*big.Int)
+	for _, name := range names {
+		balance, err := bc.Balance(ta.Addrinfo[name].String())
+		require.NoError(err)
+		balances[name] = balance
+	}
+
+	require.Error(bc.RollbackTo(tip))
+	// The undo logs are kept for the latest 3 heights only
+	require.Error(bc.RollbackTo(tip - 4))
+	require.Equal(tip, bc.TipHeight())
+
+	require.NoError(bc.RollbackTo(tip - 2))
+	require.Equal(tip-2, bc.TipHeight())
+	require.Equal(blks[tip-3].HashBlock(), bc.TipHash())
+	height, err := sf.Height()
+	require.NoError(err)
+	require.Equal(tip-2, height)
+	_, err = bc.GetBlockByHeight(tip - 1)
+	require.Error(err)
+
+	// The rolled back blocks are committed again onto the same states
+	n, err := bc.CommitBlocks(blks[tip-2:], nil)
+	require.NoError(err)
+	require.Equal(2, n)
+	require.Equal(blks[tip-1].HashBlock(), bc.TipHash())
+	for _, name := range names {
+		balance, err := bc.Balance(ta.Addrinfo[name].String())
+		require.NoError(err)
+		require.Equal(balances[name], balance)
+	}
+}
+
+func TestBlockchain_ForkAndRebase(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.BlockSync.MaxReorgDepth = 3
+	genesisConfig := genesis.Default
+
+	sf, err := factory.NewStateDB(cfg, factory.InMemStateDBOption())
+	require.NoError(err)
+	sf.AddActionHandlers(account.NewProtocol())
+	bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit))
+	bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+	sf.AddActionHandlers(vote.NewProtocol(bc))
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addCreatorToFactory(sf))
+	require.NoError(addTestingTsfBlocks(bc))
+
+	tip := bc.TipHeight()
+	blks := make([]*block.Block, 0, tip)
+	for h := uint64(1); h <= tip; h++ {
+		blk, err := bc.GetBlockByHeight(h)
+		require.NoError(err)
+		blks = append(blks, blk)
+	}
+	names := []string{"producer", "alfa", "bravo", "charlie", "delta", "echo", "foxtrot"}
+	balances := make(map[string]*big.Int)
+	for _, name := range names {
+		balance, err := bc.Balance(ta.Addrinfo[name].String())
+		require.NoError(err)
+		balances[name] = balance
+	}
+
+	// The chain moves onto an empty block, which leaves the top 2 blocks off the chain
+	require.NoError(bc.RollbackTo(tip - 2))
+	empty, err := bc.MintNewBlock(
+		nil,
+		ta.Keyinfo["producer"].PubKey,
+		ta.Keyinfo["producer"].PriKey,
+		ta.Addrinfo["producer"].String(),
+		1,
+	)
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(empty))
+	require.NoError(bc.CommitBlock(empty))
+	require.Equal(tip-1, bc.TipHeight())
+
+	// A block extending the tip, a block on the chain, or a block of an unknown branch isn't a side block
+	require.Error(bc.Fork(empty))
+	require.Error(bc.Fork(blks[tip-3]))
+	require.Error(bc.Fork(blks[tip-1]))
+	require.NoError(bc.Fork(blks[tip-2]))
+	require.NoError(bc.Fork(blks[tip-1]))
+
+	require.Error(bc.Rebase(hash.Hash256b([]byte("unknown")), nil))
+	// The chain isn't rebased once the empty block to revert is finalized
+	err = bc.Rebase(blks[tip-1].HashBlock(), func(*block.Block) error { return nil })
+	require.Equal(ErrFinalizedBlock, errors.Cause(err))
+	require.Equal(empty.HashBlock(), bc.TipHash())
+	require.NoError(bc.Rebase(blks[tip-1].HashBlock(), func(*block.Block) error {
+		return errors.New("insufficient endorsements")
+	}))
+	require.Equal(tip, bc.TipHeight())
+	require.Equal(blks[tip-1].HashBlock(), bc.TipHash())
+	for _, name := range names {
+		balance, err := bc.Balance(ta.Addrinfo[name].String())
+		require.NoError(err)
+		require.Equal(balances[name], balance)
+	}
+	// The reverted block is kept as a side block, while the rebased ones aren't any more
+	dao := bc.(*blockchain).dao
+	side, err := dao.getSideBlock(empty.HashBlock())
+	require.NoError(err)
+	require.Equal(empty.HashBlock(), side.HashBlock())
+	_, err = dao.getSideBlock(blks[tip-1].HashBlock())
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// The chain is rebased back onto the empty block
+	require.NoError(bc.Rebase(empty.HashBlock(), nil))
+	require.Equal(tip-1, bc.TipHeight())
+	require.Equal(empty.HashBlock(), bc.TipHash())
+
+	// The side branch forks from the chain too deep below the tip once the chain grows, and is pruned
+	for i := 0; i < 3; i++ {
+		blk, err := bc.MintNewBlock(
+			nil,
+			ta.Keyinfo["producer"].PubKey,
+			ta.Keyinfo["producer"].PriKey,
+			ta.Addrinfo["producer"].String(),
+			int64(i+2),
+		)
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	require.Error(bc.Rebase(blks[tip-1].HashBlock(), nil))
+	_, err = dao.getSideBlock(blks[tip-2].HashBlock())
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+}
+
+func TestBlockchain_PruneReceipts(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.ReceiptRetentionEpochs = 2
+	genesisConfig := genesis.Default
+	// Each epoch has a single block
+	genesisConfig.NumDelegates = 1
+	genesisConfig.NumSubEpochs = 1
+
+	sf, err := factory.NewStateDB(cfg, factory.InMemStateDBOption())
+	require.NoError(err)
+	sf.AddActionHandlers(account.NewProtocol())
+	bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit))
+	bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+	sf.AddActionHandlers(vote.NewProtocol(bc))
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addCreatorToFactory(sf))
+	require.NoError(addTestingTsfBlocks(bc))
+
+	// The receipts of the blocks before the latest 2 epochs are pruned
+	tip := bc.TipHeight()
+	pruned, err := bc.(*blockchain).dao.getReceiptsPrunedHeight()
+	require.NoError(err)
+	require.Equal(tip-2, pruned)
+	// The blocks are all kept
+	for h := uint64(1); h <= tip; h++ {
+		_, err := bc.GetBlockByHeight(h)
+		require.NoError(err)
+	}
+}
+
+func TestBlockchain_ExecuteContractReadWithOverrides(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), GenesisOption(genesis.Default))
+	require.NoError(bc.Start(ctx))
+	defer func() { require.NoError(bc.Stop(ctx)) }()
+
+	contract := ta.Addrinfo["alfa"].String()
+	caller := ta.Addrinfo["bravo"]
+	read := func(overrides []*evm.StateOverride) []byte {
+		ex, err := action.NewExecution(contract, 1, big.NewInt(0), 100000, big.NewInt(0), nil)
+		require.NoError(err)
+		receipt, err := bc.ExecuteContractReadWithOverrides(caller, ex, overrides)
+		require.NoError(err)
+		return receipt.ReturnValue
+	}
+	value := hash.Hash256b([]byte("value"))
+	// PUSH1 0, SLOAD, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, RETURN
+	loadSlot, err := hex.DecodeString("60005460005260206000f3")
+	require.NoError(err)
+	// ADDRESS, BALANCE, PUSH1 0, MSTORE, PUSH1 32, PUSH1 0, RETURN
+	loadBalance, err := hex.DecodeString("303160005260206000f3")
+	require.NoError(err)
+
+	require.Empty(read(nil))
+	require.Equal(value[:], read([]*evm.StateOverride{{
+		Address: contract,
+		Code:    loadSlot,
+		Storage: map[hash.Hash256]hash.Hash256{hash.ZeroHash256: value},
+	}}))
+	balance := read([]*evm.StateOverride{{Address: contract, Balance: big.NewInt(12345), Code: loadBalance}})
+	require.Equal(big.NewInt(12345), big.NewInt(0).SetBytes(balance))
+
+	// The overrides aren't persisted
+	require.Empty(read(nil))
+	state, err := bc.StateByAddr(contract)
+	require.NoError(err)
+	require.Equal(big.NewInt(0), state.Balance)
+
+	ex, err := action.NewExecution(contract, 1, big.NewInt(0), 100000, big.NewInt(0), nil)
+	require.NoError(err)
+	_, err = bc.ExecuteContractReadWithOverrides(caller, ex, []*evm.StateOverride{
+		{Address: contract, Balance: big.NewInt(1)},
+		{Address: contract, Code: loadSlot},
+	})
+	require.Error(err)
+}
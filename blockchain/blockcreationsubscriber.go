@@ -6,9 +6,25 @@
 
 package blockchain
 
-import "github.com/iotexproject/iotex-core/blockchain/block"
+import (
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
 
 // BlockCreationSubscriber is an interface which will get notified when a block is created
 type BlockCreationSubscriber interface {
 	HandleBlock(*block.Block) error
 }
+
+// BlockRevertSubscriber is an interface which will get notified when a block is reverted from the tip of the chain
+type BlockRevertSubscriber interface {
+	HandleBlockRevert(*block.Block) error
+}
+
+// BlockStateSubscriber is a BlockCreationSubscriber which also reads the states right after each block committed.
+// HandleBlockStates is called synchronously in the order of the blocks, before the states of the block are committed,
+// so it should return quickly
+type BlockStateSubscriber interface {
+	BlockCreationSubscriber
+	HandleBlockStates(*block.Block, protocol.StateManager) error
+}
@@ -8,12 +8,14 @@ package blockchain
 
 import (
 	"context"
+	"sync"
 
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-ethereum/core/types"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -22,6 +24,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
@@ -42,6 +45,16 @@ const (
 	blockAddressActionMappingNS         = "address<->action"
 	blockAddressActionCountMappingNS    = "address<->actioncount"
 	receiptsNS                          = "receipts"
+	logBloomNS                          = "logBlooms"
+	sideBlockNS                         = "sideBlocks"
+	subscriberCursorNS                  = "subscriberCursors"
+
+	// maxReceiptsPrunedPerCall is the max number of blocks whose receipts are pruned at a time
+	maxReceiptsPrunedPerCall = 1000
+	// maxBlocksPrunedPerCall is the max number of blocks whose bodies are pruned at a time
+	maxBlocksPrunedPerCall = 1000
+	// maxSideBlockHeightsPrunedPerCall is the max number of heights whose side blocks are pruned at a time
+	maxSideBlockHeightsPrunedPerCall = 1000
 )
 
 var (
@@ -66,6 +79,19 @@ var (
 	executionToPrefix   = []byte("execution-to")
 	actionFromPrefix    = []byte("action-from")
 	actionToPrefix      = []byte("action-to")
+	// receiptsPrunedHeightKey is the key of the height at or below which the receipts are pruned
+	receiptsPrunedHeightKey = []byte("receipts-pruned-height")
+	// blocksPrunedHeightKey is the key of the height at or below which the block bodies are pruned
+	blocksPrunedHeightKey = []byte("blocks-pruned-height")
+	// sideBlocksPrunedHeightKey is the key of the height at or below which the side blocks are pruned
+	sideBlocksPrunedHeightKey = []byte("side-blocks-pruned-height")
+)
+
+var (
+	// ErrReceiptPruned indicates the receipt has been pruned from the DB
+	ErrReceiptPruned = errors.New("receipt is pruned")
+	// ErrBlockPruned indicates the block body has been pruned from the DB
+	ErrBlockPruned = errors.New("block is pruned")
 )
 
 var _ lifecycle.StartStopper = (*blockDAO)(nil)
@@ -74,6 +100,10 @@ type blockDAO struct {
 	writeIndex bool
 	kvstore    db.KVStore
 	lifecycle  lifecycle.Lifecycle
+	// compactOnStart compacts the KV store on start, once the pruned blocks have left enough of it free
+	compactOnStart bool
+	// pruneMutex serializes the pruning, which may run in the background along with the commits
+	pruneMutex sync.Mutex
 }
 
 // newBlockDAO instantiates a block DAO
@@ -92,6 +122,15 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to start child services")
 	}
+	if dao.compactOnStart {
+		compacted, err := db.Compact(dao.kvstore)
+		if err != nil && errors.Cause(err) != db.ErrCompactNotSupported {
+			return errors.Wrap(err, "failed to compact the KV store")
+		}
+		if compacted {
+			log.L().Info("Compacted the chain DB.")
+		}
+	}
 
 	// set init height value
 	if _, err = dao.kvstore.Get(blockNS, topHeightKey); err != nil &&
@@ -174,6 +213,9 @@ func (dao *blockDAO) getBlockHeight(hash hash.Hash256) (uint64, error) {
 func (dao *blockDAO) getBlock(hash hash.Hash256) (*block.Block, error) {
 	value, err := dao.kvstore.Get(blockNS, hash[:])
 	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist && dao.isBlockPruned(hash) {
+			return nil, errors.Wrapf(ErrBlockPruned, "block %x", hash)
+		}
 		return nil, errors.Wrapf(err, "failed to get block %x", hash)
 	}
 	if len(value) == 0 {
@@ -258,6 +300,21 @@ func (dao *blockDAO) getReceiptByActionHash(h hash.Hash256) (*action.Receipt, er
 	receiptsBytes, err := dao.kvstore.Get(receiptsNS, heightBytes)
 	if err != nil {
 		height := enc.MachineEndian.Uint64(heightBytes)
+		if errors.Cause(err) == db.ErrNotExist {
+			pruned, pErr := dao.getReceiptsPrunedHeight()
+			if pErr != nil {
+				return nil, pErr
+			}
+			if height <= pruned {
+				return nil, errors.Wrapf(
+					ErrReceiptPruned,
+					"action %x is in block %d, while the receipts at or below height %d are pruned",
+					h,
+					height,
+					pruned,
+				)
+			}
+		}
 		return nil, errors.Wrapf(err, "failed to get receipts of block %d", height)
 	}
 	receipts := iotextypes.Receipts{}
@@ -271,7 +328,7 @@ func (dao *blockDAO) getReceiptByActionHash(h hash.Hash256) (*action.Receipt, er
 			return &r, nil
 		}
 	}
-	return nil, errors.Errorf("receipt of action %x isn't found", h)
+	return nil, errors.Wrapf(db.ErrNotExist, "receipt of action %x isn't found", h)
 }
 
 // putBlock puts a block
@@ -338,6 +395,261 @@ func (dao *blockDAO) putReceipts(blkHeight uint64, blkReceipts []*action.Receipt
 		return err
 	}
 	batch.Put(receiptsNS, heightBytes[:], receiptsBytes, "Failed to put receipts of block %d", blkHeight)
+	bloom := logBloom(blkReceipts)
+	batch.Put(logBloomNS, heightBytes[:], bloom.Bytes(), "Failed to put log bloom of block %d", blkHeight)
+	return dao.kvstore.Commit(batch)
+}
+
+// getReceipts returns the receipts of the block at the height
+func (dao *blockDAO) getReceipts(height uint64) ([]*action.Receipt, error) {
+	receiptsBytes, err := dao.kvstore.Get(receiptsNS, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipts of block %d", height)
+	}
+	receipts := iotextypes.Receipts{}
+	if err := proto.Unmarshal(receiptsBytes, &receipts); err != nil {
+		return nil, err
+	}
+	res := make([]*action.Receipt, 0, len(receipts.Receipts))
+	for _, receipt := range receipts.Receipts {
+		r := &action.Receipt{}
+		r.ConvertFromReceiptPb(receipt)
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// getLogBloom returns the bloom filter of the logs in the block at the height. The returned error is db.ErrNotExist if
+// the block has no bloom stored, e.g., it's committed before the blooms are stored
+func (dao *blockDAO) getLogBloom(height uint64) (types.Bloom, error) {
+	value, err := dao.kvstore.Get(logBloomNS, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		return types.Bloom{}, errors.Wrapf(err, "failed to get log bloom of block %d", height)
+	}
+	return types.BytesToBloom(value), nil
+}
+
+// getReceiptsPrunedHeight returns the height at or below which the receipts are pruned, which is 0 if no receipt is
+// pruned
+func (dao *blockDAO) getReceiptsPrunedHeight() (uint64, error) {
+	value, err := dao.kvstore.Get(blockNS, receiptsPrunedHeightKey)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to get receipts pruned height")
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
+// pruneReceipts deletes the receipts of the blocks at or below the height, while the blocks and the action to receipt
+// index are kept. At most maxReceiptsPrunedPerCall blocks are pruned at a time, and the rest are left to the
+// following calls
+func (dao *blockDAO) pruneReceipts(height uint64) error {
+	dao.pruneMutex.Lock()
+	defer dao.pruneMutex.Unlock()
+	pruned, err := dao.getReceiptsPrunedHeight()
+	if err != nil {
+		return err
+	}
+	if height <= pruned {
+		return nil
+	}
+	if height-pruned > maxReceiptsPrunedPerCall {
+		height = pruned + maxReceiptsPrunedPerCall
+	}
+	batch := db.NewBatch()
+	for h := pruned + 1; h <= height; h++ {
+		batch.Delete(receiptsNS, byteutil.Uint64ToBytes(h), "failed to delete receipts of block %d", h)
+		batch.Delete(logBloomNS, byteutil.Uint64ToBytes(h), "failed to delete log bloom of block %d", h)
+	}
+	batch.Put(blockNS, receiptsPrunedHeightKey, byteutil.Uint64ToBytes(height), "failed to put receipts pruned height")
+	return dao.kvstore.Commit(batch)
+}
+
+// getBlocksPrunedHeight returns the height at or below which the block bodies are pruned, which is 0 if no block is
+// pruned
+func (dao *blockDAO) getBlocksPrunedHeight() (uint64, error) {
+	value, err := dao.kvstore.Get(blockNS, blocksPrunedHeightKey)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to get blocks pruned height")
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
+// getSubscriberCursor returns the height of the last block handled by the durable subscriber with the name. The
+// returned error is db.ErrNotExist if the subscriber has never been added
+func (dao *blockDAO) getSubscriberCursor(name string) (uint64, error) {
+	value, err := dao.kvstore.Get(subscriberCursorNS, []byte(name))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get cursor of subscriber %s", name)
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
+// putSubscriberCursor puts the height of the last block handled by the durable subscriber with the name
+func (dao *blockDAO) putSubscriberCursor(name string, height uint64) error {
+	if err := dao.kvstore.Put(subscriberCursorNS, []byte(name), byteutil.Uint64ToBytes(height)); err != nil {
+		return errors.Wrapf(err, "failed to put cursor of subscriber %s", name)
+	}
+	return nil
+}
+
+// commitSubscriberCursor commits the batch of the writes of the durable subscriber with the name, along with its cursor
+// moved to the height
+func (dao *blockDAO) commitSubscriberCursor(name string, height uint64, batch db.KVStoreBatch) error {
+	batch.Put(
+		subscriberCursorNS,
+		[]byte(name),
+		byteutil.Uint64ToBytes(height),
+		"failed to put cursor of subscriber %s",
+		name,
+	)
+	if err := dao.kvstore.Commit(batch); err != nil {
+		return errors.Wrapf(err, "failed to commit cursor of subscriber %s", name)
+	}
+	return nil
+}
+
+// isBlockPruned tells whether the body of the block on the chain is pruned
+func (dao *blockDAO) isBlockPruned(hash hash.Hash256) bool {
+	height, err := dao.getBlockHeight(hash)
+	if err != nil {
+		return false
+	}
+	pruned, err := dao.getBlocksPrunedHeight()
+	return err == nil && height <= pruned
+}
+
+// pruneBlocks deletes the bodies of the blocks at or below the height, while the hash <-> height mappings and the
+// index are kept. At most maxBlocksPrunedPerCall blocks are pruned at a time, and it returns the height at or below
+// which the blocks are pruned after the call
+func (dao *blockDAO) pruneBlocks(height uint64) (uint64, error) {
+	dao.pruneMutex.Lock()
+	defer dao.pruneMutex.Unlock()
+	pruned, err := dao.getBlocksPrunedHeight()
+	if err != nil {
+		return 0, err
+	}
+	if height <= pruned {
+		return pruned, nil
+	}
+	if height-pruned > maxBlocksPrunedPerCall {
+		height = pruned + maxBlocksPrunedPerCall
+	}
+	batch := db.NewBatch()
+	for h := pruned + 1; h <= height; h++ {
+		hash, err := dao.getBlockHash(h)
+		if err != nil {
+			return pruned, errors.Wrapf(err, "failed to get hash of block %d", h)
+		}
+		batch.Delete(blockNS, hash[:], "failed to delete block %d", h)
+	}
+	batch.Put(blockNS, blocksPrunedHeightKey, byteutil.Uint64ToBytes(height), "failed to put blocks pruned height")
+	if err := dao.kvstore.Commit(batch); err != nil {
+		return pruned, err
+	}
+	return height, nil
+}
+
+// putSideBlock puts a block off the canonical chain, which is kept by hash until the side blocks at its height are
+// pruned. The hashes of the side blocks at a height are kept along, so that they are pruned by height
+func (dao *blockDAO) putSideBlock(blk *block.Block) error {
+	hash := blk.HashBlock()
+	if _, err := dao.kvstore.Get(sideBlockNS, hash[:]); err == nil {
+		return nil
+	} else if errors.Cause(err) != db.ErrNotExist {
+		return errors.Wrapf(err, "failed to get side block %x", hash)
+	}
+	serialized, err := blk.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize block")
+	}
+	heightKey := append(heightPrefix, byteutil.Uint64ToBytes(blk.Height())...)
+	hashes, err := dao.kvstore.Get(sideBlockNS, heightKey)
+	if err != nil && errors.Cause(err) != db.ErrNotExist {
+		return errors.Wrapf(err, "failed to get side blocks at height %d", blk.Height())
+	}
+	batch := db.NewBatch()
+	batch.Put(sideBlockNS, hash[:], serialized, "failed to put side block %x", hash)
+	batch.Put(
+		sideBlockNS,
+		heightKey,
+		append(append([]byte{}, hashes...), hash[:]...),
+		"failed to put side blocks at height %d",
+		blk.Height(),
+	)
+	return dao.kvstore.Commit(batch)
+}
+
+// getSideBlock returns a block off the canonical chain
+func (dao *blockDAO) getSideBlock(hash hash.Hash256) (*block.Block, error) {
+	value, err := dao.kvstore.Get(sideBlockNS, hash[:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get side block %x", hash)
+	}
+	if len(value) == 0 {
+		return nil, errors.Wrapf(db.ErrNotExist, "side block %x missing", hash)
+	}
+	blk := block.Block{}
+	if err = blk.Deserialize(value); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize block")
+	}
+	return &blk, nil
+}
+
+// deleteSideBlock deletes a side block, e.g., once it's moved onto the canonical chain. Its hash is left at its height
+// until the height is pruned
+func (dao *blockDAO) deleteSideBlock(hash hash.Hash256) error {
+	return dao.kvstore.Delete(sideBlockNS, hash[:])
+}
+
+// getSideBlocksPrunedHeight returns the height at or below which the side blocks are pruned
+func (dao *blockDAO) getSideBlocksPrunedHeight() (uint64, error) {
+	value, err := dao.kvstore.Get(blockNS, sideBlocksPrunedHeightKey)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to get side blocks pruned height")
+	}
+	return enc.MachineEndian.Uint64(value), nil
+}
+
+// pruneSideBlocks deletes the side blocks at or below the height. At most maxSideBlockHeightsPrunedPerCall heights are
+// pruned at a time, and the rest are left to the following calls
+func (dao *blockDAO) pruneSideBlocks(height uint64) error {
+	dao.pruneMutex.Lock()
+	defer dao.pruneMutex.Unlock()
+	pruned, err := dao.getSideBlocksPrunedHeight()
+	if err != nil {
+		return err
+	}
+	if height <= pruned {
+		return nil
+	}
+	if height-pruned > maxSideBlockHeightsPrunedPerCall {
+		height = pruned + maxSideBlockHeightsPrunedPerCall
+	}
+	batch := db.NewBatch()
+	for h := pruned + 1; h <= height; h++ {
+		heightKey := append(heightPrefix, byteutil.Uint64ToBytes(h)...)
+		hashes, err := dao.kvstore.Get(sideBlockNS, heightKey)
+		if err != nil {
+			if errors.Cause(err) == db.ErrNotExist {
+				continue
+			}
+			return errors.Wrapf(err, "failed to get side blocks at height %d", h)
+		}
+		for i := 0; i+len(hash.ZeroHash256) <= len(hashes); i += len(hash.ZeroHash256) {
+			batch.Delete(sideBlockNS, hashes[i:i+len(hash.ZeroHash256)], "failed to delete side block at height %d", h)
+		}
+		batch.Delete(sideBlockNS, heightKey, "failed to delete side blocks at height %d", h)
+	}
+	batch.Put(blockNS, sideBlocksPrunedHeightKey, byteutil.Uint64ToBytes(height), "failed to put side blocks pruned height")
 	return dao.kvstore.Commit(batch)
 }
 
@@ -374,6 +686,9 @@ func (dao *blockDAO) deleteTipBlock() error {
 	heightKey := append(heightPrefix, heightValue...)
 	batch.Delete(blockHashHeightMappingNS, heightKey, "failed to delete height -> hash mapping")
 
+	// Delete log bloom
+	batch.Delete(logBloomNS, heightValue, "failed to delete log bloom")
+
 	// Update tip height
 	topHeight := enc.MachineEndian.Uint64(heightValue) - 1
 	topHeightValue := byteutil.Uint64ToBytes(topHeight)
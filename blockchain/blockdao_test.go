@@ -23,6 +23,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -713,3 +714,99 @@ func TestBlockDao_putReceipts(t *testing.T) {
 		assert.Equal(t, receipt.ActHash, r.ActHash)
 	}
 }
+
+func TestBlockDao_pruneReceipts(t *testing.T) {
+	require := require.New(t)
+
+	blkDao := newBlockDAO(db.NewMemKVStore(), true)
+	heights := []uint64{1, 2, maxReceiptsPrunedPerCall + 1, maxReceiptsPrunedPerCall + 2}
+	for _, height := range heights {
+		receipt := &action.Receipt{
+			ActHash:     hash.Hash256b(byteutil.Uint64ToBytes(height)),
+			Status:      1,
+			GasConsumed: height,
+			Logs:        []*action.Log{},
+		}
+		require.NoError(blkDao.putReceipts(height, []*action.Receipt{receipt}))
+	}
+	receiptOf := func(height uint64) (*action.Receipt, error) {
+		return blkDao.getReceiptByActionHash(hash.Hash256b(byteutil.Uint64ToBytes(height)))
+	}
+
+	require.NoError(blkDao.pruneReceipts(1))
+	_, err := receiptOf(1)
+	require.Equal(ErrReceiptPruned, errors.Cause(err))
+	r, err := receiptOf(2)
+	require.NoError(err)
+	require.Equal(uint64(2), r.GasConsumed)
+
+	// At most maxReceiptsPrunedPerCall blocks are pruned at a time
+	require.NoError(blkDao.pruneReceipts(maxReceiptsPrunedPerCall + 2))
+	pruned, err := blkDao.getReceiptsPrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(maxReceiptsPrunedPerCall+1), pruned)
+	_, err = receiptOf(maxReceiptsPrunedPerCall + 1)
+	require.Equal(ErrReceiptPruned, errors.Cause(err))
+	_, err = receiptOf(maxReceiptsPrunedPerCall + 2)
+	require.NoError(err)
+	require.NoError(blkDao.pruneReceipts(maxReceiptsPrunedPerCall + 2))
+	_, err = receiptOf(maxReceiptsPrunedPerCall + 2)
+	require.Equal(ErrReceiptPruned, errors.Cause(err))
+
+	// Pruning a lower height is a no-op
+	require.NoError(blkDao.pruneReceipts(2))
+	pruned, err = blkDao.getReceiptsPrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(maxReceiptsPrunedPerCall+2), pruned)
+}
+
+func TestBlockDao_sideBlocks(t *testing.T) {
+	require := require.New(t)
+
+	blkDao := newBlockDAO(db.NewMemKVStore(), false)
+	newBlock := func(height uint64, prev string) *block.Block {
+		return block.NewBlockDeprecated(
+			uint32(1),
+			height,
+			hash.Hash256b([]byte(prev)),
+			testutil.TimestampNow(),
+			testaddress.Keyinfo["producer"].PubKey,
+			nil,
+		)
+	}
+	blks := []*block.Block{newBlock(1, "a"), newBlock(1, "b"), newBlock(2, "c")}
+	for _, blk := range blks {
+		require.NoError(blkDao.putSideBlock(blk))
+	}
+	// A side block is put once only
+	require.NoError(blkDao.putSideBlock(blks[0]))
+	for _, blk := range blks {
+		side, err := blkDao.getSideBlock(blk.HashBlock())
+		require.NoError(err)
+		require.Equal(blk.HashBlock(), side.HashBlock())
+	}
+	_, err := blkDao.getBlock(blks[0].HashBlock())
+	require.Error(err)
+
+	require.NoError(blkDao.deleteSideBlock(blks[2].HashBlock()))
+	_, err = blkDao.getSideBlock(blks[2].HashBlock())
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	require.NoError(blkDao.pruneSideBlocks(1))
+	for _, blk := range blks[:2] {
+		_, err = blkDao.getSideBlock(blk.HashBlock())
+		require.Equal(db.ErrNotExist, errors.Cause(err))
+	}
+	require.NoError(blkDao.putSideBlock(blks[2]))
+	pruned, err := blkDao.getSideBlocksPrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(1), pruned)
+
+	// At most maxSideBlockHeightsPrunedPerCall heights are pruned at a time
+	require.NoError(blkDao.pruneSideBlocks(maxSideBlockHeightsPrunedPerCall + 2))
+	pruned, err = blkDao.getSideBlocksPrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(maxSideBlockHeightsPrunedPerCall+1), pruned)
+	_, err = blkDao.getSideBlock(blks[2].HashBlock())
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+}
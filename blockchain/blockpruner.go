@@ -0,0 +1,87 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+)
+
+var _ lifecycle.StartStopper = (*blockPruner)(nil)
+
+// blockPruner prunes the old blocks in the background, so that a non-archive node doesn't grow unbounded. The bodies of
+// the blocks before the latest ones are deleted, along with their receipts in the keepLastN mode
+type blockPruner struct {
+	dao  *blockDAO
+	mode string
+	keep uint64
+	task *routine.RecurringTask
+}
+
+func newBlockPruner(dao *blockDAO, cfg config.Chain) *blockPruner {
+	p := &blockPruner{
+		dao:  dao,
+		mode: cfg.PruneMode,
+		keep: cfg.PruneKeepBlocks,
+	}
+	p.task = routine.NewRecurringTask(p.prune, cfg.PruneInterval)
+	return p
+}
+
+// Start starts pruning the blocks periodically
+func (p *blockPruner) Start(ctx context.Context) error {
+	return p.task.Start(ctx)
+}
+
+// Stop stops pruning the blocks
+func (p *blockPruner) Stop(ctx context.Context) error {
+	return p.task.Stop(ctx)
+}
+
+// prune prunes the blocks before the latest ones in batches, until all of them are pruned or a batch fails. Each batch
+// is committed on its own, so the commits of the new blocks aren't held up
+func (p *blockPruner) prune() {
+	tipHeight, err := p.dao.getBlockchainHeight()
+	if err != nil {
+		log.L().Error("Failed to get tip height.", zap.Error(err))
+		return
+	}
+	if tipHeight <= p.keep {
+		return
+	}
+	target := tipHeight - p.keep
+	for {
+		pruned, err := p.dao.pruneBlocks(target)
+		if err != nil {
+			log.L().Error("Failed to prune blocks.", zap.Uint64("height", target), zap.Error(err))
+			return
+		}
+		done := pruned >= target
+		if p.mode == config.PruneModeKeepLastN {
+			if err := p.dao.pruneReceipts(target); err != nil {
+				log.L().Error("Failed to prune receipts.", zap.Uint64("height", target), zap.Error(err))
+				return
+			}
+			receiptsPruned, err := p.dao.getReceiptsPrunedHeight()
+			if err != nil {
+				log.L().Error("Failed to get receipts pruned height.", zap.Error(err))
+				return
+			}
+			done = done && receiptsPruned >= target
+		}
+		if done {
+			log.L().Debug("Pruned blocks.", zap.String("mode", p.mode), zap.Uint64("height", target))
+			return
+		}
+	}
+}
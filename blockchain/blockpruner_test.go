@@ -0,0 +1,100 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestBlockPruner(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	tip := uint64(maxBlocksPrunedPerCall + 10)
+	newDAO := func() *blockDAO {
+		dao := newBlockDAO(db.NewMemKVStore(), true)
+		require.NoError(dao.Start(ctx))
+		prev := hash.ZeroHash256
+		for h := uint64(1); h <= tip; h++ {
+			blk := block.NewBlockDeprecated(
+				uint32(1),
+				h,
+				prev,
+				testutil.TimestampNow(),
+				ta.Keyinfo["producer"].PubKey,
+				nil,
+			)
+			require.NoError(dao.putBlock(blk))
+			require.NoError(dao.putReceipts(h, []*action.Receipt{{
+				ActHash: hash.Hash256b(byteutil.Uint64ToBytes(h)),
+				Status:  1,
+				Logs:    []*action.Log{},
+			}}))
+			prev = blk.HashBlock()
+		}
+		return dao
+	}
+	getBlock := func(dao *blockDAO, height uint64) (*block.Block, error) {
+		hash, err := dao.getBlockHash(height)
+		require.NoError(err)
+		return dao.getBlock(hash)
+	}
+	getReceipt := func(dao *blockDAO, height uint64) (*action.Receipt, error) {
+		return dao.getReceiptByActionHash(hash.Hash256b(byteutil.Uint64ToBytes(height)))
+	}
+	cfg := config.Default.Chain
+	cfg.PruneKeepBlocks = 3
+	cfg.PruneInterval = time.Hour
+
+	// The bodies and the receipts of the blocks before the latest 3 ones are pruned in batches
+	cfg.PruneMode = config.PruneModeKeepLastN
+	dao := newDAO()
+	newBlockPruner(dao, cfg).prune()
+	pruned, err := dao.getBlocksPrunedHeight()
+	require.NoError(err)
+	require.Equal(tip-3, pruned)
+	for _, h := range []uint64{1, tip - 3} {
+		_, err = getBlock(dao, h)
+		require.Equal(ErrBlockPruned, errors.Cause(err))
+		_, err = getReceipt(dao, h)
+		require.Equal(ErrReceiptPruned, errors.Cause(err))
+	}
+	blk, err := getBlock(dao, tip-2)
+	require.NoError(err)
+	require.Equal(tip-2, blk.Height())
+	_, err = getReceipt(dao, tip-2)
+	require.NoError(err)
+
+	// The receipts are kept
+	cfg.PruneMode = config.PruneModeKeepReceiptsOnly
+	dao = newDAO()
+	newBlockPruner(dao, cfg).prune()
+	_, err = getBlock(dao, 1)
+	require.Equal(ErrBlockPruned, errors.Cause(err))
+	_, err = getReceipt(dao, 1)
+	require.NoError(err)
+	_, err = getBlock(dao, tip-2)
+	require.NoError(err)
+
+	// A block not on the chain isn't taken as pruned
+	_, err = dao.getBlock(hash.Hash256b([]byte("unknown")))
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/hash"
@@ -38,6 +39,13 @@ type Validator interface {
 	// AddActionValidators add validators
 	AddActionValidators(...protocol.ActionValidator)
 	AddActionEnvelopeValidators(...protocol.ActionEnvelopeValidator)
+	// SetValidationCache sets the cache of the validated actions, which are not validated again when minting a block
+	SetValidationCache(ValidationCache)
+}
+
+// ValidationCache tells whether an action has been validated against the current state of its sender
+type ValidationCache interface {
+	Validated(action.SealedEnvelope) bool
 }
 
 type validator struct {
@@ -45,6 +53,10 @@ type validator struct {
 	validatorAddr            string
 	actionEnvelopeValidators []protocol.ActionEnvelopeValidator
 	actionValidators         []protocol.ActionValidator
+	validationCache          ValidationCache
+	// hashOrderHeight is the fork height from which the blocks have to be of the hash order, 0 means never
+	hashOrderHeight uint64
+	disabledRules   map[string]bool
 }
 
 var (
@@ -64,22 +76,53 @@ var (
 
 // Validate validates the given block's content
 func (v *validator) Validate(blk *block.Block, tipHeight uint64, tipHash hash.Hash256) error {
-	if err := verifyHeightAndHash(blk, tipHeight, tipHash); err != nil {
-		return errors.Wrap(err, "failed to verify block's height and hash")
+	if v.sf == nil {
+		return v.validate(blk, tipHeight, tipHash, nil)
 	}
-	if err := verifySigAndRoot(blk); err != nil {
-		return errors.Wrap(err, "failed to verify block's signature and merkle root")
+	return v.validate(blk, tipHeight, tipHash, v.sf.Nonce)
+}
+
+// validate validates the given block's content against the enabled validation rules in order, checking the action
+// nonces against the confirmed nonces returned by nonceFn. The actions aren't validated if nonceFn is nil
+func (v *validator) validate(
+	blk *block.Block,
+	tipHeight uint64,
+	tipHash hash.Hash256,
+	nonceFn func(string) (uint64, error),
+) error {
+	tip := validationTip{height: tipHeight, hash: tipHash, nonceFn: nonceFn}
+	for _, rule := range validationRules {
+		if v.disabledRules[rule.name] {
+			continue
+		}
+		if err := rule.check(v, blk, tip); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if v.sf != nil {
-		return v.ValidateActionsOnly(
-			blk.Actions,
-			blk.PublicKey(),
-			blk.ChainID(),
-			blk.Height(),
-		)
+// validateRules validates the given block's content against the enabled stateful or stateless validation rules in
+// order
+func (v *validator) validateRules(blk *block.Block, tip validationTip, stateful bool) error {
+	for _, rule := range validationRules {
+		if v.disabledRules[rule.name] || rule.stateful != stateful {
+			continue
+		}
+		if err := rule.check(v, blk, tip); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// disableRules disables the validation rules of the names, which have to be registered and not required
+func (v *validator) disableRules(names ...string) error {
+	disabled, err := disabledValidationRules(names)
+	if err != nil {
+		return err
+	}
+	v.disabledRules = disabled
 	return nil
 }
 
@@ -93,11 +136,29 @@ func (v *validator) AddActionEnvelopeValidators(validators ...protocol.ActionEnv
 	v.actionEnvelopeValidators = append(v.actionEnvelopeValidators, validators...)
 }
 
+// SetValidationCache sets the cache of the validated actions
+func (v *validator) SetValidationCache(cache ValidationCache) {
+	v.validationCache = cache
+}
+
+// ValidateActionsOnly validates the actions of a block to mint. The actions found in the validation cache skip the
+// action validators, while their nonces are still checked
 func (v *validator) ValidateActionsOnly(
 	actions []action.SealedEnvelope,
 	pk keypair.PublicKey,
 	chainID uint32,
 	height uint64,
+) error {
+	return v.validateActionsOnly(actions, pk, chainID, height, v.sf.Nonce, v.validationCache)
+}
+
+func (v *validator) validateActionsOnly(
+	actions []action.SealedEnvelope,
+	pk keypair.PublicKey,
+	chainID uint32,
+	height uint64,
+	nonceFn func(string) (uint64, error),
+	cache ValidationCache,
 ) error {
 	// Verify transfers, votes, executions, witness, and secrets
 	errChan := make(chan error, len(actions))
@@ -110,6 +171,7 @@ func (v *validator) ValidateActionsOnly(
 		height,
 		accountNonceMap,
 		errChan,
+		cache,
 	); err != nil {
 		close(errChan)
 		return err
@@ -126,7 +188,7 @@ func (v *validator) ValidateActionsOnly(
 	}
 	//Verify each account's Nonce
 	for srcAddr, receivedNonces := range accountNonceMap {
-		confirmedNonce, err := v.sf.Nonce(srcAddr)
+		confirmedNonce, err := nonceFn(srcAddr)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get the confirmed nonce of address %s", srcAddr)
 		}
@@ -154,6 +216,7 @@ func (v *validator) validateActions(
 	height uint64,
 	accountNonceMap map[string][]uint64,
 	errChan chan error,
+	cache ValidationCache,
 ) error {
 	producerPK := keypair.HashPubKey(pk)
 	producerAddr, err := address.FromBytes(producerPK[:])
@@ -169,6 +232,9 @@ func (v *validator) validateActions(
 			return err
 		}
 		appendActionIndex(accountNonceMap, caller.String(), selp.Nonce())
+		if cache != nil && cache.Validated(selp) {
+			continue
+		}
 		ctx := protocol.WithValidateActionsCtx(
 			context.Background(),
 			protocol.ValidateActionsCtx{
@@ -231,16 +297,24 @@ func verifyHeightAndHash(blk *block.Block, tipHeight uint64, tipHash hash.Hash25
 }
 
 func verifySigAndRoot(blk *block.Block) error {
-	if blk.Height() > 0 {
-		// verify new block's signature is correct
-		if !blk.VerifySignature() {
-			return errors.Wrapf(
-				ErrInvalidBlock,
-				"failed to verify block's signature with public key: %x",
-				blk.PublicKey())
-		}
+	if err := verifySignature(blk); err != nil {
+		return err
 	}
+	return verifyTxRoot(blk)
+}
 
+func verifySignature(blk *block.Block) error {
+	// verify new block's signature is correct
+	if blk.Height() > 0 && !blk.VerifySignature() {
+		return errors.Wrapf(
+			ErrInvalidBlock,
+			"failed to verify block's signature with public key: %x",
+			blk.PublicKey())
+	}
+	return nil
+}
+
+func verifyTxRoot(blk *block.Block) error {
 	hashExpect := blk.TxRoot()
 	hashActual := blk.CalculateTxRoot()
 	if !bytes.Equal(hashExpect[:], hashActual[:]) {
@@ -253,6 +327,72 @@ func verifySigAndRoot(blk *block.Block) error {
 	return nil
 }
 
+// verifyHeaderVersion verifies that the block header is of the version activated at its height, and carries the fields
+// of the version. A block of a version unknown to this node can't be hashed and validated, so the node has to be
+// upgraded to follow the chain
+func verifyHeaderVersion(blk *block.Block, forkHeights map[uint32]uint64) error {
+	if !block.IsKnownHeaderVersion(blk.Version()) {
+		return errors.Wrapf(block.ErrUnknownHeaderVersion, "version %d, the node has to be upgraded", blk.Version())
+	}
+	if expected := block.HeaderVersionAt(forkHeights, blk.Height()); blk.Version() != expected {
+		return errors.Wrapf(ErrInvalidBlock, "wrong header version %d, expecting %d", blk.Version(), expected)
+	}
+	// The VRF proof itself is verified by the consensus, which knows the BLS key of the producer
+	if hasProof := len(blk.VRFProof()) != 0; hasProof != (blk.Version() >= block.HeaderVersionV2) {
+		return errors.Wrapf(ErrInvalidBlock, "VRF proof in the header of version %d", blk.Version())
+	}
+	return nil
+}
+
+// actionOrderAt returns the action order of the blocks at the height, which is the hash order from the fork height
+func actionOrderAt(hashOrderHeight uint64, height uint64) uint32 {
+	if hashOrderHeight != 0 && height >= hashOrderHeight {
+		return block.HashOrder
+	}
+	return block.ArrivalOrder
+}
+
+// verifyActionOrder verifies that a block is of the action order in effect at its height, and the actions in a block
+// of the hash order are in the order the producer would have picked them, by picking them again from the accounts'
+// actions in the block. The grant reward actions are appended by the producer after picking the actions, so they are
+// left out
+func verifyActionOrder(blk *block.Block, hashOrderHeight uint64) error {
+	if expected := actionOrderAt(hashOrderHeight, blk.Height()); blk.ActionOrder() != expected {
+		return errors.Wrapf(ErrInvalidBlock, "wrong action order %d, expecting %d", blk.ActionOrder(), expected)
+	}
+	if blk.ActionOrder() == block.ArrivalOrder {
+		return nil
+	}
+	picked := make([]action.SealedEnvelope, 0, len(blk.Actions))
+	accountActs := make(map[string][]action.SealedEnvelope)
+	for _, selp := range blk.Actions {
+		if _, ok := selp.Action().(*action.GrantReward); ok {
+			continue
+		}
+		callerPKHash := keypair.HashPubKey(selp.SrcPubkey())
+		caller, err := address.FromBytes(callerPKHash[:])
+		if err != nil {
+			return err
+		}
+		picked = append(picked, selp)
+		accountActs[caller.String()] = append(accountActs[caller.String()], selp)
+	}
+	it := actioniterator.NewHashOrderedActionIterator(accountActs, blk.PrevHash())
+	for i := range picked {
+		expected, _ := it.Next()
+		if expected.Hash() != picked[i].Hash() {
+			return errors.Wrapf(
+				ErrInvalidBlock,
+				"action %x at position %d doesn't follow the hash order, expecting %x",
+				picked[i].Hash(),
+				i,
+				expected.Hash(),
+			)
+		}
+	}
+	return nil
+}
+
 func appendActionIndex(accountNonceMap map[string][]uint64, srcAddr string, nonce uint64) {
 	if nonce == 0 {
 		return
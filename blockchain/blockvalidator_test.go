@@ -20,6 +20,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
@@ -77,6 +78,91 @@ func TestSignBlock(t *testing.T) {
 	require.Nil(val.Validate(&blk, 2, blkhash))
 }
 
+func TestWrongActionOrder(t *testing.T) {
+	require := require.New(t)
+	val := validator{sf: nil, validatorAddr: "", hashOrderHeight: 1}
+
+	tsf1, err := testutil.SignedTransfer(ta.Addrinfo["bravo"].String(), ta.Keyinfo["alfa"].PriKey, 1, big.NewInt(20), []byte{}, 100000, big.NewInt(10))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["bravo"].PriKey, 1, big.NewInt(30), []byte{}, 100000, big.NewInt(10))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["charlie"].PriKey, 1, big.NewInt(30), []byte{}, 100000, big.NewInt(20))
+	require.NoError(err)
+
+	prevHash := tsf1.Hash()
+	it := actioniterator.NewHashOrderedActionIterator(map[string][]action.SealedEnvelope{
+		ta.Addrinfo["alfa"].String():    {tsf1},
+		ta.Addrinfo["bravo"].String():   {tsf2},
+		ta.Addrinfo["charlie"].String(): {tsf3},
+	}, prevHash)
+	var ordered []action.SealedEnvelope
+	for selp, ok := it.Next(); ok; selp, ok = it.Next() {
+		ordered = append(ordered, selp)
+	}
+	require.Equal(tsf3, ordered[0])
+	newBlock := func(order uint32, acts ...action.SealedEnvelope) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetChainID(1).
+			SetHeight(1).
+			SetPrevBlockHash(prevHash).
+			SetActionOrder(order).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(acts...).
+			SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		return &blk
+	}
+
+	require.NoError(val.Validate(newBlock(block.HashOrder, ordered...), 0, prevHash))
+	require.NoError(val.Validate(newBlock(block.HashOrder, ordered[0], ordered[1]), 0, prevHash))
+	// The actions of the same gas price can't be swapped in the hash order
+	swapped := []action.SealedEnvelope{ordered[0], ordered[2], ordered[1]}
+	require.Error(val.Validate(newBlock(block.HashOrder, swapped...), 0, prevHash))
+	require.Error(val.Validate(newBlock(block.HashOrder+1, ordered...), 0, prevHash))
+	// The producer can't choose the order on its own, which is set by the genesis from the fork height
+	require.Error(val.Validate(newBlock(block.ArrivalOrder, swapped...), 0, prevHash))
+	val.hashOrderHeight = 2
+	require.NoError(val.Validate(newBlock(block.ArrivalOrder, swapped...), 0, prevHash))
+	require.Error(val.Validate(newBlock(block.HashOrder, ordered...), 0, prevHash))
+	val.hashOrderHeight = 0
+	require.NoError(val.Validate(newBlock(block.ArrivalOrder, swapped...), 0, prevHash))
+	require.Error(val.Validate(newBlock(block.HashOrder, ordered...), 0, prevHash))
+}
+
+func TestWrongHeaderVersion(t *testing.T) {
+	require := require.New(t)
+
+	newBlockWithProof := func(height uint64, version uint32, proof []byte) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetVersion(version).
+			SetHeight(height).
+			SetVRFProof(proof).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		return &blk
+	}
+	newBlock := func(height uint64, version uint32) *block.Block {
+		if version >= block.HeaderVersionV2 {
+			return newBlockWithProof(height, version, []byte("proof"))
+		}
+		return newBlockWithProof(height, version, nil)
+	}
+	require.NoError(verifyHeaderVersion(newBlock(1, block.HeaderVersionV1), nil))
+	err := verifyHeaderVersion(newBlock(1, block.HeaderVersionV2+1), nil)
+	require.Equal(block.ErrUnknownHeaderVersion, errors.Cause(err))
+	// A known version has to be activated at the height of the block
+	require.NoError(verifyHeaderVersion(newBlock(10, block.HeaderVersionV1), map[uint32]uint64{block.HeaderVersionV1: 0}))
+	err = verifyHeaderVersion(newBlock(1, block.HeaderVersionV1), map[uint32]uint64{block.HeaderVersionV1: 0, 2: 1})
+	require.Error(err)
+	// The VRF proof is only carried by the headers of version 2 on
+	forks := map[uint32]uint64{block.HeaderVersionV2: 5}
+	require.NoError(verifyHeaderVersion(newBlock(5, block.HeaderVersionV2), forks))
+	require.NoError(verifyHeaderVersion(newBlock(4, block.HeaderVersionV1), forks))
+	require.Error(verifyHeaderVersion(newBlockWithProof(4, block.HeaderVersionV1, []byte("proof")), forks))
+	require.Error(verifyHeaderVersion(newBlockWithProof(5, block.HeaderVersionV2, nil), forks))
+}
+
 func TestWrongNonce(t *testing.T) {
 	cfg := config.Default
 	genesisCfg := genesis.Default
@@ -331,8 +417,33 @@ func TestWrongAddress(t *testing.T) {
 	)
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "error when validating contract's address"))
+
+	// The cached actions skip the validation, while their nonces are still checked
+	val.SetValidationCache(testValidationCache{selp.Hash(): true})
+	require.NoError(t, val.ValidateActionsOnly(
+		blk3.Actions,
+		blk3.PublicKey(),
+		blk3.ChainID(),
+		blk3.Height(),
+	))
+	require.Error(t, val.ValidateActionsOnly(
+		append(blk3.Actions, selp),
+		blk3.PublicKey(),
+		blk3.ChainID(),
+		blk3.Height(),
+	))
+	require.Error(t, val.ValidateActionsOnly(
+		blk2.Actions,
+		blk2.PublicKey(),
+		blk2.ChainID(),
+		blk2.Height(),
+	))
 }
 
+type testValidationCache map[hash.Hash256]bool
+
+func (c testValidationCache) Validated(selp action.SealedEnvelope) bool { return c[selp.Hash()] }
+
 func TestCoinbaseTransferValidation(t *testing.T) {
 	t.Skip("It is skipped because testnet_actions.yaml doesn't match the chain ID")
 	ctx := context.Background()
@@ -0,0 +1,286 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+type (
+	// pipelinedBlock is a block executed in the working set, which is passed down the commit pipeline
+	pipelinedBlock struct {
+		blk *block.Block
+		ws  factory.WorkingSet
+	}
+
+	// commitPipeline commits the sequential blocks in four stages running on separate goroutines: validating a block
+	// against the stateless rules, executing it on top of the pending states of the previous block, writing it into
+	// the block DB, and committing its states. The stages are connected by the channels buffering up to depth blocks,
+	// so that a stage falling behind holds back the stages before it, and the next block is validated and executed
+	// while the previous blocks are written to the disk. A block written into the block DB always gets its states
+	// committed, so a failure stops the stages before it, while the blocks passed the failed stage are committed
+	commitPipeline struct {
+		bc             *blockchain
+		val            *validator
+		validateFooter func(*block.Block) error
+		depth          int
+		// abort is closed once the execute or the write stage fails, so that the stages before it stop
+		abort     chan struct{}
+		abortOnce sync.Once
+
+		mutex     sync.Mutex
+		cond      *sync.Cond
+		committed uint64 // height of the last block whose states are committed
+		finished  bool   // whether the commit stage has finished
+		errHeight uint64
+		err       error
+	}
+)
+
+func newCommitPipeline(
+	bc *blockchain,
+	val *validator,
+	validateFooter func(*block.Block) error,
+	depth uint64,
+) *commitPipeline {
+	p := &commitPipeline{
+		bc:             bc,
+		val:            val,
+		validateFooter: validateFooter,
+		depth:          int(depth),
+		abort:          make(chan struct{}),
+		committed:      bc.tipHeight,
+	}
+	p.cond = sync.NewCond(&p.mutex)
+	return p
+}
+
+// run commits the blocks through the pipeline, and returns the number of the blocks committed, together with the
+// error of the lowest block failed
+func (p *commitPipeline) run(blks []*block.Block) (int, error) {
+	validated := make(chan *block.Block, p.depth)
+	executed := make(chan pipelinedBlock, p.depth)
+	written := make(chan pipelinedBlock, p.depth)
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		p.validate(blks, validated)
+	}()
+	go func() {
+		defer wg.Done()
+		p.execute(validated, executed)
+	}()
+	go func() {
+		defer wg.Done()
+		p.write(executed, written)
+	}()
+	n := p.commit(written)
+	wg.Wait()
+
+	if n > 0 {
+		tipHeight := p.bc.tipHeight
+		p.bc.pruneReceipts(tipHeight)
+		p.bc.pruneSideBlocks(tipHeight)
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return n, p.err
+}
+
+// validate is the first stage, which checks the footers and the stateless rules with the previous block as the tip.
+// The delegates of an epoch are calculated from the committed states, so the first block of an epoch waits for the
+// blocks before it to be committed
+func (p *commitPipeline) validate(blks []*block.Block, out chan<- *block.Block) {
+	defer close(out)
+	tipHeight, tipHash := p.bc.tipHeight, p.bc.tipHash
+	for i, blk := range blks {
+		if i > 0 && p.epochNum(blk.Height()) != p.epochNum(tipHeight) && !p.waitCommitted(tipHeight) {
+			return
+		}
+		if p.validateFooter != nil {
+			if err := p.validateFooter(blk); err != nil {
+				p.fail(blk.Height(), err)
+				return
+			}
+		}
+		validateTimer := p.bc.timerFactory.NewTimer("validate")
+		err := p.val.validateRules(blk, validationTip{height: tipHeight, hash: tipHash}, false)
+		validateTimer.End()
+		if err != nil {
+			p.fail(blk.Height(), errors.Wrapf(err, "error when validating block %d", blk.Height()))
+			return
+		}
+		select {
+		case out <- blk:
+		case <-p.abort:
+			return
+		}
+		tipHeight, tipHash = blk.Height(), blk.HashBlock()
+	}
+}
+
+// execute is the second stage, which checks the stateful rules and runs the actions on top of the pending states of
+// the previous block
+func (p *commitPipeline) execute(in <-chan *block.Block, out chan<- pipelinedBlock) {
+	defer close(out)
+	var prev factory.WorkingSet
+	for blk := range in {
+		ws, err := p.newWorkingSet(prev, blk.Height())
+		if err != nil {
+			p.fail(blk.Height(), err)
+			p.stop()
+			return
+		}
+		if ws == nil {
+			return
+		}
+		validateTimer := p.bc.timerFactory.NewTimer("validate")
+		err = p.val.validateRules(blk, validationTip{nonceFn: pendingNonceFn(ws)}, true)
+		validateTimer.End()
+		if err == nil {
+			err = p.bc.runBlockOn(blk, ws)
+		} else {
+			err = errors.Wrapf(err, "error when validating block %d", blk.Height())
+		}
+		if err != nil {
+			p.fail(blk.Height(), err)
+			p.stop()
+			return
+		}
+		select {
+		case out <- pipelinedBlock{blk: blk, ws: ws}:
+		case <-p.abort:
+			return
+		}
+		prev = ws
+	}
+}
+
+// write is the third stage, which writes the blocks into the block DB. The blocks written are always passed on, so
+// that their states get committed
+func (p *commitPipeline) write(in <-chan pipelinedBlock, out chan<- pipelinedBlock) {
+	defer close(out)
+	for b := range in {
+		putTimer := p.bc.timerFactory.NewTimer("putBlock")
+		err := p.bc.dao.putBlock(b.blk)
+		putTimer.End()
+		if err != nil {
+			p.fail(b.blk.Height(), err)
+			p.stop()
+			return
+		}
+		out <- b
+	}
+}
+
+// commit is the last stage, which commits the states of the blocks and moves the tip, and returns the number of the
+// blocks committed
+func (p *commitPipeline) commit(in <-chan pipelinedBlock) int {
+	defer func() {
+		p.mutex.Lock()
+		p.finished = true
+		p.mutex.Unlock()
+		p.cond.Broadcast()
+	}()
+	n := 0
+	for b := range in {
+		blk := b.blk
+		p.bc.emitStatesToSubscribers(blk, b.ws)
+		sfTimer := p.bc.timerFactory.NewTimer("sf.Commit")
+		err := p.bc.sf.Commit(b.ws)
+		sfTimer.End()
+		if err != nil {
+			log.L().Panic("Error when committing states.", zap.Error(err))
+		}
+		// update tip hash and height
+		atomic.StoreUint64(&p.bc.tipHeight, blk.Height())
+		p.bc.tipHash = blk.HashBlock()
+		p.bc.minted.clear()
+		// write smart contract receipt into DB
+		if err := p.bc.dao.putReceipts(blk.Height(), blk.Receipts); err != nil {
+			log.L().Error(
+				"Failed to put smart contract receipts into DB.",
+				zap.Error(err),
+				zap.Uint64("height", blk.Height()),
+			)
+		}
+		blk.HeaderLogger(log.L()).Info("Committed a block.", log.Hex("tipHash", p.bc.tipHash[:]))
+		// emit block to all block subscribers
+		p.bc.emitToSubscribers(blk)
+		n++
+
+		p.mutex.Lock()
+		p.committed = blk.Height()
+		p.mutex.Unlock()
+		p.cond.Broadcast()
+	}
+	return n
+}
+
+// newWorkingSet creates the working set of the block on top of the pending states of the previous block. A fresh
+// working set is created if the previous block has been committed, or once it's committed if the state factory isn't
+// able to build on the pending states. It returns nil if the previous block won't be committed
+func (p *commitPipeline) newWorkingSet(prev factory.WorkingSet, height uint64) (factory.WorkingSet, error) {
+	if prev != nil && !p.isCommitted(height-1) {
+		ws, err := p.bc.sf.NewWorkingSetOn(prev)
+		if err == nil {
+			return ws, nil
+		}
+		if !p.waitCommitted(height - 1) {
+			return nil, nil
+		}
+	}
+	ws, err := p.bc.sf.NewWorkingSet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	return ws, nil
+}
+
+func (p *commitPipeline) isCommitted(height uint64) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.committed >= height
+}
+
+// waitCommitted waits until the states of the block at the height are committed, and returns false if the commit
+// stage finishes before that
+func (p *commitPipeline) waitCommitted(height uint64) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for p.committed < height && !p.finished {
+		p.cond.Wait()
+	}
+	return p.committed >= height
+}
+
+// fail records the error of the block, of which the one of the lowest block is returned
+func (p *commitPipeline) fail(height uint64, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.err == nil || height < p.errHeight {
+		p.errHeight, p.err = height, err
+	}
+}
+
+// stop stops the stages before the failed one
+func (p *commitPipeline) stop() {
+	p.abortOnce.Do(func() { close(p.abort) })
+}
+
+func (p *commitPipeline) epochNum(height uint64) uint64 {
+	return getEpochNum(height, p.bc.genesisConfig.NumDelegates, p.bc.genesisConfig.NumSubEpochs)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestCommitPipeline(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Default
+	genesisConfig := genesis.Default
+
+	testPipeline := func(t *testing.T, newFactory func(config.Config) (factory.Factory, error)) {
+		require := require.New(t)
+		newChain := func(cfg config.Config) (Blockchain, factory.Factory) {
+			sf, err := newFactory(cfg)
+			require.NoError(err)
+			sf.AddActionHandlers(account.NewProtocol())
+			bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+			bc.Validator().AddActionEnvelopeValidators(
+				protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit),
+			)
+			bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+			sf.AddActionHandlers(vote.NewProtocol(bc))
+			require.NoError(bc.Start(ctx))
+			require.NoError(addCreatorToFactory(sf))
+			return bc, sf
+		}
+
+		src, _ := newChain(cfg)
+		defer func() {
+			require.NoError(src.Stop(ctx))
+		}()
+		require.NoError(addTestingTsfBlocks(src))
+		tip := src.TipHeight()
+		blks := make([]*block.Block, 0, tip)
+		for h := uint64(1); h <= tip; h++ {
+			blk, err := src.GetBlockByHeight(h)
+			require.NoError(err)
+			blks = append(blks, blk)
+		}
+
+		pipelineCfg := cfg
+		pipelineCfg.Chain.CommitPipelineDepth = 2
+		bc, sf := newChain(pipelineCfg)
+		defer func() {
+			require.NoError(bc.Stop(ctx))
+		}()
+		// The blocks before the one failing the footer validation are committed
+		errFooter := errors.New("invalid footer")
+		n, err := bc.CommitBlocks(blks, func(blk *block.Block) error {
+			if blk.Height() == 3 {
+				return errFooter
+			}
+			return nil
+		})
+		require.Equal(errFooter, err)
+		require.Equal(2, n)
+		require.Equal(uint64(2), bc.TipHeight())
+
+		n, err = bc.CommitBlocks(blks[2:], nil)
+		require.NoError(err)
+		require.Equal(len(blks)-2, n)
+		require.Equal(tip, bc.TipHeight())
+		require.Equal(src.TipHash(), bc.TipHash())
+		height, err := sf.Height()
+		require.NoError(err)
+		require.Equal(tip, height)
+		for _, name := range []string{"producer", "alfa", "bravo", "charlie", "delta", "echo", "foxtrot"} {
+			addr := ta.Addrinfo[name].String()
+			expected, err := src.Balance(addr)
+			require.NoError(err)
+			balance, err := bc.Balance(addr)
+			require.NoError(err)
+			require.Equal(expected, balance)
+		}
+		for h := uint64(1); h <= tip; h++ {
+			expected, err := src.(*blockchain).dao.getReceipts(h)
+			require.NoError(err)
+			receipts, err := bc.(*blockchain).dao.getReceipts(h)
+			require.NoError(err)
+			require.Equal(calculateReceiptRoot(expected), calculateReceiptRoot(receipts))
+		}
+
+		// A block that is already committed is rejected
+		n, err = bc.CommitBlocks(blks[len(blks)-1:], nil)
+		require.Error(err)
+		require.Equal(0, n)
+	}
+
+	t.Run("state DB", func(t *testing.T) {
+		testPipeline(t, func(cfg config.Config) (factory.Factory, error) {
+			return factory.NewStateDB(cfg, factory.InMemStateDBOption())
+		})
+	})
+	// The trie factory isn't able to build on the pending states, so a block is executed once the previous one is
+	// committed
+	t.Run("trie factory", func(t *testing.T) {
+		testPipeline(t, func(cfg config.Config) (factory.Factory, error) {
+			return factory.NewFactory(cfg, factory.InMemTrieOption())
+		})
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// durableSubscriberRetryInterval is the interval to wait before handling a block again, after the subscriber fails
+var durableSubscriberRetryInterval = time.Second
+
+// batchedBlockSubscriber is a durable subscriber keeping its data in the chain DB. Its writes for a block are put into
+// the batch which moves its cursor, so that the block isn't handled twice if the batch isn't committed
+type batchedBlockSubscriber interface {
+	handleBlockInBatch(*block.Block, db.KVStoreBatch) error
+}
+
+// durableSubscriber delivers the committed blocks to the subscriber one by one in order, starting from the block above
+// its cursor persisted in the chain DB. The cursor is advanced once the subscriber has handled a block, so a block
+// failed is handled again, and the blocks committed while the subscriber is down are replayed when it's added again.
+// A block may be delivered again if the node stops before the cursor is persisted, unless the subscriber writes into
+// the chain DB in the batch committed along with the cursor
+type durableSubscriber struct {
+	name   string
+	s      BlockCreationSubscriber
+	bc     *blockchain
+	notify chan struct{}
+	quit   chan struct{}
+	done   chan struct{}
+}
+
+func newDurableSubscriber(bc *blockchain, name string, s BlockCreationSubscriber) *durableSubscriber {
+	return &durableSubscriber{
+		name:   name,
+		s:      s,
+		bc:     bc,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// start starts delivering the blocks. A subscriber added for the first time starts from the current tip
+func (ds *durableSubscriber) start() error {
+	if _, err := ds.bc.dao.getSubscriberCursor(ds.name); err != nil {
+		if errors.Cause(err) != db.ErrNotExist {
+			return err
+		}
+		if err := ds.bc.dao.putSubscriberCursor(ds.name, ds.bc.TipHeight()); err != nil {
+			return err
+		}
+	}
+	ds.quit = make(chan struct{})
+	ds.done = make(chan struct{})
+	go ds.run()
+	ds.wake()
+	return nil
+}
+
+// stop stops delivering the blocks, and waits for the block being handled
+func (ds *durableSubscriber) stop() {
+	if ds.quit == nil {
+		return
+	}
+	close(ds.quit)
+	<-ds.done
+	ds.quit = nil
+}
+
+// wake notifies the subscriber of a new block committed
+func (ds *durableSubscriber) wake() {
+	select {
+	case ds.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (ds *durableSubscriber) run() {
+	defer close(ds.done)
+	for {
+		select {
+		case <-ds.quit:
+			return
+		case <-ds.notify:
+		}
+		for {
+			delivered, err := ds.deliverNext()
+			if err != nil {
+				log.L().Error("Failed to deliver block to durable subscriber.",
+					zap.String("subscriber", ds.name),
+					zap.Error(err))
+				select {
+				case <-ds.quit:
+					return
+				case <-time.After(durableSubscriberRetryInterval):
+				}
+				continue
+			}
+			if !delivered {
+				break
+			}
+			select {
+			case <-ds.quit:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// deliverNext delivers the block above the cursor, and returns false if the subscriber has caught up with the tip. The
+// cursor is moved back to the tip if the chain has been reverted below it
+func (ds *durableSubscriber) deliverNext() (bool, error) {
+	cursor, err := ds.bc.dao.getSubscriberCursor(ds.name)
+	if err != nil {
+		return false, err
+	}
+	tip := ds.bc.subscribedTip()
+	if cursor > tip {
+		return false, ds.bc.dao.putSubscriberCursor(ds.name, tip)
+	}
+	if cursor == tip {
+		return false, nil
+	}
+	height := cursor + 1
+	batch := db.NewBatch()
+	blk, err := ds.bc.getBlockByHeight(height)
+	switch errors.Cause(err) {
+	case nil:
+		if err := ds.handle(blk, batch); err != nil {
+			return false, err
+		}
+	case ErrBlockPruned:
+		// The block is gone for good, so it's skipped to let the subscriber go on
+		log.L().Error("Skipped pruned block for durable subscriber.",
+			zap.String("subscriber", ds.name),
+			zap.Uint64("height", height))
+	default:
+		return false, err
+	}
+	return true, ds.bc.dao.commitSubscriberCursor(ds.name, height, batch)
+}
+
+// handle hands the block over to the subscriber, along with its receipts if they are kept. The batched subscriber puts
+// its writes into the batch
+func (ds *durableSubscriber) handle(blk *block.Block, batch db.KVStoreBatch) error {
+	if blk.Receipts == nil {
+		receipts, err := ds.bc.dao.getReceipts(blk.Height())
+		if err != nil {
+			log.L().Warn("Failed to get receipts of block for durable subscriber.",
+				zap.String("subscriber", ds.name),
+				zap.Uint64("height", blk.Height()),
+				zap.Error(err))
+		}
+		blk.Receipts = receipts
+	}
+	if bs, ok := ds.s.(batchedBlockSubscriber); ok {
+		return errors.Wrapf(bs.handleBlockInBatch(blk, batch), "failed to handle block %d", blk.Height())
+	}
+	return errors.Wrapf(ds.s.HandleBlock(blk), "failed to handle block %d", blk.Height())
+}
+
+// subscribedTip returns the height of the last block the durable subscribers could be delivered. A block committed
+// isn't delivered until it's emitted, so that its receipts are there
+func (bc *blockchain) subscribedTip() uint64 {
+	tip := bc.TipHeight()
+	if emitted := atomic.LoadUint64(&bc.emittedHeight); emitted < tip {
+		return emitted
+	}
+	return tip
+}
+
+// subscriberLag returns the number of the committed blocks which haven't been handled by the durable subscriber
+func (bc *blockchain) subscriberLag(name string) uint64 {
+	cursor, err := bc.dao.getSubscriberCursor(name)
+	if err != nil {
+		return 0
+	}
+	if tip := bc.subscribedTip(); tip > cursor {
+		return tip - cursor
+	}
+	return 0
+}
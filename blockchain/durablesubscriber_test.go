@@ -0,0 +1,223 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type recordingSubscriber struct {
+	// failures is the number of times to fail each block before it's handled
+	failures int
+	failed   map[uint64]int
+	handled  chan *block.Block
+}
+
+func (s *recordingSubscriber) HandleBlock(blk *block.Block) error {
+	if s.failed[blk.Height()] < s.failures {
+		s.failed[blk.Height()]++
+		return errors.New("failed to handle block")
+	}
+	s.handled <- blk
+	return nil
+}
+
+type failingIndexBuilder struct {
+	*IndexBuilder
+	failed map[uint64]bool
+}
+
+// handleBlockInBatch fails each block once after its indices are put into the batch
+func (ib *failingIndexBuilder) handleBlockInBatch(blk *block.Block, batch db.KVStoreBatch) error {
+	if err := ib.IndexBuilder.handleBlockInBatch(blk, batch); err != nil {
+		return err
+	}
+	if !ib.failed[blk.Height()] {
+		ib.failed[blk.Height()] = true
+		return errors.New("failed to handle block")
+	}
+	return nil
+}
+
+func TestBlockchain_DurableSubscriber(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	genesisConfig := genesis.Default
+	retryInterval := durableSubscriberRetryInterval
+	durableSubscriberRetryInterval = time.Millisecond
+	defer func() {
+		durableSubscriberRetryInterval = retryInterval
+	}()
+
+	newChain := func() Blockchain {
+		sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+		require.NoError(err)
+		sf.AddActionHandlers(account.NewProtocol())
+		bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+		bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit))
+		bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+		sf.AddActionHandlers(vote.NewProtocol(bc))
+		require.NoError(bc.Start(ctx))
+		require.NoError(addCreatorToFactory(sf))
+		return bc
+	}
+	src := newChain()
+	defer func() {
+		require.NoError(src.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(src))
+	tip := src.TipHeight()
+	blks := make([]*block.Block, 0, tip)
+	for h := uint64(1); h <= tip; h++ {
+		blk, err := src.GetBlockByHeight(h)
+		require.NoError(err)
+		blks = append(blks, blk)
+	}
+	expectHandled := func(s *recordingSubscriber, from, to uint64) {
+		for h := from; h <= to; h++ {
+			select {
+			case blk := <-s.handled:
+				require.Equal(h, blk.Height())
+				receipts, err := src.(*blockchain).dao.getReceipts(h)
+				require.NoError(err)
+				require.NotNil(blk.Receipts)
+				require.Equal(calculateReceiptRoot(receipts), calculateReceiptRoot(blk.Receipts))
+			case <-time.After(5 * time.Second):
+				require.FailNow("timeout waiting for block", "height %d", h)
+			}
+		}
+		select {
+		case blk := <-s.handled:
+			require.FailNow("unexpected block", "height %d", blk.Height())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	bc := newChain()
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	commit := func(blks []*block.Block) {
+		for _, blk := range blks {
+			n, err := bc.CommitBlocks([]*block.Block{blk}, nil)
+			require.NoError(err)
+			require.Equal(1, n)
+		}
+	}
+	sub := &recordingSubscriber{failures: 2, failed: make(map[uint64]int), handled: make(chan *block.Block, tip)}
+	require.Error(bc.AddDurableSubscriber("test", nil))
+	require.NoError(bc.AddDurableSubscriber("test", sub))
+	require.Error(bc.AddDurableSubscriber("test", sub))
+	commit(blks[:2])
+	// The blocks failed are retried rather than skipped
+	expectHandled(sub, 1, 2)
+	require.Equal(uint64(0), bc.(*blockchain).subscriberLag("test"))
+
+	// The blocks committed while the subscriber is removed are replayed once it's added again
+	require.NoError(bc.RemoveSubscriber(sub))
+	commit(blks[2:])
+	require.Equal(tip-2, bc.(*blockchain).subscriberLag("test"))
+	sub = &recordingSubscriber{handled: make(chan *block.Block, tip)}
+	require.NoError(bc.AddDurableSubscriber("test", sub))
+	expectHandled(sub, 3, tip)
+
+	// A subscriber added for the first time starts from the tip
+	late := &recordingSubscriber{handled: make(chan *block.Block, tip)}
+	require.NoError(bc.AddDurableSubscriber("late", late))
+	expectHandled(late, 1, 0)
+	require.Equal(uint64(0), bc.(*blockchain).subscriberLag("late"))
+}
+
+func TestBlockchain_DurableIndexBuilder(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := config.Default
+	cfg.Chain.EnableIndex = true
+	cfg.Chain.EnableAsyncIndexWrite = true
+	genesisConfig := genesis.Default
+	retryInterval := durableSubscriberRetryInterval
+	durableSubscriberRetryInterval = time.Millisecond
+	defer func() {
+		durableSubscriberRetryInterval = retryInterval
+	}()
+
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(err)
+	sf.AddActionHandlers(account.NewProtocol())
+	bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit))
+	bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+	sf.AddActionHandlers(vote.NewProtocol(bc))
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addCreatorToFactory(sf))
+
+	ib, err := NewIndexBuilder(bc)
+	require.NoError(err)
+	require.NoError(bc.AddDurableSubscriber(
+		IndexBuilderSubscriberName,
+		&failingIndexBuilder{IndexBuilder: ib, failed: make(map[uint64]bool)},
+	))
+	require.NoError(addTestingTsfBlocks(bc))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return ib.Lag() == 0, nil
+	}))
+
+	// Every block is delivered again after a failure, and it's only indexed once
+	var numActions, numTransfers, numVotes uint64
+	senders := make(map[string]uint64)
+	for h := uint64(1); h <= bc.TipHeight(); h++ {
+		blk, err := bc.GetBlockByHeight(h)
+		require.NoError(err)
+		transfers, votes, _ := action.ClassifyActions(blk.Actions)
+		numActions += uint64(len(blk.Actions))
+		numTransfers += uint64(len(transfers))
+		numVotes += uint64(len(votes))
+		for _, selp := range blk.Actions {
+			pkHash := keypair.HashPubKey(selp.SrcPubkey())
+			sender, err := address.FromBytes(pkHash[:])
+			require.NoError(err)
+			senders[sender.String()]++
+		}
+	}
+	require.NotZero(numActions)
+	total, err := bc.GetTotalActions()
+	require.NoError(err)
+	require.Equal(numActions, total)
+	total, err = bc.GetTotalTransfers()
+	require.NoError(err)
+	require.Equal(numTransfers, total)
+	total, err = bc.GetTotalVotes()
+	require.NoError(err)
+	require.Equal(numVotes, total)
+	for sender, count := range senders {
+		indexed, err := getActionCountBySenderAddress(ib.store, sender)
+		require.NoError(err)
+		require.Equal(count, indexed)
+	}
+}
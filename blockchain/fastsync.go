@@ -0,0 +1,85 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// ImportTrustedBlock appends a block at or below the trusted checkpoint to the chain without running its actions. The
+// caller has verified the block hash against the header chain ending at the checkpoint, so the states are neither
+// updated nor validated, until they are loaded from the snapshot at the checkpoint. The receipts come along with the
+// block from the peer, and are verified against the receipt root, so that they are stored and the block is indexed
+// and emitted to the subscribers as if it were executed
+func (bc *blockchain) ImportTrustedBlock(blk *block.Block) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	timer := bc.timerFactory.NewTimer("ImportTrustedBlock")
+	defer timer.End()
+
+	if blk.Height() != bc.tipHeight+1 {
+		return errors.Errorf("block %d doesn't follow the tip height %d", blk.Height(), bc.tipHeight)
+	}
+	if blk.PrevHash() != bc.tipHash {
+		return errors.Errorf(
+			"previous hash %x of block %d doesn't match the tip hash %x",
+			blk.PrevHash(),
+			blk.Height(),
+			bc.tipHash,
+		)
+	}
+	if err := blk.VerifyReceiptRoot(calculateReceiptRoot(blk.Receipts)); err != nil {
+		return errors.Wrapf(err, "failed to verify receipts of block %d", blk.Height())
+	}
+	if err := bc.dao.putBlock(blk); err != nil {
+		return err
+	}
+	if err := bc.dao.putReceipts(blk.Height(), blk.Receipts); err != nil {
+		return errors.Wrapf(err, "failed to put receipts into DB on height %d", blk.Height())
+	}
+	atomic.StoreUint64(&bc.tipHeight, blk.Height())
+	bc.tipHash = blk.HashBlock()
+	bc.minted.clear()
+	bc.pruneReceipts(blk.Height())
+	blk.HeaderLogger(log.L()).Debug("Imported a trusted block.", log.Hex("tipHash", bc.tipHash[:]))
+
+	bc.emitToSubscribers(blk)
+	return nil
+}
+
+// LoadStateSnapshot puts the states in the snapshot into the state factory, and moves the state height to the
+// snapshot height, which has to be the tip height. The states of the chain are left as they are if it fails
+func (bc *blockchain) LoadStateSnapshot(snapshot *StateSnapshot) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if snapshot.Height != bc.tipHeight {
+		return errors.Errorf("state snapshot height %d doesn't match the tip height %d", snapshot.Height, bc.tipHeight)
+	}
+	ws, err := bc.sf.NewWorkingSet()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	if err := putSnapshotStates(ws, snapshot); err != nil {
+		return err
+	}
+	ws.UpdateBlockLevelInfo(snapshot.Height)
+	if err := bc.sf.Commit(ws); err != nil {
+		return errors.Wrapf(err, "failed to commit the states at height %d", snapshot.Height)
+	}
+	log.L().Info("Loaded the state snapshot.",
+		zap.Uint64("height", snapshot.Height),
+		zap.Int("accounts", len(snapshot.Accounts)),
+		zap.Int("states", len(snapshot.States)))
+	return nil
+}
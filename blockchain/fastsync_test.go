@@ -0,0 +1,103 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func newFastSyncTestChain(t *testing.T) Blockchain {
+	require := require.New(t)
+	cfg := config.Default
+	genesisCfg := genesis.Default
+	registry := protocol.Registry{}
+	bc := NewBlockchain(
+		cfg,
+		InMemStateFactoryOption(),
+		InMemDaoOption(),
+		GenesisOption(genesisCfg),
+		RegistryOption(&registry),
+	)
+	acc := account.NewProtocol()
+	v := vote.NewProtocol(bc)
+	rp := rewarding.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	require.NoError(registry.Register(rewarding.ProtocolID, rp))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.ActionGasLimit))
+	bc.Validator().AddActionValidators(acc, v)
+	bc.GetFactory().AddActionHandlers(acc, v, rp)
+	return bc
+}
+
+func TestBlockchain_ImportTrustedBlock(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	bc := newFastSyncTestChain(t)
+	require.NoError(bc.Start(ctx))
+	defer func() { require.NoError(bc.Stop(ctx)) }()
+	require.NoError(addTestingTsfBlocks(bc))
+	tipHeight := bc.TipHeight()
+	snapshot, err := bc.ExportState(ctx, 0)
+	require.NoError(err)
+
+	synced := newFastSyncTestChain(t)
+	require.NoError(synced.Start(ctx))
+	defer func() { require.NoError(synced.Stop(ctx)) }()
+
+	// The blocks are imported in order without running their actions
+	blk, err := bc.GetBlockByHeight(2)
+	require.NoError(err)
+	require.Error(synced.ImportTrustedBlock(blk))
+	for height := uint64(1); height <= tipHeight; height++ {
+		blk, err := bc.GetBlockByHeight(height)
+		require.NoError(err)
+		receipts, err := bc.GetReceiptsByHeight(height)
+		require.NoError(err)
+		// The receipts have to match the receipt root
+		blk.Receipts = receipts[:len(receipts)-1]
+		require.Error(synced.ImportTrustedBlock(blk))
+		blk.Receipts = receipts
+		require.NoError(synced.ImportTrustedBlock(blk))
+		imported, err := synced.GetReceiptsByHeight(height)
+		require.NoError(err)
+		require.Equal(calculateReceiptRoot(receipts), calculateReceiptRoot(imported))
+	}
+	require.Equal(tipHeight, synced.TipHeight())
+	require.Equal(bc.TipHash(), synced.TipHash())
+	stateHeight, err := synced.GetFactory().Height()
+	require.NoError(err)
+	require.Equal(uint64(0), stateHeight)
+
+	// The states are loaded from the snapshot at the tip height
+	snapshot.Height--
+	require.Error(synced.LoadStateSnapshot(snapshot))
+	snapshot.Height++
+	require.NoError(synced.LoadStateSnapshot(snapshot))
+	stateHeight, err = synced.GetFactory().Height()
+	require.NoError(err)
+	require.Equal(tipHeight, stateHeight)
+	for _, account := range snapshot.Accounts {
+		balance, err := synced.Balance(account.Address)
+		require.NoError(err)
+		require.Equal(account.Balance, balance.String())
+		nonce, err := synced.Nonce(account.Address)
+		require.NoError(err)
+		require.Equal(account.Nonce, nonce)
+	}
+}
@@ -7,17 +7,21 @@
 package genesis
 
 import (
+	"encoding/hex"
 	"flag"
 	"math/big"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/config"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 // DefaultAdminPrivateKey is used to create the default genesis config. It could facilitate quick setup of the
@@ -29,11 +33,13 @@ var (
 	Default Genesis
 
 	genesisPath      string
+	subGenesisPath   string
 	defaultAdminAddr address.Address
 )
 
 func init() {
 	flag.StringVar(&genesisPath, "genesis-path", "", "Genesis path")
+	flag.StringVar(&subGenesisPath, "sub-genesis-path", "", "Sub chain genesis path")
 	sk, err := keypair.DecodePrivateKey(DefaultAdminPrivateKey)
 	if err != nil {
 		log.L().Panic("Error when decoding the default admin private key.", zap.Error(err))
@@ -50,17 +56,33 @@ func init() {
 func initDefaultConfig() {
 	Default = Genesis{
 		Blockchain: Blockchain{
-			Timestamp:      1546329600,
-			BlockGasLimit:  20000000,
-			ActionGasLimit: 5000000,
-			NumSubEpochs:   1,
-			NumDelegates:   21,
+			Timestamp:                  1546329600,
+			BlockGasLimit:              20000000,
+			ActionGasLimit:             5000000,
+			NumSubEpochs:               1,
+			NumDelegates:               21,
+			NumCandidates:              101,
+			CommitThresholdNumerator:   2,
+			CommitThresholdDenominator: 3,
 		},
 		Rewarding: Rewarding{
-			InitAdminAddrStr: defaultAdminAddr.String(),
-			InitBalanceStr:   unit.ConvertIotxToRau(1200000000).String(),
-			BlockRewardStr:   unit.ConvertIotxToRau(36).String(),
-			EpochRewardStr:   unit.ConvertIotxToRau(400000).String(),
+			InitAdminAddrStr:       defaultAdminAddr.String(),
+			InitBalanceStr:         unit.ConvertIotxToRau(1200000000).String(),
+			BlockRewardStr:         unit.ConvertIotxToRau(36).String(),
+			EpochRewardStr:         unit.ConvertIotxToRau(400000).String(),
+			MultisigProposalTTL:    17280,
+			EpochSnapshotRetention: 720,
+		},
+		Evidence: Evidence{
+			MaxEvidenceAge: 720,
+		},
+		CircuitBreaker: CircuitBreaker{
+			MaxPauseDuration: 8640,
+		},
+		DustReclaim: DustReclaim{
+			DustThresholdStr:      "0",
+			DustInactiveEpochs:    720,
+			DustMaxSweepsPerBlock: 1000,
 		},
 	}
 }
@@ -69,8 +91,19 @@ type (
 	// Genesis is the root level of genesis config. Genesis config is the network-wide blockchain config. All the nodes
 	// participating into the same network should use the same genesis config.
 	Genesis struct {
-		Blockchain `yaml:"blockchain"`
-		Rewarding  `yaml:"rewarding"`
+		Blockchain     `yaml:"blockchain"`
+		Rewarding      `yaml:"rewarding"`
+		Allowlist      `yaml:"allowlist"`
+		Governance     `yaml:"governance"`
+		Regenesis      `yaml:"regenesis"`
+		Checkpoint     `yaml:"checkpoint"`
+		Evidence       `yaml:"evidence"`
+		Session        `yaml:"session"`
+		FreeGas        `yaml:"freeGas"`
+		BLS            `yaml:"bls"`
+		CircuitBreaker `yaml:"circuitBreaker"`
+		DustReclaim    `yaml:"dustReclaim"`
+		DKG            `yaml:"dkg"`
 	}
 	// Blockchain contains blockchain level configs
 	Blockchain struct {
@@ -84,6 +117,26 @@ type (
 		NumSubEpochs uint64 `yaml:"numSubEpochs"`
 		// NumDelegates is the number of delegates that participate into one epoch of block production
 		NumDelegates uint64 `yaml:"numDelegates"`
+		// NumCandidates is the number of the candidates of the most votes, among which the delegates of an epoch are
+		// ranked by the protocols on chain, such as the DKG and the evidence protocols. It can't be less than the
+		// number of delegates
+		NumCandidates uint64 `yaml:"numCandidates"`
+		// BlockInterval is the interval of block production. It's taken by the sub chains, while the root chain
+		// follows the consensus config of the node. 0 means that the sub chain keeps the interval in the consensus
+		// config of its own node
+		BlockInterval time.Duration `yaml:"blockInterval"`
+		// CommitThresholdNumerator and CommitThresholdDenominator make the fraction of the delegates, more than which
+		// have to endorse a block for it to be committed. It has to be in [2/3, 1), and is 2/3 by default
+		CommitThresholdNumerator   uint64 `yaml:"commitThresholdNumerator"`
+		CommitThresholdDenominator uint64 `yaml:"commitThresholdDenominator"`
+		// HeaderVersionHeights maps the block header versions to the fork heights from which the blocks are produced
+		// with them. Version 1 is used from the genesis block. Version 2 carries the VRF proofs of the producers, which
+		// select the proposers of the next blocks, and requires the BLS keys of the delegates
+		HeaderVersionHeights map[uint32]uint64 `yaml:"headerVersionHeights"`
+		// HashActionOrderHeight is the fork height from which the actions of the same gas price in a block are ordered
+		// by the hash of the action hash and the previous block hash rather than by their arrival, so that the producer
+		// can't front-run them. The validators reject the blocks of the other order from then on. 0 means never
+		HashActionOrderHeight uint64 `yaml:"hashActionOrderHeight"`
 	}
 	// Rewarding contains the configs for rewarding protocol
 	Rewarding struct {
@@ -95,16 +148,163 @@ type (
 		BlockRewardStr string `yaml:"blockReward"`
 		// EpochReward is the epoch reward amount in decimal string format
 		EpochRewardStr string `yaml:"epochReward"`
+		// GasFeeBurnPercentage is the percentage of the gas fee to burn
+		GasFeeBurnPercentage uint64 `yaml:"gasFeeBurnPercentage"`
+		// GasFeeProducerPercentage is the percentage of the gas fee to grant to the block producer immediately. The
+		// rest of the gas fee, after burning and granting to the producer, is deposited into the rewarding fund
+		GasFeeProducerPercentage uint64 `yaml:"gasFeeProducerPercentage"`
+		// InitMultisigAdminAddrStrs are the addresses of the initial rewarding protocol multisig admins in encoded
+		// string format. If it's empty, the init admin is in charge of the admin changes alone
+		InitMultisigAdminAddrStrs []string `yaml:"initMultisigAdminAddrs"`
+		// MultisigThreshold is the number of approvals from the multisig admins to make an admin change
+		MultisigThreshold uint64 `yaml:"multisigThreshold"`
+		// MultisigProposalTTL is the number of blocks a pending admin change lasts before it expires. 0 means it never
+		// expires
+		MultisigProposalTTL uint64 `yaml:"multisigProposalTTL"`
+		// EpochSnapshotHeight is the fork height from which the rewarding accounts are snapshotted at the end of each
+		// epoch, 0 means never
+		EpochSnapshotHeight uint64 `yaml:"epochSnapshotHeight"`
+		// EpochSnapshotRetention is the number of the latest epochs whose snapshots are kept, 0 means all
+		EpochSnapshotRetention uint64 `yaml:"epochSnapshotRetention"`
+		// ClaimLogHeight is the fork height from which the claims from the rewarding fund emit the claim logs, 0 means
+		// never
+		ClaimLogHeight uint64 `yaml:"claimLogHeight"`
+	}
+	// Allowlist contains the configs for allowlist protocol, which only accepts the actions sent by the addresses in the
+	// allowlist. It's meant for the permissioned deployments
+	Allowlist struct {
+		// EnableAllowlist enables the allowlist protocol
+		EnableAllowlist bool `yaml:"enable"`
+		// InitAllowlistAdminAddrStr is the address of the initial allowlist admin in encoded string format
+		InitAllowlistAdminAddrStr string `yaml:"initAdminAddr"`
+		// InitAllowedAddrStrs are the addresses initially in the allowlist in encoded string format
+		InitAllowedAddrStrs []string `yaml:"initAllowedAddrs"`
+	}
+	// Governance contains the configs for governance protocol, which lets the admin tune the roll-DPoS timing
+	// parameters on chain
+	Governance struct {
+		// EnableGovernance enables the governance protocol
+		EnableGovernance bool `yaml:"enable"`
+		// InitGovernanceAdminAddrStr is the address of the initial governance admin in encoded string format
+		InitGovernanceAdminAddrStr string `yaml:"initAdminAddr"`
+	}
+	// Regenesis contains the configs to bootstrap a new chain from the states exported from another chain. The states
+	// are put into the genesis states, and the state digest is embedded into the genesis block as its previous block
+	// hash
+	Regenesis struct {
+		// StateSnapshotPath is the path of the state snapshot file. If it's empty, the chain starts from scratch
+		StateSnapshotPath string `yaml:"stateSnapshotPath"`
+		// StateDigestStr is the digest of the state snapshot in hex string format, which has to match the file
+		StateDigestStr string `yaml:"stateDigest"`
+	}
+	// Checkpoint contains the trusted checkpoint of the chain. A node in fast sync mode verifies the block headers down
+	// from the checkpoint, imports the blocks up to it without executing them, and loads the states at the checkpoint
+	// from a snapshot
+	Checkpoint struct {
+		// CheckpointHeight is the height of the checkpoint block, 0 means no checkpoint
+		CheckpointHeight uint64 `yaml:"height"`
+		// CheckpointHashStr is the hash of the checkpoint block in hex string format
+		CheckpointHashStr string `yaml:"hash"`
+		// CheckpointStateDigestStr is the digest of the state snapshot at the checkpoint height in hex string format
+		CheckpointStateDigestStr string `yaml:"stateDigest"`
+	}
+	// Evidence contains the configs for evidence protocol, which records the delegates endorsing different blocks for
+	// the same vote, so that they could be penalized
+	Evidence struct {
+		// EnableEvidence enables the evidence protocol and the detection of the double signs in consensus
+		EnableEvidence bool `yaml:"enable"`
+		// MaxEvidenceAge is the number of blocks after the double sign, within which the evidence has to be included
+		MaxEvidenceAge uint64 `yaml:"maxAge"`
+	}
+	// Session contains the configs for session protocol, which lets a gateway send the micro actions signed by many
+	// devices in one session envelope
+	Session struct {
+		// EnableSession enables the session protocol
+		EnableSession bool `yaml:"enable"`
+	}
+	// FreeGas contains the configs for free gas protocol, which lets the accounts without balance send the lightweight
+	// actions at zero gas price up to a daily quota
+	FreeGas struct {
+		// EnableFreeGas enables the free gas protocol
+		EnableFreeGas bool `yaml:"enable"`
+		// DailyQuota is the intrinsic gas of the free gas actions an account could send in a day
+		DailyQuota uint64 `yaml:"dailyQuota"`
+		// FreeGasActions maps the type names of the actions granted free gas, such as Transfer, to their max sizes in
+		// bytes, where 0 means no limit
+		FreeGasActions map[string]uint64 `yaml:"actions"`
+		// DailyCap is the intrinsic gas of the free gas actions all the accounts could send in a day, where 0 means no
+		// cap
+		DailyCap uint64 `yaml:"dailyCap"`
+	}
+	// BLS contains the configs to aggregate the BLS signatures of the endorsements into the block footers. The BLS keys
+	// of the delegates are kept by the BLS key protocol, where the delegates register and rotate their keys
+	BLS struct {
+		// EnableBLS enables the BLS key protocol and the aggregated endorsements in the block footers
+		EnableBLS bool `yaml:"enable"`
+		// DelegateBLSKeys are the BLS public keys registered in the genesis block, which take effect from the start
+		DelegateBLSKeys []DelegateBLSKey `yaml:"delegateKeys"`
+	}
+	// CircuitBreaker contains the configs for circuit breaker protocol, which lets the governance admin pause the
+	// actions of certain types network-wide in an emergency. It requires the governance protocol
+	CircuitBreaker struct {
+		// EnableCircuitBreaker enables the circuit breaker protocol
+		EnableCircuitBreaker bool `yaml:"enable"`
+		// MaxPauseDuration is the max number of blocks a pause lasts, after which it expires
+		MaxPauseDuration uint64 `yaml:"maxPauseDuration"`
+	}
+	// DustReclaim contains the configs to reclaim the state size taken by the dust accounts, which have never sent any
+	// action and hold tiny balances. It's a hard fork, from which the dust accounts not receiving any transfer for a
+	// number of epochs are deleted, and the total of their balances burnt is recorded
+	DustReclaim struct {
+		// DustReclaimHeight is the fork height from which the dust accounts are reclaimed, 0 means never
+		DustReclaimHeight uint64 `yaml:"height"`
+		// DustThresholdStr is the max balance of a dust account in decimal string format
+		DustThresholdStr string `yaml:"threshold"`
+		// DustInactiveEpochs is the number of epochs without any transfer to a dust account, after which it's deleted
+		DustInactiveEpochs uint64 `yaml:"inactiveEpochs"`
+		// DustMaxSweepsPerBlock is the max number of the dust accounts checked in a block, which bounds the extra work
+		// of a block when a large number of accounts are due
+		DustMaxSweepsPerBlock uint64 `yaml:"maxSweepsPerBlock"`
+	}
+	// DKG contains the configs of the distributed key generation of the epoch beacons. It's a hard fork, from which
+	// the delegates of each epoch generate the beacon seeding the delegate order of the next epoch
+	DKG struct {
+		// DKGHeight is the fork height from which the epochs run the key generation, 0 means never
+		DKGHeight uint64 `yaml:"height"`
+	}
+	// DelegateBLSKey is the BLS public key of a delegate, which comes with the proof of possession of its private key,
+	// i.e., its signature of action.BLSPossessionMessage
+	DelegateBLSKey struct {
+		// Address is the address of the delegate in encoded string format
+		Address string `yaml:"address"`
+		// PubKeyStr is the BLS public key in hex string format
+		PubKeyStr string `yaml:"pubKey"`
+		// ProofStr is the proof of possession of the BLS private key in hex string format
+		ProofStr string `yaml:"proof"`
 	}
 )
 
 // New constructs a genesis config. It loads the default values, and could be overwritten by values defined in the yaml
 // config files
 func New() (Genesis, error) {
+	return newGenesis(genesisPath)
+}
+
+// NewSub constructs the genesis config of the sub chains. It loads the default values, and could be overwritten by
+// values defined in the sub chain genesis yaml file. If there is no such file, the genesis config of the root chain is
+// returned
+func NewSub() (Genesis, error) {
+	if subGenesisPath == "" {
+		return New()
+	}
+	return newGenesis(subGenesisPath)
+}
+
+func newGenesis(path string) (Genesis, error) {
 	opts := make([]config.YAMLOption, 0)
 	opts = append(opts, config.Static(Default))
-	if genesisPath != "" {
-		opts = append(opts, config.File(genesisPath))
+	if path != "" {
+		opts = append(opts, config.File(path))
 	}
 	yaml, err := config.NewYAML(opts...)
 	if err != nil {
@@ -115,9 +315,36 @@ func New() (Genesis, error) {
 	if err := yaml.Get(config.Root).Populate(&genesis); err != nil {
 		return Genesis{}, errors.Wrap(err, "failed to unmarshal yaml genesis to struct")
 	}
+	if err := genesis.validate(); err != nil {
+		return Genesis{}, err
+	}
 	return genesis, nil
 }
 
+// validate checks the parameters which would otherwise fail the genesis block
+func (g *Genesis) validate() error {
+	if g.GasFeeBurnPercentage > 100 ||
+		g.GasFeeProducerPercentage > 100 ||
+		g.GasFeeBurnPercentage+g.GasFeeProducerPercentage > 100 {
+		return errors.Errorf(
+			"gas fee burn percentage %d plus producer percentage %d shouldn't exceed 100",
+			g.GasFeeBurnPercentage,
+			g.GasFeeProducerPercentage,
+		)
+	}
+	if g.NumCandidates < g.NumDelegates {
+		return errors.Errorf(
+			"number of candidates %d shouldn't be less than number of delegates %d",
+			g.NumCandidates,
+			g.NumDelegates,
+		)
+	}
+	if g.DustReclaimHeight != 0 && g.DustMaxSweepsPerBlock == 0 {
+		return errors.New("max sweeps per block of the dust reclaim should be greater than 0")
+	}
+	return nil
+}
+
 // InitAdminAddr returns the address of the initial rewarding protocol admin
 func (r *Rewarding) InitAdminAddr() address.Address {
 	addr, err := address.FromString(r.InitAdminAddrStr)
@@ -127,6 +354,19 @@ func (r *Rewarding) InitAdminAddr() address.Address {
 	return addr
 }
 
+// InitMultisigAdminAddrs returns the addresses of the initial rewarding protocol multisig admins
+func (r *Rewarding) InitMultisigAdminAddrs() []address.Address {
+	addrs := make([]address.Address, 0, len(r.InitMultisigAdminAddrStrs))
+	for _, addrStr := range r.InitMultisigAdminAddrStrs {
+		addr, err := address.FromString(addrStr)
+		if err != nil {
+			log.L().Panic("Error when decoding the rewarding protocol multisig admin address from string.", zap.Error(err))
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
 // InitBalance returns the init balance of the rewarding fund
 func (r *Rewarding) InitBalance() *big.Int {
 	val, ok := big.NewInt(0).SetString(r.InitBalanceStr, 10)
@@ -153,3 +393,96 @@ func (r *Rewarding) EpochReward() *big.Int {
 	}
 	return val
 }
+
+// DustThreshold returns the max balance of a dust account
+func (d *DustReclaim) DustThreshold() *big.Int {
+	val, ok := big.NewInt(0).SetString(d.DustThresholdStr, 10)
+	if !ok {
+		log.S().Panicf("Error when casting dust threshold string %s into big int", d.DustThresholdStr)
+	}
+	return val
+}
+
+// InitAllowlistAdminAddr returns the address of the initial allowlist admin
+func (a *Allowlist) InitAllowlistAdminAddr() address.Address {
+	addr, err := address.FromString(a.InitAllowlistAdminAddrStr)
+	if err != nil {
+		log.L().Panic("Error when decoding the allowlist init admin address from string.", zap.Error(err))
+	}
+	return addr
+}
+
+// InitAllowedAddrs returns the addresses initially in the allowlist
+func (a *Allowlist) InitAllowedAddrs() []address.Address {
+	addrs := make([]address.Address, 0, len(a.InitAllowedAddrStrs))
+	for _, addrStr := range a.InitAllowedAddrStrs {
+		addr, err := address.FromString(addrStr)
+		if err != nil {
+			log.L().Panic("Error when decoding the allowed address from string.", zap.Error(err))
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// StateDigest returns the digest of the state snapshot to bootstrap from
+func (r *Regenesis) StateDigest() hash.Hash256 {
+	digest, err := hex.DecodeString(r.StateDigestStr)
+	if err != nil || len(digest) != len(hash.ZeroHash256) {
+		log.S().Panicf("Error when decoding state digest string %s", r.StateDigestStr)
+	}
+	return byteutil.BytesTo32B(digest)
+}
+
+// CheckpointHash returns the hash of the checkpoint block
+func (c *Checkpoint) CheckpointHash() hash.Hash256 {
+	h, err := hex.DecodeString(c.CheckpointHashStr)
+	if err != nil || len(h) != len(hash.ZeroHash256) {
+		log.S().Panicf("Error when decoding checkpoint hash string %s", c.CheckpointHashStr)
+	}
+	return byteutil.BytesTo32B(h)
+}
+
+// CheckpointStateDigest returns the digest of the state snapshot at the checkpoint height
+func (c *Checkpoint) CheckpointStateDigest() hash.Hash256 {
+	digest, err := hex.DecodeString(c.CheckpointStateDigestStr)
+	if err != nil || len(digest) != len(hash.ZeroHash256) {
+		log.S().Panicf("Error when decoding checkpoint state digest string %s", c.CheckpointStateDigestStr)
+	}
+	return byteutil.BytesTo32B(digest)
+}
+
+// InitDelegateBLSKeys returns the addresses of the delegates registering BLS keys in the genesis block, together with
+// their BLS public keys and the proofs of possession, which are verified by the BLS key protocol
+func (b *BLS) InitDelegateBLSKeys() ([]address.Address, [][]byte, [][]byte) {
+	addrs := make([]address.Address, 0, len(b.DelegateBLSKeys))
+	pks := make([][]byte, 0, len(b.DelegateBLSKeys))
+	proofs := make([][]byte, 0, len(b.DelegateBLSKeys))
+	for _, key := range b.DelegateBLSKeys {
+		addr, err := address.FromString(key.Address)
+		if err != nil {
+			log.L().Panic("Error when decoding the delegate address from string.", zap.Error(err))
+		}
+		pk, err := hex.DecodeString(key.PubKeyStr)
+		if err != nil {
+			log.L().Panic("Error when decoding the delegate BLS public key.", zap.Error(err))
+		}
+		proof, err := hex.DecodeString(key.ProofStr)
+		if err != nil {
+			log.L().Panic("Error when decoding the proof of possession.", zap.Error(err))
+		}
+		addrs = append(addrs, addr)
+		pks = append(pks, pk)
+		proofs = append(proofs, proof)
+	}
+	return addrs, pks, proofs
+}
+
+// InitGovernanceAdminAddr returns the address of the initial governance admin
+func (g *Governance) InitGovernanceAdminAddr() address.Address {
+	addr, err := address.FromString(g.InitGovernanceAdminAddrStr)
+	if err != nil {
+		log.L().Panic("Error when decoding the governance init admin address from string.", zap.Error(err))
+	}
+	return addr
+}
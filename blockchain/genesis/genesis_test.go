@@ -7,7 +7,11 @@
 package genesis
 
 import (
+	"io/ioutil"
+	"math"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,8 +26,59 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, Default.ActionGasLimit, cfg.ActionGasLimit)
 	assert.Equal(t, Default.NumSubEpochs, cfg.NumSubEpochs)
 	assert.Equal(t, Default.NumDelegates, cfg.NumDelegates)
+	assert.Equal(t, Default.BlockInterval, cfg.BlockInterval)
 	// Validate rewarding protocol
 	assert.Equal(t, Default.InitAdminAddr().String(), cfg.InitAdminAddr().String())
 	assert.Equal(t, Default.BlockReward(), cfg.BlockReward())
 	assert.Equal(t, Default.EpochReward(), cfg.EpochReward())
 }
+
+func TestSubConfig(t *testing.T) {
+	require := require.New(t)
+	// without the sub chain genesis, the root chain genesis is taken
+	cfg, err := NewSub()
+	require.NoError(err)
+	require.Equal(Default.NumDelegates, cfg.NumDelegates)
+
+	file, err := ioutil.TempFile("", "sub-genesis")
+	require.NoError(err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("blockchain:\n  numSubEpochs: 2\n  numDelegates: 4\n  blockInterval: 2s\n")
+	require.NoError(err)
+	require.NoError(file.Close())
+
+	subGenesisPath = file.Name()
+	defer func() { subGenesisPath = "" }()
+	cfg, err = NewSub()
+	require.NoError(err)
+	require.Equal(uint64(2), cfg.NumSubEpochs)
+	require.Equal(uint64(4), cfg.NumDelegates)
+	require.Equal(2*time.Second, cfg.BlockInterval)
+	require.Equal(Default.BlockGasLimit, cfg.BlockGasLimit)
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+	g := Default
+	require.NoError(g.validate())
+	g.GasFeeBurnPercentage = 60
+	g.GasFeeProducerPercentage = 40
+	require.NoError(g.validate())
+	g.GasFeeProducerPercentage = 41
+	require.Error(g.validate())
+	g.GasFeeBurnPercentage = math.MaxUint64
+	g.GasFeeProducerPercentage = 2
+	require.Error(g.validate())
+
+	g = Default
+	g.NumCandidates = g.NumDelegates
+	require.NoError(g.validate())
+	g.NumCandidates = g.NumDelegates - 1
+	require.Error(g.validate())
+
+	g = Default
+	g.DustMaxSweepsPerBlock = 0
+	require.NoError(g.validate())
+	g.DustReclaimHeight = 10
+	require.Error(g.validate())
+}
@@ -11,8 +11,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
-	"go.uber.org/zap"
-	"golang.org/x/net/context"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/address"
@@ -38,11 +36,14 @@ func init() {
 	prometheus.MustRegister(batchSizeMtc)
 }
 
-// IndexBuilder defines the index builder
+// IndexBuilderSubscriberName is the name of the durable subscription of the index builder
+const IndexBuilderSubscriberName = "indexBuilder"
+
+// IndexBuilder defines the index builder, which builds the indices of the blocks delivered by its durable
+// subscription to the blockchain
 type IndexBuilder struct {
+	bc           *blockchain
 	store        db.KVStore
-	pendingBlks  chan *block.Block
-	cancelChan   chan interface{}
 	timerFactory *prometheustimer.TimerFactory
 }
 
@@ -62,62 +63,40 @@ func NewIndexBuilder(chain Blockchain) (*IndexBuilder, error) {
 		return nil, err
 	}
 	return &IndexBuilder{
+		bc:           bc,
 		store:        bc.dao.kvstore,
-		pendingBlks:  make(chan *block.Block, 64), // Actually 1 should be enough
-		cancelChan:   make(chan interface{}),
 		timerFactory: timerFactory,
 	}, nil
 }
 
-// Start starts the index builder
-func (ib *IndexBuilder) Start(_ context.Context) error {
-	go func() {
-		for {
-			select {
-			case <-ib.cancelChan:
-				return
-			case blk := <-ib.pendingBlks:
-				timer := ib.timerFactory.NewTimer("indexBlock")
-				batch := db.NewBatch()
-				if err := indexBlock(ib.store, blk, batch); err != nil {
-					log.L().Info(
-						"Error when indexing the block",
-						zap.Uint64("height", blk.Height()),
-						zap.Error(err),
-					)
-				}
-				// index receipts
-				if err := putReceipts(blk.Height(), blk.Receipts, batch); err != nil {
-					log.L().Info(
-						"Error when indexing the block",
-						zap.Uint64("height", blk.Height()),
-						zap.Error(err),
-					)
-				}
-				batchSizeMtc.WithLabelValues().Set(float64(batch.Size()))
-				if err := ib.store.Commit(batch); err != nil {
-					log.L().Info(
-						"Error when indexing the block",
-						zap.Uint64("height", blk.Height()),
-						zap.Error(err),
-					)
-				}
-				timer.End()
-			}
-		}
-	}()
-	return nil
+// Lag returns the number of the committed blocks which haven't been indexed yet
+func (ib *IndexBuilder) Lag() uint64 {
+	return ib.bc.subscriberLag(IndexBuilderSubscriberName)
 }
 
-// Stop stops the index builder
-func (ib *IndexBuilder) Stop(_ context.Context) error {
-	close(ib.cancelChan)
-	return nil
+// HandleBlock creates the indices for the actions and receipts in the block
+func (ib *IndexBuilder) HandleBlock(blk *block.Block) error {
+	batch := db.NewBatch()
+	if err := ib.handleBlockInBatch(blk, batch); err != nil {
+		return err
+	}
+	return errors.Wrapf(ib.store.Commit(batch), "failed to commit indices of block %d", blk.Height())
 }
 
-// HandleBlock handles the block and create the indices for the actions and receipts in it
-func (ib *IndexBuilder) HandleBlock(blk *block.Block) error {
-	ib.pendingBlks <- blk
+// handleBlockInBatch puts the indices of the block into the batch, which is committed along with the cursor of the
+// durable subscription. The totals and the per-address counts are incremented, so a block delivered again would be
+// counted twice if its indices were committed on their own
+func (ib *IndexBuilder) handleBlockInBatch(blk *block.Block, batch db.KVStoreBatch) error {
+	timer := ib.timerFactory.NewTimer("indexBlock")
+	defer timer.End()
+	if err := indexBlock(ib.store, blk, batch); err != nil {
+		return errors.Wrapf(err, "failed to index block %d", blk.Height())
+	}
+	// index receipts
+	if err := putReceipts(blk.Height(), blk.Receipts, batch); err != nil {
+		return errors.Wrapf(err, "failed to index receipts of block %d", blk.Height())
+	}
+	batchSizeMtc.WithLabelValues().Set(float64(batch.Size()))
 	return nil
 }
 
@@ -0,0 +1,117 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// logBloom returns the bloom filter of the addresses and the topics of the logs in the receipts
+func logBloom(receipts []*action.Receipt) types.Bloom {
+	var bloom types.Bloom
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			bloom.Add(new(big.Int).SetBytes([]byte(l.Address)))
+			for _, topic := range l.Topics {
+				bloom.Add(new(big.Int).SetBytes(topic[:]))
+			}
+		}
+	}
+	return bloom
+}
+
+// bloomMayMatch returns false if the logs filtered by the address and the topics are surely not in the bloom
+func bloomMayMatch(bloom types.Bloom, topics []hash.Hash256, address string) bool {
+	if address != "" && !bloom.Test(new(big.Int).SetBytes([]byte(address))) {
+		return false
+	}
+	for _, topic := range topics {
+		if topic == hash.ZeroHash256 {
+			continue
+		}
+		if !bloom.Test(new(big.Int).SetBytes(topic[:])) {
+			return false
+		}
+	}
+	return true
+}
+
+// logMatches returns true if the log is of the address and has the topics at their positions. An empty address
+// matches any address, and a zero topic matches any topic at its position
+func logMatches(l *action.Log, topics []hash.Hash256, address string) bool {
+	if address != "" && l.Address != address {
+		return false
+	}
+	if len(topics) > len(l.Topics) {
+		return false
+	}
+	for i, topic := range topics {
+		if topic != hash.ZeroHash256 && topic != l.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterLogs returns the logs of the address with the topics in the blocks from fromHeight to toHeight. An empty
+// address matches any address, and a zero topic matches any topic at its position. The blocks whose log blooms don't
+// contain the address or the topics are skipped without reading their receipts
+func (bc *blockchain) FilterLogs(
+	fromHeight uint64,
+	toHeight uint64,
+	topics []hash.Hash256,
+	address string,
+) ([]*action.Log, error) {
+	if fromHeight > toHeight {
+		return nil, errors.Errorf("from height %d is higher than to height %d", fromHeight, toHeight)
+	}
+	if tip := bc.TipHeight(); toHeight > tip {
+		return nil, errors.Errorf("to height %d is higher than tip height %d", toHeight, tip)
+	}
+	pruned, err := bc.dao.getReceiptsPrunedHeight()
+	if err != nil {
+		return nil, err
+	}
+	if fromHeight <= pruned {
+		return nil, errors.Wrapf(ErrReceiptPruned, "receipts at or below height %d are pruned", pruned)
+	}
+	var logs []*action.Log
+	for height := fromHeight; height <= toHeight; height++ {
+		bloom, err := bc.dao.getLogBloom(height)
+		switch {
+		case err == nil:
+			if !bloomMayMatch(bloom, topics, address) {
+				continue
+			}
+		case errors.Cause(err) != db.ErrNotExist:
+			return nil, err
+		}
+		receipts, err := bc.dao.getReceipts(height)
+		if err != nil {
+			if errors.Cause(err) == db.ErrNotExist {
+				// The block has no receipts
+				continue
+			}
+			return nil, err
+		}
+		for _, receipt := range receipts {
+			for _, l := range receipt.Logs {
+				if logMatches(l, topics, address) {
+					logs = append(logs, l)
+				}
+			}
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+func TestFilterLogs(t *testing.T) {
+	require := require.New(t)
+
+	transfer := hash.Hash256b([]byte("Transfer"))
+	approval := hash.Hash256b([]byte("Approval"))
+	alice := hash.Hash256b([]byte("alice"))
+	bob := hash.Hash256b([]byte("bob"))
+	newLog := func(height uint64, address string, topics ...hash.Hash256) *action.Log {
+		return &action.Log{Address: address, Topics: topics, BlockNumber: height}
+	}
+	logs := map[uint64][]*action.Log{
+		1: {newLog(1, "token", transfer, alice, bob)},
+		2: {},
+		3: {newLog(3, "token", approval, alice), newLog(3, "other", transfer, bob, alice)},
+		5: {newLog(5, "token", transfer, bob, alice)},
+	}
+
+	dao := newBlockDAO(db.NewMemKVStore(), true)
+	for height := uint64(1); height <= 5; height++ {
+		if height == 4 {
+			// Block without receipts
+			continue
+		}
+		receipt := &action.Receipt{
+			ActHash: hash.Hash256b(byteutil.Uint64ToBytes(height)),
+			Logs:    logs[height],
+		}
+		require.NoError(dao.putReceipts(height, []*action.Receipt{receipt}))
+	}
+	bc := &blockchain{dao: dao, tipHeight: 5}
+
+	bloom, err := dao.getLogBloom(1)
+	require.NoError(err)
+	require.True(bloomMayMatch(bloom, []hash.Hash256{transfer, alice}, "token"))
+	require.False(bloomMayMatch(bloom, []hash.Hash256{approval}, ""))
+	require.False(bloomMayMatch(bloom, nil, "other"))
+	_, err = dao.getLogBloom(4)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	res, err := bc.FilterLogs(1, 5, []hash.Hash256{transfer}, "")
+	require.NoError(err)
+	require.Equal([]*action.Log{logs[1][0], logs[3][1], logs[5][0]}, res)
+	res, err = bc.FilterLogs(1, 5, []hash.Hash256{transfer}, "token")
+	require.NoError(err)
+	require.Equal([]*action.Log{logs[1][0], logs[5][0]}, res)
+	// A zero topic matches any topic at its position
+	res, err = bc.FilterLogs(1, 5, []hash.Hash256{hash.ZeroHash256, alice}, "")
+	require.NoError(err)
+	require.Equal([]*action.Log{logs[1][0], logs[3][0]}, res)
+	res, err = bc.FilterLogs(2, 4, nil, "token")
+	require.NoError(err)
+	require.Equal([]*action.Log{logs[3][0]}, res)
+	res, err = bc.FilterLogs(1, 5, []hash.Hash256{transfer, alice, bob, alice}, "")
+	require.NoError(err)
+	require.Empty(res)
+
+	// The blocks committed before the blooms are stored are scanned
+	require.NoError(dao.kvstore.Delete(logBloomNS, byteutil.Uint64ToBytes(5)))
+	res, err = bc.FilterLogs(5, 5, []hash.Hash256{transfer}, "token")
+	require.NoError(err)
+	require.Equal([]*action.Log{logs[5][0]}, res)
+
+	_, err = bc.FilterLogs(3, 2, nil, "")
+	require.Error(err)
+	_, err = bc.FilterLogs(1, 6, nil, "")
+	require.Error(err)
+	require.NoError(dao.pruneReceipts(1))
+	_, err = bc.FilterLogs(1, 5, nil, "")
+	require.Equal(ErrReceiptPruned, errors.Cause(err))
+	_, err = dao.getLogBloom(1)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+	res, err = bc.FilterLogs(2, 5, []hash.Hash256{transfer}, "")
+	require.NoError(err)
+	require.Equal([]*action.Log{logs[3][1], logs[5][0]}, res)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+// mintedCacheSize is the max number of blocks minted at the same height, e.g., in different rounds, whose execution
+// results are cached
+const mintedCacheSize = 8
+
+type (
+	// mintedCache keeps the execution results of the blocks minted at the next height, keyed by block hash, so that a
+	// minted block isn't executed again when it is validated and committed, e.g., once the proposal comes back from
+	// the network as a block without the working set
+	mintedCache struct {
+		mu      sync.Mutex
+		height  uint64
+		results map[hash.Hash256]*mintedResult
+		order   []hash.Hash256
+	}
+
+	mintedResult struct {
+		ws       factory.WorkingSet
+		receipts []*action.Receipt
+	}
+)
+
+func newMintedCache() *mintedCache {
+	return &mintedCache{results: make(map[hash.Hash256]*mintedResult)}
+}
+
+// put caches the execution result of the minted block. The results of the blocks minted at a lower height are
+// dropped, since their working sets are stale once the tip moves
+func (c *mintedCache) put(blk *block.Block, ws factory.WorkingSet, receipts []*action.Receipt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if blk.Height() != c.height {
+		c.reset(blk.Height())
+	}
+	if len(c.order) == mintedCacheSize {
+		delete(c.results, c.order[0])
+		c.order = c.order[1:]
+	}
+	blkHash := blk.HashBlock()
+	c.results[blkHash] = &mintedResult{ws: ws, receipts: receipts}
+	c.order = append(c.order, blkHash)
+}
+
+// take returns the execution result of the block minted with the hash, and removes it from the cache, as a working
+// set can only be committed once
+func (c *mintedCache) take(blk *block.Block) (*mintedResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if blk.Height() != c.height {
+		return nil, false
+	}
+	blkHash := blk.HashBlock()
+	result, ok := c.results[blkHash]
+	if !ok {
+		return nil, false
+	}
+	delete(c.results, blkHash)
+	for i, h := range c.order {
+		if h == blkHash {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return result, true
+}
+
+// clear drops all the cached results, once a block is committed
+func (c *mintedCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reset(0)
+}
+
+func (c *mintedCache) reset(height uint64) {
+	c.height = height
+	c.results = make(map[hash.Hash256]*mintedResult)
+	c.order = nil
+}
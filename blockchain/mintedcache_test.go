@@ -0,0 +1,60 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestMintedCache(t *testing.T) {
+	require := require.New(t)
+	newBlock := func(height uint64, ts int64) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(ts).
+			SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		return &blk
+	}
+
+	c := newMintedCache()
+	blk := newBlock(1, 1)
+	c.put(blk, nil, nil)
+	_, ok := c.take(newBlock(1, 2))
+	require.False(ok)
+	_, ok = c.take(blk)
+	require.True(ok)
+	// The result is taken only once
+	_, ok = c.take(blk)
+	require.False(ok)
+
+	// The results of a lower height are dropped
+	c.put(blk, nil, nil)
+	c.put(newBlock(2, 1), nil, nil)
+	_, ok = c.take(blk)
+	require.False(ok)
+	_, ok = c.take(newBlock(2, 1))
+	require.True(ok)
+
+	// The oldest result is dropped once the cache is full
+	for i := 0; i <= mintedCacheSize; i++ {
+		c.put(newBlock(2, int64(i)), nil, nil)
+	}
+	_, ok = c.take(newBlock(2, 0))
+	require.False(ok)
+	_, ok = c.take(newBlock(2, mintedCacheSize))
+	require.True(ok)
+
+	c.clear()
+	_, ok = c.take(newBlock(2, 1))
+	require.False(ok)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+// rawState is a state already in serialized form
+type rawState []byte
+
+// Serialize returns the raw state as is
+func (s rawState) Serialize() ([]byte, error) { return s, nil }
+
+// genesisPrevHash returns the previous block hash of the genesis block, which is the digest of the state snapshot in
+// case of regenesis
+func (bc *blockchain) genesisPrevHash() hash.Hash256 {
+	if bc.genesisConfig.StateSnapshotPath == "" {
+		return Gen.ParentHash
+	}
+	return bc.genesisConfig.StateDigest()
+}
+
+// createSnapshotStates puts the states in the regenesis state snapshot into the genesis working set. It runs after the
+// protocols are initialized, so that the states carried over from the old chain take precedence
+func (bc *blockchain) createSnapshotStates(ws factory.WorkingSet) error {
+	path := bc.genesisConfig.StateSnapshotPath
+	if path == "" {
+		return nil
+	}
+	snapshot, digest, err := ReadStateSnapshot(path)
+	if err != nil {
+		return err
+	}
+	if digest != bc.genesisConfig.StateDigest() {
+		return errors.Errorf(
+			"digest %x of state snapshot doesn't match the one %s in genesis config",
+			digest,
+			bc.genesisConfig.StateDigestStr,
+		)
+	}
+	if err := putSnapshotStates(ws, snapshot); err != nil {
+		return err
+	}
+	log.L().Info("Loaded the states of regenesis.",
+		zap.Uint64("height", snapshot.Height),
+		zap.Int("accounts", len(snapshot.Accounts)),
+		zap.Int("states", len(snapshot.States)))
+	return nil
+}
+
+// putSnapshotStates puts the accounts and the raw states in the snapshot into the working set
+func putSnapshotStates(ws factory.WorkingSet, snapshot *StateSnapshot) error {
+	for _, s := range snapshot.Accounts {
+		addr, err := address.FromString(s.Address)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode address %s", s.Address)
+		}
+		account, err := s.account()
+		if err != nil {
+			return errors.Wrapf(err, "failed to load account %s", s.Address)
+		}
+		if err := ws.PutState(byteutil.BytesTo20B(addr.Bytes()), account); err != nil {
+			return errors.Wrapf(err, "failed to put account %s", s.Address)
+		}
+	}
+	for _, e := range snapshot.States {
+		key, err := hex.DecodeString(e.Key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode key %s", e.Key)
+		}
+		value, err := hex.DecodeString(e.Value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode value of key %s", e.Key)
+		}
+		if e.Namespace != factory.AccountKVNameSpace {
+			ws.GetCachedBatch().Put(e.Namespace, key, value, "failed to put state %x in namespace %s", key, e.Namespace)
+			continue
+		}
+		if len(key) != len(hash.ZeroHash160) {
+			return errors.Errorf("invalid key %x in namespace %s", key, e.Namespace)
+		}
+		if err := ws.PutState(byteutil.BytesTo20B(key), rawState(value)); err != nil {
+			return errors.Wrapf(err, "failed to put state %x", key)
+		}
+	}
+	return nil
+}
+
+func (s *AccountSnapshot) account() (*state.Account, error) {
+	account := state.EmptyAccount()
+	account.Nonce = s.Nonce
+	if _, ok := account.Balance.SetString(s.Balance, 10); !ok {
+		return nil, errors.Errorf("invalid balance %s", s.Balance)
+	}
+	if s.CodeHash != "" {
+		codeHash, err := hex.DecodeString(s.CodeHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid code hash %s", s.CodeHash)
+		}
+		account.CodeHash = codeHash
+	}
+	if s.Root != "" {
+		root, err := hex.DecodeString(s.Root)
+		if err != nil || len(root) != len(hash.ZeroHash256) {
+			return nil, errors.Errorf("invalid root %s", s.Root)
+		}
+		account.Root = byteutil.BytesTo32B(root)
+	}
+	account.IsCandidate = s.IsCandidate
+	if s.VotingWeight != "" {
+		if _, ok := account.VotingWeight.SetString(s.VotingWeight, 10); !ok {
+			return nil, errors.Errorf("invalid voting weight %s", s.VotingWeight)
+		}
+	}
+	account.Votee = s.Votee
+	return &account, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestBlockchain_Regenesis(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	newChain := func(genesisCfg genesis.Genesis) Blockchain {
+		registry := protocol.Registry{}
+		bc := NewBlockchain(
+			config.Default,
+			InMemStateFactoryOption(),
+			InMemDaoOption(),
+			GenesisOption(genesisCfg),
+			RegistryOption(&registry),
+		)
+		acc := account.NewProtocol()
+		v := vote.NewProtocol(bc)
+		rp := rewarding.NewProtocol()
+		require.NoError(registry.Register(account.ProtocolID, acc))
+		require.NoError(registry.Register(vote.ProtocolID, v))
+		require.NoError(registry.Register(rewarding.ProtocolID, rp))
+		bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.ActionGasLimit))
+		bc.Validator().AddActionValidators(acc, v)
+		bc.GetFactory().AddActionHandlers(acc, v, rp)
+		return bc
+	}
+
+	bc := newChain(genesis.Default)
+	require.NoError(bc.Start(ctx))
+	defer func() { require.NoError(bc.Stop(ctx)) }()
+	require.NoError(addTestingTsfBlocks(bc))
+	snapshot, err := bc.ExportState(ctx, 0)
+	require.NoError(err)
+
+	file, err := ioutil.TempFile("", "state-snapshot")
+	require.NoError(err)
+	require.NoError(file.Close())
+	defer func() { require.NoError(os.Remove(file.Name())) }()
+	digest, err := WriteStateSnapshot(file.Name(), snapshot)
+	require.NoError(err)
+	loaded, loadedDigest, err := ReadStateSnapshot(file.Name())
+	require.NoError(err)
+	require.Equal(digest, loadedDigest)
+	require.Equal(snapshot, loaded)
+
+	// The new chain starts with the balances and nonces of the old chain, and its genesis block refers to the digest
+	genesisCfg := genesis.Default
+	genesisCfg.StateSnapshotPath = file.Name()
+	genesisCfg.StateDigestStr = hex.EncodeToString(digest[:])
+	newBC := newChain(genesisCfg)
+	require.NoError(newBC.Start(ctx))
+	defer func() { require.NoError(newBC.Stop(ctx)) }()
+	require.Equal(uint64(0), newBC.TipHeight())
+	genesisBlk, err := newBC.GetBlockByHeight(0)
+	require.NoError(err)
+	require.Equal(digest, genesisBlk.PrevHash())
+	for _, account := range snapshot.Accounts {
+		balance, err := newBC.Balance(account.Address)
+		require.NoError(err)
+		require.Equal(account.Balance, balance.String())
+		nonce, err := newBC.Nonce(account.Address)
+		require.NoError(err)
+		require.Equal(account.Nonce, nonce)
+	}
+
+	// A digest not matching the snapshot fails the bootstrap
+	genesisCfg.StateDigestStr = hex.EncodeToString(make([]byte, 32))
+	require.Error(newChain(genesisCfg).Start(ctx))
+}
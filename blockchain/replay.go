@@ -0,0 +1,105 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+// ErrReplayMismatch indicates that the replayed states differ from the stored chain
+var ErrReplayMismatch = errors.New("replayed states mismatch")
+
+// Replay re-executes the blocks from genesis to target height against a fresh in-memory state factory, and compares
+// the state root, delta state digest and receipt root of each block with the replayed ones. It stops at the first
+// mismatch, and returns the last height being verified. Target height 0 means the tip height
+func (bc *blockchain) Replay(ctx context.Context, targetHeight uint64) (uint64, error) {
+	tipHeight := bc.TipHeight()
+	if targetHeight == 0 || targetHeight > tipHeight {
+		targetHeight = tipHeight
+	}
+	sf, err := factory.NewFactory(bc.config, factory.InMemTrieOption())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create state factory")
+	}
+	if bc.registry != nil {
+		for _, p := range bc.registry.All() {
+			sf.AddActionHandlers(p)
+		}
+	}
+	if err := sf.Start(ctx); err != nil {
+		return 0, errors.Wrap(err, "failed to start state factory")
+	}
+	defer func() {
+		if err := sf.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop state factory.", zap.Error(err))
+		}
+	}()
+	// The replayer shares everything with the chain except for the state factory
+	replayer := &blockchain{
+		config:        bc.config,
+		genesisConfig: bc.genesisConfig,
+		registry:      bc.registry,
+		sf:            sf,
+	}
+
+	if err := replayer.replayGenesis(); err != nil {
+		return 0, err
+	}
+	for height := uint64(1); height <= targetHeight; height++ {
+		select {
+		case <-ctx.Done():
+			return height - 1, ctx.Err()
+		default:
+		}
+		blk, err := bc.GetBlockByHeight(height)
+		if err != nil {
+			return height - 1, errors.Wrapf(err, "failed to get block %d", height)
+		}
+		if err := replayer.replayBlock(blk); err != nil {
+			return height - 1, err
+		}
+		log.L().Debug("Replayed a block.", zap.Uint64("height", height))
+	}
+	log.L().Info("Replayed the chain.", zap.Uint64("height", targetHeight))
+	return targetHeight, nil
+}
+
+// replayGenesis builds the states of genesis block the same way as starting an empty blockchain
+func (bc *blockchain) replayGenesis() error {
+	if bc.config.Chain.GenesisActionsPath == "" && bc.config.Chain.EmptyGenesis {
+		return nil
+	}
+	return bc.buildStateInGenesis()
+}
+
+func (bc *blockchain) replayBlock(blk *block.Block) error {
+	ws, err := bc.sf.NewWorkingSet()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	root, receipts, err := bc.runActions(blk.RunnableActions(), ws)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run actions of block %d", blk.Height())
+	}
+	if err := blk.VerifyStateRoot(root); err != nil {
+		return errors.Wrapf(ErrReplayMismatch, "block %d: %v", blk.Height(), err)
+	}
+	if err := blk.VerifyDeltaStateDigest(ws.Digest()); err != nil {
+		return errors.Wrapf(ErrReplayMismatch, "block %d: %v", blk.Height(), err)
+	}
+	if err := blk.VerifyReceiptRoot(calculateReceiptRoot(receipts)); err != nil {
+		return errors.Wrapf(ErrReplayMismatch, "block %d: %v", blk.Height(), err)
+	}
+	return bc.sf.Commit(ws)
+}
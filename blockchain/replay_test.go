@@ -0,0 +1,138 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestBlockchain_Replay(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	genesisCfg := genesis.Default
+	registry := protocol.Registry{}
+	bc := NewBlockchain(
+		cfg,
+		InMemStateFactoryOption(),
+		InMemDaoOption(),
+		GenesisOption(genesisCfg),
+		RegistryOption(&registry),
+	)
+	acc := account.NewProtocol()
+	v := vote.NewProtocol(bc)
+	rp := rewarding.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	require.NoError(registry.Register(rewarding.ProtocolID, rp))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.ActionGasLimit))
+	bc.Validator().AddActionValidators(acc, v)
+	bc.GetFactory().AddActionHandlers(acc, v, rp)
+	require.NoError(bc.Start(ctx))
+	defer func() { require.NoError(bc.Stop(ctx)) }()
+	require.NoError(addTestingTsfBlocks(bc))
+	tipHeight := bc.TipHeight()
+	require.True(tipHeight > 2)
+
+	height, err := bc.Replay(ctx, 0)
+	require.NoError(err)
+	require.Equal(tipHeight, height)
+	height, err = bc.Replay(ctx, 2)
+	require.NoError(err)
+	require.Equal(uint64(2), height)
+
+	// Replaying with different genesis states stops at the first block
+	bc.(*blockchain).genesisConfig.Rewarding.InitBalanceStr = "1"
+	height, err = bc.Replay(ctx, 0)
+	require.Equal(ErrReplayMismatch, errors.Cause(err))
+	require.Equal(uint64(0), height)
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = bc.Replay(cancelCtx, 0)
+	require.Error(err)
+}
+
+func TestBlockchain_ExportState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	genesisCfg := genesis.Default
+	registry := protocol.Registry{}
+	bc := NewBlockchain(
+		cfg,
+		InMemStateFactoryOption(),
+		InMemDaoOption(),
+		GenesisOption(genesisCfg),
+		RegistryOption(&registry),
+	)
+	acc := account.NewProtocol()
+	v := vote.NewProtocol(bc)
+	rp := rewarding.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	require.NoError(registry.Register(rewarding.ProtocolID, rp))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.ActionGasLimit))
+	bc.Validator().AddActionValidators(acc, v)
+	bc.GetFactory().AddActionHandlers(acc, v, rp)
+	require.NoError(bc.Start(ctx))
+	defer func() { require.NoError(bc.Stop(ctx)) }()
+	require.NoError(addTestingTsfBlocks(bc))
+	tipHeight := bc.TipHeight()
+
+	snapshot, err := bc.ExportState(ctx, 0)
+	require.NoError(err)
+	require.Equal(tipHeight, snapshot.Height)
+	require.NotEmpty(snapshot.Accounts)
+	require.NotEmpty(snapshot.States)
+	for i, account := range snapshot.Accounts {
+		if i > 0 {
+			require.True(snapshot.Accounts[i-1].Address < account.Address)
+		}
+		balance, err := bc.Balance(account.Address)
+		require.NoError(err)
+		require.Equal(balance.String(), account.Balance)
+		nonce, err := bc.Nonce(account.Address)
+		require.NoError(err)
+		require.Equal(nonce, account.Nonce)
+		state, err := bc.StateByAddr(account.Address)
+		require.NoError(err)
+		require.Equal(state.IsCandidate, account.IsCandidate)
+		require.Equal(state.VotingWeight.String(), account.VotingWeight)
+		require.Equal(state.Votee, account.Votee)
+	}
+	digest, err := snapshot.Digest()
+	require.NoError(err)
+
+	// Exporting again yields the same digest regardless of the undo logs kept, while exporting at a lower height
+	// doesn't
+	bc.(*blockchain).config.BlockSync.MaxReorgDepth = 2
+	snapshot, err = bc.ExportState(ctx, tipHeight)
+	require.NoError(err)
+	digest2, err := snapshot.Digest()
+	require.NoError(err)
+	require.Equal(digest, digest2)
+	snapshot, err = bc.ExportState(ctx, 2)
+	require.NoError(err)
+	require.Equal(uint64(2), snapshot.Height)
+	digest2, err = snapshot.Digest()
+	require.NoError(err)
+	require.NotEqual(digest, digest2)
+}
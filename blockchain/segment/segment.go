@@ -0,0 +1,275 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package segment
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// A segment starts with the magic bytes, followed by a sequence of records of ascending heights. Each record is a
+// serialized block prefixed by its length, and followed by the CRC32 checksum of the bytes, all the integers in big
+// endian. The segment ends with a record of length 0 and the number of the blocks, so that a truncated segment is
+// detected
+var magic = []byte("IOTXBLKS")
+
+const (
+	// maxBlockSize is the max size in bytes of a serialized block in a segment, which bounds the memory allocated for
+	// a block read from an untrusted source
+	maxBlockSize = 32 << 20
+	// importBatchSize is the number of the blocks committed in a batch by ImportStream
+	importBatchSize = 100
+)
+
+var (
+	// ErrInvalidSegment indicates that the segment is corrupted or truncated
+	ErrInvalidSegment = errors.New("invalid segment")
+
+	crc32Table = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// FileName returns the name of the segment file containing the blocks within [start, end]
+func FileName(start uint64, end uint64) string {
+	return fmt.Sprintf("blocks-%012d-%012d.seg", start, end)
+}
+
+// Export writes the blocks within [start, end] into segment files under dir, each of which contains at most
+// blocksPerSegment blocks. It returns the paths of the segment files in ascending order of heights
+func Export(bc blockchain.Blockchain, dir string, start uint64, end uint64, blocksPerSegment uint64) ([]string, error) {
+	if start == 0 || start > end {
+		return nil, errors.Errorf("invalid block range [%d, %d]", start, end)
+	}
+	if end > bc.TipHeight() {
+		return nil, errors.Errorf("end height %d is greater than tip height %d", end, bc.TipHeight())
+	}
+	if blocksPerSegment == 0 {
+		return nil, errors.New("number of blocks per segment must be greater than 0")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory %s", dir)
+	}
+	var paths []string
+	for segStart := start; segStart <= end; segStart += blocksPerSegment {
+		segEnd := segStart + blocksPerSegment - 1
+		if segEnd > end {
+			segEnd = end
+		}
+		path := filepath.Join(dir, FileName(segStart, segEnd))
+		if err := writeSegment(bc, path, segStart, segEnd); err != nil {
+			return nil, err
+		}
+		log.L().Info("Exported blocks.",
+			zap.Uint64("start", segStart),
+			zap.Uint64("end", segEnd),
+			zap.String("file", path))
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Import reads the blocks from the segment files in the given order, and hands each block above the tip height to
+// commit, which is expected to fully validate the block before committing it. The blocks must be contiguous
+func Import(paths []string, tipHeight uint64, commit func(*block.Block) error) error {
+	for _, path := range paths {
+		blks, err := ReadSegment(path)
+		if err != nil {
+			return err
+		}
+		for _, blk := range blks {
+			if blk.Height() <= tipHeight {
+				continue
+			}
+			if blk.Height() != tipHeight+1 {
+				return errors.Errorf("block %d in %s isn't next to tip height %d", blk.Height(), path, tipHeight)
+			}
+			if err := commit(blk); err != nil {
+				return errors.Wrapf(err, "failed to commit block %d in %s", blk.Height(), path)
+			}
+			tipHeight = blk.Height()
+		}
+		log.L().Info("Imported blocks.", zap.String("file", path), zap.Uint64("tipHeight", tipHeight))
+	}
+	return nil
+}
+
+// ImportStream reads the blocks from the segment, and commits the ones above the tip height of the blockchain in
+// batches. Each block is validated before being committed, and validateFooter validates its consensus footer if not
+// nil. The blocks at or below the tip height are skipped, as long as they are the same as the ones on the chain
+func ImportStream(r io.Reader, bc blockchain.Blockchain, validateFooter func(*block.Block) error) error {
+	batch := make([]*block.Block, 0, importBatchSize)
+	commit := func() error {
+		for len(batch) > 0 {
+			n, err := bc.CommitBlocks(batch, validateFooter)
+			if err != nil {
+				return errors.Wrapf(err, "failed to commit block %d", batch[n].Height())
+			}
+			batch = batch[n:]
+		}
+		return nil
+	}
+	tipHeight := bc.TipHeight()
+	if err := Read(r, func(blk *block.Block) error {
+		if blk.Height() <= tipHeight {
+			h, err := bc.GetHashByHeight(blk.Height())
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the hash of block %d", blk.Height())
+			}
+			if h != blk.HashBlock() {
+				return errors.Errorf("block %d diverges from the chain", blk.Height())
+			}
+			return nil
+		}
+		if blk.Height() != tipHeight+1 {
+			return errors.Errorf("block %d isn't next to height %d", blk.Height(), tipHeight)
+		}
+		batch = append(batch, blk)
+		tipHeight = blk.Height()
+		if len(batch) < importBatchSize {
+			return nil
+		}
+		return commit()
+	}); err != nil {
+		return err
+	}
+	return commit()
+}
+
+// ReadSegment reads all the blocks in a segment file
+func ReadSegment(path string) ([]*block.Block, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+
+	var blks []*block.Block
+	if err := Read(f, func(blk *block.Block) error {
+		blks = append(blks, blk)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return blks, nil
+}
+
+// Read reads the blocks from the segment, and hands each of them to fn in order
+func Read(r io.Reader, fn func(*block.Block) error) error {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, header); err != nil || !bytes.Equal(header, magic) {
+		return errors.Wrap(ErrInvalidSegment, "failed to read the magic bytes")
+	}
+	var count uint64
+	for {
+		var size uint32
+		if err := binary.Read(br, binary.BigEndian, &size); err != nil {
+			return errors.Wrapf(ErrInvalidSegment, "failed to read the size of record %d", count)
+		}
+		if size == 0 {
+			break
+		}
+		if size > maxBlockSize {
+			return errors.Wrapf(ErrInvalidSegment, "size %d of record %d exceeds the limit %d", size, count, maxBlockSize)
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return errors.Wrapf(ErrInvalidSegment, "failed to read record %d", count)
+		}
+		var checksum uint32
+		if err := binary.Read(br, binary.BigEndian, &checksum); err != nil {
+			return errors.Wrapf(ErrInvalidSegment, "failed to read the checksum of record %d", count)
+		}
+		if checksum != crc32.Checksum(buf, crc32Table) {
+			return errors.Wrapf(ErrInvalidSegment, "wrong checksum of record %d", count)
+		}
+		blk := &block.Block{}
+		if err := blk.Deserialize(buf); err != nil {
+			return errors.Wrapf(err, "failed to deserialize record %d", count)
+		}
+		if err := fn(blk); err != nil {
+			return err
+		}
+		count++
+	}
+	var expected uint64
+	if err := binary.Read(br, binary.BigEndian, &expected); err != nil {
+		return errors.Wrap(ErrInvalidSegment, "failed to read the number of the blocks")
+	}
+	if expected != count {
+		return errors.Wrapf(ErrInvalidSegment, "read %d blocks, expecting %d", count, expected)
+	}
+	return nil
+}
+
+// Write writes the blocks within [start, end] into a segment
+func Write(w io.Writer, bc blockchain.Blockchain, start uint64, end uint64) error {
+	if start == 0 || start > end {
+		return errors.Errorf("invalid block range [%d, %d]", start, end)
+	}
+	if tipHeight := bc.TipHeight(); end > tipHeight {
+		return errors.Errorf("end height %d is greater than tip height %d", end, tipHeight)
+	}
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(magic); err != nil {
+		return err
+	}
+	for height := start; height <= end; height++ {
+		blk, err := bc.GetBlockByHeight(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block %d", height)
+		}
+		buf, err := blk.Serialize()
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize block %d", height)
+		}
+		if len(buf) > maxBlockSize {
+			return errors.Errorf("size %d of block %d exceeds the limit %d", len(buf), height, maxBlockSize)
+		}
+		if err := binary.Write(bw, binary.BigEndian, uint32(len(buf))); err != nil {
+			return err
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+		if err := binary.Write(bw, binary.BigEndian, crc32.Checksum(buf, crc32Table)); err != nil {
+			return err
+		}
+	}
+	if err := binary.Write(bw, binary.BigEndian, uint32(0)); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, end-start+1); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeSegment(bc blockchain.Blockchain, path string, start uint64, end uint64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", path)
+	}
+	defer f.Close()
+
+	if err := Write(f, bc, start, end); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package segment
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func newTestChain(t *testing.T) blockchain.Blockchain {
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesis.Default),
+	)
+	require.NotNil(t, bc)
+	bc.Validator().AddActionValidators(account.NewProtocol())
+	require.NoError(t, bc.Start(context.Background()))
+	return bc
+}
+
+func mintTestBlocks(t *testing.T, bc blockchain.Blockchain, n int) {
+	for i := 0; i < n; i++ {
+		blk, err := bc.MintNewBlock(
+			nil,
+			ta.Keyinfo["producer"].PubKey,
+			ta.Keyinfo["producer"].PriKey,
+			ta.Addrinfo["producer"].String(),
+			0,
+		)
+		require.NoError(t, err)
+		require.NoError(t, bc.ValidateBlock(blk))
+		require.NoError(t, bc.CommitBlock(blk))
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "segment")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	bc1 := newTestChain(t)
+	defer func() { require.NoError(bc1.Stop(context.Background())) }()
+	mintTestBlocks(t, bc1, 5)
+
+	_, err = Export(bc1, dir, 0, 3, 2)
+	require.Error(err)
+	_, err = Export(bc1, dir, 1, 6, 2)
+	require.Error(err)
+	_, err = Export(bc1, dir, 1, 3, 0)
+	require.Error(err)
+
+	paths, err := Export(bc1, dir, 1, 5, 2)
+	require.NoError(err)
+	require.Equal([]string{
+		filepath.Join(dir, FileName(1, 2)),
+		filepath.Join(dir, FileName(3, 4)),
+		filepath.Join(dir, FileName(5, 5)),
+	}, paths)
+	blks, err := ReadSegment(paths[1])
+	require.NoError(err)
+	require.Equal(2, len(blks))
+	require.Equal(uint64(3), blks[0].Height())
+	require.Equal(uint64(4), blks[1].Height())
+
+	bc2 := newTestChain(t)
+	defer func() { require.NoError(bc2.Stop(context.Background())) }()
+	commit := func(blk *block.Block) error {
+		if err := bc2.ValidateBlock(blk); err != nil {
+			return err
+		}
+		return bc2.CommitBlock(blk)
+	}
+	// The blocks must be contiguous to the tip
+	require.Error(Import(paths[1:], bc2.TipHeight(), commit))
+	require.NoError(Import(paths[:2], bc2.TipHeight(), commit))
+	require.Equal(uint64(4), bc2.TipHeight())
+	// The blocks at or below the tip height are skipped
+	require.NoError(Import(paths, bc2.TipHeight(), commit))
+	require.Equal(uint64(5), bc2.TipHeight())
+	for height := uint64(1); height <= 5; height++ {
+		h1, err := bc1.GetHashByHeight(height)
+		require.NoError(err)
+		h2, err := bc2.GetHashByHeight(height)
+		require.NoError(err)
+		require.Equal(h1, h2)
+	}
+
+	// A truncated segment file
+	data, err := ioutil.ReadFile(paths[0])
+	require.NoError(err)
+	truncated := filepath.Join(dir, "truncated.seg")
+	require.NoError(ioutil.WriteFile(truncated, data[:len(data)-1], 0644))
+	_, err = ReadSegment(truncated)
+	require.Error(err)
+
+	// Not a segment file
+	invalid := filepath.Join(dir, "invalid.seg")
+	require.NoError(ioutil.WriteFile(invalid, []byte("invalid"), 0644))
+	_, err = ReadSegment(invalid)
+	require.Error(err)
+
+	// A block size beyond the limit is rejected before the block is read
+	oversized := filepath.Join(dir, "oversized.seg")
+	require.NoError(ioutil.WriteFile(oversized, append(append([]byte{}, magic...), 0xff, 0xff, 0xff, 0xff), 0644))
+	_, err = ReadSegment(oversized)
+	require.Equal(ErrInvalidSegment, errors.Cause(err))
+}
+
+func TestImportStream(t *testing.T) {
+	require := require.New(t)
+
+	bc1 := newTestChain(t)
+	defer func() { require.NoError(bc1.Stop(context.Background())) }()
+	mintTestBlocks(t, bc1, 5)
+
+	var buf bytes.Buffer
+	require.Error(Write(&buf, bc1, 0, 5))
+	require.Error(Write(&buf, bc1, 1, 6))
+	require.NoError(Write(&buf, bc1, 1, 5))
+	seg := buf.Bytes()
+
+	// The block whose footer is rejected isn't committed, along with the ones after it
+	bc2 := newTestChain(t)
+	defer func() { require.NoError(bc2.Stop(context.Background())) }()
+	errFooter := errors.New("invalid footer")
+	err := ImportStream(bytes.NewReader(seg), bc2, func(blk *block.Block) error {
+		if blk.Height() == 3 {
+			return errFooter
+		}
+		return nil
+	})
+	require.Equal(errFooter, errors.Cause(err))
+	require.Equal(uint64(2), bc2.TipHeight())
+
+	// The blocks at or below the tip height are skipped
+	require.NoError(ImportStream(bytes.NewReader(seg), bc2, nil))
+	require.Equal(uint64(5), bc2.TipHeight())
+	for height := uint64(1); height <= 5; height++ {
+		h1, err := bc1.GetHashByHeight(height)
+		require.NoError(err)
+		h2, err := bc2.GetHashByHeight(height)
+		require.NoError(err)
+		require.Equal(h1, h2)
+	}
+
+	readAll := func(data []byte) error {
+		return Read(bytes.NewReader(data), func(*block.Block) error { return nil })
+	}
+	require.NoError(readAll(seg))
+	// A truncated segment
+	require.Equal(ErrInvalidSegment, errors.Cause(readAll(seg[:len(seg)-1])))
+	require.Equal(ErrInvalidSegment, errors.Cause(readAll(seg[:len(seg)-12])))
+	// A corrupted block
+	corrupted := append([]byte{}, seg...)
+	corrupted[len(magic)+4+10] ^= 0xff
+	require.Equal(ErrInvalidSegment, errors.Cause(readAll(corrupted)))
+	// A block size over the limit
+	oversized := append([]byte{}, seg...)
+	binary.BigEndian.PutUint32(oversized[len(magic):], maxBlockSize+1)
+	require.Equal(ErrInvalidSegment, errors.Cause(readAll(oversized)))
+}
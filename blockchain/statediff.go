@@ -0,0 +1,198 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+type (
+	// StateDiff is the changes of the states made by each action of a block, whose state root or delta state digest
+	// doesn't match the local execution. The states are compared with the state diffs of the same block dumped by
+	// other nodes to find out the action diverging
+	StateDiff struct {
+		Height    uint64 `json:"height"`
+		BlockHash string `json:"blockHash"`
+		// Error is the mismatch found when validating the block
+		Error string `json:"error"`
+		// ExpectedStateRoot and ExpectedDeltaStateDigest are in the header, while StateRoot and DeltaStateDigest are
+		// of the local execution
+		ExpectedStateRoot        string             `json:"expectedStateRoot"`
+		StateRoot                string             `json:"stateRoot"`
+		ExpectedDeltaStateDigest string             `json:"expectedDeltaStateDigest"`
+		DeltaStateDigest         string             `json:"deltaStateDigest"`
+		Actions                  []*ActionStateDiff `json:"actions"`
+	}
+
+	// ActionStateDiff is the changes of the states made by an action in a state diff
+	ActionStateDiff struct {
+		ActionHash string         `json:"actionHash"`
+		Changes    []*StateChange `json:"changes"`
+	}
+
+	// StateChange is the change of a state in a state diff, with the key and the values hex encoded. Address is set if
+	// the state is the account of the producer, or a sender or recipient of the actions in the block. Before or After
+	// is empty if the state doesn't exist
+	StateChange struct {
+		Key     string `json:"key"`
+		Address string `json:"address,omitempty"`
+		Before  string `json:"before,omitempty"`
+		After   string `json:"after,omitempty"`
+	}
+)
+
+// StateDiffPath returns the path of the file the state diff of the block at the height is dumped to
+func StateDiffPath(dir string, height uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("statediff-%d.json", height))
+}
+
+// ReadStateDiff reads the state diff from a JSON file
+func ReadStateDiff(path string) (*StateDiff, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read state diff file %s", path)
+	}
+	var diff StateDiff
+	if err := json.Unmarshal(data, &diff); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal state diff file %s", path)
+	}
+	return &diff, nil
+}
+
+// StateDiff returns the state diff dumped for the block at the height, whose states don't match the local execution
+func (bc *blockchain) StateDiff(height uint64) (*StateDiff, error) {
+	dir := bc.config.Chain.StateDiffDir
+	if dir == "" {
+		return nil, errors.New("state diff isn't enabled")
+	}
+	return ReadStateDiff(StateDiffPath(dir, height))
+}
+
+// stateDiffSnapshot takes the snapshot of the working set before running the actions of a block, if the state diff
+// is enabled, so that the actions could be run again to dump the state diff on mismatch
+func (bc *blockchain) stateDiffSnapshot(ws factory.WorkingSet) int {
+	if bc.config.Chain.StateDiffDir == "" {
+		return -1
+	}
+	return ws.Snapshot()
+}
+
+// verifyStates verifies the state root and the delta state digest of the working set against the header, and dumps
+// the state diff of the block on mismatch if the snapshot has been taken
+func (bc *blockchain) verifyStates(blk *block.Block, root hash.Hash256, ws factory.WorkingSet, snapshot int) error {
+	err := blk.VerifyStateRoot(root)
+	if err == nil {
+		err = blk.VerifyDeltaStateDigest(ws.Digest())
+	}
+	if err != nil && snapshot >= 0 {
+		if dErr := bc.dumpStateDiff(blk, ws, snapshot, err); dErr != nil {
+			log.L().Error("Failed to dump state diff.", zap.Error(dErr), zap.Uint64("height", blk.Height()))
+		}
+	}
+	return err
+}
+
+// dumpStateDiff reverts the working set to the snapshot, runs the actions of the block again while recording the
+// changes of the states, and writes the state diff into the directory. The block is rejected anyway, so the working
+// set is left with the changes
+func (bc *blockchain) dumpStateDiff(blk *block.Block, ws factory.WorkingSet, snapshot int, mismatch error) error {
+	if err := ws.Revert(snapshot); err != nil {
+		return errors.Wrap(err, "failed to revert working set")
+	}
+	acts := blk.RunnableActions()
+	ctx, err := bc.runActionsCtx(acts)
+	if err != nil {
+		return err
+	}
+	root, actionDiffs, err := factory.RunActionsWithDiffs(ctx, ws, acts.BlockHeight(), acts.Actions())
+	if err != nil {
+		return errors.Wrapf(err, "failed to run the actions of block %d", blk.Height())
+	}
+	blkHash := blk.HashBlock()
+	expectedRoot := blk.StateRoot()
+	expectedDigest := blk.DeltaStateDigest()
+	digest := ws.Digest()
+	diff := &StateDiff{
+		Height:                   blk.Height(),
+		BlockHash:                hex.EncodeToString(blkHash[:]),
+		Error:                    mismatch.Error(),
+		ExpectedStateRoot:        hex.EncodeToString(expectedRoot[:]),
+		StateRoot:                hex.EncodeToString(root[:]),
+		ExpectedDeltaStateDigest: hex.EncodeToString(expectedDigest[:]),
+		DeltaStateDigest:         hex.EncodeToString(digest[:]),
+		Actions:                  make([]*ActionStateDiff, 0, len(actionDiffs)),
+	}
+	addrs := blockAddrs(blk)
+	for _, actionDiff := range actionDiffs {
+		d := &ActionStateDiff{
+			ActionHash: hex.EncodeToString(actionDiff.ActionHash[:]),
+			Changes:    make([]*StateChange, 0, len(actionDiff.Changes)),
+		}
+		for _, change := range actionDiff.Changes {
+			d.Changes = append(d.Changes, &StateChange{
+				Key:     hex.EncodeToString(change.Key[:]),
+				Address: addrs[change.Key],
+				Before:  hex.EncodeToString(change.Before),
+				After:   hex.EncodeToString(change.After),
+			})
+		}
+		diff.Actions = append(diff.Actions, d)
+	}
+
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal state diff")
+	}
+	dir := bc.config.Chain.StateDiffDir
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create state diff directory %s", dir)
+	}
+	path := StateDiffPath(dir, blk.Height())
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write state diff file %s", path)
+	}
+	blk.HeaderLogger(log.L()).Warn("Dumped the state diff of the block.", zap.String("path", path))
+	return nil
+}
+
+// blockAddrs returns the addresses of the producer, and the senders and the recipients of the actions in the block,
+// keyed by their account state keys
+func blockAddrs(blk *block.Block) map[hash.Hash160]string {
+	addrs := make(map[hash.Hash160]string)
+	add := func(encoded string) {
+		if addr, err := address.FromString(encoded); err == nil {
+			addrs[byteutil.BytesTo20B(addr.Bytes())] = encoded
+		}
+	}
+	add(blk.ProducerAddress())
+	for _, selp := range blk.Actions {
+		pkHash := keypair.HashPubKey(selp.SrcPubkey())
+		if src, err := address.FromBytes(pkHash[:]); err == nil {
+			addrs[pkHash] = src.String()
+		}
+		if dst, ok := selp.Destination(); ok {
+			add(dst)
+		}
+	}
+	return addrs
+}
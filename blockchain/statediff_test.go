@@ -0,0 +1,119 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestBlockchain_StateDiff(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "statediff")
+	require.NoError(err)
+	defer func() {
+		require.NoError(os.RemoveAll(dir))
+	}()
+	cfg := config.Default
+	genesisConfig := genesis.Default
+
+	newChain := func(cfg config.Config) (Blockchain, factory.Factory) {
+		sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+		require.NoError(err)
+		sf.AddActionHandlers(account.NewProtocol())
+		bc := NewBlockchain(cfg, PrecreatedStateFactoryOption(sf), InMemDaoOption(), GenesisOption(genesisConfig))
+		bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisConfig.Blockchain.ActionGasLimit))
+		bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc))
+		sf.AddActionHandlers(vote.NewProtocol(bc))
+		require.NoError(bc.Start(ctx))
+		require.NoError(addCreatorToFactory(sf))
+		return bc, sf
+	}
+
+	src, _ := newChain(cfg)
+	defer func() {
+		require.NoError(src.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(src))
+	blk, err := src.GetBlockByHeight(1)
+	require.NoError(err)
+
+	diffCfg := cfg
+	diffCfg.Chain.StateDiffDir = dir
+	bc, sf := newChain(diffCfg)
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	_, err = bc.StateDiff(1)
+	require.Error(err)
+
+	// The balance of the producer diverges from the source chain
+	producer := ta.Addrinfo["producer"]
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	acct, err := util.LoadAccount(ws, byteutil.BytesTo20B(producer.Bytes()))
+	require.NoError(err)
+	acct.Balance.Add(acct.Balance, big.NewInt(1))
+	require.NoError(ws.PutState(byteutil.BytesTo20B(producer.Bytes()), acct))
+	gasLimit := testutil.TestGasLimit
+	_, _, err = ws.RunActions(protocol.WithRunActionsCtx(ctx, protocol.RunActionsCtx{
+		Producer: producer,
+		GasLimit: &gasLimit,
+	}), 0, nil)
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+
+	require.Error(bc.ValidateBlock(blk))
+	require.Equal(uint64(0), bc.TipHeight())
+	_, err = os.Stat(StateDiffPath(dir, 1))
+	require.NoError(err)
+
+	diff, err := bc.StateDiff(1)
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	expectedRoot := blk.StateRoot()
+	require.Equal(uint64(1), diff.Height)
+	require.Equal(hex.EncodeToString(blkHash[:]), diff.BlockHash)
+	require.Equal(hex.EncodeToString(expectedRoot[:]), diff.ExpectedStateRoot)
+	require.NotEqual(diff.ExpectedStateRoot, diff.StateRoot)
+	require.NotEmpty(diff.Error)
+	require.Equal(len(blk.Actions), len(diff.Actions))
+	producerChanged := false
+	for i, actionDiff := range diff.Actions {
+		h := blk.Actions[i].Hash()
+		require.Equal(hex.EncodeToString(h[:]), actionDiff.ActionHash)
+		for _, change := range actionDiff.Changes {
+			require.NotEqual(change.Before, change.After)
+			if change.Address == producer.String() {
+				require.Equal(hex.EncodeToString(producer.Bytes()), change.Key)
+				producerChanged = true
+			}
+		}
+	}
+	require.True(producerChanged)
+
+	_, err = bc.StateDiff(2)
+	require.Error(err)
+}
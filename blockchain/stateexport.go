@@ -0,0 +1,345 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+type (
+	// StateSnapshot is the canonical form of the full states at a height. Accounts are sorted by address, and the rest
+	// of the states, including contract code and storage and the protocol states, are sorted by namespace and key. The
+	// bookkeeping records of the state db, which depend on the node config, are excluded, so that the snapshot only
+	// depends on the blocks
+	StateSnapshot struct {
+		Height   uint64             `json:"height"`
+		Accounts []*AccountSnapshot `json:"accounts"`
+		States   []*StateEntry      `json:"states"`
+	}
+
+	// AccountSnapshot is the state of an account in a state snapshot
+	AccountSnapshot struct {
+		Address  string `json:"address"`
+		Balance  string `json:"balance"`
+		Nonce    uint64 `json:"nonce"`
+		CodeHash string `json:"codeHash,omitempty"`
+		Root     string `json:"root,omitempty"`
+		// IsCandidate, VotingWeight and Votee are the vote states of the account, which are always present
+		IsCandidate  bool   `json:"isCandidate"`
+		VotingWeight string `json:"votingWeight"`
+		Votee        string `json:"votee"`
+	}
+
+	// StateEntry is a raw state record in a state snapshot, with the key and value hex encoded
+	StateEntry struct {
+		Namespace string `json:"namespace"`
+		Key       string `json:"key"`
+		Value     string `json:"value"`
+	}
+
+	// stateSnapshotFile is the JSON file format of a state snapshot
+	stateSnapshotFile struct {
+		Digest   string         `json:"digest"`
+		Snapshot *StateSnapshot `json:"snapshot"`
+	}
+
+	// recordingKVStore keeps all the records committed into the underlying KV store, which cannot be iterated
+	recordingKVStore struct {
+		db.KVStore
+		mutex   sync.Mutex
+		records map[string]map[string][]byte
+	}
+)
+
+// Digest returns the hash of the JSON encoding of the state snapshot
+func (s *StateSnapshot) Digest() (hash.Hash256, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return hash.ZeroHash256, errors.Wrap(err, "failed to marshal state snapshot")
+	}
+	return hash.Hash256b(data), nil
+}
+
+// WriteStateSnapshot writes the state snapshot together with its digest into a JSON file, and returns the digest
+func WriteStateSnapshot(path string, snapshot *StateSnapshot) (hash.Hash256, error) {
+	digest, err := snapshot.Digest()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	data, err := json.MarshalIndent(&stateSnapshotFile{
+		Digest:   hex.EncodeToString(digest[:]),
+		Snapshot: snapshot,
+	}, "", "  ")
+	if err != nil {
+		return hash.ZeroHash256, errors.Wrap(err, "failed to marshal state snapshot file")
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return hash.ZeroHash256, errors.Wrapf(err, "failed to write state snapshot file %s", path)
+	}
+	return digest, nil
+}
+
+// ReadStateSnapshot reads the state snapshot from a JSON file, and checks it against the digest in the file
+func ReadStateSnapshot(path string) (*StateSnapshot, hash.Hash256, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, hash.ZeroHash256, errors.Wrapf(err, "failed to read state snapshot file %s", path)
+	}
+	var file stateSnapshotFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, hash.ZeroHash256, errors.Wrapf(err, "failed to unmarshal state snapshot file %s", path)
+	}
+	if file.Snapshot == nil {
+		return nil, hash.ZeroHash256, errors.Errorf("state snapshot file %s has no snapshot", path)
+	}
+	digest, err := file.Snapshot.Digest()
+	if err != nil {
+		return nil, hash.ZeroHash256, err
+	}
+	if hex.EncodeToString(digest[:]) != file.Digest {
+		return nil, hash.ZeroHash256, errors.Errorf(
+			"digest %x of state snapshot file %s doesn't match the recorded one %s",
+			digest,
+			path,
+			file.Digest,
+		)
+	}
+	return file.Snapshot, digest, nil
+}
+
+// ExportState re-executes the blocks from genesis to target height against a fresh in-memory state db, and returns
+// the canonical snapshot of the states at target height. Target height 0 means the tip height
+func (bc *blockchain) ExportState(ctx context.Context, targetHeight uint64) (*StateSnapshot, error) {
+	tipHeight := bc.TipHeight()
+	if targetHeight == 0 || targetHeight > tipHeight {
+		targetHeight = tipHeight
+	}
+	kv := &recordingKVStore{KVStore: db.NewMemKVStore(), records: make(map[string]map[string][]byte)}
+	sf, err := factory.NewStateDB(bc.config, factory.PrecreatedStateDBOption(kv))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create state db")
+	}
+	if bc.registry != nil {
+		for _, p := range bc.registry.All() {
+			sf.AddActionHandlers(p)
+		}
+	}
+	if err := sf.Start(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to start state db")
+	}
+	defer func() {
+		if err := sf.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop state db.", zap.Error(err))
+		}
+	}()
+	exporter := &blockchain{
+		config:        bc.config,
+		genesisConfig: bc.genesisConfig,
+		registry:      bc.registry,
+		sf:            sf,
+	}
+
+	addrs := bc.genesisAddrs()
+	if err := exporter.replayGenesis(); err != nil {
+		return nil, err
+	}
+	for height := uint64(1); height <= targetHeight; height++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		blk, err := bc.GetBlockByHeight(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get block %d", height)
+		}
+		receipts, err := exporter.exportBlock(blk)
+		if err != nil {
+			return nil, err
+		}
+		addrs[blk.ProducerAddress()] = struct{}{}
+		for _, selp := range blk.Actions {
+			pkHash := keypair.HashPubKey(selp.SrcPubkey())
+			if src, err := address.FromBytes(pkHash[:]); err == nil {
+				addrs[src.String()] = struct{}{}
+			}
+			if dst, ok := selp.Destination(); ok {
+				addrs[dst] = struct{}{}
+			}
+		}
+		for _, r := range receipts {
+			if r.ContractAddress != "" {
+				addrs[r.ContractAddress] = struct{}{}
+			}
+		}
+	}
+	snapshot, err := kv.snapshot(addrs)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Height = targetHeight
+	log.L().Info("Exported the states.", zap.Uint64("height", targetHeight), zap.Int("accounts", len(snapshot.Accounts)))
+	return snapshot, nil
+}
+
+// genesisAddrs returns the addresses whose accounts are created in the genesis states
+func (bc *blockchain) genesisAddrs() map[string]struct{} {
+	addrs := make(map[string]struct{})
+	if bc.config.Chain.GenesisActionsPath == "" && bc.config.Chain.EmptyGenesis {
+		return addrs
+	}
+	actions := loadGenesisData(bc.config.Chain)
+	for _, transfer := range actions.Transfers {
+		rpk, _ := decodeKey(transfer.RecipientPK, "")
+		addrs[generateAddr(rpk)] = struct{}{}
+	}
+	for _, nominator := range actions.SelfNominators {
+		pk, _ := decodeKey(nominator.PubKey, "")
+		addrs[generateAddr(pk)] = struct{}{}
+	}
+	pk, _ := decodeKey(actions.Creation.PubKey, "")
+	addrs[generateAddr(pk)] = struct{}{}
+	if bc.genesisConfig.EnableGovernance {
+		addrs[bc.genesisConfig.InitGovernanceAdminAddrStr] = struct{}{}
+	}
+	return addrs
+}
+
+func (bc *blockchain) exportBlock(blk *block.Block) ([]*action.Receipt, error) {
+	ws, err := bc.sf.NewWorkingSet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain working set from state db")
+	}
+	_, receipts, err := bc.runActions(blk.RunnableActions(), ws)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run actions of block %d", blk.Height())
+	}
+	if err := blk.VerifyReceiptRoot(calculateReceiptRoot(receipts)); err != nil {
+		return nil, errors.Wrapf(ErrReplayMismatch, "block %d: %v", blk.Height(), err)
+	}
+	return receipts, bc.sf.Commit(ws)
+}
+
+// Commit records the entries of the batch before committing it into the underlying KV store
+func (kv *recordingKVStore) Commit(b db.KVStoreBatch) error {
+	kv.mutex.Lock()
+	defer kv.mutex.Unlock()
+	b.Lock()
+	for i := 0; i < b.Size(); i++ {
+		write, err := b.Entry(i)
+		if err != nil {
+			b.Unlock()
+			return err
+		}
+		ns, ok := kv.records[write.Namespace()]
+		if !ok {
+			ns = make(map[string][]byte)
+			kv.records[write.Namespace()] = ns
+		}
+		if write.WriteType() == db.Delete {
+			delete(ns, string(write.Key()))
+		} else {
+			ns[string(write.Key())] = write.Value()
+		}
+	}
+	b.Unlock()
+	return kv.KVStore.Commit(b)
+}
+
+// snapshot converts the recorded entries into a state snapshot, where the account states of the given addresses are
+// decoded, and the bookkeeping entries of the state db are skipped
+func (kv *recordingKVStore) snapshot(addrs map[string]struct{}) (*StateSnapshot, error) {
+	kv.mutex.Lock()
+	defer kv.mutex.Unlock()
+	accountKeys := make(map[string]string)
+	for encoded := range addrs {
+		addr, err := address.FromString(encoded)
+		if err != nil {
+			continue
+		}
+		accountKeys[string(addr.Bytes())] = encoded
+	}
+	snapshot := &StateSnapshot{
+		Accounts: []*AccountSnapshot{},
+		States:   []*StateEntry{},
+	}
+	for ns, records := range kv.records {
+		// The undo logs are kept for the number of heights in the config
+		if ns == factory.UndoKVNameSpace {
+			continue
+		}
+		for key, value := range records {
+			if ns == factory.AccountKVNameSpace {
+				if key == factory.CurrentHeightKey || key == factory.StateGrowthKey {
+					continue
+				}
+				if encoded, ok := accountKeys[key]; ok {
+					var account state.Account
+					if err := account.Deserialize(value); err != nil {
+						return nil, errors.Wrapf(err, "failed to deserialize account %s", encoded)
+					}
+					snapshot.Accounts = append(snapshot.Accounts, newAccountSnapshot(encoded, &account))
+					continue
+				}
+			}
+			snapshot.States = append(snapshot.States, &StateEntry{
+				Namespace: ns,
+				Key:       hex.EncodeToString([]byte(key)),
+				Value:     hex.EncodeToString(value),
+			})
+		}
+	}
+	sort.Slice(snapshot.Accounts, func(i, j int) bool {
+		return snapshot.Accounts[i].Address < snapshot.Accounts[j].Address
+	})
+	sort.Slice(snapshot.States, func(i, j int) bool {
+		if snapshot.States[i].Namespace != snapshot.States[j].Namespace {
+			return snapshot.States[i].Namespace < snapshot.States[j].Namespace
+		}
+		return snapshot.States[i].Key < snapshot.States[j].Key
+	})
+	return snapshot, nil
+}
+
+func newAccountSnapshot(addr string, account *state.Account) *AccountSnapshot {
+	s := &AccountSnapshot{
+		Address: addr,
+		Balance: account.Balance.String(),
+		Nonce:   account.Nonce,
+	}
+	if len(account.CodeHash) > 0 {
+		s.CodeHash = hex.EncodeToString(account.CodeHash)
+	}
+	if account.Root != hash.ZeroHash256 {
+		s.Root = hex.EncodeToString(account.Root[:])
+	}
+	s.IsCandidate = account.IsCandidate
+	s.VotingWeight = "0"
+	if account.VotingWeight != nil {
+		s.VotingWeight = account.VotingWeight.String()
+	}
+	s.Votee = account.Votee
+	return s
+}
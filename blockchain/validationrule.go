@@ -0,0 +1,156 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+const (
+	// RuleCategoryStructural is the category of the rules on how a block is built and linked to the chain
+	RuleCategoryStructural = "structural"
+	// RuleCategorySignature is the category of the rules on the signatures
+	RuleCategorySignature = "signature"
+	// RuleCategoryProtocol is the category of the rules on the actions against the states and the protocols
+	RuleCategoryProtocol = "protocol"
+)
+
+// ErrUnknownValidationRule indicates the validation rule isn't registered
+var ErrUnknownValidationRule = errors.New("unknown validation rule")
+
+type (
+	// ValidationRuleSpec is the machine-readable spec of a block validation rule, so that the rules of two node
+	// versions can be diffed
+	ValidationRuleSpec struct {
+		Name        string `json:"name"`
+		Category    string `json:"category"`
+		Description string `json:"description"`
+		// Required tells the rule can't be disabled, because the chain can't be followed without it
+		Required bool `json:"required"`
+		Enabled  bool `json:"enabled"`
+	}
+
+	// validationRule is a named check of a block, which is run by the validator in the order of registration
+	validationRule struct {
+		name        string
+		category    string
+		description string
+		required    bool
+		// stateful tells the rule checks the block against the states through the nonce function, so that it's run
+		// once the actions of the previous blocks are run when the blocks are committed in a pipeline
+		stateful bool
+		check    func(v *validator, blk *block.Block, tip validationTip) error
+	}
+
+	// validationTip is the tip a block is validated on top of. The actions aren't validated if nonceFn is nil
+	validationTip struct {
+		height  uint64
+		hash    hash.Hash256
+		nonceFn func(string) (uint64, error)
+	}
+)
+
+// validationRules are the rules a block is validated against, in order
+var validationRules = []validationRule{
+	{
+		name:        "height_and_prev_hash",
+		category:    RuleCategoryStructural,
+		description: "the block is on top of the tip, with the height and the previous hash of the tip",
+		required:    true,
+		check: func(_ *validator, blk *block.Block, tip validationTip) error {
+			return errors.Wrap(verifyHeightAndHash(blk, tip.height, tip.hash), "failed to verify block's height and hash")
+		},
+	},
+	{
+		name:        "block_signature",
+		category:    RuleCategorySignature,
+		description: "the block is signed by the producer, except the genesis block",
+		required:    true,
+		check: func(_ *validator, blk *block.Block, _ validationTip) error {
+			return errors.Wrap(verifySignature(blk), "failed to verify block's signature")
+		},
+	},
+	{
+		name:        "tx_root",
+		category:    RuleCategoryStructural,
+		description: "the tx root in the header is the merkle root of the actions",
+		required:    true,
+		check: func(_ *validator, blk *block.Block, _ validationTip) error {
+			return errors.Wrap(verifyTxRoot(blk), "failed to verify block's merkle root")
+		},
+	},
+	{
+		name:     "action_order",
+		category: RuleCategoryStructural,
+		description: "the block is of the action order of the genesis at its height, and the actions of a block of " +
+			"the hash order are in the order the producer would have picked them",
+		required: true,
+		check: func(v *validator, blk *block.Block, _ validationTip) error {
+			return errors.Wrap(verifyActionOrder(blk, v.hashOrderHeight), "failed to verify block's action order")
+		},
+	},
+	{
+		name:     "actions",
+		category: RuleCategoryProtocol,
+		description: "each action passes the envelope validators and the validators of the protocols, and the nonces " +
+			"of each sender continue from the confirmed one, which includes the gas limit of each action being no " +
+			"more than the action gas limit of the genesis and covering its intrinsic gas",
+		required: true,
+		stateful: true,
+		check: func(v *validator, blk *block.Block, tip validationTip) error {
+			if tip.nonceFn == nil {
+				return nil
+			}
+			return v.validateActionsOnly(blk.Actions, blk.PublicKey(), blk.ChainID(), blk.Height(), tip.nonceFn, nil)
+		},
+	},
+}
+
+// ValidationRules returns the spec of the rules a block is validated against in order, with the rules of the names
+// disabled, e.g., on a testnet
+func ValidationRules(disabled []string) ([]ValidationRuleSpec, error) {
+	disabledRules, err := disabledValidationRules(disabled)
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]ValidationRuleSpec, 0, len(validationRules))
+	for _, r := range validationRules {
+		specs = append(specs, ValidationRuleSpec{
+			Name:        r.name,
+			Category:    r.category,
+			Description: r.description,
+			Required:    r.required,
+			Enabled:     !disabledRules[r.name],
+		})
+	}
+	return specs, nil
+}
+
+// disabledValidationRules returns the set of the rules to disable. The rules have to be registered and not required
+func disabledValidationRules(names []string) (map[string]bool, error) {
+	disabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		var rule *validationRule
+		for i := range validationRules {
+			if validationRules[i].name == name {
+				rule = &validationRules[i]
+				break
+			}
+		}
+		if rule == nil {
+			return nil, errors.Wrapf(ErrUnknownValidationRule, "rule %s", name)
+		}
+		if rule.required {
+			return nil, errors.Errorf("validation rule %s is required", name)
+		}
+		disabled[name] = true
+	}
+	return disabled, nil
+}
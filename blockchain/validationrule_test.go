@@ -0,0 +1,77 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestValidationRules(t *testing.T) {
+	require := require.New(t)
+
+	specs, err := ValidationRules(nil)
+	require.NoError(err)
+	names := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		names = append(names, spec.Name)
+		require.True(spec.Enabled)
+		require.NotEmpty(spec.Description)
+	}
+	require.Equal([]string{
+		"height_and_prev_hash",
+		"block_signature",
+		"tx_root",
+		"action_order",
+		"actions",
+	}, names)
+	require.Equal(RuleCategoryStructural, specs[0].Category)
+	require.True(specs[0].Required)
+
+	_, err = ValidationRules([]string{"unknown"})
+	require.Equal(ErrUnknownValidationRule, errors.Cause(err))
+	// The required rules can't be disabled
+	_, err = ValidationRules([]string{"tx_root"})
+	require.Error(err)
+	_, err = ValidationRules([]string{"block_signature"})
+	require.Error(err)
+}
+
+func TestValidator_DisabledRules(t *testing.T) {
+	require := require.New(t)
+
+	// The signature and the actions of a block are validated on every node, as are the other rules
+	specs, err := ValidationRules(nil)
+	require.NoError(err)
+	val := &validator{}
+	for _, spec := range specs {
+		require.True(spec.Required)
+		require.Error(val.disableRules(spec.Name))
+	}
+	// The gas of the actions is validated along with the actions
+	require.Equal(ErrUnknownValidationRule, errors.Cause(val.disableRules("action_gas_limit")))
+
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey, 1, big.NewInt(20), []byte{}, 100000, big.NewInt(10))
+	require.NoError(err)
+	prevHash := tsf.Hash()
+	blk, err := block.NewTestingBuilder().
+		SetChainID(1).
+		SetHeight(1).
+		SetPrevBlockHash(prevHash).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.NoError(val.Validate(&blk, 0, prevHash))
+}
@@ -8,9 +8,11 @@ package blocksync
 
 import (
 	"context"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/actpool"
@@ -18,10 +20,13 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/routine"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
 
 type (
@@ -29,12 +34,17 @@ type (
 	UnicastOutbound func(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error
 	// Neighbors returns the neighbors' addresses
 	Neighbors func(ctx context.Context) ([]peerstore.PeerInfo, error)
+	// BlockPeer bans the peer for the duration
+	BlockPeer func(peer peerstore.PeerInfo, duration time.Duration)
 )
 
 // Config represents the config to setup blocksync
 type Config struct {
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
+	blockPeerHandler BlockPeer
+	checkpoint       *fastSync
+	pinnedSnapshot   uint64
 }
 
 // Option is the option to override the blocksync config
@@ -56,14 +66,55 @@ func WithNeighbors(neighborsHandler Neighbors) Option {
 	}
 }
 
+// WithBlockPeer is the option to set the callback banning the peers which abuse the sync requests
+func WithBlockPeer(blockPeerHandler BlockPeer) Option {
+	return func(cfg *Config) error {
+		cfg.blockPeerHandler = blockPeerHandler
+		return nil
+	}
+}
+
+// WithCheckpoint is the option to fast sync up to the trusted checkpoint, whose states are fetched from the snapshot URL
+func WithCheckpoint(height uint64, blkHash, stateDigest hash.Hash256, snapshotURL string) Option {
+	return func(cfg *Config) error {
+		if height == 0 {
+			return errors.New("checkpoint height should be greater than 0")
+		}
+		cfg.checkpoint = newFastSync(height, blkHash, stateDigest, snapshotURL)
+		return nil
+	}
+}
+
+// WithPinnedSnapshot is the option to keep serving the state snapshot at the height, e.g., the checkpoint in genesis,
+// besides the latest ones at the snapshot interval
+func WithPinnedSnapshot(height uint64) Option {
+	return func(cfg *Config) error {
+		cfg.pinnedSnapshot = height
+		return nil
+	}
+}
+
 // BlockSync defines the interface of blocksyncer
 type BlockSync interface {
 	lifecycle.StartStopper
 
 	TargetHeight() uint64
+	CatchingUp() bool
+	SyncToHeight(height uint64)
 	ProcessSyncRequest(ctx context.Context, peer peerstore.PeerInfo, sync *iotexrpc.BlockSync) error
 	ProcessBlock(ctx context.Context, blk *block.Block) error
-	ProcessBlockSync(ctx context.Context, blk *block.Block) error
+	ProcessBlockSync(ctx context.Context, peer peerstore.PeerInfo, blk *block.Block) error
+	ProcessCompactBlock(ctx context.Context, peer peerstore.PeerInfo, cb *iotexrpc.CompactBlock) error
+	ProcessBlockActionsRequest(ctx context.Context, peer peerstore.PeerInfo, req *iotexrpc.BlockActionsRequest) error
+	ProcessBlockActions(ctx context.Context, peer peerstore.PeerInfo, ba *iotexrpc.BlockActions) error
+	ProcessSnapshotChunkRequest(ctx context.Context, peer peerstore.PeerInfo, req *iotexrpc.SnapshotChunkRequest) error
+	ProcessSnapshotChunk(ctx context.Context, peer peerstore.PeerInfo, chunk *iotexrpc.SnapshotChunk) error
+	BufferStats() *iotexapi.BlockSyncBufferStats
+	PeerScores() []*iotexapi.PeerScore
+	ForkEvents() []*iotexapi.ForkEvent
+	SyncStatus() *iotexapi.BlockSyncStatus
+	SubscribeSyncStatus(ch chan<- *iotexapi.BlockSyncStatus)
+	UnsubscribeSyncStatus(ch chan<- *iotexapi.BlockSyncStatus)
 }
 
 // blockSyncer implements BlockSync interface
@@ -73,11 +124,24 @@ type blockSyncer struct {
 	ackSyncReq       bool   // acknowledges incoming Sync request
 	commitHeight     uint64 // last commit block height
 	buf              *blockBuffer
+	rep              *peerReputation
+	forks            *forkTracker
+	progress         *syncProgress
 	worker           *syncWorker
+	assembler        *compactAssembler
+	fast             *fastSync
+	snapshots        *snapshotProvider // generates and serves the state snapshots, nil if disabled
 	bc               blockchain.Blockchain
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
+	blockPeerHandler BlockPeer
+	limiter          *syncRequestLimiter
+	snapshotLimiter  *syncRequestLimiter
+	maxRequestBlocks uint64
+	catchUpDistance  uint64
+	catchUpQuorum    int
 	chaser           *routine.RecurringTask
+	reporter         *routine.RecurringTask
 }
 
 // NewBlockSyncer returns a new block syncer instance
@@ -92,12 +156,24 @@ func NewBlockSyncer(
 	if cfg.IsFullnode() {
 		bufSize <<= 3
 	}
+	// A peer is considered stalled if it doesn't respond a request in a few sync intervals
+	rep := newPeerReputation(cfg.BlockSync.Interval * 3)
+	spillDBConfig := cfg.DB
+	spillDBConfig.DbPath = cfg.BlockSync.SpillDBPath
+	if spillDBConfig.DbPath == "" {
+		spillDBConfig.DbPath = cfg.Chain.ChainDBPath + ".spill"
+	}
 	buf := &blockBuffer{
-		blocks: make(map[uint64]*block.Block),
-		bc:     chain,
-		ap:     ap,
-		cs:     cs,
-		size:   bufSize,
+		blocks:         make(map[uint64]*block.Block),
+		bc:             chain,
+		ap:             ap,
+		cs:             cs,
+		size:           bufSize,
+		spillThreshold: cfg.BlockSync.SpillThresholdBytes,
+		spillDBConfig:  spillDBConfig,
+		rep:            rep,
+		commitBatch:    cfg.BlockSync.CommitBatchSize,
+		maxReorgDepth:  cfg.BlockSync.MaxReorgDepth,
 	}
 	bsCfg := Config{}
 	for _, opt := range opts {
@@ -111,11 +187,43 @@ func NewBlockSyncer(
 		ackSyncReq:       cfg.IsDelegate() || cfg.IsFullnode(),
 		bc:               chain,
 		buf:              buf,
+		rep:              rep,
+		forks:            newForkTracker(),
+		progress:         newSyncProgress(),
 		unicastHandler:   bsCfg.unicastHandler,
 		neighborsHandler: bsCfg.neighborsHandler,
-		worker:           newSyncWorker(chain.ChainID(), cfg, bsCfg.unicastHandler, bsCfg.neighborsHandler, buf),
+		blockPeerHandler: bsCfg.blockPeerHandler,
+		limiter: newSyncRequestLimiter(
+			cfg.BlockSync.SyncRequestLimit,
+			cfg.BlockSync.SyncRequestWindow,
+			cfg.BlockSync.SyncRequestBanDuration,
+		),
+		maxRequestBlocks: cfg.BlockSync.MaxSyncRequestBlocks,
+		catchUpDistance:  cfg.BlockSync.CatchUpDistance,
+		catchUpQuorum:    cfg.BlockSync.CatchUpQuorum,
+		worker:           newSyncWorker(chain.ChainID(), cfg, bsCfg.unicastHandler, bsCfg.neighborsHandler, buf, rep),
+		assembler:        newCompactAssembler(ap, int(bufSize)),
+	}
+	if bsCfg.checkpoint != nil {
+		// Fast sync has nothing to do once the tip passes the checkpoint
+		bs.fast = bsCfg.checkpoint
+		buf.fast = bs.fast
+		bs.worker.fast = bs.fast
 	}
+	if cfg.BlockSync.SnapshotInterval > 0 {
+		bs.snapshots = newSnapshotProvider(chain, cfg.BlockSync, bsCfg.pinnedSnapshot)
+		// The snapshot chunks are much larger than the blocks, so they are limited on their own
+		bs.snapshotLimiter = newSyncRequestLimiter(
+			cfg.BlockSync.SnapshotRequestLimit,
+			cfg.BlockSync.SyncRequestWindow,
+			cfg.BlockSync.SyncRequestBanDuration,
+		)
+	}
+	buf.rerequest = bs.worker.RequestHeight
 	bs.chaser = routine.NewRecurringTask(bs.Chase, cfg.BlockSync.Interval*10)
+	if cfg.BlockSync.Interval != 0 {
+		bs.reporter = routine.NewRecurringTask(bs.reportProgress, cfg.BlockSync.Interval)
+	}
 	return bs, nil
 }
 
@@ -126,13 +234,50 @@ func (bs *blockSyncer) TargetHeight() uint64 {
 	return bs.worker.targetHeight
 }
 
+// CatchingUp returns whether the tip is more than the catch-up distance behind the height which a quorum of peers
+// have recently delivered blocks up to, while the node is better off syncing blocks than taking part in the consensus.
+// The sync target height isn't used, as a single peer could raise it by broadcasting a block of any height
+func (bs *blockSyncer) CatchingUp() bool {
+	if bs.catchUpDistance == 0 {
+		return false
+	}
+	return bs.rep.quorumHeight(bs.catchUpQuorum, time.Now()) > bs.bc.TipHeight()+bs.catchUpDistance
+}
+
+// SyncToHeight raises the sync target height to the height observed on the network, e.g., by the consensus, and sends
+// the sync requests right away rather than waiting for the next sync interval. Nothing is sent if the target height
+// is already as high
+func (bs *blockSyncer) SyncToHeight(height uint64) {
+	if !bs.worker.SetTargetHeight(height) {
+		return
+	}
+	log.L().Info("Sync to the observed height.", zap.Uint64("height", height))
+	go bs.worker.Sync()
+}
+
 // Start starts a block syncer
 func (bs *blockSyncer) Start(ctx context.Context) error {
 	log.L().Debug("Starting block syncer.")
+	if bs.fast != nil {
+		if err := bs.fast.resume(bs.bc); err != nil {
+			return errors.Wrap(err, "failed to load the states at the checkpoint")
+		}
+	}
 	bs.commitHeight = bs.buf.CommitHeight()
+	bs.progress.reset(bs.bc.TipHeight(), time.Now())
 	if err := bs.chaser.Start(ctx); err != nil {
 		return err
 	}
+	if bs.reporter != nil {
+		if err := bs.reporter.Start(ctx); err != nil {
+			return err
+		}
+	}
+	if bs.snapshots != nil {
+		if err := bs.snapshots.Start(ctx); err != nil {
+			return err
+		}
+	}
 	return bs.worker.Start(ctx)
 }
 
@@ -142,7 +287,50 @@ func (bs *blockSyncer) Stop(ctx context.Context) error {
 	if err := bs.chaser.Stop(ctx); err != nil {
 		return err
 	}
-	return bs.worker.Stop(ctx)
+	if bs.reporter != nil {
+		if err := bs.reporter.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	if bs.snapshots != nil {
+		if err := bs.snapshots.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	if err := bs.worker.Stop(ctx); err != nil {
+		return err
+	}
+	return bs.buf.Close(ctx)
+}
+
+// BufferStats returns the statistics of the block buffer
+func (bs *blockSyncer) BufferStats() *iotexapi.BlockSyncBufferStats {
+	return bs.buf.Stats()
+}
+
+// PeerScores returns the scores of the peers serving the sync requests
+func (bs *blockSyncer) PeerScores() []*iotexapi.PeerScore {
+	return bs.rep.table()
+}
+
+// ForkEvents returns the recent competing blocks observed at the same height, the latest first
+func (bs *blockSyncer) ForkEvents() []*iotexapi.ForkEvent {
+	return bs.forks.recent()
+}
+
+// SyncStatus returns the progress of the block sync
+func (bs *blockSyncer) SyncStatus() *iotexapi.BlockSyncStatus {
+	return bs.progress.status(bs.bc.TipHeight(), bs.TargetHeight(), bs.worker.PeersInUse())
+}
+
+// SubscribeSyncStatus adds a channel receiving the sync status reported every sync interval
+func (bs *blockSyncer) SubscribeSyncStatus(ch chan<- *iotexapi.BlockSyncStatus) {
+	bs.progress.subscribe(ch)
+}
+
+// UnsubscribeSyncStatus removes a channel receiving the sync status
+func (bs *blockSyncer) UnsubscribeSyncStatus(ch chan<- *iotexapi.BlockSyncStatus) {
+	bs.progress.unsubscribe(ch)
 }
 
 // ProcessBlock processes an incoming latest committed block
@@ -157,8 +345,11 @@ func (bs *blockSyncer) ProcessBlock(_ context.Context, blk *block.Block) error {
 	switch re {
 	case bCheckinLower:
 		log.L().Debug("Drop block lower than buffer's accept height.")
+		bs.checkFork(blk, forkSourceConsensus)
+		bs.reorgOnFork(blk)
 	case bCheckinExisting:
 		log.L().Debug("Drop block exists in buffer.")
+		bs.checkFork(blk, forkSourceConsensus)
 	case bCheckinHigher:
 		needSync = true
 	case bCheckinValid:
@@ -173,35 +364,152 @@ func (bs *blockSyncer) ProcessBlock(_ context.Context, blk *block.Block) error {
 	return nil
 }
 
-func (bs *blockSyncer) ProcessBlockSync(_ context.Context, blk *block.Block) error {
+// ProcessBlockSync processes a block delivered by peer in response to a sync request
+func (bs *blockSyncer) ProcessBlockSync(_ context.Context, peer peerstore.PeerInfo, blk *block.Block) error {
 	if !bs.ackBlockSync {
 		// node is not meant to handle sync block, simply exit
 		return nil
 	}
-	bs.buf.Flush(blk)
+	bs.rep.responded(peer.ID.Pretty(), blk.Height(), time.Now())
+	if _, re := bs.buf.Flush(blk); re == bCheckinLower || re == bCheckinExisting {
+		bs.checkFork(blk, forkSourceSync)
+		if re == bCheckinLower {
+			bs.reorgOnFork(blk)
+		}
+	}
 	if bs.bc.TipHeight() == bs.TargetHeight() {
 		bs.worker.SetTargetHeight(bs.TargetHeight() + bs.buf.bufSize())
 	}
 	return nil
 }
 
+// ProcessCompactBlock processes a compact block delivered by peer in response to a sync request. The block is
+// reconstructed with the actions in the actpool, and the missing actions are requested from the peer
+func (bs *blockSyncer) ProcessCompactBlock(ctx context.Context, peer peerstore.PeerInfo, cb *iotexrpc.CompactBlock) error {
+	if !bs.ackBlockSync {
+		// node is not meant to handle sync block, simply exit
+		return nil
+	}
+	height := cb.GetHeader().GetHeight()
+	tipHeight := bs.bc.TipHeight()
+	if height <= tipHeight {
+		return nil
+	}
+	if bs.fast != nil && bs.fast.syncingHeaders(tipHeight) {
+		bs.rep.responded(peer.ID.Pretty(), height, time.Now())
+		if err := bs.fast.addHeader(cb.GetHeader(), tipHeight); err != nil {
+			bs.rep.invalid(height)
+			return err
+		}
+		return nil
+	}
+	pb, missing, err := bs.assembler.assemble(peer.ID.Pretty(), cb, tipHeight)
+	if err != nil {
+		return err
+	}
+	if pb != nil {
+		return bs.processSyncedBlock(ctx, peer, pb)
+	}
+	log.L().Debug(
+		"Request the actions missing from the compact block.",
+		zap.Uint64("height", height),
+		zap.Int("missing", len(missing)),
+		zap.String("peerID", peer.ID.Pretty()),
+	)
+	return bs.unicastHandler(ctx, peer, &iotexrpc.BlockActionsRequest{Height: height, Indexes: missing})
+}
+
+// ProcessBlockActions processes the actions missing from a compact block delivered by peer
+func (bs *blockSyncer) ProcessBlockActions(ctx context.Context, peer peerstore.PeerInfo, ba *iotexrpc.BlockActions) error {
+	if !bs.ackBlockSync {
+		// node is not meant to handle sync block, simply exit
+		return nil
+	}
+	pb, err := bs.assembler.fill(peer.ID.Pretty(), ba)
+	if err != nil {
+		bs.rep.invalid(ba.Height)
+		return err
+	}
+	return bs.processSyncedBlock(ctx, peer, pb)
+}
+
+// processSyncedBlock processes a block reconstructed from the compact form, whose tx root is verified in conversion
+func (bs *blockSyncer) processSyncedBlock(ctx context.Context, peer peerstore.PeerInfo, pb *iotextypes.Block) error {
+	blk := &block.Block{}
+	if err := blk.ConvertFromBlockPb(pb); err != nil {
+		return errors.Wrapf(err, "failed to reconstruct block %d", pb.GetHeader().GetHeight())
+	}
+	return bs.ProcessBlockSync(ctx, peer, blk)
+}
+
+// checkFork records a fork event if a block dropped by the buffer competes with the one chosen at the same height. The
+// competing block is recorded only if it's signed by its producer and endorsed by the delegates, so that a peer can't
+// fill up the fork events with the blocks made up
+func (bs *blockSyncer) checkFork(blk *block.Block, source string) {
+	chosen, reason, ok := bs.buf.chosenBlockHash(blk.Height())
+	if !ok {
+		return
+	}
+	competing := blk.HashBlock()
+	if chosen == competing {
+		return
+	}
+	if !blk.VerifySignature() {
+		log.L().Debug("Ignored competing block with invalid signature.", zap.Uint64("height", blk.Height()))
+		return
+	}
+	if err := bs.buf.cs.ValidateBlockFooter(blk); err != nil {
+		log.L().Debug("Ignored competing block with invalid footer.", zap.Uint64("height", blk.Height()), zap.Error(err))
+		return
+	}
+	bs.forks.observe(blk.Height(), chosen, competing, source, reason, time.Now())
+}
+
+// reorgOnFork reorganizes the chain onto the block competing with the committed one if the consensus prefers it. The
+// competing block is committed by the rebase or on the rolled back tip, and the canonical branch above it is synced
+// from the peers right away
+func (bs *blockSyncer) reorgOnFork(blk *block.Block) {
+	reorged, err := bs.buf.reorg(blk)
+	if err != nil {
+		log.L().Error("Failed to reorganize the chain.", zap.Uint64("height", blk.Height()), zap.Error(err))
+		return
+	}
+	if !reorged {
+		return
+	}
+	bs.buf.Flush(blk)
+	go bs.worker.Sync()
+}
+
 // ProcessSyncRequest processes a block sync request
 func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.PeerInfo, sync *iotexrpc.BlockSync) error {
 	if !bs.ackSyncReq {
 		// node is not meant to handle sync request, simply exit
 		return nil
 	}
+	allowed, banned := bs.limiter.allow(peer.ID.Pretty(), time.Now())
+	if banned && bs.blockPeerHandler != nil {
+		bs.blockPeerHandler(peer, bs.limiter.banDuration)
+	}
+	if !allowed {
+		return errors.Errorf("peer %s exceeds the sync request limit", peer.ID.Pretty())
+	}
+	reqEnd := sync.End
+	if bs.maxRequestBlocks > 0 && reqEnd >= sync.Start && reqEnd-sync.Start >= bs.maxRequestBlocks {
+		// The requester asks for the rest in the following requests
+		reqEnd = sync.Start + bs.maxRequestBlocks - 1
+	}
 
 	end := bs.bc.TipHeight()
 	switch {
-	case sync.End < end:
-		end = sync.End
-	case sync.End > end:
+	case reqEnd < end:
+		end = reqEnd
+	case reqEnd > end:
 		log.L().Info(
 			"Do not have requested blocks",
 			zap.String("peerID", peer.ID.Pretty()),
 			zap.Uint64("start", sync.Start),
-			zap.Uint64("end", sync.End),
+			zap.Uint64("end", reqEnd),
 			zap.Uint64("tipHeight", end),
 		)
 	}
@@ -210,16 +518,116 @@ func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.Pe
 		if err != nil {
 			return err
 		}
+		var msg proto.Message
+		switch {
+		case sync.Compact:
+			msg = compactBlockOf(blk)
+		case sync.Receipts:
+			blkPb := blk.ConvertToBlockPb()
+			receipts, err := bs.bc.GetReceiptsByHeight(i)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get receipts of block %d", i)
+			}
+			for _, receipt := range receipts {
+				blkPb.Receipts = append(blkPb.Receipts, receipt.ConvertToReceiptPb())
+			}
+			msg = &iotexrpc.BlockContainer{Block: blkPb}
+		default:
+			msg = &iotexrpc.BlockContainer{Block: blk.ConvertToBlockPb()}
+		}
 		// TODO: send back multiple blocks in one shot
-		if err := bs.unicastHandler(context.Background(), peer,
-			&iotexrpc.BlockContainer{Block: blk.ConvertToBlockPb()},
-		); err != nil {
+		if err := bs.unicastHandler(context.Background(), peer, msg); err != nil {
 			log.L().Warn("Failed to response to ProcessSyncRequest.", zap.Error(err))
 		}
 	}
 	return nil
 }
 
+// ProcessBlockActionsRequest processes a request of the actions missing from a compact block
+func (bs *blockSyncer) ProcessBlockActionsRequest(
+	ctx context.Context,
+	peer peerstore.PeerInfo,
+	req *iotexrpc.BlockActionsRequest,
+) error {
+	if !bs.ackSyncReq {
+		// node is not meant to handle sync request, simply exit
+		return nil
+	}
+	allowed, banned := bs.limiter.allow(peer.ID.Pretty(), time.Now())
+	if banned && bs.blockPeerHandler != nil {
+		bs.blockPeerHandler(peer, bs.limiter.banDuration)
+	}
+	if !allowed {
+		return errors.Errorf("peer %s exceeds the sync request limit", peer.ID.Pretty())
+	}
+	blk, err := bs.bc.GetBlockByHeight(req.Height)
+	if err != nil {
+		return err
+	}
+	res := &iotexrpc.BlockActions{Height: req.Height}
+	for _, index := range req.Indexes {
+		if int(index) >= len(blk.Actions) {
+			return errors.Errorf("block %d doesn't have action %d", req.Height, index)
+		}
+		res.Indexes = append(res.Indexes, index)
+		res.Actions = append(res.Actions, blk.Actions[index].Proto())
+	}
+	return bs.unicastHandler(ctx, peer, res)
+}
+
+// ProcessSnapshotChunkRequest processes a request of a chunk of the state snapshot served by the node
+func (bs *blockSyncer) ProcessSnapshotChunkRequest(
+	ctx context.Context,
+	peer peerstore.PeerInfo,
+	req *iotexrpc.SnapshotChunkRequest,
+) error {
+	if !bs.ackSyncReq || bs.snapshots == nil {
+		// node is not meant to serve state snapshots, simply exit
+		return nil
+	}
+	allowed, banned := bs.snapshotLimiter.allow(peer.ID.Pretty(), time.Now())
+	if banned && bs.blockPeerHandler != nil {
+		bs.blockPeerHandler(peer, bs.snapshotLimiter.banDuration)
+	}
+	if !allowed {
+		return errors.Errorf("peer %s exceeds the snapshot request limit", peer.ID.Pretty())
+	}
+	chunk, err := bs.snapshots.chunk(req.Height, req.Index)
+	if err != nil {
+		return err
+	}
+	return bs.unicastHandler(ctx, peer, chunk)
+}
+
+// ProcessSnapshotChunk processes a chunk of the state snapshot at the checkpoint downloaded in fast sync
+func (bs *blockSyncer) ProcessSnapshotChunk(
+	_ context.Context,
+	peer peerstore.PeerInfo,
+	chunk *iotexrpc.SnapshotChunk,
+) error {
+	if bs.fast == nil || bs.fast.download == nil {
+		return nil
+	}
+	completed, err := bs.fast.download.add(chunk)
+	if err != nil {
+		return errors.Wrapf(err, "invalid snapshot chunk from peer %s", peer.ID.Pretty())
+	}
+	if !completed {
+		return nil
+	}
+	// The node may have imported the checkpoint block before the snapshot is downloaded
+	if err := bs.fast.resume(bs.bc); err != nil {
+		log.L().Panic("Failed to load the states at the checkpoint.", zap.Error(err))
+	}
+	return nil
+}
+
+// reportProgress measures the sync speed and publishes the sync status
+func (bs *blockSyncer) reportProgress() {
+	bs.progress.update(bs.bc.TipHeight(), time.Now())
+	bs.progress.publish(bs.SyncStatus())
+}
+
 // Chase sets the block sync target height to be blockchain height + 1
 func (bs *blockSyncer) Chase() {
 	if bs.commitHeight != bs.buf.CommitHeight() {
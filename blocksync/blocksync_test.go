@@ -8,16 +8,19 @@ package blocksync
 
 import (
 	"context"
+	"encoding/hex"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/actpool"
@@ -26,7 +29,9 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
@@ -197,6 +202,61 @@ func TestBlockSyncerProcessSyncRequest(t *testing.T) {
 	assert.Nil(bs.ProcessSyncRequest(context.Background(), peerstore.PeerInfo{}, pbBs))
 }
 
+func TestBlockSyncerProcessSyncRequestReceipts(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBc := mock_blockchain.NewMockBlockchain(ctrl)
+	mBc.EXPECT().ChainID().AnyTimes().Return(config.Default.Chain.ID)
+	blk := block.NewBlockDeprecated(
+		uint32(123),
+		uint64(1),
+		hash.Hash256{},
+		testutil.TimestampNow(),
+		ta.Keyinfo["producer"].PubKey,
+		nil,
+	)
+	mBc.EXPECT().GetBlockByHeight(uint64(1)).AnyTimes().Return(blk, nil)
+	mBc.EXPECT().TipHeight().AnyTimes().Return(uint64(1))
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	ap, err := actpool.NewActPool(mBc, cfg.ActPool)
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+
+	cfgFullNode := config.Config{
+		NodeType: config.FullNodeType,
+	}
+	cfgFullNode.Network.BootstrapNodes = []string{"123"}
+	var sent *iotexrpc.BlockContainer
+	bs, err := NewBlockSyncer(
+		cfgFullNode,
+		mBc,
+		ap,
+		cs,
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			sent = msg.(*iotexrpc.BlockContainer)
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return nil, nil }),
+	)
+	require.NoError(err)
+
+	// The receipts are sent along with the blocks if requested
+	pbBs := &iotexrpc.BlockSync{Start: 1, End: 1, Receipts: true}
+	mBc.EXPECT().GetReceiptsByHeight(uint64(1)).Return(nil, errors.New("receipts not found")).Times(1)
+	require.Error(bs.ProcessSyncRequest(context.Background(), peerstore.PeerInfo{}, pbBs))
+	mBc.EXPECT().GetReceiptsByHeight(uint64(1)).Return([]*action.Receipt{{Status: 1}}, nil).Times(1)
+	require.NoError(bs.ProcessSyncRequest(context.Background(), peerstore.PeerInfo{}, pbBs))
+	require.Equal(1, len(sent.Block.Receipts))
+	received := &block.Block{}
+	require.NoError(received.ConvertFromBlockPb(sent.Block))
+	require.Equal(1, len(received.Receipts))
+	require.Equal(uint64(1), received.Receipts[0].Status)
+}
+
 func TestBlockSyncerProcessSyncRequestError(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -227,6 +287,163 @@ func TestBlockSyncerProcessSyncRequestError(t *testing.T) {
 	require.Error(bs.ProcessSyncRequest(context.Background(), peerstore.PeerInfo{}, pbBs))
 }
 
+func TestBlockSyncerProcessSyncRequestLimit(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.NodeType = config.FullNodeType
+	cfg.BlockSync.MaxSyncRequestBlocks = 3
+	cfg.BlockSync.SyncRequestLimit = 2
+	cfg.BlockSync.SyncRequestWindow = time.Minute
+	cfg.BlockSync.SyncRequestBanDuration = time.Minute
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	chain.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	blk := block.NewBlockDeprecated(
+		uint32(1),
+		uint64(1),
+		hash.Hash256{},
+		testutil.TimestampNow(),
+		ta.Keyinfo["producer"].PubKey,
+		nil,
+	)
+	// Only the first 3 blocks of each request are served
+	for h := uint64(1); h <= 3; h++ {
+		chain.EXPECT().GetBlockByHeight(h).Return(blk, nil).Times(2)
+	}
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+
+	var sent int
+	var blocked []peerstore.PeerInfo
+	bs, err := NewBlockSyncer(
+		cfg,
+		chain,
+		ap,
+		cs,
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, _ proto.Message) error {
+			sent++
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return nil, nil }),
+		WithBlockPeer(func(peer peerstore.PeerInfo, duration time.Duration) {
+			require.Equal(time.Minute, duration)
+			blocked = append(blocked, peer)
+		}),
+	)
+	require.NoError(err)
+
+	abuser := peerstore.PeerInfo{ID: peer.ID("abuser")}
+	pbBs := &iotexrpc.BlockSync{Start: 1, End: 50}
+	require.NoError(bs.ProcessSyncRequest(context.Background(), abuser, pbBs))
+	require.NoError(bs.ProcessSyncRequest(context.Background(), abuser, pbBs))
+	require.Equal(6, sent)
+	require.Equal(uint64(50), pbBs.End)
+
+	// The peer exceeding the limit is banned, and its requests are dropped
+	require.Error(bs.ProcessSyncRequest(context.Background(), abuser, pbBs))
+	require.Error(bs.ProcessSyncRequest(context.Background(), abuser, pbBs))
+	require.Equal([]peerstore.PeerInfo{abuser}, blocked)
+	require.Equal(6, sent)
+}
+
+func TestBlockSyncerCompactBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.NodeType = config.FullNodeType
+	blk := newCompactTestBlock(t, 5)
+	ctx := context.Background()
+
+	// The serving node responds the block and the missing actions
+	server := mock_blockchain.NewMockBlockchain(ctrl)
+	server.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	server.EXPECT().TipHeight().Return(uint64(5)).AnyTimes()
+	server.EXPECT().GetBlockByHeight(uint64(5)).Return(&blk, nil).AnyTimes()
+	var served []proto.Message
+	bs1, err := NewBlockSyncer(
+		cfg,
+		server,
+		mock_actpool.NewMockActPool(ctrl),
+		mock_consensus.NewMockConsensus(ctrl),
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			served = append(served, msg)
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return nil, nil }),
+	)
+	require.NoError(err)
+
+	// The syncing node has all the actions but the 2nd one in its actpool
+	client := mock_blockchain.NewMockBlockchain(ctrl)
+	client.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	client.EXPECT().TipHeight().Return(uint64(4)).AnyTimes()
+	ap := mock_actpool.NewMockActPool(ctrl)
+	for i, selp := range blk.Actions {
+		if i == 1 {
+			ap.EXPECT().GetActionByHash(selp.Hash()).Return(action.SealedEnvelope{}, errors.New("not exist")).Times(1)
+			continue
+		}
+		ap.EXPECT().GetActionByHash(selp.Hash()).Return(selp, nil).Times(1)
+	}
+	ap.EXPECT().Reset().Times(1)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).Times(1)
+	cs.EXPECT().Calibrate(uint64(5)).Times(1)
+	client.EXPECT().ValidateBlock(gomock.Any()).Return(nil).Times(1)
+	client.EXPECT().CommitBlock(gomock.Any()).DoAndReturn(func(committed *block.Block) error {
+		require.Equal(blk.HashBlock(), committed.HashBlock())
+		require.Equal(blk.CalculateTxRoot(), committed.CalculateTxRoot())
+		return nil
+	}).Times(1)
+	var requested []proto.Message
+	bs2, err := NewBlockSyncer(
+		cfg,
+		client,
+		ap,
+		cs,
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			requested = append(requested, msg)
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return nil, nil }),
+	)
+	require.NoError(err)
+
+	serverPeer := peerstore.PeerInfo{ID: peer.ID("server")}
+	clientPeer := peerstore.PeerInfo{ID: peer.ID("client")}
+	require.NoError(bs1.ProcessSyncRequest(ctx, clientPeer, &iotexrpc.BlockSync{Start: 5, End: 5, Compact: true}))
+	require.Equal(1, len(served))
+	cb, ok := served[0].(*iotexrpc.CompactBlock)
+	require.True(ok)
+	require.Equal(3, len(cb.ActionHashes))
+
+	require.NoError(bs2.ProcessCompactBlock(ctx, serverPeer, cb))
+	require.Equal([]proto.Message{&iotexrpc.BlockActionsRequest{Height: 5, Indexes: []uint32{1}}}, requested)
+
+	require.NoError(bs1.ProcessBlockActionsRequest(ctx, clientPeer, requested[0].(*iotexrpc.BlockActionsRequest)))
+	require.Equal(2, len(served))
+	ba, ok := served[1].(*iotexrpc.BlockActions)
+	require.True(ok)
+	require.Equal([]uint32{1}, ba.Indexes)
+	require.Error(bs1.ProcessBlockActionsRequest(ctx, clientPeer, &iotexrpc.BlockActionsRequest{
+		Height:  5,
+		Indexes: []uint32{3},
+	}))
+
+	require.NoError(bs2.ProcessBlockActions(ctx, serverPeer, ba))
+	// The block is no longer pending
+	require.Error(bs2.ProcessBlockActions(ctx, serverPeer, ba))
+}
+
 func TestBlockSyncerProcessBlockTipHeight(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -275,6 +492,14 @@ func TestBlockSyncerProcessBlockTipHeight(t *testing.T) {
 	)
 	require.NotNil(blk)
 	require.NoError(err)
+	competing, err := chain.MintNewBlock(
+		nil,
+		ta.Keyinfo["producer"].PubKey,
+		ta.Keyinfo["producer"].PriKey,
+		ta.Addrinfo["producer"].String(),
+		1,
+	)
+	require.NoError(err)
 	bs.(*blockSyncer).ackBlockCommit = false
 	require.Nil(bs.ProcessBlock(ctx, blk))
 	h2 := chain.TipHeight()
@@ -290,6 +515,26 @@ func TestBlockSyncerProcessBlockTipHeight(t *testing.T) {
 	require.Nil(bs.ProcessBlock(ctx, blk))
 	h4 := chain.TipHeight()
 	assert.Equal(t, h3, h4)
+	require.Empty(bs.ForkEvents())
+
+	// a competing block without valid endorsements isn't recorded
+	cs.EXPECT().ValidateBlockFooter(competing).Return(errors.New("not endorsed")).Times(1)
+	require.Nil(bs.ProcessBlock(ctx, competing))
+	require.Empty(bs.ForkEvents())
+
+	// a competing block at the committed height is recorded as a fork
+	cs.EXPECT().ValidateBlockFooter(competing).Return(nil).Times(1)
+	require.Nil(bs.ProcessBlock(ctx, competing))
+	assert.Equal(t, h3, chain.TipHeight())
+	events := bs.ForkEvents()
+	require.Equal(1, len(events))
+	blkHash := blk.HashBlock()
+	competingHash := competing.HashBlock()
+	require.Equal(h3, events[0].Height)
+	require.Equal(hex.EncodeToString(blkHash[:]), events[0].ChosenHash)
+	require.Equal(hex.EncodeToString(competingHash[:]), events[0].CompetingHash)
+	require.Equal(forkSourceConsensus, events[0].Source)
+	require.Equal(forkReasonCommitted, events[0].Reason)
 }
 
 func TestBlockSyncerProcessBlockOutOfOrder(t *testing.T) {
@@ -481,9 +726,10 @@ func TestBlockSyncerProcessBlockSync(t *testing.T) {
 	h1 := chain1.TipHeight()
 	assert.Equal(t, uint64(3), h1)
 
-	require.Nil(bs2.ProcessBlockSync(ctx, blk3))
-	require.Nil(bs2.ProcessBlockSync(ctx, blk2))
-	require.Nil(bs2.ProcessBlockSync(ctx, blk1))
+	peer := peerstore.PeerInfo{}
+	require.Nil(bs2.ProcessBlockSync(ctx, peer, blk3))
+	require.Nil(bs2.ProcessBlockSync(ctx, peer, blk2))
+	require.Nil(bs2.ProcessBlockSync(ctx, peer, blk1))
 	h2 := chain2.TipHeight()
 	assert.Equal(t, h1, h2)
 }
@@ -513,6 +759,8 @@ func TestBlockSyncerSync(t *testing.T) {
 	require.NotNil(bs)
 	require.NoError(err)
 	require.Nil(bs.Start(ctx))
+	statuses := make(chan *iotexapi.BlockSyncStatus, 1)
+	bs.SubscribeSyncStatus(statuses)
 	time.Sleep(time.Millisecond << 7)
 
 	defer func() {
@@ -545,6 +793,19 @@ func TestBlockSyncerSync(t *testing.T) {
 	require.NoError(err)
 	require.Nil(bs.ProcessBlock(ctx, blk))
 	time.Sleep(time.Millisecond << 7)
+
+	// The sync status is reported every sync interval
+	<-statuses
+	select {
+	case status := <-statuses:
+		require.Equal(uint64(0), status.StartingHeight)
+		require.Equal(uint64(2), status.CurrentHeight)
+		require.Empty(status.Peers)
+	case <-time.After(time.Second):
+		require.Fail("no sync status is reported")
+	}
+	bs.UnsubscribeSyncStatus(statuses)
+	require.Equal(uint64(2), bs.SyncStatus().CurrentHeight)
 }
 
 func newTestConfig() (config.Config, error) {
@@ -589,3 +850,91 @@ func TestBlockSyncerChaser(t *testing.T) {
 		return bs.TargetHeight() == 1, nil
 	}))
 }
+
+func TestBlockSyncerCatchingUp(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.BlockSync.CatchUpDistance = 4
+
+	chain := bc.NewBlockchain(cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption(), bc.GenesisOption(genesis.Default))
+	require.NoError(chain.Start(ctx))
+	defer func() {
+		require.NoError(chain.Stop(ctx))
+	}()
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	bs, err := NewBlockSyncer(cfg, chain, ap, cs, opts...)
+	require.NoError(err)
+
+	// The target height raised by a single block doesn't make the node catch up
+	require.False(bs.CatchingUp())
+	bs.(*blockSyncer).worker.SetTargetHeight(100)
+	require.False(bs.CatchingUp())
+
+	// The node catches up until the tip is within the distance of the height delivered by a quorum of peers
+	rep := bs.(*blockSyncer).rep
+	now := time.Now()
+	rep.requested("a", 1, 5, now)
+	rep.responded("a", 5, now)
+	require.False(bs.CatchingUp())
+	rep.requested("b", 1, 5, now)
+	rep.responded("b", 5, now)
+	require.True(bs.CatchingUp())
+	bs.(*blockSyncer).catchUpDistance = 5
+	require.False(bs.CatchingUp())
+	bs.(*blockSyncer).catchUpDistance = 0
+	require.False(bs.CatchingUp())
+}
+
+func TestBlockSyncerSyncToHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	chain.EXPECT().TipHeight().Return(uint64(4)).AnyTimes()
+	requested := make(chan *iotexrpc.BlockSync, 1)
+	bs, err := NewBlockSyncer(
+		cfg,
+		chain,
+		mock_actpool.NewMockActPool(ctrl),
+		mock_consensus.NewMockConsensus(ctrl),
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			requested <- msg.(*iotexrpc.BlockSync)
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) {
+			return []peerstore.PeerInfo{{}}, nil
+		}),
+	)
+	require.NoError(err)
+
+	// The blocks up to the observed height are requested without waiting for the sync interval
+	bs.SyncToHeight(10)
+	require.Equal(uint64(10), bs.TargetHeight())
+	select {
+	case req := <-requested:
+		require.Equal(uint64(5), req.Start)
+		require.Equal(uint64(10), req.End)
+	case <-time.After(5 * time.Second):
+		require.Fail("no sync request is sent")
+	}
+
+	// Nothing is requested if the target height is already as high
+	bs.SyncToHeight(8)
+	require.Equal(uint64(10), bs.TargetHeight())
+	select {
+	case <-requested:
+		require.Fail("unexpected sync request")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -7,15 +7,24 @@
 package blocksync
 
 import (
+	"context"
+	"os"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
 )
 
 type bCheckinResult int
@@ -28,15 +37,31 @@ const (
 	bCheckinSkipNil
 )
 
-// blockBuffer is used to keep in-coming block in order.
+const spillNamespace = "spilledBlocks"
+
+// blockBuffer is used to keep in-coming block in order. If spillThreshold is set, the highest blocks are spilled to a
+// temporary on-disk store once the blocks in memory exceed the threshold in bytes, and are reloaded when the lower
+// heights commit.
 type blockBuffer struct {
-	mu           sync.RWMutex
-	blocks       map[uint64]*block.Block
-	bc           blockchain.Blockchain
-	ap           actpool.ActPool
-	cs           consensus.Consensus
-	size         uint64
-	commitHeight uint64 // last commit block height
+	mu             sync.RWMutex
+	blocks         map[uint64]*block.Block
+	bc             blockchain.Blockchain
+	ap             actpool.ActPool
+	cs             consensus.Consensus
+	size           uint64
+	commitHeight   uint64 // last commit block height
+	rep            *peerReputation
+	spillThreshold uint64            // max total bytes of the blocks kept in memory, 0 means no limit
+	memBytes       uint64            // total bytes of the blocks kept in memory, tracked if spillThreshold is set
+	blockBytes     map[uint64]uint64 // height -> serialized size of the block kept in memory
+	spillDBConfig  config.DB
+	spill          db.KVStore
+	spilled        map[uint64]uint64   // height -> serialized size of the spilled block
+	rerequest      func(height uint64) // requests the block of height again once it fails to commit or is lost
+	commitBatch    uint64              // max number of blocks committed in a batch
+	fast           *fastSync           // imports the blocks up to the checkpoint in fast sync, nil if disabled
+	maxReorgDepth  uint64              // max number of blocks reverted in a reorg, 0 disables the reorg
+	highestTip     uint64              // highest tip height seen, from which the reorg depth counts
 }
 
 // CommitHeight return the last commit block height
@@ -44,8 +69,16 @@ func (b *blockBuffer) CommitHeight() uint64 {
 	return b.commitHeight
 }
 
-// Flush tries to put given block into buffer and flush buffer into blockchain.
+// Flush tries to put given block into buffer and flush buffer into blockchain. If the flushing stalls at a height because
+// the block fails to commit or is lost, the block is requested again right away instead of waiting for the next sync
 func (b *blockBuffer) Flush(blk *block.Block) (bool, bCheckinResult) {
+	var stalledHeight uint64
+	defer func() {
+		// The request is sent after the buffer is unlocked, because the sync worker locks the buffer as well
+		if stalledHeight != 0 && b.rerequest != nil {
+			b.rerequest(stalledHeight)
+		}
+	}()
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	if blk == nil {
@@ -57,39 +90,65 @@ func (b *blockBuffer) Flush(blk *block.Block) (bool, bCheckinResult) {
 	if blkHeight <= confirmedHeight {
 		return false, bCheckinLower
 	}
-	if _, ok := b.blocks[blkHeight]; ok {
+	if b.has(blkHeight) {
 		return false, bCheckinExisting
 	}
 	if blkHeight > confirmedHeight+b.size {
 		return false, bCheckinHigher
 	}
-	b.blocks[blkHeight] = blk
 	l := log.L().With(
 		zap.Uint64("recvHeight", blkHeight),
 		zap.Uint64("confirmedHeight", confirmedHeight),
 		zap.String("source", "blockBuffer"))
-	var heightToSync uint64
-	for heightToSync = confirmedHeight + 1; heightToSync <= confirmedHeight+b.size; heightToSync++ {
-		blk, ok := b.blocks[heightToSync]
-		if !ok {
-			break
-		}
-		delete(b.blocks, heightToSync)
-		if err := commitBlock(b.bc, b.ap, b.cs, blk); err != nil {
-			// TODO: if the error is because the block has been committed, continue
-			l.Error("Failed to commit the block.", zap.Error(err), zap.Uint64("syncHeight", heightToSync))
-			break
+	b.put(blk, l)
+	heightToSync := confirmedHeight + 1
+	if b.fast != nil {
+		heightToSync, stalledHeight = b.importTrusted(heightToSync, confirmedHeight+b.size, l)
+	}
+	switch {
+	case b.fast != nil && heightToSync <= b.fast.checkpointHeight:
+		// The blocks up to the checkpoint wait for their headers to be verified, rather than being executed
+	case b.commitBatch > 1:
+		heightToSync, stalledHeight = b.commitInBatches(heightToSync, confirmedHeight+b.size, l)
+	default:
+		for ; heightToSync <= confirmedHeight+b.size; heightToSync++ {
+			buffered := b.has(heightToSync)
+			blk, ok := b.take(heightToSync, l)
+			if !ok {
+				if buffered {
+					// The spilled block fails to reload
+					stalledHeight = heightToSync
+				}
+				break
+			}
+			if err := commitBlock(b.bc, b.ap, b.cs, blk); err != nil {
+				// TODO: if the error is because the block has been committed, continue
+				l.Error("Failed to commit the block.", zap.Error(err), zap.Uint64("syncHeight", heightToSync))
+				b.rep.invalid(heightToSync)
+				stalledHeight = b.heightToRequest(blk)
+				break
+			}
+			b.rep.committed(heightToSync)
+			b.commitHeight = heightToSync
+			l.Info("Successfully committed block.", zap.Uint64("syncedHeight", heightToSync))
 		}
-		b.commitHeight = heightToSync
-		l.Info("Successfully committed block.", zap.Uint64("syncedHeight", heightToSync))
 	}
 
 	// clean up on memory leak
-	if len(b.blocks) > int(b.size)*2 {
-		l.Warn("blockBuffer is leaking memory.", zap.Int("bufferSize", len(b.blocks)))
+	if len(b.blocks)+len(b.spilled) > int(b.size)*2 {
+		l.Warn(
+			"blockBuffer is leaking memory.",
+			zap.Int("bufferSize", len(b.blocks)),
+			zap.Int("spilled", len(b.spilled)),
+		)
 		for h := range b.blocks {
 			if h <= confirmedHeight {
-				delete(b.blocks, h)
+				b.drop(h)
+			}
+		}
+		for h := range b.spilled {
+			if h <= confirmedHeight {
+				b.unspill(h, l)
 			}
 		}
 	}
@@ -97,6 +156,169 @@ func (b *blockBuffer) Flush(blk *block.Block) (bool, bCheckinResult) {
 	return heightToSync > blkHeight, bCheckinValid
 }
 
+// importTrusted imports the buffered blocks from height start to end, whose headers have been verified in fast sync,
+// without running their actions. It returns the next height to commit, and the height the flushing stalls at if the
+// block fails to import or is lost
+func (b *blockBuffer) importTrusted(start, end uint64, l *zap.Logger) (uint64, uint64) {
+	height := start
+	defer func() {
+		if height > start {
+			b.cs.Calibrate(height - 1)
+			b.ap.Reset()
+		}
+	}()
+	for ; height <= end && b.fast.trusts(height); height++ {
+		buffered := b.has(height)
+		blk, ok := b.take(height, l)
+		if !ok {
+			if buffered {
+				// The spilled block fails to reload
+				return height, height
+			}
+			break
+		}
+		if err := b.fast.importBlock(b.bc, blk); err != nil {
+			l.Error("Failed to import the block.", zap.Error(err), zap.Uint64("syncHeight", height))
+			b.rep.invalid(height)
+			return height, height
+		}
+		b.rep.committed(height)
+		b.commitHeight = height
+		l.Debug("Successfully imported block.", zap.Uint64("syncedHeight", height))
+	}
+	return height, 0
+}
+
+// commitInBatches commits the buffered blocks from height start to end in batches of commitBatch blocks. It returns the
+// next height to commit, and the height the flushing stalls at if the block fails to commit or is lost
+func (b *blockBuffer) commitInBatches(start, end uint64, l *zap.Logger) (uint64, uint64) {
+	var (
+		height = start
+		lost   uint64
+	)
+	for height <= end {
+		var blks []*block.Block
+		for h := height; h <= end && uint64(len(blks)) < b.commitBatch; h++ {
+			buffered := b.has(h)
+			blk, ok := b.take(h, l)
+			if !ok {
+				if buffered {
+					// The spilled block fails to reload
+					lost = h
+				}
+				break
+			}
+			blks = append(blks, blk)
+		}
+		if len(blks) == 0 {
+			break
+		}
+		n, err := commitBlocks(b.bc, b.ap, b.cs, blks)
+		for _, blk := range blks[:n] {
+			b.rep.committed(blk.Height())
+		}
+		if n > 0 {
+			height += uint64(n)
+			b.commitHeight = height - 1
+			l.Info("Successfully committed blocks.", zap.Uint64("syncedHeight", b.commitHeight), zap.Int("blocks", n))
+		}
+		if n < len(blks) {
+			if n == 0 {
+				l.Error("Failed to commit the block.", zap.Error(err), zap.Uint64("syncHeight", height))
+				b.rep.invalid(height)
+				for _, blk := range blks[1:] {
+					b.put(blk, l)
+				}
+				return height, b.heightToRequest(blks[0])
+			}
+			// The batch ends at an epoch boundary, or the block depends on the states committed in the batch, so it
+			// is retried as the first one of the next batch
+			for _, blk := range blks[n:] {
+				b.put(blk, l)
+			}
+			continue
+		}
+		if lost != 0 {
+			break
+		}
+	}
+	if lost != height {
+		lost = 0
+	}
+	return height, lost
+}
+
+// heightToRequest returns the height to request again once the block fails to commit. If the reorg is enabled and the
+// block isn't built on the tip, the node may have committed a fork, so the block at the tip height is requested to be
+// checked against the committed one. The fork is walked down this way until the branches meet
+func (b *blockBuffer) heightToRequest(blk *block.Block) uint64 {
+	if b.maxReorgDepth > 0 && blk.Height() > 1 && blk.PrevHash() != b.bc.TipHash() {
+		return blk.Height() - 1
+	}
+	return blk.Height()
+}
+
+// reorg rebases the chain onto the competing block if the consensus prefers it to the block committed at its height,
+// e.g., it's endorsed by more delegates once a network partition heals. If the branch of the competing block isn't
+// known, the chain is rolled back below the committed block instead. No more than maxReorgDepth blocks below the
+// highest tip are reverted. The buffered blocks are dropped, as they are either built on the reverted
+// branch or to be synced again. It returns whether the chain is rolled back
+func (b *blockBuffer) reorg(blk *block.Block) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	height := blk.Height()
+	tip := b.bc.TipHeight()
+	if tip > b.highestTip {
+		b.highestTip = tip
+	}
+	if b.maxReorgDepth == 0 || height == 0 || height > tip || b.highestTip-height >= b.maxReorgDepth {
+		return false, nil
+	}
+	committed, err := b.bc.GetBlockByHeight(height)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get committed block %d", height)
+	}
+	if !b.cs.PreferBlock(committed, blk) {
+		return false, nil
+	}
+	l := log.L().With(zap.Uint64("reorgHeight", height), zap.String("source", "blockBuffer"))
+	if err := b.bc.Fork(blk); err == nil {
+		// The branch of the competing block is known, so the chain is rebased onto it, while the committed branch is
+		// kept as side blocks
+		if err := b.bc.Rebase(blk.HashBlock(), b.cs.ValidateBlockFooter); err != nil {
+			return false, errors.Wrapf(err, "failed to rebase chain onto block %d", height)
+		}
+	} else {
+		l.Debug("Failed to keep the competing block as a side block.", zap.Error(err))
+		for h := height; h <= tip; h++ {
+			reverted, err := b.bc.GetBlockByHeight(h)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to get committed block %d", h)
+			}
+			if b.cs.ValidateBlockFooter(reverted) == nil {
+				return false, errors.Wrapf(blockchain.ErrFinalizedBlock, "failed to roll back finalized block %d", h)
+			}
+		}
+		if err := b.bc.RollbackTo(height - 1); err != nil {
+			return false, errors.Wrapf(err, "failed to roll back chain to height %d", height-1)
+		}
+	}
+	for h := range b.blocks {
+		b.drop(h)
+	}
+	for h := range b.spilled {
+		b.unspill(h, l)
+	}
+	b.commitHeight = b.bc.TipHeight()
+	committedHash, preferredHash := committed.HashBlock(), blk.HashBlock()
+	l.Warn(
+		"Reorganized the chain onto the preferred block.",
+		log.Hex("committedHash", committedHash[:]),
+		log.Hex("preferredHash", preferredHash[:]),
+	)
+	return true, nil
+}
+
 // GetBlocksIntervalsToSync returns groups of syncBlocksInterval are missing upto targetHeight.
 func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocksInterval {
 	var (
@@ -118,7 +340,7 @@ func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocks
 	}
 
 	for h := confirmedHeight + 1; h <= targetHeight; h++ {
-		if _, ok := b.blocks[h]; !ok {
+		if !b.has(h) {
 			if !startSet {
 				start = h
 				startSet = true
@@ -132,7 +354,7 @@ func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocks
 	}
 
 	// handle last block
-	if _, ok := b.blocks[targetHeight]; !ok {
+	if !b.has(targetHeight) {
 		if !startSet {
 			start = targetHeight
 		}
@@ -145,3 +367,171 @@ func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocks
 func (b *blockBuffer) bufSize() uint64 {
 	return b.size
 }
+
+// Stats returns the statistics of the buffer
+func (b *blockBuffer) Stats() *iotexapi.BlockSyncBufferStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	stats := &iotexapi.BlockSyncBufferStats{
+		Size:          b.size,
+		InMemory:      uint64(len(b.blocks)),
+		Spilled:       uint64(len(b.spilled)),
+		CommitHeight:  b.commitHeight,
+		InMemoryBytes: b.memBytes,
+	}
+	for _, size := range b.spilled {
+		stats.SpilledBytes += size
+	}
+	return stats
+}
+
+// Close closes and removes the spill store
+func (b *blockBuffer) Close(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.spill == nil {
+		return nil
+	}
+	if err := b.spill.Stop(ctx); err != nil {
+		return err
+	}
+	b.spill = nil
+	b.spilled = nil
+	return os.RemoveAll(b.spillDBConfig.DbPath)
+}
+
+// chosenBlockHash returns the hash of the block which has been chosen at height, and the reason why it is chosen,
+// i.e., it has been committed, or it is waiting in the buffer
+func (b *blockBuffer) chosenBlockHash(height uint64) (hash.Hash256, string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if height <= b.bc.TipHeight() {
+		h, err := b.bc.GetHashByHeight(height)
+		if err != nil {
+			return hash.ZeroHash256, "", false
+		}
+		return h, forkReasonCommitted, true
+	}
+	if blk, ok := b.blocks[height]; ok {
+		return blk.HashBlock(), forkReasonBufferedFirst, true
+	}
+	if _, ok := b.spilled[height]; !ok {
+		return hash.ZeroHash256, "", false
+	}
+	blkBytes, err := b.spill.Get(spillNamespace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		return hash.ZeroHash256, "", false
+	}
+	blk := &block.Block{}
+	if err := blk.Deserialize(blkBytes); err != nil {
+		return hash.ZeroHash256, "", false
+	}
+	return blk.HashBlock(), forkReasonBufferedFirst, true
+}
+
+// has returns whether the block of height is in the buffer
+func (b *blockBuffer) has(height uint64) bool {
+	if _, ok := b.blocks[height]; ok {
+		return true
+	}
+	_, ok := b.spilled[height]
+	return ok
+}
+
+// put adds a block into the buffer. If the blocks in memory exceed the threshold in bytes, the highest ones are spilled
+// to disk, because they are the last to commit, while the lowest one is always kept. If the spilling fails, the block
+// is kept in memory
+func (b *blockBuffer) put(blk *block.Block, l *zap.Logger) {
+	b.keep(blk)
+	for b.spillThreshold > 0 && b.memBytes > b.spillThreshold && len(b.blocks) > 1 {
+		var victim *block.Block
+		for _, inMem := range b.blocks {
+			if victim == nil || inMem.Height() > victim.Height() {
+				victim = inMem
+			}
+		}
+		b.drop(victim.Height())
+		if err := b.spillBlock(victim); err != nil {
+			l.Warn("Failed to spill the block.", zap.Error(err), zap.Uint64("spillHeight", victim.Height()))
+			b.keep(victim)
+			return
+		}
+	}
+}
+
+// keep adds a block into memory, and counts its size if the spilling is enabled
+func (b *blockBuffer) keep(blk *block.Block) {
+	b.blocks[blk.Height()] = blk
+	if b.spillThreshold == 0 {
+		return
+	}
+	if b.blockBytes == nil {
+		b.blockBytes = make(map[uint64]uint64)
+	}
+	size := uint64(proto.Size(blk.ConvertToBlockPb()))
+	b.blockBytes[blk.Height()] = size
+	b.memBytes += size
+}
+
+// drop removes the block of height from memory
+func (b *blockBuffer) drop(height uint64) {
+	delete(b.blocks, height)
+	if size, ok := b.blockBytes[height]; ok {
+		delete(b.blockBytes, height)
+		b.memBytes -= size
+	}
+}
+
+// take removes the block of height from the buffer and returns it, reloading it from disk if it has been spilled
+func (b *blockBuffer) take(height uint64, l *zap.Logger) (*block.Block, bool) {
+	if blk, ok := b.blocks[height]; ok {
+		b.drop(height)
+		return blk, true
+	}
+	if _, ok := b.spilled[height]; !ok {
+		return nil, false
+	}
+	defer b.unspill(height, l)
+	blkBytes, err := b.spill.Get(spillNamespace, byteutil.Uint64ToBytes(height))
+	if err != nil {
+		l.Error("Failed to reload the spilled block.", zap.Error(err), zap.Uint64("spillHeight", height))
+		return nil, false
+	}
+	blk := &block.Block{}
+	if err := blk.Deserialize(blkBytes); err != nil {
+		l.Error("Failed to deserialize the spilled block.", zap.Error(err), zap.Uint64("spillHeight", height))
+		return nil, false
+	}
+	return blk, true
+}
+
+func (b *blockBuffer) spillBlock(blk *block.Block) error {
+	if b.spill == nil {
+		// Blocks spilled before the last stop are useless, so always start from an empty store
+		if err := os.RemoveAll(b.spillDBConfig.DbPath); err != nil {
+			return errors.Wrap(err, "failed to clean up the spill store")
+		}
+		spill := db.NewOnDiskDB(b.spillDBConfig)
+		if err := spill.Start(context.Background()); err != nil {
+			return errors.Wrap(err, "failed to open the spill store")
+		}
+		b.spill = spill
+		b.spilled = make(map[uint64]uint64)
+	}
+	blkBytes, err := blk.Serialize()
+	if err != nil {
+		return err
+	}
+	if err := b.spill.Put(spillNamespace, byteutil.Uint64ToBytes(blk.Height()), blkBytes); err != nil {
+		return err
+	}
+	b.spilled[blk.Height()] = uint64(len(blkBytes))
+	return nil
+}
+
+func (b *blockBuffer) unspill(height uint64, l *zap.Logger) {
+	delete(b.spilled, height)
+	if err := b.spill.Delete(spillNamespace, byteutil.Uint64ToBytes(height)); err != nil {
+		l.Warn("Failed to delete the spilled block.", zap.Error(err), zap.Uint64("spillHeight", height))
+	}
+}
@@ -8,9 +8,15 @@ package blocksync
 
 import (
 	"context"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -21,6 +27,9 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -275,3 +284,340 @@ func TestBlockBufferGetBlocksIntervalsToSync(t *testing.T) {
 	b.Flush(blk)
 	assert.Len(b.GetBlocksIntervalsToSync(0), 0)
 }
+
+func TestBlockBufferSpill(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.DB.DbPath = "blocksync-spill.test"
+	testutil.CleanupPath(t, cfg.DB.DbPath)
+	defer testutil.CleanupPath(t, cfg.DB.DbPath)
+
+	newChain := func() blockchain.Blockchain {
+		chain := blockchain.NewBlockchain(
+			cfg,
+			blockchain.InMemStateFactoryOption(),
+			blockchain.InMemDaoOption(),
+			blockchain.GenesisOption(genesis.Default),
+		)
+		chain.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(chain, genesis.Default.ActionGasLimit))
+		chain.Validator().AddActionValidators(account.NewProtocol())
+		require.NoError(chain.Start(ctx))
+		return chain
+	}
+	// Mint the blocks on another chain, and sync them in the reverse order
+	src := newChain()
+	defer func() { require.NoError(src.Stop(ctx)) }()
+	var blks []*block.Block
+	for i := 0; i < 4; i++ {
+		blk, err := src.MintNewBlock(
+			nil,
+			ta.Keyinfo["producer"].PubKey,
+			ta.Keyinfo["producer"].PriKey,
+			ta.Addrinfo["producer"].String(),
+			0,
+		)
+		require.NoError(err)
+		require.NoError(src.CommitBlock(blk))
+		blks = append(blks, blk)
+	}
+
+	chain := newChain()
+	defer func() { require.NoError(chain.Stop(ctx)) }()
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NoError(err)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).Times(4)
+	cs.EXPECT().Calibrate(gomock.Any()).Times(4)
+
+	b := blockBuffer{
+		bc:             chain,
+		ap:             ap,
+		cs:             cs,
+		blocks:         make(map[uint64]*block.Block),
+		size:           16,
+		spillThreshold: 1,
+		spillDBConfig:  cfg.DB,
+	}
+	// The lowest block is kept in memory, even though it's beyond the threshold
+	for i := 3; i > 0; i-- {
+		moved, re := b.Flush(blks[i])
+		require.False(moved)
+		require.Equal(bCheckinValid, re)
+	}
+	moved, re := b.Flush(blks[2])
+	require.False(moved)
+	require.Equal(bCheckinExisting, re)
+
+	// The highest blocks are spilled, and the lowest one is kept in memory
+	stats := b.Stats()
+	require.Equal(uint64(1), stats.InMemory)
+	require.Equal(uint64(2), stats.Spilled)
+	require.True(stats.SpilledBytes > 0)
+	require.Equal(uint64(proto.Size(blks[1].ConvertToBlockPb())), stats.InMemoryBytes)
+	_, ok := b.blocks[2]
+	require.True(ok)
+	out := b.GetBlocksIntervalsToSync(4)
+	require.Equal([]syncBlocksInterval{{Start: 1, End: 1}}, out)
+
+	// The spilled blocks are reloaded and committed
+	moved, re = b.Flush(blks[0])
+	require.True(moved)
+	require.Equal(bCheckinValid, re)
+	require.Equal(uint64(4), chain.TipHeight())
+	stats = b.Stats()
+	require.Equal(uint64(0), stats.InMemory)
+	require.Equal(uint64(0), stats.Spilled)
+	require.Equal(uint64(0), stats.InMemoryBytes)
+	require.Equal(uint64(4), stats.CommitHeight)
+
+	require.NoError(b.Close(ctx))
+	_, err = os.Stat(cfg.DB.DbPath)
+	require.True(os.IsNotExist(err))
+}
+
+func TestBlockBufferRerequest(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(errors.New("invalid footer")).Times(1)
+
+	rep := newPeerReputation(time.Minute)
+	b := &blockBuffer{
+		bc:     chain,
+		cs:     cs,
+		blocks: make(map[uint64]*block.Block),
+		size:   16,
+		rep:    rep,
+	}
+	var requests []*iotexrpc.BlockSync
+	w := newSyncWorker(
+		1,
+		cfg,
+		func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			requests = append(requests, msg.(*iotexrpc.BlockSync))
+			return nil
+		},
+		func(_ context.Context) ([]peerstore.PeerInfo, error) {
+			return []peerstore.PeerInfo{{ID: peer.ID("peer")}}, nil
+		},
+		b,
+		rep,
+	)
+	b.rerequest = w.RequestHeight
+
+	newBlock := func(height uint64) *block.Block {
+		return block.NewBlockDeprecated(
+			uint32(1),
+			height,
+			hash.Hash256{},
+			testutil.TimestampNow(),
+			ta.Keyinfo["producer"].PubKey,
+			nil,
+		)
+	}
+	// A gap waiting for the block isn't requested again
+	moved, re := b.Flush(newBlock(2))
+	require.False(moved)
+	require.Equal(bCheckinValid, re)
+	require.Empty(requests)
+
+	// The block failing to commit is requested again right away
+	moved, re = b.Flush(newBlock(1))
+	require.False(moved)
+	require.Equal(bCheckinValid, re)
+	require.Equal([]*iotexrpc.BlockSync{{Start: 1, End: 1}}, requests)
+	require.False(b.has(1))
+	require.True(b.has(2))
+}
+
+func TestBlockBufferCommitInBatches(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	ap := mock_actpool.NewMockActPool(ctrl)
+	ap.EXPECT().Reset().Times(2)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().Calibrate(uint64(2)).Times(1)
+	cs.EXPECT().Calibrate(uint64(3)).Times(1)
+
+	var batches [][]uint64
+	results := []struct {
+		n   int
+		err error
+	}{
+		// the whole batch is committed
+		{2, nil},
+		// the batch ends at an epoch boundary
+		{1, nil},
+		// the first block of the batch is invalid
+		{0, errors.New("invalid block")},
+	}
+	chain.EXPECT().CommitBlocks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(blks []*block.Block, _ func(*block.Block) error) (int, error) {
+			heights := make([]uint64, 0, len(blks))
+			for _, blk := range blks {
+				heights = append(heights, blk.Height())
+			}
+			r := results[len(batches)]
+			batches = append(batches, heights)
+			return r.n, r.err
+		},
+	).Times(3)
+
+	rep := newPeerReputation(time.Minute)
+	b := &blockBuffer{
+		bc:          chain,
+		ap:          ap,
+		cs:          cs,
+		blocks:      make(map[uint64]*block.Block),
+		size:        16,
+		commitBatch: 2,
+		rep:         rep,
+	}
+	var requests []*iotexrpc.BlockSync
+	w := newSyncWorker(
+		1,
+		cfg,
+		func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			requests = append(requests, msg.(*iotexrpc.BlockSync))
+			return nil
+		},
+		func(_ context.Context) ([]peerstore.PeerInfo, error) {
+			return []peerstore.PeerInfo{{ID: peer.ID("peer")}}, nil
+		},
+		b,
+		rep,
+	)
+	b.rerequest = w.RequestHeight
+
+	newBlock := func(height uint64) *block.Block {
+		return block.NewBlockDeprecated(
+			uint32(1),
+			height,
+			hash.Hash256{},
+			testutil.TimestampNow(),
+			ta.Keyinfo["producer"].PubKey,
+			nil,
+		)
+	}
+	for h := uint64(2); h <= 5; h++ {
+		moved, re := b.Flush(newBlock(h))
+		require.False(moved)
+		require.Equal(bCheckinValid, re)
+	}
+	require.Empty(batches)
+
+	moved, re := b.Flush(newBlock(1))
+	require.True(moved)
+	require.Equal(bCheckinValid, re)
+	require.Equal([][]uint64{{1, 2}, {3, 4}, {4, 5}}, batches)
+	require.Equal(uint64(3), b.CommitHeight())
+	// the invalid block is requested again, and the blocks after it are kept
+	require.Equal([]*iotexrpc.BlockSync{{Start: 4, End: 4}}, requests)
+	require.False(b.has(4))
+	require.True(b.has(5))
+}
+
+func TestBlockBufferReorg(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tip := uint64(5)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().DoAndReturn(func() uint64 { return tip }).AnyTimes()
+	cs := mock_consensus.NewMockConsensus(ctrl)
+
+	b := &blockBuffer{
+		bc:            chain,
+		cs:            cs,
+		blocks:        make(map[uint64]*block.Block),
+		size:          16,
+		maxReorgDepth: 3,
+	}
+	newBlock := func(height uint64, prevHash hash.Hash256) *block.Block {
+		return block.NewBlockDeprecated(
+			uint32(1),
+			height,
+			prevHash,
+			testutil.TimestampNow(),
+			ta.Keyinfo["producer"].PubKey,
+			nil,
+		)
+	}
+	committed := newBlock(4, hash.Hash256{})
+	competing := newBlock(4, hash.Hash256b([]byte("fork")))
+	chain.EXPECT().GetBlockByHeight(uint64(4)).Return(committed, nil).Times(2)
+	chain.EXPECT().GetBlockByHeight(uint64(4)).Return(competing, nil).Times(4)
+
+	// The block above the tip or too deep below the highest tip isn't a fork to reorganize onto
+	reorged, err := b.reorg(newBlock(6, hash.Hash256{}))
+	require.NoError(err)
+	require.False(reorged)
+	reorged, err = b.reorg(newBlock(2, hash.Hash256{}))
+	require.NoError(err)
+	require.False(reorged)
+
+	// The committed block is preferred
+	cs.EXPECT().PreferBlock(committed, competing).Return(false).Times(1)
+	reorged, err = b.reorg(competing)
+	require.NoError(err)
+	require.False(reorged)
+
+	// The competing block is preferred, and the chain is rebased onto its branch
+	b.blocks[7] = newBlock(7, hash.Hash256{})
+	cs.EXPECT().PreferBlock(committed, competing).Return(true).Times(1)
+	chain.EXPECT().Fork(competing).Return(nil).Times(1)
+	chain.EXPECT().Rebase(competing.HashBlock(), gomock.Any()).DoAndReturn(
+		func(hash.Hash256, func(*block.Block) error) error {
+			tip = 4
+			return nil
+		},
+	).Times(1)
+	reorged, err = b.reorg(competing)
+	require.NoError(err)
+	require.True(reorged)
+	require.Equal(uint64(4), b.commitHeight)
+	require.False(b.has(7))
+
+	// The branch of the competing block isn't known, and the chain isn't rolled back below it once the competing block
+	// is finalized
+	another := newBlock(4, hash.Hash256b([]byte("another fork")))
+	cs.EXPECT().PreferBlock(competing, another).Return(true).Times(2)
+	chain.EXPECT().Fork(another).Return(blockchain.ErrInvalidBlock).Times(2)
+	cs.EXPECT().ValidateBlockFooter(competing).Return(nil).Times(1)
+	reorged, err = b.reorg(another)
+	require.Equal(blockchain.ErrFinalizedBlock, errors.Cause(err))
+	require.False(reorged)
+
+	// The chain is rolled back below the competing block not finalized
+	cs.EXPECT().ValidateBlockFooter(competing).Return(errors.New("insufficient endorsements")).Times(1)
+	chain.EXPECT().RollbackTo(uint64(3)).DoAndReturn(func(height uint64) error {
+		tip = height
+		return nil
+	}).Times(1)
+	reorged, err = b.reorg(another)
+	require.NoError(err)
+	require.True(reorged)
+	require.Equal(uint64(3), b.commitHeight)
+
+	// The depth is counted from the highest tip ever committed
+	reorged, err = b.reorg(newBlock(2, hash.Hash256{}))
+	require.NoError(err)
+	require.False(reorged)
+}
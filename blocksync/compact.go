@@ -0,0 +1,152 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+type (
+	// compactAssembler reconstructs the blocks received in compact form with the actions in the actpool. A block
+	// missing some of the actions is kept until they are delivered by the peer sending the compact block
+	compactAssembler struct {
+		mu         sync.Mutex
+		ap         actpool.ActPool
+		maxPending int
+		pending    map[uint64]*pendingBlock
+	}
+
+	pendingBlock struct {
+		peerID  string
+		block   *iotextypes.Block
+		hashes  []hash.Hash256
+		missing map[uint32]bool
+	}
+)
+
+func newCompactAssembler(ap actpool.ActPool, maxPending int) *compactAssembler {
+	return &compactAssembler{
+		ap:         ap,
+		maxPending: maxPending,
+		pending:    make(map[uint64]*pendingBlock),
+	}
+}
+
+// compactBlockOf converts the block to the compact form
+func compactBlockOf(blk *block.Block) *iotexrpc.CompactBlock {
+	hashes := make([][]byte, 0, len(blk.Actions))
+	for _, selp := range blk.Actions {
+		h := selp.Hash()
+		hashes = append(hashes, h[:])
+	}
+	return &iotexrpc.CompactBlock{
+		Header:       blk.ConvertToBlockHeaderPb(),
+		Footer:       blk.ConvertToBlockFooterPb(),
+		ActionHashes: hashes,
+	}
+}
+
+// assemble reconstructs the compact block sent by the peer. If some of the actions are missing from the actpool, the
+// block is kept pending and the indexes of the missing actions are returned. The pending blocks at or below the tip
+// height are dropped
+func (c *compactAssembler) assemble(
+	peerID string,
+	cb *iotexrpc.CompactBlock,
+	tipHeight uint64,
+) (*iotextypes.Block, []uint32, error) {
+	if cb.GetHeader() == nil {
+		return nil, nil, errors.New("compact block doesn't have a header")
+	}
+	height := cb.GetHeader().GetHeight()
+	pb := &iotextypes.Block{
+		Header:  cb.GetHeader(),
+		Actions: make([]*iotextypes.Action, len(cb.ActionHashes)),
+		Footer:  cb.GetFooter(),
+	}
+	hashes := make([]hash.Hash256, len(cb.ActionHashes))
+	var missing []uint32
+	for i, h := range cb.ActionHashes {
+		if len(h) != len(hash.ZeroHash256) {
+			return nil, nil, errors.Errorf("invalid hash of action %d in compact block %d", i, height)
+		}
+		copy(hashes[i][:], h)
+		selp, err := c.ap.GetActionByHash(hashes[i])
+		if err != nil {
+			missing = append(missing, uint32(i))
+			continue
+		}
+		pb.Actions[i] = selp.Proto()
+	}
+	if len(missing) == 0 {
+		return pb, nil, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for h := range c.pending {
+		if h <= tipHeight {
+			delete(c.pending, h)
+		}
+	}
+	if _, ok := c.pending[height]; !ok && len(c.pending) >= c.maxPending {
+		return nil, nil, errors.Errorf("too many compact blocks waiting for the missing actions, drop block %d", height)
+	}
+	p := &pendingBlock{
+		peerID:  peerID,
+		block:   pb,
+		hashes:  hashes,
+		missing: make(map[uint32]bool, len(missing)),
+	}
+	for _, i := range missing {
+		p.missing[i] = true
+	}
+	c.pending[height] = p
+	return nil, missing, nil
+}
+
+// fill puts the actions delivered by the peer into the pending block, and returns the block if no action is missing
+// any more. The block is dropped if the peer fails to deliver all the missing actions
+func (c *compactAssembler) fill(peerID string, ba *iotexrpc.BlockActions) (*iotextypes.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.pending[ba.Height]
+	if !ok || p.peerID != peerID {
+		return nil, errors.Errorf("no compact block %d from peer %s is waiting for the actions", ba.Height, peerID)
+	}
+	// The block is either completed or dropped
+	delete(c.pending, ba.Height)
+	if len(ba.Indexes) != len(ba.Actions) {
+		return nil, errors.Errorf("%d indexes mismatch %d actions", len(ba.Indexes), len(ba.Actions))
+	}
+	for i, index := range ba.Indexes {
+		if !p.missing[index] {
+			continue
+		}
+		selp := action.SealedEnvelope{}
+		if err := selp.LoadProto(ba.Actions[i]); err != nil {
+			return nil, errors.Wrapf(err, "failed to load action %d of block %d", index, ba.Height)
+		}
+		if selp.Hash() != p.hashes[index] {
+			return nil, errors.Errorf("hash of action %d of block %d mismatches", index, ba.Height)
+		}
+		p.block.Actions[index] = ba.Actions[i]
+		delete(p.missing, index)
+	}
+	if len(p.missing) != 0 {
+		return nil, errors.Errorf("%d actions of block %d are still missing", len(p.missing), ba.Height)
+	}
+	return p.block, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func newCompactTestBlock(t *testing.T, height uint64) block.Block {
+	require := require.New(t)
+	var selps []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		selp, err := testutil.SignedTransfer(ta.Addrinfo["bravo"].String(), ta.Keyinfo["alfa"].PriKey, nonce,
+			big.NewInt(int64(nonce)), []byte{}, testutil.TestGasLimit, big.NewInt(0))
+		require.NoError(err)
+		selps = append(selps, selp)
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(selps...).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	return blk
+}
+
+func TestCompactAssembler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blk := newCompactTestBlock(t, 5)
+	cb := compactBlockOf(&blk)
+	require.Equal(3, len(cb.ActionHashes))
+	require.Equal(blk.Height(), cb.Header.Height)
+
+	// All the actions are in the actpool
+	ap := mock_actpool.NewMockActPool(ctrl)
+	for _, selp := range blk.Actions {
+		ap.EXPECT().GetActionByHash(selp.Hash()).Return(selp, nil).Times(1)
+	}
+	c := newCompactAssembler(ap, 1)
+	pb, missing, err := c.assemble("peer", cb, 4)
+	require.NoError(err)
+	require.Nil(missing)
+	require.True(proto.Equal(blk.ConvertToBlockPb(), pb))
+
+	// The 2nd action is missing
+	ap = mock_actpool.NewMockActPool(ctrl)
+	for i, selp := range blk.Actions {
+		if i == 1 {
+			ap.EXPECT().GetActionByHash(selp.Hash()).Return(action.SealedEnvelope{}, errors.New("not exist")).
+				AnyTimes()
+			continue
+		}
+		ap.EXPECT().GetActionByHash(selp.Hash()).Return(selp, nil).AnyTimes()
+	}
+	c = newCompactAssembler(ap, 1)
+	pb, missing, err = c.assemble("peer", cb, 4)
+	require.NoError(err)
+	require.Nil(pb)
+	require.Equal([]uint32{1}, missing)
+
+	// The pending blocks are limited
+	other := newCompactTestBlock(t, 6)
+	_, _, err = c.assemble("peer", compactBlockOf(&other), 4)
+	require.Error(err)
+
+	// The actions are only accepted from the peer sending the compact block
+	ba := &iotexrpc.BlockActions{
+		Height:  5,
+		Indexes: []uint32{1},
+		Actions: []*iotextypes.Action{blk.Actions[1].Proto()},
+	}
+	_, err = c.fill("other", ba)
+	require.Error(err)
+	pb, err = c.fill("peer", ba)
+	require.NoError(err)
+	require.True(proto.Equal(blk.ConvertToBlockPb(), pb))
+	_, err = c.fill("peer", ba)
+	require.Error(err)
+
+	// The block is dropped if the delivered action mismatches the hash
+	_, _, err = c.assemble("peer", cb, 4)
+	require.NoError(err)
+	ba.Actions[0] = blk.Actions[0].Proto()
+	_, err = c.fill("peer", ba)
+	require.Error(err)
+	require.Empty(c.pending)
+
+	// The pending blocks at or below the tip are dropped
+	_, _, err = c.assemble("peer", cb, 4)
+	require.NoError(err)
+	_, missing, err = c.assemble("peer", compactBlockOf(&other), 5)
+	require.NoError(err)
+	require.Equal([]uint32{1}, missing)
+	require.Equal(1, len(c.pending))
+
+	_, _, err = c.assemble("peer", &iotexrpc.CompactBlock{}, 4)
+	require.Error(err)
+}
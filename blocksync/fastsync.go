@@ -0,0 +1,305 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// headerWindow is the max number of headers requested in a sync round
+	headerWindow = 4096
+	// headerRequestSize is the max number of headers requested from a peer at a time
+	headerRequestSize = 128
+	// snapshotFetchTimeout is how long the download of the state snapshot may take
+	snapshotFetchTimeout = 30 * time.Minute
+)
+
+type (
+	// fastSync syncs the chain up to a trusted checkpoint. The headers are requested in compact blocks, and verified
+	// from the checkpoint hash downward along their previous block hashes. Once the headers down to the tip are
+	// verified, the blocks up to the checkpoint are imported without running their actions, as long as their hashes
+	// match the verified ones, and the states at the checkpoint are loaded from a snapshot
+	fastSync struct {
+		mu               sync.RWMutex
+		checkpointHeight uint64
+		checkpointHash   hash.Hash256
+		stateDigest      hash.Hash256
+		snapshotURL      string
+		verifiedHeight   uint64                    // lowest height whose header has been verified
+		expectedHash     hash.Hash256              // hash the header below the verified height has to match
+		verified         map[uint64]hash.Hash256   // height -> verified block hash
+		pending          map[uint64]*pendingHeader // headers received but not linked to the verified ones yet
+		download         *snapshotDownload         // downloads the snapshot from the peers, nil if fetched from URL
+	}
+
+	pendingHeader struct {
+		hash     hash.Hash256
+		prevHash hash.Hash256
+	}
+)
+
+func newFastSync(checkpointHeight uint64, checkpointHash, stateDigest hash.Hash256, snapshotURL string) *fastSync {
+	f := &fastSync{
+		checkpointHeight: checkpointHeight,
+		checkpointHash:   checkpointHash,
+		stateDigest:      stateDigest,
+		snapshotURL:      snapshotURL,
+		verifiedHeight:   checkpointHeight + 1,
+		expectedHash:     checkpointHash,
+		verified:         make(map[uint64]hash.Hash256),
+		pending:          make(map[uint64]*pendingHeader),
+	}
+	if snapshotURL == config.SnapshotFromPeers {
+		f.download = newSnapshotDownload(checkpointHeight, stateDigest)
+	}
+	return f
+}
+
+// syncingHeaders returns whether the headers between the tip and the checkpoint haven't been all verified
+func (f *fastSync) syncingHeaders(tipHeight uint64) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return tipHeight < f.checkpointHeight && f.verifiedHeight > tipHeight+1
+}
+
+// headerIntervals returns groups of the headers missing right below the verified ones, up to headerWindow headers.
+// Each group has no more than headerRequestSize headers, so that they are requested from different peers
+func (f *fastSync) headerIntervals(tipHeight uint64) []syncBlocksInterval {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var (
+		start    uint64
+		startSet bool
+		bi       []syncBlocksInterval
+	)
+	lowest := tipHeight + 1
+	if f.verifiedHeight > lowest+headerWindow {
+		lowest = f.verifiedHeight - headerWindow
+	}
+	for h := lowest; h < f.verifiedHeight; h++ {
+		if _, ok := f.pending[h]; !ok {
+			if startSet && h-start == headerRequestSize {
+				bi = append(bi, syncBlocksInterval{Start: start, End: h - 1})
+				startSet = false
+			}
+			if !startSet {
+				start = h
+				startSet = true
+			}
+			continue
+		}
+		if startSet {
+			bi = append(bi, syncBlocksInterval{Start: start, End: h - 1})
+			startSet = false
+		}
+	}
+	if startSet {
+		bi = append(bi, syncBlocksInterval{Start: start, End: f.verifiedHeight - 1})
+	}
+	return bi
+}
+
+// addHeader verifies the header against the verified ones above it. A header which can't be linked yet is kept
+// pending, and a header breaking the chain of the previous block hashes is rejected
+func (f *fastSync) addHeader(pb *iotextypes.BlockHeader, tipHeight uint64) error {
+	blk := &block.Block{}
+	blk.ConvertFromBlockHeaderPb(&iotextypes.Block{Header: pb})
+	height := blk.Height()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if height <= tipHeight || height >= f.verifiedHeight {
+		return nil
+	}
+	header := &pendingHeader{hash: blk.HashBlock(), prevHash: blk.PrevHash()}
+	if height != f.verifiedHeight-1 {
+		f.pending[height] = header
+		return nil
+	}
+	for header != nil {
+		if header.hash != f.expectedHash {
+			return errors.Errorf("hash %x of header %d doesn't match the verified chain", header.hash, height)
+		}
+		f.verified[height] = header.hash
+		f.verifiedHeight = height
+		f.expectedHash = header.prevHash
+		height--
+		if height <= tipHeight {
+			break
+		}
+		header = f.pending[height]
+		delete(f.pending, height)
+	}
+	if f.verifiedHeight <= tipHeight+1 {
+		log.L().Info("Verified the headers down from the checkpoint.", zap.Uint64("checkpoint", f.checkpointHeight))
+	}
+	return nil
+}
+
+// fetchingSnapshot returns whether the chunks of the state snapshot at the checkpoint are to be requested from the
+// peers, which starts once the headers down to the tip are verified, and ends once the snapshot is downloaded
+func (f *fastSync) fetchingSnapshot(bc blockchain.Blockchain) bool {
+	if f.download == nil || f.download.result() != nil {
+		return false
+	}
+	tipHeight := bc.TipHeight()
+	if tipHeight > f.checkpointHeight || f.syncingHeaders(tipHeight) {
+		return false
+	}
+	if tipHeight < f.checkpointHeight {
+		return true
+	}
+	stateHeight, err := bc.GetFactory().Height()
+	return err == nil && stateHeight < f.checkpointHeight
+}
+
+// trusts returns whether the block of height could be imported without running its actions
+func (f *fastSync) trusts(height uint64) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	_, ok := f.verified[height]
+	return ok
+}
+
+// importBlock imports the block whose hash has been verified. The states at the checkpoint are loaded once the
+// checkpoint block is imported, and the snapshot is fetched beforehand, so that the checkpoint block is retried if
+// the snapshot isn't available
+func (f *fastSync) importBlock(bc blockchain.Blockchain, blk *block.Block) error {
+	height := blk.Height()
+	f.mu.RLock()
+	expected, ok := f.verified[height]
+	f.mu.RUnlock()
+	if !ok {
+		return errors.Errorf("header of block %d hasn't been verified", height)
+	}
+	if blkHash := blk.HashBlock(); blkHash != expected {
+		return errors.Errorf("hash %x of block %d doesn't match the verified one %x", blkHash, height, expected)
+	}
+	if txRoot := blk.CalculateTxRoot(); txRoot != blk.TxRoot() {
+		return errors.Errorf("tx root %x of block %d doesn't match the header", txRoot, height)
+	}
+	var snapshot *blockchain.StateSnapshot
+	if height == f.checkpointHeight {
+		var err error
+		if snapshot, err = f.fetchSnapshot(); err != nil {
+			return err
+		}
+	}
+	if err := bc.ImportTrustedBlock(blk); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	delete(f.verified, height)
+	f.mu.Unlock()
+	if snapshot == nil {
+		return nil
+	}
+	if err := bc.LoadStateSnapshot(snapshot); err != nil {
+		// The node loads the snapshot again on restart, since the states can't catch up with the tip otherwise
+		log.L().Panic("Failed to load the states at the checkpoint.", zap.Error(err), zap.Uint64("height", height))
+	}
+	log.L().Info("Fast sync reached the checkpoint.", zap.Uint64("height", height))
+	return nil
+}
+
+// resume loads the states at the checkpoint, if the node stopped after importing the checkpoint block but before
+// loading the states. If the snapshot is downloaded from the peers, the states are loaded once the download completes
+func (f *fastSync) resume(bc blockchain.Blockchain) error {
+	if bc.TipHeight() != f.checkpointHeight {
+		return nil
+	}
+	if f.download != nil && f.download.result() == nil {
+		return nil
+	}
+	stateHeight, err := bc.GetFactory().Height()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the state height")
+	}
+	if stateHeight >= f.checkpointHeight {
+		return nil
+	}
+	snapshot, err := f.fetchSnapshot()
+	if err != nil {
+		return err
+	}
+	return bc.LoadStateSnapshot(snapshot)
+}
+
+// fetchSnapshot reads the state snapshot from the snapshot URL, and checks it against the checkpoint. The snapshot
+// downloaded from the peers has been checked already
+func (f *fastSync) fetchSnapshot() (*blockchain.StateSnapshot, error) {
+	if f.download != nil {
+		if snapshot := f.download.result(); snapshot != nil {
+			return snapshot, nil
+		}
+		return nil, errors.New("state snapshot hasn't been downloaded from the peers yet")
+	}
+	path := f.snapshotURL
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		downloaded, err := downloadSnapshot(f.snapshotURL)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(downloaded)
+		path = downloaded
+	}
+	snapshot, digest, err := blockchain.ReadStateSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	if digest != f.stateDigest {
+		return nil, errors.Errorf("digest %x of state snapshot doesn't match the checkpoint %x", digest, f.stateDigest)
+	}
+	if snapshot.Height != f.checkpointHeight {
+		return nil, errors.Errorf(
+			"state snapshot height %d doesn't match the checkpoint height %d",
+			snapshot.Height,
+			f.checkpointHeight,
+		)
+	}
+	return snapshot, nil
+}
+
+// downloadSnapshot downloads the state snapshot into a temporary file, and returns its path
+func downloadSnapshot(url string) (string, error) {
+	client := &http.Client{Timeout: snapshotFetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to fetch state snapshot from %s", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to fetch state snapshot from %s: %s", url, resp.Status)
+	}
+	file, err := ioutil.TempFile("", "state-snapshot")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create state snapshot file")
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		os.Remove(file.Name())
+		return "", errors.Wrapf(err, "failed to download state snapshot from %s", url)
+	}
+	return file.Name(), nil
+}
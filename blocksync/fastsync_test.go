@@ -0,0 +1,197 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// newTestChain builds n blocks linked by their previous block hashes
+func newTestChain(t *testing.T, n uint64) []*block.Block {
+	var (
+		blks     []*block.Block
+		prevHash hash.Hash256
+	)
+	for height := uint64(1); height <= n; height++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+		require.NoError(t, err)
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+	return blks
+}
+
+func TestFastSyncHeaders(t *testing.T) {
+	require := require.New(t)
+	blks := newTestChain(t, 10)
+	f := newFastSync(8, blks[7].HashBlock(), hash.ZeroHash256, "")
+
+	require.True(f.syncingHeaders(0))
+	require.Equal([]syncBlocksInterval{{Start: 1, End: 8}}, f.headerIntervals(0))
+	require.Equal([]syncBlocksInterval{{Start: 4, End: 8}}, f.headerIntervals(3))
+
+	// The headers above the checkpoint or at the tip are ignored, and the ones not linked yet are kept pending
+	require.NoError(f.addHeader(blks[9].ConvertToBlockHeaderPb(), 0))
+	require.NoError(f.addHeader(blks[5].ConvertToBlockHeaderPb(), 0))
+	require.NoError(f.addHeader(blks[6].ConvertToBlockHeaderPb(), 0))
+	require.False(f.trusts(7))
+	require.Equal([]syncBlocksInterval{{Start: 1, End: 5}, {Start: 8, End: 8}}, f.headerIntervals(0))
+
+	// The checkpoint header links the pending ones
+	require.NoError(f.addHeader(blks[7].ConvertToBlockHeaderPb(), 0))
+	for height := uint64(6); height <= 8; height++ {
+		require.True(f.trusts(height))
+	}
+	require.False(f.trusts(5))
+	require.Equal([]syncBlocksInterval{{Start: 1, End: 5}}, f.headerIntervals(0))
+
+	// A header of another chain is rejected
+	forked, err := block.NewTestingBuilder().
+		SetHeight(5).
+		SetPrevBlockHash(blks[3].HashBlock()).
+		SetTimeStamp(0).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.Error(f.addHeader(forked.ConvertToBlockHeaderPb(), 0))
+	require.False(f.trusts(5))
+
+	for height := uint64(1); height <= 5; height++ {
+		require.NoError(f.addHeader(blks[height-1].ConvertToBlockHeaderPb(), 0))
+	}
+	require.True(f.trusts(1))
+	require.False(f.syncingHeaders(0))
+	require.Empty(f.headerIntervals(0))
+}
+
+func TestFastSyncImportBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blks := newTestChain(t, 3)
+	snapshot := &blockchain.StateSnapshot{
+		Height:   3,
+		Accounts: []*blockchain.AccountSnapshot{{Address: ta.Addrinfo["alfa"].String(), Balance: "100"}},
+		States:   []*blockchain.StateEntry{},
+	}
+	path := filepath.Join(os.TempDir(), "fastsync-snapshot.json")
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+	digest, err := blockchain.WriteStateSnapshot(path, snapshot)
+	require.NoError(err)
+
+	f := newFastSync(3, blks[2].HashBlock(), digest, path)
+	for _, blk := range blks {
+		require.NoError(f.addHeader(blk.ConvertToBlockHeaderPb(), 0))
+	}
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+
+	// A block not matching the verified hash is rejected
+	other, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(0).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.Error(f.importBlock(chain, &other))
+
+	chain.EXPECT().ImportTrustedBlock(blks[0]).Return(nil).Times(1)
+	require.NoError(f.importBlock(chain, blks[0]))
+	require.False(f.trusts(1))
+	require.Error(f.importBlock(chain, blks[0]))
+
+	// The states are loaded along with the checkpoint block
+	chain.EXPECT().ImportTrustedBlock(blks[1]).Return(nil).Times(1)
+	require.NoError(f.importBlock(chain, blks[1]))
+	chain.EXPECT().ImportTrustedBlock(blks[2]).Return(nil).Times(1)
+	chain.EXPECT().LoadStateSnapshot(gomock.Any()).DoAndReturn(func(s *blockchain.StateSnapshot) error {
+		require.Equal(snapshot, s)
+		return nil
+	}).Times(1)
+	require.NoError(f.importBlock(chain, blks[2]))
+
+	// The checkpoint block is kept for retry if the snapshot doesn't match
+	f = newFastSync(3, blks[2].HashBlock(), hash.ZeroHash256, path)
+	for _, blk := range blks {
+		require.NoError(f.addHeader(blk.ConvertToBlockHeaderPb(), 0))
+	}
+	_, err = f.fetchSnapshot()
+	require.Error(err)
+	chain.EXPECT().ImportTrustedBlock(blks[0]).Return(nil).Times(1)
+	chain.EXPECT().ImportTrustedBlock(blks[1]).Return(nil).Times(1)
+	require.NoError(f.importBlock(chain, blks[0]))
+	require.NoError(f.importBlock(chain, blks[1]))
+	require.Error(f.importBlock(chain, blks[2]))
+	require.True(f.trusts(3))
+}
+
+func TestFastSyncSnapshotFromPeers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blks := newTestChain(t, 3)
+	snapshot := newTestSnapshot(3)
+	digest, err := snapshot.Digest()
+	require.NoError(err)
+	f := newFastSync(3, blks[2].HashBlock(), digest, config.SnapshotFromPeers)
+	require.NotNil(f.download)
+
+	var tipHeight uint64
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().DoAndReturn(func() uint64 { return tipHeight }).AnyTimes()
+	// The snapshot isn't requested until the headers are verified
+	require.False(f.fetchingSnapshot(chain))
+	for _, blk := range blks {
+		require.NoError(f.addHeader(blk.ConvertToBlockHeaderPb(), 0))
+	}
+	require.True(f.fetchingSnapshot(chain))
+	_, err = f.fetchSnapshot()
+	require.Error(err)
+
+	// The checkpoint block waits for the snapshot
+	chain.EXPECT().ImportTrustedBlock(gomock.Any()).Return(nil).Times(3)
+	require.NoError(f.importBlock(chain, blks[0]))
+	require.NoError(f.importBlock(chain, blks[1]))
+	require.Error(f.importBlock(chain, blks[2]))
+	require.True(f.trusts(3))
+
+	data, err := json.Marshal(snapshot)
+	require.NoError(err)
+	// A single chunk is the merkle root itself
+	root := hash.Hash256b(data)
+	completed, err := f.download.add(&iotexrpc.SnapshotChunk{
+		Height: 3,
+		Index:  0,
+		Count:  1,
+		Root:   root[:],
+		Data:   data,
+	})
+	require.NoError(err)
+	require.True(completed)
+	require.False(f.fetchingSnapshot(chain))
+	chain.EXPECT().LoadStateSnapshot(snapshot).Return(nil).Times(1)
+	require.NoError(f.importBlock(chain, blks[2]))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+const (
+	// forkSourceConsensus indicates the competing block is a committed block broadcast by the delegates
+	forkSourceConsensus = "consensus"
+	// forkSourceSync indicates the competing block is delivered by a peer in response to a sync request
+	forkSourceSync = "sync"
+
+	// forkReasonCommitted indicates the chosen block has been committed to the chain
+	forkReasonCommitted = "committed"
+	// forkReasonBufferedFirst indicates the chosen block was received first, and is waiting in the buffer to commit
+	forkReasonBufferedFirst = "bufferedFirst"
+
+	// maxForkEvents is the number of the recent fork events kept
+	maxForkEvents = 128
+)
+
+var forkMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_blocksync_fork_events",
+		Help: "Competing blocks observed at the same height",
+	},
+	[]string{"source", "reason"},
+)
+
+func init() {
+	prometheus.MustRegister(forkMtc)
+}
+
+// forkTracker records the competing blocks observed at the same height, keeping the recent ones
+type forkTracker struct {
+	mu     sync.Mutex
+	events []*iotexapi.ForkEvent
+}
+
+func newForkTracker() *forkTracker {
+	return &forkTracker{}
+}
+
+// observe records a fork event if the competing block differs from the chosen one. The same competing block received
+// repeatedly is recorded once
+func (f *forkTracker) observe(height uint64, chosen, competing hash.Hash256, source, reason string, now time.Time) {
+	if chosen == competing {
+		return
+	}
+	chosenHash := hex.EncodeToString(chosen[:])
+	competingHash := hex.EncodeToString(competing[:])
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range f.events {
+		if e.Height == height && e.ChosenHash == chosenHash && e.CompetingHash == competingHash {
+			return
+		}
+	}
+	f.events = append(f.events, &iotexapi.ForkEvent{
+		Height:        height,
+		ChosenHash:    chosenHash,
+		CompetingHash: competingHash,
+		Source:        source,
+		Reason:        reason,
+		Timestamp:     now.Unix(),
+	})
+	if len(f.events) > maxForkEvents {
+		f.events = f.events[len(f.events)-maxForkEvents:]
+	}
+	forkMtc.WithLabelValues(source, reason).Inc()
+	log.L().Warn(
+		"Observed competing blocks at the same height.",
+		zap.Uint64("height", height),
+		zap.String("chosenHash", chosenHash),
+		zap.String("competingHash", competingHash),
+		zap.String("source", source),
+		zap.String("reason", reason),
+	)
+}
+
+// recent returns the recorded fork events, the latest first
+func (f *forkTracker) recent() []*iotexapi.ForkEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events := make([]*iotexapi.ForkEvent, len(f.events))
+	for i, e := range f.events {
+		events[len(f.events)-1-i] = e
+	}
+	return events
+}
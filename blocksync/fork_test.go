@@ -0,0 +1,54 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestForkTracker(t *testing.T) {
+	require := require.New(t)
+
+	f := newForkTracker()
+	chosen := hash.Hash256b([]byte("chosen"))
+	competing := hash.Hash256b([]byte("competing"))
+	now := time.Unix(100, 0)
+
+	// The same block isn't a fork
+	f.observe(1, chosen, chosen, forkSourceSync, forkReasonCommitted, now)
+	require.Empty(f.recent())
+
+	f.observe(1, chosen, competing, forkSourceSync, forkReasonCommitted, now)
+	// The same competing block is recorded once
+	f.observe(1, chosen, competing, forkSourceConsensus, forkReasonCommitted, now)
+	f.observe(2, competing, chosen, forkSourceConsensus, forkReasonBufferedFirst, now)
+	events := f.recent()
+	require.Equal(2, len(events))
+	require.Equal(uint64(2), events[0].Height)
+	require.Equal(forkSourceConsensus, events[0].Source)
+	require.Equal(forkReasonBufferedFirst, events[0].Reason)
+	require.Equal(uint64(1), events[1].Height)
+	require.Equal(hex.EncodeToString(chosen[:]), events[1].ChosenHash)
+	require.Equal(hex.EncodeToString(competing[:]), events[1].CompetingHash)
+	require.Equal(forkSourceSync, events[1].Source)
+	require.Equal(int64(100), events[1].Timestamp)
+
+	// Only the recent events are kept
+	for i := 0; i < maxForkEvents; i++ {
+		f.observe(uint64(i+3), chosen, competing, forkSourceSync, forkReasonCommitted, now)
+	}
+	events = f.recent()
+	require.Equal(maxForkEvents, len(events))
+	require.Equal(uint64(maxForkEvents+2), events[0].Height)
+	require.Equal(uint64(3), events[maxForkEvents-1].Height)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+// syncProgress tracks the sync speed since the block syncer starts, and publishes the sync status to the subscribers
+type syncProgress struct {
+	mu             sync.RWMutex
+	startingHeight uint64
+	lastHeight     uint64
+	lastTime       time.Time
+	blocksPerSec   float64
+	subscribers    map[chan<- *iotexapi.BlockSyncStatus]struct{}
+}
+
+func newSyncProgress() *syncProgress {
+	return &syncProgress{subscribers: make(map[chan<- *iotexapi.BlockSyncStatus]struct{})}
+}
+
+// reset starts tracking from the height
+func (p *syncProgress) reset(height uint64, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.startingHeight = height
+	p.lastHeight = height
+	p.lastTime = now
+	p.blocksPerSec = 0
+}
+
+// update measures the sync speed since the last update
+func (p *syncProgress) update(height uint64, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if elapsed := now.Sub(p.lastTime); elapsed > 0 && height >= p.lastHeight {
+		p.blocksPerSec = float64(height-p.lastHeight) / elapsed.Seconds()
+	}
+	p.lastHeight = height
+	p.lastTime = now
+}
+
+// status returns the sync status with the current and target heights, and the peers in use
+func (p *syncProgress) status(currentHeight, targetHeight uint64, peers []string) *iotexapi.BlockSyncStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return &iotexapi.BlockSyncStatus{
+		StartingHeight:  p.startingHeight,
+		CurrentHeight:   currentHeight,
+		TargetHeight:    targetHeight,
+		Peers:           peers,
+		BlocksPerSecond: p.blocksPerSec,
+	}
+}
+
+func (p *syncProgress) subscribe(ch chan<- *iotexapi.BlockSyncStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers[ch] = struct{}{}
+}
+
+func (p *syncProgress) unsubscribe(ch chan<- *iotexapi.BlockSyncStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.subscribers, ch)
+}
+
+// publish sends the status to the subscribers. A subscriber which isn't ready to receive misses the status, instead of
+// blocking the block syncer
+func (p *syncProgress) publish(status *iotexapi.BlockSyncStatus) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for ch := range p.subscribers {
+		select {
+		case ch <- status:
+		default:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+func TestSyncProgress(t *testing.T) {
+	require := require.New(t)
+
+	p := newSyncProgress()
+	start := time.Unix(100, 0)
+	p.reset(10, start)
+	status := p.status(10, 20, nil)
+	require.Equal(uint64(10), status.StartingHeight)
+	require.Equal(uint64(20), status.TargetHeight)
+	require.Equal(float64(0), status.BlocksPerSecond)
+
+	// The speed is measured since the last update
+	p.update(30, start.Add(2*time.Second))
+	require.Equal(float64(10), p.status(30, 40, nil).BlocksPerSecond)
+	p.update(33, start.Add(5*time.Second))
+	status = p.status(33, 40, []string{"peer"})
+	require.Equal(uint64(10), status.StartingHeight)
+	require.Equal(uint64(33), status.CurrentHeight)
+	require.Equal([]string{"peer"}, status.Peers)
+	require.Equal(float64(1), status.BlocksPerSecond)
+
+	// A subscriber not ready to receive misses the status
+	ch := make(chan *iotexapi.BlockSyncStatus, 1)
+	p.subscribe(ch)
+	p.publish(status)
+	p.publish(p.status(34, 40, nil))
+	require.Equal(status, <-ch)
+	require.Empty(ch)
+	p.unsubscribe(ch)
+	p.publish(status)
+	require.Empty(ch)
+}
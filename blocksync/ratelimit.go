@@ -0,0 +1,79 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// syncRequestLimiter limits the number of sync requests served for a peer within a window. A peer exceeding the
+	// limit is banned for a while, during which its requests are dropped
+	syncRequestLimiter struct {
+		mu          sync.Mutex
+		limit       uint64 // 0 means no limit
+		window      time.Duration
+		banDuration time.Duration
+		windows     map[string]*requestWindow
+		banned      map[string]time.Time // peer -> time the ban ends
+	}
+
+	requestWindow struct {
+		start time.Time
+		count uint64
+	}
+)
+
+func newSyncRequestLimiter(limit uint64, window, banDuration time.Duration) *syncRequestLimiter {
+	return &syncRequestLimiter{
+		limit:       limit,
+		window:      window,
+		banDuration: banDuration,
+		windows:     make(map[string]*requestWindow),
+		banned:      make(map[string]time.Time),
+	}
+}
+
+// allow returns whether a sync request from the peer is served, and whether the peer is banned by the request
+func (l *syncRequestLimiter) allow(peerID string, now time.Time) (bool, bool) {
+	if l.limit == 0 {
+		return true, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+	if _, ok := l.banned[peerID]; ok {
+		return false, false
+	}
+	w, ok := l.windows[peerID]
+	if !ok {
+		w = &requestWindow{start: now}
+		l.windows[peerID] = w
+	}
+	w.count++
+	if w.count <= l.limit {
+		return true, false
+	}
+	delete(l.windows, peerID)
+	l.banned[peerID] = now.Add(l.banDuration)
+	return false, true
+}
+
+// prune drops the ended bans and windows
+func (l *syncRequestLimiter) prune(now time.Time) {
+	for id, end := range l.banned {
+		if !now.Before(end) {
+			delete(l.banned, id)
+		}
+	}
+	for id, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, id)
+		}
+	}
+}
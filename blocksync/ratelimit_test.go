@@ -0,0 +1,46 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRequestLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	allow := func(l *syncRequestLimiter, peerID string, at time.Time) []bool {
+		allowed, banned := l.allow(peerID, at)
+		return []bool{allowed, banned}
+	}
+
+	// No limit
+	l := newSyncRequestLimiter(0, time.Second, time.Minute)
+	for i := 0; i < 100; i++ {
+		require.Equal([]bool{true, false}, allow(l, "peer", now))
+	}
+
+	l = newSyncRequestLimiter(2, 10*time.Second, time.Minute)
+	require.Equal([]bool{true, false}, allow(l, "peer", now))
+	require.Equal([]bool{true, false}, allow(l, "peer", now.Add(time.Second)))
+	require.Equal([]bool{true, false}, allow(l, "other", now.Add(time.Second)))
+	// The requests are counted again in a new window
+	require.Equal([]bool{true, false}, allow(l, "peer", now.Add(10*time.Second)))
+	require.Equal([]bool{true, false}, allow(l, "peer", now.Add(11*time.Second)))
+	// The peer exceeding the limit is banned
+	require.Equal([]bool{false, true}, allow(l, "peer", now.Add(12*time.Second)))
+	require.Equal([]bool{false, false}, allow(l, "peer", now.Add(30*time.Second)))
+	require.Equal([]bool{true, false}, allow(l, "other", now.Add(30*time.Second)))
+	// The ban ends
+	require.Equal([]bool{true, false}, allow(l, "peer", now.Add(72*time.Second)))
+	require.Equal(1, len(l.windows))
+	require.Empty(l.banned)
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+const (
+	// maxPeerScore is the score of a peer without any misbehavior
+	maxPeerScore = 100
+	// minSyncPeerScore is the min score of a peer to be asked for blocks, unless no peer reaches it
+	minSyncPeerScore = 0
+	// invalidBlockPenalty is the penalty of each invalid block a peer delivers
+	invalidBlockPenalty = 25
+	// stalledRequestPenalty is the penalty of each sync request a peer doesn't respond to in time
+	stalledRequestPenalty = 10
+	// maxLatencyPenalty caps the penalty of the response latency, which is 1 per 100ms
+	maxLatencyPenalty = 50
+	// penaltyDecayPeriods is the number of stall timeouts without a new penalty, after which a penalty of the peer is
+	// forgiven, so that a peer recovered isn't shunned forever
+	penaltyDecayPeriods = 10
+	// peerIdlePeriods is the number of stall timeouts without any request or block, after which a peer is forgotten
+	peerIdlePeriods = 100
+	// maxTrackedPeers caps the number of peers tracked, beyond which the least recently active ones are forgotten
+	maxTrackedPeers = 1000
+	// deliveredHeightPeriods is the number of stall timeouts without raising the highest height a peer delivers,
+	// after which the height no longer counts toward the quorum height
+	deliveredHeightPeriods = 10
+)
+
+type (
+	// peerReputation tracks how the peers serve the sync requests, i.e., the response latency, the invalid blocks
+	// and the stalled requests, and scores the peers accordingly
+	peerReputation struct {
+		mu           sync.Mutex
+		stallTimeout time.Duration
+		peers        map[string]*peerRecord
+		origins      map[uint64]string // height -> peer which delivered the block
+	}
+
+	peerRecord struct {
+		requests  uint64
+		responses uint64
+		stalled   uint64
+		invalid   uint64
+		latency   time.Duration // moving average of the time to the first block of a request
+		pending   []*syncRequest
+		active    time.Time // last time a request is sent to or a block is received from the peer
+		penalized time.Time // last time a penalty is added or forgiven, zero if a penalty is just added
+		delivered uint64    // highest height of the blocks delivered in response to the requests
+		raised    time.Time // last time the highest delivered height is raised
+	}
+
+	syncRequest struct {
+		start     uint64
+		end       uint64
+		sent      time.Time
+		responded bool
+	}
+)
+
+func newPeerReputation(stallTimeout time.Duration) *peerReputation {
+	return &peerReputation{
+		stallTimeout: stallTimeout,
+		peers:        make(map[string]*peerRecord),
+		origins:      make(map[uint64]string),
+	}
+}
+
+// requested records a sync request of blocks from start to end sent to a peer
+func (r *peerReputation) requested(peerID string, start, end uint64, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := r.peer(peerID)
+	p.requests++
+	p.active = now
+	p.pending = append(p.pending, &syncRequest{start: start, end: end, sent: now})
+}
+
+// responded records a block of height delivered by a peer
+func (r *peerReputation) responded(peerID string, height uint64, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := r.peer(peerID)
+	p.responses++
+	p.active = now
+	r.origins[height] = peerID
+	for i, req := range p.pending {
+		if height < req.start || height > req.end {
+			continue
+		}
+		if height > p.delivered {
+			p.delivered = height
+			p.raised = now
+		}
+		if !req.responded {
+			req.responded = true
+			sample := now.Sub(req.sent)
+			if p.latency == 0 {
+				p.latency = sample
+			} else {
+				p.latency = (p.latency*7 + sample) / 8
+			}
+		}
+		if height == req.end {
+			p.pending = append(p.pending[:i], p.pending[i+1:]...)
+		}
+		return
+	}
+}
+
+// invalid penalizes the peer which delivered the block of height, if the block fails to commit
+func (r *peerReputation) invalid(height uint64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	peerID, ok := r.origins[height]
+	if !ok {
+		return
+	}
+	delete(r.origins, height)
+	p := r.peer(peerID)
+	p.invalid++
+	p.penalized = time.Time{}
+}
+
+// committed forgets the origin of the block of height
+func (r *peerReputation) committed(height uint64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.origins, height)
+}
+
+// expire penalizes the peers for the requests which haven't been responded in time, and drops the requests and the
+// origins of the heights which have been confirmed. It also forgives the penalties which are old enough, and forgets
+// the peers idle for long or beyond the max number of peers tracked
+func (r *peerReputation) expire(confirmedHeight uint64, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, p := range r.peers {
+		pending := p.pending[:0]
+		for _, req := range p.pending {
+			switch {
+			case req.end <= confirmedHeight:
+			case now.Sub(req.sent) < r.stallTimeout:
+				pending = append(pending, req)
+			case !req.responded:
+				p.stalled++
+				p.penalized = time.Time{}
+			}
+		}
+		p.pending = pending
+		if len(p.pending) == 0 && now.Sub(p.active) >= peerIdlePeriods*r.stallTimeout {
+			delete(r.peers, id)
+			continue
+		}
+		p.decay(now, penaltyDecayPeriods*r.stallTimeout)
+	}
+	if len(r.peers) > maxTrackedPeers {
+		ids := make([]string, 0, len(r.peers))
+		for id := range r.peers {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return r.peers[ids[i]].active.Before(r.peers[ids[j]].active) })
+		for _, id := range ids[:len(ids)-maxTrackedPeers] {
+			delete(r.peers, id)
+		}
+	}
+	for h := range r.origins {
+		if h <= confirmedHeight {
+			delete(r.origins, h)
+		}
+	}
+}
+
+// quorumHeight returns the highest height which at least quorum peers have delivered blocks up to. Only the blocks
+// delivered in response to the requests count, so that a peer can't claim a height out of nothing, and a height
+// expires unless the peer keeps raising it, so that the quorum height falls back to 0 once the sync has caught up
+func (r *peerReputation) quorumHeight(quorum int, now time.Time) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if quorum < 1 {
+		quorum = 1
+	}
+	heights := make([]uint64, 0, len(r.peers))
+	for id, p := range r.peers {
+		if p.delivered == 0 || now.Sub(p.raised) >= deliveredHeightPeriods*r.stallTimeout {
+			continue
+		}
+		if r.score(id) < minSyncPeerScore {
+			continue
+		}
+		heights = append(heights, p.delivered)
+	}
+	if len(heights) < quorum {
+		return 0
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights[quorum-1]
+}
+
+// rank sorts the peers by score in descending order, and drops the ones below the min score unless all of them are
+func (r *peerReputation) rank(peers []peerstore.PeerInfo) []peerstore.PeerInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scores := make(map[string]int64, len(peers))
+	for _, p := range peers {
+		scores[p.ID.Pretty()] = r.score(p.ID.Pretty())
+	}
+	ranked := make([]peerstore.PeerInfo, len(peers))
+	copy(ranked, peers)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID.Pretty()] > scores[ranked[j].ID.Pretty()]
+	})
+	for i, p := range ranked {
+		if scores[p.ID.Pretty()] < minSyncPeerScore {
+			if i == 0 {
+				return ranked
+			}
+			return ranked[:i]
+		}
+	}
+	return ranked
+}
+
+// table returns the scores of all the tracked peers in descending order
+func (r *peerReputation) table() []*iotexapi.PeerScore {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	table := make([]*iotexapi.PeerScore, 0, len(r.peers))
+	for id, p := range r.peers {
+		table = append(table, &iotexapi.PeerScore{
+			PeerID:          id,
+			Score:           r.score(id),
+			Requests:        p.requests,
+			Responses:       p.responses,
+			StalledRequests: p.stalled,
+			InvalidBlocks:   p.invalid,
+			LatencyMs:       uint64(p.latency / time.Millisecond),
+		})
+	}
+	sort.Slice(table, func(i, j int) bool {
+		if table[i].Score != table[j].Score {
+			return table[i].Score > table[j].Score
+		}
+		return table[i].PeerID < table[j].PeerID
+	})
+	return table
+}
+
+func (r *peerReputation) score(peerID string) int64 {
+	p, ok := r.peers[peerID]
+	if !ok {
+		return maxPeerScore
+	}
+	latencyPenalty := int64(p.latency / (100 * time.Millisecond))
+	if latencyPenalty > maxLatencyPenalty {
+		latencyPenalty = maxLatencyPenalty
+	}
+	return maxPeerScore -
+		invalidBlockPenalty*int64(p.invalid) -
+		stalledRequestPenalty*int64(p.stalled) -
+		latencyPenalty
+}
+
+// decay forgives a penalty of the peer, the stalled request first, if no penalty has been added for the period
+func (p *peerRecord) decay(now time.Time, period time.Duration) {
+	if p.stalled == 0 && p.invalid == 0 {
+		return
+	}
+	if p.penalized.IsZero() {
+		p.penalized = now
+		return
+	}
+	if now.Sub(p.penalized) < period {
+		return
+	}
+	if p.stalled > 0 {
+		p.stalled--
+	} else {
+		p.invalid--
+	}
+	p.penalized = now
+}
+
+func (r *peerReputation) peer(peerID string) *peerRecord {
+	p, ok := r.peers[peerID]
+	if !ok {
+		p = &peerRecord{}
+		r.peers[peerID] = p
+	}
+	return p
+}
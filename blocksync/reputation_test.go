@@ -0,0 +1,123 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerReputation(t *testing.T) {
+	require := require.New(t)
+
+	good := peerstore.PeerInfo{ID: peer.ID("good")}
+	slow := peerstore.PeerInfo{ID: peer.ID("slow")}
+	stalled := peerstore.PeerInfo{ID: peer.ID("stalled")}
+	bad := peerstore.PeerInfo{ID: peer.ID("bad")}
+	peers := []peerstore.PeerInfo{bad, stalled, slow, good}
+
+	r := newPeerReputation(time.Second)
+	// The unknown peers are scored the same, so the order is kept
+	require.Equal(peers, r.rank(peers))
+
+	now := time.Now()
+	r.requested(good.ID.Pretty(), 1, 2, now)
+	r.requested(slow.ID.Pretty(), 3, 4, now)
+	r.requested(stalled.ID.Pretty(), 5, 6, now)
+	r.requested(bad.ID.Pretty(), 7, 8, now)
+	r.responded(good.ID.Pretty(), 1, now.Add(100*time.Millisecond))
+	r.responded(good.ID.Pretty(), 2, now.Add(200*time.Millisecond))
+	r.responded(slow.ID.Pretty(), 3, now.Add(900*time.Millisecond))
+	r.responded(bad.ID.Pretty(), 7, now.Add(100*time.Millisecond))
+	r.responded(bad.ID.Pretty(), 8, now.Add(100*time.Millisecond))
+	for i := 0; i < 5; i++ {
+		r.invalid(7)
+		r.responded(bad.ID.Pretty(), 7, now)
+	}
+	r.committed(1)
+	r.invalid(1)
+
+	// Only the request without any response is stalled
+	r.expire(0, now.Add(2*time.Second))
+
+	table := r.table()
+	require.Equal(4, len(table))
+	require.Equal(good.ID.Pretty(), table[0].PeerID)
+	require.Equal(int64(99), table[0].Score)
+	require.Equal(uint64(1), table[0].Requests)
+	require.Equal(uint64(2), table[0].Responses)
+	require.Equal(uint64(100), table[0].LatencyMs)
+	require.Equal(slow.ID.Pretty(), table[1].PeerID)
+	require.Equal(int64(91), table[1].Score)
+	require.Equal(stalled.ID.Pretty(), table[2].PeerID)
+	require.Equal(int64(90), table[2].Score)
+	require.Equal(uint64(1), table[2].StalledRequests)
+	require.Equal(bad.ID.Pretty(), table[3].PeerID)
+	require.Equal(int64(-26), table[3].Score)
+	require.Equal(uint64(5), table[3].InvalidBlocks)
+
+	// The peer below the min score isn't asked for blocks
+	require.Equal([]peerstore.PeerInfo{good, slow, stalled}, r.rank(peers))
+	// Unless no peer reaches the min score
+	require.Equal([]peerstore.PeerInfo{bad}, r.rank([]peerstore.PeerInfo{bad}))
+
+	// The requests and the origins of the confirmed heights are dropped
+	r.requested(stalled.ID.Pretty(), 9, 10, now)
+	r.responded(stalled.ID.Pretty(), 9, now)
+	r.expire(10, now.Add(2*time.Second))
+	require.Empty(r.peers[stalled.ID.Pretty()].pending)
+	require.Empty(r.origins)
+
+	// The penalties are forgiven one by one if no new penalty is added for a while
+	r.requested(bad.ID.Pretty(), 11, 12, now.Add(12*time.Second))
+	r.expire(10, now.Add(12500*time.Millisecond))
+	require.Equal(uint64(4), r.peers[bad.ID.Pretty()].invalid)
+	require.Equal(uint64(0), r.peers[stalled.ID.Pretty()].stalled)
+	r.expire(10, now.Add(12600*time.Millisecond))
+	require.Equal(uint64(4), r.peers[bad.ID.Pretty()].invalid)
+	// A new penalty restarts the decay
+	r.responded(bad.ID.Pretty(), 11, now.Add(12*time.Second))
+	r.invalid(11)
+	r.expire(10, now.Add(12700*time.Millisecond))
+	r.expire(10, now.Add(22*time.Second))
+	require.Equal(uint64(5), r.peers[bad.ID.Pretty()].invalid)
+
+	// The peers idle for long are forgotten, unless they have requests pending
+	r.expire(10, now.Add(102*time.Second))
+	require.Equal(1, len(r.peers))
+	_, ok := r.peers[bad.ID.Pretty()]
+	require.True(ok)
+}
+
+func TestPeerReputationQuorumHeight(t *testing.T) {
+	require := require.New(t)
+
+	r := newPeerReputation(time.Second)
+	now := time.Now()
+	// The blocks not requested don't count
+	r.responded("a", 100, now)
+	require.Equal(uint64(0), r.quorumHeight(1, now))
+
+	r.requested("a", 1, 20, now)
+	r.requested("b", 1, 20, now)
+	r.requested("c", 1, 20, now)
+	r.responded("a", 20, now)
+	r.responded("b", 15, now)
+	require.Equal(uint64(20), r.quorumHeight(1, now))
+	require.Equal(uint64(15), r.quorumHeight(2, now))
+	require.Equal(uint64(0), r.quorumHeight(3, now))
+
+	// The heights expire unless they keep being raised
+	r.responded("c", 18, now.Add(5*time.Second))
+	require.Equal(uint64(15), r.quorumHeight(3, now.Add(5*time.Second)))
+	require.Equal(uint64(18), r.quorumHeight(1, now.Add(deliveredHeightPeriods*time.Second)))
+	require.Equal(uint64(0), r.quorumHeight(2, now.Add(deliveredHeightPeriods*time.Second)))
+}
@@ -0,0 +1,428 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+)
+
+const (
+	// maxSnapshotChunks is the max number of the chunks of a state snapshot downloaded from the peers
+	maxSnapshotChunks = 1 << 16
+	// maxSnapshotRoots is the max number of the merkle roots whose chunks are downloaded at the same time, since the
+	// roots claimed by the peers aren't trusted until their snapshots are assembled
+	maxSnapshotRoots = 4
+	// snapshotChunksPerPeer is the max number of the chunks requested from a peer in a sync round
+	snapshotChunksPerPeer = 4
+)
+
+// ErrSnapshotNotServed indicates that the requested state snapshot isn't served by the node
+var ErrSnapshotNotServed = errors.New("state snapshot isn't served")
+
+type (
+	// snapshotProvider generates the state snapshots at the heights of the snapshot interval and the pinned height,
+	// e.g., the checkpoint in genesis, and serves them to the peers in chunks. A snapshot is kept as its JSON encoding
+	// in a file, and each chunk is served with its merkle proof against the root of all the chunks, so that a chunk is
+	// verified on its own once received
+	snapshotProvider struct {
+		mu        sync.RWMutex
+		bc        blockchain.Blockchain
+		interval  uint64
+		pinned    uint64 // height of the snapshot never dropped, 0 if none
+		dir       string
+		chunkSize uint64
+		kept      int
+		snapshots map[uint64]*servedSnapshot
+		task      *routine.RecurringTask
+	}
+
+	servedSnapshot struct {
+		path  string
+		size  uint64
+		count uint32
+		tree  *crypto.Merkle
+		root  hash.Hash256
+	}
+
+	// snapshotDownload collects the chunks of the state snapshot at the checkpoint from the peers. The chunks are
+	// grouped by the merkle root they are proved against, and the snapshot assembled from the chunks of a root is only
+	// taken if it matches the state digest of the checkpoint
+	snapshotDownload struct {
+		mu       sync.Mutex
+		height   uint64
+		digest   hash.Hash256
+		sets     map[hash.Hash256]*chunkSet
+		snapshot *blockchain.StateSnapshot
+	}
+
+	chunkSet struct {
+		count  uint32
+		chunks map[uint32][]byte
+	}
+)
+
+func newSnapshotProvider(bc blockchain.Blockchain, cfg config.BlockSync, pinned uint64) *snapshotProvider {
+	p := &snapshotProvider{
+		bc:        bc,
+		interval:  cfg.SnapshotInterval,
+		pinned:    pinned,
+		dir:       cfg.SnapshotDir,
+		chunkSize: cfg.SnapshotChunkSize,
+		kept:      int(cfg.SnapshotsKept),
+		snapshots: make(map[uint64]*servedSnapshot),
+	}
+	p.task = routine.NewRecurringTask(p.generate, cfg.Interval)
+	return p
+}
+
+// Start loads the snapshots generated before, and starts generating the new ones periodically
+func (p *snapshotProvider) Start(ctx context.Context) error {
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create snapshot directory %s", p.dir)
+	}
+	paths, err := filepath.Glob(filepath.Join(p.dir, "snapshot-*.json"))
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the snapshots in %s", p.dir)
+	}
+	for _, path := range paths {
+		var height uint64
+		if _, err := fmt.Sscanf(filepath.Base(path), "snapshot-%d.json", &height); err != nil {
+			continue
+		}
+		s, err := p.load(path)
+		if err != nil {
+			log.L().Warn("Failed to load the state snapshot.", zap.String("file", path), zap.Error(err))
+			continue
+		}
+		p.snapshots[height] = s
+	}
+	p.evict()
+	return p.task.Start(ctx)
+}
+
+// Stop stops generating the snapshots
+func (p *snapshotProvider) Stop(ctx context.Context) error {
+	return p.task.Stop(ctx)
+}
+
+// generate generates the snapshot at the latest height of the interval or the pinned height below the tip, if it
+// hasn't been generated
+func (p *snapshotProvider) generate() {
+	tipHeight := p.bc.TipHeight()
+	height := tipHeight / p.interval * p.interval
+	if p.pinned != 0 && p.pinned <= tipHeight && !p.has(p.pinned) {
+		height = p.pinned
+	}
+	if height == 0 || p.has(height) {
+		return
+	}
+	snapshot, err := p.bc.ExportState(context.Background(), height)
+	if err != nil {
+		log.L().Error("Failed to export the states.", zap.Uint64("height", height), zap.Error(err))
+		return
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		log.L().Error("Failed to marshal state snapshot.", zap.Uint64("height", height), zap.Error(err))
+		return
+	}
+	path := filepath.Join(p.dir, fmt.Sprintf("snapshot-%d.json", height))
+	// The snapshot is written into a temporary file first, so that a partial one is never loaded
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		log.L().Error("Failed to write state snapshot.", zap.String("file", tmp), zap.Error(err))
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		log.L().Error("Failed to rename state snapshot.", zap.String("file", tmp), zap.Error(err))
+		return
+	}
+	s, err := p.newServedSnapshot(path, data)
+	if err != nil {
+		log.L().Error("Failed to chunk state snapshot.", zap.String("file", path), zap.Error(err))
+		return
+	}
+	p.mu.Lock()
+	p.snapshots[height] = s
+	p.mu.Unlock()
+	p.evict()
+	log.L().Info("Generated the state snapshot.",
+		zap.Uint64("height", height),
+		zap.Uint32("chunks", s.count),
+		log.Hex("root", s.root[:]))
+}
+
+// has returns whether the snapshot at the height is served
+func (p *snapshotProvider) has(height uint64) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.snapshots[height]
+	return ok
+}
+
+// evict drops the snapshots older than the latest ones kept, except the pinned one
+func (p *snapshotProvider) evict() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	heights := make([]uint64, 0, len(p.snapshots))
+	for h := range p.snapshots {
+		if h != p.pinned {
+			heights = append(heights, h)
+		}
+	}
+	if len(heights) <= p.kept {
+		return
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	for _, h := range heights[p.kept:] {
+		if err := os.Remove(p.snapshots[h].path); err != nil {
+			log.L().Warn("Failed to remove state snapshot.", zap.String("file", p.snapshots[h].path), zap.Error(err))
+		}
+		delete(p.snapshots, h)
+	}
+}
+
+func (p *snapshotProvider) load(path string) (*servedSnapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.newServedSnapshot(path, data)
+}
+
+// newServedSnapshot builds the merkle tree of the chunks of the snapshot data
+func (p *snapshotProvider) newServedSnapshot(path string, data []byte) (*servedSnapshot, error) {
+	size := uint64(len(data))
+	if size == 0 {
+		return nil, errors.New("state snapshot is empty")
+	}
+	count := (size + p.chunkSize - 1) / p.chunkSize
+	if count > maxSnapshotChunks {
+		return nil, errors.Errorf("state snapshot has %d chunks, more than %d", count, maxSnapshotChunks)
+	}
+	leaves := make([]hash.Hash256, 0, count)
+	for start := uint64(0); start < size; start += p.chunkSize {
+		end := start + p.chunkSize
+		if end > size {
+			end = size
+		}
+		leaves = append(leaves, hash.Hash256b(data[start:end]))
+	}
+	tree := crypto.NewMerkleTree(leaves)
+	return &servedSnapshot{
+		path:  path,
+		size:  size,
+		count: uint32(count),
+		tree:  tree,
+		root:  tree.HashTree(),
+	}, nil
+}
+
+// chunk returns the chunk of the snapshot at the height with its merkle proof
+func (p *snapshotProvider) chunk(height uint64, index uint32) (*iotexrpc.SnapshotChunk, error) {
+	p.mu.RLock()
+	s, ok := p.snapshots[height]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, errors.Wrapf(ErrSnapshotNotServed, "height %d", height)
+	}
+	if index >= s.count {
+		return nil, errors.Errorf("state snapshot at height %d doesn't have chunk %d", height, index)
+	}
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open state snapshot %s", s.path)
+	}
+	defer f.Close()
+	start := uint64(index) * p.chunkSize
+	end := start + p.chunkSize
+	if end > s.size {
+		end = s.size
+	}
+	data := make([]byte, end-start)
+	if _, err := f.ReadAt(data, int64(start)); err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "failed to read chunk %d of state snapshot %s", index, s.path)
+	}
+	proof, err := s.tree.Proof(int(index))
+	if err != nil {
+		return nil, err
+	}
+	res := &iotexrpc.SnapshotChunk{
+		Height: height,
+		Index:  index,
+		Count:  s.count,
+		Root:   s.root[:],
+		Data:   data,
+	}
+	for _, h := range proof {
+		res.Proof = append(res.Proof, h[:])
+	}
+	return res, nil
+}
+
+func newSnapshotDownload(height uint64, digest hash.Hash256) *snapshotDownload {
+	return &snapshotDownload{
+		height: height,
+		digest: digest,
+		sets:   make(map[hash.Hash256]*chunkSet),
+	}
+}
+
+// result returns the snapshot once it's downloaded and verified, nil otherwise
+func (d *snapshotDownload) result() *blockchain.StateSnapshot {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.snapshot
+}
+
+// missing returns up to n indexes of the chunks to request. The first chunk is requested until any chunk is received,
+// which tells the number of the chunks, and then the chunks missing from the most complete root
+func (d *snapshotDownload) missing(n int) []uint32 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.snapshot != nil || n <= 0 {
+		return nil
+	}
+	var best *chunkSet
+	for _, set := range d.sets {
+		if best == nil || len(set.chunks) > len(best.chunks) {
+			best = set
+		}
+	}
+	if best == nil {
+		return []uint32{0}
+	}
+	var indexes []uint32
+	for i := uint32(0); i < best.count && len(indexes) < n; i++ {
+		if _, ok := best.chunks[i]; !ok {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// add verifies the chunk against its merkle root, and assembles the snapshot once all the chunks of the root are
+// received. The chunks of a root are dropped if the assembled snapshot doesn't match the state digest. It returns
+// whether the chunk completes the download
+func (d *snapshotDownload) add(chunk *iotexrpc.SnapshotChunk) (bool, error) {
+	if chunk.Height != d.height {
+		return false, errors.Errorf("chunk of state snapshot at height %d, expecting %d", chunk.Height, d.height)
+	}
+	if chunk.Count == 0 || chunk.Count > maxSnapshotChunks || chunk.Index >= chunk.Count {
+		return false, errors.Errorf("invalid chunk %d of %d chunks", chunk.Index, chunk.Count)
+	}
+	root, err := toHash256(chunk.Root)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid merkle root of the chunk")
+	}
+	proof := make([]hash.Hash256, 0, len(chunk.Proof))
+	for _, b := range chunk.Proof {
+		h, err := toHash256(b)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid merkle proof of the chunk")
+		}
+		proof = append(proof, h)
+	}
+	if crypto.MerkleRootFromProof(hash.Hash256b(chunk.Data), chunk.Index, proof) != root {
+		return false, errors.Errorf("chunk %d doesn't match the merkle root %x", chunk.Index, root)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.snapshot != nil {
+		return false, nil
+	}
+	set, ok := d.sets[root]
+	if !ok {
+		if len(d.sets) >= maxSnapshotRoots {
+			d.evictLocked()
+		}
+		set = &chunkSet{count: chunk.Count, chunks: make(map[uint32][]byte)}
+		d.sets[root] = set
+	}
+	if set.count != chunk.Count {
+		return false, errors.Errorf("chunk claims %d chunks of merkle root %x, expecting %d", chunk.Count, root, set.count)
+	}
+	set.chunks[chunk.Index] = chunk.Data
+	if uint32(len(set.chunks)) < set.count {
+		return false, nil
+	}
+	snapshot, err := d.assemble(set)
+	if err != nil {
+		delete(d.sets, root)
+		return false, errors.Wrapf(err, "failed to assemble the chunks of merkle root %x", root)
+	}
+	d.snapshot = snapshot
+	d.sets = nil
+	log.L().Info("Downloaded the state snapshot from the peers.", zap.Uint64("height", d.height))
+	return true, nil
+}
+
+// evictLocked drops the chunks of the least complete root
+func (d *snapshotDownload) evictLocked() {
+	var (
+		worst     hash.Hash256
+		worstSize = -1
+	)
+	for root, set := range d.sets {
+		if worstSize < 0 || len(set.chunks) < worstSize {
+			worst, worstSize = root, len(set.chunks)
+		}
+	}
+	delete(d.sets, worst)
+}
+
+func (d *snapshotDownload) assemble(set *chunkSet) (*blockchain.StateSnapshot, error) {
+	var buf bytes.Buffer
+	for i := uint32(0); i < set.count; i++ {
+		buf.Write(set.chunks[i])
+	}
+	snapshot := &blockchain.StateSnapshot{}
+	if err := json.Unmarshal(buf.Bytes(), snapshot); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal state snapshot")
+	}
+	digest, err := snapshot.Digest()
+	if err != nil {
+		return nil, err
+	}
+	if digest != d.digest {
+		return nil, errors.Errorf("digest %x of state snapshot doesn't match the checkpoint %x", digest, d.digest)
+	}
+	if snapshot.Height != d.height {
+		return nil, errors.Errorf("state snapshot height %d doesn't match the checkpoint height %d", snapshot.Height, d.height)
+	}
+	return snapshot, nil
+}
+
+func toHash256(b []byte) (hash.Hash256, error) {
+	var h hash.Hash256
+	if len(b) != len(h) {
+		return h, errors.Errorf("invalid hash length %d", len(b))
+	}
+	copy(h[:], b)
+	return h, nil
+}
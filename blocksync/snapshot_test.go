@@ -0,0 +1,263 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
+)
+
+func newTestSnapshot(height uint64) *blockchain.StateSnapshot {
+	snapshot := &blockchain.StateSnapshot{Height: height}
+	for i := 0; i < 20; i++ {
+		snapshot.Accounts = append(snapshot.Accounts, &blockchain.AccountSnapshot{
+			Address: fmt.Sprintf("io1account%d", i),
+			Balance: fmt.Sprintf("%d", height*100+uint64(i)),
+			Nonce:   uint64(i),
+		})
+	}
+	return snapshot
+}
+
+// downloadFrom requests the chunks from the provider until the download completes
+func downloadFrom(t *testing.T, p *snapshotProvider, d *snapshotDownload) {
+	for i := 0; i < maxSnapshotChunks; i++ {
+		indexes := d.missing(3)
+		if len(indexes) == 0 {
+			return
+		}
+		for _, index := range indexes {
+			chunk, err := p.chunk(d.height, index)
+			require.NoError(t, err)
+			_, err = d.add(chunk)
+			require.NoError(t, err)
+		}
+	}
+	t.Fatal("download doesn't complete")
+}
+
+func TestSnapshotProvider(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "state-snapshots")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	cfg := config.Default.BlockSync
+	cfg.Interval = time.Hour
+	cfg.SnapshotInterval = 10
+	cfg.SnapshotDir = dir
+	cfg.SnapshotChunkSize = 100
+	cfg.SnapshotsKept = 1
+
+	var tipHeight uint64
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().DoAndReturn(func() uint64 { return tipHeight }).AnyTimes()
+	chain.EXPECT().ExportState(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, height uint64) (*blockchain.StateSnapshot, error) {
+			return newTestSnapshot(height), nil
+		},
+	).Times(3)
+
+	p := newSnapshotProvider(chain, cfg, 5)
+	require.NoError(p.Start(context.Background()))
+	defer func() { require.NoError(p.Stop(context.Background())) }()
+
+	// Nothing to generate below the first interval
+	tipHeight = 4
+	p.generate()
+	require.Empty(p.snapshots)
+	// The pinned snapshot is generated first
+	tipHeight = 25
+	p.generate()
+	require.True(p.has(5))
+	require.False(p.has(20))
+	p.generate()
+	require.True(p.has(20))
+	p.generate()
+	// The older snapshot is dropped, but the pinned one is kept
+	tipHeight = 31
+	p.generate()
+	require.True(p.has(5))
+	require.False(p.has(20))
+	require.True(p.has(30))
+	_, err = os.Stat(filepath.Join(dir, "snapshot-20.json"))
+	require.True(os.IsNotExist(err))
+
+	_, err = p.chunk(20, 0)
+	require.Error(err)
+	_, err = p.chunk(30, p.snapshots[30].count)
+	require.Error(err)
+	require.True(p.snapshots[30].count > 1)
+
+	expected := newTestSnapshot(30)
+	digest, err := expected.Digest()
+	require.NoError(err)
+	d := newSnapshotDownload(30, digest)
+	require.Nil(d.result())
+	downloadFrom(t, p, d)
+	require.Equal(expected, d.result())
+
+	// The snapshots are served again after restart
+	p2 := newSnapshotProvider(chain, cfg, 5)
+	require.NoError(p2.Start(context.Background()))
+	defer func() { require.NoError(p2.Stop(context.Background())) }()
+	require.True(p2.has(5))
+	require.True(p2.has(30))
+	require.Equal(p.snapshots[30].root, p2.snapshots[30].root)
+}
+
+func TestSnapshotDownload(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "state-snapshots")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	cfg := config.Default.BlockSync
+	cfg.Interval = time.Hour
+	cfg.SnapshotInterval = 10
+	cfg.SnapshotDir = dir
+	cfg.SnapshotChunkSize = 100
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	chain.EXPECT().ExportState(gomock.Any(), uint64(10)).Return(newTestSnapshot(10), nil).Times(1)
+	p := newSnapshotProvider(chain, cfg, 0)
+	p.generate()
+
+	digest, err := newTestSnapshot(10).Digest()
+	require.NoError(err)
+	d := newSnapshotDownload(10, digest)
+	require.Equal([]uint32{0}, d.missing(3))
+	chunk, err := p.chunk(10, 1)
+	require.NoError(err)
+
+	// A chunk not matching its proof is rejected
+	tampered := proto.Clone(chunk).(*iotexrpc.SnapshotChunk)
+	tampered.Data[0] ^= 0xff
+	_, err = d.add(tampered)
+	require.Error(err)
+	tampered = proto.Clone(chunk).(*iotexrpc.SnapshotChunk)
+	tampered.Height = 20
+	_, err = d.add(tampered)
+	require.Error(err)
+	tampered = proto.Clone(chunk).(*iotexrpc.SnapshotChunk)
+	tampered.Index = 2
+	_, err = d.add(tampered)
+	require.Error(err)
+	require.Equal([]uint32{0}, d.missing(3))
+
+	completed, err := d.add(chunk)
+	require.NoError(err)
+	require.False(completed)
+	require.Equal([]uint32{0, 2, 3}, d.missing(3))
+
+	// The chunks assembled into a snapshot not matching the digest are dropped
+	bad := newSnapshotDownload(10, hash.Hash256b([]byte("digest")))
+	count := p.snapshots[10].count
+	for i := uint32(0); i < count; i++ {
+		chunk, err := p.chunk(10, i)
+		require.NoError(err)
+		completed, err = bad.add(chunk)
+		require.False(completed)
+		if i+1 < count {
+			require.NoError(err)
+		} else {
+			require.Error(err)
+		}
+	}
+	require.Nil(bad.result())
+	require.Equal([]uint32{0}, bad.missing(3))
+
+	downloadFrom(t, p, d)
+	require.Equal(newTestSnapshot(10), d.result())
+	require.Nil(d.missing(3))
+}
+
+func TestBlockSyncerProcessSnapshotChunkRequestLimit(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "state-snapshots")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.NodeType = config.FullNodeType
+	cfg.BlockSync.SnapshotInterval = 10
+	cfg.BlockSync.SnapshotDir = dir
+	cfg.BlockSync.SnapshotRequestLimit = 2
+	cfg.BlockSync.SyncRequestWindow = time.Minute
+	cfg.BlockSync.SyncRequestBanDuration = time.Minute
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	chain.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	chain.EXPECT().ExportState(gomock.Any(), uint64(10)).Return(newTestSnapshot(10), nil).Times(1)
+	ap, err := actpool.NewActPool(chain, cfg.ActPool)
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+
+	var sent []proto.Message
+	var blocked []peerstore.PeerInfo
+	bs, err := NewBlockSyncer(
+		cfg,
+		chain,
+		ap,
+		cs,
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			sent = append(sent, msg)
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return nil, nil }),
+		WithBlockPeer(func(peer peerstore.PeerInfo, duration time.Duration) {
+			blocked = append(blocked, peer)
+		}),
+	)
+	require.NoError(err)
+	bs.(*blockSyncer).snapshots.generate()
+
+	ctx := context.Background()
+	abuser := peerstore.PeerInfo{ID: peer.ID("abuser")}
+	req := &iotexrpc.SnapshotChunkRequest{Height: 10, Index: 0}
+	require.NoError(bs.ProcessSnapshotChunkRequest(ctx, abuser, req))
+	require.Error(bs.ProcessSnapshotChunkRequest(ctx, abuser, &iotexrpc.SnapshotChunkRequest{Height: 20}))
+	require.Len(sent, 1)
+	require.Equal(uint64(10), sent[0].(*iotexrpc.SnapshotChunk).Height)
+
+	// The peer exceeding the limit is banned, and its requests are dropped
+	require.Error(bs.ProcessSnapshotChunkRequest(ctx, abuser, req))
+	require.Error(bs.ProcessSnapshotChunkRequest(ctx, abuser, req))
+	require.Equal([]peerstore.PeerInfo{abuser}, blocked)
+	require.Len(sent, 1)
+
+	// The chunks are ignored if not fast syncing from the peers
+	require.NoError(bs.ProcessSnapshotChunk(ctx, abuser, sent[0].(*iotexrpc.SnapshotChunk)))
+}
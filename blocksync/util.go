@@ -32,6 +32,18 @@ func commitBlock(bc blockchain.Blockchain, ap actpool.ActPool, cs consensus.Cons
 	return nil
 }
 
+// commitBlocks commits the sequential blocks in a batch. It returns the number of the blocks committed, and the error
+// why the next block fails to commit
+func commitBlocks(bc blockchain.Blockchain, ap actpool.ActPool, cs consensus.Consensus, blks []*block.Block) (int, error) {
+	n, err := bc.CommitBlocks(blks, cs.ValidateBlockFooter)
+	if n > 0 {
+		cs.Calibrate(blks[n-1].Height())
+		// remove transfers in these blocks from ActPool and reset ActPool state
+		ap.Reset()
+	}
+	return n, err
+}
+
 // syncTaskInterval returns the recurring sync task interval, or 0 if this config should not need to run sync task
 func syncTaskInterval(cfg config.Config) time.Duration {
 	if cfg.IsLightweight() {
@@ -9,7 +9,9 @@ package blocksync
 import (
 	"context"
 	"sync"
+	"time"
 
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/config"
@@ -27,10 +29,13 @@ type syncWorker struct {
 	chainID          uint32
 	mu               sync.RWMutex
 	targetHeight     uint64
+	peersInUse       []string // peers which the blocks are requested from in the last sync round
+	compact          bool     // requests the blocks in compact form
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
-	rrIdx            int
 	buf              *blockBuffer
+	rep              *peerReputation
+	fast             *fastSync // requests the headers down from the checkpoint in fast sync, nil if disabled
 	task             *routine.RecurringTask
 }
 
@@ -40,14 +45,16 @@ func newSyncWorker(
 	unicastHandler UnicastOutbound,
 	neighborsHandler Neighbors,
 	buf *blockBuffer,
+	rep *peerReputation,
 ) *syncWorker {
 	w := &syncWorker{
 		chainID:          chainID,
 		unicastHandler:   unicastHandler,
 		neighborsHandler: neighborsHandler,
 		buf:              buf,
+		rep:              rep,
 		targetHeight:     0,
-		rrIdx:            0,
+		compact:          cfg.BlockSync.CompactBlocks,
 	}
 	if interval := syncTaskInterval(cfg); interval != 0 {
 		w.task = routine.NewRecurringTask(w.Sync, cfg.BlockSync.Interval)
@@ -69,12 +76,24 @@ func (w *syncWorker) Stop(ctx context.Context) error {
 	return nil
 }
 
-func (w *syncWorker) SetTargetHeight(h uint64) {
+// SetTargetHeight raises the target height to h, and returns whether it's raised
+func (w *syncWorker) SetTargetHeight(h uint64) bool {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	if h > w.targetHeight {
 		w.targetHeight = h
+		return true
 	}
+	return false
+}
+
+// PeersInUse returns the peers which the blocks are requested from in the last sync round
+func (w *syncWorker) PeersInUse() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	peers := make([]string, len(w.peersInUse))
+	copy(peers, w.peersInUse)
+	return peers
 }
 
 // Sync checks the sliding window and send more sync request if needed
@@ -92,20 +111,89 @@ func (w *syncWorker) Sync() {
 		log.L().Warn("Error when get neighbor peers.", zap.Error(err))
 		return
 	}
-	intervals := w.buf.GetBlocksIntervalsToSync(w.targetHeight)
+	now := time.Now()
+	tipHeight := w.buf.bc.TipHeight()
+	w.rep.expire(tipHeight, now)
+	// The intervals are requested from the best scored peers first
+	peers = w.rep.rank(peers)
+	var intervals []syncBlocksInterval
+	// The headers are taken from the compact blocks in fast sync
+	headers := w.fast != nil && w.fast.syncingHeaders(tipHeight)
+	if headers {
+		intervals = w.fast.headerIntervals(tipHeight)
+	} else {
+		intervals = w.buf.GetBlocksIntervalsToSync(w.targetHeight)
+	}
 	if intervals != nil {
 		log.L().Info("block sync intervals.",
 			zap.Any("intervals", intervals),
-			zap.Uint64("targetHeight", w.targetHeight))
+			zap.Uint64("targetHeight", w.targetHeight),
+			zap.Bool("headers", headers))
 	}
-	for _, interval := range intervals {
-		w.rrIdx %= len(peers)
-		p := peers[w.rrIdx]
-		if err := w.unicastHandler(ctx, p, &iotexrpc.BlockSync{
-			Start: interval.Start, End: interval.End,
-		}); err != nil {
+	inUse := make(map[string]bool)
+	w.peersInUse = nil
+	for i, interval := range intervals {
+		p := peers[i%len(peers)]
+		if err := w.unicastHandler(ctx, p, w.syncRequest(interval.Start, interval.End, headers)); err != nil {
 			log.L().Warn("Failed to sync block.", zap.Error(err))
+			continue
+		}
+		w.rep.requested(p.ID.Pretty(), interval.Start, interval.End, now)
+		if id := p.ID.Pretty(); !inUse[id] {
+			inUse[id] = true
+			w.peersInUse = append(w.peersInUse, id)
+		}
+	}
+	if w.fast != nil && w.fast.fetchingSnapshot(w.buf.bc) {
+		w.requestSnapshotChunks(ctx, peers)
+	}
+}
+
+// syncRequest returns the request of the blocks in the interval. The blocks at or below the checkpoint in fast sync
+// are imported without being executed, so they are requested in full along with their receipts
+func (w *syncWorker) syncRequest(start, end uint64, headers bool) *iotexrpc.BlockSync {
+	if headers {
+		return &iotexrpc.BlockSync{Start: start, End: end, Compact: true}
+	}
+	if w.fast != nil && start <= w.fast.checkpointHeight {
+		return &iotexrpc.BlockSync{Start: start, End: end, Receipts: true}
+	}
+	return &iotexrpc.BlockSync{Start: start, End: end, Compact: w.compact}
+}
+
+// requestSnapshotChunks requests the missing chunks of the state snapshot at the checkpoint across the peers
+func (w *syncWorker) requestSnapshotChunks(ctx context.Context, peers []peerstore.PeerInfo) {
+	indexes := w.fast.download.missing(len(peers) * snapshotChunksPerPeer)
+	for i, index := range indexes {
+		if err := w.unicastHandler(ctx, peers[i%len(peers)], &iotexrpc.SnapshotChunkRequest{
+			Height: w.fast.checkpointHeight, Index: index,
+		}); err != nil {
+			log.L().Warn("Failed to request snapshot chunk.", zap.Error(err))
 		}
-		w.rrIdx++
 	}
 }
+
+// RequestHeight requests the block of height from the best scored peer, without waiting for the next sync round
+func (w *syncWorker) RequestHeight(height uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ctx := context.Background()
+	peers, err := w.neighborsHandler(ctx)
+	if err != nil {
+		log.L().Warn("Error when get neighbor peers.", zap.Error(err))
+		return
+	}
+	if len(peers) == 0 {
+		log.L().Debug("No peer exist to sync with.")
+		return
+	}
+	now := time.Now()
+	p := w.rep.rank(peers)[0]
+	if err := w.unicastHandler(ctx, p, w.syncRequest(height, height, false)); err != nil {
+		log.L().Warn("Failed to request the block again.", zap.Error(err), zap.Uint64("height", height))
+		return
+	}
+	w.rep.requested(p.ID.Pretty(), height, height, now)
+	log.L().Info("Requested the block again.", zap.Uint64("height", height), zap.String("peer", p.ID.Pretty()))
+}
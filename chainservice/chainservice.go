@@ -9,6 +9,7 @@ package chainservice
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
@@ -17,6 +18,9 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/blskey"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/api"
@@ -26,6 +30,8 @@ import (
 	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/explorer"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
@@ -35,6 +41,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/timesanity"
 )
 
 // ChainService is a blockchain service with all blockchain components.
@@ -45,15 +52,18 @@ type ChainService struct {
 	chain        blockchain.Blockchain
 	explorer     *explorer.Server
 	api          *api.Server
-	indexBuilder *blockchain.IndexBuilder
+	apiRouter    *api.Server
 	indexservice *indexservice.Server
 	registry     *protocol.Registry
+	timeSanity   *timesanity.Checker
 }
 
 type optionParams struct {
-	rootChainAPI  explorerapi.Explorer
-	isTesting     bool
-	genesisConfig genesis.Genesis
+	rootChainAPI           explorerapi.Explorer
+	isTesting              bool
+	genesisConfig          genesis.Genesis
+	genesisConsensusParams bool
+	apiRouter              *api.Server
 }
 
 // Option sets ChainService construction parameter.
@@ -83,6 +93,25 @@ func WithGenesis(genesisConfig genesis.Genesis) Option {
 	}
 }
 
+// WithGenesisConsensusParams is an option to run the consensus with the block interval and the epoch parameters defined
+// in the genesis config, rather than in the node config. It's meant for the sub chains, which shouldn't inherit the
+// parameters of the root chain
+func WithGenesisConsensusParams() Option {
+	return func(ops *optionParams) error {
+		ops.genesisConsensusParams = true
+		return nil
+	}
+}
+
+// WithAPIRouter is an option to serve the api of the chain by the api server of another chain, which routes the
+// requests by chain ID, instead of listening on a port of its own
+func WithAPIRouter(router *api.Server) Option {
+	return func(ops *optionParams) error {
+		ops.apiRouter = router
+		return nil
+	}
+}
+
 // New creates a ChainService from config and network.Overlay and dispatcher.Dispatcher.
 func New(
 	cfg config.Config,
@@ -131,8 +160,8 @@ func New(
 		if indexBuilder, err = blockchain.NewIndexBuilder(chain); err != nil {
 			return nil, errors.Wrap(err, "failed to create index builder")
 		}
-		if err := chain.AddSubscriber(indexBuilder); err != nil {
-			log.L().Warn("Failed to add subscriber: index builder.", zap.Error(err))
+		if err := chain.AddDurableSubscriber(blockchain.IndexBuilderSubscriberName, indexBuilder); err != nil {
+			return nil, errors.Wrap(err, "failed to add durable subscriber: index builder")
 		}
 	}
 
@@ -141,30 +170,134 @@ func New(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create actpool")
 	}
+	if cfg.ActPool.EnableValidationCache {
+		chain.Validator().SetValidationCache(actPool)
+	}
+	if err := registerAdmissionHooks(actPool, cfg.ActPool); err != nil {
+		return nil, err
+	}
+	// Put the actions of the reverted blocks back into actpool when the chain is recovered to a lower height
+	if err := chain.AddRevertSubscriber(actPool); err != nil {
+		return nil, errors.Wrap(err, "failed to add revert subscriber: actpool")
+	}
+
+	var rebroadcaster *actpool.Rebroadcaster
+	if cfg.ActPool.RebroadcastAfterBlocks > 0 {
+		rebroadcaster = actpool.NewRebroadcaster(
+			chain,
+			actPool,
+			cfg.ActPool.RebroadcastAfterBlocks,
+			func(msg proto.Message) error {
+				return p2pAgent.BroadcastOutbound(p2p.WitContext(context.Background(), p2p.Context{ChainID: chain.ChainID()}), msg)
+			},
+		)
+		if err := actPool.AddSubscriber(rebroadcaster); err != nil {
+			return nil, errors.Wrap(err, "failed to add subscriber: rebroadcaster")
+		}
+		if err := chain.AddSubscriber(rebroadcaster); err != nil {
+			return nil, errors.Wrap(err, "failed to add subscriber: rebroadcaster")
+		}
+	}
 
 	copts := []consensus.Option{
 		consensus.WithBroadcast(func(msg proto.Message) error {
 			return p2pAgent.BroadcastOutbound(p2p.WitContext(context.Background(), p2p.Context{ChainID: chain.ChainID()}), msg)
 		}),
+		consensus.WithCommitThreshold(
+			ops.genesisConfig.CommitThresholdNumerator,
+			ops.genesisConfig.CommitThresholdDenominator,
+		),
 	}
 	if ops.rootChainAPI != nil {
 		copts = append(copts, consensus.WithRootChainAPI(ops.rootChainAPI))
 	}
+	if ops.genesisConfig.EnableGovernance {
+		copts = append(copts, consensus.WithConsensusParamsByHeight(
+			func(height uint64) (*governance.ConsensusParams, error) {
+				return governance.ReadConsensusParams(chain.GetFactory(), height)
+			},
+		))
+	}
+	if ops.genesisConfig.EnableEvidence {
+		copts = append(copts, consensus.WithDoubleSignDetection())
+	}
+	if ops.genesisConfig.DKGHeight != 0 {
+		copts = append(copts, consensus.WithDKG(
+			dkg.Schedule{
+				ForkHeight: ops.genesisConfig.DKGHeight,
+				EpochSize:  ops.genesisConfig.NumDelegates * ops.genesisConfig.NumSubEpochs,
+			},
+			chain.GetFactory(),
+		))
+	}
+	if vrfHeight, ok := ops.genesisConfig.HeaderVersionHeights[block.HeaderVersionV2]; ok {
+		copts = append(copts, consensus.WithVRF(vrfHeight))
+	}
+	if ops.genesisConfig.EnableBLS {
+		blsPriKey, err := cfg.BLSPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		copts = append(copts, consensus.WithBLS(
+			blsPriKey,
+			func(height uint64, addrs []string) (map[string][]byte, error) {
+				return blskey.ReadKeys(chain.GetFactory(), height, addrs)
+			},
+		))
+	}
+	consensusCfg := cfg.Consensus
+	if ops.genesisConsensusParams {
+		copts = append(copts, consensus.WithGenesis(ops.genesisConfig.Blockchain))
+		if consensusCfg, err = consensus.ConfigFromGenesis(consensusCfg, ops.genesisConfig.Blockchain); err != nil {
+			return nil, err
+		}
+	}
+	var timeSanity *timesanity.Checker
+	if cfg.TimeSanity.Enabled {
+		timeSanity = timesanity.NewChecker(cfg.TimeSanity)
+		if cfg.TimeSanity.RefuseToPropose {
+			copts = append(copts, consensus.WithClockSkewed(timeSanity.Skewed))
+		}
+	}
+	// The block syncer is created after the consensus, which it calibrates
+	var bs blocksync.BlockSync
+	if cfg.BlockSync.CatchUpDistance > 0 {
+		copts = append(copts, consensus.WithCatchingUp(func() bool {
+			return bs != nil && bs.CatchingUp()
+		}))
+	}
+	if cfg.Consensus.RollDPoS.SyncTriggerDistance > 0 {
+		copts = append(copts, consensus.WithSyncToHeight(func(height uint64) {
+			if bs != nil {
+				bs.SyncToHeight(height)
+			}
+		}))
+	}
 	consensus, err := consensus.NewConsensus(cfg, chain, actPool, copts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create consensus")
 	}
-	bs, err := blocksync.NewBlockSyncer(
-		cfg,
-		chain,
-		actPool,
-		consensus,
+	bsOpts := []blocksync.Option{
 		blocksync.WithUnicastOutBound(func(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error {
 			ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chain.ChainID()})
 			return p2pAgent.UnicastOutbound(ctx, peer, msg)
 		}),
 		blocksync.WithNeighbors(p2pAgent.Neighbors),
-	)
+		blocksync.WithBlockPeer(p2pAgent.BlockPeer),
+	}
+	if cfg.BlockSync.FastSync && ops.genesisConfig.CheckpointHeight > 0 {
+		bsOpts = append(bsOpts, blocksync.WithCheckpoint(
+			ops.genesisConfig.CheckpointHeight,
+			ops.genesisConfig.CheckpointHash(),
+			ops.genesisConfig.CheckpointStateDigest(),
+			cfg.BlockSync.SnapshotURL,
+		))
+	}
+	if cfg.BlockSync.SnapshotInterval > 0 && ops.genesisConfig.CheckpointHeight > 0 {
+		// The peers fast syncing to the checkpoint keep fetching its snapshot
+		bsOpts = append(bsOpts, blocksync.WithPinnedSnapshot(ops.genesisConfig.CheckpointHeight))
+	}
+	bs, err = blocksync.NewBlockSyncer(cfg, chain, actPool, consensus, bsOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create blockSyncer")
 	}
@@ -176,39 +309,79 @@ func New(
 		}
 	}
 
-	var exp *explorer.Server
-	if cfg.Explorer.Enabled {
-		exp, err = explorer.NewServer(
-			cfg.Explorer,
-			chain,
-			consensus,
-			dispatcher,
-			actPool,
-			idx,
-			explorer.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
+	var apiSvr *api.Server
+	if cfg.API.Enabled {
+		commitThreshold, err := scheme.NewCommitThreshold(
+			ops.genesisConfig.CommitThresholdNumerator,
+			ops.genesisConfig.CommitThresholdDenominator,
+		)
+		if err != nil {
+			return nil, err
+		}
+		apiOpts := []api.Option{
+			api.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
 				ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
 				return p2pAgent.BroadcastOutbound(ctx, msg)
 			}),
+			api.WithRegistry(&registry),
+			api.WithBlockSync(bs),
+			api.WithNumDelegates(uint64(consensusCfg.RollDPoS.NumDelegates)),
+			api.WithNumSubEpochs(uint64(consensusCfg.RollDPoS.NumSubEpochs)),
+			api.WithCommitThreshold(commitThreshold),
+			api.WithConsensus(consensus),
+			api.WithNeighbors(p2pAgent.Neighbors),
+			api.WithNetworkInfo(p2pAgent.Info),
+		}
+		if rebroadcaster != nil {
+			apiOpts = append(apiOpts, api.WithLocalActionTracker(rebroadcaster.Track))
+		}
+		if indexBuilder != nil {
+			apiOpts = append(apiOpts, api.WithIndexBuilder(indexBuilder))
+		}
+		if timeline := consensusTimeline(consensus); timeline != nil {
+			apiOpts = append(apiOpts, api.WithConsensusTimeline(timeline))
+		}
+		if !ops.isTesting {
+			apiOpts = append(apiOpts, api.WithDBPaths(map[string]string{
+				"chain": cfg.Chain.ChainDBPath,
+				"trie":  cfg.Chain.TrieDBPath,
+			}))
+		}
+		apiSvr, err = api.NewServer(cfg.API, chain, dispatcher, actPool, idx, apiOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var exp *explorer.Server
+	switch {
+	case cfg.Explorer.Enabled && cfg.Explorer.APIBridge:
+		if apiSvr == nil {
+			return nil, errors.New("failed to bridge explorer to api, which is not enabled")
+		}
+		exp, err = explorer.NewAPIBridgeServer(
+			cfg.Explorer,
+			apiSvr,
 			explorer.WithNeighbors(p2pAgent.Neighbors),
 			explorer.WithNetworkInfo(p2pAgent.Info),
 		)
 		if err != nil {
 			return nil, err
 		}
-	}
-
-	var apiSvr *api.Server
-	if cfg.API.Enabled {
-		apiSvr, err = api.NewServer(
-			cfg.API,
+	case cfg.Explorer.Enabled:
+		exp, err = explorer.NewServer(
+			cfg.Explorer,
 			chain,
+			consensus,
 			dispatcher,
 			actPool,
 			idx,
-			api.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
+			explorer.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
 				ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
 				return p2pAgent.BroadcastOutbound(ctx, msg)
 			}),
+			explorer.WithNeighbors(p2pAgent.Neighbors),
+			explorer.WithNetworkInfo(p2pAgent.Info),
 		)
 		if err != nil {
 			return nil, err
@@ -221,10 +394,11 @@ func New(
 		blocksync:    bs,
 		consensus:    consensus,
 		indexservice: idx,
-		indexBuilder: indexBuilder,
 		explorer:     exp,
 		api:          apiSvr,
+		apiRouter:    ops.apiRouter,
 		registry:     &registry,
+		timeSanity:   timeSanity,
 	}, nil
 }
 
@@ -238,6 +412,9 @@ func (cs *ChainService) Start(ctx context.Context) error {
 	if err := cs.chain.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting blockchain")
 	}
+	if err := cs.actpool.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting actpool")
+	}
 	if err := cs.consensus.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting consensus")
 	}
@@ -250,13 +427,17 @@ func (cs *ChainService) Start(ctx context.Context) error {
 		}
 	}
 	if cs.api != nil {
-		if err := cs.api.Start(); err != nil {
+		if cs.apiRouter != nil {
+			if err := cs.apiRouter.AddChain(cs.api); err != nil {
+				return errors.Wrap(err, "err when adding chain to API router")
+			}
+		} else if err := cs.api.Start(); err != nil {
 			return errors.Wrap(err, "err when starting API server")
 		}
 	}
-	if cs.indexBuilder != nil {
-		if err := cs.indexBuilder.Start(ctx); err != nil {
-			return errors.Wrap(err, "error when starting index builder")
+	if cs.timeSanity != nil {
+		if err := cs.timeSanity.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting time sanity checker")
 		}
 	}
 	return nil
@@ -264,9 +445,9 @@ func (cs *ChainService) Start(ctx context.Context) error {
 
 // Stop stops the server
 func (cs *ChainService) Stop(ctx context.Context) error {
-	if cs.indexBuilder != nil {
-		if err := cs.indexBuilder.Stop(ctx); err != nil {
-			return errors.Wrap(err, "error when stopping index builder")
+	if cs.timeSanity != nil {
+		if err := cs.timeSanity.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping time sanity checker")
 		}
 	}
 	if cs.explorer != nil {
@@ -275,7 +456,9 @@ func (cs *ChainService) Stop(ctx context.Context) error {
 		}
 	}
 	if cs.api != nil {
-		if err := cs.api.Stop(); err != nil {
+		if cs.apiRouter != nil {
+			cs.apiRouter.RemoveChain(cs.ChainID())
+		} else if err := cs.api.Stop(); err != nil {
 			return errors.Wrap(err, "error when stopping API server")
 		}
 	}
@@ -290,6 +473,9 @@ func (cs *ChainService) Stop(ctx context.Context) error {
 	if err := cs.blocksync.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blocksync")
 	}
+	if err := cs.actpool.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping actpool")
+	}
 	if err := cs.chain.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping blockchain")
 	}
@@ -317,22 +503,64 @@ func (cs *ChainService) HandleAction(_ context.Context, actPb *iotextypes.Action
 	return nil
 }
 
+// HandleActions handles a burst of incoming actions, which are added into actpool in batch
+func (cs *ChainService) HandleActions(_ context.Context, actPbs []*iotextypes.Action) []error {
+	errs := make([]error, len(actPbs))
+	acts := make([]action.SealedEnvelope, 0, len(actPbs))
+	idx := make([]int, 0, len(actPbs))
+	for i, actPb := range actPbs {
+		var act action.SealedEnvelope
+		if err := act.LoadProto(actPb); err != nil {
+			errs[i] = err
+			continue
+		}
+		acts = append(acts, act)
+		idx = append(idx, i)
+	}
+	for i, err := range cs.actpool.AddBatch(acts) {
+		if err == nil {
+			continue
+		}
+		errs[idx[i]] = err
+		log.L().Debug("Failed to add action.",
+			zap.Error(err),
+			zap.Uint64("nonce", acts[i].Nonce()))
+	}
+	return errs
+}
+
 // HandleBlock handles incoming block request.
 func (cs *ChainService) HandleBlock(ctx context.Context, pbBlock *iotextypes.Block) error {
 	blk := &block.Block{}
 	if err := blk.ConvertFromBlockPb(pbBlock); err != nil {
 		return err
 	}
+	// Only the fresh block signed by its producer is taken as a sample of the peer clocks
+	if cs.timeSanity != nil && blk.Height() == cs.chain.TipHeight()+1 && blk.VerifySignature() {
+		cs.timeSanity.ObserveBlock(blk.ProducerAddress(), time.Unix(blk.Timestamp(), 0), time.Now())
+	}
 	return cs.blocksync.ProcessBlock(ctx, blk)
 }
 
 // HandleBlockSync handles incoming block sync request.
-func (cs *ChainService) HandleBlockSync(ctx context.Context, pbBlock *iotextypes.Block) error {
+func (cs *ChainService) HandleBlockSync(ctx context.Context, peer peerstore.PeerInfo, pbBlock *iotextypes.Block) error {
 	blk := &block.Block{}
 	if err := blk.ConvertFromBlockPb(pbBlock); err != nil {
 		return err
 	}
-	return cs.blocksync.ProcessBlockSync(ctx, blk)
+	return cs.blocksync.ProcessBlockSync(ctx, peer, blk)
+}
+
+// ImportBlock validates the footer and the content of a block from an offline source, and commits it. It is meant to
+// be used when the node isn't running consensus, e.g., importing exported blocks before starting the node
+func (cs *ChainService) ImportBlock(blk *block.Block) error {
+	if err := cs.consensus.ValidateBlockFooter(blk); err != nil {
+		return err
+	}
+	if err := cs.chain.ValidateBlock(blk); err != nil {
+		return err
+	}
+	return cs.chain.CommitBlock(blk)
 }
 
 // HandleSyncRequest handles incoming sync request.
@@ -340,6 +568,39 @@ func (cs *ChainService) HandleSyncRequest(ctx context.Context, peer peerstore.Pe
 	return cs.blocksync.ProcessSyncRequest(ctx, peer, sync)
 }
 
+// HandleCompactBlock handles incoming compact block in response to a sync request.
+func (cs *ChainService) HandleCompactBlock(ctx context.Context, peer peerstore.PeerInfo, cb *iotexrpc.CompactBlock) error {
+	return cs.blocksync.ProcessCompactBlock(ctx, peer, cb)
+}
+
+// HandleBlockActionsRequest handles incoming request of the actions missing from a compact block.
+func (cs *ChainService) HandleBlockActionsRequest(
+	ctx context.Context,
+	peer peerstore.PeerInfo,
+	req *iotexrpc.BlockActionsRequest,
+) error {
+	return cs.blocksync.ProcessBlockActionsRequest(ctx, peer, req)
+}
+
+// HandleBlockActions handles incoming actions missing from a compact block.
+func (cs *ChainService) HandleBlockActions(ctx context.Context, peer peerstore.PeerInfo, ba *iotexrpc.BlockActions) error {
+	return cs.blocksync.ProcessBlockActions(ctx, peer, ba)
+}
+
+// HandleSnapshotChunkRequest handles incoming request of a chunk of a state snapshot.
+func (cs *ChainService) HandleSnapshotChunkRequest(
+	ctx context.Context,
+	peer peerstore.PeerInfo,
+	req *iotexrpc.SnapshotChunkRequest,
+) error {
+	return cs.blocksync.ProcessSnapshotChunkRequest(ctx, peer, req)
+}
+
+// HandleSnapshotChunk handles incoming chunk of a state snapshot.
+func (cs *ChainService) HandleSnapshotChunk(ctx context.Context, peer peerstore.PeerInfo, chunk *iotexrpc.SnapshotChunk) error {
+	return cs.blocksync.ProcessSnapshotChunk(ctx, peer, chunk)
+}
+
 // HandleConsensusMsg handles incoming consensus message.
 func (cs *ChainService) HandleConsensusMsg(msg *iotexrpc.Consensus) error {
 	return cs.consensus.HandleConsensusMsg(msg)
@@ -373,11 +634,47 @@ func (cs *ChainService) IndexService() *indexservice.Server {
 	return cs.indexservice
 }
 
+// consensusTimeline returns the round timelines recorded by the consensus, or nil if its scheme isn't RollDPoS
+func consensusTimeline(c consensus.Consensus) api.ConsensusTimeline {
+	cs, ok := c.(*consensus.IotxConsensus)
+	if !ok {
+		return nil
+	}
+	r, ok := cs.Scheme().(*rolldpos.RollDPoS)
+	if !ok {
+		return nil
+	}
+	return r.RoundTimelines
+}
+
+// registerAdmissionHooks adds the admission policies configured by the operator to actpool, except for the min gas
+// price, which is enforced by actpool itself
+func registerAdmissionHooks(ap actpool.ActPool, cfg config.ActPool) error {
+	var hooks []actpool.AdmissionHook
+	if len(cfg.BlockedSenders) > 0 {
+		hooks = append(hooks, actpool.SenderBlacklist(cfg.BlockedSenders...))
+	}
+	if len(cfg.BlockedContracts) > 0 {
+		hooks = append(hooks, actpool.ContractBlacklist(cfg.BlockedContracts...))
+	}
+	for _, hook := range hooks {
+		if err := ap.RegisterAdmissionHook(hook); err != nil {
+			return errors.Wrap(err, "failed to register admission hook")
+		}
+	}
+	return nil
+}
+
 // Explorer returns the explorer instance
 func (cs *ChainService) Explorer() *explorer.Server {
 	return cs.explorer
 }
 
+// APIServer returns the API server
+func (cs *ChainService) APIServer() *api.Server {
+	return cs.api
+}
+
 // RegisterProtocol register a protocol
 func (cs *ChainService) RegisterProtocol(id string, p protocol.Protocol) error {
 	if err := cs.registry.Register(id, p); err != nil {
@@ -9,6 +9,7 @@ package config
 import (
 	"encoding/hex"
 	"flag"
+	"math/big"
 	"os"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
@@ -54,6 +56,25 @@ const (
 	StandaloneScheme = "STANDALONE"
 	// NOOPScheme means that the node does not create only block
 	NOOPScheme = "NOOP"
+	// IBFTScheme means that a fixed set of validators take turns to propose blocks, which are committed once endorsed
+	// by more than 2/3 of the validators
+	IBFTScheme = "IBFT"
+
+	// PruneModeArchive keeps all the blocks and their receipts
+	PruneModeArchive = "archive"
+	// PruneModeKeepLastN deletes the bodies and the receipts of the blocks before the latest ones
+	PruneModeKeepLastN = "keepLastN"
+	// PruneModeKeepReceiptsOnly deletes the bodies of the blocks before the latest ones, while their receipts are kept
+	PruneModeKeepReceiptsOnly = "keepReceiptsOnly"
+
+	// SnapshotFromPeers is the snapshot URL to fetch the state snapshot in chunks from the peers in fast sync
+	SnapshotFromPeers = "p2p"
+
+	// FailoverPrimary is the role of the delegate node which is active by default in a failover pair
+	FailoverPrimary = "primary"
+	// FailoverBackup is the role of the delegate node which stands by in a failover pair
+	FailoverBackup = "backup"
+
 	// IndexTransfer is table identifier for transfer index in indexer
 	IndexTransfer = "transfer"
 	// IndexVote is table identifier for vote index in indexer
@@ -94,12 +115,40 @@ var (
 			EnableIndex:                  false,
 			EnableAsyncIndexWrite:        false,
 			AllowedBlockGasResidue:       10000,
+			MaxBlockBytes:                0,
+			ReceiptRetentionEpochs:       0,
+			SlowActionThreshold:          time.Second,
+			PruneMode:                    PruneModeArchive,
+			PruneKeepBlocks:              0,
+			PruneInterval:                time.Minute,
+			DisabledValidationRules:      []string{},
+			CommitPipelineDepth:          0,
+			StateDiffDir:                 "",
 		},
 		ActPool: ActPool{
-			MaxNumActsPerPool: 32000,
-			MaxNumActsPerAcct: 2000,
-			MaxNumActsToPick:  0,
-			ActionExpiry:      10 * time.Minute,
+			MaxNumActsPerPool:      32000,
+			MaxNumActsPerAcct:      2000,
+			MaxNumActsToPick:       0,
+			ActionExpiry:           10 * time.Minute,
+			GapEvictionTTL:         0,
+			PickInFIFO:             false,
+			ReplaceByFeePct:        10,
+			JournalPath:            "",
+			JournalRotation:        time.Hour,
+			MaxPoolBytes:           0,
+			EvictLowestGasPrice:    true,
+			RebroadcastAfterBlocks: 0,
+			EnableValidationCache:  false,
+			BlockedSenders:         []string{},
+			BlockedContracts:       []string{},
+			LocalSenders:           []string{},
+			MinGasPriceStr:         "0",
+			DynamicGasPriceFloor: DynamicGasPriceFloor{
+				Enabled:            false,
+				HighUtilizationPct: 80,
+				LowUtilizationPct:  20,
+				StepPct:            10,
+			},
 		},
 		Consensus: Consensus{
 			Scheme: NOOPScheme,
@@ -112,25 +161,61 @@ var (
 					AcceptLockEndorsementTTL:     2 * time.Second,
 					EventChanSize:                10000,
 				},
-				ToleratedOvertime: 2 * time.Second,
-				DelegateInterval:  10 * time.Second,
-				Delay:             5 * time.Second,
-				NumSubEpochs:      1,
-				NumDelegates:      21,
-				TimeBasedRotation: false,
+				ToleratedOvertime:   2 * time.Second,
+				DelegateInterval:    10 * time.Second,
+				Delay:               5 * time.Second,
+				NumSubEpochs:        1,
+				NumDelegates:        21,
+				TimeBasedRotation:   false,
+				ProposerGraceWindow: 0,
+				SyncTriggerDistance: 0,
+				WithholdDetection: WithholdDetection{
+					Window:           0,
+					MaxLateProposals: 0,
+				},
+				RemoteSigner: RemoteSigner{
+					Endpoint: "",
+					Timeout:  time.Second,
+				},
+			},
+			IBFT: IBFT{
+				Validators:    []string{},
+				BlockInterval: 5 * time.Second,
+				RoundTimeout:  10 * time.Second,
+				LockPath:      "/tmp/ibft.lock",
 			},
 			BlockCreationInterval: 10 * time.Second,
 		},
 		BlockSync: BlockSync{
-			Interval:   10 * time.Second,
-			BufferSize: 16,
+			Interval:               10 * time.Second,
+			BufferSize:             16,
+			SpillThresholdBytes:    0,
+			SpillDBPath:            "",
+			MaxSyncRequestBlocks:   128,
+			SyncRequestLimit:       30,
+			SyncRequestWindow:      10 * time.Second,
+			SyncRequestBanDuration: 5 * time.Minute,
+			CommitBatchSize:        1,
+			CompactBlocks:          false,
+			CatchUpDistance:        0,
+			CatchUpQuorum:          2,
+			FastSync:               false,
+			SnapshotURL:            "",
+			MaxReorgDepth:          0,
+			SnapshotInterval:       0,
+			SnapshotDir:            "/tmp/state-snapshots",
+			SnapshotChunkSize:      512 * 1024,
+			SnapshotsKept:          2,
+			SnapshotRequestLimit:   64,
 		},
 		Dispatcher: Dispatcher{
-			EventChanSize: 10000,
+			EventChanSize:   10000,
+			ActionBatchSize: 0,
 		},
 		Explorer: Explorer{
 			Enabled:    false,
 			UseIndexer: false,
+			Host:       "",
 			Port:       14004,
 			TpsWindow:  10,
 			GasStation: GasStation{
@@ -143,6 +228,7 @@ var (
 		API: API{
 			Enabled:   false,
 			UseRDS:    false,
+			Host:      "",
 			Port:      14014,
 			TpsWindow: 10,
 			GasStation: GasStation{
@@ -151,6 +237,10 @@ var (
 				Percentile:         60,
 			},
 			MaxTransferPayloadBytes: 1024,
+			ReadStateCacheSize:      1000,
+			KeyStorePath:            "",
+			RequireAPIKey:           false,
+			RangeQueryLimit:         100,
 		},
 		Indexer: Indexer{
 			Enabled:           false,
@@ -166,6 +256,31 @@ var (
 			HTTPProbePort:         7788,
 			StartSubChainInterval: 10 * time.Second,
 		},
+		RewardClaim: RewardClaim{
+			Enabled:      false,
+			Interval:     time.Hour,
+			ThresholdStr: "0",
+			Recipient:    "",
+			GasLimit:     10000,
+			GasPriceStr:  "0",
+			DryRun:       false,
+		},
+		TimeSanity: TimeSanity{
+			Enabled:         false,
+			Interval:        time.Minute,
+			NTPServer:       "pool.ntp.org:123",
+			NTPTimeout:      5 * time.Second,
+			MaxNTPSkew:      time.Second,
+			PeerWindow:      20,
+			MaxPeerSkew:     10 * time.Second,
+			RefuseToPropose: false,
+		},
+		Backup: Backup{
+			Enabled:   false,
+			Interval:  24 * time.Hour,
+			Dir:       "./backup",
+			Retention: 3,
+		},
 		DB: DB{
 			UseBadgerDB: false,
 			NumRetries:  3,
@@ -183,11 +298,16 @@ var (
 		ValidateKeyPair,
 		ValidateConsensusScheme,
 		ValidateRollDPoS,
+		ValidateIBFT,
 		ValidateDispatcher,
 		ValidateExplorer,
 		ValidateAPI,
 		ValidateActPool,
 		ValidateChain,
+		ValidateRewardClaim,
+		ValidateTimeSanity,
+		ValidateBackup,
+		ValidateBlockSync,
 	}
 
 	// PrivateKey is a randomly generated producer's key for testing purpose
@@ -197,10 +317,13 @@ var (
 // Network is the config struct for network package
 type (
 	Network struct {
-		Host           string   `yaml:"host"`
-		Port           int      `yaml:"port"`
-		ExternalHost   string   `yaml:"externalHost"`
-		ExternalPort   int      `yaml:"externalPort"`
+		// Host is the IPv4 address, or the host name, to listen on
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+		// ExternalHost is the IPv4 address, or the host name, advertised to the peers
+		ExternalHost string `yaml:"externalHost"`
+		ExternalPort int    `yaml:"externalPort"`
+		// BootstrapNodes are the multiaddrs of the bootstrap nodes, which could be reached over IPv4 or IPv6
 		BootstrapNodes []string `yaml:"bootstrapNodes"`
 		MasterKey      string   `yaml:"masterKey"` // master key will be PrivateKey if not set.
 	}
@@ -213,6 +336,7 @@ type (
 		Address                      string `yaml:"address"`
 		ProducerPubKey               string `yaml:"producerPubKey"`
 		ProducerPrivKey              string `yaml:"producerPrivKey"`
+		ProducerBLSPrivKey           string `yaml:"producerBLSPrivKey"`
 		GenesisActionsPath           string `yaml:"genesisActionsPath"`
 		EmptyGenesis                 bool   `yaml:"emptyGenesis"`
 		NumCandidates                uint   `yaml:"numCandidates"`
@@ -225,6 +349,37 @@ type (
 		EnableAsyncIndexWrite bool `yaml:"enableAsyncIndexWrite"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
+		// MaxBlockBytes is the max total size in bytes of the actions picked to mint a block. Default is 0, which means
+		// no limit on the size
+		MaxBlockBytes uint64 `yaml:"maxBlockBytes"`
+		// ReceiptRetentionEpochs is the number of the latest epochs whose receipts are kept, while the receipts of the
+		// earlier blocks are pruned and the blocks themselves are kept. 0 means keeping all the receipts
+		ReceiptRetentionEpochs uint64 `yaml:"receiptRetentionEpochs"`
+		// AnchorInterval is the number of sub-chain blocks between two puts of the block hash and merkle roots into
+		// main-chain by the producer of this node. 0 means not relaying the sub-chain blocks
+		AnchorInterval uint64 `yaml:"anchorInterval"`
+		// SlowActionThreshold is the execution time above which an action is logged as a slow one. 0 means not
+		// logging the slow actions
+		SlowActionThreshold time.Duration `yaml:"slowActionThreshold"`
+		// PruneMode is how the old blocks are pruned, which is one of archive, keepLastN and keepReceiptsOnly. The
+		// blocks are pruned in the background, and the chain DB is compacted on start if the pruned blocks have left
+		// at least half of it free
+		PruneMode string `yaml:"pruneMode"`
+		// PruneKeepBlocks is the number of the latest blocks kept when the blocks are pruned
+		PruneKeepBlocks uint64 `yaml:"pruneKeepBlocks"`
+		// PruneInterval is the interval to prune the blocks in the background
+		PruneInterval time.Duration `yaml:"pruneInterval"`
+		// DisabledValidationRules are the names of the block validation rules which aren't run, e.g., on a testnet.
+		// The required rules can't be disabled
+		DisabledValidationRules []string `yaml:"disabledValidationRules"`
+		// CommitPipelineDepth is the number of blocks buffered between the stages of committing a batch of blocks,
+		// e.g., when syncing, where validating, executing, writing the blocks and writing the states run on separate
+		// goroutines. 0 means committing the blocks stage by stage
+		CommitPipelineDepth uint64 `yaml:"commitPipelineDepth"`
+		// StateDiffDir is the directory to dump the changes of the states made by each action of a block, whose state
+		// root or delta state digest doesn't match the local execution, in order to compare with other nodes. Empty
+		// means not diagnosing the mismatches
+		StateDiffDir string `yaml:"stateDiffDir"`
 	}
 
 	// Consensus is the config struct for consensus package
@@ -232,13 +387,72 @@ type (
 		// There are three schemes that are supported
 		Scheme                string        `yaml:"scheme"`
 		RollDPoS              RollDPoS      `yaml:"rollDPoS"`
+		IBFT                  IBFT          `yaml:"ibft"`
 		BlockCreationInterval time.Duration `yaml:"blockCreationInterval"`
+		// InstantSeal makes the standalone scheme create a block as soon as there are actions in actpool, rather than
+		// every block creation interval. It is meant for the local development node
+		InstantSeal bool `yaml:"instantSeal"`
+		// SkipEmptyBlocks makes the proposer skip minting a block when there is no action to pack, so that the chain
+		// db doesn't grow with empty blocks on a low-traffic deployment
+		SkipEmptyBlocks bool `yaml:"skipEmptyBlocks"`
+		// EmptyBlockInterval is how long after the tip block an empty block is minted anyway as a heartbeat, when the
+		// empty blocks are skipped. 0 means never minting an empty block
+		EmptyBlockInterval time.Duration `yaml:"emptyBlockInterval"`
 	}
 
 	// BlockSync is the config struct for the BlockSync
 	BlockSync struct {
 		Interval   time.Duration `yaml:"interval"` // update duration
 		BufferSize uint64        `yaml:"bufferSize"`
+		// SpillThresholdBytes is the max total size in bytes of the buffered blocks kept in memory, beyond which the
+		// highest blocks are spilled to disk. 0 means all the buffered blocks are kept in memory
+		SpillThresholdBytes uint64 `yaml:"spillThresholdBytes"`
+		// SpillDBPath is the path of the temporary store of the spilled blocks. Default is "", which means the path
+		// of the chain DB suffixed with ".spill", so that the nodes on the same host don't share the store
+		SpillDBPath string `yaml:"spillDBPath"`
+		// MaxSyncRequestBlocks is the max number of blocks served for a sync request, 0 means no limit
+		MaxSyncRequestBlocks uint64 `yaml:"maxSyncRequestBlocks"`
+		// SyncRequestLimit is the max number of sync requests served for a peer within SyncRequestWindow, 0 means no
+		// limit
+		SyncRequestLimit  uint64        `yaml:"syncRequestLimit"`
+		SyncRequestWindow time.Duration `yaml:"syncRequestWindow"`
+		// SyncRequestBanDuration is how long a peer exceeding the sync request limit is banned
+		SyncRequestBanDuration time.Duration `yaml:"syncRequestBanDuration"`
+		// CommitBatchSize is the max number of sequential buffered blocks whose states are committed in a batch, which
+		// saves the DB transactions during sync. 0 or 1 means the blocks are committed one by one
+		CommitBatchSize uint64 `yaml:"commitBatchSize"`
+		// CompactBlocks requests the blocks in compact form, i.e., the header and the action hashes. The blocks are
+		// reconstructed with the actions in the actpool, and only the missing actions are requested from the peer
+		CompactBlocks bool `yaml:"compactBlocks"`
+		// CatchUpDistance is the number of blocks the tip may fall behind the height delivered by a quorum of peers,
+		// beyond which the delegate holds the consensus in standby until the sync catches up. 0 means the consensus
+		// never waits. The peers only deliver the blocks within the buffer above the tip, so the distance has to be
+		// less than the buffer size
+		CatchUpDistance uint64 `yaml:"catchUpDistance"`
+		// CatchUpQuorum is the number of peers which have to deliver the blocks up to a height before it counts
+		CatchUpQuorum int `yaml:"catchUpQuorum"`
+		// FastSync syncs a fresh node up to the trusted checkpoint in genesis by verifying the block headers down from
+		// the checkpoint hash, and loading the states at the checkpoint from a snapshot. Only the blocks after the
+		// checkpoint are executed and fully validated
+		FastSync bool `yaml:"fastSync"`
+		// SnapshotURL is where the state snapshot at the checkpoint is fetched from in fast sync, either an http(s) URL
+		// or a local file path, or p2p to fetch it in chunks from the peers serving the snapshots
+		SnapshotURL string `yaml:"snapshotURL"`
+		// MaxReorgDepth is the max number of blocks the chain is rolled back, to reorganize onto the branch which the
+		// consensus prefers over the committed one, e.g., once a network partition heals. 0 disables the reorg
+		MaxReorgDepth uint64 `yaml:"maxReorgDepth"`
+		// SnapshotInterval is the interval of the heights at which the state snapshots are generated and served to the
+		// peers in chunks, besides the checkpoint height in genesis. 0 means no snapshot is served
+		SnapshotInterval uint64 `yaml:"snapshotInterval"`
+		// SnapshotDir is the directory of the state snapshots served to the peers
+		SnapshotDir string `yaml:"snapshotDir"`
+		// SnapshotChunkSize is the max number of bytes of a snapshot chunk
+		SnapshotChunkSize uint64 `yaml:"snapshotChunkSize"`
+		// SnapshotsKept is the number of the latest state snapshots kept in the snapshot directory
+		SnapshotsKept uint64 `yaml:"snapshotsKept"`
+		// SnapshotRequestLimit is the max number of snapshot chunk requests served for a peer within SyncRequestWindow,
+		// 0 means no limit
+		SnapshotRequestLimit uint64 `yaml:"snapshotRequestLimit"`
 	}
 
 	// RollDPoS is the config struct for RollDPoS consensus package
@@ -251,11 +465,78 @@ type (
 		NumSubEpochs      uint `yaml:"numSubEpochs"`
 		NumDelegates      uint `yaml:"numDelegates"`
 		TimeBasedRotation bool `yaml:"timeBasedRotation"`
+		// ProposerGraceWindow is how far the local time may drift from the round in which the proposer of a block is
+		// scheduled, for the block to be accepted. Default is 0, which only accepts the proposer of the current round
+		ProposerGraceWindow time.Duration `yaml:"proposerGraceWindow"`
+		// SyncTriggerDistance is how far above the local tip a proposal has to be, for the block sync toward it to be
+		// triggered right away rather than in the next sync interval. Default is 0, which disables the trigger
+		SyncTriggerDistance uint64 `yaml:"syncTriggerDistance"`
+		// Failover pairs the node with another delegate node sharing the same key
+		Failover Failover `yaml:"failover"`
+		// WithholdDetection detects the proposers who broadcast their blocks too late to be endorsed
+		WithholdDetection WithholdDetection `yaml:"withholdDetection"`
+		// RemoteSigner keeps the delegate's key in a remote signer instead of the node
+		RemoteSigner RemoteSigner `yaml:"remoteSigner"`
+	}
+
+	// IBFT is the config struct for IBFT consensus package, which is meant for the private chains with a fixed set of
+	// validators
+	IBFT struct {
+		// Validators are the addresses of the validators, which take turns to propose blocks
+		Validators []string `yaml:"validators"`
+		// BlockInterval is the minimum interval between two blocks
+		BlockInterval time.Duration `yaml:"blockInterval"`
+		// RoundTimeout is how long to wait for a block to be committed before requesting to move on to the next round
+		RoundTimeout time.Duration `yaml:"roundTimeout"`
+		// LockPath is the file to persist the block the validator is locked on and the last round it voted in, so that
+		// the validator never votes against its lock after a restart
+		LockPath string `yaml:"lockPath"`
+	}
+
+	// Failover is the config of a pair of delegate nodes sharing the same key, which coordinate through a signing
+	// lease so that only one of them signs in a round
+	Failover struct {
+		// Role is either primary or backup. An empty role disables the failover
+		Role string `yaml:"role"`
+		// NodeID identifies the node in the pair
+		NodeID string `yaml:"nodeID"`
+		// LeasePath is the path of the lease file on the storage shared by the pair
+		LeasePath string `yaml:"leasePath"`
+		// LeaseTTL is how long the lease is held without renewal, which is renewed every third of it, and should be
+		// larger than the delegate interval
+		LeaseTTL time.Duration `yaml:"leaseTTL"`
+	}
+
+	// RemoteSigner is the config of the remote signer, e.g., an HSM or an isolated signing box, which signs the
+	// proposals, the endorsements and the evidences of the delegate
+	RemoteSigner struct {
+		// Endpoint is the address of the signer service. An empty endpoint signs with the producer private key, which
+		// is never read otherwise
+		Endpoint string `yaml:"endpoint"`
+		// Timeout is how long to wait for a signature, which should be well within a consensus round
+		Timeout time.Duration `yaml:"timeout"`
+		// CACertPath is the file of the certificate authority verifying the TLS certificate of the signer service
+		CACertPath string `yaml:"caCertPath"`
+		// AuthToken is the token to authenticate with the signer service
+		AuthToken string `yaml:"authToken"`
+	}
+
+	// WithholdDetection is the config to detect the proposers who withhold their blocks, i.e., whose blocks arrive after
+	// the time to accept a block of the round
+	WithholdDetection struct {
+		// Window is the number of the latest proposals of a proposer to look at. Default is 0, which disables the
+		// detection
+		Window uint `yaml:"window"`
+		// MaxLateProposals is the number of the late proposals in the window, at which an evidence is recorded
+		MaxLateProposals uint `yaml:"maxLateProposals"`
 	}
 
 	// Dispatcher is the dispatcher config
 	Dispatcher struct {
 		EventChanSize uint `yaml:"eventChanSize"`
+		// ActionBatchSize is the max number of the queued actions handed over to a subscriber at once, if it handles
+		// actions in batch. Default is 0, which handles the actions one by one
+		ActionBatchSize uint `yaml:"actionBatchSize"`
 	}
 
 	// Explorer is the explorer service config
@@ -268,6 +549,11 @@ type (
 		GasStation GasStation `yaml:"gasStation"`
 		// MaxTransferPayloadBytes limits how many bytes a playload can contain at most
 		MaxTransferPayloadBytes uint64 `yaml:"maxTransferPayloadBytes"`
+		// APIBridge serves the legacy explorer endpoints with the data of the api, which has to be enabled, in place
+		// of the deprecated explorer service
+		APIBridge bool `yaml:"apiBridge"`
+		// Host is the address to listen on, empty means all the IPv4 and IPv6 addresses
+		Host string `yaml:"host"`
 	}
 
 	// API is the api service config
@@ -280,6 +566,17 @@ type (
 		GasStation GasStation `yaml:"gasStation"`
 		// MaxTransferPayloadBytes limits how many bytes a playload can contain at most
 		MaxTransferPayloadBytes uint64 `yaml:"maxTransferPayloadBytes"`
+		// ReadStateCacheSize is the max number of the protocol read results cached at the tip, 0 disables the cache
+		ReadStateCacheSize int `yaml:"readStateCacheSize"`
+		// KeyStorePath is the file of the API keys managed via the admin endpoint on HTTPAdminPort, empty disables the
+		// API keys
+		KeyStorePath string `yaml:"keyStorePath"`
+		// RequireAPIKey rejects the requests without an API key, otherwise they are served without any quota
+		RequireAPIKey bool `yaml:"requireAPIKey"`
+		// Host is the address to listen on, empty means all the IPv4 and IPv6 addresses
+		Host string `yaml:"host"`
+		// RangeQueryLimit is the max number of blocks to return in a single range query
+		RangeQueryLimit uint64 `yaml:"rangeQueryLimit"`
 	}
 
 	// GasStation is the gas station config
@@ -289,6 +586,54 @@ type (
 		Percentile         int    `yaml:"Percentile"`
 	}
 
+	// RewardClaim is the config to automatically claim the unclaimed reward of the local delegate
+	RewardClaim struct {
+		Enabled bool `yaml:"enabled"`
+		// Interval is the interval to check the unclaimed balance of the local delegate
+		Interval time.Duration `yaml:"interval"`
+		// ThresholdStr is the min unclaimed balance to trigger a claim in decimal string format
+		ThresholdStr string `yaml:"threshold"`
+		// Recipient is the address to receive the claimed reward. If it's empty, the reward goes to the delegate itself
+		Recipient string `yaml:"recipient"`
+		GasLimit  uint64 `yaml:"gasLimit"`
+		// GasPriceStr is the gas price of the claim action in decimal string format
+		GasPriceStr string `yaml:"gasPrice"`
+		// DryRun only logs the claim action that would be submitted
+		DryRun bool `yaml:"dryRun"`
+	}
+
+	// TimeSanity is the config to check the local clock against a NTP server and the timestamps of the blocks from the
+	// peers
+	TimeSanity struct {
+		Enabled bool `yaml:"enabled"`
+		// Interval is the interval to check the clock skew
+		Interval time.Duration `yaml:"interval"`
+		// NTPServer is the address of the NTP server. If it's empty, the clock isn't checked against NTP
+		NTPServer  string        `yaml:"ntpServer"`
+		NTPTimeout time.Duration `yaml:"ntpTimeout"`
+		// MaxNTPSkew is the max tolerated offset between the local clock and the NTP server
+		MaxNTPSkew time.Duration `yaml:"maxNTPSkew"`
+		// PeerWindow is the number of the recent blocks from the peers whose timestamps are compared
+		PeerWindow int `yaml:"peerWindow"`
+		// MaxPeerSkew is the max tolerated median offset between the receiving time and the timestamp of the recent
+		// blocks from the peers, which should cover the time to reach consensus and propagate a block
+		MaxPeerSkew time.Duration `yaml:"maxPeerSkew"`
+		// RefuseToPropose stops the node from proposing blocks while the clock is skewed
+		RefuseToPropose bool `yaml:"refuseToPropose"`
+	}
+
+	// Backup is the config to periodically take a hot backup of the chain, where only the latest backups are kept
+	Backup struct {
+		Enabled bool `yaml:"enabled"`
+		// Interval is the interval between the backups
+		Interval time.Duration `yaml:"interval"`
+		// Dir is the directory holding the backups, each of which is in a sub directory named after its time, or the
+		// name given to the admin endpoint
+		Dir string `yaml:"dir"`
+		// Retention is the number of the latest backups to keep
+		Retention int `yaml:"retention"`
+	}
+
 	// Indexer is the index service config
 	Indexer struct {
 		Enabled           bool   `yaml:"enabled"`
@@ -309,19 +654,75 @@ type (
 		HTTPMetricsPort       int           `yaml:"httpMetricsPort"`
 		HTTPProbePort         int           `yaml:"httpProbePort"`
 		StartSubChainInterval time.Duration `yaml:"startSubChainInterval"`
+		// HTTPAdminPort is the port number of the admin endpoints, e.g., to take a backup, which are only served on the
+		// loopback interface. It is 0 by default, meaning the admin endpoints have been disabled
+		HTTPAdminPort int `yaml:"httpAdminPort"`
 	}
 
 	// ActPool is the actpool config
 	ActPool struct {
 		// MaxNumActsPerPool indicates maximum number of actions the whole actpool can hold
 		MaxNumActsPerPool uint64 `yaml:"maxNumActsPerPool"`
-		// MaxNumActsPerAcct indicates maximum number of actions an account queue can hold
+		// MaxNumActsPerAcct indicates maximum number of actions an account queue can hold, which is the quota of a
+		// sender
 		MaxNumActsPerAcct uint64 `yaml:"maxNumActsPerAcct"`
 		// MaxNumActsToPick indicates maximum number of actions to pick to mint a block. Default is 0, which means no
 		// limit on the number of actions to pick.
 		MaxNumActsToPick uint64 `yaml:"maxNumActsToPick"`
 		// ActionExpiry defines how long an action will be kept in action pool.
 		ActionExpiry time.Duration `yaml:"actionExpiry"`
+		// GapEvictionTTL defines how long an action beyond the pending nonce will be kept waiting for the preceding
+		// nonces. Default is 0, which means the action is kept until it expires.
+		GapEvictionTTL time.Duration `yaml:"gapEvictionTTL"`
+		// PickInFIFO indicates whether to pick the actions account by account, rather than by gas price from high to
+		// low
+		PickInFIFO bool `yaml:"pickInFIFO"`
+		// ReplaceByFeePct is the minimum percentage by which the gas price of an action should exceed that of the
+		// action with the same nonce in pool to replace it. Default is 10, and 0 disables the replacement.
+		ReplaceByFeePct uint64 `yaml:"replaceByFeePct"`
+		// JournalPath is the path of the journal file, which keeps the actions accepted by actpool so that they are
+		// reloaded after the node restarts. Default is empty, which disables the journal
+		JournalPath string `yaml:"journalPath"`
+		// JournalRotation is the interval to regenerate the journal with the actions in actpool, which drops the
+		// actions that have been committed or evicted
+		JournalRotation time.Duration `yaml:"journalRotation"`
+		// MaxPoolBytes indicates maximum total size in bytes of the actions the whole actpool can hold. Default is 0,
+		// which means no limit
+		MaxPoolBytes uint64 `yaml:"maxPoolBytes"`
+		// EvictLowestGasPrice indicates whether to make room for an action when actpool is full, by dropping the action
+		// of the lowest gas price among the last actions of the other senders, if its gas price is lower
+		EvictLowestGasPrice bool `yaml:"evictLowestGasPrice"`
+		// RebroadcastAfterBlocks is the number of blocks after which an action submitted via this node's API is
+		// broadcast again if it is still in pool, in case the initial broadcast is missed by the delegates. Default is
+		// 0, which disables the re-broadcast
+		RebroadcastAfterBlocks uint64 `yaml:"rebroadcastAfterBlocks"`
+		// EnableValidationCache indicates whether to cache the actions which have passed the validation, so that they
+		// aren't validated again when minting a block, until the nonce or the balance of their senders changes
+		EnableValidationCache bool `yaml:"enableValidationCache"`
+		// BlockedSenders are the addresses whose actions are rejected by actpool
+		BlockedSenders []string `yaml:"blockedSenders"`
+		// BlockedContracts are the addresses of the contracts whose executions are rejected by actpool
+		BlockedContracts []string `yaml:"blockedContracts"`
+		// LocalSenders are the addresses of the node operator, whose actions sent via the API of this node are kept in
+		// pool as local actions, which aren't evicted for the actions received from the network
+		LocalSenders []string `yaml:"localSenders"`
+		// MinGasPriceStr is the min gas price in decimal string format of the actions accepted by actpool
+		MinGasPriceStr string `yaml:"minGasPrice"`
+		// DynamicGasPriceFloor adjusts the min gas price of the actions accepted by actpool with the utilization of
+		// the pool, which never goes below MinGasPriceStr
+		DynamicGasPriceFloor DynamicGasPriceFloor `yaml:"dynamicGasPriceFloor"`
+	}
+
+	// DynamicGasPriceFloor is the config to raise the min gas price of actpool when it's crowded and lower it when it
+	// drains. The floor is adjusted once a block is committed
+	DynamicGasPriceFloor struct {
+		Enabled bool `yaml:"enabled"`
+		// HighUtilizationPct is the percentage of the pool capacity in use, at or above which the floor is raised
+		HighUtilizationPct uint64 `yaml:"highUtilizationPct"`
+		// LowUtilizationPct is the percentage of the pool capacity in use, at or below which the floor is lowered
+		LowUtilizationPct uint64 `yaml:"lowUtilizationPct"`
+		// StepPct is the percentage by which the floor is raised or lowered each time
+		StepPct uint64 `yaml:"stepPct"`
 	}
 
 	// DB is the config for database
@@ -361,19 +762,22 @@ type (
 
 	// Config is the root config struct, each package's config should be put as its sub struct
 	Config struct {
-		NodeType   string           `yaml:"nodeType"`
-		Network    Network          `yaml:"network"`
-		Chain      Chain            `yaml:"chain"`
-		ActPool    ActPool          `yaml:"actPool"`
-		Consensus  Consensus        `yaml:"consensus"`
-		BlockSync  BlockSync        `yaml:"blockSync"`
-		Dispatcher Dispatcher       `yaml:"dispatcher"`
-		Explorer   Explorer         `yaml:"explorer"`
-		API        API              `yaml:"api"`
-		Indexer    Indexer          `yaml:"indexer"`
-		System     System           `yaml:"system"`
-		DB         DB               `yaml:"db"`
-		Log        log.GlobalConfig `yaml:"log"`
+		NodeType    string           `yaml:"nodeType"`
+		Network     Network          `yaml:"network"`
+		Chain       Chain            `yaml:"chain"`
+		ActPool     ActPool          `yaml:"actPool"`
+		Consensus   Consensus        `yaml:"consensus"`
+		BlockSync   BlockSync        `yaml:"blockSync"`
+		Dispatcher  Dispatcher       `yaml:"dispatcher"`
+		Explorer    Explorer         `yaml:"explorer"`
+		API         API              `yaml:"api"`
+		Indexer     Indexer          `yaml:"indexer"`
+		System      System           `yaml:"system"`
+		RewardClaim RewardClaim      `yaml:"rewardClaim"`
+		TimeSanity  TimeSanity       `yaml:"timeSanity"`
+		Backup      Backup           `yaml:"backup"`
+		DB          DB               `yaml:"db"`
+		Log         log.GlobalConfig `yaml:"log"`
 	}
 
 	// Validate is the interface of validating the config
@@ -403,8 +807,8 @@ func New(validates ...Validate) (Config, error) {
 		return Config{}, errors.Wrap(err, "failed to unmarshal YAML config to struct")
 	}
 
-	// set network master key to private key
-	if cfg.Network.MasterKey == "" {
+	// set network master key to private key, unless the private key is kept in a remote signer
+	if cfg.Network.MasterKey == "" && cfg.Consensus.RollDPoS.RemoteSigner.Endpoint == "" {
 		cfg.Network.MasterKey = cfg.Chain.ProducerPrivKey
 	}
 
@@ -492,8 +896,28 @@ func (cfg Config) KeyPair() (keypair.PublicKey, keypair.PrivateKey, error) {
 	return pk, sk, nil
 }
 
-// ValidateKeyPair validates the block producer address
+// BLSPrivateKey returns the decoded BLS private key of the block producer, which is nil if it isn't configured
+func (cfg Config) BLSPrivateKey() ([]uint32, error) {
+	if cfg.Chain.ProducerBLSPrivKey == "" {
+		return nil, nil
+	}
+	skBytes, err := hex.DecodeString(cfg.Chain.ProducerBLSPrivKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when decoding BLS private key")
+	}
+	sk, err := cp.BLS.PrivateKeyFromBytes(skBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when decoding BLS private key")
+	}
+	return sk, nil
+}
+
+// ValidateKeyPair validates the block producer address, which is skipped if the producer private key is kept in a
+// remote signer
 func ValidateKeyPair(cfg Config) error {
+	if cfg.Consensus.RollDPoS.RemoteSigner.Endpoint != "" {
+		return nil
+	}
 	pkBytes, err := hex.DecodeString(cfg.Chain.ProducerPubKey)
 	if err != nil {
 		return err
@@ -523,6 +947,19 @@ func ValidateChain(cfg Config) error {
 	if cfg.Consensus.Scheme == RollDPoSScheme && cfg.Chain.NumCandidates < cfg.Consensus.RollDPoS.NumDelegates {
 		return errors.Wrapf(ErrInvalidCfg, "candidate number should be greater than or equal to delegate number")
 	}
+	switch cfg.Chain.PruneMode {
+	case "", PruneModeArchive:
+	case PruneModeKeepLastN, PruneModeKeepReceiptsOnly:
+		// The blocks above the reorg depth are read when the chain is rolled back
+		if cfg.Chain.PruneKeepBlocks <= cfg.BlockSync.MaxReorgDepth {
+			return errors.Wrap(ErrInvalidCfg, "number of the blocks kept should be greater than the max reorg depth")
+		}
+		if cfg.Chain.PruneInterval <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "prune interval should be greater than 0")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown prune mode %s", cfg.Chain.PruneMode)
+	}
 	return nil
 }
 
@@ -544,6 +981,29 @@ func ValidateConsensusScheme(cfg Config) error {
 	return nil
 }
 
+// ValidateIBFT validates the IBFT configs
+func ValidateIBFT(cfg Config) error {
+	if cfg.Consensus.Scheme != IBFTScheme {
+		return nil
+	}
+	ibft := cfg.Consensus.IBFT
+	if len(ibft.Validators) == 0 {
+		return errors.Wrap(ErrInvalidCfg, "IBFT validators should not be empty")
+	}
+	for _, validator := range ibft.Validators {
+		if _, err := address.FromString(validator); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "invalid IBFT validator address %s", validator)
+		}
+	}
+	if ibft.BlockInterval <= 0 || ibft.RoundTimeout <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "IBFT block interval and round timeout should be greater than 0")
+	}
+	if ibft.LockPath == "" {
+		return errors.Wrap(ErrInvalidCfg, "IBFT lock path should not be empty")
+	}
+	return nil
+}
+
 // ValidateDispatcher validates the dispatcher configs
 func ValidateDispatcher(cfg Config) error {
 	if cfg.Dispatcher.EventChanSize <= 0 {
@@ -569,6 +1029,32 @@ func ValidateRollDPoS(cfg Config) error {
 	if ttl >= rollDPoS.DelegateInterval {
 		return errors.Wrap(ErrInvalidCfg, "roll-DPoS ttl sum is larger than proposer interval")
 	}
+	failover := rollDPoS.Failover
+	switch failover.Role {
+	case "":
+	case FailoverPrimary, FailoverBackup:
+		if failover.NodeID == "" || failover.LeasePath == "" {
+			return errors.Wrap(ErrInvalidCfg, "failover node ID and lease path should be set")
+		}
+		// The lease is renewed every third of the ttl, and acquired at the beginning of a round, which it should cover
+		if failover.LeaseTTL <= rollDPoS.DelegateInterval {
+			return errors.Wrap(ErrInvalidCfg, "failover lease ttl should be larger than roll-DPoS delegate interval")
+		}
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown failover role %s", failover.Role)
+	}
+	withhold := rollDPoS.WithholdDetection
+	if withhold.Window > 0 && (withhold.MaxLateProposals == 0 || withhold.MaxLateProposals > withhold.Window) {
+		return errors.Wrap(ErrInvalidCfg, "withhold detection max late proposals should be in (0, window]")
+	}
+	if remote := rollDPoS.RemoteSigner; remote.Endpoint != "" {
+		if remote.Timeout <= 0 {
+			return errors.Wrap(ErrInvalidCfg, "remote signer timeout should be greater than 0")
+		}
+		if remote.CACertPath == "" || remote.AuthToken == "" {
+			return errors.Wrap(ErrInvalidCfg, "remote signer CA certificate and auth token should be set")
+		}
+	}
 
 	return nil
 }
@@ -578,6 +1064,9 @@ func ValidateExplorer(cfg Config) error {
 	if cfg.Explorer.Enabled && cfg.Explorer.TpsWindow <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "tps window is not a positive integer when the explorer is enabled")
 	}
+	if cfg.Explorer.Enabled && cfg.Explorer.APIBridge && !cfg.API.Enabled {
+		return errors.Wrap(ErrInvalidCfg, "api is not enabled when the explorer is bridged to the api")
+	}
 	return nil
 }
 
@@ -586,6 +1075,12 @@ func ValidateAPI(cfg Config) error {
 	if cfg.API.Enabled && cfg.API.TpsWindow <= 0 {
 		return errors.Wrap(ErrInvalidCfg, "tps window is not a positive integer when the api is enabled")
 	}
+	if cfg.API.RequireAPIKey && cfg.API.KeyStorePath == "" {
+		return errors.Wrap(ErrInvalidCfg, "key store path should be set when api key is required")
+	}
+	if cfg.API.KeyStorePath != "" && cfg.System.HTTPAdminPort <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "admin port should be set to manage the api keys in the key store")
+	}
 	return nil
 }
 
@@ -605,6 +1100,113 @@ func ValidateActPool(cfg Config) error {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		)
 	}
+	if cfg.ActPool.JournalPath != "" && cfg.ActPool.JournalRotation <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "journal rotation interval should be greater than 0")
+	}
+	if cfg.ActPool.MinGasPriceStr != "" {
+		if price, ok := big.NewInt(0).SetString(cfg.ActPool.MinGasPriceStr, 10); !ok || price.Sign() < 0 {
+			return errors.Wrapf(ErrInvalidCfg, "min gas price %s is invalid", cfg.ActPool.MinGasPriceStr)
+		}
+	}
+	if floor := cfg.ActPool.DynamicGasPriceFloor; floor.Enabled {
+		if floor.LowUtilizationPct >= floor.HighUtilizationPct || floor.HighUtilizationPct > 100 {
+			return errors.Wrap(ErrInvalidCfg, "gas price floor utilization should be low < high <= 100")
+		}
+		if floor.StepPct == 0 || floor.StepPct >= 100 {
+			return errors.Wrap(ErrInvalidCfg, "gas price floor step should be in (0, 100)")
+		}
+	}
+	for _, addr := range cfg.ActPool.BlockedSenders {
+		if _, err := address.FromString(addr); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "blocked sender %s is invalid", addr)
+		}
+	}
+	for _, addr := range cfg.ActPool.BlockedContracts {
+		if _, err := address.FromString(addr); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "blocked contract %s is invalid", addr)
+		}
+	}
+	for _, addr := range cfg.ActPool.LocalSenders {
+		if _, err := address.FromString(addr); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "local sender %s is invalid", addr)
+		}
+	}
+	return nil
+}
+
+// ValidateRewardClaim validates the reward claim configs
+func ValidateRewardClaim(cfg Config) error {
+	if !cfg.RewardClaim.Enabled {
+		return nil
+	}
+	if cfg.RewardClaim.Interval <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "reward claim interval should be greater than 0")
+	}
+	if threshold, ok := big.NewInt(0).SetString(cfg.RewardClaim.ThresholdStr, 10); !ok || threshold.Sign() < 0 {
+		return errors.Wrapf(ErrInvalidCfg, "reward claim threshold %s is invalid", cfg.RewardClaim.ThresholdStr)
+	}
+	if gasPrice, ok := big.NewInt(0).SetString(cfg.RewardClaim.GasPriceStr, 10); !ok || gasPrice.Sign() < 0 {
+		return errors.Wrapf(ErrInvalidCfg, "reward claim gas price %s is invalid", cfg.RewardClaim.GasPriceStr)
+	}
+	if cfg.RewardClaim.Recipient != "" {
+		if _, err := address.FromString(cfg.RewardClaim.Recipient); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "reward claim recipient %s is invalid", cfg.RewardClaim.Recipient)
+		}
+	}
+	return nil
+}
+
+// ValidateTimeSanity validates the time sanity configs
+func ValidateTimeSanity(cfg Config) error {
+	if !cfg.TimeSanity.Enabled {
+		return nil
+	}
+	if cfg.TimeSanity.Interval <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "time sanity interval should be greater than 0")
+	}
+	if cfg.TimeSanity.NTPServer != "" && (cfg.TimeSanity.NTPTimeout <= 0 || cfg.TimeSanity.MaxNTPSkew <= 0) {
+		return errors.Wrap(ErrInvalidCfg, "NTP timeout and max NTP skew should be greater than 0")
+	}
+	if cfg.TimeSanity.PeerWindow <= 0 || cfg.TimeSanity.MaxPeerSkew <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "peer window and max peer skew should be greater than 0")
+	}
+	return nil
+}
+
+// ValidateBackup validates the backup configs
+func ValidateBackup(cfg Config) error {
+	if !cfg.Backup.Enabled {
+		return nil
+	}
+	if cfg.Backup.Interval <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "backup interval should be greater than 0")
+	}
+	if cfg.Backup.Dir == "" {
+		return errors.Wrap(ErrInvalidCfg, "backup dir should not be empty")
+	}
+	if cfg.Backup.Retention <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "backup retention should be greater than 0")
+	}
+	return nil
+}
+
+// ValidateBlockSync validates the block sync configs
+func ValidateBlockSync(cfg Config) error {
+	if cfg.BlockSync.FastSync && cfg.BlockSync.SnapshotURL == "" {
+		return errors.Wrap(ErrInvalidCfg, "snapshot URL should not be empty in fast sync")
+	}
+	if cfg.BlockSync.SnapshotInterval == 0 {
+		return nil
+	}
+	if cfg.BlockSync.SnapshotDir == "" {
+		return errors.Wrap(ErrInvalidCfg, "snapshot dir should not be empty")
+	}
+	if cfg.BlockSync.SnapshotChunkSize == 0 {
+		return errors.Wrap(ErrInvalidCfg, "snapshot chunk size should be greater than 0")
+	}
+	if cfg.BlockSync.SnapshotsKept == 0 {
+		return errors.Wrap(ErrInvalidCfg, "number of the snapshots kept should be greater than 0")
+	}
 	return nil
 }
 
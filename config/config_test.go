@@ -198,6 +198,11 @@ func TestValidateKeyPair(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "block producer has unmatched pubkey and prikey"),
 	)
+
+	// The private key isn't read with a remote signer
+	cfg.Chain.ProducerPrivKey = ""
+	cfg.Consensus.RollDPoS.RemoteSigner.Endpoint = "127.0.0.1:14690"
+	require.NoError(t, ValidateKeyPair(cfg))
 }
 
 func TestValidateExplorer(t *testing.T) {
@@ -211,6 +216,31 @@ func TestValidateExplorer(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "tps window is not a positive integer when the explorer is enabled"),
 	)
+
+	cfg.Explorer.TpsWindow = 10
+	cfg.Explorer.APIBridge = true
+	err = ValidateExplorer(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	cfg.API.Enabled = true
+	require.NoError(t, ValidateExplorer(cfg))
+}
+
+func TestValidateAPI(t *testing.T) {
+	cfg := Default
+	cfg.API.Enabled = true
+	cfg.API.RequireAPIKey = true
+	err := ValidateAPI(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "key store path should be set when api key is required")
+
+	// The api keys are only managed via the admin endpoint
+	cfg.API.KeyStorePath = "/tmp/api-keys.json"
+	err = ValidateAPI(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.Contains(t, err.Error(), "admin port should be set to manage the api keys in the key store")
+
+	cfg.System.HTTPAdminPort = 9009
+	require.NoError(t, ValidateAPI(cfg))
 }
 
 func TestValidateChain(t *testing.T) {
@@ -236,6 +266,135 @@ func TestValidateChain(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "candidate number should be greater than or equal to delegate number"),
 	)
+
+	cfg = Default
+	cfg.Chain.PruneMode = "keepAll"
+	err = ValidateChain(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown prune mode keepAll"))
+
+	cfg.Chain.PruneMode = PruneModeKeepLastN
+	cfg.Chain.PruneKeepBlocks = 3
+	cfg.BlockSync.MaxReorgDepth = 3
+	err = ValidateChain(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "greater than the max reorg depth"))
+
+	cfg.Chain.PruneKeepBlocks = 4
+	require.NoError(t, ValidateChain(cfg))
+	cfg.Chain.PruneInterval = 0
+	err = ValidateChain(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "prune interval should be greater than 0"))
+}
+
+func TestValidateRewardClaim(t *testing.T) {
+	cfg := Default
+	cfg.RewardClaim.Enabled = true
+	require.NoError(t, ValidateRewardClaim(cfg))
+
+	cfg.RewardClaim.Interval = 0
+	err := ValidateRewardClaim(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "reward claim interval should be greater than 0"))
+
+	cfg.RewardClaim.Interval = Default.RewardClaim.Interval
+	cfg.RewardClaim.ThresholdStr = "-1"
+	err = ValidateRewardClaim(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "reward claim threshold -1 is invalid"))
+
+	cfg.RewardClaim.ThresholdStr = Default.RewardClaim.ThresholdStr
+	cfg.RewardClaim.GasPriceStr = "abc"
+	err = ValidateRewardClaim(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "reward claim gas price abc is invalid"))
+
+	cfg.RewardClaim.GasPriceStr = Default.RewardClaim.GasPriceStr
+	cfg.RewardClaim.Recipient = "io1invalid"
+	err = ValidateRewardClaim(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "reward claim recipient io1invalid is invalid"))
+}
+
+func TestValidateTimeSanity(t *testing.T) {
+	cfg := Default
+	cfg.TimeSanity.Enabled = true
+	require.NoError(t, ValidateTimeSanity(cfg))
+
+	cfg.TimeSanity.Interval = 0
+	err := ValidateTimeSanity(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "time sanity interval should be greater than 0"))
+
+	cfg.TimeSanity.Interval = Default.TimeSanity.Interval
+	cfg.TimeSanity.MaxNTPSkew = 0
+	err = ValidateTimeSanity(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "NTP timeout and max NTP skew should be greater than 0"))
+
+	// The NTP configs aren't used without a NTP server
+	cfg.TimeSanity.NTPServer = ""
+	require.NoError(t, ValidateTimeSanity(cfg))
+
+	cfg.TimeSanity.PeerWindow = 0
+	err = ValidateTimeSanity(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "peer window and max peer skew should be greater than 0"))
+}
+
+func TestValidateBackup(t *testing.T) {
+	cfg := Default
+	cfg.Backup.Enabled = true
+	require.NoError(t, ValidateBackup(cfg))
+
+	cfg.Backup.Interval = 0
+	err := ValidateBackup(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "backup interval should be greater than 0"))
+
+	cfg.Backup.Interval = Default.Backup.Interval
+	cfg.Backup.Dir = ""
+	err = ValidateBackup(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "backup dir should not be empty"))
+
+	cfg.Backup.Dir = Default.Backup.Dir
+	cfg.Backup.Retention = 0
+	err = ValidateBackup(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "backup retention should be greater than 0"))
+}
+
+func TestValidateBlockSync(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateBlockSync(cfg))
+
+	cfg.BlockSync.FastSync = true
+	err := ValidateBlockSync(cfg)
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "snapshot URL should not be empty in fast sync"))
+
+	cfg.BlockSync.SnapshotURL = "https://example.com/snapshot.json"
+	require.NoError(t, ValidateBlockSync(cfg))
+
+	cfg.BlockSync.SnapshotInterval = 1000
+	require.NoError(t, ValidateBlockSync(cfg))
+	cfg.BlockSync.SnapshotChunkSize = 0
+	err = ValidateBlockSync(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "snapshot chunk size should be greater than 0"))
 }
 
 func TestValidateConsensusScheme(t *testing.T) {
@@ -316,6 +475,88 @@ func TestValidateRollDPoS(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "roll-DPoS event delegate number should be greater than 0"),
 	)
+
+	cfg.Consensus.RollDPoS.NumDelegates = 21
+	cfg.Consensus.RollDPoS.DelegateInterval = 10 * time.Second
+	cfg.Consensus.RollDPoS.Failover.Role = "standby"
+	err = ValidateRollDPoS(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "unknown failover role standby"))
+
+	cfg.Consensus.RollDPoS.Failover.Role = FailoverBackup
+	err = ValidateRollDPoS(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "failover node ID and lease path should be set"))
+
+	cfg.Consensus.RollDPoS.Failover.NodeID = "node2"
+	cfg.Consensus.RollDPoS.Failover.LeasePath = "/shared/delegate.lease"
+	cfg.Consensus.RollDPoS.Failover.LeaseTTL = 10 * time.Second
+	err = ValidateRollDPoS(cfg)
+	require.NotNil(t, err)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "failover lease ttl should be larger than roll-DPoS delegate interval"))
+
+	cfg.Consensus.RollDPoS.Failover.LeaseTTL = 20 * time.Second
+	require.NoError(t, ValidateRollDPoS(cfg))
+
+	cfg.Consensus.RollDPoS.WithholdDetection.Window = 10
+	cfg.Consensus.RollDPoS.WithholdDetection.MaxLateProposals = 11
+	err = ValidateRollDPoS(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "withhold detection max late proposals should be in (0, window]"))
+
+	cfg.Consensus.RollDPoS.WithholdDetection.MaxLateProposals = 3
+	require.NoError(t, ValidateRollDPoS(cfg))
+
+	cfg.Consensus.RollDPoS.RemoteSigner.Endpoint = "127.0.0.1:14015"
+	cfg.Consensus.RollDPoS.RemoteSigner.Timeout = 0
+	err = ValidateRollDPoS(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "remote signer timeout should be greater than 0"))
+
+	cfg.Consensus.RollDPoS.RemoteSigner.Timeout = time.Second
+	err = ValidateRollDPoS(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "remote signer CA certificate and auth token should be set"))
+
+	cfg.Consensus.RollDPoS.RemoteSigner.CACertPath = "/etc/iotex/signer-ca.pem"
+	cfg.Consensus.RollDPoS.RemoteSigner.AuthToken = "token"
+	require.NoError(t, ValidateRollDPoS(cfg))
+}
+
+func TestValidateIBFT(t *testing.T) {
+	cfg := Default
+	cfg.NodeType = DelegateType
+	require.NoError(t, ValidateIBFT(cfg))
+
+	cfg.Consensus.Scheme = IBFTScheme
+	err := ValidateIBFT(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "IBFT validators should not be empty"))
+
+	cfg.Consensus.IBFT.Validators = []string{"invalid"}
+	err = ValidateIBFT(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "invalid IBFT validator address invalid"))
+
+	addr, err := cfg.BlockchainAddress()
+	require.NoError(t, err)
+	cfg.Consensus.IBFT.Validators = []string{addr.String()}
+	cfg.Consensus.IBFT.RoundTimeout = 0
+	err = ValidateIBFT(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "IBFT block interval and round timeout should be greater than 0"))
+
+	cfg.Consensus.IBFT.RoundTimeout = 10 * time.Second
+	cfg.Consensus.IBFT.LockPath = ""
+	err = ValidateIBFT(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "IBFT lock path should not be empty"))
+
+	cfg.Consensus.IBFT.LockPath = "/tmp/ibft.lock"
+	require.NoError(t, ValidateIBFT(cfg))
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -356,6 +597,49 @@ func TestValidateActPool(t *testing.T) {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		),
 	)
+
+	cfg.ActPool.MaxNumActsPerPool = 100
+	cfg.ActPool.JournalPath = "actpool.journal"
+	cfg.ActPool.JournalRotation = 0
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "journal rotation interval should be greater than 0"))
+
+	cfg.ActPool.JournalRotation = time.Hour
+	cfg.ActPool.MinGasPriceStr = "-1"
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "min gas price -1 is invalid"))
+
+	cfg.ActPool.MinGasPriceStr = "10"
+	cfg.ActPool.BlockedSenders = []string{"io1invalid"}
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "blocked sender io1invalid is invalid"))
+
+	cfg.ActPool.BlockedSenders = nil
+	cfg.ActPool.LocalSenders = []string{"io1invalid"}
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "local sender io1invalid is invalid"))
+
+	cfg.ActPool.LocalSenders = nil
+	require.NoError(t, ValidateActPool(cfg))
+
+	cfg.ActPool.DynamicGasPriceFloor.Enabled = true
+	cfg.ActPool.DynamicGasPriceFloor.LowUtilizationPct = 90
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "gas price floor utilization should be low < high <= 100"))
+
+	cfg.ActPool.DynamicGasPriceFloor.LowUtilizationPct = 20
+	cfg.ActPool.DynamicGasPriceFloor.StepPct = 0
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "gas price floor step should be in (0, 100)"))
+
+	cfg.ActPool.DynamicGasPriceFloor.StepPct = 10
+	require.NoError(t, ValidateActPool(cfg))
 }
 
 func TestCheckNodeType(t *testing.T) {
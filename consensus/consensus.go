@@ -9,18 +9,25 @@ package consensus
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
 
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/scheme/ibft"
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/consensus/signer"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
@@ -36,18 +43,36 @@ type Consensus interface {
 	HandleConsensusMsg(*iotexrpc.Consensus) error
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
+	PreferBlock(committed, competing *block.Block) bool
 	Metrics() (scheme.ConsensusMetrics, error)
+	// Signer returns the signer of the delegate, which is nil if the scheme mints no blocks
+	Signer() signer.Signer
 }
 
 // IotxConsensus implements Consensus
 type IotxConsensus struct {
 	cfg    config.Consensus
 	scheme scheme.Scheme
+	signer signer.Signer
+	// remoteSigner is the connection to the remote signer if it's configured, which is closed on stop
+	remoteSigner *signer.RemoteSigner
 }
 
 type optionParams struct {
 	rootChainAPI     explorerapi.Explorer
 	broadcastHandler scheme.Broadcast
+	clockSkewed      scheme.ClockSkewed
+	catchingUp       scheme.CatchingUp
+	syncToHeight     scheme.SyncToHeight
+	genesisConfig    *genesis.Blockchain
+	paramsByHeight   rolldpos.ConsensusParamsByHeightFunc
+	detectDoubleSign bool
+	blsPriKey        []uint32
+	blsKeysByHeight  rolldpos.BLSKeysByHeightFunc
+	commitThreshold  scheme.CommitThreshold
+	dkgSchedule      dkg.Schedule
+	dkgStateReader   protocol.StateReader
+	vrfHeight        uint64
 }
 
 // Option sets Consensus construction parameter.
@@ -69,6 +94,100 @@ func WithBroadcast(broadcastHandler scheme.Broadcast) Option {
 	}
 }
 
+// WithClockSkewed is an option to stop proposing blocks while the local clock is skewed
+func WithClockSkewed(clockSkewed scheme.ClockSkewed) Option {
+	return func(ops *optionParams) error {
+		ops.clockSkewed = clockSkewed
+		return nil
+	}
+}
+
+// WithCatchingUp is an option to hold the consensus in standby while the node is catching up with the network tip
+func WithCatchingUp(catchingUp scheme.CatchingUp) Option {
+	return func(ops *optionParams) error {
+		ops.catchingUp = catchingUp
+		return nil
+	}
+}
+
+// WithSyncToHeight is an option to trigger the block sync toward the height of the proposals far above the local tip
+func WithSyncToHeight(syncToHeight scheme.SyncToHeight) Option {
+	return func(ops *optionParams) error {
+		ops.syncToHeight = syncToHeight
+		return nil
+	}
+}
+
+// WithGenesis is an option to take the block interval and the epoch parameters from the genesis config rather than
+// the node config, which allows a sub chain to run with its own parameters
+func WithGenesis(genesisConfig genesis.Blockchain) Option {
+	return func(ops *optionParams) error {
+		ops.genesisConfig = &genesisConfig
+		return nil
+	}
+}
+
+// WithConsensusParamsByHeight is an option to take the block interval and the round ttls of roll-DPoS from the chain
+// states set by the governance, which override the config from the epochs they take effect
+func WithConsensusParamsByHeight(f rolldpos.ConsensusParamsByHeightFunc) Option {
+	return func(ops *optionParams) error {
+		ops.paramsByHeight = f
+		return nil
+	}
+}
+
+// WithDoubleSignDetection is an option to detect the delegates endorsing different blocks for the same vote, and put
+// the evidences into the minted blocks for the evidence protocol
+func WithDoubleSignDetection() Option {
+	return func(ops *optionParams) error {
+		ops.detectDoubleSign = true
+		return nil
+	}
+}
+
+// WithCommitThreshold is an option to commit a block once more than numerator/denominator of the delegates endorse it,
+// rather than 2/3. The threshold has to be in [2/3, 1), and a zero denominator means the default one
+func WithCommitThreshold(numerator, denominator uint64) Option {
+	return func(ops *optionParams) error {
+		threshold, err := scheme.NewCommitThreshold(numerator, denominator)
+		if err != nil {
+			return err
+		}
+		ops.commitThreshold = threshold
+		return nil
+	}
+}
+
+// WithBLS is an option to sign the endorsements with the BLS private key, aggregate their BLS signatures in the minted
+// blocks, and accept the blocks with the aggregated endorsements signed with the BLS keys of the delegates registered
+// on chain
+func WithBLS(priKey []uint32, f rolldpos.BLSKeysByHeightFunc) Option {
+	return func(ops *optionParams) error {
+		ops.blsPriKey = priKey
+		ops.blsKeysByHeight = f
+		return nil
+	}
+}
+
+// WithDKG is an option to take the part in the distributed key generation of the epoch beacons recorded by the DKG
+// protocol, and seed the delegate order of each epoch with the beacon generated in the previous epoch
+func WithDKG(schedule dkg.Schedule, sr protocol.StateReader) Option {
+	return func(ops *optionParams) error {
+		ops.dkgSchedule = schedule
+		ops.dkgStateReader = sr
+		return nil
+	}
+}
+
+// WithVRF is an option to select the proposer of each block with the VRF proof of the producer of the previous block
+// from the height, which requires the BLS keys of the delegates
+func WithVRF(height uint64) Option {
+	return func(ops *optionParams) error {
+		ops.vrfHeight = height
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg config.Config,
@@ -83,14 +202,54 @@ func NewConsensus(
 		}
 	}
 
+	if ops.genesisConfig != nil {
+		consensusCfg, err := ConfigFromGenesis(cfg.Consensus, *ops.genesisConfig)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Consensus = consensusCfg
+	}
+	if ops.commitThreshold.Denominator == 0 {
+		ops.commitThreshold = scheme.DefaultCommitThreshold
+	}
 	clock := clock.New()
 	cs := &IotxConsensus{cfg: cfg.Consensus}
+	budget := PickBudget(cfg, ops.genesisConfig)
+	var emptyBlockDue scheme.EmptyBlockDue
+	if cfg.Consensus.SkipEmptyBlocks {
+		emptyBlockDue = func() bool {
+			return EmptyBlockDue(bc, clock, cfg.Consensus.EmptyBlockInterval)
+		}
+	}
+	// The signer of the delegate and its address, which are set by the schemes minting blocks
+	var (
+		s    signer.Signer
+		addr string
+	)
 	mintBlockCB := func() (*block.Block, error) {
-		actionMap := ap.PendingActionMap()
-		log.L().Debug("Pick actions.", zap.Int("actions", len(actionMap)))
+		if ops.clockSkewed != nil && ops.clockSkewed() {
+			return nil, errors.New("refuse to mint a block while the local clock is skewed")
+		}
+		acts := ap.PickActs(budget)
+		log.L().Debug("Pick actions.", zap.Int("actions", len(acts)))
+		if len(acts) == 0 && emptyBlockDue != nil && !emptyBlockDue() {
+			log.L().Debug("Skip minting an empty block.")
+			return nil, nil
+		}
+		actionMap, err := actpool.GroupBySender(acts)
+		if err != nil {
+			return nil, err
+		}
 
-		pk, sk, addr := GetAddr(cfg)
-		blk, err := bc.MintNewBlock(actionMap, pk, sk, addr, clock.Now().Unix())
+		blk, err := bc.MintNewBlockSignedWith(
+			actionMap,
+			s.PublicKey(),
+			signer.SignFunc(s, signer.KindBlock, bc.TipHeight()+1, 0, 0),
+			signer.SignFunc(s, signer.KindAction, bc.TipHeight()+1, 0, 0),
+			addr,
+			clock.Now().Unix(),
+			nil,
+		)
 		if err != nil {
 			log.L().Error("Failed to mint a block.", zap.Error(err))
 			return nil, err
@@ -121,16 +280,29 @@ func NewConsensus(
 	var err error
 	switch cfg.Consensus.Scheme {
 	case config.RollDPoSScheme:
-		pk, sk, addr := GetAddr(cfg)
+		if s, addr, err = cs.newSigner(cfg); err != nil {
+			return nil, err
+		}
 		bd := rolldpos.NewRollDPoSBuilder().
 			SetAddr(addr).
-			SetPubKey(pk).
-			SetPriKey(sk).
+			SetPubKey(s.PublicKey()).
 			SetConfig(cfg.Consensus.RollDPoS).
 			SetBlockchain(bc).
 			SetActPool(ap).
 			SetClock(clock).
-			SetBroadcast(ops.broadcastHandler)
+			SetBroadcast(ops.broadcastHandler).
+			SetClockSkewed(ops.clockSkewed).
+			SetCatchingUp(ops.catchingUp).
+			SetSyncToHeight(ops.syncToHeight).
+			SetEmptyBlockDue(emptyBlockDue).
+			SetPickBudget(budget).
+			SetConsensusParamsByHeightFunc(ops.paramsByHeight).
+			SetDetectDoubleSign(ops.detectDoubleSign).
+			SetBLS(ops.blsPriKey, ops.blsKeysByHeight).
+			SetCommitThreshold(ops.commitThreshold).
+			SetDKG(ops.dkgSchedule, ops.dkgStateReader).
+			SetVRF(ops.vrfHeight).
+			SetSigner(s)
 		if ops.rootChainAPI != nil {
 			bd = bd.SetCandidatesByHeightFunc(func(h uint64) ([]*state.Candidate, error) {
 				rawcs, err := ops.rootChainAPI.GetCandidateMetricsByHeight(int64(h))
@@ -172,16 +344,55 @@ func NewConsensus(
 	case config.NOOPScheme:
 		cs.scheme = scheme.NewNoop()
 	case config.StandaloneScheme:
+		if s, addr, err = cs.newSigner(cfg); err != nil {
+			return nil, err
+		}
+		interval := cfg.Consensus.BlockCreationInterval
+		var standaloneOpts []scheme.StandaloneOption
+		if cfg.Consensus.InstantSeal {
+			interval = scheme.InstantSealInterval
+			standaloneOpts = append(standaloneOpts, scheme.WithInstantSeal(func() bool {
+				for _, acts := range ap.PendingActionMap() {
+					if len(acts) > 0 {
+						return true
+					}
+				}
+				return false
+			}))
+		}
 		cs.scheme = scheme.NewStandalone(
 			mintBlockCB,
 			commitBlockCB,
 			broadcastBlockCB,
 			bc,
-			cfg.Consensus.BlockCreationInterval,
+			interval,
+			standaloneOpts...,
+		)
+	case config.IBFTScheme:
+		if cfg.Consensus.RollDPoS.RemoteSigner.Endpoint != "" {
+			return nil, errors.New("remote signer is only supported by roll-DPoS and standalone schemes")
+		}
+		var pk keypair.PublicKey
+		var sk keypair.PrivateKey
+		pk, sk, addr = GetAddr(cfg)
+		s = signer.NewLocalSigner(sk)
+		cs.scheme = ibft.NewIBFT(
+			cfg.Consensus.IBFT,
+			ops.commitThreshold,
+			addr,
+			pk,
+			sk,
+			bc,
+			mintBlockCB,
+			commitBlockCB,
+			broadcastBlockCB,
+			ops.broadcastHandler,
+			clock,
 		)
 	default:
 		return nil, errors.Errorf("unexpected IotxConsensus scheme %s", cfg.Consensus.Scheme)
 	}
+	cs.signer = s
 
 	return cs, nil
 }
@@ -205,9 +416,40 @@ func (c *IotxConsensus) Stop(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to stop scheme %s", c.cfg.Scheme)
 	}
+	if c.remoteSigner != nil {
+		if err := c.remoteSigner.Close(); err != nil {
+			return errors.Wrap(err, "failed to close remote signer")
+		}
+	}
 	return nil
 }
 
+// newSigner returns the signer of the delegate and its address. The delegate is identified by the key kept in the
+// remote signer if it's configured, in which case the producer private key is never read
+func (c *IotxConsensus) newSigner(cfg config.Config) (signer.Signer, string, error) {
+	remote := cfg.Consensus.RollDPoS.RemoteSigner
+	if remote.Endpoint == "" {
+		_, sk, addr := GetAddr(cfg)
+		return signer.NewLocalSigner(sk), addr, nil
+	}
+	creds, err := credentials.NewClientTLSFromFile(remote.CACertPath, "")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "error when loading the CA certificate of the remote signer")
+	}
+	rs, err := signer.NewRemoteSigner(remote.Endpoint, remote.Timeout, creds, remote.AuthToken)
+	if err != nil {
+		return nil, "", err
+	}
+	pkHash := keypair.HashPubKey(rs.PublicKey())
+	producer, err := address.FromBytes(pkHash[:])
+	if err != nil {
+		rs.Close()
+		return nil, "", errors.Wrap(err, "error when deriving the address of the remote signer")
+	}
+	c.remoteSigner = rs
+	return rs, producer.String(), nil
+}
+
 // Metrics returns consensus metrics
 func (c *IotxConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	return c.scheme.Metrics()
@@ -228,11 +470,68 @@ func (c *IotxConsensus) ValidateBlockFooter(blk *block.Block) error {
 	return c.scheme.ValidateBlockFooter(blk)
 }
 
+// PreferBlock tells whether the competing block is preferred over the committed block at the same height
+func (c *IotxConsensus) PreferBlock(committed, competing *block.Block) bool {
+	return c.scheme.PreferBlock(committed, competing)
+}
+
 // Scheme returns the scheme instance
 func (c *IotxConsensus) Scheme() scheme.Scheme {
 	return c.scheme
 }
 
+// Signer returns the signer of the delegate
+func (c *IotxConsensus) Signer() signer.Signer {
+	return c.signer
+}
+
+// PickBudget returns the budget of the actions picked from the action pool to mint a block, which is limited by the
+// block gas limit in genesis config if it's given
+func PickBudget(cfg config.Config, genesisConfig *genesis.Blockchain) actpool.PickBudget {
+	budget := actpool.PickBudget{ByteLimit: cfg.Chain.MaxBlockBytes}
+	if genesisConfig != nil {
+		budget.GasLimit = genesisConfig.BlockGasLimit
+	}
+	return budget
+}
+
+// ConfigFromGenesis overwrites the block interval and the epoch parameters of the consensus config with the ones
+// defined in the genesis config. The result is validated again, as the FSM TTLs have to fit in the block interval of
+// the genesis config
+func ConfigFromGenesis(cfg config.Consensus, genesisConfig genesis.Blockchain) (config.Consensus, error) {
+	if genesisConfig.BlockInterval != 0 {
+		cfg.RollDPoS.DelegateInterval = genesisConfig.BlockInterval
+		cfg.IBFT.BlockInterval = genesisConfig.BlockInterval
+		cfg.BlockCreationInterval = genesisConfig.BlockInterval
+	}
+	if genesisConfig.NumDelegates != 0 {
+		cfg.RollDPoS.NumDelegates = uint(genesisConfig.NumDelegates)
+	}
+	if genesisConfig.NumSubEpochs != 0 {
+		cfg.RollDPoS.NumSubEpochs = uint(genesisConfig.NumSubEpochs)
+	}
+	for _, validate := range []config.Validate{config.ValidateRollDPoS, config.ValidateIBFT} {
+		if err := validate(config.Config{Consensus: cfg}); err != nil {
+			return cfg, errors.Wrap(err, "invalid consensus config from genesis")
+		}
+	}
+	return cfg, nil
+}
+
+// EmptyBlockDue returns whether an empty block should be minted as a heartbeat, i.e., the interval has passed since
+// the tip block. It never returns true if the interval is 0
+func EmptyBlockDue(bc blockchain.Blockchain, c clock.Clock, interval time.Duration) bool {
+	if interval == 0 {
+		return false
+	}
+	tip, err := bc.GetBlockByHeight(bc.TipHeight())
+	if err != nil {
+		log.L().Error("Failed to get the tip block.", zap.Error(err))
+		return true
+	}
+	return !c.Now().Before(time.Unix(tip.Timestamp(), 0).Add(interval))
+}
+
 // GetAddr returns the iotex address
 func GetAddr(cfg config.Config) (keypair.PublicKey, keypair.PrivateKey, string) {
 	addr, err := cfg.BlockchainAddress()
@@ -0,0 +1,104 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestConfigFromGenesis(t *testing.T) {
+	require := require.New(t)
+
+	// zero values in genesis config don't overwrite the consensus config
+	cfg, err := ConfigFromGenesis(config.Default.Consensus, genesis.Blockchain{})
+	require.NoError(err)
+	require.Equal(config.Default.Consensus, cfg)
+
+	cfg, err = ConfigFromGenesis(config.Default.Consensus, genesis.Blockchain{
+		NumSubEpochs:  2,
+		NumDelegates:  4,
+		BlockInterval: 2 * time.Second,
+	})
+	require.NoError(err)
+	require.Equal(uint(2), cfg.RollDPoS.NumSubEpochs)
+	require.Equal(uint(4), cfg.RollDPoS.NumDelegates)
+	require.Equal(2*time.Second, cfg.RollDPoS.DelegateInterval)
+	require.Equal(2*time.Second, cfg.IBFT.BlockInterval)
+	require.Equal(2*time.Second, cfg.BlockCreationInterval)
+	require.Equal(config.Default.Consensus.RollDPoS.FSM, cfg.RollDPoS.FSM)
+
+	// the FSM TTLs don't fit in the block interval of the genesis config
+	consensusCfg := config.Default.Consensus
+	consensusCfg.Scheme = config.RollDPoSScheme
+	consensusCfg.RollDPoS.FSM.AcceptBlockTTL = 2 * time.Second
+	consensusCfg.RollDPoS.FSM.AcceptProposalEndorsementTTL = time.Second
+	consensusCfg.RollDPoS.FSM.AcceptLockEndorsementTTL = time.Second
+	_, err = ConfigFromGenesis(consensusCfg, genesis.Blockchain{})
+	require.NoError(err)
+	_, err = ConfigFromGenesis(consensusCfg, genesis.Blockchain{BlockInterval: 3 * time.Second})
+	require.Equal(config.ErrInvalidCfg, errors.Cause(err))
+}
+
+func TestPickBudget(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	require.Equal(actpool.PickBudget{}, PickBudget(cfg, nil))
+
+	cfg.Chain.MaxBlockBytes = 1024
+	genesisCfg := genesis.Default.Blockchain
+	require.Equal(actpool.PickBudget{
+		GasLimit:  genesisCfg.BlockGasLimit,
+		ByteLimit: 1024,
+	}, PickBudget(cfg, &genesisCfg))
+}
+
+func TestEmptyBlockDue(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClock := clock.NewMock()
+	mockClock.Add(time.Hour)
+	tip := block.NewBlockDeprecated(
+		1,
+		5,
+		hash.ZeroHash256,
+		mockClock.Now().Unix(),
+		testaddress.Keyinfo["producer"].PubKey,
+		nil,
+	)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().Return(uint64(5)).AnyTimes()
+	bc.EXPECT().GetBlockByHeight(uint64(5)).Return(tip, nil).Times(2)
+
+	// empty blocks are never due without an interval
+	require.False(EmptyBlockDue(bc, mockClock, 0))
+
+	mockClock.Add(9 * time.Second)
+	require.False(EmptyBlockDue(bc, mockClock, 10*time.Second))
+	mockClock.Add(time.Second)
+	require.True(EmptyBlockDue(bc, mockClock, 10*time.Second))
+
+	// an empty block is minted if the tip block is unknown
+	bc.EXPECT().GetBlockByHeight(uint64(5)).Return(nil, errors.New("mock error")).Times(1)
+	require.True(EmptyBlockDue(bc, mockClock, 10*time.Second))
+}
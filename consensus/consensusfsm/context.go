@@ -34,6 +34,7 @@ type Context interface {
 	BroadcastEndorsement(Endorsement)
 
 	Prepare() (time.Duration, error)
+	RoundTTLs() (acceptBlockTTL, acceptProposalEndorsementTTL, acceptLockEndorsementTTL time.Duration)
 	MintBlock() (Endorsement, error)
 	NewProposalEndorsement(Endorsement) (Endorsement, error)
 	NewLockEndorsement() (Endorsement, error)
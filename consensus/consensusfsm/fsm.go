@@ -9,6 +9,7 @@ package consensusfsm
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/facebookgo/clock"
@@ -32,10 +33,18 @@ var (
 		},
 		[]string{"result"},
 	)
+	viewChangeMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_view_change",
+			Help: "Consensus messages handled and rounds timed out",
+		},
+		[]string{"type"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(consensusMtc)
+	prometheus.MustRegister(viewChangeMtc)
 }
 
 const (
@@ -89,6 +98,22 @@ type Config struct {
 	AcceptLockEndorsementTTL     time.Duration `yaml:"acceptLockEndorsementTTL"`
 }
 
+// Stats counts the view change activities of the FSM since it was created
+type Stats struct {
+	// ProposalsReceived is the number of block proposals handled
+	ProposalsReceived uint64
+	// ProposalEndorsements is the number of proposal endorsements (prevotes) handled
+	ProposalEndorsements uint64
+	// LockEndorsements is the number of lock endorsements (votes) handled
+	LockEndorsements uint64
+	// PreCommitEndorsements is the number of pre-commit endorsements handled
+	PreCommitEndorsements uint64
+	// RoundTimeouts is the number of rounds ended without enough lock endorsements
+	RoundTimeouts uint64
+	// ConsensusReached is the number of rounds ended with a committed block
+	ConsensusReached uint64
+}
+
 // ConsensusFSM wraps over the general purpose FSM and implements the consensus logic
 type ConsensusFSM struct {
 	fsm   fsm.FSM
@@ -98,6 +123,7 @@ type ConsensusFSM struct {
 	cfg   Config
 	ctx   Context
 	wg    sync.WaitGroup
+	stats Stats // updated atomically
 }
 
 // NewConsensusFSM returns a new fsm
@@ -255,6 +281,18 @@ func (m *ConsensusFSM) CurrentState() fsm.State {
 	return m.fsm.CurrentState()
 }
 
+// Stats returns the view change statistics of the FSM
+func (m *ConsensusFSM) Stats() Stats {
+	return Stats{
+		ProposalsReceived:     atomic.LoadUint64(&m.stats.ProposalsReceived),
+		ProposalEndorsements:  atomic.LoadUint64(&m.stats.ProposalEndorsements),
+		LockEndorsements:      atomic.LoadUint64(&m.stats.LockEndorsements),
+		PreCommitEndorsements: atomic.LoadUint64(&m.stats.PreCommitEndorsements),
+		RoundTimeouts:         atomic.LoadUint64(&m.stats.RoundTimeouts),
+		ConsensusReached:      atomic.LoadUint64(&m.stats.ConsensusReached),
+	}
+}
+
 // NumPendingEvents returns the number of pending events
 func (m *ConsensusFSM) NumPendingEvents() int {
 	return len(m.evtq)
@@ -349,25 +387,30 @@ func (m *ConsensusFSM) prepare(_ fsm.Event) (fsm.State, error) {
 	var blk Endorsement
 	if isProposer {
 		m.ctx.Logger().Info("current node is the proposer")
-		if blk, err = m.ctx.MintBlock(); err != nil || blk == nil {
+		if blk, err = m.ctx.MintBlock(); err != nil {
 			// TODO: review the return state
 			m.ctx.Logger().Error("Error when minting a block", zap.Error(err))
 			m.ProducePrepareEvent(0)
 			return sPrepare, nil
 		}
+		if blk == nil {
+			// The proposer skips the empty block, and waits through the round as the other delegates do
+			m.ctx.Logger().Info("skip proposing an empty block")
+		}
 	}
 	if delay > 0 {
 		time.Sleep(delay)
 	}
 	// Setup timeout for waiting for proposed block
-	ttl := m.cfg.AcceptBlockTTL
+	blockTTL, proposalEndorsementTTL, lockEndorsementTTL := m.ctx.RoundTTLs()
+	ttl := blockTTL
 	m.produceConsensusEvent(eFailedToReceiveBlock, ttl)
-	ttl += m.cfg.AcceptProposalEndorsementTTL
+	ttl += proposalEndorsementTTL
 	m.produceConsensusEvent(eStopReceivingProposalEndorsement, ttl)
-	ttl += m.cfg.AcceptLockEndorsementTTL
+	ttl += lockEndorsementTTL
 	m.produceConsensusEvent(eStopReceivingLockEndorsement, ttl)
 	// TODO add timeout for commit collection
-	if isProposer {
+	if isProposer && blk != nil {
 		m.ctx.Logger().Info("Broadcast init proposal.", log.Hex("blockHash", blk.Hash()))
 		m.ProduceReceiveBlockEvent(blk)
 		m.ctx.BroadcastBlockProposal(blk)
@@ -388,6 +431,7 @@ func (m *ConsensusFSM) onReceiveBlock(evt fsm.Event) (fsm.State, error) {
 		m.ctx.Logger().Error("invalid data type", zap.Any("data", cEvt.Data()))
 		return sAcceptBlockProposal, nil
 	}
+	m.count(&m.stats.ProposalsReceived, "proposal")
 	en, err := m.ctx.NewProposalEndorsement(block)
 	if err != nil {
 		m.ctx.Logger().Debug("Failed to generate proposal endorsement", zap.Error(err))
@@ -426,6 +470,7 @@ func (m *ConsensusFSM) onReceiveProposalEndorsement(evt fsm.Event) (fsm.State, e
 		m.ctx.Logger().Error("invalid data type", zap.Any("data", cEvt.Data()))
 		return sAcceptProposalEndorsement, nil
 	}
+	m.count(&m.stats.ProposalEndorsements, "proposalEndorsement")
 	err := m.ctx.AddProposalEndorsement(en)
 	if err != nil || !m.ctx.IsLocked() {
 		m.ctx.Logger().Debug("Failed to add proposal endorsement", zap.Error(err))
@@ -462,6 +507,7 @@ func (m *ConsensusFSM) onReceiveLockEndorsement(evt fsm.Event) (fsm.State, error
 		m.ctx.Logger().Error("invalid data type", zap.Any("data", cEvt.Data()))
 		return sAcceptLockEndorsement, nil
 	}
+	m.count(&m.stats.LockEndorsements, "lockEndorsement")
 	err := m.ctx.AddLockEndorsement(en)
 	switch {
 	case err != nil:
@@ -487,6 +533,7 @@ func (m *ConsensusFSM) onReceiveLockEndorsement(evt fsm.Event) (fsm.State, error
 
 func (m *ConsensusFSM) onStopReceivingLockEndorsement(evt fsm.Event) (fsm.State, error) {
 	m.ctx.LoggerWithStats().Warn("Not enough lock endorsements")
+	m.count(&m.stats.RoundTimeouts, "roundTimeout")
 
 	m.ProducePrepareEvent(0)
 
@@ -504,6 +551,7 @@ func (m *ConsensusFSM) onReceivePreCommitEndorsement(evt fsm.Event) (fsm.State,
 		m.ctx.Logger().Error("invalid data type", zap.Any("data", cEvt.Data()))
 		return sAcceptPreCommitEndorsement, nil
 	}
+	m.count(&m.stats.PreCommitEndorsements, "preCommitEndorsement")
 	if err := m.ctx.AddPreCommitEndorsement(en); err != nil {
 		m.ctx.Logger().Error("error when adding pre-commit endorsement", zap.Error(err))
 		return sAcceptPreCommitEndorsement, nil
@@ -514,12 +562,19 @@ func (m *ConsensusFSM) onReceivePreCommitEndorsement(evt fsm.Event) (fsm.State,
 	m.ctx.LoggerWithStats().Debug("Ready to commit")
 
 	consensusMtc.WithLabelValues("ReachConsenus").Inc()
+	m.count(&m.stats.ConsensusReached, "consensusReached")
 	m.ctx.OnConsensusReached()
 	m.ProducePrepareEvent(0)
 
 	return sPrepare, nil
 }
 
+// count increments a view change counter of the stats and the prometheus metric of the type
+func (m *ConsensusFSM) count(counter *uint64, typ string) {
+	atomic.AddUint64(counter, 1)
+	viewChangeMtc.WithLabelValues(typ).Inc()
+}
+
 // handleBackdoorEvt takes the dst state from the event and move the FSM into it
 func (m *ConsensusFSM) handleBackdoorEvt(evt fsm.Event) (fsm.State, error) {
 	cEvt, ok := evt.(*ConsensusEvent)
@@ -79,12 +79,8 @@ func TestStateTransitions(t *testing.T) {
 				data:      data,
 			}
 		}).AnyTimes()
-	cfsm, err := NewConsensusFSM(Config{
-		EventChanSize:                10,
-		AcceptBlockTTL:               4 * time.Second,
-		AcceptProposalEndorsementTTL: 2 * time.Second,
-		AcceptLockEndorsementTTL:     2 * time.Second,
-	}, mockCtx, mockClock)
+	mockCtx.EXPECT().RoundTTLs().Return(4*time.Second, 2*time.Second, 2*time.Second).AnyTimes()
+	cfsm, err := NewConsensusFSM(Config{EventChanSize: 10}, mockCtx, mockClock)
 	require.Nil(err)
 	require.NotNil(cfsm)
 	require.Equal(sPrepare, cfsm.CurrentState())
@@ -142,6 +138,27 @@ func TestStateTransitions(t *testing.T) {
 				evt := <-cfsm.evtq
 				require.Equal(ePrepare, evt.Type())
 			})
+			t.Run("skip-empty-block", func(t *testing.T) {
+				mockCtx.EXPECT().IsDelegate().Return(true).Times(1)
+				mockCtx.EXPECT().IsProposer().Return(true).Times(1)
+				mockCtx.EXPECT().Prepare().Return(time.Duration(0), nil).Times(1)
+				mockCtx.EXPECT().MintBlock().Return(nil, nil).Times(1)
+				mockCtx.EXPECT().BroadcastBlockProposal(gomock.Any()).Times(0)
+				state, err := cfsm.prepare(nil)
+				require.NoError(err)
+				require.Equal(sAcceptBlockProposal, state)
+				// The proposer waits through the round as the other delegates do
+				time.Sleep(100 * time.Millisecond)
+				mockClock.Add(4 * time.Second)
+				evt := <-cfsm.evtq
+				require.Equal(eFailedToReceiveBlock, evt.Type())
+				mockClock.Add(2 * time.Second)
+				evt = <-cfsm.evtq
+				require.Equal(eStopReceivingProposalEndorsement, evt.Type())
+				mockClock.Add(2 * time.Second)
+				evt = <-cfsm.evtq
+				require.Equal(eStopReceivingLockEndorsement, evt.Type())
+			})
 			t.Run("success-to-mint", func(t *testing.T) {
 				mockCtx.EXPECT().IsDelegate().Return(true).Times(1)
 				mockCtx.EXPECT().IsProposer().Return(true).Times(1)
@@ -427,3 +444,58 @@ func TestStateTransitions(t *testing.T) {
 		require.Equal(sPrepare, state)
 	})
 }
+
+func TestStats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCtx := NewMockContext(ctrl)
+	mockCtx.EXPECT().Logger().Return(log.L()).AnyTimes()
+	mockCtx.EXPECT().LoggerWithStats().Return(log.L()).AnyTimes()
+	mockCtx.EXPECT().NewConsensusEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(eventType fsm.EventType, data interface{}) *ConsensusEvent {
+			return &ConsensusEvent{
+				eventType: eventType,
+				data:      data,
+			}
+		}).AnyTimes()
+	cfsm, err := NewConsensusFSM(Config{EventChanSize: 10}, mockCtx, clock.NewMock())
+	require.NoError(err)
+	require.Equal(Stats{}, cfsm.Stats())
+
+	// Events with invalid data aren't counted
+	_, err = cfsm.onReceiveBlock(&ConsensusEvent{})
+	require.NoError(err)
+	require.Equal(Stats{}, cfsm.Stats())
+
+	mockCtx.EXPECT().NewProposalEndorsement(gomock.Any()).Return(nil, errors.New("some error")).Times(1)
+	_, err = cfsm.onReceiveBlock(&ConsensusEvent{data: NewMockEndorsement(ctrl)})
+	require.NoError(err)
+	mockCtx.EXPECT().AddProposalEndorsement(gomock.Any()).Return(errors.New("some error")).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err = cfsm.onReceiveProposalEndorsement(&ConsensusEvent{data: NewMockEndorsement(ctrl)})
+		require.NoError(err)
+	}
+	mockCtx.EXPECT().AddLockEndorsement(gomock.Any()).Return(nil).Times(1)
+	mockCtx.EXPECT().ReadyToPreCommit().Return(false).Times(1)
+	_, err = cfsm.onReceiveLockEndorsement(&ConsensusEvent{data: NewMockEndorsement(ctrl)})
+	require.NoError(err)
+	_, err = cfsm.onStopReceivingLockEndorsement(nil)
+	require.NoError(err)
+	<-cfsm.evtq
+	mockCtx.EXPECT().AddPreCommitEndorsement(gomock.Any()).Return(nil).Times(1)
+	mockCtx.EXPECT().ReadyToCommit().Return(true).Times(1)
+	mockCtx.EXPECT().OnConsensusReached().Return().Times(1)
+	_, err = cfsm.onReceivePreCommitEndorsement(&ConsensusEvent{data: NewMockEndorsement(ctrl)})
+	require.NoError(err)
+	<-cfsm.evtq
+
+	require.Equal(Stats{
+		ProposalsReceived:     1,
+		ProposalEndorsements:  2,
+		LockEndorsements:      1,
+		PreCommitEndorsements: 1,
+		RoundTimeouts:         1,
+		ConsensusReached:      1,
+	}, cfsm.Stats())
+}
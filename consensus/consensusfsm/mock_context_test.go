@@ -188,6 +188,20 @@ func (mr *MockContextMockRecorder) Prepare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepare", reflect.TypeOf((*MockContext)(nil).Prepare))
 }
 
+// RoundTTLs mocks base method
+func (m *MockContext) RoundTTLs() (time.Duration, time.Duration, time.Duration) {
+	ret := m.ctrl.Call(m, "RoundTTLs")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(time.Duration)
+	return ret0, ret1, ret2
+}
+
+// RoundTTLs indicates an expected call of RoundTTLs
+func (mr *MockContextMockRecorder) RoundTTLs() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoundTTLs", reflect.TypeOf((*MockContext)(nil).RoundTTLs))
+}
+
 // MintBlock mocks base method
 func (m *MockContext) MintBlock() (Endorsement, error) {
 	ret := m.ctrl.Call(m, "MintBlock")
@@ -0,0 +1,170 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package lease provides the signing lease of a failover pair of delegate nodes sharing the same key. Only the node
+// holding the lease signs, so that the pair never double signs in a round, while the standby node takes over once the
+// lease of the active one expires. The renewals of the lease are the heartbeats of the active node, and every
+// signature is fenced by the token of the lease it is signed under.
+package lease
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotHeld indicates the lease isn't held by the holder with the fencing token
+	ErrNotHeld = errors.New("lease not held")
+	// ErrFenced indicates a previous holder of the lease has signed in the round or a later one
+	ErrFenced = errors.New("round fenced by previous lease holder")
+)
+
+// Lease is a lease held by one holder at a time until it expires. It could be backed by any store supporting atomic
+// read-modify-write, e.g., a file on shared storage or an etcd cluster
+type Lease interface {
+	// Hold acquires the lease for the holder if it is free or expired, or renews it if the holder holds it. The lease
+	// is held until ttl elapses from now. It returns the fencing token of the lease held, which is increased every
+	// time the lease is acquired, or 0 if another holder holds the lease
+	Hold(holder string, now time.Time, ttl time.Duration) (uint64, error)
+	// Fence returns ErrNotHeld unless the holder holds the lease acquired with the fencing token, which hasn't expired,
+	// and ErrFenced if a holder with another token has signed at the height and round or a later one. Otherwise it
+	// records the height and round as signed with the token, in the same atomic update of the lease
+	Fence(holder string, token uint64, now time.Time, height uint64, round uint32) error
+	// Release frees the lease if the holder holds it
+	Release(holder string) error
+}
+
+// record is the state of the lease. The token is kept after the lease is released, so that it never goes back, and
+// so is the last round signed under the lease
+type record struct {
+	Holder       string    `json:"holder"`
+	Expiry       time.Time `json:"expiry"`
+	Token        uint64    `json:"token"`
+	SignedToken  uint64    `json:"signedToken"`
+	SignedHeight uint64    `json:"signedHeight"`
+	SignedRound  uint32    `json:"signedRound"`
+}
+
+// fileLease keeps the lease in a file on the storage shared by the pair, which is updated under an exclusive lock
+type fileLease struct {
+	path string
+}
+
+// NewFileLease creates a lease kept in the file of path
+func NewFileLease(path string) Lease {
+	return &fileLease{path: path}
+}
+
+// Hold acquires or renews the lease
+func (l *fileLease) Hold(holder string, now time.Time, ttl time.Duration) (uint64, error) {
+	if holder == "" || ttl <= 0 {
+		return 0, errors.Errorf("invalid holder %s or ttl %s of lease", holder, ttl)
+	}
+	var token uint64
+	err := l.update(func(r *record) bool {
+		expired := !now.Before(r.Expiry)
+		if r.Holder != holder && r.Holder != "" && !expired {
+			return false
+		}
+		if r.Holder != holder || expired {
+			// The lease is acquired anew, so the signatures fenced by the previous token are rejected
+			r.Token++
+		}
+		r.Holder = holder
+		r.Expiry = now.Add(ttl)
+		token = r.Token
+		return true
+	})
+	return token, err
+}
+
+// Fence checks the lease is held with the token and records the round signed
+func (l *fileLease) Fence(holder string, token uint64, now time.Time, height uint64, round uint32) error {
+	var err error
+	if uerr := l.update(func(r *record) bool {
+		if r.Holder != holder || r.Token != token || !now.Before(r.Expiry) {
+			err = errors.Wrapf(ErrNotHeld, "holder %s with token %d", holder, token)
+			return false
+		}
+		later := height > r.SignedHeight || height == r.SignedHeight && round > r.SignedRound
+		if r.SignedToken != token && r.SignedToken != 0 && !later {
+			// The previous holder may have signed in the round before it was fenced off
+			err = errors.Wrapf(
+				ErrFenced,
+				"height %d round %d signed with token %d",
+				r.SignedHeight,
+				r.SignedRound,
+				r.SignedToken,
+			)
+			return false
+		}
+		if r.SignedToken == token && !later {
+			return false
+		}
+		r.SignedToken = token
+		r.SignedHeight = height
+		r.SignedRound = round
+		return true
+	}); uerr != nil {
+		return uerr
+	}
+	return err
+}
+
+// Release frees the lease
+func (l *fileLease) Release(holder string) error {
+	return l.update(func(r *record) bool {
+		if r.Holder != holder {
+			return false
+		}
+		r.Holder = ""
+		r.Expiry = time.Time{}
+		return true
+	})
+}
+
+// update reads the record, applies the change and writes the record back if it is changed, while holding an
+// exclusive lock of the file
+func (l *fileLease) update(change func(r *record) bool) error {
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open lease file %s", l.path)
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return errors.Wrapf(err, "failed to lock lease file %s", l.path)
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read lease file %s", l.path)
+	}
+	var r record
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &r); err != nil {
+			return errors.Wrapf(err, "failed to parse lease file %s", l.path)
+		}
+	}
+	if !change(&r) {
+		return nil
+	}
+	if data, err = json.Marshal(&r); err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return errors.Wrapf(err, "failed to truncate lease file %s", l.path)
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return errors.Wrapf(err, "failed to write lease file %s", l.path)
+	}
+	return f.Sync()
+}
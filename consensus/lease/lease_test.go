@@ -0,0 +1,87 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package lease
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLease(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "lease")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "delegate.lease")
+	primary := NewFileLease(path)
+	backup := NewFileLease(path)
+	now := time.Unix(1000, 0)
+	ttl := 10 * time.Second
+
+	// The ttl has to be positive
+	_, err = primary.Hold("primary", now, 0)
+	require.Error(err)
+
+	// The free lease is acquired by the first holder
+	token, err := primary.Hold("primary", now, ttl)
+	require.NoError(err)
+	require.Equal(uint64(1), token)
+	token, err = backup.Hold("backup", now.Add(5*time.Second), ttl)
+	require.NoError(err)
+	require.Equal(uint64(0), token)
+
+	// The holder renews the lease with the same fencing token
+	token, err = primary.Hold("primary", now.Add(8*time.Second), ttl)
+	require.NoError(err)
+	require.Equal(uint64(1), token)
+	token, err = backup.Hold("backup", now.Add(12*time.Second), ttl)
+	require.NoError(err)
+	require.Equal(uint64(0), token)
+	require.NoError(primary.Fence("primary", 1, now.Add(17*time.Second), 5, 1))
+	require.Equal(ErrNotHeld, errors.Cause(primary.Fence("primary", 1, now.Add(18*time.Second), 6, 0)))
+	require.Equal(ErrNotHeld, errors.Cause(backup.Fence("backup", 1, now.Add(12*time.Second), 6, 0)))
+
+	// The expired lease is taken over with a new fencing token, which fences off the previous holder
+	token, err = backup.Hold("backup", now.Add(18*time.Second), ttl)
+	require.NoError(err)
+	require.Equal(uint64(2), token)
+	token, err = primary.Hold("primary", now.Add(19*time.Second), ttl)
+	require.NoError(err)
+	require.Equal(uint64(0), token)
+	require.Equal(ErrNotHeld, errors.Cause(primary.Fence("primary", 1, now.Add(19*time.Second), 6, 0)))
+	// The new holder doesn't sign in the rounds the previous holder may have signed in
+	require.Equal(ErrFenced, errors.Cause(backup.Fence("backup", 2, now.Add(19*time.Second), 5, 0)))
+	require.Equal(ErrFenced, errors.Cause(backup.Fence("backup", 2, now.Add(19*time.Second), 5, 1)))
+	require.NoError(backup.Fence("backup", 2, now.Add(19*time.Second), 5, 2))
+	// The holder signs again in the rounds it has signed in, e.g., the commit endorsement after the lock
+	require.NoError(backup.Fence("backup", 2, now.Add(19*time.Second), 5, 2))
+
+	// Only the holder releases the lease, and the token goes on increasing
+	require.NoError(primary.Release("primary"))
+	token, err = primary.Hold("primary", now.Add(20*time.Second), ttl)
+	require.NoError(err)
+	require.Equal(uint64(0), token)
+	require.NoError(backup.Release("backup"))
+	require.Equal(ErrNotHeld, errors.Cause(backup.Fence("backup", 2, now.Add(20*time.Second), 6, 0)))
+	token, err = primary.Hold("primary", now.Add(20*time.Second), ttl)
+	require.NoError(err)
+	require.Equal(uint64(3), token)
+	require.Equal(ErrFenced, errors.Cause(primary.Fence("primary", 3, now.Add(20*time.Second), 5, 2)))
+	require.NoError(primary.Fence("primary", 3, now.Add(20*time.Second), 6, 0))
+
+	// The corrupted lease file fails holding
+	require.NoError(ioutil.WriteFile(path, []byte("corrupted"), 0600))
+	_, err = primary.Hold("primary", now, ttl)
+	require.Error(err)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+var equivocationMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_consensus_equivocation",
+		Help: "Number of the delegates endorsing the commit of two different blocks at the same height",
+	},
+	[]string{"endorser"},
+)
+
+func init() {
+	prometheus.MustRegister(equivocationMtc)
+}
+
+// RecordEquivocation records the delegates who have endorsed the commit of both the committed and the competing block
+// at the same height, which both reach the commit threshold. As any two quorums overlap in more than 1/3 of the
+// delegates, the blocks prove those delegates have equivocated. The offenders are logged and counted, and returned
+func RecordEquivocation(committed, competing *block.Block, delegates []string) []string {
+	delegateSet := make(map[string]bool, len(delegates))
+	for _, delegate := range delegates {
+		delegateSet[delegate] = true
+	}
+	endorsed := make(map[string]bool)
+	for _, endorser := range committed.CommitEndorsers() {
+		if delegateSet[endorser] {
+			endorsed[endorser] = true
+		}
+	}
+	offenders := []string{}
+	for _, endorser := range competing.CommitEndorsers() {
+		if endorsed[endorser] {
+			delete(endorsed, endorser)
+			offenders = append(offenders, endorser)
+			equivocationMtc.WithLabelValues(endorser).Inc()
+		}
+	}
+	committedHash := committed.HashBlock()
+	competingHash := competing.HashBlock()
+	log.L().Error(
+		"Two blocks are finalized at the same height.",
+		zap.Uint64("height", committed.Height()),
+		log.Hex("committedHash", committedHash[:]),
+		log.Hex("competingHash", competingHash[:]),
+		zap.Strings("offenders", offenders),
+	)
+	return offenders
+}
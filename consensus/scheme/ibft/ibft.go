@@ -0,0 +1,719 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ibft
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+)
+
+// tickInterval is how often the consensus checks whether to propose a block or to request a round change
+const tickInterval = 100 * time.Millisecond
+
+// IBFT is a lightweight byzantine fault tolerant consensus scheme for the private chains, where a fixed set of
+// validators take turns to propose blocks. The consensus on a height goes through rounds, each of which has a proposer
+// in turn:
+//   - the validators prevote, i.e., endorse with the LOCK topic, the block proposed in the round
+//   - once more than the commit threshold, 2/3 by default, of the validators prevote a block in the round, a validator
+//     locks on the block and endorses it with the COMMIT topic
+//   - the block is committed once more than the threshold of the validators endorse it with the COMMIT topic in a round
+//
+// A validator locked on a block only prevotes the block, unless more than the threshold of the validators prevote
+// another block in a later round, and it proposes the block again when it is in turn. If no block is committed within
+// the round timeout, the validators request to move on to the next round, which they do once more than the threshold
+// of them request so. The lock and the last round voted in are persisted, so that a validator never votes against them
+// after a restart.
+type IBFT struct {
+	mutex      sync.Mutex
+	cfg        config.IBFT
+	chain      blockchain.Blockchain
+	createCb   scheme.CreateBlockCB
+	commitCb   scheme.ConsensusDoneCB
+	pubCb      scheme.BroadcastCB
+	broadcast  scheme.Broadcast
+	clock      clock.Clock
+	pubKey     keypair.PublicKey
+	priKey     keypair.PrivateKey
+	addr       string
+	validators map[string]bool
+	threshold  scheme.CommitThreshold
+	task       *routine.RecurringTask
+
+	// the states of the height in consensus
+	height      uint64
+	round       uint32
+	heightStart time.Time
+	roundStart  time.Time
+	proposed    bool
+	voted       bool
+	votedRound  uint32
+	requested   uint32
+	locked      *lock
+	blocks      map[hash.Hash256]*block.Block
+	proposals   map[uint32]hash.Hash256
+	votes       map[voteKey]map[string]*endorsement.Endorsement
+	changes     map[string]uint32
+}
+
+type (
+	lock struct {
+		blk   *block.Block
+		round uint32
+	}
+
+	voteKey struct {
+		topic   endorsement.ConsensusVoteTopic
+		round   uint32
+		blkHash hash.Hash256
+	}
+
+	// lockFile is the content of the file persisting the lock and the last round voted in at the height
+	lockFile struct {
+		Height      uint64 `json:"height"`
+		Voted       bool   `json:"voted"`
+		VotedRound  uint32 `json:"votedRound"`
+		LockedRound uint32 `json:"lockedRound"`
+		LockedBlock []byte `json:"lockedBlock"`
+	}
+)
+
+// NewIBFT creates an IBFT struct
+func NewIBFT(
+	cfg config.IBFT,
+	threshold scheme.CommitThreshold,
+	addr string,
+	pubKey keypair.PublicKey,
+	priKey keypair.PrivateKey,
+	bc blockchain.Blockchain,
+	create scheme.CreateBlockCB,
+	commit scheme.ConsensusDoneCB,
+	pub scheme.BroadcastCB,
+	broadcast scheme.Broadcast,
+	c clock.Clock,
+) scheme.Scheme {
+	validators := make(map[string]bool, len(cfg.Validators))
+	for _, validator := range cfg.Validators {
+		validators[validator] = true
+	}
+	ibft := &IBFT{
+		cfg:        cfg,
+		chain:      bc,
+		createCb:   create,
+		commitCb:   commit,
+		pubCb:      pub,
+		broadcast:  broadcast,
+		clock:      c,
+		pubKey:     pubKey,
+		priKey:     priKey,
+		addr:       addr,
+		validators: validators,
+		threshold:  threshold,
+	}
+	ibft.task = routine.NewRecurringTask(ibft.tick, tickInterval)
+	return ibft
+}
+
+// Start starts the IBFT consensus
+func (c *IBFT) Start(ctx context.Context) error {
+	c.mutex.Lock()
+	c.reset(c.chain.TipHeight() + 1)
+	err := c.load()
+	c.mutex.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the IBFT lock")
+	}
+	return c.task.Start(ctx)
+}
+
+// Stop stops the IBFT consensus
+func (c *IBFT) Stop(ctx context.Context) error {
+	return c.task.Stop(ctx)
+}
+
+// HandleConsensusMsg handles the block proposals, the endorsements and the round changes from the other validators
+func (c *IBFT) HandleConsensusMsg(msg *iotexrpc.Consensus) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.validators[c.addr] {
+		return nil
+	}
+	c.sync()
+	if msg.Height != c.height {
+		log.L().Debug(
+			"Ignore the consensus message not at the consensus height.",
+			zap.Uint64("consensusHeight", c.height),
+			zap.Uint64("msgHeight", msg.Height),
+		)
+		return nil
+	}
+	switch msg.Type {
+	case iotexrpc.Consensus_PROPOSAL:
+		blk := &block.Block{}
+		if err := blk.Deserialize(msg.Data); err != nil {
+			return errors.Wrap(err, "failed to deserialize block")
+		}
+		if blk.Height() != msg.Height {
+			return errors.Errorf(
+				"block height %d is not the same as consensus message height",
+				blk.Height(),
+			)
+		}
+		return c.handleProposal(blk, msg.Round)
+	case iotexrpc.Consensus_ENDORSEMENT:
+		en := &endorsement.Endorsement{}
+		if err := en.Deserialize(msg.Data); err != nil {
+			return errors.Wrap(err, "error when deserializing a msg to endorsement")
+		}
+		return c.handleEndorsement(en)
+	case iotexrpc.Consensus_ROUND_CHANGE:
+		en := &endorsement.Endorsement{}
+		if err := en.Deserialize(msg.Data); err != nil {
+			return errors.Wrap(err, "error when deserializing a msg to round change")
+		}
+		return c.handleRoundChange(en)
+	default:
+		return errors.Errorf("Invalid consensus message type %s", msg.Type)
+	}
+}
+
+// Calibrate moves the consensus onto the height next to the given one
+func (c *IBFT) Calibrate(height uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if height+1 > c.height {
+		c.reset(height + 1)
+	}
+}
+
+// ValidateBlockFooter validates that the block is proposed by a validator and endorsed by more than the threshold of
+// the validators
+func (c *IBFT) ValidateBlockFooter(blk *block.Block) error {
+	if !c.validators[blk.ProducerAddress()] {
+		return errors.Errorf("block proposer %s is not a validator", blk.ProducerAddress())
+	}
+	if !c.hasQuorum(blk.NumOfDelegateEndorsements(c.cfg.Validators)) {
+		log.L().Warn(
+			"Insufficient endorsements in receiving block",
+			zap.Uint64("blockHeight", blk.Height()),
+			zap.Int("numOfValidators", len(c.cfg.Validators)),
+			zap.Int("numOfValidatorEndorsements", blk.NumOfDelegateEndorsements(c.cfg.Validators)),
+		)
+		blk.FooterLogger(log.L()).Info("Endorsements in footer")
+		return errors.New("insufficient endorsements from validators")
+	}
+	return nil
+}
+
+// PreferBlock tells whether the competing block is preferred over the committed block at the same height, which is the
+// case if the competing block is valid and endorsed by more validators, while the committed block doesn't have a
+// quorum. A block with a quorum is final, so the competing block with a quorum as well is recorded as the evidence of
+// the validators endorsing both equivocating
+func (c *IBFT) PreferBlock(committed, competing *block.Block) bool {
+	if committed.Height() != competing.Height() || committed.HashBlock() == competing.HashBlock() {
+		return false
+	}
+	if err := c.ValidateBlockFooter(competing); err != nil {
+		return false
+	}
+	numCommitted := committed.NumOfDelegateEndorsements(c.cfg.Validators)
+	if c.hasQuorum(numCommitted) {
+		scheme.RecordEquivocation(committed, competing, c.cfg.Validators)
+		return false
+	}
+	return competing.NumOfDelegateEndorsements(c.cfg.Validators) > numCommitted
+}
+
+// Metrics returns IBFT consensus metrics
+func (c *IBFT) Metrics() (scheme.ConsensusMetrics, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return scheme.ConsensusMetrics{
+		LatestHeight:        c.chain.TipHeight(),
+		LatestDelegates:     c.cfg.Validators,
+		LatestBlockProducer: c.proposer(c.height, c.round),
+		Candidates:          c.cfg.Validators,
+	}, nil
+}
+
+func (c *IBFT) tick() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.validators[c.addr] {
+		return
+	}
+	c.sync()
+	if now := c.clock.Now(); now.Sub(c.roundStart) >= c.cfg.RoundTimeout {
+		// the request is sent again every round timeout, in case it is lost
+		c.roundStart = now
+		round := c.round + 1
+		if c.requested > round {
+			round = c.requested
+		}
+		c.requestRoundChange(round)
+	}
+	c.propose()
+}
+
+// propose proposes a block if the validator is in turn in the round, which is the block it is locked on if any
+func (c *IBFT) propose() {
+	if c.proposed || c.proposer(c.height, c.round) != c.addr || c.clock.Now().Sub(c.heightStart) < c.cfg.BlockInterval {
+		return
+	}
+	c.proposed = true
+	if c.locked != nil {
+		// the other validators learn that the locked block is proposed in the round from the prevote of the proposer
+		c.proposals[c.round] = c.locked.blk.HashBlock()
+		c.broadcastBlock(c.locked.blk)
+		c.prevote()
+		return
+	}
+	blk, err := c.createCb()
+	if err != nil {
+		log.L().Error("Failed to create a block.", zap.Error(err))
+		return
+	}
+	if blk == nil {
+		// the empty block is skipped, and the round times out
+		return
+	}
+	c.broadcastBlock(blk)
+	if err := c.handleProposal(blk, c.round); err != nil {
+		log.L().Error("Failed to handle the proposed block.", zap.Error(err))
+	}
+}
+
+func (c *IBFT) handleProposal(blk *block.Block, round uint32) error {
+	producer := blk.ProducerAddress()
+	if !c.proposedInTurn(producer, blk.Height(), round) {
+		return errors.Errorf("block proposer %s is not in turn in any round up to %d", producer, round)
+	}
+	if !blk.VerifySignature() {
+		return errors.New("invalid block signature")
+	}
+	blkHash := blk.HashBlock()
+	if _, ok := c.blocks[blkHash]; !ok {
+		if err := c.chain.ValidateBlock(blk); err != nil {
+			return errors.Wrap(err, "error when validating the proposed block")
+		}
+		c.blocks[blkHash] = blk
+	}
+	if _, ok := c.proposals[round]; !ok && c.proposer(blk.Height(), round) == producer {
+		c.proposals[round] = blkHash
+	}
+	c.prevote()
+	// the votes on the block may have arrived before the block
+	for _, key := range c.voteKeys() {
+		if key.blkHash == blkHash {
+			c.checkQuorum(key)
+		}
+	}
+	return nil
+}
+
+func (c *IBFT) handleEndorsement(en *endorsement.Endorsement) error {
+	vote := en.ConsensusVote()
+	if vote.Height != c.height || (vote.Topic != endorsement.LOCK && vote.Topic != endorsement.COMMIT) {
+		return nil
+	}
+	if !c.validators[en.Endorser()] {
+		return errors.Errorf("endorser %s is not a validator", en.Endorser())
+	}
+	if !en.VerifySignature() {
+		return errors.New("invalid endorsement signature")
+	}
+	key := c.addVote(en)
+	c.prevote()
+	c.checkQuorum(key)
+	return nil
+}
+
+func (c *IBFT) handleRoundChange(en *endorsement.Endorsement) error {
+	vote := en.ConsensusVote()
+	if vote.Height != c.height {
+		return nil
+	}
+	if vote.Topic != endorsement.PROPOSAL {
+		return errors.Errorf("invalid round change topic %d", vote.Topic)
+	}
+	if !c.validators[en.Endorser()] {
+		return errors.Errorf("endorser %s is not a validator", en.Endorser())
+	}
+	if !en.VerifySignature() {
+		return errors.New("invalid round change signature")
+	}
+	if vote.Round <= c.changes[en.Endorser()] {
+		return nil
+	}
+	c.changes[en.Endorser()] = vote.Round
+
+	rounds := make([]uint32, 0, len(c.changes))
+	for _, round := range c.changes {
+		rounds = append(rounds, round)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] > rounds[j] })
+	// i+1 validators request to move on to the rounds[i] or a later round
+	for i, round := range rounds {
+		if round <= c.round {
+			break
+		}
+		if c.hasQuorum(i + 1) {
+			c.moveTo(round)
+			return nil
+		}
+	}
+	// join the request once more than 1/3 of the validators, which include an honest one, request it
+	for i, round := range rounds {
+		if round <= c.round || round <= c.requested {
+			break
+		}
+		if 3*(i+1) > len(c.cfg.Validators) {
+			c.requestRoundChange(round)
+			break
+		}
+	}
+	return nil
+}
+
+func (c *IBFT) requestRoundChange(round uint32) {
+	if round > c.requested {
+		c.requested = round
+	}
+	en := endorsement.NewEndorsement(
+		endorsement.NewConsensusVote(nil, c.height, round, endorsement.PROPOSAL),
+		c.pubKey,
+		c.priKey,
+		c.addr,
+	)
+	if en == nil {
+		return
+	}
+	data, err := en.Serialize()
+	if err != nil {
+		log.L().Error("Failed to serialize round change.", zap.Error(err))
+		return
+	}
+	c.send(iotexrpc.Consensus_ROUND_CHANGE, round, data)
+	if err := c.handleRoundChange(en); err != nil {
+		log.L().Error("Failed to handle the round change.", zap.Error(err))
+	}
+}
+
+func (c *IBFT) moveTo(round uint32) {
+	log.L().Debug("Move on to the next round.", zap.Uint64("height", c.height), zap.Uint32("round", round))
+	c.round = round
+	c.roundStart = c.clock.Now()
+	c.proposed = false
+	c.propose()
+	c.prevote()
+	for _, key := range c.voteKeys() {
+		c.checkQuorum(key)
+	}
+}
+
+// prevote prevotes the block proposed in the round, unless the validator has voted in the round, or it is locked on
+// another block which is not prevoted by more than the threshold of the validators in a later round
+func (c *IBFT) prevote() {
+	if c.voted && c.votedRound >= c.round {
+		return
+	}
+	blkHash, ok := c.proposals[c.round]
+	if !ok {
+		// the proposer locked on a block proposes it again by prevoting it
+		if blkHash, ok = c.prevoteOf(c.proposer(c.height, c.round), c.round); !ok {
+			return
+		}
+	}
+	if _, ok := c.blocks[blkHash]; !ok {
+		return
+	}
+	if c.locked != nil && c.locked.blk.HashBlock() != blkHash && !c.hasPolka(blkHash, c.locked.round+1, c.round) {
+		log.L().Debug(
+			"Refuse to prevote the block against the lock.",
+			zap.Uint64("height", c.height),
+			zap.Uint32("round", c.round),
+			zap.Uint32("lockedRound", c.locked.round),
+		)
+		return
+	}
+	c.voted, c.votedRound = true, c.round
+	if err := c.persist(); err != nil {
+		log.L().Error("Failed to persist the IBFT lock.", zap.Error(err))
+		return
+	}
+	c.endorse(blkHash, c.round, endorsement.LOCK)
+}
+
+// checkQuorum locks on the block once it is prevoted by more than the threshold of the validators in the current round,
+// and commits the block once it is endorsed with the COMMIT topic by more than the threshold of the validators
+func (c *IBFT) checkQuorum(key voteKey) {
+	blk, ok := c.blocks[key.blkHash]
+	if !ok || blk.Height() != c.height || !c.hasQuorum(len(c.votes[key])) {
+		return
+	}
+	switch key.topic {
+	case endorsement.LOCK:
+		if key.round != c.round || (c.locked != nil && c.locked.round >= key.round) {
+			return
+		}
+		c.locked = &lock{blk: blk, round: key.round}
+		if err := c.persist(); err != nil {
+			log.L().Error("Failed to persist the IBFT lock.", zap.Error(err))
+			return
+		}
+		c.endorse(key.blkHash, key.round, endorsement.COMMIT)
+	case endorsement.COMMIT:
+		c.commit(blk, key)
+	}
+}
+
+func (c *IBFT) commit(blk *block.Block, key voteKey) {
+	log.L().Info("Consensus reached.", zap.Uint64("height", c.height), zap.Uint32("round", key.round))
+	endorsers := make([]string, 0, len(c.votes[key]))
+	for endorser := range c.votes[key] {
+		endorsers = append(endorsers, endorser)
+	}
+	sort.Strings(endorsers)
+	set := endorsement.NewSet(key.blkHash[:])
+	for _, endorser := range endorsers {
+		if err := set.AddEndorsement(c.votes[key][endorser]); err != nil {
+			log.L().Error("Failed to add endorsement to set.", zap.Error(err))
+			return
+		}
+	}
+	if err := blk.Finalize(set, c.clock.Now()); err != nil {
+		log.L().Error("Failed to add endorsements to block.", zap.Error(err))
+		return
+	}
+	if err := c.commitCb(blk); err != nil {
+		log.L().Error("Failed to commit block.", zap.Error(err))
+		return
+	}
+	if err := c.pubCb(blk); err != nil {
+		log.L().Error("Failed to broadcast block.", zap.Error(err))
+	}
+	c.reset(blk.Height() + 1)
+}
+
+func (c *IBFT) endorse(blkHash hash.Hash256, round uint32, topic endorsement.ConsensusVoteTopic) {
+	en := endorsement.NewEndorsement(
+		endorsement.NewConsensusVote(blkHash[:], c.height, round, topic),
+		c.pubKey,
+		c.priKey,
+		c.addr,
+	)
+	if en == nil {
+		return
+	}
+	data, err := en.Serialize()
+	if err != nil {
+		log.L().Error("Failed to serialize endorsement.", zap.Error(err))
+		return
+	}
+	c.send(iotexrpc.Consensus_ENDORSEMENT, round, data)
+	c.checkQuorum(c.addVote(en))
+}
+
+func (c *IBFT) addVote(en *endorsement.Endorsement) voteKey {
+	vote := en.ConsensusVote()
+	key := voteKey{topic: vote.Topic, round: vote.Round, blkHash: byteutil.BytesTo32B(vote.BlkHash)}
+	votes, ok := c.votes[key]
+	if !ok {
+		votes = map[string]*endorsement.Endorsement{}
+		c.votes[key] = votes
+	}
+	if _, ok := votes[en.Endorser()]; !ok {
+		votes[en.Endorser()] = en
+	}
+	return key
+}
+
+// voteKeys returns the keys of the votes, so that the votes could be checked while they are updated
+func (c *IBFT) voteKeys() []voteKey {
+	keys := make([]voteKey, 0, len(c.votes))
+	for key := range c.votes {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// prevoteOf returns the block prevoted by the validator in the round
+func (c *IBFT) prevoteOf(validator string, round uint32) (hash.Hash256, bool) {
+	for key, votes := range c.votes {
+		if key.topic != endorsement.LOCK || key.round != round {
+			continue
+		}
+		if _, ok := votes[validator]; ok {
+			return key.blkHash, true
+		}
+	}
+	return hash.ZeroHash256, false
+}
+
+// hasPolka tells whether the block is prevoted by more than the threshold of the validators in a round of [from, to)
+func (c *IBFT) hasPolka(blkHash hash.Hash256, from, to uint32) bool {
+	for key, votes := range c.votes {
+		if key.topic == endorsement.LOCK && key.blkHash == blkHash && key.round >= from && key.round < to &&
+			c.hasQuorum(len(votes)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *IBFT) broadcastBlock(blk *block.Block) {
+	data, err := blk.Serialize()
+	if err != nil {
+		log.L().Error("Failed to serialize block.", zap.Error(err))
+		return
+	}
+	c.send(iotexrpc.Consensus_PROPOSAL, c.round, data)
+}
+
+func (c *IBFT) send(msgType iotexrpc.Consensus_ConsensusMessageType, round uint32, data []byte) {
+	if c.broadcast == nil {
+		return
+	}
+	if err := c.broadcast(&iotexrpc.Consensus{
+		Height:    c.height,
+		Round:     round,
+		Type:      msgType,
+		Data:      data,
+		Timestamp: &timestamp.Timestamp{Seconds: c.clock.Now().Unix()},
+	}); err != nil {
+		log.L().Error("Failed to broadcast consensus message.", zap.Error(err))
+	}
+}
+
+func (c *IBFT) hasQuorum(numOfEndorsements int) bool {
+	return c.threshold.Reached(numOfEndorsements, len(c.cfg.Validators))
+}
+
+// proposer returns the validator in turn to propose the block of the height in the round
+func (c *IBFT) proposer(height uint64, round uint32) string {
+	if len(c.cfg.Validators) == 0 {
+		return ""
+	}
+	return c.cfg.Validators[(height+uint64(round))%uint64(len(c.cfg.Validators))]
+}
+
+// proposedInTurn tells whether the producer is in turn to propose in a round up to the given one, as a block locked on
+// is proposed again in the later rounds
+func (c *IBFT) proposedInTurn(producer string, height uint64, round uint32) bool {
+	for r := uint32(0); r <= round && int(r) < len(c.cfg.Validators); r++ {
+		if c.proposer(height, round-r) == producer {
+			return true
+		}
+	}
+	return false
+}
+
+// sync moves the consensus onto the next height if the chain has grown by the blocks synced from the network
+func (c *IBFT) sync() {
+	if height := c.chain.TipHeight() + 1; height > c.height {
+		c.reset(height)
+	}
+}
+
+func (c *IBFT) reset(height uint64) {
+	now := c.clock.Now()
+	c.height = height
+	c.round = 0
+	c.heightStart = now
+	c.roundStart = now
+	c.proposed = false
+	c.voted = false
+	c.votedRound = 0
+	c.requested = 0
+	c.locked = nil
+	c.blocks = map[hash.Hash256]*block.Block{}
+	c.proposals = map[uint32]hash.Hash256{}
+	c.votes = map[voteKey]map[string]*endorsement.Endorsement{}
+	c.changes = map[string]uint32{}
+}
+
+// persist writes the lock and the last round voted in into a temporary file first, so that the lock file is never left
+// partially written
+func (c *IBFT) persist() error {
+	file := lockFile{Height: c.height, Voted: c.voted, VotedRound: c.votedRound}
+	if c.locked != nil {
+		data, err := c.locked.blk.Serialize()
+		if err != nil {
+			return errors.Wrap(err, "failed to serialize the locked block")
+		}
+		file.LockedRound, file.LockedBlock = c.locked.round, data
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the IBFT lock")
+	}
+	tmp := c.cfg.LockPath + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write IBFT lock file %s", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, c.cfg.LockPath), "failed to rename IBFT lock file %s", tmp)
+}
+
+// load restores the lock and the last round voted in, if they are persisted at the consensus height
+func (c *IBFT) load() error {
+	data, err := ioutil.ReadFile(c.cfg.LockPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read IBFT lock file %s", c.cfg.LockPath)
+	}
+	var file lockFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal IBFT lock file %s", c.cfg.LockPath)
+	}
+	if file.Height != c.height {
+		return nil
+	}
+	c.voted, c.votedRound = file.Voted, file.VotedRound
+	if c.voted {
+		c.round = c.votedRound
+	}
+	if len(file.LockedBlock) == 0 {
+		return nil
+	}
+	blk := &block.Block{}
+	if err := blk.Deserialize(file.LockedBlock); err != nil {
+		return errors.Wrap(err, "failed to deserialize the locked block")
+	}
+	c.locked = &lock{blk: blk, round: file.LockedRound}
+	c.blocks[blk.HashBlock()] = blk
+	if c.round < c.locked.round {
+		c.round = c.locked.round
+	}
+	return nil
+}
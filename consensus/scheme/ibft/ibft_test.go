@@ -0,0 +1,377 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package ibft
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+var validatorNames = []string{"alfa", "bravo", "charlie", "delta"}
+
+type testNode struct {
+	name    string
+	ibft    *IBFT
+	cfg     config.IBFT
+	tip     uint64
+	blocks  []*block.Block
+	offline bool
+}
+
+type testNetwork struct {
+	dir   string
+	nodes []*testNode
+	msgs  []*iotexrpc.Consensus
+	from  []*testNode
+}
+
+func newTestNetwork(t *testing.T, ctrl *gomock.Controller, c clock.Clock) *testNetwork {
+	dir, err := ioutil.TempDir("", "ibft")
+	require.NoError(t, err)
+	cfg := config.Default.Consensus.IBFT
+	for _, name := range validatorNames {
+		cfg.Validators = append(cfg.Validators, ta.Addrinfo[name].String())
+	}
+	network := &testNetwork{dir: dir}
+	for _, name := range validatorNames {
+		cfg.LockPath = filepath.Join(dir, name+".lock")
+		node := &testNode{name: name, cfg: cfg}
+		key := ta.Keyinfo[name]
+		bc := mock_blockchain.NewMockBlockchain(ctrl)
+		bc.EXPECT().TipHeight().DoAndReturn(func() uint64 { return node.tip }).AnyTimes()
+		bc.EXPECT().ValidateBlock(gomock.Any()).Return(nil).AnyTimes()
+		create := func() (*block.Block, error) {
+			blk, err := block.NewTestingBuilder().
+				SetHeight(node.tip+1).
+				SetTimeStamp(c.Now().Unix()).
+				SignAndBuild(key.PubKey, key.PriKey)
+			return &blk, err
+		}
+		commit := func(blk *block.Block) error {
+			node.tip = blk.Height()
+			node.blocks = append(node.blocks, blk)
+			return nil
+		}
+		pub := func(*block.Block) error { return nil }
+		broadcast := func(msg proto.Message) error {
+			network.msgs = append(network.msgs, msg.(*iotexrpc.Consensus))
+			network.from = append(network.from, node)
+			return nil
+		}
+		node.ibft = NewIBFT(
+			cfg,
+			scheme.DefaultCommitThreshold,
+			ta.Addrinfo[name].String(),
+			key.PubKey,
+			key.PriKey,
+			bc,
+			create,
+			commit,
+			pub,
+			broadcast,
+			c,
+		).(*IBFT)
+		network.nodes = append(network.nodes, node)
+	}
+	return network
+}
+
+func (n *testNetwork) tick() {
+	for _, node := range n.nodes {
+		if !node.offline {
+			node.ibft.tick()
+		}
+	}
+}
+
+// deliver delivers the broadcast messages to the online nodes until there is no more message
+func (n *testNetwork) deliver(t *testing.T) {
+	n.deliverExcept(t, func(*iotexrpc.Consensus) bool { return false })
+}
+
+// deliverExcept delivers the broadcast messages except the dropped ones
+func (n *testNetwork) deliverExcept(t *testing.T, drop func(*iotexrpc.Consensus) bool) {
+	for len(n.msgs) > 0 {
+		msg, from := n.msgs[0], n.from[0]
+		n.msgs, n.from = n.msgs[1:], n.from[1:]
+		if from.offline || drop(msg) {
+			continue
+		}
+		for _, node := range n.nodes {
+			if node == from || node.offline {
+				continue
+			}
+			require.NoError(t, node.ibft.HandleConsensusMsg(msg))
+		}
+	}
+}
+
+func TestIBFT_Commit(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := clock.NewMock()
+	network := newTestNetwork(t, ctrl, c)
+	defer testutil.CleanupPath(t, network.dir)
+	network.tick()
+	network.deliver(t)
+	for _, node := range network.nodes {
+		require.Equal(uint64(0), node.tip)
+	}
+
+	c.Add(config.Default.Consensus.IBFT.BlockInterval)
+	network.tick()
+	network.deliver(t)
+	for _, node := range network.nodes {
+		require.Equal(uint64(1), node.tip)
+		blk := node.blocks[0]
+		// bravo is in turn to propose the block at height 1 in round 0
+		require.Equal(ta.Addrinfo["bravo"].String(), blk.ProducerAddress())
+		require.NoError(node.ibft.ValidateBlockFooter(blk))
+	}
+
+	metrics, err := network.nodes[0].ibft.Metrics()
+	require.NoError(err)
+	require.Equal(uint64(1), metrics.LatestHeight)
+	require.Equal(ta.Addrinfo["charlie"].String(), metrics.LatestBlockProducer)
+}
+
+func TestIBFT_RoundChange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := clock.NewMock()
+	network := newTestNetwork(t, ctrl, c)
+	defer testutil.CleanupPath(t, network.dir)
+	network.nodes[1].offline = true
+	network.tick()
+	c.Add(config.Default.Consensus.IBFT.BlockInterval)
+	network.tick()
+	network.deliver(t)
+	for _, node := range network.nodes {
+		require.Equal(uint64(0), node.tip)
+	}
+
+	c.Add(config.Default.Consensus.IBFT.RoundTimeout)
+	network.tick()
+	network.deliver(t)
+	for _, node := range network.nodes {
+		if node.offline {
+			require.Equal(uint64(0), node.tip)
+			continue
+		}
+		require.Equal(uint64(1), node.tip)
+		// charlie is in turn to propose in round 1 as bravo is offline
+		require.Equal(ta.Addrinfo["charlie"].String(), node.blocks[0].ProducerAddress())
+	}
+}
+
+func TestIBFT_Lock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := clock.NewMock()
+	network := newTestNetwork(t, ctrl, c)
+	defer testutil.CleanupPath(t, network.dir)
+	isCommit := func(msg *iotexrpc.Consensus) bool {
+		if msg.Type != iotexrpc.Consensus_ENDORSEMENT {
+			return false
+		}
+		en := &endorsement.Endorsement{}
+		require.NoError(en.Deserialize(msg.Data))
+		return en.ConsensusVote().Topic == endorsement.COMMIT
+	}
+	// The validators lock on the block proposed by bravo in round 0, while the commits are lost
+	network.tick()
+	c.Add(config.Default.Consensus.IBFT.BlockInterval)
+	network.tick()
+	network.deliverExcept(t, isCommit)
+	var locked *block.Block
+	for _, node := range network.nodes {
+		require.Equal(uint64(0), node.tip)
+		require.NotNil(node.ibft.locked)
+		require.Equal(uint32(0), node.ibft.locked.round)
+		locked = node.ibft.locked.blk
+	}
+
+	// The validators move on to round 1 upon the round change requests, where charlie proposes the locked block again
+	c.Add(config.Default.Consensus.IBFT.RoundTimeout)
+	network.tick()
+	network.deliver(t)
+	for _, node := range network.nodes {
+		require.Equal(uint64(1), node.tip)
+		require.Equal(locked.HashBlock(), node.blocks[0].HashBlock())
+		require.Equal(ta.Addrinfo["bravo"].String(), node.blocks[0].ProducerAddress())
+	}
+}
+
+func TestIBFT_PersistLock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := clock.NewMock()
+	network := newTestNetwork(t, ctrl, c)
+	defer testutil.CleanupPath(t, network.dir)
+	// charlie and delta are offline, so that the block proposed by bravo is prevoted but not locked on
+	network.nodes[2].offline = true
+	network.nodes[3].offline = true
+	network.tick()
+	c.Add(config.Default.Consensus.IBFT.BlockInterval)
+	network.tick()
+	network.deliver(t)
+	bravo := network.nodes[1]
+	require.True(bravo.ibft.voted)
+	require.Nil(bravo.ibft.locked)
+
+	// bravo never prevotes again in round 0 after a restart
+	restarted := NewIBFT(
+		bravo.cfg,
+		scheme.DefaultCommitThreshold,
+		ta.Addrinfo["bravo"].String(),
+		ta.Keyinfo["bravo"].PubKey,
+		ta.Keyinfo["bravo"].PriKey,
+		bravo.ibft.chain,
+		nil,
+		nil,
+		nil,
+		nil,
+		c,
+	).(*IBFT)
+	restarted.reset(1)
+	require.NoError(restarted.load())
+	require.True(restarted.voted)
+	require.Equal(uint32(0), restarted.votedRound)
+	require.Nil(restarted.locked)
+
+	// the lock is restored after a restart
+	blk := bravo.ibft.blocks[bravo.ibft.proposals[0]]
+	bravo.ibft.locked = &lock{blk: blk, round: 0}
+	require.NoError(bravo.ibft.persist())
+	restarted.reset(1)
+	require.NoError(restarted.load())
+	require.NotNil(restarted.locked)
+	require.Equal(blk.HashBlock(), restarted.locked.blk.HashBlock())
+
+	// the lock at a previous height is ignored
+	restarted.reset(2)
+	require.NoError(restarted.load())
+	require.False(restarted.voted)
+	require.Nil(restarted.locked)
+
+	// the lock file is missing
+	require.NoError(os.Remove(bravo.cfg.LockPath))
+	restarted.reset(1)
+	require.NoError(restarted.load())
+	require.Nil(restarted.locked)
+}
+
+func TestIBFT_ValidateBlockFooter(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	network := newTestNetwork(t, ctrl, clock.NewMock())
+	defer testutil.CleanupPath(t, network.dir)
+	ibft := network.nodes[0].ibft
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.Error(ibft.ValidateBlockFooter(&blk))
+
+	blk, err = block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(ta.Keyinfo["bravo"].PubKey, ta.Keyinfo["bravo"].PriKey)
+	require.NoError(err)
+	require.Error(ibft.ValidateBlockFooter(&blk))
+
+	// alfa is not in turn to propose the block at height 1 in round 0
+	blk, err = block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(ta.Keyinfo["alfa"].PubKey, ta.Keyinfo["alfa"].PriKey)
+	require.NoError(err)
+	data, err := blk.Serialize()
+	require.NoError(err)
+	require.Error(network.nodes[1].ibft.HandleConsensusMsg(&iotexrpc.Consensus{
+		Height: 1,
+		Type:   iotexrpc.Consensus_PROPOSAL,
+		Data:   data,
+	}))
+}
+
+func TestIBFT_PreferBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The block of round 1 is committed with bravo offline
+	c := clock.NewMock()
+	partitioned := newTestNetwork(t, ctrl, c)
+	defer testutil.CleanupPath(t, partitioned.dir)
+	partitioned.nodes[1].offline = true
+	partitioned.tick()
+	c.Add(config.Default.Consensus.IBFT.BlockInterval)
+	partitioned.tick()
+	partitioned.deliver(t)
+	c.Add(config.Default.Consensus.IBFT.RoundTimeout)
+	partitioned.tick()
+	partitioned.deliver(t)
+	committed := partitioned.nodes[0].blocks[0]
+
+	// The block of round 0 is committed by all the validators
+	c = clock.NewMock()
+	network := newTestNetwork(t, ctrl, c)
+	defer testutil.CleanupPath(t, network.dir)
+	network.tick()
+	c.Add(config.Default.Consensus.IBFT.BlockInterval)
+	network.tick()
+	network.deliver(t)
+	competing := network.nodes[0].blocks[0]
+
+	ibft := partitioned.nodes[0].ibft
+	require.NotEqual(committed.HashBlock(), competing.HashBlock())
+	require.False(ibft.PreferBlock(committed, committed))
+	// Both blocks are endorsed by a quorum of 3 validators, so the committed one is final, and the two quorums prove at
+	// least 2 validators have equivocated
+	require.False(ibft.PreferBlock(committed, competing))
+	require.False(ibft.PreferBlock(competing, committed))
+	require.True(len(scheme.RecordEquivocation(committed, competing, ibft.cfg.Validators)) >= 2)
+
+	// The block not endorsed by the validators is never preferred
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(ta.Keyinfo["charlie"].PubKey, ta.Keyinfo["charlie"].PriKey)
+	require.NoError(err)
+	require.False(ibft.PreferBlock(committed, &blk))
+	// The committed block without a quorum is replaced
+	require.True(ibft.PreferBlock(&blk, competing))
+}
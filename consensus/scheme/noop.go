@@ -46,6 +46,11 @@ func (n *Noop) ValidateBlockFooter(*block.Block) error {
 	return nil
 }
 
+// PreferBlock always keeps the committed block
+func (n *Noop) PreferBlock(*block.Block, *block.Block) bool {
+	return false
+}
+
 // Metrics is not implemented for standalone scheme
 func (n *Noop) Metrics() (ConsensusMetrics, error) {
 	return ConsensusMetrics{}, errors.Wrapf(
@@ -0,0 +1,340 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sync"
+
+	ethcrypto "github.com/iotexproject/go-ethereum/crypto"
+	"github.com/iotexproject/go-ethereum/crypto/ecies"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/consensus/signer"
+	"github.com/iotexproject/iotex-core/pkg/bls"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// maxPendingDKGMessages is the number of the DKG messages kept in memory until they are included in a block
+const maxPendingDKGMessages = 500
+
+// dkgParticipant takes the part of the delegate in the distributed key generation of the epoch beacons, and keeps the
+// DKG messages of the delegates received until they are included in a block. The secrets of the delegate only live
+// in memory for the epoch, so a delegate restarted in the middle of an epoch sits out the key generation of the
+// epoch. It has its own lock as the messages are received out of the consensus rounds
+type dkgParticipant struct {
+	mutex    sync.Mutex
+	schedule dkg.Schedule
+	sr       protocol.StateReader
+	epoch    uint64
+	encKey   *ecies.PrivateKey
+	poly     *bls.Polynomial
+	// shares are the valid shares the delegate has received from the dealers
+	shares map[string][]byte
+	// sent are the messages the delegate has sent in the epoch, which are sent again until they are recorded
+	sent    [action.NumDKGPhases]*action.SealedEnvelope
+	pending map[hash.Hash256]action.SealedEnvelope
+}
+
+func newDKGParticipant(schedule dkg.Schedule, sr protocol.StateReader) *dkgParticipant {
+	return &dkgParticipant{
+		schedule: schedule,
+		sr:       sr,
+		pending:  make(map[hash.Hash256]action.SealedEnvelope),
+	}
+}
+
+// seed returns the seed of the delegate order of the epoch
+func (p *dkgParticipant) seed(epoch uint64) ([]byte, error) {
+	return dkg.ReadSeed(p.sr, epoch)
+}
+
+// step sends the message of the delegate in the phase of the height, if it hasn't been recorded on chain yet, which is
+// signed in the round. The message is returned to broadcast, which is nil if there is nothing to send
+func (p *dkgParticipant) step(
+	height uint64,
+	round uint32,
+	delegate string,
+	s signer.Signer,
+) (*action.SealedEnvelope, error) {
+	epoch, phase, ok := p.schedule.Phase(height)
+	if !ok {
+		return nil, nil
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if epoch != p.epoch {
+		p.epoch = epoch
+		p.encKey = nil
+		p.poly = nil
+		p.shares = make(map[string][]byte)
+		p.sent = [action.NumDKGPhases]*action.SealedEnvelope{}
+	}
+	t, err := dkg.ReadTranscript(p.sr, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.Delegates) == 0 && phase != action.DKGKey {
+		// Either no key has been recorded, or the beacon has been generated and the transcript deleted
+		return nil, nil
+	}
+	if _, ok := t.Messages[phase][delegate]; ok {
+		return nil, nil
+	}
+	if selp := p.sent[phase]; selp != nil {
+		p.pending[selp.Hash()] = *selp
+		return selp, nil
+	}
+	if phase != action.DKGKey && p.encKey == nil {
+		// The delegate has missed the start of the key generation of the epoch
+		return nil, nil
+	}
+	if len(t.Delegates) > 0 && t.Index(delegate) == 0 {
+		return nil, nil
+	}
+	var m *action.DKGMessage
+	switch phase {
+	case action.DKGKey:
+		m, err = p.key(epoch)
+	case action.DKGDeal:
+		m, err = p.deal(t)
+	case action.DKGComplaint:
+		m, err = p.complain(t, delegate)
+	case action.DKGJustification:
+		m = p.justify(t, delegate)
+	case action.DKGBeaconShare:
+		m, err = p.signBeacon(t, delegate)
+	}
+	if err != nil || m == nil {
+		return nil, err
+	}
+	eb := action.EnvelopeBuilder{}
+	elp := eb.SetNonce(0).
+		SetGasPrice(big.NewInt(0)).
+		SetAction(m).
+		Build()
+	selp, err := action.SignWith(elp, s.PublicKey(), signer.SignFunc(s, signer.KindAction, height, round, 0))
+	if err != nil {
+		return nil, errors.Wrap(err, "error when signing DKG message")
+	}
+	p.sent[phase] = &selp
+	p.pending[selp.Hash()] = selp
+	return &selp, nil
+}
+
+func (p *dkgParticipant) key(epoch uint64) (*action.DKGMessage, error) {
+	encKey, err := ecies.GenerateKey(rand.Reader, ethcrypto.S256(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when generating DKG encryption key")
+	}
+	p.encKey = encKey
+	b := action.DKGMessageBuilder{}
+	m := b.SetEpoch(epoch).SetEncryptionKey(keypair.PublicKeyToBytes(encKey.PublicKey.ExportECDSA())).Build()
+	return &m, nil
+}
+
+func (p *dkgParticipant) deal(t *dkg.Transcript) (*action.DKGMessage, error) {
+	poly, err := bls.NewPolynomial(t.Degree())
+	if err != nil {
+		return nil, err
+	}
+	var shares []action.DKGShare
+	for _, recipient := range t.Delegates {
+		key, ok := t.Messages[action.DKGKey][recipient]
+		if !ok {
+			continue
+		}
+		pk, err := keypair.BytesToPublicKey(key.EncryptionKey())
+		if err != nil {
+			return nil, err
+		}
+		encrypted, err := ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(pk), poly.Share(t.Index(recipient)), nil, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when encrypting share to %s", recipient)
+		}
+		shares = append(shares, action.DKGShare{Recipient: recipient, Share: encrypted})
+	}
+	if len(shares) == 0 {
+		return nil, nil
+	}
+	p.poly = poly
+	b := action.DKGMessageBuilder{}
+	m := b.SetEpoch(t.Epoch).SetDeal(poly.Commitments(), shares).Build()
+	return &m, nil
+}
+
+// complain decrypts and verifies the shares to the delegate, and accuses the dealers whose shares are missing or
+// invalid. Nothing is sent if all the shares are valid
+func (p *dkgParticipant) complain(t *dkg.Transcript, delegate string) (*action.DKGMessage, error) {
+	if _, ok := t.Messages[action.DKGKey][delegate]; !ok {
+		return nil, nil
+	}
+	var accused []string
+	for _, dealer := range t.Delegates {
+		deal, ok := t.Messages[action.DKGDeal][dealer]
+		if !ok {
+			continue
+		}
+		share, err := p.decryptShare(t, delegate, deal)
+		if err != nil {
+			log.L().Info("Invalid DKG share.", zap.String("dealer", dealer), zap.Error(err))
+			if dealer != delegate {
+				accused = append(accused, dealer)
+			}
+			continue
+		}
+		p.shares[dealer] = share
+	}
+	if len(accused) == 0 {
+		return nil, nil
+	}
+	b := action.DKGMessageBuilder{}
+	m := b.SetEpoch(t.Epoch).SetComplaint(accused).Build()
+	return &m, nil
+}
+
+func (p *dkgParticipant) decryptShare(t *dkg.Transcript, delegate string, deal *action.DKGMessage) ([]byte, error) {
+	for _, share := range deal.Shares() {
+		if share.Recipient != delegate {
+			continue
+		}
+		decrypted, err := p.encKey.Decrypt(share.Share, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when decrypting share")
+		}
+		if err := bls.VerifyShare(deal.Commitments(), t.Index(delegate), decrypted); err != nil {
+			return nil, err
+		}
+		return decrypted, nil
+	}
+	return nil, errors.New("no share to the delegate")
+}
+
+// justify reveals the shares to the delegates complaining about the dealer
+func (p *dkgParticipant) justify(t *dkg.Transcript, delegate string) *action.DKGMessage {
+	if p.poly == nil {
+		return nil
+	}
+	var shares []action.DKGShare
+	for _, complainer := range t.Delegates {
+		complaint, ok := t.Messages[action.DKGComplaint][complainer]
+		if !ok {
+			continue
+		}
+		for _, accused := range complaint.Accused() {
+			if accused == delegate {
+				shares = append(shares, action.DKGShare{Recipient: complainer, Share: p.poly.Share(t.Index(complainer))})
+				break
+			}
+		}
+	}
+	if len(shares) == 0 {
+		return nil
+	}
+	b := action.DKGMessageBuilder{}
+	m := b.SetEpoch(t.Epoch).SetJustification(shares).Build()
+	return &m
+}
+
+// signBeacon signs the beacon message with the group key share, which sums up the shares from the qualified dealers,
+// either received in the deals or revealed in the justifications
+func (p *dkgParticipant) signBeacon(t *dkg.Transcript, delegate string) (*action.DKGMessage, error) {
+	var shares [][]byte
+	for _, dealer := range t.Qualified() {
+		share, ok := p.shares[dealer]
+		if !ok {
+			if justification, ok := t.Messages[action.DKGJustification][dealer]; ok {
+				for _, revealed := range justification.Shares() {
+					if revealed.Recipient == delegate {
+						share = revealed.Share
+					}
+				}
+			}
+		}
+		if share == nil {
+			log.L().Info("Missing DKG share of qualified dealer.", zap.String("dealer", dealer))
+			return nil, nil
+		}
+		shares = append(shares, share)
+	}
+	sk, err := bls.AggregateShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := p.seed(t.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	b := action.DKGMessageBuilder{}
+	m := b.SetEpoch(t.Epoch).SetBeaconShare(sk.Sign(dkg.BeaconMessage(t.Epoch+1, seed))).Build()
+	return &m, nil
+}
+
+// add keeps the DKG message of a delegate received to put into the block
+func (p *dkgParticipant) add(selp action.SealedEnvelope) error {
+	if _, ok := selp.Action().(*action.DKGMessage); !ok {
+		return errors.New("action is not a DKG message")
+	}
+	if err := action.Verify(selp); err != nil {
+		return errors.Wrap(err, "invalid DKG message signature")
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.pending) >= maxPendingDKGMessages {
+		return errors.New("too many pending DKG messages")
+	}
+	p.pending[selp.Hash()] = selp
+	return nil
+}
+
+// messages returns the pending messages of the phase of the height, grouped by their senders
+func (p *dkgParticipant) messages(height uint64) (map[string][]action.SealedEnvelope, error) {
+	epoch, phase, ok := p.schedule.Phase(height)
+	if !ok {
+		return nil, nil
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	acts := make(map[string][]action.SealedEnvelope)
+	for _, selp := range p.pending {
+		m := selp.Action().(*action.DKGMessage)
+		if m.Epoch() != epoch || m.Kind() != phase {
+			continue
+		}
+		pkHash := keypair.HashPubKey(selp.SrcPubkey())
+		sender, err := address.FromBytes(pkHash[:])
+		if err != nil {
+			return nil, err
+		}
+		acts[sender.String()] = append(acts[sender.String()], selp)
+	}
+	return acts, nil
+}
+
+// commit drops the pending messages included in the committed block, and the ones out of the phase of the next height
+func (p *dkgParticipant) commit(height uint64, acts []action.SealedEnvelope) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, selp := range acts {
+		delete(p.pending, selp.Hash())
+	}
+	epoch, phase, ok := p.schedule.Phase(height + 1)
+	for h, selp := range p.pending {
+		m := selp.Action().(*action.DKGMessage)
+		if !ok || m.Epoch() != epoch || m.Kind() < phase {
+			delete(p.pending, h)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/signer"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+func TestDKGParticipant(t *testing.T) {
+	require := require.New(t)
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	var candidates state.CandidateList
+	for _, addr := range testAddrs {
+		candidates = append(candidates, &state.Candidate{
+			Address:   addr.encodedAddr,
+			PublicKey: addr.pubKey,
+			Votes:     big.NewInt(1),
+		})
+	}
+	require.NoError(ws.PutState(candidatesutil.ConstructKey(0), &candidates))
+	require.NoError(sf.Commit(ws))
+
+	// 4 out of the 5 candidates run the key generation in the epoch of 2 sub-epochs, where each phase but the last one
+	// takes a block
+	schedule := dkg.Schedule{ForkHeight: 1, EpochSize: 8}
+	p := dkg.NewProtocol(schedule, uint64(len(testAddrs)), 4)
+	participants := make([]*dkgParticipant, len(testAddrs))
+	for i := range participants {
+		participants[i] = newDKGParticipant(schedule, sf)
+	}
+	proposer := participants[0]
+	rejected := 0
+	for height := uint64(1); height <= schedule.EpochSize; height++ {
+		for i, participant := range participants {
+			selp, err := participant.step(height, 0, testAddrs[i].encodedAddr, signer.NewLocalSigner(testAddrs[i].priKey))
+			require.NoError(err)
+			if selp != nil && i != 0 {
+				require.NoError(proposer.add(*selp))
+			}
+		}
+		messages, err := proposer.messages(height)
+		require.NoError(err)
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		var acts []action.SealedEnvelope
+		for sender, selps := range messages {
+			caller, err := address.FromString(sender)
+			require.NoError(err)
+			for _, selp := range selps {
+				ctx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+					BlockHeight: height,
+					Caller:      caller,
+					GasPrice:    big.NewInt(0),
+				})
+				receipt, err := p.Handle(ctx, selp.Action(), ws)
+				require.NoError(err)
+				if receipt.Status != 0 {
+					rejected++
+				}
+				acts = append(acts, selp)
+			}
+		}
+		require.NoError(sf.Commit(ws))
+		proposer.commit(height, acts)
+	}
+	// The key of the candidate out of the delegates, and the beacon shares more than the threshold are rejected
+	require.Equal(3, rejected)
+	seed, err := dkg.ReadSeed(sf, 2)
+	require.NoError(err)
+	require.NotEqual(crypto.CryptoSeed, seed)
+	require.Empty(proposer.pending)
+
+	// The delegates of the next epoch are ordered with the beacon
+	epoch, err := newEpochCtx(4, 2, 9, sf.CandidatesByHeight, proposer.seed)
+	require.NoError(err)
+	addrs := make([]string, 0, len(testAddrs))
+	for _, addr := range testAddrs {
+		addrs = append(addrs, addr.encodedAddr)
+	}
+	crypto.SortCandidates(addrs, 2, seed)
+	require.Equal(addrs[:4], epoch.delegates)
+
+	// The message of another kind than the phase of the height isn't put into the block
+	b := action.DKGMessageBuilder{}
+	m := b.SetEpoch(2).SetEncryptionKey([]byte("key")).Build()
+	eb := action.EnvelopeBuilder{}
+	selp, err := action.Sign(eb.SetAction(&m).SetGasPrice(big.NewInt(0)).Build(), testAddrs[1].priKey)
+	require.NoError(err)
+	require.NoError(proposer.add(selp))
+	messages, err := proposer.messages(10)
+	require.NoError(err)
+	require.Empty(messages)
+	messages, err = proposer.messages(9)
+	require.NoError(err)
+	require.Len(messages[testAddrs[1].encodedAddr], 1)
+}
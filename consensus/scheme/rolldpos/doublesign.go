@@ -0,0 +1,140 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/endorsement"
+)
+
+// maxPendingDoubleSigns is the number of the evidences kept in memory until they are included in a block
+const maxPendingDoubleSigns = 100
+
+var doubleSignMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_consensus_double_sign",
+		Help: "Number of the delegates endorsing two different blocks at the same height, round and topic",
+	},
+	[]string{"endorser"},
+)
+
+func init() {
+	prometheus.MustRegister(doubleSignMtc)
+}
+
+// voteKey identifies the vote a delegate is allowed to cast only once
+type voteKey struct {
+	endorser string
+	height   uint64
+	round    uint32
+	topic    endorsement.ConsensusVoteTopic
+}
+
+func newVoteKey(en *endorsement.Endorsement) voteKey {
+	vote := en.ConsensusVote()
+	return voteKey{
+		endorser: en.Endorser(),
+		height:   vote.Height,
+		round:    vote.Round,
+		topic:    vote.Topic,
+	}
+}
+
+// doubleSignDetector keeps the first endorsement of each vote, and makes an evidence out of another endorsement on a
+// different block. The evidences are pending until the block including them is committed. It has its own lock as the
+// evidences are taken when minting a block
+type doubleSignDetector struct {
+	mutex    sync.Mutex
+	votes    map[voteKey]*endorsement.Endorsement
+	reported map[voteKey]bool
+	pending  []*action.DoubleSignEvidence
+}
+
+func newDoubleSignDetector() *doubleSignDetector {
+	return &doubleSignDetector{
+		votes:    make(map[voteKey]*endorsement.Endorsement),
+		reported: make(map[voteKey]bool),
+	}
+}
+
+// observe records the endorsement, and returns the evidence if the endorser has endorsed another block for the same
+// vote. The endorsements without a block are skipped, and each vote is reported once
+func (d *doubleSignDetector) observe(en *endorsement.Endorsement) *action.DoubleSignEvidence {
+	if len(en.ConsensusVote().BlkHash) == 0 {
+		return nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	key := newVoteKey(en)
+	first, ok := d.votes[key]
+	if !ok {
+		d.votes[key] = en
+		return nil
+	}
+	if d.reported[key] || bytes.Equal(first.ConsensusVote().BlkHash, en.ConsensusVote().BlkHash) {
+		return nil
+	}
+	b := action.DoubleSignEvidenceBuilder{}
+	evidence := b.SetEndorsements(first, en).Build()
+	if err := evidence.Verify(); err != nil {
+		return nil
+	}
+	d.reported[key] = true
+	doubleSignMtc.WithLabelValues(key.endorser).Inc()
+	d.pending = append(d.pending, &evidence)
+	if len(d.pending) > maxPendingDoubleSigns {
+		d.pending = d.pending[len(d.pending)-maxPendingDoubleSigns:]
+	}
+	return &evidence
+}
+
+// pendingEvidences returns the evidences not included in a committed block yet
+func (d *doubleSignDetector) pendingEvidences() []*action.DoubleSignEvidence {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	evidences := make([]*action.DoubleSignEvidence, len(d.pending))
+	copy(evidences, d.pending)
+	return evidences
+}
+
+// HandleBlock commits the block appended to the chain, either committed by the consensus or synced from the peers, so
+// that the evidences included in the synced blocks aren't put into a block again
+func (d *doubleSignDetector) HandleBlock(blk *block.Block) error {
+	d.commit(blk.Height(), blk.Actions)
+	return nil
+}
+
+// commit drops the pending evidences included in the committed block, and forgets the votes below its height
+func (d *doubleSignDetector) commit(height uint64, acts []action.SealedEnvelope) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	included := make(map[voteKey]bool)
+	for _, selp := range acts {
+		if evidence, ok := selp.Action().(*action.DoubleSignEvidence); ok {
+			included[newVoteKey(evidence.First())] = true
+		}
+	}
+	pending := d.pending[:0]
+	for _, evidence := range d.pending {
+		if !included[newVoteKey(evidence.First())] {
+			pending = append(pending, evidence)
+		}
+	}
+	d.pending = pending
+	for key := range d.votes {
+		if key.height <= height {
+			delete(d.votes, key)
+			delete(d.reported, key)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/signer"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+func testEndorsement(signer int, blkHash []byte, height uint64, round uint32) *endorsement.Endorsement {
+	return endorsement.NewEndorsement(
+		endorsement.NewConsensusVote(blkHash, height, round, endorsement.PROPOSAL),
+		testAddrs[signer].pubKey,
+		testAddrs[signer].priKey,
+		testAddrs[signer].encodedAddr,
+	)
+}
+
+func TestDoubleSignDetector(t *testing.T) {
+	require := require.New(t)
+	d := newDoubleSignDetector()
+
+	require.Nil(d.observe(testEndorsement(0, []byte("block1"), 5, 1)))
+	// The same endorsement received again, or the one without a block, isn't evidence
+	require.Nil(d.observe(testEndorsement(0, []byte("block1"), 5, 1)))
+	require.Nil(d.observe(testEndorsement(0, nil, 5, 1)))
+	// The endorsements of another delegate or round don't conflict
+	require.Nil(d.observe(testEndorsement(1, []byte("block2"), 5, 1)))
+	require.Nil(d.observe(testEndorsement(0, []byte("block2"), 5, 2)))
+
+	evidence := d.observe(testEndorsement(0, []byte("block2"), 5, 1))
+	require.NotNil(evidence)
+	require.NoError(evidence.Verify())
+	require.Equal(testAddrs[0].encodedAddr, evidence.Offender())
+	// The same vote is reported once, while the conflicting vote of another delegate is reported on its own
+	require.Nil(d.observe(testEndorsement(0, []byte("block3"), 5, 1)))
+	require.NotNil(d.observe(testEndorsement(1, []byte("block3"), 5, 1)))
+	require.Equal(2, len(d.pendingEvidences()))
+
+	// The evidences are pending until they are included in a committed block
+	d.commit(4, nil)
+	require.Equal(2, len(d.pendingEvidences()))
+	eb := action.EnvelopeBuilder{}
+	selp, err := action.Sign(eb.SetAction(evidence).Build(), testAddrs[2].priKey)
+	require.NoError(err)
+	d.commit(5, []action.SealedEnvelope{selp})
+	pending := d.pendingEvidences()
+	require.Equal(1, len(pending))
+	require.Equal(testAddrs[1].encodedAddr, pending[0].Offender())
+	require.Empty(d.votes)
+
+	// The evidence included in a block synced from the peers is dropped as well
+	eb = action.EnvelopeBuilder{}
+	selp, err = action.Sign(eb.SetAction(pending[0]).Build(), testAddrs[2].priKey)
+	require.NoError(err)
+	acts := []action.SealedEnvelope{selp}
+	blk := block.NewBlockDeprecated(1, 6, hash.Hash256{}, time.Now().Unix(), testAddrs[0].pubKey, acts)
+	require.NoError(d.HandleBlock(blk))
+	require.Empty(d.pendingEvidences())
+}
+
+func TestRollDPoSCtx_ObserveEndorsement(t *testing.T) {
+	require := require.New(t)
+	blk := block.NewBlockDeprecated(1, 5, hash.Hash256{}, time.Now().Unix(), testAddrs[0].pubKey, nil)
+	ctx := &rollDPoSCtx{
+		encodedAddr: testAddrs[1].encodedAddr,
+		pubKey:      testAddrs[1].pubKey,
+		signer:      signer.NewLocalSigner(testAddrs[1].priKey),
+		epoch: &epochCtx{
+			delegates: []string{testAddrs[0].encodedAddr, testAddrs[1].encodedAddr, testAddrs[2].encodedAddr},
+		},
+		round: &roundCtx{
+			height:          5,
+			number:          1,
+			block:           &blockWrapper{Block: blk, round: 1},
+			endorsementSets: make(map[string]*endorsement.Set),
+		},
+		doubleSign: newDoubleSignDetector(),
+	}
+	topics := map[endorsement.ConsensusVoteTopic]bool{endorsement.PROPOSAL: true}
+	blkHash := blk.HashBlock()
+
+	require.NoError(ctx.processEndorsement(&endorsementWrapper{testEndorsement(0, blkHash[:], 5, 1)}, topics))
+	// The endorsement on another block is rejected, but detected as a double sign
+	require.Error(ctx.processEndorsement(&endorsementWrapper{testEndorsement(0, []byte("block2"), 5, 1)}, topics))
+	// The endorsements of the non-delegates or on another height aren't detected
+	require.Error(ctx.processEndorsement(&endorsementWrapper{testEndorsement(3, blkHash[:], 5, 1)}, topics))
+	require.Error(ctx.processEndorsement(&endorsementWrapper{testEndorsement(3, []byte("block2"), 5, 1)}, topics))
+	require.Error(ctx.processEndorsement(&endorsementWrapper{testEndorsement(2, blkHash[:], 6, 1)}, topics))
+	require.Error(ctx.processEndorsement(&endorsementWrapper{testEndorsement(2, []byte("block2"), 6, 1)}, topics))
+
+	// The evidence is signed by the node into a free action
+	acts, err := ctx.evidenceActions()
+	require.NoError(err)
+	require.Equal(1, len(acts))
+	require.Equal(uint64(0), acts[0].Nonce())
+	require.Equal(0, acts[0].GasPrice().Sign())
+	require.Equal(keypair.PublicKeyToBytes(testAddrs[1].pubKey), keypair.PublicKeyToBytes(acts[0].SrcPubkey()))
+	evidence, ok := acts[0].Action().(*action.DoubleSignEvidence)
+	require.True(ok)
+	require.Equal(testAddrs[0].encodedAddr, evidence.Offender())
+}
@@ -9,6 +9,7 @@ package rolldpos
 import (
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/state"
 )
@@ -22,6 +23,13 @@ type epochCtx struct {
 	// subEpochNum is the ordinal number of sub-epoch within the current epoch
 	subEpochNum uint64
 	delegates   []string
+	// seed is the seed of the delegate order of the epoch, which the VRF proofs of the producers are computed over
+	seed []byte
+	// params are the timing parameters set on chain for the epoch, which is nil if the config ones apply
+	params *governance.ConsensusParams
+	// blsPubKeys are the BLS public keys of the delegates in effect in the epoch, which is nil if the endorsements
+	// aren't aggregated
+	blsPubKeys map[string][]byte
 }
 
 func getEpochHeight(
@@ -53,6 +61,7 @@ func newEpochCtx(
 	numSubEpochs uint,
 	blockHeight uint64,
 	candidatesByHeight func(uint64) ([]*state.Candidate, error),
+	seedByEpoch func(uint64) ([]byte, error),
 ) (*epochCtx, error) {
 	epochNum := getEpochNum(blockHeight, numDelegates, numSubEpochs)
 	epochHeight := getEpochHeight(epochNum, numDelegates, numSubEpochs)
@@ -75,12 +84,19 @@ func newEpochCtx(
 	for _, candidate := range candidates {
 		addrs = append(addrs, candidate.Address)
 	}
-	crypto.SortCandidates(addrs, epochNum, crypto.CryptoSeed)
+	seed := crypto.CryptoSeed
+	if seedByEpoch != nil {
+		if seed, err = seedByEpoch(epochNum); err != nil {
+			return nil, errors.Wrapf(err, "failed to get seed of epoch %d", epochNum)
+		}
+	}
+	crypto.SortCandidates(addrs, epochNum, seed)
 
 	return &epochCtx{
 		num:         epochNum,
 		delegates:   addrs[:numDelegates],
 		subEpochNum: (blockHeight - epochHeight) / uint64(numDelegates),
 		height:      epochHeight,
+		seed:        seed,
 	}, nil
 }
@@ -66,19 +66,19 @@ func TestNewEpochCtx(t *testing.T) {
 	f := func(uint64) ([]*state.Candidate, error) {
 		return candidates, errors.New("some error")
 	}
-	epoch, err := newEpochCtx(numDelegates, numSubEpochs, 1, f)
+	epoch, err := newEpochCtx(numDelegates, numSubEpochs, 1, f, nil)
 	require.Error(err)
 	require.Nil(epoch)
 	f = func(uint64) ([]*state.Candidate, error) {
 		return candidates[:20], nil
 	}
-	epoch, err = newEpochCtx(numDelegates, numSubEpochs, 1, f)
+	epoch, err = newEpochCtx(numDelegates, numSubEpochs, 1, f, nil)
 	require.Error(err)
 	require.Nil(epoch)
 	f = func(uint64) ([]*state.Candidate, error) {
 		return candidates[:24], nil
 	}
-	epoch, err = newEpochCtx(numDelegates, numSubEpochs, 1, f)
+	epoch, err = newEpochCtx(numDelegates, numSubEpochs, 1, f, nil)
 	require.NoError(err)
 	require.NotNil(epoch)
 	require.Equal(uint64(1), epoch.num)
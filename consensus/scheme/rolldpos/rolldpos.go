@@ -7,26 +7,36 @@
 package rolldpos
 
 import (
+	"bytes"
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/facebookgo/clock"
+	"github.com/golang/protobuf/proto"
 	"github.com/iotexproject/go-fsm"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/consensus/lease"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/signer"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
 
 var (
@@ -45,6 +55,13 @@ var (
 		},
 		[]string{},
 	)
+
+	epochTransitionMtc = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_epoch_transitions",
+			Help: "Number of times consensus moves into a new epoch",
+		},
+	)
 )
 
 const sigSize = 5 // number of uint32s in BLS sig
@@ -52,6 +69,7 @@ const sigSize = 5 // number of uint32s in BLS sig
 func init() {
 	prometheus.MustRegister(timeSlotMtc)
 	prometheus.MustRegister(blockIntervalMtc)
+	prometheus.MustRegister(epochTransitionMtc)
 }
 
 var (
@@ -110,6 +128,9 @@ func (ew *endorsementWrapper) Topic() endorsement.ConsensusVoteTopic {
 type RollDPoS struct {
 	cfsm *consensusfsm.ConsensusFSM
 	ctx  *rollDPoSCtx
+	// renewQuit and renewDone stop renewing the signing lease, which are nil if the failover is disabled
+	renewQuit chan struct{}
+	renewDone chan struct{}
 }
 
 // Start starts RollDPoS consensus
@@ -118,13 +139,65 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 		return errors.Wrap(err, "error when starting the consensus FSM")
 	}
 	r.ctx.round = &roundCtx{height: 0}
+	if r.ctx.doubleSign != nil {
+		if err := r.ctx.chain.AddSubscriber(r.ctx.doubleSign); err != nil {
+			return errors.Wrap(err, "error when subscribing the double sign detection to the blocks")
+		}
+	}
+	if r.ctx.cfg.Failover.Role == config.FailoverBackup {
+		// The backup node stands by for a lease ttl, so that the primary node starting at the same time signs
+		r.ctx.standbyUntil = r.ctx.clock.Now().Add(r.ctx.cfg.Failover.LeaseTTL)
+	}
+
+	if r.ctx.lease != nil {
+		r.renewQuit = make(chan struct{})
+		r.renewDone = make(chan struct{})
+		go r.renewLease()
+	}
 	r.cfsm.ProducePrepareEvent(r.ctx.cfg.Delay)
 	return nil
 }
 
+// renewLease renews the signing lease every third of the lease ttl, so that it never expires while the node is up
+func (r *RollDPoS) renewLease() {
+	defer close(r.renewDone)
+	ticker := r.ctx.clock.Ticker(r.ctx.cfg.Failover.LeaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.renewQuit:
+			return
+		case <-ticker.C:
+			r.ctx.renewLease()
+		}
+	}
+}
+
 // Stop stops RollDPoS consensus
 func (r *RollDPoS) Stop(ctx context.Context) error {
-	return errors.Wrap(r.cfsm.Stop(ctx), "error when stopping the consensus FSM")
+	if err := r.cfsm.Stop(ctx); err != nil {
+		return errors.Wrap(err, "error when stopping the consensus FSM")
+	}
+	if r.ctx.doubleSign != nil {
+		if err := r.ctx.chain.RemoveSubscriber(r.ctx.doubleSign); err != nil {
+			return errors.Wrap(err, "error when unsubscribing the double sign detection from the blocks")
+		}
+	}
+
+	if r.ctx.lease != nil {
+		if r.renewQuit != nil {
+			close(r.renewQuit)
+			<-r.renewDone
+			r.renewQuit = nil
+		}
+		atomic.StoreUint64(&r.ctx.leaseToken, 0)
+		// Hand over the signing lease to the other node of the failover pair right away
+		return errors.Wrap(
+			r.ctx.lease.Release(r.ctx.cfg.Failover.NodeID),
+			"error when releasing the signing lease",
+		)
+	}
+	return nil
 }
 
 // HandleConsensusMsg handles incoming consensus message
@@ -156,6 +229,8 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotexrpc.Consensus) error {
 		if !block.VerifySignature() {
 			return errors.Errorf("invalid block signature")
 		}
+		r.ctx.observeProposal(block)
+		r.ctx.observeHeight(block)
 		r.cfsm.ProduceReceiveBlockEvent(&blockWrapper{block, msg.Round})
 	case iotexrpc.Consensus_ENDORSEMENT:
 		en := &endorsement.Endorsement{}
@@ -181,6 +256,16 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotexrpc.Consensus) error {
 		case endorsement.COMMIT:
 			r.cfsm.ProduceReceivePreCommitEndorsementEvent(ew)
 		}
+	case iotexrpc.Consensus_DKG:
+		actPb := &iotextypes.Action{}
+		if err := proto.Unmarshal(data, actPb); err != nil {
+			return errors.Wrap(err, "error when deserializing a msg to DKG message")
+		}
+		selp := action.SealedEnvelope{}
+		if err := selp.LoadProto(actPb); err != nil {
+			return errors.Wrap(err, "error when loading DKG message")
+		}
+		return r.ctx.addDKGMessage(selp)
 	default:
 		return errors.Errorf("Invalid consensus message type %s", msg.Type)
 	}
@@ -209,7 +294,23 @@ func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
 			round.proposer,
 		)
 	}
-	if 3*blk.NumOfDelegateEndorsements(epoch.delegates) <= 2*len(epoch.delegates) {
+	if err := r.ctx.verifyVRFProof(epoch, blk); err != nil {
+		return err
+	}
+	for _, aggregate := range blk.AggregatedEndorsements() {
+		if r.ctx.blsKeysByHeightFunc == nil {
+			return errors.New("aggregated endorsement isn't enabled")
+		}
+		vote := aggregate.ConsensusVote()
+		blkHash := blk.HashBlock()
+		if vote.Height != blk.Height() || !bytes.Equal(vote.BlkHash, blkHash[:]) {
+			return errors.New("aggregated endorsement isn't on the block")
+		}
+		if err := verifyAggregate(aggregate, epoch, r.ctx.commitThreshold); err != nil {
+			return errors.Wrapf(err, "invalid aggregated endorsement of topic %d", vote.Topic)
+		}
+	}
+	if !r.ctx.commitThreshold.Reached(blk.NumOfDelegateEndorsements(epoch.delegates), len(epoch.delegates)) {
 		log.L().Warn(
 			"Insufficient endorsements in receiving block",
 			zap.Uint64("blockHeight", blk.Height()),
@@ -226,6 +327,38 @@ func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
 	return nil
 }
 
+// PreferBlock tells whether the competing block is preferred over the committed block at the same height, which is the
+// case if the competing block passes the footer validation and is endorsed by more delegates of the epoch, while the
+// committed block is not finalized, i.e., doesn't reach the commit threshold. A finalized block is never replaced, and
+// a competing block finalized as well proves the delegates endorsing both have equivocated, whose commit endorsements
+// are put into the double sign detector as evidence
+func (r *RollDPoS) PreferBlock(committed, competing *block.Block) bool {
+	if committed.Height() != competing.Height() || committed.HashBlock() == competing.HashBlock() {
+		return false
+	}
+	if err := r.ValidateBlockFooter(competing); err != nil {
+		log.L().Debug("Invalid competing block.", zap.Uint64("height", competing.Height()), zap.Error(err))
+		return false
+	}
+	epoch, err := r.ctx.epochCtxByHeight(competing.Height())
+	if err != nil {
+		return false
+	}
+	numCommitted := committed.NumOfDelegateEndorsements(epoch.delegates)
+	if r.ctx.commitThreshold.Reached(numCommitted, len(epoch.delegates)) {
+		scheme.RecordEquivocation(committed, competing, epoch.delegates)
+		if r.ctx.doubleSign != nil {
+			for _, blk := range []*block.Block{committed, competing} {
+				for _, en := range blk.CommitEndorsements() {
+					r.ctx.doubleSign.observe(en)
+				}
+			}
+		}
+		return false
+	}
+	return competing.NumOfDelegateEndorsements(epoch.delegates) > numCommitted
+}
+
 // Metrics returns RollDPoS consensus metrics
 func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 	var metrics scheme.ConsensusMetrics
@@ -244,15 +377,36 @@ func (r *RollDPoS) Metrics() (scheme.ConsensusMetrics, error) {
 		candidateAddresses[i] = c.Address
 	}
 
+	stats := r.cfsm.Stats()
+
 	return scheme.ConsensusMetrics{
-		LatestEpoch:         epoch.num,
-		LatestHeight:        height,
-		LatestDelegates:     epoch.delegates,
-		LatestBlockProducer: r.ctx.round.proposer,
-		Candidates:          candidateAddresses,
+		LatestEpoch:          epoch.num,
+		LatestHeight:         height,
+		LatestDelegates:      epoch.delegates,
+		LatestBlockProducer:  r.ctx.round.proposer,
+		Candidates:           candidateAddresses,
+		ProposalsReceived:    stats.ProposalsReceived,
+		ProposalEndorsements: stats.ProposalEndorsements,
+		LockEndorsements:     stats.LockEndorsements,
+		RoundTimeouts:        stats.RoundTimeouts,
+		EpochTransitions:     atomic.LoadUint64(&r.ctx.epochTransitions),
 	}, nil
 }
 
+// RoundTimelines returns the timelines of the latest count rounds, the latest first, which are all the kept rounds if
+// count is 0
+func (r *RollDPoS) RoundTimelines(count int) []*iotexapi.RoundTimeline {
+	return r.ctx.timeline.recent(count)
+}
+
+// WithholdEvidences returns the latest evidences of the proposers withholding their blocks
+func (r *RollDPoS) WithholdEvidences() []WithholdEvidence {
+	if r.ctx.withhold == nil {
+		return nil
+	}
+	return r.ctx.withhold.latestEvidences()
+}
+
 // NumPendingEvts returns the number of pending events
 func (r *RollDPoS) NumPendingEvts() int {
 	return r.cfsm.NumPendingEvents()
@@ -267,15 +421,30 @@ func (r *RollDPoS) CurrentState() fsm.State {
 type Builder struct {
 	cfg config.RollDPoS
 	// TODO: we should use keystore in the future
-	encodedAddr            string
-	pubKey                 keypair.PublicKey
-	priKey                 keypair.PrivateKey
-	chain                  blockchain.Blockchain
-	actPool                actpool.ActPool
-	broadcastHandler       scheme.Broadcast
-	clock                  clock.Clock
-	rootChainAPI           explorer.Explorer
-	candidatesByHeightFunc CandidatesByHeightFunc
+	encodedAddr                 string
+	pubKey                      keypair.PublicKey
+	priKey                      keypair.PrivateKey
+	signer                      signer.Signer
+	chain                       blockchain.Blockchain
+	actPool                     actpool.ActPool
+	broadcastHandler            scheme.Broadcast
+	clock                       clock.Clock
+	rootChainAPI                explorer.Explorer
+	candidatesByHeightFunc      CandidatesByHeightFunc
+	lease                       lease.Lease
+	clockSkewed                 scheme.ClockSkewed
+	catchingUp                  scheme.CatchingUp
+	syncToHeight                scheme.SyncToHeight
+	emptyBlockDue               scheme.EmptyBlockDue
+	pickBudget                  actpool.PickBudget
+	consensusParamsByHeightFunc ConsensusParamsByHeightFunc
+	detectDoubleSign            bool
+	blsPriKey                   []uint32
+	blsKeysByHeightFunc         BLSKeysByHeightFunc
+	commitThreshold             scheme.CommitThreshold
+	dkgSchedule                 dkg.Schedule
+	dkgStateReader              protocol.StateReader
+	vrfHeight                   uint64
 }
 
 // NewRollDPoSBuilder instantiates a Builder instance
@@ -307,6 +476,13 @@ func (b *Builder) SetPriKey(priKey keypair.PrivateKey) *Builder {
 	return b
 }
 
+// SetSigner sets the signer of the proposals, the endorsements and the evidences of the delegate, e.g., a remote
+// signer keeping the key out of the node. Without it, they are signed by the private key
+func (b *Builder) SetSigner(s signer.Signer) *Builder {
+	b.signer = s
+	return b
+}
+
 // SetBlockchain sets the blockchain APIs
 func (b *Builder) SetBlockchain(chain blockchain.Blockchain) *Builder {
 	b.chain = chain
@@ -345,6 +521,92 @@ func (b *Builder) SetCandidatesByHeightFunc(
 	return b
 }
 
+// SetSigningLease sets the signing lease of the failover pair, which is a lease file at the configured path by default
+func (b *Builder) SetSigningLease(lease lease.Lease) *Builder {
+	b.lease = lease
+	return b
+}
+
+// SetClockSkewed sets the callback checking whether the local clock is skewed, in which case the node refuses to propose
+func (b *Builder) SetClockSkewed(clockSkewed scheme.ClockSkewed) *Builder {
+	b.clockSkewed = clockSkewed
+	return b
+}
+
+// SetCatchingUp sets the callback checking whether the node is catching up with the network tip, in which case the node
+// stays in standby
+func (b *Builder) SetCatchingUp(catchingUp scheme.CatchingUp) *Builder {
+	b.catchingUp = catchingUp
+	return b
+}
+
+// SetSyncToHeight sets the callback triggering the block sync toward the height of the proposals far above the local
+// tip, which takes effect if the sync trigger distance is set
+func (b *Builder) SetSyncToHeight(syncToHeight scheme.SyncToHeight) *Builder {
+	b.syncToHeight = syncToHeight
+	return b
+}
+
+// SetEmptyBlockDue sets the callback checking whether an empty block should be minted as a heartbeat, while the
+// proposer skips minting the empty blocks otherwise. The empty blocks are always minted if it isn't set
+func (b *Builder) SetEmptyBlockDue(emptyBlockDue scheme.EmptyBlockDue) *Builder {
+	b.emptyBlockDue = emptyBlockDue
+	return b
+}
+
+// SetPickBudget sets the budget of the actions picked from the action pool to mint a block
+func (b *Builder) SetPickBudget(budget actpool.PickBudget) *Builder {
+	b.pickBudget = budget
+	return b
+}
+
+// SetConsensusParamsByHeightFunc sets the function reading the timing parameters set on chain, which override the ones
+// in the config from the epochs they take effect
+func (b *Builder) SetConsensusParamsByHeightFunc(f ConsensusParamsByHeightFunc) *Builder {
+	b.consensusParamsByHeightFunc = f
+	return b
+}
+
+// SetDetectDoubleSign sets whether to detect the delegates endorsing different blocks for the same vote, and put the
+// evidences into the minted blocks. It requires the evidence protocol to handle the evidences
+func (b *Builder) SetDetectDoubleSign(detect bool) *Builder {
+	b.detectDoubleSign = detect
+	return b
+}
+
+// SetBLS sets the BLS private key of the node and the function reading the BLS keys of the delegates registered on
+// chain, with which the endorsements are aggregated in the block footers. The private key could be nil if the node
+// isn't a delegate
+func (b *Builder) SetBLS(priKey []uint32, f BLSKeysByHeightFunc) *Builder {
+	b.blsPriKey = priKey
+	b.blsKeysByHeightFunc = f
+	return b
+}
+
+// SetCommitThreshold sets the fraction of the delegates, more than which have to endorse a block. Default is 2/3
+func (b *Builder) SetCommitThreshold(threshold scheme.CommitThreshold) *Builder {
+	b.commitThreshold = threshold
+	return b
+}
+
+// SetDKG sets the schedule of the distributed key generation of the epoch beacons, and the reader of the committed
+// states to read the DKG messages recorded on chain with. From the fork height of the schedule, the delegates take the
+// part in the key generation, and the delegate order of each epoch is seeded with the beacon generated in the previous
+// epoch. It requires the DKG protocol to record the messages
+func (b *Builder) SetDKG(schedule dkg.Schedule, sr protocol.StateReader) *Builder {
+	b.dkgSchedule = schedule
+	b.dkgStateReader = sr
+	return b
+}
+
+// SetVRF sets the height from which the producers put their VRF proofs into the blocks, and the proposer of each block
+// is selected with the VRF proof in the previous block. The proofs are signed with the BLS keys set by SetBLS, over the
+// seed of the epoch, which is the DKG beacon if SetDKG is set as well
+func (b *Builder) SetVRF(height uint64) *Builder {
+	b.vrfHeight = height
+	return b
+}
+
 // Build builds a RollDPoS consensus module
 func (b *Builder) Build() (*RollDPoS, error) {
 	if b.chain == nil {
@@ -359,17 +621,59 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
+	if b.commitThreshold.Denominator == 0 {
+		b.commitThreshold = scheme.DefaultCommitThreshold
+	}
+	if b.signer == nil {
+		if b.priKey == nil {
+			return nil, errors.Wrap(ErrNewRollDPoS, "neither signer nor private key is set")
+		}
+		b.signer = signer.NewLocalSigner(b.priKey)
+	}
+	if b.cfg.Failover.Role != "" && b.lease == nil {
+		b.lease = lease.NewFileLease(b.cfg.Failover.LeasePath)
+	}
 	ctx := rollDPoSCtx{
-		cfg:                    b.cfg,
-		encodedAddr:            b.encodedAddr,
-		pubKey:                 b.pubKey,
-		priKey:                 b.priKey,
-		chain:                  b.chain,
-		actPool:                b.actPool,
-		broadcastHandler:       b.broadcastHandler,
-		clock:                  b.clock,
-		rootChainAPI:           b.rootChainAPI,
-		candidatesByHeightFunc: b.candidatesByHeightFunc,
+		cfg:                         b.cfg,
+		encodedAddr:                 b.encodedAddr,
+		pubKey:                      b.pubKey,
+		signer:                      b.signer,
+		chain:                       b.chain,
+		actPool:                     b.actPool,
+		broadcastHandler:            b.broadcastHandler,
+		clock:                       b.clock,
+		rootChainAPI:                b.rootChainAPI,
+		candidatesByHeightFunc:      b.candidatesByHeightFunc,
+		lease:                       b.lease,
+		clockSkewed:                 b.clockSkewed,
+		catchingUp:                  b.catchingUp,
+		syncToHeight:                b.syncToHeight,
+		emptyBlockDue:               b.emptyBlockDue,
+		pickBudget:                  b.pickBudget,
+		consensusParamsByHeightFunc: b.consensusParamsByHeightFunc,
+		blsPriKey:                   b.blsPriKey,
+		blsKeysByHeightFunc:         b.blsKeysByHeightFunc,
+		commitThreshold:             b.commitThreshold,
+		vrfHeight:                   b.vrfHeight,
+	}
+	if b.cfg.WithholdDetection.Window > 0 {
+		ctx.withhold = newWithholdDetector(b.cfg.WithholdDetection)
+	}
+	if b.detectDoubleSign {
+		ctx.doubleSign = newDoubleSignDetector()
+	}
+	ctx.timeline = newTimelineRecorder()
+	if b.vrfHeight != 0 && b.blsKeysByHeightFunc == nil {
+		return nil, errors.Wrap(ErrNewRollDPoS, "VRF requires the BLS keys of the delegates")
+	}
+	if b.dkgSchedule.ForkHeight != 0 {
+		if b.dkgStateReader == nil {
+			return nil, errors.Wrap(ErrNewRollDPoS, "DKG state reader is nil")
+		}
+		ctx.dkg = newDKGParticipant(b.dkgSchedule, b.dkgStateReader)
+	}
+	if ctx.lease != nil {
+		ctx.signer = &fencedSigner{Signer: b.signer, ctx: &ctx}
 	}
 	cfsm, err := consensusfsm.NewConsensusFSM(b.cfg.FSM, &ctx, b.clock)
 	if err != nil {
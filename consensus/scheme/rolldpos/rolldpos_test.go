@@ -36,6 +36,8 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/signer"
 	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/p2p/node"
 	"github.com/iotexproject/iotex-core/pkg/hash"
@@ -232,6 +234,9 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	cp.SortCandidates(candidates, m.LatestEpoch, cp.CryptoSeed)
 	assert.Equal(t, candidates[:4], m.LatestDelegates)
 	assert.Equal(t, candidates[1], m.LatestBlockProducer)
+	// The first epoch the context enters isn't a transition
+	assert.Equal(t, uint64(0), m.EpochTransitions)
+	assert.Equal(t, uint64(0), m.RoundTimeouts)
 }
 
 func makeTestRollDPoSCtx(
@@ -256,7 +261,8 @@ func makeTestRollDPoSCtx(
 		cfg:              cfg,
 		encodedAddr:      addr.encodedAddr,
 		pubKey:           addr.pubKey,
-		priKey:           addr.priKey,
+		signer:           signer.NewLocalSigner(addr.priKey),
+		commitThreshold:  scheme.DefaultCommitThreshold,
 		chain:            chain,
 		actPool:          actPool,
 		broadcastHandler: broadcastCB,
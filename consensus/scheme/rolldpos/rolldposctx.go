@@ -9,20 +9,29 @@ package rolldpos
 import (
 	"bytes"
 	"encoding/hex"
+	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/facebookgo/clock"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/iotexproject/go-fsm"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/consensus/lease"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/signer"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
@@ -34,6 +43,14 @@ import (
 // CandidatesByHeightFunc defines a function to overwrite candidates
 type CandidatesByHeightFunc func(uint64) ([]*state.Candidate, error)
 
+// ConsensusParamsByHeightFunc defines a function to read the timing parameters in effect at a height set on chain,
+// which returns nil if none is set
+type ConsensusParamsByHeightFunc func(uint64) (*governance.ConsensusParams, error)
+
+// BLSKeysByHeightFunc defines a function to read the BLS public keys of the delegates in effect at a height registered
+// on chain, keyed by the addresses, where the delegates without keys are left out
+type BLSKeysByHeightFunc func(uint64, []string) (map[string][]byte, error)
+
 // roundCtx keeps the context data for the current round and block.
 type roundCtx struct {
 	height          uint64
@@ -49,7 +66,7 @@ type rollDPoSCtx struct {
 	cfg              config.RollDPoS
 	encodedAddr      string
 	pubKey           keypair.PublicKey
-	priKey           keypair.PrivateKey
+	signer           signer.Signer
 	chain            blockchain.Blockchain
 	actPool          actpool.ActPool
 	broadcastHandler scheme.Broadcast
@@ -59,7 +76,58 @@ type rollDPoSCtx struct {
 	rootChainAPI     explorer.Explorer
 	// candidatesByHeightFunc is only used for testing purpose
 	candidatesByHeightFunc CandidatesByHeightFunc
-	mutex                  sync.RWMutex
+	// lease is the signing lease of the failover pair, which is nil if the failover is disabled
+	lease lease.Lease
+	// standbyUntil is when the backup node stops standing by after it starts, and the lease renewals of the primary
+	// node are the heartbeats it watches since then
+	standbyUntil time.Time
+	holdsLease   bool
+	// leaseToken is the fencing token of the signing lease held, which is checked before signing, and is 0 if the lease
+	// isn't held. It's updated atomically
+	leaseToken uint64
+	// clockSkewed returns whether the local clock is skewed, which is nil if the clock isn't checked
+	clockSkewed scheme.ClockSkewed
+	// catchingUp returns whether the node is far behind the network tip, which is nil if the node never waits for the
+	// block sync
+	catchingUp scheme.CatchingUp
+	// syncToHeight triggers the block sync toward the height of a proposal far above the local tip, which is nil if
+	// the sync is only triggered periodically
+	syncToHeight scheme.SyncToHeight
+	// syncing is whether the node stays in standby for the block sync in the round
+	syncing bool
+	// emptyBlockDue returns whether to mint an empty block as a heartbeat, which is nil if the empty blocks aren't
+	// skipped
+	emptyBlockDue scheme.EmptyBlockDue
+	// pickBudget is the budget of the actions picked from the action pool to mint a block
+	pickBudget actpool.PickBudget
+	// consensusParamsByHeightFunc reads the timing parameters set on chain, which is nil if they are only taken from
+	// the config
+	consensusParamsByHeightFunc ConsensusParamsByHeightFunc
+	// announcedHeight is the first height of the sub-epoch whose proposer schedule is announced last time
+	announcedHeight uint64
+	// withhold detects the proposers withholding their blocks, which is nil if the detection is disabled
+	withhold *withholdDetector
+	// doubleSign detects the delegates endorsing different blocks for the same vote, and keeps the evidences to put
+	// into the blocks, which is nil if the detection is disabled
+	doubleSign *doubleSignDetector
+	// timeline records the timelines of the latest rounds, which is nil if they aren't recorded
+	timeline *timelineRecorder
+	// blsPriKey signs the endorsements to be aggregated and the VRF proofs, which is nil if the node has no BLS key
+	blsPriKey []uint32
+	// blsKeysByHeightFunc reads the BLS keys of the delegates registered on chain, which is nil if the endorsements
+	// aren't aggregated
+	blsKeysByHeightFunc BLSKeysByHeightFunc
+	// commitThreshold is the fraction of the delegates, more than which have to endorse a block
+	commitThreshold scheme.CommitThreshold
+	// vrfHeight is the height from which the producers put their VRF proofs into the blocks, which seed the proposer
+	// selection of the next blocks. It is 0 if the proposers are rotated by the height
+	vrfHeight uint64
+	// dkg takes the part of the delegate in the key generation of the epoch beacons, which is nil if the delegate
+	// order isn't seeded with the beacons
+	dkg *dkgParticipant
+	// epochTransitions is the number of times the context moves into a new epoch, which is updated atomically
+	epochTransitions uint64
+	mutex            sync.RWMutex
 }
 
 func (ctx *rollDPoSCtx) Prepare() (time.Duration, error) {
@@ -69,6 +137,7 @@ func (ctx *rollDPoSCtx) Prepare() (time.Duration, error) {
 	if err := ctx.updateEpoch(height); err != nil {
 		return ctx.cfg.DelegateInterval, err
 	}
+	ctx.announceSchedule(height)
 	// If the current node is the delegate, move to the next state
 	if !ctx.isDelegate() {
 		log.L().Info(
@@ -78,6 +147,23 @@ func (ctx *rollDPoSCtx) Prepare() (time.Duration, error) {
 		)
 		return ctx.cfg.DelegateInterval, nil
 	}
+	// A delegate far behind the tip cannot win the rounds, and only wastes the bandwidth broadcasting its endorsements
+	if ctx.syncing = ctx.catchingUp != nil && ctx.catchingUp(); ctx.syncing {
+		log.L().Info(
+			"current node is catching up with the network tip",
+			zap.Uint64("epoch", ctx.epoch.num),
+			zap.Uint64("height", height),
+		)
+		return ctx.cfg.DelegateInterval, nil
+	}
+	if ctx.holdsLease = ctx.holdLease(); !ctx.holdsLease {
+		log.L().Info(
+			"current node is a standby delegate",
+			zap.Uint64("epoch", ctx.epoch.num),
+			zap.Uint64("height", height),
+		)
+		return ctx.cfg.DelegateInterval, nil
+	}
 	log.L().Info(
 		"current node is a delegate",
 		zap.Uint64("epoch", ctx.epoch.num),
@@ -89,10 +175,19 @@ func (ctx *rollDPoSCtx) Prepare() (time.Duration, error) {
 	if err := ctx.updateRound(height); err != nil {
 		return ctx.cfg.DelegateInterval, err
 	}
+	if ctx.dkg != nil {
+		ctx.stepDKG()
+	}
 
 	return ctx.round.timestamp.Sub(ctx.clock.Now()), nil
 }
 
+func (ctx *rollDPoSCtx) RoundTTLs() (time.Duration, time.Duration, time.Duration) {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	return ctx.roundTTLs()
+}
+
 func (ctx *rollDPoSCtx) ReadyToCommit() bool {
 	ctx.mutex.RLock()
 	defer ctx.mutex.RUnlock()
@@ -112,7 +207,11 @@ func (ctx *rollDPoSCtx) OnConsensusReached() {
 	defer ctx.mutex.Unlock()
 	ctx.logger().Info("consensus reached", zap.Uint64("blockHeight", ctx.round.height))
 	pendingBlock := ctx.round.block
-	if err := pendingBlock.Block.Finalize(
+	if aggregates := ctx.aggregateEndorsements(); aggregates != nil {
+		if err := pendingBlock.Block.FinalizeAggregate(aggregates, ctx.clock.Now()); err != nil {
+			ctx.logger().Panic("failed to add aggregated endorsement to block", zap.Error(err))
+		}
+	} else if err := pendingBlock.Block.Finalize(
 		ctx.round.proofOfLock,
 		ctx.clock.Now(),
 	); err != nil {
@@ -123,6 +222,16 @@ func (ctx *rollDPoSCtx) OnConsensusReached() {
 		// TODO: review the error handling logic (panic?)
 		ctx.logger().Panic("error when committing a block", zap.Error(err))
 	}
+	if ctx.timeline != nil {
+		ctx.timeline.committed(ctx.clock.Now())
+	}
+	if ctx.doubleSign != nil {
+		// The detector is notified of the committed blocks asynchronously as well, which is too late for the next block
+		ctx.doubleSign.commit(pendingBlock.Height(), pendingBlock.Actions)
+	}
+	if ctx.dkg != nil {
+		ctx.dkg.commit(pendingBlock.Height(), pendingBlock.Actions)
+	}
 	// Remove transfers in this block from ActPool and reset ActPool state
 	ctx.actPool.Reset()
 	// Broadcast the committed block to the network
@@ -136,7 +245,7 @@ func (ctx *rollDPoSCtx) OnConsensusReached() {
 		}
 		// putblock to parent chain if the current node is proposer and current chain is a sub chain
 		if ctx.round.proposer == ctx.encodedAddr && ctx.chain.ChainAddress() != "" {
-			putBlockToParentChain(ctx.rootChainAPI, ctx.chain.ChainAddress(), ctx.pubKey, ctx.priKey, ctx.encodedAddr, pendingBlock.Block)
+			putBlockToParentChain(ctx.rootChainAPI, ctx.chain.ChainAddress(), ctx.signer, ctx.encodedAddr, pendingBlock.Block)
 		}
 	} else {
 		ctx.logger().Panic(
@@ -151,14 +260,51 @@ func (ctx *rollDPoSCtx) MintBlock() (consensusfsm.Endorsement, error) {
 	defer ctx.mutex.Unlock()
 	blk := ctx.round.block
 	if blk == nil {
-		actionMap := ctx.actPool.PendingActionMap()
-		log.L().Debug("Pick actions from the action pool.", zap.Int("action", len(actionMap)))
-		b, err := ctx.chain.MintNewBlock(
+		// A block minted with a skewed clock is rejected by the other delegates for its invalid timestamp
+		if ctx.clockSkewed != nil && ctx.clockSkewed() {
+			return nil, errors.New("refuse to mint a block while the local clock is skewed")
+		}
+		acts := ctx.actPool.PickActs(ctx.pickBudget)
+		log.L().Debug("Pick actions from the action pool.", zap.Int("action", len(acts)))
+		actionMap, err := actpool.GroupBySender(acts)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.doubleSign != nil {
+			evidences, err := ctx.evidenceActions()
+			if err != nil {
+				return nil, err
+			}
+			// The evidences come first as their nonce is 0
+			actionMap[ctx.encodedAddr] = append(evidences, actionMap[ctx.encodedAddr]...)
+		}
+		if ctx.dkg != nil {
+			messages, err := ctx.dkg.messages(ctx.round.height)
+			if err != nil {
+				return nil, err
+			}
+			// The DKG messages of the delegates come first as well
+			for sender, msgs := range messages {
+				actionMap[sender] = append(msgs, actionMap[sender]...)
+			}
+		}
+		// The evidences and the DKG messages are the only actions which don't come from the action pool
+		if len(actionMap) == 0 && ctx.emptyBlockDue != nil && !ctx.emptyBlockDue() {
+			ctx.logger().Info("skip minting an empty block")
+			return nil, nil
+		}
+		proof, err := ctx.vrfProof(ctx.round.height)
+		if err != nil {
+			return nil, err
+		}
+		b, err := ctx.chain.MintNewBlockSignedWith(
 			actionMap,
-			ctx.pubKey,
-			ctx.priKey,
+			ctx.signer.PublicKey(),
+			signer.SignFunc(ctx.signer, signer.KindBlock, ctx.round.height, ctx.round.number, 0),
+			signer.SignFunc(ctx.signer, signer.KindAction, ctx.round.height, ctx.round.number, 0),
 			ctx.encodedAddr,
 			ctx.round.timestamp.Unix(),
+			proof,
 		)
 		if err != nil {
 			return nil, err
@@ -235,8 +381,7 @@ func (ctx *rollDPoSCtx) NewProposalEndorsement(en consensusfsm.Endorsement) (con
 			)
 		}
 		producer := blk.Endorser()
-		expectedProposer := ctx.round.proposer
-		if producer == "" || producer != expectedProposer {
+		if producer == "" || !ctx.isScheduledProposer(producer) {
 			return nil, errors.Errorf(
 				"unexpected block proposer %s, %s expected",
 				producer,
@@ -244,11 +389,17 @@ func (ctx *rollDPoSCtx) NewProposalEndorsement(en consensusfsm.Endorsement) (con
 			)
 		}
 		if producer != ctx.round.proposer || blk.WorkingSet == nil {
+			if err := ctx.verifyVRFProof(ctx.epoch, blk.Block); err != nil {
+				return nil, err
+			}
 			if err := ctx.chain.ValidateBlock(blk.Block); err != nil {
 				return nil, errors.Wrapf(err, "error when validating the proposed block")
 			}
 		}
 		ctx.round.block = blk
+		if ctx.timeline != nil {
+			ctx.timeline.proposalReceived(ctx.clock.Now())
+		}
 	}
 
 	return ctx.newEndorsement(endorsement.PROPOSAL)
@@ -291,6 +442,9 @@ func (ctx *rollDPoSCtx) AddProposalEndorsement(en consensusfsm.Endorsement) erro
 	if ctx.hasEnoughEndorsements(hash, expectedTopics) {
 		// TODO: handle the case of multiple prooves of lock
 		ctx.round.proofOfLock = ctx.round.endorsementSets[hex.EncodeToString(hash)]
+		if ctx.timeline != nil {
+			ctx.timeline.quorum(endorsement.PROPOSAL, ctx.clock.Now())
+		}
 	}
 
 	return nil
@@ -324,13 +478,17 @@ func (ctx *rollDPoSCtx) ReadyToPreCommit() bool {
 		return false
 	}
 
-	return ctx.hasEnoughEndorsements(
+	ready := ctx.hasEnoughEndorsements(
 		ctx.round.proofOfLock.BlockHash(),
 		map[endorsement.ConsensusVoteTopic]bool{
 			endorsement.LOCK:   true,
 			endorsement.COMMIT: true, // commit endorse is counted as one proposal endorse
 		},
 	)
+	if ready && ctx.timeline != nil {
+		ctx.timeline.quorum(endorsement.LOCK, ctx.clock.Now())
+	}
+	return ready
 }
 
 func (ctx *rollDPoSCtx) AddPreCommitEndorsement(en consensusfsm.Endorsement) error {
@@ -410,7 +568,8 @@ func (ctx *rollDPoSCtx) IsDelegate() bool {
 	ctx.mutex.RLock()
 	defer ctx.mutex.RUnlock()
 
-	return ctx.isDelegate()
+	// The standby node of the failover pair or the node catching up doesn't participate in the consensus
+	return ctx.isDelegate() && !ctx.syncing && (ctx.lease == nil || ctx.holdsLease)
 }
 
 func (ctx *rollDPoSCtx) IsProposer() bool {
@@ -447,7 +606,7 @@ func (ctx *rollDPoSCtx) hasEnoughEndorsements(
 		ctx.epoch.delegates,
 	)
 	numDelegates := len(ctx.epoch.delegates)
-	return numDelegates >= 4 && validNum > numDelegates*2/3 ||
+	return numDelegates >= 4 && ctx.commitThreshold.Reached(validNum, numDelegates) ||
 		numDelegates < 4 && validNum >= numDelegates
 }
 
@@ -506,19 +665,76 @@ func (ctx *rollDPoSCtx) newEndorsement(topic endorsement.ConsensusVoteTopic) (co
 	if ctx.round.block != nil {
 		hash = ctx.round.block.Hash()
 	}
-	endorsement := endorsement.NewEndorsement(
+	en, err := endorsement.NewEndorsementSignedWith(
 		endorsement.NewConsensusVote(
 			hash,
 			ctx.round.height,
 			ctx.round.number,
 			topic,
 		),
-		ctx.pubKey,
-		ctx.priKey,
+		ctx.signer.PublicKey(),
+		signer.SignFunc(ctx.signer, signer.KindEndorsement, ctx.round.height, ctx.round.number, uint32(topic)),
 		ctx.encodedAddr,
 	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when signing endorsement")
+	}
+	if ctx.blsPriKey != nil {
+		if err := en.SignBLS(ctx.blsPriKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &endorsementWrapper{en}, nil
+}
+
+// aggregateEndorsements aggregates the BLS signatures of the endorsements in the proof of lock, one aggregate for each
+// topic endorsed by enough delegates. It returns nil if the aggregation is disabled, or the COMMIT endorsements cannot
+// be aggregated to take the place of the endorsements, in which case the endorsements are put into the block as they
+// are
+func (ctx *rollDPoSCtx) aggregateEndorsements() []*endorsement.Aggregate {
+	if ctx.blsKeysByHeightFunc == nil {
+		return nil
+	}
+	var aggregates []*endorsement.Aggregate
+	for _, topic := range []endorsement.ConsensusVoteTopic{endorsement.PROPOSAL, endorsement.LOCK, endorsement.COMMIT} {
+		aggregate, err := endorsement.AggregateEndorsements(ctx.round.proofOfLock, topic, ctx.epoch.blsPubKeys)
+		if err == nil {
+			err = verifyAggregate(aggregate, ctx.epoch, ctx.commitThreshold)
+		}
+		if err != nil {
+			if topic == endorsement.COMMIT {
+				ctx.logger().Warn("failed to aggregate commit endorsements", zap.Error(err))
+				return nil
+			}
+			ctx.logger().Debug("failed to aggregate endorsements", zap.Uint8("topic", uint8(topic)), zap.Error(err))
+			continue
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+	return aggregates
+}
 
-	return &endorsementWrapper{endorsement}, nil
+// verifyAggregate verifies that the aggregate is signed by more than the threshold of the delegates of the epoch with
+// their registered BLS keys
+func verifyAggregate(aggregate *endorsement.Aggregate, epoch *epochCtx, threshold scheme.CommitThreshold) error {
+	delegateSet := make(map[string]bool, len(epoch.delegates))
+	for _, delegate := range epoch.delegates {
+		delegateSet[delegate] = true
+	}
+	for _, endorser := range aggregate.Endorsers() {
+		if !delegateSet[endorser] {
+			return errors.Errorf("endorser %s isn't a delegate", endorser)
+		}
+	}
+	if !threshold.Reached(len(aggregate.Endorsers()), len(epoch.delegates)) {
+		return errors.Errorf(
+			"%d endorsers of %d delegates are insufficient",
+			len(aggregate.Endorsers()),
+			len(epoch.delegates),
+		)
+	}
+	return aggregate.Verify(epoch.blsPubKeys)
 }
 
 func (ctx *rollDPoSCtx) isProposedBlock(hash []byte) bool {
@@ -550,6 +766,8 @@ func (ctx *rollDPoSCtx) processEndorsement(
 		return errors.New("invalid endorsement")
 	}
 	vote := endorse.ConsensusVote()
+	// The conflicting endorsements are detected before the ones on another block are rejected
+	ctx.observeEndorsement(endorse.Endorsement)
 	if !ctx.isProposedBlock(vote.BlkHash) {
 		return errors.New("the endorsed block was not the proposed block")
 	}
@@ -573,7 +791,31 @@ func (ctx *rollDPoSCtx) processEndorsement(
 		ctx.round.endorsementSets[blkHashHex] = endorsementSet
 	}
 
-	return endorsementSet.AddEndorsement(endorse.Endorsement)
+	if err := endorsementSet.AddEndorsement(endorse.Endorsement); err != nil {
+		return err
+	}
+	if ctx.timeline != nil {
+		ctx.timeline.endorsed(endorse.Endorser(), vote.Topic, ctx.clock.Now())
+	}
+	return nil
+}
+
+// blockInterval returns the block interval of the epoch, which is set on chain or taken from the config
+func (ctx *rollDPoSCtx) blockInterval(epoch *epochCtx) time.Duration {
+	if epoch != nil && epoch.params != nil {
+		return epoch.params.BlockInterval
+	}
+	return ctx.cfg.DelegateInterval
+}
+
+// roundTTLs returns the ttls of the rounds in the current epoch, which are set on chain or taken from the config
+func (ctx *rollDPoSCtx) roundTTLs() (time.Duration, time.Duration, time.Duration) {
+	if ctx.epoch != nil && ctx.epoch.params != nil {
+		params := ctx.epoch.params
+		return params.AcceptBlockTTL, params.AcceptProposalEndorsementTTL, params.AcceptLockEndorsementTTL
+	}
+	fsm := ctx.cfg.FSM
+	return fsm.AcceptBlockTTL, fsm.AcceptProposalEndorsementTTL, fsm.AcceptLockEndorsementTTL
 }
 
 // updateEpoch updates the current epoch
@@ -587,6 +829,15 @@ func (ctx *rollDPoSCtx) updateEpoch(height uint64) error {
 		if err != nil {
 			return err
 		}
+		if ctx.epoch != nil {
+			atomic.AddUint64(&ctx.epochTransitions, 1)
+			epochTransitionMtc.Inc()
+		}
+		if ctx.consensusParamsByHeightFunc != nil {
+			if epoch.params, err = ctx.consensusParamsByHeightFunc(epoch.height); err != nil {
+				return errors.Wrapf(err, "error when reading consensus params of epoch %d", epoch.num)
+			}
+		}
 		ctx.epoch = epoch
 	}
 	return nil
@@ -633,7 +884,7 @@ func (ctx *rollDPoSCtx) roundCtxByTime(
 		return nil, err
 	}
 	// proposer interval should be always larger than 0
-	interval := ctx.cfg.DelegateInterval
+	interval := ctx.blockInterval(epoch)
 	if interval <= 0 {
 		ctx.logger().Panic("invalid proposer interval")
 	}
@@ -678,6 +929,9 @@ func (ctx *rollDPoSCtx) updateRound(height uint64) error {
 		}
 	}
 	ctx.round = round
+	if ctx.timeline != nil {
+		ctx.timeline.startRound(round.height, round.number, round.proposer, round.timestamp)
+	}
 
 	return nil
 }
@@ -692,8 +946,79 @@ func (ctx *rollDPoSCtx) isDelegate() bool {
 	return false
 }
 
+// holdLease acquires or renews the signing lease if the failover is enabled, and returns whether the current node
+// signs in the round
+func (ctx *rollDPoSCtx) holdLease() bool {
+	if ctx.lease == nil {
+		return true
+	}
+	now := ctx.clock.Now()
+	if now.Before(ctx.standbyUntil) {
+		return false
+	}
+	failover := ctx.cfg.Failover
+	token, err := ctx.lease.Hold(failover.NodeID, now, failover.LeaseTTL)
+	if err != nil {
+		ctx.logger().Error("error when holding the signing lease", zap.Error(err))
+		token = 0
+	}
+	atomic.StoreUint64(&ctx.leaseToken, token)
+	return token != 0
+}
+
+// renewLease renews the signing lease held, independent of the rounds which may take longer than the lease ttl. The
+// node stops signing once the lease is lost, until it acquires the lease again in a round
+func (ctx *rollDPoSCtx) renewLease() {
+	token := atomic.LoadUint64(&ctx.leaseToken)
+	if token == 0 {
+		return
+	}
+	failover := ctx.cfg.Failover
+	renewed, err := ctx.lease.Hold(failover.NodeID, ctx.clock.Now(), failover.LeaseTTL)
+	if err != nil {
+		ctx.logger().Error("error when renewing the signing lease", zap.Error(err))
+		return
+	}
+	if renewed == token {
+		return
+	}
+	ctx.logger().Warn("lost the signing lease", zap.Uint64("token", token), zap.Uint64("renewedToken", renewed))
+	// The lease acquired anew in between isn't trusted until the next round
+	atomic.CompareAndSwapUint64(&ctx.leaseToken, token, 0)
+	ctx.mutex.Lock()
+	ctx.holdsLease = false
+	ctx.mutex.Unlock()
+}
+
+// fenceLease returns an error unless the node still holds the signing lease acquired with the fencing token, and no
+// node holding the lease with another token has signed in the round or a later one
+func (ctx *rollDPoSCtx) fenceLease(height uint64, round uint32) error {
+	token := atomic.LoadUint64(&ctx.leaseToken)
+	if token == 0 {
+		return lease.ErrNotHeld
+	}
+	return ctx.lease.Fence(ctx.cfg.Failover.NodeID, token, ctx.clock.Now(), height, round)
+}
+
+// fencedSigner signs only if the node still holds the signing lease acquired with the fencing token, so that a node
+// stalled past the lease ttl in a round never signs along with the other node of the failover pair taking over, and
+// the node taking over never signs again in a round the other node may have signed in
+type fencedSigner struct {
+	signer.Signer
+	ctx *rollDPoSCtx
+}
+
+// Sign signs the message after checking the lease
+func (s *fencedSigner) Sign(msg signer.Message) ([]byte, error) {
+	if err := s.ctx.fenceLease(msg.Height, msg.Round); err != nil {
+		return nil, errors.Wrap(err, "error when checking the signing lease")
+	}
+	return s.Signer.Sign(msg)
+}
+
 // rotatedProposer will rotate among the delegates to choose the proposer. It is pseudo order based on the position
-// in the delegate list and the block height
+// in the delegate list and the block height, or the VRF proof in the previous block since the VRF height, in which
+// case the proposer isn't known until the previous block is committed
 func (ctx *rollDPoSCtx) rotatedProposer(epoch *epochCtx, height uint64, round uint32) (
 	proposer string,
 	err error,
@@ -703,10 +1028,222 @@ func (ctx *rollDPoSCtx) rotatedProposer(epoch *epochCtx, height uint64, round ui
 	if numDelegates == 0 {
 		return "", ErrZeroDelegate
 	}
+	pos := height
+	if ctx.vrfHeight != 0 && height > ctx.vrfHeight {
+		prev, err := ctx.chain.GetBlockByHeight(height - 1)
+		if err != nil {
+			return "", errors.Wrapf(err, "error when getting the VRF proof at height %d", height-1)
+		}
+		pos = vrfOutput(prev.VRFProof())
+	}
 	if !ctx.cfg.TimeBasedRotation {
-		return delegates[(height)%uint64(numDelegates)], nil
+		return delegates[pos%uint64(numDelegates)], nil
+	}
+	return delegates[(pos+uint64(round))%uint64(numDelegates)], nil
+}
+
+// vrfProof returns the VRF proof to put into the block at the height, which is nil below the VRF height
+func (ctx *rollDPoSCtx) vrfProof(height uint64) ([]byte, error) {
+	if ctx.vrfHeight == 0 || height < ctx.vrfHeight {
+		return nil, nil
 	}
-	return delegates[(height+uint64(round))%uint64(numDelegates)], nil
+	if ctx.blsPriKey == nil {
+		return nil, errors.New("no BLS key to prove the VRF")
+	}
+	return proveVRF(ctx.blsPriKey, ctx.epoch.seed, height)
+}
+
+// verifyVRFProof verifies the VRF proof in the block against the BLS key of the producer
+func (ctx *rollDPoSCtx) verifyVRFProof(epoch *epochCtx, blk *block.Block) error {
+	if ctx.vrfHeight == 0 || blk.Height() < ctx.vrfHeight {
+		return nil
+	}
+	if ctx.blsKeysByHeightFunc == nil {
+		return errors.New("no BLS keys to verify the VRF proofs")
+	}
+	return verifyVRF(epoch.blsPubKeys[blk.ProducerAddress()], epoch.seed, blk.Height(), blk.VRFProof())
+}
+
+// isScheduledProposer returns whether the producer proposes in the current round, or in a round within the grace
+// window around the current time, in case the local round calculation drifts slightly from the producer's
+func (ctx *rollDPoSCtx) isScheduledProposer(producer string) bool {
+	if producer == ctx.round.proposer {
+		return true
+	}
+	grace := ctx.cfg.ProposerGraceWindow
+	if grace <= 0 {
+		return false
+	}
+	lastBlockTime, err := ctx.getBlockTime(ctx.round.height - 1)
+	if err != nil {
+		ctx.logger().Error("error when getting the last block time", zap.Error(err))
+		return false
+	}
+	now := ctx.clock.Now()
+	// The window may start before the last block, in which case it starts from round 0
+	first, err := ctx.calcRoundNum(lastBlockTime, now.Add(-grace), ctx.blockInterval(ctx.epoch))
+	if err != nil {
+		first = 0
+	}
+	last, err := ctx.calcRoundNum(lastBlockTime, now.Add(grace), ctx.blockInterval(ctx.epoch))
+	if err != nil {
+		return false
+	}
+	for round := first; round <= last; round++ {
+		proposer, err := ctx.rotatedProposer(ctx.epoch, ctx.round.height, round)
+		if err != nil {
+			return false
+		}
+		if proposer == producer {
+			ctx.logger().Info(
+				"accept the proposer scheduled in a round within the grace window",
+				zap.String("proposer", producer),
+				zap.Uint32("round", round),
+			)
+			return true
+		}
+	}
+	return false
+}
+
+// proposerSchedule returns the first height of the sub-epoch following the one of the given height, and the proposers
+// in round 0 of its blocks. The proposers are empty if the next sub-epoch starts a new epoch, whose delegates aren't
+// fixed until the last block of the current epoch is committed
+func (ctx *rollDPoSCtx) proposerSchedule(epoch *epochCtx, height uint64) (uint64, []string) {
+	numDelegates := uint64(ctx.cfg.NumDelegates)
+	start := ((height-1)/numDelegates+1)*numDelegates + 1
+	if getEpochNum(start, ctx.cfg.NumDelegates, ctx.cfg.NumSubEpochs) != epoch.num {
+		return start, nil
+	}
+	proposers := make([]string, 0, numDelegates)
+	for h := start; h < start+numDelegates; h++ {
+		proposer, err := ctx.rotatedProposer(epoch, h, 0)
+		if err != nil {
+			return start, nil
+		}
+		proposers = append(proposers, proposer)
+	}
+	return start, proposers
+}
+
+// announceSchedule logs the proposer schedule of the next sub-epoch once it's fixed
+func (ctx *rollDPoSCtx) announceSchedule(height uint64) {
+	start, proposers := ctx.proposerSchedule(ctx.epoch, height)
+	if len(proposers) == 0 || start == ctx.announcedHeight {
+		return
+	}
+	ctx.announcedHeight = start
+	ctx.logger().Info(
+		"proposer schedule of the next sub-epoch",
+		zap.Uint64("startHeight", start),
+		zap.Strings("proposers", proposers),
+	)
+}
+
+// observeProposal checks whether the block proposal of the current round arrives after the time to accept a block, and
+// records an evidence if its proposer does so too often
+func (ctx *rollDPoSCtx) observeProposal(blk *block.Block) {
+	if ctx.withhold == nil {
+		return
+	}
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	producer := blk.ProducerAddress()
+	if ctx.round == nil || blk.Height() != ctx.round.height || producer != ctx.round.proposer ||
+		producer == ctx.encodedAddr {
+		return
+	}
+	now := ctx.clock.Now()
+	acceptBlockTTL, _, _ := ctx.roundTTLs()
+	late := now.After(ctx.round.timestamp.Add(acceptBlockTTL))
+	if evidence := ctx.withhold.observe(producer, blk.Height(), late, now); evidence != nil {
+		ctx.logger().Warn(
+			"proposer withholds its blocks",
+			zap.String("proposer", evidence.Proposer),
+			zap.Uint("lateProposals", evidence.LateProposals),
+			zap.Uint("window", evidence.Window),
+		)
+	}
+}
+
+// observeHeight triggers the block sync toward the height of a proposal, if it's more than the sync trigger distance
+// above the local tip. The consensus has stalled for the node in that case, as it cannot endorse the proposals until
+// the missing blocks arrive. Only the proposals of the delegates of the current epoch are trusted, and the target is
+// capped at an epoch above the tip, beyond which the delegates aren't known yet
+func (ctx *rollDPoSCtx) observeHeight(blk *block.Block) {
+	distance := ctx.cfg.SyncTriggerDistance
+	if ctx.syncToHeight == nil || distance == 0 {
+		return
+	}
+	tip := ctx.chain.TipHeight()
+	height := blk.Height()
+	if height <= tip+distance {
+		return
+	}
+	ctx.mutex.RLock()
+	isDelegate := ctx.epoch != nil && ctx.isDelegateEndorsement(blk.ProducerAddress())
+	ctx.mutex.RUnlock()
+	if !isDelegate {
+		ctx.Logger().Debug(
+			"ignored proposal far above the tip from a non-delegate",
+			zap.Uint64("proposalHeight", height),
+			zap.String("producer", blk.ProducerAddress()),
+		)
+		return
+	}
+	ctx.Logger().Info(
+		"observed proposal far above the tip",
+		zap.Uint64("proposalHeight", height),
+		zap.Uint64("tipHeight", tip),
+	)
+	// The proposed block is to be built on the network tip
+	target := height - 1
+	if epochLen := uint64(ctx.cfg.NumDelegates) * uint64(ctx.cfg.NumSubEpochs); epochLen > 0 && target > tip+epochLen {
+		target = tip + epochLen
+	}
+	ctx.syncToHeight(target)
+}
+
+// observeEndorsement checks whether the delegate has endorsed another block for the same vote in the current round,
+// and keeps the evidence if so
+func (ctx *rollDPoSCtx) observeEndorsement(en *endorsement.Endorsement) {
+	if ctx.doubleSign == nil || en.ConsensusVote().Height != ctx.round.height ||
+		!ctx.isDelegateEndorsement(en.Endorser()) {
+		return
+	}
+	if evidence := ctx.doubleSign.observe(en); evidence != nil {
+		vote := en.ConsensusVote()
+		ctx.logger().Warn(
+			"delegate endorses different blocks",
+			zap.String("endorser", evidence.Offender()),
+			zap.Uint32("round", vote.Round),
+			zap.Uint8("topic", uint8(vote.Topic)),
+		)
+	}
+}
+
+// evidenceActions signs the pending double sign evidences into the actions to put into the block. Like the grant
+// reward action, they are free and their nonce is 0
+func (ctx *rollDPoSCtx) evidenceActions() ([]action.SealedEnvelope, error) {
+	evidences := ctx.doubleSign.pendingEvidences()
+	acts := make([]action.SealedEnvelope, 0, len(evidences))
+	for _, evidence := range evidences {
+		eb := action.EnvelopeBuilder{}
+		elp := eb.SetNonce(0).
+			SetGasPrice(big.NewInt(0)).
+			SetAction(evidence).
+			Build()
+		selp, err := action.SignWith(
+			elp,
+			ctx.signer.PublicKey(),
+			signer.SignFunc(ctx.signer, signer.KindAction, ctx.round.height, ctx.round.number, 0),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when signing double sign evidence")
+		}
+		acts = append(acts, selp)
+	}
+	return acts, nil
 }
 
 // getBlockTime returns the duration since block time
@@ -721,22 +1258,6 @@ func (ctx *rollDPoSCtx) getBlockTime(height uint64) (time.Time, error) {
 	return time.Unix(blk.Header.Timestamp(), 0), nil
 }
 
-func (ctx *rollDPoSCtx) getProposer(
-	height uint64,
-	round uint32,
-	delegates []string,
-) (string, error) {
-	numDelegates := ctx.cfg.NumDelegates
-	if int(numDelegates) != len(delegates) {
-		return "", errors.New("delegates number is different from config")
-	}
-	if !ctx.cfg.TimeBasedRotation {
-		return delegates[(height)%uint64(numDelegates)], nil
-	}
-
-	return delegates[(height+uint64(round))%uint64(numDelegates)], nil
-}
-
 func (ctx *rollDPoSCtx) epochCtxByHeight(height uint64) (*epochCtx, error) {
 	f := ctx.candidatesByHeightFunc
 	if f == nil {
@@ -745,5 +1266,70 @@ func (ctx *rollDPoSCtx) epochCtxByHeight(height uint64) (*epochCtx, error) {
 		}
 	}
 
-	return newEpochCtx(ctx.cfg.NumDelegates, ctx.cfg.NumSubEpochs, height, f)
+	var seedByEpoch func(uint64) ([]byte, error)
+	if ctx.dkg != nil {
+		seedByEpoch = ctx.dkg.seed
+	}
+
+	epoch, err := newEpochCtx(ctx.cfg.NumDelegates, ctx.cfg.NumSubEpochs, height, f, seedByEpoch)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.blsKeysByHeightFunc != nil {
+		if epoch.blsPubKeys, err = ctx.blsKeysByHeightFunc(epoch.height, epoch.delegates); err != nil {
+			return nil, errors.Wrapf(err, "error when reading BLS keys of epoch %d", epoch.num)
+		}
+	}
+	return epoch, nil
+}
+
+// stepDKG sends the DKG message of the delegate in the phase of the round height
+func (ctx *rollDPoSCtx) stepDKG() {
+	selp, err := ctx.dkg.step(ctx.round.height, ctx.round.number, ctx.encodedAddr, ctx.signer)
+	if err != nil {
+		ctx.logger().Error("error when taking the part in the key generation", zap.Error(err))
+		return
+	}
+	if selp == nil {
+		return
+	}
+	data, err := proto.Marshal(selp.Proto())
+	if err != nil {
+		ctx.logger().Error("error when serializing DKG message", zap.Error(err))
+		return
+	}
+	if err := ctx.broadcastHandler(&iotexrpc.Consensus{
+		Height:    ctx.round.height,
+		Round:     ctx.round.number,
+		Type:      iotexrpc.Consensus_DKG,
+		Data:      data,
+		Timestamp: &timestamp.Timestamp{Seconds: ctx.clock.Now().Unix()},
+	}); err != nil {
+		ctx.logger().Error("fail to broadcast DKG message", zap.Error(err))
+	}
+}
+
+// addDKGMessage keeps the DKG message of a delegate of the current epoch to put into the block
+func (ctx *rollDPoSCtx) addDKGMessage(selp action.SealedEnvelope) error {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	if ctx.dkg == nil {
+		return errors.New("DKG is disabled")
+	}
+	m, ok := selp.Action().(*action.DKGMessage)
+	if !ok {
+		return errors.New("action is not a DKG message")
+	}
+	if ctx.epoch == nil || m.Epoch() != ctx.epoch.num {
+		return errors.Errorf("DKG message of epoch %d is not of the current epoch", m.Epoch())
+	}
+	pkHash := keypair.HashPubKey(selp.SrcPubkey())
+	sender, err := address.FromBytes(pkHash[:])
+	if err != nil {
+		return err
+	}
+	if !ctx.isDelegateEndorsement(sender.String()) {
+		return errors.Errorf("DKG message sender %s is not a delegate", sender.String())
+	}
+	return ctx.dkg.add(selp)
 }
@@ -7,18 +7,27 @@
 package rolldpos
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/consensus/lease"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/consensus/signer"
 	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
@@ -156,5 +165,464 @@ func TestRollDPoSCtx(t *testing.T) {
 		require.Equal(t, clock.Now().Add(7*time.Second), ctx.round.timestamp)
 		require.Equal(t, uint64(9), ctx.round.height)
 	})
+	t.Run("consensus-params", func(t *testing.T) {
+		blockHeight := uint64(8)
+		clock := clock.NewMock()
+		blk := block.NewBlockDeprecated(
+			1,
+			blockHeight,
+			hash.ZeroHash256,
+			testutil.TimestampNowFromClock(clock),
+			testAddrs[0].pubKey,
+			make([]action.SealedEnvelope, 0),
+		)
+		ctx := makeTestRollDPoSCtx(
+			testAddrs[0],
+			ctrl,
+			config.RollDPoS{
+				NumSubEpochs:     1,
+				NumDelegates:     4,
+				DelegateInterval: 10 * time.Second,
+				FSM: consensusfsm.Config{
+					AcceptBlockTTL:               4 * time.Second,
+					AcceptProposalEndorsementTTL: 2 * time.Second,
+					AcceptLockEndorsementTTL:     2 * time.Second,
+				},
+			},
+			func(blockchain *mock_blockchain.MockBlockchain) {
+				blockchain.EXPECT().GetBlockByHeight(blockHeight).Return(blk, nil).Times(1)
+				blockchain.EXPECT().CandidatesByHeight(gomock.Any()).Return([]*state.Candidate{
+					{Address: testAddrs[0].encodedAddr},
+					{Address: testAddrs[1].encodedAddr},
+					{Address: testAddrs[2].encodedAddr},
+					{Address: testAddrs[3].encodedAddr},
+				}, nil).AnyTimes()
+			},
+			func(_ *mock_actpool.MockActPool) {},
+			nil,
+			clock,
+		)
+		ctx.round = &roundCtx{height: blockHeight + 1}
+		// The params are set on chain from epoch 3, which starts at height 9
+		ctx.consensusParamsByHeightFunc = func(height uint64) (*governance.ConsensusParams, error) {
+			switch {
+			case height < 9:
+				return nil, nil
+			case height == 9:
+				return &governance.ConsensusParams{
+					Height:                       9,
+					BlockInterval:                5 * time.Second,
+					AcceptBlockTTL:               2 * time.Second,
+					AcceptProposalEndorsementTTL: time.Second,
+					AcceptLockEndorsementTTL:     time.Second,
+				}, nil
+			default:
+				return nil, errors.New("some error")
+			}
+		}
+
+		// The config applies before the params take effect
+		require.NoError(t, ctx.updateEpoch(blockHeight))
+		blockTTL, proposalEndorsementTTL, lockEndorsementTTL := ctx.RoundTTLs()
+		require.Equal(t, []time.Duration{4 * time.Second, 2 * time.Second, 2 * time.Second},
+			[]time.Duration{blockTTL, proposalEndorsementTTL, lockEndorsementTTL})
+		require.Equal(t, 10*time.Second, ctx.blockInterval(ctx.epoch))
+
+		require.NoError(t, ctx.updateEpoch(blockHeight+1))
+		require.Equal(t, uint64(1), ctx.epochTransitions)
+		blockTTL, proposalEndorsementTTL, lockEndorsementTTL = ctx.RoundTTLs()
+		require.Equal(t, []time.Duration{2 * time.Second, time.Second, time.Second},
+			[]time.Duration{blockTTL, proposalEndorsementTTL, lockEndorsementTTL})
+		// The rounds are calculated with the block interval of the epoch
+		clock.Add(12 * time.Second)
+		require.NoError(t, ctx.updateRound(blockHeight+1))
+		require.Equal(t, uint32(2), ctx.round.number)
+		require.Equal(t, clock.Now().Add(3*time.Second), ctx.round.timestamp)
+
+		// The epoch isn't moved on if the params fail to be read
+		require.Error(t, ctx.updateEpoch(blockHeight+5))
+		require.Equal(t, uint64(3), ctx.epoch.num)
+	})
+	t.Run("failover-lease", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "lease")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "delegate.lease")
+
+		clock := clock.NewMock()
+		newCtx := func(role string, nodeID string) *rollDPoSCtx {
+			return &rollDPoSCtx{
+				cfg: config.RollDPoS{Failover: config.Failover{
+					Role:      role,
+					NodeID:    nodeID,
+					LeasePath: path,
+					LeaseTTL:  10 * time.Second,
+				}},
+				encodedAddr: testAddrs[0].encodedAddr,
+				epoch:       &epochCtx{delegates: []string{testAddrs[0].encodedAddr}},
+				round:       &roundCtx{},
+				clock:       clock,
+				lease:       lease.NewFileLease(path),
+			}
+		}
+		primary := newCtx(config.FailoverPrimary, "primary")
+		backup := newCtx(config.FailoverBackup, "backup")
+		backup.standbyUntil = clock.Now().Add(10 * time.Second)
+
+		// The backup stands by even if the lease is free
+		require.False(t, backup.holdLease())
+		primary.holdsLease = primary.holdLease()
+		require.True(t, primary.holdsLease)
+		require.True(t, primary.IsDelegate())
+		require.False(t, backup.IsDelegate())
+
+		// The backup takes over once the lease of the primary expires
+		clock.Add(8 * time.Second)
+		require.True(t, primary.holdLease())
+		clock.Add(9 * time.Second)
+		require.False(t, backup.holdLease())
+		clock.Add(2 * time.Second)
+		backup.holdsLease = backup.holdLease()
+		require.True(t, backup.holdsLease)
+		require.True(t, backup.IsDelegate())
+		primary.holdsLease = primary.holdLease()
+		require.False(t, primary.holdsLease)
+		require.False(t, primary.IsDelegate())
+
+		// The backup keeps the lease once it takes over
+		clock.Add(8 * time.Second)
+		require.True(t, backup.holdLease())
+		require.False(t, primary.holdLease())
+
+		// The signatures are fenced by the lease, which is renewed independent of the rounds
+		s := &fencedSigner{Signer: signer.NewLocalSigner(testAddrs[0].priKey), ctx: backup}
+		h := hash.Hash256b([]byte("block"))
+		msg := signer.Message{Kind: signer.KindBlock, Height: 5, Round: 1, Hash: h[:]}
+		_, err = s.Sign(msg)
+		require.NoError(t, err)
+		clock.Add(9 * time.Second)
+		backup.renewLease()
+		clock.Add(9 * time.Second)
+		_, err = s.Sign(msg)
+		require.NoError(t, err)
+		// The node stalled past the lease ttl doesn't sign once the other node takes over
+		clock.Add(11 * time.Second)
+		primary.holdsLease = primary.holdLease()
+		require.True(t, primary.holdsLease)
+		_, err = s.Sign(msg)
+		require.Equal(t, lease.ErrNotHeld, errors.Cause(err))
+		backup.renewLease()
+		require.False(t, backup.IsDelegate())
+		_, err = s.Sign(msg)
+		require.Equal(t, lease.ErrNotHeld, errors.Cause(err))
+		// The node taking over doesn't sign again in the round the other node has signed in
+		ps := &fencedSigner{Signer: signer.NewLocalSigner(testAddrs[0].priKey), ctx: primary}
+		_, err = ps.Sign(msg)
+		require.Equal(t, lease.ErrFenced, errors.Cause(err))
+		msg.Round = 2
+		_, err = ps.Sign(msg)
+		require.NoError(t, err)
+	})
+	t.Run("catching-up", func(t *testing.T) {
+		chain := mock_blockchain.NewMockBlockchain(ctrl)
+		clock := clock.NewMock()
+		chain.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+		chain.EXPECT().GetBlockByHeight(uint64(0)).Return(block.NewBlockDeprecated(
+			1,
+			0,
+			hash.Hash256{},
+			testutil.TimestampNowFromClock(clock),
+			testAddrs[0].pubKey,
+			nil,
+		), nil).AnyTimes()
+		catchingUp := true
+		ctx := &rollDPoSCtx{
+			cfg: config.RollDPoS{
+				NumSubEpochs:     1,
+				NumDelegates:     1,
+				DelegateInterval: 10 * time.Second,
+			},
+			encodedAddr: testAddrs[0].encodedAddr,
+			chain:       chain,
+			epoch:       &epochCtx{num: 1, delegates: []string{testAddrs[0].encodedAddr}},
+			round:       &roundCtx{},
+			clock:       clock,
+			catchingUp:  func() bool { return catchingUp },
+		}
+
+		// The delegate stands by while it's catching up, and takes part again once the sync is close to the tip
+		interval, err := ctx.Prepare()
+		require.NoError(t, err)
+		require.Equal(t, 10*time.Second, interval)
+		require.False(t, ctx.IsDelegate())
+		catchingUp = false
+		clock.Add(time.Second)
+		_, err = ctx.Prepare()
+		require.NoError(t, err)
+		require.True(t, ctx.IsDelegate())
+	})
+	t.Run("proposer-grace-window", func(t *testing.T) {
+		delegates := make([]string, 4)
+		for i := 0; i < len(delegates); i++ {
+			delegates[i] = testAddrs[i].encodedAddr
+		}
+		clock := clock.NewMock()
+		blk := block.NewBlockDeprecated(
+			1,
+			8,
+			hash.Hash256{},
+			testutil.TimestampNowFromClock(clock),
+			testAddrs[0].pubKey,
+			nil,
+		)
+		chain := mock_blockchain.NewMockBlockchain(ctrl)
+		chain.EXPECT().GetBlockByHeight(uint64(8)).Return(blk, nil).AnyTimes()
+		ctx := &rollDPoSCtx{
+			cfg: config.RollDPoS{
+				NumSubEpochs:      1,
+				NumDelegates:      4,
+				DelegateInterval:  10 * time.Second,
+				ToleratedOvertime: 2 * time.Second,
+				TimeBasedRotation: true,
+			},
+			chain: chain,
+			epoch: &epochCtx{delegates: delegates},
+			round: &roundCtx{height: 9, number: 1, proposer: delegates[2]},
+			clock: clock,
+		}
+		clock.Add(15 * time.Second)
+
+		// Only the proposer of the current round is accepted without the grace window
+		require.True(t, ctx.isScheduledProposer(delegates[2]))
+		require.False(t, ctx.isScheduledProposer(delegates[1]))
+
+		// The proposer of round 0 is accepted as it's within the grace window, but not the one of round 2
+		ctx.cfg.ProposerGraceWindow = 6 * time.Second
+		require.True(t, ctx.isScheduledProposer(delegates[2]))
+		require.True(t, ctx.isScheduledProposer(delegates[1]))
+		require.False(t, ctx.isScheduledProposer(delegates[3]))
+		require.False(t, ctx.isScheduledProposer(delegates[0]))
+	})
+	t.Run("proposer-schedule", func(t *testing.T) {
+		delegates := make([]string, 4)
+		for i := 0; i < len(delegates); i++ {
+			delegates[i] = testAddrs[i].encodedAddr
+		}
+		ctx := &rollDPoSCtx{
+			cfg: config.RollDPoS{
+				NumSubEpochs: 2,
+				NumDelegates: 4,
+			},
+			epoch: &epochCtx{num: 1, delegates: delegates},
+			round: &roundCtx{height: 1},
+		}
+
+		// The schedule of the second sub-epoch is known in the first one
+		start, proposers := ctx.proposerSchedule(ctx.epoch, 1)
+		require.Equal(t, uint64(5), start)
+		require.Equal(t, 4, len(proposers))
+		for i, proposer := range proposers {
+			require.Equal(t, delegates[(5+i)%4], proposer)
+		}
+		ctx.announceSchedule(1)
+		require.Equal(t, uint64(5), ctx.announcedHeight)
+
+		// The schedule of the next epoch isn't known until its delegates are fixed
+		_, proposers = ctx.proposerSchedule(ctx.epoch, 5)
+		require.Empty(t, proposers)
+		ctx.announceSchedule(5)
+		require.Equal(t, uint64(5), ctx.announcedHeight)
+	})
+	t.Run("clock-skewed", func(t *testing.T) {
+		chain := mock_blockchain.NewMockBlockchain(ctrl)
+		actPool := mock_actpool.NewMockActPool(ctrl)
+		skewed := true
+		ctx := &rollDPoSCtx{
+			encodedAddr: testAddrs[0].encodedAddr,
+			pubKey:      testAddrs[0].pubKey,
+			signer:      signer.NewLocalSigner(testAddrs[0].priKey),
+			chain:       chain,
+			actPool:     actPool,
+			round:       &roundCtx{height: 2, timestamp: time.Now()},
+			clockSkewed: func() bool { return skewed },
+		}
+		_, err := ctx.MintBlock()
+		require.Error(t, err)
+
+		skewed = false
+		blk := block.NewBlockDeprecated(
+			1,
+			2,
+			hash.Hash256{},
+			testutil.TimestampNow(),
+			testAddrs[0].pubKey,
+			nil,
+		)
+		actPool.EXPECT().PickActs(gomock.Any()).Return(nil).Times(1)
+		chain.EXPECT().
+			MintNewBlockSignedWith(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(blk, nil).Times(1)
+		en, err := ctx.MintBlock()
+		require.NoError(t, err)
+		require.Equal(t, blk, en.(*blockWrapper).Block)
+	})
+	t.Run("skip-empty-block", func(t *testing.T) {
+		chain := mock_blockchain.NewMockBlockchain(ctrl)
+		actPool := mock_actpool.NewMockActPool(ctrl)
+		due := false
+		ctx := &rollDPoSCtx{
+			encodedAddr:   testAddrs[0].encodedAddr,
+			pubKey:        testAddrs[0].pubKey,
+			signer:        signer.NewLocalSigner(testAddrs[0].priKey),
+			chain:         chain,
+			actPool:       actPool,
+			round:         &roundCtx{height: 2, timestamp: time.Now()},
+			emptyBlockDue: func() bool { return due },
+		}
+		actPool.EXPECT().PickActs(gomock.Any()).Return(nil).Times(1)
+		en, err := ctx.MintBlock()
+		require.NoError(t, err)
+		require.Nil(t, en)
+
+		// The empty block is minted as a heartbeat
+		due = true
+		blk := block.NewBlockDeprecated(
+			1,
+			2,
+			hash.Hash256{},
+			testutil.TimestampNow(),
+			testAddrs[0].pubKey,
+			nil,
+		)
+		actPool.EXPECT().PickActs(gomock.Any()).Return(nil).Times(1)
+		chain.EXPECT().
+			MintNewBlockSignedWith(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(blk, nil).Times(1)
+		en, err = ctx.MintBlock()
+		require.NoError(t, err)
+		require.Equal(t, blk, en.(*blockWrapper).Block)
+	})
+}
+
+func TestRollDPoSCtx_ObserveHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	var synced []uint64
+	ctx := &rollDPoSCtx{
+		chain:        chain,
+		epoch:        &epochCtx{delegates: []string{testAddrs[0].encodedAddr}},
+		round:        &roundCtx{height: 11},
+		syncToHeight: func(height uint64) { synced = append(synced, height) },
+	}
+	proposal := func(height uint64, producer *addrKeyPair) *block.Block {
+		return block.NewBlockDeprecated(1, height, hash.Hash256{}, time.Now().Unix(), producer.pubKey, nil)
+	}
+
+	// The trigger is disabled by default
+	ctx.observeHeight(proposal(20, testAddrs[0]))
+	require.Empty(synced)
+
+	ctx.cfg.SyncTriggerDistance = 3
+	ctx.observeHeight(proposal(13, testAddrs[0]))
+	require.Empty(synced)
+	ctx.observeHeight(proposal(14, testAddrs[0]))
+	require.Equal([]uint64{13}, synced)
+
+	// The proposals of the non-delegates are ignored
+	ctx.observeHeight(proposal(15, testAddrs[1]))
+	require.Equal([]uint64{13}, synced)
+
+	// The target is capped at an epoch above the tip
+	ctx.cfg.NumDelegates = 4
+	ctx.cfg.NumSubEpochs = 2
+	ctx.observeHeight(proposal(1000, testAddrs[0]))
+	require.Equal([]uint64{13, 18}, synced)
+}
+
+func TestRollDPoSCtx_AggregateEndorsements(t *testing.T) {
+	require := require.New(t)
+
+	blkHash := hash.Hash256b([]byte("block"))
+	addrs := make([]*addrKeyPair, 0, 14)
+	delegates := make([]string, 0, 14)
+	blsKeys := make([][]uint32, 0, 14)
+	pubKeys := map[string][]byte{}
+	for i := 0; i < 14; i++ {
+		addr := newTestAddr()
+		sk := crypto.DKG.SkGeneration()
+		pk, err := crypto.BLS.NewPubKey(sk)
+		require.NoError(err)
+		addrs = append(addrs, addr)
+		delegates = append(delegates, addr.encodedAddr)
+		blsKeys = append(blsKeys, sk)
+		pubKeys[addr.encodedAddr] = pk
+	}
+	// The first delegate signs with a key other than the registered one
+	badKey := crypto.DKG.SkGeneration()
+	proofOfLock := func(numSigners int, numProposalSigners int) *endorsement.Set {
+		set := endorsement.NewSet(blkHash[:])
+		for i, addr := range addrs {
+			for _, topic := range []endorsement.ConsensusVoteTopic{
+				endorsement.PROPOSAL,
+				endorsement.LOCK,
+				endorsement.COMMIT,
+			} {
+				en := endorsement.NewEndorsement(
+					endorsement.NewConsensusVote(blkHash[:], 2, 0, topic),
+					addr.pubKey,
+					addr.priKey,
+					addr.encodedAddr,
+				)
+				sk := blsKeys[i]
+				if i == 0 {
+					sk = badKey
+				}
+				if i < numSigners && (topic != endorsement.PROPOSAL || i < numProposalSigners) {
+					require.NoError(en.SignBLS(sk))
+				}
+				require.NoError(set.AddEndorsement(en))
+			}
+		}
+		return set
+	}
+	ctx := &rollDPoSCtx{
+		epoch:           &epochCtx{delegates: delegates, blsPubKeys: pubKeys},
+		round:           &roundCtx{height: 2, proofOfLock: proofOfLock(13, 5)},
+		commitThreshold: scheme.DefaultCommitThreshold,
+		blsKeysByHeightFunc: func(uint64, []string) (map[string][]byte, error) {
+			return pubKeys, nil
+		},
+	}
+	// The topics endorsed by too few delegates are left out, and so are the bad signatures
+	aggregates := ctx.aggregateEndorsements()
+	require.Equal(2, len(aggregates))
+	require.Equal(endorsement.LOCK, aggregates[0].ConsensusVote().Topic)
+	require.Equal(endorsement.COMMIT, aggregates[1].ConsensusVote().Topic)
+	aggregate := aggregates[1]
+	require.Equal(12, len(aggregate.Endorsers()))
+	require.NotContains(aggregate.Endorsers(), delegates[0])
+	require.NoError(verifyAggregate(aggregate, ctx.epoch, scheme.DefaultCommitThreshold))
+	// The aggregate is rejected if it's signed by a non-delegate or with another key
+	require.Error(verifyAggregate(
+		aggregate,
+		&epochCtx{delegates: delegates[2:], blsPubKeys: pubKeys},
+		scheme.DefaultCommitThreshold,
+	))
+	pubKeys[delegates[1]] = pubKeys[delegates[2]]
+	require.Error(verifyAggregate(aggregate, ctx.epoch, scheme.DefaultCommitThreshold))
+	pubKeys[delegates[1]], _ = crypto.BLS.NewPubKey(blsKeys[1])
+	// More than 6/7 of the delegates have to sign with a higher threshold
+	require.Error(verifyAggregate(aggregate, ctx.epoch, scheme.CommitThreshold{Numerator: 6, Denominator: 7}))
 
+	// The endorsements are kept as they are if too few delegates sign with their BLS keys
+	ctx.round.proofOfLock = proofOfLock(11, 0)
+	require.Nil(ctx.aggregateEndorsements())
+	ctx.round.proofOfLock = proofOfLock(14, 14)
+	require.Equal(3, len(ctx.aggregateEndorsements()))
+	ctx.blsKeysByHeightFunc = nil
+	require.Nil(ctx.aggregateEndorsements())
 }
@@ -7,31 +7,24 @@
 package rolldpos
 
 import (
-	"encoding/hex"
-	"math/big"
-	"sort"
-
-	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
-	"github.com/iotexproject/iotex-core/action"
-	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/action/protocol/multichain/subchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/signer"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
-	"github.com/iotexproject/iotex-core/pkg/hash"
-	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
 func putBlockToParentChain(
 	rootChainAPI explorerapi.Explorer,
 	subChainAddr string,
-	senderPubKey keypair.PublicKey,
-	senderPriKey keypair.PrivateKey,
+	sender signer.Signer,
 	senderAddr string,
 	b *block.Block,
 ) {
-	if err := putBlockToParentChainTask(rootChainAPI, subChainAddr, senderPubKey, senderPriKey, b); err != nil {
+	sign := signer.SignFunc(sender, signer.KindAction, b.Height(), 0, 0)
+	if err := subchain.PutBlockToParentChainSignedWith(rootChainAPI, subChainAddr, sender.PublicKey(), sign, b); err != nil {
 		log.L().Error("Failed to put block merkle roots to parent chain.",
 			zap.String("subChainAddress", subChainAddr),
 			zap.String("senderAddress", senderAddr),
@@ -44,94 +37,3 @@ func putBlockToParentChain(
 		zap.String("senderAddress", senderAddr),
 		zap.Uint64("height", b.Height()))
 }
-
-func putBlockToParentChainTask(
-	rootChainAPI explorerapi.Explorer,
-	subChainAddr string,
-	senderPubKey keypair.PublicKey,
-	senderPriKey keypair.PrivateKey,
-	b *block.Block,
-) error {
-	req, err := constructPutSubChainBlockRequest(rootChainAPI, subChainAddr, senderPubKey, senderPriKey, b)
-	if err != nil {
-		return errors.Wrap(err, "fail to construct PutSubChainBlockRequest")
-	}
-
-	if _, err := rootChainAPI.PutSubChainBlock(req); err != nil {
-		return errors.Wrap(err, "fail to call explorerapi to put block")
-	}
-	return nil
-}
-
-func constructPutSubChainBlockRequest(
-	rootChainAPI explorerapi.Explorer,
-	subChainAddr string,
-	senderPubKey keypair.PublicKey,
-	senderPriKey keypair.PrivateKey,
-	b *block.Block,
-) (explorerapi.PutSubChainBlockRequest, error) {
-	senderPKHash := keypair.HashPubKey(senderPubKey)
-	senderPCAddr, err := address.FromBytes(senderPKHash[:])
-	if err != nil {
-		return explorerapi.PutSubChainBlockRequest{}, err
-	}
-	encodedSenderPCAddr := senderPCAddr.String()
-
-	// get sender current pending nonce on parent chain
-	senderPCAddrDetails, err := rootChainAPI.GetAddressDetails(encodedSenderPCAddr)
-	if err != nil {
-		return explorerapi.PutSubChainBlockRequest{}, errors.Wrap(err, "fail to get address details")
-	}
-
-	rootm := make(map[string]hash.Hash256)
-	rootm["state"] = b.StateRoot()
-	rootm["tx"] = b.TxRoot()
-	pb := action.NewPutBlock(
-		uint64(senderPCAddrDetails.PendingNonce),
-		subChainAddr,
-		b.Height(),
-		rootm,
-		1000000,        // gas limit
-		big.NewInt(10), //gas price
-	)
-
-	bd := &action.EnvelopeBuilder{}
-	elp := bd.SetNonce(uint64(senderPCAddrDetails.PendingNonce)).
-		SetDestinationAddress(subChainAddr).
-		SetGasPrice(big.NewInt(10)).
-		SetGasLimit(1000000).
-		SetAction(pb).Build()
-
-	// sign action
-	selp, err := action.Sign(elp, senderPriKey)
-	if err != nil {
-		return explorerapi.PutSubChainBlockRequest{}, errors.Wrap(err, "fail to sign put block action")
-	}
-
-	req := explorerapi.PutSubChainBlockRequest{
-		Version:         int64(selp.Version()),
-		Nonce:           int64(selp.Nonce()),
-		SenderPubKey:    keypair.EncodePublicKey(senderPubKey),
-		GasLimit:        int64(selp.GasLimit()),
-		GasPrice:        selp.GasPrice().String(),
-		SubChainAddress: pb.SubChainAddress(),
-		Height:          int64(pb.Height()),
-		Roots:           make([]explorerapi.PutSubChainBlockMerkelRoot, 0, 2),
-		Signature:       hex.EncodeToString(selp.Signature()),
-	}
-
-	// put merkel roots
-	keys := make([]string, 0, len(rootm))
-	for k := range rootm {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	for _, k := range keys {
-		v := rootm[k]
-		req.Roots = append(req.Roots, explorerapi.PutSubChainBlockMerkelRoot{
-			Name:  k,
-			Value: hex.EncodeToString(v[:]),
-		})
-	}
-	return req, nil
-}
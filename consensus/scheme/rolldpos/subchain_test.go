@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/signer"
 	explorerapi "github.com/iotexproject/iotex-core/explorer/idl/explorer"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/version"
@@ -119,5 +120,5 @@ func TestPutBlockToParentChain(t *testing.T) {
 		assert.Equal(t, in.Height, req.Height)
 	})
 
-	putBlockToParentChain(exp, req.SubChainAddress, pubKey, priKey, addr, &blk)
+	putBlockToParentChain(exp, req.SubChainAddress, signer.NewLocalSigner(priKey), addr, &blk)
 }
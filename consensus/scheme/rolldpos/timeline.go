@@ -0,0 +1,179 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+// maxRoundTimelines is the number of the latest round timelines kept in memory
+const maxRoundTimelines = 100
+
+// roundTimeline is when the events of a consensus round happen. A zero time means the event doesn't happen
+type roundTimeline struct {
+	height           uint64
+	round            uint32
+	proposer         string
+	start            time.Time
+	proposalReceived time.Time
+	proposalQuorum   time.Time
+	lockQuorum       time.Time
+	committed        time.Time
+	// endorsements is when the first endorsement of each topic is received from each endorser
+	endorsements map[string]map[endorsement.ConsensusVoteTopic]time.Time
+}
+
+// timelineRecorder records the timelines of the latest rounds, so that the delegates slowing the rounds down can be
+// told. It has its own lock as the timelines are read by the API, outside of the consensus FSM
+type timelineRecorder struct {
+	mutex     sync.RWMutex
+	timelines []*roundTimeline
+}
+
+func newTimelineRecorder() *timelineRecorder {
+	return &timelineRecorder{}
+}
+
+// startRound starts the timeline of a round, unless it's the round of the latest timeline
+func (r *timelineRecorder) startRound(height uint64, round uint32, proposer string, start time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if l := r.latest(); l != nil && l.height == height && l.round == round {
+		return
+	}
+	r.timelines = append(r.timelines, &roundTimeline{
+		height:       height,
+		round:        round,
+		proposer:     proposer,
+		start:        start,
+		endorsements: make(map[string]map[endorsement.ConsensusVoteTopic]time.Time),
+	})
+	if len(r.timelines) > maxRoundTimelines {
+		r.timelines = r.timelines[len(r.timelines)-maxRoundTimelines:]
+	}
+}
+
+// proposalReceived records when the proposal of the latest round is received
+func (r *timelineRecorder) proposalReceived(at time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if l := r.latest(); l != nil {
+		setOnce(&l.proposalReceived, at)
+	}
+}
+
+// endorsed records when an endorsement of the latest round is received
+func (r *timelineRecorder) endorsed(endorser string, topic endorsement.ConsensusVoteTopic, at time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	l := r.latest()
+	if l == nil {
+		return
+	}
+	topics, ok := l.endorsements[endorser]
+	if !ok {
+		topics = make(map[endorsement.ConsensusVoteTopic]time.Time)
+		l.endorsements[endorser] = topics
+	}
+	if _, ok := topics[topic]; !ok {
+		topics[topic] = at
+	}
+}
+
+// quorum records when the endorsements of the topic reach the quorum in the latest round
+func (r *timelineRecorder) quorum(topic endorsement.ConsensusVoteTopic, at time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	l := r.latest()
+	if l == nil {
+		return
+	}
+	switch topic {
+	case endorsement.PROPOSAL:
+		setOnce(&l.proposalQuorum, at)
+	case endorsement.LOCK:
+		setOnce(&l.lockQuorum, at)
+	}
+}
+
+// committed records when the block of the latest round is committed
+func (r *timelineRecorder) committed(at time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if l := r.latest(); l != nil {
+		setOnce(&l.committed, at)
+	}
+}
+
+// recent returns the timelines of the latest count rounds, the latest first, which are all the kept ones if count is 0
+func (r *timelineRecorder) recent(count int) []*iotexapi.RoundTimeline {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if count <= 0 || count > len(r.timelines) {
+		count = len(r.timelines)
+	}
+	timelines := make([]*iotexapi.RoundTimeline, 0, count)
+	for i := len(r.timelines) - 1; i >= len(r.timelines)-count; i-- {
+		timelines = append(timelines, r.timelines[i].toProto())
+	}
+	return timelines
+}
+
+func (r *timelineRecorder) latest() *roundTimeline {
+	if len(r.timelines) == 0 {
+		return nil
+	}
+	return r.timelines[len(r.timelines)-1]
+}
+
+func (t *roundTimeline) toProto() *iotexapi.RoundTimeline {
+	endorsers := make([]string, 0, len(t.endorsements))
+	for endorser := range t.endorsements {
+		endorsers = append(endorsers, endorser)
+	}
+	sort.Strings(endorsers)
+	latencies := make([]*iotexapi.EndorsementLatency, 0, len(endorsers))
+	for _, endorser := range endorsers {
+		topics := t.endorsements[endorser]
+		latencies = append(latencies, &iotexapi.EndorsementLatency{
+			Endorser: endorser,
+			Proposal: t.sinceStart(topics[endorsement.PROPOSAL]),
+			Lock:     t.sinceStart(topics[endorsement.LOCK]),
+			Commit:   t.sinceStart(topics[endorsement.COMMIT]),
+		})
+	}
+	return &iotexapi.RoundTimeline{
+		Height:           t.height,
+		Round:            t.round,
+		Proposer:         t.proposer,
+		StartTime:        t.start.UnixNano() / int64(time.Millisecond),
+		ProposalReceived: t.sinceStart(t.proposalReceived),
+		ProposalQuorum:   t.sinceStart(t.proposalQuorum),
+		LockQuorum:       t.sinceStart(t.lockQuorum),
+		Committed:        t.sinceStart(t.committed),
+		Endorsements:     latencies,
+	}
+}
+
+// sinceStart returns the milliseconds from the start of the round to the time, or 0 if the time is zero
+func (t *roundTimeline) sinceStart(at time.Time) int64 {
+	if at.IsZero() {
+		return 0
+	}
+	return int64(at.Sub(t.start) / time.Millisecond)
+}
+
+func setOnce(t *time.Time, at time.Time) {
+	if t.IsZero() {
+		*t = at
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+func TestTimelineRecorder(t *testing.T) {
+	require := require.New(t)
+	r := newTimelineRecorder()
+	start := time.Unix(1000, 0)
+	at := func(ms int) time.Time {
+		return start.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	// Nothing is recorded before a round starts
+	r.proposalReceived(at(100))
+	require.Empty(r.recent(0))
+
+	r.startRound(5, 0, "a", start)
+	// The round is started once only
+	r.startRound(5, 0, "a", at(500))
+	r.proposalReceived(at(300))
+	r.endorsed("b", endorsement.PROPOSAL, at(400))
+	r.endorsed("a", endorsement.PROPOSAL, at(350))
+	// The first endorsement of a topic counts
+	r.endorsed("a", endorsement.PROPOSAL, at(800))
+	r.quorum(endorsement.PROPOSAL, at(400))
+	r.endorsed("a", endorsement.LOCK, at(600))
+	r.quorum(endorsement.LOCK, at(900))
+	r.quorum(endorsement.LOCK, at(1000))
+	r.endorsed("a", endorsement.COMMIT, at(1100))
+	r.committed(at(1200))
+
+	r.startRound(5, 1, "b", at(10000))
+	timelines := r.recent(0)
+	require.Len(timelines, 2)
+	require.Equal(&iotexapi.RoundTimeline{Height: 5, Round: 1, Proposer: "b", StartTime: 1010000,
+		Endorsements: []*iotexapi.EndorsementLatency{}}, timelines[0])
+	require.Equal(&iotexapi.RoundTimeline{
+		Height:           5,
+		Round:            0,
+		Proposer:         "a",
+		StartTime:        1000000,
+		ProposalReceived: 300,
+		ProposalQuorum:   400,
+		LockQuorum:       900,
+		Committed:        1200,
+		Endorsements: []*iotexapi.EndorsementLatency{
+			{Endorser: "a", Proposal: 350, Lock: 600, Commit: 1100},
+			{Endorser: "b", Proposal: 400},
+		},
+	}, timelines[1])
+	require.Equal(timelines[:1], r.recent(1))
+
+	// Only the latest rounds are kept
+	for h := uint64(6); h < 6+maxRoundTimelines; h++ {
+		r.startRound(h, 0, "a", start)
+	}
+	timelines = r.recent(0)
+	require.Len(timelines, maxRoundTimelines)
+	require.Equal(uint64(5+maxRoundTimelines), timelines[0].Height)
+	require.Equal(uint64(6), timelines[maxRoundTimelines-1].Height)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// vrfDomain separates the VRF messages from the other messages signed with the BLS keys
+var vrfDomain = []byte("VRF")
+
+// The BLS signature of the producer over the epoch seed and the height is used as the VRF: it is unique to the key and
+// the message, and can be verified with the public key, while nobody else can compute it ahead of the producer. The
+// proof in a block seeds the proposer selection of the next block, so the proposer order can't be precomputed beyond
+// the next block
+
+// vrfMessage returns the message signed by the producer of the block at the height
+func vrfMessage(seed []byte, height uint64) []byte {
+	msg := make([]byte, 0, len(vrfDomain)+len(seed)+8)
+	msg = append(msg, vrfDomain...)
+	msg = append(msg, seed...)
+	return append(msg, byteutil.Uint64ToBytes(height)...)
+}
+
+// proveVRF returns the VRF proof of the producer of the block at the height
+func proveVRF(sk []uint32, seed []byte, height uint64) ([]byte, error) {
+	_, proof, err := crypto.BLS.Sign(sk, vrfMessage(seed, height))
+	if err != nil {
+		return nil, errors.Wrap(err, "error when proving VRF")
+	}
+	return proof, nil
+}
+
+// verifyVRF verifies the VRF proof of the producer of the block at the height
+func verifyVRF(pk []byte, seed []byte, height uint64, proof []byte) error {
+	if pk == nil {
+		return errors.New("no BLS public key of the producer")
+	}
+	return errors.Wrap(crypto.BLS.Verify(pk, vrfMessage(seed, height), proof), "invalid VRF proof")
+}
+
+// vrfOutput returns the random number out of the VRF proof
+func vrfOutput(proof []byte) uint64 {
+	h := hash.Hash256b(proof)
+	return byteutil.BytesToUint64(h[:8])
+}
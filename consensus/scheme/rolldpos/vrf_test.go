@@ -0,0 +1,99 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+)
+
+func TestVRF(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk := crypto.DKG.SkGeneration()
+	pk, err := crypto.BLS.NewPubKey(sk)
+	require.NoError(err)
+	other := crypto.DKG.SkGeneration()
+	otherPK, err := crypto.BLS.NewPubKey(other)
+	require.NoError(err)
+	delegates := []string{testAddrs[0].encodedAddr, testAddrs[1].encodedAddr, testAddrs[2].encodedAddr}
+	epoch := &epochCtx{
+		delegates:  delegates,
+		seed:       crypto.CryptoSeed,
+		blsPubKeys: map[string][]byte{testAddrs[0].encodedAddr: pk},
+	}
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	ctx := &rollDPoSCtx{
+		cfg:       config.RollDPoS{},
+		chain:     chain,
+		epoch:     epoch,
+		blsPriKey: sk,
+		blsKeysByHeightFunc: func(uint64, []string) (map[string][]byte, error) {
+			return epoch.blsPubKeys, nil
+		},
+		vrfHeight: 10,
+	}
+
+	// The proof is unique to the key, the seed and the height
+	proof, err := proveVRF(sk, epoch.seed, 10)
+	require.NoError(err)
+	again, err := proveVRF(sk, epoch.seed, 10)
+	require.NoError(err)
+	require.Equal(proof, again)
+	require.NoError(verifyVRF(pk, epoch.seed, 10, proof))
+	require.Error(verifyVRF(pk, epoch.seed, 11, proof))
+	require.Error(verifyVRF(pk, []byte("seed"), 10, proof))
+	require.Error(verifyVRF(otherPK, epoch.seed, 10, proof))
+	require.Error(verifyVRF(nil, epoch.seed, 10, proof))
+
+	// No proof is put into the blocks below the VRF height
+	p, err := ctx.vrfProof(9)
+	require.NoError(err)
+	require.Nil(p)
+	p, err = ctx.vrfProof(10)
+	require.NoError(err)
+	require.Equal(proof, p)
+
+	newBlock := func(height uint64, proof []byte) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetVersion(block.HeaderVersionV2).
+			SetHeight(height).
+			SetVRFProof(proof).
+			SignAndBuild(testAddrs[0].pubKey, testAddrs[0].priKey)
+		require.NoError(err)
+		return &blk
+	}
+	require.NoError(ctx.verifyVRFProof(epoch, newBlock(10, proof)))
+	otherProof, err := proveVRF(other, epoch.seed, 10)
+	require.NoError(err)
+	require.Error(ctx.verifyVRFProof(epoch, newBlock(10, otherProof)))
+	require.Error(ctx.verifyVRFProof(epoch, newBlock(11, proof)))
+	require.NoError(ctx.verifyVRFProof(epoch, newBlock(9, nil)))
+
+	// The proposers up to the VRF height are rotated by the height, and the later ones are selected with the proof in
+	// the previous block, which isn't known ahead of it
+	proposer, err := ctx.rotatedProposer(epoch, 10, 0)
+	require.NoError(err)
+	require.Equal(delegates[1], proposer)
+	chain.EXPECT().GetBlockByHeight(uint64(10)).Return(newBlock(10, proof), nil).Times(1)
+	proposer, err = ctx.rotatedProposer(epoch, 11, 0)
+	require.NoError(err)
+	require.Equal(delegates[vrfOutput(proof)%3], proposer)
+	chain.EXPECT().GetBlockByHeight(uint64(11)).Return(nil, errors.New("not found")).Times(1)
+	_, err = ctx.rotatedProposer(epoch, 12, 0)
+	require.Error(err)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+// maxWithholdEvidences is the number of the latest evidences kept in memory
+const maxWithholdEvidences = 100
+
+var (
+	lateProposalMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_late_proposal",
+			Help: "Number of the block proposals arriving after the time to accept a block",
+		},
+		[]string{"proposer"},
+	)
+
+	withholdEvidenceMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_consensus_withhold_evidence",
+			Help: "Number of the evidences of a proposer withholding its blocks",
+		},
+		[]string{"proposer"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(lateProposalMtc)
+	prometheus.MustRegister(withholdEvidenceMtc)
+}
+
+// WithholdEvidence is the record of a proposer whose blocks arrive too late to be endorsed too often
+type WithholdEvidence struct {
+	Proposer string
+	// Height is the height of the last late proposal
+	Height uint64
+	// LateProposals is the number of the late proposals among the latest Window proposals of the proposer
+	LateProposals uint
+	Window        uint
+	Timestamp     time.Time
+}
+
+// withholdDetector keeps whether each of the latest proposals of a proposer is late. It has its own lock as the
+// proposals are observed when the consensus messages are received, outside of the consensus FSM
+type withholdDetector struct {
+	mutex     sync.Mutex
+	cfg       config.WithholdDetection
+	history   map[string][]bool
+	lastSeen  map[string]uint64
+	evidences []WithholdEvidence
+}
+
+func newWithholdDetector(cfg config.WithholdDetection) *withholdDetector {
+	return &withholdDetector{
+		cfg:      cfg,
+		history:  make(map[string][]bool),
+		lastSeen: make(map[string]uint64),
+	}
+}
+
+// observe records whether the proposal of the proposer at the height is late, and returns the evidence if the number
+// of the late proposals in the window reaches the limit. Only the first proposal at a height is counted, as the same
+// block may be received from multiple peers
+func (d *withholdDetector) observe(proposer string, height uint64, late bool, now time.Time) *WithholdEvidence {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.lastSeen[proposer] >= height {
+		return nil
+	}
+	d.lastSeen[proposer] = height
+	if late {
+		lateProposalMtc.WithLabelValues(proposer).Inc()
+	}
+	history := append(d.history[proposer], late)
+	if uint(len(history)) > d.cfg.Window {
+		history = history[uint(len(history))-d.cfg.Window:]
+	}
+	d.history[proposer] = history
+	var numLate uint
+	for _, l := range history {
+		if l {
+			numLate++
+		}
+	}
+	if !late || numLate < d.cfg.MaxLateProposals {
+		return nil
+	}
+	evidence := WithholdEvidence{
+		Proposer:      proposer,
+		Height:        height,
+		LateProposals: numLate,
+		Window:        d.cfg.Window,
+		Timestamp:     now,
+	}
+	withholdEvidenceMtc.WithLabelValues(proposer).Inc()
+	d.evidences = append(d.evidences, evidence)
+	if len(d.evidences) > maxWithholdEvidences {
+		d.evidences = d.evidences[len(d.evidences)-maxWithholdEvidences:]
+	}
+	// Start over so that the same late proposals don't make another evidence
+	delete(d.history, proposer)
+	return &evidence
+}
+
+// latestEvidences returns the latest evidences
+func (d *withholdDetector) latestEvidences() []WithholdEvidence {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	evidences := make([]WithholdEvidence, len(d.evidences))
+	copy(evidences, d.evidences)
+	return evidences
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestWithholdDetector(t *testing.T) {
+	require := require.New(t)
+	d := newWithholdDetector(config.WithholdDetection{Window: 4, MaxLateProposals: 2})
+	now := time.Now()
+
+	require.Nil(d.observe("a", 1, true, now))
+	// The same block received again isn't counted
+	require.Nil(d.observe("a", 1, true, now))
+	require.Nil(d.observe("a", 2, false, now))
+	require.Nil(d.observe("b", 3, true, now))
+	evidence := d.observe("a", 4, true, now)
+	require.NotNil(evidence)
+	require.Equal(WithholdEvidence{Proposer: "a", Height: 4, LateProposals: 2, Window: 4, Timestamp: now}, *evidence)
+
+	// The history starts over after an evidence, and the late proposals out of the window are dropped
+	require.Nil(d.observe("a", 5, true, now))
+	for h := uint64(6); h < 10; h++ {
+		require.Nil(d.observe("a", h, false, now))
+	}
+	require.Nil(d.observe("a", 10, true, now))
+	require.NotNil(d.observe("a", 11, true, now))
+	require.Equal(2, len(d.latestEvidences()))
+}
+
+func TestRollDPoSCtx_ObserveProposal(t *testing.T) {
+	require := require.New(t)
+	clock := clock.NewMock()
+	ctx := &rollDPoSCtx{
+		cfg: config.RollDPoS{
+			FSM:               consensusfsm.Config{AcceptBlockTTL: 4 * time.Second},
+			WithholdDetection: config.WithholdDetection{Window: 3, MaxLateProposals: 1},
+		},
+		encodedAddr: testAddrs[1].encodedAddr,
+		clock:       clock,
+		withhold:    newWithholdDetector(config.WithholdDetection{Window: 3, MaxLateProposals: 1}),
+	}
+	newBlock := func(height uint64, producer int) *block.Block {
+		return block.NewBlockDeprecated(
+			1,
+			height,
+			hash.Hash256{},
+			testutil.TimestampNowFromClock(clock),
+			testAddrs[producer].pubKey,
+			nil,
+		)
+	}
+
+	// The proposal arriving within the time to accept a block is in time
+	ctx.round = &roundCtx{height: 2, proposer: testAddrs[0].encodedAddr, timestamp: clock.Now()}
+	clock.Add(3 * time.Second)
+	ctx.observeProposal(newBlock(2, 0))
+	require.Empty(ctx.withhold.latestEvidences())
+
+	// The proposal of another proposer or the own one isn't counted
+	ctx.round = &roundCtx{height: 3, proposer: testAddrs[0].encodedAddr, timestamp: clock.Now()}
+	clock.Add(5 * time.Second)
+	ctx.observeProposal(newBlock(3, 2))
+	ctx.observeProposal(newBlock(3, 1))
+	require.Empty(ctx.withhold.latestEvidences())
+
+	ctx.observeProposal(newBlock(3, 0))
+	evidences := ctx.withhold.latestEvidences()
+	require.Equal(1, len(evidences))
+	require.Equal(testAddrs[0].encodedAddr, evidences[0].Proposer)
+	require.Equal(uint64(3), evidences[0].Height)
+}
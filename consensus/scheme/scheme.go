@@ -8,6 +8,7 @@ package scheme
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
@@ -29,6 +30,54 @@ type BroadcastCB func(*block.Block) error
 // Broadcast sends a broadcast message to the whole network
 type Broadcast func(msg proto.Message) error
 
+// ClockSkewed returns whether the local clock is skewed, in which case the node refuses to propose
+type ClockSkewed func() bool
+
+// CatchingUp returns whether the node is far behind the network tip, in which case the node stays in standby rather
+// than taking part in the consensus
+type CatchingUp func() bool
+
+// SyncToHeight asks the block sync to sync up to the height, which the consensus observes far above the local tip
+type SyncToHeight func(height uint64)
+
+// HasPendingActions returns whether there are actions ready to be packed into a block
+type HasPendingActions func() bool
+
+// EmptyBlockDue returns whether a block without actions should be minted as a heartbeat, while the empty blocks are
+// skipped otherwise
+type EmptyBlockDue func() bool
+
+// CommitThreshold is the fraction of the delegates, more than which have to endorse a block for it to be committed
+type CommitThreshold struct {
+	Numerator   uint64
+	Denominator uint64
+}
+
+// DefaultCommitThreshold is the 2/3 threshold, which tolerates less than 1/3 of the delegates being byzantine
+var DefaultCommitThreshold = CommitThreshold{Numerator: 2, Denominator: 3}
+
+// NewCommitThreshold creates a commit threshold, which has to be in [2/3, 1), so that any two quorums overlap in more
+// than 1/3 of the delegates, and no two blocks are committed at the same height unless more than 1/3 of the delegates
+// equivocate. A zero denominator means the default threshold
+func NewCommitThreshold(numerator, denominator uint64) (CommitThreshold, error) {
+	if denominator == 0 {
+		return DefaultCommitThreshold, nil
+	}
+	if numerator >= denominator || 3*numerator < 2*denominator {
+		return CommitThreshold{}, errors.Errorf(
+			"commit threshold %d/%d is out of [2/3, 1)",
+			numerator,
+			denominator,
+		)
+	}
+	return CommitThreshold{Numerator: numerator, Denominator: denominator}, nil
+}
+
+// Reached returns whether the endorsements from the delegates reach the threshold
+func (t CommitThreshold) Reached(numEndorsements, numDelegates int) bool {
+	return uint64(numEndorsements)*t.Denominator > uint64(numDelegates)*t.Numerator
+}
+
 // Scheme is the interface that consensus schemes should implement
 type Scheme interface {
 	lifecycle.StartStopper
@@ -36,6 +85,9 @@ type Scheme interface {
 	HandleConsensusMsg(msg *iotexrpc.Consensus) error
 	Calibrate(uint64)
 	ValidateBlockFooter(*block.Block) error
+	// PreferBlock tells whether the competing block is preferred over the block committed at the same height, in which
+	// case the chain should be reorganized onto the branch of the competing block. A finalized block is never replaced
+	PreferBlock(committed, competing *block.Block) bool
 	Metrics() (ConsensusMetrics, error)
 }
 
@@ -46,4 +98,11 @@ type ConsensusMetrics struct {
 	LatestDelegates     []string
 	LatestBlockProducer string
 	Candidates          []string
+	// The view change counters below are accumulated since the node started. A growing number of round timeouts
+	// against few proposals received indicates the consensus is losing liveness
+	ProposalsReceived    uint64
+	ProposalEndorsements uint64
+	LockEndorsements     uint64
+	RoundTimeouts        uint64
+	EpochTransitions     uint64
 }
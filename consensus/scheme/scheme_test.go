@@ -0,0 +1,38 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitThreshold(t *testing.T) {
+	require := require.New(t)
+
+	threshold, err := NewCommitThreshold(0, 0)
+	require.NoError(err)
+	require.Equal(DefaultCommitThreshold, threshold)
+	require.False(threshold.Reached(14, 21))
+	require.True(threshold.Reached(15, 21))
+
+	threshold, err = NewCommitThreshold(3, 4)
+	require.NoError(err)
+	require.False(threshold.Reached(15, 20))
+	require.True(threshold.Reached(16, 20))
+
+	// The threshold has to be in [2/3, 1)
+	_, err = NewCommitThreshold(1, 2)
+	require.Error(err)
+	_, err = NewCommitThreshold(13, 20)
+	require.Error(err)
+	_, err = NewCommitThreshold(3, 3)
+	require.Error(err)
+	_, err = NewCommitThreshold(4, 3)
+	require.Error(err)
+}
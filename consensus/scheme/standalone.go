@@ -20,25 +20,47 @@ import (
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 )
 
+// InstantSealInterval is the interval to check for pending actions when the standalone scheme seals blocks instantly
+const InstantSealInterval = 100 * time.Millisecond
+
 // Standalone is the consensus scheme that periodically create blocks
 type Standalone struct {
 	task *routine.RecurringTask
 }
 
 type standaloneHandler struct {
-	bc       blockchain.Blockchain
-	createCb CreateBlockCB
-	commitCb ConsensusDoneCB
-	pubCb    BroadcastCB
+	bc                blockchain.Blockchain
+	createCb          CreateBlockCB
+	commitCb          ConsensusDoneCB
+	pubCb             BroadcastCB
+	hasPendingActions HasPendingActions
+}
+
+// StandaloneOption sets Standalone construction parameter
+type StandaloneOption func(*standaloneHandler)
+
+// WithInstantSeal is an option to create a block only when there are pending actions, which is meant for development
+// so that the actions are committed as soon as they arrive
+func WithInstantSeal(hasPendingActions HasPendingActions) StandaloneOption {
+	return func(h *standaloneHandler) {
+		h.hasPendingActions = hasPendingActions
+	}
 }
 
 func (s *standaloneHandler) Run() {
-	log.L().Info("Created a new block.", zap.String("at", time.Now().String()))
+	if s.hasPendingActions != nil && !s.hasPendingActions() {
+		return
+	}
 	blk, err := s.createCb()
 	if err != nil {
 		log.L().Error("Failed to create.", zap.Error(err))
 		return
 	}
+	if blk == nil {
+		// the empty block is skipped
+		return
+	}
+	log.L().Info("Created a new block.", zap.String("at", time.Now().String()))
 
 	if err := s.commitCb(blk); err != nil {
 		log.L().Error("Failed to commit.", zap.Error(err))
@@ -51,13 +73,23 @@ func (s *standaloneHandler) Run() {
 }
 
 // NewStandalone creates a Standalone struct.
-func NewStandalone(create CreateBlockCB, commit ConsensusDoneCB, pub BroadcastCB, bc blockchain.Blockchain, interval time.Duration) Scheme {
+func NewStandalone(
+	create CreateBlockCB,
+	commit ConsensusDoneCB,
+	pub BroadcastCB,
+	bc blockchain.Blockchain,
+	interval time.Duration,
+	opts ...StandaloneOption,
+) Scheme {
 	h := &standaloneHandler{
 		bc:       bc,
 		createCb: create,
 		commitCb: commit,
 		pubCb:    pub,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
 	return &Standalone{
 		task: routine.NewRecurringTask(h.Run, interval),
 	}
@@ -88,6 +120,11 @@ func (n *Standalone) ValidateBlockFooter(*block.Block) error {
 	return nil
 }
 
+// PreferBlock always keeps the committed block, as the standalone node is the only producer
+func (n *Standalone) PreferBlock(*block.Block, *block.Block) bool {
+	return false
+}
+
 // Metrics is not implemented for standalone scheme
 func (n *Standalone) Metrics() (ConsensusMetrics, error) {
 	return ConsensusMetrics{}, errors.Wrapf(
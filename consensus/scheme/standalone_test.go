@@ -0,0 +1,50 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+func TestStandaloneInstantSeal(t *testing.T) {
+	require := require.New(t)
+
+	var created, committed, published int
+	create := func() (*block.Block, error) {
+		created++
+		return &block.Block{}, nil
+	}
+	commit := func(*block.Block) error {
+		committed++
+		return nil
+	}
+	pub := func(*block.Block) error {
+		published++
+		return nil
+	}
+
+	// Without instant seal, a block is created every time
+	h := &standaloneHandler{createCb: create, commitCb: commit, pubCb: pub}
+	h.Run()
+	require.Equal(1, created)
+
+	// With instant seal, a block is created only when there are pending actions
+	pending := false
+	h = &standaloneHandler{createCb: create, commitCb: commit, pubCb: pub}
+	WithInstantSeal(func() bool { return pending })(h)
+	h.Run()
+	require.Equal(1, created)
+	pending = true
+	h.Run()
+	require.Equal(2, created)
+	require.Equal(2, committed)
+	require.Equal(2, published)
+}
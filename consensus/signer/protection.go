@@ -0,0 +1,112 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrConflict indicates the message conflicts with a message signed before
+var ErrConflict = errors.New("message conflicts with a signed one")
+
+// signedMessage is the last block or endorsement of a topic signed
+type signedMessage struct {
+	Height uint64 `json:"height"`
+	Round  uint32 `json:"round"`
+	Hash   string `json:"hash"`
+}
+
+// protectedSigner keeps the last block and endorsement of each topic signed in a file, so that it never signs a
+// conflicting one even after a restart
+type protectedSigner struct {
+	Signer
+	path   string
+	mutex  sync.Mutex
+	signed map[string]signedMessage
+}
+
+// NewProtectedSigner creates a signer refusing to sign a block or an endorsement of a topic below the height and round
+// of the last one signed, or a different one at the same height and round, so that the delegate never double signs.
+// The last messages signed are kept in the file of path, which is written before a signature is returned
+func NewProtectedSigner(s Signer, path string) (Signer, error) {
+	if path == "" {
+		return nil, errors.New("empty path of the signed messages")
+	}
+	ps := &protectedSigner{Signer: s, path: path, signed: make(map[string]signedMessage)}
+	data, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to read signed messages %s", path)
+	default:
+		if err := json.Unmarshal(data, &ps.signed); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse signed messages %s", path)
+		}
+	}
+	return ps, nil
+}
+
+// Sign signs the message unless it conflicts with the last one of its kind and topic signed
+func (s *protectedSigner) Sign(msg Message) ([]byte, error) {
+	switch msg.Kind {
+	case KindAction:
+		// The actions are protected from replay by their nonce
+		return s.Signer.Sign(msg)
+	case KindBlock, KindEndorsement:
+	default:
+		return nil, errors.Errorf("unknown kind %d of message", msg.Kind)
+	}
+	key := fmt.Sprintf("%d-%d", msg.Kind, msg.Topic)
+	h := hex.EncodeToString(msg.Hash)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if last, ok := s.signed[key]; ok {
+		if msg.Height < last.Height || msg.Height == last.Height && msg.Round < last.Round {
+			return nil, errors.Wrapf(ErrConflict, "height %d round %d is below the last one signed", msg.Height, msg.Round)
+		}
+		if msg.Height == last.Height && msg.Round == last.Round {
+			if h != last.Hash {
+				return nil, errors.Wrapf(ErrConflict, "another hash has been signed at height %d round %d", msg.Height, msg.Round)
+			}
+			return s.Signer.Sign(msg)
+		}
+	}
+	prev, existed := s.signed[key]
+	s.signed[key] = signedMessage{Height: msg.Height, Round: msg.Round, Hash: h}
+	if err := s.persist(); err != nil {
+		if existed {
+			s.signed[key] = prev
+		} else {
+			delete(s.signed, key)
+		}
+		return nil, err
+	}
+	return s.Signer.Sign(msg)
+}
+
+// persist writes the last messages signed into a temporary file, which then replaces the file atomically
+func (s *protectedSigner) persist() error {
+	data, err := json.Marshal(s.signed)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write signed messages %s", tmp)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return errors.Wrapf(err, "failed to replace signed messages %s", s.path)
+	}
+	return nil
+}
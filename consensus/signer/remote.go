@@ -0,0 +1,110 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/iotexproject/iotex-core/consensus/signer/signerpb"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+// RemoteSigner signs with the private key kept in a remote signer, e.g., an HSM or an isolated signing box, which
+// serves the signer service over gRPC
+type RemoteSigner struct {
+	conn    *grpc.ClientConn
+	client  signerpb.SignerServiceClient
+	pubKey  keypair.PublicKey
+	timeout time.Duration
+}
+
+// tokenAuth authenticates the calls to the remote signer with a token, which is only sent over TLS
+type tokenAuth string
+
+// GetRequestMetadata returns the token as the metadata of a call
+func (t tokenAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authHeader: "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity requires TLS to send the token
+func (t tokenAuth) RequireTransportSecurity() bool {
+	return true
+}
+
+// NewRemoteSigner connects to the remote signer at the endpoint over TLS with the credentials, authenticates with the
+// token, and fetches the public key of the delegate. Each call to the remote signer times out after the timeout
+func NewRemoteSigner(
+	endpoint string,
+	timeout time.Duration,
+	creds credentials.TransportCredentials,
+	token string,
+) (*RemoteSigner, error) {
+	if creds == nil || token == "" {
+		return nil, errors.New("remote signer requires TLS credentials and an auth token")
+	}
+	conn, err := grpc.Dial(
+		endpoint,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(tokenAuth(token)),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial remote signer %s", endpoint)
+	}
+	s := &RemoteSigner{
+		conn:    conn,
+		client:  signerpb.NewSignerServiceClient(conn),
+		timeout: timeout,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, err := s.client.GetPublicKey(ctx, &signerpb.GetPublicKeyRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "failed to get public key from remote signer %s", endpoint)
+	}
+	if s.pubKey, err = keypair.BytesToPublicKey(res.PublicKey); err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "invalid public key from remote signer %s", endpoint)
+	}
+	return s, nil
+}
+
+// PublicKey returns the public key of the delegate
+func (s *RemoteSigner) PublicKey() keypair.PublicKey {
+	return s.pubKey
+}
+
+// Sign requests the remote signer to sign the message. The signature is verified against the public key, so that a
+// misconfigured remote signer is caught before the signature gets out
+func (s *RemoteSigner) Sign(msg Message) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	res, err := s.client.Sign(ctx, &signerpb.SignRequest{
+		Hash:   msg.Hash,
+		Kind:   msg.Kind,
+		Height: msg.Height,
+		Round:  msg.Round,
+		Topic:  msg.Topic,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign with remote signer")
+	}
+	if err := verify(s.pubKey, msg.Hash, res.Signature); err != nil {
+		return nil, errors.Wrap(err, "invalid signature from remote signer")
+	}
+	return res.Signature, nil
+}
+
+// Close closes the connection to the remote signer
+func (s *RemoteSigner) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/consensus/signer/signerpb"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+// authHeader is the metadata carrying the token the remote signers authenticate with
+const authHeader = "authorization"
+
+// Service serves the signer service with a signer, e.g., on an isolated signing box with a local signer, to which the
+// delegate node connects by a remote signer
+type Service struct {
+	signer Signer
+	token  string
+}
+
+// NewService creates a signer service signing with the signer, which keeps the messages signed in the file of path to
+// never double sign, and only serves the clients authenticated with the token
+func NewService(s Signer, path string, token string) (*Service, error) {
+	if token == "" {
+		return nil, errors.New("empty auth token of signer service")
+	}
+	ps, err := NewProtectedSigner(s, path)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{signer: ps, token: token}, nil
+}
+
+// NewServer creates a gRPC server serving the signer service over TLS with the certificate and the key files
+func NewServer(s *Service, certFile string, keyFile string) (*grpc.Server, error) {
+	creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the TLS certificate of signer service")
+	}
+	server := grpc.NewServer(grpc.Creds(creds))
+	signerpb.RegisterSignerServiceServer(server, s)
+	return server, nil
+}
+
+// GetPublicKey returns the public key of the signer
+func (s *Service) GetPublicKey(
+	ctx context.Context,
+	_ *signerpb.GetPublicKeyRequest,
+) (*signerpb.GetPublicKeyResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return &signerpb.GetPublicKeyResponse{PublicKey: keypair.PublicKeyToBytes(s.signer.PublicKey())}, nil
+}
+
+// Sign signs the hash of the message, which has to be a 32-byte hash
+func (s *Service) Sign(ctx context.Context, in *signerpb.SignRequest) (*signerpb.SignResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if len(in.Hash) != len(hash.ZeroHash256) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid length of hash %d", len(in.Hash))
+	}
+	sig, err := s.signer.Sign(Message{
+		Kind:   in.Kind,
+		Height: in.Height,
+		Round:  in.Round,
+		Topic:  in.Topic,
+		Hash:   in.Hash,
+	})
+	if errors.Cause(err) == ErrConflict {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.SignResponse{Signature: sig}, nil
+}
+
+// authenticate checks the request carries the token
+func (s *Service) authenticate(ctx context.Context) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing auth token")
+	}
+	for _, v := range md.Get(authHeader) {
+		if subtle.ConstantTimeCompare([]byte(v), []byte("Bearer "+s.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid auth token")
+}
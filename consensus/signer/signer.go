@@ -0,0 +1,88 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"github.com/iotexproject/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+// The kinds of the messages signed
+const (
+	// KindAction is an action put into a block by the delegate, e.g., a grant reward, a double sign evidence or a DKG
+	// message
+	KindAction uint32 = iota
+	// KindBlock is a block proposed by the delegate
+	KindBlock
+	// KindEndorsement is an endorsement of the delegate
+	KindEndorsement
+)
+
+// Message is a message to sign, whose kind, height, round and topic type the hash, so that the signer never signs
+// two blocks or two endorsements of a topic in a round
+type Message struct {
+	Kind   uint32
+	Height uint64
+	Round  uint32
+	// Topic is the topic of an endorsement
+	Topic uint32
+	Hash  []byte
+}
+
+// Signer signs the consensus messages of a delegate, i.e., the proposed blocks, the endorsements and the actions put
+// into the blocks by the delegate, so that the private key doesn't have to be kept in the node process
+type Signer interface {
+	// PublicKey returns the public key of the delegate
+	PublicKey() keypair.PublicKey
+	// Sign signs the hash of the message with the private key of the delegate
+	Sign(msg Message) ([]byte, error)
+}
+
+// SignFunc returns the function signing the hashes of the messages of the kind, height, round and topic with the
+// signer
+func SignFunc(s Signer, kind uint32, height uint64, round uint32, topic uint32) func([]byte) ([]byte, error) {
+	return func(h []byte) ([]byte, error) {
+		return s.Sign(Message{Kind: kind, Height: height, Round: round, Topic: topic, Hash: h})
+	}
+}
+
+type localSigner struct {
+	sk keypair.PrivateKey
+}
+
+// NewLocalSigner creates a signer with the private key kept in the node process
+func NewLocalSigner(sk keypair.PrivateKey) Signer {
+	return &localSigner{sk: sk}
+}
+
+// PublicKey returns the public key of the private key
+func (s *localSigner) PublicKey() keypair.PublicKey {
+	return &s.sk.PublicKey
+}
+
+// Sign signs the hash of the message with the private key
+func (s *localSigner) Sign(msg Message) ([]byte, error) {
+	return crypto.Sign(msg.Hash, s.sk)
+}
+
+// verify verifies the signature of the hash against the public key
+func verify(pk keypair.PublicKey, h []byte, sig []byte) error {
+	if len(h) != len(hash.ZeroHash256) {
+		return errors.Errorf("invalid length of hash %d", len(h))
+	}
+	if len(sig) != action.SignatureLength {
+		return errors.Errorf("invalid length of signature %d", len(sig))
+	}
+	if !crypto.VerifySignature(keypair.PublicKeyToBytes(pk), h, sig[:action.SignatureLength-1]) {
+		return errors.Errorf("signature %x doesn't match the public key", sig)
+	}
+	return nil
+}
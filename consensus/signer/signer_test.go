@@ -0,0 +1,170 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestLocalSigner(t *testing.T) {
+	require := require.New(t)
+
+	sk := testaddress.Keyinfo["producer"].PriKey
+	s := NewLocalSigner(sk)
+	require.Equal(keypair.PublicKeyToBytes(&sk.PublicKey), keypair.PublicKeyToBytes(s.PublicKey()))
+
+	h := hash.Hash256b([]byte("block"))
+	sig, err := SignFunc(s, KindBlock, 1, 0, 0)(h[:])
+	require.NoError(err)
+	require.NoError(verify(s.PublicKey(), h[:], sig))
+	require.Error(verify(testaddress.Keyinfo["alfa"].PubKey, h[:], sig))
+	require.Error(verify(s.PublicKey(), h[:16], sig))
+	require.Error(verify(s.PublicKey(), h[:], sig[:32]))
+}
+
+func TestProtectedSigner(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "signer")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "signed.json")
+	_, err = NewProtectedSigner(NewLocalSigner(testaddress.Keyinfo["producer"].PriKey), "")
+	require.Error(err)
+	s, err := NewProtectedSigner(NewLocalSigner(testaddress.Keyinfo["producer"].PriKey), path)
+	require.NoError(err)
+
+	h1 := hash.Hash256b([]byte("block1"))
+	h2 := hash.Hash256b([]byte("block2"))
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 5, Round: 1, Hash: h1[:]})
+	require.NoError(err)
+	// The same message is signed again, while a different one in the round or an earlier round is refused
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 5, Round: 1, Hash: h1[:]})
+	require.NoError(err)
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 5, Round: 1, Hash: h2[:]})
+	require.Equal(ErrConflict, errors.Cause(err))
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 5, Round: 0, Hash: h2[:]})
+	require.Equal(ErrConflict, errors.Cause(err))
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 5, Round: 2, Hash: h2[:]})
+	require.NoError(err)
+	// The endorsements of each topic are protected apart from the blocks
+	_, err = s.Sign(Message{Kind: KindEndorsement, Height: 5, Round: 1, Topic: 1, Hash: h1[:]})
+	require.NoError(err)
+	_, err = s.Sign(Message{Kind: KindEndorsement, Height: 5, Round: 1, Topic: 2, Hash: h2[:]})
+	require.NoError(err)
+	_, err = s.Sign(Message{Kind: KindEndorsement, Height: 5, Round: 1, Topic: 1, Hash: h2[:]})
+	require.Equal(ErrConflict, errors.Cause(err))
+	// The actions aren't protected, while the unknown kinds are refused
+	_, err = s.Sign(Message{Kind: KindAction, Height: 1, Hash: h1[:]})
+	require.NoError(err)
+	_, err = s.Sign(Message{Kind: 9, Height: 6, Hash: h1[:]})
+	require.Error(err)
+
+	// The messages signed are kept after a restart
+	s, err = NewProtectedSigner(NewLocalSigner(testaddress.Keyinfo["producer"].PriKey), path)
+	require.NoError(err)
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 5, Round: 2, Hash: h1[:]})
+	require.Equal(ErrConflict, errors.Cause(err))
+	_, err = s.Sign(Message{Kind: KindEndorsement, Height: 5, Round: 1, Topic: 2, Hash: h1[:]})
+	require.Equal(ErrConflict, errors.Cause(err))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "signer")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeTestCert(t, dir)
+
+	sk := testaddress.Keyinfo["producer"].PriKey
+	_, err = NewService(NewLocalSigner(sk), filepath.Join(dir, "signed.json"), "")
+	require.Error(err)
+	svc, err := NewService(NewLocalSigner(sk), filepath.Join(dir, "signed.json"), "token")
+	require.NoError(err)
+	server, err := NewServer(svc, certFile, keyFile)
+	require.NoError(err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	go server.Serve(lis)
+	defer server.Stop()
+	creds, err := credentials.NewClientTLSFromFile(certFile, "")
+	require.NoError(err)
+
+	// The remote signer requires TLS and a valid token
+	_, err = NewRemoteSigner(lis.Addr().String(), time.Second, nil, "token")
+	require.Error(err)
+	_, err = NewRemoteSigner(lis.Addr().String(), time.Second, creds, "invalid")
+	require.Error(err)
+
+	s, err := NewRemoteSigner(lis.Addr().String(), time.Second, creds, "token")
+	require.NoError(err)
+	defer func() { require.NoError(s.Close()) }()
+	require.Equal(keypair.PublicKeyToBytes(&sk.PublicKey), keypair.PublicKeyToBytes(s.PublicKey()))
+
+	h := hash.Hash256b([]byte("block"))
+	sig, err := s.Sign(Message{Kind: KindBlock, Height: 5, Round: 1, Hash: h[:]})
+	require.NoError(err)
+	require.NoError(verify(&sk.PublicKey, h[:], sig))
+
+	// The signer service never signs a conflicting block
+	h2 := hash.Hash256b([]byte("block2"))
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 5, Round: 1, Hash: h2[:]})
+	require.Error(err)
+
+	// The signer service only signs 32-byte hashes
+	_, err = s.Sign(Message{Kind: KindBlock, Height: 6, Hash: []byte("block")})
+	require.Error(err)
+}
+
+// writeTestCert writes a self-signed TLS certificate of 127.0.0.1 and its key into the directory
+func writeTestCert(t *testing.T, dir string) (string, string) {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "signer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+	certFile := filepath.Join(dir, "signer.crt")
+	keyFile := filepath.Join(dir, "signer.key")
+	require.NoError(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
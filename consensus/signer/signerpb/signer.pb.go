@@ -0,0 +1,339 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: signer.proto
+
+package signerpb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type GetPublicKeyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyRequest) Reset()         { *m = GetPublicKeyRequest{} }
+func (m *GetPublicKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyRequest) ProtoMessage()    {}
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_signer_3f4b2c1d7e9a8b60, []int{0}
+}
+func (m *GetPublicKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyRequest.Unmarshal(m, b)
+}
+func (m *GetPublicKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetPublicKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyRequest.Merge(dst, src)
+}
+func (m *GetPublicKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyRequest.Size(m)
+}
+func (m *GetPublicKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyRequest proto.InternalMessageInfo
+
+type GetPublicKeyResponse struct {
+	PublicKey            []byte   `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyResponse) Reset()         { *m = GetPublicKeyResponse{} }
+func (m *GetPublicKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyResponse) ProtoMessage()    {}
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_signer_3f4b2c1d7e9a8b60, []int{1}
+}
+func (m *GetPublicKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyResponse.Unmarshal(m, b)
+}
+func (m *GetPublicKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetPublicKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyResponse.Merge(dst, src)
+}
+func (m *GetPublicKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyResponse.Size(m)
+}
+func (m *GetPublicKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyResponse proto.InternalMessageInfo
+
+func (m *GetPublicKeyResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+// The kind, height, round and topic type the message signed, so that the signer never signs two blocks or two
+// endorsements of a topic in a round
+type SignRequest struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// 0 is an action, 1 is a block and 2 is an endorsement
+	Kind   uint32 `protobuf:"varint,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Round  uint32 `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	// the topic of an endorsement
+	Topic                uint32   `protobuf:"varint,5,opt,name=topic,proto3" json:"topic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRequest) Reset()         { *m = SignRequest{} }
+func (m *SignRequest) String() string { return proto.CompactTextString(m) }
+func (*SignRequest) ProtoMessage()    {}
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_signer_3f4b2c1d7e9a8b60, []int{2}
+}
+func (m *SignRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRequest.Unmarshal(m, b)
+}
+func (m *SignRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRequest.Marshal(b, m, deterministic)
+}
+func (dst *SignRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRequest.Merge(dst, src)
+}
+func (m *SignRequest) XXX_Size() int {
+	return xxx_messageInfo_SignRequest.Size(m)
+}
+func (m *SignRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRequest proto.InternalMessageInfo
+
+func (m *SignRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *SignRequest) GetKind() uint32 {
+	if m != nil {
+		return m.Kind
+	}
+	return 0
+}
+
+func (m *SignRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SignRequest) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *SignRequest) GetTopic() uint32 {
+	if m != nil {
+		return m.Topic
+	}
+	return 0
+}
+
+type SignResponse struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignResponse) Reset()         { *m = SignResponse{} }
+func (m *SignResponse) String() string { return proto.CompactTextString(m) }
+func (*SignResponse) ProtoMessage()    {}
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_signer_3f4b2c1d7e9a8b60, []int{3}
+}
+func (m *SignResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignResponse.Unmarshal(m, b)
+}
+func (m *SignResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignResponse.Marshal(b, m, deterministic)
+}
+func (dst *SignResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignResponse.Merge(dst, src)
+}
+func (m *SignResponse) XXX_Size() int {
+	return xxx_messageInfo_SignResponse.Size(m)
+}
+func (m *SignResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignResponse proto.InternalMessageInfo
+
+func (m *SignResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetPublicKeyRequest)(nil), "signerpb.GetPublicKeyRequest")
+	proto.RegisterType((*GetPublicKeyResponse)(nil), "signerpb.GetPublicKeyResponse")
+	proto.RegisterType((*SignRequest)(nil), "signerpb.SignRequest")
+	proto.RegisterType((*SignResponse)(nil), "signerpb.SignResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SignerServiceClient is the client API for SignerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SignerServiceClient interface {
+	// get the public key of the delegate
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	// sign the hash of a block, an endorsement or an action
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+}
+
+type signerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerServiceClient(cc *grpc.ClientConn) SignerServiceClient {
+	return &signerServiceClient{cc}
+}
+
+func (c *signerServiceClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/signerpb.SignerService/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerServiceClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, "/signerpb.SignerService/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServiceServer is the server API for SignerService service.
+type SignerServiceServer interface {
+	// get the public key of the delegate
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	// sign the hash of a block, an endorsement or an action
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+}
+
+func RegisterSignerServiceServer(s *grpc.Server, srv SignerServiceServer) {
+	s.RegisterService(&_SignerService_serviceDesc, srv)
+}
+
+func _SignerService_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signerpb.SignerService/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SignerService_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signerpb.SignerService/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SignerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "signerpb.SignerService",
+	HandlerType: (*SignerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _SignerService_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _SignerService_Sign_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signer.proto",
+}
+
+func init() { proto.RegisterFile("signer.proto", fileDescriptor_signer_3f4b2c1d7e9a8b60) }
+
+var fileDescriptor_signer_3f4b2c1d7e9a8b60 = []byte{
+	// 195 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0xce, 0x4c, 0xcf,
+	0x4b, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x80, 0xf0, 0x0a, 0x92, 0x94, 0x44,
+	0xb9, 0x84, 0xdd, 0x53, 0x4b, 0x02, 0x4a, 0x93, 0x72, 0x32, 0x93, 0xbd, 0x53, 0x2b, 0x83, 0x52,
+	0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x94, 0x4c, 0xb8, 0x44, 0x50, 0x85, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a,
+	0x53, 0x85, 0x64, 0xb8, 0x38, 0x0b, 0x60, 0x82, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x3c, 0x41, 0x08,
+	0x01, 0x25, 0x45, 0x2e, 0xee, 0xe0, 0xcc, 0xf4, 0x3c, 0xa8, 0x21, 0x42, 0x42, 0x5c, 0x2c, 0x19,
+	0x89, 0xc5, 0x19, 0x50, 0x75, 0x60, 0xb6, 0x92, 0x0e, 0x17, 0x0f, 0x44, 0x09, 0xc2, 0x40, 0x90,
+	0x5b, 0x12, 0x4b, 0x4a, 0x8b, 0x52, 0x61, 0x06, 0xc2, 0x05, 0x8c, 0x66, 0x32, 0x72, 0xf1, 0x06,
+	0x83, 0x9d, 0x1a, 0x9c, 0x5a, 0x54, 0x96, 0x99, 0x9c, 0x2a, 0xe4, 0xcf, 0xc5, 0x83, 0xec, 0x30,
+	0x21, 0x59, 0x3d, 0x98, 0x57, 0xf4, 0xb0, 0xf8, 0x43, 0x4a, 0x0e, 0x97, 0x34, 0xc4, 0x7a, 0x25,
+	0x06, 0x21, 0x73, 0x2e, 0x16, 0x90, 0x0d, 0x42, 0xa2, 0x08, 0x95, 0x48, 0x7e, 0x90, 0x12, 0x43,
+	0x17, 0x86, 0x69, 0x4c, 0x62, 0x03, 0x07, 0xa5, 0x31, 0x60, 0x00, 0xa5, 0xdd, 0x85, 0xf5, 0x5a,
+	0x01, 0x00, 0x00,
+}
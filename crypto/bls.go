@@ -31,6 +31,13 @@ const (
 	numnodes    = 21
 )
 
+const (
+	// BLSPublicKeyLength is the length of a serialized BLS public key in bytes
+	BLSPublicKeyLength = 120
+	// BLSSignatureLength is the length of a serialized BLS signature in bytes
+	BLSSignatureLength = 20
+)
+
 var (
 	// BLS represents a bls struct singleton that contains the set of cryptography functions
 	BLS bls
@@ -120,6 +127,30 @@ func (b *bls) PkValidation(pk []byte) error {
 	return ErrInvalidKey
 }
 
+// PrivateKeyFromBytes deserializes the bytes into a private key
+func (b *bls) PrivateKeyFromBytes(data []byte) ([]uint32, error) {
+	if len(data) != privkeySize*4 {
+		return nil, errors.Wrapf(ErrInvalidKey, "private key of %d bytes", len(data))
+	}
+	sk := make([]uint32, privkeySize)
+	if err := binary.Read(bytes.NewReader(data), enc.MachineEndian, sk); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+// PrivateKeyBytes serializes the private key into bytes
+func (b *bls) PrivateKeyBytes(sk []uint32) ([]byte, error) {
+	if len(sk) != privkeySize {
+		return nil, errors.Wrapf(ErrInvalidKey, "private key of %d words", len(sk))
+	}
+	buf := new(bytes.Buffer)
+	if err := binary.Write(buf, enc.MachineEndian, sk); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // SignShare signs the message and returns the signature
 func (b *bls) SignShare(privkey []uint32, msg []byte) (bool, []byte, error) {
 	return b.Sign(privkey, msg)
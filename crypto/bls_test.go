@@ -98,3 +98,25 @@ func TestTBLS(t *testing.T) {
 		require.NoError(err)
 	}
 }
+
+func TestBLSPrivateKeyBytes(t *testing.T) {
+	require := require.New(t)
+	sk := DKG.SkGeneration()
+	data, err := BLS.PrivateKeyBytes(sk)
+	require.NoError(err)
+	decoded, err := BLS.PrivateKeyFromBytes(data)
+	require.NoError(err)
+	require.Equal(sk, decoded)
+	_, err = BLS.PrivateKeyFromBytes(data[1:])
+	require.Error(err)
+	_, err = BLS.PrivateKeyBytes(sk[1:])
+	require.Error(err)
+
+	pk, err := BLS.NewPubKey(decoded)
+	require.NoError(err)
+	require.Len(pk, BLSPublicKeyLength)
+	_, sig, err := BLS.Sign(decoded, []byte("hello iotex message"))
+	require.NoError(err)
+	require.Len(sig, BLSSignatureLength)
+	require.NoError(BLS.Verify(pk, []byte("hello iotex message"), sig))
+}
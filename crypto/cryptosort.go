@@ -16,7 +16,7 @@ import (
 )
 
 var (
-	// CryptoSeed is a hardcoded seed that will be replaced by a seed produced dynamically.
+	// CryptoSeed is the hardcoded seed of the delegate order, which the epoch beacons replace from the DKG fork height
 	CryptoSeed = []byte{0x12, 0x34, 0x56, 0x78, 0x90, 0xab, 0xcd, 0xef}
 )
 
@@ -7,6 +7,7 @@
 package crypto
 
 import (
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotexproject/iotex-core/pkg/hash"
@@ -83,3 +84,44 @@ func (mk *Merkle) HashTree() hash.Hash256 {
 	mk.root = merkle[0]
 	return mk.root
 }
+
+// Proof returns the sibling hashes on the path from the leaf of the given index to the root
+func (mk *Merkle) Proof(index int) ([]hash.Hash256, error) {
+	if index < 0 || index >= mk.size {
+		return nil, errors.Errorf("leaf index %d is out of range [0, %d)", index, mk.size)
+	}
+	var proof []hash.Hash256
+	level := mk.leaf[:mk.size]
+	for len(level) > 1 {
+		// copy the last hash if the number of hashes at this level is odd
+		if len(level)&1 != 0 {
+			level = append(level[:len(level):len(level)], level[len(level)-1])
+		}
+		proof = append(proof, level[index^1])
+		next := make([]hash.Hash256, len(level)>>1)
+		for i := range next {
+			h := level[i<<1][:]
+			h = append(h, level[i<<1+1][:]...)
+			next[i] = blake2b.Sum256(h)
+		}
+		level = next
+		index >>= 1
+	}
+	return proof, nil
+}
+
+// MerkleRootFromProof calculates the root hash of a merkle tree given a leaf, its index and its proof
+func MerkleRootFromProof(leaf hash.Hash256, index uint32, proof []hash.Hash256) hash.Hash256 {
+	root := leaf
+	for _, sibling := range proof {
+		var h []byte
+		if index&1 == 0 {
+			h = append(root[:], sibling[:]...)
+		} else {
+			h = append(sibling[:], root[:]...)
+		}
+		root = blake2b.Sum256(h)
+		index >>= 1
+	}
+	return root
+}
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/pkg/hash"
 )
@@ -69,3 +70,25 @@ func TestMerkleTree(t *testing.T) {
 	assert.Equal(t, 0, bytes.Compare(expected[:], actual5[:]))
 	assert.Equal(t, -1, bytes.Compare(actual5[:], actual4[:]))
 }
+
+func TestMerkleProof(t *testing.T) {
+	require := require.New(t)
+
+	var leaves []hash.Hash256
+	for size := 1; size <= 9; size++ {
+		leaves = append(leaves, hash.Hash256b([]byte{byte(size)}))
+		mk := NewMerkleTree(leaves)
+		root := mk.HashTree()
+		for i, leaf := range leaves {
+			proof, err := mk.Proof(i)
+			require.NoError(err)
+			require.Equal(root, MerkleRootFromProof(leaf, uint32(i), proof))
+			// the proof doesn't work for the other leaves
+			require.NotEqual(root, MerkleRootFromProof(hash.ZeroHash256, uint32(i), proof))
+		}
+		_, err := mk.Proof(size + 1)
+		require.Error(err)
+		_, err = mk.Proof(-1)
+		require.Error(err)
+	}
+}
@@ -7,6 +7,7 @@
 package db
 
 import (
+	"encoding/binary"
 	"sync"
 
 	"github.com/iotexproject/iotex-core/pkg/log"
@@ -102,6 +103,18 @@ const (
 	Delete int32 = 1
 )
 
+// WriteType returns the type of the write operation, Put or Delete
+func (wi *writeInfo) WriteType() int32 { return wi.writeType }
+
+// Namespace returns the namespace of the written record
+func (wi *writeInfo) Namespace() string { return wi.namespace }
+
+// Key returns the key of the written record
+func (wi *writeInfo) Key() []byte { return wi.key }
+
+// Value returns the value of the written record, which is nil for Delete
+func (wi *writeInfo) Value() []byte { return wi.value }
+
 func (wi *writeInfo) serialize() []byte {
 	bytes := make([]byte, 0)
 	bytes = append(bytes, []byte(wi.namespace)...)
@@ -197,6 +210,115 @@ func (b *baseKVStoreBatch) truncate(size int) {
 	b.writeQueue = b.writeQueue[:size]
 }
 
+// MergeBatches merges the entries of the batches in order into a new batch
+func MergeBatches(batches ...KVStoreBatch) KVStoreBatch {
+	merged := &baseKVStoreBatch{}
+	for _, b := range batches {
+		b.Lock()
+		for i := 0; i < b.Size(); i++ {
+			wi, err := b.Entry(i)
+			if err != nil {
+				log.S().Panicf("Batch entry %d doesn't exist", i)
+			}
+			merged.writeQueue = append(merged.writeQueue, *wi)
+		}
+		b.Unlock()
+	}
+	return merged
+}
+
+// UndoBatch returns the batch undoing the writes of the batch onto the KV store, which puts back the records overwritten
+// or deleted by the writes, and deletes the records created by them
+func UndoBatch(kv KVStore, b KVStoreBatch) (KVStoreBatch, error) {
+	type record struct{ namespace, key string }
+	undo := &baseKVStoreBatch{}
+	written := make(map[record]struct{})
+	b.Lock()
+	defer b.Unlock()
+	for i := 0; i < b.Size(); i++ {
+		wi, err := b.Entry(i)
+		if err != nil {
+			return nil, err
+		}
+		r := record{wi.namespace, string(wi.key)}
+		if _, ok := written[r]; ok {
+			continue
+		}
+		written[r] = struct{}{}
+		value, err := kv.Get(wi.namespace, wi.key)
+		switch errors.Cause(err) {
+		case nil:
+			undo.batch(Put, wi.namespace, wi.key, value, "failed to put back key %x", wi.key)
+		case ErrNotExist:
+			undo.batch(Delete, wi.namespace, wi.key, nil, "failed to delete key %x", wi.key)
+		default:
+			return nil, errors.Wrapf(err, "failed to get key %x in namespace %s", wi.key, wi.namespace)
+		}
+	}
+	return undo, nil
+}
+
+// SerializeBatch encodes the writes of the batch, so that the batch could be kept in a KV store
+func SerializeBatch(b KVStoreBatch) []byte {
+	b.Lock()
+	defer b.Unlock()
+	var data []byte
+	appendBytes := func(v []byte) {
+		var l [binary.MaxVarintLen64]byte
+		data = append(data, l[:binary.PutUvarint(l[:], uint64(len(v)))]...)
+		data = append(data, v...)
+	}
+	for i := 0; i < b.Size(); i++ {
+		wi, err := b.Entry(i)
+		if err != nil {
+			log.S().Panicf("Batch entry %d doesn't exist", i)
+		}
+		data = append(data, byte(wi.writeType))
+		appendBytes([]byte(wi.namespace))
+		appendBytes(wi.key)
+		appendBytes(wi.value)
+	}
+	return data
+}
+
+// DeserializeBatch decodes the batch encoded by SerializeBatch
+func DeserializeBatch(data []byte) (KVStoreBatch, error) {
+	b := &baseKVStoreBatch{}
+	nextBytes := func() ([]byte, error) {
+		l, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < l {
+			return nil, errors.New("malformed batch")
+		}
+		v := data[n : n+int(l)]
+		data = data[n+int(l):]
+		return v, nil
+	}
+	for len(data) > 0 {
+		op := int32(data[0])
+		if op != Put && op != Delete {
+			return nil, errors.Errorf("unknown write type %d", op)
+		}
+		data = data[1:]
+		namespace, err := nextBytes()
+		if err != nil {
+			return nil, err
+		}
+		key, err := nextBytes()
+		if err != nil {
+			return nil, err
+		}
+		value, err := nextBytes()
+		if err != nil {
+			return nil, err
+		}
+		if op == Delete {
+			value = nil
+		}
+		b.batch(op, string(namespace), key, value, "failed to write key %x", key)
+	}
+	return b, nil
+}
+
 //======================================
 // CachedBatch implementation
 //======================================
@@ -7,6 +7,7 @@
 package db
 
 import (
+	"context"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -169,3 +170,75 @@ func BenchmarkCachedBatch_Digest(b *testing.B) {
 		require.NotEqual(b, hash.ZeroHash256, h)
 	}
 }
+
+func TestMergeBatches(t *testing.T) {
+	require := require.New(t)
+
+	b1 := NewBatch()
+	b1.Put(bucket1, testK1[0], testV1[0], "")
+	b1.Put(bucket1, testK1[1], testV1[1], "")
+	b2 := NewCachedBatch()
+	b2.Delete(bucket1, testK1[0], "")
+	b2.Put(bucket1, testK2[0], testV2[0], "")
+
+	merged := MergeBatches(b1, b2)
+	require.Equal(4, merged.Size())
+	expected := []struct {
+		key       []byte
+		value     []byte
+		writeType int32
+	}{
+		{testK1[0], testV1[0], Put},
+		{testK1[1], testV1[1], Put},
+		{testK1[0], nil, Delete},
+		{testK2[0], testV2[0], Put},
+	}
+	for i, e := range expected {
+		w, err := merged.Entry(i)
+		require.NoError(err)
+		require.Equal(bucket1, w.namespace)
+		require.Equal(e.key, w.key)
+		require.Equal(e.value, w.value)
+		require.Equal(e.writeType, w.writeType)
+	}
+	// the source batches are left untouched
+	require.Equal(2, b1.Size())
+	require.Equal(2, b2.Size())
+	require.Equal(0, MergeBatches().Size())
+}
+
+func TestUndoBatch(t *testing.T) {
+	require := require.New(t)
+
+	kv := NewMemKVStore()
+	require.NoError(kv.Start(context.Background()))
+	require.NoError(kv.Put(bucket1, testK1[0], testV1[0]))
+	require.NoError(kv.Put(bucket1, testK1[1], testV1[1]))
+
+	b := NewBatch()
+	b.Put(bucket1, testK1[0], testV2[0], "")
+	b.Put(bucket1, testK1[0], testV2[1], "")
+	b.Delete(bucket1, testK1[1], "")
+	b.Put(bucket1, testK2[0], testV2[0], "")
+	undo, err := UndoBatch(kv, b)
+	require.NoError(err)
+	require.Equal(3, undo.Size())
+
+	// The undo batch survives the serialization
+	undo, err = DeserializeBatch(SerializeBatch(undo))
+	require.NoError(err)
+	require.Equal(3, undo.Size())
+	_, err = DeserializeBatch([]byte{byte(Put), 10})
+	require.Error(err)
+
+	require.NoError(kv.Commit(b))
+	require.NoError(kv.Commit(undo))
+	v, err := kv.Get(bucket1, testK1[0])
+	require.NoError(err)
+	require.Equal(testV1[0], v)
+	v, err = kv.Get(bucket1, testK1[1])
+	require.NoError(err)
+	require.Equal(testV1[1], v)
+	_, err = kv.Get(bucket1, testK2[0])
+	require.Equal(ErrNotExist, errors.Cause(err))
+}
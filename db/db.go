@@ -25,6 +25,10 @@ var (
 	ErrAlreadyExist = errors.New("already exist in DB")
 	// ErrIO indicates the generic error of DB I/O operation
 	ErrIO = errors.New("DB I/O operation error")
+	// ErrBackupNotSupported indicates the KV store cannot be backed up while running
+	ErrBackupNotSupported = errors.New("backup isn't supported by the KV store")
+	// ErrCompactNotSupported indicates the KV store cannot be compacted
+	ErrCompactNotSupported = errors.New("compaction isn't supported by the KV store")
 )
 
 // KVStore is the interface of KV store.
@@ -124,6 +128,34 @@ func (m *memKVStore) Commit(b KVStoreBatch) (e error) {
 	return e
 }
 
+// Snapshot is a consistent read-only view of a running KV store, which is copied into a file while the KV store keeps
+// being written. It has to be closed once copied
+type Snapshot interface {
+	// WriteTo writes the copy of the snapshot into the file at the path, which must not exist
+	WriteTo(path string) error
+	// Close releases the snapshot
+	Close() error
+}
+
+// NewSnapshot takes a snapshot of the running KV store
+func NewSnapshot(kv KVStore) (Snapshot, error) {
+	s, ok := kv.(interface{ snapshot() (Snapshot, error) })
+	if !ok {
+		return nil, ErrBackupNotSupported
+	}
+	return s.snapshot()
+}
+
+// Compact shrinks the file of the KV store, if at least half of it is left free by the deleted records. It has to be
+// called before the KV store is in use, and returns whether the KV store is compacted
+func Compact(kv KVStore) (bool, error) {
+	c, ok := kv.(interface{ compact() (bool, error) })
+	if !ok {
+		return false, ErrCompactNotSupported
+	}
+	return c.compact()
+}
+
 // NewOnDiskDB instantiates an on-disk KV store
 func NewOnDiskDB(cfg config.DB) KVStore {
 	if cfg.UseBadgerDB {
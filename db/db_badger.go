@@ -8,6 +8,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/pkg/errors"
@@ -47,6 +48,7 @@ func (b *badgerDB) Stop(_ context.Context) error {
 
 // Put inserts a <key, value> record
 func (b *badgerDB) Put(namespace string, key, value []byte) (err error) {
+	defer func(start time.Time) { observeOp("put", namespace, start, err) }(time.Now())
 	for c := uint8(0); c < b.config.NumRetries; c++ {
 		err = b.db.Update(func(txn *badger.Txn) error {
 			k := append([]byte(namespace), key...)
@@ -65,6 +67,13 @@ func (b *badgerDB) Put(namespace string, key, value []byte) (err error) {
 
 // Get retrieves a record
 func (b *badgerDB) Get(namespace string, key []byte) ([]byte, error) {
+	start := time.Now()
+	value, err := b.get(namespace, key)
+	observeOp("get", namespace, start, err)
+	return value, err
+}
+
+func (b *badgerDB) get(namespace string, key []byte) ([]byte, error) {
 	var value []byte
 	err := b.db.View(func(txn *badger.Txn) error {
 		k := append([]byte(namespace), key...)
@@ -88,6 +97,7 @@ func (b *badgerDB) Get(namespace string, key []byte) ([]byte, error) {
 
 // Delete deletes a record
 func (b *badgerDB) Delete(namespace string, key []byte) (err error) {
+	defer func(start time.Time) { observeOp("delete", namespace, start, err) }(time.Now())
 	for c := uint8(0); c < b.config.NumRetries; c++ {
 		err = b.db.Update(func(txn *badger.Txn) error {
 			k := append([]byte(namespace), key...)
@@ -105,9 +115,11 @@ func (b *badgerDB) Delete(namespace string, key []byte) (err error) {
 
 // Commit commits a batch
 func (b *badgerDB) Commit(batch KVStoreBatch) (err error) {
+	start := time.Now()
 	succeed := true
 	batch.Lock()
 	defer func() {
+		observeCommit(batch, start, err)
 		if succeed {
 			// clear the batch if commit succeeds
 			batch.ClearAndUnlock()
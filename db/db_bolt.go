@@ -8,14 +8,21 @@ package db
 
 import (
 	"context"
+	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	bolt "go.etcd.io/bbolt"
 
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 )
 
-const fileMode = 0600
+const (
+	fileMode = 0600
+	// compactBatchSize is the number of records copied in a transaction when the DB is compacted
+	compactBatchSize = 10000
+)
 
 // boltDB is KVStore implementation based bolt DB
 type boltDB struct {
@@ -46,6 +53,7 @@ func (b *boltDB) Stop(_ context.Context) error {
 
 // Put inserts a <key, value> record
 func (b *boltDB) Put(namespace string, key, value []byte) (err error) {
+	defer func(start time.Time) { observeOp("put", namespace, start, err) }(time.Now())
 	numRetries := b.config.NumRetries
 	for c := uint8(0); c < numRetries; c++ {
 		if err = b.db.Update(func(tx *bolt.Tx) error {
@@ -66,6 +74,13 @@ func (b *boltDB) Put(namespace string, key, value []byte) (err error) {
 
 // Get retrieves a record
 func (b *boltDB) Get(namespace string, key []byte) ([]byte, error) {
+	start := time.Now()
+	value, err := b.get(namespace, key)
+	observeOp("get", namespace, start, err)
+	return value, err
+}
+
+func (b *boltDB) get(namespace string, key []byte) ([]byte, error) {
 	var value []byte
 	err := b.db.View(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(namespace))
@@ -92,6 +107,7 @@ func (b *boltDB) Get(namespace string, key []byte) ([]byte, error) {
 
 // Delete deletes a record
 func (b *boltDB) Delete(namespace string, key []byte) (err error) {
+	defer func(start time.Time) { observeOp("delete", namespace, start, err) }(time.Now())
 	numRetries := b.config.NumRetries
 	for c := uint8(0); c < numRetries; c++ {
 		err = b.db.Update(func(tx *bolt.Tx) error {
@@ -113,9 +129,11 @@ func (b *boltDB) Delete(namespace string, key []byte) (err error) {
 
 // Commit commits a batch
 func (b *boltDB) Commit(batch KVStoreBatch) (err error) {
+	start := time.Now()
 	succeed := true
 	batch.Lock()
 	defer func() {
+		observeCommit(batch, start, err)
 		if succeed {
 			// clear the batch if commit succeeds
 			batch.ClearAndUnlock()
@@ -168,6 +186,119 @@ func (b *boltDB) Commit(batch KVStoreBatch) (err error) {
 // private functions
 //======================================
 
+// snapshot begins a read-only transaction, which sees the DB as of now and doesn't block the writes, though the DB
+// can't grow its memory map until the transaction is closed
+func (b *boltDB) snapshot() (Snapshot, error) {
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return &boltSnapshot{tx: tx}, nil
+}
+
+// boltSnapshot is the snapshot of a bolt DB in a read-only transaction
+type boltSnapshot struct {
+	tx *bolt.Tx
+}
+
+func (s *boltSnapshot) WriteTo(path string) error {
+	if fileutil.FileExists(path) {
+		return errors.Wrapf(ErrAlreadyExist, "backup file %s", path)
+	}
+	if err := s.tx.CopyFile(path, fileMode); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+func (s *boltSnapshot) Close() error {
+	if err := s.tx.Rollback(); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+// compact copies the records into a new file, which leaves the free pages out, and replaces the DB with it, if at least
+// half of the pages are free
+func (b *boltDB) compact() (bool, error) {
+	var size int64
+	if err := b.db.View(func(tx *bolt.Tx) error {
+		size = tx.Size()
+		return nil
+	}); err != nil {
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	// The pages freed by the latest transaction are pending until the next one
+	stats := b.db.Stats()
+	if int64((stats.FreePageN+stats.PendingPageN)*b.db.Info().PageSize)*2 < size {
+		return false, nil
+	}
+	tmpPath := b.path + ".compact"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	dst, err := bolt.Open(tmpPath, fileMode, nil)
+	if err != nil {
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	if err := b.copyTo(dst); err != nil {
+		dst.Close()
+		os.RemoveAll(tmpPath)
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	if err := dst.Close(); err != nil {
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	if err := b.db.Close(); err != nil {
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	if err := os.Rename(tmpPath, b.path); err != nil {
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	if b.db, err = bolt.Open(b.path, fileMode, nil); err != nil {
+		return false, errors.Wrap(ErrIO, err.Error())
+	}
+	return true, nil
+}
+
+// copyTo copies the records of each bucket into the destination DB, in transactions of compactBatchSize records
+func (b *boltDB) copyTo(dst *bolt.DB) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, src *bolt.Bucket) error {
+			var keys, values [][]byte
+			flush := func() error {
+				err := dst.Update(func(dstTx *bolt.Tx) error {
+					bucket, err := dstTx.CreateBucketIfNotExists(name)
+					if err != nil {
+						return err
+					}
+					// The records are put in the order of the keys, so the pages are filled up
+					bucket.FillPercent = 1.0
+					for i := range keys {
+						if err := bucket.Put(keys[i], values[i]); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+				keys, values = keys[:0], values[:0]
+				return err
+			}
+			if err := src.ForEach(func(k, v []byte) error {
+				keys = append(keys, append([]byte{}, k...))
+				values = append(values, append([]byte{}, v...))
+				if len(keys) < compactBatchSize {
+					return nil
+				}
+				return flush()
+			}); err != nil {
+				return err
+			}
+			return flush()
+		})
+	})
+}
+
 // intentionally fail to test DB can successfully rollback
 func (b *boltDB) batchPutForceFail(namespace string, key [][]byte, value [][]byte) error {
 	return b.db.Update(func(tx *bolt.Tx) error {
@@ -10,11 +10,13 @@ import (
 	"context"
 	"io/ioutil"
 	"math/rand"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 func BenchmarkBoltDB_Get(b *testing.B) {
@@ -54,3 +56,57 @@ func BenchmarkBoltDB_Get(b *testing.B) {
 		runBenchmark(b, 100)
 	})
 }
+
+func TestBoltDB_Compact(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	f, err := ioutil.TempFile("", "boltdb")
+	require.NoError(err)
+	path := f.Name()
+	require.NoError(f.Close())
+	defer os.RemoveAll(path)
+	cfg := config.Default.DB
+	cfg.DbPath = path
+	kv := NewOnDiskDB(cfg)
+	require.NoError(kv.Start(ctx))
+	defer func() {
+		require.NoError(kv.Stop(ctx))
+	}()
+
+	value := make([]byte, 1024)
+	batch := NewBatch()
+	for i := 0; i < 1000; i++ {
+		batch.Put("ns", byteutil.Uint64ToBytes(uint64(i)), value, "failed to put %d", i)
+	}
+	require.NoError(kv.Commit(batch))
+	// Nothing to compact
+	compacted, err := Compact(kv)
+	require.NoError(err)
+	require.False(compacted)
+
+	for i := 10; i < 1000; i++ {
+		batch.Delete("ns", byteutil.Uint64ToBytes(uint64(i)), "failed to delete %d", i)
+	}
+	require.NoError(kv.Commit(batch))
+	fi, err := os.Stat(path)
+	require.NoError(err)
+	size := fi.Size()
+	compacted, err = Compact(kv)
+	require.NoError(err)
+	require.True(compacted)
+	fi, err = os.Stat(path)
+	require.NoError(err)
+	require.True(fi.Size() < size)
+	for i := 0; i < 10; i++ {
+		v, err := kv.Get("ns", byteutil.Uint64ToBytes(uint64(i)))
+		require.NoError(err)
+		require.Equal(value, v)
+	}
+	_, err = kv.Get("ns", byteutil.Uint64ToBytes(10))
+	require.Error(err)
+	require.NoError(kv.Put("ns", byteutil.Uint64ToBytes(10), value))
+
+	_, err = Compact(NewMemKVStore())
+	require.Equal(ErrCompactNotSupported, err)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// batchNamespace is the namespace label of the batch commits, which may write into multiple namespaces
+const batchNamespace = "batch"
+
+var (
+	dbLatencyMtc = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "iotex_db_latency",
+			Help:    "Latency of the DB operations in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.00001, 2, 20),
+		},
+		[]string{"operation", "namespace"},
+	)
+
+	dbErrorMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_db_errors",
+			Help: "Failed DB operations",
+		},
+		[]string{"operation", "namespace"},
+	)
+
+	dbBatchEntryMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_db_batch_entries",
+			Help: "Entries written by the batch commits",
+		},
+		[]string{"type", "namespace"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(dbLatencyMtc)
+	prometheus.MustRegister(dbErrorMtc)
+	prometheus.MustRegister(dbBatchEntryMtc)
+}
+
+// observeOp records the latency of the operation on the namespace started at the given time, and counts the error
+// other than the record not existing
+func observeOp(operation string, namespace string, start time.Time, err error) {
+	dbLatencyMtc.WithLabelValues(operation, namespace).Observe(time.Since(start).Seconds())
+	if err != nil && errors.Cause(err) != ErrNotExist {
+		dbErrorMtc.WithLabelValues(operation, namespace).Inc()
+	}
+}
+
+// observeCommit records the commit of the batch started at the given time, and counts the entries of a succeeded
+// commit by namespace. The batch has to be locked
+func observeCommit(batch KVStoreBatch, start time.Time, err error) {
+	observeOp("commit", batchNamespace, start, err)
+	if err != nil {
+		return
+	}
+	for i := 0; i < batch.Size(); i++ {
+		write, err := batch.Entry(i)
+		if err != nil {
+			return
+		}
+		typ := "put"
+		if write.writeType == Delete {
+			typ = "delete"
+		}
+		dbBatchEntryMtc.WithLabelValues(typ, write.namespace).Inc()
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestDB_Metrics(t *testing.T) {
+	require := require.New(t)
+
+	counter := func(c *prometheus.CounterVec, labels ...string) float64 {
+		var metric dto.Metric
+		require.NoError(c.WithLabelValues(labels...).Write(&metric))
+		return metric.GetCounter().GetValue()
+	}
+	samples := func(operation, namespace string) uint64 {
+		var metric dto.Metric
+		h, ok := dbLatencyMtc.WithLabelValues(operation, namespace).(prometheus.Histogram)
+		require.True(ok)
+		require.NoError(h.Write(&metric))
+		return metric.GetHistogram().GetSampleCount()
+	}
+
+	testMetrics := func(kvStore KVStore, t *testing.T) {
+		ctx := context.Background()
+		require.NoError(kvStore.Start(ctx))
+		defer func() { require.NoError(kvStore.Stop(ctx)) }()
+
+		ns := "metrics"
+		puts, gets, deletes := samples("put", ns), samples("get", ns), samples("delete", ns)
+		commits := samples("commit", batchNamespace)
+		putEntries, deleteEntries := counter(dbBatchEntryMtc, "put", ns), counter(dbBatchEntryMtc, "delete", ns)
+
+		require.NoError(kvStore.Put(ns, []byte("k1"), []byte("v1")))
+		_, err := kvStore.Get(ns, []byte("k1"))
+		require.NoError(err)
+		require.NoError(kvStore.Delete(ns, []byte("k1")))
+		batch := NewBatch()
+		batch.Put(ns, []byte("k2"), []byte("v2"), "")
+		batch.Put(ns, []byte("k3"), []byte("v3"), "")
+		batch.Delete(ns, []byte("k2"), "")
+		require.NoError(kvStore.Commit(batch))
+
+		require.Equal(puts+1, samples("put", ns))
+		require.Equal(gets+1, samples("get", ns))
+		require.Equal(deletes+1, samples("delete", ns))
+		require.Equal(commits+1, samples("commit", batchNamespace))
+		require.Equal(putEntries+2, counter(dbBatchEntryMtc, "put", ns))
+		require.Equal(deleteEntries+1, counter(dbBatchEntryMtc, "delete", ns))
+	}
+
+	// A missing record isn't counted as an error
+	getErrors := counter(dbErrorMtc, "get", "metrics")
+	observeOp("get", "metrics", time.Now(), errors.Wrap(ErrNotExist, "key doesn't exist"))
+	require.Equal(getErrors, counter(dbErrorMtc, "get", "metrics"))
+	observeOp("get", "metrics", time.Now(), errors.Wrap(ErrIO, "failed to read"))
+	require.Equal(getErrors+1, counter(dbErrorMtc, "get", "metrics"))
+
+	cfg := config.Default.DB
+	path := "test-metrics.bolt"
+	cfg.DbPath = path
+	t.Run("Bolt DB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testMetrics(NewOnDiskDB(cfg), t)
+	})
+
+	path = "test-metrics.badger"
+	cfg.DbPath = path
+	cfg.UseBadgerDB = true
+	t.Run("Badger DB", func(t *testing.T) {
+		testutil.CleanupPath(t, path)
+		defer testutil.CleanupPath(t, path)
+		testMetrics(NewOnDiskDB(cfg), t)
+	})
+}
@@ -30,11 +30,22 @@ import (
 type Subscriber interface {
 	HandleAction(context.Context, *iotextypes.Action) error
 	HandleBlock(context.Context, *iotextypes.Block) error
-	HandleBlockSync(context.Context, *iotextypes.Block) error
+	HandleBlockSync(context.Context, peerstore.PeerInfo, *iotextypes.Block) error
 	HandleSyncRequest(context.Context, peerstore.PeerInfo, *iotexrpc.BlockSync) error
+	HandleCompactBlock(context.Context, peerstore.PeerInfo, *iotexrpc.CompactBlock) error
+	HandleBlockActionsRequest(context.Context, peerstore.PeerInfo, *iotexrpc.BlockActionsRequest) error
+	HandleBlockActions(context.Context, peerstore.PeerInfo, *iotexrpc.BlockActions) error
+	HandleSnapshotChunkRequest(context.Context, peerstore.PeerInfo, *iotexrpc.SnapshotChunkRequest) error
+	HandleSnapshotChunk(context.Context, peerstore.PeerInfo, *iotexrpc.SnapshotChunk) error
 	HandleConsensusMsg(*iotexrpc.Consensus) error
 }
 
+// BatchActionHandler is implemented by the subscriber which handles a burst of actions at once, which returns the error
+// of each action
+type BatchActionHandler interface {
+	HandleActions(context.Context, []*iotextypes.Action) []error
+}
+
 // Dispatcher is used by peers, handles incoming block and header notifications and relays announcements of new blocks.
 type Dispatcher interface {
 	lifecycle.StartStopper
@@ -67,6 +78,7 @@ type blockMsg struct {
 	chainID uint32
 	block   *iotextypes.Block
 	blkType uint32
+	peer    peerstore.PeerInfo // sender of the sync data
 }
 
 func (m blockMsg) ChainID() uint32 {
@@ -85,6 +97,44 @@ func (m blockSyncMsg) ChainID() uint32 {
 	return m.chainID
 }
 
+// compactBlockMsg packages a proto compact block message.
+type compactBlockMsg struct {
+	ctx     context.Context
+	chainID uint32
+	block   *iotexrpc.CompactBlock
+	peer    peerstore.PeerInfo
+}
+
+func (m compactBlockMsg) ChainID() uint32 {
+	return m.chainID
+}
+
+// blockActionsMsg packages a proto message requesting or responding the actions of a compact block.
+type blockActionsMsg struct {
+	ctx     context.Context
+	chainID uint32
+	req     *iotexrpc.BlockActionsRequest
+	actions *iotexrpc.BlockActions
+	peer    peerstore.PeerInfo
+}
+
+func (m blockActionsMsg) ChainID() uint32 {
+	return m.chainID
+}
+
+// snapshotChunkMsg packages a proto message requesting or responding a chunk of a state snapshot.
+type snapshotChunkMsg struct {
+	ctx     context.Context
+	chainID uint32
+	req     *iotexrpc.SnapshotChunkRequest
+	chunk   *iotexrpc.SnapshotChunk
+	peer    peerstore.PeerInfo
+}
+
+func (m snapshotChunkMsg) ChainID() uint32 {
+	return m.chainID
+}
+
 // actionMsg packages a proto action message.
 type actionMsg struct {
 	ctx     context.Context
@@ -108,15 +158,18 @@ type IotxDispatcher struct {
 
 	subscribers   map[uint32]Subscriber
 	subscribersMU sync.RWMutex
+	// actionBatchSize is the max number of the actions handled at once by a batch action handler
+	actionBatchSize int
 }
 
 // NewDispatcher creates a new Dispatcher
 func NewDispatcher(cfg config.Config) (Dispatcher, error) {
 	d := &IotxDispatcher{
-		eventChan:   make(chan interface{}, cfg.Dispatcher.EventChanSize),
-		eventAudit:  make(map[uint32]int),
-		quit:        make(chan struct{}),
-		subscribers: make(map[uint32]Subscriber),
+		eventChan:       make(chan interface{}, cfg.Dispatcher.EventChanSize),
+		eventAudit:      make(map[uint32]int),
+		quit:            make(chan struct{}),
+		subscribers:     make(map[uint32]Subscriber),
+		actionBatchSize: int(cfg.Dispatcher.ActionBatchSize),
 	}
 	return d, nil
 }
@@ -176,17 +229,16 @@ loop:
 	for {
 		select {
 		case m := <-d.eventChan:
-			switch msg := m.(type) {
-			case *actionMsg:
-				d.handleActionMsg(msg)
-			case *blockMsg:
-				d.handleBlockMsg(msg)
-			case *blockSyncMsg:
-				d.handleBlockSyncMsg(msg)
-
-			default:
-				log.L().Warn("Invalid message type in block handler.", zap.Any("msg", msg))
+			if msg, ok := m.(*actionMsg); ok && d.actionBatchSize > 1 {
+				// Take the following actions in queue as well, and handle the message interrupting them afterwards
+				batch, next := d.drainActionMsgs(msg)
+				d.handleActionMsgs(batch)
+				if next == nil {
+					continue
+				}
+				m = next
 			}
+			d.handleEvent(m)
 
 		case <-d.quit:
 			break loop
@@ -197,6 +249,80 @@ loop:
 	log.L().Info("News handler done.")
 }
 
+func (d *IotxDispatcher) handleEvent(m interface{}) {
+	switch msg := m.(type) {
+	case *actionMsg:
+		d.handleActionMsg(msg)
+	case *blockMsg:
+		d.handleBlockMsg(msg)
+	case *blockSyncMsg:
+		d.handleBlockSyncMsg(msg)
+	case *compactBlockMsg:
+		d.handleCompactBlockMsg(msg)
+	case *blockActionsMsg:
+		d.handleBlockActionsMsg(msg)
+	case *snapshotChunkMsg:
+		d.handleSnapshotChunkMsg(msg)
+
+	default:
+		log.L().Warn("Invalid message type in block handler.", zap.Any("msg", msg))
+	}
+}
+
+// drainActionMsgs takes the action messages following the given one in queue without waiting, until the batch is full
+// or a message of another type is taken, which is returned separately
+func (d *IotxDispatcher) drainActionMsgs(first *actionMsg) ([]*actionMsg, interface{}) {
+	batch := []*actionMsg{first}
+	for len(batch) < d.actionBatchSize {
+		select {
+		case m := <-d.eventChan:
+			msg, ok := m.(*actionMsg)
+			if !ok {
+				return batch, m
+			}
+			batch = append(batch, msg)
+		default:
+			return batch, nil
+		}
+	}
+	return batch, nil
+}
+
+// handleActionMsgs handles the action messages of each chain in batch, if the subscriber is a batch action handler
+func (d *IotxDispatcher) handleActionMsgs(msgs []*actionMsg) {
+	chainIDs := make([]uint32, 0)
+	msgsByChain := make(map[uint32][]*actionMsg)
+	for _, m := range msgs {
+		if _, ok := msgsByChain[m.ChainID()]; !ok {
+			chainIDs = append(chainIDs, m.ChainID())
+		}
+		msgsByChain[m.ChainID()] = append(msgsByChain[m.ChainID()], m)
+	}
+	for _, chainID := range chainIDs {
+		d.subscribersMU.RLock()
+		subscriber, ok := d.subscribers[chainID]
+		d.subscribersMU.RUnlock()
+		handler, isBatch := subscriber.(BatchActionHandler)
+		if !ok || !isBatch {
+			for _, m := range msgsByChain[chainID] {
+				d.handleActionMsg(m)
+			}
+			continue
+		}
+		acts := make([]*iotextypes.Action, 0, len(msgsByChain[chainID]))
+		for _, m := range msgsByChain[chainID] {
+			d.updateEventAudit(protogen.MsgActionType)
+			acts = append(acts, m.action)
+		}
+		for _, err := range handler.HandleActions(msgsByChain[chainID][0].ctx, acts) {
+			if err != nil {
+				requestMtc.WithLabelValues("AddAction", "false").Inc()
+				log.L().Debug("Handle action request error.", zap.Error(err))
+			}
+		}
+	}
+}
+
 // handleActionMsg handles actionMsg from all peers.
 func (d *IotxDispatcher) handleActionMsg(m *actionMsg) {
 	d.updateEventAudit(protogen.MsgActionType)
@@ -222,7 +348,7 @@ func (d *IotxDispatcher) handleBlockMsg(m *blockMsg) {
 			}
 		} else if m.blkType == protogen.MsgBlockSyncDataType {
 			d.updateEventAudit(protogen.MsgBlockSyncDataType)
-			if err := subscriber.HandleBlockSync(m.ctx, m.block); err != nil {
+			if err := subscriber.HandleBlockSync(m.ctx, m.peer, m.block); err != nil {
 				log.L().Error("Fail to sync the block.", zap.Error(err))
 			}
 		}
@@ -249,6 +375,64 @@ func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
 	}
 }
 
+// handleCompactBlockMsg handles compact blocks from peers.
+func (d *IotxDispatcher) handleCompactBlockMsg(m *compactBlockMsg) {
+	d.updateEventAudit(protogen.MsgCompactBlockType)
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
+		if err := subscriber.HandleCompactBlock(m.ctx, m.peer, m.block); err != nil {
+			log.L().Error("Fail to sync the compact block.", zap.Error(err))
+		}
+	} else {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+	}
+}
+
+// handleBlockActionsMsg handles the requests and responses of the actions missing from compact blocks.
+func (d *IotxDispatcher) handleBlockActionsMsg(m *blockActionsMsg) {
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+	subscriber, ok := d.subscribers[m.ChainID()]
+	if !ok {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+		return
+	}
+	if m.req != nil {
+		d.updateEventAudit(protogen.MsgBlockActionsReqType)
+		if err := subscriber.HandleBlockActionsRequest(m.ctx, m.peer, m.req); err != nil {
+			log.L().Error("Failed to handle block actions request.", zap.Error(err))
+		}
+		return
+	}
+	d.updateEventAudit(protogen.MsgBlockActionsType)
+	if err := subscriber.HandleBlockActions(m.ctx, m.peer, m.actions); err != nil {
+		log.L().Error("Fail to handle the block actions.", zap.Error(err))
+	}
+}
+
+// handleSnapshotChunkMsg handles the requests and responses of the chunks of state snapshots.
+func (d *IotxDispatcher) handleSnapshotChunkMsg(m *snapshotChunkMsg) {
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+	subscriber, ok := d.subscribers[m.ChainID()]
+	if !ok {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+		return
+	}
+	if m.req != nil {
+		d.updateEventAudit(protogen.MsgSnapshotChunkReqType)
+		if err := subscriber.HandleSnapshotChunkRequest(m.ctx, m.peer, m.req); err != nil {
+			log.L().Error("Failed to handle snapshot chunk request.", zap.Error(err))
+		}
+		return
+	}
+	d.updateEventAudit(protogen.MsgSnapshotChunkType)
+	if err := subscriber.HandleSnapshotChunk(m.ctx, m.peer, m.chunk); err != nil {
+		log.L().Error("Failed to handle the snapshot chunk.", zap.Error(err))
+	}
+}
+
 // dispatchAction adds the passed action message to the news handling queue.
 func (d *IotxDispatcher) dispatchAction(ctx context.Context, chainID uint32, msg proto.Message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
@@ -288,7 +472,7 @@ func (d *IotxDispatcher) dispatchBlockSyncReq(ctx context.Context, chainID uint3
 }
 
 // dispatchBlockSyncData handles block sync data
-func (d *IotxDispatcher) dispatchBlockSyncData(ctx context.Context, chainID uint32, msg proto.Message) {
+func (d *IotxDispatcher) dispatchBlockSyncData(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		return
 	}
@@ -298,6 +482,72 @@ func (d *IotxDispatcher) dispatchBlockSyncData(ctx context.Context, chainID uint
 		chainID: chainID,
 		block:   data.Block,
 		blkType: protogen.MsgBlockSyncDataType,
+		peer:    peer,
+	})
+}
+
+// dispatchCompactBlock adds the passed compact block to the news handling queue.
+func (d *IotxDispatcher) dispatchCompactBlock(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	d.enqueueEvent(&compactBlockMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		block:   (msg).(*iotexrpc.CompactBlock),
+		peer:    peer,
+	})
+}
+
+// dispatchBlockActionsReq adds the passed block actions request to the news handling queue.
+func (d *IotxDispatcher) dispatchBlockActionsReq(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	d.enqueueEvent(&blockActionsMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		req:     (msg).(*iotexrpc.BlockActionsRequest),
+		peer:    peer,
+	})
+}
+
+// dispatchBlockActions adds the passed block actions to the news handling queue.
+func (d *IotxDispatcher) dispatchBlockActions(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	d.enqueueEvent(&blockActionsMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		actions: (msg).(*iotexrpc.BlockActions),
+		peer:    peer,
+	})
+}
+
+// dispatchSnapshotChunkReq adds the passed snapshot chunk request to the news handling queue.
+func (d *IotxDispatcher) dispatchSnapshotChunkReq(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	d.enqueueEvent(&snapshotChunkMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		req:     (msg).(*iotexrpc.SnapshotChunkRequest),
+		peer:    peer,
+	})
+}
+
+// dispatchSnapshotChunk adds the passed snapshot chunk to the news handling queue.
+func (d *IotxDispatcher) dispatchSnapshotChunk(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	d.enqueueEvent(&snapshotChunkMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		chunk:   (msg).(*iotexrpc.SnapshotChunk),
+		peer:    peer,
 	})
 }
 
@@ -341,7 +591,17 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	case protogen.MsgBlockSyncReqType:
 		d.dispatchBlockSyncReq(ctx, chainID, peer, message)
 	case protogen.MsgBlockSyncDataType:
-		d.dispatchBlockSyncData(ctx, chainID, message)
+		d.dispatchBlockSyncData(ctx, chainID, peer, message)
+	case protogen.MsgCompactBlockType:
+		d.dispatchCompactBlock(ctx, chainID, peer, message)
+	case protogen.MsgBlockActionsReqType:
+		d.dispatchBlockActionsReq(ctx, chainID, peer, message)
+	case protogen.MsgBlockActionsType:
+		d.dispatchBlockActions(ctx, chainID, peer, message)
+	case protogen.MsgSnapshotChunkReqType:
+		d.dispatchSnapshotChunkReq(ctx, chainID, peer, message)
+	case protogen.MsgSnapshotChunkType:
+		d.dispatchSnapshotChunk(ctx, chainID, peer, message)
 	default:
 		log.L().Warn("Unexpected msgType handled by HandleTell.", zap.Uint32("msgType", msgType))
 	}
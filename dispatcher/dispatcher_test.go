@@ -14,8 +14,10 @@ import (
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/protogen"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/protogen/testingpb"
@@ -54,6 +56,11 @@ func setTestCase() []proto.Message {
 		&iotexrpc.BlockSync{},
 		&iotexrpc.BlockContainer{},
 		&iotexrpc.BlockContainer{Block: &iotextypes.Block{}},
+		&iotexrpc.CompactBlock{},
+		&iotexrpc.BlockActionsRequest{},
+		&iotexrpc.BlockActions{},
+		&iotexrpc.SnapshotChunkRequest{},
+		&iotexrpc.SnapshotChunk{},
 		&testingpb.TestPayload{},
 	}
 }
@@ -88,16 +95,93 @@ func TestHandleTell(t *testing.T) {
 	}
 }
 
+func TestHandleActionsInBatch(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Config{
+		Consensus:  config.Consensus{Scheme: config.NOOPScheme},
+		Dispatcher: config.Dispatcher{EventChanSize: 1024, ActionBatchSize: 4},
+	}
+	dp, err := NewDispatcher(cfg)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	s := &batchSubscriber{}
+	d.AddSubscriber(config.Default.Chain.ID, s)
+
+	// The events are put in queue directly, as they are enqueued asynchronously
+	ctx := context.Background()
+	for i := 0; i < 6; i++ {
+		d.eventChan <- &actionMsg{ctx: ctx, chainID: config.Default.Chain.ID, action: &iotextypes.Action{}}
+	}
+	d.eventChan <- &blockMsg{ctx: ctx, chainID: config.Default.Chain.ID, block: &iotextypes.Block{}}
+	d.eventChan <- &actionMsg{ctx: ctx, chainID: config.Default.Chain.ID, action: &iotextypes.Action{}}
+
+	// The actions are handled in batches of the given size, and the block interrupting them is handled in order
+	batch, next := d.drainActionMsgs((<-d.eventChan).(*actionMsg))
+	require.Equal(4, len(batch))
+	require.Nil(next)
+	d.handleActionMsgs(batch)
+	batch, next = d.drainActionMsgs((<-d.eventChan).(*actionMsg))
+	require.Equal(2, len(batch))
+	require.IsType(&blockMsg{}, next)
+	d.handleActionMsgs(batch)
+	batch, next = d.drainActionMsgs((<-d.eventChan).(*actionMsg))
+	require.Equal(1, len(batch))
+	require.Nil(next)
+	d.handleActionMsgs(batch)
+	require.Equal([]int{4, 2, 1}, s.batches)
+	require.Equal(7, d.EventAudit()[protogen.MsgActionType])
+}
+
+type batchSubscriber struct {
+	DummySubscriber
+	batches []int
+}
+
+func (s *batchSubscriber) HandleActions(_ context.Context, acts []*iotextypes.Action) []error {
+	s.batches = append(s.batches, len(acts))
+	return make([]error, len(acts))
+}
+
 type DummySubscriber struct{}
 
 func (s *DummySubscriber) HandleBlock(context.Context, *iotextypes.Block) error { return nil }
 
-func (s *DummySubscriber) HandleBlockSync(context.Context, *iotextypes.Block) error { return nil }
+func (s *DummySubscriber) HandleBlockSync(context.Context, peerstore.PeerInfo, *iotextypes.Block) error {
+	return nil
+}
 
 func (s *DummySubscriber) HandleSyncRequest(context.Context, peerstore.PeerInfo, *iotexrpc.BlockSync) error {
 	return nil
 }
 
+func (s *DummySubscriber) HandleCompactBlock(context.Context, peerstore.PeerInfo, *iotexrpc.CompactBlock) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleBlockActionsRequest(
+	context.Context,
+	peerstore.PeerInfo,
+	*iotexrpc.BlockActionsRequest,
+) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleBlockActions(context.Context, peerstore.PeerInfo, *iotexrpc.BlockActions) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleSnapshotChunkRequest(
+	context.Context,
+	peerstore.PeerInfo,
+	*iotexrpc.SnapshotChunkRequest,
+) error {
+	return nil
+}
+
+func (s *DummySubscriber) HandleSnapshotChunk(context.Context, peerstore.PeerInfo, *iotexrpc.SnapshotChunk) error {
+	return nil
+}
+
 func (s *DummySubscriber) HandleAction(context.Context, *iotextypes.Action) error { return nil }
 
 func (s *DummySubscriber) HandleConsensusMsg(*iotexrpc.Consensus) error { return nil }
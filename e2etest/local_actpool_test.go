@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/p2p"
@@ -94,7 +95,7 @@ func TestLocalActPool(t *testing.T) {
 	// Wait until server receives the 1st action
 	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
 		require.NoError(cli.BroadcastOutbound(p2pCtx, tsf1.Proto()))
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 1, nil
 	}))
 
@@ -117,7 +118,7 @@ func TestLocalActPool(t *testing.T) {
 
 	// Wait until server receives all the transfers
 	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		// 2 valid transfers and 1 valid vote and 1 valid execution
 		return len(acts) == 4, nil
 	}))
@@ -175,7 +176,7 @@ func TestPressureActPool(t *testing.T) {
 	// Wait until server receives the 1st action
 	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
 		require.NoError(cli.BroadcastOutbound(p2pCtx, tsf.Proto()))
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 1, nil
 	}))
 
@@ -187,7 +188,7 @@ func TestPressureActPool(t *testing.T) {
 
 	// Wait until committed blocks contain all broadcasted actions
 	err = testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 1000, nil
 	})
 	require.Nil(err)
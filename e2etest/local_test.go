@@ -8,6 +8,7 @@ package e2etest
 
 import (
 	"context"
+	"io/ioutil"
 	"math/big"
 	"sort"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
@@ -186,7 +188,7 @@ func TestLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, act1); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 1, nil
 	})
 	require.Nil(err)
@@ -227,7 +229,7 @@ func TestLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, act2); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 2, nil
 	})
 	require.Nil(err)
@@ -256,7 +258,7 @@ func TestLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, act3); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 3, nil
 	})
 	require.Nil(err)
@@ -285,7 +287,7 @@ func TestLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, act4); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 4, nil
 	})
 	require.Nil(err)
@@ -613,7 +615,7 @@ func TestVoteLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, acttsf4); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 7, nil
 	})
 	require.Nil(err)
@@ -668,7 +670,7 @@ func TestVoteLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, act5); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 2, nil
 	})
 	require.Nil(err)
@@ -717,7 +719,7 @@ func TestVoteLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, act6); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 1, nil
 	})
 	require.Nil(err)
@@ -772,7 +774,7 @@ func TestVoteLocalCommit(t *testing.T) {
 		if err := p.BroadcastOutbound(p2pCtx, act7); err != nil {
 			return false, err
 		}
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) == 1, nil
 	})
 	require.Nil(err)
@@ -814,6 +816,11 @@ func TestStartExistingBlockchain(t *testing.T) {
 	cfg.Chain.ChainDBPath = testDBPath
 	cfg.Consensus.Scheme = config.NOOPScheme
 	cfg.Network.Port = testutil.RandomPort()
+	// The reverted actions are kept in the actpool journal across restarts
+	journalFile, err := ioutil.TempFile("", "actpool-journal")
+	require.NoError(err)
+	require.NoError(journalFile.Close())
+	cfg.ActPool.JournalPath = journalFile.Name()
 
 	svr, err := itx.NewServer(cfg)
 	require.Nil(err)
@@ -826,6 +833,7 @@ func TestStartExistingBlockchain(t *testing.T) {
 		require.NoError(svr.Stop(ctx))
 		testutil.CleanupPath(t, testTriePath)
 		testutil.CleanupPath(t, testDBPath)
+		testutil.CleanupPath(t, journalFile.Name())
 	}()
 
 	require.NoError(addTestingTsfBlocks(bc))
@@ -867,6 +875,9 @@ func TestStartExistingBlockchain(t *testing.T) {
 	require.Equal(21, len(candidates))
 
 	// Recover to height 2 from an existing state DB with Height 3
+	blk3, err := bc.GetBlockByHeight(3)
+	require.NoError(err)
+	require.True(len(blk3.Actions) > 1)
 	require.NoError(bc.RecoverChainAndState(2))
 	require.NoError(svr.Stop(ctx))
 	svr, err = itx.NewServer(cfg)
@@ -880,6 +891,15 @@ func TestStartExistingBlockchain(t *testing.T) {
 	candidates, err = bc.CandidatesByHeight(uint64(0))
 	require.NoError(err)
 	require.Equal(21, len(candidates))
+	// The actions of the reverted block are put back into actpool
+	ap := svr.ChainService(chainID).ActionPool()
+	for _, selp := range blk3.Actions {
+		if _, ok := selp.Action().(*action.GrantReward); ok {
+			continue
+		}
+		_, err := ap.GetActionByHash(selp.Hash())
+		require.NoError(err)
+	}
 }
 
 func newTestConfig() (config.Config, error) {
@@ -0,0 +1,190 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// groupSize is the number of the BLS signatures aggregated into one, which is fixed by crypto.BLS
+const groupSize = crypto.Degree + 1
+
+// Aggregate is the endorsements of a block on a topic, whose BLS signatures are aggregated. The endorsers are split
+// into groups of groupSize, each of which comes with one aggregated signature, and the last group overlaps with the
+// previous one if the endorsers couldn't be evenly split. It takes much less space than the endorsements
+type Aggregate struct {
+	vote       *ConsensusVote
+	endorsers  []string
+	signatures [][]byte
+}
+
+// AggregateEndorsements aggregates the BLS signatures of the endorsements on the topic in the set. As the round is a
+// part of the vote, only the endorsements of the round endorsed by the most endorsers are aggregated. Each signature
+// is verified against the BLS public key of its endorser first, and the ones of the unknown endorsers or failing the
+// verification are left out, so that a bad signature wouldn't spoil the aggregate
+func AggregateEndorsements(s *Set, topic ConsensusVoteTopic, pubKeys map[string][]byte) (*Aggregate, error) {
+	byRound := map[uint32][]*Endorsement{}
+	var round uint32
+	for _, en := range s.endorsements {
+		vote := en.ConsensusVote()
+		if vote.Topic != topic || len(en.BLSSignature()) == 0 {
+			continue
+		}
+		pk, ok := pubKeys[en.Endorser()]
+		if !ok {
+			continue
+		}
+		hash := vote.Hash()
+		if err := crypto.BLS.Verify(pk, hash[:], en.BLSSignature()); err != nil {
+			continue
+		}
+		byRound[vote.Round] = append(byRound[vote.Round], en)
+		if len(byRound[vote.Round]) > len(byRound[round]) {
+			round = vote.Round
+		}
+	}
+	ens := byRound[round]
+	if len(ens) < groupSize {
+		return nil, errors.Errorf("%d valid BLS signatures, fewer than %d to aggregate", len(ens), groupSize)
+	}
+	sort.Slice(ens, func(i, j int) bool { return ens[i].Endorser() < ens[j].Endorser() })
+	endorsers := make([]string, 0, len(ens))
+	for _, en := range ens {
+		endorsers = append(endorsers, en.Endorser())
+	}
+	bounds := groupBounds(len(ens))
+	sigs := make([][]byte, 0, len(bounds))
+	for _, b := range bounds {
+		ids := make([][]uint8, 0, groupSize)
+		groupSigs := make([][]byte, 0, groupSize)
+		for _, en := range ens[b[0]:b[1]] {
+			ids = append(ids, endorserID(en.Endorser()))
+			groupSigs = append(groupSigs, en.BLSSignature())
+		}
+		sig, err := crypto.BLS.SignAggregate(ids, groupSigs)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when aggregating BLS signatures")
+		}
+		sigs = append(sigs, sig)
+	}
+	return &Aggregate{
+		vote:       ens[0].ConsensusVote(),
+		endorsers:  endorsers,
+		signatures: sigs,
+	}, nil
+}
+
+// ConsensusVote returns the vote endorsed
+func (a *Aggregate) ConsensusVote() *ConsensusVote {
+	return a.vote
+}
+
+// Endorsers returns the endorsers whose signatures are aggregated
+func (a *Aggregate) Endorsers() []string {
+	return a.endorsers
+}
+
+// Signatures returns the aggregated signatures of the groups of the endorsers
+func (a *Aggregate) Signatures() [][]byte {
+	return a.signatures
+}
+
+// Verify verifies the aggregated signatures against the BLS public keys of the endorsers
+func (a *Aggregate) Verify(pubKeys map[string][]byte) error {
+	if len(a.endorsers) < groupSize {
+		return errors.Errorf("%d endorsers, fewer than %d to aggregate", len(a.endorsers), groupSize)
+	}
+	bounds := groupBounds(len(a.endorsers))
+	if len(a.signatures) != len(bounds) {
+		return errors.Errorf("%d signatures for %d groups of endorsers", len(a.signatures), len(bounds))
+	}
+	hash := a.vote.Hash()
+	for i, b := range bounds {
+		ids := make([][]uint8, 0, groupSize)
+		pks := make([][]byte, 0, groupSize)
+		for _, endorser := range a.endorsers[b[0]:b[1]] {
+			pk, ok := pubKeys[endorser]
+			if !ok {
+				return errors.Errorf("no BLS public key of endorser %s", endorser)
+			}
+			ids = append(ids, endorserID(endorser))
+			pks = append(pks, pk)
+		}
+		if err := crypto.BLS.VerifyAggregate(ids, pks, hash[:], a.signatures[i]); err != nil {
+			return errors.Wrapf(err, "error when verifying the aggregated signature of group %d", i)
+		}
+	}
+	return nil
+}
+
+// ToProto converts the aggregate to protobuf
+func (a *Aggregate) ToProto() *iotextypes.AggregatedEndorsement {
+	return &iotextypes.AggregatedEndorsement{
+		Height:     a.vote.Height,
+		Round:      a.vote.Round,
+		BlockHash:  a.vote.BlkHash,
+		Endorsers:  a.endorsers,
+		Signatures: a.signatures,
+		Topic:      iotextypes.Endorsement_ConsensusVoteTopic(a.vote.Topic),
+	}
+}
+
+// FromProto converts protobuf to the aggregate
+func (a *Aggregate) FromProto(aPb *iotextypes.AggregatedEndorsement) error {
+	topic := ConsensusVoteTopic(aPb.Topic)
+	if topic != PROPOSAL && topic != LOCK && topic != COMMIT {
+		return errors.Errorf("invalid topic %d", aPb.Topic)
+	}
+	endorserSet := make(map[string]bool, len(aPb.Endorsers))
+	for _, endorser := range aPb.Endorsers {
+		if endorserSet[endorser] {
+			return errors.Errorf("duplicate endorser %s", endorser)
+		}
+		endorserSet[endorser] = true
+	}
+	a.vote = NewConsensusVote(aPb.BlockHash, aPb.Height, aPb.Round, topic)
+	a.endorsers = aPb.Endorsers
+	a.signatures = aPb.Signatures
+	return nil
+}
+
+// AggregateLogger logs the details of the aggregate
+func (a *Aggregate) AggregateLogger(l *zap.Logger) *zap.Logger {
+	return l.With(
+		zap.String("blockHash", hex.EncodeToString(a.vote.BlkHash)),
+		zap.Uint64("blockHeight", a.vote.Height),
+		zap.Uint32("round", a.vote.Round),
+		zap.Uint8("topic", uint8(a.vote.Topic)),
+		zap.Strings("endorsers", a.endorsers),
+	)
+}
+
+// groupBounds returns the bounds of the groups of n endorsers, where n is no less than groupSize
+func groupBounds(n int) [][2]int {
+	bounds := make([][2]int, 0, (n+groupSize-1)/groupSize)
+	for start := 0; start < n; start += groupSize {
+		if start+groupSize > n {
+			start = n - groupSize
+		}
+		bounds = append(bounds, [2]int{start, start + groupSize})
+	}
+	return bounds
+}
+
+// endorserID returns the id of the endorser with which its BLS signature is weighted in the aggregate
+func endorserID(endorser string) []uint8 {
+	id := hash.Hash256b([]byte(endorser))
+	return id[:]
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestAggregateEndorsements(t *testing.T) {
+	require := require.New(t)
+
+	hash := []byte{'2', '1'}
+	set := NewSet(hash)
+	pubKeys := map[string][]byte{}
+	newEndorsement := func(endorser string, topic ConsensusVoteTopic) *Endorsement {
+		return NewEndorsement(
+			NewConsensusVote(hash, 1, 2, topic),
+			testaddress.Keyinfo["producer"].PubKey,
+			testaddress.Keyinfo["producer"].PriKey,
+			endorser,
+		)
+	}
+	// 14 endorsers are split into 2 overlapping groups
+	for i := 0; i < 14; i++ {
+		endorser := fmt.Sprintf("endorser%02d", i)
+		sk := crypto.DKG.SkGeneration()
+		pk, err := crypto.BLS.NewPubKey(sk)
+		require.NoError(err)
+		pubKeys[endorser] = pk
+		en := newEndorsement(endorser, LOCK)
+		require.NoError(en.SignBLS(sk))
+		require.NoError(set.AddEndorsement(en))
+	}
+	// The endorsements of other topics, without BLS signatures, of unknown endorsers or with bad signatures are left
+	// out
+	require.NoError(set.AddEndorsement(newEndorsement("unsigned", LOCK)))
+	commit := newEndorsement("committer", COMMIT)
+	require.NoError(commit.SignBLS(crypto.DKG.SkGeneration()))
+	require.NoError(set.AddEndorsement(commit))
+	unknown := newEndorsement("unknown", LOCK)
+	require.NoError(unknown.SignBLS(crypto.DKG.SkGeneration()))
+	require.NoError(set.AddEndorsement(unknown))
+	bad := newEndorsement("bad", LOCK)
+	require.NoError(bad.SignBLS(crypto.DKG.SkGeneration()))
+	require.NoError(set.AddEndorsement(bad))
+	pubKeys["bad"] = pubKeys["endorser00"]
+
+	aggregate, err := AggregateEndorsements(set, LOCK, pubKeys)
+	require.NoError(err)
+	require.Equal(14, len(aggregate.Endorsers()))
+	require.Equal(2, len(aggregate.Signatures()))
+	require.Equal(LOCK, aggregate.ConsensusVote().Topic)
+	require.NoError(aggregate.Verify(pubKeys))
+	delete(pubKeys, "bad")
+	swapped := map[string][]byte{}
+	for endorser, pk := range pubKeys {
+		swapped[endorser] = pk
+	}
+	swapped["endorser13"] = pubKeys["endorser12"]
+	require.Error(aggregate.Verify(swapped))
+	delete(swapped, "endorser13")
+	require.Error(aggregate.Verify(swapped))
+
+	// The aggregate survives the protobuf round trip
+	pb := aggregate.ToProto()
+	clone := &Aggregate{}
+	require.NoError(clone.FromProto(pb))
+	require.Equal(aggregate.ConsensusVote().Hash(), clone.ConsensusVote().Hash())
+	require.NoError(clone.Verify(pubKeys))
+	pb.Signatures = pb.Signatures[:1]
+	require.NoError(clone.FromProto(pb))
+	require.Error(clone.Verify(pubKeys))
+	pb.Endorsers[1] = pb.Endorsers[0]
+	require.Error(clone.FromProto(pb))
+
+	// There have to be enough valid signatures to aggregate
+	_, err = AggregateEndorsements(set, COMMIT, pubKeys)
+	require.Error(err)
+	_, err = AggregateEndorsements(NewSet(hash), LOCK, pubKeys)
+	require.Error(err)
+}
@@ -16,6 +16,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/crypto/blake2b"
 
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
@@ -69,6 +70,8 @@ type Endorsement struct {
 	endorser       string
 	endorserPubkey keypair.PublicKey
 	signature      []byte
+	// blsSignature is the BLS signature of the consensus vote, which is nil if the endorser has no BLS key
+	blsSignature []byte
 }
 
 // NewEndorsement creates an Endorsement for an consensus vote
@@ -87,6 +90,27 @@ func NewEndorsement(object *ConsensusVote, endorserPubKey keypair.PublicKey, end
 	}
 }
 
+// NewEndorsementSignedWith creates an Endorsement for an consensus vote, which is signed with the sign function, e.g.,
+// of a remote signer holding the private key of the endorser
+func NewEndorsementSignedWith(
+	object *ConsensusVote,
+	endorserPubKey keypair.PublicKey,
+	sign func([]byte) ([]byte, error),
+	endorserAddr string,
+) (*Endorsement, error) {
+	hash := object.Hash()
+	sig, err := sign(hash[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign endorsement")
+	}
+	return &Endorsement{
+		object:         object,
+		endorser:       endorserAddr,
+		endorserPubkey: endorserPubKey,
+		signature:      sig,
+	}, nil
+}
+
 // ConsensusVote returns the Object of the endorse for signature
 func (en *Endorsement) ConsensusVote() *ConsensusVote {
 	return en.object
@@ -107,6 +131,23 @@ func (en *Endorsement) Signature() []byte {
 	return en.signature
 }
 
+// BLSSignature returns the BLS signature of this endorsement, which is nil if it isn't signed with a BLS key
+func (en *Endorsement) BLSSignature() []byte {
+	return en.blsSignature
+}
+
+// SignBLS signs the consensus vote with the BLS key of the endorser, so that the signature could be aggregated with
+// the ones of the other endorsers of the same vote
+func (en *Endorsement) SignBLS(sk []uint32) error {
+	hash := en.object.Hash()
+	_, sig, err := cp.BLS.Sign(sk, hash[:])
+	if err != nil {
+		return errors.Wrap(err, "error when signing the consensus vote with BLS key")
+	}
+	en.blsSignature = sig
+	return nil
+}
+
 // VerifySignature verifies that the endorse with pubkey
 func (en *Endorsement) VerifySignature() bool {
 	hash := en.object.Hash()
@@ -138,6 +179,7 @@ func (en *Endorsement) ToProtoMsg() *iotextypes.Endorsement {
 		EndorserPubKey: keypair.PublicKeyToBytes(pubkey),
 		Decision:       true,
 		Signature:      en.Signature(),
+		BlsSignature:   en.BLSSignature(),
 	}
 }
 
@@ -181,6 +223,7 @@ func (en *Endorsement) FromProtoMsg(endorsePb *iotextypes.Endorsement) error {
 	en.endorser = endorsePb.Endorser
 	en.endorserPubkey = pubKey
 	en.signature = endorsePb.Signature
+	en.blsSignature = endorsePb.BlsSignature
 
 	return nil
 }
@@ -151,6 +151,36 @@ func (s *Set) NumOfValidEndorsements(topics map[ConsensusVoteTopic]bool, endorse
 	return cnt
 }
 
+// Endorsers returns the distinct endorsers of the endorsements of the given topics
+func (s *Set) Endorsers(topics map[ConsensusVoteTopic]bool) []string {
+	endorserSet := map[string]bool{}
+	endorsers := []string{}
+	for _, endorsement := range s.endorsements {
+		if _, ok := topics[endorsement.ConsensusVote().Topic]; !ok {
+			continue
+		}
+		if _, ok := endorserSet[endorsement.endorser]; ok {
+			continue
+		}
+		endorserSet[endorsement.endorser] = true
+		endorsers = append(endorsers, endorsement.endorser)
+	}
+
+	return endorsers
+}
+
+// Endorsements returns the endorsements of the given topics
+func (s *Set) Endorsements(topics map[ConsensusVoteTopic]bool) []*Endorsement {
+	endorsements := []*Endorsement{}
+	for _, endorsement := range s.endorsements {
+		if _, ok := topics[endorsement.ConsensusVote().Topic]; ok {
+			endorsements = append(endorsements, endorsement)
+		}
+	}
+
+	return endorsements
+}
+
 // ToProto convert the endorsement set to protobuf
 func (s *Set) ToProto() *iotextypes.EndorsementSet {
 	endorsements := []*iotextypes.Endorsement{}
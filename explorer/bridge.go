@@ -0,0 +1,979 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/multichain/mainchain"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/sdk/eventdecoder"
+)
+
+const (
+	// bridgePageSize is the number of actions requested from the api at a time, when paging through them for the
+	// actions of a type
+	bridgePageSize = 100
+	// bridgeMaxScannedActions is the number of actions a request pages through at most, so that a public request for
+	// the actions of a rare type can't scan the whole history of an address
+	bridgeMaxScannedActions = 100 * bridgePageSize
+	// bridgeMaxScannedBlocks is the number of blocks a request for the last actions of a type scans at most
+	bridgeMaxScannedBlocks = 1000
+)
+
+// ErrNotSupported indicates the legacy endpoint isn't served by the api bridge, as the api has no data for it
+var ErrNotSupported = errors.New("not supported by the api bridge")
+
+// apiBridge serves the legacy explorer endpoints by translating them into the requests of the api server, so that the
+// explorer service could be disabled while the integrations of the legacy JSON-RPC keep working during the migration.
+// The data the api doesn't serve are left empty, e.g., the block and the timestamp of an action looked up by hash, and
+// the state root returns ErrNotSupported. The requests for the actions of a type scan a bounded number of actions or
+// blocks, so they may return less than the limit
+type apiBridge struct {
+	api                *api.Server
+	cfg                config.Explorer
+	gs                 GasStation
+	neighborsHandler   Neighbors
+	networkInfoHandler NetworkInfo
+	mainChain          *mainchain.Protocol
+}
+
+// bridgedAction is an action served by the api, along with the block it's in unless it's pending
+type bridgedAction struct {
+	selp      action.SealedEnvelope
+	pending   bool
+	blkID     string
+	timestamp int64
+}
+
+// NewAPIBridgeServer instantiates an explorer server serving the legacy endpoints with the data of the api server
+func NewAPIBridgeServer(cfg config.Explorer, apiSvr *api.Server, opts ...Option) (*Server, error) {
+	expCfg := Config{}
+	for _, opt := range opts {
+		if err := opt(&expCfg); err != nil {
+			return nil, err
+		}
+	}
+	return &Server{
+		cfg: cfg,
+		exp: &apiBridge{
+			api:                apiSvr,
+			cfg:                cfg,
+			gs:                 GasStation{cfg: cfg},
+			neighborsHandler:   expCfg.neighborsHandler,
+			networkInfoHandler: expCfg.networkInfoHandler,
+		},
+	}, nil
+}
+
+// GetBlockchainHeight returns the current blockchain tip height
+func (b *apiBridge) GetBlockchainHeight() (int64, error) {
+	res, err := b.api.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return int64(res.ChainMeta.Height), nil
+}
+
+// GetAddressBalance returns the balance of an address
+func (b *apiBridge) GetAddressBalance(address string) (string, error) {
+	res, err := b.api.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: address})
+	if err != nil {
+		return "", err
+	}
+	return res.AccountMeta.Balance, nil
+}
+
+// GetAddressDetails returns the properties of an address. Whether the address is a candidate isn't served by the api
+func (b *apiBridge) GetAddressDetails(address string) (explorer.AddressDetails, error) {
+	res, err := b.api.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: address})
+	if err != nil {
+		return explorer.AddressDetails{}, err
+	}
+	return explorer.AddressDetails{
+		Address:      address,
+		TotalBalance: res.AccountMeta.Balance,
+		Nonce:        int64(res.AccountMeta.Nonce),
+		PendingNonce: int64(res.AccountMeta.PendingNonce),
+	}, nil
+}
+
+// GetLastTransfersByRange returns transfers in [-(offset+limit-1), -offset] from block with height startBlockHeight
+func (b *apiBridge) GetLastTransfersByRange(
+	startBlockHeight int64,
+	offset int64,
+	limit int64,
+	_ bool,
+) ([]explorer.Transfer, error) {
+	acts, err := b.lastActionsByRange(startBlockHeight, offset, limit, isTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerTransfers(acts)
+}
+
+// GetTransferByID returns transfer by transfer id
+func (b *apiBridge) GetTransferByID(transferID string) (explorer.Transfer, error) {
+	act, err := b.actionByHash(transferID)
+	if err != nil {
+		return explorer.Transfer{}, err
+	}
+	return toExplorerTransfer(act)
+}
+
+// GetTransfersByAddress returns all transfers associated with an address
+func (b *apiBridge) GetTransfersByAddress(address string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	acts, err := b.actionsByAddress(address, offset, limit, isTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerTransfers(acts)
+}
+
+// GetUnconfirmedTransfersByAddress returns all unconfirmed transfers in actpool associated with an address
+func (b *apiBridge) GetUnconfirmedTransfersByAddress(
+	address string,
+	offset int64,
+	limit int64,
+) ([]explorer.Transfer, error) {
+	acts, err := b.unconfirmedActionsByAddress(address, offset, limit, isTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerTransfers(acts)
+}
+
+// GetTransfersByBlockID returns transfers in a block
+func (b *apiBridge) GetTransfersByBlockID(blkID string, offset int64, limit int64) ([]explorer.Transfer, error) {
+	acts, err := b.actionsByBlock(blkID, offset, limit, isTransfer)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerTransfers(acts)
+}
+
+// GetLastVotesByRange returns votes in [-(offset+limit-1), -offset] from block with height startBlockHeight
+func (b *apiBridge) GetLastVotesByRange(startBlockHeight int64, offset int64, limit int64) ([]explorer.Vote, error) {
+	acts, err := b.lastActionsByRange(startBlockHeight, offset, limit, isVote)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerVotes(acts)
+}
+
+// GetVoteByID returns vote by vote id
+func (b *apiBridge) GetVoteByID(voteID string) (explorer.Vote, error) {
+	act, err := b.actionByHash(voteID)
+	if err != nil {
+		return explorer.Vote{}, err
+	}
+	return toExplorerVote(act)
+}
+
+// GetVotesByAddress returns all votes associated with an address
+func (b *apiBridge) GetVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	acts, err := b.actionsByAddress(address, offset, limit, isVote)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerVotes(acts)
+}
+
+// GetUnconfirmedVotesByAddress returns all unconfirmed votes in actpool associated with an address
+func (b *apiBridge) GetUnconfirmedVotesByAddress(address string, offset int64, limit int64) ([]explorer.Vote, error) {
+	acts, err := b.unconfirmedActionsByAddress(address, offset, limit, isVote)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerVotes(acts)
+}
+
+// GetVotesByBlockID returns votes in a block
+func (b *apiBridge) GetVotesByBlockID(blkID string, offset int64, limit int64) ([]explorer.Vote, error) {
+	acts, err := b.actionsByBlock(blkID, offset, limit, isVote)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerVotes(acts)
+}
+
+// GetLastExecutionsByRange returns executions in [-(offset+limit-1), -offset] from block with height startBlockHeight
+func (b *apiBridge) GetLastExecutionsByRange(
+	startBlockHeight int64,
+	offset int64,
+	limit int64,
+) ([]explorer.Execution, error) {
+	acts, err := b.lastActionsByRange(startBlockHeight, offset, limit, isExecution)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerExecutions(acts)
+}
+
+// GetExecutionByID returns execution by execution id
+func (b *apiBridge) GetExecutionByID(executionID string) (explorer.Execution, error) {
+	act, err := b.actionByHash(executionID)
+	if err != nil {
+		return explorer.Execution{}, err
+	}
+	return toExplorerExecution(act)
+}
+
+// GetExecutionsByAddress returns all executions associated with an address
+func (b *apiBridge) GetExecutionsByAddress(address string, offset int64, limit int64) ([]explorer.Execution, error) {
+	acts, err := b.actionsByAddress(address, offset, limit, isExecution)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerExecutions(acts)
+}
+
+// GetUnconfirmedExecutionsByAddress returns all unconfirmed executions in actpool associated with an address
+func (b *apiBridge) GetUnconfirmedExecutionsByAddress(
+	address string,
+	offset int64,
+	limit int64,
+) ([]explorer.Execution, error) {
+	acts, err := b.unconfirmedActionsByAddress(address, offset, limit, isExecution)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerExecutions(acts)
+}
+
+// GetExecutionsByBlockID returns executions in a block
+func (b *apiBridge) GetExecutionsByBlockID(blkID string, offset int64, limit int64) ([]explorer.Execution, error) {
+	acts, err := b.actionsByBlock(blkID, offset, limit, isExecution)
+	if err != nil {
+		return nil, err
+	}
+	return toExplorerExecutions(acts)
+}
+
+// GetCreateDeposit gets create deposit by ID
+func (b *apiBridge) GetCreateDeposit(createDepositID string) (explorer.CreateDeposit, error) {
+	act, err := b.actionByHash(createDepositID)
+	if err != nil {
+		return explorer.CreateDeposit{}, err
+	}
+	return toExplorerCreateDeposit(act)
+}
+
+// GetCreateDepositsByAddress gets the create deposits sent from an address
+func (b *apiBridge) GetCreateDepositsByAddress(
+	address string,
+	offset int64,
+	limit int64,
+) ([]explorer.CreateDeposit, error) {
+	acts, err := b.actionsByAddress(address, offset, limit, func(selp action.SealedEnvelope) bool {
+		_, ok := selp.Action().(*action.CreateDeposit)
+		return ok && senderOf(selp) == address
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]explorer.CreateDeposit, 0, len(acts))
+	for _, act := range acts {
+		cd, err := toExplorerCreateDeposit(act)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cd)
+	}
+	return res, nil
+}
+
+// GetSettleDeposit gets settle deposit by ID
+func (b *apiBridge) GetSettleDeposit(settleDepositID string) (explorer.SettleDeposit, error) {
+	act, err := b.actionByHash(settleDepositID)
+	if err != nil {
+		return explorer.SettleDeposit{}, err
+	}
+	return toExplorerSettleDeposit(act)
+}
+
+// GetSettleDepositsByAddress gets the settle deposits to an address
+func (b *apiBridge) GetSettleDepositsByAddress(
+	address string,
+	offset int64,
+	limit int64,
+) ([]explorer.SettleDeposit, error) {
+	acts, err := b.actionsByAddress(address, offset, limit, func(selp action.SealedEnvelope) bool {
+		sd, ok := selp.Action().(*action.SettleDeposit)
+		return ok && sd.Recipient() == address
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]explorer.SettleDeposit, 0, len(acts))
+	for _, act := range acts {
+		sd, err := toExplorerSettleDeposit(act)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, sd)
+	}
+	return res, nil
+}
+
+// GetLastBlocksByRange get block with height [offset-limit+1, offset]
+func (b *apiBridge) GetLastBlocksByRange(offset int64, limit int64) ([]explorer.Block, error) {
+	var res []explorer.Block
+	for height := offset; height >= 0 && int64(len(res)) < limit; height-- {
+		blk, err := b.rawBlock(uint64(height))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, convertBlockToExplorerBlock(blk))
+	}
+	return res, nil
+}
+
+// GetBlockByID returns block by block id
+func (b *apiBridge) GetBlockByID(blkID string) (explorer.Block, error) {
+	res, err := b.api.GetBlockMetas(context.Background(), &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByHash{
+			ByHash: &iotexapi.GetBlockMetaByHashRequest{BlkHash: blkID},
+		},
+	})
+	if err != nil {
+		return explorer.Block{}, err
+	}
+	blk, err := b.rawBlock(res.BlkMetas[0].Height)
+	if err != nil {
+		return explorer.Block{}, err
+	}
+	explorerBlock := convertBlockToExplorerBlock(blk)
+	explorerBlock.ID = blkID
+	return explorerBlock, nil
+}
+
+// GetCoinStatistic returns stats in blockchain. The numbers of the transfers, votes and executions aren't served by
+// the api, so the number of all actions is returned as the transfers
+func (b *apiBridge) GetCoinStatistic() (explorer.CoinStatistic, error) {
+	res, err := b.api.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return explorer.CoinStatistic{}, err
+	}
+	return explorer.CoinStatistic{
+		Height:    int64(res.ChainMeta.Height),
+		Supply:    res.ChainMeta.Supply,
+		Transfers: res.ChainMeta.NumActions,
+		Aps:       res.ChainMeta.Tps,
+	}, nil
+}
+
+// GetConsensusMetrics returns the latest consensus metrics. The candidates aren't served by the api
+func (b *apiBridge) GetConsensusMetrics() (explorer.ConsensusMetrics, error) {
+	res, err := b.api.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return explorer.ConsensusMetrics{}, err
+	}
+	if res.Consensus == nil {
+		return explorer.ConsensusMetrics{}, ErrNotSupported
+	}
+	return explorer.ConsensusMetrics{
+		LatestEpoch:         int64(res.Consensus.Epoch),
+		LatestDelegates:     res.Consensus.Delegates,
+		LatestBlockProducer: res.Consensus.LatestBlockProducer,
+		Candidates:          []string{},
+	}, nil
+}
+
+// GetCandidateMetrics returns the candidates at the latest consensus height, along with whether they are the delegates
+// of the latest epoch and the latest block producer
+func (b *apiBridge) GetCandidateMetrics() (explorer.CandidateMetrics, error) {
+	res, err := b.api.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return explorer.CandidateMetrics{}, err
+	}
+	if res.Consensus == nil {
+		return explorer.CandidateMetrics{}, ErrNotSupported
+	}
+	allCandidates, err := b.api.CandidatesByHeight(res.Consensus.Height)
+	if err != nil {
+		return explorer.CandidateMetrics{}, errors.Wrap(err, "failed to get the candidate metrics")
+	}
+	delegateSet := make(map[string]bool, len(res.Consensus.Delegates))
+	for _, d := range res.Consensus.Delegates {
+		delegateSet[d] = true
+	}
+	candidates := make([]explorer.Candidate, 0, len(allCandidates))
+	for _, c := range allCandidates {
+		candidates = append(candidates, explorer.Candidate{
+			Address:          c.Address,
+			TotalVote:        c.Votes.String(),
+			CreationHeight:   int64(c.CreationHeight),
+			LastUpdateHeight: int64(c.LastUpdateHeight),
+			IsDelegate:       delegateSet[c.Address],
+			IsProducer:       res.Consensus.LatestBlockProducer == c.Address,
+		})
+	}
+	return explorer.CandidateMetrics{
+		Candidates:   candidates,
+		LatestEpoch:  int64(res.Consensus.Epoch),
+		LatestHeight: int64(res.Consensus.Height),
+	}, nil
+}
+
+// GetCandidateMetricsByHeight returns the candidates at the height
+func (b *apiBridge) GetCandidateMetricsByHeight(h int64) (explorer.CandidateMetrics, error) {
+	if h < 0 {
+		return explorer.CandidateMetrics{}, errors.New("invalid height")
+	}
+	allCandidates, err := b.api.CandidatesByHeight(uint64(h))
+	if err != nil {
+		return explorer.CandidateMetrics{}, errors.Wrap(err, "failed to get the candidate metrics")
+	}
+	candidates := make([]explorer.Candidate, 0, len(allCandidates))
+	for _, c := range allCandidates {
+		candidates = append(candidates, explorer.Candidate{
+			Address:          c.Address,
+			PubKey:           keypair.EncodePublicKey(c.PublicKey),
+			TotalVote:        c.Votes.String(),
+			CreationHeight:   int64(c.CreationHeight),
+			LastUpdateHeight: int64(c.LastUpdateHeight),
+		})
+	}
+	return explorer.CandidateMetrics{Candidates: candidates}, nil
+}
+
+// SendTransfer sends a transfer
+func (b *apiBridge) SendTransfer(tsfJSON explorer.SendTransferRequest) (explorer.SendTransferResponse, error) {
+	actPb, err := convertExplorerTransferToActionPb(&tsfJSON, b.cfg.MaxTransferPayloadBytes)
+	if err != nil {
+		return explorer.SendTransferResponse{}, err
+	}
+	h, err := b.sendAction(actPb)
+	if err != nil {
+		return explorer.SendTransferResponse{}, err
+	}
+	return explorer.SendTransferResponse{Hash: h}, nil
+}
+
+// SendVote sends a vote
+func (b *apiBridge) SendVote(voteJSON explorer.SendVoteRequest) (explorer.SendVoteResponse, error) {
+	actPb, err := convertExplorerVoteToActionPb(&voteJSON)
+	if err != nil {
+		return explorer.SendVoteResponse{}, err
+	}
+	h, err := b.sendAction(actPb)
+	if err != nil {
+		return explorer.SendVoteResponse{}, err
+	}
+	return explorer.SendVoteResponse{Hash: h}, nil
+}
+
+// SendSmartContract sends a smart contract
+func (b *apiBridge) SendSmartContract(execution explorer.Execution) (explorer.SendSmartContractResponse, error) {
+	actPb, err := convertExplorerExecutionToActionPb(&execution)
+	if err != nil {
+		return explorer.SendSmartContractResponse{}, err
+	}
+	h, err := b.sendAction(actPb)
+	if err != nil {
+		return explorer.SendSmartContractResponse{}, err
+	}
+	return explorer.SendSmartContractResponse{Hash: h}, nil
+}
+
+// PutSubChainBlock put block merkel root on root chain
+func (b *apiBridge) PutSubChainBlock(
+	putBlockJSON explorer.PutSubChainBlockRequest,
+) (explorer.PutSubChainBlockResponse, error) {
+	actPb, err := convertExplorerPutSubChainBlockToActionPb(&putBlockJSON)
+	if err != nil {
+		return explorer.PutSubChainBlockResponse{}, err
+	}
+	h, err := b.sendAction(actPb)
+	if err != nil {
+		return explorer.PutSubChainBlockResponse{}, err
+	}
+	return explorer.PutSubChainBlockResponse{Hash: h}, nil
+}
+
+// SendAction is the API to send an action to blockchain
+func (b *apiBridge) SendAction(req explorer.SendActionRequest) (explorer.SendActionResponse, error) {
+	var actPb iotextypes.Action
+	if err := jsonpb.UnmarshalString(req.Payload, &actPb); err != nil {
+		return explorer.SendActionResponse{}, err
+	}
+	if _, err := b.sendAction(&actPb); err != nil {
+		return explorer.SendActionResponse{}, err
+	}
+	return explorer.SendActionResponse{}, nil
+}
+
+// GetPeers return a list of node peers and itself's network addsress info
+func (b *apiBridge) GetPeers() (explorer.GetPeersResponse, error) {
+	if b.neighborsHandler == nil || b.networkInfoHandler == nil {
+		return explorer.GetPeersResponse{}, ErrNotSupported
+	}
+	peers, err := b.neighborsHandler(context.Background())
+	if err != nil {
+		return explorer.GetPeersResponse{}, err
+	}
+	var exppeers []explorer.Node
+	for _, p := range peers {
+		exppeers = append(exppeers, explorer.Node{Address: fmt.Sprintf("%v", p)})
+	}
+	return explorer.GetPeersResponse{
+		Self:  explorer.Node{Address: fmt.Sprintf("%v", b.networkInfoHandler())},
+		Peers: exppeers,
+	}, nil
+}
+
+// GetReceiptByExecutionID gets receipt with corresponding execution id
+// Deprecated
+func (b *apiBridge) GetReceiptByExecutionID(id string) (explorer.Receipt, error) {
+	return b.GetReceiptByActionID(id)
+}
+
+// GetReceiptByActionID gets receipt with corresponding action id
+func (b *apiBridge) GetReceiptByActionID(id string) (explorer.Receipt, error) {
+	receipt, err := b.receipt(id)
+	if err != nil {
+		return explorer.Receipt{}, err
+	}
+	return convertReceiptToExplorerReceipt(receipt)
+}
+
+// GetDecodedLogsByActionID gets the logs in the receipt of an action decoded with the contract abi
+func (b *apiBridge) GetDecodedLogsByActionID(id string, abi string) ([]explorer.DecodedLog, error) {
+	decoder, err := eventdecoder.New(abi)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := b.receipt(id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLogs(decoder, receipt)
+}
+
+// ReadExecutionState reads the state in a contract address specified by the slot
+func (b *apiBridge) ReadExecutionState(execution explorer.Execution) (string, error) {
+	actPb, err := convertExplorerExecutionToActionPb(&execution)
+	if err != nil {
+		return "", err
+	}
+	res, err := b.api.ReadContract(context.Background(), &iotexapi.ReadContractRequest{Action: actPb})
+	if err != nil {
+		return "", err
+	}
+	return res.Data, nil
+}
+
+// GetBlockOrActionByHash get block or action by a hash
+func (b *apiBridge) GetBlockOrActionByHash(hashStr string) (explorer.GetBlkOrActResponse, error) {
+	if blk, err := b.GetBlockByID(hashStr); err == nil {
+		return explorer.GetBlkOrActResponse{Block: &blk}, nil
+	}
+	if act, err := b.actionByHash(hashStr); err == nil {
+		switch act.selp.Action().(type) {
+		case *action.Transfer:
+			tsf, err := toExplorerTransfer(act)
+			return explorer.GetBlkOrActResponse{Transfer: &tsf}, err
+		case *action.Vote:
+			vote, err := toExplorerVote(act)
+			return explorer.GetBlkOrActResponse{Vote: &vote}, err
+		case *action.Execution:
+			exe, err := toExplorerExecution(act)
+			return explorer.GetBlkOrActResponse{Execution: &exe}, err
+		}
+	}
+	if addr, err := b.GetAddressDetails(hashStr); err == nil {
+		return explorer.GetBlkOrActResponse{Address: &addr}, nil
+	}
+	return explorer.GetBlkOrActResponse{}, nil
+}
+
+// CreateDeposit deposits balance from main-chain to sub-chain
+func (b *apiBridge) CreateDeposit(req explorer.CreateDepositRequest) (explorer.CreateDepositResponse, error) {
+	actPb, err := convertExplorerCreateDepositToActionPb(&req)
+	if err != nil {
+		return explorer.CreateDepositResponse{}, err
+	}
+	h, err := b.sendAction(actPb)
+	if err != nil {
+		return explorer.CreateDepositResponse{}, err
+	}
+	return explorer.CreateDepositResponse{Hash: h}, nil
+}
+
+// GetDeposits returns the deposits of a sub-chain in the given range in descending order by the index
+func (b *apiBridge) GetDeposits(subChainID int64, offset int64, limit int64) ([]explorer.Deposit, error) {
+	return getDeposits(b.mainChain, subChainID, offset, limit)
+}
+
+// SettleDeposit settles deposit on sub-chain
+func (b *apiBridge) SettleDeposit(req explorer.SettleDepositRequest) (explorer.SettleDepositResponse, error) {
+	actPb, err := convertExplorerSettleDepositToActionPb(&req)
+	if err != nil {
+		return explorer.SettleDepositResponse{}, err
+	}
+	h, err := b.sendAction(actPb)
+	if err != nil {
+		return explorer.SettleDepositResponse{}, err
+	}
+	return explorer.SettleDepositResponse{Hash: h}, nil
+}
+
+// SuggestGasPrice suggest gas price
+func (b *apiBridge) SuggestGasPrice() (int64, error) {
+	res, err := b.api.SuggestGasPrice(context.Background(), &iotexapi.SuggestGasPriceRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return int64(res.GasPrice), nil
+}
+
+// EstimateGasForTransfer estimate gas for transfer
+func (b *apiBridge) EstimateGasForTransfer(tsfJSON explorer.SendTransferRequest) (int64, error) {
+	return b.gs.estimateGasForTransfer(tsfJSON)
+}
+
+// EstimateGasForVote suggest gas for vote
+func (b *apiBridge) EstimateGasForVote() (int64, error) {
+	return b.gs.estimateGasForVote()
+}
+
+// EstimateGasForSmartContract suggest gas for smart contract
+func (b *apiBridge) EstimateGasForSmartContract(execution explorer.Execution) (int64, error) {
+	actPb, err := convertExplorerExecutionToActionPb(&execution)
+	if err != nil {
+		return 0, err
+	}
+	res, err := b.api.EstimateGasForAction(context.Background(), &iotexapi.EstimateGasForActionRequest{Action: actPb})
+	if err != nil {
+		return 0, err
+	}
+	return int64(res.Gas), nil
+}
+
+// GetStateRootHash isn't supported by the api bridge
+func (b *apiBridge) GetStateRootHash(int64) (string, error) {
+	return "", ErrNotSupported
+}
+
+// sendAction sends the action via the api, and returns its hash
+func (b *apiBridge) sendAction(actPb *iotextypes.Action) (string, error) {
+	selp := &action.SealedEnvelope{}
+	if err := selp.LoadProto(actPb); err != nil {
+		return "", err
+	}
+	if _, err := b.api.SendAction(context.Background(), &iotexapi.SendActionRequest{Action: actPb}); err != nil {
+		return "", err
+	}
+	h := selp.Hash()
+	return hex.EncodeToString(h[:]), nil
+}
+
+// rawBlock gets the block at the height via the api
+func (b *apiBridge) rawBlock(height uint64) (*block.Block, error) {
+	res, err := b.api.GetRawBlocks(context.Background(), &iotexapi.GetRawBlocksRequest{StartHeight: height, Count: 1})
+	if err != nil {
+		return nil, err
+	}
+	blk := &block.Block{}
+	if err := blk.ConvertFromBlockPb(res.Blocks[0]); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert block %d", height)
+	}
+	return blk, nil
+}
+
+// receipt gets the receipt of an action via the api
+func (b *apiBridge) receipt(id string) (*action.Receipt, error) {
+	res, err := b.api.GetReceiptByAction(context.Background(), &iotexapi.GetReceiptByActionRequest{ActionHash: id})
+	if err != nil {
+		return nil, err
+	}
+	receipt := &action.Receipt{}
+	receipt.ConvertFromReceiptPb(res.Receipt)
+	return receipt, nil
+}
+
+// actionByHash gets an action via the api, which is pending if it isn't charged a fee
+func (b *apiBridge) actionByHash(id string) (bridgedAction, error) {
+	res, err := b.api.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByHash{
+			ByHash: &iotexapi.GetActionByHashRequest{ActionHash: id, CheckPending: true},
+		},
+	})
+	if err != nil {
+		return bridgedAction{}, err
+	}
+	act := bridgedAction{pending: len(res.Fees) == 0}
+	if err := act.selp.LoadProto(res.Actions[0]); err != nil {
+		return bridgedAction{}, err
+	}
+	return act, nil
+}
+
+// actionsByAddress gets the confirmed actions of an address via the api, the latest first
+func (b *apiBridge) actionsByAddress(
+	address string,
+	offset int64,
+	limit int64,
+	keep func(action.SealedEnvelope) bool,
+) ([]bridgedAction, error) {
+	return b.pageActions(func(start, count uint64) *iotexapi.GetActionsRequest {
+		return &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_ByAddr{
+				ByAddr: &iotexapi.GetActionsByAddressRequest{Address: address, Start: start, Count: count},
+			},
+		}
+	}, offset, limit, keep, bridgedAction{})
+}
+
+// unconfirmedActionsByAddress gets the pending actions of an address via the api, the latest first
+func (b *apiBridge) unconfirmedActionsByAddress(
+	address string,
+	offset int64,
+	limit int64,
+	keep func(action.SealedEnvelope) bool,
+) ([]bridgedAction, error) {
+	return b.pageActions(func(start, count uint64) *iotexapi.GetActionsRequest {
+		return &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_UnconfirmedByAddr{
+				UnconfirmedByAddr: &iotexapi.GetUnconfirmedActionsByAddressRequest{
+					Address: address,
+					Start:   start,
+					Count:   count,
+				},
+			},
+		}
+	}, offset, limit, keep, bridgedAction{pending: true})
+}
+
+// actionsByBlock gets the actions in a block via the api, the last first
+func (b *apiBridge) actionsByBlock(
+	blkID string,
+	offset int64,
+	limit int64,
+	keep func(action.SealedEnvelope) bool,
+) ([]bridgedAction, error) {
+	res, err := b.api.GetBlockMetas(context.Background(), &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByHash{
+			ByHash: &iotexapi.GetBlockMetaByHashRequest{BlkHash: blkID},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.pageActions(func(start, count uint64) *iotexapi.GetActionsRequest {
+		return &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_ByBlk{
+				ByBlk: &iotexapi.GetActionsByBlockRequest{BlkHash: blkID, Start: start, Count: count},
+			},
+		}
+	}, offset, limit, keep, bridgedAction{blkID: blkID, timestamp: res.BlkMetas[0].Timestamp})
+}
+
+// lastActionsByRange gets the actions in the blocks from startBlockHeight down via the api, the last first. At most
+// bridgeMaxScannedBlocks blocks are scanned
+func (b *apiBridge) lastActionsByRange(
+	startBlockHeight int64,
+	offset int64,
+	limit int64,
+	keep func(action.SealedEnvelope) bool,
+) ([]bridgedAction, error) {
+	var (
+		res   []bridgedAction
+		count int64
+	)
+	for height := startBlockHeight; height >= 0 && startBlockHeight-height < bridgeMaxScannedBlocks; height-- {
+		blk, err := b.rawBlock(uint64(height))
+		if err != nil {
+			return nil, err
+		}
+		h := blk.HashBlock()
+		blkID := hex.EncodeToString(h[:])
+		timestamp := blk.ConvertToBlockHeaderPb().GetTimestamp().GetSeconds()
+		for i := len(blk.Actions) - 1; i >= 0; i-- {
+			if !keep(blk.Actions[i]) {
+				continue
+			}
+			count++
+			if count <= offset {
+				continue
+			}
+			if int64(len(res)) >= limit {
+				return res, nil
+			}
+			res = append(res, bridgedAction{selp: blk.Actions[i], blkID: blkID, timestamp: timestamp})
+		}
+	}
+	return res, nil
+}
+
+// pageActions pages through the actions of the requests, and returns at most limit ones the keep function returns
+// true of, skipping the first offset ones. The returned actions are of the block and the pending state of the template.
+// At most bridgeMaxScannedActions actions are paged through
+func (b *apiBridge) pageActions(
+	request func(start, count uint64) *iotexapi.GetActionsRequest,
+	offset int64,
+	limit int64,
+	keep func(action.SealedEnvelope) bool,
+	template bridgedAction,
+) ([]bridgedAction, error) {
+	var (
+		res     []bridgedAction
+		skipped int64
+	)
+	for start := uint64(0); int64(len(res)) < limit && start < bridgeMaxScannedActions; start += bridgePageSize {
+		page, err := b.api.GetActions(context.Background(), request(start, bridgePageSize))
+		if err != nil {
+			return nil, err
+		}
+		for _, actPb := range page.Actions {
+			act := template
+			if err := act.selp.LoadProto(actPb); err != nil {
+				return nil, err
+			}
+			if !keep(act.selp) {
+				continue
+			}
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			if int64(len(res)) >= limit {
+				break
+			}
+			res = append(res, act)
+		}
+		if len(page.Actions) < bridgePageSize {
+			break
+		}
+	}
+	return res, nil
+}
+
+func isTransfer(selp action.SealedEnvelope) bool {
+	_, ok := selp.Action().(*action.Transfer)
+	return ok
+}
+
+func isVote(selp action.SealedEnvelope) bool {
+	_, ok := selp.Action().(*action.Vote)
+	return ok
+}
+
+func isExecution(selp action.SealedEnvelope) bool {
+	_, ok := selp.Action().(*action.Execution)
+	return ok
+}
+
+// senderOf returns the address of the sender of the action, or an empty string if it fails to be converted
+func senderOf(selp action.SealedEnvelope) string {
+	pkHash := keypair.HashPubKey(selp.SrcPubkey())
+	addr, err := address.FromBytes(pkHash[:])
+	if err != nil {
+		return ""
+	}
+	return addr.String()
+}
+
+func toExplorerCreateDeposit(act bridgedAction) (explorer.CreateDeposit, error) {
+	cd, err := castActionToCreateDeposit(act.selp, act.pending)
+	if err != nil {
+		return explorer.CreateDeposit{}, err
+	}
+	cd.BlockID = act.blkID
+	cd.Timestamp = act.timestamp
+	return cd, nil
+}
+
+func toExplorerSettleDeposit(act bridgedAction) (explorer.SettleDeposit, error) {
+	sd, err := castActionToSettleDeposit(act.selp, act.pending)
+	if err != nil {
+		return explorer.SettleDeposit{}, err
+	}
+	sd.BlockID = act.blkID
+	sd.Timestamp = act.timestamp
+	return sd, nil
+}
+
+func toExplorerTransfer(act bridgedAction) (explorer.Transfer, error) {
+	tsf, err := convertTsfToExplorerTsf(act.selp, act.pending)
+	if err != nil {
+		return explorer.Transfer{}, err
+	}
+	tsf.BlockID = act.blkID
+	tsf.Timestamp = act.timestamp
+	return tsf, nil
+}
+
+func toExplorerTransfers(acts []bridgedAction) ([]explorer.Transfer, error) {
+	res := make([]explorer.Transfer, 0, len(acts))
+	for _, act := range acts {
+		tsf, err := toExplorerTransfer(act)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, tsf)
+	}
+	return res, nil
+}
+
+func toExplorerVote(act bridgedAction) (explorer.Vote, error) {
+	vote, err := convertVoteToExplorerVote(act.selp, act.pending)
+	if err != nil {
+		return explorer.Vote{}, err
+	}
+	vote.BlockID = act.blkID
+	vote.Timestamp = act.timestamp
+	return vote, nil
+}
+
+func toExplorerVotes(acts []bridgedAction) ([]explorer.Vote, error) {
+	res := make([]explorer.Vote, 0, len(acts))
+	for _, act := range acts {
+		vote, err := toExplorerVote(act)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, vote)
+	}
+	return res, nil
+}
+
+func toExplorerExecution(act bridgedAction) (explorer.Execution, error) {
+	exe, err := convertExecutionToExplorerExecution(act.selp, act.pending)
+	if err != nil {
+		return explorer.Execution{}, err
+	}
+	exe.BlockID = act.blkID
+	exe.Timestamp = act.timestamp
+	return exe, nil
+}
+
+func toExplorerExecutions(acts []bridgedAction) ([]explorer.Execution, error) {
+	res := make([]explorer.Execution, 0, len(acts))
+	for _, act := range acts {
+		exe, err := toExplorerExecution(act)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, exe)
+	}
+	return res, nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package explorer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/vote"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/explorer/idl/explorer"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestAPIBridge(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Chain.EnableIndex = true
+	genesisCfg := genesis.Default
+
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.NoError(err)
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(
+		cfg,
+		blockchain.PrecreatedStateFactoryOption(sf),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NotNil(bc)
+	ap, err := actpool.NewActPool(bc, cfg.ActPool)
+	require.NoError(err)
+	sf.AddActionHandlers(account.NewProtocol(), vote.NewProtocol(nil), execution.NewProtocol(bc))
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	ap.AddActionValidators(vote.NewProtocol(bc), execution.NewProtocol(bc))
+	bc.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesisCfg.Blockchain.ActionGasLimit))
+	bc.Validator().AddActionValidators(account.NewProtocol(), vote.NewProtocol(bc), execution.NewProtocol(bc))
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addCreatorToFactory(sf))
+	require.NoError(addTestingBlocks(bc))
+	require.NoError(addActsToActPool(ap))
+
+	explorerCfg := config.Explorer{TpsWindow: 10, MaxTransferPayloadBytes: 1024}
+	svc := &Service{
+		bc:  bc,
+		ap:  ap,
+		cfg: explorerCfg,
+		gs:  GasStation{bc, explorerCfg},
+	}
+	apiSvr, err := api.NewServer(cfg.API, bc, nil, ap, nil)
+	require.NoError(err)
+	bridgeSvr, err := NewAPIBridgeServer(explorerCfg, apiSvr)
+	require.NoError(err)
+	bridge := bridgeSvr.Explorer()
+
+	height, err := bridge.GetBlockchainHeight()
+	require.NoError(err)
+	require.Equal(int64(bc.TipHeight()), height)
+
+	blks, err := svc.GetLastBlocksByRange(height, 10)
+	require.NoError(err)
+	bridgedBlks, err := bridge.GetLastBlocksByRange(height, 10)
+	require.NoError(err)
+	require.Equal(blks, bridgedBlks)
+	blk, err := bridge.GetBlockByID(blks[1].ID)
+	require.NoError(err)
+	require.Equal(blks[1], blk)
+
+	for _, name := range []string{"producer", "alfa", "bravo", "charlie", "delta"} {
+		addr := ta.Addrinfo[name].String()
+		details, err := svc.GetAddressDetails(addr)
+		require.NoError(err)
+		bridgedDetails, err := bridge.GetAddressDetails(addr)
+		require.NoError(err)
+		// Whether the address is a candidate isn't served by the api
+		details.IsCandidate = false
+		require.Equal(details, bridgedDetails)
+
+		// The actions of an address are served the latest first by the api
+		transfers, err := svc.GetTransfersByAddress(addr, 0, 10)
+		require.NoError(err)
+		bridgedTransfers, err := bridge.GetTransfersByAddress(addr, 0, 10)
+		require.NoError(err)
+		require.ElementsMatch(transferIDs(transfers), transferIDs(bridgedTransfers))
+		votes, err := svc.GetVotesByAddress(addr, 0, 10)
+		require.NoError(err)
+		bridgedVotes, err := bridge.GetVotesByAddress(addr, 0, 10)
+		require.NoError(err)
+		require.Len(bridgedVotes, len(votes))
+		executions, err := svc.GetExecutionsByAddress(addr, 0, 10)
+		require.NoError(err)
+		bridgedExecutions, err := bridge.GetExecutionsByAddress(addr, 0, 10)
+		require.NoError(err)
+		require.Len(bridgedExecutions, len(executions))
+
+		transfers, err = svc.GetUnconfirmedTransfersByAddress(addr, 0, 10)
+		require.NoError(err)
+		bridgedTransfers, err = bridge.GetUnconfirmedTransfersByAddress(addr, 0, 10)
+		require.NoError(err)
+		require.ElementsMatch(transfers, bridgedTransfers)
+	}
+	transfers, err := bridge.GetTransfersByAddress(ta.Addrinfo["charlie"].String(), 2, 2)
+	require.NoError(err)
+	require.Len(transfers, 2)
+
+	transfers, err = svc.GetLastTransfersByRange(4, 1, 3, true)
+	require.NoError(err)
+	bridgedTransfers, err := bridge.GetLastTransfersByRange(4, 1, 3, true)
+	require.NoError(err)
+	require.Equal(transfers, bridgedTransfers)
+	votes, err := svc.GetLastVotesByRange(4, 0, 10)
+	require.NoError(err)
+	bridgedVotes, err := bridge.GetLastVotesByRange(4, 0, 10)
+	require.NoError(err)
+	require.Equal(votes, bridgedVotes)
+	executions, err := svc.GetLastExecutionsByRange(4, 0, 10)
+	require.NoError(err)
+	bridgedExecutions, err := bridge.GetLastExecutionsByRange(4, 0, 10)
+	require.NoError(err)
+	require.Equal(executions, bridgedExecutions)
+
+	lastTransfers := transfers
+	for _, blk := range blks {
+		transfers, err = svc.GetTransfersByBlockID(blk.ID, 0, 10)
+		require.NoError(err)
+		bridgedTransfers, err = bridge.GetTransfersByBlockID(blk.ID, 0, 10)
+		require.NoError(err)
+		require.ElementsMatch(transfers, bridgedTransfers)
+		require.Len(bridgedTransfers, int(blk.Transfers))
+	}
+
+	// The block of an action looked up by hash isn't served by the api
+	transfer, err := svc.GetTransferByID(lastTransfers[0].ID)
+	require.NoError(err)
+	bridgedTransfer, err := bridge.GetTransferByID(lastTransfers[0].ID)
+	require.NoError(err)
+	transfer.BlockID, transfer.Timestamp = "", 0
+	require.Equal(transfer, bridgedTransfer)
+	res, err := bridge.GetBlockOrActionByHash(lastTransfers[0].ID)
+	require.NoError(err)
+	require.Equal(&bridgedTransfer, res.Transfer)
+	_, err = bridge.GetVoteByID(lastTransfers[0].ID)
+	require.Error(err)
+
+	receipt, err := svc.GetReceiptByActionID(executions[0].ID)
+	require.NoError(err)
+	bridgedReceipt, err := bridge.GetReceiptByActionID(executions[0].ID)
+	require.NoError(err)
+	require.Equal(receipt, bridgedReceipt)
+
+	candidates, err := svc.GetCandidateMetricsByHeight(height)
+	require.NoError(err)
+	bridgedCandidates, err := bridge.GetCandidateMetricsByHeight(height)
+	require.NoError(err)
+	require.Equal(candidates, bridgedCandidates)
+
+	// The peers aren't served without the p2p handlers
+	_, err = bridge.GetPeers()
+	require.Equal(ErrNotSupported, errors.Cause(err))
+	_, err = bridge.GetStateRootHash(1)
+	require.Equal(ErrNotSupported, errors.Cause(err))
+}
+
+func transferIDs(transfers []explorer.Transfer) []string {
+	ids := make([]string, 0, len(transfers))
+	for _, transfer := range transfers {
+		ids = append(ids, transfer.ID)
+	}
+	return ids
+}
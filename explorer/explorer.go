@@ -24,6 +24,7 @@ import (
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/dispatcher"
@@ -33,6 +34,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/sdk/eventdecoder"
 )
 
 var (
@@ -684,38 +686,83 @@ func (exp *Service) GetReceiptByExecutionID(id string) (explorer.Receipt, error)
 
 // GetReceiptByActionID gets receipt with corresponding action id
 func (exp *Service) GetReceiptByActionID(id string) (explorer.Receipt, error) {
-	bytes, err := hex.DecodeString(id)
+	receipt, err := exp.getReceipt(id)
 	if err != nil {
 		return explorer.Receipt{}, err
 	}
+	return convertReceiptToExplorerReceipt(receipt)
+}
+
+// GetDecodedLogsByActionID gets the logs in the receipt of an action decoded with the contract abi. The logs which
+// aren't emitted by the events in the abi are skipped
+func (exp *Service) GetDecodedLogsByActionID(id string, abi string) ([]explorer.DecodedLog, error) {
+	decoder, err := eventdecoder.New(abi)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := exp.getReceipt(id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLogs(decoder, receipt)
+}
+
+// decodeLogs decodes the logs in the receipt with the decoder, skipping the logs which aren't emitted by its events
+func decodeLogs(decoder *eventdecoder.Decoder, receipt *action.Receipt) ([]explorer.DecodedLog, error) {
+	events, err := decoder.DecodeReceipt(receipt)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]explorer.DecodedLog, 0, len(events))
+	for _, event := range events {
+		decoded := explorer.DecodedLog{
+			Address: event.Address,
+			Event:   event.Name,
+			Index:   int64(event.Index),
+		}
+		for _, arg := range event.Args {
+			decoded.Args = append(decoded.Args, explorer.DecodedLogArg{
+				Name:    arg.Name,
+				Type:    arg.Type,
+				Indexed: arg.Indexed,
+				Value:   eventdecoder.FormatValue(arg.Value),
+			})
+		}
+		logs = append(logs, decoded)
+	}
+	return logs, nil
+}
+
+// getReceipt gets the receipt of an action from the blockchain or the indexer
+func (exp *Service) getReceipt(id string) (*action.Receipt, error) {
+	bytes, err := hex.DecodeString(id)
+	if err != nil {
+		return nil, err
+	}
 	var actionHash hash.Hash256
 	copy(actionHash[:], bytes)
 
 	// get receipt from boltdb
 	if !exp.cfg.UseIndexer {
-		receipt, err := exp.bc.GetReceiptByActionHash(actionHash)
-		if err != nil {
-			return explorer.Receipt{}, err
-		}
-		return convertReceiptToExplorerReceipt(receipt)
+		return exp.bc.GetReceiptByActionHash(actionHash)
 	}
 
 	// get receipt from indexer
 	blkHash, err := exp.idx.Indexer().GetBlockByIndex(config.IndexReceipt, actionHash)
 	if err != nil {
-		return explorer.Receipt{}, err
+		return nil, err
 	}
 	blk, err := exp.bc.GetBlockByHash(blkHash)
 	if err != nil {
-		return explorer.Receipt{}, err
+		return nil, err
 	}
 
 	for _, receipt := range blk.Receipts {
 		if receipt.Hash() == actionHash {
-			return convertReceiptToExplorerReceipt(receipt)
+			return receipt, nil
 		}
 	}
-	return explorer.Receipt{}, err
+	return nil, errors.Errorf("receipt of action %s isn't found in block %x", id, blkHash)
 }
 
 // GetCreateDeposit gets create deposit by ID
@@ -812,42 +859,7 @@ func (exp *Service) GetLastBlocksByRange(offset int64, limit int64) ([]explorer.
 			return []explorer.Block{}, err
 		}
 
-		blockHeaderPb := blk.ConvertToBlockHeaderPb()
-		hash, err := exp.bc.GetHashByHeight(uint64(height))
-		if err != nil {
-			return []explorer.Block{}, err
-		}
-
-		transfers, votes, executions := action.ClassifyActions(blk.Actions)
-		totalAmount := big.NewInt(0)
-		totalSize := uint32(0)
-		for _, transfer := range transfers {
-			totalAmount.Add(totalAmount, transfer.Amount())
-			totalSize += transfer.TotalSize()
-		}
-
-		txRoot := blk.TxRoot()
-		stateRoot := blk.StateRoot()
-		deltaStateDigest := blk.DeltaStateDigest()
-		explorerBlock := explorer.Block{
-			ID:         hex.EncodeToString(hash[:]),
-			Height:     int64(blockHeaderPb.Height),
-			Timestamp:  blockHeaderPb.GetTimestamp().GetSeconds(),
-			Transfers:  int64(len(transfers)),
-			Votes:      int64(len(votes)),
-			Executions: int64(len(executions)),
-			Amount:     totalAmount.String(),
-			Size:       int64(totalSize),
-			GenerateBy: explorer.BlockGenerator{
-				Name:    "",
-				Address: keypair.EncodePublicKey(blk.PublicKey()),
-			},
-			TxRoot:           hex.EncodeToString(txRoot[:]),
-			StateRoot:        hex.EncodeToString(stateRoot[:]),
-			DeltaStateDigest: hex.EncodeToString(deltaStateDigest[:]),
-		}
-
-		res = append(res, explorerBlock)
+		res = append(res, convertBlockToExplorerBlock(blk))
 	}
 
 	return res, nil
@@ -867,37 +879,8 @@ func (exp *Service) GetBlockByID(blkID string) (explorer.Block, error) {
 		return explorer.Block{}, err
 	}
 
-	blkHeaderPb := blk.ConvertToBlockHeaderPb()
-
-	transfers, votes, executions := action.ClassifyActions(blk.Actions)
-	totalAmount := big.NewInt(0)
-	totalSize := uint32(0)
-	for _, transfer := range transfers {
-		totalAmount.Add(totalAmount, transfer.Amount())
-		totalSize += transfer.TotalSize()
-	}
-
-	txRoot := blk.TxRoot()
-	stateRoot := blk.StateRoot()
-	deltaStateDigest := blk.DeltaStateDigest()
-	explorerBlock := explorer.Block{
-		ID:         blkID,
-		Height:     int64(blkHeaderPb.Height),
-		Timestamp:  blkHeaderPb.GetTimestamp().GetSeconds(),
-		Transfers:  int64(len(transfers)),
-		Votes:      int64(len(votes)),
-		Executions: int64(len(executions)),
-		Amount:     totalAmount.String(),
-		Size:       int64(totalSize),
-		GenerateBy: explorer.BlockGenerator{
-			Name:    "",
-			Address: keypair.EncodePublicKey(blk.PublicKey()),
-		},
-		TxRoot:           hex.EncodeToString(txRoot[:]),
-		StateRoot:        hex.EncodeToString(stateRoot[:]),
-		DeltaStateDigest: hex.EncodeToString(deltaStateDigest[:]),
-	}
-
+	explorerBlock := convertBlockToExplorerBlock(blk)
+	explorerBlock.ID = blkID
 	return explorerBlock, nil
 }
 
@@ -1096,33 +1079,10 @@ func (exp *Service) SendVote(voteJSON explorer.SendVoteRequest) (resp explorer.S
 		requestMtc.WithLabelValues("SendVote", succeed).Inc()
 	}()
 
-	selfPubKey, err := keypair.StringToPubKeyBytes(voteJSON.VoterPubKey)
+	actPb, err := convertExplorerVoteToActionPb(&voteJSON)
 	if err != nil {
 		return explorer.SendVoteResponse{}, err
 	}
-	signature, err := hex.DecodeString(voteJSON.Signature)
-	if err != nil {
-		return explorer.SendVoteResponse{}, err
-	}
-	gasPrice, ok := big.NewInt(0).SetString(voteJSON.GasPrice, 10)
-	if !ok {
-		return explorer.SendVoteResponse{}, errors.New("failed to set vote gas price")
-	}
-	actPb := &iotextypes.Action{
-		Core: &iotextypes.ActionCore{
-			Action: &iotextypes.ActionCore_Vote{
-				Vote: &iotextypes.Vote{
-					VoteeAddress: voteJSON.Votee,
-				},
-			},
-			Version:  uint32(voteJSON.Version),
-			Nonce:    uint64(voteJSON.Nonce),
-			GasLimit: uint64(voteJSON.GasLimit),
-			GasPrice: gasPrice.Bytes(),
-		},
-		SenderPubKey: selfPubKey,
-		Signature:    signature,
-	}
 	// broadcast to the network
 	if err := exp.broadcastHandler(context.Background(), exp.bc.ChainID(), actPb); err != nil {
 		return explorer.SendVoteResponse{}, err
@@ -1150,47 +1110,10 @@ func (exp *Service) PutSubChainBlock(putBlockJSON explorer.PutSubChainBlockReque
 		requestMtc.WithLabelValues("PutBlock", succeed).Inc()
 	}()
 
-	senderPubKey, err := keypair.StringToPubKeyBytes(putBlockJSON.SenderPubKey)
-	if err != nil {
-		return explorer.PutSubChainBlockResponse{}, err
-	}
-	signature, err := hex.DecodeString(putBlockJSON.Signature)
+	actPb, err := convertExplorerPutSubChainBlockToActionPb(&putBlockJSON)
 	if err != nil {
 		return explorer.PutSubChainBlockResponse{}, err
 	}
-	gasPrice, ok := big.NewInt(0).SetString(putBlockJSON.GasPrice, 10)
-	if !ok {
-		return explorer.PutSubChainBlockResponse{}, errors.New("failed to set vote gas price")
-	}
-
-	roots := make([]*iotextypes.MerkleRoot, 0)
-	for _, mr := range putBlockJSON.Roots {
-		v, err := hex.DecodeString(mr.Value)
-		if err != nil {
-			return explorer.PutSubChainBlockResponse{}, err
-		}
-		roots = append(roots, &iotextypes.MerkleRoot{
-			Name:  mr.Name,
-			Value: v,
-		})
-	}
-	actPb := &iotextypes.Action{
-		Core: &iotextypes.ActionCore{
-			Action: &iotextypes.ActionCore_PutBlock{
-				PutBlock: &iotextypes.PutBlock{
-					SubChainAddress: putBlockJSON.SubChainAddress,
-					Height:          uint64(putBlockJSON.Height),
-					Roots:           roots,
-				},
-			},
-			Version:  uint32(putBlockJSON.Version),
-			Nonce:    uint64(putBlockJSON.Nonce),
-			GasLimit: uint64(putBlockJSON.GasLimit),
-			GasPrice: gasPrice.Bytes(),
-		},
-		SenderPubKey: senderPubKey,
-		Signature:    signature,
-	}
 	// broadcast to the network
 	if err := exp.broadcastHandler(context.Background(), exp.bc.ChainID(), actPb); err != nil {
 		return explorer.PutSubChainBlockResponse{}, err
@@ -1381,40 +1304,10 @@ func (exp *Service) CreateDeposit(req explorer.CreateDepositRequest) (res explor
 		requestMtc.WithLabelValues("createDeposit", succeed).Inc()
 	}()
 
-	senderPubKey, err := keypair.StringToPubKeyBytes(req.SenderPubKey)
-	if err != nil {
-		return res, err
-	}
-	signature, err := hex.DecodeString(req.Signature)
+	actPb, err := convertExplorerCreateDepositToActionPb(&req)
 	if err != nil {
 		return res, err
 	}
-	amount, ok := big.NewInt(0).SetString(req.Amount, 10)
-	if !ok {
-		return res, errors.New("error when converting amount string into big int type")
-	}
-	gasPrice, ok := big.NewInt(0).SetString(req.GasPrice, 10)
-	if !ok {
-		return res, errors.New("error when converting gas price string into big int type")
-	}
-	actPb := &iotextypes.Action{
-		Core: &iotextypes.ActionCore{
-			Action: &iotextypes.ActionCore_CreateDeposit{
-				CreateDeposit: &iotextypes.CreateDeposit{
-					ChainID:   uint32(req.ChainID),
-					Amount:    amount.Bytes(),
-					Recipient: req.Recipient,
-				},
-			},
-			Version:  uint32(req.Version),
-			Nonce:    uint64(req.Nonce),
-			GasLimit: uint64(req.GasLimit),
-			GasPrice: gasPrice.Bytes(),
-		},
-		SenderPubKey: senderPubKey,
-		Signature:    signature,
-	}
-
 	// broadcast to the network
 	if err := exp.broadcastHandler(context.Background(), exp.bc.ChainID(), actPb); err != nil {
 		return res, err
@@ -1432,54 +1325,7 @@ func (exp *Service) CreateDeposit(req explorer.CreateDepositRequest) (res explor
 
 // GetDeposits returns the deposits of a sub-chain in the given range in descending order by the index
 func (exp *Service) GetDeposits(subChainID int64, offset int64, limit int64) ([]explorer.Deposit, error) {
-	subChainsInOp, err := exp.mainChain.SubChainsInOperation()
-	if err != nil {
-		return nil, err
-	}
-	var targetSubChain mainchain.InOperation
-	for _, subChainInOp := range subChainsInOp {
-		if subChainInOp.ID == uint32(subChainID) {
-			targetSubChain = subChainInOp
-		}
-	}
-	if targetSubChain.ID != uint32(subChainID) {
-		return nil, errors.Errorf("sub-chain %d is not found in operation", subChainID)
-	}
-	subChainAddr, err := address.FromBytes(targetSubChain.Addr)
-	if err != nil {
-		return nil, err
-	}
-	subChain, err := exp.mainChain.SubChain(subChainAddr)
-	if err != nil {
-		return nil, err
-	}
-	idx := uint64(offset)
-	// If the last deposit index is lower than the start index, reset it
-	if subChain.DepositCount-1 < idx {
-		idx = subChain.DepositCount - 1
-	}
-	var deposits []explorer.Deposit
-	for count := int64(0); count < limit; count++ {
-		deposit, err := exp.mainChain.Deposit(subChainAddr, idx)
-		if err != nil {
-			return nil, err
-		}
-		recipient, err := address.FromBytes(deposit.Addr)
-		if err != nil {
-			return nil, err
-		}
-		deposits = append(deposits, explorer.Deposit{
-			Amount:    deposit.Amount.String(),
-			Address:   recipient.String(),
-			Confirmed: deposit.Confirmed,
-		})
-		if idx > 0 {
-			idx--
-		} else {
-			break
-		}
-	}
-	return deposits, nil
+	return getDeposits(exp.mainChain, subChainID, offset, limit)
 }
 
 // SettleDeposit settles deposit on sub-chain
@@ -1492,39 +1338,10 @@ func (exp *Service) SettleDeposit(req explorer.SettleDepositRequest) (res explor
 		requestMtc.WithLabelValues("settleDeposit", succeed).Inc()
 	}()
 
-	senderPubKey, err := keypair.StringToPubKeyBytes(req.SenderPubKey)
-	if err != nil {
-		return res, err
-	}
-	signature, err := hex.DecodeString(req.Signature)
+	actPb, err := convertExplorerSettleDepositToActionPb(&req)
 	if err != nil {
 		return res, err
 	}
-	amount, ok := big.NewInt(0).SetString(req.Amount, 10)
-	if !ok {
-		return res, errors.New("error when converting amount string into big int type")
-	}
-	gasPrice, ok := big.NewInt(0).SetString(req.GasPrice, 10)
-	if !ok {
-		return res, errors.New("error when converting gas price string into big int type")
-	}
-	actPb := &iotextypes.Action{
-		Core: &iotextypes.ActionCore{
-			Action: &iotextypes.ActionCore_SettleDeposit{
-				SettleDeposit: &iotextypes.SettleDeposit{
-					Amount:    amount.Bytes(),
-					Index:     uint64(req.Index),
-					Recipient: req.Recipient,
-				},
-			},
-			Version:  uint32(req.Version),
-			Nonce:    uint64(req.Nonce),
-			GasLimit: uint64(req.GasLimit),
-			GasPrice: gasPrice.Bytes(),
-		},
-		SenderPubKey: senderPubKey,
-		Signature:    signature,
-	}
 	// broadcast to the network
 	if err := exp.broadcastHandler(context.Background(), exp.bc.ChainID(), actPb); err != nil {
 		return res, err
@@ -1569,6 +1386,66 @@ func (exp *Service) GetStateRootHash(blockHeight int64) (string, error) {
 	return hex.EncodeToString(rootHash[:]), nil
 }
 
+// getDeposits returns the deposits of a sub-chain in the given range in descending order by the index
+func getDeposits(
+	mainChain *mainchain.Protocol,
+	subChainID int64,
+	offset int64,
+	limit int64,
+) ([]explorer.Deposit, error) {
+	if mainChain == nil {
+		return nil, errors.New("main-chain protocol is not set")
+	}
+	subChainsInOp, err := mainChain.SubChainsInOperation()
+	if err != nil {
+		return nil, err
+	}
+	var targetSubChain mainchain.InOperation
+	for _, subChainInOp := range subChainsInOp {
+		if subChainInOp.ID == uint32(subChainID) {
+			targetSubChain = subChainInOp
+		}
+	}
+	if targetSubChain.ID != uint32(subChainID) {
+		return nil, errors.Errorf("sub-chain %d is not found in operation", subChainID)
+	}
+	subChainAddr, err := address.FromBytes(targetSubChain.Addr)
+	if err != nil {
+		return nil, err
+	}
+	subChain, err := mainChain.SubChain(subChainAddr)
+	if err != nil {
+		return nil, err
+	}
+	idx := uint64(offset)
+	// If the last deposit index is lower than the start index, reset it
+	if subChain.DepositCount-1 < idx {
+		idx = subChain.DepositCount - 1
+	}
+	var deposits []explorer.Deposit
+	for count := int64(0); count < limit; count++ {
+		deposit, err := mainChain.Deposit(subChainAddr, idx)
+		if err != nil {
+			return nil, err
+		}
+		recipient, err := address.FromBytes(deposit.Addr)
+		if err != nil {
+			return nil, err
+		}
+		deposits = append(deposits, explorer.Deposit{
+			Amount:    deposit.Amount.String(),
+			Address:   recipient.String(),
+			Confirmed: deposit.Confirmed,
+		})
+		if idx > 0 {
+			idx--
+		} else {
+			break
+		}
+	}
+	return deposits, nil
+}
+
 // getTransfer takes in a blockchain and transferHash and returns an Explorer Transfer
 func getTransfer(bc blockchain.Blockchain, ap actpool.ActPool, transferHash hash.Hash256, idx *indexservice.Server, useIndexer bool) (explorer.Transfer, error) {
 	explorerTransfer := explorer.Transfer{}
@@ -1821,6 +1698,38 @@ func castActionToSettleDeposit(selp action.SealedEnvelope, pending bool) (explor
 	return settleDeposit, nil
 }
 
+func convertBlockToExplorerBlock(blk *block.Block) explorer.Block {
+	transfers, votes, executions := action.ClassifyActions(blk.Actions)
+	totalAmount := big.NewInt(0)
+	totalSize := uint32(0)
+	for _, transfer := range transfers {
+		totalAmount.Add(totalAmount, transfer.Amount())
+		totalSize += transfer.TotalSize()
+	}
+
+	hash := blk.HashBlock()
+	txRoot := blk.TxRoot()
+	stateRoot := blk.StateRoot()
+	deltaStateDigest := blk.DeltaStateDigest()
+	return explorer.Block{
+		ID:         hex.EncodeToString(hash[:]),
+		Height:     int64(blk.Height()),
+		Timestamp:  blk.ConvertToBlockHeaderPb().GetTimestamp().GetSeconds(),
+		Transfers:  int64(len(transfers)),
+		Votes:      int64(len(votes)),
+		Executions: int64(len(executions)),
+		Amount:     totalAmount.String(),
+		Size:       int64(totalSize),
+		GenerateBy: explorer.BlockGenerator{
+			Name:    "",
+			Address: keypair.EncodePublicKey(blk.PublicKey()),
+		},
+		TxRoot:           hex.EncodeToString(txRoot[:]),
+		StateRoot:        hex.EncodeToString(stateRoot[:]),
+		DeltaStateDigest: hex.EncodeToString(deltaStateDigest[:]),
+	}
+}
+
 func convertTsfToExplorerTsf(selp action.SealedEnvelope, isPending bool) (explorer.Transfer, error) {
 	transfer, ok := selp.Action().(*action.Transfer)
 	if !ok {
@@ -1969,6 +1878,37 @@ func convertExplorerExecutionToActionPb(execution *explorer.Execution) (*iotexty
 	return actPb, nil
 }
 
+func convertExplorerVoteToActionPb(voteJSON *explorer.SendVoteRequest) (*iotextypes.Action, error) {
+	selfPubKey, err := keypair.StringToPubKeyBytes(voteJSON.VoterPubKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(voteJSON.Signature)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, ok := big.NewInt(0).SetString(voteJSON.GasPrice, 10)
+	if !ok {
+		return nil, errors.New("failed to set vote gas price")
+	}
+	actPb := &iotextypes.Action{
+		Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_Vote{
+				Vote: &iotextypes.Vote{
+					VoteeAddress: voteJSON.Votee,
+				},
+			},
+			Version:  uint32(voteJSON.Version),
+			Nonce:    uint64(voteJSON.Nonce),
+			GasLimit: uint64(voteJSON.GasLimit),
+			GasPrice: gasPrice.Bytes(),
+		},
+		SenderPubKey: selfPubKey,
+		Signature:    signature,
+	}
+	return actPb, nil
+}
+
 func convertExplorerTransferToActionPb(tsfJSON *explorer.SendTransferRequest,
 	maxTransferPayloadBytes uint64) (*iotextypes.Action, error) {
 	payload, err := hex.DecodeString(tsfJSON.Payload)
@@ -2018,3 +1958,124 @@ func convertExplorerTransferToActionPb(tsfJSON *explorer.SendTransferRequest,
 	}
 	return actPb, nil
 }
+
+func convertExplorerPutSubChainBlockToActionPb(
+	putBlockJSON *explorer.PutSubChainBlockRequest,
+) (*iotextypes.Action, error) {
+	senderPubKey, err := keypair.StringToPubKeyBytes(putBlockJSON.SenderPubKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(putBlockJSON.Signature)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, ok := big.NewInt(0).SetString(putBlockJSON.GasPrice, 10)
+	if !ok {
+		return nil, errors.New("failed to set vote gas price")
+	}
+
+	roots := make([]*iotextypes.MerkleRoot, 0)
+	for _, mr := range putBlockJSON.Roots {
+		v, err := hex.DecodeString(mr.Value)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, &iotextypes.MerkleRoot{
+			Name:  mr.Name,
+			Value: v,
+		})
+	}
+	actPb := &iotextypes.Action{
+		Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_PutBlock{
+				PutBlock: &iotextypes.PutBlock{
+					SubChainAddress: putBlockJSON.SubChainAddress,
+					Height:          uint64(putBlockJSON.Height),
+					Roots:           roots,
+				},
+			},
+			Version:  uint32(putBlockJSON.Version),
+			Nonce:    uint64(putBlockJSON.Nonce),
+			GasLimit: uint64(putBlockJSON.GasLimit),
+			GasPrice: gasPrice.Bytes(),
+		},
+		SenderPubKey: senderPubKey,
+		Signature:    signature,
+	}
+	return actPb, nil
+}
+
+func convertExplorerCreateDepositToActionPb(req *explorer.CreateDepositRequest) (*iotextypes.Action, error) {
+	senderPubKey, err := keypair.StringToPubKeyBytes(req.SenderPubKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := big.NewInt(0).SetString(req.Amount, 10)
+	if !ok {
+		return nil, errors.New("error when converting amount string into big int type")
+	}
+	gasPrice, ok := big.NewInt(0).SetString(req.GasPrice, 10)
+	if !ok {
+		return nil, errors.New("error when converting gas price string into big int type")
+	}
+	actPb := &iotextypes.Action{
+		Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_CreateDeposit{
+				CreateDeposit: &iotextypes.CreateDeposit{
+					ChainID:   uint32(req.ChainID),
+					Amount:    amount.Bytes(),
+					Recipient: req.Recipient,
+				},
+			},
+			Version:  uint32(req.Version),
+			Nonce:    uint64(req.Nonce),
+			GasLimit: uint64(req.GasLimit),
+			GasPrice: gasPrice.Bytes(),
+		},
+		SenderPubKey: senderPubKey,
+		Signature:    signature,
+	}
+	return actPb, nil
+}
+
+func convertExplorerSettleDepositToActionPb(req *explorer.SettleDepositRequest) (*iotextypes.Action, error) {
+	senderPubKey, err := keypair.StringToPubKeyBytes(req.SenderPubKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := big.NewInt(0).SetString(req.Amount, 10)
+	if !ok {
+		return nil, errors.New("error when converting amount string into big int type")
+	}
+	gasPrice, ok := big.NewInt(0).SetString(req.GasPrice, 10)
+	if !ok {
+		return nil, errors.New("error when converting gas price string into big int type")
+	}
+	actPb := &iotextypes.Action{
+		Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_SettleDeposit{
+				SettleDeposit: &iotextypes.SettleDeposit{
+					Amount:    amount.Bytes(),
+					Index:     uint64(req.Index),
+					Recipient: req.Recipient,
+				},
+			},
+			Version:  uint32(req.Version),
+			Nonce:    uint64(req.Nonce),
+			GasLimit: uint64(req.GasLimit),
+			GasPrice: gasPrice.Bytes(),
+		},
+		SenderPubKey: senderPubKey,
+		Signature:    signature,
+	}
+	return actPb, nil
+}
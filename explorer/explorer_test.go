@@ -18,6 +18,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/go-ethereum/crypto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -995,6 +996,85 @@ func TestExplorerGetReceiptByExecutionID(t *testing.T) {
 	require.Equal(eHashStr, receipt.Hash)
 }
 
+func TestExplorerGetDecodedLogsByActionID(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	cfg.Chain.EnableIndex = true
+	genesisCfg := genesis.Default
+
+	testutil.CleanupPath(t, testTriePath)
+	defer testutil.CleanupPath(t, testTriePath)
+	testutil.CleanupPath(t, testDBPath)
+	defer testutil.CleanupPath(t, testDBPath)
+
+	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
+	require.Nil(err)
+
+	// create chain
+	ctx := context.Background()
+	bc := blockchain.NewBlockchain(
+		cfg,
+		blockchain.PrecreatedStateFactoryOption(sf),
+		blockchain.InMemDaoOption(),
+		blockchain.GenesisOption(genesisCfg),
+	)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	sf.AddActionHandlers(execution.NewProtocol(bc))
+	require.NoError(addCreatorToFactory(sf))
+
+	svc := Service{bc: bc}
+
+	// The init code emits Deployed(address indexed creator, uint256 value) with the caller and 42, and deploys
+	// nothing. It's PUSH1 42 PUSH1 0 MSTORE CALLER PUSH32 <topic> PUSH1 32 PUSH1 0 LOG2 STOP
+	abi := `[{"type":"event","name":"Deployed","inputs":[
+		{"name":"creator","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false}]}]`
+	data := []byte{0x60, 0x2a, 0x60, 0x00, 0x52, 0x33, 0x7f}
+	data = append(data, crypto.Keccak256([]byte("Deployed(address,uint256)"))...)
+	data = append(data, 0x60, 0x20, 0x60, 0x00, 0xa2, 0x00)
+	execution, err := testutil.SignedExecution(action.EmptyAddress, ta.Keyinfo["producer"].PriKey, 1, big.NewInt(0), 1000000, big.NewInt(testutil.TestGasPrice), data)
+	require.NoError(err)
+
+	actionMap := make(map[string][]action.SealedEnvelope)
+	actionMap[ta.Addrinfo["producer"].String()] = []action.SealedEnvelope{execution}
+	blk, err := bc.MintNewBlock(
+		actionMap,
+		ta.Keyinfo["producer"].PubKey,
+		ta.Keyinfo["producer"].PriKey,
+		ta.Addrinfo["producer"].String(),
+		0,
+	)
+	require.NoError(err)
+	require.Nil(bc.CommitBlock(blk))
+
+	eHash := execution.Hash()
+	eHashStr := hex.EncodeToString(eHash[:])
+	logs, err := svc.GetDecodedLogsByActionID(eHashStr, abi)
+	require.NoError(err)
+	require.Equal(1, len(logs))
+	require.Equal("Deployed", logs[0].Event)
+	require.Equal([]explorer.DecodedLogArg{
+		{Name: "creator", Type: "address", Indexed: true, Value: ta.Addrinfo["producer"].String()},
+		{Name: "value", Type: "uint256", Indexed: false, Value: "42"},
+	}, logs[0].Args)
+
+	// The logs of the other events are skipped
+	logs, err = svc.GetDecodedLogsByActionID(eHashStr, `[]`)
+	require.NoError(err)
+	require.Empty(logs)
+
+	_, err = svc.GetDecodedLogsByActionID(eHashStr, "invalid abi")
+	require.Error(err)
+	_, err = svc.GetDecodedLogsByActionID(hex.EncodeToString(hash.ZeroHash256[:]), abi)
+	require.Error(err)
+}
+
 func TestService_CreateDeposit(t *testing.T) {
 	t.Parallel()
 
@@ -99,6 +99,20 @@ type SendExecutionResponse struct {
 	Receipt Receipt `json:"receipt"`
 }
 
+type DecodedLogArg struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+	Value   string `json:"value"`
+}
+
+type DecodedLog struct {
+	Address string          `json:"address"`
+	Event   string          `json:"event"`
+	Index   int64           `json:"index"`
+	Args    []DecodedLogArg `json:"args"`
+}
+
 type Vote struct {
 	Version     int64  `json:"version"`
 	ID          string `json:"ID"`
@@ -341,6 +355,7 @@ type Explorer interface {
 	GetPeers() (GetPeersResponse, error)
 	GetReceiptByExecutionID(id string) (Receipt, error)
 	GetReceiptByActionID(id string) (Receipt, error)
+	GetDecodedLogsByActionID(id string, abi string) ([]DecodedLog, error)
 	ReadExecutionState(request Execution) (string, error)
 	GetBlockOrActionByHash(hashStr string) (GetBlkOrActResponse, error)
 	CreateDeposit(request CreateDepositRequest) (CreateDepositResponse, error)
@@ -1010,6 +1025,24 @@ func (_p ExplorerProxy) GetReceiptByActionID(id string) (Receipt, error) {
 	return Receipt{}, _err
 }
 
+func (_p ExplorerProxy) GetDecodedLogsByActionID(id string, abi string) ([]DecodedLog, error) {
+	_res, _err := _p.client.Call("Explorer.getDecodedLogsByActionID", id, abi)
+	if _err == nil {
+		_retType := _p.idl.Method("Explorer.getDecodedLogsByActionID").Returns
+		_res, _err = barrister.Convert(_p.idl, &_retType, reflect.TypeOf([]DecodedLog{}), _res, "")
+	}
+	if _err == nil {
+		_cast, _ok := _res.([]DecodedLog)
+		if !_ok {
+			_t := reflect.TypeOf(_res)
+			_msg := fmt.Sprintf("Explorer.getDecodedLogsByActionID returned invalid type: %v", _t)
+			return []DecodedLog{}, &barrister.JsonRpcError{Code: -32000, Message: _msg}
+		}
+		return _cast, nil
+	}
+	return []DecodedLog{}, _err
+}
+
 func (_p ExplorerProxy) ReadExecutionState(request Execution) (string, error) {
 	_res, _err := _p.client.Call("Explorer.readExecutionState", request)
 	if _err == nil {
@@ -1813,6 +1846,90 @@ var IdlJsonRaw = `[
         "date_generated": 0,
         "checksum": ""
     },
+    {
+        "type": "struct",
+        "name": "DecodedLogArg",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "name",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "type",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "indexed",
+                "type": "bool",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "value",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
+    {
+        "type": "struct",
+        "name": "DecodedLog",
+        "comment": "",
+        "value": "",
+        "extends": "",
+        "fields": [
+            {
+                "name": "address",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "event",
+                "type": "string",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "index",
+                "type": "int",
+                "optional": false,
+                "is_array": false,
+                "comment": ""
+            },
+            {
+                "name": "args",
+                "type": "DecodedLogArg",
+                "optional": false,
+                "is_array": true,
+                "comment": ""
+            }
+        ],
+        "values": null,
+        "functions": null,
+        "barrister_version": "",
+        "date_generated": 0,
+        "checksum": ""
+    },
     {
         "type": "struct",
         "name": "Vote",
@@ -3965,6 +4082,33 @@ var IdlJsonRaw = `[
                     "comment": ""
                 }
             },
+            {
+                "name": "getDecodedLogsByActionID",
+                "comment": "get the logs in the receipt of an action decoded with the contract abi",
+                "params": [
+                    {
+                        "name": "id",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    },
+                    {
+                        "name": "abi",
+                        "type": "string",
+                        "optional": false,
+                        "is_array": false,
+                        "comment": ""
+                    }
+                ],
+                "returns": {
+                    "name": "",
+                    "type": "DecodedLog",
+                    "optional": false,
+                    "is_array": true,
+                    "comment": ""
+                }
+            },
             {
                 "name": "readExecutionState",
                 "comment": "read execution state",
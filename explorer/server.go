@@ -105,11 +105,12 @@ func NewServer(
 
 // SetMainChainProtocol sets the main-chain side multi-chain protocol
 func (s *Server) SetMainChainProtocol(p *mainchain.Protocol) {
-	svr, ok := s.exp.(*Service)
-	if !ok {
-		return
+	switch svr := s.exp.(type) {
+	case *Service:
+		svr.SetMainChainProtocol(p)
+	case *apiBridge:
+		svr.mainChain = p
 	}
-	svr.SetMainChainProtocol(p)
 }
 
 // Start starts the explorer server
@@ -121,7 +122,7 @@ func (s *Server) Start(_ context.Context) error {
 		s.jrpcSvr = explorer.NewJSONServer(idl, true, s.exp)
 		s.jrpcSvr.AddFilter(logFilter{})
 		s.httpSvr = http.Server{Handler: &corsAdaptor{expSvr: s.jrpcSvr}}
-		listener, err := net.Listen("tcp", ":"+portStr)
+		listener, err := net.Listen("tcp", net.JoinHostPort(s.cfg.Host, portStr))
 		if err != nil {
 			log.L().Panic("Error when creating network listener", zap.Error(err))
 		}
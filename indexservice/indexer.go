@@ -10,6 +10,7 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"fmt"
+	"sort"
 
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
@@ -40,6 +41,47 @@ type (
 		UserAddress string
 		IndexHash   string
 	}
+	// BlockParticipation defines the schema of "delegate participation" table
+	BlockParticipation struct {
+		NodeAddress string
+		BlockHeight uint64
+		Delegate    string
+		Proposed    bool
+		Endorsed    bool
+	}
+	// DelegateParticipation summarizes the participation of a delegate in a range of blocks
+	DelegateParticipation struct {
+		Delegate     string
+		Proposals    uint64
+		Endorsements uint64
+		MissedSlots  uint64
+	}
+	// BlockStats defines the schema of "block stats" table
+	BlockStats struct {
+		NodeAddress string
+		BlockHeight uint64
+		Timestamp   int64
+		NumActions  uint64
+		GasUsed     uint64
+	}
+	// AccountActivity defines the schema of "account activity" table, which records the addresses sending or
+	// receiving actions in a block
+	AccountActivity struct {
+		NodeAddress string
+		BlockHeight uint64
+		Address     string
+		Sent        bool
+	}
+	// EpochStats aggregates the statistics of the indexed blocks in a range of heights
+	EpochStats struct {
+		StartHeight   uint64
+		EndHeight     uint64
+		NumActions    uint64
+		AverageTPS    float64
+		GasUsed       uint64
+		ActiveSenders uint64
+		NewAccounts   uint64
+	}
 )
 
 // Indexer handles the index build for blocks
@@ -49,6 +91,12 @@ type Indexer struct {
 	hexEncodedNodeAddr string
 }
 
+const (
+	delegateParticipationTableName = "delegate_participation"
+	blockStatsTableName            = "block_stats"
+	accountActivityTableName       = "account_activity"
+)
+
 var (
 	// ErrNotExist indicates certain item does not exist in Blockchain database
 	ErrNotExist = errors.New("not exist in DB")
@@ -160,6 +208,29 @@ func (idx *Indexer) BuildIndex(blk *block.Block) error {
 			}
 		}
 
+		// log delegate participation
+		for _, participation := range BlockParticipations(blk) {
+			if err := idx.UpdateDelegateParticipation(tx, participation); err != nil {
+				return errors.Wrapf(err, "failed to update delegate participation table")
+			}
+		}
+
+		// log block stats
+		if err := idx.UpdateBlockStats(tx, BlockStatsOf(blk)); err != nil {
+			return errors.Wrapf(err, "failed to update block stats table")
+		}
+
+		// log account activity
+		activities, err := BlockActivities(blk)
+		if err != nil {
+			return err
+		}
+		for _, activity := range activities {
+			if err := idx.UpdateAccountActivity(tx, activity); err != nil {
+				return errors.Wrapf(err, "failed to update account activity table")
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -189,6 +260,39 @@ func (idx *Indexer) UpdateIndexHistory(blk *block.Block, tx *sql.Tx, indexIdenti
 	return nil
 }
 
+// UpdateDelegateParticipation stores the participation of a delegate in a block into delegate participation table
+func (idx *Indexer) UpdateDelegateParticipation(tx *sql.Tx, participation *BlockParticipation) error {
+	insertQuery := fmt.Sprintf("INSERT INTO %s (node_address,block_height,delegate,proposed,endorsed) VALUES (?, ?, ?, ?, ?)",
+		delegateParticipationTableName)
+	if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, participation.BlockHeight, participation.Delegate,
+		participation.Proposed, participation.Endorsed); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateBlockStats stores the statistics of a block into block stats table
+func (idx *Indexer) UpdateBlockStats(tx *sql.Tx, stats *BlockStats) error {
+	insertQuery := fmt.Sprintf("INSERT INTO %s (node_address,block_height,timestamp,num_actions,gas_used) VALUES (?, ?, ?, ?, ?)",
+		blockStatsTableName)
+	if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, stats.BlockHeight, stats.Timestamp, stats.NumActions,
+		stats.GasUsed); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateAccountActivity stores the activity of an address in a block into account activity table
+func (idx *Indexer) UpdateAccountActivity(tx *sql.Tx, activity *AccountActivity) error {
+	insertQuery := fmt.Sprintf("INSERT INTO %s (node_address,block_height,address,sent) VALUES (?, ?, ?, ?)",
+		accountActivityTableName)
+	if _, err := tx.Exec(insertQuery, idx.hexEncodedNodeAddr, activity.BlockHeight, activity.Address,
+		activity.Sent); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetIndexHistory gets index history
 func (idx *Indexer) GetIndexHistory(indexIdentifier string, userAddr string) ([]hash.Hash256, error) {
 	getQuery := fmt.Sprintf("SELECT * FROM %s WHERE node_address=? AND user_address=?",
@@ -269,6 +373,92 @@ func (idx *Indexer) getIndexHistory(getQuery string, userAddr string) ([]hash.Ha
 	return indexHashes, nil
 }
 
+// GetDelegateParticipation returns the participation of each delegate in the blocks within [startHeight, endHeight].
+// The given delegates are included even if they haven't participated in any of the blocks
+func (idx *Indexer) GetDelegateParticipation(
+	startHeight uint64,
+	endHeight uint64,
+	delegates []string,
+) ([]*DelegateParticipation, error) {
+	if startHeight > endHeight {
+		return nil, errors.Errorf("start height %d is greater than end height %d", startHeight, endHeight)
+	}
+	db := idx.store.GetDB()
+
+	getQuery := fmt.Sprintf("SELECT * FROM %s WHERE node_address=? AND block_height>=? AND block_height<=?",
+		delegateParticipationTableName)
+	stmt, err := db.Prepare(getQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to prepare get query")
+	}
+
+	rows, err := stmt.Query(idx.hexEncodedNodeAddr, startHeight, endHeight)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute get query")
+	}
+
+	var blockParticipation BlockParticipation
+	parsedRows, err := s.ParseSQLRows(rows, &blockParticipation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse results")
+	}
+
+	participations := make([]*BlockParticipation, 0, len(parsedRows))
+	for _, parsedRow := range parsedRows {
+		participations = append(participations, parsedRow.(*BlockParticipation))
+	}
+	return SummarizeParticipation(participations, endHeight-startHeight+1, delegates), nil
+}
+
+// GetEpochStats returns the statistics of the indexed blocks within [startHeight, endHeight]. The average TPS is
+// calculated over the time between the block before startHeight and the last indexed block
+func (idx *Indexer) GetEpochStats(startHeight uint64, endHeight uint64) (*EpochStats, error) {
+	if startHeight == 0 || startHeight > endHeight {
+		return nil, errors.Errorf("invalid height range [%d, %d]", startHeight, endHeight)
+	}
+	db := idx.store.GetDB()
+
+	getQuery := fmt.Sprintf("SELECT * FROM %s WHERE node_address=? AND block_height>=? AND block_height<=? "+
+		"ORDER BY block_height", blockStatsTableName)
+	stmt, err := db.Prepare(getQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to prepare get query")
+	}
+	rows, err := stmt.Query(idx.hexEncodedNodeAddr, startHeight-1, endHeight)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute get query")
+	}
+	var blockStats BlockStats
+	parsedRows, err := s.ParseSQLRows(rows, &blockStats)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse results")
+	}
+	stats := make([]*BlockStats, 0, len(parsedRows))
+	for _, parsedRow := range parsedRows {
+		stats = append(stats, parsedRow.(*BlockStats))
+	}
+	epochStats := SummarizeBlockStats(stats, startHeight)
+	if epochStats == nil {
+		return nil, errors.Wrapf(ErrNotExist, "no block within [%d, %d] is indexed", startHeight, endHeight)
+	}
+
+	// count the distinct senders
+	countQuery := fmt.Sprintf("SELECT COUNT(DISTINCT address) FROM %s WHERE node_address=? AND block_height>=? "+
+		"AND block_height<=? AND sent=?", accountActivityTableName)
+	if err := db.QueryRow(countQuery, idx.hexEncodedNodeAddr, startHeight, endHeight, true).
+		Scan(&epochStats.ActiveSenders); err != nil {
+		return nil, errors.Wrapf(err, "failed to count active senders")
+	}
+	// count the addresses whose first activity is within the range
+	countQuery = fmt.Sprintf("SELECT COUNT(*) FROM (SELECT address FROM %s WHERE node_address=? GROUP BY address "+
+		"HAVING MIN(block_height)>=? AND MIN(block_height)<=?) AS first_seen", accountActivityTableName)
+	if err := db.QueryRow(countQuery, idx.hexEncodedNodeAddr, startHeight, endHeight).
+		Scan(&epochStats.NewAccounts); err != nil {
+		return nil, errors.Wrapf(err, "failed to count new accounts")
+	}
+	return epochStats, nil
+}
+
 func (idx *Indexer) getBlockByIndexTableName(indexIndentifier string) string {
 	return fmt.Sprintf("block_by_index_%s", indexIndentifier)
 }
@@ -295,5 +485,162 @@ func (idx *Indexer) CreateTablesIfNotExist() error {
 		}
 	}
 
+	// create delegate participation table
+	if _, err := idx.store.GetDB().Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ([node_address] TEXT NOT NULL, "+
+		"[block_height] INTEGER NOT NULL, [delegate] TEXT NOT NULL, [proposed] BOOLEAN NOT NULL, "+
+		"[endorsed] BOOLEAN NOT NULL)", delegateParticipationTableName)); err != nil {
+		return err
+	}
+
+	// create block stats table
+	if _, err := idx.store.GetDB().Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ([node_address] TEXT NOT NULL, "+
+		"[block_height] INTEGER NOT NULL, [timestamp] INTEGER NOT NULL, [num_actions] INTEGER NOT NULL, "+
+		"[gas_used] INTEGER NOT NULL)", blockStatsTableName)); err != nil {
+		return err
+	}
+
+	// create account activity table
+	if _, err := idx.store.GetDB().Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ([node_address] TEXT NOT NULL, "+
+		"[block_height] INTEGER NOT NULL, [address] TEXT NOT NULL, [sent] BOOLEAN NOT NULL)",
+		accountActivityTableName)); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// BlockParticipations returns the participation of the delegates in a block, which includes the producer of the block
+// and the delegates who have endorsed the commit of the block
+func BlockParticipations(blk *block.Block) []*BlockParticipation {
+	producer := blk.ProducerAddress()
+	participations := []*BlockParticipation{{
+		BlockHeight: blk.Height(),
+		Delegate:    producer,
+		Proposed:    true,
+	}}
+	for _, endorser := range blk.CommitEndorsers() {
+		if endorser == producer {
+			participations[0].Endorsed = true
+			continue
+		}
+		participations = append(participations, &BlockParticipation{
+			BlockHeight: blk.Height(),
+			Delegate:    endorser,
+			Endorsed:    true,
+		})
+	}
+	return participations
+}
+
+// SummarizeParticipation aggregates the participation of the delegates in numBlocks blocks. A slot is regarded as
+// missed by a delegate if it has neither proposed nor endorsed the block. The given delegates are included with all the
+// slots missed if they haven't participated in any of the blocks, and the result is sorted by delegate address
+func SummarizeParticipation(
+	participations []*BlockParticipation,
+	numBlocks uint64,
+	delegates []string,
+) []*DelegateParticipation {
+	summaries := map[string]*DelegateParticipation{}
+	for _, delegate := range delegates {
+		summaries[delegate] = &DelegateParticipation{Delegate: delegate, MissedSlots: numBlocks}
+	}
+	for _, participation := range participations {
+		summary, ok := summaries[participation.Delegate]
+		if !ok {
+			summary = &DelegateParticipation{Delegate: participation.Delegate, MissedSlots: numBlocks}
+			summaries[participation.Delegate] = summary
+		}
+		if participation.Proposed {
+			summary.Proposals++
+		}
+		if participation.Endorsed {
+			summary.Endorsements++
+		}
+		if summary.MissedSlots > 0 {
+			summary.MissedSlots--
+		}
+	}
+	res := make([]*DelegateParticipation, 0, len(summaries))
+	for _, summary := range summaries {
+		res = append(res, summary)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Delegate < res[j].Delegate })
+	return res
+}
+
+// BlockStatsOf returns the number of actions and the gas used in a block
+func BlockStatsOf(blk *block.Block) *BlockStats {
+	stats := &BlockStats{
+		BlockHeight: blk.Height(),
+		Timestamp:   blk.Timestamp(),
+		NumActions:  uint64(len(blk.Actions)),
+	}
+	for _, receipt := range blk.Receipts {
+		stats.GasUsed += receipt.GasConsumed
+	}
+	return stats
+}
+
+// BlockActivities returns the addresses sending or receiving the actions in a block, each of which appears once
+func BlockActivities(blk *block.Block) ([]*AccountActivity, error) {
+	var activities []*AccountActivity
+	byAddress := map[string]*AccountActivity{}
+	record := func(addr string, sent bool) {
+		activity, ok := byAddress[addr]
+		if !ok {
+			activity = &AccountActivity{BlockHeight: blk.Height(), Address: addr}
+			byAddress[addr] = activity
+			activities = append(activities, activity)
+		}
+		activity.Sent = activity.Sent || sent
+	}
+	for _, selp := range blk.Actions {
+		callerPKHash := keypair.HashPubKey(selp.SrcPubkey())
+		callerAddr, err := address.FromBytes(callerPKHash[:])
+		if err != nil {
+			return nil, err
+		}
+		record(callerAddr.String(), true)
+		if dst, ok := selp.Destination(); ok && dst != "" {
+			record(dst, false)
+		}
+	}
+	return activities, nil
+}
+
+// SummarizeBlockStats aggregates the statistics of the blocks from startHeight on, which are sorted by height. The
+// block right before startHeight, if given, marks the beginning of the time over which the average TPS is calculated.
+// It returns nil if no block from startHeight on is given
+func SummarizeBlockStats(stats []*BlockStats, startHeight uint64) *EpochStats {
+	var (
+		epochStats *EpochStats
+		beginTime  int64
+		endTime    int64
+	)
+	for _, stat := range stats {
+		if stat.BlockHeight < startHeight {
+			beginTime = stat.Timestamp
+			continue
+		}
+		if epochStats == nil {
+			epochStats = &EpochStats{StartHeight: startHeight}
+			if beginTime == 0 {
+				beginTime = stat.Timestamp
+			}
+		}
+		epochStats.EndHeight = stat.BlockHeight
+		epochStats.NumActions += stat.NumActions
+		epochStats.GasUsed += stat.GasUsed
+		endTime = stat.Timestamp
+	}
+	if epochStats == nil {
+		return nil
+	}
+	duration := endTime - beginTime
+	// if time duration is less than 1 second, we set it to be 1 second
+	if duration <= 0 {
+		duration = 1
+	}
+	epochStats.AverageTPS = float64(epochStats.NumActions) / float64(duration)
+	return epochStats
+}
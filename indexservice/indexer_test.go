@@ -11,12 +11,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/db/sql"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/version"
@@ -179,6 +182,43 @@ func testSQLite3StorePutGet(store sql.Store, t *testing.T) {
 	require.Nil(err)
 	require.Equal(blkHash4, blk.HashBlock())
 
+	// get delegate participation
+	participations, err := idx.GetDelegateParticipation(123456788, 123456789, nil)
+	require.Nil(err)
+	require.Equal(1, len(participations))
+	require.Equal(&DelegateParticipation{
+		Delegate:     addr1,
+		Proposals:    1,
+		Endorsements: 0,
+		MissedSlots:  1,
+	}, participations[0])
+	_, err = idx.GetDelegateParticipation(123456789, 123456788, nil)
+	require.Error(err)
+
+	// get epoch stats
+	epochStats, err := idx.GetEpochStats(123456789, 123456790)
+	require.Nil(err)
+	require.Equal(&EpochStats{
+		StartHeight:   123456789,
+		EndHeight:     123456789,
+		NumActions:    3,
+		AverageTPS:    3,
+		GasUsed:       3,
+		ActiveSenders: 1,
+		NewAccounts:   2,
+	}, epochStats)
+	_, err = idx.GetEpochStats(123456790, 123456791)
+	require.Equal(ErrNotExist, errors.Cause(err))
+	_, err = idx.GetEpochStats(123456789, 123456788)
+	require.Error(err)
+
+	for _, tableName := range []string{delegateParticipationTableName, blockStatsTableName, accountActivityTableName} {
+		stmt, err := db.Prepare(fmt.Sprintf("DELETE FROM %s WHERE node_address=?", tableName))
+		require.Nil(err)
+		_, err = stmt.Exec(nodeAddr)
+		require.Nil(err)
+	}
+
 	// create block by index tables
 	for _, indexIdentifier := range idx.cfg.BlockByIndexList {
 		stmt, err := db.Prepare(fmt.Sprintf("DELETE FROM %s WHERE node_address=?",
@@ -198,6 +238,145 @@ func testSQLite3StorePutGet(store sql.Store, t *testing.T) {
 	}
 }
 
+func TestSummarizeParticipation(t *testing.T) {
+	require := require.New(t)
+
+	producer := testaddress.Keyinfo["alfa"]
+	endorser := testaddress.Keyinfo["bravo"]
+	blk := block.Block{}
+	vote := endorsement.NewConsensusVote([]byte("hash"), 2, 0, endorsement.COMMIT)
+	lock := endorsement.NewConsensusVote([]byte("hash"), 2, 0, endorsement.LOCK)
+	require.NoError(blk.ConvertFromBlockPb(&iotextypes.Block{
+		Header: &iotextypes.BlockHeader{
+			Version: version.ProtocolVersion,
+			Height:  2,
+			Pubkey:  keypair.PublicKeyToBytes(producer.PubKey),
+		},
+		Footer: &iotextypes.BlockFooter{
+			Endorsements: &iotextypes.EndorsementSet{
+				BlockHash: []byte("hash"),
+				Endorsements: []*iotextypes.Endorsement{
+					endorsement.NewEndorsement(
+						vote, producer.PubKey, producer.PriKey, testaddress.Addrinfo["alfa"].String(),
+					).ToProtoMsg(),
+					endorsement.NewEndorsement(
+						vote, endorser.PubKey, endorser.PriKey, testaddress.Addrinfo["bravo"].String(),
+					).ToProtoMsg(),
+					// Non-commit endorsement isn't counted
+					endorsement.NewEndorsement(
+						lock, producer.PubKey, producer.PriKey, testaddress.Addrinfo["charlie"].String(),
+					).ToProtoMsg(),
+				},
+			},
+		},
+	}))
+
+	participations := BlockParticipations(&blk)
+	require.Equal([]*BlockParticipation{
+		{BlockHeight: 2, Delegate: testaddress.Addrinfo["alfa"].String(), Proposed: true, Endorsed: true},
+		{BlockHeight: 2, Delegate: testaddress.Addrinfo["bravo"].String(), Endorsed: true},
+	}, participations)
+
+	participations = append(participations, &BlockParticipation{
+		BlockHeight: 3,
+		Delegate:    testaddress.Addrinfo["bravo"].String(),
+		Proposed:    true,
+	})
+	// The delegate absent from all the blocks is included with all the slots missed
+	absent := testaddress.Addrinfo["delta"].String()
+	summaries := SummarizeParticipation(participations, 3, []string{testaddress.Addrinfo["alfa"].String(), absent})
+	require.Equal(3, len(summaries))
+	summaryByDelegate := map[string]*DelegateParticipation{}
+	for _, summary := range summaries {
+		summaryByDelegate[summary.Delegate] = summary
+	}
+	require.Equal(&DelegateParticipation{
+		Delegate:     testaddress.Addrinfo["alfa"].String(),
+		Proposals:    1,
+		Endorsements: 1,
+		MissedSlots:  2,
+	}, summaryByDelegate[testaddress.Addrinfo["alfa"].String()])
+	require.Equal(&DelegateParticipation{
+		Delegate:     testaddress.Addrinfo["bravo"].String(),
+		Proposals:    1,
+		Endorsements: 1,
+		MissedSlots:  1,
+	}, summaryByDelegate[testaddress.Addrinfo["bravo"].String()])
+	require.Equal(&DelegateParticipation{Delegate: absent, MissedSlots: 3}, summaryByDelegate[absent])
+}
+
+func TestSummarizeBlockStats(t *testing.T) {
+	require := require.New(t)
+
+	addr1 := testaddress.Addrinfo["alfa"].String()
+	addr2 := testaddress.Addrinfo["bravo"].String()
+	pubKey1 := testaddress.Keyinfo["alfa"].PubKey
+	pubKey2 := testaddress.Keyinfo["bravo"].PubKey
+	blk := block.Block{}
+	require.NoError(blk.ConvertFromBlockPb(&iotextypes.Block{
+		Header: &iotextypes.BlockHeader{
+			Version:   version.ProtocolVersion,
+			Height:    5,
+			Timestamp: &timestamp.Timestamp{Seconds: 100},
+			Pubkey:    keypair.PublicKeyToBytes(pubKey1),
+		},
+		Actions: []*iotextypes.Action{
+			{
+				Core: &iotextypes.ActionCore{
+					Action: &iotextypes.ActionCore_Transfer{
+						Transfer: &iotextypes.Transfer{Recipient: addr2},
+					},
+					Version: version.ProtocolVersion,
+					Nonce:   1,
+				},
+				SenderPubKey: keypair.PublicKeyToBytes(pubKey1),
+			},
+			{
+				Core: &iotextypes.ActionCore{
+					Action: &iotextypes.ActionCore_Transfer{
+						Transfer: &iotextypes.Transfer{Recipient: addr1},
+					},
+					Version: version.ProtocolVersion,
+					Nonce:   1,
+				},
+				SenderPubKey: keypair.PublicKeyToBytes(pubKey2),
+			},
+		},
+	}))
+	blk.Receipts = []*action.Receipt{{GasConsumed: 10}, {GasConsumed: 20}}
+
+	require.Equal(&BlockStats{BlockHeight: 5, Timestamp: 100, NumActions: 2, GasUsed: 30}, BlockStatsOf(&blk))
+	activities, err := BlockActivities(&blk)
+	require.NoError(err)
+	require.Equal([]*AccountActivity{
+		{BlockHeight: 5, Address: addr1, Sent: true},
+		{BlockHeight: 5, Address: addr2, Sent: true},
+	}, activities)
+
+	stats := []*BlockStats{
+		{BlockHeight: 4, Timestamp: 90, NumActions: 7, GasUsed: 70},
+		{BlockHeight: 5, Timestamp: 100, NumActions: 2, GasUsed: 30},
+		{BlockHeight: 6, Timestamp: 110, NumActions: 8, GasUsed: 80},
+	}
+	// the block before the start height marks the beginning of the time
+	require.Equal(&EpochStats{
+		StartHeight: 5,
+		EndHeight:   6,
+		NumActions:  10,
+		AverageTPS:  0.5,
+		GasUsed:     110,
+	}, SummarizeBlockStats(stats, 5))
+	// otherwise the time begins at the first block
+	require.Equal(&EpochStats{
+		StartHeight: 4,
+		EndHeight:   6,
+		NumActions:  17,
+		AverageTPS:  0.85,
+		GasUsed:     180,
+	}, SummarizeBlockStats(stats, 4))
+	require.Nil(SummarizeBlockStats(stats[:1], 5))
+}
+
 func TestIndexServiceOnSqlite3(t *testing.T) {
 	t.Run("Indexer", func(t *testing.T) {
 		testutil.CleanupPath(t, config.Default.DB.SQLITE3.SQLite3File)
@@ -68,6 +68,7 @@ mockgen -destination=./test/mock/mock_lifecycle/mock_lifecycle.go \
 mkdir -p ./test/mock/mock_actpool
 mockgen -destination=./test/mock/mock_actpool/mock_actpool.go  \
         -source=./actpool/actpool.go \
+        -imports =github.com/iotexproject/iotex-core/actpool \
         -package=mock_actpool \
         ActPool
 
@@ -12,11 +12,13 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	p2p "github.com/iotexproject/go-p2p"
+	peer "github.com/libp2p/go-libp2p-peer"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	multiaddr "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
@@ -74,6 +76,8 @@ type Agent struct {
 	broadcastInboundHandler    HandleBroadcastInbound
 	unicastInboundAsyncHandler HandleUnicastInboundAsync
 	host                       *p2p.Host
+	blockedMu                  sync.RWMutex
+	blocked                    map[peer.ID]time.Time // peer -> time the block ends
 }
 
 // NewAgent instantiates a local P2P agent instance
@@ -82,6 +86,7 @@ func NewAgent(cfg config.Network, broadcastHandler HandleBroadcastInbound, unica
 		cfg:                        cfg,
 		broadcastInboundHandler:    broadcastHandler,
 		unicastInboundAsyncHandler: unicastHandler,
+		blocked:                    make(map[peer.ID]time.Time),
 	}
 }
 
@@ -89,6 +94,7 @@ func NewAgent(cfg config.Network, broadcastHandler HandleBroadcastInbound, unica
 func (p *Agent) Start(ctx context.Context) error {
 	ready := make(chan interface{})
 	p2p.SetLogger(log.L())
+	// TODO listen on and advertise the IPv6 addresses once go-p2p supports them, which only binds to IPv4 for now
 	opts := []p2p.Option{
 		p2p.HostName(p.cfg.Host),
 		p2p.Port(p.cfg.Port),
@@ -100,6 +106,10 @@ func (p *Agent) Start(ctx context.Context) error {
 		opts = append(opts, p2p.ExternalHostName(p.cfg.ExternalHost))
 		opts = append(opts, p2p.ExternalPort(p.cfg.ExternalPort))
 	}
+	bootAddrs, err := bootstrapAddresses(p.cfg.BootstrapNodes)
+	if err != nil {
+		return err
+	}
 	host, err := p2p.NewHost(ctx, opts...)
 	if err != nil {
 		return errors.Wrap(err, "error when instantiating Agent host")
@@ -191,6 +201,10 @@ func (p *Agent) Start(ctx context.Context) error {
 			return
 		}
 		peerID = stream.Conn().RemotePeer().Pretty()
+		if p.isBlocked(stream.Conn().RemotePeer()) {
+			err = errors.Errorf("peer %s is blocked", peerID)
+			return
+		}
 		peerInfo := peerstore.PeerInfo{
 			ID:    stream.Conn().RemotePeer(),
 			Addrs: []multiaddr.Multiaddr{stream.Conn().RemoteMultiaddr()},
@@ -201,18 +215,18 @@ func (p *Agent) Start(ctx context.Context) error {
 		return errors.Wrap(err, "error when adding unicast pubsub")
 	}
 
-	if len(p.cfg.BootstrapNodes) > 0 {
+	if len(bootAddrs) > 0 {
 		var (
 			tryNum  int
 			errNum  int
 			connNum int
 		)
-		conn := make(chan interface{}, len(p.cfg.BootstrapNodes))
-		connErrChan := make(chan error, len(p.cfg.BootstrapNodes))
+		conn := make(chan interface{}, len(bootAddrs))
+		connErrChan := make(chan error, len(bootAddrs))
 
 		// try to connect to all bootstrap node beside itself.
-		for _, bootstrapNode := range p.cfg.BootstrapNodes {
-			bootAddr := multiaddr.StringCast(bootstrapNode)
+		for _, bootAddr := range bootAddrs {
+			bootAddr := bootAddr
 			if strings.Contains(bootAddr.String(), host.HostIdentity()) {
 				continue
 			}
@@ -330,6 +344,10 @@ func (p *Agent) UnicastOutbound(ctx context.Context, peer peerstore.PeerInfo, ms
 	if err != nil {
 		return
 	}
+	if p.isBlocked(peer.ID) {
+		err = errors.Errorf("peer %s is blocked", peer.ID.Pretty())
+		return
+	}
 	p2pCtx, ok := GetContext(ctx)
 	if !ok {
 		err = errors.New("P2P context doesn't exist")
@@ -360,9 +378,51 @@ func (p *Agent) Info() peerstore.PeerInfo { return p.host.Info() }
 // Self returns the self network address
 func (p *Agent) Self() []multiaddr.Multiaddr { return p.host.Addresses() }
 
-// Neighbors returns the neighbors' peer info
+// Neighbors returns the neighbors' peer info, excluding the blocked peers
 func (p *Agent) Neighbors(ctx context.Context) ([]peerstore.PeerInfo, error) {
-	return p.host.Neighbors(ctx)
+	neighbors, err := p.host.Neighbors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.filterBlocked(neighbors), nil
+}
+
+// BlockPeer blocks the peer for the duration, during which the unicast messages from and to the peer are dropped, and
+// the peer isn't returned as a neighbor
+func (p *Agent) BlockPeer(target peerstore.PeerInfo, duration time.Duration) {
+	p.blockedMu.Lock()
+	defer p.blockedMu.Unlock()
+	p.blocked[target.ID] = time.Now().Add(duration)
+	log.L().Warn("Blocked peer.", zap.String("peer", target.ID.Pretty()), zap.Duration("duration", duration))
+}
+
+func (p *Agent) isBlocked(id peer.ID) bool {
+	p.blockedMu.RLock()
+	end, ok := p.blocked[id]
+	p.blockedMu.RUnlock()
+	if !ok {
+		return false
+	}
+	if time.Now().Before(end) {
+		return true
+	}
+	p.blockedMu.Lock()
+	defer p.blockedMu.Unlock()
+	// The peer may have been blocked again in between
+	if end, ok := p.blocked[id]; ok && !time.Now().Before(end) {
+		delete(p.blocked, id)
+	}
+	return false
+}
+
+func (p *Agent) filterBlocked(peers []peerstore.PeerInfo) []peerstore.PeerInfo {
+	filtered := peers[:0]
+	for _, pi := range peers {
+		if !p.isBlocked(pi.ID) {
+			filtered = append(filtered, pi)
+		}
+	}
+	return filtered
 }
 
 func convertAppMsg(msg proto.Message) (uint32, []byte, error) {
@@ -377,6 +437,19 @@ func convertAppMsg(msg proto.Message) (uint32, []byte, error) {
 	return msgType, msgBody, nil
 }
 
+// bootstrapAddresses parses the multiaddrs of the bootstrap nodes, which could be /ip4 or /ip6 ones
+func bootstrapAddresses(nodes []string) ([]multiaddr.Multiaddr, error) {
+	addrs := make([]multiaddr.Multiaddr, 0, len(nodes))
+	for _, node := range nodes {
+		addr, err := multiaddr.NewMultiaddr(node)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error when parsing the address of bootstrap node %s", node)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
 func exponentialRetry(f func() error, retryInterval time.Duration, numRetries int) (err error) {
 	for i := 0; i < numRetries; i++ {
 		if err = f(); err == nil {
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/stretchr/testify/require"
 
@@ -127,3 +128,40 @@ func TestUnicast(t *testing.T) {
 		}))
 	}
 }
+
+func TestBlockPeer(t *testing.T) {
+	require := require.New(t)
+
+	agent := NewAgent(config.Default.Network, nil, nil)
+	blocked := peerstore.PeerInfo{ID: peer.ID("blocked")}
+	expired := peerstore.PeerInfo{ID: peer.ID("expired")}
+	other := peerstore.PeerInfo{ID: peer.ID("other")}
+	agent.BlockPeer(blocked, time.Minute)
+	agent.BlockPeer(expired, -time.Second)
+
+	require.True(agent.isBlocked(blocked.ID))
+	require.False(agent.isBlocked(expired.ID))
+	require.False(agent.isBlocked(other.ID))
+	require.Equal(
+		[]peerstore.PeerInfo{expired, other},
+		agent.filterBlocked([]peerstore.PeerInfo{blocked, expired, other}),
+	)
+}
+
+func TestAgentAddresses(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	b := func(_ context.Context, _ uint32, _ proto.Message) {}
+	u := func(_ context.Context, _ uint32, _ peerstore.PeerInfo, _ proto.Message) {}
+
+	// An invalid bootstrap node address is rejected rather than panicking
+	cfg := config.Network{Host: "127.0.0.1", Port: testutil.RandomPort(), BootstrapNodes: []string{"127.0.0.1:4689"}}
+	require.Error(NewAgent(cfg, b, u).Start(ctx))
+
+	addrs, err := bootstrapAddresses([]string{
+		"/ip4/127.0.0.1/tcp/4689/ipfs/12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ",
+		"/ip6/::1/tcp/4689/ipfs/12D3KooWJwW6pUpTkxPTMv84RPLPMQVEAjZ6fvJuX4oZrvW5DAGQ",
+	})
+	require.NoError(err)
+	require.Equal(2, len(addrs))
+}
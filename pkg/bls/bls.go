@@ -0,0 +1,186 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package bls implements the BLS signatures on the BN256 curve for the threshold scheme of the distributed key
+// generation, whose polynomial commitments are publicly verifiable. The public keys are points on G2, and the
+// signatures are points on G1. The BLS keys of the delegates are the ones of the crypto package instead
+package bls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+
+	bn256 "github.com/iotexproject/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// PrivateKeyLength is the length of a private key in bytes
+	PrivateKeyLength = 32
+	// PublicKeyLength is the length of a public key in bytes
+	PublicKeyLength = 128
+	// SignatureLength is the length of a signature in bytes
+	SignatureLength = 64
+)
+
+var (
+	// ErrInvalidKey indicates the key is malformed
+	ErrInvalidKey = errors.New("invalid BLS key")
+	// ErrInvalidSignature indicates the signature is malformed or doesn't match the message
+	ErrInvalidSignature = errors.New("invalid BLS signature")
+
+	// signatureDomain separates the hashes of the messages signed with the keys from the other hashes
+	signatureDomain = []byte("BLS_SIG_BN256G1")
+	g2Generator     = new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	curveB          = big.NewInt(3)
+)
+
+type (
+	// PrivateKey is a BLS private key
+	PrivateKey struct {
+		x *big.Int
+	}
+
+	// PublicKey is a BLS public key
+	PublicKey struct {
+		p *bn256.G2
+	}
+)
+
+// GenerateKey generates a random private key
+func GenerateKey() (*PrivateKey, error) {
+	x, _, err := bn256.RandomG2(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "error when generating BLS private key")
+	}
+	return &PrivateKey{x: x}, nil
+}
+
+// BytesToPrivateKey converts a byte slice to a private key
+func BytesToPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != PrivateKeyLength {
+		return nil, errors.Wrapf(ErrInvalidKey, "private key length %d, %d expected", len(b), PrivateKeyLength)
+	}
+	x := new(big.Int).SetBytes(b)
+	if x.Sign() == 0 || x.Cmp(bn256.Order) >= 0 {
+		return nil, errors.Wrap(ErrInvalidKey, "private key is out of range")
+	}
+	return &PrivateKey{x: x}, nil
+}
+
+// Bytes returns the private key in bytes
+func (sk *PrivateKey) Bytes() []byte {
+	b := make([]byte, PrivateKeyLength)
+	xb := sk.x.Bytes()
+	copy(b[PrivateKeyLength-len(xb):], xb)
+	return b
+}
+
+// PublicKey returns the public key of the private key
+func (sk *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{p: new(bn256.G2).ScalarBaseMult(sk.x)}
+}
+
+// Sign signs the message
+func (sk *PrivateKey) Sign(msg []byte) []byte {
+	return new(bn256.G1).ScalarMult(hashToG1(signatureDomain, msg), sk.x).Marshal()
+}
+
+// BytesToPublicKey converts a byte slice to a public key
+func BytesToPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeyLength || isZero(b) {
+		return nil, errors.Wrap(ErrInvalidKey, "public key is malformed")
+	}
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(b); err != nil {
+		return nil, errors.Wrapf(ErrInvalidKey, "public key is malformed: %v", err)
+	}
+	// Unlike G1, the curve of G2 has points out of the group
+	if !isZero(new(bn256.G2).ScalarMult(p, bn256.Order).Marshal()) {
+		return nil, errors.Wrap(ErrInvalidKey, "public key is out of the group")
+	}
+	return &PublicKey{p: p}, nil
+}
+
+// Bytes returns the public key in bytes
+func (pk *PublicKey) Bytes() []byte {
+	return pk.p.Marshal()
+}
+
+// Equal returns whether the two public keys are the same
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return other != nil && bytes.Equal(pk.Bytes(), other.Bytes())
+}
+
+// Verify verifies the signature of the message
+func (pk *PublicKey) Verify(msg []byte, sig []byte) error {
+	return verify(pk.p, hashToG1(signatureDomain, msg), sig)
+}
+
+// verify checks e(sig, g2) == e(h, pk), i.e., e(-sig, g2) * e(h, pk) == 1
+func verify(pk *bn256.G2, h *bn256.G1, sig []byte) error {
+	s, err := toG1(sig)
+	if err != nil {
+		return err
+	}
+	negSig := new(bn256.G1).Neg(s)
+	if !bn256.PairingCheck([]*bn256.G1{negSig, h}, []*bn256.G2{g2Generator, pk}) {
+		return errors.Wrap(ErrInvalidSignature, "signature doesn't match")
+	}
+	return nil
+}
+
+func toG1(sig []byte) (*bn256.G1, error) {
+	if len(sig) != SignatureLength || isZero(sig) {
+		return nil, errors.Wrap(ErrInvalidSignature, "signature is malformed")
+	}
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(sig); err != nil {
+		return nil, errors.Wrapf(ErrInvalidSignature, "signature is malformed: %v", err)
+	}
+	return p, nil
+}
+
+// hashToG1 maps the message onto a point on G1 by trying the hashes of the message with an increasing counter as
+// the x coordinate, until x³+3 is a square. As the cofactor of G1 is 1, every point on the curve is in G1
+func hashToG1(domain []byte, msg []byte) *bn256.G1 {
+	for counter := uint32(0); ; counter++ {
+		stream := append([]byte{}, domain...)
+		stream = append(stream, byteutil.Uint32ToBytes(counter)...)
+		stream = append(stream, msg...)
+		h := hash.Hash256b(stream)
+		x := new(big.Int).SetBytes(h[:])
+		x.Mod(x, bn256.P)
+		rhs := new(big.Int).Exp(x, big.NewInt(3), bn256.P)
+		rhs.Add(rhs, curveB).Mod(rhs, bn256.P)
+		y := new(big.Int).ModSqrt(rhs, bn256.P)
+		if y == nil {
+			continue
+		}
+		b := make([]byte, SignatureLength)
+		xb, yb := x.Bytes(), y.Bytes()
+		copy(b[32-len(xb):32], xb)
+		copy(b[SignatureLength-len(yb):], yb)
+		p := new(bn256.G1)
+		if _, err := p.Unmarshal(b); err != nil {
+			continue
+		}
+		return p
+	}
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
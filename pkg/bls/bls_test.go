@@ -0,0 +1,39 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package bls
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := GenerateKey()
+	require.NoError(err)
+	sk2, err := BytesToPrivateKey(sk.Bytes())
+	require.NoError(err)
+	pk, err := BytesToPublicKey(sk2.PublicKey().Bytes())
+	require.NoError(err)
+	require.True(pk.Equal(sk.PublicKey()))
+
+	msg := []byte("block hash")
+	sig := sk.Sign(msg)
+	require.Equal(SignatureLength, len(sig))
+	require.NoError(pk.Verify(msg, sig))
+	require.Equal(ErrInvalidSignature, errors.Cause(pk.Verify([]byte("another block hash"), sig)))
+
+	// The point at infinity is neither a public key nor a signature
+	_, err = BytesToPublicKey(make([]byte, PublicKeyLength))
+	require.Equal(ErrInvalidKey, errors.Cause(err))
+	require.Equal(ErrInvalidSignature, errors.Cause(pk.Verify(msg, make([]byte, SignatureLength))))
+	_, err = BytesToPrivateKey(make([]byte, PrivateKeyLength))
+	require.Error(err)
+}
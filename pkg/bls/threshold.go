@@ -0,0 +1,205 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package bls
+
+import (
+	"math/big"
+
+	bn256 "github.com/iotexproject/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidShare indicates the share of a dealer doesn't match its commitments
+var ErrInvalidShare = errors.New("invalid secret share")
+
+// Polynomial is the secret polynomial of a dealer in a distributed key generation. Its constant term is the dealer's
+// contribution to the group private key, and its value at the index of a participant is the share sent to the
+// participant. The coefficients are committed as public keys, so that every share could be verified publicly, and the
+// group public key and the public key shares of the participants could be derived from the commitments of all the
+// qualified dealers
+type Polynomial struct {
+	coeffs []*big.Int
+}
+
+// NewPolynomial generates a random secret polynomial of the degree, any degree+1 shares of which recover the secret
+func NewPolynomial(degree int) (*Polynomial, error) {
+	if degree < 0 {
+		return nil, errors.Errorf("invalid degree %d", degree)
+	}
+	coeffs := make([]*big.Int, degree+1)
+	for i := range coeffs {
+		sk, err := GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = sk.x
+	}
+	return &Polynomial{coeffs: coeffs}, nil
+}
+
+// Degree returns the degree of the polynomial
+func (p *Polynomial) Degree() int {
+	return len(p.coeffs) - 1
+}
+
+// Commitments returns the public keys of the coefficients
+func (p *Polynomial) Commitments() [][]byte {
+	commitments := make([][]byte, len(p.coeffs))
+	for i, c := range p.coeffs {
+		commitments[i] = new(bn256.G2).ScalarBaseMult(c).Marshal()
+	}
+	return commitments
+}
+
+// Share returns the value of the polynomial at the index of a participant, which starts from 1
+func (p *Polynomial) Share(index uint64) []byte {
+	x := new(big.Int).SetUint64(index)
+	v := new(big.Int)
+	for i := len(p.coeffs) - 1; i >= 0; i-- {
+		v.Mul(v, x).Add(v, p.coeffs[i]).Mod(v, bn256.Order)
+	}
+	return (&PrivateKey{x: v}).Bytes()
+}
+
+// VerifyShare verifies that the share at the index is the value of the polynomial committed
+func VerifyShare(commitments [][]byte, index uint64, share []byte) error {
+	sk, err := BytesToPrivateKey(share)
+	if err != nil {
+		return errors.Wrap(ErrInvalidShare, err.Error())
+	}
+	pk, err := PublicKeyShare(commitments, index)
+	if err != nil {
+		return err
+	}
+	if !pk.Equal(sk.PublicKey()) {
+		return errors.Wrapf(ErrInvalidShare, "share of index %d doesn't match the commitments", index)
+	}
+	return nil
+}
+
+// PublicKeyShare returns the public key of the share at the index, evaluating the committed polynomial in the
+// exponent. The public key at index 0 is the public key of the secret
+func PublicKeyShare(commitments [][]byte, index uint64) (*PublicKey, error) {
+	points, err := toG2s(commitments)
+	if err != nil {
+		return nil, err
+	}
+	x := new(big.Int).SetUint64(index)
+	v := new(bn256.G2).Set(points[len(points)-1])
+	for i := len(points) - 2; i >= 0; i-- {
+		v = new(bn256.G2).ScalarMult(v, x)
+		v = new(bn256.G2).Add(v, points[i])
+	}
+	return &PublicKey{p: v}, nil
+}
+
+// AggregateCommitments sums up the commitments of the qualified dealers into the commitments of the group polynomial.
+// All the dealers should commit to polynomials of the same degree
+func AggregateCommitments(commitments [][][]byte) ([][]byte, error) {
+	if len(commitments) == 0 {
+		return nil, errors.Wrap(ErrInvalidKey, "no commitment to aggregate")
+	}
+	sum, err := toG2s(commitments[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range commitments[1:] {
+		if len(c) != len(sum) {
+			return nil, errors.Wrapf(ErrInvalidKey, "%d commitments, expecting %d", len(c), len(sum))
+		}
+		points, err := toG2s(c)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sum {
+			sum[i] = new(bn256.G2).Add(sum[i], points[i])
+		}
+	}
+	aggregated := make([][]byte, len(sum))
+	for i, p := range sum {
+		aggregated[i] = p.Marshal()
+	}
+	return aggregated, nil
+}
+
+// AggregateShares sums up the shares received from the qualified dealers into the private key share of the
+// participant in the group
+func AggregateShares(shares [][]byte) (*PrivateKey, error) {
+	if len(shares) == 0 {
+		return nil, errors.Wrap(ErrInvalidKey, "no share to aggregate")
+	}
+	sum := new(big.Int)
+	for _, share := range shares {
+		sk, err := BytesToPrivateKey(share)
+		if err != nil {
+			return nil, err
+		}
+		sum.Add(sum, sk.x).Mod(sum, bn256.Order)
+	}
+	if sum.Sign() == 0 {
+		return nil, errors.Wrap(ErrInvalidKey, "aggregated share is zero")
+	}
+	return &PrivateKey{x: sum}, nil
+}
+
+// RecoverSignature interpolates the signatures of the same message signed by the private key shares at the distinct
+// indices into the signature of the group private key. Any degree+1 valid signature shares recover the same
+// signature, which makes the group signature unique
+func RecoverSignature(indices []uint64, sigs [][]byte) ([]byte, error) {
+	if len(indices) == 0 || len(indices) != len(sigs) {
+		return nil, errors.Wrapf(ErrInvalidSignature, "%d indices of %d signatures", len(indices), len(sigs))
+	}
+	xs := make([]*big.Int, len(indices))
+	seen := make(map[uint64]bool, len(indices))
+	for i, index := range indices {
+		if index == 0 || seen[index] {
+			return nil, errors.Wrapf(ErrInvalidSignature, "invalid or duplicate index %d", index)
+		}
+		seen[index] = true
+		xs[i] = new(big.Int).SetUint64(index)
+	}
+	var recovered *bn256.G1
+	for i, sig := range sigs {
+		p, err := toG1(sig)
+		if err != nil {
+			return nil, err
+		}
+		// The Lagrange coefficient at 0 is the product of x_j/(x_j-x_i) for all j != i
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j, xj := range xs {
+			if j == i {
+				continue
+			}
+			num.Mul(num, xj).Mod(num, bn256.Order)
+			diff := new(big.Int).Sub(xj, xs[i])
+			den.Mul(den, diff).Mod(den, bn256.Order)
+		}
+		coeff := num.Mul(num, den.ModInverse(den, bn256.Order)).Mod(num, bn256.Order)
+		term := new(bn256.G1).ScalarMult(p, coeff)
+		if recovered == nil {
+			recovered = term
+			continue
+		}
+		recovered = new(bn256.G1).Add(recovered, term)
+	}
+	return recovered.Marshal(), nil
+}
+
+func toG2s(commitments [][]byte) ([]*bn256.G2, error) {
+	if len(commitments) == 0 {
+		return nil, errors.Wrap(ErrInvalidKey, "no commitment")
+	}
+	points := make([]*bn256.G2, len(commitments))
+	for i, c := range commitments {
+		pk, err := BytesToPublicKey(c)
+		if err != nil {
+			return nil, errors.Wrapf(err, "commitment %d is invalid", i)
+		}
+		points[i] = pk.p
+	}
+	return points, nil
+}
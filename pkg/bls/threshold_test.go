@@ -0,0 +1,83 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package bls
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreshold(t *testing.T) {
+	require := require.New(t)
+
+	// 3 dealers share their secrets among 4 participants with polynomials of degree 1
+	const n, degree = 4, 1
+	polys := make([]*Polynomial, 3)
+	commitments := make([][][]byte, len(polys))
+	for i := range polys {
+		p, err := NewPolynomial(degree)
+		require.NoError(err)
+		require.Equal(degree, p.Degree())
+		polys[i] = p
+		commitments[i] = p.Commitments()
+	}
+	group, err := AggregateCommitments(commitments)
+	require.NoError(err)
+	groupPK, err := PublicKeyShare(group, 0)
+	require.NoError(err)
+
+	msg := []byte("epoch beacon")
+	sigs := make([][]byte, n)
+	for index := uint64(1); index <= n; index++ {
+		shares := make([][]byte, len(polys))
+		for i, p := range polys {
+			shares[i] = p.Share(index)
+			require.NoError(VerifyShare(commitments[i], index, shares[i]))
+			require.Equal(ErrInvalidShare, errors.Cause(VerifyShare(commitments[i], index+1, shares[i])))
+		}
+		sk, err := AggregateShares(shares)
+		require.NoError(err)
+		pk, err := PublicKeyShare(group, index)
+		require.NoError(err)
+		require.True(pk.Equal(sk.PublicKey()))
+		sigs[index-1] = sk.Sign(msg)
+		require.NoError(pk.Verify(msg, sigs[index-1]))
+	}
+
+	// Any 2 signature shares recover the same signature of the group key
+	sig, err := RecoverSignature([]uint64{1, 2}, sigs[:2])
+	require.NoError(err)
+	require.NoError(groupPK.Verify(msg, sig))
+	sig2, err := RecoverSignature([]uint64{4, 2}, [][]byte{sigs[3], sigs[1]})
+	require.NoError(err)
+	require.Equal(sig, sig2)
+	sig3, err := RecoverSignature([]uint64{1, 3, 4}, [][]byte{sigs[0], sigs[2], sigs[3]})
+	require.NoError(err)
+	require.Equal(sig, sig3)
+
+	// A single share isn't enough, and the indices have to match the shares
+	sig4, err := RecoverSignature([]uint64{1}, sigs[:1])
+	require.NoError(err)
+	require.Error(groupPK.Verify(msg, sig4))
+	sig5, err := RecoverSignature([]uint64{2, 1}, sigs[:2])
+	require.NoError(err)
+	require.Error(groupPK.Verify(msg, sig5))
+	_, err = RecoverSignature([]uint64{1, 1}, sigs[:2])
+	require.Error(err)
+	_, err = RecoverSignature([]uint64{0, 1}, sigs[:2])
+	require.Error(err)
+
+	// The commitments of different degrees cannot be aggregated
+	p, err := NewPolynomial(degree + 1)
+	require.NoError(err)
+	_, err = AggregateCommitments(append(commitments, p.Commitments()))
+	require.Error(err)
+	_, err = AggregateCommitments(nil)
+	require.Error(err)
+}
@@ -24,6 +24,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type PendingActionEventType int32
+
+const (
+	PendingActionEventType_ADDED     PendingActionEventType = 0
+	PendingActionEventType_PROMOTED  PendingActionEventType = 1
+	PendingActionEventType_REMOVED   PendingActionEventType = 2
+	PendingActionEventType_CONFIRMED PendingActionEventType = 3
+)
+
+var PendingActionEventType_name = map[int32]string{
+	0: "ADDED",
+	1: "PROMOTED",
+	2: "REMOVED",
+	3: "CONFIRMED",
+}
+var PendingActionEventType_value = map[string]int32{
+	"ADDED":     0,
+	"PROMOTED":  1,
+	"REMOVED":   2,
+	"CONFIRMED": 3,
+}
+
+func (x PendingActionEventType) String() string {
+	return proto.EnumName(PendingActionEventType_name, int32(x))
+}
+func (PendingActionEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{0}
+}
+
 type GetAccountRequest struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -35,7 +64,7 @@ func (m *GetAccountRequest) Reset()         { *m = GetAccountRequest{} }
 func (m *GetAccountRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAccountRequest) ProtoMessage()    {}
 func (*GetAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{0}
+	return fileDescriptor_api_e88018757adab6e4, []int{0}
 }
 func (m *GetAccountRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetAccountRequest.Unmarshal(m, b)
@@ -73,7 +102,7 @@ func (m *GetAccountResponse) Reset()         { *m = GetAccountResponse{} }
 func (m *GetAccountResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAccountResponse) ProtoMessage()    {}
 func (*GetAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{1}
+	return fileDescriptor_api_e88018757adab6e4, []int{1}
 }
 func (m *GetAccountResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetAccountResponse.Unmarshal(m, b)
@@ -117,7 +146,7 @@ func (m *GetActionsRequest) Reset()         { *m = GetActionsRequest{} }
 func (m *GetActionsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetActionsRequest) ProtoMessage()    {}
 func (*GetActionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{2}
+	return fileDescriptor_api_e88018757adab6e4, []int{2}
 }
 func (m *GetActionsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetActionsRequest.Unmarshal(m, b)
@@ -356,7 +385,7 @@ func (m *GetActionsByIndexRequest) Reset()         { *m = GetActionsByIndexReque
 func (m *GetActionsByIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*GetActionsByIndexRequest) ProtoMessage()    {}
 func (*GetActionsByIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{3}
+	return fileDescriptor_api_e88018757adab6e4, []int{3}
 }
 func (m *GetActionsByIndexRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetActionsByIndexRequest.Unmarshal(m, b)
@@ -402,7 +431,7 @@ func (m *GetActionByHashRequest) Reset()         { *m = GetActionByHashRequest{}
 func (m *GetActionByHashRequest) String() string { return proto.CompactTextString(m) }
 func (*GetActionByHashRequest) ProtoMessage()    {}
 func (*GetActionByHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{4}
+	return fileDescriptor_api_e88018757adab6e4, []int{4}
 }
 func (m *GetActionByHashRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetActionByHashRequest.Unmarshal(m, b)
@@ -449,7 +478,7 @@ func (m *GetActionsByAddressRequest) Reset()         { *m = GetActionsByAddressR
 func (m *GetActionsByAddressRequest) String() string { return proto.CompactTextString(m) }
 func (*GetActionsByAddressRequest) ProtoMessage()    {}
 func (*GetActionsByAddressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{5}
+	return fileDescriptor_api_e88018757adab6e4, []int{5}
 }
 func (m *GetActionsByAddressRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetActionsByAddressRequest.Unmarshal(m, b)
@@ -503,7 +532,7 @@ func (m *GetUnconfirmedActionsByAddressRequest) Reset()         { *m = GetUnconf
 func (m *GetUnconfirmedActionsByAddressRequest) String() string { return proto.CompactTextString(m) }
 func (*GetUnconfirmedActionsByAddressRequest) ProtoMessage()    {}
 func (*GetUnconfirmedActionsByAddressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{6}
+	return fileDescriptor_api_e88018757adab6e4, []int{6}
 }
 func (m *GetUnconfirmedActionsByAddressRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetUnconfirmedActionsByAddressRequest.Unmarshal(m, b)
@@ -557,7 +586,7 @@ func (m *GetActionsByBlockRequest) Reset()         { *m = GetActionsByBlockReque
 func (m *GetActionsByBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*GetActionsByBlockRequest) ProtoMessage()    {}
 func (*GetActionsByBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{7}
+	return fileDescriptor_api_e88018757adab6e4, []int{7}
 }
 func (m *GetActionsByBlockRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetActionsByBlockRequest.Unmarshal(m, b)
@@ -599,17 +628,22 @@ func (m *GetActionsByBlockRequest) GetCount() uint64 {
 }
 
 type GetActionsResponse struct {
-	Actions              []*iotextypes.Action `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Actions []*iotextypes.Action `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	// fee breakdown of the actions in the same order, empty if any of the actions is pending. The fee of an action
+	// whose receipt is pruned is empty
+	Fees []*iotextypes.ActionFee `protobuf:"bytes,2,rep,name=fees,proto3" json:"fees,omitempty"`
+	// whether the block of each action is finalized, false for the pending actions
+	Finalized            []bool   `protobuf:"varint,3,rep,packed,name=finalized,proto3" json:"finalized,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetActionsResponse) Reset()         { *m = GetActionsResponse{} }
 func (m *GetActionsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetActionsResponse) ProtoMessage()    {}
 func (*GetActionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{8}
+	return fileDescriptor_api_e88018757adab6e4, []int{8}
 }
 func (m *GetActionsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetActionsResponse.Unmarshal(m, b)
@@ -636,6 +670,20 @@ func (m *GetActionsResponse) GetActions() []*iotextypes.Action {
 	return nil
 }
 
+func (m *GetActionsResponse) GetFees() []*iotextypes.ActionFee {
+	if m != nil {
+		return m.Fees
+	}
+	return nil
+}
+
+func (m *GetActionsResponse) GetFinalized() []bool {
+	if m != nil {
+		return m.Finalized
+	}
+	return nil
+}
+
 type GetBlockMetasRequest struct {
 	// Types that are valid to be assigned to Lookup:
 	//	*GetBlockMetasRequest_ByIndex
@@ -650,7 +698,7 @@ func (m *GetBlockMetasRequest) Reset()         { *m = GetBlockMetasRequest{} }
 func (m *GetBlockMetasRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockMetasRequest) ProtoMessage()    {}
 func (*GetBlockMetasRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{9}
+	return fileDescriptor_api_e88018757adab6e4, []int{9}
 }
 func (m *GetBlockMetasRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockMetasRequest.Unmarshal(m, b)
@@ -793,7 +841,7 @@ func (m *GetBlockMetasByIndexRequest) Reset()         { *m = GetBlockMetasByInde
 func (m *GetBlockMetasByIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockMetasByIndexRequest) ProtoMessage()    {}
 func (*GetBlockMetasByIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{10}
+	return fileDescriptor_api_e88018757adab6e4, []int{10}
 }
 func (m *GetBlockMetasByIndexRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockMetasByIndexRequest.Unmarshal(m, b)
@@ -838,7 +886,7 @@ func (m *GetBlockMetaByHashRequest) Reset()         { *m = GetBlockMetaByHashReq
 func (m *GetBlockMetaByHashRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockMetaByHashRequest) ProtoMessage()    {}
 func (*GetBlockMetaByHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{11}
+	return fileDescriptor_api_e88018757adab6e4, []int{11}
 }
 func (m *GetBlockMetaByHashRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockMetaByHashRequest.Unmarshal(m, b)
@@ -876,7 +924,7 @@ func (m *GetBlockMetasResponse) Reset()         { *m = GetBlockMetasResponse{} }
 func (m *GetBlockMetasResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockMetasResponse) ProtoMessage()    {}
 func (*GetBlockMetasResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{12}
+	return fileDescriptor_api_e88018757adab6e4, []int{12}
 }
 func (m *GetBlockMetasResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockMetasResponse.Unmarshal(m, b)
@@ -903,6 +951,90 @@ func (m *GetBlockMetasResponse) GetBlkMetas() []*iotextypes.BlockMeta {
 	return nil
 }
 
+type GetRawBlocksRequest struct {
+	StartHeight          uint64   `protobuf:"varint,1,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRawBlocksRequest) Reset()         { *m = GetRawBlocksRequest{} }
+func (m *GetRawBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRawBlocksRequest) ProtoMessage()    {}
+func (*GetRawBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{13}
+}
+func (m *GetRawBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRawBlocksRequest.Unmarshal(m, b)
+}
+func (m *GetRawBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRawBlocksRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetRawBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRawBlocksRequest.Merge(dst, src)
+}
+func (m *GetRawBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRawBlocksRequest.Size(m)
+}
+func (m *GetRawBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRawBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRawBlocksRequest proto.InternalMessageInfo
+
+func (m *GetRawBlocksRequest) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *GetRawBlocksRequest) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type GetRawBlocksResponse struct {
+	Blocks               []*iotextypes.Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetRawBlocksResponse) Reset()         { *m = GetRawBlocksResponse{} }
+func (m *GetRawBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRawBlocksResponse) ProtoMessage()    {}
+func (*GetRawBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{14}
+}
+func (m *GetRawBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRawBlocksResponse.Unmarshal(m, b)
+}
+func (m *GetRawBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRawBlocksResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetRawBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRawBlocksResponse.Merge(dst, src)
+}
+func (m *GetRawBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRawBlocksResponse.Size(m)
+}
+func (m *GetRawBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRawBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRawBlocksResponse proto.InternalMessageInfo
+
+func (m *GetRawBlocksResponse) GetBlocks() []*iotextypes.Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
 type GetChainMetaRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -913,7 +1045,7 @@ func (m *GetChainMetaRequest) Reset()         { *m = GetChainMetaRequest{} }
 func (m *GetChainMetaRequest) String() string { return proto.CompactTextString(m) }
 func (*GetChainMetaRequest) ProtoMessage()    {}
 func (*GetChainMetaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{13}
+	return fileDescriptor_api_e88018757adab6e4, []int{15}
 }
 func (m *GetChainMetaRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetChainMetaRequest.Unmarshal(m, b)
@@ -933,8 +1065,14 @@ func (m *GetChainMetaRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetChainMetaRequest proto.InternalMessageInfo
 
+// The sections below chainMeta give an overview of the node, each of which is unset if the node doesn't run the module
+// or it fails to be read
 type GetChainMetaResponse struct {
 	ChainMeta            *iotextypes.ChainMeta `protobuf:"bytes,1,opt,name=chainMeta,proto3" json:"chainMeta,omitempty"`
+	Sync                 *SyncMeta             `protobuf:"bytes,3,opt,name=sync,proto3" json:"sync,omitempty"`
+	ActPool              *ActPoolStats         `protobuf:"bytes,4,opt,name=actPool,proto3" json:"actPool,omitempty"`
+	Consensus            *ConsensusMeta        `protobuf:"bytes,5,opt,name=consensus,proto3" json:"consensus,omitempty"`
+	Network              *NetworkMeta          `protobuf:"bytes,6,opt,name=network,proto3" json:"network,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -944,7 +1082,7 @@ func (m *GetChainMetaResponse) Reset()         { *m = GetChainMetaResponse{} }
 func (m *GetChainMetaResponse) String() string { return proto.CompactTextString(m) }
 func (*GetChainMetaResponse) ProtoMessage()    {}
 func (*GetChainMetaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{14}
+	return fileDescriptor_api_e88018757adab6e4, []int{16}
 }
 func (m *GetChainMetaResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetChainMetaResponse.Unmarshal(m, b)
@@ -971,6 +1109,34 @@ func (m *GetChainMetaResponse) GetChainMeta() *iotextypes.ChainMeta {
 	return nil
 }
 
+func (m *GetChainMetaResponse) GetSync() *SyncMeta {
+	if m != nil {
+		return m.Sync
+	}
+	return nil
+}
+
+func (m *GetChainMetaResponse) GetActPool() *ActPoolStats {
+	if m != nil {
+		return m.ActPool
+	}
+	return nil
+}
+
+func (m *GetChainMetaResponse) GetConsensus() *ConsensusMeta {
+	if m != nil {
+		return m.Consensus
+	}
+	return nil
+}
+
+func (m *GetChainMetaResponse) GetNetwork() *NetworkMeta {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
 type SendActionRequest struct {
 	Action               *iotextypes.Action `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
@@ -982,7 +1148,7 @@ func (m *SendActionRequest) Reset()         { *m = SendActionRequest{} }
 func (m *SendActionRequest) String() string { return proto.CompactTextString(m) }
 func (*SendActionRequest) ProtoMessage()    {}
 func (*SendActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{15}
+	return fileDescriptor_api_e88018757adab6e4, []int{17}
 }
 func (m *SendActionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SendActionRequest.Unmarshal(m, b)
@@ -1019,7 +1185,7 @@ func (m *SendActionResponse) Reset()         { *m = SendActionResponse{} }
 func (m *SendActionResponse) String() string { return proto.CompactTextString(m) }
 func (*SendActionResponse) ProtoMessage()    {}
 func (*SendActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{16}
+	return fileDescriptor_api_e88018757adab6e4, []int{18}
 }
 func (m *SendActionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SendActionResponse.Unmarshal(m, b)
@@ -1050,7 +1216,7 @@ func (m *GetReceiptByActionRequest) Reset()         { *m = GetReceiptByActionReq
 func (m *GetReceiptByActionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReceiptByActionRequest) ProtoMessage()    {}
 func (*GetReceiptByActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{17}
+	return fileDescriptor_api_e88018757adab6e4, []int{19}
 }
 func (m *GetReceiptByActionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReceiptByActionRequest.Unmarshal(m, b)
@@ -1078,17 +1244,18 @@ func (m *GetReceiptByActionRequest) GetActionHash() string {
 }
 
 type GetReceiptByActionResponse struct {
-	Receipt              *iotextypes.Receipt `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Receipt              *iotextypes.Receipt   `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Fee                  *iotextypes.ActionFee `protobuf:"bytes,2,opt,name=fee,proto3" json:"fee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *GetReceiptByActionResponse) Reset()         { *m = GetReceiptByActionResponse{} }
 func (m *GetReceiptByActionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReceiptByActionResponse) ProtoMessage()    {}
 func (*GetReceiptByActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{18}
+	return fileDescriptor_api_e88018757adab6e4, []int{20}
 }
 func (m *GetReceiptByActionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReceiptByActionResponse.Unmarshal(m, b)
@@ -1115,18 +1282,27 @@ func (m *GetReceiptByActionResponse) GetReceipt() *iotextypes.Receipt {
 	return nil
 }
 
+func (m *GetReceiptByActionResponse) GetFee() *iotextypes.ActionFee {
+	if m != nil {
+		return m.Fee
+	}
+	return nil
+}
+
 type ReadContractRequest struct {
-	Action               *iotextypes.Action `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Action *iotextypes.Action `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	// the states substituted for this read only, which are discarded afterwards
+	StateOverrides       []*StateOverride `protobuf:"bytes,2,rep,name=stateOverrides,proto3" json:"stateOverrides,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *ReadContractRequest) Reset()         { *m = ReadContractRequest{} }
 func (m *ReadContractRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadContractRequest) ProtoMessage()    {}
 func (*ReadContractRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{19}
+	return fileDescriptor_api_e88018757adab6e4, []int{21}
 }
 func (m *ReadContractRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadContractRequest.Unmarshal(m, b)
@@ -1153,6 +1329,13 @@ func (m *ReadContractRequest) GetAction() *iotextypes.Action {
 	return nil
 }
 
+func (m *ReadContractRequest) GetStateOverrides() []*StateOverride {
+	if m != nil {
+		return m.StateOverrides
+	}
+	return nil
+}
+
 type ReadContractResponse struct {
 	Data                 string   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1164,7 +1347,7 @@ func (m *ReadContractResponse) Reset()         { *m = ReadContractResponse{} }
 func (m *ReadContractResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadContractResponse) ProtoMessage()    {}
 func (*ReadContractResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{20}
+	return fileDescriptor_api_e88018757adab6e4, []int{22}
 }
 func (m *ReadContractResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadContractResponse.Unmarshal(m, b)
@@ -1201,7 +1384,7 @@ func (m *SuggestGasPriceRequest) Reset()         { *m = SuggestGasPriceRequest{}
 func (m *SuggestGasPriceRequest) String() string { return proto.CompactTextString(m) }
 func (*SuggestGasPriceRequest) ProtoMessage()    {}
 func (*SuggestGasPriceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{21}
+	return fileDescriptor_api_e88018757adab6e4, []int{23}
 }
 func (m *SuggestGasPriceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SuggestGasPriceRequest.Unmarshal(m, b)
@@ -1232,7 +1415,7 @@ func (m *SuggestGasPriceResponse) Reset()         { *m = SuggestGasPriceResponse
 func (m *SuggestGasPriceResponse) String() string { return proto.CompactTextString(m) }
 func (*SuggestGasPriceResponse) ProtoMessage()    {}
 func (*SuggestGasPriceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{22}
+	return fileDescriptor_api_e88018757adab6e4, []int{24}
 }
 func (m *SuggestGasPriceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SuggestGasPriceResponse.Unmarshal(m, b)
@@ -1270,7 +1453,7 @@ func (m *EstimateGasForActionRequest) Reset()         { *m = EstimateGasForActio
 func (m *EstimateGasForActionRequest) String() string { return proto.CompactTextString(m) }
 func (*EstimateGasForActionRequest) ProtoMessage()    {}
 func (*EstimateGasForActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{23}
+	return fileDescriptor_api_e88018757adab6e4, []int{25}
 }
 func (m *EstimateGasForActionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimateGasForActionRequest.Unmarshal(m, b)
@@ -1308,7 +1491,7 @@ func (m *EstimateGasForActionResponse) Reset()         { *m = EstimateGasForActi
 func (m *EstimateGasForActionResponse) String() string { return proto.CompactTextString(m) }
 func (*EstimateGasForActionResponse) ProtoMessage()    {}
 func (*EstimateGasForActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_7407a1b92915c4a6, []int{24}
+	return fileDescriptor_api_e88018757adab6e4, []int{26}
 }
 func (m *EstimateGasForActionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EstimateGasForActionResponse.Unmarshal(m, b)
@@ -1335,155 +1518,2777 @@ func (m *EstimateGasForActionResponse) GetGas() uint64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*GetAccountRequest)(nil), "iotexapi.GetAccountRequest")
-	proto.RegisterType((*GetAccountResponse)(nil), "iotexapi.GetAccountResponse")
-	proto.RegisterType((*GetActionsRequest)(nil), "iotexapi.GetActionsRequest")
-	proto.RegisterType((*GetActionsByIndexRequest)(nil), "iotexapi.GetActionsByIndexRequest")
-	proto.RegisterType((*GetActionByHashRequest)(nil), "iotexapi.GetActionByHashRequest")
-	proto.RegisterType((*GetActionsByAddressRequest)(nil), "iotexapi.GetActionsByAddressRequest")
-	proto.RegisterType((*GetUnconfirmedActionsByAddressRequest)(nil), "iotexapi.GetUnconfirmedActionsByAddressRequest")
-	proto.RegisterType((*GetActionsByBlockRequest)(nil), "iotexapi.GetActionsByBlockRequest")
-	proto.RegisterType((*GetActionsResponse)(nil), "iotexapi.GetActionsResponse")
-	proto.RegisterType((*GetBlockMetasRequest)(nil), "iotexapi.GetBlockMetasRequest")
-	proto.RegisterType((*GetBlockMetasByIndexRequest)(nil), "iotexapi.GetBlockMetasByIndexRequest")
-	proto.RegisterType((*GetBlockMetaByHashRequest)(nil), "iotexapi.GetBlockMetaByHashRequest")
-	proto.RegisterType((*GetBlockMetasResponse)(nil), "iotexapi.GetBlockMetasResponse")
-	proto.RegisterType((*GetChainMetaRequest)(nil), "iotexapi.GetChainMetaRequest")
-	proto.RegisterType((*GetChainMetaResponse)(nil), "iotexapi.GetChainMetaResponse")
-	proto.RegisterType((*SendActionRequest)(nil), "iotexapi.SendActionRequest")
-	proto.RegisterType((*SendActionResponse)(nil), "iotexapi.SendActionResponse")
-	proto.RegisterType((*GetReceiptByActionRequest)(nil), "iotexapi.GetReceiptByActionRequest")
-	proto.RegisterType((*GetReceiptByActionResponse)(nil), "iotexapi.GetReceiptByActionResponse")
-	proto.RegisterType((*ReadContractRequest)(nil), "iotexapi.ReadContractRequest")
-	proto.RegisterType((*ReadContractResponse)(nil), "iotexapi.ReadContractResponse")
-	proto.RegisterType((*SuggestGasPriceRequest)(nil), "iotexapi.SuggestGasPriceRequest")
-	proto.RegisterType((*SuggestGasPriceResponse)(nil), "iotexapi.SuggestGasPriceResponse")
-	proto.RegisterType((*EstimateGasForActionRequest)(nil), "iotexapi.EstimateGasForActionRequest")
-	proto.RegisterType((*EstimateGasForActionResponse)(nil), "iotexapi.EstimateGasForActionResponse")
+type ReadStateRequest struct {
+	ProtocolID           []byte   `protobuf:"bytes,1,opt,name=protocolID,proto3" json:"protocolID,omitempty"`
+	MethodName           []byte   `protobuf:"bytes,2,opt,name=methodName,proto3" json:"methodName,omitempty"`
+	Arguments            [][]byte `protobuf:"bytes,3,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// APIServiceClient is the client API for APIService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type APIServiceClient interface {
-	// get the address detail of an address
-	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error)
-	// get action(s) by:
-	// 1. start index and action count
-	// 2. action hash
-	// 3. address with start index and action count
-	// 4. get unconfirmed actions by address with start index and action count
-	// 5. block hash with start index and action count
-	GetActions(ctx context.Context, in *GetActionsRequest, opts ...grpc.CallOption) (*GetActionsResponse, error)
-	// get block metadata(s) by:
-	// 1. start index and block count
-	// 2. block hash
-	GetBlockMetas(ctx context.Context, in *GetBlockMetasRequest, opts ...grpc.CallOption) (*GetBlockMetasResponse, error)
-	// get chain metadata
-	GetChainMeta(ctx context.Context, in *GetChainMetaRequest, opts ...grpc.CallOption) (*GetChainMetaResponse, error)
-	// sendAction
-	SendAction(ctx context.Context, in *SendActionRequest, opts ...grpc.CallOption) (*SendActionResponse, error)
-	// get receipt by action Hash
-	GetReceiptByAction(ctx context.Context, in *GetReceiptByActionRequest, opts ...grpc.CallOption) (*GetReceiptByActionResponse, error)
-	// TODO: read contract
-	ReadContract(ctx context.Context, in *ReadContractRequest, opts ...grpc.CallOption) (*ReadContractResponse, error)
-	// suggest gas price
-	SuggestGasPrice(ctx context.Context, in *SuggestGasPriceRequest, opts ...grpc.CallOption) (*SuggestGasPriceResponse, error)
-	// estimate gas for action
-	EstimateGasForAction(ctx context.Context, in *EstimateGasForActionRequest, opts ...grpc.CallOption) (*EstimateGasForActionResponse, error)
+func (m *ReadStateRequest) Reset()         { *m = ReadStateRequest{} }
+func (m *ReadStateRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadStateRequest) ProtoMessage()    {}
+func (*ReadStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{27}
 }
-
-type aPIServiceClient struct {
-	cc *grpc.ClientConn
+func (m *ReadStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadStateRequest.Unmarshal(m, b)
 }
-
-func NewAPIServiceClient(cc *grpc.ClientConn) APIServiceClient {
-	return &aPIServiceClient{cc}
+func (m *ReadStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadStateRequest.Marshal(b, m, deterministic)
 }
-
-func (c *aPIServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error) {
-	out := new(GetAccountResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetAccount", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (dst *ReadStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadStateRequest.Merge(dst, src)
+}
+func (m *ReadStateRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadStateRequest.Size(m)
+}
+func (m *ReadStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadStateRequest.DiscardUnknown(m)
 }
 
-func (c *aPIServiceClient) GetActions(ctx context.Context, in *GetActionsRequest, opts ...grpc.CallOption) (*GetActionsResponse, error) {
-	out := new(GetActionsResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetActions", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ReadStateRequest proto.InternalMessageInfo
+
+func (m *ReadStateRequest) GetProtocolID() []byte {
+	if m != nil {
+		return m.ProtocolID
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIServiceClient) GetBlockMetas(ctx context.Context, in *GetBlockMetasRequest, opts ...grpc.CallOption) (*GetBlockMetasResponse, error) {
-	out := new(GetBlockMetasResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetBlockMetas", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ReadStateRequest) GetMethodName() []byte {
+	if m != nil {
+		return m.MethodName
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIServiceClient) GetChainMeta(ctx context.Context, in *GetChainMetaRequest, opts ...grpc.CallOption) (*GetChainMetaResponse, error) {
-	out := new(GetChainMetaResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetChainMeta", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ReadStateRequest) GetArguments() [][]byte {
+	if m != nil {
+		return m.Arguments
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIServiceClient) SendAction(ctx context.Context, in *SendActionRequest, opts ...grpc.CallOption) (*SendActionResponse, error) {
-	out := new(SendActionResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/SendAction", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ReadStateResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *aPIServiceClient) GetReceiptByAction(ctx context.Context, in *GetReceiptByActionRequest, opts ...grpc.CallOption) (*GetReceiptByActionResponse, error) {
-	out := new(GetReceiptByActionResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetReceiptByAction", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ReadStateResponse) Reset()         { *m = ReadStateResponse{} }
+func (m *ReadStateResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadStateResponse) ProtoMessage()    {}
+func (*ReadStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{28}
+}
+func (m *ReadStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadStateResponse.Unmarshal(m, b)
+}
+func (m *ReadStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadStateResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReadStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadStateResponse.Merge(dst, src)
+}
+func (m *ReadStateResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadStateResponse.Size(m)
+}
+func (m *ReadStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadStateResponse.DiscardUnknown(m)
 }
 
-func (c *aPIServiceClient) ReadContract(ctx context.Context, in *ReadContractRequest, opts ...grpc.CallOption) (*ReadContractResponse, error) {
-	out := new(ReadContractResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/ReadContract", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ReadStateResponse proto.InternalMessageInfo
+
+func (m *ReadStateResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return out, nil
+	return nil
 }
 
-func (c *aPIServiceClient) SuggestGasPrice(ctx context.Context, in *SuggestGasPriceRequest, opts ...grpc.CallOption) (*SuggestGasPriceResponse, error) {
-	out := new(SuggestGasPriceResponse)
-	err := c.cc.Invoke(ctx, "/iotexapi.APIService/SuggestGasPrice", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type GetDelegateParticipationRequest struct {
+	Window               uint64   `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *aPIServiceClient) EstimateGasForAction(ctx context.Context, in *EstimateGasForActionRequest, opts ...grpc.CallOption) (*EstimateGasForActionResponse, error) {
-	out := new(EstimateGasForActionResponse)
+func (m *GetDelegateParticipationRequest) Reset()         { *m = GetDelegateParticipationRequest{} }
+func (m *GetDelegateParticipationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDelegateParticipationRequest) ProtoMessage()    {}
+func (*GetDelegateParticipationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{29}
+}
+func (m *GetDelegateParticipationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDelegateParticipationRequest.Unmarshal(m, b)
+}
+func (m *GetDelegateParticipationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDelegateParticipationRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetDelegateParticipationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDelegateParticipationRequest.Merge(dst, src)
+}
+func (m *GetDelegateParticipationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDelegateParticipationRequest.Size(m)
+}
+func (m *GetDelegateParticipationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDelegateParticipationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDelegateParticipationRequest proto.InternalMessageInfo
+
+func (m *GetDelegateParticipationRequest) GetWindow() uint64 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+type DelegateParticipation struct {
+	Delegate             string   `protobuf:"bytes,1,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	Proposals            uint64   `protobuf:"varint,2,opt,name=proposals,proto3" json:"proposals,omitempty"`
+	Endorsements         uint64   `protobuf:"varint,3,opt,name=endorsements,proto3" json:"endorsements,omitempty"`
+	MissedSlots          uint64   `protobuf:"varint,4,opt,name=missedSlots,proto3" json:"missedSlots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DelegateParticipation) Reset()         { *m = DelegateParticipation{} }
+func (m *DelegateParticipation) String() string { return proto.CompactTextString(m) }
+func (*DelegateParticipation) ProtoMessage()    {}
+func (*DelegateParticipation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{30}
+}
+func (m *DelegateParticipation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DelegateParticipation.Unmarshal(m, b)
+}
+func (m *DelegateParticipation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DelegateParticipation.Marshal(b, m, deterministic)
+}
+func (dst *DelegateParticipation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelegateParticipation.Merge(dst, src)
+}
+func (m *DelegateParticipation) XXX_Size() int {
+	return xxx_messageInfo_DelegateParticipation.Size(m)
+}
+func (m *DelegateParticipation) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelegateParticipation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DelegateParticipation proto.InternalMessageInfo
+
+func (m *DelegateParticipation) GetDelegate() string {
+	if m != nil {
+		return m.Delegate
+	}
+	return ""
+}
+
+func (m *DelegateParticipation) GetProposals() uint64 {
+	if m != nil {
+		return m.Proposals
+	}
+	return 0
+}
+
+func (m *DelegateParticipation) GetEndorsements() uint64 {
+	if m != nil {
+		return m.Endorsements
+	}
+	return 0
+}
+
+func (m *DelegateParticipation) GetMissedSlots() uint64 {
+	if m != nil {
+		return m.MissedSlots
+	}
+	return 0
+}
+
+type GetDelegateParticipationResponse struct {
+	StartHeight          uint64                   `protobuf:"varint,1,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	EndHeight            uint64                   `protobuf:"varint,2,opt,name=endHeight,proto3" json:"endHeight,omitempty"`
+	Delegates            []*DelegateParticipation `protobuf:"bytes,3,rep,name=delegates,proto3" json:"delegates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetDelegateParticipationResponse) Reset()         { *m = GetDelegateParticipationResponse{} }
+func (m *GetDelegateParticipationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDelegateParticipationResponse) ProtoMessage()    {}
+func (*GetDelegateParticipationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{31}
+}
+func (m *GetDelegateParticipationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDelegateParticipationResponse.Unmarshal(m, b)
+}
+func (m *GetDelegateParticipationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDelegateParticipationResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetDelegateParticipationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDelegateParticipationResponse.Merge(dst, src)
+}
+func (m *GetDelegateParticipationResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDelegateParticipationResponse.Size(m)
+}
+func (m *GetDelegateParticipationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDelegateParticipationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDelegateParticipationResponse proto.InternalMessageInfo
+
+func (m *GetDelegateParticipationResponse) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *GetDelegateParticipationResponse) GetEndHeight() uint64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *GetDelegateParticipationResponse) GetDelegates() []*DelegateParticipation {
+	if m != nil {
+		return m.Delegates
+	}
+	return nil
+}
+
+type GetBlockSyncBufferStatsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockSyncBufferStatsRequest) Reset()         { *m = GetBlockSyncBufferStatsRequest{} }
+func (m *GetBlockSyncBufferStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockSyncBufferStatsRequest) ProtoMessage()    {}
+func (*GetBlockSyncBufferStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{32}
+}
+func (m *GetBlockSyncBufferStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockSyncBufferStatsRequest.Unmarshal(m, b)
+}
+func (m *GetBlockSyncBufferStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockSyncBufferStatsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBlockSyncBufferStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockSyncBufferStatsRequest.Merge(dst, src)
+}
+func (m *GetBlockSyncBufferStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockSyncBufferStatsRequest.Size(m)
+}
+func (m *GetBlockSyncBufferStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockSyncBufferStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockSyncBufferStatsRequest proto.InternalMessageInfo
+
+type BlockSyncBufferStats struct {
+	Size                 uint64   `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	InMemory             uint64   `protobuf:"varint,2,opt,name=inMemory,proto3" json:"inMemory,omitempty"`
+	Spilled              uint64   `protobuf:"varint,3,opt,name=spilled,proto3" json:"spilled,omitempty"`
+	SpilledBytes         uint64   `protobuf:"varint,4,opt,name=spilledBytes,proto3" json:"spilledBytes,omitempty"`
+	CommitHeight         uint64   `protobuf:"varint,5,opt,name=commitHeight,proto3" json:"commitHeight,omitempty"`
+	InMemoryBytes        uint64   `protobuf:"varint,6,opt,name=inMemoryBytes,proto3" json:"inMemoryBytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockSyncBufferStats) Reset()         { *m = BlockSyncBufferStats{} }
+func (m *BlockSyncBufferStats) String() string { return proto.CompactTextString(m) }
+func (*BlockSyncBufferStats) ProtoMessage()    {}
+func (*BlockSyncBufferStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{33}
+}
+func (m *BlockSyncBufferStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSyncBufferStats.Unmarshal(m, b)
+}
+func (m *BlockSyncBufferStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSyncBufferStats.Marshal(b, m, deterministic)
+}
+func (dst *BlockSyncBufferStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSyncBufferStats.Merge(dst, src)
+}
+func (m *BlockSyncBufferStats) XXX_Size() int {
+	return xxx_messageInfo_BlockSyncBufferStats.Size(m)
+}
+func (m *BlockSyncBufferStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSyncBufferStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSyncBufferStats proto.InternalMessageInfo
+
+func (m *BlockSyncBufferStats) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *BlockSyncBufferStats) GetInMemory() uint64 {
+	if m != nil {
+		return m.InMemory
+	}
+	return 0
+}
+
+func (m *BlockSyncBufferStats) GetSpilled() uint64 {
+	if m != nil {
+		return m.Spilled
+	}
+	return 0
+}
+
+func (m *BlockSyncBufferStats) GetSpilledBytes() uint64 {
+	if m != nil {
+		return m.SpilledBytes
+	}
+	return 0
+}
+
+func (m *BlockSyncBufferStats) GetCommitHeight() uint64 {
+	if m != nil {
+		return m.CommitHeight
+	}
+	return 0
+}
+
+func (m *BlockSyncBufferStats) GetInMemoryBytes() uint64 {
+	if m != nil {
+		return m.InMemoryBytes
+	}
+	return 0
+}
+
+type GetBlockSyncBufferStatsResponse struct {
+	Stats                *BlockSyncBufferStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetBlockSyncBufferStatsResponse) Reset()         { *m = GetBlockSyncBufferStatsResponse{} }
+func (m *GetBlockSyncBufferStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockSyncBufferStatsResponse) ProtoMessage()    {}
+func (*GetBlockSyncBufferStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{34}
+}
+func (m *GetBlockSyncBufferStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockSyncBufferStatsResponse.Unmarshal(m, b)
+}
+func (m *GetBlockSyncBufferStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockSyncBufferStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetBlockSyncBufferStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockSyncBufferStatsResponse.Merge(dst, src)
+}
+func (m *GetBlockSyncBufferStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockSyncBufferStatsResponse.Size(m)
+}
+func (m *GetBlockSyncBufferStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockSyncBufferStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockSyncBufferStatsResponse proto.InternalMessageInfo
+
+func (m *GetBlockSyncBufferStatsResponse) GetStats() *BlockSyncBufferStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type GetBlockSyncPeerScoresRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockSyncPeerScoresRequest) Reset()         { *m = GetBlockSyncPeerScoresRequest{} }
+func (m *GetBlockSyncPeerScoresRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockSyncPeerScoresRequest) ProtoMessage()    {}
+func (*GetBlockSyncPeerScoresRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{35}
+}
+func (m *GetBlockSyncPeerScoresRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockSyncPeerScoresRequest.Unmarshal(m, b)
+}
+func (m *GetBlockSyncPeerScoresRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockSyncPeerScoresRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBlockSyncPeerScoresRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockSyncPeerScoresRequest.Merge(dst, src)
+}
+func (m *GetBlockSyncPeerScoresRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockSyncPeerScoresRequest.Size(m)
+}
+func (m *GetBlockSyncPeerScoresRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockSyncPeerScoresRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockSyncPeerScoresRequest proto.InternalMessageInfo
+
+type PeerScore struct {
+	PeerID               string   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Score                int64    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Requests             uint64   `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	Responses            uint64   `protobuf:"varint,4,opt,name=responses,proto3" json:"responses,omitempty"`
+	StalledRequests      uint64   `protobuf:"varint,5,opt,name=stalledRequests,proto3" json:"stalledRequests,omitempty"`
+	InvalidBlocks        uint64   `protobuf:"varint,6,opt,name=invalidBlocks,proto3" json:"invalidBlocks,omitempty"`
+	LatencyMs            uint64   `protobuf:"varint,7,opt,name=latencyMs,proto3" json:"latencyMs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerScore) Reset()         { *m = PeerScore{} }
+func (m *PeerScore) String() string { return proto.CompactTextString(m) }
+func (*PeerScore) ProtoMessage()    {}
+func (*PeerScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{36}
+}
+func (m *PeerScore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerScore.Unmarshal(m, b)
+}
+func (m *PeerScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerScore.Marshal(b, m, deterministic)
+}
+func (dst *PeerScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerScore.Merge(dst, src)
+}
+func (m *PeerScore) XXX_Size() int {
+	return xxx_messageInfo_PeerScore.Size(m)
+}
+func (m *PeerScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerScore proto.InternalMessageInfo
+
+func (m *PeerScore) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+func (m *PeerScore) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *PeerScore) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *PeerScore) GetResponses() uint64 {
+	if m != nil {
+		return m.Responses
+	}
+	return 0
+}
+
+func (m *PeerScore) GetStalledRequests() uint64 {
+	if m != nil {
+		return m.StalledRequests
+	}
+	return 0
+}
+
+func (m *PeerScore) GetInvalidBlocks() uint64 {
+	if m != nil {
+		return m.InvalidBlocks
+	}
+	return 0
+}
+
+func (m *PeerScore) GetLatencyMs() uint64 {
+	if m != nil {
+		return m.LatencyMs
+	}
+	return 0
+}
+
+type GetBlockSyncPeerScoresResponse struct {
+	Peers                []*PeerScore `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetBlockSyncPeerScoresResponse) Reset()         { *m = GetBlockSyncPeerScoresResponse{} }
+func (m *GetBlockSyncPeerScoresResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockSyncPeerScoresResponse) ProtoMessage()    {}
+func (*GetBlockSyncPeerScoresResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{37}
+}
+func (m *GetBlockSyncPeerScoresResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockSyncPeerScoresResponse.Unmarshal(m, b)
+}
+func (m *GetBlockSyncPeerScoresResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockSyncPeerScoresResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetBlockSyncPeerScoresResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockSyncPeerScoresResponse.Merge(dst, src)
+}
+func (m *GetBlockSyncPeerScoresResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockSyncPeerScoresResponse.Size(m)
+}
+func (m *GetBlockSyncPeerScoresResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockSyncPeerScoresResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockSyncPeerScoresResponse proto.InternalMessageInfo
+
+func (m *GetBlockSyncPeerScoresResponse) GetPeers() []*PeerScore {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type WatchAddressesRequest struct {
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchAddressesRequest) Reset()         { *m = WatchAddressesRequest{} }
+func (m *WatchAddressesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchAddressesRequest) ProtoMessage()    {}
+func (*WatchAddressesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{38}
+}
+func (m *WatchAddressesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchAddressesRequest.Unmarshal(m, b)
+}
+func (m *WatchAddressesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchAddressesRequest.Marshal(b, m, deterministic)
+}
+func (dst *WatchAddressesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchAddressesRequest.Merge(dst, src)
+}
+func (m *WatchAddressesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchAddressesRequest.Size(m)
+}
+func (m *WatchAddressesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchAddressesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchAddressesRequest proto.InternalMessageInfo
+
+func (m *WatchAddressesRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type BalanceDelta struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Balance              string   `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Delta                string   `protobuf:"bytes,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	ActionHashes         []string `protobuf:"bytes,4,rep,name=actionHashes,proto3" json:"actionHashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceDelta) Reset()         { *m = BalanceDelta{} }
+func (m *BalanceDelta) String() string { return proto.CompactTextString(m) }
+func (*BalanceDelta) ProtoMessage()    {}
+func (*BalanceDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{39}
+}
+func (m *BalanceDelta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceDelta.Unmarshal(m, b)
+}
+func (m *BalanceDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceDelta.Marshal(b, m, deterministic)
+}
+func (dst *BalanceDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceDelta.Merge(dst, src)
+}
+func (m *BalanceDelta) XXX_Size() int {
+	return xxx_messageInfo_BalanceDelta.Size(m)
+}
+func (m *BalanceDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceDelta proto.InternalMessageInfo
+
+func (m *BalanceDelta) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *BalanceDelta) GetBalance() string {
+	if m != nil {
+		return m.Balance
+	}
+	return ""
+}
+
+func (m *BalanceDelta) GetDelta() string {
+	if m != nil {
+		return m.Delta
+	}
+	return ""
+}
+
+func (m *BalanceDelta) GetActionHashes() []string {
+	if m != nil {
+		return m.ActionHashes
+	}
+	return nil
+}
+
+type WatchAddressesResponse struct {
+	Height               uint64          `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash            string          `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Deltas               []*BalanceDelta `protobuf:"bytes,3,rep,name=deltas,proto3" json:"deltas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *WatchAddressesResponse) Reset()         { *m = WatchAddressesResponse{} }
+func (m *WatchAddressesResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchAddressesResponse) ProtoMessage()    {}
+func (*WatchAddressesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{40}
+}
+func (m *WatchAddressesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchAddressesResponse.Unmarshal(m, b)
+}
+func (m *WatchAddressesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchAddressesResponse.Marshal(b, m, deterministic)
+}
+func (dst *WatchAddressesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchAddressesResponse.Merge(dst, src)
+}
+func (m *WatchAddressesResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchAddressesResponse.Size(m)
+}
+func (m *WatchAddressesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchAddressesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchAddressesResponse proto.InternalMessageInfo
+
+func (m *WatchAddressesResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *WatchAddressesResponse) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *WatchAddressesResponse) GetDeltas() []*BalanceDelta {
+	if m != nil {
+		return m.Deltas
+	}
+	return nil
+}
+
+type GetForkEventsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetForkEventsRequest) Reset()         { *m = GetForkEventsRequest{} }
+func (m *GetForkEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetForkEventsRequest) ProtoMessage()    {}
+func (*GetForkEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{41}
+}
+func (m *GetForkEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetForkEventsRequest.Unmarshal(m, b)
+}
+func (m *GetForkEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetForkEventsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetForkEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetForkEventsRequest.Merge(dst, src)
+}
+func (m *GetForkEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetForkEventsRequest.Size(m)
+}
+func (m *GetForkEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetForkEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetForkEventsRequest proto.InternalMessageInfo
+
+type ForkEvent struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// hash of the block on the chosen branch
+	ChosenHash string `protobuf:"bytes,2,opt,name=chosenHash,proto3" json:"chosenHash,omitempty"`
+	// hash of the competing block which is dropped
+	CompetingHash string `protobuf:"bytes,3,opt,name=competingHash,proto3" json:"competingHash,omitempty"`
+	// where the competing block comes from, either consensus or sync
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// why the chosen block wins, either committed or bufferedFirst
+	Reason               string   `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Timestamp            int64    `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForkEvent) Reset()         { *m = ForkEvent{} }
+func (m *ForkEvent) String() string { return proto.CompactTextString(m) }
+func (*ForkEvent) ProtoMessage()    {}
+func (*ForkEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{42}
+}
+func (m *ForkEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForkEvent.Unmarshal(m, b)
+}
+func (m *ForkEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForkEvent.Marshal(b, m, deterministic)
+}
+func (dst *ForkEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForkEvent.Merge(dst, src)
+}
+func (m *ForkEvent) XXX_Size() int {
+	return xxx_messageInfo_ForkEvent.Size(m)
+}
+func (m *ForkEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForkEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForkEvent proto.InternalMessageInfo
+
+func (m *ForkEvent) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ForkEvent) GetChosenHash() string {
+	if m != nil {
+		return m.ChosenHash
+	}
+	return ""
+}
+
+func (m *ForkEvent) GetCompetingHash() string {
+	if m != nil {
+		return m.CompetingHash
+	}
+	return ""
+}
+
+func (m *ForkEvent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ForkEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ForkEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type GetForkEventsResponse struct {
+	Events               []*ForkEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetForkEventsResponse) Reset()         { *m = GetForkEventsResponse{} }
+func (m *GetForkEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetForkEventsResponse) ProtoMessage()    {}
+func (*GetForkEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{43}
+}
+func (m *GetForkEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetForkEventsResponse.Unmarshal(m, b)
+}
+func (m *GetForkEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetForkEventsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetForkEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetForkEventsResponse.Merge(dst, src)
+}
+func (m *GetForkEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetForkEventsResponse.Size(m)
+}
+func (m *GetForkEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetForkEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetForkEventsResponse proto.InternalMessageInfo
+
+func (m *GetForkEventsResponse) GetEvents() []*ForkEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type BlockSyncStatus struct {
+	// tip height when the block sync starts
+	StartingHeight uint64 `protobuf:"varint,1,opt,name=startingHeight,proto3" json:"startingHeight,omitempty"`
+	CurrentHeight  uint64 `protobuf:"varint,2,opt,name=currentHeight,proto3" json:"currentHeight,omitempty"`
+	TargetHeight   uint64 `protobuf:"varint,3,opt,name=targetHeight,proto3" json:"targetHeight,omitempty"`
+	// peers which the blocks are requested from in the last sync round
+	Peers []string `protobuf:"bytes,4,rep,name=peers,proto3" json:"peers,omitempty"`
+	// number of blocks committed per second since the last report
+	BlocksPerSecond      float64  `protobuf:"fixed64,5,opt,name=blocksPerSecond,proto3" json:"blocksPerSecond,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockSyncStatus) Reset()         { *m = BlockSyncStatus{} }
+func (m *BlockSyncStatus) String() string { return proto.CompactTextString(m) }
+func (*BlockSyncStatus) ProtoMessage()    {}
+func (*BlockSyncStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{44}
+}
+func (m *BlockSyncStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSyncStatus.Unmarshal(m, b)
+}
+func (m *BlockSyncStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSyncStatus.Marshal(b, m, deterministic)
+}
+func (dst *BlockSyncStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSyncStatus.Merge(dst, src)
+}
+func (m *BlockSyncStatus) XXX_Size() int {
+	return xxx_messageInfo_BlockSyncStatus.Size(m)
+}
+func (m *BlockSyncStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSyncStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSyncStatus proto.InternalMessageInfo
+
+func (m *BlockSyncStatus) GetStartingHeight() uint64 {
+	if m != nil {
+		return m.StartingHeight
+	}
+	return 0
+}
+
+func (m *BlockSyncStatus) GetCurrentHeight() uint64 {
+	if m != nil {
+		return m.CurrentHeight
+	}
+	return 0
+}
+
+func (m *BlockSyncStatus) GetTargetHeight() uint64 {
+	if m != nil {
+		return m.TargetHeight
+	}
+	return 0
+}
+
+func (m *BlockSyncStatus) GetPeers() []string {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *BlockSyncStatus) GetBlocksPerSecond() float64 {
+	if m != nil {
+		return m.BlocksPerSecond
+	}
+	return 0
+}
+
+type GetBlockSyncStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockSyncStatusRequest) Reset()         { *m = GetBlockSyncStatusRequest{} }
+func (m *GetBlockSyncStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockSyncStatusRequest) ProtoMessage()    {}
+func (*GetBlockSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{45}
+}
+func (m *GetBlockSyncStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockSyncStatusRequest.Unmarshal(m, b)
+}
+func (m *GetBlockSyncStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockSyncStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBlockSyncStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockSyncStatusRequest.Merge(dst, src)
+}
+func (m *GetBlockSyncStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockSyncStatusRequest.Size(m)
+}
+func (m *GetBlockSyncStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockSyncStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockSyncStatusRequest proto.InternalMessageInfo
+
+type GetBlockSyncStatusResponse struct {
+	Status               *BlockSyncStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetBlockSyncStatusResponse) Reset()         { *m = GetBlockSyncStatusResponse{} }
+func (m *GetBlockSyncStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockSyncStatusResponse) ProtoMessage()    {}
+func (*GetBlockSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{46}
+}
+func (m *GetBlockSyncStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockSyncStatusResponse.Unmarshal(m, b)
+}
+func (m *GetBlockSyncStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockSyncStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetBlockSyncStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockSyncStatusResponse.Merge(dst, src)
+}
+func (m *GetBlockSyncStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockSyncStatusResponse.Size(m)
+}
+func (m *GetBlockSyncStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockSyncStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockSyncStatusResponse proto.InternalMessageInfo
+
+func (m *GetBlockSyncStatusResponse) GetStatus() *BlockSyncStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type StreamBlockSyncStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamBlockSyncStatusRequest) Reset()         { *m = StreamBlockSyncStatusRequest{} }
+func (m *StreamBlockSyncStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamBlockSyncStatusRequest) ProtoMessage()    {}
+func (*StreamBlockSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{47}
+}
+func (m *StreamBlockSyncStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBlockSyncStatusRequest.Unmarshal(m, b)
+}
+func (m *StreamBlockSyncStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBlockSyncStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *StreamBlockSyncStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBlockSyncStatusRequest.Merge(dst, src)
+}
+func (m *StreamBlockSyncStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamBlockSyncStatusRequest.Size(m)
+}
+func (m *StreamBlockSyncStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBlockSyncStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamBlockSyncStatusRequest proto.InternalMessageInfo
+
+type StreamBlockSyncStatusResponse struct {
+	Status               *BlockSyncStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StreamBlockSyncStatusResponse) Reset()         { *m = StreamBlockSyncStatusResponse{} }
+func (m *StreamBlockSyncStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamBlockSyncStatusResponse) ProtoMessage()    {}
+func (*StreamBlockSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{48}
+}
+func (m *StreamBlockSyncStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBlockSyncStatusResponse.Unmarshal(m, b)
+}
+func (m *StreamBlockSyncStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBlockSyncStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *StreamBlockSyncStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBlockSyncStatusResponse.Merge(dst, src)
+}
+func (m *StreamBlockSyncStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamBlockSyncStatusResponse.Size(m)
+}
+func (m *StreamBlockSyncStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBlockSyncStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamBlockSyncStatusResponse proto.InternalMessageInfo
+
+func (m *StreamBlockSyncStatusResponse) GetStatus() *BlockSyncStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type GetEpochStatsRequest struct {
+	EpochNumber          uint64   `protobuf:"varint,1,opt,name=epochNumber,proto3" json:"epochNumber,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEpochStatsRequest) Reset()         { *m = GetEpochStatsRequest{} }
+func (m *GetEpochStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEpochStatsRequest) ProtoMessage()    {}
+func (*GetEpochStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{49}
+}
+func (m *GetEpochStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEpochStatsRequest.Unmarshal(m, b)
+}
+func (m *GetEpochStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEpochStatsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetEpochStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEpochStatsRequest.Merge(dst, src)
+}
+func (m *GetEpochStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEpochStatsRequest.Size(m)
+}
+func (m *GetEpochStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEpochStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEpochStatsRequest proto.InternalMessageInfo
+
+func (m *GetEpochStatsRequest) GetEpochNumber() uint64 {
+	if m != nil {
+		return m.EpochNumber
+	}
+	return 0
+}
+
+type EpochStats struct {
+	EpochNumber uint64 `protobuf:"varint,1,opt,name=epochNumber,proto3" json:"epochNumber,omitempty"`
+	StartHeight uint64 `protobuf:"varint,2,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	// the last indexed height of the epoch, which is less than the last height of an ongoing epoch
+	EndHeight  uint64 `protobuf:"varint,3,opt,name=endHeight,proto3" json:"endHeight,omitempty"`
+	NumActions uint64 `protobuf:"varint,4,opt,name=numActions,proto3" json:"numActions,omitempty"`
+	// number of actions per second between the end of the last epoch and the end of this one
+	AverageTps float64 `protobuf:"fixed64,5,opt,name=averageTps,proto3" json:"averageTps,omitempty"`
+	GasUsed    uint64  `protobuf:"varint,6,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+	// number of distinct addresses sending actions
+	ActiveSenders uint64 `protobuf:"varint,7,opt,name=activeSenders,proto3" json:"activeSenders,omitempty"`
+	// number of addresses appearing on chain for the first time
+	NewAccounts          uint64   `protobuf:"varint,8,opt,name=newAccounts,proto3" json:"newAccounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EpochStats) Reset()         { *m = EpochStats{} }
+func (m *EpochStats) String() string { return proto.CompactTextString(m) }
+func (*EpochStats) ProtoMessage()    {}
+func (*EpochStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{50}
+}
+func (m *EpochStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EpochStats.Unmarshal(m, b)
+}
+func (m *EpochStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EpochStats.Marshal(b, m, deterministic)
+}
+func (dst *EpochStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EpochStats.Merge(dst, src)
+}
+func (m *EpochStats) XXX_Size() int {
+	return xxx_messageInfo_EpochStats.Size(m)
+}
+func (m *EpochStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_EpochStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EpochStats proto.InternalMessageInfo
+
+func (m *EpochStats) GetEpochNumber() uint64 {
+	if m != nil {
+		return m.EpochNumber
+	}
+	return 0
+}
+
+func (m *EpochStats) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *EpochStats) GetEndHeight() uint64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *EpochStats) GetNumActions() uint64 {
+	if m != nil {
+		return m.NumActions
+	}
+	return 0
+}
+
+func (m *EpochStats) GetAverageTps() float64 {
+	if m != nil {
+		return m.AverageTps
+	}
+	return 0
+}
+
+func (m *EpochStats) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *EpochStats) GetActiveSenders() uint64 {
+	if m != nil {
+		return m.ActiveSenders
+	}
+	return 0
+}
+
+func (m *EpochStats) GetNewAccounts() uint64 {
+	if m != nil {
+		return m.NewAccounts
+	}
+	return 0
+}
+
+type GetEpochStatsResponse struct {
+	Stats                *EpochStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetEpochStatsResponse) Reset()         { *m = GetEpochStatsResponse{} }
+func (m *GetEpochStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEpochStatsResponse) ProtoMessage()    {}
+func (*GetEpochStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{51}
+}
+func (m *GetEpochStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEpochStatsResponse.Unmarshal(m, b)
+}
+func (m *GetEpochStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEpochStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetEpochStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEpochStatsResponse.Merge(dst, src)
+}
+func (m *GetEpochStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEpochStatsResponse.Size(m)
+}
+func (m *GetEpochStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEpochStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEpochStatsResponse proto.InternalMessageInfo
+
+func (m *GetEpochStatsResponse) GetStats() *EpochStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type StreamPendingActionsRequest struct {
+	// senders of the actions to stream, which are all the senders if empty
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// types of the events to stream, which are all the types if empty
+	EventTypes []PendingActionEventType `protobuf:"varint,2,rep,packed,name=eventTypes,proto3,enum=iotexapi.PendingActionEventType" json:"eventTypes,omitempty"`
+	// types of the actions to stream, such as transfer and execution, which are all the types if empty
+	ActionTypes          []string `protobuf:"bytes,3,rep,name=actionTypes,proto3" json:"actionTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamPendingActionsRequest) Reset()         { *m = StreamPendingActionsRequest{} }
+func (m *StreamPendingActionsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamPendingActionsRequest) ProtoMessage()    {}
+func (*StreamPendingActionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{52}
+}
+func (m *StreamPendingActionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamPendingActionsRequest.Unmarshal(m, b)
+}
+func (m *StreamPendingActionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamPendingActionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *StreamPendingActionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamPendingActionsRequest.Merge(dst, src)
+}
+func (m *StreamPendingActionsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamPendingActionsRequest.Size(m)
+}
+func (m *StreamPendingActionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamPendingActionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamPendingActionsRequest proto.InternalMessageInfo
+
+func (m *StreamPendingActionsRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *StreamPendingActionsRequest) GetEventTypes() []PendingActionEventType {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *StreamPendingActionsRequest) GetActionTypes() []string {
+	if m != nil {
+		return m.ActionTypes
+	}
+	return nil
+}
+
+type StreamPendingActionsResponse struct {
+	Type                 PendingActionEventType `protobuf:"varint,1,opt,name=type,proto3,enum=iotexapi.PendingActionEventType" json:"type,omitempty"`
+	ActHash              string                 `protobuf:"bytes,2,opt,name=actHash,proto3" json:"actHash,omitempty"`
+	Action               *iotextypes.Action     `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *StreamPendingActionsResponse) Reset()         { *m = StreamPendingActionsResponse{} }
+func (m *StreamPendingActionsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamPendingActionsResponse) ProtoMessage()    {}
+func (*StreamPendingActionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{53}
+}
+func (m *StreamPendingActionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamPendingActionsResponse.Unmarshal(m, b)
+}
+func (m *StreamPendingActionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamPendingActionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *StreamPendingActionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamPendingActionsResponse.Merge(dst, src)
+}
+func (m *StreamPendingActionsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamPendingActionsResponse.Size(m)
+}
+func (m *StreamPendingActionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamPendingActionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamPendingActionsResponse proto.InternalMessageInfo
+
+func (m *StreamPendingActionsResponse) GetType() PendingActionEventType {
+	if m != nil {
+		return m.Type
+	}
+	return PendingActionEventType_ADDED
+}
+
+func (m *StreamPendingActionsResponse) GetActHash() string {
+	if m != nil {
+		return m.ActHash
+	}
+	return ""
+}
+
+func (m *StreamPendingActionsResponse) GetAction() *iotextypes.Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+type GetActPoolStatsRequest struct {
+	// sender of the actions to list, which lists no action if empty
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetActPoolStatsRequest) Reset()         { *m = GetActPoolStatsRequest{} }
+func (m *GetActPoolStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetActPoolStatsRequest) ProtoMessage()    {}
+func (*GetActPoolStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{54}
+}
+func (m *GetActPoolStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetActPoolStatsRequest.Unmarshal(m, b)
+}
+func (m *GetActPoolStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetActPoolStatsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetActPoolStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetActPoolStatsRequest.Merge(dst, src)
+}
+func (m *GetActPoolStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetActPoolStatsRequest.Size(m)
+}
+func (m *GetActPoolStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetActPoolStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetActPoolStatsRequest proto.InternalMessageInfo
+
+func (m *GetActPoolStatsRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type ActPoolStats struct {
+	// number of actions whose nonces are contiguous from the pending nonces of their senders
+	NumExecutable uint64 `protobuf:"varint,1,opt,name=numExecutable,proto3" json:"numExecutable,omitempty"`
+	// number of actions waiting for the preceding nonces
+	NumQueued uint64 `protobuf:"varint,2,opt,name=numQueued,proto3" json:"numQueued,omitempty"`
+	Capacity  uint64 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Bytes     uint64 `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// min gas price of the actions accepted by actpool in decimal string format
+	GasPriceFloor        string   `protobuf:"bytes,5,opt,name=gasPriceFloor,proto3" json:"gasPriceFloor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActPoolStats) Reset()         { *m = ActPoolStats{} }
+func (m *ActPoolStats) String() string { return proto.CompactTextString(m) }
+func (*ActPoolStats) ProtoMessage()    {}
+func (*ActPoolStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{55}
+}
+func (m *ActPoolStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActPoolStats.Unmarshal(m, b)
+}
+func (m *ActPoolStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActPoolStats.Marshal(b, m, deterministic)
+}
+func (dst *ActPoolStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActPoolStats.Merge(dst, src)
+}
+func (m *ActPoolStats) XXX_Size() int {
+	return xxx_messageInfo_ActPoolStats.Size(m)
+}
+func (m *ActPoolStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActPoolStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActPoolStats proto.InternalMessageInfo
+
+func (m *ActPoolStats) GetNumExecutable() uint64 {
+	if m != nil {
+		return m.NumExecutable
+	}
+	return 0
+}
+
+func (m *ActPoolStats) GetNumQueued() uint64 {
+	if m != nil {
+		return m.NumQueued
+	}
+	return 0
+}
+
+func (m *ActPoolStats) GetCapacity() uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ActPoolStats) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *ActPoolStats) GetGasPriceFloor() string {
+	if m != nil {
+		return m.GasPriceFloor
+	}
+	return ""
+}
+
+type GetActPoolStatsResponse struct {
+	Stats                *ActPoolStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	ExecutableActHashes  []string      `protobuf:"bytes,2,rep,name=executableActHashes,proto3" json:"executableActHashes,omitempty"`
+	QueuedActHashes      []string      `protobuf:"bytes,3,rep,name=queuedActHashes,proto3" json:"queuedActHashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetActPoolStatsResponse) Reset()         { *m = GetActPoolStatsResponse{} }
+func (m *GetActPoolStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetActPoolStatsResponse) ProtoMessage()    {}
+func (*GetActPoolStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{56}
+}
+func (m *GetActPoolStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetActPoolStatsResponse.Unmarshal(m, b)
+}
+func (m *GetActPoolStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetActPoolStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetActPoolStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetActPoolStatsResponse.Merge(dst, src)
+}
+func (m *GetActPoolStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetActPoolStatsResponse.Size(m)
+}
+func (m *GetActPoolStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetActPoolStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetActPoolStatsResponse proto.InternalMessageInfo
+
+func (m *GetActPoolStatsResponse) GetStats() *ActPoolStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *GetActPoolStatsResponse) GetExecutableActHashes() []string {
+	if m != nil {
+		return m.ExecutableActHashes
+	}
+	return nil
+}
+
+func (m *GetActPoolStatsResponse) GetQueuedActHashes() []string {
+	if m != nil {
+		return m.QueuedActHashes
+	}
+	return nil
+}
+
+// the substitution of the states of an address in a contract read
+type StateOverride struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// balance in decimal string, empty means the balance isn't overridden
+	Balance string `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	// empty means the code isn't overridden
+	Code                 []byte             `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Storage              []*StorageOverride `protobuf:"bytes,4,rep,name=storage,proto3" json:"storage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *StateOverride) Reset()         { *m = StateOverride{} }
+func (m *StateOverride) String() string { return proto.CompactTextString(m) }
+func (*StateOverride) ProtoMessage()    {}
+func (*StateOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{57}
+}
+func (m *StateOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateOverride.Unmarshal(m, b)
+}
+func (m *StateOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateOverride.Marshal(b, m, deterministic)
+}
+func (dst *StateOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateOverride.Merge(dst, src)
+}
+func (m *StateOverride) XXX_Size() int {
+	return xxx_messageInfo_StateOverride.Size(m)
+}
+func (m *StateOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateOverride proto.InternalMessageInfo
+
+func (m *StateOverride) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *StateOverride) GetBalance() string {
+	if m != nil {
+		return m.Balance
+	}
+	return ""
+}
+
+func (m *StateOverride) GetCode() []byte {
+	if m != nil {
+		return m.Code
+	}
+	return nil
+}
+
+func (m *StateOverride) GetStorage() []*StorageOverride {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+type StorageOverride struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageOverride) Reset()         { *m = StorageOverride{} }
+func (m *StorageOverride) String() string { return proto.CompactTextString(m) }
+func (*StorageOverride) ProtoMessage()    {}
+func (*StorageOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{58}
+}
+func (m *StorageOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageOverride.Unmarshal(m, b)
+}
+func (m *StorageOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageOverride.Marshal(b, m, deterministic)
+}
+func (dst *StorageOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageOverride.Merge(dst, src)
+}
+func (m *StorageOverride) XXX_Size() int {
+	return xxx_messageInfo_StorageOverride.Size(m)
+}
+func (m *StorageOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageOverride proto.InternalMessageInfo
+
+func (m *StorageOverride) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StorageOverride) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type DBSize struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Bytes                uint64   `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DBSize) Reset()         { *m = DBSize{} }
+func (m *DBSize) String() string { return proto.CompactTextString(m) }
+func (*DBSize) ProtoMessage()    {}
+func (*DBSize) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{59}
+}
+func (m *DBSize) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBSize.Unmarshal(m, b)
+}
+func (m *DBSize) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBSize.Marshal(b, m, deterministic)
+}
+func (dst *DBSize) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBSize.Merge(dst, src)
+}
+func (m *DBSize) XXX_Size() int {
+	return xxx_messageInfo_DBSize.Size(m)
+}
+func (m *DBSize) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBSize.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBSize proto.InternalMessageInfo
+
+func (m *DBSize) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DBSize) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DBSize) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type StorageMeta struct {
+	Dbs                  []*DBSize `protobuf:"bytes,1,rep,name=dbs,proto3" json:"dbs,omitempty"`
+	TotalBytes           uint64    `protobuf:"varint,2,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *StorageMeta) Reset()         { *m = StorageMeta{} }
+func (m *StorageMeta) String() string { return proto.CompactTextString(m) }
+func (*StorageMeta) ProtoMessage()    {}
+func (*StorageMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{60}
+}
+func (m *StorageMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageMeta.Unmarshal(m, b)
+}
+func (m *StorageMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageMeta.Marshal(b, m, deterministic)
+}
+func (dst *StorageMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageMeta.Merge(dst, src)
+}
+func (m *StorageMeta) XXX_Size() int {
+	return xxx_messageInfo_StorageMeta.Size(m)
+}
+func (m *StorageMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageMeta proto.InternalMessageInfo
+
+func (m *StorageMeta) GetDbs() []*DBSize {
+	if m != nil {
+		return m.Dbs
+	}
+	return nil
+}
+
+func (m *StorageMeta) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+type SyncMeta struct {
+	BlockSync  *BlockSyncStatus `protobuf:"bytes,1,opt,name=blockSync,proto3" json:"blockSync,omitempty"`
+	CatchingUp bool             `protobuf:"varint,2,opt,name=catchingUp,proto3" json:"catchingUp,omitempty"`
+	// number of committed blocks which haven't been indexed yet
+	IndexLag             uint64   `protobuf:"varint,3,opt,name=indexLag,proto3" json:"indexLag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncMeta) Reset()         { *m = SyncMeta{} }
+func (m *SyncMeta) String() string { return proto.CompactTextString(m) }
+func (*SyncMeta) ProtoMessage()    {}
+func (*SyncMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{61}
+}
+func (m *SyncMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncMeta.Unmarshal(m, b)
+}
+func (m *SyncMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncMeta.Marshal(b, m, deterministic)
+}
+func (dst *SyncMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncMeta.Merge(dst, src)
+}
+func (m *SyncMeta) XXX_Size() int {
+	return xxx_messageInfo_SyncMeta.Size(m)
+}
+func (m *SyncMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncMeta proto.InternalMessageInfo
+
+func (m *SyncMeta) GetBlockSync() *BlockSyncStatus {
+	if m != nil {
+		return m.BlockSync
+	}
+	return nil
+}
+
+func (m *SyncMeta) GetCatchingUp() bool {
+	if m != nil {
+		return m.CatchingUp
+	}
+	return false
+}
+
+func (m *SyncMeta) GetIndexLag() uint64 {
+	if m != nil {
+		return m.IndexLag
+	}
+	return 0
+}
+
+type ConsensusMeta struct {
+	Epoch               uint64   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Height              uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Delegates           []string `protobuf:"bytes,3,rep,name=delegates,proto3" json:"delegates,omitempty"`
+	LatestBlockProducer string   `protobuf:"bytes,4,opt,name=latestBlockProducer,proto3" json:"latestBlockProducer,omitempty"`
+	NumCandidates       uint64   `protobuf:"varint,5,opt,name=numCandidates,proto3" json:"numCandidates,omitempty"`
+	// the counters are accumulated since the node started
+	ProposalsReceived    uint64   `protobuf:"varint,6,opt,name=proposalsReceived,proto3" json:"proposalsReceived,omitempty"`
+	RoundTimeouts        uint64   `protobuf:"varint,7,opt,name=roundTimeouts,proto3" json:"roundTimeouts,omitempty"`
+	EpochTransitions     uint64   `protobuf:"varint,8,opt,name=epochTransitions,proto3" json:"epochTransitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConsensusMeta) Reset()         { *m = ConsensusMeta{} }
+func (m *ConsensusMeta) String() string { return proto.CompactTextString(m) }
+func (*ConsensusMeta) ProtoMessage()    {}
+func (*ConsensusMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{62}
+}
+func (m *ConsensusMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsensusMeta.Unmarshal(m, b)
+}
+func (m *ConsensusMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsensusMeta.Marshal(b, m, deterministic)
+}
+func (dst *ConsensusMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusMeta.Merge(dst, src)
+}
+func (m *ConsensusMeta) XXX_Size() int {
+	return xxx_messageInfo_ConsensusMeta.Size(m)
+}
+func (m *ConsensusMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusMeta proto.InternalMessageInfo
+
+func (m *ConsensusMeta) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *ConsensusMeta) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ConsensusMeta) GetDelegates() []string {
+	if m != nil {
+		return m.Delegates
+	}
+	return nil
+}
+
+func (m *ConsensusMeta) GetLatestBlockProducer() string {
+	if m != nil {
+		return m.LatestBlockProducer
+	}
+	return ""
+}
+
+func (m *ConsensusMeta) GetNumCandidates() uint64 {
+	if m != nil {
+		return m.NumCandidates
+	}
+	return 0
+}
+
+func (m *ConsensusMeta) GetProposalsReceived() uint64 {
+	if m != nil {
+		return m.ProposalsReceived
+	}
+	return 0
+}
+
+func (m *ConsensusMeta) GetRoundTimeouts() uint64 {
+	if m != nil {
+		return m.RoundTimeouts
+	}
+	return 0
+}
+
+func (m *ConsensusMeta) GetEpochTransitions() uint64 {
+	if m != nil {
+		return m.EpochTransitions
+	}
+	return 0
+}
+
+type NetworkMeta struct {
+	// the peer ID and addresses are only served by the admin endpoint
+	PeerID               string   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Addresses            []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	NumNeighbors         uint64   `protobuf:"varint,3,opt,name=numNeighbors,proto3" json:"numNeighbors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkMeta) Reset()         { *m = NetworkMeta{} }
+func (m *NetworkMeta) String() string { return proto.CompactTextString(m) }
+func (*NetworkMeta) ProtoMessage()    {}
+func (*NetworkMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{63}
+}
+func (m *NetworkMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkMeta.Unmarshal(m, b)
+}
+func (m *NetworkMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkMeta.Marshal(b, m, deterministic)
+}
+func (dst *NetworkMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkMeta.Merge(dst, src)
+}
+func (m *NetworkMeta) XXX_Size() int {
+	return xxx_messageInfo_NetworkMeta.Size(m)
+}
+func (m *NetworkMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkMeta proto.InternalMessageInfo
+
+func (m *NetworkMeta) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+func (m *NetworkMeta) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *NetworkMeta) GetNumNeighbors() uint64 {
+	if m != nil {
+		return m.NumNeighbors
+	}
+	return 0
+}
+
+type GetConsensusTimelineRequest struct {
+	Count                uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConsensusTimelineRequest) Reset()         { *m = GetConsensusTimelineRequest{} }
+func (m *GetConsensusTimelineRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusTimelineRequest) ProtoMessage()    {}
+func (*GetConsensusTimelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{64}
+}
+func (m *GetConsensusTimelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusTimelineRequest.Unmarshal(m, b)
+}
+func (m *GetConsensusTimelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusTimelineRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetConsensusTimelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusTimelineRequest.Merge(dst, src)
+}
+func (m *GetConsensusTimelineRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusTimelineRequest.Size(m)
+}
+func (m *GetConsensusTimelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusTimelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusTimelineRequest proto.InternalMessageInfo
+
+func (m *GetConsensusTimelineRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type EndorsementLatency struct {
+	Endorser             string   `protobuf:"bytes,1,opt,name=endorser,proto3" json:"endorser,omitempty"`
+	Proposal             int64    `protobuf:"varint,2,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	Lock                 int64    `protobuf:"varint,3,opt,name=lock,proto3" json:"lock,omitempty"`
+	Commit               int64    `protobuf:"varint,4,opt,name=commit,proto3" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndorsementLatency) Reset()         { *m = EndorsementLatency{} }
+func (m *EndorsementLatency) String() string { return proto.CompactTextString(m) }
+func (*EndorsementLatency) ProtoMessage()    {}
+func (*EndorsementLatency) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{65}
+}
+func (m *EndorsementLatency) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorsementLatency.Unmarshal(m, b)
+}
+func (m *EndorsementLatency) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorsementLatency.Marshal(b, m, deterministic)
+}
+func (dst *EndorsementLatency) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorsementLatency.Merge(dst, src)
+}
+func (m *EndorsementLatency) XXX_Size() int {
+	return xxx_messageInfo_EndorsementLatency.Size(m)
+}
+func (m *EndorsementLatency) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorsementLatency.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorsementLatency proto.InternalMessageInfo
+
+func (m *EndorsementLatency) GetEndorser() string {
+	if m != nil {
+		return m.Endorser
+	}
+	return ""
+}
+
+func (m *EndorsementLatency) GetProposal() int64 {
+	if m != nil {
+		return m.Proposal
+	}
+	return 0
+}
+
+func (m *EndorsementLatency) GetLock() int64 {
+	if m != nil {
+		return m.Lock
+	}
+	return 0
+}
+
+func (m *EndorsementLatency) GetCommit() int64 {
+	if m != nil {
+		return m.Commit
+	}
+	return 0
+}
+
+type RoundTimeline struct {
+	Height               uint64                `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round                uint32                `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Proposer             string                `protobuf:"bytes,3,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	StartTime            int64                 `protobuf:"varint,4,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	ProposalReceived     int64                 `protobuf:"varint,5,opt,name=proposalReceived,proto3" json:"proposalReceived,omitempty"`
+	ProposalQuorum       int64                 `protobuf:"varint,6,opt,name=proposalQuorum,proto3" json:"proposalQuorum,omitempty"`
+	LockQuorum           int64                 `protobuf:"varint,7,opt,name=lockQuorum,proto3" json:"lockQuorum,omitempty"`
+	Committed            int64                 `protobuf:"varint,8,opt,name=committed,proto3" json:"committed,omitempty"`
+	Endorsements         []*EndorsementLatency `protobuf:"bytes,9,rep,name=endorsements,proto3" json:"endorsements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *RoundTimeline) Reset()         { *m = RoundTimeline{} }
+func (m *RoundTimeline) String() string { return proto.CompactTextString(m) }
+func (*RoundTimeline) ProtoMessage()    {}
+func (*RoundTimeline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{66}
+}
+func (m *RoundTimeline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoundTimeline.Unmarshal(m, b)
+}
+func (m *RoundTimeline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoundTimeline.Marshal(b, m, deterministic)
+}
+func (dst *RoundTimeline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundTimeline.Merge(dst, src)
+}
+func (m *RoundTimeline) XXX_Size() int {
+	return xxx_messageInfo_RoundTimeline.Size(m)
+}
+func (m *RoundTimeline) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundTimeline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundTimeline proto.InternalMessageInfo
+
+func (m *RoundTimeline) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RoundTimeline) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundTimeline) GetProposer() string {
+	if m != nil {
+		return m.Proposer
+	}
+	return ""
+}
+
+func (m *RoundTimeline) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RoundTimeline) GetProposalReceived() int64 {
+	if m != nil {
+		return m.ProposalReceived
+	}
+	return 0
+}
+
+func (m *RoundTimeline) GetProposalQuorum() int64 {
+	if m != nil {
+		return m.ProposalQuorum
+	}
+	return 0
+}
+
+func (m *RoundTimeline) GetLockQuorum() int64 {
+	if m != nil {
+		return m.LockQuorum
+	}
+	return 0
+}
+
+func (m *RoundTimeline) GetCommitted() int64 {
+	if m != nil {
+		return m.Committed
+	}
+	return 0
+}
+
+func (m *RoundTimeline) GetEndorsements() []*EndorsementLatency {
+	if m != nil {
+		return m.Endorsements
+	}
+	return nil
+}
+
+type GetConsensusTimelineResponse struct {
+	Rounds               []*RoundTimeline `protobuf:"bytes,1,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetConsensusTimelineResponse) Reset()         { *m = GetConsensusTimelineResponse{} }
+func (m *GetConsensusTimelineResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusTimelineResponse) ProtoMessage()    {}
+func (*GetConsensusTimelineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{67}
+}
+func (m *GetConsensusTimelineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusTimelineResponse.Unmarshal(m, b)
+}
+func (m *GetConsensusTimelineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusTimelineResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetConsensusTimelineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusTimelineResponse.Merge(dst, src)
+}
+func (m *GetConsensusTimelineResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusTimelineResponse.Size(m)
+}
+func (m *GetConsensusTimelineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusTimelineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusTimelineResponse proto.InternalMessageInfo
+
+func (m *GetConsensusTimelineResponse) GetRounds() []*RoundTimeline {
+	if m != nil {
+		return m.Rounds
+	}
+	return nil
+}
+
+type GetStateDiffRequest struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateDiffRequest) Reset()         { *m = GetStateDiffRequest{} }
+func (m *GetStateDiffRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateDiffRequest) ProtoMessage()    {}
+func (*GetStateDiffRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{68}
+}
+func (m *GetStateDiffRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateDiffRequest.Unmarshal(m, b)
+}
+func (m *GetStateDiffRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateDiffRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetStateDiffRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateDiffRequest.Merge(dst, src)
+}
+func (m *GetStateDiffRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateDiffRequest.Size(m)
+}
+func (m *GetStateDiffRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateDiffRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateDiffRequest proto.InternalMessageInfo
+
+func (m *GetStateDiffRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// the key and the values are hex encoded, and a value is empty if the state doesn't exist
+type StateChange struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// address of the account, if the state is the account of the producer, or a sender or recipient of the actions
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Before               string   `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+	After                string   `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateChange) Reset()         { *m = StateChange{} }
+func (m *StateChange) String() string { return proto.CompactTextString(m) }
+func (*StateChange) ProtoMessage()    {}
+func (*StateChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{69}
+}
+func (m *StateChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChange.Unmarshal(m, b)
+}
+func (m *StateChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChange.Marshal(b, m, deterministic)
+}
+func (dst *StateChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChange.Merge(dst, src)
+}
+func (m *StateChange) XXX_Size() int {
+	return xxx_messageInfo_StateChange.Size(m)
+}
+func (m *StateChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChange proto.InternalMessageInfo
+
+func (m *StateChange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateChange) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *StateChange) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *StateChange) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+type ActionStateDiff struct {
+	ActHash              string         `protobuf:"bytes,1,opt,name=actHash,proto3" json:"actHash,omitempty"`
+	Changes              []*StateChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ActionStateDiff) Reset()         { *m = ActionStateDiff{} }
+func (m *ActionStateDiff) String() string { return proto.CompactTextString(m) }
+func (*ActionStateDiff) ProtoMessage()    {}
+func (*ActionStateDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{70}
+}
+func (m *ActionStateDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionStateDiff.Unmarshal(m, b)
+}
+func (m *ActionStateDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionStateDiff.Marshal(b, m, deterministic)
+}
+func (dst *ActionStateDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionStateDiff.Merge(dst, src)
+}
+func (m *ActionStateDiff) XXX_Size() int {
+	return xxx_messageInfo_ActionStateDiff.Size(m)
+}
+func (m *ActionStateDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionStateDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionStateDiff proto.InternalMessageInfo
+
+func (m *ActionStateDiff) GetActHash() string {
+	if m != nil {
+		return m.ActHash
+	}
+	return ""
+}
+
+func (m *ActionStateDiff) GetChanges() []*StateChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// the expected roots are in the block header, while the others are of the local execution
+type StateDiff struct {
+	Height                   uint64             `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlkHash                  string             `protobuf:"bytes,2,opt,name=blkHash,proto3" json:"blkHash,omitempty"`
+	Error                    string             `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ExpectedStateRoot        string             `protobuf:"bytes,4,opt,name=expectedStateRoot,proto3" json:"expectedStateRoot,omitempty"`
+	StateRoot                string             `protobuf:"bytes,5,opt,name=stateRoot,proto3" json:"stateRoot,omitempty"`
+	ExpectedDeltaStateDigest string             `protobuf:"bytes,6,opt,name=expectedDeltaStateDigest,proto3" json:"expectedDeltaStateDigest,omitempty"`
+	DeltaStateDigest         string             `protobuf:"bytes,7,opt,name=deltaStateDigest,proto3" json:"deltaStateDigest,omitempty"`
+	Actions                  []*ActionStateDiff `protobuf:"bytes,8,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}           `json:"-"`
+	XXX_unrecognized         []byte             `json:"-"`
+	XXX_sizecache            int32              `json:"-"`
+}
+
+func (m *StateDiff) Reset()         { *m = StateDiff{} }
+func (m *StateDiff) String() string { return proto.CompactTextString(m) }
+func (*StateDiff) ProtoMessage()    {}
+func (*StateDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{71}
+}
+func (m *StateDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateDiff.Unmarshal(m, b)
+}
+func (m *StateDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateDiff.Marshal(b, m, deterministic)
+}
+func (dst *StateDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateDiff.Merge(dst, src)
+}
+func (m *StateDiff) XXX_Size() int {
+	return xxx_messageInfo_StateDiff.Size(m)
+}
+func (m *StateDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateDiff proto.InternalMessageInfo
+
+func (m *StateDiff) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateDiff) GetBlkHash() string {
+	if m != nil {
+		return m.BlkHash
+	}
+	return ""
+}
+
+func (m *StateDiff) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *StateDiff) GetExpectedStateRoot() string {
+	if m != nil {
+		return m.ExpectedStateRoot
+	}
+	return ""
+}
+
+func (m *StateDiff) GetStateRoot() string {
+	if m != nil {
+		return m.StateRoot
+	}
+	return ""
+}
+
+func (m *StateDiff) GetExpectedDeltaStateDigest() string {
+	if m != nil {
+		return m.ExpectedDeltaStateDigest
+	}
+	return ""
+}
+
+func (m *StateDiff) GetDeltaStateDigest() string {
+	if m != nil {
+		return m.DeltaStateDigest
+	}
+	return ""
+}
+
+func (m *StateDiff) GetActions() []*ActionStateDiff {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+type GetStateDiffResponse struct {
+	StateDiff            *StateDiff `protobuf:"bytes,1,opt,name=stateDiff,proto3" json:"stateDiff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetStateDiffResponse) Reset()         { *m = GetStateDiffResponse{} }
+func (m *GetStateDiffResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateDiffResponse) ProtoMessage()    {}
+func (*GetStateDiffResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{72}
+}
+func (m *GetStateDiffResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateDiffResponse.Unmarshal(m, b)
+}
+func (m *GetStateDiffResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateDiffResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetStateDiffResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateDiffResponse.Merge(dst, src)
+}
+func (m *GetStateDiffResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateDiffResponse.Size(m)
+}
+func (m *GetStateDiffResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateDiffResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateDiffResponse proto.InternalMessageInfo
+
+func (m *GetStateDiffResponse) GetStateDiff() *StateDiff {
+	if m != nil {
+		return m.StateDiff
+	}
+	return nil
+}
+
+type GetStateGrowthRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateGrowthRequest) Reset()         { *m = GetStateGrowthRequest{} }
+func (m *GetStateGrowthRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateGrowthRequest) ProtoMessage()    {}
+func (*GetStateGrowthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{73}
+}
+func (m *GetStateGrowthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateGrowthRequest.Unmarshal(m, b)
+}
+func (m *GetStateGrowthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateGrowthRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetStateGrowthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateGrowthRequest.Merge(dst, src)
+}
+func (m *GetStateGrowthRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateGrowthRequest.Size(m)
+}
+func (m *GetStateGrowthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateGrowthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateGrowthRequest proto.InternalMessageInfo
+
+// the growths are negative if more are deleted than added
+type GetStateGrowthResponse struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	TrieNodes            int64    `protobuf:"varint,2,opt,name=trieNodes,proto3" json:"trieNodes,omitempty"`
+	StateBytes           int64    `protobuf:"varint,3,opt,name=stateBytes,proto3" json:"stateBytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateGrowthResponse) Reset()         { *m = GetStateGrowthResponse{} }
+func (m *GetStateGrowthResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateGrowthResponse) ProtoMessage()    {}
+func (*GetStateGrowthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_api_e88018757adab6e4, []int{74}
+}
+func (m *GetStateGrowthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateGrowthResponse.Unmarshal(m, b)
+}
+func (m *GetStateGrowthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateGrowthResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetStateGrowthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateGrowthResponse.Merge(dst, src)
+}
+func (m *GetStateGrowthResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateGrowthResponse.Size(m)
+}
+func (m *GetStateGrowthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateGrowthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateGrowthResponse proto.InternalMessageInfo
+
+func (m *GetStateGrowthResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetStateGrowthResponse) GetTrieNodes() int64 {
+	if m != nil {
+		return m.TrieNodes
+	}
+	return 0
+}
+
+func (m *GetStateGrowthResponse) GetStateBytes() int64 {
+	if m != nil {
+		return m.StateBytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*GetAccountRequest)(nil), "iotexapi.GetAccountRequest")
+	proto.RegisterType((*GetAccountResponse)(nil), "iotexapi.GetAccountResponse")
+	proto.RegisterType((*GetActionsRequest)(nil), "iotexapi.GetActionsRequest")
+	proto.RegisterType((*GetActionsByIndexRequest)(nil), "iotexapi.GetActionsByIndexRequest")
+	proto.RegisterType((*GetActionByHashRequest)(nil), "iotexapi.GetActionByHashRequest")
+	proto.RegisterType((*GetActionsByAddressRequest)(nil), "iotexapi.GetActionsByAddressRequest")
+	proto.RegisterType((*GetUnconfirmedActionsByAddressRequest)(nil), "iotexapi.GetUnconfirmedActionsByAddressRequest")
+	proto.RegisterType((*GetActionsByBlockRequest)(nil), "iotexapi.GetActionsByBlockRequest")
+	proto.RegisterType((*GetActionsResponse)(nil), "iotexapi.GetActionsResponse")
+	proto.RegisterType((*GetBlockMetasRequest)(nil), "iotexapi.GetBlockMetasRequest")
+	proto.RegisterType((*GetBlockMetasByIndexRequest)(nil), "iotexapi.GetBlockMetasByIndexRequest")
+	proto.RegisterType((*GetBlockMetaByHashRequest)(nil), "iotexapi.GetBlockMetaByHashRequest")
+	proto.RegisterType((*GetBlockMetasResponse)(nil), "iotexapi.GetBlockMetasResponse")
+	proto.RegisterType((*GetRawBlocksRequest)(nil), "iotexapi.GetRawBlocksRequest")
+	proto.RegisterType((*GetRawBlocksResponse)(nil), "iotexapi.GetRawBlocksResponse")
+	proto.RegisterType((*GetChainMetaRequest)(nil), "iotexapi.GetChainMetaRequest")
+	proto.RegisterType((*GetChainMetaResponse)(nil), "iotexapi.GetChainMetaResponse")
+	proto.RegisterType((*SendActionRequest)(nil), "iotexapi.SendActionRequest")
+	proto.RegisterType((*SendActionResponse)(nil), "iotexapi.SendActionResponse")
+	proto.RegisterType((*GetReceiptByActionRequest)(nil), "iotexapi.GetReceiptByActionRequest")
+	proto.RegisterType((*GetReceiptByActionResponse)(nil), "iotexapi.GetReceiptByActionResponse")
+	proto.RegisterType((*ReadContractRequest)(nil), "iotexapi.ReadContractRequest")
+	proto.RegisterType((*ReadContractResponse)(nil), "iotexapi.ReadContractResponse")
+	proto.RegisterType((*SuggestGasPriceRequest)(nil), "iotexapi.SuggestGasPriceRequest")
+	proto.RegisterType((*SuggestGasPriceResponse)(nil), "iotexapi.SuggestGasPriceResponse")
+	proto.RegisterType((*EstimateGasForActionRequest)(nil), "iotexapi.EstimateGasForActionRequest")
+	proto.RegisterType((*EstimateGasForActionResponse)(nil), "iotexapi.EstimateGasForActionResponse")
+	proto.RegisterType((*ReadStateRequest)(nil), "iotexapi.ReadStateRequest")
+	proto.RegisterType((*ReadStateResponse)(nil), "iotexapi.ReadStateResponse")
+	proto.RegisterType((*GetDelegateParticipationRequest)(nil), "iotexapi.GetDelegateParticipationRequest")
+	proto.RegisterType((*DelegateParticipation)(nil), "iotexapi.DelegateParticipation")
+	proto.RegisterType((*GetDelegateParticipationResponse)(nil), "iotexapi.GetDelegateParticipationResponse")
+	proto.RegisterType((*GetBlockSyncBufferStatsRequest)(nil), "iotexapi.GetBlockSyncBufferStatsRequest")
+	proto.RegisterType((*BlockSyncBufferStats)(nil), "iotexapi.BlockSyncBufferStats")
+	proto.RegisterType((*GetBlockSyncBufferStatsResponse)(nil), "iotexapi.GetBlockSyncBufferStatsResponse")
+	proto.RegisterType((*GetBlockSyncPeerScoresRequest)(nil), "iotexapi.GetBlockSyncPeerScoresRequest")
+	proto.RegisterType((*PeerScore)(nil), "iotexapi.PeerScore")
+	proto.RegisterType((*GetBlockSyncPeerScoresResponse)(nil), "iotexapi.GetBlockSyncPeerScoresResponse")
+	proto.RegisterType((*WatchAddressesRequest)(nil), "iotexapi.WatchAddressesRequest")
+	proto.RegisterType((*BalanceDelta)(nil), "iotexapi.BalanceDelta")
+	proto.RegisterType((*WatchAddressesResponse)(nil), "iotexapi.WatchAddressesResponse")
+	proto.RegisterType((*GetForkEventsRequest)(nil), "iotexapi.GetForkEventsRequest")
+	proto.RegisterType((*ForkEvent)(nil), "iotexapi.ForkEvent")
+	proto.RegisterType((*GetForkEventsResponse)(nil), "iotexapi.GetForkEventsResponse")
+	proto.RegisterType((*BlockSyncStatus)(nil), "iotexapi.BlockSyncStatus")
+	proto.RegisterType((*GetBlockSyncStatusRequest)(nil), "iotexapi.GetBlockSyncStatusRequest")
+	proto.RegisterType((*GetBlockSyncStatusResponse)(nil), "iotexapi.GetBlockSyncStatusResponse")
+	proto.RegisterType((*StreamBlockSyncStatusRequest)(nil), "iotexapi.StreamBlockSyncStatusRequest")
+	proto.RegisterType((*StreamBlockSyncStatusResponse)(nil), "iotexapi.StreamBlockSyncStatusResponse")
+	proto.RegisterType((*GetEpochStatsRequest)(nil), "iotexapi.GetEpochStatsRequest")
+	proto.RegisterType((*EpochStats)(nil), "iotexapi.EpochStats")
+	proto.RegisterType((*GetEpochStatsResponse)(nil), "iotexapi.GetEpochStatsResponse")
+	proto.RegisterType((*StreamPendingActionsRequest)(nil), "iotexapi.StreamPendingActionsRequest")
+	proto.RegisterType((*StreamPendingActionsResponse)(nil), "iotexapi.StreamPendingActionsResponse")
+	proto.RegisterType((*GetActPoolStatsRequest)(nil), "iotexapi.GetActPoolStatsRequest")
+	proto.RegisterType((*ActPoolStats)(nil), "iotexapi.ActPoolStats")
+	proto.RegisterType((*GetActPoolStatsResponse)(nil), "iotexapi.GetActPoolStatsResponse")
+	proto.RegisterType((*StateOverride)(nil), "iotexapi.StateOverride")
+	proto.RegisterType((*StorageOverride)(nil), "iotexapi.StorageOverride")
+	proto.RegisterType((*DBSize)(nil), "iotexapi.DBSize")
+	proto.RegisterType((*StorageMeta)(nil), "iotexapi.StorageMeta")
+	proto.RegisterType((*SyncMeta)(nil), "iotexapi.SyncMeta")
+	proto.RegisterType((*ConsensusMeta)(nil), "iotexapi.ConsensusMeta")
+	proto.RegisterType((*NetworkMeta)(nil), "iotexapi.NetworkMeta")
+	proto.RegisterType((*GetConsensusTimelineRequest)(nil), "iotexapi.GetConsensusTimelineRequest")
+	proto.RegisterType((*EndorsementLatency)(nil), "iotexapi.EndorsementLatency")
+	proto.RegisterType((*RoundTimeline)(nil), "iotexapi.RoundTimeline")
+	proto.RegisterType((*GetConsensusTimelineResponse)(nil), "iotexapi.GetConsensusTimelineResponse")
+	proto.RegisterType((*GetStateDiffRequest)(nil), "iotexapi.GetStateDiffRequest")
+	proto.RegisterType((*StateChange)(nil), "iotexapi.StateChange")
+	proto.RegisterType((*ActionStateDiff)(nil), "iotexapi.ActionStateDiff")
+	proto.RegisterType((*StateDiff)(nil), "iotexapi.StateDiff")
+	proto.RegisterType((*GetStateDiffResponse)(nil), "iotexapi.GetStateDiffResponse")
+	proto.RegisterType((*GetStateGrowthRequest)(nil), "iotexapi.GetStateGrowthRequest")
+	proto.RegisterType((*GetStateGrowthResponse)(nil), "iotexapi.GetStateGrowthResponse")
+	proto.RegisterEnum("iotexapi.PendingActionEventType", PendingActionEventType_name, PendingActionEventType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// APIServiceClient is the client API for APIService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type APIServiceClient interface {
+	// get the address detail of an address
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error)
+	// get action(s) by:
+	// 1. start index and action count
+	// 2. action hash
+	// 3. address with start index and action count
+	// 4. get unconfirmed actions by address with start index and action count
+	// 5. block hash with start index and action count
+	GetActions(ctx context.Context, in *GetActionsRequest, opts ...grpc.CallOption) (*GetActionsResponse, error)
+	// get block metadata(s) by:
+	// 1. start index and block count
+	// 2. block hash
+	GetBlockMetas(ctx context.Context, in *GetBlockMetasRequest, opts ...grpc.CallOption) (*GetBlockMetasResponse, error)
+	// get raw blocks, including the footers
+	GetRawBlocks(ctx context.Context, in *GetRawBlocksRequest, opts ...grpc.CallOption) (*GetRawBlocksResponse, error)
+	// get chain metadata
+	GetChainMeta(ctx context.Context, in *GetChainMetaRequest, opts ...grpc.CallOption) (*GetChainMetaResponse, error)
+	// sendAction
+	SendAction(ctx context.Context, in *SendActionRequest, opts ...grpc.CallOption) (*SendActionResponse, error)
+	// get receipt by action Hash
+	GetReceiptByAction(ctx context.Context, in *GetReceiptByActionRequest, opts ...grpc.CallOption) (*GetReceiptByActionResponse, error)
+	// TODO: read contract
+	ReadContract(ctx context.Context, in *ReadContractRequest, opts ...grpc.CallOption) (*ReadContractResponse, error)
+	// suggest gas price
+	SuggestGasPrice(ctx context.Context, in *SuggestGasPriceRequest, opts ...grpc.CallOption) (*SuggestGasPriceResponse, error)
+	// estimate gas for action
+	EstimateGasForAction(ctx context.Context, in *EstimateGasForActionRequest, opts ...grpc.CallOption) (*EstimateGasForActionResponse, error)
+	// read state from a protocol
+	ReadState(ctx context.Context, in *ReadStateRequest, opts ...grpc.CallOption) (*ReadStateResponse, error)
+	// get the proposals, endorsements and missed slots of each delegate in the recent blocks
+	GetDelegateParticipation(ctx context.Context, in *GetDelegateParticipationRequest, opts ...grpc.CallOption) (*GetDelegateParticipationResponse, error)
+	// get the statistics of the block sync buffer
+	GetBlockSyncBufferStats(ctx context.Context, in *GetBlockSyncBufferStatsRequest, opts ...grpc.CallOption) (*GetBlockSyncBufferStatsResponse, error)
+	// get the scores of the peers serving the block sync requests
+	GetBlockSyncPeerScores(ctx context.Context, in *GetBlockSyncPeerScoresRequest, opts ...grpc.CallOption) (*GetBlockSyncPeerScoresResponse, error)
+	// stream the balance deltas of the watched addresses in the blocks affecting them
+	WatchAddresses(ctx context.Context, in *WatchAddressesRequest, opts ...grpc.CallOption) (APIService_WatchAddressesClient, error)
+	// get the recent fork events, i.e., the competing blocks observed at the same height
+	GetForkEvents(ctx context.Context, in *GetForkEventsRequest, opts ...grpc.CallOption) (*GetForkEventsResponse, error)
+	// get the progress of the block sync
+	GetBlockSyncStatus(ctx context.Context, in *GetBlockSyncStatusRequest, opts ...grpc.CallOption) (*GetBlockSyncStatusResponse, error)
+	// stream the progress of the block sync, which is reported every sync interval
+	StreamBlockSyncStatus(ctx context.Context, in *StreamBlockSyncStatusRequest, opts ...grpc.CallOption) (APIService_StreamBlockSyncStatusClient, error)
+	// get the aggregated statistics of the blocks in an epoch
+	GetEpochStats(ctx context.Context, in *GetEpochStatsRequest, opts ...grpc.CallOption) (*GetEpochStatsResponse, error)
+	// stream the changes of the actions in actpool, i.e., added, promoted, removed and confirmed
+	StreamPendingActions(ctx context.Context, in *StreamPendingActionsRequest, opts ...grpc.CallOption) (APIService_StreamPendingActionsClient, error)
+	// get the numbers of the executable and the queued actions in actpool
+	GetActPoolStats(ctx context.Context, in *GetActPoolStatsRequest, opts ...grpc.CallOption) (*GetActPoolStatsResponse, error)
+	// get the timelines of the latest consensus rounds, along with the endorsement latency of each delegate
+	GetConsensusTimeline(ctx context.Context, in *GetConsensusTimelineRequest, opts ...grpc.CallOption) (*GetConsensusTimelineResponse, error)
+	// get the changes of the states made by each action of a block, whose states don't match the local execution
+	GetStateDiff(ctx context.Context, in *GetStateDiffRequest, opts ...grpc.CallOption) (*GetStateDiffResponse, error)
+	// get the net number of trie nodes and state bytes added up to the current height
+	GetStateGrowth(ctx context.Context, in *GetStateGrowthRequest, opts ...grpc.CallOption) (*GetStateGrowthResponse, error)
+}
+
+type aPIServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIServiceClient(cc *grpc.ClientConn) APIServiceClient {
+	return &aPIServiceClient{cc}
+}
+
+func (c *aPIServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error) {
+	out := new(GetAccountResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetActions(ctx context.Context, in *GetActionsRequest, opts ...grpc.CallOption) (*GetActionsResponse, error) {
+	out := new(GetActionsResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetActions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockMetas(ctx context.Context, in *GetBlockMetasRequest, opts ...grpc.CallOption) (*GetBlockMetasResponse, error) {
+	out := new(GetBlockMetasResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetBlockMetas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetRawBlocks(ctx context.Context, in *GetRawBlocksRequest, opts ...grpc.CallOption) (*GetRawBlocksResponse, error) {
+	out := new(GetRawBlocksResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetRawBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetChainMeta(ctx context.Context, in *GetChainMetaRequest, opts ...grpc.CallOption) (*GetChainMetaResponse, error) {
+	out := new(GetChainMetaResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetChainMeta", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SendAction(ctx context.Context, in *SendActionRequest, opts ...grpc.CallOption) (*SendActionResponse, error) {
+	out := new(SendActionResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/SendAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetReceiptByAction(ctx context.Context, in *GetReceiptByActionRequest, opts ...grpc.CallOption) (*GetReceiptByActionResponse, error) {
+	out := new(GetReceiptByActionResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetReceiptByAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) ReadContract(ctx context.Context, in *ReadContractRequest, opts ...grpc.CallOption) (*ReadContractResponse, error) {
+	out := new(ReadContractResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/ReadContract", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) SuggestGasPrice(ctx context.Context, in *SuggestGasPriceRequest, opts ...grpc.CallOption) (*SuggestGasPriceResponse, error) {
+	out := new(SuggestGasPriceResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/SuggestGasPrice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) EstimateGasForAction(ctx context.Context, in *EstimateGasForActionRequest, opts ...grpc.CallOption) (*EstimateGasForActionResponse, error) {
+	out := new(EstimateGasForActionResponse)
 	err := c.cc.Invoke(ctx, "/iotexapi.APIService/EstimateGasForAction", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -1491,6 +4296,201 @@ func (c *aPIServiceClient) EstimateGasForAction(ctx context.Context, in *Estimat
 	return out, nil
 }
 
+func (c *aPIServiceClient) ReadState(ctx context.Context, in *ReadStateRequest, opts ...grpc.CallOption) (*ReadStateResponse, error) {
+	out := new(ReadStateResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/ReadState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetDelegateParticipation(ctx context.Context, in *GetDelegateParticipationRequest, opts ...grpc.CallOption) (*GetDelegateParticipationResponse, error) {
+	out := new(GetDelegateParticipationResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetDelegateParticipation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockSyncBufferStats(ctx context.Context, in *GetBlockSyncBufferStatsRequest, opts ...grpc.CallOption) (*GetBlockSyncBufferStatsResponse, error) {
+	out := new(GetBlockSyncBufferStatsResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetBlockSyncBufferStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockSyncPeerScores(ctx context.Context, in *GetBlockSyncPeerScoresRequest, opts ...grpc.CallOption) (*GetBlockSyncPeerScoresResponse, error) {
+	out := new(GetBlockSyncPeerScoresResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetBlockSyncPeerScores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) WatchAddresses(ctx context.Context, in *WatchAddressesRequest, opts ...grpc.CallOption) (APIService_WatchAddressesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[0], "/iotexapi.APIService/WatchAddresses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceWatchAddressesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_WatchAddressesClient interface {
+	Recv() (*WatchAddressesResponse, error)
+	grpc.ClientStream
+}
+
+type aPIServiceWatchAddressesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceWatchAddressesClient) Recv() (*WatchAddressesResponse, error) {
+	m := new(WatchAddressesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) GetForkEvents(ctx context.Context, in *GetForkEventsRequest, opts ...grpc.CallOption) (*GetForkEventsResponse, error) {
+	out := new(GetForkEventsResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetForkEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetBlockSyncStatus(ctx context.Context, in *GetBlockSyncStatusRequest, opts ...grpc.CallOption) (*GetBlockSyncStatusResponse, error) {
+	out := new(GetBlockSyncStatusResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetBlockSyncStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) StreamBlockSyncStatus(ctx context.Context, in *StreamBlockSyncStatusRequest, opts ...grpc.CallOption) (APIService_StreamBlockSyncStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[1], "/iotexapi.APIService/StreamBlockSyncStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceStreamBlockSyncStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_StreamBlockSyncStatusClient interface {
+	Recv() (*StreamBlockSyncStatusResponse, error)
+	grpc.ClientStream
+}
+
+type aPIServiceStreamBlockSyncStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceStreamBlockSyncStatusClient) Recv() (*StreamBlockSyncStatusResponse, error) {
+	m := new(StreamBlockSyncStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) GetEpochStats(ctx context.Context, in *GetEpochStatsRequest, opts ...grpc.CallOption) (*GetEpochStatsResponse, error) {
+	out := new(GetEpochStatsResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetEpochStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) StreamPendingActions(ctx context.Context, in *StreamPendingActionsRequest, opts ...grpc.CallOption) (APIService_StreamPendingActionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[2], "/iotexapi.APIService/StreamPendingActions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceStreamPendingActionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_StreamPendingActionsClient interface {
+	Recv() (*StreamPendingActionsResponse, error)
+	grpc.ClientStream
+}
+
+type aPIServiceStreamPendingActionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceStreamPendingActionsClient) Recv() (*StreamPendingActionsResponse, error) {
+	m := new(StreamPendingActionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIServiceClient) GetActPoolStats(ctx context.Context, in *GetActPoolStatsRequest, opts ...grpc.CallOption) (*GetActPoolStatsResponse, error) {
+	out := new(GetActPoolStatsResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetActPoolStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetConsensusTimeline(ctx context.Context, in *GetConsensusTimelineRequest, opts ...grpc.CallOption) (*GetConsensusTimelineResponse, error) {
+	out := new(GetConsensusTimelineResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetConsensusTimeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetStateDiff(ctx context.Context, in *GetStateDiffRequest, opts ...grpc.CallOption) (*GetStateDiffResponse, error) {
+	out := new(GetStateDiffResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetStateDiff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceClient) GetStateGrowth(ctx context.Context, in *GetStateGrowthRequest, opts ...grpc.CallOption) (*GetStateGrowthResponse, error) {
+	out := new(GetStateGrowthResponse)
+	err := c.cc.Invoke(ctx, "/iotexapi.APIService/GetStateGrowth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServiceServer is the server API for APIService service.
 type APIServiceServer interface {
 	// get the address detail of an address
@@ -1506,6 +4506,8 @@ type APIServiceServer interface {
 	// 1. start index and block count
 	// 2. block hash
 	GetBlockMetas(context.Context, *GetBlockMetasRequest) (*GetBlockMetasResponse, error)
+	// get raw blocks, including the footers
+	GetRawBlocks(context.Context, *GetRawBlocksRequest) (*GetRawBlocksResponse, error)
 	// get chain metadata
 	GetChainMeta(context.Context, *GetChainMetaRequest) (*GetChainMetaResponse, error)
 	// sendAction
@@ -1518,6 +4520,34 @@ type APIServiceServer interface {
 	SuggestGasPrice(context.Context, *SuggestGasPriceRequest) (*SuggestGasPriceResponse, error)
 	// estimate gas for action
 	EstimateGasForAction(context.Context, *EstimateGasForActionRequest) (*EstimateGasForActionResponse, error)
+	// read state from a protocol
+	ReadState(context.Context, *ReadStateRequest) (*ReadStateResponse, error)
+	// get the proposals, endorsements and missed slots of each delegate in the recent blocks
+	GetDelegateParticipation(context.Context, *GetDelegateParticipationRequest) (*GetDelegateParticipationResponse, error)
+	// get the statistics of the block sync buffer
+	GetBlockSyncBufferStats(context.Context, *GetBlockSyncBufferStatsRequest) (*GetBlockSyncBufferStatsResponse, error)
+	// get the scores of the peers serving the block sync requests
+	GetBlockSyncPeerScores(context.Context, *GetBlockSyncPeerScoresRequest) (*GetBlockSyncPeerScoresResponse, error)
+	// stream the balance deltas of the watched addresses in the blocks affecting them
+	WatchAddresses(*WatchAddressesRequest, APIService_WatchAddressesServer) error
+	// get the recent fork events, i.e., the competing blocks observed at the same height
+	GetForkEvents(context.Context, *GetForkEventsRequest) (*GetForkEventsResponse, error)
+	// get the progress of the block sync
+	GetBlockSyncStatus(context.Context, *GetBlockSyncStatusRequest) (*GetBlockSyncStatusResponse, error)
+	// stream the progress of the block sync, which is reported every sync interval
+	StreamBlockSyncStatus(*StreamBlockSyncStatusRequest, APIService_StreamBlockSyncStatusServer) error
+	// get the aggregated statistics of the blocks in an epoch
+	GetEpochStats(context.Context, *GetEpochStatsRequest) (*GetEpochStatsResponse, error)
+	// stream the changes of the actions in actpool, i.e., added, promoted, removed and confirmed
+	StreamPendingActions(*StreamPendingActionsRequest, APIService_StreamPendingActionsServer) error
+	// get the numbers of the executable and the queued actions in actpool
+	GetActPoolStats(context.Context, *GetActPoolStatsRequest) (*GetActPoolStatsResponse, error)
+	// get the timelines of the latest consensus rounds, along with the endorsement latency of each delegate
+	GetConsensusTimeline(context.Context, *GetConsensusTimelineRequest) (*GetConsensusTimelineResponse, error)
+	// get the changes of the states made by each action of a block, whose states don't match the local execution
+	GetStateDiff(context.Context, *GetStateDiffRequest) (*GetStateDiffResponse, error)
+	// get the net number of trie nodes and state bytes added up to the current height
+	GetStateGrowth(context.Context, *GetStateGrowthRequest) (*GetStateGrowthResponse, error)
 }
 
 func RegisterAPIServiceServer(s *grpc.Server, srv APIServiceServer) {
@@ -1578,6 +4608,24 @@ func _APIService_GetBlockMetas_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _APIService_GetRawBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRawBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetRawBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetRawBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetRawBlocks(ctx, req.(*GetRawBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _APIService_GetChainMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetChainMetaRequest)
 	if err := dec(in); err != nil {
@@ -1686,6 +4734,267 @@ func _APIService_EstimateGasForAction_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _APIService_ReadState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).ReadState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/ReadState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).ReadState(ctx, req.(*ReadStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetDelegateParticipation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDelegateParticipationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetDelegateParticipation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetDelegateParticipation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetDelegateParticipation(ctx, req.(*GetDelegateParticipationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlockSyncBufferStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockSyncBufferStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlockSyncBufferStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetBlockSyncBufferStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlockSyncBufferStats(ctx, req.(*GetBlockSyncBufferStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlockSyncPeerScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockSyncPeerScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlockSyncPeerScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetBlockSyncPeerScores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlockSyncPeerScores(ctx, req.(*GetBlockSyncPeerScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_WatchAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAddressesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).WatchAddresses(m, &aPIServiceWatchAddressesServer{stream})
+}
+
+type APIService_WatchAddressesServer interface {
+	Send(*WatchAddressesResponse) error
+	grpc.ServerStream
+}
+
+type aPIServiceWatchAddressesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceWatchAddressesServer) Send(m *WatchAddressesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_GetForkEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetForkEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetForkEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetForkEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetForkEvents(ctx, req.(*GetForkEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetBlockSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockSyncStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetBlockSyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetBlockSyncStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetBlockSyncStatus(ctx, req.(*GetBlockSyncStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_StreamBlockSyncStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlockSyncStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).StreamBlockSyncStatus(m, &aPIServiceStreamBlockSyncStatusServer{stream})
+}
+
+type APIService_StreamBlockSyncStatusServer interface {
+	Send(*StreamBlockSyncStatusResponse) error
+	grpc.ServerStream
+}
+
+type aPIServiceStreamBlockSyncStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceStreamBlockSyncStatusServer) Send(m *StreamBlockSyncStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_GetEpochStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEpochStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetEpochStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetEpochStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetEpochStats(ctx, req.(*GetEpochStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_StreamPendingActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPendingActionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).StreamPendingActions(m, &aPIServiceStreamPendingActionsServer{stream})
+}
+
+type APIService_StreamPendingActionsServer interface {
+	Send(*StreamPendingActionsResponse) error
+	grpc.ServerStream
+}
+
+type aPIServiceStreamPendingActionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceStreamPendingActionsServer) Send(m *StreamPendingActionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _APIService_GetActPoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActPoolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetActPoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetActPoolStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetActPoolStats(ctx, req.(*GetActPoolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetConsensusTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetConsensusTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetConsensusTimeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetConsensusTimeline(ctx, req.(*GetConsensusTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetStateDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetStateDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetStateDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetStateDiff(ctx, req.(*GetStateDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIService_GetStateGrowth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateGrowthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceServer).GetStateGrowth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexapi.APIService/GetStateGrowth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceServer).GetStateGrowth(ctx, req.(*GetStateGrowthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _APIService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "iotexapi.APIService",
 	HandlerType: (*APIServiceServer)(nil),
@@ -1702,6 +5011,10 @@ var _APIService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockMetas",
 			Handler:    _APIService_GetBlockMetas_Handler,
 		},
+		{
+			MethodName: "GetRawBlocks",
+			Handler:    _APIService_GetRawBlocks_Handler,
+		},
 		{
 			MethodName: "GetChainMeta",
 			Handler:    _APIService_GetChainMeta_Handler,
@@ -1726,70 +5039,258 @@ var _APIService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EstimateGasForAction",
 			Handler:    _APIService_EstimateGasForAction_Handler,
 		},
+		{
+			MethodName: "ReadState",
+			Handler:    _APIService_ReadState_Handler,
+		},
+		{
+			MethodName: "GetDelegateParticipation",
+			Handler:    _APIService_GetDelegateParticipation_Handler,
+		},
+		{
+			MethodName: "GetBlockSyncBufferStats",
+			Handler:    _APIService_GetBlockSyncBufferStats_Handler,
+		},
+		{
+			MethodName: "GetBlockSyncPeerScores",
+			Handler:    _APIService_GetBlockSyncPeerScores_Handler,
+		},
+		{
+			MethodName: "GetForkEvents",
+			Handler:    _APIService_GetForkEvents_Handler,
+		},
+		{
+			MethodName: "GetBlockSyncStatus",
+			Handler:    _APIService_GetBlockSyncStatus_Handler,
+		},
+		{
+			MethodName: "GetEpochStats",
+			Handler:    _APIService_GetEpochStats_Handler,
+		},
+		{
+			MethodName: "GetActPoolStats",
+			Handler:    _APIService_GetActPoolStats_Handler,
+		},
+		{
+			MethodName: "GetConsensusTimeline",
+			Handler:    _APIService_GetConsensusTimeline_Handler,
+		},
+		{
+			MethodName: "GetStateDiff",
+			Handler:    _APIService_GetStateDiff_Handler,
+		},
+		{
+			MethodName: "GetStateGrowth",
+			Handler:    _APIService_GetStateGrowth_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAddresses",
+			Handler:       _APIService_WatchAddresses_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamBlockSyncStatus",
+			Handler:       _APIService_StreamBlockSyncStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamPendingActions",
+			Handler:       _APIService_StreamPendingActions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
 
-func init() { proto.RegisterFile("api.proto", fileDescriptor_api_7407a1b92915c4a6) }
-
-var fileDescriptor_api_7407a1b92915c4a6 = []byte{
-	// 899 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x7f, 0x6f, 0xdb, 0x44,
-	0x18, 0x4e, 0x9b, 0x34, 0x3f, 0xde, 0x16, 0xb1, 0x5e, 0xd3, 0xcd, 0x78, 0xa3, 0x8c, 0x63, 0x43,
-	0xd3, 0x44, 0x13, 0xe8, 0x18, 0x12, 0x43, 0x0c, 0xc5, 0x15, 0x4d, 0x43, 0x85, 0x5a, 0xb9, 0x42,
-	0x42, 0x08, 0x09, 0xce, 0xe7, 0xc3, 0x31, 0x49, 0x6c, 0x63, 0x5f, 0x50, 0xf3, 0x11, 0xf8, 0x1a,
-	0x7c, 0x48, 0xfe, 0x46, 0xbe, 0x3b, 0xc7, 0xe7, 0xc4, 0x4e, 0x69, 0xc5, 0x7f, 0xb9, 0xf7, 0xde,
-	0xe7, 0x79, 0x7f, 0x3e, 0xe7, 0x40, 0x87, 0x44, 0x7e, 0x2f, 0x8a, 0x43, 0x1e, 0xa2, 0xb6, 0x1f,
-	0x72, 0x76, 0x43, 0x22, 0xdf, 0xdc, 0x23, 0x94, 0xfb, 0x61, 0x20, 0xed, 0xe6, 0x03, 0x67, 0x1a,
-	0xd2, 0x09, 0x1d, 0x13, 0x5f, 0x59, 0xf0, 0x31, 0xec, 0x0f, 0x19, 0x1f, 0x50, 0x1a, 0xce, 0x03,
-	0x6e, 0xb3, 0x3f, 0xe6, 0x2c, 0xe1, 0xc8, 0x80, 0x16, 0x71, 0xdd, 0x98, 0x25, 0x89, 0xb1, 0xf5,
-	0x74, 0xeb, 0x45, 0xc7, 0xce, 0x8e, 0xf8, 0x12, 0x90, 0xee, 0x9e, 0x44, 0x61, 0x90, 0x30, 0xf4,
-	0x25, 0xec, 0x12, 0x69, 0xfa, 0x9e, 0x71, 0x22, 0x30, 0xbb, 0x27, 0x8f, 0x7a, 0x22, 0x09, 0xbe,
-	0x88, 0x58, 0xd2, 0x1b, 0xe4, 0xd7, 0xb6, 0xee, 0x8b, 0xff, 0xd9, 0x56, 0x09, 0xa4, 0x59, 0x26,
-	0x59, 0x02, 0x6f, 0xa1, 0xe5, 0x2c, 0x46, 0x81, 0xcb, 0x6e, 0x14, 0x19, 0xee, 0x65, 0x15, 0xf5,
-	0x72, 0x6f, 0x4b, 0xba, 0x28, 0xd0, 0x79, 0xcd, 0xce, 0x40, 0xe8, 0x0d, 0x34, 0x9d, 0xc5, 0x39,
-	0x49, 0xc6, 0xc6, 0xb6, 0x80, 0x3f, 0x2d, 0x81, 0x5b, 0xc2, 0x21, 0x07, 0x2b, 0x04, 0x7a, 0x9b,
-	0x62, 0x07, 0xae, 0x1b, 0x1b, 0x75, 0x81, 0x7d, 0x56, 0x1e, 0x7a, 0x20, 0x3b, 0x52, 0xc0, 0xa7,
-	0x36, 0xf4, 0x0b, 0xec, 0xcf, 0x03, 0x1a, 0x06, 0xbf, 0xf9, 0xf1, 0x8c, 0xb9, 0xd2, 0xd1, 0x68,
-	0x08, 0xaa, 0x7e, 0x81, 0xea, 0x87, 0xdc, 0xab, 0x9a, 0x75, 0x9d, 0x0b, 0xbd, 0x81, 0x1d, 0x67,
-	0x61, 0x4d, 0x27, 0xc6, 0xce, 0xa6, 0xd6, 0x58, 0xe9, 0xa4, 0x73, 0x1e, 0x09, 0xb1, 0xda, 0xd0,
-	0x9c, 0x86, 0xe1, 0x64, 0x1e, 0xe1, 0x33, 0x30, 0xaa, 0x3a, 0x89, 0xba, 0xb0, 0x93, 0x70, 0x12,
-	0x73, 0xd1, 0xfc, 0x86, 0x2d, 0x0f, 0xa9, 0x55, 0xcc, 0x4d, 0xf4, 0xb4, 0x61, 0xcb, 0x03, 0xfe,
-	0x19, 0x1e, 0x96, 0xb7, 0x14, 0x1d, 0x01, 0xc8, 0xe5, 0x13, 0x83, 0x90, 0x8b, 0xa4, 0x59, 0x10,
-	0x86, 0x3d, 0x3a, 0x66, 0x74, 0x72, 0xc5, 0x02, 0xd7, 0x0f, 0x3c, 0x41, 0xdb, 0xb6, 0x0b, 0x36,
-	0xec, 0x80, 0x59, 0xdd, 0xf4, 0xea, 0x3d, 0xcd, 0x2b, 0xd8, 0x2e, 0xad, 0xa0, 0xae, 0x57, 0x30,
-	0x83, 0xe7, 0xff, 0x69, 0x1a, 0xff, 0x53, 0xb8, 0x5f, 0x8b, 0x8d, 0xd7, 0xe7, 0x94, 0x46, 0x70,
-	0xa6, 0x13, 0xad, 0x5f, 0xd9, 0xf1, 0x4e, 0x11, 0x2c, 0x25, 0x52, 0x25, 0x29, 0x25, 0xd2, 0x4f,
-	0xa0, 0x25, 0x9b, 0x9f, 0x66, 0x5f, 0x7f, 0xb1, 0x7b, 0x82, 0x8a, 0x02, 0x4d, 0xaf, 0xec, 0xcc,
-	0x05, 0xff, 0xbd, 0x05, 0xdd, 0x21, 0xe3, 0x22, 0xbb, 0x54, 0xa8, 0xcb, 0x26, 0x0c, 0x56, 0xa5,
-	0xf9, 0xbc, 0xb0, 0x7f, 0x39, 0xa0, 0x5a, 0x9d, 0x5f, 0xaf, 0xa8, 0xf3, 0xa3, 0x72, 0x86, 0x0a,
-	0x81, 0x6a, 0x3b, 0x3c, 0x82, 0xc7, 0x1b, 0x42, 0xde, 0x69, 0x8d, 0x5f, 0xc3, 0x7b, 0x95, 0xb1,
-	0xab, 0xc7, 0x82, 0xbf, 0x83, 0xc3, 0x95, 0x2e, 0xa9, 0x6e, 0x7f, 0x06, 0x6d, 0x67, 0x2a, 0x6d,
-	0xaa, 0xdd, 0x87, 0x7a, 0xbb, 0x97, 0x08, 0x7b, 0xe9, 0x86, 0x0f, 0xe1, 0x60, 0xc8, 0xf8, 0x69,
-	0xfa, 0x38, 0x8b, 0x1b, 0x19, 0x1c, 0x5f, 0x88, 0x41, 0x68, 0x66, 0x15, 0xe1, 0x15, 0x74, 0x68,
-	0x66, 0x54, 0xa3, 0x28, 0x84, 0xc8, 0x11, 0xb9, 0x1f, 0xfe, 0x06, 0xf6, 0xaf, 0x59, 0xa0, 0x36,
-	0x3c, 0x2b, 0xef, 0x25, 0x34, 0xe5, 0xd8, 0x15, 0x4d, 0xd9, 0x62, 0x28, 0x0f, 0xdc, 0x05, 0xa4,
-	0x13, 0xc8, 0x5c, 0xf0, 0x57, 0xa2, 0x7b, 0x36, 0xa3, 0xcc, 0x8f, 0xb8, 0xb5, 0x28, 0xd2, 0xdf,
-	0xf2, 0x0e, 0xe0, 0x0b, 0xa1, 0xf1, 0x35, 0xb0, 0x2a, 0xf3, 0x18, 0x5a, 0xb1, 0xbc, 0x52, 0xd9,
-	0x1d, 0xe8, 0xd9, 0x29, 0x94, 0x9d, 0xf9, 0xe0, 0x01, 0x1c, 0xd8, 0x8c, 0xb8, 0xa7, 0x61, 0xc0,
-	0x63, 0x42, 0xf9, 0x7d, 0x4a, 0x7c, 0x09, 0xdd, 0x22, 0x85, 0xca, 0x04, 0x41, 0xc3, 0x25, 0xaa,
-	0xd7, 0x1d, 0x5b, 0xfc, 0xc6, 0x06, 0x3c, 0xbc, 0x9e, 0x7b, 0x1e, 0x4b, 0xf8, 0x90, 0x24, 0x57,
-	0xb1, 0x4f, 0x59, 0x36, 0xb6, 0xd7, 0xf0, 0x68, 0xed, 0x46, 0x11, 0x99, 0xd0, 0xf6, 0x94, 0x4d,
-	0xad, 0xe6, 0xf2, 0x9c, 0xae, 0xf4, 0xb7, 0x09, 0xf7, 0x67, 0x84, 0xb3, 0x21, 0x49, 0xce, 0xc2,
-	0xf8, 0xfe, 0xa3, 0xfa, 0x14, 0x9e, 0x94, 0x53, 0xa9, 0x34, 0x1e, 0x40, 0xdd, 0x23, 0x89, 0xca,
-	0x20, 0xfd, 0x79, 0xf2, 0x57, 0x13, 0x60, 0x70, 0x35, 0xba, 0x66, 0xf1, 0x9f, 0x3e, 0x65, 0x68,
-	0x04, 0x90, 0x7f, 0xec, 0xd1, 0xe3, 0x95, 0xef, 0x8c, 0xfe, 0x8f, 0xc1, 0x7c, 0x52, 0x7e, 0xa9,
-	0xd6, 0xa3, 0xb6, 0xa4, 0x12, 0x8f, 0xcb, 0x1a, 0x95, 0xfe, 0xed, 0x5f, 0xa3, 0x2a, 0xbc, 0x62,
-	0xb8, 0x86, 0x6c, 0x78, 0xa7, 0x20, 0x39, 0x74, 0x54, 0xf1, 0x00, 0x65, 0x84, 0x1f, 0x54, 0xde,
-	0x2f, 0x39, 0x2f, 0x61, 0x4f, 0xd7, 0x18, 0x7a, 0xbf, 0x00, 0x59, 0x95, 0xa4, 0x79, 0x54, 0x75,
-	0xad, 0xd7, 0x9b, 0xcb, 0x44, 0xaf, 0x77, 0x4d, 0x7d, 0x7a, 0xbd, 0x25, 0xca, 0xaa, 0x21, 0x22,
-	0x5e, 0xf3, 0x15, 0x79, 0xa0, 0xe2, 0x9b, 0x59, 0xae, 0x3c, 0xf3, 0xd9, 0x66, 0x27, 0xbd, 0x7c,
-	0x7d, 0xe3, 0xf5, 0xf2, 0x4b, 0xc4, 0xa4, 0x97, 0x5f, 0x26, 0x14, 0x5c, 0x43, 0x3f, 0xc2, 0xbb,
-	0x2b, 0xcb, 0x8f, 0xb4, 0xbf, 0x60, 0xe5, 0x8a, 0x31, 0x3f, 0xdc, 0xe0, 0xb1, 0x64, 0xf6, 0xa0,
-	0x5b, 0xb6, 0xd4, 0x48, 0xfb, 0x0a, 0x6d, 0xd0, 0x8f, 0xf9, 0xf1, 0x6d, 0x6e, 0x59, 0x20, 0xeb,
-	0x8b, 0x9f, 0x3e, 0xf7, 0x7c, 0x3e, 0x9e, 0x3b, 0x3d, 0x1a, 0xce, 0xfa, 0x02, 0x15, 0xc5, 0xe1,
-	0xef, 0x8c, 0x72, 0x79, 0x38, 0xa6, 0x61, 0xcc, 0xfa, 0xe2, 0x4f, 0xb4, 0xc7, 0x82, 0x7e, 0x46,
-	0xeb, 0x34, 0x85, 0xe9, 0xd5, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x4c, 0xe5, 0xf9, 0x5f, 0x8e,
-	0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("api.proto", fileDescriptor_api_e88018757adab6e4) }
+
+var fileDescriptor_api_e88018757adab6e4 = []byte{
+	// 2958 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcf, 0x73, 0x1c, 0x47,
+	0xf5, 0xf7, 0xee, 0x4a, 0x2b, 0xed, 0x93, 0x14, 0xcb, 0x6d, 0x59, 0xde, 0x8c, 0x15, 0x59, 0xe9,
+	0x38, 0xfe, 0xf5, 0x4d, 0x64, 0x7f, 0xed, 0x18, 0x48, 0xa8, 0xfc, 0x90, 0x2c, 0xc9, 0x31, 0x44,
+	0x96, 0xd2, 0x72, 0x70, 0x8a, 0xa2, 0x0a, 0x66, 0x67, 0x5a, 0xab, 0x41, 0xbb, 0x33, 0x9b, 0x99,
+	0x1e, 0xd9, 0x9b, 0xa2, 0xa0, 0x8a, 0x2b, 0x1c, 0xb8, 0x51, 0xc5, 0x85, 0x02, 0x2e, 0x14, 0x57,
+	0x0e, 0xdc, 0xb8, 0x70, 0xe4, 0xaf, 0xe0, 0x9f, 0xa0, 0x38, 0x52, 0xaf, 0x7f, 0xcc, 0xf4, 0xcc,
+	0xce, 0x48, 0x8e, 0x8b, 0xdb, 0xf6, 0xa7, 0x5f, 0xbf, 0x7e, 0xbf, 0xfa, 0xf5, 0xeb, 0x37, 0x0b,
+	0x1d, 0x77, 0x14, 0xac, 0x8f, 0xe2, 0x48, 0x44, 0x64, 0x36, 0x88, 0x04, 0x7f, 0xe1, 0x8e, 0x02,
+	0x67, 0xde, 0xf5, 0x44, 0x10, 0x85, 0x0a, 0x77, 0x16, 0x7b, 0x83, 0xc8, 0x3b, 0xf6, 0x8e, 0xdc,
+	0x40, 0x23, 0xf4, 0x5d, 0xb8, 0xf0, 0x88, 0x8b, 0x0d, 0xcf, 0x8b, 0xd2, 0x50, 0x30, 0xfe, 0x55,
+	0xca, 0x13, 0x41, 0xba, 0x30, 0xe3, 0xfa, 0x7e, 0xcc, 0x93, 0xa4, 0xdb, 0x58, 0x6b, 0xdc, 0xec,
+	0x30, 0x33, 0xa4, 0x7b, 0x40, 0x6c, 0xf2, 0x64, 0x14, 0x85, 0x09, 0x27, 0xef, 0xc3, 0x9c, 0xab,
+	0xa0, 0x5d, 0x2e, 0x5c, 0xb9, 0x66, 0xee, 0xde, 0xe5, 0x75, 0x29, 0x84, 0x18, 0x8f, 0x78, 0xb2,
+	0xbe, 0x91, 0x4f, 0x33, 0x9b, 0x96, 0xfe, 0xbb, 0xa9, 0x05, 0x40, 0x29, 0x13, 0x23, 0xc0, 0x47,
+	0x30, 0xd3, 0x1b, 0x3f, 0x0e, 0x7d, 0xfe, 0x42, 0x33, 0xa3, 0xeb, 0x46, 0xa3, 0xf5, 0x9c, 0x7a,
+	0x53, 0x91, 0xe8, 0x45, 0x9f, 0x9e, 0x63, 0x66, 0x11, 0xf9, 0x00, 0xda, 0xbd, 0xf1, 0xa7, 0x6e,
+	0x72, 0xd4, 0x6d, 0xca, 0xe5, 0x6b, 0x15, 0xcb, 0x37, 0x25, 0x41, 0xbe, 0x58, 0xaf, 0x20, 0x1f,
+	0xe1, 0xda, 0x0d, 0xdf, 0x8f, 0xbb, 0x2d, 0xb9, 0xf6, 0x5a, 0xf5, 0xd6, 0x1b, 0xca, 0x22, 0x85,
+	0xf5, 0x88, 0x91, 0x1f, 0xc3, 0x85, 0x34, 0xf4, 0xa2, 0xf0, 0x30, 0x88, 0x87, 0xdc, 0x57, 0x84,
+	0xdd, 0x29, 0xc9, 0xea, 0x4e, 0x81, 0xd5, 0x17, 0x39, 0x55, 0x3d, 0xd7, 0x49, 0x5e, 0xe4, 0x03,
+	0x98, 0xee, 0x8d, 0x37, 0x07, 0xc7, 0xdd, 0xe9, 0xd3, 0x4c, 0xb3, 0x89, 0x9e, 0xce, 0xf9, 0xa8,
+	0x25, 0x9b, 0xb3, 0xd0, 0x1e, 0x44, 0xd1, 0x71, 0x3a, 0xa2, 0x3b, 0xd0, 0xad, 0xb3, 0x24, 0x59,
+	0x82, 0xe9, 0x44, 0xb8, 0xb1, 0x90, 0xc6, 0x9f, 0x62, 0x6a, 0x80, 0xa8, 0xf4, 0x9b, 0xb4, 0xe9,
+	0x14, 0x53, 0x03, 0xfa, 0x23, 0x58, 0xae, 0x36, 0x29, 0x59, 0x05, 0x50, 0xc1, 0x27, 0x1d, 0xa1,
+	0x02, 0xc9, 0x42, 0x08, 0x85, 0x79, 0xef, 0x88, 0x7b, 0xc7, 0xfb, 0x3c, 0xf4, 0x83, 0xb0, 0x2f,
+	0xd9, 0xce, 0xb2, 0x02, 0x46, 0x7b, 0xe0, 0xd4, 0x1b, 0xbd, 0x3e, 0x4e, 0x73, 0x0d, 0x9a, 0x95,
+	0x1a, 0xb4, 0x6c, 0x0d, 0x86, 0xf0, 0xf6, 0x4b, 0x79, 0xe3, 0x7f, 0xb4, 0xdd, 0x4f, 0xa0, 0x5b,
+	0xe7, 0x27, 0xdc, 0xa1, 0x37, 0x38, 0xb6, 0xec, 0x65, 0x86, 0xdf, 0x68, 0x87, 0x5f, 0x35, 0x80,
+	0xe4, 0x5b, 0x64, 0xa7, 0xf4, 0x1d, 0x98, 0x51, 0xd6, 0x47, 0xf1, 0x5b, 0x37, 0xe7, 0xee, 0x91,
+	0xe2, 0x09, 0xc5, 0x29, 0x66, 0x48, 0xc8, 0x2d, 0x98, 0x3a, 0xe4, 0x3c, 0xe9, 0x36, 0x25, 0xe9,
+	0xa5, 0x49, 0xd2, 0x1d, 0xce, 0x99, 0x24, 0x21, 0x2b, 0xd0, 0x39, 0x0c, 0x42, 0x77, 0x10, 0x7c,
+	0xcd, 0xfd, 0x6e, 0x6b, 0xad, 0x75, 0x73, 0x96, 0xe5, 0x00, 0xfd, 0x63, 0x03, 0x96, 0x1e, 0x71,
+	0x21, 0xf5, 0xc4, 0x23, 0x9f, 0x99, 0x73, 0xa3, 0x7c, 0xc8, 0xdf, 0x2e, 0x44, 0x72, 0xbe, 0xa0,
+	0xfe, 0x9c, 0x7f, 0x58, 0x3a, 0xe7, 0x6f, 0x55, 0x73, 0xa8, 0x39, 0xea, 0xd6, 0x69, 0x78, 0x0c,
+	0x57, 0x4e, 0xd9, 0xf2, 0x1b, 0x1d, 0x88, 0x07, 0xf0, 0x7a, 0xed, 0xde, 0xf5, 0x0e, 0xa6, 0xdf,
+	0x83, 0x4b, 0x25, 0x2b, 0x69, 0xb7, 0xfd, 0x3f, 0xcc, 0xf6, 0x06, 0x0a, 0xeb, 0x36, 0x26, 0x9d,
+	0x91, 0xad, 0x60, 0x19, 0x19, 0xdd, 0x85, 0x8b, 0x8f, 0xb8, 0x60, 0xee, 0x73, 0x39, 0x99, 0x19,
+	0x7c, 0x0d, 0xe6, 0xa4, 0xe0, 0x9f, 0xf2, 0xa0, 0x7f, 0x64, 0x74, 0xb1, 0xa1, 0x1a, 0x8d, 0x36,
+	0x60, 0xa9, 0xc8, 0x4e, 0x4b, 0x76, 0x0b, 0xda, 0xf2, 0x3e, 0x31, 0x72, 0x5d, 0x98, 0x90, 0x8b,
+	0x69, 0x02, 0x7a, 0x49, 0x4a, 0xf4, 0x10, 0x2f, 0x1e, 0x29, 0xab, 0x92, 0x88, 0xfe, 0xbd, 0x09,
+	0x4b, 0x45, 0x5c, 0xb3, 0xbe, 0x0f, 0x1d, 0xcf, 0x80, 0x3a, 0x3a, 0x0a, 0x5a, 0xe7, 0x2b, 0x72,
+	0x3a, 0x72, 0x07, 0x66, 0x12, 0x11, 0xc5, 0x6e, 0x9f, 0x77, 0x9b, 0xf6, 0x12, 0x0c, 0x87, 0x03,
+	0x35, 0x21, 0x97, 0x18, 0x2a, 0x72, 0x1d, 0xa6, 0x92, 0x71, 0xe8, 0xe9, 0x44, 0x4f, 0x2c, 0xea,
+	0x71, 0xe8, 0x49, 0x52, 0x39, 0x4f, 0xee, 0xca, 0x93, 0xb3, 0x1f, 0x45, 0x03, 0x9d, 0xc8, 0x97,
+	0x73, 0xd2, 0x0d, 0x35, 0x71, 0x20, 0x5c, 0x91, 0x30, 0x43, 0x46, 0x1e, 0x40, 0xc7, 0x43, 0x45,
+	0xc2, 0x24, 0x4d, 0xba, 0xd3, 0xf6, 0x7d, 0x88, 0x6b, 0x1e, 0x9a, 0x29, 0xad, 0x81, 0x19, 0xa2,
+	0x06, 0x21, 0x17, 0xcf, 0xa3, 0xf8, 0xb8, 0xdb, 0x2e, 0x6b, 0xf0, 0x44, 0x4d, 0x28, 0x0d, 0x34,
+	0x15, 0xfd, 0x18, 0x2e, 0x1c, 0xf0, 0x50, 0x67, 0x2c, 0xe3, 0xe7, 0xdb, 0xd0, 0x56, 0xa7, 0xb8,
+	0xdb, 0xb0, 0x15, 0x2b, 0x9c, 0x73, 0x4d, 0x41, 0x97, 0x80, 0xd8, 0x0c, 0x94, 0xf9, 0xe9, 0x77,
+	0x65, 0x0c, 0x33, 0xee, 0xf1, 0x60, 0x24, 0x36, 0xc7, 0x45, 0xf6, 0x67, 0xe4, 0x75, 0x2a, 0xc0,
+	0xa9, 0x5a, 0xac, 0x3d, 0xfb, 0x2e, 0xcc, 0xc4, 0x6a, 0x4a, 0x4b, 0x77, 0xd1, 0x96, 0x4e, 0xaf,
+	0x62, 0x86, 0x86, 0xdc, 0x80, 0xd6, 0x21, 0x2f, 0xf9, 0xb3, 0x9c, 0x85, 0x90, 0x82, 0xfe, 0xb2,
+	0x01, 0x17, 0x19, 0x77, 0xfd, 0x87, 0x51, 0x28, 0x62, 0xd7, 0x13, 0xaf, 0x60, 0x0c, 0xf2, 0x31,
+	0xbc, 0x96, 0x08, 0x57, 0xf0, 0xbd, 0x13, 0x1e, 0xc7, 0x81, 0x9f, 0x65, 0xbf, 0xcb, 0x76, 0x1c,
+	0x59, 0xf3, 0xac, 0x44, 0x4e, 0x6f, 0xc3, 0x52, 0x51, 0x06, 0xad, 0x34, 0x81, 0x29, 0xdf, 0xd5,
+	0x91, 0xdc, 0x61, 0xf2, 0x37, 0xed, 0xc2, 0xf2, 0x41, 0xda, 0xef, 0xf3, 0x44, 0x3c, 0x72, 0x93,
+	0xfd, 0x38, 0xf0, 0xb8, 0x39, 0x15, 0x0f, 0xe0, 0xf2, 0xc4, 0x8c, 0x66, 0xe4, 0xc0, 0x6c, 0x5f,
+	0x63, 0xfa, 0xfc, 0x66, 0x63, 0xcc, 0x61, 0xdb, 0x89, 0x08, 0x86, 0xae, 0xe0, 0x8f, 0xdc, 0x64,
+	0x27, 0x8a, 0x5f, 0x3d, 0x2a, 0xee, 0xc2, 0x4a, 0x35, 0x2b, 0x2d, 0xc6, 0x22, 0xb4, 0xfa, 0x6e,
+	0xa2, 0x25, 0xc0, 0x9f, 0x74, 0x04, 0x8b, 0xa8, 0xb9, 0x34, 0x8f, 0x15, 0x28, 0xb2, 0xc8, 0xf4,
+	0xa2, 0xc1, 0xe3, 0x2d, 0x49, 0x3c, 0xcf, 0x2c, 0x04, 0xe7, 0x87, 0x5c, 0x1c, 0x45, 0xfe, 0x13,
+	0x77, 0xa8, 0x5c, 0x3c, 0xcf, 0x2c, 0x04, 0xef, 0x15, 0x37, 0xee, 0xa7, 0x43, 0x1e, 0x8a, 0x44,
+	0xde, 0x2b, 0xf3, 0x2c, 0x07, 0xe8, 0x0d, 0xb8, 0x60, 0xed, 0x58, 0x61, 0xe8, 0x79, 0x6d, 0xe8,
+	0xf7, 0xe1, 0xea, 0x23, 0x2e, 0xb6, 0xf8, 0x80, 0xf7, 0x5d, 0xc1, 0xf7, 0xdd, 0x58, 0x04, 0x5e,
+	0x30, 0x72, 0x6d, 0xdb, 0x2c, 0x43, 0xfb, 0x79, 0x10, 0xfa, 0xd1, 0x73, 0xad, 0x92, 0x1e, 0xd1,
+	0xdf, 0x36, 0xe0, 0x52, 0xe5, 0x42, 0x74, 0x84, 0xaf, 0x27, 0xb4, 0x57, 0xb3, 0x31, 0xca, 0x3d,
+	0x8a, 0xa3, 0x51, 0x94, 0xb8, 0x83, 0x44, 0x67, 0xd2, 0x1c, 0xc0, 0xb2, 0x87, 0x87, 0x7e, 0x14,
+	0x27, 0xdc, 0x28, 0x86, 0x04, 0x05, 0x0c, 0x33, 0xf5, 0x30, 0x48, 0x12, 0xee, 0x1f, 0x0c, 0x22,
+	0x91, 0xc8, 0xa4, 0x33, 0xc5, 0x6c, 0x88, 0xfe, 0xa1, 0x01, 0x6b, 0xf5, 0x5a, 0x69, 0x6b, 0x9c,
+	0x9d, 0xf0, 0x57, 0xa0, 0xc3, 0x43, 0x5f, 0xcf, 0x6b, 0x51, 0x33, 0x80, 0x7c, 0x08, 0x1d, 0xa3,
+	0x94, 0x72, 0xc0, 0xdc, 0xbd, 0xab, 0xf9, 0x51, 0xa8, 0xde, 0x3b, 0x5f, 0x41, 0xd7, 0x60, 0xd5,
+	0x5c, 0x69, 0x98, 0x50, 0x37, 0xd3, 0xc3, 0x43, 0x1e, 0xab, 0x44, 0xa9, 0x23, 0xfd, 0xcf, 0x0d,
+	0x58, 0xaa, 0x9a, 0x47, 0x3f, 0x26, 0xc1, 0xd7, 0x26, 0xc6, 0xe5, 0x6f, 0x34, 0x39, 0x26, 0xfa,
+	0x61, 0x14, 0x8f, 0xb5, 0xa8, 0xd9, 0x18, 0xef, 0xd5, 0x64, 0x14, 0x0c, 0x06, 0xb2, 0x00, 0xc1,
+	0x29, 0x33, 0x44, 0x73, 0xeb, 0x9f, 0x9b, 0x63, 0xc1, 0x8d, 0x2d, 0x0b, 0x18, 0xd2, 0x78, 0xd1,
+	0x70, 0x18, 0x18, 0x43, 0x4d, 0x2b, 0x1a, 0x1b, 0xa3, 0xcf, 0x64, 0x14, 0x55, 0x2b, 0xa3, 0xcd,
+	0xfd, 0x9e, 0xac, 0x12, 0x44, 0xa2, 0x0f, 0xd8, 0x6a, 0x6e, 0xaa, 0xca, 0x65, 0x8a, 0x98, 0x5e,
+	0x85, 0x37, 0x6c, 0xc6, 0xfb, 0x9c, 0xc7, 0x07, 0x5e, 0x14, 0xf3, 0xcc, 0x48, 0xff, 0x6a, 0x40,
+	0x27, 0x43, 0x31, 0x54, 0x47, 0x9c, 0xc7, 0xfa, 0x40, 0x75, 0x98, 0x1e, 0xc9, 0x12, 0x05, 0x09,
+	0xa4, 0x69, 0x5a, 0x4c, 0x0d, 0xd0, 0x66, 0xb1, 0x62, 0x63, 0x02, 0x2d, 0x1b, 0xa3, 0xef, 0x63,
+	0x2d, 0xba, 0x31, 0x4b, 0x0e, 0x90, 0x9b, 0x70, 0x3e, 0x11, 0x2e, 0xda, 0x88, 0x19, 0x06, 0xca,
+	0x2c, 0x65, 0x98, 0x5c, 0x83, 0x85, 0x20, 0x3c, 0x71, 0x07, 0x81, 0xbf, 0xa9, 0xaa, 0x81, 0xb6,
+	0xa4, 0x2b, 0x82, 0xb8, 0xdb, 0xc0, 0x15, 0x3c, 0xf4, 0xc6, 0xbb, 0x49, 0x77, 0x46, 0xed, 0x96,
+	0x01, 0xf4, 0xfb, 0xc5, 0x50, 0xb1, 0x8d, 0x90, 0x15, 0x1b, 0xd3, 0xa8, 0xa9, 0xa9, 0x35, 0x2e,
+	0xe6, 0xc6, 0xcd, 0x88, 0x99, 0xa2, 0xa0, 0x0f, 0xe0, 0xd2, 0x33, 0x57, 0x78, 0x47, 0xba, 0x7c,
+	0xcf, 0x2c, 0x29, 0x13, 0x8a, 0xc1, 0x24, 0x9f, 0x0e, 0xcb, 0x01, 0xfa, 0x33, 0x98, 0xdf, 0x74,
+	0x07, 0x6e, 0xe8, 0xf1, 0x2d, 0x3e, 0x10, 0xee, 0x29, 0xe5, 0x3e, 0x56, 0x71, 0x8a, 0xb2, 0xdb,
+	0xd4, 0x55, 0x9c, 0x1a, 0xa2, 0x17, 0x7c, 0x5c, 0x2c, 0x8d, 0xdd, 0x61, 0x6a, 0x80, 0xf1, 0x95,
+	0xdf, 0x8f, 0xd2, 0xd8, 0xb8, 0x75, 0x01, 0xa3, 0x3f, 0x87, 0xe5, 0xb2, 0xd0, 0x5a, 0xf3, 0x65,
+	0x68, 0x1f, 0xd9, 0x07, 0x58, 0x8f, 0x50, 0x1b, 0x59, 0x5d, 0x65, 0xf5, 0x6f, 0x87, 0xe5, 0x00,
+	0x59, 0x87, 0xb6, 0xdc, 0xdc, 0x1c, 0x5c, 0xab, 0x64, 0xb1, 0xb5, 0x64, 0x9a, 0x8a, 0x2e, 0xcb,
+	0x4a, 0x6c, 0x27, 0x8a, 0x8f, 0xb7, 0x4f, 0x78, 0x98, 0x1f, 0xd1, 0xbf, 0x35, 0xa0, 0x93, 0xa1,
+	0xb5, 0xb2, 0xac, 0x02, 0x78, 0x47, 0x51, 0xc2, 0x43, 0x4b, 0x18, 0x0b, 0xc1, 0x18, 0xf1, 0xa2,
+	0xe1, 0x88, 0x8b, 0x20, 0xec, 0x4b, 0x12, 0x65, 0x9f, 0x22, 0x88, 0xdc, 0x93, 0x28, 0x8d, 0x3d,
+	0x2e, 0xc3, 0xb1, 0xc3, 0xf4, 0x08, 0xf1, 0x98, 0xbb, 0x49, 0x14, 0xca, 0x10, 0xec, 0x30, 0x3d,
+	0x42, 0x0b, 0x88, 0x60, 0xc8, 0x13, 0xe1, 0x0e, 0x47, 0x32, 0xea, 0x5a, 0x2c, 0x07, 0xe8, 0x96,
+	0xac, 0xa8, 0x6d, 0x8d, 0xb4, 0x41, 0xff, 0x0f, 0xda, 0x5c, 0x22, 0x93, 0xb1, 0x94, 0x51, 0x33,
+	0x4d, 0x42, 0xff, 0xd1, 0x80, 0xf3, 0x59, 0x5c, 0xe2, 0xc1, 0x4d, 0x13, 0x72, 0x5d, 0xd6, 0x09,
+	0xb1, 0x94, 0xdb, 0xb6, 0x46, 0x09, 0x95, 0x5a, 0xa7, 0x71, 0xcc, 0x43, 0x51, 0xc8, 0xb0, 0x45,
+	0x10, 0xa3, 0x43, 0xb8, 0x71, 0x9f, 0x1b, 0x22, 0x7d, 0x21, 0xd8, 0x18, 0xc6, 0x95, 0x8a, 0x7e,
+	0x15, 0x3a, 0x6a, 0x80, 0x67, 0x54, 0xd5, 0xd7, 0xfb, 0x3c, 0x3e, 0xe0, 0x5e, 0x14, 0xfa, 0xd2,
+	0x40, 0x0d, 0x56, 0x86, 0xe9, 0x95, 0xfc, 0x51, 0x92, 0xeb, 0x61, 0x5c, 0xbc, 0x07, 0x4e, 0xd5,
+	0x64, 0xf6, 0xfe, 0x68, 0x27, 0x12, 0xd1, 0x69, 0xed, 0xf5, 0x8a, 0xb4, 0xa6, 0x97, 0x68, 0x42,
+	0xba, 0x0a, 0x2b, 0x07, 0x22, 0xe6, 0xee, 0xb0, 0x66, 0x43, 0x06, 0x6f, 0xd4, 0xcc, 0xbf, 0xfa,
+	0x9e, 0xdf, 0x91, 0xf1, 0xbb, 0x3d, 0x8a, 0xbc, 0x23, 0xfb, 0x8a, 0xc1, 0x3b, 0x90, 0x23, 0xf8,
+	0x24, 0x1d, 0xf6, 0x78, 0x6c, 0xee, 0x40, 0x0b, 0xa2, 0xbf, 0x69, 0x02, 0xe4, 0xeb, 0xce, 0x5e,
+	0x50, 0xbe, 0x56, 0x9b, 0x67, 0x5c, 0xab, 0xad, 0xf2, 0xb5, 0xba, 0x0a, 0x10, 0xa6, 0x43, 0xfd,
+	0x3c, 0xd7, 0x99, 0xd7, 0x42, 0x70, 0xde, 0x3d, 0xe1, 0xf8, 0x42, 0x79, 0x3a, 0x4a, 0xb4, 0x47,
+	0x2d, 0x04, 0xd3, 0x4f, 0xdf, 0x4d, 0xbe, 0x48, 0xb8, 0xaf, 0x53, 0xad, 0x19, 0x62, 0xc0, 0x61,
+	0x52, 0x39, 0xe1, 0x58, 0xd3, 0xf3, 0xd8, 0x24, 0xda, 0x22, 0x88, 0xf2, 0x87, 0xfc, 0xb9, 0x6e,
+	0xc9, 0x25, 0xdd, 0x59, 0x25, 0xbf, 0x05, 0xd1, 0x87, 0xf2, 0xe8, 0xd8, 0xc6, 0xd4, 0x8e, 0xb9,
+	0x5d, 0xbc, 0xe2, 0x96, 0x72, 0xbf, 0x58, 0xc4, 0xfa, 0x62, 0xfb, 0x7d, 0x03, 0xae, 0x28, 0x37,
+	0xeb, 0x6e, 0x4e, 0xa9, 0xc9, 0x77, 0x6a, 0x36, 0x26, 0x9f, 0x00, 0xc8, 0x13, 0xf8, 0x74, 0x3c,
+	0xd2, 0x75, 0xf8, 0x6b, 0x76, 0x1b, 0xaf, 0xc0, 0x72, 0xdb, 0x10, 0x32, 0x6b, 0x0d, 0xaa, 0xa9,
+	0x32, 0xac, 0x62, 0xd1, 0x92, 0x3b, 0xd8, 0x10, 0xfd, 0x5d, 0xc3, 0x04, 0x6a, 0x59, 0xc2, 0xec,
+	0x46, 0x9f, 0xc2, 0xfa, 0x58, 0x6a, 0xfb, 0x32, 0xdb, 0x4b, 0x6a, 0x79, 0x71, 0x78, 0xc2, 0xca,
+	0x84, 0x66, 0x68, 0xd5, 0xe0, 0xad, 0x33, 0x6b, 0xf0, 0x7b, 0xa6, 0xb1, 0x96, 0x3f, 0x2f, 0xcf,
+	0x6c, 0xcf, 0xfe, 0xa5, 0x01, 0xf3, 0xf6, 0x0a, 0x0c, 0x88, 0x30, 0x1d, 0x6e, 0xbf, 0xe0, 0x5e,
+	0x2a, 0xdc, 0xde, 0xc0, 0x14, 0x54, 0x45, 0x10, 0x3d, 0x11, 0xa6, 0xc3, 0xcf, 0x53, 0x9e, 0x72,
+	0xdf, 0x54, 0x81, 0x19, 0x80, 0x35, 0x84, 0xe7, 0x8e, 0x5c, 0x2f, 0x10, 0x63, 0x53, 0x43, 0x98,
+	0x31, 0xe6, 0xa5, 0x9e, 0x55, 0x56, 0xa9, 0x01, 0xee, 0x6a, 0x5e, 0x25, 0x3b, 0x83, 0x28, 0x8a,
+	0x75, 0xda, 0x2e, 0x82, 0xf4, 0x4f, 0x0d, 0xb8, 0x3c, 0xa1, 0x61, 0xd6, 0xab, 0x2a, 0xc4, 0x59,
+	0xdd, 0x7b, 0x5b, 0x11, 0x91, 0xbb, 0x70, 0x91, 0x67, 0xda, 0x6c, 0x28, 0x5b, 0xeb, 0xa0, 0xe9,
+	0xb0, 0xaa, 0x29, 0xcc, 0x9c, 0x5f, 0x49, 0xed, 0x72, 0x6a, 0x15, 0x1f, 0x65, 0x98, 0xfe, 0xba,
+	0x01, 0x0b, 0x85, 0x47, 0xdf, 0x2b, 0xd5, 0x05, 0x04, 0xa6, 0xbc, 0xc8, 0xe7, 0xd2, 0x7e, 0xf3,
+	0x4c, 0xfe, 0x26, 0xf7, 0xf3, 0x76, 0xc5, 0xd4, 0x5a, 0xab, 0x98, 0xe5, 0x74, 0xbb, 0xc2, 0xec,
+	0x99, 0xb5, 0x2c, 0xe8, 0xfb, 0x70, 0xbe, 0x34, 0x87, 0x8f, 0xb1, 0x63, 0x3e, 0xd6, 0x4f, 0x1e,
+	0xfc, 0x89, 0x5e, 0x39, 0x71, 0x07, 0xa9, 0x79, 0x53, 0xa9, 0x01, 0xdd, 0x81, 0xf6, 0xd6, 0xe6,
+	0x01, 0x56, 0xd2, 0x04, 0xa6, 0x42, 0x7c, 0x72, 0xe9, 0xe7, 0x28, 0xfe, 0x46, 0x6c, 0xe4, 0x0a,
+	0x13, 0xb1, 0xf2, 0x77, 0xee, 0xdd, 0x96, 0xe5, 0x5d, 0xfa, 0x39, 0xcc, 0x59, 0xdd, 0x14, 0x42,
+	0xa1, 0xe5, 0xf7, 0xcc, 0x55, 0xba, 0x68, 0x3d, 0x0f, 0xe4, 0x5e, 0x0c, 0x27, 0x31, 0xa3, 0x89,
+	0x48, 0xb8, 0x03, 0x55, 0x82, 0xab, 0x08, 0xb3, 0x10, 0xfa, 0x0b, 0x98, 0x35, 0x2d, 0x17, 0xf2,
+	0x6d, 0x5d, 0xd6, 0x20, 0x70, 0x76, 0xfa, 0xcf, 0x69, 0x71, 0x13, 0x0f, 0x2b, 0xa8, 0x20, 0xec,
+	0x7f, 0x31, 0xd2, 0xdd, 0x64, 0x0b, 0x51, 0xef, 0x07, 0x9f, 0xbf, 0xf8, 0xcc, 0xed, 0x9b, 0x38,
+	0x36, 0x63, 0xfa, 0xd7, 0x26, 0x2c, 0x14, 0xba, 0x32, 0xa8, 0xbb, 0xcc, 0xf9, 0xa6, 0xe5, 0x27,
+	0x07, 0x56, 0xfd, 0xd3, 0x2c, 0xd7, 0x62, 0xc5, 0x97, 0x52, 0xc7, 0x7a, 0x08, 0x61, 0x7c, 0x0e,
+	0xf0, 0x87, 0xba, 0x63, 0xf7, 0xe3, 0xc8, 0x4f, 0x3d, 0x1e, 0xeb, 0x22, 0xa7, 0x6a, 0x4a, 0x9f,
+	0xdb, 0x87, 0x6e, 0xe8, 0x07, 0xbe, 0xe4, 0x39, 0x9d, 0x9d, 0xdb, 0x1c, 0x24, 0xef, 0xc0, 0x85,
+	0xec, 0x5d, 0x29, 0x3b, 0x27, 0x27, 0xd9, 0x95, 0x30, 0x39, 0x81, 0x3c, 0xe3, 0x28, 0x0d, 0xfd,
+	0xa7, 0xc1, 0x90, 0x47, 0xa9, 0xc8, 0x2e, 0x87, 0x02, 0x48, 0x6e, 0xc3, 0xa2, 0x54, 0xf5, 0x69,
+	0xec, 0x86, 0x49, 0xa0, 0xae, 0x28, 0x75, 0x43, 0x4c, 0xe0, 0xb4, 0x0f, 0x73, 0x56, 0x57, 0xaa,
+	0xf6, 0x69, 0x52, 0x48, 0xf4, 0xcd, 0x72, 0xa2, 0xa7, 0x30, 0x1f, 0xa6, 0xc3, 0x27, 0x68, 0xc7,
+	0x5e, 0x14, 0x67, 0xef, 0x61, 0x1b, 0xa3, 0xf7, 0x65, 0x7b, 0x36, 0x73, 0x10, 0x0a, 0x3b, 0x08,
+	0x42, 0x6e, 0xb5, 0x67, 0x55, 0xdb, 0x12, 0xf7, 0x5d, 0x30, 0x6d, 0xcb, 0x17, 0x40, 0xb6, 0xf3,
+	0x47, 0xf5, 0x67, 0xea, 0xad, 0x81, 0x51, 0xa0, 0x9f, 0xda, 0xb1, 0x79, 0xb8, 0x9b, 0x31, 0xce,
+	0x19, 0xb3, 0xe9, 0x67, 0x54, 0x36, 0xc6, 0xf3, 0x81, 0x1e, 0x92, 0xe2, 0xb5, 0x98, 0xfc, 0x8d,
+	0x0a, 0xab, 0x37, 0xa2, 0x74, 0x65, 0x8b, 0xe9, 0x11, 0xfd, 0x67, 0x13, 0x16, 0x98, 0xb1, 0x2a,
+	0x0a, 0x5a, 0x5b, 0x37, 0x2f, 0xc1, 0xb4, 0x34, 0xbf, 0xdc, 0x6e, 0x81, 0xa9, 0x41, 0x2e, 0x07,
+	0x8f, 0x75, 0xa1, 0x9c, 0x8d, 0xd1, 0x98, 0xb2, 0xd2, 0x40, 0xd6, 0x7a, 0xdb, 0x1c, 0x40, 0xef,
+	0x19, 0x89, 0xb3, 0x80, 0x98, 0x96, 0x44, 0x13, 0x38, 0x56, 0xb1, 0x06, 0xfb, 0x3c, 0x8d, 0xe2,
+	0x74, 0xa8, 0x4b, 0xe8, 0x12, 0x8a, 0xe7, 0x0a, 0xb5, 0xd5, 0x34, 0x33, 0x92, 0xc6, 0x42, 0x50,
+	0x22, 0xa5, 0xb7, 0xe0, 0xbe, 0x0c, 0x95, 0x16, 0xcb, 0x01, 0xf2, 0x49, 0xa9, 0xdd, 0xd1, 0x91,
+	0x79, 0x62, 0xc5, 0x2a, 0x1c, 0x26, 0x7c, 0x54, 0x6c, 0x86, 0xd0, 0x3d, 0x58, 0xa9, 0x76, 0xbe,
+	0xbe, 0x2b, 0xee, 0x40, 0x5b, 0x9a, 0xcd, 0xe4, 0x20, 0xab, 0x5b, 0x57, 0x70, 0x02, 0xd3, 0x64,
+	0xb7, 0x77, 0x61, 0xb9, 0xfa, 0xfe, 0x26, 0x1d, 0x98, 0xde, 0xd8, 0xda, 0xda, 0xde, 0x5a, 0x3c,
+	0x47, 0xe6, 0x61, 0x76, 0x9f, 0xed, 0xed, 0xee, 0x3d, 0xdd, 0xde, 0x5a, 0x6c, 0x90, 0x39, 0x98,
+	0x61, 0xdb, 0xbb, 0x7b, 0x3f, 0xd8, 0xde, 0x5a, 0x6c, 0x92, 0x05, 0xe8, 0x3c, 0xdc, 0x7b, 0xb2,
+	0xf3, 0x98, 0xed, 0x6e, 0x6f, 0x2d, 0xb6, 0xee, 0xfd, 0xe7, 0x3c, 0xc0, 0xc6, 0xfe, 0xe3, 0x03,
+	0x1e, 0x9f, 0x04, 0x1e, 0x27, 0x8f, 0x01, 0xf2, 0x4f, 0xa4, 0xe4, 0x4a, 0xe9, 0xeb, 0x9c, 0xfd,
+	0x9d, 0xd5, 0x59, 0xa9, 0x9e, 0xd4, 0x4d, 0xd8, 0x73, 0x19, 0x2b, 0x55, 0x16, 0x5e, 0xa9, 0xfa,
+	0xd0, 0x57, 0xc7, 0xaa, 0x50, 0xc7, 0xd0, 0x73, 0x84, 0xc1, 0x42, 0xe1, 0xf3, 0x02, 0x59, 0xad,
+	0xf9, 0xd8, 0x62, 0x18, 0x5e, 0xad, 0x9d, 0xcf, 0x78, 0xee, 0xc1, 0xbc, 0xfd, 0x5d, 0x80, 0xbc,
+	0x51, 0x58, 0x52, 0xfe, 0xfc, 0xe0, 0xac, 0xd6, 0x4d, 0x97, 0x18, 0x66, 0xbd, 0xfd, 0x12, 0xc3,
+	0xf2, 0xd7, 0x03, 0x67, 0xb5, 0x6e, 0xda, 0x36, 0x60, 0xde, 0xdd, 0xb6, 0x0d, 0x38, 0xd1, 0x34,
+	0x77, 0x56, 0xaa, 0x27, 0x33, 0x56, 0xae, 0xfc, 0xa6, 0x56, 0xea, 0x6a, 0x93, 0xe2, 0x07, 0xa7,
+	0xea, 0x86, 0xb9, 0x73, 0xed, 0x74, 0x22, 0x5b, 0x7d, 0xbb, 0x7b, 0x6c, 0xab, 0x5f, 0xd1, 0xd9,
+	0x76, 0x56, 0xeb, 0xa6, 0x33, 0x86, 0x5f, 0xc2, 0xf9, 0x52, 0x23, 0x99, 0x58, 0x35, 0x6c, 0x75,
+	0xf7, 0xd9, 0x79, 0xf3, 0x14, 0x8a, 0x8c, 0x73, 0x1f, 0x96, 0xaa, 0x1a, 0xc4, 0xc4, 0xfa, 0x84,
+	0x77, 0x4a, 0x2f, 0xda, 0xb9, 0x7e, 0x16, 0x59, 0xb6, 0xd1, 0x0e, 0x74, 0xb2, 0x2e, 0x2f, 0x71,
+	0x8a, 0x1a, 0xdb, 0xcd, 0x66, 0xe7, 0x4a, 0xe5, 0x5c, 0xc6, 0x27, 0x91, 0x5f, 0x5d, 0xab, 0x7b,
+	0xb9, 0xb7, 0x0a, 0xfe, 0x39, 0xad, 0x51, 0xec, 0xdc, 0x7e, 0x19, 0xd2, 0x6c, 0xd3, 0x91, 0x2c,
+	0x70, 0x2b, 0x1b, 0x9c, 0x37, 0x27, 0x8f, 0x57, 0x75, 0x8f, 0xd4, 0xb9, 0xf5, 0x12, 0x94, 0xd9,
+	0x8e, 0x43, 0x58, 0xb6, 0x89, 0xf2, 0x3e, 0x1a, 0xb9, 0x51, 0xcd, 0x66, 0xa2, 0xdd, 0xe8, 0xdc,
+	0x3c, 0x9b, 0x30, 0xdb, 0xee, 0x19, 0xbc, 0x56, 0x6c, 0x5a, 0x11, 0x2b, 0x6d, 0x54, 0xf6, 0xe0,
+	0x9c, 0xb5, 0x7a, 0x02, 0xc3, 0xf6, 0x6e, 0x43, 0xa7, 0xab, 0xbc, 0x77, 0x53, 0x4a, 0x57, 0x13,
+	0x6d, 0x2a, 0xe7, 0x6a, 0xed, 0x7c, 0xe9, 0x04, 0x97, 0x7b, 0x39, 0x6f, 0x55, 0xab, 0x5b, 0x68,
+	0x58, 0x38, 0xd7, 0x4e, 0x27, 0xca, 0xb6, 0x18, 0xc0, 0xa5, 0xca, 0xc6, 0x06, 0xb9, 0x6e, 0x97,
+	0xf6, 0xf5, 0x9d, 0x11, 0xe7, 0xc6, 0x99, 0x74, 0x13, 0x46, 0xb2, 0x5a, 0x17, 0x45, 0x23, 0x4d,
+	0xf4, 0x42, 0x9c, 0xab, 0xb5, 0xf3, 0x99, 0x06, 0x01, 0x2c, 0x55, 0xbd, 0x88, 0xed, 0x83, 0x7d,
+	0xca, 0x9b, 0xde, 0xb9, 0x7e, 0x16, 0x99, 0x25, 0xfe, 0x97, 0x70, 0xbe, 0xf4, 0xfc, 0x23, 0x13,
+	0xff, 0xd3, 0x29, 0xbf, 0x7d, 0x9d, 0x37, 0x4f, 0xa1, 0xb0, 0xb3, 0x53, 0x55, 0xc5, 0x40, 0x8a,
+	0x7f, 0x30, 0xa8, 0x2b, 0x27, 0x9d, 0xeb, 0x67, 0x91, 0x99, 0x8d, 0x36, 0xbf, 0xf5, 0xc3, 0xf7,
+	0xfa, 0x81, 0x38, 0x4a, 0x7b, 0xeb, 0x5e, 0x34, 0xbc, 0x23, 0x57, 0x8d, 0xe2, 0xe8, 0xa7, 0xdc,
+	0x13, 0x6a, 0xf0, 0x2e, 0x1e, 0x98, 0x3b, 0xf2, 0x93, 0x57, 0x9f, 0x87, 0x77, 0x0c, 0xdb, 0x5e,
+	0x5b, 0x42, 0xf7, 0xff, 0x3b, 0x00, 0x78, 0x4d, 0x1b, 0xa0, 0xb3, 0x25, 0x00, 0x00,
 }
@@ -23,29 +23,38 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type Consensus_ConsensusMessageType int32
 
 const (
-	Consensus_PROPOSAL    Consensus_ConsensusMessageType = 0
-	Consensus_ENDORSEMENT Consensus_ConsensusMessageType = 1
+	Consensus_PROPOSAL     Consensus_ConsensusMessageType = 0
+	Consensus_ENDORSEMENT  Consensus_ConsensusMessageType = 1
+	Consensus_ROUND_CHANGE Consensus_ConsensusMessageType = 2
+	Consensus_DKG          Consensus_ConsensusMessageType = 3
 )
 
 var Consensus_ConsensusMessageType_name = map[int32]string{
 	0: "PROPOSAL",
 	1: "ENDORSEMENT",
+	2: "ROUND_CHANGE",
+	3: "DKG",
 }
 var Consensus_ConsensusMessageType_value = map[string]int32{
-	"PROPOSAL":    0,
-	"ENDORSEMENT": 1,
+	"PROPOSAL":     0,
+	"ENDORSEMENT":  1,
+	"ROUND_CHANGE": 2,
+	"DKG":          3,
 }
 
 func (x Consensus_ConsensusMessageType) String() string {
 	return proto.EnumName(Consensus_ConsensusMessageType_name, int32(x))
 }
 func (Consensus_ConsensusMessageType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_b65c618a256dbdb5, []int{2, 0}
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{5, 0}
 }
 
 type BlockSync struct {
-	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	End                  uint64   `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Start uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// respond the blocks in compact form, which the requester reconstructs with the actions in its actpool
+	Compact              bool     `protobuf:"varint,4,opt,name=compact,proto3" json:"compact,omitempty"`
+	Receipts             bool     `protobuf:"varint,5,opt,name=receipts,proto3" json:"receipts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -55,7 +64,7 @@ func (m *BlockSync) Reset()         { *m = BlockSync{} }
 func (m *BlockSync) String() string { return proto.CompactTextString(m) }
 func (*BlockSync) ProtoMessage()    {}
 func (*BlockSync) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_b65c618a256dbdb5, []int{0}
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{0}
 }
 func (m *BlockSync) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockSync.Unmarshal(m, b)
@@ -89,6 +98,20 @@ func (m *BlockSync) GetEnd() uint64 {
 	return 0
 }
 
+func (m *BlockSync) GetCompact() bool {
+	if m != nil {
+		return m.Compact
+	}
+	return false
+}
+
+func (m *BlockSync) GetReceipts() bool {
+	if m != nil {
+		return m.Receipts
+	}
+	return false
+}
+
 // block container
 // used to send old/existing blocks in block sync
 type BlockContainer struct {
@@ -102,7 +125,7 @@ func (m *BlockContainer) Reset()         { *m = BlockContainer{} }
 func (m *BlockContainer) String() string { return proto.CompactTextString(m) }
 func (*BlockContainer) ProtoMessage()    {}
 func (*BlockContainer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_b65c618a256dbdb5, []int{1}
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{1}
 }
 func (m *BlockContainer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockContainer.Unmarshal(m, b)
@@ -129,6 +152,165 @@ func (m *BlockContainer) GetBlock() *iotextypes.Block {
 	return nil
 }
 
+// compact block
+// used to send the header, footer and action hashes of an old/existing block in block sync
+type CompactBlock struct {
+	Header               *iotextypes.BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Footer               *iotextypes.BlockFooter `protobuf:"bytes,2,opt,name=footer,proto3" json:"footer,omitempty"`
+	ActionHashes         [][]byte                `protobuf:"bytes,3,rep,name=actionHashes,proto3" json:"actionHashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *CompactBlock) Reset()         { *m = CompactBlock{} }
+func (m *CompactBlock) String() string { return proto.CompactTextString(m) }
+func (*CompactBlock) ProtoMessage()    {}
+func (*CompactBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{2}
+}
+func (m *CompactBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactBlock.Unmarshal(m, b)
+}
+func (m *CompactBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactBlock.Marshal(b, m, deterministic)
+}
+func (dst *CompactBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlock.Merge(dst, src)
+}
+func (m *CompactBlock) XXX_Size() int {
+	return xxx_messageInfo_CompactBlock.Size(m)
+}
+func (m *CompactBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlock proto.InternalMessageInfo
+
+func (m *CompactBlock) GetHeader() *iotextypes.BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetFooter() *iotextypes.BlockFooter {
+	if m != nil {
+		return m.Footer
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetActionHashes() [][]byte {
+	if m != nil {
+		return m.ActionHashes
+	}
+	return nil
+}
+
+// request of the actions of a compact block missing from the actpool of the requester
+type BlockActionsRequest struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// indexes of the actions in the block
+	Indexes              []uint32 `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockActionsRequest) Reset()         { *m = BlockActionsRequest{} }
+func (m *BlockActionsRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockActionsRequest) ProtoMessage()    {}
+func (*BlockActionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{3}
+}
+func (m *BlockActionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockActionsRequest.Unmarshal(m, b)
+}
+func (m *BlockActionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockActionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *BlockActionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockActionsRequest.Merge(dst, src)
+}
+func (m *BlockActionsRequest) XXX_Size() int {
+	return xxx_messageInfo_BlockActionsRequest.Size(m)
+}
+func (m *BlockActionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockActionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockActionsRequest proto.InternalMessageInfo
+
+func (m *BlockActionsRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockActionsRequest) GetIndexes() []uint32 {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+// response to BlockActionsRequest
+type BlockActions struct {
+	Height               uint64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Indexes              []uint32             `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+	Actions              []*iotextypes.Action `protobuf:"bytes,3,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BlockActions) Reset()         { *m = BlockActions{} }
+func (m *BlockActions) String() string { return proto.CompactTextString(m) }
+func (*BlockActions) ProtoMessage()    {}
+func (*BlockActions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{4}
+}
+func (m *BlockActions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockActions.Unmarshal(m, b)
+}
+func (m *BlockActions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockActions.Marshal(b, m, deterministic)
+}
+func (dst *BlockActions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockActions.Merge(dst, src)
+}
+func (m *BlockActions) XXX_Size() int {
+	return xxx_messageInfo_BlockActions.Size(m)
+}
+func (m *BlockActions) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockActions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockActions proto.InternalMessageInfo
+
+func (m *BlockActions) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockActions) GetIndexes() []uint32 {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+func (m *BlockActions) GetActions() []*iotextypes.Action {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
 type Consensus struct {
 	Height               uint64                         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Round                uint32                         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
@@ -144,7 +326,7 @@ func (m *Consensus) Reset()         { *m = Consensus{} }
 func (m *Consensus) String() string { return proto.CompactTextString(m) }
 func (*Consensus) ProtoMessage()    {}
 func (*Consensus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_b65c618a256dbdb5, []int{2}
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{5}
 }
 func (m *Consensus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Consensus.Unmarshal(m, b)
@@ -199,37 +381,183 @@ func (m *Consensus) GetData() []byte {
 	return nil
 }
 
+// request of a chunk of the state snapshot at the height
+type SnapshotChunkRequest struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Index                uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotChunkRequest) Reset()         { *m = SnapshotChunkRequest{} }
+func (m *SnapshotChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunkRequest) ProtoMessage()    {}
+func (*SnapshotChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{6}
+}
+func (m *SnapshotChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotChunkRequest.Unmarshal(m, b)
+}
+func (m *SnapshotChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotChunkRequest.Marshal(b, m, deterministic)
+}
+func (dst *SnapshotChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunkRequest.Merge(dst, src)
+}
+func (m *SnapshotChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotChunkRequest.Size(m)
+}
+func (m *SnapshotChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChunkRequest proto.InternalMessageInfo
+
+func (m *SnapshotChunkRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SnapshotChunkRequest) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// response to SnapshotChunkRequest
+// the chunk is verified against the merkle root of all the chunks of the snapshot with the proof
+type SnapshotChunk struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Index  uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// number of the chunks of the snapshot
+	Count                uint32   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Root                 []byte   `protobuf:"bytes,4,opt,name=root,proto3" json:"root,omitempty"`
+	Data                 []byte   `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,6,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
+func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunk) ProtoMessage()    {}
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_29dd99f755dd45ad, []int{7}
+}
+func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotChunk.Unmarshal(m, b)
+}
+func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotChunk.Marshal(b, m, deterministic)
+}
+func (dst *SnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunk.Merge(dst, src)
+}
+func (m *SnapshotChunk) XXX_Size() int {
+	return xxx_messageInfo_SnapshotChunk.Size(m)
+}
+func (m *SnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChunk proto.InternalMessageInfo
+
+func (m *SnapshotChunk) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *SnapshotChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SnapshotChunk) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockSync)(nil), "iotexrpc.BlockSync")
 	proto.RegisterType((*BlockContainer)(nil), "iotexrpc.BlockContainer")
+	proto.RegisterType((*CompactBlock)(nil), "iotexrpc.CompactBlock")
+	proto.RegisterType((*BlockActionsRequest)(nil), "iotexrpc.BlockActionsRequest")
+	proto.RegisterType((*BlockActions)(nil), "iotexrpc.BlockActions")
 	proto.RegisterType((*Consensus)(nil), "iotexrpc.Consensus")
+	proto.RegisterType((*SnapshotChunkRequest)(nil), "iotexrpc.SnapshotChunkRequest")
+	proto.RegisterType((*SnapshotChunk)(nil), "iotexrpc.SnapshotChunk")
 	proto.RegisterEnum("iotexrpc.Consensus_ConsensusMessageType", Consensus_ConsensusMessageType_name, Consensus_ConsensusMessageType_value)
 }
 
-func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_b65c618a256dbdb5) }
-
-var fileDescriptor_rpc_b65c618a256dbdb5 = []byte{
-	// 346 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0x4d, 0x6f, 0xea, 0x30,
-	0x10, 0x7c, 0x79, 0x04, 0x44, 0x0c, 0x8f, 0x97, 0x5a, 0xa8, 0x8a, 0xb8, 0x14, 0xe5, 0xd2, 0x5c,
-	0xea, 0x48, 0xd0, 0x56, 0xad, 0xd4, 0x4b, 0xa1, 0xdc, 0xca, 0x87, 0x0c, 0xa7, 0xde, 0x12, 0xe3,
-	0x26, 0x69, 0xc1, 0x8e, 0x6c, 0x47, 0x2a, 0xff, 0xbd, 0x87, 0x2a, 0x1b, 0x02, 0x97, 0xde, 0x76,
-	0xbc, 0x33, 0xbb, 0x33, 0x6b, 0xe4, 0xa8, 0x9c, 0x91, 0x5c, 0x49, 0x23, 0x71, 0x3b, 0x93, 0x86,
-	0x7f, 0xa9, 0x9c, 0x0d, 0xdc, 0x78, 0x27, 0xd9, 0x27, 0x4b, 0xa3, 0x4c, 0x54, 0xbd, 0xc1, 0x55,
-	0x22, 0x65, 0xb2, 0xe3, 0x21, 0xa0, 0xb8, 0x78, 0x0f, 0x4d, 0xb6, 0xe7, 0xda, 0x44, 0xfb, 0xbc,
-	0x22, 0xf8, 0x63, 0xe4, 0x4c, 0x4a, 0xd1, 0xfa, 0x20, 0x18, 0xee, 0xa3, 0xa6, 0x36, 0x91, 0x32,
-	0xde, 0xdf, 0xa1, 0x15, 0xd8, 0xb4, 0x02, 0xd8, 0x45, 0x0d, 0x2e, 0xb6, 0x5e, 0x03, 0xde, 0xca,
-	0xd2, 0x7f, 0x44, 0x3d, 0x10, 0x4d, 0xa5, 0x30, 0x51, 0x26, 0xb8, 0xc2, 0xd7, 0xa8, 0x09, 0xbb,
-	0x3d, 0x6b, 0x68, 0x05, 0x9d, 0xd1, 0x05, 0x01, 0x4f, 0xe6, 0x90, 0x73, 0x4d, 0x80, 0x4a, 0xab,
-	0xbe, 0xff, 0x6d, 0x21, 0x67, 0x2a, 0x85, 0xe6, 0x42, 0x17, 0x1a, 0x5f, 0xa2, 0x56, 0xca, 0xb3,
-	0x24, 0x35, 0xa0, 0xb3, 0xe9, 0x11, 0x95, 0x46, 0x94, 0x2c, 0xc4, 0x16, 0x8c, 0xfc, 0xa3, 0x15,
-	0xc0, 0x4f, 0xc8, 0x2e, 0x27, 0x82, 0x93, 0xde, 0x28, 0x20, 0x75, 0x6e, 0x72, 0x1a, 0x78, 0xae,
-	0xe6, 0x5c, 0xeb, 0x28, 0xe1, 0x9b, 0x43, 0xce, 0x29, 0xa8, 0xf0, 0x03, 0x72, 0x4e, 0xe1, 0x3d,
-	0x1b, 0x6c, 0x0e, 0x48, 0x75, 0x1e, 0x52, 0x9f, 0x87, 0x6c, 0x6a, 0x06, 0x3d, 0x93, 0x31, 0x46,
-	0xf6, 0x36, 0x32, 0x91, 0xd7, 0x1c, 0x5a, 0x41, 0x97, 0x42, 0xed, 0xdf, 0xa1, 0xfe, 0x6f, 0xbb,
-	0x70, 0x17, 0xb5, 0x57, 0x74, 0xb9, 0x5a, 0xae, 0x9f, 0x5f, 0xdd, 0x3f, 0xf8, 0x3f, 0xea, 0xcc,
-	0x16, 0x2f, 0x4b, 0xba, 0x9e, 0xcd, 0x67, 0x8b, 0x8d, 0x6b, 0x4d, 0xee, 0xdf, 0x6e, 0x93, 0xcc,
-	0xa4, 0x45, 0x4c, 0x98, 0xdc, 0x87, 0x10, 0x20, 0x57, 0xf2, 0x83, 0x33, 0x53, 0x81, 0x1b, 0x26,
-	0xd5, 0xf1, 0xb7, 0x12, 0x2e, 0xc2, 0x3a, 0x61, 0xdc, 0x82, 0xa7, 0xf1, 0x4f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xfe, 0x0c, 0x58, 0x74, 0xf7, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_29dd99f755dd45ad) }
+
+var fileDescriptor_rpc_29dd99f755dd45ad = []byte{
+	// 549 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcd, 0x6e, 0xdb, 0x3c,
+	0x10, 0xfc, 0x14, 0xdb, 0x49, 0xbc, 0x96, 0xf3, 0xb9, 0xac, 0xd1, 0x0a, 0xb9, 0x54, 0xd0, 0xa5,
+	0x3a, 0xb4, 0x32, 0xe0, 0xfe, 0xa0, 0x05, 0x7a, 0x49, 0x9c, 0xb4, 0x39, 0x34, 0x71, 0x40, 0xfb,
+	0xd4, 0x1b, 0x4d, 0xd3, 0x92, 0x9a, 0x98, 0x54, 0x49, 0x0a, 0x88, 0x1f, 0xa3, 0x40, 0x1f, 0xb7,
+	0x87, 0x42, 0x4b, 0x29, 0x3f, 0x68, 0x82, 0x22, 0xb7, 0x9d, 0xe1, 0xce, 0x70, 0xb9, 0xbb, 0x84,
+	0xae, 0x2e, 0x78, 0x52, 0x68, 0x65, 0x15, 0xd9, 0xcd, 0x95, 0x15, 0x57, 0xba, 0xe0, 0xfb, 0x3e,
+	0xe3, 0x36, 0x57, 0xd2, 0xf1, 0xfb, 0x83, 0xc5, 0xa5, 0xe2, 0x17, 0x3c, 0x63, 0x79, 0xc3, 0xbc,
+	0x48, 0x95, 0x4a, 0x2f, 0xc5, 0x08, 0xd1, 0xa2, 0x5c, 0x8d, 0x6c, 0xbe, 0x16, 0xc6, 0xb2, 0x75,
+	0xe1, 0x12, 0xa2, 0x53, 0xe8, 0x1e, 0x56, 0xa2, 0xd9, 0x46, 0x72, 0x32, 0x84, 0x8e, 0xb1, 0x4c,
+	0xdb, 0x60, 0x2b, 0xf4, 0xe2, 0x36, 0x75, 0x80, 0x0c, 0xa0, 0x25, 0xe4, 0x32, 0x68, 0x21, 0x57,
+	0x85, 0x24, 0x80, 0x1d, 0xae, 0xd6, 0x05, 0xe3, 0x36, 0x68, 0x87, 0x5e, 0xbc, 0x4b, 0x1b, 0x18,
+	0x7d, 0x84, 0x3d, 0xb4, 0x9b, 0x28, 0x69, 0x59, 0x2e, 0x85, 0x26, 0x2f, 0xa1, 0x83, 0x55, 0x05,
+	0x5e, 0xe8, 0xc5, 0xbd, 0xf1, 0x93, 0x04, 0x6b, 0xb7, 0x9b, 0x42, 0x98, 0x04, 0x53, 0xa9, 0x3b,
+	0x8f, 0x7e, 0x79, 0xe0, 0x4f, 0x9c, 0x0d, 0xf2, 0x64, 0x04, 0xdb, 0x99, 0x60, 0x4b, 0xa1, 0x6b,
+	0xe9, 0xf3, 0xbf, 0xa4, 0x27, 0x78, 0x4c, 0xeb, 0xb4, 0x4a, 0xb0, 0x52, 0xca, 0x0a, 0x1d, 0x6c,
+	0x3d, 0x20, 0xf8, 0x8c, 0xc7, 0xb4, 0x4e, 0x23, 0x11, 0xd4, 0xfd, 0x3b, 0x61, 0x26, 0x13, 0x26,
+	0x68, 0x85, 0xad, 0xd8, 0xa7, 0x77, 0xb8, 0xe8, 0x0b, 0x3c, 0x45, 0xe9, 0x01, 0x92, 0x86, 0x8a,
+	0x1f, 0xa5, 0x30, 0x96, 0x3c, 0xab, 0x8a, 0xcb, 0xd3, 0xcc, 0x62, 0x71, 0x6d, 0x5a, 0xa3, 0xaa,
+	0x35, 0xb9, 0x5c, 0x8a, 0x2b, 0x61, 0x82, 0xad, 0xb0, 0x15, 0xf7, 0x69, 0x03, 0x23, 0x09, 0xfe,
+	0x6d, 0xa3, 0xc7, 0x3b, 0x90, 0x57, 0xb0, 0xe3, 0x4a, 0x73, 0x95, 0xf6, 0xc6, 0xe4, 0xf6, 0x03,
+	0x9d, 0x2f, 0x6d, 0x52, 0xa2, 0xdf, 0x1e, 0x74, 0x27, 0x4a, 0x1a, 0x21, 0x4d, 0xf9, 0xf0, 0x6d,
+	0x43, 0xe8, 0x68, 0x55, 0xca, 0x25, 0xb6, 0xac, 0x4f, 0x1d, 0x20, 0x9f, 0xa0, 0x5d, 0x99, 0xe2,
+	0xcc, 0xf7, 0xc6, 0x71, 0xd2, 0xec, 0x5b, 0x72, 0x6d, 0x78, 0x13, 0x9d, 0x0a, 0x63, 0x58, 0x2a,
+	0xe6, 0x9b, 0x42, 0x50, 0x54, 0x91, 0x0f, 0xd0, 0xbd, 0x5e, 0x33, 0x5c, 0x90, 0xde, 0x78, 0x3f,
+	0x71, 0x8b, 0x98, 0x34, 0x8b, 0x98, 0xcc, 0x9b, 0x0c, 0x7a, 0x93, 0x4c, 0x08, 0xb4, 0x97, 0xcc,
+	0xb2, 0xa0, 0x13, 0x7a, 0xb1, 0x4f, 0x31, 0x8e, 0xde, 0xc1, 0xf0, 0xbe, 0xbb, 0x88, 0x0f, 0xbb,
+	0xe7, 0x74, 0x7a, 0x3e, 0x9d, 0x1d, 0x7c, 0x1d, 0xfc, 0x47, 0xfe, 0x87, 0xde, 0xf1, 0xd9, 0xd1,
+	0x94, 0xce, 0x8e, 0x4f, 0x8f, 0xcf, 0xe6, 0x03, 0x2f, 0x3a, 0x82, 0xe1, 0x4c, 0xb2, 0xc2, 0x64,
+	0xca, 0x4e, 0xb2, 0x52, 0x5e, 0xfc, 0x6b, 0x70, 0x43, 0xe8, 0x60, 0x9f, 0x9b, 0x46, 0x20, 0x88,
+	0x7e, 0x7a, 0xd0, 0xbf, 0x63, 0xf3, 0x38, 0x7d, 0xc5, 0x72, 0x55, 0x4a, 0x8b, 0x9d, 0xec, 0x53,
+	0x07, 0xaa, 0x67, 0x6a, 0xa5, 0xdc, 0xe7, 0xf1, 0x29, 0xc6, 0xf7, 0x3d, 0xbd, 0x52, 0x17, 0x5a,
+	0xa9, 0x55, 0xb0, 0x8d, 0x8b, 0xe9, 0xc0, 0xe1, 0xfb, 0x6f, 0x6f, 0xd3, 0xdc, 0x66, 0xe5, 0x22,
+	0xe1, 0x6a, 0x3d, 0xc2, 0xd1, 0x14, 0x5a, 0x7d, 0x17, 0xdc, 0x3a, 0xf0, 0x9a, 0x2b, 0x5d, 0xff,
+	0xf8, 0x54, 0xc8, 0x51, 0x33, 0xbb, 0xc5, 0x36, 0x52, 0x6f, 0xfe, 0x0c, 0x00, 0x62, 0x27, 0x97,
+	0x96, 0x49, 0x04, 0x00, 0x00,
 }
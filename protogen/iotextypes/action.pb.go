@@ -22,24 +22,30 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type RewardType int32
 
 const (
-	RewardType_BlockReward RewardType = 0
-	RewardType_EpochReward RewardType = 1
+	RewardType_BlockReward    RewardType = 0
+	RewardType_EpochReward    RewardType = 1
+	RewardType_EpochRewardCap RewardType = 2
+	RewardType_MinClaimAmount RewardType = 3
 )
 
 var RewardType_name = map[int32]string{
 	0: "BlockReward",
 	1: "EpochReward",
+	2: "EpochRewardCap",
+	3: "MinClaimAmount",
 }
 var RewardType_value = map[string]int32{
-	"BlockReward": 0,
-	"EpochReward": 1,
+	"BlockReward":    0,
+	"EpochReward":    1,
+	"EpochRewardCap": 2,
+	"MinClaimAmount": 3,
 }
 
 func (x RewardType) String() string {
 	return proto.EnumName(RewardType_name, int32(x))
 }
 func (RewardType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{0}
+	return fileDescriptor_action_0741c87ee0149c28, []int{0}
 }
 
 type Transfer struct {
@@ -56,7 +62,7 @@ func (m *Transfer) Reset()         { *m = Transfer{} }
 func (m *Transfer) String() string { return proto.CompactTextString(m) }
 func (*Transfer) ProtoMessage()    {}
 func (*Transfer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{0}
+	return fileDescriptor_action_0741c87ee0149c28, []int{0}
 }
 func (m *Transfer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transfer.Unmarshal(m, b)
@@ -109,7 +115,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{1}
+	return fileDescriptor_action_0741c87ee0149c28, []int{1}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Vote.Unmarshal(m, b)
@@ -156,7 +162,7 @@ func (m *Execution) Reset()         { *m = Execution{} }
 func (m *Execution) String() string { return proto.CompactTextString(m) }
 func (*Execution) ProtoMessage()    {}
 func (*Execution) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{2}
+	return fileDescriptor_action_0741c87ee0149c28, []int{2}
 }
 func (m *Execution) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Execution.Unmarshal(m, b)
@@ -213,7 +219,7 @@ func (m *StartSubChain) Reset()         { *m = StartSubChain{} }
 func (m *StartSubChain) String() string { return proto.CompactTextString(m) }
 func (*StartSubChain) ProtoMessage()    {}
 func (*StartSubChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{3}
+	return fileDescriptor_action_0741c87ee0149c28, []int{3}
 }
 func (m *StartSubChain) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StartSubChain.Unmarshal(m, b)
@@ -281,7 +287,7 @@ func (m *StopSubChain) Reset()         { *m = StopSubChain{} }
 func (m *StopSubChain) String() string { return proto.CompactTextString(m) }
 func (*StopSubChain) ProtoMessage()    {}
 func (*StopSubChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{4}
+	return fileDescriptor_action_0741c87ee0149c28, []int{4}
 }
 func (m *StopSubChain) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StopSubChain.Unmarshal(m, b)
@@ -334,7 +340,7 @@ func (m *MerkleRoot) Reset()         { *m = MerkleRoot{} }
 func (m *MerkleRoot) String() string { return proto.CompactTextString(m) }
 func (*MerkleRoot) ProtoMessage()    {}
 func (*MerkleRoot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{5}
+	return fileDescriptor_action_0741c87ee0149c28, []int{5}
 }
 func (m *MerkleRoot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MerkleRoot.Unmarshal(m, b)
@@ -381,7 +387,7 @@ func (m *PutBlock) Reset()         { *m = PutBlock{} }
 func (m *PutBlock) String() string { return proto.CompactTextString(m) }
 func (*PutBlock) ProtoMessage()    {}
 func (*PutBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{6}
+	return fileDescriptor_action_0741c87ee0149c28, []int{6}
 }
 func (m *PutBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutBlock.Unmarshal(m, b)
@@ -435,7 +441,7 @@ func (m *CreateDeposit) Reset()         { *m = CreateDeposit{} }
 func (m *CreateDeposit) String() string { return proto.CompactTextString(m) }
 func (*CreateDeposit) ProtoMessage()    {}
 func (*CreateDeposit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{7}
+	return fileDescriptor_action_0741c87ee0149c28, []int{7}
 }
 func (m *CreateDeposit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateDeposit.Unmarshal(m, b)
@@ -489,7 +495,7 @@ func (m *SettleDeposit) Reset()         { *m = SettleDeposit{} }
 func (m *SettleDeposit) String() string { return proto.CompactTextString(m) }
 func (*SettleDeposit) ProtoMessage()    {}
 func (*SettleDeposit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{8}
+	return fileDescriptor_action_0741c87ee0149c28, []int{8}
 }
 func (m *SettleDeposit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SettleDeposit.Unmarshal(m, b)
@@ -530,6 +536,124 @@ func (m *SettleDeposit) GetIndex() uint64 {
 	return 0
 }
 
+// burn the token on sub-chain to withdraw it to main-chain
+type CreateWithdraw struct {
+	Amount               []byte   `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Recipient            string   `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWithdraw) Reset()         { *m = CreateWithdraw{} }
+func (m *CreateWithdraw) String() string { return proto.CompactTextString(m) }
+func (*CreateWithdraw) ProtoMessage()    {}
+func (*CreateWithdraw) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{9}
+}
+func (m *CreateWithdraw) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWithdraw.Unmarshal(m, b)
+}
+func (m *CreateWithdraw) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWithdraw.Marshal(b, m, deterministic)
+}
+func (dst *CreateWithdraw) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWithdraw.Merge(dst, src)
+}
+func (m *CreateWithdraw) XXX_Size() int {
+	return xxx_messageInfo_CreateWithdraw.Size(m)
+}
+func (m *CreateWithdraw) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWithdraw.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWithdraw proto.InternalMessageInfo
+
+func (m *CreateWithdraw) GetAmount() []byte {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *CreateWithdraw) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
+// unlock the token on main-chain, given the proof of the withdrawal's inclusion in a sub-chain block put on main-chain
+type SettleWithdraw struct {
+	SubChainAddress      string   `protobuf:"bytes,1,opt,name=subChainAddress,proto3" json:"subChainAddress,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Withdraw             *Action  `protobuf:"bytes,3,opt,name=withdraw,proto3" json:"withdraw,omitempty"`
+	Index                uint32   `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	Proof                [][]byte `protobuf:"bytes,5,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SettleWithdraw) Reset()         { *m = SettleWithdraw{} }
+func (m *SettleWithdraw) String() string { return proto.CompactTextString(m) }
+func (*SettleWithdraw) ProtoMessage()    {}
+func (*SettleWithdraw) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{10}
+}
+func (m *SettleWithdraw) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SettleWithdraw.Unmarshal(m, b)
+}
+func (m *SettleWithdraw) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SettleWithdraw.Marshal(b, m, deterministic)
+}
+func (dst *SettleWithdraw) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettleWithdraw.Merge(dst, src)
+}
+func (m *SettleWithdraw) XXX_Size() int {
+	return xxx_messageInfo_SettleWithdraw.Size(m)
+}
+func (m *SettleWithdraw) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettleWithdraw.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SettleWithdraw proto.InternalMessageInfo
+
+func (m *SettleWithdraw) GetSubChainAddress() string {
+	if m != nil {
+		return m.SubChainAddress
+	}
+	return ""
+}
+
+func (m *SettleWithdraw) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SettleWithdraw) GetWithdraw() *Action {
+	if m != nil {
+		return m.Withdraw
+	}
+	return nil
+}
+
+func (m *SettleWithdraw) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SettleWithdraw) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 // plum main chain APIs
 type CreatePlumChain struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -541,7 +665,7 @@ func (m *CreatePlumChain) Reset()         { *m = CreatePlumChain{} }
 func (m *CreatePlumChain) String() string { return proto.CompactTextString(m) }
 func (*CreatePlumChain) ProtoMessage()    {}
 func (*CreatePlumChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{9}
+	return fileDescriptor_action_0741c87ee0149c28, []int{11}
 }
 func (m *CreatePlumChain) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreatePlumChain.Unmarshal(m, b)
@@ -572,7 +696,7 @@ func (m *TerminatePlumChain) Reset()         { *m = TerminatePlumChain{} }
 func (m *TerminatePlumChain) String() string { return proto.CompactTextString(m) }
 func (*TerminatePlumChain) ProtoMessage()    {}
 func (*TerminatePlumChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{10}
+	return fileDescriptor_action_0741c87ee0149c28, []int{12}
 }
 func (m *TerminatePlumChain) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TerminatePlumChain.Unmarshal(m, b)
@@ -612,7 +736,7 @@ func (m *PlumPutBlock) Reset()         { *m = PlumPutBlock{} }
 func (m *PlumPutBlock) String() string { return proto.CompactTextString(m) }
 func (*PlumPutBlock) ProtoMessage()    {}
 func (*PlumPutBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{11}
+	return fileDescriptor_action_0741c87ee0149c28, []int{13}
 }
 func (m *PlumPutBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumPutBlock.Unmarshal(m, b)
@@ -666,7 +790,7 @@ func (m *PlumCreateDeposit) Reset()         { *m = PlumCreateDeposit{} }
 func (m *PlumCreateDeposit) String() string { return proto.CompactTextString(m) }
 func (*PlumCreateDeposit) ProtoMessage()    {}
 func (*PlumCreateDeposit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{12}
+	return fileDescriptor_action_0741c87ee0149c28, []int{14}
 }
 func (m *PlumCreateDeposit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumCreateDeposit.Unmarshal(m, b)
@@ -724,7 +848,7 @@ func (m *PlumStartExit) Reset()         { *m = PlumStartExit{} }
 func (m *PlumStartExit) String() string { return proto.CompactTextString(m) }
 func (*PlumStartExit) ProtoMessage()    {}
 func (*PlumStartExit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{13}
+	return fileDescriptor_action_0741c87ee0149c28, []int{15}
 }
 func (m *PlumStartExit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumStartExit.Unmarshal(m, b)
@@ -808,7 +932,7 @@ func (m *PlumChallengeExit) Reset()         { *m = PlumChallengeExit{} }
 func (m *PlumChallengeExit) String() string { return proto.CompactTextString(m) }
 func (*PlumChallengeExit) ProtoMessage()    {}
 func (*PlumChallengeExit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{14}
+	return fileDescriptor_action_0741c87ee0149c28, []int{16}
 }
 func (m *PlumChallengeExit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumChallengeExit.Unmarshal(m, b)
@@ -879,7 +1003,7 @@ func (m *PlumResponseChallengeExit) Reset()         { *m = PlumResponseChallenge
 func (m *PlumResponseChallengeExit) String() string { return proto.CompactTextString(m) }
 func (*PlumResponseChallengeExit) ProtoMessage()    {}
 func (*PlumResponseChallengeExit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{15}
+	return fileDescriptor_action_0741c87ee0149c28, []int{17}
 }
 func (m *PlumResponseChallengeExit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumResponseChallengeExit.Unmarshal(m, b)
@@ -953,7 +1077,7 @@ func (m *PlumFinalizeExit) Reset()         { *m = PlumFinalizeExit{} }
 func (m *PlumFinalizeExit) String() string { return proto.CompactTextString(m) }
 func (*PlumFinalizeExit) ProtoMessage()    {}
 func (*PlumFinalizeExit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{16}
+	return fileDescriptor_action_0741c87ee0149c28, []int{18}
 }
 func (m *PlumFinalizeExit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumFinalizeExit.Unmarshal(m, b)
@@ -999,7 +1123,7 @@ func (m *PlumSettleDeposit) Reset()         { *m = PlumSettleDeposit{} }
 func (m *PlumSettleDeposit) String() string { return proto.CompactTextString(m) }
 func (*PlumSettleDeposit) ProtoMessage()    {}
 func (*PlumSettleDeposit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{17}
+	return fileDescriptor_action_0741c87ee0149c28, []int{19}
 }
 func (m *PlumSettleDeposit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumSettleDeposit.Unmarshal(m, b)
@@ -1040,7 +1164,7 @@ func (m *PlumTransfer) Reset()         { *m = PlumTransfer{} }
 func (m *PlumTransfer) String() string { return proto.CompactTextString(m) }
 func (*PlumTransfer) ProtoMessage()    {}
 func (*PlumTransfer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{18}
+	return fileDescriptor_action_0741c87ee0149c28, []int{20}
 }
 func (m *PlumTransfer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlumTransfer.Unmarshal(m, b)
@@ -1102,6 +1226,8 @@ type ActionCore struct {
 	//	*ActionCore_PutBlock
 	//	*ActionCore_CreateDeposit
 	//	*ActionCore_SettleDeposit
+	//	*ActionCore_CreateWithdraw
+	//	*ActionCore_SettleWithdraw
 	//	*ActionCore_CreatePlumChain
 	//	*ActionCore_TerminatePlumChain
 	//	*ActionCore_PlumPutBlock
@@ -1116,6 +1242,14 @@ type ActionCore struct {
 	//	*ActionCore_ClaimFromRewardingFund
 	//	*ActionCore_SetReward
 	//	*ActionCore_GrantReward
+	//	*ActionCore_UpdateAllowlist
+	//	*ActionCore_SetConsensusParams
+	//	*ActionCore_DoubleSignEvidence
+	//	*ActionCore_SessionEnvelope
+	//	*ActionCore_PauseActions
+	//	*ActionCore_DkgMessage
+	//	*ActionCore_RegisterBLSKey
+	//	*ActionCore_SetRewardingAdmin
 	Action               isActionCore_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
@@ -1126,7 +1260,7 @@ func (m *ActionCore) Reset()         { *m = ActionCore{} }
 func (m *ActionCore) String() string { return proto.CompactTextString(m) }
 func (*ActionCore) ProtoMessage()    {}
 func (*ActionCore) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{19}
+	return fileDescriptor_action_0741c87ee0149c28, []int{21}
 }
 func (m *ActionCore) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActionCore.Unmarshal(m, b)
@@ -1210,6 +1344,14 @@ type ActionCore_SettleDeposit struct {
 	SettleDeposit *SettleDeposit `protobuf:"bytes,17,opt,name=settleDeposit,proto3,oneof"`
 }
 
+type ActionCore_CreateWithdraw struct {
+	CreateWithdraw *CreateWithdraw `protobuf:"bytes,34,opt,name=createWithdraw,proto3,oneof"`
+}
+
+type ActionCore_SettleWithdraw struct {
+	SettleWithdraw *SettleWithdraw `protobuf:"bytes,35,opt,name=settleWithdraw,proto3,oneof"`
+}
+
 type ActionCore_CreatePlumChain struct {
 	CreatePlumChain *CreatePlumChain `protobuf:"bytes,18,opt,name=createPlumChain,proto3,oneof"`
 }
@@ -1266,6 +1408,38 @@ type ActionCore_GrantReward struct {
 	GrantReward *GrantReward `protobuf:"bytes,33,opt,name=grantReward,proto3,oneof"`
 }
 
+type ActionCore_UpdateAllowlist struct {
+	UpdateAllowlist *UpdateAllowlist `protobuf:"bytes,40,opt,name=updateAllowlist,proto3,oneof"`
+}
+
+type ActionCore_SetConsensusParams struct {
+	SetConsensusParams *SetConsensusParams `protobuf:"bytes,41,opt,name=setConsensusParams,proto3,oneof"`
+}
+
+type ActionCore_DoubleSignEvidence struct {
+	DoubleSignEvidence *DoubleSignEvidence `protobuf:"bytes,42,opt,name=doubleSignEvidence,proto3,oneof"`
+}
+
+type ActionCore_SessionEnvelope struct {
+	SessionEnvelope *SessionEnvelope `protobuf:"bytes,43,opt,name=sessionEnvelope,proto3,oneof"`
+}
+
+type ActionCore_PauseActions struct {
+	PauseActions *PauseActions `protobuf:"bytes,44,opt,name=pauseActions,proto3,oneof"`
+}
+
+type ActionCore_DkgMessage struct {
+	DkgMessage *DKGMessage `protobuf:"bytes,45,opt,name=dkgMessage,proto3,oneof"`
+}
+
+type ActionCore_RegisterBLSKey struct {
+	RegisterBLSKey *RegisterBLSKey `protobuf:"bytes,46,opt,name=registerBLSKey,proto3,oneof"`
+}
+
+type ActionCore_SetRewardingAdmin struct {
+	SetRewardingAdmin *SetRewardingAdmin `protobuf:"bytes,36,opt,name=setRewardingAdmin,proto3,oneof"`
+}
+
 func (*ActionCore_Transfer) isActionCore_Action() {}
 
 func (*ActionCore_Vote) isActionCore_Action() {}
@@ -1282,6 +1456,10 @@ func (*ActionCore_CreateDeposit) isActionCore_Action() {}
 
 func (*ActionCore_SettleDeposit) isActionCore_Action() {}
 
+func (*ActionCore_CreateWithdraw) isActionCore_Action() {}
+
+func (*ActionCore_SettleWithdraw) isActionCore_Action() {}
+
 func (*ActionCore_CreatePlumChain) isActionCore_Action() {}
 
 func (*ActionCore_TerminatePlumChain) isActionCore_Action() {}
@@ -1310,6 +1488,22 @@ func (*ActionCore_SetReward) isActionCore_Action() {}
 
 func (*ActionCore_GrantReward) isActionCore_Action() {}
 
+func (*ActionCore_UpdateAllowlist) isActionCore_Action() {}
+
+func (*ActionCore_SetConsensusParams) isActionCore_Action() {}
+
+func (*ActionCore_DoubleSignEvidence) isActionCore_Action() {}
+
+func (*ActionCore_SessionEnvelope) isActionCore_Action() {}
+
+func (*ActionCore_PauseActions) isActionCore_Action() {}
+
+func (*ActionCore_DkgMessage) isActionCore_Action() {}
+
+func (*ActionCore_RegisterBLSKey) isActionCore_Action() {}
+
+func (*ActionCore_SetRewardingAdmin) isActionCore_Action() {}
+
 func (m *ActionCore) GetAction() isActionCore_Action {
 	if m != nil {
 		return m.Action
@@ -1373,6 +1567,20 @@ func (m *ActionCore) GetSettleDeposit() *SettleDeposit {
 	return nil
 }
 
+func (m *ActionCore) GetCreateWithdraw() *CreateWithdraw {
+	if x, ok := m.GetAction().(*ActionCore_CreateWithdraw); ok {
+		return x.CreateWithdraw
+	}
+	return nil
+}
+
+func (m *ActionCore) GetSettleWithdraw() *SettleWithdraw {
+	if x, ok := m.GetAction().(*ActionCore_SettleWithdraw); ok {
+		return x.SettleWithdraw
+	}
+	return nil
+}
+
 func (m *ActionCore) GetCreatePlumChain() *CreatePlumChain {
 	if x, ok := m.GetAction().(*ActionCore_CreatePlumChain); ok {
 		return x.CreatePlumChain
@@ -1471,6 +1679,62 @@ func (m *ActionCore) GetGrantReward() *GrantReward {
 	return nil
 }
 
+func (m *ActionCore) GetUpdateAllowlist() *UpdateAllowlist {
+	if x, ok := m.GetAction().(*ActionCore_UpdateAllowlist); ok {
+		return x.UpdateAllowlist
+	}
+	return nil
+}
+
+func (m *ActionCore) GetSetConsensusParams() *SetConsensusParams {
+	if x, ok := m.GetAction().(*ActionCore_SetConsensusParams); ok {
+		return x.SetConsensusParams
+	}
+	return nil
+}
+
+func (m *ActionCore) GetDoubleSignEvidence() *DoubleSignEvidence {
+	if x, ok := m.GetAction().(*ActionCore_DoubleSignEvidence); ok {
+		return x.DoubleSignEvidence
+	}
+	return nil
+}
+
+func (m *ActionCore) GetSessionEnvelope() *SessionEnvelope {
+	if x, ok := m.GetAction().(*ActionCore_SessionEnvelope); ok {
+		return x.SessionEnvelope
+	}
+	return nil
+}
+
+func (m *ActionCore) GetPauseActions() *PauseActions {
+	if x, ok := m.GetAction().(*ActionCore_PauseActions); ok {
+		return x.PauseActions
+	}
+	return nil
+}
+
+func (m *ActionCore) GetDkgMessage() *DKGMessage {
+	if x, ok := m.GetAction().(*ActionCore_DkgMessage); ok {
+		return x.DkgMessage
+	}
+	return nil
+}
+
+func (m *ActionCore) GetRegisterBLSKey() *RegisterBLSKey {
+	if x, ok := m.GetAction().(*ActionCore_RegisterBLSKey); ok {
+		return x.RegisterBLSKey
+	}
+	return nil
+}
+
+func (m *ActionCore) GetSetRewardingAdmin() *SetRewardingAdmin {
+	if x, ok := m.GetAction().(*ActionCore_SetRewardingAdmin); ok {
+		return x.SetRewardingAdmin
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ActionCore) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _ActionCore_OneofMarshaler, _ActionCore_OneofUnmarshaler, _ActionCore_OneofSizer, []interface{}{
@@ -1482,6 +1746,8 @@ func (*ActionCore) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) er
 		(*ActionCore_PutBlock)(nil),
 		(*ActionCore_CreateDeposit)(nil),
 		(*ActionCore_SettleDeposit)(nil),
+		(*ActionCore_CreateWithdraw)(nil),
+		(*ActionCore_SettleWithdraw)(nil),
 		(*ActionCore_CreatePlumChain)(nil),
 		(*ActionCore_TerminatePlumChain)(nil),
 		(*ActionCore_PlumPutBlock)(nil),
@@ -1496,6 +1762,14 @@ func (*ActionCore) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) er
 		(*ActionCore_ClaimFromRewardingFund)(nil),
 		(*ActionCore_SetReward)(nil),
 		(*ActionCore_GrantReward)(nil),
+		(*ActionCore_UpdateAllowlist)(nil),
+		(*ActionCore_SetConsensusParams)(nil),
+		(*ActionCore_DoubleSignEvidence)(nil),
+		(*ActionCore_SessionEnvelope)(nil),
+		(*ActionCore_PauseActions)(nil),
+		(*ActionCore_DkgMessage)(nil),
+		(*ActionCore_RegisterBLSKey)(nil),
+		(*ActionCore_SetRewardingAdmin)(nil),
 	}
 }
 
@@ -1543,6 +1817,16 @@ func _ActionCore_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.SettleDeposit); err != nil {
 			return err
 		}
+	case *ActionCore_CreateWithdraw:
+		b.EncodeVarint(34<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CreateWithdraw); err != nil {
+			return err
+		}
+	case *ActionCore_SettleWithdraw:
+		b.EncodeVarint(35<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SettleWithdraw); err != nil {
+			return err
+		}
 	case *ActionCore_CreatePlumChain:
 		b.EncodeVarint(18<<3 | proto.WireBytes)
 		if err := b.EncodeMessage(x.CreatePlumChain); err != nil {
@@ -1613,6 +1897,46 @@ func _ActionCore_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.GrantReward); err != nil {
 			return err
 		}
+	case *ActionCore_UpdateAllowlist:
+		b.EncodeVarint(40<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.UpdateAllowlist); err != nil {
+			return err
+		}
+	case *ActionCore_SetConsensusParams:
+		b.EncodeVarint(41<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SetConsensusParams); err != nil {
+			return err
+		}
+	case *ActionCore_DoubleSignEvidence:
+		b.EncodeVarint(42<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DoubleSignEvidence); err != nil {
+			return err
+		}
+	case *ActionCore_SessionEnvelope:
+		b.EncodeVarint(43<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SessionEnvelope); err != nil {
+			return err
+		}
+	case *ActionCore_PauseActions:
+		b.EncodeVarint(44<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PauseActions); err != nil {
+			return err
+		}
+	case *ActionCore_DkgMessage:
+		b.EncodeVarint(45<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DkgMessage); err != nil {
+			return err
+		}
+	case *ActionCore_RegisterBLSKey:
+		b.EncodeVarint(46<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RegisterBLSKey); err != nil {
+			return err
+		}
+	case *ActionCore_SetRewardingAdmin:
+		b.EncodeVarint(36<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SetRewardingAdmin); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ActionCore.Action has unexpected type %T", x)
@@ -1687,6 +2011,22 @@ func _ActionCore_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buf
 		err := b.DecodeMessage(msg)
 		m.Action = &ActionCore_SettleDeposit{msg}
 		return true, err
+	case 34: // action.createWithdraw
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CreateWithdraw)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_CreateWithdraw{msg}
+		return true, err
+	case 35: // action.settleWithdraw
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SettleWithdraw)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_SettleWithdraw{msg}
+		return true, err
 	case 18: // action.createPlumChain
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
@@ -1799,6 +2139,70 @@ func _ActionCore_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buf
 		err := b.DecodeMessage(msg)
 		m.Action = &ActionCore_GrantReward{msg}
 		return true, err
+	case 40: // action.updateAllowlist
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(UpdateAllowlist)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_UpdateAllowlist{msg}
+		return true, err
+	case 41: // action.setConsensusParams
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SetConsensusParams)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_SetConsensusParams{msg}
+		return true, err
+	case 42: // action.doubleSignEvidence
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DoubleSignEvidence)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_DoubleSignEvidence{msg}
+		return true, err
+	case 43: // action.sessionEnvelope
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SessionEnvelope)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_SessionEnvelope{msg}
+		return true, err
+	case 44: // action.pauseActions
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PauseActions)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_PauseActions{msg}
+		return true, err
+	case 45: // action.dkgMessage
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DKGMessage)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_DkgMessage{msg}
+		return true, err
+	case 46: // action.registerBLSKey
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RegisterBLSKey)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_RegisterBLSKey{msg}
+		return true, err
+	case 36: // action.setRewardingAdmin
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SetRewardingAdmin)
+		err := b.DecodeMessage(msg)
+		m.Action = &ActionCore_SetRewardingAdmin{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1848,6 +2252,16 @@ func _ActionCore_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ActionCore_CreateWithdraw:
+		s := proto.Size(x.CreateWithdraw)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_SettleWithdraw:
+		s := proto.Size(x.SettleWithdraw)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case *ActionCore_CreatePlumChain:
 		s := proto.Size(x.CreatePlumChain)
 		n += 2 // tag and wire
@@ -1918,6 +2332,46 @@ func _ActionCore_OneofSizer(msg proto.Message) (n int) {
 		n += 2 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ActionCore_UpdateAllowlist:
+		s := proto.Size(x.UpdateAllowlist)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_SetConsensusParams:
+		s := proto.Size(x.SetConsensusParams)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_DoubleSignEvidence:
+		s := proto.Size(x.DoubleSignEvidence)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_SessionEnvelope:
+		s := proto.Size(x.SessionEnvelope)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_PauseActions:
+		s := proto.Size(x.PauseActions)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_DkgMessage:
+		s := proto.Size(x.DkgMessage)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_RegisterBLSKey:
+		s := proto.Size(x.RegisterBLSKey)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ActionCore_SetRewardingAdmin:
+		s := proto.Size(x.SetRewardingAdmin)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1938,7 +2392,7 @@ func (m *Action) Reset()         { *m = Action{} }
 func (m *Action) String() string { return proto.CompactTextString(m) }
 func (*Action) ProtoMessage()    {}
 func (*Action) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{20}
+	return fileDescriptor_action_0741c87ee0149c28, []int{22}
 }
 func (m *Action) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Action.Unmarshal(m, b)
@@ -1995,7 +2449,7 @@ func (m *Receipt) Reset()         { *m = Receipt{} }
 func (m *Receipt) String() string { return proto.CompactTextString(m) }
 func (*Receipt) ProtoMessage()    {}
 func (*Receipt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{21}
+	return fileDescriptor_action_0741c87ee0149c28, []int{23}
 }
 func (m *Receipt) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Receipt.Unmarshal(m, b)
@@ -2073,7 +2527,7 @@ func (m *Log) Reset()         { *m = Log{} }
 func (m *Log) String() string { return proto.CompactTextString(m) }
 func (*Log) ProtoMessage()    {}
 func (*Log) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{22}
+	return fileDescriptor_action_0741c87ee0149c28, []int{24}
 }
 func (m *Log) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Log.Unmarshal(m, b)
@@ -2147,7 +2601,7 @@ func (m *DepositToRewardingFund) Reset()         { *m = DepositToRewardingFund{}
 func (m *DepositToRewardingFund) String() string { return proto.CompactTextString(m) }
 func (*DepositToRewardingFund) ProtoMessage()    {}
 func (*DepositToRewardingFund) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{23}
+	return fileDescriptor_action_0741c87ee0149c28, []int{25}
 }
 func (m *DepositToRewardingFund) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DepositToRewardingFund.Unmarshal(m, b)
@@ -2184,6 +2638,7 @@ func (m *DepositToRewardingFund) GetData() []byte {
 type ClaimFromRewardingFund struct {
 	Amount               []byte   `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Recipient            string   `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2193,7 +2648,7 @@ func (m *ClaimFromRewardingFund) Reset()         { *m = ClaimFromRewardingFund{}
 func (m *ClaimFromRewardingFund) String() string { return proto.CompactTextString(m) }
 func (*ClaimFromRewardingFund) ProtoMessage()    {}
 func (*ClaimFromRewardingFund) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{24}
+	return fileDescriptor_action_0741c87ee0149c28, []int{26}
 }
 func (m *ClaimFromRewardingFund) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ClaimFromRewardingFund.Unmarshal(m, b)
@@ -2227,6 +2682,13 @@ func (m *ClaimFromRewardingFund) GetData() []byte {
 	return nil
 }
 
+func (m *ClaimFromRewardingFund) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
 type SetReward struct {
 	Amount               []byte     `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
 	Data                 []byte     `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2240,7 +2702,7 @@ func (m *SetReward) Reset()         { *m = SetReward{} }
 func (m *SetReward) String() string { return proto.CompactTextString(m) }
 func (*SetReward) ProtoMessage()    {}
 func (*SetReward) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{25}
+	return fileDescriptor_action_0741c87ee0149c28, []int{27}
 }
 func (m *SetReward) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetReward.Unmarshal(m, b)
@@ -2292,7 +2754,7 @@ func (m *GrantReward) Reset()         { *m = GrantReward{} }
 func (m *GrantReward) String() string { return proto.CompactTextString(m) }
 func (*GrantReward) ProtoMessage()    {}
 func (*GrantReward) Descriptor() ([]byte, []int) {
-	return fileDescriptor_action_f9357b0377ca58dc, []int{26}
+	return fileDescriptor_action_0741c87ee0149c28, []int{28}
 }
 func (m *GrantReward) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GrantReward.Unmarshal(m, b)
@@ -2319,6 +2781,573 @@ func (m *GrantReward) GetType() RewardType {
 	return RewardType_BlockReward
 }
 
+type UpdateAllowlist struct {
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Remove               bool     `protobuf:"varint,2,opt,name=remove,proto3" json:"remove,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateAllowlist) Reset()         { *m = UpdateAllowlist{} }
+func (m *UpdateAllowlist) String() string { return proto.CompactTextString(m) }
+func (*UpdateAllowlist) ProtoMessage()    {}
+func (*UpdateAllowlist) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{29}
+}
+func (m *UpdateAllowlist) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAllowlist.Unmarshal(m, b)
+}
+func (m *UpdateAllowlist) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAllowlist.Marshal(b, m, deterministic)
+}
+func (dst *UpdateAllowlist) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAllowlist.Merge(dst, src)
+}
+func (m *UpdateAllowlist) XXX_Size() int {
+	return xxx_messageInfo_UpdateAllowlist.Size(m)
+}
+func (m *UpdateAllowlist) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAllowlist.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAllowlist proto.InternalMessageInfo
+
+func (m *UpdateAllowlist) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *UpdateAllowlist) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
+}
+
+// SetConsensusParams sets the roll-DPoS timing parameters taking effect from the next epoch. The durations are in
+// milliseconds
+type SetConsensusParams struct {
+	BlockInterval                uint64   `protobuf:"varint,1,opt,name=blockInterval,proto3" json:"blockInterval,omitempty"`
+	AcceptBlockTTL               uint64   `protobuf:"varint,2,opt,name=acceptBlockTTL,proto3" json:"acceptBlockTTL,omitempty"`
+	AcceptProposalEndorsementTTL uint64   `protobuf:"varint,3,opt,name=acceptProposalEndorsementTTL,proto3" json:"acceptProposalEndorsementTTL,omitempty"`
+	AcceptLockEndorsementTTL     uint64   `protobuf:"varint,4,opt,name=acceptLockEndorsementTTL,proto3" json:"acceptLockEndorsementTTL,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *SetConsensusParams) Reset()         { *m = SetConsensusParams{} }
+func (m *SetConsensusParams) String() string { return proto.CompactTextString(m) }
+func (*SetConsensusParams) ProtoMessage()    {}
+func (*SetConsensusParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{30}
+}
+func (m *SetConsensusParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetConsensusParams.Unmarshal(m, b)
+}
+func (m *SetConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetConsensusParams.Marshal(b, m, deterministic)
+}
+func (dst *SetConsensusParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetConsensusParams.Merge(dst, src)
+}
+func (m *SetConsensusParams) XXX_Size() int {
+	return xxx_messageInfo_SetConsensusParams.Size(m)
+}
+func (m *SetConsensusParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetConsensusParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetConsensusParams proto.InternalMessageInfo
+
+func (m *SetConsensusParams) GetBlockInterval() uint64 {
+	if m != nil {
+		return m.BlockInterval
+	}
+	return 0
+}
+
+func (m *SetConsensusParams) GetAcceptBlockTTL() uint64 {
+	if m != nil {
+		return m.AcceptBlockTTL
+	}
+	return 0
+}
+
+func (m *SetConsensusParams) GetAcceptProposalEndorsementTTL() uint64 {
+	if m != nil {
+		return m.AcceptProposalEndorsementTTL
+	}
+	return 0
+}
+
+func (m *SetConsensusParams) GetAcceptLockEndorsementTTL() uint64 {
+	if m != nil {
+		return m.AcceptLockEndorsementTTL
+	}
+	return 0
+}
+
+// DoubleSignEvidence proves that a delegate endorsed two different blocks at the same height, round and topic
+type DoubleSignEvidence struct {
+	First                *Endorsement `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *Endorsement `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DoubleSignEvidence) Reset()         { *m = DoubleSignEvidence{} }
+func (m *DoubleSignEvidence) String() string { return proto.CompactTextString(m) }
+func (*DoubleSignEvidence) ProtoMessage()    {}
+func (*DoubleSignEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{31}
+}
+func (m *DoubleSignEvidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DoubleSignEvidence.Unmarshal(m, b)
+}
+func (m *DoubleSignEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DoubleSignEvidence.Marshal(b, m, deterministic)
+}
+func (dst *DoubleSignEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoubleSignEvidence.Merge(dst, src)
+}
+func (m *DoubleSignEvidence) XXX_Size() int {
+	return xxx_messageInfo_DoubleSignEvidence.Size(m)
+}
+func (m *DoubleSignEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoubleSignEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DoubleSignEvidence proto.InternalMessageInfo
+
+func (m *DoubleSignEvidence) GetFirst() *Endorsement {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *DoubleSignEvidence) GetSecond() *Endorsement {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
+// MicroAction is a payload signed by a device, whose nonce is counted per device by the session protocol. The
+// signature covers the other fields
+type MicroAction struct {
+	Nonce                uint64   `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	SenderPubKey         []byte   `protobuf:"bytes,3,opt,name=senderPubKey,proto3" json:"senderPubKey,omitempty"`
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	ChainID              uint32   `protobuf:"varint,5,opt,name=chainID,proto3" json:"chainID,omitempty"`
+	Gateway              string   `protobuf:"bytes,6,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MicroAction) Reset()         { *m = MicroAction{} }
+func (m *MicroAction) String() string { return proto.CompactTextString(m) }
+func (*MicroAction) ProtoMessage()    {}
+func (*MicroAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{32}
+}
+func (m *MicroAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MicroAction.Unmarshal(m, b)
+}
+func (m *MicroAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MicroAction.Marshal(b, m, deterministic)
+}
+func (dst *MicroAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MicroAction.Merge(dst, src)
+}
+func (m *MicroAction) XXX_Size() int {
+	return xxx_messageInfo_MicroAction.Size(m)
+}
+func (m *MicroAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_MicroAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MicroAction proto.InternalMessageInfo
+
+func (m *MicroAction) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *MicroAction) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *MicroAction) GetSenderPubKey() []byte {
+	if m != nil {
+		return m.SenderPubKey
+	}
+	return nil
+}
+
+func (m *MicroAction) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *MicroAction) GetChainID() uint32 {
+	if m != nil {
+		return m.ChainID
+	}
+	return 0
+}
+
+func (m *MicroAction) GetGateway() string {
+	if m != nil {
+		return m.Gateway
+	}
+	return ""
+}
+
+// SessionEnvelope batches the micro actions of many devices into one action, whose fee is paid by the gateway
+// sending it
+type SessionEnvelope struct {
+	MicroActions         []*MicroAction `protobuf:"bytes,1,rep,name=microActions,proto3" json:"microActions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SessionEnvelope) Reset()         { *m = SessionEnvelope{} }
+func (m *SessionEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SessionEnvelope) ProtoMessage()    {}
+func (*SessionEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{33}
+}
+func (m *SessionEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionEnvelope.Unmarshal(m, b)
+}
+func (m *SessionEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionEnvelope.Marshal(b, m, deterministic)
+}
+func (dst *SessionEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionEnvelope.Merge(dst, src)
+}
+func (m *SessionEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SessionEnvelope.Size(m)
+}
+func (m *SessionEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionEnvelope proto.InternalMessageInfo
+
+func (m *SessionEnvelope) GetMicroActions() []*MicroAction {
+	if m != nil {
+		return m.MicroActions
+	}
+	return nil
+}
+
+// PauseActions pauses the actions of the types from the start height for the duration in number of blocks. A zero
+// duration lifts the pause
+type PauseActions struct {
+	ActionTypes          []string `protobuf:"bytes,1,rep,name=actionTypes,proto3" json:"actionTypes,omitempty"`
+	StartHeight          uint64   `protobuf:"varint,2,opt,name=startHeight,proto3" json:"startHeight,omitempty"`
+	Duration             uint64   `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseActions) Reset()         { *m = PauseActions{} }
+func (m *PauseActions) String() string { return proto.CompactTextString(m) }
+func (*PauseActions) ProtoMessage()    {}
+func (*PauseActions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{34}
+}
+func (m *PauseActions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseActions.Unmarshal(m, b)
+}
+func (m *PauseActions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseActions.Marshal(b, m, deterministic)
+}
+func (dst *PauseActions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseActions.Merge(dst, src)
+}
+func (m *PauseActions) XXX_Size() int {
+	return xxx_messageInfo_PauseActions.Size(m)
+}
+func (m *PauseActions) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseActions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseActions proto.InternalMessageInfo
+
+func (m *PauseActions) GetActionTypes() []string {
+	if m != nil {
+		return m.ActionTypes
+	}
+	return nil
+}
+
+func (m *PauseActions) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *PauseActions) GetDuration() uint64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+// SetRewardingAdmin replaces the single rewarding protocol admin if admin is set, otherwise the multisig admins and
+// the number of approvals required
+type SetRewardingAdmin struct {
+	Admin                string   `protobuf:"bytes,1,opt,name=admin,proto3" json:"admin,omitempty"`
+	MultisigAdmins       []string `protobuf:"bytes,2,rep,name=multisigAdmins,proto3" json:"multisigAdmins,omitempty"`
+	MultisigThreshold    uint64   `protobuf:"varint,3,opt,name=multisigThreshold,proto3" json:"multisigThreshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetRewardingAdmin) Reset()         { *m = SetRewardingAdmin{} }
+func (m *SetRewardingAdmin) String() string { return proto.CompactTextString(m) }
+func (*SetRewardingAdmin) ProtoMessage()    {}
+func (*SetRewardingAdmin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{35}
+}
+func (m *SetRewardingAdmin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRewardingAdmin.Unmarshal(m, b)
+}
+func (m *SetRewardingAdmin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRewardingAdmin.Marshal(b, m, deterministic)
+}
+func (dst *SetRewardingAdmin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRewardingAdmin.Merge(dst, src)
+}
+func (m *SetRewardingAdmin) XXX_Size() int {
+	return xxx_messageInfo_SetRewardingAdmin.Size(m)
+}
+func (m *SetRewardingAdmin) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRewardingAdmin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRewardingAdmin proto.InternalMessageInfo
+
+func (m *SetRewardingAdmin) GetAdmin() string {
+	if m != nil {
+		return m.Admin
+	}
+	return ""
+}
+
+func (m *SetRewardingAdmin) GetMultisigAdmins() []string {
+	if m != nil {
+		return m.MultisigAdmins
+	}
+	return nil
+}
+
+func (m *SetRewardingAdmin) GetMultisigThreshold() uint64 {
+	if m != nil {
+		return m.MultisigThreshold
+	}
+	return 0
+}
+
+type DKGShare struct {
+	Recipient            string   `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Share                []byte   `protobuf:"bytes,2,opt,name=share,proto3" json:"share,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DKGShare) Reset()         { *m = DKGShare{} }
+func (m *DKGShare) String() string { return proto.CompactTextString(m) }
+func (*DKGShare) ProtoMessage()    {}
+func (*DKGShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{36}
+}
+func (m *DKGShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DKGShare.Unmarshal(m, b)
+}
+func (m *DKGShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DKGShare.Marshal(b, m, deterministic)
+}
+func (dst *DKGShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DKGShare.Merge(dst, src)
+}
+func (m *DKGShare) XXX_Size() int {
+	return xxx_messageInfo_DKGShare.Size(m)
+}
+func (m *DKGShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_DKGShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DKGShare proto.InternalMessageInfo
+
+func (m *DKGShare) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
+func (m *DKGShare) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+type DKGMessage struct {
+	Epoch                uint64      `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Kind                 uint32      `protobuf:"varint,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	EncryptionKey        []byte      `protobuf:"bytes,3,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
+	Commitments          [][]byte    `protobuf:"bytes,4,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	Shares               []*DKGShare `protobuf:"bytes,5,rep,name=shares,proto3" json:"shares,omitempty"`
+	Accused              []string    `protobuf:"bytes,6,rep,name=accused,proto3" json:"accused,omitempty"`
+	SignatureShare       []byte      `protobuf:"bytes,7,opt,name=signatureShare,proto3" json:"signatureShare,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DKGMessage) Reset()         { *m = DKGMessage{} }
+func (m *DKGMessage) String() string { return proto.CompactTextString(m) }
+func (*DKGMessage) ProtoMessage()    {}
+func (*DKGMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{37}
+}
+func (m *DKGMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DKGMessage.Unmarshal(m, b)
+}
+func (m *DKGMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DKGMessage.Marshal(b, m, deterministic)
+}
+func (dst *DKGMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DKGMessage.Merge(dst, src)
+}
+func (m *DKGMessage) XXX_Size() int {
+	return xxx_messageInfo_DKGMessage.Size(m)
+}
+func (m *DKGMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DKGMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DKGMessage proto.InternalMessageInfo
+
+func (m *DKGMessage) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *DKGMessage) GetKind() uint32 {
+	if m != nil {
+		return m.Kind
+	}
+	return 0
+}
+
+func (m *DKGMessage) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
+func (m *DKGMessage) GetCommitments() [][]byte {
+	if m != nil {
+		return m.Commitments
+	}
+	return nil
+}
+
+func (m *DKGMessage) GetShares() []*DKGShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+func (m *DKGMessage) GetAccused() []string {
+	if m != nil {
+		return m.Accused
+	}
+	return nil
+}
+
+func (m *DKGMessage) GetSignatureShare() []byte {
+	if m != nil {
+		return m.SignatureShare
+	}
+	return nil
+}
+
+type RegisterBLSKey struct {
+	PublicKey            []byte   `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Proof                []byte   `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterBLSKey) Reset()         { *m = RegisterBLSKey{} }
+func (m *RegisterBLSKey) String() string { return proto.CompactTextString(m) }
+func (*RegisterBLSKey) ProtoMessage()    {}
+func (*RegisterBLSKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_action_0741c87ee0149c28, []int{38}
+}
+func (m *RegisterBLSKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterBLSKey.Unmarshal(m, b)
+}
+func (m *RegisterBLSKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterBLSKey.Marshal(b, m, deterministic)
+}
+func (dst *RegisterBLSKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterBLSKey.Merge(dst, src)
+}
+func (m *RegisterBLSKey) XXX_Size() int {
+	return xxx_messageInfo_RegisterBLSKey.Size(m)
+}
+func (m *RegisterBLSKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterBLSKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterBLSKey proto.InternalMessageInfo
+
+func (m *RegisterBLSKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *RegisterBLSKey) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Transfer)(nil), "iotextypes.Transfer")
 	proto.RegisterType((*Vote)(nil), "iotextypes.Vote")
@@ -2329,6 +3358,8 @@ func init() {
 	proto.RegisterType((*PutBlock)(nil), "iotextypes.PutBlock")
 	proto.RegisterType((*CreateDeposit)(nil), "iotextypes.CreateDeposit")
 	proto.RegisterType((*SettleDeposit)(nil), "iotextypes.SettleDeposit")
+	proto.RegisterType((*CreateWithdraw)(nil), "iotextypes.CreateWithdraw")
+	proto.RegisterType((*SettleWithdraw)(nil), "iotextypes.SettleWithdraw")
 	proto.RegisterType((*CreatePlumChain)(nil), "iotextypes.CreatePlumChain")
 	proto.RegisterType((*TerminatePlumChain)(nil), "iotextypes.TerminatePlumChain")
 	proto.RegisterType((*PlumPutBlock)(nil), "iotextypes.PlumPutBlock")
@@ -2348,112 +3379,152 @@ func init() {
 	proto.RegisterType((*ClaimFromRewardingFund)(nil), "iotextypes.ClaimFromRewardingFund")
 	proto.RegisterType((*SetReward)(nil), "iotextypes.SetReward")
 	proto.RegisterType((*GrantReward)(nil), "iotextypes.GrantReward")
+	proto.RegisterType((*UpdateAllowlist)(nil), "iotextypes.UpdateAllowlist")
+	proto.RegisterType((*SetConsensusParams)(nil), "iotextypes.SetConsensusParams")
+	proto.RegisterType((*DoubleSignEvidence)(nil), "iotextypes.DoubleSignEvidence")
+	proto.RegisterType((*MicroAction)(nil), "iotextypes.MicroAction")
+	proto.RegisterType((*SessionEnvelope)(nil), "iotextypes.SessionEnvelope")
+	proto.RegisterType((*PauseActions)(nil), "iotextypes.PauseActions")
+	proto.RegisterType((*SetRewardingAdmin)(nil), "iotextypes.SetRewardingAdmin")
+	proto.RegisterType((*DKGShare)(nil), "iotextypes.DKGShare")
+	proto.RegisterType((*DKGMessage)(nil), "iotextypes.DKGMessage")
+	proto.RegisterType((*RegisterBLSKey)(nil), "iotextypes.RegisterBLSKey")
 	proto.RegisterEnum("iotextypes.RewardType", RewardType_name, RewardType_value)
 }
 
-func init() { proto.RegisterFile("action.proto", fileDescriptor_action_f9357b0377ca58dc) }
-
-var fileDescriptor_action_f9357b0377ca58dc = []byte{
-	// 1615 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xb7, 0x1d, 0xc7, 0x8d, 0x27, 0x4e, 0xe3, 0x2c, 0xa9, 0x7b, 0x49, 0x4b, 0x1b, 0xae, 0x02,
-	0x45, 0xa1, 0x38, 0x52, 0x11, 0x55, 0x0a, 0x52, 0x45, 0xf3, 0xa7, 0x35, 0xd0, 0x42, 0xb4, 0x89,
-	0xfa, 0x50, 0x90, 0xd0, 0xe5, 0xbc, 0x71, 0x8e, 0xda, 0xb7, 0xa7, 0xbd, 0xbd, 0x34, 0xe9, 0x03,
-	0xef, 0x7c, 0x08, 0x3e, 0x08, 0x1f, 0x80, 0x57, 0x24, 0xbe, 0x0f, 0x48, 0x68, 0xff, 0xdd, 0xed,
-	0xfd, 0x71, 0xda, 0x54, 0x95, 0x78, 0xbb, 0x99, 0xfd, 0xcd, 0x9f, 0x9d, 0x9d, 0x9d, 0x99, 0x3d,
-	0xe8, 0x78, 0x3e, 0x0f, 0x68, 0xd8, 0x8f, 0x18, 0xe5, 0x14, 0x41, 0x40, 0x39, 0x39, 0xe3, 0xe7,
-	0x11, 0x89, 0x57, 0x6f, 0x8f, 0x28, 0x1d, 0x8d, 0xc9, 0xa6, 0x5c, 0x39, 0x4a, 0x8e, 0x37, 0x79,
-	0x30, 0x21, 0x31, 0xf7, 0x26, 0x91, 0x02, 0xbb, 0x2f, 0x60, 0xee, 0x90, 0x79, 0x61, 0x7c, 0x4c,
-	0x18, 0xea, 0x41, 0xcb, 0x9b, 0xd0, 0x24, 0xe4, 0x4e, 0x7d, 0xad, 0xbe, 0xde, 0xc1, 0x9a, 0x42,
-	0x37, 0xa1, 0xcd, 0x88, 0x1f, 0x44, 0x01, 0x09, 0xb9, 0xd3, 0x58, 0xab, 0xaf, 0xb7, 0x71, 0xc6,
-	0x40, 0x0e, 0x5c, 0x89, 0xbc, 0xf3, 0x31, 0xf5, 0x86, 0xce, 0x8c, 0x14, 0x33, 0xa4, 0x3b, 0x84,
-	0xe6, 0x73, 0xca, 0x09, 0xda, 0x82, 0x76, 0x6a, 0x56, 0xaa, 0x9e, 0xbf, 0xb7, 0xda, 0x57, 0x8e,
-	0xf5, 0x8d, 0x63, 0xfd, 0x43, 0x83, 0xc0, 0x19, 0x18, 0xb9, 0xd0, 0x39, 0xa5, 0x9c, 0x90, 0x47,
-	0xc3, 0x21, 0x23, 0x71, 0xac, 0x8d, 0xe7, 0x78, 0xee, 0x01, 0xb4, 0xf7, 0xce, 0x88, 0x9f, 0x88,
-	0x08, 0x4c, 0xdd, 0xc2, 0x2a, 0xcc, 0xf9, 0x34, 0xe4, 0xcc, 0xf3, 0xcd, 0x0e, 0x52, 0x1a, 0x21,
-	0x68, 0x0e, 0x3d, 0xee, 0x69, 0xef, 0xe5, 0xb7, 0xfb, 0x77, 0x1d, 0x16, 0x0e, 0xb8, 0xc7, 0xf8,
-	0x41, 0x72, 0xb4, 0x73, 0xe2, 0x05, 0xa1, 0xd8, 0xa6, 0x2f, 0x3e, 0xbe, 0xd9, 0x95, 0xaa, 0x17,
-	0xb0, 0x21, 0xd1, 0x3a, 0x2c, 0xc6, 0xc4, 0x4f, 0x58, 0xc0, 0xcf, 0x77, 0x49, 0x44, 0xe3, 0x40,
-	0x99, 0xe8, 0xe0, 0x22, 0x1b, 0x6d, 0x40, 0x97, 0x46, 0x84, 0x79, 0xc2, 0x55, 0x03, 0x55, 0x56,
-	0x4b, 0x7c, 0xb4, 0x06, 0xf3, 0xb1, 0x70, 0x60, 0x40, 0x82, 0xd1, 0x09, 0x77, 0x9a, 0x6b, 0xf5,
-	0xf5, 0x26, 0xb6, 0x59, 0xa8, 0x0f, 0x28, 0xf2, 0x18, 0x09, 0x35, 0xfd, 0xc3, 0xf1, 0x71, 0x4c,
-	0xb8, 0x33, 0x2b, 0x81, 0x15, 0x2b, 0x2e, 0x83, 0xce, 0x01, 0xa7, 0xd1, 0x5b, 0xec, 0xe8, 0x16,
-	0x40, 0xcc, 0x69, 0xa4, 0x4d, 0x37, 0xa4, 0x46, 0x8b, 0x23, 0x77, 0xac, 0xb5, 0x98, 0x93, 0x99,
-	0x91, 0x41, 0x2d, 0xb2, 0xdd, 0xfb, 0x00, 0xcf, 0x08, 0x7b, 0x39, 0x26, 0x98, 0x52, 0x19, 0xe9,
-	0xd0, 0x9b, 0x10, 0x69, 0xae, 0x8d, 0xe5, 0x37, 0x5a, 0x86, 0xd9, 0x53, 0x6f, 0x9c, 0x10, 0x1d,
-	0x33, 0x45, 0xb8, 0xaf, 0x61, 0x6e, 0x3f, 0xe1, 0xdb, 0x63, 0xea, 0xbf, 0xac, 0xb2, 0x56, 0xaf,
-	0xb4, 0x26, 0x4e, 0xff, 0xc4, 0xf6, 0x59, 0x53, 0xe8, 0x2e, 0xcc, 0x32, 0x4a, 0xb9, 0xf0, 0x72,
-	0x66, 0x7d, 0xfe, 0x5e, 0xaf, 0x9f, 0xdd, 0x90, 0x7e, 0xe6, 0x1e, 0x56, 0x20, 0xf7, 0x67, 0x58,
-	0xd8, 0x61, 0xc4, 0xe3, 0xc4, 0x1c, 0xc5, 0xf4, 0x40, 0x65, 0xe9, 0xd6, 0x98, 0x7e, 0x63, 0x66,
-	0x0a, 0x37, 0xc6, 0xfd, 0x11, 0x16, 0x0e, 0x08, 0xe7, 0xe3, 0xd4, 0xc0, 0xbb, 0x5d, 0xbc, 0x65,
-	0x98, 0x0d, 0xc2, 0x21, 0x39, 0x93, 0x06, 0x9a, 0x58, 0x11, 0xee, 0x12, 0x2c, 0x2a, 0xef, 0xf7,
-	0xc7, 0xc9, 0x44, 0x46, 0xc7, 0x7d, 0x08, 0xe8, 0x90, 0xb0, 0x49, 0x10, 0xda, 0xdc, 0xb7, 0x0f,
-	0xab, 0xfb, 0x67, 0x1d, 0x3a, 0x42, 0xee, 0x3d, 0x9e, 0xc8, 0x83, 0xfc, 0x89, 0xdc, 0xb1, 0x4f,
-	0xc4, 0x36, 0xd5, 0x17, 0x07, 0x13, 0xef, 0x85, 0x9c, 0x9d, 0xeb, 0xe3, 0x59, 0xdd, 0x02, 0xc8,
-	0x98, 0xa8, 0x0b, 0x33, 0x2f, 0xc9, 0xb9, 0x36, 0x2f, 0x3e, 0xab, 0x13, 0xea, 0xcb, 0xc6, 0x56,
-	0xdd, 0x8d, 0x61, 0x49, 0x6e, 0x3f, 0x77, 0xb8, 0x97, 0xda, 0xcb, 0x3b, 0x1c, 0xf6, 0xbf, 0x0d,
-	0x58, 0x10, 0x56, 0x65, 0x35, 0xd9, 0x3b, 0xbb, 0x94, 0xc5, 0x0d, 0xe8, 0x46, 0x8c, 0x9c, 0x06,
-	0x34, 0x89, 0x4d, 0x91, 0xd6, 0xb6, 0x4b, 0x7c, 0xf4, 0x10, 0x56, 0x8b, 0x3c, 0x19, 0xc1, 0x7d,
-	0x46, 0xe9, 0xb1, 0xae, 0x32, 0x17, 0x20, 0xd0, 0xd7, 0x70, 0xa3, 0x72, 0x35, 0x57, 0x7f, 0x2e,
-	0x82, 0x88, 0x62, 0x4d, 0xce, 0x02, 0x9e, 0x7a, 0x3a, 0x2b, 0x6d, 0xe6, 0x78, 0xe8, 0x3e, 0xf4,
-	0x6c, 0xda, 0xf2, 0xb0, 0x25, 0xd1, 0x53, 0x56, 0xd1, 0x16, 0x5c, 0x2f, 0xad, 0x68, 0xcf, 0xae,
-	0x48, 0xcf, 0xa6, 0x2d, 0xbb, 0xbf, 0x35, 0xf4, 0xa9, 0x9f, 0x78, 0xe3, 0x31, 0x09, 0x47, 0xe4,
-	0x92, 0x67, 0xd0, 0x83, 0x96, 0x4f, 0xe5, 0xdd, 0xd7, 0x19, 0xac, 0x28, 0x74, 0x17, 0x96, 0x7c,
-	0xa3, 0x32, 0xdd, 0xb2, 0x0a, 0x73, 0x79, 0x41, 0x44, 0xb7, 0xc4, 0xb4, 0x36, 0xdf, 0x94, 0x72,
-	0x17, 0x41, 0xd0, 0x36, 0xdc, 0xac, 0x5e, 0xd6, 0x61, 0x50, 0x75, 0xff, 0x42, 0x8c, 0xfb, 0x47,
-	0x03, 0x56, 0x44, 0x2c, 0x30, 0x89, 0x23, 0x1a, 0xc6, 0xe4, 0xff, 0x8d, 0xc9, 0x06, 0x74, 0x99,
-	0x76, 0x24, 0x05, 0xab, 0x40, 0x94, 0xf8, 0x22, 0xbb, 0x8b, 0x3c, 0x2b, 0x7c, 0x2a, 0xd3, 0x2e,
-	0x40, 0xbc, 0x29, 0xbb, 0x5b, 0x6f, 0xcc, 0x6e, 0xf7, 0x10, 0xba, 0x22, 0x74, 0x8f, 0x83, 0xd0,
-	0x1b, 0x07, 0xaf, 0xdf, 0x53, 0xc4, 0xdc, 0x4f, 0x55, 0x72, 0x96, 0xda, 0x81, 0x06, 0xd7, 0x73,
-	0xe0, 0x5f, 0x55, 0x19, 0xb6, 0xe7, 0xb5, 0x2a, 0x9c, 0xb8, 0x88, 0x43, 0x12, 0x52, 0x59, 0xf0,
-	0x03, 0x1a, 0xea, 0x92, 0x91, 0xe3, 0x89, 0x2a, 0x49, 0x5f, 0x85, 0xfa, 0x78, 0xda, 0x58, 0x11,
-	0xf9, 0x52, 0xd6, 0x2c, 0x96, 0xb2, 0x7f, 0x3a, 0x00, 0x8f, 0xe4, 0xa4, 0xb9, 0x43, 0x19, 0x11,
-	0x6d, 0xf1, 0x94, 0xb0, 0x58, 0x58, 0xd0, 0x6d, 0x51, 0x93, 0x42, 0x79, 0x48, 0x43, 0x9f, 0xe8,
-	0xcd, 0x2a, 0x42, 0xcc, 0x60, 0x23, 0x2f, 0x7e, 0x1a, 0x4c, 0xf4, 0xd4, 0xd3, 0xc4, 0x29, 0xad,
-	0xd7, 0xf6, 0x59, 0xe0, 0x13, 0x9d, 0x03, 0x29, 0x8d, 0xee, 0xc1, 0x1c, 0x37, 0xf9, 0x01, 0x72,
-	0x7a, 0x5c, 0xb6, 0xdb, 0x85, 0x09, 0xc7, 0xa0, 0x86, 0x53, 0x1c, 0xfa, 0x04, 0x9a, 0x62, 0x48,
-	0x74, 0xe6, 0x25, 0xbe, 0x6b, 0xe3, 0xc5, 0x48, 0x3a, 0xa8, 0x61, 0xb9, 0x8e, 0xbe, 0x80, 0x36,
-	0x31, 0xc3, 0xa3, 0xd3, 0x91, 0xe0, 0x6b, 0x36, 0x38, 0x9d, 0x2c, 0x07, 0x35, 0x9c, 0x21, 0xd1,
-	0x23, 0x58, 0x88, 0xed, 0xe9, 0xd0, 0x59, 0x90, 0xa2, 0x2b, 0xb6, 0x68, 0x6e, 0x7c, 0x1c, 0xd4,
-	0x70, 0x5e, 0x02, 0x3d, 0x84, 0x4e, 0x6c, 0x4d, 0x63, 0xce, 0x55, 0xa9, 0xc1, 0xc9, 0x6b, 0xc8,
-	0xd6, 0x07, 0x35, 0x9c, 0xc3, 0x8b, 0xa8, 0x44, 0xba, 0x49, 0x3a, 0x8b, 0xe5, 0xa8, 0x98, 0x06,
-	0x2a, 0xa2, 0x62, 0x70, 0xc2, 0x6d, 0xdf, 0x6e, 0x7e, 0x4e, 0xb7, 0xec, 0x76, 0xae, 0x3b, 0x0a,
-	0xb7, 0x73, 0x12, 0x72, 0xe7, 0x76, 0xb2, 0x3a, 0x4b, 0x15, 0x3b, 0xb7, 0x01, 0x72, 0xe7, 0xb9,
-	0xf4, 0x7e, 0x02, 0x8b, 0x7e, 0x7e, 0x42, 0x71, 0x90, 0x54, 0x72, 0xa3, 0xec, 0x47, 0x0a, 0x19,
-	0xd4, 0x70, 0x51, 0x0a, 0xed, 0x03, 0xe2, 0xa5, 0xb9, 0xc6, 0xf9, 0x40, 0xea, 0xba, 0x95, 0x4b,
-	0x91, 0x12, 0x6a, 0x50, 0xc3, 0x15, 0xb2, 0xe2, 0x50, 0x22, 0x6b, 0xfa, 0x70, 0x96, 0xcb, 0x87,
-	0x62, 0x4f, 0x27, 0xe2, 0x50, 0x6c, 0x3c, 0x7a, 0x06, 0x4b, 0x51, 0x71, 0xc2, 0x70, 0xae, 0x49,
-	0x25, 0x1f, 0x16, 0x95, 0x14, 0x03, 0x5d, 0x96, 0x14, 0xc1, 0x8e, 0xec, 0xd1, 0xc1, 0xe9, 0x95,
-	0x83, 0x9d, 0x9b, 0x2d, 0x44, 0xb0, 0x73, 0x12, 0xa9, 0x47, 0x76, 0xa5, 0x77, 0xae, 0x4f, 0xf1,
-	0xc8, 0x06, 0xa5, 0x1e, 0xe5, 0x7a, 0x04, 0x81, 0x95, 0x68, 0x5a, 0x03, 0x71, 0x1c, 0xa9, 0xf6,
-	0xe3, 0xa2, 0xda, 0x4a, 0xf0, 0xa0, 0x86, 0xa7, 0x6b, 0x42, 0xdf, 0x42, 0x37, 0x2a, 0x14, 0x5b,
-	0x67, 0x45, 0x6a, 0xbf, 0x59, 0xd4, 0x6e, 0x63, 0x06, 0x35, 0x5c, 0x92, 0x33, 0x11, 0xc8, 0x25,
-	0xa5, 0xb3, 0x5a, 0x1d, 0x81, 0x62, 0xe6, 0x96, 0x25, 0x4d, 0x8a, 0xa4, 0x1d, 0xeb, 0x46, 0x75,
-	0x8a, 0x58, 0x55, 0x29, 0x87, 0x47, 0x3f, 0x41, 0x6f, 0xa8, 0x54, 0x1d, 0x52, 0x4c, 0x5e, 0x79,
-	0x6c, 0x18, 0x84, 0xa3, 0xc7, 0x49, 0x38, 0x74, 0x6e, 0x49, 0x4d, 0xae, 0xad, 0x69, 0xb7, 0x12,
-	0x39, 0xa8, 0xe1, 0x29, 0x3a, 0x84, 0x76, 0x7f, 0xec, 0x05, 0x93, 0xc7, 0x8c, 0x4e, 0xf2, 0xda,
-	0x6f, 0x97, 0xb5, 0xef, 0x54, 0x22, 0x85, 0xf6, 0x6a, 0x1d, 0xa2, 0x5a, 0xc6, 0x84, 0x2b, 0x9e,
-	0xb3, 0x56, 0xae, 0x96, 0x07, 0x66, 0x51, 0x54, 0xcb, 0x14, 0x89, 0xbe, 0x82, 0xf9, 0x11, 0xf3,
-	0x42, 0x23, 0xf8, 0x91, 0x14, 0xbc, 0x6e, 0x0b, 0x3e, 0xc9, 0x96, 0x07, 0x35, 0x6c, 0xa3, 0xb7,
-	0xe7, 0xa0, 0xa5, 0xfe, 0x6e, 0xb8, 0xa7, 0xd0, 0x52, 0xdd, 0x07, 0x6d, 0x40, 0xd3, 0xa7, 0x8c,
-	0xe8, 0x7f, 0x09, 0xb9, 0xe7, 0x5c, 0xd6, 0x9f, 0xb0, 0xc4, 0x88, 0x66, 0x18, 0x93, 0x70, 0x48,
-	0xd8, 0x7e, 0x72, 0xf4, 0x1d, 0x39, 0x37, 0xcd, 0xd0, 0xe6, 0x89, 0xb6, 0x17, 0x07, 0xa3, 0xd0,
-	0xe3, 0x09, 0x23, 0x7a, 0x5e, 0xc9, 0x18, 0xee, 0x5f, 0x75, 0xb8, 0x82, 0x89, 0x4f, 0x82, 0x48,
-	0xbe, 0xca, 0x19, 0xe1, 0x09, 0x0b, 0x9f, 0xcb, 0x27, 0x86, 0x7a, 0xae, 0xd9, 0x2c, 0xd1, 0x94,
-	0x63, 0xee, 0xf1, 0x24, 0x36, 0x9d, 0x5e, 0x51, 0xa2, 0x5b, 0x7a, 0x3e, 0x1f, 0x78, 0xf1, 0x89,
-	0xf9, 0x4d, 0xa2, 0x49, 0xa1, 0x73, 0xe4, 0xc5, 0x3b, 0x34, 0x8c, 0x93, 0x09, 0x19, 0x9a, 0x97,
-	0xbe, 0xc5, 0x12, 0x73, 0x86, 0xf9, 0x5b, 0x61, 0xe6, 0x8c, 0x59, 0x35, 0x67, 0x14, 0xd8, 0xe8,
-	0x0e, 0x34, 0xc7, 0x74, 0x14, 0x3b, 0x2d, 0xf9, 0xac, 0x5a, 0xb4, 0x23, 0xf3, 0x94, 0x8e, 0xb0,
-	0x5c, 0x74, 0x7f, 0xaf, 0xc3, 0xcc, 0x53, 0x3a, 0x92, 0x2e, 0xe5, 0xc6, 0x16, 0x43, 0x8a, 0x4d,
-	0x70, 0x1a, 0x05, 0xbe, 0xd8, 0xc4, 0x8c, 0x78, 0xea, 0x28, 0xaa, 0xea, 0x57, 0x89, 0x70, 0xff,
-	0x48, 0x14, 0xbf, 0xef, 0x93, 0xc9, 0x91, 0x9e, 0xe0, 0x9a, 0xd8, 0x66, 0x09, 0x3b, 0xfc, 0x2c,
-	0x94, 0x5b, 0x57, 0x93, 0x9a, 0x21, 0xb3, 0x27, 0x6c, 0x4b, 0x0e, 0x10, 0xfa, 0x09, 0xbb, 0x0b,
-	0xbd, 0xea, 0xc4, 0x9f, 0xfa, 0x50, 0x36, 0x7e, 0x35, 0xac, 0x5f, 0x38, 0xbb, 0xd0, 0xab, 0x4e,
-	0xf0, 0x4b, 0x69, 0xf1, 0xa1, 0x9d, 0x66, 0xf5, 0x65, 0x04, 0x45, 0x8e, 0x8a, 0xb8, 0xcb, 0x50,
-	0x5d, 0xcd, 0xe7, 0xa8, 0xd2, 0x76, 0x78, 0x1e, 0x11, 0x2c, 0x31, 0xee, 0x03, 0x98, 0xb7, 0x6e,
-	0x40, 0x2a, 0x5a, 0x7f, 0xb3, 0xe8, 0x46, 0x1f, 0x20, 0xe3, 0xa1, 0x45, 0x98, 0x97, 0x8d, 0x48,
-	0xb1, 0xba, 0x35, 0xc1, 0xd8, 0x8b, 0xa8, 0x7f, 0xa2, 0x19, 0xf5, 0xed, 0xad, 0x17, 0xf7, 0x47,
-	0x01, 0x3f, 0x49, 0x8e, 0xfa, 0x3e, 0x9d, 0x6c, 0x4a, 0xcd, 0x11, 0xa3, 0xbf, 0x10, 0x9f, 0x2b,
-	0xe2, 0x33, 0x71, 0x69, 0xd4, 0xef, 0xc2, 0x11, 0x09, 0x37, 0x33, 0xd3, 0x47, 0x2d, 0xc9, 0xfc,
-	0xfc, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x88, 0xce, 0xd9, 0xd0, 0x6d, 0x14, 0x00, 0x00,
+func init() { proto.RegisterFile("action.proto", fileDescriptor_action_0741c87ee0149c28) }
+
+var fileDescriptor_action_0741c87ee0149c28 = []byte{
+	// 2088 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xef, 0x6e, 0x1c, 0x49,
+	0x11, 0xdf, 0x7f, 0xde, 0xd8, 0xe5, 0xb5, 0xbd, 0x6e, 0x72, 0xce, 0xc4, 0x09, 0x39, 0x33, 0x81,
+	0x93, 0xf1, 0xe5, 0xd6, 0x52, 0x10, 0x91, 0xef, 0x4e, 0x8a, 0x70, 0x6c, 0x27, 0x7b, 0xe0, 0x1c,
+	0xd6, 0xd8, 0x04, 0xe9, 0x40, 0x42, 0xe3, 0xd9, 0xf6, 0x7a, 0xc8, 0xec, 0xf4, 0xa8, 0xbb, 0xc7,
+	0x7f, 0xee, 0x03, 0x7c, 0xe6, 0x21, 0x78, 0x05, 0xbe, 0xf3, 0x00, 0x7c, 0x45, 0xe2, 0x15, 0x78,
+	0x0f, 0x10, 0xaa, 0xee, 0x9e, 0xd9, 0x9e, 0x3f, 0xeb, 0xc4, 0xe1, 0xa4, 0xfb, 0xb6, 0x55, 0xfd,
+	0xab, 0xea, 0xaa, 0xea, 0x9a, 0xaa, 0xea, 0x5e, 0xe8, 0xf9, 0x81, 0x0c, 0x59, 0x3c, 0x48, 0x38,
+	0x93, 0x8c, 0x40, 0xc8, 0x24, 0xbd, 0x92, 0xd7, 0x09, 0x15, 0xeb, 0xab, 0x34, 0x1e, 0x31, 0x2e,
+	0xe8, 0x84, 0xc6, 0x52, 0x2f, 0xaf, 0x7f, 0x3c, 0x66, 0x6c, 0x1c, 0xd1, 0x6d, 0x45, 0x9d, 0xa6,
+	0x67, 0xdb, 0x32, 0x9c, 0x50, 0x21, 0xfd, 0x49, 0xa2, 0x01, 0xee, 0x37, 0x30, 0x7f, 0xc2, 0xfd,
+	0x58, 0x9c, 0x51, 0x4e, 0xd6, 0xa0, 0xeb, 0x4f, 0x58, 0x1a, 0x4b, 0xa7, 0xb9, 0xd1, 0xdc, 0xec,
+	0x79, 0x86, 0x22, 0x0f, 0x61, 0x81, 0xd3, 0x20, 0x4c, 0x42, 0x1a, 0x4b, 0xa7, 0xb5, 0xd1, 0xdc,
+	0x5c, 0xf0, 0xa6, 0x0c, 0xe2, 0xc0, 0x9d, 0xc4, 0xbf, 0x8e, 0x98, 0x3f, 0x72, 0xda, 0x4a, 0x2c,
+	0x23, 0xdd, 0x11, 0x74, 0xde, 0x30, 0x49, 0xc9, 0x0e, 0x2c, 0xe4, 0xdb, 0x2a, 0xd5, 0x8b, 0x4f,
+	0xd7, 0x07, 0xda, 0xb0, 0x41, 0x66, 0xd8, 0xe0, 0x24, 0x43, 0x78, 0x53, 0x30, 0x71, 0xa1, 0x77,
+	0xc1, 0x24, 0xa5, 0xbb, 0xa3, 0x11, 0xa7, 0x42, 0x98, 0xcd, 0x0b, 0x3c, 0xf7, 0x18, 0x16, 0x0e,
+	0xae, 0x68, 0x90, 0x62, 0x50, 0x66, 0xba, 0xb0, 0x0e, 0xf3, 0x01, 0x8b, 0x25, 0xf7, 0x83, 0xcc,
+	0x83, 0x9c, 0x26, 0x04, 0x3a, 0x23, 0x5f, 0xfa, 0xc6, 0x7a, 0xf5, 0xdb, 0xfd, 0x57, 0x13, 0x96,
+	0x8e, 0xa5, 0xcf, 0xe5, 0x71, 0x7a, 0xba, 0x77, 0xee, 0x87, 0x31, 0xba, 0x19, 0xe0, 0x8f, 0xaf,
+	0xf6, 0x95, 0xea, 0x25, 0x2f, 0x23, 0xc9, 0x26, 0xac, 0x08, 0x1a, 0xa4, 0x3c, 0x94, 0xd7, 0xfb,
+	0x34, 0x61, 0x22, 0xd4, 0x5b, 0xf4, 0xbc, 0x32, 0x9b, 0x6c, 0x41, 0x9f, 0x25, 0x94, 0xfb, 0x68,
+	0x6a, 0x06, 0xd5, 0xbb, 0x56, 0xf8, 0x64, 0x03, 0x16, 0x05, 0x1a, 0x30, 0xa4, 0xe1, 0xf8, 0x5c,
+	0x3a, 0x9d, 0x8d, 0xe6, 0x66, 0xc7, 0xb3, 0x59, 0x64, 0x00, 0x24, 0xf1, 0x39, 0x8d, 0x0d, 0xfd,
+	0xeb, 0xb3, 0x33, 0x41, 0xa5, 0x33, 0xa7, 0x80, 0x35, 0x2b, 0x2e, 0x87, 0xde, 0xb1, 0x64, 0xc9,
+	0x7b, 0x78, 0xf4, 0x08, 0x40, 0x48, 0x96, 0x98, 0xad, 0x5b, 0x4a, 0xa3, 0xc5, 0x51, 0x1e, 0x1b,
+	0x2d, 0xd9, 0xc9, 0xb4, 0x55, 0x50, 0xcb, 0x6c, 0xf7, 0x19, 0xc0, 0x6b, 0xca, 0xdf, 0x46, 0xd4,
+	0x63, 0x4c, 0x45, 0x3a, 0xf6, 0x27, 0x54, 0x6d, 0xb7, 0xe0, 0xa9, 0xdf, 0xe4, 0x2e, 0xcc, 0x5d,
+	0xf8, 0x51, 0x4a, 0x4d, 0xcc, 0x34, 0xe1, 0x7e, 0x0b, 0xf3, 0x47, 0xa9, 0x7c, 0x11, 0xb1, 0xe0,
+	0x6d, 0xdd, 0x6e, 0xcd, 0xda, 0xdd, 0xf0, 0xf4, 0xcf, 0x6d, 0x9b, 0x0d, 0x45, 0x9e, 0xc0, 0x1c,
+	0x67, 0x4c, 0xa2, 0x95, 0xed, 0xcd, 0xc5, 0xa7, 0x6b, 0x83, 0xe9, 0x47, 0x33, 0x98, 0x9a, 0xe7,
+	0x69, 0x90, 0xfb, 0x07, 0x58, 0xda, 0xe3, 0xd4, 0x97, 0x34, 0x3b, 0x8a, 0xd9, 0x81, 0x9a, 0xa6,
+	0x5b, 0x6b, 0xf6, 0x17, 0xd3, 0x2e, 0x7d, 0x31, 0xee, 0xef, 0x60, 0xe9, 0x98, 0x4a, 0x19, 0xe5,
+	0x1b, 0x7c, 0xd8, 0x87, 0x77, 0x17, 0xe6, 0xc2, 0x78, 0x44, 0xaf, 0xd4, 0x06, 0x1d, 0x4f, 0x13,
+	0xee, 0x4b, 0x58, 0xd6, 0xd6, 0xff, 0x36, 0x94, 0xe7, 0x23, 0xee, 0x5f, 0x7e, 0x98, 0x76, 0xf7,
+	0x6f, 0x4d, 0x58, 0xd6, 0x56, 0xe6, 0x8a, 0xfe, 0xff, 0x83, 0x18, 0xc0, 0xfc, 0xa5, 0xd1, 0xa6,
+	0xac, 0x5e, 0x7c, 0x4a, 0xec, 0xb3, 0xd8, 0x55, 0x95, 0xcd, 0xcb, 0x31, 0x53, 0x17, 0x3b, 0x2a,
+	0xee, 0x9a, 0x40, 0x6e, 0xc2, 0x19, 0x3b, 0x73, 0xe6, 0x36, 0xda, 0x98, 0x32, 0x8a, 0x70, 0x57,
+	0x61, 0x45, 0x3b, 0x7e, 0x14, 0xa5, 0x13, 0x65, 0x8d, 0xfb, 0x1c, 0xc8, 0x09, 0xe5, 0x93, 0x30,
+	0xb6, 0xb9, 0xef, 0xef, 0x86, 0xfb, 0x8f, 0x26, 0xf4, 0x50, 0xee, 0x3b, 0x4c, 0xc5, 0xcf, 0x8b,
+	0xa9, 0xf8, 0xd8, 0x76, 0xdf, 0xde, 0x6a, 0x80, 0x19, 0x29, 0x0e, 0x62, 0xc9, 0xaf, 0x4d, 0x5e,
+	0xae, 0xef, 0x00, 0x4c, 0x99, 0xa4, 0x0f, 0xed, 0xb7, 0xf4, 0xda, 0x6c, 0x8f, 0x3f, 0xeb, 0xbf,
+	0xa4, 0x2f, 0x5a, 0x3b, 0x4d, 0x57, 0xc0, 0xaa, 0x72, 0xbf, 0x90, 0xd5, 0xb7, 0xf2, 0xe5, 0x03,
+	0xb2, 0xfc, 0x3f, 0x2d, 0x58, 0xc2, 0x5d, 0x55, 0x19, 0x3d, 0xb8, 0xba, 0xd5, 0x8e, 0x5b, 0xd0,
+	0x4f, 0x38, 0xbd, 0x08, 0x59, 0x2a, 0xb2, 0xee, 0x64, 0xf6, 0xae, 0xf0, 0xc9, 0x73, 0x58, 0x2f,
+	0xf3, 0x54, 0x04, 0x8f, 0x54, 0x8a, 0xe8, 0xf2, 0x7a, 0x03, 0x82, 0xfc, 0x02, 0x1e, 0xd4, 0xae,
+	0x16, 0x0a, 0xef, 0x4d, 0x10, 0xec, 0x52, 0xf4, 0x2a, 0x94, 0xb9, 0xa5, 0x73, 0x6a, 0xcf, 0x02,
+	0x8f, 0x3c, 0x83, 0x35, 0x9b, 0xb6, 0x2c, 0xec, 0x2a, 0xf4, 0x8c, 0x55, 0xb2, 0x03, 0xf7, 0x2a,
+	0x2b, 0xc6, 0xb2, 0x3b, 0xca, 0xb2, 0x59, 0xcb, 0xee, 0x5f, 0x5a, 0xe6, 0xd4, 0xcf, 0xfd, 0x28,
+	0xa2, 0xf1, 0x98, 0xde, 0xf2, 0x0c, 0xd6, 0xa0, 0x1b, 0x30, 0x55, 0xf4, 0x4c, 0x06, 0x6b, 0x8a,
+	0x3c, 0x81, 0xd5, 0x20, 0x53, 0x99, 0xbb, 0xac, 0xc3, 0x5c, 0x5d, 0xc0, 0xe8, 0x56, 0x98, 0x96,
+	0xf3, 0x1d, 0x25, 0x77, 0x13, 0x84, 0xbc, 0x80, 0x87, 0xf5, 0xcb, 0x26, 0x0c, 0xba, 0xe1, 0xdd,
+	0x88, 0x71, 0xff, 0xde, 0x82, 0xfb, 0x18, 0x0b, 0x8f, 0x8a, 0x84, 0xc5, 0x82, 0x7e, 0xbf, 0x31,
+	0xd9, 0x82, 0x3e, 0x37, 0x86, 0xe4, 0x60, 0x1d, 0x88, 0x0a, 0x1f, 0xb3, 0xbb, 0xcc, 0xb3, 0xc2,
+	0xa7, 0x33, 0xed, 0x06, 0xc4, 0xbb, 0xb2, 0xbb, 0xfb, 0xce, 0xec, 0x76, 0x4f, 0xa0, 0x8f, 0xa1,
+	0x7b, 0x19, 0xc6, 0x7e, 0x14, 0x7e, 0xfb, 0x1d, 0x45, 0xcc, 0xfd, 0x54, 0x27, 0x67, 0xa5, 0x0f,
+	0x1a, 0x70, 0xb3, 0x00, 0xfe, 0x93, 0x2e, 0xc3, 0xf6, 0xa0, 0x5a, 0x87, 0xc3, 0x0f, 0x71, 0x44,
+	0x63, 0xa6, 0x0a, 0x7e, 0xc8, 0x62, 0x53, 0x32, 0x0a, 0x3c, 0xac, 0x92, 0xec, 0x32, 0x36, 0xc7,
+	0xb3, 0xe0, 0x69, 0xa2, 0x58, 0xca, 0x3a, 0xe5, 0x52, 0xf6, 0xdf, 0x3e, 0x80, 0xee, 0x4d, 0x7b,
+	0x8c, 0x53, 0x9c, 0x07, 0x2e, 0x28, 0x17, 0xb8, 0x83, 0x99, 0x07, 0x0c, 0x89, 0xca, 0x63, 0x16,
+	0x07, 0xd4, 0x38, 0xab, 0x09, 0x1c, 0x3e, 0xc7, 0xbe, 0x38, 0x0c, 0x27, 0x66, 0xdc, 0xeb, 0x78,
+	0x39, 0x6d, 0xd6, 0x8e, 0x78, 0x18, 0x50, 0x93, 0x03, 0x39, 0x4d, 0x9e, 0xc2, 0xbc, 0xcc, 0xf2,
+	0x03, 0x54, 0xb7, 0xbc, 0x6b, 0xb7, 0x8b, 0x2c, 0x1c, 0xc3, 0x86, 0x97, 0xe3, 0xc8, 0x27, 0xd0,
+	0xc1, 0xe9, 0xd8, 0x59, 0x54, 0xf8, 0xbe, 0x8d, 0xc7, 0x59, 0x7c, 0xd8, 0xf0, 0xd4, 0x3a, 0xf9,
+	0x39, 0x2c, 0xd0, 0x6c, 0x6a, 0x76, 0x7a, 0x0a, 0xfc, 0x91, 0x0d, 0xce, 0x47, 0xea, 0x61, 0xc3,
+	0x9b, 0x22, 0xc9, 0x2e, 0x2c, 0x09, 0x7b, 0x2c, 0x76, 0x96, 0x94, 0xe8, 0x7d, 0x5b, 0xb4, 0x30,
+	0x37, 0x0f, 0x1b, 0x5e, 0x51, 0x82, 0x3c, 0x87, 0x9e, 0xb0, 0xc6, 0x50, 0x67, 0x59, 0x69, 0x70,
+	0x8a, 0x1a, 0xa6, 0xeb, 0xc3, 0x86, 0x57, 0xc0, 0x63, 0x54, 0x12, 0xd3, 0x24, 0x9d, 0x95, 0x6a,
+	0x54, 0xb2, 0x06, 0x8a, 0x51, 0xc9, 0x70, 0x68, 0x76, 0x60, 0x37, 0x3f, 0xa7, 0x5f, 0x35, 0xbb,
+	0xd0, 0x1d, 0xd1, 0xec, 0x82, 0x84, 0xf2, 0xdc, 0x4e, 0x56, 0x67, 0xb5, 0xc6, 0x73, 0x1b, 0xa0,
+	0x3c, 0xb7, 0x19, 0x64, 0x1f, 0x96, 0x83, 0xc2, 0x68, 0xe6, 0xb8, 0xe6, 0x32, 0x54, 0x31, 0x23,
+	0x43, 0x0c, 0x1b, 0x5e, 0x49, 0x06, 0xb5, 0x88, 0xc2, 0x5c, 0xe6, 0x3c, 0xae, 0x6a, 0x29, 0x4e,
+	0x6e, 0xa8, 0xa5, 0x28, 0x43, 0x5e, 0xc1, 0x4a, 0x50, 0x9c, 0x96, 0x1c, 0xa2, 0xd4, 0x3c, 0xa8,
+	0x1a, 0x93, 0x43, 0x86, 0x0d, 0xaf, 0x2c, 0x45, 0x8e, 0x80, 0xc8, 0xca, 0x8c, 0xe5, 0xfc, 0x40,
+	0xe9, 0x7a, 0x54, 0x48, 0xd7, 0x0a, 0x6a, 0xd8, 0xf0, 0x6a, 0x64, 0x31, 0x41, 0x12, 0x6b, 0x12,
+	0x72, 0xee, 0x56, 0x13, 0xc4, 0x9e, 0x94, 0x30, 0x41, 0x6c, 0x3c, 0x79, 0x0d, 0xab, 0x49, 0x79,
+	0xda, 0x71, 0x3e, 0x52, 0x4a, 0x7e, 0x58, 0x56, 0x52, 0x3e, 0xf4, 0xaa, 0x24, 0x1e, 0x7c, 0x62,
+	0x8f, 0x31, 0xce, 0x5a, 0xf5, 0xe0, 0x0b, 0x73, 0x0e, 0x1e, 0x7c, 0x41, 0x22, 0xb7, 0xc8, 0xee,
+	0x3a, 0xce, 0xbd, 0x19, 0x16, 0xd9, 0xa0, 0xdc, 0x22, 0x9b, 0x49, 0x28, 0xdc, 0x4f, 0x66, 0x35,
+	0x33, 0xc7, 0x51, 0x6a, 0x7f, 0x52, 0x56, 0x5b, 0x0b, 0x1e, 0x36, 0xbc, 0xd9, 0x9a, 0xc8, 0x2f,
+	0xa1, 0x9f, 0x94, 0x0a, 0xbf, 0x73, 0x5f, 0x69, 0x7f, 0x58, 0xd6, 0x6e, 0x63, 0x86, 0x0d, 0xaf,
+	0x22, 0x97, 0x45, 0xa0, 0xf0, 0x81, 0x38, 0xeb, 0xf5, 0x11, 0x28, 0x7f, 0x45, 0x55, 0xc9, 0x2c,
+	0x45, 0xf2, 0xee, 0xf9, 0xa0, 0x3e, 0x45, 0xac, 0x0a, 0x59, 0xc0, 0x93, 0xdf, 0xc3, 0xda, 0x48,
+	0xab, 0x3a, 0x61, 0x1e, 0xbd, 0xf4, 0xf9, 0x28, 0x8c, 0xc7, 0x2f, 0xd3, 0x78, 0xe4, 0x3c, 0x52,
+	0x9a, 0x5c, 0x5b, 0xd3, 0x7e, 0x2d, 0x72, 0xd8, 0xf0, 0x66, 0xe8, 0x40, 0xed, 0x41, 0xe4, 0x87,
+	0x93, 0x97, 0x9c, 0x4d, 0x8a, 0xda, 0x3f, 0xae, 0x6a, 0xdf, 0xab, 0x45, 0xa2, 0xf6, 0x7a, 0x1d,
+	0x58, 0xb9, 0x05, 0x95, 0x9a, 0xe7, 0x6c, 0x54, 0x2b, 0xf7, 0x71, 0xb6, 0x88, 0x95, 0x3b, 0x47,
+	0x92, 0x2f, 0x61, 0x71, 0xcc, 0xfd, 0x38, 0x13, 0xfc, 0x91, 0x12, 0xbc, 0x67, 0x0b, 0xbe, 0x9a,
+	0x2e, 0x0f, 0x1b, 0x9e, 0x8d, 0xc6, 0x6a, 0x91, 0x26, 0x23, 0x5f, 0xd2, 0xdd, 0x28, 0x62, 0x97,
+	0x51, 0x28, 0xa4, 0xb3, 0x59, 0xad, 0x16, 0xbf, 0x29, 0x42, 0xb0, 0x5a, 0x94, 0xa4, 0xb0, 0x5a,
+	0x08, 0x2a, 0xf7, 0x30, 0xd9, 0x62, 0x91, 0x8a, 0x23, 0x9f, 0xfb, 0x13, 0xe1, 0xfc, 0xb4, 0x5a,
+	0x2d, 0x8e, 0x2b, 0x28, 0xac, 0x16, 0x55, 0x59, 0xd4, 0x38, 0x62, 0xe9, 0x69, 0x44, 0x8f, 0xc3,
+	0x71, 0x7c, 0x70, 0x11, 0x8e, 0x28, 0xf6, 0xdf, 0xad, 0xaa, 0xc6, 0xfd, 0x0a, 0x0a, 0x35, 0x56,
+	0x65, 0xd1, 0x59, 0x41, 0x05, 0xf6, 0xf3, 0x83, 0xf8, 0x82, 0x46, 0x2c, 0xa1, 0xce, 0xa7, 0x55,
+	0x67, 0x8f, 0x8b, 0x10, 0x74, 0xb6, 0x24, 0xa5, 0xb2, 0xd4, 0x4f, 0x05, 0xd5, 0xa3, 0x83, 0x70,
+	0x9e, 0xd4, 0x64, 0xa9, 0xb5, 0xae, 0xb2, 0xd4, 0xa2, 0x5f, 0xcc, 0x43, 0x57, 0xbf, 0xf5, 0xb9,
+	0x17, 0xd0, 0xd5, 0x4c, 0xb2, 0x05, 0x9d, 0x80, 0x71, 0x6a, 0x9e, 0xd1, 0xd6, 0xaa, 0xb7, 0x67,
+	0x9c, 0x50, 0x3c, 0x85, 0xc1, 0x71, 0x48, 0xd0, 0x78, 0x44, 0xf9, 0x51, 0x7a, 0xfa, 0x2b, 0x7a,
+	0x9d, 0x8d, 0x43, 0x36, 0x0f, 0x07, 0x1f, 0x11, 0x8e, 0x63, 0x5f, 0xa6, 0x9c, 0x9a, 0x89, 0x75,
+	0xca, 0x70, 0xff, 0xd9, 0x84, 0x3b, 0x1e, 0x0d, 0x68, 0x98, 0xa8, 0x07, 0x29, 0x4e, 0x65, 0xca,
+	0xe3, 0x37, 0xea, 0x92, 0xa9, 0xdf, 0x12, 0x6c, 0x16, 0x8e, 0x65, 0x42, 0xfa, 0x32, 0x15, 0xd9,
+	0xac, 0xa7, 0x29, 0x9c, 0x97, 0xfc, 0x40, 0x0e, 0x7d, 0x71, 0x9e, 0xbd, 0x10, 0x1a, 0x12, 0x75,
+	0x8e, 0x7d, 0x81, 0x47, 0x9a, 0x4e, 0xe8, 0x28, 0x7b, 0xe4, 0xb2, 0x58, 0x38, 0x69, 0x66, 0x0f,
+	0x75, 0xd9, 0xa4, 0x39, 0xa7, 0x27, 0xcd, 0x12, 0x9b, 0x3c, 0x86, 0x4e, 0xc4, 0xc6, 0xc2, 0xe9,
+	0xaa, 0x8b, 0xf5, 0x8a, 0x1d, 0x99, 0x43, 0x36, 0xf6, 0xd4, 0xa2, 0xfb, 0xd7, 0x26, 0xb4, 0x0f,
+	0xd9, 0x58, 0x99, 0x54, 0x18, 0x5c, 0x33, 0x12, 0x9d, 0x90, 0x2c, 0x09, 0x03, 0x74, 0x02, 0x5f,
+	0x17, 0x0c, 0x55, 0xf7, 0x4a, 0x88, 0xe6, 0x9f, 0x62, 0xcb, 0xf9, 0x3a, 0x9d, 0x9c, 0x9a, 0x19,
+	0xbe, 0xe3, 0xd9, 0x2c, 0xdc, 0x47, 0x5e, 0xc5, 0xca, 0x75, 0x3d, 0xab, 0x67, 0xe4, 0xf4, 0x69,
+	0xa3, 0x6b, 0x3d, 0x6d, 0xb8, 0xfb, 0xb0, 0x56, 0x5f, 0x6e, 0x66, 0xbe, 0xe2, 0x64, 0x76, 0xb5,
+	0xac, 0xd7, 0xcb, 0x53, 0x58, 0xab, 0x2f, 0x2b, 0xb7, 0xd1, 0xf2, 0x8e, 0xeb, 0x7d, 0x00, 0x0b,
+	0x79, 0xa5, 0xb9, 0x95, 0xda, 0x2d, 0xe8, 0xe0, 0xa9, 0x28, 0x8d, 0xcb, 0xc5, 0x0c, 0xd6, 0xda,
+	0x4e, 0xae, 0x13, 0xea, 0x29, 0x8c, 0xfb, 0x39, 0x2c, 0x5a, 0x55, 0x29, 0x17, 0x6d, 0xbe, 0x87,
+	0xe8, 0x2b, 0x58, 0x29, 0xd5, 0x23, 0x74, 0xc8, 0x9c, 0x32, 0xc5, 0x63, 0x6f, 0xa3, 0x43, 0x39,
+	0x03, 0x7d, 0xe0, 0x74, 0xc2, 0x2e, 0xf4, 0xf0, 0x3e, 0xef, 0x19, 0xca, 0xfd, 0x77, 0x13, 0x48,
+	0xb5, 0x1a, 0x91, 0x1f, 0xc3, 0x92, 0x3a, 0xe8, 0xaf, 0x62, 0x49, 0xf9, 0x85, 0x1f, 0x99, 0xab,
+	0x48, 0x91, 0x49, 0x3e, 0x81, 0x65, 0x3f, 0x08, 0x68, 0xa2, 0x47, 0x93, 0x93, 0x93, 0x43, 0xf3,
+	0x69, 0x94, 0xb8, 0x78, 0xc9, 0xd5, 0x9c, 0x23, 0xce, 0x12, 0x26, 0xfc, 0xe8, 0x60, 0xfa, 0x94,
+	0x8f, 0x52, 0xfa, 0xda, 0x70, 0x23, 0x86, 0x7c, 0x01, 0x8e, 0x5e, 0x3f, 0x64, 0xc1, 0xdb, 0x92,
+	0xbc, 0x4e, 0xcd, 0x99, 0xeb, 0xae, 0x04, 0x52, 0xad, 0x8f, 0xe4, 0x33, 0x98, 0x3b, 0x0b, 0xb9,
+	0x90, 0x4e, 0xb3, 0xda, 0x2d, 0x2c, 0x05, 0x9e, 0x46, 0x91, 0x6d, 0xe8, 0x0a, 0x1a, 0xb0, 0x78,
+	0xe4, 0xb4, 0x6e, 0xc6, 0x1b, 0x98, 0xfb, 0x67, 0x58, 0x7c, 0x1d, 0x06, 0x9c, 0x99, 0xda, 0x96,
+	0xdf, 0x9e, 0x9a, 0xf6, 0xed, 0xc9, 0xfa, 0x7f, 0xa1, 0x55, 0xf8, 0x7f, 0xa1, 0x52, 0xdf, 0xda,
+	0xef, 0xaa, 0x6f, 0x9d, 0x72, 0x7d, 0xfb, 0x1a, 0x56, 0x4a, 0x75, 0x9c, 0x7c, 0x09, 0xbd, 0xc9,
+	0xd4, 0x26, 0x9d, 0x27, 0x25, 0x57, 0x2c, 0x9b, 0xbd, 0x02, 0xd8, 0x8d, 0xa1, 0x67, 0x57, 0x74,
+	0x2c, 0x10, 0xba, 0x82, 0x63, 0x62, 0x66, 0x39, 0x67, 0xb3, 0xca, 0xcf, 0xfc, 0xad, 0xea, 0x33,
+	0xff, 0x3a, 0xcc, 0x8f, 0x52, 0xfd, 0xdf, 0x40, 0x76, 0x7b, 0xcc, 0xe8, 0xad, 0x37, 0x00, 0xd3,
+	0xc4, 0x27, 0x2b, 0xb0, 0xa8, 0x12, 0x4a, 0xb3, 0xfa, 0x0d, 0x64, 0x1c, 0x24, 0x2c, 0x38, 0x37,
+	0x8c, 0x26, 0x21, 0xb0, 0x6c, 0x31, 0xf6, 0xfc, 0xa4, 0xdf, 0x42, 0xde, 0xeb, 0x30, 0x56, 0xf5,
+	0x62, 0x57, 0x7d, 0xb5, 0xfd, 0xf6, 0x8b, 0x9d, 0x6f, 0x9e, 0x8d, 0x43, 0x79, 0x9e, 0x9e, 0x0e,
+	0x02, 0x36, 0xd9, 0x56, 0xae, 0x27, 0x9c, 0xfd, 0x91, 0x06, 0x52, 0x13, 0x9f, 0x61, 0x7f, 0xd1,
+	0x7f, 0x2a, 0x8d, 0x69, 0xbc, 0x3d, 0x8d, 0xcd, 0x69, 0x57, 0x31, 0x7f, 0xf6, 0xbf, 0x01, 0x00,
+	0xb0, 0xec, 0x00, 0x46, 0xa6, 0x1a, 0x00, 0x00,
 }
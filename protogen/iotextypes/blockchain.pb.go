@@ -33,6 +33,8 @@ type BlockHeader struct {
 	Reserved             []byte               `protobuf:"bytes,10,opt,name=reserved,proto3" json:"reserved,omitempty"`
 	Signature            []byte               `protobuf:"bytes,11,opt,name=signature,proto3" json:"signature,omitempty"`
 	Pubkey               []byte               `protobuf:"bytes,12,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	ActionOrder          uint32               `protobuf:"varint,13,opt,name=actionOrder,proto3" json:"actionOrder,omitempty"`
+	VrfProof             []byte               `protobuf:"bytes,14,opt,name=vrfProof,proto3" json:"vrfProof,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -42,7 +44,7 @@ func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{0}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{0}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
@@ -146,20 +148,35 @@ func (m *BlockHeader) GetPubkey() []byte {
 	return nil
 }
 
+func (m *BlockHeader) GetActionOrder() uint32 {
+	if m != nil {
+		return m.ActionOrder
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetVrfProof() []byte {
+	if m != nil {
+		return m.VrfProof
+	}
+	return nil
+}
+
 // footer of a block
 type BlockFooter struct {
-	CommitTimestamp      int64           `protobuf:"varint,1,opt,name=CommitTimestamp,proto3" json:"CommitTimestamp,omitempty"`
-	Endorsements         *EndorsementSet `protobuf:"bytes,2,opt,name=endorsements,proto3" json:"endorsements,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	CommitTimestamp        int64                    `protobuf:"varint,1,opt,name=CommitTimestamp,proto3" json:"CommitTimestamp,omitempty"`
+	Endorsements           *EndorsementSet          `protobuf:"bytes,2,opt,name=endorsements,proto3" json:"endorsements,omitempty"`
+	AggregatedEndorsements []*AggregatedEndorsement `protobuf:"bytes,3,rep,name=aggregatedEndorsements,proto3" json:"aggregatedEndorsements,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                 `json:"-"`
+	XXX_unrecognized       []byte                   `json:"-"`
+	XXX_sizecache          int32                    `json:"-"`
 }
 
 func (m *BlockFooter) Reset()         { *m = BlockFooter{} }
 func (m *BlockFooter) String() string { return proto.CompactTextString(m) }
 func (*BlockFooter) ProtoMessage()    {}
 func (*BlockFooter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{1}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{1}
 }
 func (m *BlockFooter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockFooter.Unmarshal(m, b)
@@ -193,12 +210,20 @@ func (m *BlockFooter) GetEndorsements() *EndorsementSet {
 	return nil
 }
 
+func (m *BlockFooter) GetAggregatedEndorsements() []*AggregatedEndorsement {
+	if m != nil {
+		return m.AggregatedEndorsements
+	}
+	return nil
+}
+
 // block consists of header followed by transactions
 // hash of current block can be computed from header hence not stored
 type Block struct {
 	Header               *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Actions              []*Action    `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
 	Footer               *BlockFooter `protobuf:"bytes,3,opt,name=footer,proto3" json:"footer,omitempty"`
+	Receipts             []*Receipt   `protobuf:"bytes,4,rep,name=receipts,proto3" json:"receipts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -208,7 +233,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{2}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{2}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -249,6 +274,13 @@ func (m *Block) GetFooter() *BlockFooter {
 	return nil
 }
 
+func (m *Block) GetReceipts() []*Receipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
 // Receipts consists of a collection of recepit
 type Receipts struct {
 	Receipts             []*Receipt `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
@@ -261,7 +293,7 @@ func (m *Receipts) Reset()         { *m = Receipts{} }
 func (m *Receipts) String() string { return proto.CompactTextString(m) }
 func (*Receipts) ProtoMessage()    {}
 func (*Receipts) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{3}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{3}
 }
 func (m *Receipts) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Receipts.Unmarshal(m, b)
@@ -304,7 +336,7 @@ func (m *Candidate) Reset()         { *m = Candidate{} }
 func (m *Candidate) String() string { return proto.CompactTextString(m) }
 func (*Candidate) ProtoMessage()    {}
 func (*Candidate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{4}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{4}
 }
 func (m *Candidate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Candidate.Unmarshal(m, b)
@@ -370,7 +402,7 @@ func (m *CandidateList) Reset()         { *m = CandidateList{} }
 func (m *CandidateList) String() string { return proto.CompactTextString(m) }
 func (*CandidateList) ProtoMessage()    {}
 func (*CandidateList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{5}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{5}
 }
 func (m *CandidateList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CandidateList.Unmarshal(m, b)
@@ -399,10 +431,12 @@ func (m *CandidateList) GetCandidates() []*Candidate {
 
 // Blockchain Metadata
 type ChainMeta struct {
-	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Supply               string   `protobuf:"bytes,2,opt,name=supply,proto3" json:"supply,omitempty"`
-	NumActions           int64    `protobuf:"varint,3,opt,name=numActions,proto3" json:"numActions,omitempty"`
-	Tps                  int64    `protobuf:"varint,4,opt,name=tps,proto3" json:"tps,omitempty"`
+	Height     uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Supply     string `protobuf:"bytes,2,opt,name=supply,proto3" json:"supply,omitempty"`
+	NumActions int64  `protobuf:"varint,3,opt,name=numActions,proto3" json:"numActions,omitempty"`
+	Tps        int64  `protobuf:"varint,4,opt,name=tps,proto3" json:"tps,omitempty"`
+	// height of the latest block committed with the endorsements of more than 2/3 delegates, which can't be reverted
+	IrreversibleHeight   uint64   `protobuf:"varint,5,opt,name=irreversibleHeight,proto3" json:"irreversibleHeight,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -412,7 +446,7 @@ func (m *ChainMeta) Reset()         { *m = ChainMeta{} }
 func (m *ChainMeta) String() string { return proto.CompactTextString(m) }
 func (*ChainMeta) ProtoMessage()    {}
 func (*ChainMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{6}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{6}
 }
 func (m *ChainMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChainMeta.Unmarshal(m, b)
@@ -460,17 +494,27 @@ func (m *ChainMeta) GetTps() int64 {
 	return 0
 }
 
+func (m *ChainMeta) GetIrreversibleHeight() uint64 {
+	if m != nil {
+		return m.IrreversibleHeight
+	}
+	return 0
+}
+
 // Block Metadata
 type BlockMeta struct {
-	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
-	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	NumActions           int64    `protobuf:"varint,4,opt,name=numActions,proto3" json:"numActions,omitempty"`
-	ProducerAddress      string   `protobuf:"bytes,5,opt,name=producerAddress,proto3" json:"producerAddress,omitempty"`
-	TransferAmount       string   `protobuf:"bytes,6,opt,name=transferAmount,proto3" json:"transferAmount,omitempty"`
-	TxRoot               string   `protobuf:"bytes,7,opt,name=txRoot,proto3" json:"txRoot,omitempty"`
-	ReceiptRoot          string   `protobuf:"bytes,8,opt,name=receiptRoot,proto3" json:"receiptRoot,omitempty"`
-	DeltaStateDigest     string   `protobuf:"bytes,9,opt,name=deltaStateDigest,proto3" json:"deltaStateDigest,omitempty"`
+	Hash             string       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height           uint64       `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp        int64        `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	NumActions       int64        `protobuf:"varint,4,opt,name=numActions,proto3" json:"numActions,omitempty"`
+	ProducerAddress  string       `protobuf:"bytes,5,opt,name=producerAddress,proto3" json:"producerAddress,omitempty"`
+	TransferAmount   string       `protobuf:"bytes,6,opt,name=transferAmount,proto3" json:"transferAmount,omitempty"`
+	TxRoot           string       `protobuf:"bytes,7,opt,name=txRoot,proto3" json:"txRoot,omitempty"`
+	ReceiptRoot      string       `protobuf:"bytes,8,opt,name=receiptRoot,proto3" json:"receiptRoot,omitempty"`
+	DeltaStateDigest string       `protobuf:"bytes,9,opt,name=deltaStateDigest,proto3" json:"deltaStateDigest,omitempty"`
+	Footer           *BlockFooter `protobuf:"bytes,10,opt,name=footer,proto3" json:"footer,omitempty"`
+	// whether the block is committed with the endorsements of more than 2/3 delegates, or merely observed
+	Finalized            bool     `protobuf:"varint,11,opt,name=finalized,proto3" json:"finalized,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -480,7 +524,7 @@ func (m *BlockMeta) Reset()         { *m = BlockMeta{} }
 func (m *BlockMeta) String() string { return proto.CompactTextString(m) }
 func (*BlockMeta) ProtoMessage()    {}
 func (*BlockMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{7}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{7}
 }
 func (m *BlockMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMeta.Unmarshal(m, b)
@@ -563,12 +607,139 @@ func (m *BlockMeta) GetDeltaStateDigest() string {
 	return ""
 }
 
+func (m *BlockMeta) GetFooter() *BlockFooter {
+	if m != nil {
+		return m.Footer
+	}
+	return nil
+}
+
+func (m *BlockMeta) GetFinalized() bool {
+	if m != nil {
+		return m.Finalized
+	}
+	return false
+}
+
+// Action fee breakdown
+type ActionFee struct {
+	IntrinsicGas uint64 `protobuf:"varint,1,opt,name=intrinsicGas,proto3" json:"intrinsicGas,omitempty"`
+	ExecutionGas uint64 `protobuf:"varint,2,opt,name=executionGas,proto3" json:"executionGas,omitempty"`
+	GasPrice     string `protobuf:"bytes,3,opt,name=gasPrice,proto3" json:"gasPrice,omitempty"`
+	TotalFee     string `protobuf:"bytes,4,opt,name=totalFee,proto3" json:"totalFee,omitempty"`
+	Beneficiary  string `protobuf:"bytes,5,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
+	BurnedFee    string `protobuf:"bytes,6,opt,name=burnedFee,proto3" json:"burnedFee,omitempty"`
+	ProducerFee  string `protobuf:"bytes,7,opt,name=producerFee,proto3" json:"producerFee,omitempty"`
+	Producer     string `protobuf:"bytes,8,opt,name=producer,proto3" json:"producer,omitempty"`
+	FundFee      string `protobuf:"bytes,9,opt,name=fundFee,proto3" json:"fundFee,omitempty"`
+	// the intrinsic gas charged to the free gas quota of the sender, if the action is sent at zero gas price
+	FreeGas              uint64   `protobuf:"varint,10,opt,name=freeGas,proto3" json:"freeGas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActionFee) Reset()         { *m = ActionFee{} }
+func (m *ActionFee) String() string { return proto.CompactTextString(m) }
+func (*ActionFee) ProtoMessage()    {}
+func (*ActionFee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{8}
+}
+func (m *ActionFee) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionFee.Unmarshal(m, b)
+}
+func (m *ActionFee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionFee.Marshal(b, m, deterministic)
+}
+func (dst *ActionFee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionFee.Merge(dst, src)
+}
+func (m *ActionFee) XXX_Size() int {
+	return xxx_messageInfo_ActionFee.Size(m)
+}
+func (m *ActionFee) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionFee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionFee proto.InternalMessageInfo
+
+func (m *ActionFee) GetIntrinsicGas() uint64 {
+	if m != nil {
+		return m.IntrinsicGas
+	}
+	return 0
+}
+
+func (m *ActionFee) GetExecutionGas() uint64 {
+	if m != nil {
+		return m.ExecutionGas
+	}
+	return 0
+}
+
+func (m *ActionFee) GetGasPrice() string {
+	if m != nil {
+		return m.GasPrice
+	}
+	return ""
+}
+
+func (m *ActionFee) GetTotalFee() string {
+	if m != nil {
+		return m.TotalFee
+	}
+	return ""
+}
+
+func (m *ActionFee) GetBeneficiary() string {
+	if m != nil {
+		return m.Beneficiary
+	}
+	return ""
+}
+
+func (m *ActionFee) GetBurnedFee() string {
+	if m != nil {
+		return m.BurnedFee
+	}
+	return ""
+}
+
+func (m *ActionFee) GetProducerFee() string {
+	if m != nil {
+		return m.ProducerFee
+	}
+	return ""
+}
+
+func (m *ActionFee) GetProducer() string {
+	if m != nil {
+		return m.Producer
+	}
+	return ""
+}
+
+func (m *ActionFee) GetFundFee() string {
+	if m != nil {
+		return m.FundFee
+	}
+	return ""
+}
+
+func (m *ActionFee) GetFreeGas() uint64 {
+	if m != nil {
+		return m.FreeGas
+	}
+	return 0
+}
+
 // Account Metadata
 type AccountMeta struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	Balance              string   `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
 	Nonce                uint64   `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	PendingNonce         uint64   `protobuf:"varint,4,opt,name=pendingNonce,proto3" json:"pendingNonce,omitempty"`
+	PendingBalance       string   `protobuf:"bytes,5,opt,name=pendingBalance,proto3" json:"pendingBalance,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -578,7 +749,7 @@ func (m *AccountMeta) Reset()         { *m = AccountMeta{} }
 func (m *AccountMeta) String() string { return proto.CompactTextString(m) }
 func (*AccountMeta) ProtoMessage()    {}
 func (*AccountMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_blockchain_9f72dcad996a5e71, []int{8}
+	return fileDescriptor_blockchain_feefef34d8d6758c, []int{9}
 }
 func (m *AccountMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AccountMeta.Unmarshal(m, b)
@@ -626,6 +797,13 @@ func (m *AccountMeta) GetPendingNonce() uint64 {
 	return 0
 }
 
+func (m *AccountMeta) GetPendingBalance() string {
+	if m != nil {
+		return m.PendingBalance
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*BlockHeader)(nil), "iotextypes.BlockHeader")
 	proto.RegisterType((*BlockFooter)(nil), "iotextypes.BlockFooter")
@@ -635,59 +813,73 @@ func init() {
 	proto.RegisterType((*CandidateList)(nil), "iotextypes.CandidateList")
 	proto.RegisterType((*ChainMeta)(nil), "iotextypes.ChainMeta")
 	proto.RegisterType((*BlockMeta)(nil), "iotextypes.BlockMeta")
+	proto.RegisterType((*ActionFee)(nil), "iotextypes.ActionFee")
 	proto.RegisterType((*AccountMeta)(nil), "iotextypes.AccountMeta")
 }
 
-func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_9f72dcad996a5e71) }
-
-var fileDescriptor_blockchain_9f72dcad996a5e71 = []byte{
-	// 768 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x4d, 0x8f, 0xe3, 0x44,
-	0x10, 0x95, 0x27, 0x9f, 0xae, 0x64, 0xd8, 0xa1, 0x81, 0xc5, 0x8a, 0x56, 0x10, 0x59, 0x08, 0x45,
-	0x08, 0x12, 0x69, 0x10, 0x68, 0x25, 0x24, 0xa4, 0xec, 0x2c, 0xab, 0x45, 0x03, 0x1c, 0x7a, 0xe0,
-	0xc2, 0xad, 0x63, 0xd7, 0x38, 0x66, 0x62, 0xb7, 0xd5, 0xdd, 0x0e, 0x13, 0xf1, 0x23, 0x38, 0xf2,
-	0x13, 0x38, 0xf1, 0x1f, 0x51, 0x57, 0xb7, 0x13, 0x27, 0x99, 0xbd, 0xa5, 0x5e, 0x3d, 0xbb, 0x5e,
-	0x55, 0x3d, 0x57, 0xe0, 0x6a, 0xb5, 0x91, 0xc9, 0x43, 0xb2, 0x16, 0x79, 0x39, 0xaf, 0x94, 0x34,
-	0x92, 0x41, 0x2e, 0x0d, 0x3e, 0x9a, 0x5d, 0x85, 0x7a, 0x32, 0x16, 0x89, 0xc9, 0xa5, 0xcf, 0x4c,
-	0xde, 0xc7, 0x32, 0x95, 0x4a, 0x63, 0x81, 0xa5, 0xf1, 0xd0, 0xa7, 0x99, 0x94, 0xd9, 0x06, 0x17,
-	0x14, 0xad, 0xea, 0xfb, 0x85, 0xc9, 0x0b, 0xd4, 0x46, 0x14, 0x95, 0x23, 0xc4, 0x7f, 0x77, 0x60,
-	0xf4, 0xca, 0x96, 0x78, 0x8b, 0x22, 0x45, 0xc5, 0x22, 0x18, 0x6c, 0x51, 0xe9, 0x5c, 0x96, 0x51,
-	0x30, 0x0d, 0x66, 0x97, 0xbc, 0x09, 0x6d, 0x86, 0x64, 0xfc, 0xf8, 0x3a, 0xba, 0x70, 0x19, 0x1f,
-	0xb2, 0xe7, 0xd0, 0x5f, 0x63, 0x9e, 0xad, 0x4d, 0xd4, 0x99, 0x06, 0xb3, 0x2e, 0xf7, 0x11, 0x7b,
-	0x09, 0xe1, 0xbe, 0x5c, 0xd4, 0x9d, 0x06, 0xb3, 0xd1, 0xf5, 0x64, 0xee, 0x04, 0xcd, 0x1b, 0x41,
-	0xf3, 0x5f, 0x1b, 0x06, 0x3f, 0x90, 0xd9, 0x67, 0x70, 0x59, 0x29, 0xdc, 0x3a, 0x61, 0x42, 0xaf,
-	0xa3, 0xde, 0x34, 0x98, 0x8d, 0xf9, 0x31, 0x68, 0xeb, 0x9a, 0x47, 0x2e, 0xa5, 0x89, 0xfa, 0x94,
-	0xf6, 0x11, 0x7b, 0x01, 0xa1, 0x36, 0xc2, 0x20, 0xa5, 0x06, 0x94, 0x3a, 0x00, 0xec, 0x0b, 0xb8,
-	0x4a, 0x71, 0x63, 0xc4, 0x9d, 0x45, 0x5e, 0xe7, 0x19, 0x6a, 0x13, 0x0d, 0x89, 0x74, 0x86, 0xb3,
-	0x29, 0x8c, 0x14, 0x26, 0x98, 0x57, 0x86, 0xde, 0x15, 0x12, 0xad, 0x0d, 0xb1, 0x09, 0x0c, 0x15,
-	0x6a, 0x54, 0x5b, 0x4c, 0x23, 0xa0, 0xf4, 0x3e, 0x26, 0x1d, 0x79, 0x56, 0x0a, 0x53, 0x2b, 0x8c,
-	0x46, 0x5e, 0x47, 0x03, 0x58, 0xf5, 0x55, 0xbd, 0x7a, 0xc0, 0x5d, 0x34, 0x76, 0xea, 0x5d, 0x14,
-	0xff, 0xe9, 0x17, 0xf2, 0x46, 0x4a, 0x83, 0x8a, 0xcd, 0xe0, 0xd9, 0x8d, 0x2c, 0x8a, 0xdc, 0xec,
-	0x07, 0x45, 0x8b, 0xe9, 0xf0, 0x53, 0x98, 0x7d, 0x0f, 0xe3, 0x96, 0x01, 0x34, 0x6d, 0xc9, 0x4e,
-	0xfc, 0xe0, 0x97, 0xf9, 0x0f, 0x87, 0xfc, 0x1d, 0x1a, 0x7e, 0xc4, 0x8f, 0xff, 0x09, 0xa0, 0x47,
-	0x95, 0xd9, 0xc2, 0x2e, 0xd4, 0xda, 0x81, 0x4a, 0x8d, 0xae, 0x3f, 0x6e, 0xbf, 0xa3, 0xe5, 0x16,
-	0xee, 0x69, 0xec, 0x4b, 0x18, 0x38, 0x27, 0xda, 0xaa, 0x9d, 0xd9, 0xe8, 0x9a, 0xb5, 0x9f, 0x58,
-	0x52, 0x8a, 0x37, 0x14, 0xfb, 0xfa, 0x7b, 0x6a, 0x8e, 0xfc, 0xf2, 0xd4, 0xeb, 0x5d, 0xef, 0xdc,
-	0xd3, 0xe2, 0xef, 0x60, 0xc8, 0xdd, 0xcc, 0xed, 0xc3, 0x43, 0x3f, 0x7f, 0x1d, 0x05, 0x54, 0xeb,
-	0x83, 0xf6, 0xe3, 0x9e, 0xc7, 0xf7, 0xa4, 0xf8, 0xdf, 0x00, 0xc2, 0x1b, 0x51, 0xa6, 0x79, 0x2a,
-	0x0c, 0x5a, 0x17, 0x8b, 0x34, 0x55, 0xa8, 0x35, 0xf5, 0x16, 0xf2, 0x26, 0x64, 0x1f, 0x42, 0x6f,
-	0x2b, 0x0d, 0xba, 0xb9, 0x8d, 0xb9, 0x0b, 0xfc, 0x96, 0x6e, 0x71, 0x47, 0x5a, 0xdd, 0x96, 0x6e,
-	0x71, 0xc7, 0x3e, 0x87, 0xf7, 0x12, 0x85, 0xc2, 0x36, 0xf4, 0xd6, 0x79, 0xbf, 0x4b, 0xde, 0x3f,
-	0x41, 0xad, 0xdb, 0x36, 0x42, 0x9b, 0xdf, 0x2a, 0x5b, 0xdd, 0x33, 0x7b, 0xc4, 0x3c, 0xc3, 0xe3,
-	0x37, 0x70, 0xb9, 0x17, 0xfa, 0x53, 0xae, 0x0d, 0xfb, 0x06, 0x20, 0x69, 0x80, 0xa6, 0xdb, 0x8f,
-	0xda, 0xdd, 0xee, 0xe9, 0xbc, 0x45, 0x8c, 0x0b, 0x08, 0x6f, 0xec, 0xa7, 0xf9, 0x33, 0x1a, 0xd1,
-	0xfa, 0x38, 0x83, 0xa3, 0x8f, 0xf3, 0x39, 0xf4, 0x75, 0x5d, 0x55, 0x9b, 0x1d, 0xf5, 0x1b, 0x72,
-	0x1f, 0xb1, 0x4f, 0x00, 0xca, 0xba, 0x58, 0xfa, 0x6d, 0x76, 0xc8, 0x6a, 0x2d, 0x84, 0x5d, 0x41,
-	0xc7, 0x54, 0x9a, 0xba, 0xed, 0x70, 0xfb, 0x33, 0xfe, 0xef, 0x02, 0x42, 0xda, 0x1a, 0xd5, 0x63,
-	0xd0, 0x5d, 0xdb, 0x2f, 0xd6, 0x4d, 0x97, 0x7e, 0xb7, 0x34, 0x5c, 0x1c, 0x69, 0x78, 0xd1, 0x3e,
-	0x10, 0xae, 0x54, 0xeb, 0x08, 0x1c, 0x2b, 0xe9, 0x9e, 0x29, 0x99, 0xc1, 0xb3, 0x4a, 0xc9, 0xb4,
-	0x4e, 0x50, 0x2d, 0xfd, 0x4a, 0x7b, 0x54, 0xf4, 0x14, 0xb6, 0xcb, 0x32, 0x4a, 0x94, 0xfa, 0x1e,
-	0xd5, 0xb2, 0x90, 0x75, 0xe9, 0x0e, 0x46, 0xc8, 0x4f, 0xd0, 0xd6, 0x41, 0x19, 0xb8, 0x99, 0xf8,
-	0x83, 0x72, 0x72, 0x06, 0x86, 0x94, 0x3c, 0x3a, 0x03, 0x4f, 0x1d, 0x95, 0x90, 0x68, 0x67, 0x78,
-	0xfc, 0x17, 0x8c, 0x96, 0x49, 0x62, 0x0b, 0xd2, 0xc0, 0xde, 0xed, 0xc8, 0x08, 0x06, 0x2b, 0xb1,
-	0x11, 0x65, 0x82, 0x7e, 0x47, 0x4d, 0x68, 0xbd, 0x5a, 0x4a, 0x8b, 0xbb, 0x83, 0xeb, 0x02, 0x16,
-	0xc3, 0xb8, 0xc2, 0x32, 0xcd, 0xcb, 0xec, 0x17, 0x4a, 0x3a, 0x47, 0x1e, 0x61, 0xaf, 0x5e, 0xfe,
-	0xfe, 0x6d, 0x96, 0x9b, 0x75, 0xbd, 0x9a, 0x27, 0xb2, 0x58, 0x90, 0x95, 0x2a, 0x25, 0xff, 0xc0,
-	0xc4, 0xb8, 0xe0, 0xab, 0x44, 0x2a, 0xff, 0x77, 0x91, 0x61, 0xb9, 0x38, 0x78, 0x6d, 0xd5, 0x27,
-	0xf0, 0xeb, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x4a, 0x38, 0x77, 0xf1, 0x92, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_blockchain_feefef34d8d6758c) }
+
+var fileDescriptor_blockchain_feefef34d8d6758c = []byte{
+	// 974 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xcd, 0x8e, 0x23, 0x35,
+	0x10, 0x56, 0xe7, 0x67, 0x92, 0x76, 0x32, 0xec, 0x60, 0xd8, 0xa5, 0x35, 0x5a, 0x41, 0x68, 0x21,
+	0x14, 0x21, 0x48, 0xa4, 0x41, 0xa0, 0x95, 0x90, 0x90, 0x32, 0xbb, 0x0c, 0x8b, 0x96, 0x3f, 0x79,
+	0x41, 0x48, 0xdc, 0x9c, 0xee, 0x4a, 0xc7, 0x6c, 0x62, 0xb7, 0x6c, 0xf7, 0x68, 0xc2, 0x8b, 0x70,
+	0xe2, 0xc4, 0x81, 0x33, 0x0f, 0xc0, 0x3b, 0xf0, 0x40, 0x1c, 0x90, 0xcb, 0xee, 0x4e, 0x27, 0x33,
+	0x23, 0x71, 0xcb, 0xf7, 0xd5, 0xd7, 0x76, 0x55, 0x7d, 0xe5, 0x52, 0xc8, 0xd9, 0x72, 0xa3, 0xb2,
+	0x57, 0xd9, 0x9a, 0x0b, 0x39, 0x2b, 0xb5, 0xb2, 0x8a, 0x12, 0xa1, 0x2c, 0xdc, 0xd8, 0x5d, 0x09,
+	0xe6, 0x7c, 0xcc, 0x33, 0x2b, 0x54, 0x88, 0x9c, 0xbf, 0x0e, 0x32, 0x57, 0xda, 0xc0, 0x16, 0xa4,
+	0x0d, 0xd4, 0x3b, 0x85, 0x52, 0xc5, 0x06, 0xe6, 0x88, 0x96, 0xd5, 0x6a, 0x6e, 0xc5, 0x16, 0x8c,
+	0xe5, 0xdb, 0xd2, 0x0b, 0xd2, 0xbf, 0xba, 0x64, 0x74, 0xe9, 0xae, 0x78, 0x0e, 0x3c, 0x07, 0x4d,
+	0x13, 0x32, 0xb8, 0x06, 0x6d, 0x84, 0x92, 0x49, 0x34, 0x89, 0xa6, 0xa7, 0xac, 0x86, 0x2e, 0x82,
+	0x69, 0x7c, 0xf5, 0x2c, 0xe9, 0xf8, 0x48, 0x80, 0xf4, 0x11, 0x39, 0x59, 0x83, 0x28, 0xd6, 0x36,
+	0xe9, 0x4e, 0xa2, 0x69, 0x8f, 0x05, 0x44, 0x9f, 0x90, 0xb8, 0xb9, 0x2e, 0xe9, 0x4d, 0xa2, 0xe9,
+	0xe8, 0xe2, 0x7c, 0xe6, 0x13, 0x9a, 0xd5, 0x09, 0xcd, 0x7e, 0xa8, 0x15, 0x6c, 0x2f, 0xa6, 0xef,
+	0x91, 0xd3, 0x52, 0xc3, 0xb5, 0x4f, 0x8c, 0x9b, 0x75, 0xd2, 0x9f, 0x44, 0xd3, 0x31, 0x3b, 0x24,
+	0xdd, 0xbd, 0xf6, 0x86, 0x29, 0x65, 0x93, 0x13, 0x0c, 0x07, 0x44, 0x1f, 0x93, 0xd8, 0x58, 0x6e,
+	0x01, 0x43, 0x03, 0x0c, 0xed, 0x09, 0xfa, 0x01, 0x39, 0xcb, 0x61, 0x63, 0xf9, 0x4b, 0xc7, 0x3c,
+	0x13, 0x05, 0x18, 0x9b, 0x0c, 0x51, 0x74, 0x8b, 0xa7, 0x13, 0x32, 0xd2, 0x90, 0x81, 0x28, 0x2d,
+	0x9e, 0x15, 0xa3, 0xac, 0x4d, 0xd1, 0x73, 0x32, 0xd4, 0x60, 0x40, 0x5f, 0x43, 0x9e, 0x10, 0x0c,
+	0x37, 0x18, 0xf3, 0x10, 0x85, 0xe4, 0xb6, 0xd2, 0x90, 0x8c, 0x42, 0x1e, 0x35, 0xe1, 0xb2, 0x2f,
+	0xab, 0xe5, 0x2b, 0xd8, 0x25, 0x63, 0x9f, 0xbd, 0x47, 0xee, 0x4e, 0xef, 0xea, 0x77, 0x3a, 0x07,
+	0x9d, 0x9c, 0x62, 0xaf, 0xdb, 0x54, 0xfa, 0x4f, 0x14, 0x3c, 0xbb, 0x52, 0xca, 0x82, 0xa6, 0x53,
+	0xf2, 0xe0, 0xa9, 0xda, 0x6e, 0x85, 0x6d, 0x7a, 0x89, 0xde, 0x75, 0xd9, 0x31, 0x4d, 0x3f, 0x27,
+	0xe3, 0xd6, 0x8c, 0x98, 0xa4, 0x13, 0x4c, 0xd9, 0x8f, 0xd4, 0xec, 0x8b, 0x7d, 0xfc, 0x25, 0x58,
+	0x76, 0xa0, 0xa7, 0x3f, 0x91, 0x87, 0xbc, 0x28, 0x34, 0x14, 0xdc, 0x42, 0xde, 0x52, 0xa2, 0xf1,
+	0xa3, 0x8b, 0x77, 0xdb, 0x07, 0x2d, 0xee, 0x12, 0xb2, 0xbb, 0xbf, 0x4f, 0x7f, 0x8b, 0x48, 0x1f,
+	0x4b, 0xa2, 0x73, 0x37, 0x4c, 0x6e, 0x14, 0xb1, 0x86, 0xd1, 0xc5, 0x5b, 0xed, 0x33, 0x5b, 0x93,
+	0xca, 0x82, 0x8c, 0x7e, 0x48, 0x06, 0xbe, 0x39, 0xae, 0x9c, 0xee, 0x74, 0x74, 0x41, 0x0f, 0xb2,
+	0xc0, 0x10, 0xab, 0x25, 0xee, 0xf8, 0x15, 0x76, 0x2d, 0xe9, 0xde, 0x73, 0xbc, 0x6f, 0x2a, 0x0b,
+	0xb2, 0xf4, 0x33, 0x32, 0x64, 0xde, 0x6f, 0xf7, 0xf1, 0x30, 0x78, 0x6f, 0x92, 0x08, 0xef, 0x7a,
+	0xa3, 0xfd, 0x79, 0xd0, 0xb1, 0x46, 0x94, 0xfe, 0x19, 0x91, 0xf8, 0x29, 0x97, 0xb9, 0xc8, 0xb9,
+	0x05, 0xf7, 0x82, 0x78, 0x9e, 0x6b, 0x30, 0x06, 0x6b, 0x8b, 0x59, 0x0d, 0xe9, 0x9b, 0xa4, 0x7f,
+	0xad, 0x2c, 0x78, 0x43, 0xc6, 0xcc, 0x83, 0x30, 0x21, 0x2f, 0x60, 0x97, 0x74, 0x9b, 0x09, 0x79,
+	0x01, 0x3b, 0xfa, 0x3e, 0x79, 0x2d, 0xd3, 0xc0, 0x5d, 0x41, 0xcf, 0xfd, 0xbb, 0xeb, 0xe1, 0xbb,
+	0x3b, 0x62, 0xdd, 0xa4, 0x6f, 0xb8, 0xb1, 0x3f, 0x96, 0xee, 0xf6, 0xa0, 0xec, 0xa3, 0xf2, 0x16,
+	0x9f, 0x5e, 0x91, 0xd3, 0x26, 0xd1, 0xaf, 0x85, 0xb1, 0xf4, 0x13, 0x42, 0xb2, 0x9a, 0xa8, 0xab,
+	0x7d, 0xd8, 0xae, 0xb6, 0x91, 0xb3, 0x96, 0x30, 0xfd, 0xdd, 0x55, 0xec, 0xf6, 0xc2, 0x37, 0x60,
+	0x79, 0x6b, 0x33, 0x44, 0x07, 0x9b, 0xe1, 0x11, 0x39, 0x31, 0x55, 0x59, 0x6e, 0x76, 0x58, 0x70,
+	0xcc, 0x02, 0xa2, 0x6f, 0x13, 0x22, 0xab, 0xed, 0x22, 0xd8, 0xd9, 0xc5, 0x21, 0x6e, 0x31, 0xf4,
+	0x8c, 0x74, 0x6d, 0x69, 0xb0, 0xdc, 0x2e, 0x73, 0x3f, 0xe9, 0x8c, 0x50, 0xa1, 0x35, 0xe0, 0x92,
+	0x5a, 0x6e, 0x0e, 0xab, 0xbc, 0x23, 0x92, 0xfe, 0xdb, 0x21, 0x31, 0xda, 0x8c, 0xf9, 0x51, 0xd2,
+	0x5b, 0xbb, 0xf5, 0xe2, 0xed, 0xc0, 0xdf, 0xad, 0x9c, 0x3b, 0x07, 0x39, 0x3f, 0x6e, 0x6f, 0x33,
+	0x9f, 0xda, 0x9e, 0x38, 0xca, 0xbc, 0x77, 0x2b, 0xf3, 0x29, 0x79, 0x50, 0x6a, 0x95, 0x57, 0x19,
+	0xe8, 0x45, 0x98, 0x81, 0x3e, 0x5e, 0x7a, 0x4c, 0x3b, 0x77, 0xad, 0xe6, 0xd2, 0xac, 0x40, 0x2f,
+	0xb6, 0xaa, 0x92, 0x7e, 0xbb, 0xc5, 0xec, 0x88, 0x6d, 0x6d, 0xbf, 0x81, 0xef, 0xa1, 0x47, 0xc7,
+	0x3b, 0x6b, 0x88, 0xc1, 0x36, 0x75, 0xe7, 0x06, 0x8c, 0x51, 0x76, 0x8b, 0x6f, 0xbd, 0x17, 0xf2,
+	0xbf, 0xde, 0x8b, 0x6b, 0xd3, 0x4a, 0x48, 0xbe, 0x11, 0xbf, 0x42, 0x8e, 0x4b, 0x6f, 0xc8, 0xf6,
+	0x44, 0xfa, 0x77, 0x87, 0xc4, 0xbe, 0x25, 0x57, 0x00, 0x34, 0x25, 0x63, 0x21, 0xad, 0x16, 0xd2,
+	0x88, 0xec, 0x4b, 0x6e, 0xc2, 0x90, 0x1c, 0x70, 0x4e, 0x03, 0x37, 0x90, 0x55, 0xee, 0x1b, 0xa7,
+	0xf1, 0xa6, 0x1c, 0x70, 0x6e, 0x09, 0x17, 0xdc, 0x7c, 0xaf, 0x45, 0x06, 0xe8, 0x4c, 0xcc, 0x1a,
+	0xec, 0x62, 0x56, 0x59, 0xbe, 0xb9, 0x02, 0x40, 0x5b, 0x62, 0xd6, 0x60, 0xd7, 0xaa, 0x25, 0x48,
+	0x58, 0x89, 0x4c, 0x70, 0xbd, 0x0b, 0x86, 0xb4, 0x29, 0x57, 0xcd, 0xb2, 0xd2, 0x12, 0x72, 0xf7,
+	0xb9, 0xf7, 0x61, 0x4f, 0xb8, 0xef, 0x6b, 0xf7, 0x5c, 0xdc, 0xfb, 0xd0, 0xa6, 0xdc, 0xed, 0x35,
+	0x0c, 0x4e, 0x34, 0xd8, 0xad, 0x83, 0x55, 0x25, 0xf1, 0x64, 0xdf, 0xfd, 0x1a, 0x62, 0x44, 0x03,
+	0xb8, 0x72, 0x09, 0x96, 0x5b, 0xc3, 0xf4, 0x8f, 0x88, 0x8c, 0x16, 0x59, 0xe6, 0x06, 0x00, 0x07,
+	0xf8, 0xfe, 0x95, 0x92, 0x90, 0xc1, 0x92, 0x6f, 0xb8, 0xcc, 0x20, 0xbc, 0xb1, 0x1a, 0xba, 0x65,
+	0x23, 0x95, 0x0c, 0xad, 0xea, 0x31, 0x0f, 0x5c, 0x9f, 0x4b, 0x90, 0xb9, 0x90, 0xc5, 0xb7, 0x18,
+	0xf4, 0x2b, 0xe5, 0x80, 0x73, 0xa3, 0x19, 0xf0, 0x65, 0x38, 0xda, 0xb7, 0xec, 0x88, 0xbd, 0x7c,
+	0xf2, 0xf3, 0xa7, 0x85, 0xb0, 0xeb, 0x6a, 0x39, 0xcb, 0xd4, 0x76, 0x8e, 0x03, 0x53, 0x6a, 0xf5,
+	0x0b, 0x64, 0xd6, 0x83, 0x8f, 0x32, 0xa5, 0xc3, 0x7f, 0x92, 0x02, 0xe4, 0x7c, 0x3f, 0x51, 0xcb,
+	0x13, 0x24, 0x3f, 0xfe, 0x6f, 0x00, 0x24, 0x40, 0xbe, 0xc9, 0xf7, 0x08, 0x00, 0x00,
 }
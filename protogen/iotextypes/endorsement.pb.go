@@ -41,29 +41,31 @@ func (x Endorsement_ConsensusVoteTopic) String() string {
 	return proto.EnumName(Endorsement_ConsensusVoteTopic_name, int32(x))
 }
 func (Endorsement_ConsensusVoteTopic) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_endorsement_09e3ec3b10bdc27c, []int{0, 0}
+	return fileDescriptor_endorsement_121b07dd1c4051d4, []int{0, 0}
 }
 
 // corresponding to prepare and pre-prepare phase in view change protocol
 type Endorsement struct {
-	Height               uint64                         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Round                uint32                         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
-	BlockHash            []byte                         `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
-	Topic                Endorsement_ConsensusVoteTopic `protobuf:"varint,4,opt,name=topic,proto3,enum=iotextypes.Endorsement_ConsensusVoteTopic" json:"topic,omitempty"`
-	Endorser             string                         `protobuf:"bytes,5,opt,name=endorser,proto3" json:"endorser,omitempty"`
-	EndorserPubKey       []byte                         `protobuf:"bytes,6,opt,name=endorserPubKey,proto3" json:"endorserPubKey,omitempty"`
-	Decision             bool                           `protobuf:"varint,7,opt,name=decision,proto3" json:"decision,omitempty"`
-	Signature            []byte                         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+	Height         uint64                         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round          uint32                         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockHash      []byte                         `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Topic          Endorsement_ConsensusVoteTopic `protobuf:"varint,4,opt,name=topic,proto3,enum=iotextypes.Endorsement_ConsensusVoteTopic" json:"topic,omitempty"`
+	Endorser       string                         `protobuf:"bytes,5,opt,name=endorser,proto3" json:"endorser,omitempty"`
+	EndorserPubKey []byte                         `protobuf:"bytes,6,opt,name=endorserPubKey,proto3" json:"endorserPubKey,omitempty"`
+	Decision       bool                           `protobuf:"varint,7,opt,name=decision,proto3" json:"decision,omitempty"`
+	Signature      []byte                         `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	// BLS signature of the consensus vote, which could be aggregated with the ones of the other endorsers
+	BlsSignature         []byte   `protobuf:"bytes,9,opt,name=blsSignature,proto3" json:"blsSignature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Endorsement) Reset()         { *m = Endorsement{} }
 func (m *Endorsement) String() string { return proto.CompactTextString(m) }
 func (*Endorsement) ProtoMessage()    {}
 func (*Endorsement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endorsement_09e3ec3b10bdc27c, []int{0}
+	return fileDescriptor_endorsement_121b07dd1c4051d4, []int{0}
 }
 func (m *Endorsement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
@@ -139,6 +141,13 @@ func (m *Endorsement) GetSignature() []byte {
 	return nil
 }
 
+func (m *Endorsement) GetBlsSignature() []byte {
+	if m != nil {
+		return m.BlsSignature
+	}
+	return nil
+}
+
 type EndorsementSet struct {
 	BlockHash            []byte         `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
 	Round                uint32         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
@@ -152,7 +161,7 @@ func (m *EndorsementSet) Reset()         { *m = EndorsementSet{} }
 func (m *EndorsementSet) String() string { return proto.CompactTextString(m) }
 func (*EndorsementSet) ProtoMessage()    {}
 func (*EndorsementSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_endorsement_09e3ec3b10bdc27c, []int{1}
+	return fileDescriptor_endorsement_121b07dd1c4051d4, []int{1}
 }
 func (m *EndorsementSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EndorsementSet.Unmarshal(m, b)
@@ -193,36 +202,120 @@ func (m *EndorsementSet) GetEndorsements() []*Endorsement {
 	return nil
 }
 
+// endorsements of a block on a topic whose BLS signatures are aggregated, one signature for every group of the
+// endorsers, where the last group overlaps with the previous one if the endorsers couldn't be evenly grouped
+type AggregatedEndorsement struct {
+	Height               uint64                         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round                uint32                         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockHash            []byte                         `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Endorsers            []string                       `protobuf:"bytes,4,rep,name=endorsers,proto3" json:"endorsers,omitempty"`
+	Signatures           [][]byte                       `protobuf:"bytes,5,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	Topic                Endorsement_ConsensusVoteTopic `protobuf:"varint,6,opt,name=topic,proto3,enum=iotextypes.Endorsement_ConsensusVoteTopic" json:"topic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *AggregatedEndorsement) Reset()         { *m = AggregatedEndorsement{} }
+func (m *AggregatedEndorsement) String() string { return proto.CompactTextString(m) }
+func (*AggregatedEndorsement) ProtoMessage()    {}
+func (*AggregatedEndorsement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_endorsement_121b07dd1c4051d4, []int{2}
+}
+func (m *AggregatedEndorsement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AggregatedEndorsement.Unmarshal(m, b)
+}
+func (m *AggregatedEndorsement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AggregatedEndorsement.Marshal(b, m, deterministic)
+}
+func (dst *AggregatedEndorsement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregatedEndorsement.Merge(dst, src)
+}
+func (m *AggregatedEndorsement) XXX_Size() int {
+	return xxx_messageInfo_AggregatedEndorsement.Size(m)
+}
+func (m *AggregatedEndorsement) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregatedEndorsement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregatedEndorsement proto.InternalMessageInfo
+
+func (m *AggregatedEndorsement) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *AggregatedEndorsement) GetRound() uint32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *AggregatedEndorsement) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *AggregatedEndorsement) GetEndorsers() []string {
+	if m != nil {
+		return m.Endorsers
+	}
+	return nil
+}
+
+func (m *AggregatedEndorsement) GetSignatures() [][]byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+func (m *AggregatedEndorsement) GetTopic() Endorsement_ConsensusVoteTopic {
+	if m != nil {
+		return m.Topic
+	}
+	return Endorsement_PROPOSAL
+}
+
 func init() {
 	proto.RegisterType((*Endorsement)(nil), "iotextypes.Endorsement")
 	proto.RegisterType((*EndorsementSet)(nil), "iotextypes.EndorsementSet")
+	proto.RegisterType((*AggregatedEndorsement)(nil), "iotextypes.AggregatedEndorsement")
 	proto.RegisterEnum("iotextypes.Endorsement_ConsensusVoteTopic", Endorsement_ConsensusVoteTopic_name, Endorsement_ConsensusVoteTopic_value)
 }
 
-func init() { proto.RegisterFile("endorsement.proto", fileDescriptor_endorsement_09e3ec3b10bdc27c) }
-
-var fileDescriptor_endorsement_09e3ec3b10bdc27c = []byte{
-	// 348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0x4f, 0x4b, 0xeb, 0x40,
-	0x14, 0xc5, 0xdf, 0xf4, 0x4f, 0x5e, 0x7a, 0xdb, 0x57, 0xfa, 0x06, 0xd1, 0x41, 0x5c, 0x84, 0x2e,
-	0x24, 0x08, 0xa6, 0x50, 0x41, 0x0a, 0x6e, 0xd4, 0x22, 0x28, 0x6d, 0x49, 0x99, 0x16, 0x17, 0xee,
-	0x9a, 0xf4, 0x92, 0x44, 0xed, 0x4c, 0xc8, 0x4c, 0xc0, 0x2e, 0xfd, 0x8c, 0x7e, 0x21, 0x69, 0x52,
-	0x9b, 0xb6, 0xea, 0x6e, 0xce, 0xe1, 0xfe, 0xf9, 0xcd, 0x99, 0x81, 0xff, 0x28, 0xe6, 0x32, 0x51,
-	0xb8, 0x40, 0xa1, 0x9d, 0x38, 0x91, 0x5a, 0x52, 0x88, 0xa4, 0xc6, 0x37, 0xbd, 0x8c, 0x51, 0xb5,
-	0x3f, 0x4a, 0x50, 0xbf, 0x2b, 0x2a, 0xe8, 0x21, 0x18, 0x21, 0x46, 0x41, 0xa8, 0x19, 0xb1, 0x88,
-	0x5d, 0xe1, 0x6b, 0x45, 0x0f, 0xa0, 0x9a, 0xc8, 0x54, 0xcc, 0x59, 0xc9, 0x22, 0xf6, 0x3f, 0x9e,
-	0x0b, 0x7a, 0x02, 0x35, 0xef, 0x55, 0xfa, 0x2f, 0xf7, 0x33, 0x15, 0xb2, 0xb2, 0x45, 0xec, 0x06,
-	0x2f, 0x0c, 0x7a, 0x0d, 0x55, 0x2d, 0xe3, 0xc8, 0x67, 0x15, 0x8b, 0xd8, 0xcd, 0xee, 0x99, 0x53,
-	0xec, 0x75, 0xb6, 0x76, 0x3a, 0x7d, 0x29, 0x14, 0x0a, 0x95, 0xaa, 0x47, 0xa9, 0x71, 0xba, 0xea,
-	0xe0, 0x79, 0x23, 0x3d, 0x06, 0x73, 0x8d, 0x9f, 0xb0, 0xaa, 0x45, 0xec, 0x1a, 0xdf, 0x68, 0x7a,
-	0x0a, 0xcd, 0xaf, 0xf3, 0x38, 0xf5, 0x06, 0xb8, 0x64, 0x46, 0x06, 0xb0, 0xe7, 0xae, 0x66, 0xcc,
-	0xd1, 0x8f, 0x54, 0x24, 0x05, 0xfb, 0x6b, 0x11, 0xdb, 0xe4, 0x1b, 0xbd, 0xe2, 0x57, 0x51, 0x20,
-	0x66, 0x3a, 0x4d, 0x90, 0x99, 0x39, 0xff, 0xc6, 0x68, 0xf7, 0x80, 0x7e, 0x47, 0xa3, 0x0d, 0x30,
-	0xc7, 0xdc, 0x1d, 0xbb, 0x93, 0x9b, 0x61, 0xeb, 0x0f, 0x35, 0xa1, 0x32, 0x74, 0xfb, 0x83, 0x16,
-	0xa1, 0x00, 0x46, 0xdf, 0x1d, 0x8d, 0x1e, 0xa6, 0xad, 0x52, 0xfb, 0x9d, 0x40, 0x73, 0xeb, 0x86,
-	0x13, 0xd4, 0xbb, 0x51, 0x91, 0xfd, 0xa8, 0x7e, 0x8e, 0xf7, 0x0a, 0x1a, 0x5b, 0xaf, 0xa7, 0x58,
-	0xd9, 0x2a, 0xdb, 0xf5, 0xee, 0xd1, 0x2f, 0x39, 0xf2, 0x9d, 0xe2, 0xdb, 0xde, 0xd3, 0x65, 0x10,
-	0xe9, 0x30, 0xf5, 0x1c, 0x5f, 0x2e, 0x3a, 0x59, 0x4b, 0x9c, 0xc8, 0x67, 0xf4, 0x75, 0x2e, 0xce,
-	0x7d, 0x99, 0x60, 0x27, 0xfb, 0x11, 0x01, 0x8a, 0x4e, 0x31, 0xd3, 0x33, 0x32, 0xf3, 0xe2, 0x33,
-	0x00, 0x00, 0xff, 0xff, 0x62, 0x23, 0x92, 0xff, 0x3b, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("endorsement.proto", fileDescriptor_endorsement_121b07dd1c4051d4) }
+
+var fileDescriptor_endorsement_121b07dd1c4051d4 = []byte{
+	// 394 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x52, 0x4d, 0x8b, 0xd4, 0x40,
+	0x14, 0xb4, 0xe7, 0xcb, 0xe4, 0xed, 0x38, 0x8c, 0x8d, 0x1f, 0x8d, 0xec, 0xa1, 0xc9, 0x41, 0x1a,
+	0xc1, 0x0c, 0xac, 0x20, 0x0b, 0x5e, 0x5c, 0x07, 0x41, 0xd9, 0x5d, 0x32, 0xf4, 0x2c, 0x1e, 0xbc,
+	0x4d, 0x92, 0x47, 0x12, 0xdd, 0xed, 0x0e, 0xdd, 0x1d, 0x70, 0x8f, 0xfe, 0x12, 0x7f, 0xa6, 0x57,
+	0x99, 0x64, 0x27, 0x99, 0x8c, 0x7a, 0xdc, 0x5b, 0xaa, 0xa8, 0xce, 0xab, 0x57, 0xf5, 0xe0, 0x31,
+	0xaa, 0x54, 0x1b, 0x8b, 0x37, 0xa8, 0x5c, 0x58, 0x1a, 0xed, 0x34, 0x85, 0x42, 0x3b, 0xfc, 0xe1,
+	0x6e, 0x4b, 0xb4, 0xc1, 0xef, 0x01, 0x1c, 0x7d, 0xec, 0x14, 0xf4, 0x19, 0x4c, 0x72, 0x2c, 0xb2,
+	0xdc, 0x31, 0xc2, 0x89, 0x18, 0xc9, 0x3b, 0x44, 0x9f, 0xc0, 0xd8, 0xe8, 0x4a, 0xa5, 0x6c, 0xc0,
+	0x89, 0x78, 0x24, 0x1b, 0x40, 0x8f, 0xc1, 0x8f, 0xaf, 0x75, 0xf2, 0xfd, 0xd3, 0xc6, 0xe6, 0x6c,
+	0xc8, 0x89, 0x98, 0xca, 0x8e, 0xa0, 0xef, 0x61, 0xec, 0x74, 0x59, 0x24, 0x6c, 0xc4, 0x89, 0x98,
+	0x9d, 0xbc, 0x0a, 0xbb, 0xb9, 0xe1, 0xde, 0xcc, 0x70, 0xa9, 0x95, 0x45, 0x65, 0x2b, 0xfb, 0x45,
+	0x3b, 0xbc, 0xda, 0xbe, 0x90, 0xcd, 0x43, 0xfa, 0x02, 0xbc, 0x3b, 0xfb, 0x86, 0x8d, 0x39, 0x11,
+	0xbe, 0x6c, 0x31, 0x7d, 0x09, 0xb3, 0xdd, 0xf7, 0xaa, 0x8a, 0xcf, 0xf1, 0x96, 0x4d, 0x6a, 0x03,
+	0x07, 0xec, 0xf6, 0x1f, 0x29, 0x26, 0x85, 0x2d, 0xb4, 0x62, 0x0f, 0x39, 0x11, 0x9e, 0x6c, 0xf1,
+	0xd6, 0xbf, 0x2d, 0x32, 0xb5, 0x71, 0x95, 0x41, 0xe6, 0x35, 0xfe, 0x5b, 0x82, 0x06, 0x30, 0x8d,
+	0xaf, 0xed, 0xba, 0x15, 0xf8, 0xb5, 0xa0, 0xc7, 0x05, 0xa7, 0x40, 0xff, 0xb6, 0x4f, 0xa7, 0xe0,
+	0xad, 0x64, 0xb4, 0x8a, 0xd6, 0x67, 0x17, 0xf3, 0x07, 0xd4, 0x83, 0xd1, 0x45, 0xb4, 0x3c, 0x9f,
+	0x13, 0x0a, 0x30, 0x59, 0x46, 0x97, 0x97, 0x9f, 0xaf, 0xe6, 0x83, 0xe0, 0x27, 0x81, 0xd9, 0x5e,
+	0x0a, 0x6b, 0x74, 0xfd, 0x38, 0xc9, 0x61, 0x9c, 0xff, 0xae, 0xe0, 0x1d, 0x4c, 0xf7, 0x1a, 0xb6,
+	0x6c, 0xc8, 0x87, 0xe2, 0xe8, 0xe4, 0xf9, 0x7f, 0xb2, 0x96, 0x3d, 0x71, 0xf0, 0x8b, 0xc0, 0xd3,
+	0xb3, 0x2c, 0x33, 0x98, 0x6d, 0x1c, 0xa6, 0xf7, 0x75, 0x07, 0xc7, 0xe0, 0xef, 0x3a, 0xb1, 0x6c,
+	0xc4, 0x87, 0xc2, 0x97, 0x1d, 0xd1, 0xef, 0x60, 0x7c, 0xd0, 0xc1, 0x87, 0xd3, 0xaf, 0x6f, 0xb3,
+	0xc2, 0xe5, 0x55, 0x1c, 0x26, 0xfa, 0x66, 0x51, 0x2f, 0x55, 0x1a, 0xfd, 0x0d, 0x13, 0xd7, 0x80,
+	0xd7, 0x89, 0x36, 0xb8, 0xa8, 0xef, 0x3a, 0x43, 0xb5, 0xe8, 0xb6, 0x8e, 0x27, 0x35, 0xf9, 0xe6,
+	0xcf, 0x00, 0x81, 0x11, 0xce, 0xf3, 0x01, 0x03, 0x00, 0x00,
 }
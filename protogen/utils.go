@@ -36,6 +36,16 @@ const (
 	MsgActionType uint32 = 5
 	// MsgConsensusType is for consensus message
 	MsgConsensusType uint32 = 6
+	// MsgCompactBlockType is the compact form response to messages of type MsgBlockSyncReqType
+	MsgCompactBlockType uint32 = 7
+	// MsgBlockActionsReqType is for requests among peers to get the actions missing from a compact block
+	MsgBlockActionsReqType uint32 = 8
+	// MsgBlockActionsType is the response to messages of type MsgBlockActionsReqType
+	MsgBlockActionsType uint32 = 9
+	// MsgSnapshotChunkReqType is for requests among peers to get the chunks of a state snapshot
+	MsgSnapshotChunkReqType uint32 = 10
+	// MsgSnapshotChunkType is the response to messages of type MsgSnapshotChunkReqType
+	MsgSnapshotChunkType uint32 = 11
 	// TestPayloadType is a test payload message type
 	TestPayloadType uint32 = 10001
 )
@@ -53,6 +63,16 @@ func GetTypeFromProtoMsg(msg proto.Message) (uint32, error) {
 		return MsgActionType, nil
 	case *iotexrpc.Consensus:
 		return MsgConsensusType, nil
+	case *iotexrpc.CompactBlock:
+		return MsgCompactBlockType, nil
+	case *iotexrpc.BlockActionsRequest:
+		return MsgBlockActionsReqType, nil
+	case *iotexrpc.BlockActions:
+		return MsgBlockActionsType, nil
+	case *iotexrpc.SnapshotChunkRequest:
+		return MsgSnapshotChunkReqType, nil
+	case *iotexrpc.SnapshotChunk:
+		return MsgSnapshotChunkType, nil
 	case *testingpb.TestPayload:
 		return TestPayloadType, nil
 	default:
@@ -74,6 +94,16 @@ func TypifyProtoMsg(tp uint32, msg []byte) (proto.Message, error) {
 		m = &iotexrpc.BlockContainer{}
 	case MsgActionType:
 		m = &iotextypes.Action{}
+	case MsgCompactBlockType:
+		m = &iotexrpc.CompactBlock{}
+	case MsgBlockActionsReqType:
+		m = &iotexrpc.BlockActionsRequest{}
+	case MsgBlockActionsType:
+		m = &iotexrpc.BlockActions{}
+	case MsgSnapshotChunkReqType:
+		m = &iotexrpc.SnapshotChunkRequest{}
+	case MsgSnapshotChunkType:
+		m = &iotexrpc.SnapshotChunk{}
 	case TestPayloadType:
 		m = &testingpb.TestPayload{}
 	default:
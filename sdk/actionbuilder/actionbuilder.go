@@ -0,0 +1,344 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package actionbuilder provides the client side helpers to build, serialize, sign and estimate the fee of actions
+// offline. It doesn't depend on any node component, so that it could be used by the integrators, e.g., exchanges,
+// without running a node.
+package actionbuilder
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// actionPayload is the method set shared by all the action types, which could be wrapped by an envelope
+type actionPayload interface {
+	ByteStream() []byte
+	Cost() (*big.Int, error)
+	IntrinsicGas() (uint64, error)
+	SetEnvelopeContext(action.SealedEnvelope)
+}
+
+// Builder builds the typed actions with the common envelope fields. If the gas limit isn't set, it's set to the
+// intrinsic gas of the action, which isn't sufficient for an execution
+type Builder struct {
+	nonce    uint64
+	gasLimit uint64
+	gasPrice *big.Int
+}
+
+// New creates a builder with zero gas price
+func New() *Builder {
+	return &Builder{gasPrice: big.NewInt(0)}
+}
+
+// SetNonce sets the nonce of the action
+func (b *Builder) SetNonce(nonce uint64) *Builder {
+	b.nonce = nonce
+	return b
+}
+
+// SetGasLimit sets the gas limit of the action
+func (b *Builder) SetGasLimit(gasLimit uint64) *Builder {
+	b.gasLimit = gasLimit
+	return b
+}
+
+// SetGasPrice sets the gas price of the action
+func (b *Builder) SetGasPrice(gasPrice *big.Int) *Builder {
+	if gasPrice == nil {
+		return b
+	}
+	b.gasPrice = big.NewInt(0).Set(gasPrice)
+	return b
+}
+
+// Transfer builds a transfer of amount to recipient
+func (b *Builder) Transfer(recipient string, amount *big.Int, payload []byte) (action.Envelope, error) {
+	if err := assertAddress(recipient); err != nil {
+		return action.Envelope{}, err
+	}
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewTransfer(b.nonce, amount, recipient, payload, gasLimit, b.gasPrice)
+	})
+}
+
+// Vote builds a vote for votee. An empty votee means unvote
+func (b *Builder) Vote(votee string) (action.Envelope, error) {
+	if votee != "" {
+		if err := assertAddress(votee); err != nil {
+			return action.Envelope{}, err
+		}
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewVote(b.nonce, votee, gasLimit, b.gasPrice)
+	})
+}
+
+// Execution builds an execution of contract with data. An empty contract means deploying a new contract
+func (b *Builder) Execution(contract string, amount *big.Int, data []byte) (action.Envelope, error) {
+	if contract != action.EmptyAddress {
+		if err := assertAddress(contract); err != nil {
+			return action.Envelope{}, err
+		}
+	}
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewExecution(contract, b.nonce, amount, gasLimit, b.gasPrice, data)
+	})
+}
+
+// ClaimReward builds a claim of amount from the rewarding fund. An empty recipient means the claimer itself
+func (b *Builder) ClaimReward(amount *big.Int, recipient string, data []byte) (action.Envelope, error) {
+	if recipient != "" {
+		if err := assertAddress(recipient); err != nil {
+			return action.Envelope{}, err
+		}
+	}
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(uint64) (actionPayload, error) {
+		cb := action.ClaimFromRewardingFundBuilder{}
+		claim := cb.SetAmount(amount).SetRecipient(recipient).SetData(data).Build()
+		return &claim, nil
+	})
+}
+
+// DonateReward builds a donation of amount to the rewarding fund
+func (b *Builder) DonateReward(amount *big.Int, data []byte) (action.Envelope, error) {
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(uint64) (actionPayload, error) {
+		db := action.DonateToRewardingFundBuilder{}
+		donate := db.SetAmount(amount).SetData(data).Build()
+		return &donate, nil
+	})
+}
+
+// SetReward builds an admin action to set the reward of type t, which is one of the reward type constants in action
+// package
+func (b *Builder) SetReward(t int, amount *big.Int, data []byte) (action.Envelope, error) {
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(uint64) (actionPayload, error) {
+		sb := action.SetRewardBuilder{}
+		setReward := sb.SetRewardType(t).SetAmount(amount).SetData(data).Build()
+		return &setReward, nil
+	})
+}
+
+// UpdateAllowlist builds an admin action to add the addresses to the allowlist, or remove them from it if remove is
+// true
+func (b *Builder) UpdateAllowlist(addrs []string, remove bool) (action.Envelope, error) {
+	return b.build(func(uint64) (actionPayload, error) {
+		ub := action.UpdateAllowlistBuilder{}
+		update := ub.SetAddresses(addrs).SetRemove(remove).Build()
+		return &update, nil
+	})
+}
+
+// SetConsensusParams builds an admin action to set the block interval and the round ttls of roll-DPoS, which take
+// effect from the next epoch
+func (b *Builder) SetConsensusParams(
+	blockInterval time.Duration,
+	acceptBlockTTL time.Duration,
+	acceptProposalEndorsementTTL time.Duration,
+	acceptLockEndorsementTTL time.Duration,
+) (action.Envelope, error) {
+	return b.build(func(uint64) (actionPayload, error) {
+		sb := action.SetConsensusParamsBuilder{}
+		set := sb.SetBlockInterval(blockInterval).
+			SetAcceptTTLs(acceptBlockTTL, acceptProposalEndorsementTTL, acceptLockEndorsementTTL).
+			Build()
+		return &set, nil
+	})
+}
+
+// SessionEnvelope builds a session envelope of the micro actions signed by the devices, which is sent by a gateway
+func (b *Builder) SessionEnvelope(microActions ...*action.MicroAction) (action.Envelope, error) {
+	if len(microActions) == 0 {
+		return action.Envelope{}, errors.New("no micro action to send")
+	}
+	return b.build(func(uint64) (actionPayload, error) {
+		sb := action.SessionEnvelopeBuilder{}
+		s := sb.AddMicroActions(microActions...).Build()
+		return &s, nil
+	})
+}
+
+// PauseActions builds a governance admin action to pause the actions of the types from the start height for the
+// duration in number of blocks, or lift the pause if the duration is 0
+func (b *Builder) PauseActions(actionTypes []string, startHeight uint64, duration uint64) (action.Envelope, error) {
+	if len(actionTypes) == 0 {
+		return action.Envelope{}, errors.New("no action type to pause")
+	}
+	return b.build(func(uint64) (actionPayload, error) {
+		pb := action.PauseActionsBuilder{}
+		pause := pb.SetActionTypes(actionTypes).SetStartHeight(startHeight).SetDuration(duration).Build()
+		return &pause, nil
+	})
+}
+
+// RegisterBLSKey builds a registration of the BLS public key of the sender, which comes with the signature of
+// action.BLSPossessionMessage by the BLS private key as the proof of possession
+func (b *Builder) RegisterBLSKey(publicKey []byte, proof []byte) (action.Envelope, error) {
+	if len(publicKey) == 0 || len(proof) == 0 {
+		return action.Envelope{}, errors.New("BLS public key or proof of possession is empty")
+	}
+	return b.build(func(uint64) (actionPayload, error) {
+		rb := action.RegisterBLSKeyBuilder{}
+		r := rb.SetPublicKey(publicKey, proof).Build()
+		return &r, nil
+	})
+}
+
+// CreateDeposit builds a deposit of amount from the main chain to recipient on sub-chain chainID
+func (b *Builder) CreateDeposit(chainID uint32, recipient string, amount *big.Int) (action.Envelope, error) {
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewCreateDeposit(b.nonce, chainID, amount, recipient, gasLimit, b.gasPrice), nil
+	})
+}
+
+// SettleDeposit builds a settlement of the deposit of index to recipient on sub-chain
+func (b *Builder) SettleDeposit(index uint64, recipient string, amount *big.Int) (action.Envelope, error) {
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewSettleDeposit(b.nonce, amount, index, recipient, gasLimit, b.gasPrice), nil
+	})
+}
+
+// CreateWithdraw builds a withdraw of amount from sub-chain to recipient on the main chain
+func (b *Builder) CreateWithdraw(recipient string, amount *big.Int) (action.Envelope, error) {
+	if err := assertAmount(amount); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewCreateWithdraw(b.nonce, amount, recipient, gasLimit, b.gasPrice), nil
+	})
+}
+
+// SettleWithdraw builds a settlement of the withdraw on the main chain, given the sub-chain block of height including
+// it at index, and the merkle proof of the inclusion
+func (b *Builder) SettleWithdraw(
+	subChainAddr string,
+	height uint64,
+	withdraw action.SealedEnvelope,
+	index uint32,
+	proof []hash.Hash256,
+) (action.Envelope, error) {
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewSettleWithdraw(b.nonce, subChainAddr, height, withdraw, index, proof, gasLimit, b.gasPrice), nil
+	})
+}
+
+// StartSubChain builds an action to start sub-chain chainID
+func (b *Builder) StartSubChain(
+	chainID uint32,
+	securityDeposit *big.Int,
+	operationDeposit *big.Int,
+	startHeight uint64,
+	parentHeightOffset uint64,
+) (action.Envelope, error) {
+	if err := assertAmount(securityDeposit); err != nil {
+		return action.Envelope{}, err
+	}
+	if err := assertAmount(operationDeposit); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewStartSubChain(
+			b.nonce,
+			chainID,
+			securityDeposit,
+			operationDeposit,
+			startHeight,
+			parentHeightOffset,
+			gasLimit,
+			b.gasPrice,
+		), nil
+	})
+}
+
+// StopSubChain builds an action to stop the sub-chain of chainAddress at stopHeight
+func (b *Builder) StopSubChain(chainAddress string, stopHeight uint64) (action.Envelope, error) {
+	if err := assertAddress(chainAddress); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewStopSubChain(b.nonce, chainAddress, stopHeight, gasLimit, b.gasPrice), nil
+	})
+}
+
+// PutBlock builds an action to put the merkle roots of a sub-chain block at height on the main chain
+func (b *Builder) PutBlock(
+	subChainAddress string,
+	height uint64,
+	roots map[string]hash.Hash256,
+) (action.Envelope, error) {
+	if err := assertAddress(subChainAddress); err != nil {
+		return action.Envelope{}, err
+	}
+	return b.build(func(gasLimit uint64) (actionPayload, error) {
+		return action.NewPutBlock(b.nonce, subChainAddress, height, roots, gasLimit, b.gasPrice), nil
+	})
+}
+
+// build creates the payload with the gas limit, which defaults to the intrinsic gas, and wraps it in an envelope
+func (b *Builder) build(newPayload func(gasLimit uint64) (actionPayload, error)) (action.Envelope, error) {
+	gasLimit := b.gasLimit
+	if gasLimit == 0 {
+		p, err := newPayload(0)
+		if err != nil {
+			return action.Envelope{}, err
+		}
+		if gasLimit, err = p.IntrinsicGas(); err != nil {
+			return action.Envelope{}, errors.Wrap(err, "failed to get the intrinsic gas")
+		}
+	}
+	p, err := newPayload(gasLimit)
+	if err != nil {
+		return action.Envelope{}, err
+	}
+	eb := action.EnvelopeBuilder{}
+	return eb.SetNonce(b.nonce).
+		SetGasLimit(gasLimit).
+		SetGasPrice(b.gasPrice).
+		SetAction(p).
+		Build(), nil
+}
+
+func assertAddress(addr string) error {
+	if _, err := address.FromString(addr); err != nil {
+		return errors.Wrapf(err, "invalid address %s", addr)
+	}
+	return nil
+}
+
+func assertAmount(amount *big.Int) error {
+	if amount == nil || amount.Sign() < 0 {
+		return errors.Wrapf(action.ErrBalance, "invalid amount %v", amount)
+	}
+	return nil
+}
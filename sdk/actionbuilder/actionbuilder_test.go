@@ -0,0 +1,194 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actionbuilder
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestBuilder(t *testing.T) {
+	recipient := testaddress.Addrinfo["bravo"].String()
+	b := New().SetNonce(3).SetGasPrice(big.NewInt(2))
+
+	// The gas limit defaults to the intrinsic gas
+	elp, err := b.Transfer(recipient, big.NewInt(10), []byte{1, 2})
+	require.NoError(t, err)
+	tsf, ok := elp.Action().(*action.Transfer)
+	require.True(t, ok)
+	assert.Equal(t, recipient, tsf.Recipient())
+	assert.Equal(t, big.NewInt(10), tsf.Amount())
+	assert.Equal(t, uint64(3), elp.Nonce())
+	intrinsicGas, err := tsf.IntrinsicGas()
+	require.NoError(t, err)
+	assert.Equal(t, intrinsicGas, elp.GasLimit())
+	fee, err := EstimateFee(elp)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(0).SetUint64(2*intrinsicGas), fee)
+	assert.Equal(t, fee, MaxFee(elp))
+	cost, err := Cost(elp)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(0).Add(fee, big.NewInt(10)), cost)
+
+	b.SetGasLimit(100000)
+	elp, err = b.Execution(action.EmptyAddress, big.NewInt(0), []byte{0x60})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100000), elp.GasLimit())
+	assert.Equal(t, big.NewInt(200000), MaxFee(elp))
+	_, ok = elp.Action().(*action.Execution)
+	assert.True(t, ok)
+
+	elp, err = b.Vote(recipient)
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.Vote)
+	assert.True(t, ok)
+
+	elp, err = b.ClaimReward(big.NewInt(5), recipient, nil)
+	require.NoError(t, err)
+	claim, ok := elp.Action().(*action.ClaimFromRewardingFund)
+	require.True(t, ok)
+	assert.Equal(t, recipient, claim.Recipient())
+
+	elp, err = b.DonateReward(big.NewInt(5), nil)
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.DepositToRewardingFund)
+	assert.True(t, ok)
+
+	elp, err = b.SetReward(action.BlockReward, big.NewInt(5), nil)
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.SetReward)
+	assert.True(t, ok)
+
+	elp, err = b.UpdateAllowlist([]string{recipient}, true)
+	require.NoError(t, err)
+	update, ok := elp.Action().(*action.UpdateAllowlist)
+	require.True(t, ok)
+	assert.Equal(t, []string{recipient}, update.Addresses())
+	assert.True(t, update.Remove())
+
+	elp, err = b.SetConsensusParams(5*time.Second, time.Second, time.Second, time.Second)
+	require.NoError(t, err)
+	set, ok := elp.Action().(*action.SetConsensusParams)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, set.BlockInterval())
+
+	micro, err := action.NewMicroAction(
+		1,
+		testaddress.Addrinfo["alfa"].String(),
+		1,
+		[]byte("t=21"),
+		testaddress.Keyinfo["bravo"].PriKey,
+	)
+	require.NoError(t, err)
+	elp, err = b.SessionEnvelope(micro)
+	require.NoError(t, err)
+	session, ok := elp.Action().(*action.SessionEnvelope)
+	require.True(t, ok)
+	assert.Equal(t, 1, len(session.MicroActions()))
+	_, err = b.SessionEnvelope()
+	assert.Error(t, err)
+
+	elp, err = b.PauseActions([]string{"Execution"}, 10, 100)
+	require.NoError(t, err)
+	pause, ok := elp.Action().(*action.PauseActions)
+	require.True(t, ok)
+	assert.Equal(t, []string{"Execution"}, pause.ActionTypes())
+	assert.Equal(t, uint64(10), pause.StartHeight())
+	assert.Equal(t, uint64(100), pause.Duration())
+	_, err = b.PauseActions(nil, 10, 100)
+	assert.Error(t, err)
+
+	elp, err = b.RegisterBLSKey([]byte("public key"), []byte("proof"))
+	require.NoError(t, err)
+	register, ok := elp.Action().(*action.RegisterBLSKey)
+	require.True(t, ok)
+	assert.Equal(t, []byte("public key"), register.PublicKey())
+	_, err = b.RegisterBLSKey([]byte("public key"), nil)
+	assert.Error(t, err)
+
+	elp, err = b.CreateDeposit(2, recipient, big.NewInt(5))
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.CreateDeposit)
+	assert.True(t, ok)
+
+	elp, err = b.SettleDeposit(1, recipient, big.NewInt(5))
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.SettleDeposit)
+	assert.True(t, ok)
+
+	elp, err = b.CreateWithdraw(recipient, big.NewInt(5))
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.CreateWithdraw)
+	assert.True(t, ok)
+	withdraw, err := action.Sign(elp, testaddress.Keyinfo["alfa"].PriKey)
+	require.NoError(t, err)
+
+	elp, err = b.SettleWithdraw(recipient, 10, withdraw, 0, []hash.Hash256{hash.ZeroHash256})
+	require.NoError(t, err)
+	settle, ok := elp.Action().(*action.SettleWithdraw)
+	require.True(t, ok)
+	assert.Equal(t, withdraw.Proto(), settle.Withdraw().Proto())
+
+	elp, err = b.StartSubChain(2, big.NewInt(1), big.NewInt(1), 10, 1)
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.StartSubChain)
+	assert.True(t, ok)
+
+	elp, err = b.StopSubChain(recipient, 10)
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.StopSubChain)
+	assert.True(t, ok)
+
+	elp, err = b.PutBlock(recipient, 10, map[string]hash.Hash256{"state": hash.ZeroHash256})
+	require.NoError(t, err)
+	_, ok = elp.Action().(*action.PutBlock)
+	assert.True(t, ok)
+
+	// Invalid inputs
+	_, err = b.Transfer("invalid", big.NewInt(10), nil)
+	assert.Error(t, err)
+	_, err = b.Transfer(recipient, big.NewInt(-1), nil)
+	assert.Error(t, err)
+	_, err = b.ClaimReward(nil, "", nil)
+	assert.Error(t, err)
+	_, err = b.StopSubChain("", 10)
+	assert.Error(t, err)
+}
+
+func TestSignAndSerialize(t *testing.T) {
+	sk := testaddress.Keyinfo["alfa"].PriKey
+	elp, err := New().SetNonce(1).Transfer(testaddress.Addrinfo["bravo"].String(), big.NewInt(10), nil)
+	require.NoError(t, err)
+
+	selp, err := Sign(elp, sk)
+	require.NoError(t, err)
+	selp2, err := SignWithHexKey(elp, hex.EncodeToString(keypair.PrivateKeyToBytes(sk)))
+	require.NoError(t, err)
+	assert.Equal(t, Hash(selp), Hash(selp2))
+	_, err = SignWithHexKey(elp, "invalid")
+	assert.Error(t, err)
+
+	data, err := Serialize(selp)
+	require.NoError(t, err)
+	selp3, err := Deserialize(data)
+	require.NoError(t, err)
+	assert.Equal(t, Hash(selp), Hash(selp3))
+	assert.Equal(t, testaddress.Keyinfo["alfa"].PubKey, selp3.SrcPubkey())
+
+	_, err = Deserialize([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
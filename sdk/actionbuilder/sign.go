@@ -0,0 +1,79 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actionbuilder
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// Sign signs the action with the private key offline
+func Sign(elp action.Envelope, sk keypair.PrivateKey) (action.SealedEnvelope, error) {
+	return action.Sign(elp, sk)
+}
+
+// SignWithHexKey signs the action with the hex encoded private key offline
+func SignWithHexKey(elp action.Envelope, skHex string) (action.SealedEnvelope, error) {
+	sk, err := keypair.DecodePrivateKey(skHex)
+	if err != nil {
+		return action.SealedEnvelope{}, errors.Wrap(err, "failed to decode private key")
+	}
+	return action.Sign(elp, sk)
+}
+
+// Serialize returns the canonical serialization of the signed action, which is the protobuf accepted by the API
+func Serialize(selp action.SealedEnvelope) ([]byte, error) {
+	return proto.Marshal(selp.Proto())
+}
+
+// Deserialize parses the signed action from its canonical serialization, and verifies its signature
+func Deserialize(data []byte) (action.SealedEnvelope, error) {
+	pb := iotextypes.Action{}
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return action.SealedEnvelope{}, errors.Wrap(err, "failed to unmarshal action")
+	}
+	selp := action.SealedEnvelope{}
+	if err := selp.LoadProto(&pb); err != nil {
+		return action.SealedEnvelope{}, err
+	}
+	if err := action.Verify(selp); err != nil {
+		return action.SealedEnvelope{}, err
+	}
+	return selp, nil
+}
+
+// Hash returns the hash of the signed action, which is used to look up the action and its receipt
+func Hash(selp action.SealedEnvelope) hash.Hash256 {
+	return selp.Hash()
+}
+
+// EstimateFee returns the fee charged for the intrinsic gas of the action. The fee of an execution could be higher
+// depending on the gas consumed by the contract
+func EstimateFee(elp action.Envelope) (*big.Int, error) {
+	intrinsicGas, err := elp.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(0).Mul(elp.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
+
+// MaxFee returns the max fee that could be charged for the action, which is gas limit times gas price
+func MaxFee(elp action.Envelope) *big.Int {
+	return big.NewInt(0).Mul(elp.GasPrice(), big.NewInt(0).SetUint64(elp.GasLimit()))
+}
+
+// Cost returns the total balance required by the action, i.e., the amount transferred plus the estimated fee
+func Cost(elp action.Envelope) (*big.Int, error) {
+	return elp.Cost()
+}
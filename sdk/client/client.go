@@ -0,0 +1,320 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package client wraps the gRPC API of the nodes with connection pooling, retries of the idempotent reads and failover
+// among multiple endpoints, and provides the typed helpers for the common queries.
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// ErrNoEndpoint indicates that no endpoint is given to the client
+var ErrNoEndpoint = errors.New("no endpoint")
+
+type (
+	// Client is the API client which fails over among the endpoints
+	Client struct {
+		mu            sync.Mutex
+		endpoints     []*endpoint
+		current       int
+		maxRetries    int
+		retryInterval time.Duration
+		connsPerEnd   int
+		dialOpts      []grpc.DialOption
+	}
+
+	// endpoint is the pool of connections to a node
+	endpoint struct {
+		addr  string
+		conns []*grpc.ClientConn
+		apis  []iotexapi.APIServiceClient
+		next  int
+	}
+
+	// Option sets client construction parameter
+	Option func(*Client) error
+)
+
+// WithMaxRetries sets the max number of retries of a failed idempotent read
+func WithMaxRetries(n int) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return errors.Errorf("invalid max retries %d", n)
+		}
+		c.maxRetries = n
+		return nil
+	}
+}
+
+// WithRetryInterval sets the interval between retries
+func WithRetryInterval(d time.Duration) Option {
+	return func(c *Client) error {
+		c.retryInterval = d
+		return nil
+	}
+}
+
+// WithConnsPerEndpoint sets the number of connections to each endpoint, among which the calls are balanced
+func WithConnsPerEndpoint(n int) Option {
+	return func(c *Client) error {
+		if n <= 0 {
+			return errors.Errorf("invalid number of connections %d", n)
+		}
+		c.connsPerEnd = n
+		return nil
+	}
+}
+
+// WithDialOptions sets the gRPC dial options, which replace the default insecure option
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Client) error {
+		c.dialOpts = opts
+		return nil
+	}
+}
+
+// New creates a client connecting to the endpoints. The calls are sent to the first endpoint until it fails, and then
+// fail over to the next one
+func New(endpoints []string, opts ...Option) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoint
+	}
+	c := &Client{
+		maxRetries:    3,
+		retryInterval: 100 * time.Millisecond,
+		connsPerEnd:   1,
+		dialOpts:      []grpc.DialOption{grpc.WithInsecure()},
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range endpoints {
+		ep := &endpoint{addr: addr}
+		for i := 0; i < c.connsPerEnd; i++ {
+			conn, err := grpc.Dial(addr, c.dialOpts...)
+			if err != nil {
+				c.Close()
+				return nil, errors.Wrapf(err, "failed to dial %s", addr)
+			}
+			ep.conns = append(ep.conns, conn)
+			ep.apis = append(ep.apis, iotexapi.NewAPIServiceClient(conn))
+		}
+		c.endpoints = append(c.endpoints, ep)
+	}
+	return c, nil
+}
+
+// Close closes all the connections
+func (c *Client) Close() error {
+	var err error
+	for _, ep := range c.endpoints {
+		for _, conn := range ep.conns {
+			if e := conn.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+// Endpoint returns the address of the endpoint the calls are being sent to
+func (c *Client) Endpoint() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.endpoints[c.current].addr
+}
+
+// Read invokes an idempotent read, which is retried on the next endpoint if it fails with a retryable error
+func (c *Client) Read(ctx context.Context, call func(iotexapi.APIServiceClient) error) error {
+	return c.invoke(ctx, true, call)
+}
+
+// GetAccount returns the account meta of an address
+func (c *Client) GetAccount(ctx context.Context, addr string) (*iotextypes.AccountMeta, error) {
+	var res *iotexapi.GetAccountResponse
+	err := c.Read(ctx, func(api iotexapi.APIServiceClient) (err error) {
+		res, err = api.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: addr})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.AccountMeta, nil
+}
+
+// Balance returns the balance of an address
+func (c *Client) Balance(ctx context.Context, addr string) (*big.Int, error) {
+	meta, err := c.GetAccount(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := big.NewInt(0).SetString(meta.Balance, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid balance %s of %s", meta.Balance, addr)
+	}
+	return balance, nil
+}
+
+// PendingNonce returns the nonce to use for the next action of an address
+func (c *Client) PendingNonce(ctx context.Context, addr string) (uint64, error) {
+	meta, err := c.GetAccount(ctx, addr)
+	if err != nil {
+		return 0, err
+	}
+	return meta.PendingNonce, nil
+}
+
+// GetChainMeta returns the chain meta
+func (c *Client) GetChainMeta(ctx context.Context) (*iotextypes.ChainMeta, error) {
+	var res *iotexapi.GetChainMetaResponse
+	err := c.Read(ctx, func(api iotexapi.APIServiceClient) (err error) {
+		res, err = api.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.ChainMeta, nil
+}
+
+// TipHeight returns the tip height of the chain
+func (c *Client) TipHeight(ctx context.Context) (uint64, error) {
+	meta, err := c.GetChainMeta(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(meta.Height), nil
+}
+
+// GetReceipt returns the receipt of an action
+func (c *Client) GetReceipt(ctx context.Context, actHash hash.Hash256) (*iotextypes.Receipt, error) {
+	var res *iotexapi.GetReceiptByActionResponse
+	err := c.Read(ctx, func(api iotexapi.APIServiceClient) (err error) {
+		res, err = api.GetReceiptByAction(
+			ctx,
+			&iotexapi.GetReceiptByActionRequest{ActionHash: hex.EncodeToString(actHash[:])},
+		)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Receipt, nil
+}
+
+// SuggestGasPrice returns the gas price suggested by the node
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var res *iotexapi.SuggestGasPriceResponse
+	err := c.Read(ctx, func(api iotexapi.APIServiceClient) (err error) {
+		res, err = api.SuggestGasPrice(ctx, &iotexapi.SuggestGasPriceRequest{})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(0).SetUint64(res.GasPrice), nil
+}
+
+// ReadState reads the state of a protocol
+func (c *Client) ReadState(ctx context.Context, protocolID string, method string, args ...[]byte) ([]byte, error) {
+	var res *iotexapi.ReadStateResponse
+	err := c.Read(ctx, func(api iotexapi.APIServiceClient) (err error) {
+		res, err = api.ReadState(ctx, &iotexapi.ReadStateRequest{
+			ProtocolID: []byte(protocolID),
+			MethodName: []byte(method),
+			Arguments:  args,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// SendAction sends a signed action, and returns its hash. Unlike the reads, it's only sent to the next endpoint if the
+// current one is unavailable
+func (c *Client) SendAction(ctx context.Context, selp action.SealedEnvelope) (hash.Hash256, error) {
+	err := c.invoke(ctx, false, func(api iotexapi.APIServiceClient) error {
+		_, err := api.SendAction(ctx, &iotexapi.SendActionRequest{Action: selp.Proto()})
+		return err
+	})
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return selp.Hash(), nil
+}
+
+func (c *Client) invoke(ctx context.Context, idempotent bool, call func(iotexapi.APIServiceClient) error) error {
+	var err error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return errors.Wrapf(ctx.Err(), "last error: %v", err)
+			case <-time.After(c.retryInterval):
+			}
+		}
+		api, idx := c.pick()
+		if err = call(api); err == nil {
+			return nil
+		}
+		if !retryable(err, idempotent) {
+			return err
+		}
+		c.failover(idx)
+	}
+	return errors.Wrapf(err, "failed after %d retries", c.maxRetries)
+}
+
+// pick returns the next connection of the current endpoint in round robin
+func (c *Client) pick() (iotexapi.APIServiceClient, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ep := c.endpoints[c.current]
+	api := ep.apis[ep.next]
+	ep.next = (ep.next + 1) % len(ep.apis)
+	return api, c.current
+}
+
+// failover switches to the next endpoint, unless another call has already switched away from the failed one
+func (c *Client) failover(failed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current == failed {
+		c.current = (c.current + 1) % len(c.endpoints)
+	}
+}
+
+// retryable returns whether a failed call could be retried. An idempotent read is retried if the node is unavailable,
+// overloaded or timed out, while other calls are only retried if the node is unavailable
+func retryable(err error, idempotent bool) bool {
+	switch status.Code(errors.Cause(err)) {
+	case codes.Unavailable:
+		return true
+	case codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return idempotent
+	default:
+		return false
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// fakeServer serves the chain meta and the accounts, and fails the first failures calls with code
+type fakeServer struct {
+	iotexapi.APIServiceServer
+	calls    int32
+	failures int32
+	code     codes.Code
+}
+
+func (s *fakeServer) fail() error {
+	if atomic.AddInt32(&s.calls, 1) <= s.failures {
+		return status.Error(s.code, "injected failure")
+	}
+	return nil
+}
+
+func (s *fakeServer) GetChainMeta(context.Context, *iotexapi.GetChainMetaRequest) (*iotexapi.GetChainMetaResponse, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetChainMetaResponse{ChainMeta: &iotextypes.ChainMeta{Height: 10}}, nil
+}
+
+func (s *fakeServer) GetAccount(_ context.Context, in *iotexapi.GetAccountRequest) (*iotexapi.GetAccountResponse, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return &iotexapi.GetAccountResponse{AccountMeta: &iotextypes.AccountMeta{
+		Address:      in.Address,
+		Balance:      "100",
+		PendingNonce: 3,
+	}}, nil
+}
+
+func startFakeServer(t *testing.T, s *fakeServer) (string, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	svr := grpc.NewServer()
+	iotexapi.RegisterAPIServiceServer(svr, s)
+	go func() {
+		_ = svr.Serve(lis)
+	}()
+	return lis.Addr().String(), svr.Stop
+}
+
+// closedEndpoint returns an address no one is listening on
+func closedEndpoint(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+	return addr
+}
+
+func TestClient_Failover(t *testing.T) {
+	require := require.New(t)
+
+	s := &fakeServer{}
+	addr, stop := startFakeServer(t, s)
+	defer stop()
+	down := closedEndpoint(t)
+
+	c, err := New([]string{down, addr}, WithRetryInterval(time.Millisecond), WithConnsPerEndpoint(2))
+	require.NoError(err)
+	defer func() { require.NoError(c.Close()) }()
+	require.Equal(down, c.Endpoint())
+
+	ctx := context.Background()
+	height, err := c.TipHeight(ctx)
+	require.NoError(err)
+	require.Equal(uint64(10), height)
+	require.Equal(addr, c.Endpoint())
+
+	balance, err := c.Balance(ctx, "io1test")
+	require.NoError(err)
+	require.Equal(big.NewInt(100), balance)
+	nonce, err := c.PendingNonce(ctx, "io1test")
+	require.NoError(err)
+	require.Equal(uint64(3), nonce)
+
+	_, err = New(nil)
+	require.Equal(ErrNoEndpoint, err)
+	_, err = New([]string{addr}, WithMaxRetries(-1))
+	require.Error(err)
+	_, err = New([]string{addr}, WithConnsPerEndpoint(0))
+	require.Error(err)
+}
+
+func TestClient_Retry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// The retryable failures are retried
+	s := &fakeServer{failures: 2, code: codes.ResourceExhausted}
+	addr, stop := startFakeServer(t, s)
+	defer stop()
+	c, err := New([]string{addr}, WithRetryInterval(time.Millisecond), WithMaxRetries(2))
+	require.NoError(err)
+	defer func() { require.NoError(c.Close()) }()
+	_, err = c.GetChainMeta(ctx)
+	require.NoError(err)
+	require.Equal(int32(3), atomic.LoadInt32(&s.calls))
+
+	// Give up after the max retries
+	atomic.StoreInt32(&s.calls, 0)
+	s.failures = 3
+	_, err = c.GetChainMeta(ctx)
+	require.Error(err)
+	require.Equal(int32(3), atomic.LoadInt32(&s.calls))
+
+	// The non-retryable failures are returned immediately
+	atomic.StoreInt32(&s.calls, 0)
+	s.code = codes.InvalidArgument
+	_, err = c.GetChainMeta(ctx)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	require.Equal(int32(1), atomic.LoadInt32(&s.calls))
+
+	require.True(retryable(status.Error(codes.Unavailable, ""), false))
+	require.False(retryable(status.Error(codes.DeadlineExceeded, ""), false))
+	require.True(retryable(status.Error(codes.DeadlineExceeded, ""), true))
+}
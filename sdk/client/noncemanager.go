@@ -0,0 +1,148 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// AccountQuerier queries the account meta, which contains the confirmed nonce and the pending nonce including the
+// actions in the pool. Client implements it
+type AccountQuerier interface {
+	GetAccount(ctx context.Context, addr string) (*iotextypes.AccountMeta, error)
+}
+
+// NonceManager allocates the nonces of an address for concurrent submissions. It tracks the in-flight nonces, i.e.,
+// the allocated ones which haven't been confirmed yet, and reuses the released ones first so that no gap is left
+type NonceManager struct {
+	mu        sync.Mutex
+	querier   AccountQuerier
+	addr      string
+	synced    bool
+	confirmed uint64
+	next      uint64
+	inflight  map[uint64]bool
+	released  []uint64
+}
+
+// NewNonceManager creates a nonce manager of an address
+func NewNonceManager(querier AccountQuerier, addr string) *NonceManager {
+	return &NonceManager{
+		querier:  querier,
+		addr:     addr,
+		inflight: map[uint64]bool{},
+	}
+}
+
+// Next allocates a nonce for a new action. The smallest released nonce is reused if any, otherwise a new nonce is
+// allocated. It syncs with the node before allocating the first nonce
+func (m *NonceManager) Next(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.synced {
+		if _, err := m.sync(ctx); err != nil {
+			return 0, err
+		}
+	}
+	var nonce uint64
+	if len(m.released) > 0 {
+		nonce = m.released[0]
+		m.released = m.released[1:]
+	} else {
+		nonce = m.next
+		m.next++
+	}
+	m.inflight[nonce] = true
+	return nonce, nil
+}
+
+// Release returns an allocated nonce whose action failed to be submitted, so that it will be reused by the next
+// allocation
+func (m *NonceManager) Release(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.inflight[nonce] {
+		return
+	}
+	delete(m.inflight, nonce)
+	m.released = append(m.released, nonce)
+	sort.Slice(m.released, func(i, j int) bool { return m.released[i] < m.released[j] })
+}
+
+// Confirm marks the action of an allocated nonce as confirmed on chain
+func (m *NonceManager) Confirm(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.inflight, nonce)
+	if nonce > m.confirmed {
+		m.confirmed = nonce
+	}
+}
+
+// InFlight returns the allocated nonces which haven't been confirmed yet in ascending order
+func (m *NonceManager) InFlight() []uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inflightNonces()
+}
+
+// Resync syncs with the confirmed and the pending nonces on the node to recover from gaps. The in-flight nonces below
+// the confirmed nonce are dropped. It returns the in-flight nonces which are unknown to the node, e.g., the actions
+// were dropped by the pool, and the caller should resubmit the actions of them to fill the gap
+func (m *NonceManager) Resync(ctx context.Context) ([]uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sync(ctx)
+}
+
+func (m *NonceManager) sync(ctx context.Context) ([]uint64, error) {
+	meta, err := m.querier.GetAccount(ctx, m.addr)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Nonce > m.confirmed {
+		m.confirmed = meta.Nonce
+	}
+	for nonce := range m.inflight {
+		if nonce <= m.confirmed {
+			delete(m.inflight, nonce)
+		}
+	}
+	released := m.released[:0]
+	for _, nonce := range m.released {
+		if nonce > m.confirmed {
+			released = append(released, nonce)
+		}
+	}
+	m.released = released
+	// The nonces may have been used by another sender of the same address
+	if m.next < meta.PendingNonce {
+		m.next = meta.PendingNonce
+	}
+	m.synced = true
+
+	var missing []uint64
+	for _, nonce := range m.inflightNonces() {
+		if nonce >= meta.PendingNonce {
+			missing = append(missing, nonce)
+		}
+	}
+	return missing, nil
+}
+
+func (m *NonceManager) inflightNonces() []uint64 {
+	nonces := make([]uint64, 0, len(m.inflight))
+	for nonce := range m.inflight {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+type fakeQuerier struct {
+	meta *iotextypes.AccountMeta
+	err  error
+}
+
+func (q *fakeQuerier) GetAccount(context.Context, string) (*iotextypes.AccountMeta, error) {
+	return q.meta, q.err
+}
+
+func TestNonceManager(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	q := &fakeQuerier{err: errors.New("unavailable")}
+	m := NewNonceManager(q, "io1test")
+	_, err := m.Next(ctx)
+	require.Error(err)
+
+	// Nonce 1 to 3 are confirmed, and 4 is in the pool
+	q.err = nil
+	q.meta = &iotextypes.AccountMeta{Nonce: 3, PendingNonce: 5}
+	var wg sync.WaitGroup
+	nonces := make([]uint64, 10)
+	errs := make([]error, 10)
+	for i := range nonces {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			nonces[i], errs[i] = m.Next(ctx)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(err)
+	}
+	allocated := map[uint64]bool{}
+	for _, nonce := range nonces {
+		allocated[nonce] = true
+	}
+	require.Equal(10, len(allocated))
+	require.Equal([]uint64{5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, m.InFlight())
+
+	// The released nonces are reused first
+	m.Release(9)
+	m.Release(7)
+	m.Release(100)
+	nonce, err := m.Next(ctx)
+	require.NoError(err)
+	require.Equal(uint64(7), nonce)
+	nonce, err = m.Next(ctx)
+	require.NoError(err)
+	require.Equal(uint64(9), nonce)
+	nonce, err = m.Next(ctx)
+	require.NoError(err)
+	require.Equal(uint64(15), nonce)
+
+	m.Confirm(5)
+	m.Confirm(6)
+	require.Equal([]uint64{7, 8, 9, 10, 11, 12, 13, 14, 15}, m.InFlight())
+
+	// Up to 8 are confirmed, and 9 to 11 are in the pool, so 12 to 15 are missing
+	q.meta = &iotextypes.AccountMeta{Nonce: 8, PendingNonce: 12}
+	missing, err := m.Resync(ctx)
+	require.NoError(err)
+	require.Equal([]uint64{12, 13, 14, 15}, missing)
+	require.Equal([]uint64{9, 10, 11, 12, 13, 14, 15}, m.InFlight())
+
+	// Another sender of the same address has used up to 20
+	q.meta = &iotextypes.AccountMeta{Nonce: 20, PendingNonce: 21}
+	missing, err = m.Resync(ctx)
+	require.NoError(err)
+	require.Empty(missing)
+	require.Empty(m.InFlight())
+	nonce, err = m.Next(ctx)
+	require.NoError(err)
+	require.Equal(uint64(21), nonce)
+}
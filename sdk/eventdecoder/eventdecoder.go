@@ -0,0 +1,229 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package eventdecoder decodes the logs in the receipts into the typed events with the contract ABI. The indexed
+// arguments are decoded from the topics, and the others from the data.
+package eventdecoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/iotexproject/go-ethereum/accounts/abi"
+	"github.com/iotexproject/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// ErrUnknownEvent indicates that the log isn't emitted by any event defined in the ABI
+var ErrUnknownEvent = errors.New("unknown event")
+
+type (
+	// Decoder decodes the logs of the events defined in a contract ABI. The anonymous events can't be decoded, since
+	// their signatures aren't in the topics
+	Decoder struct {
+		events map[hash.Hash256]abi.Event
+	}
+
+	// Event is a log decoded with the event definition
+	Event struct {
+		Name    string
+		Address string // address of the contract emitting the log
+		Index   uint   // index of the log in the block
+		Args    []Arg
+	}
+
+	// Arg is a decoded argument of an event. The value of an indexed argument of dynamic type, e.g., string, bytes and
+	// slice, is the hash of it as a hash.Hash256, because only the hash is kept in the topic
+	Arg struct {
+		Name    string
+		Type    string
+		Indexed bool
+		Value   interface{}
+	}
+)
+
+// New creates a decoder from the contract ABI in JSON
+func New(abiJSON string) (*Decoder, error) {
+	contract, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the ABI")
+	}
+	d := &Decoder{events: make(map[hash.Hash256]abi.Event)}
+	for _, event := range contract.Events {
+		if event.Anonymous {
+			continue
+		}
+		d.events[hash.Hash256(event.Id())] = event
+	}
+	return d, nil
+}
+
+// DecodeLog decodes a log. ErrUnknownEvent is returned if the log isn't emitted by any event in the ABI
+func (d *Decoder) DecodeLog(log *action.Log) (*Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrUnknownEvent
+	}
+	event, ok := d.events[log.Topics[0]]
+	if !ok {
+		return nil, ErrUnknownEvent
+	}
+	var indexed, nonIndexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		} else {
+			nonIndexed = append(nonIndexed, input)
+		}
+	}
+	if len(log.Topics) != len(indexed)+1 {
+		return nil, errors.Errorf(
+			"event %s has %d indexed arguments, but the log has %d topics",
+			event.Name,
+			len(indexed),
+			len(log.Topics),
+		)
+	}
+	values, err := nonIndexed.UnpackValues(log.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unpack the data of event %s", event.Name)
+	}
+
+	decoded := &Event{Name: event.Name, Address: log.Address, Index: log.Index}
+	var numIndexed, numNonIndexed int
+	for i, input := range event.Inputs {
+		arg := Arg{Name: input.Name, Type: input.Type.String(), Indexed: input.Indexed}
+		if arg.Name == "" {
+			arg.Name = fmt.Sprintf("arg%d", i)
+		}
+		if input.Indexed {
+			numIndexed++
+			if arg.Value, err = decodeTopic(input, log.Topics[numIndexed]); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode the topic of event %s", event.Name)
+			}
+		} else {
+			arg.Value = values[numNonIndexed]
+			numNonIndexed++
+		}
+		decoded.Args = append(decoded.Args, arg)
+	}
+	return decoded, nil
+}
+
+// DecodeReceipt decodes the logs in a receipt, skipping the ones which aren't emitted by the events in the ABI
+func (d *Decoder) DecodeReceipt(receipt *action.Receipt) ([]*Event, error) {
+	var events []*Event
+	for _, log := range receipt.Logs {
+		event, err := d.DecodeLog(log)
+		if errors.Cause(err) == ErrUnknownEvent {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Unpack decodes a log into the struct pointed by out. An argument is set to the field tagged with `abi:"<name>"`,
+// or else the field of the capitalized name. A string field is set to the formatted value of the argument
+func (d *Decoder) Unpack(out interface{}, log *action.Log) error {
+	event, err := d.DecodeLog(log)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("cannot unpack into %T, which isn't a pointer to struct", out)
+	}
+	v = v.Elem()
+	for _, arg := range event.Args {
+		field, ok := fieldOf(v.Type(), arg.Name)
+		if !ok {
+			continue
+		}
+		dst := v.FieldByIndex(field.Index)
+		src := reflect.ValueOf(arg.Value)
+		switch {
+		case src.Type().AssignableTo(dst.Type()):
+			dst.Set(src)
+		case dst.Kind() == reflect.String:
+			dst.SetString(FormatValue(arg.Value))
+		default:
+			return errors.Errorf("cannot set argument %s of type %s to field %s of type %s",
+				arg.Name, src.Type(), field.Name, dst.Type())
+		}
+	}
+	return nil
+}
+
+// Map returns the values of the arguments by name
+func (e *Event) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(e.Args))
+	for _, arg := range e.Args {
+		m[arg.Name] = arg.Value
+	}
+	return m
+}
+
+// FormatValue formats a decoded value for display. An address is formatted as an IoTeX address, the bytes are
+// formatted in hex, and the others in their default format
+func FormatValue(v interface{}) string {
+	switch value := v.(type) {
+	case common.Address:
+		addr, err := address.FromBytes(value.Bytes())
+		if err != nil {
+			return value.Hex()
+		}
+		return addr.String()
+	case *big.Int:
+		return value.String()
+	case []byte:
+		return hex.EncodeToString(value)
+	case hash.Hash256:
+		return hex.EncodeToString(value[:])
+	}
+	// Fixed size bytes, e.g., bytes32
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hex.EncodeToString(b)
+	}
+	return fmt.Sprint(v)
+}
+
+func decodeTopic(input abi.Argument, topic hash.Hash256) (interface{}, error) {
+	switch input.Type.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy:
+		return topic, nil
+	}
+	values, err := abi.Arguments{{Name: input.Name, Type: input.Type}}.UnpackValues(topic[:])
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+func fieldOf(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup("abi"); ok && tag == name {
+			return t.Field(i), true
+		}
+	}
+	field, ok := t.FieldByName(strings.ToUpper(name[:1]) + name[1:])
+	if !ok || field.PkgPath != "" {
+		return reflect.StructField{}, false
+	}
+	return field, true
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package eventdecoder
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/iotexproject/go-ethereum/accounts/abi"
+	"github.com/iotexproject/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+const testABI = `[
+	{"type":"event","name":"Transfer","inputs":[
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":true},
+		{"name":"value","type":"uint256","indexed":false},
+		{"name":"memo","type":"string","indexed":false}]},
+	{"type":"event","name":"Tagged","inputs":[
+		{"name":"tag","type":"string","indexed":true},
+		{"name":"","type":"bytes32","indexed":false}]},
+	{"type":"event","name":"Hidden","anonymous":true,"inputs":[
+		{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func addressTopic(addr common.Address) hash.Hash256 {
+	var topic hash.Hash256
+	copy(topic[12:], addr.Bytes())
+	return topic
+}
+
+func TestDecoder(t *testing.T) {
+	require := require.New(t)
+
+	d, err := New(testABI)
+	require.NoError(err)
+	_, err = New("not json")
+	require.Error(err)
+
+	contract, err := abi.JSON(strings.NewReader(testABI))
+	require.NoError(err)
+	transfer := contract.Events["Transfer"]
+	from := common.BytesToAddress(ta.Addrinfo["alfa"].Bytes())
+	to := common.BytesToAddress(ta.Addrinfo["bravo"].Bytes())
+	data, err := abi.Arguments{transfer.Inputs[2], transfer.Inputs[3]}.Pack(big.NewInt(100), "rent")
+	require.NoError(err)
+	transferLog := &action.Log{
+		Address: ta.Addrinfo["charlie"].String(),
+		Topics:  []hash.Hash256{hash.Hash256(transfer.Id()), addressTopic(from), addressTopic(to)},
+		Data:    data,
+		Index:   3,
+	}
+
+	event, err := d.DecodeLog(transferLog)
+	require.NoError(err)
+	require.Equal("Transfer", event.Name)
+	require.Equal(ta.Addrinfo["charlie"].String(), event.Address)
+	require.Equal(uint(3), event.Index)
+	require.Equal(4, len(event.Args))
+	require.Equal(Arg{Name: "from", Type: "address", Indexed: true, Value: from}, event.Args[0])
+	require.Equal("uint256", event.Args[2].Type)
+	m := event.Map()
+	require.Equal(to, m["to"])
+	require.Equal(big.NewInt(100), m["value"])
+	require.Equal("rent", m["memo"])
+	require.Equal(ta.Addrinfo["alfa"].String(), FormatValue(m["from"]))
+	require.Equal("100", FormatValue(m["value"]))
+
+	// Decode into struct
+	var out struct {
+		From   common.Address
+		To     string
+		Amount *big.Int `abi:"value"`
+		Memo   string
+		Other  int
+	}
+	require.NoError(d.Unpack(&out, transferLog))
+	require.Equal(from, out.From)
+	require.Equal(ta.Addrinfo["bravo"].String(), out.To)
+	require.Equal(big.NewInt(100), out.Amount)
+	require.Equal("rent", out.Memo)
+	require.Error(d.Unpack(out, transferLog))
+	var mismatched struct{ Value int }
+	require.Error(d.Unpack(&mismatched, transferLog))
+
+	// The indexed dynamic argument is kept as the hash
+	tagged := contract.Events["Tagged"]
+	id := [32]byte{1, 2, 3}
+	data, err = abi.Arguments{tagged.Inputs[1]}.Pack(id)
+	require.NoError(err)
+	tagHash := hash.Hash256b([]byte("tag"))
+	taggedLog := &action.Log{Topics: []hash.Hash256{hash.Hash256(tagged.Id()), tagHash}, Data: data}
+	event, err = d.DecodeLog(taggedLog)
+	require.NoError(err)
+	require.Equal(tagHash, event.Map()["tag"])
+	require.Equal(id, event.Map()["arg1"])
+	require.Equal("0102030000000000000000000000000000000000000000000000000000000000", FormatValue(id))
+
+	// Skip the unknown and anonymous events
+	unknownLog := &action.Log{Topics: []hash.Hash256{hash.Hash256b([]byte("unknown"))}}
+	_, err = d.DecodeLog(unknownLog)
+	require.Equal(ErrUnknownEvent, err)
+	anonymousLog := &action.Log{Topics: []hash.Hash256{hash.Hash256(contract.Events["Hidden"].Id())}}
+	_, err = d.DecodeLog(anonymousLog)
+	require.Equal(ErrUnknownEvent, err)
+	events, err := d.DecodeReceipt(&action.Receipt{Logs: []*action.Log{unknownLog, transferLog, taggedLog}})
+	require.NoError(err)
+	require.Equal(2, len(events))
+	require.Equal("Transfer", events[0].Name)
+	require.Equal("Tagged", events[1].Name)
+
+	// The topics don't match the event
+	transferLog.Topics = transferLog.Topics[:2]
+	_, err = d.DecodeLog(transferLog)
+	require.Error(err)
+	_, err = d.DecodeReceipt(&action.Receipt{Logs: []*action.Log{transferLog}})
+	require.Error(err)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// NewAPIKeyHandler returns the admin handler to manage the API keys. A GET request lists the keys, a POST request
+// creates a key of the "name", "rateLimit" and comma separated "methods" form values, and responds with its secret,
+// and a DELETE request revokes the key of the "id" query value
+func NewAPIKeyHandler(keys *api.APIKeyStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, keys.List())
+		case http.MethodPost:
+			var rateLimit uint64
+			if s := r.FormValue("rateLimit"); s != "" {
+				var err error
+				if rateLimit, err = strconv.ParseUint(s, 10, 64); err != nil {
+					http.Error(w, "invalid rateLimit", http.StatusBadRequest)
+					return
+				}
+			}
+			var methods []string
+			for _, m := range strings.Split(r.FormValue("methods"), ",") {
+				if m = strings.TrimSpace(m); m != "" {
+					methods = append(methods, m)
+				}
+			}
+			name := r.FormValue("name")
+			if name == "" {
+				http.Error(w, "name is required", http.StatusBadRequest)
+				return
+			}
+			secret, key, err := keys.Create(name, rateLimit, methods)
+			if err != nil {
+				log.L().Error("Failed to create API key.", zap.String("name", name), zap.Error(err))
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			log.L().Info("Created API key.", zap.String("id", key.ID), zap.String("name", name))
+			writeJSON(w, struct {
+				Key string `json:"key"`
+				*api.APIKey
+			}{secret, key})
+		case http.MethodDelete:
+			id := r.FormValue("id")
+			if id == "" {
+				http.Error(w, "id is required", http.StatusBadRequest)
+				return
+			}
+			err := keys.Revoke(id)
+			switch {
+			case errors.Cause(err) == api.ErrAPIKeyNotFound:
+				http.Error(w, err.Error(), http.StatusNotFound)
+			case err != nil:
+				log.L().Error("Failed to revoke API key.", zap.String("id", id), zap.Error(err))
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			default:
+				log.L().Info("Revoked API key.", zap.String("id", id))
+			}
+		default:
+			http.Error(w, "only GET, POST and DELETE are allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.L().Error("Failed to write response.", zap.Error(err))
+	}
+}
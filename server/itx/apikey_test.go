@@ -0,0 +1,79 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestAPIKeyHandler(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(os.TempDir(), "api-keys.json")
+	testutil.CleanupPath(t, path)
+	defer testutil.CleanupPath(t, path)
+	keys, err := api.NewAPIKeyStore(path)
+	require.NoError(err)
+	handler := NewAPIKeyHandler(keys)
+
+	do := func(method string, values url.Values) *httptest.ResponseRecorder {
+		var req *http.Request
+		if method == http.MethodPost {
+			req = httptest.NewRequest(method, "/apikeys", strings.NewReader(values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, "/apikeys?"+values.Encode(), nil)
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	require.Equal(http.StatusMethodNotAllowed, do(http.MethodPut, nil).Code)
+	require.Equal(http.StatusBadRequest, do(http.MethodPost, url.Values{"rateLimit": {"10"}}).Code)
+	require.Equal(http.StatusBadRequest, do(http.MethodPost, url.Values{"name": {"wallet"}, "rateLimit": {"x"}}).Code)
+
+	w := do(http.MethodPost, url.Values{
+		"name":      {"wallet"},
+		"rateLimit": {"10"},
+		"methods":   {"GetAccount, SendAction"},
+	})
+	require.Equal(http.StatusOK, w.Code)
+	var created struct {
+		Key string `json:"key"`
+		api.APIKey
+	}
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(created.Key)
+	require.Equal("wallet", created.Name)
+	require.Equal(uint64(10), created.RateLimit)
+	require.Equal([]string{"GetAccount", "SendAction"}, created.Methods)
+
+	w = do(http.MethodGet, nil)
+	require.Equal(http.StatusOK, w.Code)
+	var listed []api.APIKey
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &listed))
+	require.Len(listed, 1)
+	require.Equal(created.ID, listed[0].ID)
+	require.NotContains(w.Body.String(), created.Key)
+
+	require.Equal(http.StatusBadRequest, do(http.MethodDelete, nil).Code)
+	require.Equal(http.StatusNotFound, do(http.MethodDelete, url.Values{"id": {"unknown"}}).Code)
+	require.Equal(http.StatusOK, do(http.MethodDelete, url.Values{"id": {created.ID}}).Code)
+	require.Empty(keys.List())
+}
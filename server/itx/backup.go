@@ -0,0 +1,231 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// backupDirPrefix is the prefix of the sub directories of the scheduled backups, followed by the UTC time, so that
+	// they are sorted by time
+	backupDirPrefix  = "backup-"
+	backupTimeFormat = "20060102T150405Z"
+	backupSuccess    = "success"
+	backupFailure    = "failure"
+)
+
+var (
+	backupMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_backup_total",
+			Help: "Number of the scheduled backups by result",
+		},
+		[]string{"result"},
+	)
+
+	lastBackupMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_backup_last_success",
+			Help: "Unix time and tip height of the last succeeded scheduled backup",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(backupMtc)
+	prometheus.MustRegister(lastBackupMtc)
+}
+
+// NewBackupHandler returns the admin handler to take a hot backup of the chain into the sub directory of the backup
+// directory, which is named by the "name" form value of a POST request. It responds with the tip height of the backup
+func NewBackupHandler(bc blockchain.Blockchain, backupDir string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name := r.FormValue("name")
+		if name == "" {
+			http.Error(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		// The backup can't be written out of the backup directory
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			http.Error(w, "name has to be a plain directory name", http.StatusBadRequest)
+			return
+		}
+		dir := filepath.Join(backupDir, name)
+		height, err := bc.Backup(dir)
+		if err != nil {
+			log.L().Error("Failed to back up the chain.", zap.String("dir", dir), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "%d\n", height)
+	}
+}
+
+// BackupStatus is the status of the scheduled backups
+type BackupStatus struct {
+	// LastSuccess is the time of the last succeeded backup, which is zero if none has succeeded yet
+	LastSuccess time.Time
+	// Height is the tip height of the last succeeded backup
+	Height uint64
+	// Dir is the directory of the last succeeded backup
+	Dir string
+	// Err is the error of the last backup, which is nil if it succeeded
+	Err error
+}
+
+// BackupScheduler is the handler to periodically take a hot backup of the chain into a new sub directory of the
+// configured directory, and remove the oldest backups beyond the retention
+type BackupScheduler struct {
+	bc     blockchain.Blockchain
+	cfg    config.Backup
+	clock  clock.Clock
+	mutex  sync.RWMutex
+	status BackupStatus
+}
+
+// NewBackupScheduler instantiates a BackupScheduler instance
+func NewBackupScheduler(bc blockchain.Blockchain, cfg config.Backup) *BackupScheduler {
+	return &BackupScheduler{
+		bc:    bc,
+		cfg:   cfg,
+		clock: clock.New(),
+	}
+}
+
+// Backup takes a backup into a new sub directory and rotates the backups. A failed backup is removed, and the older
+// backups are kept
+func (s *BackupScheduler) Backup() {
+	now := s.clock.Now().UTC()
+	dir, err := s.newBackupDir(now)
+	var height uint64
+	if err == nil {
+		height, err = s.bc.Backup(dir)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.status.Err = err
+	if err != nil {
+		log.L().Error("Failed to take the scheduled backup.", zap.String("dir", dir), zap.Error(err))
+		backupMtc.WithLabelValues(backupFailure).Inc()
+		if dir == "" {
+			return
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			log.L().Error("Failed to remove the failed backup.", zap.String("dir", dir), zap.Error(err))
+		}
+		return
+	}
+	backupMtc.WithLabelValues(backupSuccess).Inc()
+	lastBackupMtc.WithLabelValues("timestamp").Set(float64(now.Unix()))
+	lastBackupMtc.WithLabelValues("height").Set(float64(height))
+	s.status.LastSuccess = now
+	s.status.Height = height
+	s.status.Dir = dir
+	if err := s.rotate(); err != nil {
+		log.L().Error("Failed to remove the old backups.", zap.Error(err))
+	}
+}
+
+// Status returns the status of the scheduled backups
+func (s *BackupScheduler) Status() BackupStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.status
+}
+
+// newBackupDir creates the sub directory of the backup at the time, which is named after the time. A suffix is added
+// to the name if it's taken, so that an existing backup is never written into, or removed as a failed one
+func (s *BackupScheduler) newBackupDir(now time.Time) (string, error) {
+	if err := os.MkdirAll(s.cfg.Dir, 0700); err != nil {
+		return "", errors.Wrapf(err, "failed to create backup directory %s", s.cfg.Dir)
+	}
+	name := backupDirPrefix + now.Format(backupTimeFormat)
+	for i := 1; ; i++ {
+		dir := filepath.Join(s.cfg.Dir, name)
+		err := os.Mkdir(dir, 0700)
+		if err == nil {
+			return dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", errors.Wrapf(err, "failed to create backup directory %s", dir)
+		}
+		name = fmt.Sprintf("%s%s-%d", backupDirPrefix, now.Format(backupTimeFormat), i)
+	}
+}
+
+// NewBackupStatusHandler returns the admin handler responding with the status of the scheduled backups, which is kept
+// out of the health check of the node, as a failed backup doesn't stop the node from serving
+func NewBackupStatusHandler(s *BackupScheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "only GET is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		status := s.Status()
+		resp := struct {
+			LastSuccess time.Time `json:"lastSuccess"`
+			Height      uint64    `json:"height"`
+			Dir         string    `json:"dir"`
+			Error       string    `json:"error,omitempty"`
+		}{
+			LastSuccess: status.LastSuccess,
+			Height:      status.Height,
+			Dir:         status.Dir,
+		}
+		if status.Err != nil {
+			resp.Error = status.Err.Error()
+		}
+		writeJSON(w, resp)
+	}
+}
+
+// rotate removes the oldest backups beyond the retention
+func (s *BackupScheduler) rotate() error {
+	files, err := ioutil.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list backup directory %s", s.cfg.Dir)
+	}
+	backups := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() && strings.HasPrefix(f.Name(), backupDirPrefix) {
+			backups = append(backups, f.Name())
+		}
+	}
+	sort.Strings(backups)
+	for len(backups) > s.cfg.Retention {
+		path := filepath.Join(s.cfg.Dir, backups[0])
+		if err := os.RemoveAll(path); err != nil {
+			return errors.Wrapf(err, "failed to remove backup %s", path)
+		}
+		log.L().Info("Removed the old backup.", zap.String("dir", path))
+		backups = backups[1:]
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+)
+
+func TestBackupHandler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	handler := NewBackupHandler(bc, "/tmp/backup")
+
+	post := func(name string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/backup", strings.NewReader(url.Values{"name": {name}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/backup?name=manual", nil))
+	require.Equal(http.StatusMethodNotAllowed, w.Code)
+	require.Equal(http.StatusBadRequest, post("").Code)
+	// The backup can't be written out of the backup directory
+	for _, name := range []string{"/tmp/other", "../other", "a/b", ".", ".."} {
+		require.Equal(http.StatusBadRequest, post(name).Code, name)
+	}
+
+	bc.EXPECT().Backup("/tmp/backup/manual").Return(uint64(10), nil).Times(1)
+	w = post("manual")
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal("10\n", w.Body.String())
+
+	bc.EXPECT().Backup("/tmp/backup/manual").Return(uint64(0), errors.New("disk full")).Times(1)
+	w = post("manual")
+	require.Equal(http.StatusInternalServerError, w.Code)
+	require.Contains(w.Body.String(), "disk full")
+}
+
+func TestBackupScheduler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(err)
+	defer func() { require.NoError(os.RemoveAll(dir)) }()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	s := NewBackupScheduler(bc, config.Backup{Enabled: true, Interval: time.Hour, Dir: dir, Retention: 2})
+	clk := clock.NewMock()
+	clk.Add(time.Hour)
+	s.clock = clk
+	backups := func() []string {
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(err)
+		names := make([]string, 0, len(files))
+		for _, f := range files {
+			names = append(names, f.Name())
+		}
+		return names
+	}
+
+	height := uint64(0)
+	bc.EXPECT().Backup(gomock.Any()).DoAndReturn(func(dir string) (uint64, error) {
+		height += 10
+		return height, os.MkdirAll(dir, 0700)
+	}).Times(3)
+	var names []string
+	for i := 0; i < 3; i++ {
+		s.Backup()
+		names = append(names, backupDirPrefix+clk.Now().UTC().Format(backupTimeFormat))
+		clk.Add(time.Hour)
+	}
+	// Only the latest backups are kept
+	require.Equal(names[1:], backups())
+	status := s.Status()
+	require.Equal(uint64(30), status.Height)
+	require.Equal(filepath.Join(dir, names[2]), status.Dir)
+	require.NoError(status.Err)
+
+	// The failed backup is removed, and it's reported by the status until the next backup succeeds. The backup in
+	// the same second doesn't take the directory of the existing one
+	clk.Add(-time.Hour)
+	bc.EXPECT().Backup(gomock.Any()).DoAndReturn(func(backupDir string) (uint64, error) {
+		require.Equal(filepath.Join(dir, names[2]+"-1"), backupDir)
+		return 0, errors.New("disk full")
+	}).Times(1)
+	s.Backup()
+	require.Equal(names[1:], backups())
+	require.Error(s.Status().Err)
+	require.Equal(uint64(30), s.Status().Height)
+
+	handler := NewBackupStatusHandler(s)
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/backup/status", nil))
+	require.Equal(http.StatusOK, w.Code)
+	var resp struct {
+		Height uint64 `json:"height"`
+		Error  string `json:"error"`
+	}
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(uint64(30), resp.Height)
+	require.Equal("disk full", resp.Error)
+}
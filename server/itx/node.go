@@ -0,0 +1,43 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+// NodeMeta provides the overview of the node which isn't served by the public API, as it's costly to read or exposes
+// the file system and the network of the node
+type NodeMeta interface {
+	StorageMeta() *iotexapi.StorageMeta
+	NetworkMeta(context.Context) (*iotexapi.NetworkMeta, error)
+}
+
+// NewNodeMetaHandler returns the admin handler responding with the storage and the network of the node. The network
+// is left unset if it fails to be read
+func NewNodeMetaHandler(meta NodeMeta) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "only GET is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		network, err := meta.NetworkMeta(r.Context())
+		if err != nil {
+			log.L().Warn("Failed to get network meta.", zap.Error(err))
+		}
+		writeJSON(w, struct {
+			Storage *iotexapi.StorageMeta `json:"storage,omitempty"`
+			Network *iotexapi.NetworkMeta `json:"network,omitempty"`
+		}{meta.StorageMeta(), network})
+	}
+}
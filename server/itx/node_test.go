@@ -0,0 +1,70 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+type mockNodeMeta struct {
+	networkErr error
+}
+
+func (m *mockNodeMeta) StorageMeta() *iotexapi.StorageMeta {
+	return &iotexapi.StorageMeta{
+		Dbs:        []*iotexapi.DBSize{{Name: "chain", Path: "/var/data/chain.db", Bytes: 10}},
+		TotalBytes: 10,
+	}
+}
+
+func (m *mockNodeMeta) NetworkMeta(context.Context) (*iotexapi.NetworkMeta, error) {
+	if m.networkErr != nil {
+		return nil, m.networkErr
+	}
+	return &iotexapi.NetworkMeta{PeerID: "peer", NumNeighbors: 2}, nil
+}
+
+func TestNodeMetaHandler(t *testing.T) {
+	require := require.New(t)
+	meta := &mockNodeMeta{}
+	handler := NewNodeMetaHandler(meta)
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodPost, "/node", nil))
+	require.Equal(http.StatusMethodNotAllowed, w.Code)
+
+	var resp struct {
+		Storage *iotexapi.StorageMeta `json:"storage"`
+		Network *iotexapi.NetworkMeta `json:"network"`
+	}
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/node", nil))
+	require.Equal(http.StatusOK, w.Code)
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal("/var/data/chain.db", resp.Storage.Dbs[0].Path)
+	require.Equal(uint64(10), resp.Storage.TotalBytes)
+	require.Equal("peer", resp.Network.PeerID)
+
+	// The storage is still served if the network fails to be read
+	meta.networkErr = errors.New("not connected")
+	resp.Network = nil
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/node", nil))
+	require.Equal(http.StatusOK, w.Code)
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(uint64(10), resp.Storage.TotalBytes)
+	require.Nil(resp.Network)
+}
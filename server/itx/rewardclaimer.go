@@ -0,0 +1,188 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/address"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/signer"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// RewardClaimer is the handler to periodically claim the unclaimed reward of the local delegate once it reaches the
+// configured threshold
+type RewardClaimer struct {
+	s         *Server
+	cfg       config.RewardClaim
+	chainID   uint32
+	addr      address.Address
+	signer    signer.Signer
+	threshold *big.Int
+	gasPrice  *big.Int
+	// inflight are the hashes of the claim actions submitted but not confirmed yet, by nonce
+	inflight map[uint64]hash.Hash256
+	mutex    sync.Mutex
+}
+
+// NewRewardClaimer instantiates a RewardClaimer instance, which signs the claim actions with the signer of the
+// delegate, i.e., the same signer as the consensus, so that it works with a remote signer as well
+func NewRewardClaimer(s *Server, sg signer.Signer, cfg config.Config) (*RewardClaimer, error) {
+	if sg == nil {
+		return nil, errors.New("reward claimer needs the signer of the delegate")
+	}
+	pkHash := keypair.HashPubKey(sg.PublicKey())
+	addr, err := address.FromBytes(pkHash[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "error when deriving the address of the signer")
+	}
+	threshold, ok := big.NewInt(0).SetString(cfg.RewardClaim.ThresholdStr, 10)
+	if !ok {
+		return nil, errors.Errorf("error when casting threshold string %s into big int", cfg.RewardClaim.ThresholdStr)
+	}
+	gasPrice, ok := big.NewInt(0).SetString(cfg.RewardClaim.GasPriceStr, 10)
+	if !ok {
+		return nil, errors.Errorf("error when casting gas price string %s into big int", cfg.RewardClaim.GasPriceStr)
+	}
+	return &RewardClaimer{
+		s:         s,
+		cfg:       cfg.RewardClaim,
+		chainID:   cfg.Chain.ID,
+		addr:      addr,
+		signer:    sg,
+		threshold: threshold,
+		gasPrice:  gasPrice,
+		inflight:  make(map[uint64]hash.Hash256),
+	}, nil
+}
+
+// Claim checks the unclaimed balance of the local delegate, and submits a claim action if it reaches the threshold
+func (c *RewardClaimer) Claim() {
+	cs := c.s.ChainService(c.chainID)
+	if cs == nil {
+		log.L().Error("Chain service doesn't exist.", zap.Uint32("chainID", c.chainID))
+		return
+	}
+	p, ok := cs.Registry().Find(rewarding.ProtocolID)
+	if !ok {
+		log.L().Error("Rewarding protocol isn't registered.")
+		return
+	}
+	rp, ok := p.(*rewarding.Protocol)
+	if !ok {
+		log.S().Panicf("Protocol %s is not a rewarding protocol", rewarding.ProtocolID)
+	}
+	ws, err := cs.Blockchain().GetFactory().NewWorkingSet()
+	if err != nil {
+		log.L().Error("Error when creating working set.", zap.Error(err))
+		return
+	}
+	balance, err := rp.UnclaimedBalance(context.Background(), ws, c.addr)
+	if err != nil {
+		log.L().Error("Error when getting the unclaimed balance.", zap.Error(err))
+		return
+	}
+	confirmedNonce, err := cs.Blockchain().Nonce(c.addr.String())
+	if err != nil {
+		log.L().Error("Error when getting the confirmed nonce.", zap.Error(err))
+		return
+	}
+	// No more claim is submitted until the previous one is confirmed or dropped, otherwise it would fail anyway
+	if c.hasInflightClaim(confirmedNonce, func(h hash.Hash256) bool {
+		_, err := cs.ActionPool().GetActionByHash(h)
+		return err == nil
+	}) {
+		log.L().Debug("Previous claim is still pending.")
+		return
+	}
+	nonce, err := cs.ActionPool().GetPendingNonce(c.addr.String())
+	if err != nil {
+		log.L().Error("Error when getting the pending nonce.", zap.Error(err))
+		return
+	}
+	selp, ok, err := c.claimAction(balance, nonce, cs.Blockchain().TipHeight()+1)
+	if err != nil {
+		log.L().Error("Error when creating the claim action.", zap.Error(err))
+		return
+	}
+	if !ok {
+		log.L().Debug("Unclaimed balance is below the threshold.",
+			zap.String("balance", balance.String()),
+			zap.String("threshold", c.threshold.String()))
+		return
+	}
+	h := selp.Hash()
+	if c.cfg.DryRun {
+		log.L().Info("Dry run of claiming the reward.",
+			zap.String("amount", balance.String()),
+			zap.Uint64("nonce", nonce),
+			zap.String("recipient", c.cfg.Recipient),
+			log.Hex("actionHash", h[:]))
+		return
+	}
+	if err := cs.ActionPool().Add(selp); err != nil {
+		log.L().Error("Error when adding the claim action into actpool.", zap.Error(err))
+		return
+	}
+	c.mutex.Lock()
+	c.inflight[nonce] = h
+	c.mutex.Unlock()
+	ctx := p2p.WitContext(context.Background(), p2p.Context{ChainID: c.chainID})
+	if err := c.s.P2PAgent().BroadcastOutbound(ctx, selp.Proto()); err != nil {
+		log.L().Warn("Error when broadcasting the claim action.", zap.Error(err))
+	}
+	log.L().Info("Claimed the reward.",
+		zap.String("amount", balance.String()),
+		zap.Uint64("nonce", nonce),
+		zap.String("recipient", c.cfg.Recipient),
+		log.Hex("actionHash", h[:]))
+}
+
+// claimAction creates the signed action to claim all the unclaimed balance, which is expected at the given height. It
+// returns false if the balance is zero or below the threshold
+func (c *RewardClaimer) claimAction(balance *big.Int, nonce uint64, height uint64) (action.SealedEnvelope, bool, error) {
+	if balance.Sign() == 0 || balance.Cmp(c.threshold) < 0 {
+		return action.SealedEnvelope{}, false, nil
+	}
+	cb := action.ClaimFromRewardingFundBuilder{}
+	claim := cb.SetAmount(balance).SetRecipient(c.cfg.Recipient).Build()
+	eb := action.EnvelopeBuilder{}
+	elp := eb.SetNonce(nonce).
+		SetGasLimit(c.cfg.GasLimit).
+		SetGasPrice(c.gasPrice).
+		SetAction(&claim).
+		Build()
+	selp, err := action.SignWith(elp, c.signer.PublicKey(), signer.SignFunc(c.signer, signer.KindAction, height, 0, 0))
+	if err != nil {
+		return action.SealedEnvelope{}, false, err
+	}
+	return selp, true, nil
+}
+
+// hasInflightClaim returns whether there's a claim action submitted but not confirmed yet. The claim actions confirmed,
+// i.e., whose nonce is not above the confirmed nonce, and the ones dropped from the actpool are forgotten
+func (c *RewardClaimer) hasInflightClaim(confirmedNonce uint64, inPool func(hash.Hash256) bool) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for nonce, h := range c.inflight {
+		if nonce <= confirmedNonce || !inPool(h) {
+			delete(c.inflight, nonce)
+		}
+	}
+	return len(c.inflight) > 0
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/signer"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestRewardClaimer_ClaimAction(t *testing.T) {
+	cfg := config.Default
+	cfg.RewardClaim.Enabled = true
+	cfg.RewardClaim.ThresholdStr = "100"
+	cfg.RewardClaim.GasPriceStr = "2"
+	cfg.RewardClaim.Recipient = testaddress.Addrinfo["alfa"].String()
+	_, err := NewRewardClaimer(nil, nil, cfg)
+	require.Error(t, err)
+	sg := signer.NewLocalSigner(testaddress.Keyinfo["producer"].PriKey)
+	claimer, err := NewRewardClaimer(nil, sg, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, testaddress.Addrinfo["producer"].String(), claimer.addr.String())
+
+	// No claim if the balance is below the threshold
+	_, ok, err := claimer.claimAction(big.NewInt(99), 1, 10)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	selp, ok, err := claimer.claimAction(big.NewInt(100), 3, 10)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), selp.Nonce())
+	assert.Equal(t, cfg.RewardClaim.GasLimit, selp.GasLimit())
+	assert.Equal(t, big.NewInt(2), selp.GasPrice())
+	claim, ok := selp.Action().(*action.ClaimFromRewardingFund)
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(100), claim.Amount())
+	assert.Equal(t, cfg.RewardClaim.Recipient, claim.Recipient())
+	require.NoError(t, action.Verify(selp))
+
+	// No claim if the balance is zero, even if there's no threshold
+	cfg.RewardClaim.ThresholdStr = "0"
+	claimer, err = NewRewardClaimer(nil, sg, cfg)
+	require.NoError(t, err)
+	_, ok, err = claimer.claimAction(big.NewInt(0), 1, 10)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRewardClaimer_InflightClaim(t *testing.T) {
+	cfg := config.Default
+	cfg.RewardClaim.Enabled = true
+	sg := signer.NewLocalSigner(testaddress.Keyinfo["producer"].PriKey)
+	claimer, err := NewRewardClaimer(nil, sg, cfg)
+	require.NoError(t, err)
+	inPool := map[hash.Hash256]bool{}
+	isInPool := func(h hash.Hash256) bool { return inPool[h] }
+	assert.False(t, claimer.hasInflightClaim(0, isInPool))
+
+	h1 := hash.Hash256b([]byte("claim1"))
+	h2 := hash.Hash256b([]byte("claim2"))
+	claimer.inflight[3] = h1
+	claimer.inflight[4] = h2
+	inPool[h1] = true
+	inPool[h2] = true
+	assert.True(t, claimer.hasInflightClaim(2, isInPool))
+	// The claim confirmed is forgotten
+	assert.True(t, claimer.hasInflightClaim(3, isInPool))
+	assert.Len(t, claimer.inflight, 1)
+	// The claim dropped from the actpool is forgotten as well
+	delete(inPool, h2)
+	assert.False(t, claimer.hasInflightClaim(3, isInPool))
+	assert.Empty(t, claimer.inflight)
+}
@@ -19,10 +19,18 @@ import (
 
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/allowlist"
+	"github.com/iotexproject/iotex-core/action/protocol/blskey"
+	"github.com/iotexproject/iotex-core/action/protocol/circuitbreaker"
+	"github.com/iotexproject/iotex-core/action/protocol/dkg"
+	"github.com/iotexproject/iotex-core/action/protocol/evidence"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/freegas"
+	"github.com/iotexproject/iotex-core/action/protocol/governance"
 	"github.com/iotexproject/iotex-core/action/protocol/multichain/mainchain"
 	"github.com/iotexproject/iotex-core/action/protocol/multichain/subchain"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/session"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/chainservice"
@@ -95,7 +103,7 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 			protocol.NewGenericValidator(cs.Blockchain(), genesisConfig.Blockchain.ActionGasLimit),
 		)
 	// Install protocols
-	if err := registerDefaultProtocols(cs, genesisConfig); err != nil {
+	if err := registerDefaultProtocols(cs, cfg, genesisConfig); err != nil {
 		return nil, err
 	}
 	mainChainProtocol := mainchain.NewProtocol(cs.Blockchain())
@@ -171,16 +179,19 @@ func (s *Server) NewSubChainService(cfg config.Config, opts ...chainservice.Opti
 }
 
 func (s *Server) newSubChainService(cfg config.Config, opts ...chainservice.Option) error {
-	genesisConfig, err := genesis.New()
+	genesisConfig, err := genesis.NewSub()
 	if err != nil {
 		return err
 	}
-	opts = append(opts, chainservice.WithGenesis(genesisConfig))
+	opts = append(opts, chainservice.WithGenesis(genesisConfig), chainservice.WithGenesisConsensusParams())
 	var mainChainAPI explorer.Explorer
 	if s.rootChainService.Explorer() != nil {
 		mainChainAPI = s.rootChainService.Explorer().Explorer()
 		opts = append(opts, chainservice.WithRootChainAPI(mainChainAPI))
 	}
+	if s.rootChainService.APIServer() != nil {
+		opts = append(opts, chainservice.WithAPIRouter(s.rootChainService.APIServer()))
+	}
 	cs, err := chainservice.New(cfg, s.p2pAgent, s.dispatcher, opts...)
 	if err != nil {
 		return err
@@ -193,13 +204,23 @@ func (s *Server) newSubChainService(cfg config.Config, opts ...chainservice.Opti
 		AddActionEnvelopeValidators(
 			protocol.NewGenericValidator(cs.Blockchain(), genesisConfig.Blockchain.ActionGasLimit),
 		)
-	if err := registerDefaultProtocols(cs, genesisConfig); err != nil {
+	if err := registerDefaultProtocols(cs, cfg, genesisConfig); err != nil {
 		return err
 	}
 	subChainProtocol := subchain.NewProtocol(cs.Blockchain(), mainChainAPI)
 	if err := cs.RegisterProtocol(subchain.ProtocolID, subChainProtocol); err != nil {
 		return err
 	}
+	if mainChainAPI != nil && cfg.Chain.AnchorInterval > 0 {
+		pk, sk, err := cfg.KeyPair()
+		if err != nil {
+			return err
+		}
+		relayer := subchain.NewRelayer(cs.Blockchain().ChainAddress(), mainChainAPI, cfg.Chain.AnchorInterval, pk, sk)
+		if err := cs.Blockchain().AddDurableSubscriber(subchain.RelayerSubscriberName, relayer); err != nil {
+			return err
+		}
+	}
 	s.chainservices[cs.ChainID()] = cs
 	return nil
 }
@@ -252,6 +273,36 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		}()
 	}
 
+	if cfg.RewardClaim.Enabled {
+		claimer, err := NewRewardClaimer(svr, svr.ChainService(cfg.Chain.ID).Consensus().Signer(), cfg)
+		if err != nil {
+			log.L().Panic("Failed to create reward claimer.", zap.Error(err))
+		}
+		task := routine.NewRecurringTask(claimer.Claim, cfg.RewardClaim.Interval)
+		if err := task.Start(ctx); err != nil {
+			log.L().Panic("Failed to start reward claim routine.", zap.Error(err))
+		}
+		defer func() {
+			if err := task.Stop(ctx); err != nil {
+				log.L().Panic("Failed to stop reward claim routine.", zap.Error(err))
+			}
+		}()
+	}
+
+	var scheduler *BackupScheduler
+	if cfg.Backup.Enabled {
+		scheduler = NewBackupScheduler(svr.ChainService(cfg.Chain.ID).Blockchain(), cfg.Backup)
+		task := routine.NewRecurringTask(scheduler.Backup, cfg.Backup.Interval)
+		if err := task.Start(ctx); err != nil {
+			log.L().Panic("Failed to start backup routine.", zap.Error(err))
+		}
+		defer func() {
+			if err := task.Stop(ctx); err != nil {
+				log.L().Panic("Failed to stop backup routine.", zap.Error(err))
+			}
+		}()
+	}
+
 	if cfg.System.HTTPProfilingPort > 0 {
 		go func() {
 			runtime.SetMutexProfileFraction(1)
@@ -282,18 +333,84 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		}()
 	}
 
+	// The admin endpoints are only served on the loopback interface
+	var aserv http.Server
+	if cfg.System.HTTPAdminPort > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/backup", NewBackupHandler(svr.ChainService(cfg.Chain.ID).Blockchain(), cfg.Backup.Dir))
+		if scheduler != nil {
+			mux.Handle("/backup/status", NewBackupStatusHandler(scheduler))
+		}
+		if apiSvr := svr.ChainService(cfg.Chain.ID).APIServer(); apiSvr != nil {
+			mux.Handle("/node", NewNodeMetaHandler(apiSvr))
+			if apiSvr.KeyStore() != nil {
+				mux.Handle("/apikeys", NewAPIKeyHandler(apiSvr.KeyStore()))
+			}
+		}
+		aserv = http.Server{
+			Addr:    fmt.Sprintf("127.0.0.1:%d", cfg.System.HTTPAdminPort),
+			Handler: mux,
+		}
+		go func() {
+			if err := aserv.ListenAndServe(); err != nil {
+				log.L().Error("Error when serving admin endpoints.", zap.Error(err))
+			}
+		}()
+	}
+
 	<-ctx.Done()
 	probeSvr.NotReady()
 	if err := mserv.Shutdown(ctx); err != nil {
 		log.L().Error("Error when serving metrics data.", zap.Error(err))
 	}
+	if err := aserv.Shutdown(ctx); err != nil {
+		log.L().Error("Error when serving admin endpoints.", zap.Error(err))
+	}
 	if err := svr.Stop(ctx); err != nil {
 		log.L().Panic("Failed to stop server.", zap.Error(err))
 	}
 }
 
-func registerDefaultProtocols(cs *chainservice.ChainService, genesisConfig genesis.Genesis) error {
-	accountProtocol := account.NewProtocol()
+func registerDefaultProtocols(cs *chainservice.ChainService, cfg config.Config, genesisConfig genesis.Genesis) error {
+	if genesisConfig.EnableAllowlist {
+		// The allowlist protocol handles the actions before the other protocols by its priority
+		allowlistProtocol := allowlist.NewProtocol(cs.Blockchain().GetFactory())
+		if err := cs.RegisterProtocol(allowlist.ProtocolID, allowlistProtocol); err != nil {
+			return err
+		}
+	}
+	if genesisConfig.EnableFreeGas {
+		// The free gas protocol handles the actions after the allowlist and before the others by its priority
+		freeGasProtocol := freegas.NewProtocol(
+			cs.Blockchain().GetFactory(),
+			genesisConfig.DailyQuota,
+			genesisConfig.DailyCap,
+			genesisConfig.FreeGasActions,
+		)
+		if err := cs.RegisterProtocol(freegas.ProtocolID, freeGasProtocol); err != nil {
+			return err
+		}
+	}
+	if genesisConfig.EnableCircuitBreaker {
+		// The circuit breaker protocol is registered before the others as well to reject the paused actions
+		circuitBreakerProtocol := circuitbreaker.NewProtocol(
+			cs.Blockchain().GetFactory(),
+			genesisConfig.MaxPauseDuration,
+		)
+		if err := cs.RegisterProtocol(circuitbreaker.ProtocolID, circuitBreakerProtocol); err != nil {
+			return err
+		}
+	}
+	var accountOpts []account.Option
+	if genesisConfig.DustReclaimHeight != 0 {
+		accountOpts = append(accountOpts, account.DustReclaimOption(
+			genesisConfig.DustReclaimHeight,
+			genesisConfig.DustThreshold(),
+			genesisConfig.DustInactiveEpochs,
+			genesisConfig.DustMaxSweepsPerBlock,
+		))
+	}
+	accountProtocol := account.NewProtocol(accountOpts...)
 	if err := cs.RegisterProtocol(account.ProtocolID, accountProtocol); err != nil {
 		return err
 	}
@@ -305,9 +422,61 @@ func registerDefaultProtocols(cs *chainservice.ChainService, genesisConfig genes
 	if err := cs.RegisterProtocol(execution.ProtocolID, executionProtocol); err != nil {
 		return err
 	}
-	rewardingProtocol := rewarding.NewProtocol()
+	var rewardingOpts []rewarding.Option
+	if genesisConfig.EpochSnapshotHeight != 0 {
+		rewardingOpts = append(rewardingOpts, rewarding.EpochSnapshotOption(
+			genesisConfig.EpochSnapshotHeight,
+			genesisConfig.EpochSnapshotRetention,
+		))
+	}
+	if genesisConfig.ClaimLogHeight != 0 {
+		rewardingOpts = append(rewardingOpts, rewarding.ClaimLogOption(genesisConfig.ClaimLogHeight))
+	}
+	rewardingProtocol := rewarding.NewProtocol(rewardingOpts...)
 	if err := cs.RegisterProtocol(rewarding.ProtocolID, rewardingProtocol); err != nil {
 		return err
 	}
+	if genesisConfig.EnableGovernance {
+		governanceProtocol := governance.NewProtocol(genesisConfig.NumDelegates, genesisConfig.NumSubEpochs)
+		if err := cs.RegisterProtocol(governance.ProtocolID, governanceProtocol); err != nil {
+			return err
+		}
+	}
+	if genesisConfig.EnableBLS {
+		blsKeyProtocol := blskey.NewProtocol(genesisConfig.NumDelegates, genesisConfig.NumSubEpochs)
+		if err := cs.RegisterProtocol(blskey.ProtocolID, blsKeyProtocol); err != nil {
+			return err
+		}
+	}
+	if genesisConfig.EnableEvidence {
+		evidenceProtocol := evidence.NewProtocol(
+			genesisConfig.NumCandidates,
+			genesisConfig.NumDelegates,
+			genesisConfig.NumSubEpochs,
+			genesisConfig.MaxEvidenceAge,
+		)
+		if err := cs.RegisterProtocol(evidence.ProtocolID, evidenceProtocol); err != nil {
+			return err
+		}
+	}
+	if genesisConfig.EnableSession {
+		sessionProtocol := session.NewProtocol(cfg.Chain.ID)
+		if err := cs.RegisterProtocol(session.ProtocolID, sessionProtocol); err != nil {
+			return err
+		}
+	}
+	if genesisConfig.DKGHeight != 0 {
+		dkgProtocol := dkg.NewProtocol(
+			dkg.Schedule{
+				ForkHeight: genesisConfig.DKGHeight,
+				EpochSize:  genesisConfig.NumDelegates * genesisConfig.NumSubEpochs,
+			},
+			genesisConfig.NumCandidates,
+			genesisConfig.NumDelegates,
+		)
+		if err := cs.RegisterProtocol(dkg.ProtocolID, dkgProtocol); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	"math/big"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -41,6 +42,11 @@ const (
 	CurrentHeightKey = "currentHeight"
 	// AccountTrieRootKey indicates the key of accountTrie root hash in underlying DB
 	AccountTrieRootKey = "accountTrieRoot"
+	// StateGrowthKey indicates the key of the cumulative state growth in underlying DB
+	StateGrowthKey = "stateGrowth"
+
+	// UndoKVNameSpace is the bucket name for the undo logs of the latest committed heights
+	UndoKVNameSpace = "Undo"
 )
 
 type (
@@ -54,8 +60,19 @@ type (
 		RootHash() hash.Hash256
 		RootHashByHeight(uint64) (hash.Hash256, error)
 		Height() (uint64, error)
+		// StateGrowth returns the current height along with the net number of trie nodes and state bytes added up to it
+		StateGrowth() (uint64, StateGrowth, error)
 		NewWorkingSet() (WorkingSet, error)
+		// NewWorkingSetOn creates a working set on top of the parent working set, which reads the pending changes of the
+		// parent before they are committed
+		NewWorkingSetOn(WorkingSet) (WorkingSet, error)
 		Commit(WorkingSet) error
+		// CommitBatch commits the working sets, each created on top of the previous one, in a batch
+		CommitBatch([]WorkingSet) error
+		// Snapshot takes a snapshot of the underlying DB to copy while running
+		Snapshot() (db.Snapshot, error)
+		// RevertTo reverts the states to those committed at the height, which is below the current height
+		RevertTo(uint64) error
 		// Candidate pool
 		CandidatesByHeight(uint64) ([]*state.Candidate, error)
 
@@ -65,14 +82,17 @@ type (
 
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
 	factory struct {
-		lifecycle          lifecycle.Lifecycle
-		mutex              sync.RWMutex
-		currentChainHeight uint64
-		numCandidates      uint
-		accountTrie        trie.Trie                // global state trie
-		dao                db.KVStore               // the underlying DB for account/contract storage
-		actionHandlers     []protocol.ActionHandler // the handlers to handle actions
-		timerFactory       *prometheustimer.TimerFactory
+		lifecycle           lifecycle.Lifecycle
+		mutex               sync.RWMutex
+		currentChainHeight  uint64
+		numCandidates       uint
+		accountTrie         trie.Trie                // global state trie
+		dao                 db.KVStore               // the underlying DB for account/contract storage
+		actionHandlers      []protocol.ActionHandler // the handlers to handle actions
+		timerFactory        *prometheustimer.TimerFactory
+		slowActionThreshold time.Duration
+		// undoHeights is the number of the latest heights whose undo logs are kept, 0 means none
+		undoHeights uint64
 	}
 )
 
@@ -114,8 +134,10 @@ func InMemTrieOption() Option {
 // NewFactory creates a new state factory
 func NewFactory(cfg config.Config, opts ...Option) (Factory, error) {
 	sf := &factory{
-		currentChainHeight: 0,
-		numCandidates:      cfg.Chain.NumCandidates,
+		currentChainHeight:  0,
+		numCandidates:       cfg.Chain.NumCandidates,
+		slowActionThreshold: cfg.Chain.SlowActionThreshold,
+		undoHeights:         cfg.BlockSync.MaxReorgDepth,
 	}
 
 	for _, opt := range opts {
@@ -167,12 +189,14 @@ func (sf *factory) Stop(ctx context.Context) error {
 	return sf.lifecycle.OnStop(ctx)
 }
 
-// AddActionHandlers adds action handlers to the state factory
+// AddActionHandlers adds action handlers to the state factory, which handle the actions by priority and then in the
+// order they're added
 func (sf *factory) AddActionHandlers(actionHandlers ...protocol.ActionHandler) {
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
 
 	sf.actionHandlers = append(sf.actionHandlers, actionHandlers...)
+	protocol.SortActionHandlers(sf.actionHandlers)
 }
 
 //======================================
@@ -240,10 +264,18 @@ func (sf *factory) Height() (uint64, error) {
 	return byteutil.BytesToUint64(height), nil
 }
 
+// StateGrowth returns the cumulative state growth at the current height
+func (sf *factory) StateGrowth() (uint64, StateGrowth, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	totals, err := stateGrowthTotals(sf.dao)
+	return sf.currentChainHeight, totals, err
+}
+
 func (sf *factory) NewWorkingSet() (WorkingSet, error) {
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
-	return NewWorkingSet(sf.currentChainHeight, sf.dao, sf.rootHash(), sf.actionHandlers)
+	return NewWorkingSet(sf.currentChainHeight, sf.dao, sf.rootHash(), sf.actionHandlers, sf.slowActionThreshold)
 }
 
 // Commit persists all changes in RunActions() into the DB
@@ -263,9 +295,17 @@ func (sf *factory) Commit(ws WorkingSet) error {
 			ws.Version(),
 		)
 	}
+	growth, totals, err := putStateGrowth(ws, AccountKVNameSpace, contractKVNameSpace)
+	if err != nil {
+		return err
+	}
+	if err := journal(ws, sf.undoHeights); err != nil {
+		return err
+	}
 	if err := ws.Commit(); err != nil {
 		return errors.Wrap(err, "failed to commit working set")
 	}
+	growth.observe(totals)
 	// Update chain height and root
 	sf.currentChainHeight = ws.Height()
 	h := ws.RootHash()
@@ -275,6 +315,48 @@ func (sf *factory) Commit(ws WorkingSet) error {
 	return nil
 }
 
+// NewWorkingSetOn isn't supported by the trie based factory, because the state trie isn't able to build on the
+// uncommitted root
+func (sf *factory) NewWorkingSetOn(parent WorkingSet) (WorkingSet, error) {
+	return nil, errors.New("working set on top of a pending one isn't supported by the trie based factory")
+}
+
+// CommitBatch commits the working sets one by one
+func (sf *factory) CommitBatch(wss []WorkingSet) error {
+	for _, ws := range wss {
+		if err := sf.Commit(ws); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Snapshot takes a snapshot of the underlying DB to copy while running
+func (sf *factory) Snapshot() (db.Snapshot, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	return db.NewSnapshot(sf.dao)
+}
+
+// RevertTo reverts the states to the height by applying the undo logs of the heights above it, and points the state
+// trie back to its root at the height
+func (sf *factory) RevertTo(height uint64) error {
+	sf.mutex.Lock()
+	defer sf.mutex.Unlock()
+	if err := revertStates(sf.dao, sf.currentChainHeight, height); err != nil {
+		return err
+	}
+	rootHash, err := sf.dao.Get(AccountKVNameSpace, []byte(AccountTrieRootKey))
+	if err != nil {
+		return errors.Wrap(err, "failed to get accountTrie's root hash")
+	}
+	if err := sf.accountTrie.SetRootHash(rootHash); err != nil {
+		return errors.Wrapf(err, "failed to revert states to height %d", height)
+	}
+	sf.currentChainHeight = height
+	return nil
+}
+
 //======================================
 // Candidate functions
 //======================================
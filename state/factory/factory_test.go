@@ -1092,6 +1092,89 @@ func TestFactory_RootHashByHeight(t *testing.T) {
 	require.NotEqual(t, hash.ZeroHash256, rootHash)
 }
 
+func TestFactory_RevertTo(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.BlockSync.MaxReorgDepth = 2
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	testRevertTo(sf, t)
+	_, err = sf.RootHashByHeight(2)
+	require.Error(err)
+
+	sdb, err := NewStateDB(cfg, InMemStateDBOption())
+	require.NoError(err)
+	testRevertTo(sdb, t)
+
+	// No undo log is kept if the reorg is disabled
+	cfg.BlockSync.MaxReorgDepth = 0
+	sdb, err = NewStateDB(cfg, InMemStateDBOption())
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(sdb.Start(ctx))
+	defer func() {
+		require.NoError(sdb.Stop(ctx))
+	}()
+	ws, err := sdb.NewWorkingSet()
+	require.NoError(err)
+	_, _, err = ws.RunActions(ctx, 1, nil)
+	require.NoError(err)
+	require.NoError(sdb.Commit(ws))
+	require.Error(sdb.RevertTo(0))
+}
+
+func testRevertTo(sf Factory, t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	addr := testaddress.Addrinfo["alfa"].String()
+	commit := func(height uint64, balance int64) {
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		acct, err := util.LoadOrCreateAccount(ws, addr, big.NewInt(0))
+		require.NoError(err)
+		acct.Balance = big.NewInt(balance)
+		require.NoError(ws.PutState(byteutil.BytesTo20B(testaddress.Addrinfo["alfa"].Bytes()), acct))
+		_, _, err = ws.RunActions(ctx, height, nil)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+	}
+	commit(1, 10)
+	rootHash1 := sf.RootHash()
+	commit(2, 20)
+	commit(3, 30)
+
+	require.Error(sf.RevertTo(3))
+	// The undo log of height 1 has been dropped, and the states are left untouched
+	require.Error(sf.RevertTo(0))
+	balance, err := sf.Balance(addr)
+	require.NoError(err)
+	require.Equal(big.NewInt(30), balance)
+
+	require.NoError(sf.RevertTo(1))
+	height, err := sf.Height()
+	require.NoError(err)
+	require.Equal(uint64(1), height)
+	require.Equal(rootHash1, sf.RootHash())
+	balance, err = sf.Balance(addr)
+	require.NoError(err)
+	require.Equal(big.NewInt(10), balance)
+
+	// The states are committed again on the reverted height
+	commit(2, 25)
+	balance, err = sf.Balance(addr)
+	require.NoError(err)
+	require.Equal(big.NewInt(25), balance)
+	require.NoError(sf.RevertTo(1))
+	balance, err = sf.Balance(addr)
+	require.NoError(err)
+	require.Equal(big.NewInt(10), balance)
+}
+
 func compareStrings(actual []string, expected []string) bool {
 	act := make(map[string]bool)
 	for i := 0; i < len(actual); i++ {
@@ -1234,3 +1317,166 @@ func randStringRunes(n int) string {
 	}
 	return string(b)
 }
+
+func TestStateDBCommitBatch(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	sdb, err := NewStateDB(cfg, InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sdb.Start(context.Background()))
+	defer func() {
+		require.NoError(sdb.Stop(context.Background()))
+	}()
+
+	addr := testaddress.Addrinfo["alfa"].String()
+	pkHash := byteutil.BytesTo20B(testaddress.Addrinfo["alfa"].Bytes())
+	ws1, err := sdb.NewWorkingSet()
+	require.NoError(err)
+	_, err = util.LoadOrCreateAccount(ws1, addr, big.NewInt(5))
+	require.NoError(err)
+	_, _, err = ws1.RunActions(context.Background(), 1, nil)
+	require.NoError(err)
+
+	// the stacked working set reads the pending changes of its parent
+	ws2, err := sdb.NewWorkingSetOn(ws1)
+	require.NoError(err)
+	require.Equal(uint64(1), ws2.Version())
+	acct, err := util.LoadAccount(ws2, pkHash)
+	require.NoError(err)
+	require.Equal(big.NewInt(5), acct.Balance)
+	acct.Balance = big.NewInt(7)
+	require.NoError(ws2.PutState(pkHash, acct))
+	_, _, err = ws2.RunActions(context.Background(), 2, nil)
+	require.NoError(err)
+
+	// nothing is persisted before the batch is committed
+	acct, err = sdb.AccountState(addr)
+	require.NoError(err)
+	require.Equal(big.NewInt(0), acct.Balance)
+
+	// working sets out of order are rejected
+	require.Error(sdb.CommitBatch([]WorkingSet{ws2, ws1}))
+	require.NoError(sdb.CommitBatch([]WorkingSet{ws1, ws2}))
+	height, err := sdb.Height()
+	require.NoError(err)
+	require.Equal(uint64(2), height)
+	acct, err = sdb.AccountState(addr)
+	require.NoError(err)
+	require.Equal(big.NewInt(7), acct.Balance)
+
+	// the trie factory can't stack working sets
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = sf.NewWorkingSetOn(ws)
+	require.Error(err)
+}
+
+func TestFactory_StateGrowth(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.BlockSync.MaxReorgDepth = 2
+	sf, err := NewFactory(cfg, InMemTrieOption())
+	require.NoError(err)
+	totals := testStateGrowth(sf, t)
+	require.True(totals[1].TrieNodes > 0)
+
+	sdb, err := NewStateDB(cfg, InMemStateDBOption())
+	require.NoError(err)
+	totals = testStateGrowth(sdb, t)
+	// Only the account of alfa and the height are stored in the state db
+	acct := state.EmptyAccount()
+	acct.Balance = big.NewInt(10)
+	data, err := acct.Serialize()
+	require.NoError(err)
+	acctSize := int64(20 + len(data))
+	heightSize := int64(len(CurrentHeightKey) + 8)
+	require.Equal(StateGrowth{StateBytes: acctSize + heightSize}, totals[1])
+	require.Equal(totals[1], totals[2])
+	require.Equal(StateGrowth{StateBytes: heightSize}, totals[3])
+
+	// The totals committed in a batch are the same as those committed one by one
+	sdb, err = NewStateDB(cfg, InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sdb.Start(context.Background()))
+	defer func() {
+		require.NoError(sdb.Stop(context.Background()))
+	}()
+	addr := testaddress.Addrinfo["alfa"].String()
+	ws1, err := sdb.NewWorkingSet()
+	require.NoError(err)
+	_, err = util.LoadOrCreateAccount(ws1, addr, big.NewInt(10))
+	require.NoError(err)
+	_, _, err = ws1.RunActions(context.Background(), 1, nil)
+	require.NoError(err)
+	ws2, err := sdb.NewWorkingSetOn(ws1)
+	require.NoError(err)
+	_, _, err = ws2.RunActions(context.Background(), 2, nil)
+	require.NoError(err)
+	require.NoError(sdb.CommitBatch([]WorkingSet{ws1, ws2}))
+	height, growth, err := sdb.StateGrowth()
+	require.NoError(err)
+	require.Equal(uint64(2), height)
+	require.Equal(totals[2], growth)
+}
+
+// testStateGrowth creates, updates and deletes the account of alfa at height 1, 2 and 3, and returns the totals at each
+// height
+func testStateGrowth(sf Factory, t *testing.T) map[uint64]StateGrowth {
+	require := require.New(t)
+	ctx := context.Background()
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	height, growth, err := sf.StateGrowth()
+	require.NoError(err)
+	require.Equal(uint64(0), height)
+	require.Equal(StateGrowth{}, growth)
+
+	addr := testaddress.Addrinfo["alfa"].String()
+	pkHash := byteutil.BytesTo20B(testaddress.Addrinfo["alfa"].Bytes())
+	totals := make(map[uint64]StateGrowth)
+	commit := func(height uint64, update func(WorkingSet)) {
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		update(ws)
+		_, _, err = ws.RunActions(ctx, height, nil)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+		h, growth, err := sf.StateGrowth()
+		require.NoError(err)
+		require.Equal(height, h)
+		totals[height] = growth
+	}
+	commit(1, func(ws WorkingSet) {
+		_, err := util.LoadOrCreateAccount(ws, addr, big.NewInt(10))
+		require.NoError(err)
+	})
+	require.True(totals[1].StateBytes > 0)
+	commit(2, func(ws WorkingSet) {
+		acct, err := util.LoadAccount(ws, pkHash)
+		require.NoError(err)
+		acct.Balance = big.NewInt(20)
+		require.NoError(ws.PutState(pkHash, acct))
+	})
+	commit(3, func(ws WorkingSet) {
+		require.NoError(ws.DelState(pkHash))
+	})
+	require.True(totals[3].StateBytes < totals[2].StateBytes)
+
+	// The totals are reverted along with the states
+	require.NoError(sf.RevertTo(1))
+	height, growth, err = sf.StateGrowth()
+	require.NoError(err)
+	require.Equal(uint64(1), height)
+	require.Equal(totals[1], growth)
+	return totals
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// contractKVNameSpace is the namespace of the contract storage tries, the same as evm.ContractKVNameSpace, which
+// imports this package in its tests
+const contractKVNameSpace = "Contract"
+
+var (
+	blockStateGrowthMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_block_state_growth",
+			Help: "Net number of trie nodes and net bytes of states added by the latest block",
+		},
+		[]string{"type"},
+	)
+	stateSizeMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_state_size",
+			Help: "Cumulative net number of trie nodes and net bytes of states",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(blockStateGrowthMtc)
+	prometheus.MustRegister(stateSizeMtc)
+}
+
+// StateGrowth is the net number of the trie nodes and the net bytes of the state records, including their keys, added.
+// They're negative if more are deleted than added
+type StateGrowth struct {
+	TrieNodes  int64
+	StateBytes int64
+}
+
+// Add adds the growth of a block onto the totals
+func (g StateGrowth) Add(other StateGrowth) StateGrowth {
+	return StateGrowth{TrieNodes: g.TrieNodes + other.TrieNodes, StateBytes: g.StateBytes + other.StateBytes}
+}
+
+// Serialize serializes the state growth into bytes
+func (g StateGrowth) Serialize() []byte {
+	return append(byteutil.Uint64ToBytes(uint64(g.TrieNodes)), byteutil.Uint64ToBytes(uint64(g.StateBytes))...)
+}
+
+// Deserialize deserializes bytes into the state growth
+func (g *StateGrowth) Deserialize(data []byte) error {
+	if len(data) != 16 {
+		return errors.Errorf("invalid state growth length %d", len(data))
+	}
+	g.TrieNodes = int64(byteutil.BytesToUint64(data[:8]))
+	g.StateBytes = int64(byteutil.BytesToUint64(data[8:]))
+	return nil
+}
+
+// observe exports the growth of the latest block and the totals
+func (g StateGrowth) observe(totals StateGrowth) {
+	blockStateGrowthMtc.WithLabelValues("trieNodes").Set(float64(g.TrieNodes))
+	blockStateGrowthMtc.WithLabelValues("stateBytes").Set(float64(g.StateBytes))
+	stateSizeMtc.WithLabelValues("trieNodes").Set(float64(totals.TrieNodes))
+	stateSizeMtc.WithLabelValues("stateBytes").Set(float64(totals.StateBytes))
+}
+
+// stateGrowthTotals reads the cumulative state growth, which is zero before any block is committed with it tracked
+func stateGrowthTotals(kv db.KVStore) (StateGrowth, error) {
+	var totals StateGrowth
+	data, err := kv.Get(AccountKVNameSpace, []byte(StateGrowthKey))
+	switch errors.Cause(err) {
+	case nil:
+		return totals, totals.Deserialize(data)
+	case db.ErrNotExist:
+		return totals, nil
+	default:
+		return totals, errors.Wrap(err, "failed to get state growth")
+	}
+}
+
+// putStateGrowth measures the growth of the states written by the working set against the underlying DB, and puts the
+// cumulative totals into its batch, so that the totals are committed and reverted along with the states. A record in
+// one of the trie namespaces is a trie node if its key is a node hash. It returns the growth of the working set along
+// with the totals
+func putStateGrowth(ws WorkingSet, trieNamespaces ...string) (StateGrowth, StateGrowth, error) {
+	type record struct{ namespace, key string }
+	kv := ws.GetDB()
+	cb := ws.GetCachedBatch()
+	isTrie := make(map[string]bool, len(trieNamespaces))
+	for _, ns := range trieNamespaces {
+		isTrie[ns] = true
+	}
+
+	var growth StateGrowth
+	cb.Lock()
+	// Only the last write of a record counts
+	last := make(map[record]int)
+	for i := 0; i < cb.Size(); i++ {
+		wi, err := cb.Entry(i)
+		if err != nil {
+			cb.Unlock()
+			return growth, growth, err
+		}
+		last[record{wi.Namespace(), string(wi.Key())}] = i
+	}
+	for r, i := range last {
+		if r.namespace == UndoKVNameSpace || (r.namespace == AccountKVNameSpace && r.key == StateGrowthKey) {
+			continue
+		}
+		wi, err := cb.Entry(i)
+		if err != nil {
+			cb.Unlock()
+			return growth, growth, err
+		}
+		existed := true
+		value, err := kv.Get(r.namespace, []byte(r.key))
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist:
+			existed = false
+		default:
+			cb.Unlock()
+			return growth, growth, errors.Wrapf(err, "failed to get key %x in namespace %s", r.key, r.namespace)
+		}
+		node := int64(0)
+		if isTrie[r.namespace] && len(r.key) == len(hash.ZeroHash256) {
+			node = 1
+		}
+		switch {
+		case wi.WriteType() == db.Delete && existed:
+			growth.TrieNodes -= node
+			growth.StateBytes -= int64(len(r.key) + len(value))
+		case wi.WriteType() == db.Put && existed:
+			growth.StateBytes += int64(len(wi.Value()) - len(value))
+		case wi.WriteType() == db.Put:
+			growth.TrieNodes += node
+			growth.StateBytes += int64(len(r.key) + len(wi.Value()))
+		}
+	}
+	cb.Unlock()
+
+	totals, err := stateGrowthTotals(kv)
+	if err != nil {
+		return growth, growth, err
+	}
+	totals = totals.Add(growth)
+	cb.Put(AccountKVNameSpace, []byte(StateGrowthKey), totals.Serialize(), "failed to store state growth")
+	return growth, totals, nil
+}
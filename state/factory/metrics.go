@@ -0,0 +1,102 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+var (
+	actionExecTimeMtc = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name:       "iotex_action_execution_time",
+			Help:       "Wall-clock time of executing an action in seconds",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"type"},
+	)
+
+	slowActionMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_slow_actions",
+			Help: "Actions whose execution time exceeds the threshold",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(actionExecTimeMtc)
+	prometheus.MustRegister(slowActionMtc)
+}
+
+// actionTiming is the execution time of an action and the receipt it produced
+type actionTiming struct {
+	elp     action.SealedEnvelope
+	receipt *action.Receipt
+	elapsed time.Duration
+}
+
+// actionObserver times the actions run in a working set, which are only observed once the working set is committed,
+// so that the runs to mint or validate a block aren't counted. The actions taking longer than the slow action threshold
+// are logged, and a zero threshold disables the logging
+type actionObserver struct {
+	slowActionThreshold time.Duration
+	timings             []actionTiming
+}
+
+// newActionObserver creates an observer, which logs the actions taking longer than the slow action threshold
+func newActionObserver(slowActionThreshold time.Duration) *actionObserver {
+	return &actionObserver{slowActionThreshold: slowActionThreshold}
+}
+
+// record records the execution time since start of the action, which produced the receipt
+func (o *actionObserver) record(elp action.SealedEnvelope, receipt *action.Receipt, start time.Time) {
+	o.timings = append(o.timings, actionTiming{elp: elp, receipt: receipt, elapsed: time.Since(start)})
+}
+
+// observe observes the actions recorded and clears them
+func (o *actionObserver) observe() {
+	for _, t := range o.timings {
+		observeAction(t.elp, t.receipt, t.elapsed, o.slowActionThreshold)
+	}
+	o.timings = nil
+}
+
+// observeAction records the execution time of the action, which produced the receipt, and logs the action if it takes
+// longer than the threshold, along with the contract it executes, so that the heavy contracts could be identified. A
+// zero threshold disables the logging
+func observeAction(elp action.SealedEnvelope, receipt *action.Receipt, elapsed time.Duration, threshold time.Duration) {
+	typ := action.TypeName(elp.Action())
+	actionExecTimeMtc.WithLabelValues(typ).Observe(elapsed.Seconds())
+	if threshold == 0 || elapsed <= threshold {
+		return
+	}
+	slowActionMtc.WithLabelValues(typ).Inc()
+	h := elp.Hash()
+	fields := []zap.Field{
+		zap.String("actionHash", hex.EncodeToString(h[:])),
+		zap.String("type", typ),
+		zap.Duration("elapsed", elapsed),
+	}
+	if exec, ok := elp.Action().(*action.Execution); ok {
+		contract := exec.Contract()
+		if contract == action.EmptyAddress {
+			// The contract deployed by the execution
+			contract = receipt.ContractAddress
+		}
+		fields = append(fields, zap.String("contract", contract), zap.Uint64("gasConsumed", receipt.GasConsumed))
+	}
+	log.L().Warn("Slow action.", fields...)
+}
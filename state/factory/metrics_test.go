@@ -0,0 +1,62 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestObserveAction(t *testing.T) {
+	require := require.New(t)
+
+	samples := func(typ string) uint64 {
+		var metric dto.Metric
+		s, ok := actionExecTimeMtc.WithLabelValues(typ).(prometheus.Summary)
+		require.True(ok)
+		require.NoError(s.Write(&metric))
+		return metric.GetSummary().GetSampleCount()
+	}
+	slowActions := func(typ string) float64 {
+		var metric dto.Metric
+		require.NoError(slowActionMtc.WithLabelValues(typ).Write(&metric))
+		return metric.GetCounter().GetValue()
+	}
+
+	tsf, err := testutil.SignedTransfer(testaddress.Addrinfo["bravo"].String(), testaddress.Keyinfo["alfa"].PriKey, 1,
+		big.NewInt(1), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice))
+	require.NoError(err)
+	exec, err := testutil.SignedExecution(action.EmptyAddress, testaddress.Keyinfo["alfa"].PriKey, 2, big.NewInt(0),
+		testutil.TestGasLimit, big.NewInt(testutil.TestGasPrice), nil)
+	require.NoError(err)
+	receipt := &action.Receipt{ContractAddress: testaddress.Addrinfo["charlie"].String(), GasConsumed: 100}
+
+	tsfs, tsfSlow := samples("Transfer"), slowActions("Transfer")
+	execs, execSlow := samples("Execution"), slowActions("Execution")
+
+	// Every action is timed, while the slow ones aren't logged if the threshold is 0
+	observeAction(tsf, receipt, time.Second, 0)
+	require.Equal(tsfs+1, samples("Transfer"))
+	require.Equal(tsfSlow, slowActions("Transfer"))
+
+	observeAction(tsf, receipt, 0, time.Hour)
+	require.Equal(tsfs+2, samples("Transfer"))
+	require.Equal(tsfSlow, slowActions("Transfer"))
+
+	observeAction(exec, receipt, time.Second, time.Millisecond)
+	require.Equal(execs+1, samples("Execution"))
+	require.Equal(execSlow+1, slowActions("Execution"))
+}
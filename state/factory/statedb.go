@@ -12,6 +12,7 @@ import (
 	"math/big"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -30,17 +31,31 @@ import (
 
 // stateDB implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
 type stateDB struct {
-	mutex              sync.RWMutex
-	currentChainHeight uint64
-	numCandidates      uint
-	dao                db.KVStore               // the underlying DB for account/contract storage
-	actionHandlers     []protocol.ActionHandler // the handlers to handle actions
-	timerFactory       *prometheustimer.TimerFactory
+	mutex               sync.RWMutex
+	currentChainHeight  uint64
+	numCandidates       uint
+	dao                 db.KVStore               // the underlying DB for account/contract storage
+	actionHandlers      []protocol.ActionHandler // the handlers to handle actions
+	timerFactory        *prometheustimer.TimerFactory
+	slowActionThreshold time.Duration
+	// undoHeights is the number of the latest heights whose undo logs are kept, 0 means none
+	undoHeights uint64
 }
 
 // StateDBOption sets stateDB construction parameter
 type StateDBOption func(*stateDB, config.Config) error
 
+// PrecreatedStateDBOption uses pre-created KV store for state db
+func PrecreatedStateDBOption(kv db.KVStore) StateDBOption {
+	return func(sdb *stateDB, cfg config.Config) (err error) {
+		if kv == nil {
+			return errors.New("Invalid empty KV store")
+		}
+		sdb.dao = kv
+		return nil
+	}
+}
+
 // DefaultStateDBOption creates trie from config for state db
 func DefaultStateDBOption() StateDBOption {
 	return func(sdb *stateDB, cfg config.Config) (err error) {
@@ -65,8 +80,10 @@ func InMemStateDBOption() StateDBOption {
 // NewStateDB creates a new state db
 func NewStateDB(cfg config.Config, opts ...StateDBOption) (Factory, error) {
 	sdb := stateDB{
-		currentChainHeight: 0,
-		numCandidates:      cfg.Chain.NumCandidates,
+		currentChainHeight:  0,
+		numCandidates:       cfg.Chain.NumCandidates,
+		slowActionThreshold: cfg.Chain.SlowActionThreshold,
+		undoHeights:         cfg.BlockSync.MaxReorgDepth,
 	}
 
 	for _, opt := range opts {
@@ -100,11 +117,13 @@ func (sdb *stateDB) Stop(ctx context.Context) error {
 	return sdb.dao.Stop(ctx)
 }
 
-// AddActionHandlers adds action handlers to the state factory
+// AddActionHandlers adds action handlers to the state factory, which handle the actions by priority and then in the
+// order they're added
 func (sdb *stateDB) AddActionHandlers(actionHandlers ...protocol.ActionHandler) {
 	sdb.mutex.Lock()
 	defer sdb.mutex.Unlock()
 	sdb.actionHandlers = append(sdb.actionHandlers, actionHandlers...)
+	protocol.SortActionHandlers(sdb.actionHandlers)
 }
 
 //======================================
@@ -158,10 +177,18 @@ func (sdb *stateDB) Height() (uint64, error) {
 	return byteutil.BytesToUint64(height), nil
 }
 
+// StateGrowth returns the cumulative state growth at the current height
+func (sdb *stateDB) StateGrowth() (uint64, StateGrowth, error) {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	totals, err := stateGrowthTotals(sdb.dao)
+	return sdb.currentChainHeight, totals, err
+}
+
 func (sdb *stateDB) NewWorkingSet() (WorkingSet, error) {
 	sdb.mutex.RLock()
 	defer sdb.mutex.RUnlock()
-	return newStateTX(sdb.currentChainHeight, sdb.dao, sdb.actionHandlers), nil
+	return newStateTX(sdb.currentChainHeight, sdb.dao, sdb.actionHandlers, sdb.slowActionThreshold), nil
 }
 
 // Commit persists all changes in RunActions() into the DB
@@ -181,14 +208,96 @@ func (sdb *stateDB) Commit(ws WorkingSet) error {
 			ws.Version(),
 		)
 	}
+	growth, totals, err := putStateGrowth(ws, contractKVNameSpace)
+	if err != nil {
+		return err
+	}
+	if err := journal(ws, sdb.undoHeights); err != nil {
+		return err
+	}
 	if err := ws.Commit(); err != nil {
 		return errors.Wrap(err, "failed to commit working set")
 	}
+	growth.observe(totals)
 	// Update chain height
 	sdb.currentChainHeight = ws.Height()
 	return nil
 }
 
+// NewWorkingSetOn creates a working set on top of the pending changes of the parent working set
+func (sdb *stateDB) NewWorkingSetOn(parent WorkingSet) (WorkingSet, error) {
+	stx, ok := parent.(*stateTX)
+	if !ok {
+		return nil, errors.New("parent working set isn't created by the state db")
+	}
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	return newStateTX(
+		stx.Height(),
+		&pendingKVStore{KVStore: stx.dao, pending: stx.cb},
+		sdb.actionHandlers,
+		sdb.slowActionThreshold,
+	), nil
+}
+
+// CommitBatch persists the changes of the working sets in a single DB transaction
+func (sdb *stateDB) CommitBatch(wss []WorkingSet) error {
+	if len(wss) == 0 {
+		return nil
+	}
+	sdb.mutex.Lock()
+	defer sdb.mutex.Unlock()
+	timer := sdb.timerFactory.NewTimer("CommitBatch")
+	defer timer.End()
+	version := sdb.currentChainHeight
+	batches := make([]db.KVStoreBatch, 0, len(wss))
+	var growth, totals StateGrowth
+	for _, ws := range wss {
+		if ws == nil {
+			return errors.New("working set doesn't exist")
+		}
+		if ws.Version() != version {
+			return fmt.Errorf("state height %d doesn't match working set version %d", version, ws.Version())
+		}
+		wsGrowth, wsTotals, err := putStateGrowth(ws, contractKVNameSpace)
+		if err != nil {
+			return err
+		}
+		if err := journal(ws, sdb.undoHeights); err != nil {
+			return err
+		}
+		growth, totals = wsGrowth, wsTotals
+		batches = append(batches, ws.GetCachedBatch())
+		version = ws.Height()
+	}
+	batch := db.MergeBatches(batches...)
+	dbBatchSizelMtc.WithLabelValues().Set(float64(batch.Size()))
+	if err := sdb.dao.Commit(batch); err != nil {
+		return errors.Wrap(err, "failed to commit working sets in a batch")
+	}
+	sdb.currentChainHeight = version
+	growth.observe(totals)
+	return nil
+}
+
+// Snapshot takes a snapshot of the underlying DB to copy while running
+func (sdb *stateDB) Snapshot() (db.Snapshot, error) {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	return db.NewSnapshot(sdb.dao)
+}
+
+// RevertTo reverts the states to the height by applying the undo logs of the heights above it
+func (sdb *stateDB) RevertTo(height uint64) error {
+	sdb.mutex.Lock()
+	defer sdb.mutex.Unlock()
+	if err := revertStates(sdb.dao, sdb.currentChainHeight, height); err != nil {
+		return err
+	}
+	sdb.currentChainHeight = height
+	return nil
+}
+
 //======================================
 // Candidate functions
 //======================================
@@ -0,0 +1,104 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+type (
+	// StateChange is the change of a state made by an action. Before or After is nil if the state doesn't exist
+	StateChange struct {
+		Key    hash.Hash160
+		Before []byte
+		After  []byte
+	}
+
+	// ActionStateDiff is the changes of the states made by an action, in the order of the states first changed
+	ActionStateDiff struct {
+		ActionHash hash.Hash256
+		Changes    []StateChange
+	}
+
+	// stateRecorder records the keys of the states changed, along with the bytes before the first change
+	stateRecorder struct {
+		keys   []hash.Hash160
+		before map[hash.Hash160][]byte
+	}
+
+	// recordingWorkingSet is a working set able to record the changes of the states
+	recordingWorkingSet interface {
+		setRecorder(*stateRecorder)
+		rawState(hash.Hash160) ([]byte, error)
+	}
+)
+
+func newStateRecorder() *stateRecorder {
+	return &stateRecorder{before: make(map[hash.Hash160][]byte)}
+}
+
+// record reads the state of the key before it's first changed
+func (r *stateRecorder) record(key hash.Hash160, rawState func(hash.Hash160) ([]byte, error)) error {
+	if r == nil {
+		return nil
+	}
+	if _, ok := r.before[key]; ok {
+		return nil
+	}
+	before, err := rawState(key)
+	if err != nil {
+		return err
+	}
+	r.keys = append(r.keys, key)
+	r.before[key] = before
+	return nil
+}
+
+// RunActionsWithDiffs runs the actions in the working set the same way as RunActions, and returns the changes of the
+// states made by each action besides the root hash. It's slower than RunActions, and is meant for diagnosing the
+// states diverging from other nodes
+func RunActionsWithDiffs(
+	ctx context.Context,
+	ws WorkingSet,
+	blockHeight uint64,
+	elps []action.SealedEnvelope,
+) (hash.Hash256, []*ActionStateDiff, error) {
+	rws, ok := ws.(recordingWorkingSet)
+	if !ok {
+		return hash.ZeroHash256, nil, errors.New("working set isn't able to record the changes of the states")
+	}
+	defer rws.setRecorder(nil)
+	diffs := make([]*ActionStateDiff, 0, len(elps))
+	for _, elp := range elps {
+		recorder := newStateRecorder()
+		rws.setRecorder(recorder)
+		if _, err := ws.RunAction(ctx, elp); err != nil {
+			return hash.ZeroHash256, nil, errors.Wrap(err, "error when run action")
+		}
+		rws.setRecorder(nil)
+		diff := &ActionStateDiff{ActionHash: elp.Hash()}
+		for _, key := range recorder.keys {
+			after, err := rws.rawState(key)
+			if err != nil {
+				return hash.ZeroHash256, nil, err
+			}
+			before := recorder.before[key]
+			if bytes.Equal(before, after) {
+				continue
+			}
+			diff.Changes = append(diff.Changes, StateChange{Key: key, Before: before, After: after})
+		}
+		diffs = append(diffs, diff)
+	}
+	return ws.UpdateBlockLevelInfo(blockHeight), diffs, nil
+}
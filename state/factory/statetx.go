@@ -8,6 +8,7 @@ package factory
 
 import (
 	"context"
+	"time"
 
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
@@ -30,6 +31,27 @@ type stateTX struct {
 	cb             db.CachedBatch // cached batch for pending writes
 	dao            db.KVStore     // the underlying DB for account/contract storage
 	actionHandlers []protocol.ActionHandler
+	observer       *actionObserver
+	// recorder records the changes of the states if it's set
+	recorder *stateRecorder
+}
+
+// pendingKVStore reads the pending changes of an uncommitted working set before the underlying DB
+type pendingKVStore struct {
+	db.KVStore
+	pending db.CachedBatch
+}
+
+// Get reads the record from the pending changes first
+func (s *pendingKVStore) Get(namespace string, key []byte) ([]byte, error) {
+	v, err := s.pending.Get(namespace, key)
+	switch errors.Cause(err) {
+	case nil:
+		return v, nil
+	case db.ErrAlreadyDeleted:
+		return nil, errors.Wrapf(db.ErrNotExist, "key %x is deleted in the pending changes", key)
+	}
+	return s.KVStore.Get(namespace, key)
 }
 
 // newStateTX creates a new state tx
@@ -37,12 +59,14 @@ func newStateTX(
 	version uint64,
 	kv db.KVStore,
 	actionHandlers []protocol.ActionHandler,
+	slowActionThreshold time.Duration,
 ) *stateTX {
 	return &stateTX{
 		ver:            version,
 		cb:             db.NewCachedBatch(),
 		dao:            kv,
 		actionHandlers: actionHandlers,
+		observer:       newActionObserver(slowActionThreshold),
 	}
 }
 
@@ -104,6 +128,7 @@ func (stx *stateTX) RunAction(
 	raCtx.IntrinsicGas = intrinsicGas
 	raCtx.Nonce = elp.Nonce()
 	ctx = protocol.WithRunActionsCtx(ctx, raCtx)
+	start := time.Now()
 	for _, actionHandler := range stx.actionHandlers {
 		receipt, err := actionHandler.Handle(ctx, elp.Action(), stx)
 		if err != nil {
@@ -116,6 +141,7 @@ func (stx *stateTX) RunAction(
 			)
 		}
 		if receipt != nil {
+			stx.observer.record(elp, receipt, start)
 			return receipt, nil
 		}
 	}
@@ -142,6 +168,7 @@ func (stx *stateTX) Commit() error {
 	if err := stx.dao.Commit(stx.cb); err != nil {
 		return errors.Wrap(err, "failed to Commit all changes to underlying DB in a batch")
 	}
+	stx.observer.observe()
 	return nil
 }
 
@@ -159,7 +186,11 @@ func (stx *stateTX) GetCachedBatch() db.CachedBatch {
 func (stx *stateTX) State(hash hash.Hash160, s interface{}) error {
 	stateDBMtc.WithLabelValues("get").Inc()
 	mstate, err := stx.cb.Get(AccountKVNameSpace, hash[:])
-	if errors.Cause(err) == db.ErrNotExist {
+	switch errors.Cause(err) {
+	case db.ErrAlreadyDeleted:
+		// The state deleted in the batch doesn't exist, as in the trie working set
+		return errors.Wrapf(state.ErrStateNotExist, "k = %x doesn't exist", hash)
+	case db.ErrNotExist:
 		if mstate, err = stx.dao.Get(AccountKVNameSpace, hash[:]); errors.Cause(err) == db.ErrNotExist {
 			return errors.Wrapf(state.ErrStateNotExist, "k = %x doesn't exist", hash)
 		}
@@ -177,12 +208,40 @@ func (stx *stateTX) PutState(pkHash hash.Hash160, s interface{}) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to convert account %v to bytes", s)
 	}
+	if err := stx.recorder.record(pkHash, stx.rawState); err != nil {
+		return err
+	}
 	stx.cb.Put(AccountKVNameSpace, pkHash[:], ss, "error when putting k = %x", pkHash)
 	return nil
 }
 
 // DelState deletes a state from DB
 func (stx *stateTX) DelState(pkHash hash.Hash160) error {
+	if err := stx.recorder.record(pkHash, stx.rawState); err != nil {
+		return err
+	}
 	stx.cb.Delete(AccountKVNameSpace, pkHash[:], "error when deleting k = %x", pkHash)
 	return nil
 }
+
+func (stx *stateTX) setRecorder(recorder *stateRecorder) { stx.recorder = recorder }
+
+// rawState returns the bytes of the state, or nil if it doesn't exist
+func (stx *stateTX) rawState(pkHash hash.Hash160) ([]byte, error) {
+	mstate, err := stx.cb.Get(AccountKVNameSpace, pkHash[:])
+	switch errors.Cause(err) {
+	case nil:
+		return mstate, nil
+	case db.ErrAlreadyDeleted:
+		return nil, nil
+	case db.ErrNotExist:
+		mstate, err = stx.dao.Get(AccountKVNameSpace, pkHash[:])
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get state of %x", pkHash)
+	}
+	return mstate, nil
+}
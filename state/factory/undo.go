@@ -0,0 +1,72 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// journal puts the undo log of the working set into its own batch, so that the undo log is committed along with the
+// states, and drops the undo log falling out of the latest undoHeights heights
+func journal(ws WorkingSet, undoHeights uint64) error {
+	if undoHeights == 0 {
+		return nil
+	}
+	cb := ws.GetCachedBatch()
+	undo, err := db.UndoBatch(ws.GetDB(), cb)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create undo log of height %d", ws.Height())
+	}
+	height := ws.Height()
+	cb.Put(
+		UndoKVNameSpace,
+		byteutil.Uint64ToBytes(height),
+		db.SerializeBatch(undo),
+		"failed to put undo log of height %d",
+		height,
+	)
+	if height > undoHeights {
+		cb.Delete(
+			UndoKVNameSpace,
+			byteutil.Uint64ToBytes(height-undoHeights),
+			"failed to delete undo log of height %d",
+			height-undoHeights,
+		)
+	}
+	return nil
+}
+
+// revertStates reverts the states committed at the heights above target, by applying their undo logs from the current
+// height down. All the undo logs are checked to exist beforehand, so that the states aren't partially reverted for lack
+// of an undo log
+func revertStates(dao db.KVStore, current, target uint64) error {
+	if target >= current {
+		return errors.Errorf("cannot revert state height %d to %d", current, target)
+	}
+	undos := make([]db.KVStoreBatch, 0, current-target)
+	for h := current; h > target; h-- {
+		data, err := dao.Get(UndoKVNameSpace, byteutil.Uint64ToBytes(h))
+		if err != nil {
+			return errors.Wrapf(err, "failed to get undo log of height %d", h)
+		}
+		undo, err := db.DeserializeBatch(data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize undo log of height %d", h)
+		}
+		undo.Delete(UndoKVNameSpace, byteutil.Uint64ToBytes(h), "failed to delete undo log of height %d", h)
+		undos = append(undos, undo)
+	}
+	for i, undo := range undos {
+		if err := dao.Commit(undo); err != nil {
+			return errors.Wrapf(err, "failed to revert states of height %d", current-uint64(i))
+		}
+	}
+	return nil
+}
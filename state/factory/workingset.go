@@ -9,6 +9,7 @@ package factory
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/iotexproject/iotex-core/address"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
@@ -80,15 +81,20 @@ type (
 		cb             db.CachedBatch       // cached batch for pending writes
 		dao            db.KVStore           // the underlying DB for account/contract storage
 		actionHandlers []protocol.ActionHandler
+		observer       *actionObserver
+		// recorder records the changes of the states if it's set
+		recorder *stateRecorder
 	}
 )
 
-// NewWorkingSet creates a new working set
+// NewWorkingSet creates a new working set, which logs the actions taking longer than the slow action threshold to
+// run
 func NewWorkingSet(
 	version uint64,
 	kv db.KVStore,
 	root hash.Hash256,
 	actionHandlers []protocol.ActionHandler,
+	slowActionThreshold time.Duration,
 ) (WorkingSet, error) {
 	ws := &workingSet{
 		ver:            version,
@@ -96,6 +102,7 @@ func NewWorkingSet(
 		cb:             db.NewCachedBatch(),
 		dao:            kv,
 		actionHandlers: actionHandlers,
+		observer:       newActionObserver(slowActionThreshold),
 	}
 	dbForTrie, err := db.NewKVStoreForTrie(AccountKVNameSpace, ws.dao, db.CachedBatchOption(ws.cb))
 	if err != nil {
@@ -177,6 +184,7 @@ func (ws *workingSet) RunAction(
 	raCtx.Nonce = elp.Nonce()
 	ctx = protocol.WithRunActionsCtx(ctx, raCtx)
 
+	start := time.Now()
 	for _, actionHandler := range ws.actionHandlers {
 		receipt, err := actionHandler.Handle(ctx, elp.Action(), ws)
 		if err != nil {
@@ -189,6 +197,7 @@ func (ws *workingSet) RunAction(
 			)
 		}
 		if receipt != nil {
+			ws.observer.record(elp, receipt, start)
 			return receipt, nil
 		}
 	}
@@ -239,6 +248,7 @@ func (ws *workingSet) Commit() error {
 	if err := ws.dao.Commit(ws.cb); err != nil {
 		return errors.Wrap(err, "failed to Commit all changes to underlying DB in a batch")
 	}
+	ws.observer.observe()
 	ws.clear()
 	return nil
 }
@@ -273,14 +283,34 @@ func (ws *workingSet) PutState(pkHash hash.Hash160, s interface{}) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to convert account %v to bytes", s)
 	}
+	if err := ws.recorder.record(pkHash, ws.rawState); err != nil {
+		return err
+	}
 	return ws.accountTrie.Upsert(pkHash[:], ss)
 }
 
 // DelState deletes a state from DB
 func (ws *workingSet) DelState(pkHash hash.Hash160) error {
+	if err := ws.recorder.record(pkHash, ws.rawState); err != nil {
+		return err
+	}
 	return ws.accountTrie.Delete(pkHash[:])
 }
 
+func (ws *workingSet) setRecorder(recorder *stateRecorder) { ws.recorder = recorder }
+
+// rawState returns the bytes of the state, or nil if it doesn't exist
+func (ws *workingSet) rawState(pkHash hash.Hash160) ([]byte, error) {
+	mstate, err := ws.accountTrie.Get(pkHash[:])
+	if errors.Cause(err) == trie.ErrNotExist {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get state of %x", pkHash)
+	}
+	return mstate, nil
+}
+
 // clearCache removes all local changes after committing to trie
 func (ws *workingSet) clear() {
 	ws.trieRoots = nil
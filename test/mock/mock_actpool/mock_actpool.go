@@ -5,9 +5,12 @@
 package mock_actpool
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	action "github.com/iotexproject/iotex-core/action"
 	protocol "github.com/iotexproject/iotex-core/action/protocol"
+	actpool "github.com/iotexproject/iotex-core/actpool"
+	block "github.com/iotexproject/iotex-core/blockchain/block"
 	hash "github.com/iotexproject/iotex-core/pkg/hash"
 	reflect "reflect"
 )
@@ -35,6 +38,30 @@ func (m *MockActPool) EXPECT() *MockActPoolMockRecorder {
 	return m.recorder
 }
 
+// Start mocks base method
+func (m *MockActPool) Start(arg0 context.Context) error {
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start
+func (mr *MockActPoolMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockActPool)(nil).Start), arg0)
+}
+
+// Stop mocks base method
+func (m *MockActPool) Stop(arg0 context.Context) error {
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop
+func (mr *MockActPoolMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockActPool)(nil).Stop), arg0)
+}
+
 // Reset mocks base method
 func (m *MockActPool) Reset() {
 	m.ctrl.Call(m, "Reset")
@@ -46,15 +73,15 @@ func (mr *MockActPoolMockRecorder) Reset() *gomock.Call {
 }
 
 // PickActs mocks base method
-func (m *MockActPool) PickActs() []action.SealedEnvelope {
-	ret := m.ctrl.Call(m, "PickActs")
+func (m *MockActPool) PickActs(budget actpool.PickBudget) []action.SealedEnvelope {
+	ret := m.ctrl.Call(m, "PickActs", budget)
 	ret0, _ := ret[0].([]action.SealedEnvelope)
 	return ret0
 }
 
 // PickActs indicates an expected call of PickActs
-func (mr *MockActPoolMockRecorder) PickActs() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickActs", reflect.TypeOf((*MockActPool)(nil).PickActs))
+func (mr *MockActPoolMockRecorder) PickActs(budget interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickActs", reflect.TypeOf((*MockActPool)(nil).PickActs), budget)
 }
 
 // PendingActionMap mocks base method
@@ -81,6 +108,30 @@ func (mr *MockActPoolMockRecorder) Add(act interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockActPool)(nil).Add), act)
 }
 
+// AddLocal mocks base method
+func (m *MockActPool) AddLocal(act action.SealedEnvelope) error {
+	ret := m.ctrl.Call(m, "AddLocal", act)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLocal indicates an expected call of AddLocal
+func (mr *MockActPoolMockRecorder) AddLocal(act interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLocal", reflect.TypeOf((*MockActPool)(nil).AddLocal), act)
+}
+
+// AddBatch mocks base method
+func (m *MockActPool) AddBatch(acts []action.SealedEnvelope) []error {
+	ret := m.ctrl.Call(m, "AddBatch", acts)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// AddBatch indicates an expected call of AddBatch
+func (mr *MockActPoolMockRecorder) AddBatch(acts interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBatch", reflect.TypeOf((*MockActPool)(nil).AddBatch), acts)
+}
+
 // GetPendingNonce mocks base method
 func (m *MockActPool) GetPendingNonce(addr string) (uint64, error) {
 	ret := m.ctrl.Call(m, "GetPendingNonce", addr)
@@ -94,6 +145,19 @@ func (mr *MockActPoolMockRecorder) GetPendingNonce(addr interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNonce", reflect.TypeOf((*MockActPool)(nil).GetPendingNonce), addr)
 }
 
+// PendingState mocks base method
+func (m *MockActPool) PendingState(addr string) (actpool.AccountState, error) {
+	ret := m.ctrl.Call(m, "PendingState", addr)
+	ret0, _ := ret[0].(actpool.AccountState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingState indicates an expected call of PendingState
+func (mr *MockActPoolMockRecorder) PendingState(addr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingState", reflect.TypeOf((*MockActPool)(nil).PendingState), addr)
+}
+
 // GetUnconfirmedActs mocks base method
 func (m *MockActPool) GetUnconfirmedActs(addr string) []action.SealedEnvelope {
 	ret := m.ctrl.Call(m, "GetUnconfirmedActs", addr)
@@ -106,6 +170,30 @@ func (mr *MockActPoolMockRecorder) GetUnconfirmedActs(addr interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnconfirmedActs", reflect.TypeOf((*MockActPool)(nil).GetUnconfirmedActs), addr)
 }
 
+// GetQueuedActs mocks base method
+func (m *MockActPool) GetQueuedActs(addr string) []action.SealedEnvelope {
+	ret := m.ctrl.Call(m, "GetQueuedActs", addr)
+	ret0, _ := ret[0].([]action.SealedEnvelope)
+	return ret0
+}
+
+// GetQueuedActs indicates an expected call of GetQueuedActs
+func (mr *MockActPoolMockRecorder) GetQueuedActs(addr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueuedActs", reflect.TypeOf((*MockActPool)(nil).GetQueuedActs), addr)
+}
+
+// Stats mocks base method
+func (m *MockActPool) Stats() actpool.Stats {
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(actpool.Stats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats
+func (mr *MockActPoolMockRecorder) Stats() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockActPool)(nil).Stats))
+}
+
 // GetActionByHash mocks base method
 func (m *MockActPool) GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, error) {
 	ret := m.ctrl.Call(m, "GetActionByHash", hash)
@@ -170,3 +258,63 @@ func (m *MockActPool) AddActionEnvelopeValidators(arg0 ...protocol.ActionEnvelop
 func (mr *MockActPoolMockRecorder) AddActionEnvelopeValidators(arg0 ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActionEnvelopeValidators", reflect.TypeOf((*MockActPool)(nil).AddActionEnvelopeValidators), arg0...)
 }
+
+// AddSubscriber mocks base method
+func (m *MockActPool) AddSubscriber(arg0 actpool.Subscriber) error {
+	ret := m.ctrl.Call(m, "AddSubscriber", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubscriber indicates an expected call of AddSubscriber
+func (mr *MockActPoolMockRecorder) AddSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockActPool)(nil).AddSubscriber), arg0)
+}
+
+// RemoveSubscriber mocks base method
+func (m *MockActPool) RemoveSubscriber(arg0 actpool.Subscriber) error {
+	ret := m.ctrl.Call(m, "RemoveSubscriber", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSubscriber indicates an expected call of RemoveSubscriber
+func (mr *MockActPoolMockRecorder) RemoveSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscriber", reflect.TypeOf((*MockActPool)(nil).RemoveSubscriber), arg0)
+}
+
+// Validated mocks base method
+func (m *MockActPool) Validated(act action.SealedEnvelope) bool {
+	ret := m.ctrl.Call(m, "Validated", act)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Validated indicates an expected call of Validated
+func (mr *MockActPoolMockRecorder) Validated(act interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validated", reflect.TypeOf((*MockActPool)(nil).Validated), act)
+}
+
+// RegisterAdmissionHook mocks base method
+func (m *MockActPool) RegisterAdmissionHook(arg0 actpool.AdmissionHook) error {
+	ret := m.ctrl.Call(m, "RegisterAdmissionHook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterAdmissionHook indicates an expected call of RegisterAdmissionHook
+func (mr *MockActPoolMockRecorder) RegisterAdmissionHook(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAdmissionHook", reflect.TypeOf((*MockActPool)(nil).RegisterAdmissionHook), arg0)
+}
+
+// HandleBlockRevert mocks base method
+func (m *MockActPool) HandleBlockRevert(blk *block.Block) error {
+	ret := m.ctrl.Call(m, "HandleBlockRevert", blk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleBlockRevert indicates an expected call of HandleBlockRevert
+func (mr *MockActPoolMockRecorder) HandleBlockRevert(blk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockRevert", reflect.TypeOf((*MockActPool)(nil).HandleBlockRevert), blk)
+}
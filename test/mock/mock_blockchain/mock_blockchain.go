@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	action "github.com/iotexproject/iotex-core/action"
+	evm "github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	address "github.com/iotexproject/iotex-core/address"
 	blockchain "github.com/iotexproject/iotex-core/blockchain"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
@@ -391,6 +392,32 @@ func (mr *MockBlockchainMockRecorder) GetReceiptByActionHash(h interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptByActionHash", reflect.TypeOf((*MockBlockchain)(nil).GetReceiptByActionHash), h)
 }
 
+// GetReceiptsByHeight mocks base method
+func (m *MockBlockchain) GetReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	ret := m.ctrl.Call(m, "GetReceiptsByHeight", height)
+	ret0, _ := ret[0].([]*action.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceiptsByHeight indicates an expected call of GetReceiptsByHeight
+func (mr *MockBlockchainMockRecorder) GetReceiptsByHeight(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptsByHeight", reflect.TypeOf((*MockBlockchain)(nil).GetReceiptsByHeight), height)
+}
+
+// FilterLogs mocks base method
+func (m *MockBlockchain) FilterLogs(fromHeight, toHeight uint64, topics []hash.Hash256, address string) ([]*action.Log, error) {
+	ret := m.ctrl.Call(m, "FilterLogs", fromHeight, toHeight, topics, address)
+	ret0, _ := ret[0].([]*action.Log)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterLogs indicates an expected call of FilterLogs
+func (mr *MockBlockchainMockRecorder) FilterLogs(fromHeight, toHeight, topics, address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterLogs", reflect.TypeOf((*MockBlockchain)(nil).FilterLogs), fromHeight, toHeight, topics, address)
+}
+
 // GetActionsFromAddress mocks base method
 func (m *MockBlockchain) GetActionsFromAddress(address string) ([]hash.Hash256, error) {
 	ret := m.ctrl.Call(m, "GetActionsFromAddress", address)
@@ -528,6 +555,94 @@ func (mr *MockBlockchainMockRecorder) RecoverChainAndState(targetHeight interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverChainAndState", reflect.TypeOf((*MockBlockchain)(nil).RecoverChainAndState), targetHeight)
 }
 
+// RollbackTo mocks base method
+func (m *MockBlockchain) RollbackTo(height uint64) error {
+	ret := m.ctrl.Call(m, "RollbackTo", height)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTo indicates an expected call of RollbackTo
+func (mr *MockBlockchainMockRecorder) RollbackTo(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTo", reflect.TypeOf((*MockBlockchain)(nil).RollbackTo), height)
+}
+
+// Fork mocks base method
+func (m *MockBlockchain) Fork(blk *block.Block) error {
+	ret := m.ctrl.Call(m, "Fork", blk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fork indicates an expected call of Fork
+func (mr *MockBlockchainMockRecorder) Fork(blk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fork", reflect.TypeOf((*MockBlockchain)(nil).Fork), blk)
+}
+
+// Rebase mocks base method
+func (m *MockBlockchain) Rebase(h hash.Hash256, validateFooter func(*block.Block) error) error {
+	ret := m.ctrl.Call(m, "Rebase", h, validateFooter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebase indicates an expected call of Rebase
+func (mr *MockBlockchainMockRecorder) Rebase(h, validateFooter interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockBlockchain)(nil).Rebase), h, validateFooter)
+}
+
+// Replay mocks base method
+func (m *MockBlockchain) Replay(ctx context.Context, targetHeight uint64) (uint64, error) {
+	ret := m.ctrl.Call(m, "Replay", ctx, targetHeight)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay
+func (mr *MockBlockchainMockRecorder) Replay(ctx, targetHeight interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockBlockchain)(nil).Replay), ctx, targetHeight)
+}
+
+// ExportState mocks base method
+func (m *MockBlockchain) ExportState(ctx context.Context, targetHeight uint64) (*blockchain.StateSnapshot, error) {
+	ret := m.ctrl.Call(m, "ExportState", ctx, targetHeight)
+	ret0, _ := ret[0].(*blockchain.StateSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportState indicates an expected call of ExportState
+func (mr *MockBlockchainMockRecorder) ExportState(ctx, targetHeight interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportState", reflect.TypeOf((*MockBlockchain)(nil).ExportState), ctx, targetHeight)
+}
+
+// StateDiff mocks base method
+func (m *MockBlockchain) StateDiff(height uint64) (*blockchain.StateDiff, error) {
+	ret := m.ctrl.Call(m, "StateDiff", height)
+	ret0, _ := ret[0].(*blockchain.StateDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateDiff indicates an expected call of StateDiff
+func (mr *MockBlockchainMockRecorder) StateDiff(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDiff", reflect.TypeOf((*MockBlockchain)(nil).StateDiff), height)
+}
+
+// Backup mocks base method
+func (m *MockBlockchain) Backup(dir string) (uint64, error) {
+	ret := m.ctrl.Call(m, "Backup", dir)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup
+func (mr *MockBlockchainMockRecorder) Backup(dir interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockBlockchain)(nil).Backup), dir)
+}
+
 // MintNewBlock mocks base method
 func (m *MockBlockchain) MintNewBlock(actionMap map[string][]action.SealedEnvelope, producerPubKey keypair.PublicKey, producerPriKey keypair.PrivateKey, producerAddr string, timestamp int64) (*block.Block, error) {
 	ret := m.ctrl.Call(m, "MintNewBlock", actionMap, producerPubKey, producerPriKey, producerAddr, timestamp)
@@ -541,6 +656,19 @@ func (mr *MockBlockchainMockRecorder) MintNewBlock(actionMap, producerPubKey, pr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintNewBlock", reflect.TypeOf((*MockBlockchain)(nil).MintNewBlock), actionMap, producerPubKey, producerPriKey, producerAddr, timestamp)
 }
 
+// MintNewBlockSignedWith mocks base method
+func (m *MockBlockchain) MintNewBlockSignedWith(actionMap map[string][]action.SealedEnvelope, producerPubKey keypair.PublicKey, sign, signAction func([]byte) ([]byte, error), producerAddr string, timestamp int64, vrfProof []byte) (*block.Block, error) {
+	ret := m.ctrl.Call(m, "MintNewBlockSignedWith", actionMap, producerPubKey, sign, signAction, producerAddr, timestamp, vrfProof)
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MintNewBlockSignedWith indicates an expected call of MintNewBlockSignedWith
+func (mr *MockBlockchainMockRecorder) MintNewBlockSignedWith(actionMap, producerPubKey, sign, signAction, producerAddr, timestamp, vrfProof interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintNewBlockSignedWith", reflect.TypeOf((*MockBlockchain)(nil).MintNewBlockSignedWith), actionMap, producerPubKey, sign, signAction, producerAddr, timestamp, vrfProof)
+}
+
 // CommitBlock mocks base method
 func (m *MockBlockchain) CommitBlock(blk *block.Block) error {
 	ret := m.ctrl.Call(m, "CommitBlock", blk)
@@ -553,6 +681,19 @@ func (mr *MockBlockchainMockRecorder) CommitBlock(blk interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBlock", reflect.TypeOf((*MockBlockchain)(nil).CommitBlock), blk)
 }
 
+// CommitBlocks mocks base method
+func (m *MockBlockchain) CommitBlocks(blks []*block.Block, validateFooter func(*block.Block) error) (int, error) {
+	ret := m.ctrl.Call(m, "CommitBlocks", blks, validateFooter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitBlocks indicates an expected call of CommitBlocks
+func (mr *MockBlockchainMockRecorder) CommitBlocks(blks, validateFooter interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBlocks", reflect.TypeOf((*MockBlockchain)(nil).CommitBlocks), blks, validateFooter)
+}
+
 // ValidateBlock mocks base method
 func (m *MockBlockchain) ValidateBlock(blk *block.Block) error {
 	ret := m.ctrl.Call(m, "ValidateBlock", blk)
@@ -565,6 +706,30 @@ func (mr *MockBlockchainMockRecorder) ValidateBlock(blk interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlock", reflect.TypeOf((*MockBlockchain)(nil).ValidateBlock), blk)
 }
 
+// ImportTrustedBlock mocks base method
+func (m *MockBlockchain) ImportTrustedBlock(blk *block.Block) error {
+	ret := m.ctrl.Call(m, "ImportTrustedBlock", blk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportTrustedBlock indicates an expected call of ImportTrustedBlock
+func (mr *MockBlockchainMockRecorder) ImportTrustedBlock(blk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTrustedBlock", reflect.TypeOf((*MockBlockchain)(nil).ImportTrustedBlock), blk)
+}
+
+// LoadStateSnapshot mocks base method
+func (m *MockBlockchain) LoadStateSnapshot(snapshot *blockchain.StateSnapshot) error {
+	ret := m.ctrl.Call(m, "LoadStateSnapshot", snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadStateSnapshot indicates an expected call of LoadStateSnapshot
+func (mr *MockBlockchainMockRecorder) LoadStateSnapshot(snapshot interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadStateSnapshot", reflect.TypeOf((*MockBlockchain)(nil).LoadStateSnapshot), snapshot)
+}
+
 // Validator mocks base method
 func (m *MockBlockchain) Validator() blockchain.Validator {
 	ret := m.ctrl.Call(m, "Validator")
@@ -600,6 +765,19 @@ func (mr *MockBlockchainMockRecorder) ExecuteContractRead(caller, ex interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteContractRead", reflect.TypeOf((*MockBlockchain)(nil).ExecuteContractRead), caller, ex)
 }
 
+// ExecuteContractReadWithOverrides mocks base method
+func (m *MockBlockchain) ExecuteContractReadWithOverrides(caller address.Address, ex *action.Execution, overrides []*evm.StateOverride) (*action.Receipt, error) {
+	ret := m.ctrl.Call(m, "ExecuteContractReadWithOverrides", caller, ex, overrides)
+	ret0, _ := ret[0].(*action.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteContractReadWithOverrides indicates an expected call of ExecuteContractReadWithOverrides
+func (mr *MockBlockchainMockRecorder) ExecuteContractReadWithOverrides(caller, ex, overrides interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteContractReadWithOverrides", reflect.TypeOf((*MockBlockchain)(nil).ExecuteContractReadWithOverrides), caller, ex, overrides)
+}
+
 // AddSubscriber mocks base method
 func (m *MockBlockchain) AddSubscriber(arg0 blockchain.BlockCreationSubscriber) error {
 	ret := m.ctrl.Call(m, "AddSubscriber", arg0)
@@ -612,6 +790,18 @@ func (mr *MockBlockchainMockRecorder) AddSubscriber(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockBlockchain)(nil).AddSubscriber), arg0)
 }
 
+// AddDurableSubscriber mocks base method
+func (m *MockBlockchain) AddDurableSubscriber(arg0 string, arg1 blockchain.BlockCreationSubscriber) error {
+	ret := m.ctrl.Call(m, "AddDurableSubscriber", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDurableSubscriber indicates an expected call of AddDurableSubscriber
+func (mr *MockBlockchainMockRecorder) AddDurableSubscriber(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDurableSubscriber", reflect.TypeOf((*MockBlockchain)(nil).AddDurableSubscriber), arg0, arg1)
+}
+
 // RemoveSubscriber mocks base method
 func (m *MockBlockchain) RemoveSubscriber(arg0 blockchain.BlockCreationSubscriber) error {
 	ret := m.ctrl.Call(m, "RemoveSubscriber", arg0)
@@ -623,3 +813,15 @@ func (m *MockBlockchain) RemoveSubscriber(arg0 blockchain.BlockCreationSubscribe
 func (mr *MockBlockchainMockRecorder) RemoveSubscriber(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscriber", reflect.TypeOf((*MockBlockchain)(nil).RemoveSubscriber), arg0)
 }
+
+// AddRevertSubscriber mocks base method
+func (m *MockBlockchain) AddRevertSubscriber(arg0 blockchain.BlockRevertSubscriber) error {
+	ret := m.ctrl.Call(m, "AddRevertSubscriber", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRevertSubscriber indicates an expected call of AddRevertSubscriber
+func (mr *MockBlockchainMockRecorder) AddRevertSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRevertSubscriber", reflect.TypeOf((*MockBlockchain)(nil).AddRevertSubscriber), arg0)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
+	iotexapi "github.com/iotexproject/iotex-core/protogen/iotexapi"
 	iotexrpc "github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	go_libp2p_peerstore "github.com/libp2p/go-libp2p-peerstore"
 	reflect "reflect"
@@ -72,6 +73,28 @@ func (mr *MockBlockSyncMockRecorder) TargetHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetHeight", reflect.TypeOf((*MockBlockSync)(nil).TargetHeight))
 }
 
+// CatchingUp mocks base method
+func (m *MockBlockSync) CatchingUp() bool {
+	ret := m.ctrl.Call(m, "CatchingUp")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CatchingUp indicates an expected call of CatchingUp
+func (mr *MockBlockSyncMockRecorder) CatchingUp() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CatchingUp", reflect.TypeOf((*MockBlockSync)(nil).CatchingUp))
+}
+
+// SyncToHeight mocks base method
+func (m *MockBlockSync) SyncToHeight(height uint64) {
+	m.ctrl.Call(m, "SyncToHeight", height)
+}
+
+// SyncToHeight indicates an expected call of SyncToHeight
+func (mr *MockBlockSyncMockRecorder) SyncToHeight(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncToHeight", reflect.TypeOf((*MockBlockSync)(nil).SyncToHeight), height)
+}
+
 // ProcessSyncRequest mocks base method
 func (m *MockBlockSync) ProcessSyncRequest(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, sync *iotexrpc.BlockSync) error {
 	ret := m.ctrl.Call(m, "ProcessSyncRequest", ctx, peer, sync)
@@ -97,13 +120,141 @@ func (mr *MockBlockSyncMockRecorder) ProcessBlock(ctx, blk interface{}) *gomock.
 }
 
 // ProcessBlockSync mocks base method
-func (m *MockBlockSync) ProcessBlockSync(ctx context.Context, blk *block.Block) error {
-	ret := m.ctrl.Call(m, "ProcessBlockSync", ctx, blk)
+func (m *MockBlockSync) ProcessBlockSync(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, blk *block.Block) error {
+	ret := m.ctrl.Call(m, "ProcessBlockSync", ctx, peer, blk)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ProcessBlockSync indicates an expected call of ProcessBlockSync
-func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(ctx, blk interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), ctx, blk)
+func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(ctx, peer, blk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), ctx, peer, blk)
+}
+
+// ProcessCompactBlock mocks base method
+func (m *MockBlockSync) ProcessCompactBlock(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, cb *iotexrpc.CompactBlock) error {
+	ret := m.ctrl.Call(m, "ProcessCompactBlock", ctx, peer, cb)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessCompactBlock indicates an expected call of ProcessCompactBlock
+func (mr *MockBlockSyncMockRecorder) ProcessCompactBlock(ctx, peer, cb interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessCompactBlock", reflect.TypeOf((*MockBlockSync)(nil).ProcessCompactBlock), ctx, peer, cb)
+}
+
+// ProcessBlockActionsRequest mocks base method
+func (m *MockBlockSync) ProcessBlockActionsRequest(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, req *iotexrpc.BlockActionsRequest) error {
+	ret := m.ctrl.Call(m, "ProcessBlockActionsRequest", ctx, peer, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessBlockActionsRequest indicates an expected call of ProcessBlockActionsRequest
+func (mr *MockBlockSyncMockRecorder) ProcessBlockActionsRequest(ctx, peer, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockActionsRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockActionsRequest), ctx, peer, req)
+}
+
+// ProcessBlockActions mocks base method
+func (m *MockBlockSync) ProcessBlockActions(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, ba *iotexrpc.BlockActions) error {
+	ret := m.ctrl.Call(m, "ProcessBlockActions", ctx, peer, ba)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessBlockActions indicates an expected call of ProcessBlockActions
+func (mr *MockBlockSyncMockRecorder) ProcessBlockActions(ctx, peer, ba interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockActions", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockActions), ctx, peer, ba)
+}
+
+// ProcessSnapshotChunkRequest mocks base method
+func (m *MockBlockSync) ProcessSnapshotChunkRequest(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, req *iotexrpc.SnapshotChunkRequest) error {
+	ret := m.ctrl.Call(m, "ProcessSnapshotChunkRequest", ctx, peer, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessSnapshotChunkRequest indicates an expected call of ProcessSnapshotChunkRequest
+func (mr *MockBlockSyncMockRecorder) ProcessSnapshotChunkRequest(ctx, peer, req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSnapshotChunkRequest", reflect.TypeOf((*MockBlockSync)(nil).ProcessSnapshotChunkRequest), ctx, peer, req)
+}
+
+// ProcessSnapshotChunk mocks base method
+func (m *MockBlockSync) ProcessSnapshotChunk(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, chunk *iotexrpc.SnapshotChunk) error {
+	ret := m.ctrl.Call(m, "ProcessSnapshotChunk", ctx, peer, chunk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessSnapshotChunk indicates an expected call of ProcessSnapshotChunk
+func (mr *MockBlockSyncMockRecorder) ProcessSnapshotChunk(ctx, peer, chunk interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessSnapshotChunk", reflect.TypeOf((*MockBlockSync)(nil).ProcessSnapshotChunk), ctx, peer, chunk)
+}
+
+// BufferStats mocks base method
+func (m *MockBlockSync) BufferStats() *iotexapi.BlockSyncBufferStats {
+	ret := m.ctrl.Call(m, "BufferStats")
+	ret0, _ := ret[0].(*iotexapi.BlockSyncBufferStats)
+	return ret0
+}
+
+// BufferStats indicates an expected call of BufferStats
+func (mr *MockBlockSyncMockRecorder) BufferStats() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BufferStats", reflect.TypeOf((*MockBlockSync)(nil).BufferStats))
+}
+
+// PeerScores mocks base method
+func (m *MockBlockSync) PeerScores() []*iotexapi.PeerScore {
+	ret := m.ctrl.Call(m, "PeerScores")
+	ret0, _ := ret[0].([]*iotexapi.PeerScore)
+	return ret0
+}
+
+// PeerScores indicates an expected call of PeerScores
+func (mr *MockBlockSyncMockRecorder) PeerScores() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerScores", reflect.TypeOf((*MockBlockSync)(nil).PeerScores))
+}
+
+// ForkEvents mocks base method
+func (m *MockBlockSync) ForkEvents() []*iotexapi.ForkEvent {
+	ret := m.ctrl.Call(m, "ForkEvents")
+	ret0, _ := ret[0].([]*iotexapi.ForkEvent)
+	return ret0
+}
+
+// ForkEvents indicates an expected call of ForkEvents
+func (mr *MockBlockSyncMockRecorder) ForkEvents() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkEvents", reflect.TypeOf((*MockBlockSync)(nil).ForkEvents))
+}
+
+// SyncStatus mocks base method
+func (m *MockBlockSync) SyncStatus() *iotexapi.BlockSyncStatus {
+	ret := m.ctrl.Call(m, "SyncStatus")
+	ret0, _ := ret[0].(*iotexapi.BlockSyncStatus)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus
+func (mr *MockBlockSyncMockRecorder) SyncStatus() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockBlockSync)(nil).SyncStatus))
+}
+
+// SubscribeSyncStatus mocks base method
+func (m *MockBlockSync) SubscribeSyncStatus(ch chan<- *iotexapi.BlockSyncStatus) {
+	m.ctrl.Call(m, "SubscribeSyncStatus", ch)
+}
+
+// SubscribeSyncStatus indicates an expected call of SubscribeSyncStatus
+func (mr *MockBlockSyncMockRecorder) SubscribeSyncStatus(ch interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeSyncStatus", reflect.TypeOf((*MockBlockSync)(nil).SubscribeSyncStatus), ch)
+}
+
+// UnsubscribeSyncStatus mocks base method
+func (m *MockBlockSync) UnsubscribeSyncStatus(ch chan<- *iotexapi.BlockSyncStatus) {
+	m.ctrl.Call(m, "UnsubscribeSyncStatus", ch)
+}
+
+// UnsubscribeSyncStatus indicates an expected call of UnsubscribeSyncStatus
+func (mr *MockBlockSyncMockRecorder) UnsubscribeSyncStatus(ch interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeSyncStatus", reflect.TypeOf((*MockBlockSync)(nil).UnsubscribeSyncStatus), ch)
 }
@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 	scheme "github.com/iotexproject/iotex-core/consensus/scheme"
+	signer "github.com/iotexproject/iotex-core/consensus/signer"
 	iotexrpc "github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	reflect "reflect"
 )
@@ -94,6 +95,18 @@ func (mr *MockConsensusMockRecorder) ValidateBlockFooter(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockFooter", reflect.TypeOf((*MockConsensus)(nil).ValidateBlockFooter), arg0)
 }
 
+// PreferBlock mocks base method
+func (m *MockConsensus) PreferBlock(committed, competing *block.Block) bool {
+	ret := m.ctrl.Call(m, "PreferBlock", committed, competing)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// PreferBlock indicates an expected call of PreferBlock
+func (mr *MockConsensusMockRecorder) PreferBlock(committed, competing interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreferBlock", reflect.TypeOf((*MockConsensus)(nil).PreferBlock), committed, competing)
+}
+
 // Metrics mocks base method
 func (m *MockConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 	ret := m.ctrl.Call(m, "Metrics")
@@ -106,3 +119,15 @@ func (m *MockConsensus) Metrics() (scheme.ConsensusMetrics, error) {
 func (mr *MockConsensusMockRecorder) Metrics() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metrics", reflect.TypeOf((*MockConsensus)(nil).Metrics))
 }
+
+// Signer mocks base method
+func (m *MockConsensus) Signer() signer.Signer {
+	ret := m.ctrl.Call(m, "Signer")
+	ret0, _ := ret[0].(signer.Signer)
+	return ret0
+}
+
+// Signer indicates an expected call of Signer
+func (mr *MockConsensusMockRecorder) Signer() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signer", reflect.TypeOf((*MockConsensus)(nil).Signer))
+}
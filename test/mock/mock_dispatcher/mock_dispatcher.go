@@ -63,15 +63,15 @@ func (mr *MockSubscriberMockRecorder) HandleBlock(arg0, arg1 interface{}) *gomoc
 }
 
 // HandleBlockSync mocks base method
-func (m *MockSubscriber) HandleBlockSync(arg0 context.Context, arg1 *iotextypes.Block) error {
-	ret := m.ctrl.Call(m, "HandleBlockSync", arg0, arg1)
+func (m *MockSubscriber) HandleBlockSync(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotextypes.Block) error {
+	ret := m.ctrl.Call(m, "HandleBlockSync", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HandleBlockSync indicates an expected call of HandleBlockSync
-func (mr *MockSubscriberMockRecorder) HandleBlockSync(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockSync", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockSync), arg0, arg1)
+func (mr *MockSubscriberMockRecorder) HandleBlockSync(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockSync", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockSync), arg0, arg1, arg2)
 }
 
 // HandleSyncRequest mocks base method
@@ -86,6 +86,66 @@ func (mr *MockSubscriberMockRecorder) HandleSyncRequest(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSyncRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleSyncRequest), arg0, arg1, arg2)
 }
 
+// HandleCompactBlock mocks base method
+func (m *MockSubscriber) HandleCompactBlock(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotexrpc.CompactBlock) error {
+	ret := m.ctrl.Call(m, "HandleCompactBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleCompactBlock indicates an expected call of HandleCompactBlock
+func (mr *MockSubscriberMockRecorder) HandleCompactBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCompactBlock", reflect.TypeOf((*MockSubscriber)(nil).HandleCompactBlock), arg0, arg1, arg2)
+}
+
+// HandleBlockActionsRequest mocks base method
+func (m *MockSubscriber) HandleBlockActionsRequest(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotexrpc.BlockActionsRequest) error {
+	ret := m.ctrl.Call(m, "HandleBlockActionsRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleBlockActionsRequest indicates an expected call of HandleBlockActionsRequest
+func (mr *MockSubscriberMockRecorder) HandleBlockActionsRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockActionsRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockActionsRequest), arg0, arg1, arg2)
+}
+
+// HandleBlockActions mocks base method
+func (m *MockSubscriber) HandleBlockActions(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotexrpc.BlockActions) error {
+	ret := m.ctrl.Call(m, "HandleBlockActions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleBlockActions indicates an expected call of HandleBlockActions
+func (mr *MockSubscriberMockRecorder) HandleBlockActions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockActions", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockActions), arg0, arg1, arg2)
+}
+
+// HandleSnapshotChunkRequest mocks base method
+func (m *MockSubscriber) HandleSnapshotChunkRequest(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotexrpc.SnapshotChunkRequest) error {
+	ret := m.ctrl.Call(m, "HandleSnapshotChunkRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleSnapshotChunkRequest indicates an expected call of HandleSnapshotChunkRequest
+func (mr *MockSubscriberMockRecorder) HandleSnapshotChunkRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSnapshotChunkRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleSnapshotChunkRequest), arg0, arg1, arg2)
+}
+
+// HandleSnapshotChunk mocks base method
+func (m *MockSubscriber) HandleSnapshotChunk(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotexrpc.SnapshotChunk) error {
+	ret := m.ctrl.Call(m, "HandleSnapshotChunk", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleSnapshotChunk indicates an expected call of HandleSnapshotChunk
+func (mr *MockSubscriberMockRecorder) HandleSnapshotChunk(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSnapshotChunk", reflect.TypeOf((*MockSubscriber)(nil).HandleSnapshotChunk), arg0, arg1, arg2)
+}
+
 // HandleConsensusMsg mocks base method
 func (m *MockSubscriber) HandleConsensusMsg(arg0 *iotexrpc.Consensus) error {
 	ret := m.ctrl.Call(m, "HandleConsensusMsg", arg0)
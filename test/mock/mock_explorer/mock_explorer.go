@@ -501,6 +501,19 @@ func (mr *MockExplorerMockRecorder) GetReceiptByActionID(id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptByActionID", reflect.TypeOf((*MockExplorer)(nil).GetReceiptByActionID), id)
 }
 
+// GetDecodedLogsByActionID mocks base method
+func (m *MockExplorer) GetDecodedLogsByActionID(id, abi string) ([]explorer.DecodedLog, error) {
+	ret := m.ctrl.Call(m, "GetDecodedLogsByActionID", id, abi)
+	ret0, _ := ret[0].([]explorer.DecodedLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDecodedLogsByActionID indicates an expected call of GetDecodedLogsByActionID
+func (mr *MockExplorerMockRecorder) GetDecodedLogsByActionID(id, abi interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecodedLogsByActionID", reflect.TypeOf((*MockExplorer)(nil).GetDecodedLogsByActionID), id, abi)
+}
+
 // ReadExecutionState mocks base method
 func (m *MockExplorer) ReadExecutionState(request explorer.Execution) (string, error) {
 	ret := m.ctrl.Call(m, "ReadExecutionState", request)
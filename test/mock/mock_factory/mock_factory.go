@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	protocol "github.com/iotexproject/iotex-core/action/protocol"
+	db "github.com/iotexproject/iotex-core/db"
 	hash "github.com/iotexproject/iotex-core/pkg/hash"
 	state "github.com/iotexproject/iotex-core/state"
 	factory "github.com/iotexproject/iotex-core/state/factory"
@@ -139,6 +140,20 @@ func (mr *MockFactoryMockRecorder) Height() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Height", reflect.TypeOf((*MockFactory)(nil).Height))
 }
 
+// StateGrowth mocks base method
+func (m *MockFactory) StateGrowth() (uint64, factory.StateGrowth, error) {
+	ret := m.ctrl.Call(m, "StateGrowth")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(factory.StateGrowth)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StateGrowth indicates an expected call of StateGrowth
+func (mr *MockFactoryMockRecorder) StateGrowth() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateGrowth", reflect.TypeOf((*MockFactory)(nil).StateGrowth))
+}
+
 // NewWorkingSet mocks base method
 func (m *MockFactory) NewWorkingSet() (factory.WorkingSet, error) {
 	ret := m.ctrl.Call(m, "NewWorkingSet")
@@ -152,6 +167,19 @@ func (mr *MockFactoryMockRecorder) NewWorkingSet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWorkingSet", reflect.TypeOf((*MockFactory)(nil).NewWorkingSet))
 }
 
+// NewWorkingSetOn mocks base method
+func (m *MockFactory) NewWorkingSetOn(arg0 factory.WorkingSet) (factory.WorkingSet, error) {
+	ret := m.ctrl.Call(m, "NewWorkingSetOn", arg0)
+	ret0, _ := ret[0].(factory.WorkingSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewWorkingSetOn indicates an expected call of NewWorkingSetOn
+func (mr *MockFactoryMockRecorder) NewWorkingSetOn(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWorkingSetOn", reflect.TypeOf((*MockFactory)(nil).NewWorkingSetOn), arg0)
+}
+
 // Commit mocks base method
 func (m *MockFactory) Commit(arg0 factory.WorkingSet) error {
 	ret := m.ctrl.Call(m, "Commit", arg0)
@@ -164,6 +192,43 @@ func (mr *MockFactoryMockRecorder) Commit(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockFactory)(nil).Commit), arg0)
 }
 
+// CommitBatch mocks base method
+func (m *MockFactory) CommitBatch(arg0 []factory.WorkingSet) error {
+	ret := m.ctrl.Call(m, "CommitBatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitBatch indicates an expected call of CommitBatch
+func (mr *MockFactoryMockRecorder) CommitBatch(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBatch", reflect.TypeOf((*MockFactory)(nil).CommitBatch), arg0)
+}
+
+// Snapshot mocks base method
+func (m *MockFactory) Snapshot() (db.Snapshot, error) {
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(db.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockFactoryMockRecorder) Snapshot() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockFactory)(nil).Snapshot))
+}
+
+// RevertTo mocks base method
+func (m *MockFactory) RevertTo(arg0 uint64) error {
+	ret := m.ctrl.Call(m, "RevertTo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertTo indicates an expected call of RevertTo
+func (mr *MockFactoryMockRecorder) RevertTo(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertTo", reflect.TypeOf((*MockFactory)(nil).RevertTo), arg0)
+}
+
 // CandidatesByHeight mocks base method
 func (m *MockFactory) CandidatesByHeight(arg0 uint64) ([]*state.Candidate, error) {
 	ret := m.ctrl.Call(m, "CandidatesByHeight", arg0)
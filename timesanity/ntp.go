@@ -0,0 +1,80 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package timesanity
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ntpPacketSize = 48
+	// ntpEpochOffset is the number of seconds from the NTP epoch (1900) to the unix epoch (1970)
+	ntpEpochOffset = 2208988800
+)
+
+// QueryNTPOffset queries the NTP server at addr in SNTP (RFC 4330), and returns the offset of the server clock from the
+// local clock, i.e., a positive offset means the local clock is behind
+func QueryNTPOffset(addr string, timeout time.Duration) (time.Duration, error) {
+	conn, err := net.DialTimeout("udp", addr, timeout)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to dial NTP server %s", addr)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	req := make([]byte, ntpPacketSize)
+	// LI = 0 (no warning), VN = 4, Mode = 3 (client)
+	req[0] = 0<<6 | 4<<3 | 3
+	sent := time.Now()
+	putNTPTime(req[40:], sent)
+	if _, err := conn.Write(req); err != nil {
+		return 0, errors.Wrapf(err, "failed to send NTP request to %s", addr)
+	}
+	resp := make([]byte, ntpPacketSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read NTP response from %s", addr)
+	}
+	received := time.Now()
+	if n < ntpPacketSize {
+		return 0, errors.Errorf("NTP response from %s is too short", addr)
+	}
+	if mode := resp[0] & 0x7; mode != 4 {
+		return 0, errors.Errorf("unexpected NTP mode %d from %s", mode, addr)
+	}
+	if stratum := resp[1]; stratum == 0 {
+		return 0, errors.Errorf("NTP server %s is unsynchronized", addr)
+	}
+	// The server echoes the transmit timestamp of the request as the originate timestamp, which tells a response to
+	// this request from a stale or spoofed one
+	if !bytes.Equal(resp[24:32], req[40:48]) {
+		return 0, errors.Errorf("NTP response from %s doesn't match the request", addr)
+	}
+	serverReceived := ntpTime(resp[32:])
+	serverSent := ntpTime(resp[40:])
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+// ntpTime decodes a NTP timestamp, which is 32-bit seconds since the NTP epoch and 32-bit fraction of a second
+func ntpTime(b []byte) time.Time {
+	sec := int64(binary.BigEndian.Uint32(b[:4])) - ntpEpochOffset
+	frac := int64(binary.BigEndian.Uint32(b[4:8]))
+	return time.Unix(sec, frac*int64(time.Second)>>32)
+}
+
+// putNTPTime encodes t as a NTP timestamp into b
+func putNTPTime(b []byte, t time.Time) {
+	binary.BigEndian.PutUint32(b[:4], uint32(t.Unix()+ntpEpochOffset))
+	binary.BigEndian.PutUint32(b[4:8], uint32((int64(t.Nanosecond())<<32)/int64(time.Second)))
+}
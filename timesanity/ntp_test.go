@@ -0,0 +1,82 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package timesanity
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serveNTP answers a NTP request with the clock ahead of the local one by offset, and sends the error to errs. The
+// originate timestamp is echoed from the request unless it's spoofed
+func serveNTP(conn net.PacketConn, offset time.Duration, stratum byte, spoofed bool, errs chan<- error) {
+	req := make([]byte, ntpPacketSize)
+	_, addr, err := conn.ReadFrom(req)
+	if err != nil {
+		errs <- err
+		return
+	}
+	resp := make([]byte, ntpPacketSize)
+	// LI = 0, VN = 4, Mode = 4 (server)
+	resp[0] = 0<<6 | 4<<3 | 4
+	resp[1] = stratum
+	if !spoofed {
+		copy(resp[24:32], req[40:48])
+	}
+	putNTPTime(resp[32:], time.Now().Add(offset))
+	putNTPTime(resp[40:], time.Now().Add(offset))
+	_, err = conn.WriteTo(resp, addr)
+	errs <- err
+}
+
+func TestQueryNTPOffset(t *testing.T) {
+	require := require.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	defer conn.Close()
+	addr := conn.LocalAddr().String()
+
+	errs := make(chan error, 1)
+	go serveNTP(conn, 3*time.Second, 2, false, errs)
+	offset, err := QueryNTPOffset(addr, time.Second)
+	require.NoError(err)
+	require.NoError(<-errs)
+	require.InDelta(float64(3*time.Second), float64(offset), float64(100*time.Millisecond))
+
+	go serveNTP(conn, -3*time.Second, 2, false, errs)
+	offset, err = QueryNTPOffset(addr, time.Second)
+	require.NoError(err)
+	require.NoError(<-errs)
+	require.InDelta(float64(-3*time.Second), float64(offset), float64(100*time.Millisecond))
+
+	// The unsynchronized server
+	go serveNTP(conn, 0, 0, false, errs)
+	_, err = QueryNTPOffset(addr, time.Second)
+	require.Error(err)
+	require.NoError(<-errs)
+
+	// The response not matching the request
+	go serveNTP(conn, 0, 2, true, errs)
+	_, err = QueryNTPOffset(addr, time.Second)
+	require.Error(err)
+	require.NoError(<-errs)
+
+	// No response
+	_, err = QueryNTPOffset(addr, 100*time.Millisecond)
+	require.Error(err)
+}
+
+func TestNTPTime(t *testing.T) {
+	now := time.Unix(1556000000, 123456789)
+	b := make([]byte, 8)
+	putNTPTime(b, now)
+	require.InDelta(t, float64(now.UnixNano()), float64(ntpTime(b).UnixNano()), 10)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package timesanity checks the local clock against a NTP server and the timestamps of the recent blocks from the
+// peers. A delegate whose clock is skewed produces blocks with invalid timestamps, so the skew is warned about, and the
+// delegate optionally refuses to propose until the clock is fixed.
+package timesanity
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+)
+
+// maxPeerOffset is the max offset of a block from a peer taken as a sample. A block further off is relayed or replayed
+// rather than fresh, and it'd take longer than that for a clock to drift away
+const maxPeerOffset = time.Hour
+
+var skewMtc = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "iotex_clock_skew_seconds",
+		Help: "Offset of the local clock against the NTP server and the peers",
+	},
+	[]string{"source"},
+)
+
+func init() {
+	prometheus.MustRegister(skewMtc)
+}
+
+// Checker checks the clock skew periodically
+type Checker struct {
+	cfg      config.TimeSanity
+	queryNTP func(addr string, timeout time.Duration) (time.Duration, error)
+	task     *routine.RecurringTask
+
+	mu sync.RWMutex
+	// peerOffsets are the offsets of the recent blocks from the peers in the order they are received, one for each
+	// producer, so that a few producers can't outweigh the others
+	peerOffsets []peerOffset
+	skewed      bool
+}
+
+type peerOffset struct {
+	producer string
+	offset   time.Duration
+}
+
+// NewChecker creates a clock skew checker
+func NewChecker(cfg config.TimeSanity) *Checker {
+	c := &Checker{
+		cfg:      cfg,
+		queryNTP: QueryNTPOffset,
+	}
+	c.task = routine.NewRecurringTask(c.Check, cfg.Interval)
+	return c
+}
+
+// Start starts checking the clock periodically
+func (c *Checker) Start(ctx context.Context) error {
+	return c.task.Start(ctx)
+}
+
+// Stop stops checking the clock
+func (c *Checker) Stop(ctx context.Context) error {
+	return c.task.Stop(ctx)
+}
+
+// ObserveBlock records the offset between the time a block from a peer is received and its timestamp, replacing the
+// previous one of the same producer. The caller has verified the block is signed by the producer. The offset beyond
+// maxPeerOffset is ignored
+func (c *Checker) ObserveBlock(producer string, timestamp time.Time, received time.Time) {
+	offset := received.Sub(timestamp)
+	if abs(offset) > maxPeerOffset {
+		log.L().Debug("Ignored block timestamp too far off.", zap.String("producer", producer), zap.Duration("offset", offset))
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, o := range c.peerOffsets {
+		if o.producer == producer {
+			c.peerOffsets = append(c.peerOffsets[:i], c.peerOffsets[i+1:]...)
+			break
+		}
+	}
+	c.peerOffsets = append(c.peerOffsets, peerOffset{producer: producer, offset: offset})
+	if len(c.peerOffsets) > c.cfg.PeerWindow {
+		c.peerOffsets = c.peerOffsets[len(c.peerOffsets)-c.cfg.PeerWindow:]
+	}
+}
+
+// Skewed returns whether the clock is found skewed by the last check
+func (c *Checker) Skewed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.skewed
+}
+
+// Check compares the local clock against the NTP server and the median offset of the recent blocks from the peers
+func (c *Checker) Check() {
+	skewed := false
+	if c.cfg.NTPServer != "" {
+		offset, err := c.queryNTP(c.cfg.NTPServer, c.cfg.NTPTimeout)
+		if err != nil {
+			log.L().Warn("Failed to query NTP server.", zap.Error(err), zap.String("server", c.cfg.NTPServer))
+		} else {
+			skewMtc.WithLabelValues("ntp").Set(offset.Seconds())
+			if abs(offset) > c.cfg.MaxNTPSkew {
+				skewed = true
+				log.L().Warn(
+					"Local clock is skewed against NTP server.",
+					zap.Duration("offset", offset),
+					zap.String("server", c.cfg.NTPServer),
+				)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Too few samples are easily biased by a single peer
+	if len(c.peerOffsets) >= (c.cfg.PeerWindow+1)/2 {
+		offsets := make([]time.Duration, len(c.peerOffsets))
+		for i, o := range c.peerOffsets {
+			offsets[i] = o.offset
+		}
+		offset := median(offsets)
+		skewMtc.WithLabelValues("peer").Set(offset.Seconds())
+		if abs(offset) > c.cfg.MaxPeerSkew {
+			skewed = true
+			log.L().Warn(
+				"Local clock is skewed against the block timestamps of the peers.",
+				zap.Duration("offset", offset),
+				zap.Int("blocks", len(c.peerOffsets)),
+			)
+		}
+	}
+	if skewed && c.cfg.RefuseToPropose {
+		log.L().Error("Refuse to propose blocks until the local clock is fixed.")
+	}
+	c.skewed = skewed
+}
+
+func median(offsets []time.Duration) time.Duration {
+	sorted := make([]time.Duration, len(offsets))
+	copy(sorted, offsets)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package timesanity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestChecker(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.TimeSanity
+	cfg.Enabled = true
+	cfg.PeerWindow = 4
+	c := NewChecker(cfg)
+	var ntpOffset time.Duration
+	var ntpErr error
+	c.queryNTP = func(addr string, timeout time.Duration) (time.Duration, error) {
+		require.Equal(cfg.NTPServer, addr)
+		require.Equal(cfg.NTPTimeout, timeout)
+		return ntpOffset, ntpErr
+	}
+
+	c.Check()
+	require.False(c.Skewed())
+
+	ntpOffset = -2 * time.Second
+	c.Check()
+	require.True(c.Skewed())
+
+	// The NTP server being unavailable doesn't indicate a skew
+	ntpErr = errors.New("timeout")
+	c.Check()
+	require.False(c.Skewed())
+
+	// The peer offsets aren't checked with too few samples
+	now := time.Now()
+	c.ObserveBlock("a", now.Add(-time.Minute), now)
+	c.Check()
+	require.False(c.Skewed())
+
+	// The median is tolerant to a few peers with skewed clocks
+	c.ObserveBlock("b", now.Add(-2*time.Second), now)
+	c.ObserveBlock("c", now.Add(-3*time.Second), now)
+	c.Check()
+	require.False(c.Skewed())
+
+	// A producer only takes its latest sample
+	c.ObserveBlock("d", now.Add(-time.Minute), now)
+	c.ObserveBlock("d", now.Add(-2*time.Second), now)
+	require.Equal(4, len(c.peerOffsets))
+	c.Check()
+	require.False(c.Skewed())
+	// The offsets too far off are ignored
+	c.ObserveBlock("e", now.Add(-2*time.Hour), now)
+	require.Equal(4, len(c.peerOffsets))
+	require.Equal("d", c.peerOffsets[3].producer)
+
+	c.ObserveBlock("e", now.Add(-time.Minute), now)
+	c.ObserveBlock("f", now.Add(-time.Minute), now)
+	require.Equal(4, len(c.peerOffsets))
+	c.Check()
+	require.True(c.Skewed())
+
+	// The old offsets are dropped out of the window
+	c.ObserveBlock("g", now.Add(time.Second), now)
+	c.ObserveBlock("h", now, now)
+	c.ObserveBlock("i", now.Add(-time.Second), now)
+	c.Check()
+	require.False(c.Skewed())
+}
+
+func TestMedian(t *testing.T) {
+	require.Equal(t, 2*time.Second, median([]time.Duration{3 * time.Second, time.Second, 2 * time.Second}))
+	require.Equal(t, 2*time.Second, median([]time.Duration{4 * time.Second, time.Second, 3 * time.Second, 0}))
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
+	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/explorer"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
@@ -88,11 +89,11 @@ func TestActionInjector(t *testing.T) {
 
 	// Wait until the injected actions in APS Mode gets into the action pool
 	require.NoError(testutil.WaitUntil(100*time.Millisecond, 5*time.Second, func() (bool, error) {
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts) >= 30, nil
 	}))
 
-	acts := svr.ChainService(chainID).ActionPool().PickActs()
+	acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 	numActsBase := len(acts)
 
 	// Test injectByInterval
@@ -106,7 +107,7 @@ func TestActionInjector(t *testing.T) {
 
 	// Wait until all the injected actions in Interval Mode gets into the action pool
 	err = testutil.WaitUntil(100*time.Millisecond, 5*time.Second, func() (bool, error) {
-		acts := svr.ChainService(chainID).ActionPool().PickActs()
+		acts := svr.ChainService(chainID).ActionPool().PickActs(actpool.PickBudget{})
 		return len(acts)-numActsBase == 4, nil
 	})
 	require.Nil(err)
@@ -0,0 +1,16 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is an admin tool to export raw blocks into segment files, import them on another node without p2p, and replay
+// the chain to verify the states
+// To use, run "make build" and " ./bin/blockio"
+package main
+
+import "github.com/iotexproject/iotex-core/tools/blockio/internal/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/segment"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// exportChainCmd represents the exportchain command
+var exportChainCmd = &cobra.Command{
+	Use:   "exportchain",
+	Short: "Exports the blocks over a range into a chain stream file.",
+	Long: `Exports the blocks over a range into a single segment of length-prefixed protobuf blocks with checksums, which
+could be imported to bootstrap a new node without syncing over p2p.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportChain(); err != nil {
+			log.L().Fatal("Failed to export the chain.", zap.Error(err))
+		}
+	},
+}
+
+// importChainCmd represents the importchain command
+var importChainCmd = &cobra.Command{
+	Use:   "importchain [chain stream file]",
+	Short: "Imports the blocks from a chain stream file.",
+	Long: `Imports the blocks from a chain stream file. Each block is fully validated, including its consensus footer,
+before being committed, and the blocks at or below the tip height are skipped.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importChain(args[0]); err != nil {
+			log.L().Fatal("Failed to import the chain.", zap.Error(err))
+		}
+	},
+}
+
+// exportChain exports the blocks over the range into the chain stream file
+func exportChain() error {
+	cs, stop, err := openChainService()
+	if err != nil {
+		return err
+	}
+	defer stop()
+	end := _exportChainEnd
+	if end == 0 {
+		end = cs.Blockchain().TipHeight()
+	}
+	f, err := os.Create(_exportChainFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the chain stream file %s", _exportChainFile)
+	}
+	defer f.Close()
+	if err := segment.Write(f, cs.Blockchain(), _exportChainStart, end); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Wrapf(err, "failed to sync the chain stream file %s", _exportChainFile)
+	}
+	log.L().Info("Exported the chain.",
+		zap.Uint64("start", _exportChainStart),
+		zap.Uint64("end", end),
+		zap.String("file", _exportChainFile))
+	return nil
+}
+
+// importChain imports the blocks from the chain stream file, and validates their consensus footers
+func importChain(path string) error {
+	cs, stop, err := openChainService()
+	if err != nil {
+		return err
+	}
+	defer stop()
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the chain stream file %s", path)
+	}
+	defer f.Close()
+	if err := segment.ImportStream(f, cs.Blockchain(), cs.Consensus().ValidateBlockFooter); err != nil {
+		return err
+	}
+	log.L().Info("Imported the chain.", zap.Uint64("tipHeight", cs.Blockchain().TipHeight()))
+	return nil
+}
+
+var (
+	_exportChainFile  string
+	_exportChainStart uint64
+	_exportChainEnd   uint64
+)
+
+func init() {
+	exportChainCmd.Flags().StringVarP(&_exportChainFile, "output", "o", "", "path of the chain stream file to write")
+	exportChainCmd.Flags().Uint64VarP(&_exportChainStart, "start", "s", 1, "start height of the blocks")
+	exportChainCmd.Flags().Uint64VarP(&_exportChainEnd, "end", "e", 0, "end height of the blocks, 0 means the tip height")
+	exportChainCmd.MarkFlagRequired("output")
+	rootCmd.AddCommand(exportChainCmd)
+	rootCmd.AddCommand(importChainCmd)
+}
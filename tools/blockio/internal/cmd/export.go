@@ -0,0 +1,66 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/segment"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports raw blocks over a range into segment files.",
+	Long:  `Exports raw blocks over a range into segment files, which could be imported on another node.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportBlocks(); err != nil {
+			log.L().Fatal("Failed to export blocks.", zap.Error(err))
+		}
+	},
+}
+
+var (
+	_exportDir        string
+	_exportStart      uint64
+	_exportEnd        uint64
+	_blocksPerSegment uint64
+)
+
+// exportBlocks exports the blocks over the range into segment files
+func exportBlocks() error {
+	cs, stop, err := openChainService()
+	if err != nil {
+		return err
+	}
+	defer stop()
+	end := _exportEnd
+	if end == 0 {
+		end = cs.Blockchain().TipHeight()
+	}
+	paths, err := segment.Export(cs.Blockchain(), _exportDir, _exportStart, end, _blocksPerSegment)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	return nil
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&_exportDir, "dir", "d", "", "directory to write the segment files")
+	exportCmd.Flags().Uint64VarP(&_exportStart, "start", "s", 1, "start height of the blocks")
+	exportCmd.Flags().Uint64VarP(&_exportEnd, "end", "e", 0, "end height of the blocks, 0 means the tip height")
+	exportCmd.Flags().Uint64VarP(&_blocksPerSegment, "blocks-per-segment", "n", 10000, "number of blocks per segment file")
+	exportCmd.MarkFlagRequired("dir")
+	rootCmd.AddCommand(exportCmd)
+}
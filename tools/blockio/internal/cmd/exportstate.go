@@ -0,0 +1,63 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// exportStateCmd represents the exportstate command
+var exportStateCmd = &cobra.Command{
+	Use:   "exportstate",
+	Short: "Exports the full states at a height into a JSON file.",
+	Long: `Re-executes the blocks from genesis to the height, and exports the accounts, contracts and protocol states in
+canonical order into a JSON file, together with the digest of the states. It could be used to regenerate the genesis
+of a hard fork, or to audit the states across nodes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportState(); err != nil {
+			log.L().Fatal("Failed to export the states.", zap.String("file", _exportStateFile), zap.Error(err))
+		}
+	},
+}
+
+var (
+	_exportStateHeight uint64
+	_exportStateFile   string
+)
+
+// exportState exports the states at the height into the JSON file, and prints the height and the digest
+func exportState() error {
+	cs, stop, err := openChainService()
+	if err != nil {
+		return err
+	}
+	defer stop()
+	snapshot, err := cs.Blockchain().ExportState(context.Background(), _exportStateHeight)
+	if err != nil {
+		return err
+	}
+	digest, err := blockchain.WriteStateSnapshot(_exportStateFile, snapshot)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d %x\n", snapshot.Height, digest)
+	return nil
+}
+
+func init() {
+	exportStateCmd.Flags().Uint64VarP(&_exportStateHeight, "height", "t", 0, "height of the states, 0 means the tip height")
+	exportStateCmd.Flags().StringVarP(&_exportStateFile, "output", "o", "", "path of the JSON file to write")
+	exportStateCmd.MarkFlagRequired("output")
+	rootCmd.AddCommand(exportStateCmd)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/segment"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [segment files]",
+	Short: "Imports raw blocks from segment files.",
+	Long: `Imports raw blocks from segment files in the given order. Each block is fully validated before being committed,
+and the blocks at or below the tip height are skipped.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importBlocks(args); err != nil {
+			log.L().Fatal("Failed to import blocks.", zap.Error(err))
+		}
+	},
+}
+
+// importBlocks imports the blocks from the segment files in order
+func importBlocks(paths []string) error {
+	cs, stop, err := openChainService()
+	if err != nil {
+		return err
+	}
+	defer stop()
+	if err := segment.Import(paths, cs.Blockchain().TipHeight(), cs.ImportBlock); err != nil {
+		return err
+	}
+	log.L().Info("Imported all the blocks.", zap.Uint64("tipHeight", cs.Blockchain().TipHeight()))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
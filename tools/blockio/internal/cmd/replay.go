@@ -0,0 +1,48 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Re-executes the chain and compares the state roots.",
+	Long: `Re-executes the blocks from genesis against a fresh state factory, and compares the state root of each height
+with the stored chain. It stops at the first mismatch.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		height, err := replayChain()
+		if err != nil {
+			log.L().Fatal("Failed to replay the chain.", zap.Uint64("lastVerifiedHeight", height), zap.Error(err))
+		}
+		log.L().Info("All the replayed states match the chain.", zap.Uint64("height", height))
+	},
+}
+
+var _replayHeight uint64
+
+// replayChain replays the chain to the target height, and returns the last height whose states are verified
+func replayChain() (uint64, error) {
+	cs, stop, err := openChainService()
+	if err != nil {
+		return 0, err
+	}
+	defer stop()
+	return cs.Blockchain().Replay(context.Background(), _replayHeight)
+}
+
+func init() {
+	replayCmd.Flags().Uint64VarP(&_replayHeight, "height", "t", 0, "target height to replay to, 0 means the tip height")
+	rootCmd.AddCommand(replayCmd)
+}
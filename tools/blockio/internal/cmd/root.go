@@ -0,0 +1,68 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/server/itx"
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "blockio [command] [flags]",
+	Short: "Command-line interface for raw block import, export and replay",
+	Long: "blockio is a command-line interface to export raw blocks into segment files, import them on another node, " +
+		"and replay the chain to verify the states.",
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		log.L().Fatal("failed to add cmd", zap.Error(err))
+	}
+}
+
+func init() {
+	// The node config is loaded from the paths specified by the flags of config package
+	rootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+}
+
+// openChainService creates the chain service of the local node and starts its blockchain only, so that the blocks can
+// be read and committed without joining the network. The returned function stops the blockchain. The commands return
+// their errors rather than exiting, so that the blockchain is stopped before the process exits
+func openChainService() (*chainservice.ChainService, func(), error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to new config")
+	}
+	svr, err := itx.NewServer(cfg)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create server")
+	}
+	cs := svr.ChainService(cfg.Chain.ID)
+	if cs == nil {
+		return nil, nil, errors.Errorf("chain service %d doesn't exist", cfg.Chain.ID)
+	}
+	ctx := context.Background()
+	if err := cs.Blockchain().Start(ctx); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to start blockchain")
+	}
+	return cs, func() {
+		if err := cs.Blockchain().Stop(ctx); err != nil {
+			log.L().Error("Failed to stop blockchain.", zap.Error(err))
+		}
+	}, nil
+}
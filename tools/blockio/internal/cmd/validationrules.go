@@ -0,0 +1,46 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// validationRulesCmd represents the validationrules command
+var validationRulesCmd = &cobra.Command{
+	Use:   "validationrules",
+	Short: "Prints the block validation rules of the node as a JSON spec.",
+	Long: `Prints the rules a block is validated against in order, with their categories and whether they are enabled by
+the node config. The specs of two node versions could be diffed to find the changes of the consensus rules.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.New()
+		if err != nil {
+			log.L().Fatal("Failed to new config.", zap.Error(err))
+		}
+		specs, err := blockchain.ValidationRules(cfg.Chain.DisabledValidationRules)
+		if err != nil {
+			log.L().Fatal("Failed to get validation rules.", zap.Error(err))
+		}
+		data, err := json.MarshalIndent(specs, "", "  ")
+		if err != nil {
+			log.L().Fatal("Failed to marshal validation rules.", zap.Error(err))
+		}
+		fmt.Println(string(data))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validationRulesCmd)
+}